@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/cmd/firelynx/client"
@@ -25,6 +27,13 @@ var (
 		Value:   "toml",
 	}
 
+	formatGetConfigFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format: toml (loadable config file), yaml, json",
+		Aliases: []string{"f"},
+		Value:   "toml",
+	}
+
 	formatStorageFlag = &cli.StringFlag{
 		Name:    "format",
 		Usage:   "Output format: text (summary), json (config data), toml (config data)",
@@ -59,11 +68,72 @@ var (
 		Required: true,
 	}
 
+	maxRecordsFlag = &cli.IntFlag{
+		Name:  "max-records",
+		Usage: "Maximum number of log records to show, most recent first (0 for the server's limit)",
+	}
+
+	formatLogsFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format: text (one line per record), json (log records)",
+		Aliases: []string{"f"},
+		Value:   "text",
+	}
+
+	formatSchemaFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format: text (services and messages), json (schema data)",
+		Aliases: []string{"f"},
+		Value:   "text",
+	}
+
+	formatStatusFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format: text (table), json (status data)",
+		Aliases: []string{"f"},
+		Value:   "text",
+	}
+
 	keepLastFlag = &cli.IntFlag{
 		Name:  "keep-last",
 		Usage: "Number of recent transactions to keep",
 		Value: 1,
 	}
+
+	mcpEndpointFlag = &cli.StringFlag{
+		Name:    "endpoint",
+		Usage:   "URL of the MCP server, e.g. http://localhost:8080/mcp",
+		Aliases: []string{"e"},
+	}
+
+	mcpSSEFlag = &cli.BoolFlag{
+		Name:  "sse",
+		Usage: "Use the SSE transport for --endpoint instead of the streamable HTTP one",
+	}
+
+	mcpCommandFlag = &cli.StringFlag{
+		Name:  "command",
+		Usage: "Command starting an MCP server on stdin and stdout, split on spaces, instead of --endpoint",
+	}
+
+	mcpHeaderFlag = &cli.StringSliceFlag{
+		Name:  "header",
+		Usage: "HTTP header sent to --endpoint as \"Name: value\", may be repeated",
+	}
+
+	mcpTimeoutFlag = &cli.IntFlag{
+		Name:    "timeout",
+		Usage:   "Timeout for the operation in seconds",
+		Aliases: []string{"t"},
+		Value:   30,
+	}
+
+	formatToolsFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format: text (table or content), json (MCP data)",
+		Aliases: []string{"f"},
+		Value:   "text",
+	}
 )
 
 var clientCmd = &cli.Command{
@@ -73,12 +143,70 @@ var clientCmd = &cli.Command{
 
   Examples:
     firelynx client apply --config myconfig.toml --server localhost:9999
+    firelynx client apply --config myconfig.toml --server localhost:9999 --reason "add /status route"
+    firelynx client status --server localhost:9999
+    firelynx client reload --server localhost:9999
     firelynx client config current --server localhost:9999 --output config.toml
     firelynx client config current --server localhost:9999 --format json
+    firelynx client get-config --server localhost:9999 --format yaml
     firelynx client config rollback --server localhost:9999 --id <TRANSACTION_ID>
     firelynx client config storage list --server localhost:9999 --page-size 5
-    firelynx client config storage clear --server localhost:9999 --keep-last 3`,
+    firelynx client config storage logs --server localhost:9999 --id <TRANSACTION_ID>
+    firelynx client config storage clear --server localhost:9999 --keep-last 3
+    firelynx client schema --server localhost:9999
+    firelynx client list-tools --endpoint http://localhost:8080/mcp
+    firelynx client call-tool --endpoint http://localhost:8080/mcp --tool calculate --arguments '{"left":1,"right":2}'`,
 	Commands: []*cli.Command{
+		{
+			Name:  "list-tools",
+			Usage: "List the tools of an MCP server",
+			Description: `Connect to an MCP server, over HTTP or stdio, and list its tools.
+
+  Examples:
+    firelynx client list-tools --endpoint http://localhost:8080/mcp
+    firelynx client list-tools --endpoint http://localhost:8080/mcp --header "Authorization: Bearer $TOKEN"
+    firelynx client list-tools --command "npx -y @modelcontextprotocol/server-everything" --format json`,
+			Flags: []cli.Flag{
+				mcpEndpointFlag,
+				mcpSSEFlag,
+				mcpCommandFlag,
+				mcpHeaderFlag,
+				mcpTimeoutFlag,
+				formatToolsFlag,
+			},
+			Action: clientListToolsAction,
+		},
+		{
+			Name:  "call-tool",
+			Usage: "Call a tool of an MCP server and print the result",
+			Description: `Connect to an MCP server, over HTTP or stdio, call a tool with JSON
+  arguments and print its result. Exits with an error when the tool returns
+  a tool error, after printing it.
+
+  Examples:
+    firelynx client call-tool --endpoint http://localhost:8080/mcp --tool calculate --arguments '{"left":1,"right":2}'
+    firelynx client call-tool --endpoint http://localhost:8080/mcp --sse --tool echo --format json`,
+			Flags: []cli.Flag{
+				mcpEndpointFlag,
+				mcpSSEFlag,
+				mcpCommandFlag,
+				mcpHeaderFlag,
+				mcpTimeoutFlag,
+				formatToolsFlag,
+				&cli.StringFlag{
+					Name:     "tool",
+					Usage:    "Name of the tool to call",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    "arguments",
+					Usage:   "Tool arguments as a JSON object",
+					Aliases: []string{"a"},
+					Value:   "{}",
+				},
+			},
+			Action: clientCallToolAction,
+		},
 		{
 			Name:  "apply",
 			Usage: "Apply configuration to the server",
@@ -96,9 +224,81 @@ var clientCmd = &cli.Command{
 					Aliases: []string{"t"},
 					Value:   5,
 				},
+				&cli.StringFlag{
+					Name:  "reason",
+					Usage: "Reason for the change, recorded in the transaction's audit record (at most 256 characters)",
+				},
 			},
 			Action: clientApplyAction,
 		},
+		{
+			Name:  "status",
+			Usage: "Show the live state of the server",
+			Description: `Show the server's state, uptime, current configuration version and
+  transaction, the number of stored transactions, and the active listeners.
+
+  Examples:
+    firelynx client status --server localhost:9999
+    firelynx client status --server localhost:9999 --format json`,
+			Flags: []cli.Flag{
+				serverFlag,
+				formatStatusFlag,
+			},
+			Action: clientStatusAction,
+		},
+		{
+			Name:  "reload",
+			Usage: "Reload the server's configuration from its config file",
+			Description: `Make the server re-read and validate the config file it was started from,
+  and apply it in a new transaction. Prints the ID of the transaction, which
+  can be passed to "config storage logs" to follow the rollout. Fails when the
+  server wasn't started with a config file.
+
+  Examples:
+    firelynx client reload --server localhost:9999`,
+			Flags: []cli.Flag{
+				serverFlag,
+			},
+			Action: clientReloadAction,
+		},
+		{
+			Name:  "get-config",
+			Usage: "Get the server's configuration as TOML, YAML or JSON",
+			Description: `Fetch the configuration the server is running and write it in the layout of
+  the TOML config file. The TOML output loads back into the same configuration,
+  so it can be edited and applied again, or diffed against the file it was
+  applied from.
+
+  Examples:
+    firelynx client get-config --server localhost:9999
+    firelynx client get-config --server localhost:9999 --format yaml
+    firelynx client get-config --server localhost:9999 --output running.toml`,
+			Flags: []cli.Flag{
+				serverFlag,
+				formatGetConfigFlag,
+				&cli.StringFlag{
+					Name:    "output",
+					Usage:   "Path to save configuration (if not provided, print to stdout)",
+					Aliases: []string{"o"},
+				},
+			},
+			Action: clientGetConfigAction,
+		},
+		{
+			Name:  "schema",
+			Usage: "List the server's RPCs and message types",
+			Description: `List the gRPC services, RPCs and message types of the server using gRPC
+  server reflection, which the server must be started with --grpc-reflection.
+
+  Examples:
+    firelynx client schema --server localhost:9999
+    firelynx client schema --server localhost:9999 --format json`,
+			Flags: []cli.Flag{
+				serverFlag,
+				formatSchemaFlag,
+			},
+			Action: clientSchemaAction,
+		},
 		{
 			Name:        "config",
 			Usage:       "Configuration operations",
@@ -139,6 +339,7 @@ var clientCmd = &cli.Command{
 				},
 				{
 					Name:        "storage",
+					Aliases:     []string{"transactions"},
 					Usage:       "Configuration transaction storage operations",
 					Description: `Manage configuration transaction history.`,
 					Commands: []*cli.Command{
@@ -178,13 +379,35 @@ var clientCmd = &cli.Command{
 							Action: storageGetAction,
 						},
 						{
-							Name:  "clear",
-							Usage: "Clear old configuration transactions",
-							Description: `Remove old transaction records.
+							Name:  "logs",
+							Usage: "Show the logs collected during a configuration transaction",
+							Description: `Show the log records the server collected while processing a transaction,
+  for example to find out why a configuration failed to apply.
+
+  Examples:
+    firelynx client config storage logs --server localhost:9999 --id <ID>
+    firelynx client config storage logs --server localhost:9999 --id <ID> --max-records 50
+    firelynx client config storage logs --server localhost:9999 --id <ID> --format json`,
+							Flags: []cli.Flag{
+								serverFlag,
+								transactionIDFlag,
+								maxRecordsFlag,
+								formatLogsFlag,
+							},
+							Action: storageLogsAction,
+						},
+						{
+							Name:    "clear",
+							Aliases: []string{"prune"},
+							Usage:   "Clear old configuration transactions",
+							Description: `Remove old transaction records and print how many were removed. The
+  server also prunes its history on its own, see the --transaction-history and
+  --transaction-max-age server flags.
 
   Examples:
     firelynx client config storage clear --server localhost:9999 --keep-last 3
-    firelynx client config storage clear --server localhost:9999 --keep-last 0`,
+    firelynx client config storage clear --server localhost:9999 --keep-last 0
+    firelynx client config transactions prune --server localhost:9999 --keep-last 10`,
 							Flags: []cli.Flag{
 								serverFlag,
 								keepLastFlag,
@@ -203,7 +426,51 @@ func clientApplyAction(ctx context.Context, cmd *cli.Command) error {
 	serverAddr := cmd.String("server")
 	timeout := time.Duration(cmd.Int("timeout")) * time.Second
 
-	if err := client.ApplyConfig(ctx, configPath, serverAddr, timeout); err != nil {
+	if err := client.ApplyConfig(ctx, configPath, serverAddr, timeout, cmd.String("reason")); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func clientStatusAction(ctx context.Context, cmd *cli.Command) error {
+	serverAddr := cmd.String("server")
+	format := cmd.String("format")
+
+	if err := client.GetStatus(ctx, serverAddr, format); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func clientReloadAction(ctx context.Context, cmd *cli.Command) error {
+	serverAddr := cmd.String("server")
+
+	if err := client.ReloadFromFile(ctx, serverAddr); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func clientGetConfigAction(ctx context.Context, cmd *cli.Command) error {
+	serverAddr := cmd.String("server")
+	format := cmd.String("format")
+	outputPath := cmd.String("output")
+
+	if err := client.GetConfig(ctx, serverAddr, format, outputPath); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func clientSchemaAction(ctx context.Context, cmd *cli.Command) error {
+	serverAddr := cmd.String("server")
+	format := cmd.String("format")
+
+	if err := client.GetSchema(ctx, serverAddr, format); err != nil {
 		return cli.Exit(err.Error(), 1)
 	}
 
@@ -249,6 +516,19 @@ func storageGetAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+func storageLogsAction(ctx context.Context, cmd *cli.Command) error {
+	serverAddr := cmd.String("server")
+	transactionID := cmd.String("id")
+	maxRecords := int32(cmd.Int("max-records"))
+	format := cmd.String("format")
+
+	if err := client.GetTransactionLogs(ctx, serverAddr, transactionID, maxRecords, format); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
 func storageClearAction(ctx context.Context, cmd *cli.Command) error {
 	serverAddr := cmd.String("server")
 	keepLast := int32(cmd.Int("keep-last"))
@@ -270,3 +550,56 @@ func storageRollbackAction(ctx context.Context, cmd *cli.Command) error {
 
 	return nil
 }
+
+func clientListToolsAction(ctx context.Context, cmd *cli.Command) error {
+	endpoint, err := mcpEndpointFromFlags(cmd)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cmd.Int("timeout"))*time.Second)
+	defer cancel()
+
+	if err := client.ListTools(ctx, endpoint, cmd.String("format")); err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+func clientCallToolAction(ctx context.Context, cmd *cli.Command) error {
+	endpoint, err := mcpEndpointFromFlags(cmd)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(cmd.Int("timeout"))*time.Second)
+	defer cancel()
+
+	err = client.CallTool(ctx, endpoint, cmd.String("tool"), cmd.String("arguments"), cmd.String("format"))
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
+	return nil
+}
+
+// mcpEndpointFromFlags returns the MCP endpoint set by exactly one of the
+// --endpoint and --command flags
+func mcpEndpointFromFlags(cmd *cli.Command) (client.MCPEndpoint, error) {
+	url := cmd.String("endpoint")
+	command := strings.Fields(cmd.String("command"))
+	if (url == "") == (len(command) == 0) {
+		return client.MCPEndpoint{}, fmt.Errorf("exactly one of --endpoint and --command is required")
+	}
+
+	headers, err := client.ParseHeaders(cmd.StringSlice("header"))
+	if err != nil {
+		return client.MCPEndpoint{}, err
+	}
+
+	return client.MCPEndpoint{
+		URL:     url,
+		SSE:     cmd.Bool("sse"),
+		Command: command,
+		Headers: headers,
+	}, nil
+}
@@ -3,82 +3,95 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"os"
+	"slices"
 	"strings"
+	"text/tabwriter"
 	"time"
 
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/client"
 	"github.com/atlanticdynamic/firelynx/internal/config/loader"
+	"github.com/atlanticdynamic/firelynx/internal/config/marshaller"
 	"github.com/pelletier/go-toml/v2"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
-// ApplyConfig applies a configuration file to the server
-func ApplyConfig(ctx context.Context, configPath, serverAddr string, timeout time.Duration) error {
+// ApplyConfig applies a configuration file to the server, with the reason for
+// the change, which may be empty
+func ApplyConfig(
+	ctx context.Context,
+	configPath, serverAddr string,
+	timeout time.Duration,
+	changeReason string,
+) error {
 	if timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, timeout)
 		defer cancel()
 	}
 
-	logger := slog.Default()
-
 	configLoader, err := loader.NewLoaderFromFilePath(configPath)
 	if err != nil {
 		return err
 	}
 
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
-	return firelynxClient.ApplyConfig(ctx, configLoader)
+	return firelynxClient.ApplyConfigWithReason(ctx, configLoader, changeReason)
 }
 
 // GetCurrentConfig retrieves the current configuration with flexible output formats
 func GetCurrentConfig(ctx context.Context, serverAddr, format, outputPath string) error {
 	if format == "toml" && outputPath != "" {
-		return GetConfig(ctx, serverAddr, outputPath)
+		return GetConfig(ctx, serverAddr, format, outputPath)
 	}
 	return GetCurrentTransaction(ctx, serverAddr, format)
 }
 
-// GetConfig retrieves the current configuration from the server
-func GetConfig(ctx context.Context, serverAddr, outputPath string) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
-
-	config, err := firelynxClient.GetConfig(ctx)
+// GetConfig retrieves the current configuration from the server and writes it
+// in the given format (toml, yaml or json) to outputPath, or to stdout. The
+// TOML output can be loaded as a config file.
+func GetConfig(ctx context.Context, serverAddr, format, outputPath string) error {
+	firelynxClient, err := newClient(serverAddr)
 	if err != nil {
 		return err
 	}
 
-	if outputPath != "" {
-		return firelynxClient.SaveConfig(config, outputPath)
+	config, err := firelynxClient.GetConfig(ctx)
+	if err != nil {
+		return err
 	}
 
-	configStr, err := firelynxClient.FormatConfig(config)
+	data, err := firelynxClient.MarshalConfig(config, marshaller.Format(format))
 	if err != nil {
 		return err
 	}
-	fmt.Println(configStr)
 
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write configuration: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Print(string(data))
 	return nil
 }
 
 // GetCurrentTransaction gets the current configuration transaction
 func GetCurrentTransaction(ctx context.Context, serverAddr, format string) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
 	transaction, err := firelynxClient.GetCurrentConfigTransaction(ctx)
 	if err != nil {
@@ -103,17 +116,23 @@ func GetCurrentTransaction(ctx context.Context, serverAddr, format string) error
 		}
 	case "toml":
 		if transaction.GetConfig() != nil {
-			tomlBytes, err := toml.Marshal(transaction.GetConfig())
+			configStr, err := firelynxClient.FormatConfig(transaction.GetConfig())
 			if err != nil {
-				return fmt.Errorf("failed to marshal config to TOML: %w", err)
+				return err
 			}
-			fmt.Print(string(tomlBytes))
+			fmt.Print(configStr)
 		}
 	default: // text format
 		fmt.Printf("Transaction ID: %s\n", transaction.GetId())
 		fmt.Printf("Source: %s\n", transaction.GetSource())
 		fmt.Printf("Source Detail: %s\n", transaction.GetSourceDetail())
 		fmt.Printf("Request ID: %s\n", transaction.GetRequestId())
+		if audit := transaction.GetAudit(); audit != nil {
+			fmt.Printf("Submitter: %s\n", valueOrNone(audit.GetSubmitter()))
+			fmt.Printf("Change Reason: %s\n", valueOrNone(audit.GetChangeReason()))
+			fmt.Printf("Content Hash: %s\n", audit.GetContentHash())
+			fmt.Printf("Signed: %t\n", audit.GetSignature() != "")
+		}
 		fmt.Printf("State: %s\n", transaction.GetState())
 		fmt.Printf("Valid: %t\n", transaction.GetIsValid())
 		if transaction.GetCreatedAt() != nil {
@@ -134,12 +153,10 @@ func ListTransactions(
 	pageSize int32,
 	pageToken, state, source, format string,
 ) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
 	transactions, nextPageToken, err := firelynxClient.ListConfigTransactions(
 		ctx,
@@ -186,8 +203,8 @@ func ListTransactions(
 		fmt.Print(string(tomlBytes))
 	default: // text format
 		// Print header
-		fmt.Printf("%-36s %-10s %-12s %-20s %-10s\n", "ID", "SOURCE", "STATE", "CREATED", "VALID")
-		fmt.Println(strings.Repeat("-", 88))
+		fmt.Printf("%-36s %-10s %-12s %-20s %-10s %s\n", "ID", "SOURCE", "STATE", "CREATED", "VALID", "REASON")
+		fmt.Println(strings.Repeat("-", 98))
 
 		// Print transactions
 		for _, tx := range transactions {
@@ -196,12 +213,13 @@ func ListTransactions(
 				createdTime = tx.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05")
 			}
 
-			fmt.Printf("%-36s %-10s %-12s %-20s %-10t\n",
+			fmt.Printf("%-36s %-10s %-12s %-20s %-10t %s\n",
 				tx.GetId(),
 				tx.GetSource(),
 				tx.GetState(),
 				createdTime,
 				tx.GetIsValid(),
+				tx.GetAudit().GetChangeReason(),
 			)
 		}
 
@@ -215,12 +233,10 @@ func ListTransactions(
 
 // GetTransaction gets a specific configuration transaction by ID
 func GetTransaction(ctx context.Context, serverAddr, transactionID, format string) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
 	transaction, err := firelynxClient.GetConfigTransaction(ctx, transactionID)
 	if err != nil {
@@ -245,11 +261,11 @@ func GetTransaction(ctx context.Context, serverAddr, transactionID, format strin
 		}
 	case "toml":
 		if transaction.GetConfig() != nil {
-			tomlBytes, err := toml.Marshal(transaction.GetConfig())
+			configStr, err := firelynxClient.FormatConfig(transaction.GetConfig())
 			if err != nil {
-				return fmt.Errorf("failed to marshal config to TOML: %w", err)
+				return err
 			}
-			fmt.Print(string(tomlBytes))
+			fmt.Print(configStr)
 		}
 	default: // text format
 		fmt.Printf("Transaction ID: %s\n", transaction.GetId())
@@ -268,6 +284,11 @@ func GetTransaction(ctx context.Context, serverAddr, transactionID, format strin
 			fmt.Printf("Config Apps: %d\n", len(transaction.GetConfig().GetApps()))
 		}
 
+		// Apps disabled with isolate_failures
+		for _, failure := range transaction.GetAppFailures() {
+			fmt.Printf("Disabled App: %s (%s)\n", failure.GetAppId(), failure.GetError())
+		}
+
 		// Show log count
 		if len(transaction.GetLogs()) > 0 {
 			fmt.Printf("Log Entries: %d\n", len(transaction.GetLogs()))
@@ -277,26 +298,79 @@ func GetTransaction(ctx context.Context, serverAddr, transactionID, format strin
 	return nil
 }
 
+// GetTransactionLogs prints the log records collected during a configuration
+// transaction, so a failed rollout can be diagnosed without access to the
+// server's logs
+func GetTransactionLogs(
+	ctx context.Context,
+	serverAddr, transactionID string,
+	maxRecords int32,
+	format string,
+) error {
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
+
+	resp, err := firelynxClient.GetTransactionLogs(ctx, transactionID, maxRecords)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		jsonBytes, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(resp)
+		if err != nil {
+			return fmt.Errorf("failed to marshal logs to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	default: // text format
+		if len(resp.GetRecords()) == 0 {
+			fmt.Printf("No logs found for transaction %s\n", transactionID)
+			return nil
+		}
+
+		for _, record := range resp.GetRecords() {
+			fmt.Println(formatLogRecord(record))
+		}
+
+		if resp.GetTruncated() {
+			fmt.Printf("\nShowing the last %d of %d log records\n",
+				len(resp.GetRecords()), resp.GetTotalRecords())
+		}
+	}
+
+	return nil
+}
+
+// formatLogRecord formats a log record as a single line with its attributes
+// sorted by key
+func formatLogRecord(record *pb.LogRecord) string {
+	var b strings.Builder
+	b.WriteString(record.GetTime().AsTime().Format(time.RFC3339Nano))
+	fmt.Fprintf(&b, " %-5s %s", strings.TrimPrefix(record.GetLevel().String(), "LEVEL_"), record.GetMessage())
+	for _, key := range slices.Sorted(maps.Keys(record.GetAttrs())) {
+		fmt.Fprintf(&b, " %s=%v", key, record.GetAttrs()[key].AsInterface())
+	}
+	return b.String()
+}
+
 // RollbackToTransaction rolls back to a previous configuration transaction
 func RollbackToTransaction(ctx context.Context, serverAddr, transactionID string) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
 	return firelynxClient.ApplyConfigFromTransaction(ctx, transactionID)
 }
 
 // ClearTransactions clears configuration transaction history
 func ClearTransactions(ctx context.Context, serverAddr string, keepLast int32) error {
-	logger := slog.Default()
-
-	firelynxClient := client.New(client.Config{
-		Logger:     logger,
-		ServerAddr: serverAddr,
-	})
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
 
 	clearedCount, err := firelynxClient.ClearConfigTransactions(ctx, keepLast)
 	if err != nil {
@@ -307,3 +381,158 @@ func ClearTransactions(ctx context.Context, serverAddr string, keepLast int32) e
 
 	return nil
 }
+
+// ReloadFromFile makes the server re-read the config file it was started from,
+// and prints the ID of the transaction the reload started
+func ReloadFromFile(ctx context.Context, serverAddr string) error {
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
+
+	resp, err := firelynxClient.ReloadFromFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	if resp.GetUnchanged() {
+		fmt.Printf("Configuration in %s is unchanged\n", resp.GetPath())
+		return nil
+	}
+	fmt.Printf("Reloaded %s in transaction %s\n", resp.GetPath(), resp.GetTransactionId())
+
+	return nil
+}
+
+// GetStatus shows the live state of the server: its state, uptime, current
+// configuration transaction, and active listeners
+func GetStatus(ctx context.Context, serverAddr, format string) error {
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
+
+	status, err := firelynxClient.GetServerStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		jsonBytes, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(status)
+		if err != nil {
+			return fmt.Errorf("failed to marshal status to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	default: // text format
+		fmt.Print(formatStatus(status))
+	}
+
+	return nil
+}
+
+// formatStatus formats the server status as a table of fields, followed by a
+// table of the active listeners
+func formatStatus(status *pb.GetServerStatusResponse) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "State:\t%s\n", status.GetState())
+	if status.GetStartTime() != nil {
+		fmt.Fprintf(w, "Started:\t%s\n", status.GetStartTime().AsTime().Format(time.RFC3339))
+	}
+	fmt.Fprintf(w, "Uptime:\t%s\n", status.GetUptime().AsDuration())
+	fmt.Fprintf(w, "Config Version:\t%s\n", valueOrNone(status.GetConfigVersion()))
+	fmt.Fprintf(w, "Transaction:\t%s\n", valueOrNone(status.GetTransactionId()))
+	fmt.Fprintf(w, "Transaction State:\t%s\n", valueOrNone(status.GetTransactionState()))
+	if status.GetTransactionReason() != "" {
+		fmt.Fprintf(w, "Change Reason:\t%s\n", status.GetTransactionReason())
+	}
+	fmt.Fprintf(w, "Transactions:\t%d\n", status.GetTransactionCount())
+	_ = w.Flush()
+
+	b.WriteString("\n")
+	if len(status.GetListeners()) == 0 {
+		b.WriteString("No active listeners\n")
+		return b.String()
+	}
+
+	w = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LISTENER\tTYPE\tADDRESS")
+	for _, l := range status.GetListeners() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", l.GetId(), l.GetType(), l.GetAddress())
+	}
+	_ = w.Flush()
+
+	return b.String()
+}
+
+// valueOrNone returns "none" for an empty value
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// GetSchema lists the RPCs and message types of the server through gRPC
+// server reflection, which the server must be started with
+func GetSchema(ctx context.Context, serverAddr, format string) error {
+	firelynxClient, err := newClient(serverAddr)
+	if err != nil {
+		return err
+	}
+
+	schema, err := firelynxClient.GetSchema(ctx)
+	if err != nil {
+		if errors.Is(err, client.ErrReflectionDisabled) {
+			return fmt.Errorf("%w (start the server with --grpc-reflection)", err)
+		}
+		return err
+	}
+
+	switch format {
+	case "json":
+		jsonBytes, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal schema to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	default: // text format
+		fmt.Print(formatSchema(schema))
+	}
+
+	return nil
+}
+
+// formatSchema formats the services with their RPCs, followed by the message
+// types
+func formatSchema(schema *client.Schema) string {
+	var b strings.Builder
+	for _, svc := range schema.Services {
+		fmt.Fprintf(&b, "service %s\n", svc.Name)
+		for _, method := range svc.Methods {
+			fmt.Fprintf(&b, "  %s\n", method)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("messages\n")
+	for _, name := range schema.Messages {
+		fmt.Fprintf(&b, "  %s\n", name)
+	}
+	return b.String()
+}
+
+// newClient creates a client for the server, with the credentials set in the
+// environment
+func newClient(serverAddr string) (*client.Client, error) {
+	cfg := client.Config{
+		Logger:     slog.Default(),
+		ServerAddr: serverAddr,
+	}
+	if err := cfg.LoadEnvCredentials(); err != nil {
+		return nil, fmt.Errorf("failed to load client credentials: %w", err)
+	}
+	return client.New(cfg), nil
+}
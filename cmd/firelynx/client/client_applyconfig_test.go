@@ -24,11 +24,11 @@ func TestApplyConfigWithTimeout(t *testing.T) {
 	ctx := t.Context()
 
 	// Test with zero timeout (should not set timeout)
-	err := ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 0)
+	err := ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 0, "")
 	require.Error(t, err, "Should fail with invalid config file")
 
 	// Test with positive timeout
-	err = ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 5*time.Second)
+	err = ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 5*time.Second, "")
 	require.Error(t, err, "Should fail with invalid config file")
 }
 
@@ -36,11 +36,11 @@ func TestApplyConfigInvalidFile(t *testing.T) {
 	ctx := t.Context()
 
 	// Test with nonexistent file
-	err := ApplyConfig(ctx, "nonexistent.toml", "localhost:50051", 0)
+	err := ApplyConfig(ctx, "nonexistent.toml", "localhost:50051", 0, "")
 	require.Error(t, err, "Should fail with nonexistent config file")
 
 	// Test with invalid file path
-	err = ApplyConfig(ctx, "/invalid/path/config.toml", "localhost:50051", 0)
+	err = ApplyConfig(ctx, "/invalid/path/config.toml", "localhost:50051", 0, "")
 	require.Error(t, err, "Should fail with invalid file path")
 }
 
@@ -48,7 +48,7 @@ func TestApplyConfigInvalidServer(t *testing.T) {
 	ctx := t.Context()
 
 	// Test with invalid server address to trigger timeout
-	err := ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 1*time.Second)
+	err := ApplyConfig(ctx, "nonexistent.toml", "invalid:1234", 1*time.Second, "")
 	require.Error(t, err, "Should fail with invalid server")
 }
 
@@ -101,7 +101,7 @@ func TestApplyConfigE2E(t *testing.T) {
 
 	// Test ApplyConfig
 	grpcAddr := fmt.Sprintf("localhost:%d", grpcPort)
-	err = ApplyConfig(ctx, updatedConfigPath, grpcAddr, 5*time.Second)
+	err = ApplyConfig(ctx, updatedConfigPath, grpcAddr, 5*time.Second, "")
 	require.NoError(t, err, "Should apply config successfully")
 
 	// Test that the new endpoint becomes available
@@ -15,9 +15,12 @@ import (
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/marshaller"
 	"github.com/atlanticdynamic/firelynx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 func TestGetCurrentConfigEdgeCases(t *testing.T) {
@@ -50,13 +53,13 @@ func TestGetConfigEdgeCases(t *testing.T) {
 	ctx := t.Context()
 
 	// Test GetConfig with invalid server and empty output path
-	err := GetConfig(ctx, "invalid:1234", "")
+	err := GetConfig(ctx, "invalid:1234", "toml", "")
 	require.Error(t, err, "Should fail with invalid server when printing to stdout")
 
 	// Test GetConfig with invalid server and output path
 	tempDir := t.TempDir()
 	outputPath := filepath.Join(tempDir, "output.toml")
-	err = GetConfig(ctx, "invalid:1234", outputPath)
+	err = GetConfig(ctx, "invalid:1234", "toml", outputPath)
 	require.Error(t, err, "Should fail with invalid server when saving to file")
 }
 
@@ -64,7 +67,7 @@ func TestGetConfigInvalidServer(t *testing.T) {
 	ctx := t.Context()
 
 	// Test with invalid server address
-	err := GetConfig(ctx, "invalid:1234", "")
+	err := GetConfig(ctx, "invalid:1234", "toml", "")
 	require.Error(t, err, "Should fail with invalid server")
 }
 
@@ -110,17 +113,40 @@ func TestGetConfigE2E(t *testing.T) {
 	// Test GetConfig with output to file
 	grpcAddr := fmt.Sprintf("localhost:%d", grpcPort)
 	outputPath := filepath.Join(tempDir, "output_config.toml")
-	err = GetConfig(ctx, grpcAddr, outputPath)
+	err = GetConfig(ctx, grpcAddr, "toml", outputPath)
 	require.NoError(t, err, "Should get config successfully")
 
 	// Verify output file exists and has content
 	assert.FileExists(t, outputPath, "Output file should exist")
 	content, err := os.ReadFile(outputPath)
 	require.NoError(t, err)
-	assert.Contains(t, string(content), "Version = 'v1'", "Output should contain config version")
+	assert.Contains(t, string(content), "version = 'v1'", "Output should contain config version")
+
+	// The TOML output loads into the config that was applied, with the
+	// defaults the server filled in
+	want, err := config.NewConfigFromBytes([]byte(testConfig))
+	require.NoError(t, err)
+	got, err := config.NewConfigFromBytes(content)
+	require.NoError(t, err)
+	wantJSON, err := protojson.Marshal(want.ToProto())
+	require.NoError(t, err)
+	gotJSON, err := protojson.Marshal(got.ToProto())
+	require.NoError(t, err)
+	assert.JSONEq(t, string(wantJSON), string(gotJSON), "Output should round-trip:\n%s", content)
+
+	// Test GetConfig in the other formats
+	yamlPath := filepath.Join(tempDir, "output_config.yaml")
+	err = GetConfig(ctx, grpcAddr, "yaml", yamlPath)
+	require.NoError(t, err, "Should get config as YAML")
+	content, err = os.ReadFile(yamlPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "version: v1")
+
+	err = GetConfig(ctx, grpcAddr, "xml", "")
+	require.ErrorIs(t, err, marshaller.ErrUnsupportedFormat)
 
 	// Test GetConfig without output path (prints to stdout)
-	err = GetConfig(ctx, grpcAddr, "")
+	err = GetConfig(ctx, grpcAddr, "json", "")
 	require.NoError(t, err, "Should get config and print to stdout")
 
 	// Shutdown server
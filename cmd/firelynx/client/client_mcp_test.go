@@ -0,0 +1,119 @@
+//go:build e2e
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders([]string{"Authorization: Bearer abc", "X-Empty:"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Authorization": "Bearer abc", "X-Empty": ""}, headers)
+
+	_, err = ParseHeaders([]string{"no colon"})
+	require.Error(t, err)
+	_, err = ParseHeaders([]string{": value"})
+	require.Error(t, err)
+}
+
+func TestFormatTools(t *testing.T) {
+	assert.Equal(t, "No tools\n", formatTools(nil))
+	assert.Equal(t, `TOOL     DESCRIPTION
+add      Adds numbers
+convert  Converts units
+`, formatTools([]*mcpsdk.Tool{
+		{Name: "add", Description: "Adds numbers"},
+		{Name: "convert", Description: "Converts units"},
+	}))
+}
+
+func TestFormatToolResult(t *testing.T) {
+	result := &mcpsdk.CallToolResult{Content: []mcpsdk.Content{
+		&mcpsdk.TextContent{Text: `{"sum":3}`},
+		&mcpsdk.ImageContent{Data: []byte{1, 2, 3}, MIMEType: "image/png"},
+		&mcpsdk.AudioContent{Data: []byte{1}, MIMEType: "audio/wav"},
+	}}
+	assert.Equal(t, "{\"sum\":3}\n[image image/png, 3 bytes]\n[audio audio/wav, 1 bytes]\n", formatToolResult(result))
+}
+
+func TestMCPEndpoint_RequiresURLOrCommand(t *testing.T) {
+	err := ListTools(t.Context(), MCPEndpoint{}, "text")
+	require.ErrorIs(t, err, ErrNoMCPEndpoint)
+}
+
+// TestMCPToolsE2E lists and calls the tools of an MCP server over the
+// streamable HTTP and SSE transports
+func TestMCPToolsE2E(t *testing.T) {
+	type addInput struct {
+		Left  float64 `json:"left"`
+		Right float64 `json:"right"`
+	}
+	type addOutput struct {
+		Sum float64 `json:"sum"`
+	}
+
+	var authorization atomic.Value
+	newServer := func(*http.Request) *mcpsdk.Server {
+		server := mcpsdk.NewServer(&mcpsdk.Implementation{Name: "test", Version: "1.0.0"}, nil)
+		mcpsdk.AddTool(server, &mcpsdk.Tool{Name: "add", Description: "Adds numbers"},
+			func(_ context.Context, _ *mcpsdk.CallToolRequest, in addInput) (*mcpsdk.CallToolResult, addOutput, error) {
+				if in.Left < 0 {
+					return nil, addOutput{}, errors.New("left must not be negative")
+				}
+				return nil, addOutput{Sum: in.Left + in.Right}, nil
+			})
+		return server
+	}
+	recordAuthorization := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization.Store(r.Header.Get("Authorization"))
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	for name, endpoint := range map[string]func(t *testing.T) MCPEndpoint{
+		"streamable HTTP": func(t *testing.T) MCPEndpoint {
+			server := httptest.NewServer(recordAuthorization(mcpsdk.NewStreamableHTTPHandler(newServer, nil)))
+			t.Cleanup(server.Close)
+			return MCPEndpoint{URL: server.URL}
+		},
+		"SSE": func(t *testing.T) MCPEndpoint {
+			server := httptest.NewServer(recordAuthorization(mcpsdk.NewSSEHandler(newServer, nil)))
+			t.Cleanup(server.Close)
+			return MCPEndpoint{URL: server.URL, SSE: true}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ep := endpoint(t)
+			ep.Headers = map[string]string{"Authorization": "Bearer abc"}
+
+			require.NoError(t, ListTools(t.Context(), ep, "text"))
+			require.NoError(t, ListTools(t.Context(), ep, "json"))
+			assert.Equal(t, "Bearer abc", authorization.Load())
+
+			require.NoError(t, CallTool(t.Context(), ep, "add", `{"left":1,"right":2}`, "text"))
+			require.NoError(t, CallTool(t.Context(), ep, "add", `{"left":1,"right":2}`, "json"))
+
+			err := CallTool(t.Context(), ep, "add", `{"left":-1,"right":2}`, "text")
+			require.ErrorIs(t, err, ErrToolCallFailed)
+
+			err = CallTool(t.Context(), ep, "add", `[1, 2]`, "text")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "JSON object")
+
+			err = CallTool(t.Context(), ep, "missing", `{}`, "text")
+			require.Error(t, err)
+			require.NotErrorIs(t, err, ErrToolCallFailed)
+		})
+	}
+}
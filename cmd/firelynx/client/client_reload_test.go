@@ -0,0 +1,72 @@
+//go:build e2e
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadFromFileE2E(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	httpPort := testutil.GetRandomPort(t)
+	grpcPort := testutil.GetRandomPort(t)
+	grpcAddr := fmt.Sprintf("localhost:%d", grpcPort)
+
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	testConfig := strings.ReplaceAll(testConfigContent, ":8080", fmt.Sprintf(":%d", httpPort))
+	require.NoError(t, os.WriteFile(configPath, []byte(testConfig), 0o644))
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	serverCtx, serverCancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Run(serverCtx, logger, configPath, fmt.Sprintf(":%d", grpcPort))
+	}()
+	t.Cleanup(func() {
+		serverCancel()
+		<-errCh
+	})
+
+	getStatus := func(path string) int {
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", httpPort, path))
+		if err != nil {
+			return 0
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		return resp.StatusCode
+	}
+	require.Eventually(t, func() bool {
+		return getStatus("/test") == http.StatusOK
+	}, 10*time.Second, 200*time.Millisecond, "Server should become ready")
+
+	// Reloading the file the server already runs changes nothing
+	require.NoError(t, ReloadFromFile(ctx, grpcAddr))
+
+	updatedConfig := strings.ReplaceAll(updatedConfigContent, ":8080", fmt.Sprintf(":%d", httpPort))
+	require.NoError(t, os.WriteFile(configPath, []byte(updatedConfig), 0o644))
+	require.NoError(t, ReloadFromFile(ctx, grpcAddr))
+
+	require.Eventually(t, func() bool {
+		return getStatus("/updated") == http.StatusOK
+	}, 10*time.Second, 200*time.Millisecond, "Updated endpoint should become available")
+
+	require.Error(t, ReloadFromFile(ctx, "invalid:1234"))
+}
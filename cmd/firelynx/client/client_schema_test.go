@@ -0,0 +1,89 @@
+//go:build e2e
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	"github.com/atlanticdynamic/firelynx/internal/client"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSchema(t *testing.T) {
+	schema := &client.Schema{
+		Services: []client.Service{{
+			Name: "settings.v1alpha1.ConfigService",
+			Methods: []client.Method{
+				{Name: "GetConfig", InputType: "settings.v1alpha1.GetConfigRequest", OutputType: "settings.v1alpha1.GetConfigResponse"},
+			},
+		}},
+		Messages: []string{"settings.v1alpha1.GetConfigRequest", "settings.v1alpha1.GetConfigResponse"},
+	}
+
+	assert.Equal(t, `service settings.v1alpha1.ConfigService
+  rpc GetConfig(settings.v1alpha1.GetConfigRequest) returns (settings.v1alpha1.GetConfigResponse)
+
+messages
+  settings.v1alpha1.GetConfigRequest
+  settings.v1alpha1.GetConfigResponse
+`, formatSchema(schema))
+}
+
+func TestGetSchemaE2E(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	// startServer runs a server with only the gRPC listener and waits for it
+	startServer := func(t *testing.T, reflection bool) string {
+		t.Helper()
+
+		grpcAddr := fmt.Sprintf("localhost:%d", testutil.GetRandomPort(t))
+		serverCtx, serverCancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.Run(serverCtx, logger, "", grpcAddr, server.WithGRPCReflection(reflection))
+		}()
+		t.Cleanup(func() {
+			serverCancel()
+			<-errCh
+		})
+
+		require.Eventually(t, func() bool {
+			conn, err := net.Dial("tcp", grpcAddr)
+			if err != nil {
+				return false
+			}
+			assert.NoError(t, conn.Close())
+			return true
+		}, 10*time.Second, 100*time.Millisecond, "gRPC listener should become ready")
+		return grpcAddr
+	}
+
+	t.Run("reflection enabled", func(t *testing.T) {
+		grpcAddr := startServer(t, true)
+
+		require.NoError(t, GetSchema(ctx, grpcAddr, "text"))
+		require.NoError(t, GetSchema(ctx, grpcAddr, "json"))
+	})
+
+	t.Run("reflection disabled", func(t *testing.T) {
+		grpcAddr := startServer(t, false)
+
+		err := GetSchema(ctx, grpcAddr, "text")
+		require.ErrorIs(t, err, client.ErrReflectionDisabled)
+		assert.Contains(t, err.Error(), "--grpc-reflection")
+	})
+}
@@ -0,0 +1,113 @@
+//go:build e2e
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestFormatStatus(t *testing.T) {
+	t.Run("with listeners", func(t *testing.T) {
+		status := &pb.GetServerStatusResponse{
+			State:            proto.String("Running"),
+			StartTime:        timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Uptime:           durationpb.New(90 * time.Second),
+			ConfigVersion:    proto.String("v1"),
+			TransactionId:    proto.String("0192-abcd"),
+			TransactionState: proto.String("completed"),
+			TransactionCount: proto.Int32(3),
+			Listeners: []*pb.ListenerStatus{
+				{Id: proto.String("public"), Type: proto.String("HTTP"), Address: proto.String(":8080")},
+				{Id: proto.String("internal-api"), Type: proto.String("HTTP"), Address: proto.String("127.0.0.1:9090")},
+			},
+		}
+
+		assert.Equal(t, `State:              Running
+Started:            2025-01-02T03:04:05Z
+Uptime:             1m30s
+Config Version:     v1
+Transaction:        0192-abcd
+Transaction State:  completed
+Transactions:       3
+
+LISTENER      TYPE  ADDRESS
+public        HTTP  :8080
+internal-api  HTTP  127.0.0.1:9090
+`, formatStatus(status))
+	})
+
+	t.Run("without a transaction", func(t *testing.T) {
+		status := &pb.GetServerStatusResponse{
+			State:  proto.String("Running"),
+			Uptime: durationpb.New(time.Second),
+		}
+
+		out := formatStatus(status)
+		assert.Contains(t, out, "Transaction:        none\n")
+		assert.Contains(t, out, "No active listeners\n")
+		assert.NotContains(t, out, "Started:")
+		assert.NotContains(t, out, "Change Reason:")
+	})
+
+	t.Run("with a change reason", func(t *testing.T) {
+		status := &pb.GetServerStatusResponse{
+			State:             proto.String("Running"),
+			Uptime:            durationpb.New(time.Second),
+			TransactionId:     proto.String("0192-abcd"),
+			TransactionState:  proto.String("completed"),
+			TransactionReason: proto.String("rolling back bad deploy"),
+		}
+
+		assert.Contains(t, formatStatus(status),
+			"Transaction State:  completed\nChange Reason:      rolling back bad deploy\n")
+	})
+}
+
+func TestGetStatusE2E(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	grpcAddr := fmt.Sprintf("localhost:%d", testutil.GetRandomPort(t))
+	serverCtx, serverCancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Run(serverCtx, logger, "", grpcAddr)
+	}()
+	t.Cleanup(func() {
+		serverCancel()
+		<-errCh
+	})
+
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", grpcAddr)
+		if err != nil {
+			return false
+		}
+		assert.NoError(t, conn.Close())
+		return true
+	}, 10*time.Second, 100*time.Millisecond, "gRPC listener should become ready")
+
+	require.NoError(t, GetStatus(ctx, grpcAddr, "text"))
+	require.NoError(t, GetStatus(ctx, grpcAddr, "json"))
+
+	require.Error(t, GetStatus(ctx, "invalid:1234", "text"))
+}
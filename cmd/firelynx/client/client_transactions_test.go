@@ -15,10 +15,14 @@ import (
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/client"
 	"github.com/atlanticdynamic/firelynx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestGetCurrentTransactionFormats(t *testing.T) {
@@ -89,6 +93,35 @@ func TestGetTransactionWithEmptyID(t *testing.T) {
 	require.Error(t, err, "Should fail with invalid server even with empty transaction ID")
 }
 
+func TestGetTransactionLogsFormats(t *testing.T) {
+	ctx := t.Context()
+
+	// Test all format variations with invalid server to verify format handling
+	formats := []string{"text", "json", "invalid"}
+
+	for _, format := range formats {
+		err := GetTransactionLogs(ctx, "invalid:1234", "test-transaction-id", 0, format)
+		require.Error(t, err, "Should fail with invalid server for format: %s", format)
+	}
+}
+
+func TestFormatLogRecord(t *testing.T) {
+	record := &pb.LogRecord{
+		Time:    timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Level:   pb.LogRecord_LEVEL_WARN.Enum(),
+		Message: proto.String("Participant failed"),
+		Attrs: map[string]*structpb.Value{
+			"participant": structpb.NewStringValue("HTTPRunner"),
+			"attempt":     structpb.NewNumberValue(2),
+		},
+	}
+
+	assert.Equal(t,
+		"2025-01-02T03:04:05Z WARN  Participant failed attempt=2 participant=HTTPRunner",
+		formatLogRecord(record),
+	)
+}
+
 func TestClearTransactionsKeepLast(t *testing.T) {
 	ctx := t.Context()
 
@@ -203,7 +236,7 @@ func TestTransactionOperationsE2E(t *testing.T) {
 	})
 
 	// Apply a config update to create transactions
-	err = ApplyConfig(ctx, updatedConfigPath, grpcAddr, 5*time.Second)
+	err = ApplyConfig(ctx, updatedConfigPath, grpcAddr, 5*time.Second, "add the updated route")
 	require.NoError(t, err, "Should apply config successfully")
 
 	// Wait for the update to complete
@@ -228,6 +261,41 @@ func TestTransactionOperationsE2E(t *testing.T) {
 		require.NoError(t, err, "Should list transactions after update")
 	})
 
+	// Test GetTransactionLogs
+	t.Run("GetTransactionLogs", func(t *testing.T) {
+		err := GetTransactionLogs(ctx, grpcAddr, "00000000-0000-0000-0000-000000000000", 0, "text")
+		require.Error(t, err, "Should fail with non-existent transaction ID")
+
+		firelynxClient := client.New(client.Config{ServerAddr: grpcAddr})
+		transactions, _, err := firelynxClient.ListConfigTransactions(ctx, "", 10, "", "")
+		require.NoError(t, err)
+		require.NotEmpty(t, transactions)
+		txID := transactions[len(transactions)-1].GetId()
+
+		err = GetTransactionLogs(ctx, grpcAddr, txID, 0, "text")
+		require.NoError(t, err, "Should show transaction logs")
+
+		err = GetTransactionLogs(ctx, grpcAddr, txID, 1, "json")
+		require.NoError(t, err, "Should show transaction logs in JSON format")
+	})
+
+	// The API transaction records the change reason and a hash of the config
+	t.Run("ListTransactions_Audit", func(t *testing.T) {
+		firelynxClient := client.New(client.Config{ServerAddr: grpcAddr})
+		transactions, _, err := firelynxClient.ListConfigTransactions(ctx, "", 10, "", "")
+		require.NoError(t, err)
+		require.NotEmpty(t, transactions)
+
+		audit := transactions[len(transactions)-1].GetAudit()
+		require.NotNil(t, audit)
+		assert.Equal(t, "add the updated route", audit.GetChangeReason())
+		assert.True(t, strings.HasPrefix(audit.GetContentHash(), "sha256:"))
+		assert.Empty(t, audit.GetSignature())
+		assert.Nil(t, transactions[0].GetAudit(), "file transactions have no audit record")
+
+		require.NoError(t, GetTransaction(ctx, grpcAddr, transactions[len(transactions)-1].GetId(), "text"))
+	})
+
 	// Test RollbackToTransaction
 	t.Run("RollbackToTransaction", func(t *testing.T) {
 		err := RollbackToTransaction(ctx, grpcAddr, "non-existent-id")
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// mcpClientName is the client name sent to MCP servers
+const mcpClientName = "firelynx-client"
+
+var (
+	// ErrNoMCPEndpoint is returned when neither an MCP URL nor a command is set
+	ErrNoMCPEndpoint = errors.New("an MCP endpoint URL or command is required")
+
+	// ErrToolCallFailed is returned when a tool returns a tool error
+	ErrToolCallFailed = errors.New("tool call failed")
+)
+
+// MCPEndpoint says how to reach an MCP server: over HTTP at URL, with the
+// streamable HTTP transport or the older SSE one, or over the stdin and
+// stdout of Command.
+type MCPEndpoint struct {
+	// URL is the HTTP endpoint of the server, e.g. http://localhost:8080/mcp
+	URL string
+
+	// SSE selects the SSE transport for URL instead of the streamable HTTP one
+	SSE bool
+
+	// Command is the program and arguments of a stdio server, used when URL
+	// is empty
+	Command []string
+
+	// Headers are sent with every HTTP request, e.g. an Authorization header
+	// for a route with an auth middleware
+	Headers map[string]string
+}
+
+// transport returns the MCP client transport for the endpoint
+func (e MCPEndpoint) transport() (mcpsdk.Transport, error) {
+	switch {
+	case e.URL != "":
+		httpClient := &http.Client{Transport: headerTransport(e.Headers)}
+		if e.SSE {
+			return &mcpsdk.SSEClientTransport{Endpoint: e.URL, HTTPClient: httpClient}, nil
+		}
+		return &mcpsdk.StreamableClientTransport{Endpoint: e.URL, HTTPClient: httpClient}, nil
+	case len(e.Command) > 0:
+		return &mcpsdk.CommandTransport{Command: exec.Command(e.Command[0], e.Command[1:]...)}, nil
+	default:
+		return nil, ErrNoMCPEndpoint
+	}
+}
+
+// connect opens an MCP session with the endpoint
+func (e MCPEndpoint) connect(ctx context.Context) (*mcpsdk.ClientSession, error) {
+	transport, err := e.transport()
+	if err != nil {
+		return nil, err
+	}
+	mcpClient := mcpsdk.NewClient(&mcpsdk.Implementation{Name: mcpClientName, Version: "1.0.0"}, nil)
+	session, err := mcpClient.Connect(ctx, transport, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MCP server: %w", err)
+	}
+	return session, nil
+}
+
+// ParseHeaders parses headers given as "Name: value"
+func ParseHeaders(headers []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(headers))
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q, expected \"Name: value\"", header)
+		}
+		parsed[name] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
+// ListTools lists the tools of the MCP server at endpoint
+func ListTools(ctx context.Context, endpoint MCPEndpoint, format string) error {
+	session, err := endpoint.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	var tools []*mcpsdk.Tool
+	for tool, err := range session.Tools(ctx, nil) {
+		if err != nil {
+			return fmt.Errorf("failed to list tools: %w", err)
+		}
+		tools = append(tools, tool)
+	}
+
+	switch format {
+	case "json":
+		jsonBytes, err := json.MarshalIndent(tools, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal tools to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	default: // text format
+		fmt.Print(formatTools(tools))
+	}
+
+	return nil
+}
+
+// formatTools formats the tools as a table of names and descriptions
+func formatTools(tools []*mcpsdk.Tool) string {
+	if len(tools) == 0 {
+		return "No tools\n"
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TOOL\tDESCRIPTION")
+	for _, tool := range tools {
+		fmt.Fprintf(w, "%s\t%s\n", tool.Name, tool.Description)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+// CallTool calls the named tool of the MCP server at endpoint with arguments,
+// a JSON object, and prints the result. A tool error is printed too, and
+// returned as ErrToolCallFailed.
+func CallTool(ctx context.Context, endpoint MCPEndpoint, tool, arguments, format string) error {
+	var args map[string]any
+	if arguments != "" {
+		if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+			return fmt.Errorf("arguments must be a JSON object: %w", err)
+		}
+	}
+
+	session, err := endpoint.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+
+	result, err := session.CallTool(ctx, &mcpsdk.CallToolParams{Name: tool, Arguments: args})
+	if err != nil {
+		return fmt.Errorf("failed to call tool %s: %w", tool, err)
+	}
+
+	switch format {
+	case "json":
+		jsonBytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal tool result to JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	default: // text format
+		fmt.Print(formatToolResult(result))
+	}
+
+	if result.IsError {
+		return fmt.Errorf("%w: %s", ErrToolCallFailed, tool)
+	}
+	return nil
+}
+
+// formatToolResult formats the content blocks of a tool result, one per line.
+// Text is printed as is, binary content as its type and size.
+func formatToolResult(result *mcpsdk.CallToolResult) string {
+	var b strings.Builder
+	for _, content := range result.Content {
+		switch c := content.(type) {
+		case *mcpsdk.TextContent:
+			b.WriteString(c.Text)
+		case *mcpsdk.ImageContent:
+			fmt.Fprintf(&b, "[image %s, %d bytes]", c.MIMEType, len(c.Data))
+		case *mcpsdk.AudioContent:
+			fmt.Fprintf(&b, "[audio %s, %d bytes]", c.MIMEType, len(c.Data))
+		default:
+			jsonBytes, err := json.Marshal(content)
+			if err != nil {
+				fmt.Fprintf(&b, "[%T]", content)
+				break
+			}
+			b.Write(jsonBytes)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// headerTransport sends headers with every request
+type headerTransport map[string]string
+
+func (h headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if len(h) > 0 {
+		r = r.Clone(r.Context())
+		for name, value := range h {
+			r.Header.Set(name, value)
+		}
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/schema"
+	"github.com/urfave/cli/v3"
+)
+
+var configCmd = &cli.Command{
+	Name:  "config",
+	Usage: "Work with configuration files",
+	Commands: []*cli.Command{
+		configSchemaCmd,
+	},
+}
+
+var configSchemaCmd = &cli.Command{
+	Name:  "schema",
+	Usage: "Print a JSON Schema of the configuration file, for editor validation and completion",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "output",
+			Aliases: []string{"o"},
+			Usage:   "Write the schema to this file instead of stdout",
+		},
+	},
+	Action: configSchemaAction,
+}
+
+func configSchemaAction(ctx context.Context, cmd *cli.Command) error {
+	data, err := schema.JSON()
+	if err != nil {
+		return fmt.Errorf("failed to generate schema: %w", err)
+	}
+	data = append(data, '\n')
+
+	if output := cmd.String("output"); output != "" {
+		if err := os.WriteFile(output, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write schema: %w", err)
+		}
+		return nil
+	}
+
+	_, err = cmd.Root().Writer.Write(data)
+	return err
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v3"
+)
+
+func TestConfigSchemaCommand(t *testing.T) {
+	t.Run("prints the schema", func(t *testing.T) {
+		var out bytes.Buffer
+		app := &cli.Command{
+			Name:     "firelynx",
+			Writer:   &out,
+			Commands: []*cli.Command{configCmd},
+		}
+		require.NoError(t, app.Run(t.Context(), []string{"firelynx", "config", "schema"}))
+
+		var printed map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &printed))
+		assert.Equal(t, schema.Draft, printed["$schema"])
+		assert.Contains(t, printed["properties"], "listeners")
+	})
+
+	t.Run("writes the schema to a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "firelynx.schema.json")
+		app := &cli.Command{
+			Name:     "firelynx",
+			Commands: []*cli.Command{configCmd},
+		}
+		require.NoError(t, app.Run(t.Context(), []string{"firelynx", "config", "schema", "--output", path}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(data))
+	})
+}
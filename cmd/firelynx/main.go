@@ -35,6 +35,7 @@ func main() {
 		Commands: []*cli.Command{
 			versionCmd,
 			validateCmd,
+			configCmd,
 			serverCmd,
 			clientCmd,
 		},
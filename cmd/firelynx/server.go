@@ -2,14 +2,26 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/atlanticdynamic/firelynx/cmd/firelynx/server"
+	"github.com/atlanticdynamic/firelynx/internal/client"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
 	"github.com/urfave/cli/v3"
 )
 
+// envConfigEnv is the environment variable that selects the config overlay,
+// when --env isn't given
+const envConfigEnv = "FIRELYNX_ENV"
+
 const (
-	invalidArgsErrorMsg = "Error: --config or --listen is required.\nSee --help for more info."
+	invalidArgsErrorMsg   = "Error: --config or --listen is required.\nSee --help for more info."
+	invalidReloadErrorMsg = "Error: --pid or --pid-file is required.\nSee --help for more info."
 )
 
 var serverCmd = &cli.Command{
@@ -18,14 +30,83 @@ var serverCmd = &cli.Command{
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:    "config",
-			Usage:   "Path to TOML configuration file",
+			Usage:   "Path to TOML configuration file, or - to read it from stdin",
 			Aliases: []string{"c"},
 		},
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment whose overlay file, e.g. config.prod.toml for prod, is merged over the --config file",
+			Sources: cli.EnvVars(envConfigEnv),
+		},
 		&cli.StringFlag{
 			Name:    "listen",
 			Usage:   "Address to bind gRPC service (tcp://host:port or a local UNIX socket unix:///path/to/socket)",
 			Aliases: []string{"l"},
 		},
+		&cli.BoolFlag{
+			Name:  "grpc-reflection",
+			Usage: "Enable gRPC server reflection on the --listen address, for `firelynx client schema` and grpcurl",
+		},
+		&cli.StringFlag{
+			Name:  "tls-cert",
+			Usage: "Path to the TLS certificate of the --listen address",
+		},
+		&cli.StringFlag{
+			Name:  "tls-key",
+			Usage: "Path to the TLS private key of the --listen address",
+		},
+		&cli.StringFlag{
+			Name:  "tls-client-ca",
+			Usage: "Path to the CA bundle that client certificates must be signed by (mutual TLS)",
+		},
+		&cli.StringFlag{
+			Name:    "auth-token",
+			Usage:   "Bearer token that calls to the --listen address must present",
+			Sources: cli.EnvVars(client.EnvAuthToken),
+		},
+		&cli.StringFlag{
+			Name:  "audit-key-file",
+			Usage: "Path to a secret key that signs the audit records of configurations submitted to the --listen address",
+		},
+		&cli.StringFlag{
+			Name:  "metrics-listen",
+			Usage: "Address to serve Prometheus metrics at /metrics (host:port), disabled when empty",
+		},
+		&cli.StringFlag{
+			Name:  "admin-listen",
+			Usage: "Address to serve the liveness and readiness checks (host:port), disabled when empty",
+		},
+		&cli.StringFlag{
+			Name:  "liveness-path",
+			Usage: "Path of the liveness check on the admin address",
+			Value: server.DefaultLivenessPath,
+		},
+		&cli.StringFlag{
+			Name:  "readiness-path",
+			Usage: "Path of the readiness check on the admin address",
+			Value: server.DefaultReadinessPath,
+		},
+		&cli.StringFlag{
+			Name:  "pid-file",
+			Usage: "Path to write the server's process ID to, for `firelynx server reload`",
+		},
+		&cli.BoolFlag{
+			Name:  "watch",
+			Usage: "Reload the --config file, and the script files it references, when they change",
+		},
+		&cli.IntFlag{
+			Name:  "transaction-history",
+			Usage: "Number of configuration transactions to keep in history",
+			Value: txstorage.DefaultMaxTransactions,
+		},
+		&cli.DurationFlag{
+			Name:  "transaction-max-age",
+			Usage: "Remove finished configuration transactions older than this from history, kept regardless of age when zero",
+		},
+	},
+	Commands: []*cli.Command{
+		serverReloadCmd,
+		serverStatusCmd,
 	},
 	Action: func(ctx context.Context, cmd *cli.Command) error {
 		configPath := cmd.String("config")
@@ -33,6 +114,89 @@ var serverCmd = &cli.Command{
 		if configPath == "" && listenAddr == "" {
 			return cli.Exit(invalidArgsErrorMsg, 1)
 		}
-		return server.Run(ctx, slog.Default(), configPath, listenAddr)
+		return server.Run(
+			ctx,
+			slog.Default(),
+			configPath,
+			listenAddr,
+			server.WithMetricsListenAddr(cmd.String("metrics-listen")),
+			server.WithAdminListenAddr(cmd.String("admin-listen")),
+			server.WithHealthPaths(cmd.String("liveness-path"), cmd.String("readiness-path")),
+			server.WithPIDFile(cmd.String("pid-file")),
+			server.WithConfigEnv(cmd.String("env")),
+			server.WithWatchConfig(cmd.Bool("watch")),
+			server.WithGRPCReflection(cmd.Bool("grpc-reflection")),
+			server.WithGRPCTLS(cmd.String("tls-cert"), cmd.String("tls-key"), cmd.String("tls-client-ca")),
+			server.WithGRPCAuthToken(cmd.String("auth-token")),
+			server.WithAuditKeyFile(cmd.String("audit-key-file")),
+			server.WithTransactionRetention(
+				int(cmd.Int("transaction-history")), cmd.Duration("transaction-max-age")),
+		)
+	},
+}
+
+var serverStatusCmd = &cli.Command{
+	Name:  "status",
+	Usage: "Show the live state of a running server, same as `firelynx client status`",
+	Description: `Show the server's state, uptime, current configuration version and
+  transaction, the number of stored transactions, and the active listeners.
+
+  Examples:
+    firelynx server status --server localhost:9999
+    firelynx server status --server localhost:9999 --format json`,
+	Flags: []cli.Flag{
+		serverFlag,
+		formatStatusFlag,
+	},
+	Action: clientStatusAction,
+}
+
+var serverReloadCmd = &cli.Command{
+	Name:  "reload",
+	Usage: "Reload a running server's configuration file by sending it SIGHUP",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "pid",
+			Usage: "Process ID of the server",
+		},
+		&cli.StringFlag{
+			Name:  "pid-file",
+			Usage: "Path of the PID file written by the server with --pid-file",
+		},
+	},
+	Action: func(ctx context.Context, cmd *cli.Command) error {
+		pid := cmd.Int("pid")
+		if pidFile := cmd.String("pid-file"); pid == 0 && pidFile != "" {
+			var err error
+			if pid, err = readPIDFile(pidFile); err != nil {
+				return err
+			}
+		}
+		if pid <= 0 {
+			return cli.Exit(invalidReloadErrorMsg, 1)
+		}
+
+		process, err := os.FindProcess(pid)
+		if err != nil {
+			return fmt.Errorf("failed to find server process %d: %w", pid, err)
+		}
+		if err := process.Signal(syscall.SIGHUP); err != nil {
+			return fmt.Errorf("failed to signal server process %d: %w", pid, err)
+		}
+		fmt.Printf("Sent reload signal to server process %d\n", pid)
+		return nil
 	},
 }
+
+// readPIDFile reads the process ID written by the server
+func readPIDFile(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read PID file: %w", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid PID file %s: %w", path, err)
+	}
+	return pid, nil
+}
@@ -0,0 +1,137 @@
+package server
+
+import "time"
+
+const (
+	// DefaultLivenessPath is where the liveness check is served on the admin address
+	DefaultLivenessPath = "/healthz"
+
+	// DefaultReadinessPath is where the readiness check is served on the admin address
+	DefaultReadinessPath = "/readyz"
+)
+
+// Option configures optional server components for Run
+type Option func(*options)
+
+type options struct {
+	metricsAddr   string
+	adminAddr     string
+	livenessPath  string
+	readinessPath string
+	pidFile       string
+	configEnv     string
+	watchConfig   bool
+	reflection    bool
+	tlsCert       string
+	tlsKey        string
+	tlsClientCA   string
+	authToken     string
+	auditKeyFile  string
+	txKeepLast    int
+	txMaxAge      time.Duration
+}
+
+// WithMetricsListenAddr serves Prometheus metrics at /metrics on addr. The
+// metrics endpoint is disabled when addr is empty.
+func WithMetricsListenAddr(addr string) Option {
+	return func(o *options) {
+		o.metricsAddr = addr
+	}
+}
+
+// WithAdminListenAddr serves the liveness and readiness checks on addr,
+// separate from the configured listeners. When the metrics address is the
+// same, metrics are served there too. The admin endpoint is disabled when
+// addr is empty.
+func WithAdminListenAddr(addr string) Option {
+	return func(o *options) {
+		o.adminAddr = addr
+	}
+}
+
+// WithHealthPaths sets the paths of the liveness and readiness checks on the
+// admin address. Empty paths keep their defaults.
+func WithHealthPaths(liveness, readiness string) Option {
+	return func(o *options) {
+		if liveness != "" {
+			o.livenessPath = liveness
+		}
+		if readiness != "" {
+			o.readinessPath = readiness
+		}
+	}
+}
+
+// WithPIDFile writes the server's process ID to path while it runs, so that
+// `firelynx server reload` can signal it. No file is written when path is
+// empty.
+func WithPIDFile(path string) Option {
+	return func(o *options) {
+		o.pidFile = path
+	}
+}
+
+// WithConfigEnv deep-merges the overlay file for env, such as config.prod.toml
+// for config.toml and "prod", over the config file. No overlay is merged when
+// env is empty.
+func WithConfigEnv(env string) Option {
+	return func(o *options) {
+		o.configEnv = env
+	}
+}
+
+// WithWatchConfig reloads the config file when it, or a local script file it
+// references, changes on disk
+func WithWatchConfig(watch bool) Option {
+	return func(o *options) {
+		o.watchConfig = watch
+	}
+}
+
+// WithGRPCReflection enables gRPC server reflection on the config service, so
+// clients can list its RPCs and message types without the generated stubs. It
+// has no effect without a listen address.
+func WithGRPCReflection(enabled bool) Option {
+	return func(o *options) {
+		o.reflection = enabled
+	}
+}
+
+// WithGRPCTLS serves the config service over TLS with the certificate and key
+// files. With a client CA file, calls must present a client certificate signed
+// by one of its CAs (mutual TLS). TLS is disabled when all are empty.
+func WithGRPCTLS(certFile, keyFile, clientCAFile string) Option {
+	return func(o *options) {
+		o.tlsCert = certFile
+		o.tlsKey = keyFile
+		o.tlsClientCA = clientCAFile
+	}
+}
+
+// WithGRPCAuthToken requires calls to the config service to present token as
+// a bearer token. The check is disabled when token is empty.
+func WithGRPCAuthToken(token string) Option {
+	return func(o *options) {
+		o.authToken = token
+	}
+}
+
+// WithAuditKeyFile signs the audit records of configurations submitted to the
+// config service with the key read from path. Records are unsigned when path
+// is empty.
+func WithAuditKeyFile(path string) Option {
+	return func(o *options) {
+		o.auditKeyFile = path
+	}
+}
+
+// WithTransactionRetention limits the configuration transaction history kept
+// in memory to the last keepLast transactions, and drops finished transactions
+// older than maxAge. Zero keeps the default history size and keeps
+// transactions regardless of age, respectively.
+func WithTransactionRetention(keepLast int, maxAge time.Duration) Option {
+	return func(o *options) {
+		o.txKeepLast = keepLast
+		o.txMaxAge = maxAge
+	}
+}
@@ -1,29 +1,68 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/logging/otlp"
+	"github.com/atlanticdynamic/firelynx/internal/server/health"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgfileloader"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgservice"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/tcp"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/atlanticdynamic/firelynx/internal/server/shutdown"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
 	"github.com/robbyt/go-supervisor/supervisor"
 )
 
+// otlpFlushTimeout bounds how long shutdown waits for batched request logs to
+// reach their OTLP collectors
+const otlpFlushTimeout = 5 * time.Second
+
+// Shutdown stage timeouts. Listeners drain in-flight requests for up to their
+// drain_timeout, 30s by default, so their stage allows longer.
+const (
+	listenersShutdownTimeout = time.Minute
+	appsShutdownTimeout      = 15 * time.Second
+	configShutdownTimeout    = 10 * time.Second
+	adminShutdownTimeout     = 5 * time.Second
+)
+
 // Run starts the firelynx server using the provided context, logger, configuration file path, and gRPC listen address.
+// On SIGINT, SIGTERM or ctx cancellation the server shuts down in stages: the listeners drain first,
+// then the transaction manager finishes applying any config, then the config sources stop, and
+// the admin and metrics endpoints stop last.
+// A configPath of cfgfileloader.StdinPath reads the configuration from stdin, once; SIGHUP
+// reloads are then ignored, and it can't be combined with WithWatchConfig or WithConfigEnv.
 // It returns an error if the server fails to start.
 func Run(
 	ctx context.Context,
 	logger *slog.Logger,
 	configPath string,
 	listenAddr string,
+	opts ...Option,
 ) error {
 	logHandler := logger.Handler()
 
+	o := options{
+		livenessPath:  DefaultLivenessPath,
+		readinessPath: DefaultReadinessPath,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Ensure at least one config provider is available
 	if configPath == "" && listenAddr == "" {
 		return fmt.Errorf(
@@ -35,6 +74,8 @@ func Run(
 	txStorage := txstorage.NewMemoryStorage(
 		txstorage.WithAsyncCleanup(true),
 		txstorage.WithLogHandler(logHandler),
+		txstorage.WithMaxTransactions(o.txKeepLast),
+		txstorage.WithMaxAge(o.txMaxAge),
 	)
 
 	// txmgrOrchestrator coordinates the configuration management rollout transactions with atomic roll-back
@@ -52,47 +93,100 @@ func Run(
 	// Get the transaction siphon channel, unbuffered and ready immediately
 	txSiphon := txMan.GetTransactionSiphon()
 
+	// Create an HTTP runner with the logger
+	httpRunner, err := http.NewRunner(
+		http.WithLogHandler(logHandler),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP runner: %w", err)
+	}
+
+	// Create a TCP runner for the stream proxy listeners
+	tcpRunner, err := tcp.NewRunner(
+		tcp.WithLogHandler(logHandler),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create TCP runner: %w", err)
+	}
+
 	// Build list of runnables based on provided arguments
 	var runnables []supervisor.Runnable
 
+	// The server handles SIGINT and SIGTERM itself, to stop the runnables in
+	// stages, so by default the supervisor only reloads every runnable on SIGHUP
+	supervisorSignals := []os.Signal{syscall.SIGHUP}
+	var configSources []supervisor.Runnable
+	var cfgFileLoader *cfgfileloader.Runner
+
 	// Create cfgfileloader if configPath is provided
 	if configPath != "" {
-		cfgFileLoader, err := cfgfileloader.NewRunner(
-			configPath,
-			txSiphon,
+		// The file loader handles SIGHUP itself, so it can wait for the
+		// reloaded config to be applied and log the outcome
+		reloadSignals := make(chan os.Signal, 1)
+		signal.Notify(reloadSignals, syscall.SIGHUP)
+		defer signal.Stop(reloadSignals)
+		// The supervisor subscribes to every signal when given none, so it
+		// gets one that it logs and ignores
+		supervisorSignals = []os.Signal{syscall.SIGUSR2}
+
+		loaderOpts := []cfgfileloader.Option{
 			cfgfileloader.WithLogHandler(logHandler),
-		)
+			cfgfileloader.WithReloadSignals(reloadSignals),
+		}
+		if o.configEnv != "" {
+			loaderOpts = append(loaderOpts, cfgfileloader.WithEnv(o.configEnv))
+		}
+		if o.watchConfig {
+			loaderOpts = append(loaderOpts, cfgfileloader.WithWatch(cfgfileloader.DefaultWatchDebounce))
+		}
+
+		cfgFileLoader, err = cfgfileloader.NewRunner(configPath, txSiphon, loaderOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to create config file loader: %w", err)
 		}
 		runnables = append(runnables, cfgFileLoader)
+		configSources = append(configSources, cfgFileLoader)
 	}
 
 	// Create cfgservice if listenAddr is provided
 	if listenAddr != "" {
-		cfgService, err := cfgservice.NewRunner(
-			listenAddr,
-			txSiphon,
+		cfgServiceOpts := []cfgservice.Option{
 			cfgservice.WithLogHandler(logHandler),
 			cfgservice.WithConfigTransactionStorage(txStorage),
-		)
+			cfgservice.WithAccessLogController(httpRunner),
+			cfgservice.WithDryRunner(txmgrOrchestrator),
+			cfgservice.WithReflection(o.reflection),
+			cfgservice.WithAuthToken(o.authToken),
+		}
+		if o.tlsCert != "" || o.tlsKey != "" || o.tlsClientCA != "" {
+			tlsConfig, err := cfgservice.NewTLSConfig(o.tlsCert, o.tlsKey, o.tlsClientCA)
+			if err != nil {
+				return fmt.Errorf("failed to configure gRPC TLS: %w", err)
+			}
+			cfgServiceOpts = append(cfgServiceOpts, cfgservice.WithTLSConfig(tlsConfig))
+		}
+		if cfgFileLoader != nil {
+			cfgServiceOpts = append(cfgServiceOpts, cfgservice.WithFileReloader(cfgFileLoader))
+		}
+		if o.auditKeyFile != "" {
+			auditKey, err := readAuditKey(o.auditKeyFile)
+			if err != nil {
+				return err
+			}
+			cfgServiceOpts = append(cfgServiceOpts, cfgservice.WithAuditKey(auditKey))
+		}
+
+		cfgService, err := cfgservice.NewRunner(listenAddr, txSiphon, cfgServiceOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to create config service: %w", err)
 		}
 		runnables = append(runnables, cfgService)
+		configSources = append(configSources, cfgService)
 	}
 
 	// Order matters: config providers first, then txmgr, then HTTP runner
 	runnables = append(runnables, txMan)
 
-	// Create an HTTP runner with the logger
-	httpRunner, err := http.NewRunner(
-		http.WithLogHandler(logHandler),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP runner: %w", err)
-	}
-
 	// Register the HTTP runner with the transaction manager as a saga participant
 	if err := txmgrOrchestrator.RegisterParticipant(httpRunner); err != nil {
 		return fmt.Errorf("failed to register HTTP runner with saga orchestrator: %w", err)
@@ -100,18 +194,207 @@ func Run(
 
 	// Add HTTP runner to runnables
 	runnables = append(runnables, httpRunner)
+
+	// Register the TCP runner the same way
+	if err := txmgrOrchestrator.RegisterParticipant(tcpRunner); err != nil {
+		return fmt.Errorf("failed to register TCP runner with saga orchestrator: %w", err)
+	}
+	runnables = append(runnables, tcpRunner)
+
+	var adminRunnables []supervisor.Runnable
+
+	// Create the admin endpoint if adminAddr is provided. It checks the
+	// components created so far, so it must be created after them.
+	if o.adminAddr != "" {
+		routes, err := healthRoutes(o, txStorage, runnables)
+		if err != nil {
+			return fmt.Errorf("failed to create health routes: %w", err)
+		}
+		if o.metricsAddr == o.adminAddr {
+			route, err := metricsRoute()
+			if err != nil {
+				return err
+			}
+			routes = append(routes, *route)
+		}
+		adminRunner, err := newHTTPRunner("admin", o.adminAddr, routes, logHandler)
+		if err != nil {
+			return fmt.Errorf("failed to create admin endpoint: %w", err)
+		}
+		runnables = append(runnables, adminRunner)
+		adminRunnables = append(adminRunnables, adminRunner)
+	}
+
+	// Create the metrics endpoint if metricsAddr is provided and not shared
+	// with the admin endpoint
+	if o.metricsAddr != "" && o.metricsAddr != o.adminAddr {
+		route, err := metricsRoute()
+		if err != nil {
+			return err
+		}
+		metricsRunner, err := newHTTPRunner("metrics", o.metricsAddr, httpserver.Routes{*route}, logHandler)
+		if err != nil {
+			return fmt.Errorf("failed to create metrics endpoint: %w", err)
+		}
+		runnables = append(runnables, metricsRunner)
+		adminRunnables = append(adminRunnables, metricsRunner)
+	}
+
+	// The supervisor cancels every runnable at once when it shuts down, so
+	// it runs until the sequencer has stopped them in order
+	supervisorCtx, cancelSupervisor := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelSupervisor()
+
+	sequencer := shutdown.New([]shutdown.Stage{
+		{Name: "listeners", Timeout: listenersShutdownTimeout, Runnables: []supervisor.Runnable{httpRunner, tcpRunner}},
+		{Name: "apps", Timeout: appsShutdownTimeout, Runnables: []supervisor.Runnable{txMan}},
+		{Name: "config", Timeout: configShutdownTimeout, Runnables: configSources},
+		{Name: "admin", Timeout: adminShutdownTimeout, Runnables: adminRunnables},
+	}, shutdown.WithLogHandler(logHandler))
+
 	pid0, err := supervisor.New(
-		supervisor.WithContext(ctx),
+		supervisor.WithContext(supervisorCtx),
 		supervisor.WithLogHandler(logHandler),
+		supervisor.WithSignals(supervisorSignals...),
 		supervisor.WithRunnables(runnables...),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create supervisor: %w", err)
 	}
-	if err := pid0.Run(); err != nil {
-		return fmt.Errorf("failed to run server: %w", err)
+
+	if o.pidFile != "" {
+		if err := writePIDFile(o.pidFile); err != nil {
+			return err
+		}
+		defer func() {
+			if err := os.Remove(o.pidFile); err != nil {
+				logger.Warn("Failed to remove PID file", "path", o.pidFile, "error", err)
+			}
+		}()
+	}
+
+	shutdownCtx, stopShutdownSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopShutdownSignals()
+	sequenced := make(chan struct{})
+	go func() {
+		defer close(sequenced)
+		<-shutdownCtx.Done()
+		// Skip the sequence when the supervisor has already stopped, e.g.
+		// because a runnable failed
+		if supervisorCtx.Err() == nil {
+			logger.Info("Shutting down")
+			sequencer.Shutdown()
+		}
+		cancelSupervisor()
+	}()
+
+	runErr := pid0.Run()
+	cancelSupervisor()
+	stopShutdownSignals()
+	<-sequenced
+
+	// The HTTP listeners have drained, so flush the request logs still
+	// batched for OTLP collectors before exiting
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), otlpFlushTimeout)
+	defer flushCancel()
+	if err := otlp.Shutdown(flushCtx); err != nil {
+		logger.Warn("Failed to flush OTLP logs", "error", err)
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("failed to run server: %w", runErr)
 	}
 
 	logger.Debug("Server shutdown complete")
 	return nil
 }
+
+// writePIDFile writes the current process ID to path
+func writePIDFile(path string) error {
+	pid := strconv.Itoa(os.Getpid()) + "\n"
+	if err := os.WriteFile(path, []byte(pid), 0o644); err != nil {
+		return fmt.Errorf("failed to write PID file: %w", err)
+	}
+	return nil
+}
+
+// readAuditKey reads the audit signing key from path. Surrounding whitespace,
+// such as a trailing newline, isn't part of the key.
+func readAuditKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit key file: %w", err)
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) == 0 {
+		return nil, fmt.Errorf("audit key file %s is empty", path)
+	}
+	return key, nil
+}
+
+// healthRoutes creates the liveness and readiness routes, which check the
+// given runnables and the current configuration transaction
+func healthRoutes(
+	o options,
+	txStorage *txstorage.MemoryStorage,
+	runnables []supervisor.Runnable,
+) (httpserver.Routes, error) {
+	var components []health.Component
+	for _, runnable := range runnables {
+		if component, ok := runnable.(health.Component); ok {
+			components = append(components, component)
+		}
+	}
+	checker := health.NewChecker(txStorage, components...)
+
+	liveness, err := httpserver.NewRouteFromHandlerFunc(
+		"liveness",
+		o.livenessPath,
+		checker.LivenessHandler().ServeHTTP,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create liveness route: %w", err)
+	}
+	readiness, err := httpserver.NewRouteFromHandlerFunc(
+		"readiness",
+		o.readinessPath,
+		checker.ReadinessHandler().ServeHTTP,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create readiness route: %w", err)
+	}
+	return httpserver.Routes{*liveness, *readiness}, nil
+}
+
+// metricsRoute creates the route that serves Prometheus metrics at /metrics
+func metricsRoute() (*httpserver.Route, error) {
+	route, err := httpserver.NewRouteFromHandlerFunc(
+		"metrics",
+		"/metrics",
+		metrics.Handler().ServeHTTP,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics route: %w", err)
+	}
+	return route, nil
+}
+
+// newHTTPRunner creates an HTTP server named name that serves routes on addr,
+// separate from the configured listeners
+func newHTTPRunner(
+	name string,
+	addr string,
+	routes httpserver.Routes,
+	logHandler slog.Handler,
+) (*httpserver.Runner, error) {
+	cfg, err := httpserver.NewConfig(addr, routes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s server config: %w", name, err)
+	}
+
+	return httpserver.NewRunner(
+		httpserver.WithName(name),
+		httpserver.WithConfig(cfg),
+		httpserver.WithLogHandler(logHandler),
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"testing"
@@ -381,10 +382,11 @@ func TestConfigFileReload(t *testing.T) {
 	}))
 
 	// Start the server
+	pidFile := filepath.Join(tempDir, "firelynx.pid")
 	serverCtx, serverCancel := context.WithCancel(ctx)
 	errCh := make(chan error, 1)
 	go func() {
-		err := Run(serverCtx, logger, configPath, "")
+		err := Run(serverCtx, logger, configPath, "", WithPIDFile(pidFile))
 		if err != nil {
 			errCh <- err
 		}
@@ -455,6 +457,23 @@ response = "New path response"`
 		"Response should contain new path echo text",
 	)
 
+	// The server wrote its PID for `firelynx server reload`
+	pid, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(os.Getpid()), strings.TrimSpace(string(pid)))
+
+	// A reload of an invalid file keeps the running config
+	require.NoError(t, os.WriteFile(configPath, []byte("not valid toml ["), 0o644))
+	require.NoError(t, proc.Signal(syscall.SIGHUP))
+	assert.Never(t, func() bool {
+		resp, err := httpClient.Get(newURL)
+		if err != nil {
+			return true
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		return resp.StatusCode != http.StatusOK
+	}, time.Second, 200*time.Millisecond, "Running config should survive a failed reload")
+
 	// Shutdown the server
 	serverCancel()
 
@@ -468,6 +487,123 @@ response = "New path response"`
 			return false
 		}
 	}, 1*time.Minute, 100*time.Millisecond, "Server shutdown timed out")
+
+	assert.NoFileExists(t, pidFile, "PID file should be removed on shutdown")
+}
+
+// TestServerMetricsEndpoint verifies that app request and response sizes are
+// exported on the metrics endpoint
+func TestServerMetricsEndpoint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping in short mode")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "test_config.toml")
+	httpAddr := fmt.Sprintf(":%d", testutil.GetRandomPort(t))
+	metricsAddr := fmt.Sprintf("localhost:%d", testutil.GetRandomPort(t))
+	configContent := strings.Replace(basicConfigTOML, ":8080", httpAddr, 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	serverCtx, serverCancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer serverCancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(serverCtx, logger, configPath, "", WithMetricsListenAddr(metricsAddr))
+		close(errCh)
+	}()
+
+	httpClient := &http.Client{Timeout: 2 * time.Second}
+	appURL := fmt.Sprintf("http://localhost%s/test", httpAddr)
+	assert.Eventually(t, func() bool {
+		resp, err := httpClient.Get(appURL)
+		if err != nil {
+			return false
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond, "Echo endpoint should become available")
+
+	var body string
+	assert.Eventually(t, func() bool {
+		resp, err := httpClient.Get("http://" + metricsAddr + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		b, err := io.ReadAll(resp.Body)
+		body = string(b)
+		return err == nil && resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond, "Metrics endpoint should become available")
+	assert.Contains(t, body, `firelynx_app_request_size_bytes_count{app="test_app"}`)
+	assert.Contains(t, body, `firelynx_app_response_size_bytes_count{app="test_app"}`)
+
+	serverCancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err, "Server should shut down cleanly")
+	case <-time.After(1 * time.Minute):
+		t.Fatal("Server shutdown timed out")
+	}
+}
+
+// TestServerHealthEndpoints verifies that the liveness and readiness checks
+// pass once the config is live, and that metrics share the admin address when
+// both use the same one
+func TestServerHealthEndpoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping in short mode")
+	}
+
+	configPath := filepath.Join(t.TempDir(), "test_config.toml")
+	httpAddr := fmt.Sprintf(":%d", testutil.GetRandomPort(t))
+	adminAddr := fmt.Sprintf("localhost:%d", testutil.GetRandomPort(t))
+	configContent := strings.Replace(basicConfigTOML, ":8080", httpAddr, 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0o644))
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	}))
+
+	serverCtx, serverCancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer serverCancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- Run(serverCtx, logger, configPath, "",
+			WithAdminListenAddr(adminAddr),
+			WithMetricsListenAddr(adminAddr),
+			WithHealthPaths("/livez", ""),
+		)
+		close(errCh)
+	}()
+
+	httpClient := &http.Client{Timeout: 2 * time.Second}
+	statusOf := func(path string) int {
+		resp, err := httpClient.Get("http://" + adminAddr + path)
+		if err != nil {
+			return 0
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		return resp.StatusCode
+	}
+
+	assert.Eventually(t, func() bool {
+		return statusOf(DefaultReadinessPath) == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond, "Server should become ready")
+	assert.Equal(t, http.StatusOK, statusOf("/livez"))
+	assert.Equal(t, http.StatusNotFound, statusOf(DefaultLivenessPath))
+	assert.Equal(t, http.StatusOK, statusOf("/metrics"))
+
+	serverCancel()
+	select {
+	case err := <-errCh:
+		require.NoError(t, err, "Server should shut down cleanly")
+	case <-time.After(1 * time.Minute):
+		t.Fatal("Server shutdown timed out")
+	}
 }
 
 // TestServerRequiresConfigSource verifies that the server returns an error
@@ -483,6 +619,32 @@ func TestServerRequiresConfigSource(t *testing.T) {
 	assert.ErrorContains(t, err, "no configuration source specified")
 }
 
+func TestReadAuditKey(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("trims surrounding whitespace", func(t *testing.T) {
+		path := filepath.Join(dir, "audit.key")
+		require.NoError(t, os.WriteFile(path, []byte("  s3cret\n"), 0o600))
+
+		key, err := readAuditKey(path)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("s3cret"), key)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.key")
+		require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+
+		_, err := readAuditKey(path)
+		require.ErrorContains(t, err, "is empty")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := readAuditKey(filepath.Join(dir, "missing.key"))
+		require.ErrorContains(t, err, "failed to read audit key file")
+	})
+}
+
 // TestNewConfigFromBytes validates that we can create configs from embedded bytes
 func TestNewConfigFromBytes(t *testing.T) {
 	// Test with the basic config
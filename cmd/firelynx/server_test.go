@@ -2,7 +2,13 @@ package main
 
 import (
 	"errors"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,3 +36,60 @@ func TestServerCmd_EmptyFlags(t *testing.T) {
 	assert.Equal(t, 1, exitErr.ExitCode())
 	assert.Equal(t, invalidArgsErrorMsg, exitErr.Error())
 }
+
+// TestServerReloadCmd_NoProcess verifies that reload requires a process to signal
+func TestServerReloadCmd_NoProcess(t *testing.T) {
+	t.Parallel()
+	cmd := &cli.Command{
+		Flags: []cli.Flag{
+			&cli.IntFlag{Name: "pid"},
+			&cli.StringFlag{Name: "pid-file"},
+		},
+	}
+
+	result := serverReloadCmd.Action(t.Context(), cmd)
+
+	var exitErr cli.ExitCoder
+	require.ErrorAs(t, result, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Equal(t, invalidReloadErrorMsg, exitErr.Error())
+}
+
+// TestServerReloadCmd_SendsSIGHUP verifies that reload signals the process in the PID file
+func TestServerReloadCmd_SendsSIGHUP(t *testing.T) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	pidFile := filepath.Join(t.TempDir(), "firelynx.pid")
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644))
+
+	err := serverReloadCmd.Run(t.Context(), []string{"reload", "--pid-file", pidFile})
+	require.NoError(t, err)
+
+	select {
+	case sig := <-signals:
+		assert.Equal(t, syscall.SIGHUP, sig)
+	case <-time.After(time.Second):
+		t.Fatal("SIGHUP was not received")
+	}
+}
+
+func TestReadPIDFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.pid")
+	require.NoError(t, os.WriteFile(valid, []byte("1234\n"), 0o644))
+	pid, err := readPIDFile(valid)
+	require.NoError(t, err)
+	assert.Equal(t, 1234, pid)
+
+	invalid := filepath.Join(dir, "invalid.pid")
+	require.NoError(t, os.WriteFile(invalid, []byte("not-a-pid"), 0o644))
+	_, err = readPIDFile(invalid)
+	require.ErrorContains(t, err, "invalid PID file")
+
+	_, err = readPIDFile(filepath.Join(dir, "missing.pid"))
+	require.ErrorContains(t, err, "failed to read PID file")
+}
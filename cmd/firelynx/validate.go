@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"charm.land/lipgloss/v2"
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/client"
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/loader"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	"github.com/urfave/cli/v3"
 )
@@ -22,6 +27,23 @@ type ValidationResult struct {
 	Error  error
 	Config *config.Config // Only populated if validation succeeded
 	Remote bool           // Whether validation was done remotely
+
+	// Checks holds each server component's readiness to apply the config,
+	// only populated by a deep remote validation
+	Checks []*pb.ParticipantCheck
+
+	// Issues holds each problem validation found, including warnings on a
+	// valid config. Empty when the config failed before validating, e.g. to
+	// load.
+	Issues []validation.Issue
+}
+
+// validationReport is the JSON form of a ValidationResult
+type validationReport struct {
+	Path   string             `json:"path"`
+	Valid  bool               `json:"valid"`
+	Error  string             `json:"error,omitempty"`
+	Issues []validation.Issue `json:"issues,omitempty"`
 }
 
 // Use existing styles from fancy package for validation output
@@ -41,11 +63,20 @@ var validateCmd = &cli.Command{
 			Aliases: []string{"s"},
 			Usage:   "Server address for remote validation (tcp://host:port or unix:///path/to/socket). If not provided, validates locally.",
 		},
+		&cli.BoolFlag{
+			Name:  "deep",
+			Usage: "Also check that the server could apply the configuration, e.g. that its listener ports can be bound (requires --server)",
+		},
 		&cli.StringFlag{
 			Name:    "config",
 			Aliases: []string{"c"},
 			Usage:   "Path to the configuration file",
 		},
+		&cli.StringFlag{
+			Name:    "env",
+			Usage:   "Environment whose overlay file, e.g. config.prod.toml for prod, is merged over each configuration file before validation",
+			Sources: cli.EnvVars(envConfigEnv),
+		},
 		&cli.BoolFlag{
 			Name:    "quiet",
 			Aliases: []string{"q"},
@@ -59,6 +90,12 @@ var validateCmd = &cli.Command{
 			Name:  "no-color",
 			Usage: "Disable colored output",
 		},
+		&cli.StringFlag{
+			Name:    "format",
+			Aliases: []string{"f"},
+			Usage:   "Output format: text (report grouped by component), json (validation issues of each file)",
+			Value:   "text",
+		},
 	},
 	Suggest:           true,
 	ReadArgsFromStdin: true,
@@ -103,15 +140,150 @@ func formatValidResult(result ValidationResult, treeView, noColor bool) string {
 	return fmt.Sprintf("%s: %s %s", path, validText, detailsText)
 }
 
-// formatInvalidResult formats an invalid validation result
+// formatParticipantCheck formats a server component's readiness to apply a
+// configuration, as reported by a deep validation
+func formatParticipantCheck(check *pb.ParticipantCheck, noColor bool) string {
+	var status string
+	switch {
+	case !check.GetReady():
+		status = "not ready: " + check.GetError()
+	case !check.GetChecked():
+		status = "not checked"
+	default:
+		status = "ready"
+	}
+
+	if noColor {
+		return fmt.Sprintf("  %s: %s", check.GetName(), status)
+	}
+
+	var statusText string
+	switch {
+	case !check.GetReady():
+		statusText = fancy.ErrorText(status)
+	case !check.GetChecked():
+		statusText = fancy.SummaryText(status)
+	default:
+		statusText = fancy.ValidText(status)
+	}
+	return fmt.Sprintf("  %s: %s", check.GetName(), statusText)
+}
+
+// notReadyError returns an error naming each component that could not apply
+// the configuration, or nil when none failed
+func notReadyError(checks []*pb.ParticipantCheck) error {
+	var errs []error
+	for _, check := range checks {
+		if !check.GetReady() {
+			errs = append(errs, fmt.Errorf("%s not ready: %s", check.GetName(), check.GetError()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// formatInvalidResult formats an invalid validation result. A result with
+// validation issues is reported as a count, followed by the issues grouped by
+// component; any other failure by its error.
 func formatInvalidResult(result ValidationResult, noColor bool) string {
+	var errorCount int
+	for _, issue := range result.Issues {
+		if issue.Severity == validation.SeverityError {
+			errorCount++
+		}
+	}
+	if errorCount == 0 {
+		if noColor {
+			return fmt.Sprintf("%s: %v", result.Path, result.Error)
+		}
+
+		path := fancy.PathText(result.Path)
+		errorMsg := fancy.ErrorText(result.Error.Error())
+		return fmt.Sprintf("%s: %s", path, errorMsg)
+	}
+
+	status := fmt.Sprintf("invalid (%d %s)", errorCount, pluralize(errorCount, "error", "errors"))
 	if noColor {
-		return fmt.Sprintf("%s: %v", result.Path, result.Error)
+		return fmt.Sprintf("%s: %s\n%s", result.Path, status, formatIssues(result.Issues, noColor))
 	}
 
 	path := fancy.PathText(result.Path)
-	errorMsg := fancy.ErrorText(result.Error.Error())
-	return fmt.Sprintf("%s: %s", path, errorMsg)
+	return fmt.Sprintf("%s: %s\n%s", path, fancy.ErrorText(status), formatIssues(result.Issues, noColor))
+}
+
+// formatIssues formats validation issues as a report grouped by component,
+// in the order the components first appear. Issues not attributed to a
+// component are grouped under "config".
+func formatIssues(issues []validation.Issue, noColor bool) string {
+	var groups []string
+	grouped := make(map[string][]validation.Issue)
+	for _, issue := range issues {
+		group := "config"
+		if issue.ComponentType != "" {
+			group = strings.TrimSpace(issue.ComponentType + " " + issue.ComponentID)
+		}
+		if _, ok := grouped[group]; !ok {
+			groups = append(groups, group)
+		}
+		grouped[group] = append(grouped[group], issue)
+	}
+
+	var lines []string
+	for _, group := range groups {
+		if noColor {
+			lines = append(lines, "  "+group)
+		} else {
+			lines = append(lines, "  "+fancy.CountText(group))
+		}
+
+		for _, issue := range grouped[group] {
+			label := string(issue.Severity)
+			if issue.Field != "" {
+				label += " " + issue.Field
+			}
+			// Messages joining several errors continue on indented lines
+			message := strings.ReplaceAll(issue.Message, "\n", "\n      ")
+
+			switch {
+			case noColor:
+				lines = append(lines, fmt.Sprintf("    %s: %s", label, message))
+			case issue.Severity == validation.SeverityWarning:
+				lines = append(lines, fmt.Sprintf("    %s: %s", fancy.WarningText(label), message))
+			default:
+				lines = append(lines, fmt.Sprintf("    %s: %s", fancy.ErrorText(label), message))
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatJSONResults formats validation results as a JSON array for tooling
+func formatJSONResults(results []ValidationResult) (string, error) {
+	reports := make([]validationReport, 0, len(results))
+	for _, result := range results {
+		report := validationReport{
+			Path:   result.Path,
+			Valid:  result.Valid,
+			Issues: result.Issues,
+		}
+		if result.Error != nil {
+			report.Error = result.Error.Error()
+		}
+		reports = append(reports, report)
+	}
+
+	jsonBytes, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation results to JSON: %w", err)
+	}
+	return string(jsonBytes), nil
+}
+
+// pluralize returns singular when count is 1, plural otherwise
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
 }
 
 // formatSummary formats the summary line
@@ -152,9 +324,12 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 	configPath := cmd.String("config")
 	serverAddr := cmd.String("server")
 	treeView := cmd.Bool("tree")
+	deep := cmd.Bool("deep")
 	quiet := cmd.Bool("quiet")
 	summaryOnly := cmd.Bool("summary")
 	noColor := !colorEnabled(cmd.Bool("no-color"))
+	format := cmd.String("format")
+	env := cmd.String("env")
 
 	var configPaths []string
 
@@ -171,12 +346,20 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 		configPaths = cmd.Args().Slice()
 	}
 
+	if deep && serverAddr == "" {
+		return fmt.Errorf("--deep requires a server address (use the --server flag)")
+	}
+
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported output format: %s (use text or json)", format)
+	}
+
 	// Validate all files and collect results
 	var results []ValidationResult
 	if serverAddr != "" {
-		results = validateRemote(ctx, configPaths, serverAddr)
+		results = validateRemote(ctx, configPaths, serverAddr, deep, env)
 	} else {
-		results = validateLocal(ctx, configPaths)
+		results = validateLocal(ctx, configPaths, env)
 	}
 
 	// Count results
@@ -189,6 +372,19 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 		}
 	}
 
+	// JSON output replaces the text report entirely, so tooling can parse it
+	if format == "json" {
+		output, err := formatJSONResults(results)
+		if err != nil {
+			return err
+		}
+		fmt.Println(output)
+		if failedCount > 0 {
+			return fmt.Errorf("validation failed")
+		}
+		return nil
+	}
+
 	// Output results based on flags
 	if !summaryOnly {
 		// Print per-file results
@@ -204,6 +400,12 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 				} else {
 					fmt.Println(lipgloss.Sprint(formatValidResult(result, false, noColor)))
 				}
+				if len(result.Issues) > 0 {
+					fmt.Println(lipgloss.Sprint(formatIssues(result.Issues, noColor)))
+				}
+				for _, check := range result.Checks {
+					fmt.Println(lipgloss.Sprint(formatParticipantCheck(check, noColor)))
+				}
 			}
 		}
 	}
@@ -224,20 +426,36 @@ func validateAction(ctx context.Context, cmd *cli.Command) error {
 	return nil
 }
 
+// validateRemote validates each config file on the server. A deep validation
+// also asks the server's components whether they could apply the config.
 func validateRemote(
 	ctx context.Context,
 	configPaths []string,
 	serverAddr string,
+	deep bool,
+	env string,
 ) []ValidationResult {
 	logger := slog.Default()
 
-	// Create a single client for remote validation (reuse connection)
-	firelynxClient := client.New(client.Config{
+	var results []ValidationResult
+
+	// Create a single client for remote validation (reuse connection), with
+	// the credentials set in the environment
+	clientCfg := client.Config{
 		Logger:     logger,
 		ServerAddr: serverAddr,
-	})
-
-	var results []ValidationResult
+	}
+	if err := clientCfg.LoadEnvCredentials(); err != nil {
+		for _, configPath := range configPaths {
+			results = append(results, ValidationResult{
+				Path:   configPath,
+				Remote: true,
+				Error:  fmt.Errorf("failed to load client credentials: %w", err),
+			})
+		}
+		return results
+	}
+	firelynxClient := client.New(clientCfg)
 
 	// Validate each file using the same gRPC connection
 	for _, configPath := range configPaths {
@@ -254,7 +472,7 @@ func validateRemote(
 		}
 
 		// Create a loader for the configuration
-		configLoader, err := loader.NewLoaderFromFilePath(configPath)
+		configLoader, err := loader.NewLoaderForEnv(configPath, env)
 		if err != nil {
 			result.Error = err
 			results = append(results, result)
@@ -269,22 +487,45 @@ func validateRemote(
 			continue
 		}
 
-		// Validate remotely using the gRPC service
-		isValid, err := firelynxClient.ValidateConfig(ctx, pbConfig)
-		if err != nil {
-			result.Error = fmt.Errorf("remote validation failed: %w", err)
-			results = append(results, result)
-			continue
-		}
+		// Validate remotely using the gRPC service. A deep validation has the
+		// server validate the config and dry run it on each component.
+		if deep {
+			checks, err := firelynxClient.DryRunConfig(ctx, pbConfig)
+			if err != nil {
+				result.Error = fmt.Errorf("remote validation failed: %w", err)
+				results = append(results, result)
+				continue
+			}
+			result.Checks = checks
+			if err := notReadyError(checks); err != nil {
+				result.Error = err
+				results = append(results, result)
+				continue
+			}
+		} else {
+			resp, err := firelynxClient.ValidateConfigIssues(ctx, pbConfig)
+			if err != nil {
+				result.Error = fmt.Errorf("remote validation failed: %w", err)
+				results = append(results, result)
+				continue
+			}
 
-		if !isValid {
-			result.Error = fmt.Errorf("configuration validation failed on server")
-			results = append(results, result)
-			continue
+			result.Issues = validation.IssuesFromProto(resp.GetIssues())
+			// The server only sees the loaded config, so add the loader's
+			// warnings about the source here
+			for _, warning := range configLoader.Warnings() {
+				result.Issues = append(result.Issues, validation.Issues(warning, validation.SeverityWarning)...)
+			}
+			if !resp.GetValid() {
+				result.Error = fmt.Errorf("remote validation failed: %w: %s",
+					client.ErrConfigRejected, resp.GetError())
+				results = append(results, result)
+				continue
+			}
 		}
 
 		// Validation succeeded - load config for display purposes
-		cfg, err := config.NewConfig(configPath)
+		cfg, err := config.NewConfigForEnv(configPath, env)
 		if err != nil {
 			// This shouldn't happen since we already validated successfully
 			result.Error = fmt.Errorf("failed to load config for display: %w", err)
@@ -300,7 +541,7 @@ func validateRemote(
 	return results
 }
 
-func validateLocal(ctx context.Context, configPaths []string) []ValidationResult {
+func validateLocal(ctx context.Context, configPaths []string, env string) []ValidationResult {
 	var results []ValidationResult
 
 	for _, configPath := range configPaths {
@@ -316,14 +557,16 @@ func validateLocal(ctx context.Context, configPaths []string) []ValidationResult
 			break
 		}
 
-		cfg, err := config.NewConfig(configPath)
+		cfg, err := config.NewConfigForEnv(configPath, env)
 		if err != nil {
 			result.Error = err
 			results = append(results, result)
 			continue
 		}
 
-		if err := cfg.Validate(); err != nil {
+		issues, err := cfg.ValidateWithIssues()
+		result.Issues = issues
+		if err != nil {
 			result.Error = err
 			results = append(results, result)
 			continue
@@ -5,15 +5,24 @@ package main
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/loader"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgservice"
+	httplistener "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
 	"github.com/atlanticdynamic/firelynx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -49,7 +58,7 @@ func TestValidateLocal(t *testing.T) {
 	t.Run("valid_config", func(t *testing.T) {
 		configPath := createTempConfigFile(t, validConfigContent)
 
-		results := validateLocal(t.Context(), []string{configPath})
+		results := validateLocal(t.Context(), []string{configPath}, "")
 		assert.Len(t, results, 1)
 		assert.True(t, results[0].Valid)
 		require.NoError(t, results[0].Error)
@@ -61,20 +70,27 @@ func TestValidateLocal(t *testing.T) {
 	t.Run("invalid_config", func(t *testing.T) {
 		configPath := createTempConfigFile(t, invalidConfigContent)
 
-		results := validateLocal(t.Context(), []string{configPath})
+		results := validateLocal(t.Context(), []string{configPath}, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
 		assert.Contains(t, results[0].Error.Error(), "duplicate ID")
 		assert.Equal(t, configPath, results[0].Path)
 		assert.False(t, results[0].Remote)
+
+		require.Len(t, results[0].Issues, 1)
+		issue := results[0].Issues[0]
+		assert.Equal(t, validation.ComponentEndpoint, issue.ComponentType)
+		assert.Equal(t, "duplicate_endpoint", issue.ComponentID)
+		assert.Equal(t, "id", issue.Field)
+		assert.Equal(t, validation.SeverityError, issue.Severity)
 	})
 
 	t.Run("multiple_configs", func(t *testing.T) {
 		configPath1 := createTempConfigFile(t, validConfigContent)
 		configPath2 := createTempConfigFile(t, validConfigContent)
 
-		results := validateLocal(t.Context(), []string{configPath1, configPath2})
+		results := validateLocal(t.Context(), []string{configPath1, configPath2}, "")
 		assert.Len(t, results, 2)
 		assert.True(t, results[0].Valid)
 		assert.True(t, results[1].Valid)
@@ -83,13 +99,51 @@ func TestValidateLocal(t *testing.T) {
 	})
 
 	t.Run("nonexistent_file", func(t *testing.T) {
-		results := validateLocal(t.Context(), []string{"/path/that/does/not/exist.toml"})
+		results := validateLocal(t.Context(), []string{"/path/that/does/not/exist.toml"}, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
 		assert.Contains(t, results[0].Error.Error(), "no such file or directory")
 	})
 
+	t.Run("env_overlay", func(t *testing.T) {
+		configPath := createTempConfigFile(t, validConfigContent)
+		// The overlay adds an app, and a route to an app that doesn't exist
+		overlay := `
+[overlay]
+lists = "append"
+
+[[endpoints]]
+id = "test_endpoint"
+[[endpoints.routes]]
+app_id = "missing_app"
+[endpoints.routes.http]
+path_prefix = "/missing"
+
+[[apps]]
+id = "other_app"
+type = "echo"
+[apps.echo]
+response = "other"
+`
+		require.NoError(t, os.WriteFile(loader.OverlayPath(configPath, "broken"), []byte(overlay), 0o644))
+
+		results := validateLocal(t.Context(), []string{configPath}, "")
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Error)
+
+		results = validateLocal(t.Context(), []string{configPath}, "broken")
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Valid)
+		require.Error(t, results[0].Error)
+		assert.Contains(t, results[0].Error.Error(), "missing_app")
+
+		results = validateLocal(t.Context(), []string{configPath}, "absent")
+		require.Len(t, results, 1)
+		require.Error(t, results[0].Error)
+		assert.Contains(t, results[0].Error.Error(), "test_config.absent.toml")
+	})
+
 	t.Run("canceled_context", func(t *testing.T) {
 		configPath := createTempConfigFile(t, validConfigContent)
 
@@ -97,7 +151,7 @@ func TestValidateLocal(t *testing.T) {
 		ctx, cancel := context.WithCancel(t.Context())
 		cancel() // Cancel immediately
 
-		results := validateLocal(ctx, []string{configPath})
+		results := validateLocal(ctx, []string{configPath}, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
@@ -137,7 +191,7 @@ func TestValidateRemote(t *testing.T) {
 	t.Run("valid_config", func(t *testing.T) {
 		configPath := createTempConfigFile(t, validConfigContent)
 
-		results := validateRemote(t.Context(), []string{configPath}, grpcAddr)
+		results := validateRemote(t.Context(), []string{configPath}, grpcAddr, false, "")
 		assert.Len(t, results, 1)
 		assert.True(t, results[0].Valid)
 		require.NoError(t, results[0].Error)
@@ -157,7 +211,7 @@ func TestValidateRemote(t *testing.T) {
 	t.Run("invalid_config", func(t *testing.T) {
 		configPath := createTempConfigFile(t, invalidConfigContent)
 
-		results := validateRemote(t.Context(), []string{configPath}, grpcAddr)
+		results := validateRemote(t.Context(), []string{configPath}, grpcAddr, false, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
@@ -166,6 +220,10 @@ func TestValidateRemote(t *testing.T) {
 		assert.Equal(t, configPath, results[0].Path)
 		assert.True(t, results[0].Remote)
 
+		require.Len(t, results[0].Issues, 1)
+		assert.Equal(t, validation.ComponentEndpoint, results[0].Issues[0].ComponentType)
+		assert.Equal(t, "duplicate_endpoint", results[0].Issues[0].ComponentID)
+
 		// Make sure no transaction was sent to the siphon for validation
 		select {
 		case tx := <-txSiphon:
@@ -179,7 +237,7 @@ func TestValidateRemote(t *testing.T) {
 		configPath1 := createTempConfigFile(t, validConfigContent)
 		configPath2 := createTempConfigFile(t, validConfigContent)
 
-		results := validateRemote(t.Context(), []string{configPath1, configPath2}, grpcAddr)
+		results := validateRemote(t.Context(), []string{configPath1, configPath2}, grpcAddr, false, "")
 		assert.Len(t, results, 2)
 		assert.True(t, results[0].Valid)
 		assert.True(t, results[1].Valid)
@@ -188,7 +246,7 @@ func TestValidateRemote(t *testing.T) {
 	})
 
 	t.Run("nonexistent_file", func(t *testing.T) {
-		results := validateRemote(t.Context(), []string{"/path/that/does/not/exist.toml"}, grpcAddr)
+		results := validateRemote(t.Context(), []string{"/path/that/does/not/exist.toml"}, grpcAddr, false, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
@@ -199,7 +257,7 @@ func TestValidateRemote(t *testing.T) {
 		configPath := createTempConfigFile(t, validConfigContent)
 
 		// Use an invalid server address
-		results := validateRemote(t.Context(), []string{configPath}, "localhost:99999")
+		results := validateRemote(t.Context(), []string{configPath}, "localhost:99999", false, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
@@ -213,7 +271,7 @@ func TestValidateRemote(t *testing.T) {
 		ctx, cancel := context.WithCancel(t.Context())
 		cancel() // Cancel immediately
 
-		results := validateRemote(ctx, []string{configPath}, grpcAddr)
+		results := validateRemote(ctx, []string{configPath}, grpcAddr, false, "")
 		assert.Len(t, results, 1)
 		assert.False(t, results[0].Valid)
 		require.Error(t, results[0].Error)
@@ -222,6 +280,87 @@ func TestValidateRemote(t *testing.T) {
 	})
 }
 
+func TestValidateRemoteDeep(t *testing.T) {
+	// Start a test gRPC server that dry runs configs on an HTTP runner
+	handler := slog.Default().Handler()
+	httpRunner, err := httplistener.NewRunner(httplistener.WithLogHandler(handler))
+	require.NoError(t, err)
+	sagaOrchestrator := orchestrator.NewSagaOrchestrator(txstorage.NewMemoryStorage(), handler)
+	require.NoError(t, sagaOrchestrator.RegisterParticipant(httpRunner))
+
+	txSiphon := make(chan *transaction.ConfigTransaction)
+	grpcAddr := fmt.Sprintf("localhost:%d", testutil.GetRandomPort(t))
+	cfgServiceRunner, err := cfgservice.NewRunner(grpcAddr, txSiphon,
+		cfgservice.WithDryRunner(sagaOrchestrator))
+	require.NoError(t, err)
+
+	cfgServiceErrCh := make(chan error, 1)
+	go func() {
+		cfgServiceErrCh <- cfgServiceRunner.Run(t.Context())
+	}()
+	require.Eventually(t, func() bool {
+		return cfgServiceRunner.IsReady()
+	}, time.Second, 10*time.Millisecond, "gRPC config service should start")
+	defer func() {
+		cfgServiceRunner.Stop()
+		assert.Eventually(t, func() bool {
+			return !cfgServiceRunner.IsReady()
+		}, time.Second, 10*time.Millisecond, "gRPC config service should stop")
+	}()
+
+	// configWithAddress returns a valid config file listening on addr
+	configWithAddress := func(t *testing.T, addr string) string {
+		t.Helper()
+		return createTempConfigFile(t, strings.Replace(validConfigContent, `":8080"`, fmt.Sprintf("%q", addr), 1))
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		configPath := configWithAddress(t, testutil.GetRandomListeningPort(t))
+
+		results := validateRemote(t.Context(), []string{configPath}, grpcAddr, true, "")
+		require.Len(t, results, 1)
+		require.NoError(t, results[0].Error)
+		assert.True(t, results[0].Valid)
+		require.Len(t, results[0].Checks, 1)
+		assert.Equal(t, "HTTPRunner", results[0].Checks[0].GetName())
+		assert.True(t, results[0].Checks[0].GetChecked())
+		assert.True(t, results[0].Checks[0].GetReady())
+
+		assert.Never(t, func() bool {
+			<-txSiphon
+			return true
+		}, 50*time.Millisecond, 10*time.Millisecond, "DryRunConfig should not send transactions to siphon")
+	})
+
+	t.Run("port in use", func(t *testing.T) {
+		held, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, held.Close()) }()
+		configPath := configWithAddress(t, held.Addr().String())
+
+		results := validateRemote(t.Context(), []string{configPath}, grpcAddr, true, "")
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Valid)
+		require.Error(t, results[0].Error)
+		assert.Contains(t, results[0].Error.Error(), "HTTPRunner not ready")
+		assert.Contains(t, results[0].Error.Error(), "cannot bind "+held.Addr().String())
+		require.Len(t, results[0].Checks, 1)
+		assert.False(t, results[0].Checks[0].GetReady())
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		configPath := createTempConfigFile(t, invalidConfigContent)
+
+		results := validateRemote(t.Context(), []string{configPath}, grpcAddr, true, "")
+		require.Len(t, results, 1)
+		assert.False(t, results[0].Valid)
+		require.Error(t, results[0].Error)
+		assert.Contains(t, results[0].Error.Error(), "remote validation failed")
+		assert.Contains(t, results[0].Error.Error(), "duplicate ID")
+		assert.Empty(t, results[0].Checks)
+	})
+}
+
 // TestValidateRemoteShutdownTiming verifies that the gRPC server can shutdown quickly
 // after validation operations, confirming that ValidateConfig doesn't create transactions
 // that get stuck in non-terminal states during shutdown
@@ -296,11 +435,34 @@ func TestValidateAction(t *testing.T) {
 			args:      []string{"test", validConfigPath, anotherConfigPath},
 			wantError: false,
 		},
+		{
+			name:      "deep_without_server",
+			args:      []string{"test", "--deep", validConfigPath},
+			wantError: true,
+			errorMsg:  "--deep requires a server address",
+		},
 		{
 			name:      "with_quiet_flag",
 			args:      []string{"test", "--quiet", validConfigPath},
 			wantError: false,
 		},
+		{
+			name:      "with_json_format",
+			args:      []string{"test", "--format", "json", validConfigPath},
+			wantError: false,
+		},
+		{
+			name:      "invalid_config_json_format",
+			args:      []string{"test", "-f", "json", invalidConfigPath},
+			wantError: true,
+			errorMsg:  "validation failed",
+		},
+		{
+			name:      "unsupported_format",
+			args:      []string{"test", "--format", "yaml", validConfigPath},
+			wantError: true,
+			errorMsg:  "unsupported output format",
+		},
 		{
 			name:      "with_summary_flag",
 			args:      []string{"test", "--summary", validConfigPath, invalidConfigPath},
@@ -331,6 +493,60 @@ func TestValidateAction(t *testing.T) {
 	}
 }
 
+func TestFormatValidationIssues(t *testing.T) {
+	result := ValidationResult{
+		Path:  "config.toml",
+		Error: errors.New("failed to validate config"),
+		Issues: []validation.Issue{
+			{
+				ComponentType: validation.ComponentListener,
+				ComponentID:   "http",
+				Field:         "address",
+				Message:       "address is missing",
+				Severity:      validation.SeverityError,
+			},
+			{Field: "default_timeout", Message: "must be positive", Severity: validation.SeverityError},
+			{
+				ComponentType: validation.ComponentListener,
+				ComponentID:   "http",
+				Message:       "first line\nsecond line",
+				Severity:      validation.SeverityWarning,
+			},
+		},
+	}
+
+	t.Run("text report groups issues by component", func(t *testing.T) {
+		assert.Equal(t, strings.Join([]string{
+			"config.toml: invalid (2 errors)",
+			"  listener http",
+			"    error address: address is missing",
+			"    warning: first line",
+			"      second line",
+			"  config",
+			"    error default_timeout: must be positive",
+		}, "\n"), formatInvalidResult(result, true))
+	})
+
+	t.Run("failure without issues shows the error", func(t *testing.T) {
+		failed := ValidationResult{Path: "config.toml", Error: errors.New("failed to load config")}
+		assert.Equal(t, "config.toml: failed to load config", formatInvalidResult(failed, true))
+	})
+
+	t.Run("json report", func(t *testing.T) {
+		output, err := formatJSONResults([]ValidationResult{result, {Path: "valid.toml", Valid: true}})
+		require.NoError(t, err)
+
+		var reports []validationReport
+		require.NoError(t, json.Unmarshal([]byte(output), &reports))
+		require.Len(t, reports, 2)
+		assert.Equal(t, "failed to validate config", reports[0].Error)
+		assert.Equal(t, result.Issues, reports[0].Issues)
+		assert.True(t, reports[1].Valid)
+		assert.Empty(t, reports[1].Issues)
+		assert.Contains(t, output, `"componentType": "listener"`)
+	})
+}
+
 func TestValidateRemoteShutdownTiming(t *testing.T) {
 	assert := assert.New(t)
 
@@ -367,7 +583,7 @@ func TestValidateRemoteShutdownTiming(t *testing.T) {
 
 			// Perform validations using modern range syntax
 			for range tc.validationCount {
-				results := validateRemote(t.Context(), []string{configPath}, grpcAddr)
+				results := validateRemote(t.Context(), []string{configPath}, grpcAddr, false, "")
 				assert.Len(results, 1)
 				assert.True(results[0].Valid)
 				require.NoError(t, results[0].Error)
@@ -0,0 +1,82 @@
+//go:build integration
+
+package config_test
+
+import (
+	_ "embed"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	scripts "github.com/atlanticdynamic/firelynx/internal/server/integration_tests/scripts"
+	"github.com/stretchr/testify/suite"
+)
+
+//go:embed script-javascript-basic.toml
+var scriptJavaScriptBasicConfig []byte
+
+// ScriptJavaScriptBasicTestSuite extends the base script integration test suite
+type ScriptJavaScriptBasicTestSuite struct {
+	scripts.ScriptIntegrationTestSuite
+}
+
+// SetupSuite initializes the test suite with the embedded JavaScript config
+func (s *ScriptJavaScriptBasicTestSuite) SetupSuite() {
+	s.SetupWithEmbeddedConfig(scriptJavaScriptBasicConfig)
+}
+
+// TestConfigurationValidation verifies the configuration loads and validates correctly
+func (s *ScriptJavaScriptBasicTestSuite) TestConfigurationValidation() {
+	s.ValidateConfigStructure(1, 1, 1)
+
+	s.ValidateEvaluator("js-demo", "javascript", 10*time.Second)
+
+	s.ValidateStaticData("js-demo", map[string]interface{}{
+		"service_name": "firelynx-js-demo",
+		"version":      "1.0.0",
+		"environment":  "example",
+	})
+
+	// Verify listener is HTTP type
+	listener := s.GetConfig().Listeners[0]
+	s.Equal(listeners.TypeHTTP, listener.Type, "Should be HTTP listener")
+
+	// Verify endpoint and route structure
+	s.Len(s.GetConfig().Endpoints[0].Routes, 1, "Should have one route")
+}
+
+// TestDataNamespaceUsage verifies the script properly uses the data namespace pattern
+func (s *ScriptJavaScriptBasicTestSuite) TestDataNamespaceUsage() {
+	s.AssertDataNamespaceUsage("js-demo")
+
+	s.AssertScriptContains("js-demo",
+		`const data = ctx.data || {}`,
+		`const request = ctx.request || {}`,
+		`data.service_name || "unknown"`,
+	)
+}
+
+// TestScriptExecution tests the basic script execution structure
+func (s *ScriptJavaScriptBasicTestSuite) TestScriptExecution() {
+	s.AssertScriptContains("js-demo",
+		"Hello from JavaScript!",
+		"message:",
+		"request_info:",
+		`(headers["User-Agent"] || [""])[0]`,
+	)
+}
+
+// TestPortAssignment verifies that port assignment works correctly
+func (s *ScriptJavaScriptBasicTestSuite) TestPortAssignment() {
+	originalPort := s.GetPort()
+	s.Positive(originalPort, "Should have assigned a valid port")
+
+	// Verify the port was updated in configuration
+	listener := s.GetConfig().Listeners[0]
+	s.Contains(listener.Address, ":", "Address should contain port")
+}
+
+// TestSuiteRunner runs the test suite
+func TestScriptJavaScriptBasicTestSuite(t *testing.T) {
+	suite.Run(t, new(ScriptJavaScriptBasicTestSuite))
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"log/slog"
 	"net"
@@ -12,21 +13,31 @@ import (
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/loader"
-	"github.com/pelletier/go-toml/v2"
+	"github.com/atlanticdynamic/firelynx/internal/config/marshaller"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
 )
 
 // Client represents a firelynx client that can send pbufs to a server
 type Client struct {
 	logger     *slog.Logger
 	serverAddr string
+	tlsConfig  *tls.Config
+	authToken  string
 }
 
 // Config holds configuration options for creating a Client
 type Config struct {
 	Logger     *slog.Logger
 	ServerAddr string
+
+	// TLSConfig connects over TLS, nil connects without TLS
+	TLSConfig *tls.Config
+
+	// AuthToken is sent as a bearer token with every call when set
+	AuthToken string
 }
 
 // New creates a new client instance
@@ -41,6 +52,8 @@ func New(cfg Config) *Client {
 	return &Client{
 		logger:     logger,
 		serverAddr: cfg.ServerAddr,
+		tlsConfig:  cfg.TLSConfig,
+		authToken:  cfg.AuthToken,
 	}
 }
 
@@ -108,7 +121,8 @@ func (c *Client) ApplyConfigFromTransaction(ctx context.Context, transactionID s
 
 	// Send update request
 	resp, err := client.UpdateConfig(ctx, &pb.UpdateConfigRequest{
-		Config: validatedPbConfig,
+		Config:       validatedPbConfig,
+		ChangeReason: proto.String("rollback to transaction " + transactionID),
 	})
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
@@ -125,11 +139,25 @@ func (c *Client) ApplyConfigFromTransaction(ctx context.Context, transactionID s
 
 // ApplyConfig sends a configuration to the server using the provided loader
 func (c *Client) ApplyConfig(ctx context.Context, configLoader loader.Loader) error {
+	return c.ApplyConfigWithReason(ctx, configLoader, "")
+}
+
+// ApplyConfigWithReason sends a configuration to the server using the provided
+// loader, with the reason for the change, which the server records in the
+// transaction's audit record
+func (c *Client) ApplyConfigWithReason(
+	ctx context.Context,
+	configLoader loader.Loader,
+	changeReason string,
+) error {
 	// Parse the configuration
 	config, err := configLoader.LoadProto()
 	if err != nil {
 		return fmt.Errorf("failed to parse configuration: %w", err)
 	}
+	for _, warning := range configLoader.Warnings() {
+		c.logger.Warn("Configuration warning", "warning", warning)
+	}
 
 	c.logger.Info("Sending configuration to server", "server", c.serverAddr)
 
@@ -149,7 +177,8 @@ func (c *Client) ApplyConfig(ctx context.Context, configLoader loader.Loader) er
 
 	// Send update request
 	resp, err := client.UpdateConfig(ctx, &pb.UpdateConfigRequest{
-		Config: config,
+		Config:       config,
+		ChangeReason: proto.String(changeReason),
 	})
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
@@ -193,12 +222,32 @@ func (c *Client) GetConfig(ctx context.Context) (*pb.ServerConfig, error) {
 
 // ValidateConfig validates a configuration against the server without applying it
 func (c *Client) ValidateConfig(ctx context.Context, config *pb.ServerConfig) (bool, error) {
+	resp, err := c.ValidateConfigIssues(ctx, config)
+	if err != nil {
+		return false, err
+	}
+
+	if !resp.GetValid() {
+		errorMsg := resp.GetError()
+		return false, fmt.Errorf("%w: %s", ErrConfigRejected, errorMsg)
+	}
+
+	return true, nil
+}
+
+// ValidateConfigIssues validates a configuration against the server without
+// applying it, returning the server's response with each problem it found. An
+// invalid configuration is reported in the response rather than as an error.
+func (c *Client) ValidateConfigIssues(
+	ctx context.Context,
+	config *pb.ServerConfig,
+) (*pb.ValidateConfigResponse, error) {
 	c.logger.Debug("Validating configuration with server", "server", c.serverAddr)
 
 	// Connect to server
 	conn, err := c.connect(ctx)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 	defer func() {
 		if err := conn.Close(); err != nil {
@@ -214,31 +263,55 @@ func (c *Client) ValidateConfig(ctx context.Context, config *pb.ServerConfig) (b
 		Config: config,
 	})
 	if err != nil {
-		return false, fmt.Errorf("failed to validate configuration: %w", err)
+		return nil, fmt.Errorf("failed to validate configuration: %w", err)
+	}
+
+	return resp, nil
+}
+
+// DryRunConfig asks the server whether each of its components could apply the
+// configuration, e.g. whether the listener ports can be bound, without applying
+// it. An invalid configuration is reported as ErrConfigRejected.
+func (c *Client) DryRunConfig(
+	ctx context.Context,
+	config *pb.ServerConfig,
+) ([]*pb.ParticipantCheck, error) {
+	c.logger.Debug("Dry running configuration on server", "server", c.serverAddr)
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.Error("Failed to close connection", "error", err)
+		}
+	}()
+
+	client := pb.NewConfigServiceClient(conn)
+	resp, err := client.DryRunConfig(ctx, &pb.DryRunConfigRequest{
+		Config: config,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to dry run configuration: %w", err)
 	}
 
 	if !resp.GetValid() {
-		errorMsg := resp.GetError()
-		return false, fmt.Errorf("%w: %s", ErrConfigRejected, errorMsg)
+		return nil, fmt.Errorf("%w: %s", ErrConfigRejected, resp.GetError())
 	}
 
-	return true, nil
+	return resp.GetParticipants(), nil
 }
 
-// SaveConfig saves a configuration to a file
+// SaveConfig saves a configuration to a file, as TOML the config loader reads
 func (c *Client) SaveConfig(config *pb.ServerConfig, outputPath string) error {
-	if config == nil {
-		return ErrNilConfig
-	}
-
-	// Convert to TOML
-	tomlBytes, err := toml.Marshal(config)
+	data, err := c.MarshalConfig(config, marshaller.FormatTOML)
 	if err != nil {
-		return fmt.Errorf("failed to convert config to TOML: %w", err)
+		return err
 	}
 
 	// Write to file
-	if err := os.WriteFile(outputPath, tomlBytes, 0o644); err != nil {
+	if err := os.WriteFile(outputPath, data, 0o644); err != nil {
 		return fmt.Errorf("failed to write configuration: %w", err)
 	}
 
@@ -248,17 +321,30 @@ func (c *Client) SaveConfig(config *pb.ServerConfig, outputPath string) error {
 
 // FormatConfig formats a configuration as a TOML string
 func (c *Client) FormatConfig(config *pb.ServerConfig) (string, error) {
-	if config == nil {
-		return "", ErrNilConfig
+	data, err := c.MarshalConfig(config, marshaller.FormatTOML)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// MarshalConfig converts a configuration to the domain config and serializes
+// it in the given format, laid out like the TOML config file
+func (c *Client) MarshalConfig(pbConfig *pb.ServerConfig, format marshaller.Format) ([]byte, error) {
+	if pbConfig == nil {
+		return nil, ErrNilConfig
 	}
 
-	// Convert to TOML
-	tomlBytes, err := toml.Marshal(config)
+	domainConfig, err := config.NewFromProto(pbConfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to convert config to TOML: %w", err)
+		return nil, fmt.Errorf("failed to convert config: %w", err)
 	}
 
-	return string(tomlBytes), nil
+	data, err := marshaller.Marshal(domainConfig, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config to %s: %w", format, err)
+	}
+	return data, nil
 }
 
 // GetCurrentConfigTransaction retrieves the current configuration transaction from the server
@@ -364,6 +450,49 @@ func (c *Client) GetConfigTransaction(
 	return resp.Transaction, nil
 }
 
+// GetTransactionLogs retrieves the log records collected during a configuration
+// transaction. A maxRecords of 0 returns as many records as the server allows;
+// when the log is truncated, the most recent records are returned.
+func (c *Client) GetTransactionLogs(
+	ctx context.Context,
+	transactionID string,
+	maxRecords int32,
+) (*pb.GetTransactionLogsResponse, error) {
+	c.logger.Debug(
+		"Getting transaction logs from server",
+		"server",
+		c.serverAddr,
+		"transaction_id",
+		transactionID,
+	)
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.Error("Failed to close connection", "error", err)
+		}
+	}()
+
+	client := pb.NewConfigServiceClient(conn)
+
+	req := &pb.GetTransactionLogsRequest{
+		TransactionId: &transactionID,
+	}
+	if maxRecords > 0 {
+		req.MaxRecords = &maxRecords
+	}
+
+	resp, err := client.GetTransactionLogs(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction logs: %w", err)
+	}
+
+	return resp, nil
+}
+
 // ClearConfigTransactions clears the history of configuration transactions on the server
 func (c *Client) ClearConfigTransactions(ctx context.Context, keepLast int32) (int32, error) {
 	c.logger.Debug(
@@ -404,41 +533,105 @@ func (c *Client) ClearConfigTransactions(ctx context.Context, keepLast int32) (i
 	return resp.GetClearedCount(), nil
 }
 
+// ReloadFromFile asks the server to re-read the config file it was started
+// from. The response holds the ID of the transaction the reload started, or
+// reports that the file is unchanged.
+func (c *Client) ReloadFromFile(ctx context.Context) (*pb.ReloadFromFileResponse, error) {
+	c.logger.Debug("Reloading server config file", "server", c.serverAddr)
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.Error("Failed to close connection", "error", err)
+		}
+	}()
+
+	client := pb.NewConfigServiceClient(conn)
+
+	resp, err := client.ReloadFromFile(ctx, &pb.ReloadFromFileRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload config file: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetServerStatus retrieves the live state of the server: its state, uptime,
+// current configuration transaction, and active listeners
+func (c *Client) GetServerStatus(ctx context.Context) (*pb.GetServerStatusResponse, error) {
+	c.logger.Debug("Getting server status", "server", c.serverAddr)
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.Error("Failed to close connection", "error", err)
+		}
+	}()
+
+	client := pb.NewConfigServiceClient(conn)
+
+	resp, err := client.GetServerStatus(ctx, &pb.GetServerStatusRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server status: %w", err)
+	}
+
+	return resp, nil
+}
+
 // connect establishes a connection to the server
 func (c *Client) connect(_ context.Context) (*grpc.ClientConn, error) {
-	// For now, we'll use insecure connections for simplicity
-	// In a production environment, you'd want to use TLS
-
 	network, address, err := c.parseServerAddr(c.serverAddr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid server address: %w", err)
 	}
 
+	opts := c.dialOptions()
+
 	// Support for both TCP and Unix socket
 	switch network {
 	case "tcp":
 		c.logger.Debug("Connecting to server via TCP", "address", address)
-		return grpc.NewClient(
-			address,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
-		)
+		return grpc.NewClient(address, opts...)
 
 	case "unix":
 		c.logger.Debug("Connecting to server via Unix socket", "path", address)
-		return grpc.NewClient(
-			"unix:"+address,
-			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		opts = append(opts,
 			grpc.WithContextDialer(func(_ context.Context, addr string) (net.Conn, error) {
 				// addr is expected to be in the format "unix:/path/to/socket"
 				socketAddr := strings.TrimPrefix(addr, "unix:")
 				return net.Dial("unix", socketAddr)
 			}),
 		)
+		return grpc.NewClient("unix:"+address, opts...)
 	default:
 		return nil, fmt.Errorf("%w: %s", ErrUnsupportedNetwork, network)
 	}
 }
 
+// dialOptions returns the transport credentials, and the bearer token when
+// one is configured
+func (c *Client) dialOptions() []grpc.DialOption {
+	creds := insecure.NewCredentials()
+	if c.tlsConfig != nil {
+		creds = credentials.NewTLS(c.tlsConfig)
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if c.authToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{
+			token:      c.authToken,
+			requireTLS: c.tlsConfig != nil,
+		}))
+	}
+	return opts
+}
+
 // parseServerAddr parses a server address string and returns network and address.
 // Similar to server's parseListenAddr but for client connections.
 func (c *Client) parseServerAddr(serverAddr string) (network string, address string, err error) {
@@ -19,6 +19,8 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 //go:embed testdata/test_config.toml
@@ -230,6 +232,25 @@ func TestConfigTransactions_Integration(t *testing.T) {
 		)
 	})
 
+	// Test GetTransactionLogs with specific ID
+	t.Run("GetTransactionLogs_SpecificID", func(t *testing.T) {
+		require.NotEmpty(t, transactionID, "Transaction ID should be available from previous test")
+
+		resp, err := client.GetTransactionLogs(ctx, transactionID, 0)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.GetRecords(), "Transaction should have collected logs")
+		assert.Equal(t, int32(len(resp.GetRecords())), resp.GetTotalRecords())
+		assert.False(t, resp.GetTruncated())
+
+		resp, err = client.GetTransactionLogs(ctx, transactionID, 1)
+		require.NoError(t, err)
+		assert.Len(t, resp.GetRecords(), 1, "Should respect the record limit")
+
+		_, err = client.GetTransactionLogs(ctx, "00000000-0000-0000-0000-000000000000", 0)
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
 	// Test ListConfigTransactions with filters
 	t.Run("ListConfigTransactions_WithFilters", func(t *testing.T) {
 		// Test with page size
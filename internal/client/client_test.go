@@ -11,6 +11,7 @@ import (
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/marshaller"
 	"github.com/atlanticdynamic/firelynx/internal/config/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -33,6 +34,10 @@ func (m *MockLoader) GetProtoConfig() *pb.ServerConfig {
 	return args.Get(0).(*pb.ServerConfig)
 }
 
+func (m *MockLoader) Warnings() []error {
+	return nil
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name string
@@ -113,12 +118,8 @@ func TestSaveConfig(t *testing.T) {
 	content, err := os.ReadFile(outputPath)
 	require.NoError(t, err)
 
-	// Just check for some expected content (uppercase V in Version)
-	assert.Contains(t, string(content), "Version")
-
-	// Check for basic config structure
-	contentStr := string(content)
-	assert.Contains(t, contentStr, "Version")
+	// The file is written with the field names the config loader reads
+	assert.Contains(t, string(content), "version = 'v1'")
 }
 
 func TestConnect(t *testing.T) {
@@ -228,6 +229,38 @@ func TestFormatConfig(t *testing.T) {
 	}
 }
 
+func TestMarshalConfig(t *testing.T) {
+	client := New(Config{
+		ServerAddr: "localhost:8080",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	v := version.Version
+	testConfig := &pb.ServerConfig{Version: &v}
+
+	t.Run("yaml", func(t *testing.T) {
+		result, err := client.MarshalConfig(testConfig, marshaller.FormatYAML)
+		require.NoError(t, err)
+		assert.Equal(t, "version: v1\n", string(result))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		result, err := client.MarshalConfig(testConfig, marshaller.FormatJSON)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"version": "v1"}`, string(result))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := client.MarshalConfig(testConfig, "xml")
+		require.ErrorIs(t, err, marshaller.ErrUnsupportedFormat)
+	})
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := client.MarshalConfig(nil, marshaller.FormatJSON)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+}
+
 func TestGetConfig(t *testing.T) {
 	// Create a client with an invalid address to force connection error
 	client := New(Config{
@@ -258,6 +291,40 @@ func TestValidateConfig(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to validate configuration")
 }
 
+func TestValidateConfigIssues(t *testing.T) {
+	// Create a client with an invalid address to force connection error
+	client := New(Config{
+		ServerAddr: "invalid-host:-1",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	v := version.Version
+	testConfig := &pb.ServerConfig{Version: &v}
+
+	// This should fail at connection time
+	resp, err := client.ValidateConfigIssues(t.Context(), testConfig)
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "failed to validate configuration")
+}
+
+func TestDryRunConfig(t *testing.T) {
+	// Create a client with an invalid address to force connection error
+	client := New(Config{
+		ServerAddr: "invalid-host:-1",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	v := version.Version
+	testConfig := &pb.ServerConfig{Version: &v}
+
+	// This should fail at connection time
+	checks, err := client.DryRunConfig(t.Context(), testConfig)
+	require.Error(t, err)
+	assert.Nil(t, checks)
+	assert.Contains(t, err.Error(), "failed to dry run configuration")
+}
+
 func TestApplyConfigWithMockLoader(t *testing.T) {
 	v := version.Version
 	tests := []struct {
@@ -341,6 +408,18 @@ func TestGetConfigTransaction(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get configuration transaction")
 }
 
+func TestGetTransactionLogs(t *testing.T) {
+	client := New(Config{
+		ServerAddr: "invalid-host:-1",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	resp, err := client.GetTransactionLogs(t.Context(), "test-transaction-id", 10)
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "failed to get transaction logs")
+}
+
 func TestClearConfigTransactions(t *testing.T) {
 	client := New(Config{
 		ServerAddr: "invalid-host:-1",
@@ -352,6 +431,30 @@ func TestClearConfigTransactions(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to clear configuration transactions")
 }
 
+func TestGetServerStatus(t *testing.T) {
+	client := New(Config{
+		ServerAddr: "invalid-host:-1",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	status, err := client.GetServerStatus(t.Context())
+	require.Error(t, err)
+	assert.Nil(t, status)
+	assert.Contains(t, err.Error(), "failed to get server status")
+}
+
+func TestReloadFromFile(t *testing.T) {
+	client := New(Config{
+		ServerAddr: "invalid-host:-1",
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+
+	resp, err := client.ReloadFromFile(t.Context())
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Contains(t, err.Error(), "failed to reload config file")
+}
+
 func TestApplyConfigFromTransactionErrors(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Environment variables holding the client's credentials
+const (
+	EnvAuthToken = "FIRELYNX_AUTH_TOKEN"
+	EnvTLSCA     = "FIRELYNX_TLS_CA"
+	EnvTLSCert   = "FIRELYNX_TLS_CERT"
+	EnvTLSKey    = "FIRELYNX_TLS_KEY"
+)
+
+// bearerToken sends a static token in the authorization metadata of every call
+type bearerToken struct {
+	token      string
+	requireTLS bool
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (b bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + b.token}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials. The
+// token is allowed without TLS so it can be used over Unix sockets.
+func (b bearerToken) RequireTransportSecurity() bool {
+	return b.requireTLS
+}
+
+// NewTLSConfig creates a TLS config verifying the server against the CAs in
+// caFile, or the system roots when it is empty. A certificate and key are
+// presented to servers requiring client certificates.
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, errors.New("both a client certificate and key are required")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// LoadEnvCredentials sets the bearer token and TLS config from the
+// FIRELYNX_AUTH_TOKEN, FIRELYNX_TLS_CA, FIRELYNX_TLS_CERT and FIRELYNX_TLS_KEY
+// environment variables. TLS is used when any of the TLS variables is set.
+func (cfg *Config) LoadEnvCredentials() error {
+	if token := os.Getenv(EnvAuthToken); token != "" {
+		cfg.AuthToken = token
+	}
+
+	caFile, certFile, keyFile := os.Getenv(EnvTLSCA), os.Getenv(EnvTLSCert), os.Getenv(EnvTLSKey)
+	if caFile == "" && certFile == "" && keyFile == "" {
+		return nil
+	}
+
+	tlsConfig, err := NewTLSConfig(caFile, certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	cfg.TLSConfig = tlsConfig
+	return nil
+}
@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// tokenConfigServer answers GetConfig when the call carries the expected
+// bearer token
+type tokenConfigServer struct {
+	pb.UnimplementedConfigServiceServer
+	token string
+}
+
+func (s *tokenConfigServer) GetConfig(ctx context.Context, _ *pb.GetConfigRequest) (*pb.GetConfigResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("authorization"); len(values) == 0 || values[0] != "Bearer "+s.token {
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token")
+	}
+	return &pb.GetConfigResponse{Config: &pb.ServerConfig{Version: proto.String("v1")}}, nil
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+
+	t.Run("system roots", func(t *testing.T) {
+		cfg, err := NewTLSConfig("", "", "")
+		require.NoError(t, err)
+		assert.Nil(t, cfg.RootCAs)
+		assert.Empty(t, cfg.Certificates)
+	})
+
+	t.Run("CA and client certificate", func(t *testing.T) {
+		cfg, err := NewTLSConfig(certs.CAFile, certs.ClientCertFile, certs.ClientKeyFile)
+		require.NoError(t, err)
+		assert.NotNil(t, cfg.RootCAs)
+		assert.Len(t, cfg.Certificates, 1)
+	})
+
+	t.Run("certificate without key", func(t *testing.T) {
+		_, err := NewTLSConfig(certs.CAFile, certs.ClientCertFile, "")
+		require.ErrorContains(t, err, "both a client certificate and key are required")
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		_, err := NewTLSConfig(certs.ClientKeyFile, "", "")
+		require.ErrorContains(t, err, "no certificates found in CA file")
+
+		_, err = NewTLSConfig("/nonexistent/ca.pem", "", "")
+		require.ErrorContains(t, err, "failed to read CA file")
+	})
+}
+
+func TestConfig_LoadEnvCredentials(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+
+	t.Run("no environment", func(t *testing.T) {
+		t.Setenv(EnvAuthToken, "")
+		t.Setenv(EnvTLSCA, "")
+		t.Setenv(EnvTLSCert, "")
+		t.Setenv(EnvTLSKey, "")
+
+		cfg := Config{}
+		require.NoError(t, cfg.LoadEnvCredentials())
+		assert.Empty(t, cfg.AuthToken)
+		assert.Nil(t, cfg.TLSConfig)
+	})
+
+	t.Run("token and TLS", func(t *testing.T) {
+		t.Setenv(EnvAuthToken, "s3cret")
+		t.Setenv(EnvTLSCA, certs.CAFile)
+		t.Setenv(EnvTLSCert, certs.ClientCertFile)
+		t.Setenv(EnvTLSKey, certs.ClientKeyFile)
+
+		cfg := Config{}
+		require.NoError(t, cfg.LoadEnvCredentials())
+		assert.Equal(t, "s3cret", cfg.AuthToken)
+		require.NotNil(t, cfg.TLSConfig)
+		assert.Len(t, cfg.TLSConfig.Certificates, 1)
+	})
+
+	t.Run("invalid TLS files", func(t *testing.T) {
+		t.Setenv(EnvTLSCA, "/nonexistent/ca.pem")
+
+		cfg := Config{}
+		require.Error(t, cfg.LoadEnvCredentials())
+	})
+}
+
+func TestClient_TLSAndToken(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+	serverCert, err := tls.LoadX509KeyPair(certs.ServerCertFile, certs.ServerKeyFile)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		MinVersion:   tls.VersionTLS12,
+	})))
+	pb.RegisterConfigServiceServer(srv, &tokenConfigServer{token: "s3cret"})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	tlsConfig, err := NewTLSConfig(certs.CAFile, "", "")
+	require.NoError(t, err)
+
+	newClient := func(token string) *Client {
+		return New(Config{
+			Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+			ServerAddr: lis.Addr().String(),
+			TLSConfig:  tlsConfig,
+			AuthToken:  token,
+		})
+	}
+
+	cfg, err := newClient("s3cret").GetConfig(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "v1", cfg.GetVersion())
+
+	_, err = newClient("wrong").GetConfig(t.Context())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestBearerToken(t *testing.T) {
+	md, err := bearerToken{token: "s3cret"}.GetRequestMetadata(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer s3cret"}, md)
+
+	assert.False(t, bearerToken{token: "s3cret"}.RequireTransportSecurity())
+	assert.True(t, bearerToken{token: "s3cret", requireTLS: true}.RequireTransportSecurity())
+}
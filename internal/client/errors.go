@@ -9,4 +9,5 @@ var (
 	ErrConnectionFailed     = errors.New("failed to connect to server")
 	ErrConfigRejected       = errors.New("server rejected configuration")
 	ErrNilConfig            = errors.New("config is nil")
+	ErrReflectionDisabled   = errors.New("server reflection is not enabled")
 )
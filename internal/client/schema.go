@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// reflectionServicePrefix names the reflection services, which are left out
+// of the schema
+const reflectionServicePrefix = "grpc.reflection."
+
+// Schema describes the services and message types a server exposes
+type Schema struct {
+	Services []Service `json:"services"`
+	Messages []string  `json:"messages"`
+}
+
+// Service describes a gRPC service and its RPCs
+type Service struct {
+	Name    string   `json:"name"`
+	Methods []Method `json:"methods"`
+}
+
+// Method describes an RPC of a service
+type Method struct {
+	Name            string `json:"name"`
+	InputType       string `json:"inputType"`
+	OutputType      string `json:"outputType"`
+	ClientStreaming bool   `json:"clientStreaming,omitempty"`
+	ServerStreaming bool   `json:"serverStreaming,omitempty"`
+}
+
+// String returns the RPC in proto syntax
+func (m Method) String() string {
+	input, output := m.InputType, m.OutputType
+	if m.ClientStreaming {
+		input = "stream " + input
+	}
+	if m.ServerStreaming {
+		output = "stream " + output
+	}
+	return fmt.Sprintf("rpc %s(%s) returns (%s)", m.Name, input, output)
+}
+
+// GetSchema lists the server's services, their RPCs and the message types
+// they use through gRPC server reflection. A server started without
+// reflection returns ErrReflectionDisabled.
+func (c *Client) GetSchema(ctx context.Context) (*Schema, error) {
+	c.logger.Debug("Getting schema from server", "server", c.serverAddr)
+
+	conn, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			c.logger.Error("Failed to close connection", "error", err)
+		}
+	}()
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, reflectionError(err)
+	}
+	defer func() {
+		if err := stream.CloseSend(); err != nil {
+			c.logger.Error("Failed to close reflection stream", "error", err)
+		}
+	}()
+
+	resp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var serviceNames []string
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		if !strings.HasPrefix(svc.GetName(), reflectionServicePrefix) {
+			serviceNames = append(serviceNames, svc.GetName())
+		}
+	}
+	slices.Sort(serviceNames)
+
+	// The server sends each file once per stream, along with the files it
+	// depends on, so the files accumulate across the symbol lookups
+	fileSet := &descriptorpb.FileDescriptorSet{}
+	for _, name := range serviceNames {
+		resp, err := reflectionRequest(stream, &rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: name},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, raw := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return nil, fmt.Errorf("failed to decode file descriptor: %w", err)
+			}
+			fileSet.File = append(fileSet.File, fd)
+		}
+	}
+
+	files, err := protodesc.NewFiles(fileSet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors: %w", err)
+	}
+
+	return buildSchema(files, serviceNames)
+}
+
+// reflectionRequest sends a request on the reflection stream and returns the
+// server's response, turning an error response into an error
+func reflectionRequest(
+	stream rpb.ServerReflection_ServerReflectionInfoClient,
+	req *rpb.ServerReflectionRequest,
+) (*rpb.ServerReflectionResponse, error) {
+	if err := stream.Send(req); err != nil {
+		if errors.Is(err, io.EOF) {
+			// The server closed the stream; Recv returns the reason
+			_, err = stream.Recv()
+		}
+		return nil, reflectionError(err)
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, reflectionError(err)
+	}
+	if errResp := resp.GetErrorResponse(); errResp != nil {
+		return nil, fmt.Errorf("reflection request failed: %s", errResp.GetErrorMessage())
+	}
+	return resp, nil
+}
+
+// reflectionError reports an Unimplemented status as ErrReflectionDisabled
+func reflectionError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: %w", ErrReflectionDisabled, err)
+	}
+	return fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+}
+
+// buildSchema describes the named services and every message type reachable
+// from their RPCs
+func buildSchema(files *protoregistry.Files, serviceNames []string) (*Schema, error) {
+	schema := &Schema{}
+	messages := make(map[protoreflect.FullName]struct{})
+
+	for _, name := range serviceNames {
+		desc, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", name, err)
+		}
+		sd, ok := desc.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a service", name)
+		}
+
+		svc := Service{Name: name}
+		methods := sd.Methods()
+		for i := range methods.Len() {
+			md := methods.Get(i)
+			svc.Methods = append(svc.Methods, Method{
+				Name:            string(md.Name()),
+				InputType:       string(md.Input().FullName()),
+				OutputType:      string(md.Output().FullName()),
+				ClientStreaming: md.IsStreamingClient(),
+				ServerStreaming: md.IsStreamingServer(),
+			})
+			collectMessages(md.Input(), messages)
+			collectMessages(md.Output(), messages)
+		}
+		schema.Services = append(schema.Services, svc)
+	}
+
+	for name := range messages {
+		schema.Messages = append(schema.Messages, string(name))
+	}
+	slices.Sort(schema.Messages)
+
+	return schema, nil
+}
+
+// collectMessages adds md and the message types of its fields, recursively.
+// Map entries are skipped, but their values are followed.
+func collectMessages(md protoreflect.MessageDescriptor, seen map[protoreflect.FullName]struct{}) {
+	if _, ok := seen[md.FullName()]; ok {
+		return
+	}
+	if !md.IsMapEntry() {
+		seen[md.FullName()] = struct{}{}
+	}
+
+	fields := md.Fields()
+	for i := range fields.Len() {
+		if fieldMsg := fields.Get(i).Message(); fieldMsg != nil {
+			collectMessages(fieldMsg, seen)
+		}
+	}
+}
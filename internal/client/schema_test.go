@@ -0,0 +1,84 @@
+package client
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// startSchemaServer serves an unimplemented config service on a random port
+func startSchemaServer(t *testing.T, withReflection bool) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	srv := grpc.NewServer()
+	pb.RegisterConfigServiceServer(srv, pb.UnimplementedConfigServiceServer{})
+	if withReflection {
+		reflection.Register(srv)
+	}
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+func TestGetSchema(t *testing.T) {
+	c := New(Config{
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ServerAddr: startSchemaServer(t, true),
+	})
+
+	schema, err := c.GetSchema(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, schema.Services, 1, "reflection services are left out")
+	svc := schema.Services[0]
+	assert.Equal(t, "settings.v1alpha1.ConfigService", svc.Name)
+	assert.Contains(t, svc.Methods, Method{
+		Name:       "GetConfig",
+		InputType:  "settings.v1alpha1.GetConfigRequest",
+		OutputType: "settings.v1alpha1.GetConfigResponse",
+	})
+	assert.Contains(t, svc.Methods, Method{
+		Name:            "WatchConfigTransaction",
+		InputType:       "settings.v1alpha1.WatchConfigTransactionRequest",
+		OutputType:      "settings.v1alpha1.WatchConfigTransactionResponse",
+		ServerStreaming: true,
+	})
+
+	// Messages nested in requests are reachable, map entries are not listed
+	assert.Contains(t, schema.Messages, "settings.v1alpha1.ServerConfig")
+	assert.Contains(t, schema.Messages, "settings.v1alpha1.AppDefinition")
+	assert.Contains(t, schema.Messages, "google.protobuf.Timestamp")
+	assert.IsIncreasing(t, schema.Messages)
+	for _, name := range schema.Messages {
+		assert.NotContains(t, name, "Entry", "map entry %s should be skipped", name)
+	}
+}
+
+func TestGetSchema_ReflectionDisabled(t *testing.T) {
+	c := New(Config{
+		Logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		ServerAddr: startSchemaServer(t, false),
+	})
+
+	_, err := c.GetSchema(t.Context())
+	require.ErrorIs(t, err, ErrReflectionDisabled)
+}
+
+func TestMethod_String(t *testing.T) {
+	m := Method{Name: "Watch", InputType: "pkg.Req", OutputType: "pkg.Resp"}
+	assert.Equal(t, "rpc Watch(pkg.Req) returns (pkg.Resp)", m.String())
+
+	m.ClientStreaming, m.ServerStreaming = true, true
+	assert.Equal(t, "rpc Watch(stream pkg.Req) returns (stream pkg.Resp)", m.String())
+}
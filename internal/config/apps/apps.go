@@ -17,8 +17,12 @@ import (
 	"errors"
 	"fmt"
 	"iter"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
 	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 )
@@ -44,15 +48,17 @@ func (a App) Validate() error {
 
 	// Validate ID
 	if err := validation.ValidateID(a.ID, "app ID"); err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.ForField("id", err))
 	}
 
 	// Config validation
 	if a.Config == nil {
-		errs = append(errs, fmt.Errorf("%w: app '%s'", ErrMissingAppConfig, a.ID))
+		errs = append(errs, validation.ForField("config",
+			fmt.Errorf("%w: app '%s'", ErrMissingAppConfig, a.ID)))
 	} else {
 		if err := a.Config.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("config for app '%s': %w", a.ID, err))
+			errs = append(errs, validation.ForField("config",
+				fmt.Errorf("config for app '%s': %w", a.ID, err)))
 		}
 	}
 
@@ -103,7 +109,22 @@ func (ac *AppCollection) All() iter.Seq[App] {
 	}
 }
 
-// Validate checks that app configurations are valid
+// mcpToolAppTypes are the types of the apps that can serve as MCP tools:
+// those whose server apps implement an MCP tool provider interface.
+var mcpToolAppTypes = map[string]bool{
+	"calculation": true,
+	"echo":        true,
+	"fileread":    true,
+	"script":      true,
+}
+
+// ServesMCPTools reports whether apps of the given type can serve as MCP tools
+func ServesMCPTools(appType string) bool {
+	return mcpToolAppTypes[appType]
+}
+
+// Validate checks that app configurations are valid. Duplicate IDs are
+// reported by the config's validation, which sees every declaration.
 func (ac *AppCollection) Validate() error {
 	var errs []error
 	appIDs := make(map[string]bool)
@@ -112,20 +133,17 @@ func (ac *AppCollection) Validate() error {
 	for i, app := range ac.apps {
 		// Validate the app ID format
 		if err := validation.ValidateID(app.ID, "app ID"); err != nil {
-			errs = append(errs, err)
+			errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID,
+				validation.ForField("id", err)))
 			continue
 		}
 
-		// Check for duplicate IDs
-		if appIDs[app.ID] {
-			errs = append(errs, fmt.Errorf("%w: app ID '%s'", ErrDuplicateID, app.ID))
-			continue
-		}
 		appIDs[app.ID] = true
 
 		// Validate the app itself
 		if err := app.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("app at index %d: %w", i, err))
+			errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID,
+				fmt.Errorf("app at index %d: %w", i, err)))
 		}
 	}
 
@@ -135,21 +153,59 @@ func (ac *AppCollection) Validate() error {
 			// Validate all referenced script apps exist
 			for _, scriptAppID := range comp.ScriptAppIDs {
 				if err := validation.ValidateID(scriptAppID, "script app ID"); err != nil {
-					errs = append(errs, fmt.Errorf("in app '%s' composite script reference: %w", app.ID, err))
+					errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID,
+						validation.ForField("script_app_ids",
+							fmt.Errorf("in app '%s' composite script reference: %w", app.ID, err))))
 					continue
 				}
 
 				if !appIDs[scriptAppID] {
-					errs = append(errs, fmt.Errorf("%w: app '%s' references script app ID '%s'",
-						ErrAppNotFound, app.ID, scriptAppID))
+					errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID,
+						validation.ForField("script_app_ids", fmt.Errorf("%w: app '%s' references script app ID '%s'",
+							ErrAppNotFound, app.ID, scriptAppID))))
 				}
 			}
 		}
 	}
 
+	// Third pass: Validate the apps MCP tools reference
+	for _, app := range ac.apps {
+		if mcpApp, isMCP := app.Config.(*mcpserver.App); isMCP {
+			errs = append(errs, ac.validateMCPToolReferences(app.ID, mcpApp)...)
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
+// validateMCPToolReferences checks that each tool of an MCP server app
+// references an existing app of a type that can serve as an MCP tool, naming
+// the type otherwise. Empty app IDs are reported by the tool's validation.
+func (ac *AppCollection) validateMCPToolReferences(appID string, mcpApp *mcpserver.App) []error {
+	var errs []error
+	for i, tool := range mcpApp.Tools {
+		if tool.AppID == "" {
+			continue
+		}
+		toolApp, found := ac.FindByID(tool.AppID)
+		switch {
+		case !found:
+			errs = append(errs, validation.ForComponent(validation.ComponentApp, appID,
+				validation.ForField("tools", fmt.Errorf("%w: tool %d of MCP app '%s' references app ID '%s'",
+					ErrAppNotFound, i, appID, tool.AppID))))
+		case toolApp.Config == nil:
+			// Reported by the app's validation
+		case !ServesMCPTools(toolApp.Config.Type()):
+			errs = append(errs, validation.ForComponent(validation.ComponentApp, appID,
+				validation.ForField("tools", fmt.Errorf(
+					"%w: tool %d of MCP app '%s' references app '%s' of type '%s', which cannot serve as an MCP tool (supported types: %s)",
+					ErrInvalidAppType, i, appID, tool.AppID, toolApp.Config.Type(),
+					strings.Join(slices.Sorted(maps.Keys(mcpToolAppTypes)), ", ")))))
+		}
+	}
+	return errs
+}
+
 // ValidateRouteAppReferences ensures all routes reference valid apps
 func (ac *AppCollection) ValidateRouteAppReferences(routes []struct{ AppID string }) error {
 	// Build map of app IDs for quick lookup
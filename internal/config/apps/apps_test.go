@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
@@ -150,6 +152,7 @@ func TestAppCollectionValidate(t *testing.T) {
 			expectError: false,
 		},
 		{
+			// Reported by the config's validation, see config.validateIDs
 			name: "Duplicate IDs",
 			apps: NewAppCollection(
 				App{
@@ -171,7 +174,7 @@ func TestAppCollectionValidate(t *testing.T) {
 					}(),
 				},
 			),
-			expectError: true,
+			expectError: false,
 		},
 		{
 			name: "Composite with valid reference",
@@ -329,6 +332,67 @@ func TestAppCollectionValidate(t *testing.T) {
 	}
 }
 
+func TestAppCollectionValidate_MCPToolReferences(t *testing.T) {
+	t.Parallel()
+
+	script := App{
+		ID: "script1",
+		Config: func() *scripts.AppScript {
+			app := scripts.NewAppScript("script1")
+			app.Evaluator = &evaluators.RisorEvaluator{Code: validRisorCode42}
+			return app
+		}(),
+	}
+	comp := App{
+		ID: "composite1",
+		Config: &composite.CompositeScript{
+			ScriptAppIDs: []string{"script1"},
+			StaticData:   &staticdata.StaticData{Data: map[string]any{"key": "value"}},
+		},
+	}
+	mcpApp := func(toolAppIDs ...string) App {
+		app := mcpserver.NewApp("mcp")
+		for _, id := range toolAppIDs {
+			app.Tools = append(app.Tools, mcpserver.Tool{AppID: id})
+		}
+		return App{ID: "mcp", Config: app}
+	}
+
+	t.Run("tool apps", func(t *testing.T) {
+		t.Parallel()
+		echoApp := echo.New("echo1")
+		echoApp.Response = "hello"
+		apps := NewAppCollection(mcpApp("script1", "echo1"), script, App{ID: "echo1", Config: echoApp})
+		require.NoError(t, apps.Validate())
+	})
+
+	t.Run("unknown app", func(t *testing.T) {
+		t.Parallel()
+		err := NewAppCollection(mcpApp("ghost"), script).Validate()
+		require.ErrorIs(t, err, ErrAppNotFound)
+		assert.Contains(t, err.Error(), "tool 0 of MCP app 'mcp' references app ID 'ghost'")
+	})
+
+	t.Run("app type that cannot serve as a tool", func(t *testing.T) {
+		t.Parallel()
+		err := NewAppCollection(mcpApp("script1", "composite1"), script, comp).Validate()
+		require.ErrorIs(t, err, ErrInvalidAppType)
+		assert.Contains(t, err.Error(), "tool 1 of MCP app 'mcp' references app 'composite1' of type 'composite_script'")
+		assert.Contains(t, err.Error(), "supported types: calculation, echo, fileread, script")
+	})
+}
+
+func TestServesMCPTools(t *testing.T) {
+	t.Parallel()
+
+	for _, appType := range []string{"calculation", "echo", "fileread", "script"} {
+		assert.True(t, ServesMCPTools(appType), appType)
+	}
+	for _, appType := range []string{"composite_script", "mcp", "openapi", "template", ""} {
+		assert.False(t, ServesMCPTools(appType), appType)
+	}
+}
+
 func TestAppCollectionFindByID(t *testing.T) {
 	t.Parallel()
 
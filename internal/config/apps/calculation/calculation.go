@@ -3,15 +3,26 @@ package calculation
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 )
 
+// ConstantsDataKey is the static data key holding a table of named numbers,
+// which requests may use as operands by name
+const ConstantsDataKey = "constants"
+
 // App contains calculation app-specific configuration.
 type App struct {
 	ID string `env_interpolation:"no"`
+
+	// StaticData is merged with the static data of the app's routes. Its
+	// "constants" key holds the constants of the app.
+	StaticData *staticdata.StaticData `env_interpolation:"no"`
 }
 
 // New creates a new calculation app configuration with the specified ID.
@@ -32,7 +43,67 @@ func (a *App) Validate() error {
 		return fmt.Errorf("%w: calculation app ID", errz.ErrMissingRequiredField)
 	}
 
-	return nil
+	if a.StaticData != nil {
+		if err := a.StaticData.Validate(); err != nil {
+			return fmt.Errorf("calculation static data: %w", err)
+		}
+	}
+
+	_, err := a.Constants()
+	return err
+}
+
+// Constants returns the constants of the static data's "constants" table,
+// nil without one. It returns an error when the table isn't a table of
+// finite numbers with non-empty names.
+func (a *App) Constants() (map[string]float64, error) {
+	if a.StaticData == nil {
+		return nil, nil
+	}
+	raw, ok := a.StaticData.Data[ConstantsDataKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	table, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: calculation static data %q must be a table, got %T",
+			errz.ErrInvalidValue, ConstantsDataKey, raw)
+	}
+
+	constants := make(map[string]float64, len(table))
+	for name, value := range table {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%w: calculation constant name must not be empty", errz.ErrInvalidValue)
+		}
+		number, ok := toFloat(value)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, fmt.Errorf("%w: calculation constant %q must be a finite number, got %v",
+				errz.ErrInvalidValue, name, value)
+		}
+		constants[name] = number
+	}
+	return constants, nil
+}
+
+// toFloat returns the value of a static data number
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// Copy returns a copy of the app with the given static data
+func (a *App) Copy(staticData *staticdata.StaticData) *App {
+	clone := *a
+	clone.StaticData = staticData
+	return &clone
 }
 
 // String returns a string representation of the calculation app.
@@ -42,5 +113,9 @@ func (a *App) String() string {
 
 // ToTree returns a tree representation of the calculation app.
 func (a *App) ToTree() *fancy.ComponentTree {
-	return fancy.NewComponentTree("Calculation App")
+	tree := fancy.NewComponentTree("Calculation App")
+	if constants, err := a.Constants(); err == nil && len(constants) > 0 {
+		tree.AddChild(fmt.Sprintf("Constants: %d", len(constants)))
+	}
+	return tree
 }
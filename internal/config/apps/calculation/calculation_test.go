@@ -3,6 +3,7 @@ package calculation
 import (
 	"testing"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -15,6 +16,20 @@ func TestApp_Validate(t *testing.T) {
 	}{
 		{name: "valid", app: &App{ID: "calc"}},
 		{name: "missing id", app: &App{}, wantErr: "missing required field: calculation app ID"},
+		{
+			name: "constants",
+			app:  withConstants(map[string]any{"vat": 0.2, "fee": int64(3)}),
+		},
+		{
+			name:    "constants not a table",
+			app:     withConstants("vat"),
+			wantErr: `calculation static data "constants" must be a table`,
+		},
+		{
+			name:    "constant not a number",
+			app:     withConstants(map[string]any{"vat": "20%"}),
+			wantErr: `calculation constant "vat" must be a finite number`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -30,6 +45,32 @@ func TestApp_Validate(t *testing.T) {
 	}
 }
 
+func TestApp_Constants(t *testing.T) {
+	constants, err := withConstants(map[string]any{"vat": 0.2, "fee": int64(3)}).Constants()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"vat": 0.2, "fee": 3}, constants)
+
+	constants, err = (&App{ID: "calc"}).Constants()
+	require.NoError(t, err)
+	assert.Nil(t, constants)
+
+	app := &App{ID: "calc"}
+	clone := app.Copy(&staticdata.StaticData{Data: map[string]any{ConstantsDataKey: map[string]any{"pi": 3.14}}})
+	constants, err = clone.Constants()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"pi": 3.14}, constants)
+	assert.Nil(t, app.StaticData, "the app is unchanged")
+}
+
+// withConstants returns a calculation app with the constants in its static
+// data
+func withConstants(constants any) *App {
+	return &App{
+		ID:         "calc",
+		StaticData: &staticdata.StaticData{Data: map[string]any{ConstantsDataKey: constants}},
+	}
+}
+
 func TestApp_Type(t *testing.T) {
 	assert.Equal(t, "calculation", (&App{ID: "calc"}).Type())
 }
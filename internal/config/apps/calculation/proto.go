@@ -1,16 +1,33 @@
 package calculation
 
-import pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+import (
+	"fmt"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+)
 
 // FromProto creates an App configuration from its protocol buffer representation.
-func FromProto(id string, proto *pbApps.CalculationApp) *App {
+func FromProto(id string, proto *pbApps.CalculationApp) (*App, error) {
 	if proto == nil {
-		return nil
+		return nil, nil
+	}
+
+	staticData, err := staticdata.FromProto(proto.StaticData)
+	if err != nil {
+		return nil, fmt.Errorf("calculation app static data: %w", err)
 	}
-	return New(id)
+
+	app := New(id)
+	app.StaticData = staticData
+	return app, nil
 }
 
 // ToProto converts the App configuration to its protocol buffer representation.
 func (a *App) ToProto() any {
-	return &pbApps.CalculationApp{}
+	proto := &pbApps.CalculationApp{}
+	if a.StaticData != nil {
+		proto.StaticData = a.StaticData.ToProto()
+	}
+	return proto
 }
@@ -4,20 +4,44 @@ import (
 	"testing"
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestProtoRoundTrip(t *testing.T) {
-	app := FromProto("calc", &pbApps.CalculationApp{})
+	app, err := FromProto("calc", &pbApps.CalculationApp{})
+	require.NoError(t, err)
 	require.NotNil(t, app)
 	assert.Equal(t, "calc", app.ID)
+	assert.Nil(t, app.StaticData)
 
 	protoApp, ok := app.ToProto().(*pbApps.CalculationApp)
 	require.True(t, ok)
 	assert.NotNil(t, protoApp)
+	assert.Nil(t, protoApp.GetStaticData())
+}
+
+func TestProtoRoundTrip_StaticData(t *testing.T) {
+	constants, err := structpb.NewValue(map[string]any{"vat": 0.2})
+	require.NoError(t, err)
+
+	app, err := FromProto("calc", &pbApps.CalculationApp{
+		StaticData: &pbData.StaticData{Data: map[string]*structpb.Value{ConstantsDataKey: constants}},
+	})
+	require.NoError(t, err)
+	got, err := app.Constants()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"vat": 0.2}, got)
+
+	protoApp, ok := app.ToProto().(*pbApps.CalculationApp)
+	require.True(t, ok)
+	assert.NotNil(t, protoApp.GetStaticData().GetData()[ConstantsDataKey].GetStructValue())
 }
 
 func TestFromProto_Nil(t *testing.T) {
-	assert.Nil(t, FromProto("calc", nil))
+	app, err := FromProto("calc", nil)
+	require.NoError(t, err)
+	assert.Nil(t, app)
 }
@@ -15,6 +15,9 @@ var (
 	// ErrEmptyScriptID indicates that an empty script ID was provided.
 	ErrEmptyScriptID = fmt.Errorf("%w: empty script ID", ErrAppCompositeScript)
 
+	// ErrNegativeStepTimeout indicates that a negative step timeout was provided.
+	ErrNegativeStepTimeout = fmt.Errorf("%w: negative step timeout", ErrAppCompositeScript)
+
 	// ErrInvalidStaticData indicates that the provided static data is invalid.
 	ErrInvalidStaticData = fmt.Errorf("%w: invalid static data", ErrAppCompositeScript)
 
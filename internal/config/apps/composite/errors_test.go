@@ -11,6 +11,7 @@ func TestErrors(t *testing.T) {
 	// Test error wrapping relationships
 	require.ErrorIs(t, ErrNoScriptsSpecified, ErrAppCompositeScript)
 	require.ErrorIs(t, ErrEmptyScriptID, ErrAppCompositeScript)
+	require.ErrorIs(t, ErrNegativeStepTimeout, ErrAppCompositeScript)
 	require.ErrorIs(t, ErrInvalidStaticData, ErrAppCompositeScript)
 	require.ErrorIs(t, ErrProtoConversion, ErrAppCompositeScript)
 }
@@ -5,6 +5,7 @@ import (
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // FromProto creates a CompositeScript from its protocol buffer representation.
@@ -20,10 +21,14 @@ func FromProto(proto *pbApps.CompositeScriptApp) (*CompositeScript, error) {
 	}
 
 	// Create and return the CompositeScript
-	return &CompositeScript{
+	cs := &CompositeScript{
 		ScriptAppIDs: proto.ScriptAppIds,
 		StaticData:   sd,
-	}, nil
+	}
+	if proto.StepTimeout != nil {
+		cs.StepTimeout = proto.StepTimeout.AsDuration()
+	}
+	return cs, nil
 }
 
 // ToProto converts a CompositeScript to its protocol buffer representation.
@@ -37,6 +42,10 @@ func (s *CompositeScript) ToProto() any {
 		ScriptAppIds: s.ScriptAppIDs,
 	}
 
+	if s.StepTimeout > 0 {
+		proto.StepTimeout = durationpb.New(s.StepTimeout)
+	}
+
 	// Convert static data if present
 	if s.StaticData != nil {
 		proto.StaticData = s.StaticData.ToProto()
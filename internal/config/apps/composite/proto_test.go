@@ -2,6 +2,7 @@ package composite
 
 import (
 	"testing"
+	"time"
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
@@ -106,6 +107,7 @@ func TestCompositeScript_ToProto(t *testing.T) {
 		}
 		script := &CompositeScript{
 			ScriptAppIDs: []string{"script1", "script2"},
+			StepTimeout:  2 * time.Second,
 			StaticData:   staticData,
 		}
 		result := script.ToProto()
@@ -113,6 +115,11 @@ func TestCompositeScript_ToProto(t *testing.T) {
 		got, ok := result.(*pbApps.CompositeScriptApp)
 		assert.True(t, ok, "Expected *pbApps.CompositeScriptApp type")
 		assert.Equal(t, []string{"script1", "script2"}, got.ScriptAppIds)
+		assert.Equal(t, 2*time.Second, got.StepTimeout.AsDuration())
 		assert.NotNil(t, got.StaticData)
+
+		roundTrip, err := FromProto(got)
+		require.NoError(t, err)
+		assert.Equal(t, script.StepTimeout, roundTrip.StepTimeout)
 	})
 }
@@ -27,6 +27,10 @@ func (s *CompositeScript) String() string {
 		staticDataStr = "nil"
 	}
 
+	if s.StepTimeout > 0 {
+		return fmt.Sprintf("CompositeScript(scriptIds=%s, stepTimeout=%s, staticData=%s)",
+			scriptIDsStr, s.StepTimeout, staticDataStr)
+	}
 	return fmt.Sprintf("CompositeScript(scriptIds=%s, staticData=%s)",
 		scriptIDsStr, staticDataStr)
 }
@@ -45,6 +49,10 @@ func (s *CompositeScript) ToTree() *fancy.ComponentTree {
 		}
 	}
 
+	if s.StepTimeout > 0 {
+		tree.AddChild(fmt.Sprintf("Step Timeout: %s", s.StepTimeout))
+	}
+
 	// Add static data if present
 	if s.StaticData != nil && len(s.StaticData.Data) > 0 {
 		staticDataBranch := tree.AddBranch(
@@ -2,6 +2,8 @@
 package composite
 
 import (
+	"time"
+
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 )
 
@@ -10,6 +12,10 @@ type CompositeScript struct {
 	// ScriptAppIDs contains the IDs of script apps to run in sequence.
 	ScriptAppIDs []string `env_interpolation:"no"`
 
+	// StepTimeout limits the run time of each script. When zero, each script
+	// uses its own timeout.
+	StepTimeout time.Duration
+
 	// StaticData contains configuration values passed to all scripts.
 	StaticData *staticdata.StaticData `env_interpolation:"yes"`
 }
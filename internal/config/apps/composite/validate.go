@@ -21,6 +21,10 @@ func (s *CompositeScript) Validate() error {
 		}
 	}
 
+	if s.StepTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrNegativeStepTimeout, s.StepTimeout))
+	}
+
 	// Validate static data if present
 	if s.StaticData != nil {
 		if err := s.StaticData.Validate(); err != nil {
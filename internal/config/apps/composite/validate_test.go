@@ -2,6 +2,7 @@ package composite
 
 import (
 	"testing"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +61,15 @@ func TestCompositeScript_Validate(t *testing.T) {
 		assert.Contains(t, err.Error(), "empty script ID: at index 1")
 	})
 
+	t.Run("negative step timeout", func(t *testing.T) {
+		script := &CompositeScript{
+			ScriptAppIDs: validScriptIDs,
+			StepTimeout:  -time.Second,
+		}
+		err := script.Validate()
+		require.ErrorIs(t, err, ErrNegativeStepTimeout)
+	})
+
 	t.Run("invalid static data", func(t *testing.T) {
 		script := &CompositeScript{
 			ScriptAppIDs: validScriptIDs,
@@ -5,7 +5,13 @@
 package echo
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"mime"
+	"slices"
+	"strings"
+	"text/template"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
@@ -16,6 +22,18 @@ import (
 type EchoApp struct {
 	ID       string `env_interpolation:"no"`
 	Response string `env_interpolation:"yes"`
+
+	// Representations are response bodies keyed by media type. When set, the
+	// HTTP response is negotiated from the Accept header.
+	Representations map[string]string `env_interpolation:"yes"`
+
+	// Template parses the response and representations as text/template
+	// templates, executed per request with the request's values
+	Template bool `env_interpolation:"no"`
+
+	// Templates parsed by Validate when Template is set
+	responseTemplate        *template.Template
+	representationTemplates map[string]*template.Template
 }
 
 // New creates a new EchoApp configuration with the specified ID
@@ -40,9 +58,92 @@ func (e *EchoApp) Validate() error {
 		return fmt.Errorf("%w: echo app ID", errz.ErrMissingRequiredField)
 	}
 
-	if e.Response == "" {
+	if e.Response == "" && len(e.Representations) == 0 {
 		return fmt.Errorf("%w: echo app response", errz.ErrMissingRequiredField)
 	}
+
+	var errs []error
+	for _, mediaType := range slices.Sorted(maps.Keys(e.Representations)) {
+		if err := validateMediaType(mediaType); err != nil {
+			errs = append(errs, fmt.Errorf("%w: echo app representation %q: %w",
+				errz.ErrInvalidValue, mediaType, err))
+		}
+	}
+
+	if e.Template {
+		if err := e.parseTemplates(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseTemplates parses the response and representations as templates, so
+// template syntax errors fail validation rather than requests
+func (e *EchoApp) parseTemplates() error {
+	var errs []error
+
+	e.responseTemplate = nil
+	if e.Response != "" {
+		tmpl, err := parseTemplate(e.ID, e.Response)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: echo app response template: %w",
+				errz.ErrInvalidValue, err))
+		}
+		e.responseTemplate = tmpl
+	}
+
+	e.representationTemplates = make(map[string]*template.Template, len(e.Representations))
+	for _, mediaType := range e.MediaTypes() {
+		tmpl, err := parseTemplate(e.ID+" "+mediaType, e.Representations[mediaType])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: echo app representation %q template: %w",
+				errz.ErrInvalidValue, mediaType, err))
+			continue
+		}
+		e.representationTemplates[mediaType] = tmpl
+	}
+
+	return errors.Join(errs...)
+}
+
+// parseTemplate parses text as a template. Missing map keys render as the
+// zero value instead of "<no value>".
+func parseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Parse(text)
+}
+
+// ResponseTemplate returns the parsed response template, or nil when Template
+// isn't set or Validate hasn't run
+func (e *EchoApp) ResponseTemplate() *template.Template {
+	return e.responseTemplate
+}
+
+// RepresentationTemplates returns the parsed representation templates keyed
+// by media type, or nil when Template isn't set or Validate hasn't run
+func (e *EchoApp) RepresentationTemplates() map[string]*template.Template {
+	return e.representationTemplates
+}
+
+// MediaTypes returns the media types of the representations in sorted order,
+// which is the order of preference when the Accept header ranks them equally
+func (e *EchoApp) MediaTypes() []string {
+	return slices.Sorted(maps.Keys(e.Representations))
+}
+
+// validateMediaType checks that a representation key is a concrete media type
+func validateMediaType(mediaType string) error {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return err
+	}
+	typ, subtype, ok := strings.Cut(parsed, "/")
+	if !ok || typ == "" || subtype == "" {
+		return errors.New("media type must be of the form type/subtype")
+	}
+	if typ == "*" || subtype == "*" {
+		return errors.New("media type cannot contain wildcards")
+	}
 	return nil
 }
 
@@ -56,5 +157,11 @@ func (e *EchoApp) ToTree() *fancy.ComponentTree {
 	tree := fancy.NewComponentTree("Echo App")
 	tree.AddChild("Type: echo")
 	tree.AddChild(fmt.Sprintf("Response: %s", e.Response))
+	if e.Template {
+		tree.AddChild("Template: true")
+	}
+	if len(e.Representations) > 0 {
+		tree.AddChild(fmt.Sprintf("Representations: %s", strings.Join(e.MediaTypes(), ", ")))
+	}
 	return tree
 }
@@ -2,6 +2,8 @@ package echo
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -37,6 +39,47 @@ func TestEchoApp_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "missing required field: echo app response",
 		},
+		{
+			name: "representations without response",
+			echo: &EchoApp{ID: "test", Representations: map[string]string{
+				"application/json":          `{"ok":true}`,
+				"text/plain; charset=utf-8": "ok",
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid representation media type",
+			echo:    &EchoApp{ID: "test", Representations: map[string]string{"json": "{}"}},
+			wantErr: true,
+			errMsg:  `invalid value: echo app representation "json"`,
+		},
+		{
+			name:    "template parse error",
+			echo:    &EchoApp{ID: "test", Response: "{{.Method", Template: true},
+			wantErr: true,
+			errMsg:  "invalid value: echo app response template",
+		},
+		{
+			name: "representation template parse error",
+			echo: &EchoApp{
+				ID:              "test",
+				Representations: map[string]string{"text/plain": "{{end}}"},
+				Template:        true,
+			},
+			wantErr: true,
+			errMsg:  `echo app representation "text/plain" template`,
+		},
+		{
+			name:    "template syntax is literal without the template flag",
+			echo:    &EchoApp{ID: "test", Response: "{{.Method"},
+			wantErr: false,
+		},
+		{
+			name:    "wildcard representation media type",
+			echo:    &EchoApp{ID: "test", Representations: map[string]string{"text/*": "ok"}},
+			wantErr: true,
+			errMsg:  "media type cannot contain wildcards",
+		},
 	}
 
 	for _, tt := range tests {
@@ -117,3 +160,45 @@ func TestEchoApp_Interpolation(t *testing.T) {
 		})
 	}
 }
+
+func TestEchoApp_InterpolationSecretSources(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "greeting")
+	require.NoError(t, os.WriteFile(secretPath, []byte("Hello from a file\n"), 0o600))
+
+	echo := &EchoApp{ID: "test-id", Response: "${file:" + secretPath + "}"}
+	require.NoError(t, echo.Validate())
+	assert.Equal(t, "Hello from a file", echo.Response)
+
+	missing := &EchoApp{ID: "test-id", Response: "${file:" + secretPath + ".missing}"}
+	err := missing.Validate()
+	require.ErrorContains(t, err, "interpolation failed for echo app")
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEchoApp_InterpolationRequiredMarker(t *testing.T) {
+	echo := &EchoApp{ID: "test-id", Response: "${ECHO_GREETING_UNSET:?set ECHO_GREETING_UNSET to the greeting}"}
+	err := echo.Validate()
+	require.ErrorContains(t, err, "interpolation failed for echo app")
+	require.ErrorContains(t, err, "set ECHO_GREETING_UNSET to the greeting")
+}
+
+func TestEchoApp_ValidateParsesTemplates(t *testing.T) {
+	echo := &EchoApp{
+		ID:              "test",
+		Response:        "{{.Method}} {{.Path}}",
+		Representations: map[string]string{"application/json": `{"path":"{{.Path}}"}`},
+		Template:        true,
+	}
+	require.NoError(t, echo.Validate())
+
+	require.NotNil(t, echo.ResponseTemplate())
+	var b strings.Builder
+	require.NoError(t, echo.ResponseTemplate().Execute(&b, map[string]string{"Method": "GET", "Path": "/x"}))
+	assert.Equal(t, "GET /x", b.String())
+	assert.Contains(t, echo.RepresentationTemplates(), "application/json")
+
+	literal := &EchoApp{ID: "test", Response: "{{.Method}}"}
+	require.NoError(t, literal.Validate())
+	assert.Nil(t, literal.ResponseTemplate())
+	assert.Nil(t, literal.RepresentationTemplates())
+}
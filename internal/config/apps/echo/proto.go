@@ -4,6 +4,8 @@
 package echo
 
 import (
+	"maps"
+
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 )
 
@@ -14,12 +16,16 @@ func EchoFromProto(id string, proto *pbApps.EchoApp) *EchoApp {
 	}
 	app := New(id)
 	app.Response = proto.GetResponse()
+	app.Representations = maps.Clone(proto.GetRepresentations())
+	app.Template = proto.GetTemplate()
 	return app
 }
 
 // ToProto converts the EchoApp configuration to its protocol buffer representation
 func (e *EchoApp) ToProto() any {
 	return &pbApps.EchoApp{
-		Response: &e.Response,
+		Response:        &e.Response,
+		Representations: maps.Clone(e.Representations),
+		Template:        &e.Template,
 	}
 }
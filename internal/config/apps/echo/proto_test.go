@@ -5,6 +5,7 @@ import (
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestEchoFromProto(t *testing.T) {
@@ -20,6 +21,23 @@ func TestEchoFromProto(t *testing.T) {
 			proto: &pbApps.EchoApp{},
 			want:  &EchoApp{ID: "test-id"},
 		},
+		{
+			name: "with representations",
+			id:   "test-id",
+			proto: &pbApps.EchoApp{
+				Representations: map[string]string{"application/json": `{"ok":true}`},
+			},
+			want: &EchoApp{
+				ID:              "test-id",
+				Representations: map[string]string{"application/json": `{"ok":true}`},
+			},
+		},
+		{
+			name:  "with template",
+			id:    "test-id",
+			proto: &pbApps.EchoApp{Response: proto.String("{{.Path}}"), Template: proto.Bool(true)},
+			want:  &EchoApp{ID: "test-id", Response: "{{.Path}}", Template: true},
+		},
 		{
 			name:  "nil proto",
 			id:    "test-id",
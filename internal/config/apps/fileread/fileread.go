@@ -6,10 +6,15 @@ import (
 	"os"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 )
 
+// BaseDirectoryDataKey is the static data key that sets another base
+// directory than the app's BaseDirectory, e.g. one per route
+const BaseDirectoryDataKey = "base_directory"
+
 // App contains fileread app-specific configuration.
 type App struct {
 	ID            string `env_interpolation:"no"`
@@ -19,6 +24,10 @@ type App struct {
 	// symlinks that resolve outside BaseDirectory. Defaults to false; the
 	// sandbox blocks symlink escapes unless this is explicitly enabled.
 	AllowExternalSymlinks bool `env_interpolation:"no"`
+
+	// StaticData is merged with the static data of the app's routes. Its
+	// "base_directory" key replaces BaseDirectory.
+	StaticData *staticdata.StaticData `env_interpolation:"no"`
 }
 
 // New creates a new fileread app configuration with the specified ID.
@@ -39,21 +48,55 @@ func (a *App) Validate() error {
 		return fmt.Errorf("%w: fileread app ID", errz.ErrMissingRequiredField)
 	}
 
-	if a.BaseDirectory == "" {
-		return fmt.Errorf("%w: fileread base_directory", errz.ErrMissingRequiredField)
+	if a.StaticData != nil {
+		if err := a.StaticData.Validate(); err != nil {
+			return fmt.Errorf("fileread static data: %w", err)
+		}
+	}
+
+	return a.ValidateBaseDirectory()
+}
+
+// ValidateBaseDirectory checks that the directory the app reads from is
+// usable. Validate checks it for the app; the route copies of the app, which
+// may set another in their static data, are checked with this after the app
+// is validated.
+func (a *App) ValidateBaseDirectory() error {
+	baseDirectory := a.EffectiveBaseDirectory()
+	if baseDirectory == "" {
+		return fmt.Errorf("%w: fileread base_directory, or a %q key in its static data",
+			errz.ErrMissingRequiredField, BaseDirectoryDataKey)
 	}
 
-	info, err := os.Stat(a.BaseDirectory)
+	info, err := os.Stat(baseDirectory)
 	if err != nil {
 		return fmt.Errorf("fileread base_directory is unusable: %w", err)
 	}
 	if !info.IsDir() {
-		return fmt.Errorf("fileread base_directory is not a directory: %s", a.BaseDirectory)
+		return fmt.Errorf("fileread base_directory is not a directory: %s", baseDirectory)
 	}
 
 	return nil
 }
 
+// EffectiveBaseDirectory returns the directory the app reads from: the one
+// set by the static data's "base_directory" key, otherwise BaseDirectory
+func (a *App) EffectiveBaseDirectory() string {
+	if a.StaticData != nil {
+		if dir, ok := a.StaticData.Data[BaseDirectoryDataKey].(string); ok && dir != "" {
+			return dir
+		}
+	}
+	return a.BaseDirectory
+}
+
+// Copy returns a copy of the app with the given static data
+func (a *App) Copy(staticData *staticdata.StaticData) *App {
+	clone := *a
+	clone.StaticData = staticData
+	return &clone
+}
+
 // String returns a string representation of the fileread app.
 func (a *App) String() string {
 	if a.AllowExternalSymlinks {
@@ -73,5 +116,8 @@ func (a *App) ToTree() *fancy.ComponentTree {
 	if a.AllowExternalSymlinks {
 		tree.AddChild("AllowExternalSymlinks: true")
 	}
+	if a.StaticData != nil && len(a.StaticData.Data) > 0 {
+		tree.AddChild(fmt.Sprintf("Static Data (%d keys)", len(a.StaticData.Data)))
+	}
 	return tree
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -58,6 +59,47 @@ func TestApp_Validate_BaseDirIsSymlinkToFile(t *testing.T) {
 	assert.Contains(t, err.Error(), "fileread base_directory is not a directory")
 }
 
+func TestApp_StaticDataBaseDirectory(t *testing.T) {
+	baseDir := t.TempDir()
+	docsDir := t.TempDir()
+	withData := func(data map[string]any) *App {
+		return &App{ID: "files", BaseDirectory: baseDir, StaticData: &staticdata.StaticData{Data: data}}
+	}
+
+	t.Run("static data replaces base directory", func(t *testing.T) {
+		app := withData(map[string]any{BaseDirectoryDataKey: docsDir})
+		require.NoError(t, app.Validate())
+		assert.Equal(t, docsDir, app.EffectiveBaseDirectory())
+	})
+
+	t.Run("static data without the key", func(t *testing.T) {
+		app := withData(map[string]any{"title": "Docs"})
+		require.NoError(t, app.Validate())
+		assert.Equal(t, baseDir, app.EffectiveBaseDirectory())
+	})
+
+	t.Run("static data alone", func(t *testing.T) {
+		app := &App{ID: "files", StaticData: &staticdata.StaticData{
+			Data: map[string]any{BaseDirectoryDataKey: docsDir},
+		}}
+		require.NoError(t, app.Validate())
+	})
+
+	t.Run("unusable static data directory", func(t *testing.T) {
+		app := withData(map[string]any{BaseDirectoryDataKey: filepath.Join(docsDir, "missing")})
+		err := app.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fileread base_directory is unusable")
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		app := withData(nil)
+		clone := app.Copy(&staticdata.StaticData{Data: map[string]any{BaseDirectoryDataKey: docsDir}})
+		assert.Equal(t, docsDir, clone.EffectiveBaseDirectory())
+		assert.Equal(t, baseDir, app.EffectiveBaseDirectory(), "the app is unchanged")
+	})
+}
+
 func TestApp_Type(t *testing.T) {
 	assert.Equal(t, "fileread", (&App{ID: "f"}).Type())
 }
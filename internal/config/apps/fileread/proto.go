@@ -1,22 +1,38 @@
 package fileread
 
-import pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+import (
+	"fmt"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+)
 
 // FromProto creates an App configuration from its protocol buffer representation.
-func FromProto(id string, proto *pbApps.FileReadApp) *App {
+func FromProto(id string, proto *pbApps.FileReadApp) (*App, error) {
 	if proto == nil {
-		return nil
+		return nil, nil
+	}
+
+	staticData, err := staticdata.FromProto(proto.StaticData)
+	if err != nil {
+		return nil, fmt.Errorf("fileread app static data: %w", err)
 	}
+
 	app := New(id)
 	app.BaseDirectory = proto.GetBaseDirectory()
 	app.AllowExternalSymlinks = proto.GetAllowExternalSymlinks()
-	return app
+	app.StaticData = staticData
+	return app, nil
 }
 
 // ToProto converts the App configuration to its protocol buffer representation.
 func (a *App) ToProto() any {
-	return &pbApps.FileReadApp{
+	proto := &pbApps.FileReadApp{
 		BaseDirectory:         &a.BaseDirectory,
 		AllowExternalSymlinks: &a.AllowExternalSymlinks,
 	}
+	if a.StaticData != nil {
+		proto.StaticData = a.StaticData.ToProto()
+	}
+	return proto
 }
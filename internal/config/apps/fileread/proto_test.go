@@ -4,15 +4,18 @@ import (
 	"testing"
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestProtoRoundTrip(t *testing.T) {
-	app := FromProto("files", &pbApps.FileReadApp{
+	app, err := FromProto("files", &pbApps.FileReadApp{
 		BaseDirectory: proto.String("/tmp/files"),
 	})
+	require.NoError(t, err)
 	require.NotNil(t, app)
 	assert.Equal(t, "files", app.ID)
 	assert.Equal(t, "/tmp/files", app.BaseDirectory)
@@ -22,17 +25,21 @@ func TestProtoRoundTrip(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, "/tmp/files", protoApp.GetBaseDirectory())
 	assert.False(t, protoApp.GetAllowExternalSymlinks())
+	assert.Nil(t, protoApp.GetStaticData())
 }
 
 func TestFromProto_Nil(t *testing.T) {
-	assert.Nil(t, FromProto("f", nil))
+	app, err := FromProto("f", nil)
+	require.NoError(t, err)
+	assert.Nil(t, app)
 }
 
 func TestProtoRoundTrip_AllowExternalSymlinks(t *testing.T) {
-	app := FromProto("files", &pbApps.FileReadApp{
+	app, err := FromProto("files", &pbApps.FileReadApp{
 		BaseDirectory:         proto.String("/tmp/files"),
 		AllowExternalSymlinks: proto.Bool(true),
 	})
+	require.NoError(t, err)
 	require.NotNil(t, app)
 	assert.True(t, app.AllowExternalSymlinks)
 
@@ -40,3 +47,18 @@ func TestProtoRoundTrip_AllowExternalSymlinks(t *testing.T) {
 	require.True(t, ok)
 	assert.True(t, protoApp.GetAllowExternalSymlinks())
 }
+
+func TestProtoRoundTrip_StaticData(t *testing.T) {
+	app, err := FromProto("files", &pbApps.FileReadApp{
+		StaticData: &pbData.StaticData{
+			Data: map[string]*structpb.Value{BaseDirectoryDataKey: structpb.NewStringValue("/tmp/docs")},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, app.StaticData)
+	assert.Equal(t, "/tmp/docs", app.EffectiveBaseDirectory())
+
+	protoApp, ok := app.ToProto().(*pbApps.FileReadApp)
+	require.True(t, ok)
+	assert.Equal(t, "/tmp/docs", protoApp.GetStaticData().GetData()[BaseDirectoryDataKey].GetStringValue())
+}
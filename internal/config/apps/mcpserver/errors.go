@@ -11,4 +11,5 @@ var (
 	ErrMissingRequiredField = errz.ErrMissingRequiredField
 	ErrEmptyID              = errz.ErrEmptyID
 	ErrDuplicateID          = errz.ErrDuplicateID
+	ErrInvalidValue         = errz.ErrInvalidValue
 )
@@ -0,0 +1,157 @@
+package mcpserver
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+)
+
+// ToolMiddleware wraps each call of an MCP tool. The middlewares of a tool are
+// applied in config order: the first one sees the call first.
+type ToolMiddleware interface {
+	// Type returns the middleware type as named in the config
+	Type() string
+
+	// Validate checks the middleware settings
+	Validate() error
+
+	// String returns a short description for display
+	String() string
+
+	toProto() *pbApps.McpToolMiddleware
+}
+
+// MiddlewareLogging logs each tool call with its arguments, duration and
+// outcome
+type MiddlewareLogging struct {
+	// OmitArguments leaves the call arguments out of the log
+	OmitArguments bool `env_interpolation:"no"`
+}
+
+// Type returns the middleware type
+func (m *MiddlewareLogging) Type() string { return "logging" }
+
+// Validate has nothing to check, every setting is valid
+func (m *MiddlewareLogging) Validate() error { return nil }
+
+// String returns a short description for display
+func (m *MiddlewareLogging) String() string {
+	if m.OmitArguments {
+		return "logging (without arguments)"
+	}
+	return "logging"
+}
+
+func (m *MiddlewareLogging) toProto() *pbApps.McpToolMiddleware {
+	pb := &pbApps.McpToolLogging{}
+	if m.OmitArguments {
+		pb.OmitArguments = &m.OmitArguments
+	}
+	return &pbApps.McpToolMiddleware{Type: &pbApps.McpToolMiddleware_Logging{Logging: pb}}
+}
+
+// MiddlewareRateLimiting limits the rate of calls of a tool, shared by all of
+// its clients, with a token bucket
+type MiddlewareRateLimiting struct {
+	// CallsPerSecond is the sustained rate of calls
+	CallsPerSecond float64 `env_interpolation:"no"`
+
+	// Burst is the number of calls allowed above the sustained rate, see
+	// EffectiveBurst when unset
+	Burst int `env_interpolation:"no"`
+}
+
+// Type returns the middleware type
+func (m *MiddlewareRateLimiting) Type() string { return "rate_limiting" }
+
+// EffectiveBurst returns Burst, or the rate rounded up and at least 1 when
+// Burst is unset
+func (m *MiddlewareRateLimiting) EffectiveBurst() int {
+	if m.Burst > 0 {
+		return m.Burst
+	}
+	return max(1, int(math.Ceil(m.CallsPerSecond)))
+}
+
+// Validate checks the rate is positive and the burst isn't negative
+func (m *MiddlewareRateLimiting) Validate() error {
+	var errs []error
+	if !(m.CallsPerSecond > 0) || math.IsInf(m.CallsPerSecond, 1) {
+		errs = append(errs, fmt.Errorf("%w: calls_per_second must be a positive number: %v",
+			ErrInvalidValue, m.CallsPerSecond))
+	}
+	if m.Burst < 0 {
+		errs = append(errs, fmt.Errorf("%w: burst must not be negative: %d", ErrInvalidValue, m.Burst))
+	}
+	return errors.Join(errs...)
+}
+
+// String returns a short description for display
+func (m *MiddlewareRateLimiting) String() string {
+	return fmt.Sprintf("rate_limiting (%g/s, burst %d)", m.CallsPerSecond, m.EffectiveBurst())
+}
+
+func (m *MiddlewareRateLimiting) toProto() *pbApps.McpToolMiddleware {
+	pb := &pbApps.McpToolRateLimiting{CallsPerSecond: &m.CallsPerSecond}
+	if m.Burst != 0 {
+		burst := int32(m.Burst)
+		pb.Burst = &burst
+	}
+	return &pbApps.McpToolMiddleware{Type: &pbApps.McpToolMiddleware_RateLimiting{RateLimiting: pb}}
+}
+
+// MiddlewareAuthentication only accepts calls from clients authenticated by an
+// auth middleware of the MCP server's route
+type MiddlewareAuthentication struct {
+	// Subjects are the subjects of the clients allowed to call the tool, any
+	// authenticated client when empty
+	Subjects []string `env_interpolation:"no"`
+}
+
+// Type returns the middleware type
+func (m *MiddlewareAuthentication) Type() string { return "authentication" }
+
+// Validate checks no subject is empty
+func (m *MiddlewareAuthentication) Validate() error {
+	var errs []error
+	for i, subject := range m.Subjects {
+		if strings.TrimSpace(subject) == "" {
+			errs = append(errs, fmt.Errorf("%w: subject %d must not be empty", ErrInvalidValue, i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String returns a short description for display
+func (m *MiddlewareAuthentication) String() string {
+	if len(m.Subjects) == 0 {
+		return "authentication"
+	}
+	return fmt.Sprintf("authentication (%s)", strings.Join(m.Subjects, ", "))
+}
+
+func (m *MiddlewareAuthentication) toProto() *pbApps.McpToolMiddleware {
+	pb := &pbApps.McpToolAuthentication{Subjects: m.Subjects}
+	return &pbApps.McpToolMiddleware{Type: &pbApps.McpToolMiddleware_Authentication{Authentication: pb}}
+}
+
+// toolMiddlewareFromProto converts a tool middleware, returning an error when
+// it has no type
+func toolMiddlewareFromProto(pb *pbApps.McpToolMiddleware) (ToolMiddleware, error) {
+	switch t := pb.GetType().(type) {
+	case *pbApps.McpToolMiddleware_Logging:
+		return &MiddlewareLogging{OmitArguments: t.Logging.GetOmitArguments()}, nil
+	case *pbApps.McpToolMiddleware_RateLimiting:
+		return &MiddlewareRateLimiting{
+			CallsPerSecond: t.RateLimiting.GetCallsPerSecond(),
+			Burst:          int(t.RateLimiting.GetBurst()),
+		}, nil
+	case *pbApps.McpToolMiddleware_Authentication:
+		return &MiddlewareAuthentication{Subjects: t.Authentication.GetSubjects()}, nil
+	default:
+		return nil, fmt.Errorf("%w: tool middleware type", ErrMissingRequiredField)
+	}
+}
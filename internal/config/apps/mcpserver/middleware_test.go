@@ -0,0 +1,109 @@
+package mcpserver
+
+import (
+	"testing"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestToolMiddleware_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		middleware    ToolMiddleware
+		errorContains string
+	}{
+		{name: "logging", middleware: &MiddlewareLogging{OmitArguments: true}},
+		{name: "rate limiting", middleware: &MiddlewareRateLimiting{CallsPerSecond: 0.5, Burst: 3}},
+		{
+			name:          "rate limiting without a rate",
+			middleware:    &MiddlewareRateLimiting{Burst: 3},
+			errorContains: "calls_per_second must be a positive number",
+		},
+		{
+			name:          "rate limiting with a negative burst",
+			middleware:    &MiddlewareRateLimiting{CallsPerSecond: 1, Burst: -1},
+			errorContains: "burst must not be negative",
+		},
+		{name: "authentication of any client", middleware: &MiddlewareAuthentication{}},
+		{name: "authentication of subjects", middleware: &MiddlewareAuthentication{Subjects: []string{"alice"}}},
+		{
+			name:          "authentication with an empty subject",
+			middleware:    &MiddlewareAuthentication{Subjects: []string{"alice", " "}},
+			errorContains: "subject 1 must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.middleware.Validate()
+			if tt.errorContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidValue)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestMiddlewareRateLimiting_EffectiveBurst(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, (&MiddlewareRateLimiting{CallsPerSecond: 1, Burst: 5}).EffectiveBurst())
+	assert.Equal(t, 3, (&MiddlewareRateLimiting{CallsPerSecond: 2.5}).EffectiveBurst())
+	assert.Equal(t, 1, (&MiddlewareRateLimiting{CallsPerSecond: 0.1}).EffectiveBurst())
+}
+
+func TestToolMiddleware_ProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	app := NewApp("mcp")
+	app.Tools = []Tool{{
+		AppID: "script",
+		Middlewares: []ToolMiddleware{
+			&MiddlewareAuthentication{Subjects: []string{"alice", "bob"}},
+			&MiddlewareRateLimiting{CallsPerSecond: 2},
+			&MiddlewareLogging{OmitArguments: true},
+		},
+	}}
+
+	pb, ok := app.ToProto().(*pbApps.McpApp)
+	require.True(t, ok)
+	require.Len(t, pb.Tools[0].Middlewares, 3)
+	assert.Nil(t, pb.Tools[0].Middlewares[1].GetRateLimiting().Burst, "an unset burst stays unset")
+
+	roundTrip, err := FromProto("mcp", pb)
+	require.NoError(t, err)
+	assert.Equal(t, app.Tools[0].Middlewares, roundTrip.Tools[0].Middlewares, "order and settings are kept")
+}
+
+func TestToolMiddleware_FromProtoWithoutType(t *testing.T) {
+	t.Parallel()
+
+	_, err := FromProto("mcp", &pbApps.McpApp{
+		Tools: []*pbApps.McpTool{{
+			AppId:       proto.String("script"),
+			Middlewares: []*pbApps.McpToolMiddleware{{}},
+		}},
+	})
+	require.ErrorIs(t, err, ErrMissingRequiredField)
+	assert.Contains(t, err.Error(), "tool script middleware 0")
+}
+
+func TestTool_ValidateMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	tool := Tool{
+		AppID:       "script",
+		Middlewares: []ToolMiddleware{&MiddlewareLogging{}, &MiddlewareRateLimiting{}},
+	}
+	err := tool.Validate()
+	require.ErrorIs(t, err, ErrInvalidValue)
+	assert.Contains(t, err.Error(), "tool middleware 1 (rate_limiting)")
+}
@@ -3,7 +3,10 @@
 package mcpserver
 
 import (
+	"fmt"
+
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // FromProto creates a domain MCP server config from protobuf representation.
@@ -25,11 +28,29 @@ func FromProto(id string, proto *pbApps.McpApp) (*App, error) {
 					Input:  toolProto.GetInputSchema(),
 					Output: toolProto.GetOutputSchema(),
 				},
+				IdempotentHint:       toolProto.GetIdempotentHint(),
+				ReadOnlyHint:         toolProto.GetReadOnlyHint(),
+				SkipOutputValidation: !toolProto.GetValidateOutput(),
+			}
+			if toolProto.CacheTtl != nil {
+				tool.CacheTTL = toolProto.CacheTtl.AsDuration()
+			}
+			for i, mwProto := range toolProto.GetMiddlewares() {
+				mw, err := toolMiddlewareFromProto(mwProto)
+				if err != nil {
+					return nil, fmt.Errorf("tool %s middleware %d: %w", tool.EffectiveID(), i, err)
+				}
+				tool.Middlewares = append(tool.Middlewares, mw)
 			}
 			app.Tools = append(app.Tools, tool)
 		}
 	}
 
+	app.MaxConcurrentToolCalls = int(proto.GetMaxConcurrentToolCalls())
+	if proto.ToolCallQueueTimeout != nil {
+		app.ToolCallQueueTimeout = proto.ToolCallQueueTimeout.AsDuration()
+	}
+
 	// Convert prompts
 	if len(proto.Prompts) > 0 {
 		app.Prompts = make([]Prompt, 0, len(proto.Prompts))
@@ -77,10 +98,34 @@ func (a *App) ToProto() any {
 				InputSchema:  &tool.Schema.Input,
 				OutputSchema: &tool.Schema.Output,
 			}
+			if tool.IdempotentHint {
+				toolProto.IdempotentHint = &tool.IdempotentHint
+			}
+			if tool.ReadOnlyHint {
+				toolProto.ReadOnlyHint = &tool.ReadOnlyHint
+			}
+			if tool.CacheTTL != 0 {
+				toolProto.CacheTtl = durationpb.New(tool.CacheTTL)
+			}
+			for _, mw := range tool.Middlewares {
+				toolProto.Middlewares = append(toolProto.Middlewares, mw.toProto())
+			}
+			if tool.SkipOutputValidation {
+				validateOutput := false
+				toolProto.ValidateOutput = &validateOutput
+			}
 			proto.Tools = append(proto.Tools, toolProto)
 		}
 	}
 
+	if a.MaxConcurrentToolCalls != 0 {
+		maxCalls := int32(a.MaxConcurrentToolCalls)
+		proto.MaxConcurrentToolCalls = &maxCalls
+	}
+	if a.ToolCallQueueTimeout != 0 {
+		proto.ToolCallQueueTimeout = durationpb.New(a.ToolCallQueueTimeout)
+	}
+
 	// Convert prompts
 	if len(a.Prompts) > 0 {
 		proto.Prompts = make([]*pbApps.McpPrompt, 0, len(a.Prompts))
@@ -2,6 +2,7 @@ package mcpserver
 
 import (
 	"testing"
+	"time"
 
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	"github.com/stretchr/testify/assert"
@@ -271,6 +272,8 @@ func TestProtoRoundTripMultiElement(t *testing.T) {
 					Input:  `{"type":"object","properties":{"a":{"type":"number"}}}`,
 					Output: `{"type":"number"}`,
 				},
+				IdempotentHint: true,
+				CacheTTL:       30 * time.Second,
 			},
 			{
 				ID:    "echo",
@@ -279,6 +282,8 @@ func TestProtoRoundTripMultiElement(t *testing.T) {
 					Input:  `{"type":"object","properties":{"msg":{"type":"string"}}}`,
 					Output: `{"type":"string"}`,
 				},
+				ReadOnlyHint:         true,
+				SkipOutputValidation: true,
 			},
 			{
 				AppID: "noop-app", // Empty Tool.ID — falls through to AppID.
@@ -312,6 +317,8 @@ func TestProtoRoundTripMultiElement(t *testing.T) {
 				URITemplate: "https://example.com/{id}",
 			},
 		},
+		MaxConcurrentToolCalls: 4,
+		ToolCallQueueTimeout:   2 * time.Second,
 	}
 
 	pb := original.ToProto().(*pbApps.McpApp)
@@ -328,12 +335,17 @@ func TestProtoRoundTripMultiElement(t *testing.T) {
 	require.NoError(t, err)
 
 	assert.Equal(t, original.ID, got.ID)
+	assert.Equal(t, original.MaxConcurrentToolCalls, got.MaxConcurrentToolCalls)
+	assert.Equal(t, original.ToolCallQueueTimeout, got.ToolCallQueueTimeout)
 	require.Len(t, got.Tools, len(original.Tools))
 	for i, want := range original.Tools {
 		assert.Equal(t, want.ID, got.Tools[i].ID, "tool %d ID", i)
 		assert.Equal(t, want.AppID, got.Tools[i].AppID, "tool %d AppID", i)
 		assert.Equal(t, want.Schema.Input, got.Tools[i].Schema.Input, "tool %d input schema", i)
 		assert.Equal(t, want.Schema.Output, got.Tools[i].Schema.Output, "tool %d output schema", i)
+		assert.Equal(t, want.IdempotentHint, got.Tools[i].IdempotentHint, "tool %d idempotent hint", i)
+		assert.Equal(t, want.ReadOnlyHint, got.Tools[i].ReadOnlyHint, "tool %d read-only hint", i)
+		assert.Equal(t, want.CacheTTL, got.Tools[i].CacheTTL, "tool %d cache TTL", i)
 	}
 	require.Len(t, got.Prompts, len(original.Prompts))
 	for i, want := range original.Prompts {
@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/google/jsonschema-go/jsonschema"
 )
 
 // schemaDefinition handles JSON schema validation for MCP primitives
@@ -19,34 +21,39 @@ type schemaDefinition struct {
 
 // ValidateInput validates the input JSON schema if provided. Schemas are
 // optional overrides — when empty, the runtime falls back to the provider's
-// auto-generated schema from its typed Go input struct.
+// auto-generated schema from its typed Go input struct. The schema must
+// resolve, since the runtime checks the arguments of tool calls against it.
 func (s *schemaDefinition) ValidateInput() error {
 	if strings.TrimSpace(s.Input) == "" {
 		return nil
 	}
 
-	// Parse JSON schema to validate structure
-	var schema map[string]interface{}
+	var schema jsonschema.Schema
 	if err := json.Unmarshal([]byte(s.Input), &schema); err != nil {
 		return fmt.Errorf("invalid input_schema JSON: %w", err)
 	}
+	if _, err := schema.Resolve(nil); err != nil {
+		return fmt.Errorf("invalid input_schema: %w", err)
+	}
 
 	return nil
 }
 
-// ValidateOutput validates the output JSON schema if provided. Output schemas
-// are accepted for future compatibility but are not currently forwarded by the
-// runtime gateway.
+// ValidateOutput validates the output JSON schema if provided. The runtime
+// checks the results of raw tools against it, without forwarding it to MCP
+// clients, so it must resolve.
 func (s *schemaDefinition) ValidateOutput() error {
 	if strings.TrimSpace(s.Output) == "" {
 		return nil // Output schema is optional
 	}
 
-	// Parse JSON schema to validate structure
-	var schema map[string]interface{}
+	var schema jsonschema.Schema
 	if err := json.Unmarshal([]byte(s.Output), &schema); err != nil {
 		return fmt.Errorf("invalid output_schema JSON: %w", err)
 	}
+	if _, err := schema.Resolve(nil); err != nil {
+		return fmt.Errorf("invalid output_schema: %w", err)
+	}
 
 	return nil
 }
@@ -56,10 +63,30 @@ func (s *schemaDefinition) ValidateOutput() error {
 // ID is optional: when empty, the tool is registered using the app's
 // MCPToolName() (the provider-defined name, e.g. "calculate"). Set ID to
 // override the registered tool name without changing the underlying app.
+//
+// IdempotentHint and ReadOnlyHint are advertised to MCP clients as tool
+// annotations. When either is set, a positive CacheTTL enables result caching
+// keyed by the canonicalized tool arguments.
+//
+// Middlewares wrap each call of the tool in order, around the result cache,
+// so calls served from the cache are still logged, rate limited and
+// authenticated.
+//
+// The results of raw tools are checked against the output schema, if any,
+// unless SkipOutputValidation is set.
 type Tool struct {
 	ID     string           `toml:"id,omitempty" env_interpolation:"no"`
 	AppID  string           `toml:"app_id"       env_interpolation:"no"`
 	Schema schemaDefinition `toml:",inline"`
+
+	IdempotentHint bool          `toml:"idempotent_hint,omitempty" env_interpolation:"no"`
+	ReadOnlyHint   bool          `toml:"read_only_hint,omitempty"  env_interpolation:"no"`
+	CacheTTL       time.Duration `toml:"cache_ttl,omitempty"       env_interpolation:"no"`
+
+	Middlewares []ToolMiddleware `toml:"middlewares,omitempty" env_interpolation:"no"`
+
+	// SkipOutputValidation is set by validate_output = false
+	SkipOutputValidation bool `env_interpolation:"no"`
 }
 
 // EffectiveID returns the explicit Tool.ID when set, otherwise falls back
@@ -73,6 +100,12 @@ func (t Tool) EffectiveID() string {
 	return t.AppID
 }
 
+// Cacheable reports whether results of this tool may be cached. Only tools
+// hinted as idempotent or read-only with a positive CacheTTL are cached.
+func (t Tool) Cacheable() bool {
+	return t.CacheTTL > 0 && (t.IdempotentHint || t.ReadOnlyHint)
+}
+
 // Prompt represents a future MCP prompt primitive that maps to a firelynx app.
 // Runtime registration is not implemented yet.
 type Prompt struct {
@@ -108,6 +141,14 @@ type App struct {
 
 	// Resources defines MCP resources that map to firelynx apps
 	Resources []Resource `toml:"resources" env_interpolation:"no"`
+
+	// MaxConcurrentToolCalls is the number of tool calls the server runs at
+	// once, unlimited when 0
+	MaxConcurrentToolCalls int `toml:"max_concurrent_tool_calls,omitempty" env_interpolation:"no"`
+
+	// ToolCallQueueTimeout is how long a call over MaxConcurrentToolCalls
+	// waits for a running call to finish before it fails, not at all when 0
+	ToolCallQueueTimeout time.Duration `toml:"tool_call_queue_timeout,omitempty" env_interpolation:"no"`
 }
 
 // NewApp creates a new MCP App with the specified ID and empty primitive collections
@@ -166,10 +207,30 @@ func (a *App) ToTree() *fancy.ComponentTree {
 	if len(a.Tools) > 0 {
 		tree.AddChild(fmt.Sprintf("Tools: %d", len(a.Tools)))
 		for _, tool := range a.Tools {
-			tree.AddChild(fmt.Sprintf("  - Tool: %s (app: %s)", tool.EffectiveID(), tool.AppID))
+			if tool.Cacheable() {
+				tree.AddChild(fmt.Sprintf(
+					"  - Tool: %s (app: %s, cache_ttl: %s)",
+					tool.EffectiveID(), tool.AppID, tool.CacheTTL,
+				))
+			} else {
+				tree.AddChild(fmt.Sprintf("  - Tool: %s (app: %s)", tool.EffectiveID(), tool.AppID))
+			}
+			for _, mw := range tool.Middlewares {
+				tree.AddChild(fmt.Sprintf("    - Middleware: %s", mw))
+			}
+			if tool.Schema.Output != "" && tool.SkipOutputValidation {
+				tree.AddChild("    - Output validation: skipped")
+			}
 		}
 	}
 
+	if a.MaxConcurrentToolCalls > 0 {
+		tree.AddChild(fmt.Sprintf(
+			"Max concurrent tool calls: %d (queue timeout: %s)",
+			a.MaxConcurrentToolCalls, a.ToolCallQueueTimeout,
+		))
+	}
+
 	if len(a.Prompts) > 0 {
 		tree.AddChild(fmt.Sprintf("Prompts: %d", len(a.Prompts)))
 		for _, prompt := range a.Prompts {
@@ -172,6 +172,17 @@ func TestTool_Validation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid input_schema JSON",
 		},
+		{
+			name: "unresolvable input schema",
+			tool: Tool{
+				AppID: "test-app",
+				Schema: schemaDefinition{
+					Input: `{"type": "object", "properties": {"a": {"$ref": "#/$defs/missing"}}}`,
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid input_schema",
+		},
 		{
 			name: "invalid output schema JSON",
 			tool: Tool{
@@ -183,6 +194,17 @@ func TestTool_Validation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid output_schema JSON",
 		},
+		{
+			name: "unresolvable output schema",
+			tool: Tool{
+				AppID: "test-app",
+				Schema: schemaDefinition{
+					Output: `{"$ref": "#/$defs/missing"}`,
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid output_schema",
+		},
 	}
 
 	for _, tt := range tests {
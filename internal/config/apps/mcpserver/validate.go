@@ -36,6 +36,28 @@ func (t *Tool) Validate() error {
 		errs = append(errs, fmt.Errorf("tool output schema: %w", err))
 	}
 
+	// Result caching is only safe for tools whose repeated calls with the
+	// same arguments yield the same result.
+	if t.CacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("%w: tool cache_ttl must not be negative: %s", ErrInvalidValue, t.CacheTTL))
+	}
+	if t.CacheTTL > 0 && !t.IdempotentHint && !t.ReadOnlyHint {
+		errs = append(errs, fmt.Errorf(
+			"%w: tool cache_ttl requires idempotent_hint or read_only_hint",
+			ErrInvalidValue,
+		))
+	}
+
+	for i, mw := range t.Middlewares {
+		if mw == nil {
+			errs = append(errs, fmt.Errorf("%w: tool middleware %d type", ErrMissingRequiredField, i))
+			continue
+		}
+		if err := mw.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("tool middleware %d (%s): %w", i, mw.Type(), err))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -99,6 +121,15 @@ func (a *App) Validate() error {
 		errs = append(errs, err)
 	}
 
+	if a.MaxConcurrentToolCalls < 0 {
+		errs = append(errs, fmt.Errorf("%w: max_concurrent_tool_calls must not be negative: %d",
+			ErrInvalidValue, a.MaxConcurrentToolCalls))
+	}
+	if a.ToolCallQueueTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: tool_call_queue_timeout must not be negative: %s",
+			ErrInvalidValue, a.ToolCallQueueTimeout))
+	}
+
 	// Validate all tools
 	for i, tool := range a.Tools {
 		if err := tool.Validate(); err != nil {
@@ -125,8 +156,8 @@ func (a *App) Validate() error {
 		errs = append(errs, err)
 	}
 
-	// Note: App ID cross-reference validation (checking if referenced app IDs exist)
-	// will be implemented once we understand how to access the app registry from validation context
+	// The apps referenced by the tools are checked by the app collection's
+	// validation, which sees every app (see apps.AppCollection.Validate)
 
 	return errors.Join(errs...)
 }
@@ -2,6 +2,7 @@ package mcpserver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,6 +128,80 @@ func TestAppValidate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "tool ID contains invalid characters",
 		},
+		{
+			name: "valid cached idempotent tool",
+			app: &App{
+				ID: "cached-tool-app",
+				Tools: []Tool{
+					{
+						AppID:          "calc-app",
+						IdempotentHint: true,
+						CacheTTL:       time.Minute,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative cache TTL",
+			app: &App{
+				ID: "negative-ttl-app",
+				Tools: []Tool{
+					{
+						AppID:        "calc-app",
+						ReadOnlyHint: true,
+						CacheTTL:     -time.Second,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "cache_ttl must not be negative",
+		},
+		{
+			name: "cache TTL on non-idempotent tool",
+			app: &App{
+				ID: "non-idempotent-ttl-app",
+				Tools: []Tool{
+					{
+						AppID:    "calc-app",
+						CacheTTL: time.Minute,
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "cache_ttl requires idempotent_hint or read_only_hint",
+		},
+		{
+			name: "valid tool call concurrency limit",
+			app: &App{
+				ID:                     "limited-app",
+				Tools:                  []Tool{{AppID: "calc-app"}},
+				MaxConcurrentToolCalls: 4,
+				ToolCallQueueTimeout:   2 * time.Second,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max concurrent tool calls",
+			app: &App{
+				ID:                     "negative-limit-app",
+				Tools:                  []Tool{{AppID: "calc-app"}},
+				MaxConcurrentToolCalls: -1,
+			},
+			wantErr: true,
+			errMsg:  "max_concurrent_tool_calls must not be negative",
+		},
+		{
+			name: "negative tool call queue timeout",
+			app: &App{
+				ID:                     "negative-queue-timeout-app",
+				Tools:                  []Tool{{AppID: "calc-app"}},
+				MaxConcurrentToolCalls: 4,
+				ToolCallQueueTimeout:   -time.Second,
+			},
+			wantErr: true,
+			errMsg:  "tool_call_queue_timeout must not be negative",
+		},
 		{
 			name: "duplicate tool IDs",
 			app: &App{
@@ -0,0 +1,95 @@
+// Package openapi provides app-specific configuration for OpenAPI apps, which
+// serve a document describing the configured HTTP routes.
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+)
+
+// Defaults for the OpenAPI app configuration
+const (
+	DefaultTitle              = "firelynx"
+	DefaultVersion            = "1.0.0"
+	DefaultSwaggerUIAssetsURL = "https://unpkg.com/swagger-ui-dist@5"
+)
+
+// App contains OpenAPI app-specific configuration.
+type App struct {
+	ID      string `env_interpolation:"no"`
+	Title   string `env_interpolation:"yes"`
+	Version string `env_interpolation:"yes"`
+
+	// SwaggerUI serves a Swagger UI page for the document
+	SwaggerUI bool `env_interpolation:"no"`
+
+	// SwaggerUIAssetsURL is the base URL of the swagger-ui-dist assets
+	SwaggerUIAssetsURL string `env_interpolation:"yes"`
+}
+
+// New creates a new OpenAPI app configuration with the specified ID and the
+// default values.
+func New(id string) *App {
+	return &App{
+		ID:                 id,
+		Title:              DefaultTitle,
+		Version:            DefaultVersion,
+		SwaggerUIAssetsURL: DefaultSwaggerUIAssetsURL,
+	}
+}
+
+// Type returns the app type.
+func (a *App) Type() string { return "openapi" }
+
+// Validate checks if the OpenAPI app configuration is valid.
+func (a *App) Validate() error {
+	if err := interpolation.InterpolateStruct(a); err != nil {
+		return fmt.Errorf("interpolation failed for openapi app: %w", err)
+	}
+
+	if a.ID == "" {
+		return fmt.Errorf("%w: openapi app ID", errz.ErrMissingRequiredField)
+	}
+
+	if a.Title == "" {
+		return fmt.Errorf("%w: openapi app title", errz.ErrMissingRequiredField)
+	}
+
+	if a.Version == "" {
+		return fmt.Errorf("%w: openapi app version", errz.ErrMissingRequiredField)
+	}
+
+	if a.SwaggerUI {
+		u, err := url.Parse(a.SwaggerUIAssetsURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: openapi swagger_ui_assets_url must be an http(s) URL: %q",
+				errz.ErrInvalidValue, a.SwaggerUIAssetsURL)
+		}
+	}
+
+	return nil
+}
+
+// String returns a string representation of the OpenAPI app.
+func (a *App) String() string {
+	if a.SwaggerUI {
+		return fmt.Sprintf("OpenAPI App (title: %s, swagger_ui: true)", a.Title)
+	}
+	return fmt.Sprintf("OpenAPI App (title: %s)", a.Title)
+}
+
+// ToTree returns a tree representation of the OpenAPI app.
+func (a *App) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("OpenAPI App")
+	tree.AddChild("Type: openapi")
+	tree.AddChild(fmt.Sprintf("Title: %s", a.Title))
+	tree.AddChild(fmt.Sprintf("Version: %s", a.Version))
+	if a.SwaggerUI {
+		tree.AddChild(fmt.Sprintf("SwaggerUI: %s", a.SwaggerUIAssetsURL))
+	}
+	return tree
+}
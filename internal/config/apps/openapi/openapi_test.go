@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	app := New("docs")
+	assert.Equal(t, "docs", app.ID)
+	assert.Equal(t, DefaultTitle, app.Title)
+	assert.Equal(t, DefaultVersion, app.Version)
+	assert.False(t, app.SwaggerUI)
+	assert.Equal(t, DefaultSwaggerUIAssetsURL, app.SwaggerUIAssetsURL)
+	require.NoError(t, app.Validate())
+}
+
+func TestApp_Validate(t *testing.T) {
+	withSwaggerUI := func(url string) *App {
+		app := New("docs")
+		app.SwaggerUI = true
+		app.SwaggerUIAssetsURL = url
+		return app
+	}
+
+	tests := []struct {
+		name    string
+		app     *App
+		wantErr string
+	}{
+		{name: "valid", app: New("docs")},
+		{name: "valid with swagger ui", app: withSwaggerUI("http://localhost:8000/assets/")},
+		{name: "missing id", app: New(""), wantErr: "missing required field: openapi app ID"},
+		{name: "missing title", app: &App{ID: "docs", Version: "1"}, wantErr: "missing required field: openapi app title"},
+		{name: "missing version", app: &App{ID: "docs", Title: "API"}, wantErr: "missing required field: openapi app version"},
+		{name: "relative assets url", app: withSwaggerUI("/assets"), wantErr: "openapi swagger_ui_assets_url must be an http(s) URL"},
+		{name: "non-http assets url", app: withSwaggerUI("ftp://example.com/assets"), wantErr: "invalid value"},
+		{name: "assets url ignored without swagger ui", app: &App{ID: "docs", Title: "API", Version: "1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.app.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApp_Validate_Interpolation(t *testing.T) {
+	t.Setenv("OPENAPI_TITLE", "Interpolated API")
+
+	app := New("docs")
+	app.Title = "${OPENAPI_TITLE}"
+	require.NoError(t, app.Validate())
+	assert.Equal(t, "Interpolated API", app.Title)
+}
+
+func TestApp_Type(t *testing.T) {
+	assert.Equal(t, "openapi", New("docs").Type())
+}
+
+func TestApp_String(t *testing.T) {
+	app := New("docs")
+	assert.Equal(t, "OpenAPI App (title: firelynx)", app.String())
+
+	app.SwaggerUI = true
+	assert.Equal(t, "OpenAPI App (title: firelynx, swagger_ui: true)", app.String())
+}
+
+func TestApp_ToTree(t *testing.T) {
+	app := New("docs")
+	app.SwaggerUI = true
+
+	tree := app.ToTree().Tree().String()
+	assert.Contains(t, tree, "OpenAPI App")
+	assert.Contains(t, tree, "Title: firelynx")
+	assert.Contains(t, tree, "Version: 1.0.0")
+	assert.Contains(t, tree, "SwaggerUI: "+DefaultSwaggerUIAssetsURL)
+}
@@ -0,0 +1,27 @@
+package openapi
+
+import pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+
+// FromProto creates an App configuration from its protocol buffer
+// representation. Unset fields take the proto defaults.
+func FromProto(id string, proto *pbApps.OpenApiApp) *App {
+	if proto == nil {
+		return nil
+	}
+	app := New(id)
+	app.Title = proto.GetTitle()
+	app.Version = proto.GetVersion()
+	app.SwaggerUI = proto.GetSwaggerUi()
+	app.SwaggerUIAssetsURL = proto.GetSwaggerUiAssetsUrl()
+	return app
+}
+
+// ToProto converts the App configuration to its protocol buffer representation.
+func (a *App) ToProto() any {
+	return &pbApps.OpenApiApp{
+		Title:              &a.Title,
+		Version:            &a.Version,
+		SwaggerUi:          &a.SwaggerUI,
+		SwaggerUiAssetsUrl: &a.SwaggerUIAssetsURL,
+	}
+}
@@ -0,0 +1,41 @@
+package openapi
+
+import (
+	"testing"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFromProto_Defaults(t *testing.T) {
+	app := FromProto("docs", &pbApps.OpenApiApp{})
+	require.NotNil(t, app)
+	assert.Equal(t, New("docs"), app)
+}
+
+func TestFromProto_Nil(t *testing.T) {
+	assert.Nil(t, FromProto("docs", nil))
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	app := FromProto("docs", &pbApps.OpenApiApp{
+		Title:              proto.String("Gateway"),
+		Version:            proto.String("2.1.0"),
+		SwaggerUi:          proto.Bool(true),
+		SwaggerUiAssetsUrl: proto.String("https://cdn.example.com/swagger-ui"),
+	})
+	require.NotNil(t, app)
+	assert.Equal(t, "Gateway", app.Title)
+	assert.True(t, app.SwaggerUI)
+
+	protoApp, ok := app.ToProto().(*pbApps.OpenApiApp)
+	require.True(t, ok)
+	assert.Equal(t, "Gateway", protoApp.GetTitle())
+	assert.Equal(t, "2.1.0", protoApp.GetVersion())
+	assert.True(t, protoApp.GetSwaggerUi())
+	assert.Equal(t, "https://cdn.example.com/swagger-ui", protoApp.GetSwaggerUiAssetsUrl())
+
+	assert.Equal(t, app, FromProto("docs", protoApp))
+}
@@ -13,10 +13,13 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
 	mcpserver "github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/robbyt/protobaggins"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // AppType represents the type of application
@@ -31,6 +34,8 @@ const (
 	AppTypeMCP         AppType = "mcp"
 	AppTypeCalculation AppType = "calculation"
 	AppTypeFileRead    AppType = "fileread"
+	AppTypeOpenAPI     AppType = "openapi"
+	AppTypeTemplate    AppType = "template"
 )
 
 // appTypeToProto converts from domain AppType to protobuf AppType enum
@@ -48,6 +53,10 @@ func appTypeToProto(appType AppType) pb.AppDefinition_Type {
 		return pb.AppDefinition_TYPE_CALCULATION
 	case AppTypeFileRead:
 		return pb.AppDefinition_TYPE_FILEREAD
+	case AppTypeOpenAPI:
+		return pb.AppDefinition_TYPE_OPENAPI
+	case AppTypeTemplate:
+		return pb.AppDefinition_TYPE_TEMPLATE
 	default:
 		return pb.AppDefinition_TYPE_UNSPECIFIED
 	}
@@ -68,6 +77,10 @@ func appTypeFromProto(pbAppType pb.AppDefinition_Type) AppType {
 		return AppTypeCalculation
 	case pb.AppDefinition_TYPE_FILEREAD:
 		return AppTypeFileRead
+	case pb.AppDefinition_TYPE_OPENAPI:
+		return AppTypeOpenAPI
+	case pb.AppDefinition_TYPE_TEMPLATE:
+		return AppTypeTemplate
 	default:
 		return AppTypeUnknown
 	}
@@ -96,6 +109,10 @@ func (ac *AppCollection) ToProto() []*pb.AppDefinition {
 			appType = AppTypeCalculation
 		case *fileread.App:
 			appType = AppTypeFileRead
+		case *openapi.App:
+			appType = AppTypeOpenAPI
+		case *template.App:
+			appType = AppTypeTemplate
 		default:
 			appType = AppTypeUnknown
 		}
@@ -111,6 +128,14 @@ func (ac *AppCollection) ToProto() []*pb.AppDefinition {
 		case *scripts.AppScript:
 			pbScript := &pbApps.ScriptApp{}
 
+			// Error bodies are only set when configured
+			if cfg.TimeoutBody != "" {
+				pbScript.TimeoutBody = &cfg.TimeoutBody
+			}
+			if cfg.ShutdownBody != "" {
+				pbScript.ShutdownBody = &cfg.ShutdownBody
+			}
+
 			// Convert static data if present
 			if cfg.StaticData != nil {
 				pbScript.StaticData = cfg.StaticData.ToProto()
@@ -131,6 +156,10 @@ func (ac *AppCollection) ToProto() []*pb.AppDefinition {
 					pbScript.Evaluator = &pbApps.ScriptApp_Extism{
 						Extism: e.ToProto(),
 					}
+				case *evaluators.JavaScriptEvaluator:
+					pbScript.Evaluator = &pbApps.ScriptApp_Javascript{
+						Javascript: e.ToProto(),
+					}
 				}
 			}
 
@@ -143,6 +172,10 @@ func (ac *AppCollection) ToProto() []*pb.AppDefinition {
 				ScriptAppIds: cfg.ScriptAppIDs,
 			}
 
+			if cfg.StepTimeout > 0 {
+				pbComposite.StepTimeout = durationpb.New(cfg.StepTimeout)
+			}
+
 			// Convert static data if present
 			if cfg.StaticData != nil {
 				pbComposite.StaticData = cfg.StaticData.ToProto()
@@ -172,6 +205,16 @@ func (ac *AppCollection) ToProto() []*pb.AppDefinition {
 			app.Config = &pb.AppDefinition_Fileread{
 				Fileread: pbFileRead,
 			}
+		case *openapi.App:
+			pbOpenAPI := cfg.ToProto().(*pbApps.OpenApiApp)
+			app.Config = &pb.AppDefinition_Openapi{
+				Openapi: pbOpenAPI,
+			}
+		case *template.App:
+			pbTemplate := cfg.ToProto().(*pbApps.TemplateApp)
+			app.Config = &pb.AppDefinition_Template{
+				Template: pbTemplate,
+			}
 		}
 
 		result = append(result, app)
@@ -256,6 +299,8 @@ func fromProto(pbApp *pb.AppDefinition) (App, error) {
 		scriptApp := scripts.NewAppScript(app.ID)
 		scriptApp.StaticData = staticData
 		scriptApp.Evaluator = evaluator
+		scriptApp.TimeoutBody = pbScript.GetTimeoutBody()
+		scriptApp.ShutdownBody = pbScript.GetShutdownBody()
 		app.Config = scriptApp
 		return app, nil
 
@@ -272,7 +317,11 @@ func fromProto(pbApp *pb.AppDefinition) (App, error) {
 			return App{}, fmt.Errorf("error converting static data: %w", err)
 		}
 
-		app.Config = composite.NewCompositeScript(pbComposite.GetScriptAppIds(), staticData)
+		compositeApp := composite.NewCompositeScript(pbComposite.GetScriptAppIds(), staticData)
+		if pbComposite.StepTimeout != nil {
+			compositeApp.StepTimeout = pbComposite.StepTimeout.AsDuration()
+		}
+		app.Config = compositeApp
 		return app, nil
 
 	case *pb.AppDefinition_Echo:
@@ -309,7 +358,10 @@ func fromProto(pbApp *pb.AppDefinition) (App, error) {
 			return App{}, fmt.Errorf("%w: app '%s' has type %s but calculation config", ErrTypeMismatch, app.ID, appType)
 		}
 
-		calculationApp := calculation.FromProto(app.ID, config.Calculation)
+		calculationApp, err := calculation.FromProto(app.ID, config.Calculation)
+		if err != nil {
+			return App{}, fmt.Errorf("error converting calculation app: %w", err)
+		}
 		if calculationApp == nil {
 			return App{}, fmt.Errorf("calculation app '%s' config is nil", app.ID)
 		}
@@ -321,13 +373,43 @@ func fromProto(pbApp *pb.AppDefinition) (App, error) {
 			return App{}, fmt.Errorf("%w: app '%s' has type %s but fileread config", ErrTypeMismatch, app.ID, appType)
 		}
 
-		fileReadApp := fileread.FromProto(app.ID, config.Fileread)
+		fileReadApp, err := fileread.FromProto(app.ID, config.Fileread)
+		if err != nil {
+			return App{}, fmt.Errorf("error converting fileread app: %w", err)
+		}
 		if fileReadApp == nil {
 			return App{}, fmt.Errorf("fileread app '%s' config is nil", app.ID)
 		}
 		app.Config = fileReadApp
 		return app, nil
 
+	case *pb.AppDefinition_Openapi:
+		if appType != AppTypeOpenAPI {
+			return App{}, fmt.Errorf("%w: app '%s' has type %s but openapi config", ErrTypeMismatch, app.ID, appType)
+		}
+
+		openAPIApp := openapi.FromProto(app.ID, config.Openapi)
+		if openAPIApp == nil {
+			return App{}, fmt.Errorf("openapi app '%s' config is nil", app.ID)
+		}
+		app.Config = openAPIApp
+		return app, nil
+
+	case *pb.AppDefinition_Template:
+		if appType != AppTypeTemplate {
+			return App{}, fmt.Errorf("%w: app '%s' has type %s but template config", ErrTypeMismatch, app.ID, appType)
+		}
+
+		templateApp, err := template.FromProto(app.ID, config.Template)
+		if err != nil {
+			return App{}, fmt.Errorf("error converting template app: %w", err)
+		}
+		if templateApp == nil {
+			return App{}, fmt.Errorf("template app '%s' config is nil", app.ID)
+		}
+		app.Config = templateApp
+		return app, nil
+
 	case nil:
 		return App{}, fmt.Errorf("%w: app '%s'", ErrNoConfigSpecified, app.ID)
 
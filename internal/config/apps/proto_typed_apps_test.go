@@ -7,6 +7,8 @@ import (
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/calculation"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
@@ -17,6 +19,10 @@ func TestAppTypeConversions_TypedApps(t *testing.T) {
 	assert.Equal(t, pb.AppDefinition_TYPE_FILEREAD, appTypeToProto(AppTypeFileRead))
 	assert.Equal(t, AppTypeCalculation, appTypeFromProto(pb.AppDefinition_TYPE_CALCULATION))
 	assert.Equal(t, AppTypeFileRead, appTypeFromProto(pb.AppDefinition_TYPE_FILEREAD))
+	assert.Equal(t, pb.AppDefinition_TYPE_OPENAPI, appTypeToProto(AppTypeOpenAPI))
+	assert.Equal(t, AppTypeOpenAPI, appTypeFromProto(pb.AppDefinition_TYPE_OPENAPI))
+	assert.Equal(t, pb.AppDefinition_TYPE_TEMPLATE, appTypeToProto(AppTypeTemplate))
+	assert.Equal(t, AppTypeTemplate, appTypeFromProto(pb.AppDefinition_TYPE_TEMPLATE))
 }
 
 func TestFromProto_TypedApps(t *testing.T) {
@@ -56,6 +62,48 @@ func TestFromProto_TypedApps(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "/tmp/files", cfg.BaseDirectory)
 	})
+
+	t.Run("openapi", func(t *testing.T) {
+		appType := pb.AppDefinition_TYPE_OPENAPI
+		pbApp := &pb.AppDefinition{
+			Id:   proto.String("docs"),
+			Type: &appType,
+			Config: &pb.AppDefinition_Openapi{
+				Openapi: &pbApps.OpenApiApp{SwaggerUi: proto.Bool(true)},
+			},
+		}
+
+		app, err := fromProto(pbApp)
+		require.NoError(t, err)
+		assert.Equal(t, "docs", app.ID)
+		cfg, ok := app.Config.(*openapi.App)
+		require.True(t, ok)
+		assert.True(t, cfg.SwaggerUI)
+		assert.Equal(t, openapi.DefaultTitle, cfg.Title)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		appType := pb.AppDefinition_TYPE_TEMPLATE
+		pbApp := &pb.AppDefinition{
+			Id:   proto.String("pages"),
+			Type: &appType,
+			Config: &pb.AppDefinition_Template{
+				Template: &pbApps.TemplateApp{
+					Directory: proto.String("/srv/templates"),
+					Template:  proto.String("index.html"),
+				},
+			},
+		}
+
+		app, err := fromProto(pbApp)
+		require.NoError(t, err)
+		assert.Equal(t, "pages", app.ID)
+		cfg, ok := app.Config.(*template.App)
+		require.True(t, ok)
+		assert.Equal(t, "pages", cfg.ID)
+		assert.Equal(t, "/srv/templates", cfg.Directory)
+		assert.Equal(t, "index.html", cfg.Template)
+	})
 }
 
 func TestFromProto_TypedApps_Errors(t *testing.T) {
@@ -122,18 +170,71 @@ func TestFromProto_TypedApps_Errors(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "fileread app 'files' config is nil")
 	})
+
+	t.Run("openapi type mismatch", func(t *testing.T) {
+		echoType := pb.AppDefinition_TYPE_ECHO
+		pbApp := &pb.AppDefinition{
+			Id:   proto.String("docs"),
+			Type: &echoType,
+			Config: &pb.AppDefinition_Openapi{
+				Openapi: &pbApps.OpenApiApp{},
+			},
+		}
+
+		_, err := fromProto(pbApp)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrTypeMismatch)
+		assert.Contains(t, err.Error(), "openapi config")
+	})
+
+	t.Run("template type mismatch", func(t *testing.T) {
+		echoType := pb.AppDefinition_TYPE_ECHO
+		pbApp := &pb.AppDefinition{
+			Id:   proto.String("pages"),
+			Type: &echoType,
+			Config: &pb.AppDefinition_Template{
+				Template: &pbApps.TemplateApp{},
+			},
+		}
+
+		_, err := fromProto(pbApp)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrTypeMismatch)
+		assert.Contains(t, err.Error(), "template config")
+	})
+
+	t.Run("template nil inner config", func(t *testing.T) {
+		appType := pb.AppDefinition_TYPE_TEMPLATE
+		pbApp := &pb.AppDefinition{
+			Id:   proto.String("pages"),
+			Type: &appType,
+			Config: &pb.AppDefinition_Template{
+				Template: nil,
+			},
+		}
+
+		_, err := fromProto(pbApp)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template app 'pages' config is nil")
+	})
 }
 
 func TestToProto_TypedApps(t *testing.T) {
 	collection := NewAppCollection(
 		App{ID: "calc", Config: calculation.New("calc")},
 		App{ID: "files", Config: &fileread.App{ID: "files", BaseDirectory: "/tmp/files"}},
+		App{ID: "docs", Config: openapi.New("docs")},
+		App{ID: "pages", Config: &template.App{ID: "pages", Directory: "/srv/templates"}},
 	)
 
 	got := collection.ToProto()
-	require.Len(t, got, 2)
+	require.Len(t, got, 4)
 	assert.Equal(t, pb.AppDefinition_TYPE_CALCULATION, got[0].GetType())
 	assert.NotNil(t, got[0].GetCalculation())
 	assert.Equal(t, pb.AppDefinition_TYPE_FILEREAD, got[1].GetType())
 	assert.Equal(t, "/tmp/files", got[1].GetFileread().GetBaseDirectory())
+	assert.Equal(t, pb.AppDefinition_TYPE_OPENAPI, got[2].GetType())
+	assert.Equal(t, openapi.DefaultTitle, got[2].GetOpenapi().GetTitle())
+	assert.Equal(t, pb.AppDefinition_TYPE_TEMPLATE, got[3].GetType())
+	assert.Equal(t, "/srv/templates", got[3].GetTemplate().GetDirectory())
 }
@@ -0,0 +1,175 @@
+package evaluators
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+)
+
+// maxCachedEvaluators bounds the compiled evaluator cache. Least recently used
+// entries are dropped first, so scripts removed from the config age out.
+const maxCachedEvaluators = 128
+
+// compiledCache is shared by all evaluators, so a config reload reuses the
+// compiled artifact of every script whose source didn't change.
+var compiledCache = newCompileCache(maxCachedEvaluators)
+
+// CompileStats describes how an evaluator's compiled artifact was obtained.
+type CompileStats struct {
+	// SourceHash identifies the engine, options, and source bytes compiled
+	SourceHash string
+	// CompileTime is how long compilation took. It is zero on a cache hit.
+	CompileTime time.Duration
+	// CacheHit is true when a previously compiled evaluator was reused
+	CacheHit bool
+}
+
+// GetCompileStats returns the compile stats for an evaluator built by this
+// package. It returns false if the evaluator hasn't been compiled.
+func GetCompileStats(e Evaluator) (CompileStats, bool) {
+	var stats *CompileStats
+	switch v := e.(type) {
+	case *RisorEvaluator:
+		stats = v.compileStats
+	case *StarlarkEvaluator:
+		stats = v.compileStats
+	case *ExtismEvaluator:
+		stats = v.compileStats
+	case *JavaScriptEvaluator:
+		stats = v.compileStats
+	}
+	if stats == nil {
+		return CompileStats{}, false
+	}
+	return *stats, true
+}
+
+// compileFunc compiles the script read from ldr.
+type compileFunc func(ldr loader.Loader) (platform.Evaluator, error)
+
+// compileCache holds compiled evaluators keyed by a hash of their source.
+type compileCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // front is most recently used
+}
+
+type cacheEntry struct {
+	key       string
+	evaluator platform.Evaluator
+}
+
+func newCompileCache(maxEntries int) *compileCache {
+	return &compileCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// compile reads the script from ldr and returns the cached evaluator for that
+// engine, options, and source, or compiles and caches a new one. The source is
+// only read once: a miss compiles from the bytes already read, so URI-based
+// scripts are not fetched twice.
+func (c *compileCache) compile(
+	evalType EvaluatorType,
+	ldr loader.Loader,
+	options []string,
+	fn compileFunc,
+) (platform.Evaluator, *CompileStats, error) {
+	source, err := readLoader(ldr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := sourceHash(evalType, options, source)
+	if cached := c.get(key); cached != nil {
+		return cached, &CompileStats{SourceHash: key, CacheHit: true}, nil
+	}
+
+	sourceLoader, err := loader.NewFromBytes(source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start := time.Now()
+	compiled, err := fn(sourceLoader)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats := &CompileStats{SourceHash: key, CompileTime: time.Since(start)}
+
+	c.put(key, compiled)
+	return compiled, stats, nil
+}
+
+func (c *compileCache) get(key string) platform.Evaluator {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*cacheEntry).evaluator
+}
+
+func (c *compileCache) put(key string, e platform.Evaluator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, evaluator: e})
+
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+func (c *compileCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// sourceHash returns the cache key for a script compiled by an engine with
+// the given options.
+func sourceHash(evalType EvaluatorType, options []string, source []byte) string {
+	h := sha256.New()
+	_, _ = fmt.Fprintf(h, "%s\x00", evalType)
+	for _, opt := range options {
+		_, _ = fmt.Fprintf(h, "%s\x00", opt)
+	}
+	_, _ = h.Write(source)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// readLoader reads the full script source from ldr.
+func readLoader(ldr loader.Loader) ([]byte, error) {
+	reader, err := ldr.GetReader(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script source: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script source: %w", err)
+	}
+	return source, nil
+}
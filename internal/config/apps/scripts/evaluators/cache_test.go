@@ -0,0 +1,158 @@
+package evaluators
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/robbyt/go-polyscript/engines/extism/wasmdata"
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompiledEvaluatorCache(t *testing.T) {
+	// The cache is shared by the package, so every case uses source unique to it
+	uniqueRisor := func(t *testing.T) string {
+		t.Helper()
+		return fmt.Sprintf("// %s\n{\"ok\": true}", t.Name())
+	}
+
+	t.Run("unchanged source is reused", func(t *testing.T) {
+		code := uniqueRisor(t)
+
+		first := &RisorEvaluator{Code: code}
+		require.NoError(t, first.Validate())
+		stats, ok := GetCompileStats(first)
+		require.True(t, ok)
+		assert.False(t, stats.CacheHit)
+		assert.Positive(t, stats.CompileTime)
+
+		// A reload builds a new evaluator from the same config
+		second := &RisorEvaluator{Code: code}
+		require.NoError(t, second.Validate())
+		stats2, ok := GetCompileStats(second)
+		require.True(t, ok)
+		assert.True(t, stats2.CacheHit)
+		assert.Zero(t, stats2.CompileTime)
+		assert.Equal(t, stats.SourceHash, stats2.SourceHash)
+
+		firstCompiled, err := first.GetCompiledEvaluator()
+		require.NoError(t, err)
+		secondCompiled, err := second.GetCompiledEvaluator()
+		require.NoError(t, err)
+		assert.Same(t, firstCompiled, secondCompiled)
+	})
+
+	t.Run("changed source is recompiled", func(t *testing.T) {
+		code := uniqueRisor(t)
+
+		first := &RisorEvaluator{Code: code}
+		require.NoError(t, first.Validate())
+		second := &RisorEvaluator{Code: code + "\n{\"ok\": false}"}
+		require.NoError(t, second.Validate())
+
+		stats, ok := GetCompileStats(second)
+		require.True(t, ok)
+		assert.False(t, stats.CacheHit)
+	})
+
+	t.Run("same source in another engine is not shared", func(t *testing.T) {
+		code := fmt.Sprintf("%q", t.Name())
+
+		starlark := &StarlarkEvaluator{Code: code}
+		require.NoError(t, starlark.Validate())
+		risor := &RisorEvaluator{Code: code}
+		require.NoError(t, risor.Validate())
+
+		stats, ok := GetCompileStats(risor)
+		require.True(t, ok)
+		assert.False(t, stats.CacheHit)
+	})
+
+	t.Run("URI source is keyed by fetched bytes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "script.risor")
+		require.NoError(t, os.WriteFile(path, []byte(uniqueRisor(t)), 0o600))
+
+		first := &RisorEvaluator{URI: "file://" + path}
+		require.NoError(t, first.Validate())
+		second := &RisorEvaluator{URI: "file://" + path}
+		require.NoError(t, second.Validate())
+		stats, ok := GetCompileStats(second)
+		require.True(t, ok)
+		assert.True(t, stats.CacheHit)
+
+		// Editing the file changes the hash even though the URI is the same
+		require.NoError(t, os.WriteFile(path, []byte(uniqueRisor(t)+"\n"), 0o600))
+		third := &RisorEvaluator{URI: "file://" + path}
+		require.NoError(t, third.Validate())
+		stats, ok = GetCompileStats(third)
+		require.True(t, ok)
+		assert.False(t, stats.CacheHit)
+	})
+
+	t.Run("extism entrypoint is part of the key", func(t *testing.T) {
+		code := base64.StdEncoding.EncodeToString(wasmdata.TestModule)
+
+		first := &ExtismEvaluator{Code: code, Entrypoint: wasmdata.EntrypointGreet}
+		require.NoError(t, first.Validate())
+		other := &ExtismEvaluator{Code: code, Entrypoint: wasmdata.EntrypointGreetNamespaced}
+		require.NoError(t, other.Validate())
+		same := &ExtismEvaluator{Code: code, Entrypoint: wasmdata.EntrypointGreet}
+		require.NoError(t, same.Validate())
+
+		firstStats, _ := GetCompileStats(first)
+		otherStats, _ := GetCompileStats(other)
+		sameStats, _ := GetCompileStats(same)
+		assert.NotEqual(t, firstStats.SourceHash, otherStats.SourceHash)
+		assert.Equal(t, firstStats.SourceHash, sameStats.SourceHash)
+		assert.True(t, sameStats.CacheHit)
+	})
+
+	t.Run("compilation errors are not cached", func(t *testing.T) {
+		code := fmt.Sprintf("// %s\nfunc(", t.Name())
+		for range 2 {
+			e := &RisorEvaluator{Code: code}
+			require.ErrorIs(t, e.Validate(), ErrCompilationFailed)
+			_, ok := GetCompileStats(e)
+			assert.False(t, ok)
+		}
+	})
+}
+
+// stubEvaluator stands in for a compiled evaluator; it is never evaluated
+type stubEvaluator struct{ platform.Evaluator }
+
+func TestCompileCache_Eviction(t *testing.T) {
+	cache := newCompileCache(2)
+	compiles := 0
+	compile := func(code string) *CompileStats {
+		t.Helper()
+		ldr, err := loader.NewFromString(code)
+		require.NoError(t, err)
+		_, stats, err := cache.compile(
+			EvaluatorTypeRisor,
+			ldr,
+			nil,
+			func(loader.Loader) (platform.Evaluator, error) {
+				compiles++
+				return stubEvaluator{}, nil
+			},
+		)
+		require.NoError(t, err)
+		return stats
+	}
+
+	compile("a")
+	compile("b")
+	assert.True(t, compile("a").CacheHit) // "a" is now most recently used
+	compile("c")                          // evicts "b"
+	assert.Equal(t, 2, cache.len())
+
+	assert.True(t, compile("a").CacheHit)
+	assert.False(t, compile("b").CacheHit)
+	assert.Equal(t, 4, compiles)
+}
@@ -9,14 +9,21 @@ var (
 	// ErrEvaluator is the base error type for evaluator package errors.
 	ErrEvaluator = errors.New("evaluator error")
 
-	ErrBothCodeAndURI       = fmt.Errorf("%w: cannot have both code and uri", ErrEvaluator)
-	ErrCompilationFailed    = fmt.Errorf("%w: script compilation failed", ErrEvaluator)
-	ErrEmptyCode            = fmt.Errorf("%w: empty code", ErrEvaluator)
-	ErrEmptyEntrypoint      = fmt.Errorf("%w: empty entrypoint", ErrEvaluator)
-	ErrInvalidEvaluatorType = fmt.Errorf("%w: invalid evaluator type", ErrEvaluator)
-	ErrLoaderCreation       = fmt.Errorf("%w: failed to create script loader", ErrEvaluator)
-	ErrMissingCodeAndURI    = fmt.Errorf("%w: must have either code or uri", ErrEvaluator)
-	ErrNegativeTimeout      = fmt.Errorf("%w: negative timeout", ErrEvaluator)
+	ErrBothCodeAndURI          = fmt.Errorf("%w: cannot have both code and uri", ErrEvaluator)
+	ErrCompilationFailed       = fmt.Errorf("%w: script compilation failed", ErrEvaluator)
+	ErrDuplicateHostFunction   = fmt.Errorf("%w: duplicate host function", ErrEvaluator)
+	ErrEmptyCode               = fmt.Errorf("%w: empty code", ErrEvaluator)
+	ErrEmptyEntrypoint         = fmt.Errorf("%w: empty entrypoint", ErrEvaluator)
+	ErrFuelExhausted           = fmt.Errorf("%w: WASM fuel exhausted", ErrEvaluator)
+	ErrInvalidEvaluatorType    = fmt.Errorf("%w: invalid evaluator type", ErrEvaluator)
+	ErrInvalidMemoryLimit      = fmt.Errorf("%w: invalid WASM memory limit", ErrEvaluator)
+	ErrLoaderCreation          = fmt.Errorf("%w: failed to create script loader", ErrEvaluator)
+	ErrMissingCodeAndURI       = fmt.Errorf("%w: must have either code or uri", ErrEvaluator)
+	ErrNegativeFuel            = fmt.Errorf("%w: negative fuel", ErrEvaluator)
+	ErrNegativeTimeout         = fmt.Errorf("%w: negative timeout", ErrEvaluator)
+	ErrNegativeURICacheTTL     = fmt.Errorf("%w: negative uri cache ttl", ErrEvaluator)
+	ErrUnavailableHostFunction = fmt.Errorf("%w: host function not available to module", ErrEvaluator)
+	ErrUnknownHostFunction     = fmt.Errorf("%w: unknown host function", ErrEvaluator)
 )
 
 // NewInvalidEvaluatorTypeError returns a new error for an invalid evaluator type.
@@ -20,6 +20,7 @@ const (
 	EvaluatorTypeRisor
 	EvaluatorTypeStarlark
 	EvaluatorTypeExtism
+	EvaluatorTypeJavaScript
 )
 
 // Evaluator is the common interface for all script evaluators.
@@ -39,9 +40,46 @@ func (t EvaluatorType) String() string {
 		return "Starlark"
 	case EvaluatorTypeExtism:
 		return "Extism"
+	case EvaluatorTypeJavaScript:
+		return "JavaScript"
 	case EvaluatorTypeUnspecified:
 		return "Unspecified"
 	default:
 		return fmt.Sprintf("Unknown(%d)", t)
 	}
 }
+
+// CanCallGoFunctions reports whether scripts of this type can call Go
+// functions passed in their input data, such as a response stream. Risor and
+// JavaScript convert functions to callables; Starlark rejects them, and Extism
+// modules only receive JSON.
+func (t EvaluatorType) CanCallGoFunctions() bool {
+	return t == EvaluatorTypeRisor || t == EvaluatorTypeJavaScript
+}
+
+// ResolveTimeout returns the execution timeout for an evaluator. Evaluators
+// that don't set their own timeout use fallback when it's positive, in which
+// case usedFallback is true; otherwise GetTimeout supplies the timeout.
+func ResolveTimeout(e Evaluator, fallback time.Duration) (timeout time.Duration, usedFallback bool) {
+	if fallback > 0 && !hasExplicitTimeout(e) {
+		return fallback, true
+	}
+	return e.GetTimeout(), false
+}
+
+// hasExplicitTimeout reports whether the evaluator was configured with its own
+// timeout. Evaluator implementations outside this package are assumed to have one.
+func hasExplicitTimeout(e Evaluator) bool {
+	switch v := e.(type) {
+	case *RisorEvaluator:
+		return v.Timeout > 0
+	case *StarlarkEvaluator:
+		return v.Timeout > 0
+	case *ExtismEvaluator:
+		return v.Timeout > 0
+	case *JavaScriptEvaluator:
+		return v.Timeout > 0
+	default:
+		return true
+	}
+}
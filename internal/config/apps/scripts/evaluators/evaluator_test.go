@@ -2,6 +2,7 @@ package evaluators
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -12,6 +13,7 @@ func TestEvaluatorTypeConstants(t *testing.T) {
 	assert.Equal(t, EvaluatorTypeRisor, EvaluatorType(1))
 	assert.Equal(t, EvaluatorTypeStarlark, EvaluatorType(2))
 	assert.Equal(t, EvaluatorTypeExtism, EvaluatorType(3))
+	assert.Equal(t, EvaluatorTypeJavaScript, EvaluatorType(4))
 }
 
 func TestEvaluatorType_String(t *testing.T) {
@@ -40,6 +42,11 @@ func TestEvaluatorType_String(t *testing.T) {
 			typ:  EvaluatorTypeExtism,
 			want: "Extism",
 		},
+		{
+			name: "javascript",
+			typ:  EvaluatorTypeJavaScript,
+			want: "JavaScript",
+		},
 		{
 			name: "unknown",
 			typ:  EvaluatorType(99),
@@ -54,3 +61,64 @@ func TestEvaluatorType_String(t *testing.T) {
 		})
 	}
 }
+
+func TestEvaluatorType_CanCallGoFunctions(t *testing.T) {
+	assert.True(t, EvaluatorTypeRisor.CanCallGoFunctions())
+	assert.True(t, EvaluatorTypeJavaScript.CanCallGoFunctions())
+	assert.False(t, EvaluatorTypeStarlark.CanCallGoFunctions())
+	assert.False(t, EvaluatorTypeExtism.CanCallGoFunctions())
+	assert.False(t, EvaluatorTypeUnspecified.CanCallGoFunctions())
+}
+
+func TestResolveTimeout(t *testing.T) {
+	const serverDefault = 5 * time.Second
+
+	tests := []struct {
+		name         string
+		evaluator    Evaluator
+		fallback     time.Duration
+		wantTimeout  time.Duration
+		wantFallback bool
+	}{
+		{
+			name:         "risor without timeout inherits fallback",
+			evaluator:    &RisorEvaluator{Code: "1"},
+			fallback:     serverDefault,
+			wantTimeout:  serverDefault,
+			wantFallback: true,
+		},
+		{
+			name:         "starlark without timeout inherits fallback",
+			evaluator:    &StarlarkEvaluator{Code: "1"},
+			fallback:     serverDefault,
+			wantTimeout:  serverDefault,
+			wantFallback: true,
+		},
+		{
+			name:         "extism without timeout inherits fallback",
+			evaluator:    &ExtismEvaluator{},
+			fallback:     serverDefault,
+			wantTimeout:  serverDefault,
+			wantFallback: true,
+		},
+		{
+			name:        "explicit timeout is kept",
+			evaluator:   &RisorEvaluator{Code: "1", Timeout: 2 * time.Second},
+			fallback:    serverDefault,
+			wantTimeout: 2 * time.Second,
+		},
+		{
+			name:        "no fallback uses built-in default",
+			evaluator:   &RisorEvaluator{Code: "1"},
+			wantTimeout: DefaultEvalTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, usedFallback := ResolveTimeout(tt.evaluator, tt.fallback)
+			assert.Equal(t, tt.wantTimeout, timeout)
+			assert.Equal(t, tt.wantFallback, usedFallback)
+		})
+	}
+}
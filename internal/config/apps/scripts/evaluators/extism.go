@@ -6,13 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 	"github.com/robbyt/go-polyscript/engines/extism"
-	"github.com/robbyt/go-polyscript/engines/extism/evaluator"
+	"github.com/robbyt/go-polyscript/engines/extism/compiler"
+	extismEvaluator "github.com/robbyt/go-polyscript/engines/extism/evaluator"
 	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+	"github.com/robbyt/go-polyscript/platform/script"
 	"github.com/robbyt/go-polyscript/platform/script/loader"
 )
 
@@ -28,9 +33,25 @@ type ExtismEvaluator struct {
 	Entrypoint string `env_interpolation:"yes"`
 	// Timeout is the maximum execution time allowed for the script.
 	Timeout time.Duration
+	// URICacheTTL is how long a script fetched from an http(s) URI is reused
+	// before the origin is revalidated. Zero uses DefaultURICacheTTL.
+	URICacheTTL time.Duration
+	// HostFunctions lists the host functions the module may import, see
+	// HostFunctionNames. A module importing any other host function fails to
+	// compile.
+	HostFunctions []string `env_interpolation:"no"`
+	// MaxMemoryPages caps the memory of the module in 64KiB pages, up to
+	// MaxWASMMemoryPages. Zero is unlimited.
+	MaxMemoryPages int
+	// Fuel is the number of WASM function calls an evaluation may make before
+	// it fails with ErrFuelExhausted. Zero is unlimited.
+	Fuel int64
 
-	// compiledEvaluator stores the concrete Extism evaluator after compilation
-	compiledEvaluator *evaluator.Evaluator
+	// compiledEvaluator stores the evaluator after compilation, possibly shared
+	// with other evaluators compiled from the same module and entrypoint
+	compiledEvaluator platform.Evaluator
+	// compileStats records how compiledEvaluator was obtained
+	compileStats *CompileStats
 	// buildOnce ensures build() is called exactly once
 	buildOnce sync.Once
 	// buildErr stores any error from the build process
@@ -47,11 +68,22 @@ func (e *ExtismEvaluator) String() string {
 	if e == nil {
 		return "Extism(nil)"
 	}
+	var extra strings.Builder
+	if len(e.HostFunctions) > 0 {
+		fmt.Fprintf(&extra, ", host_functions=%s", strings.Join(e.HostFunctions, ","))
+	}
+	if e.MaxMemoryPages > 0 {
+		fmt.Fprintf(&extra, ", max_memory_pages=%d", e.MaxMemoryPages)
+	}
+	if e.Fuel > 0 {
+		fmt.Fprintf(&extra, ", fuel=%d", e.Fuel)
+	}
 	return fmt.Sprintf(
-		"Extism(code=%d chars, entrypoint=%s, timeout=%s)",
+		"Extism(code=%d chars, entrypoint=%s, timeout=%s%s)",
 		len(e.Code),
 		e.Entrypoint,
 		e.Timeout,
+		extra.String(),
 	)
 }
 
@@ -80,6 +112,19 @@ func (e *ExtismEvaluator) Validate() error {
 	if e.Timeout < 0 {
 		errs = append(errs, ErrNegativeTimeout)
 	}
+	if e.URICacheTTL < 0 {
+		errs = append(errs, ErrNegativeURICacheTTL)
+	}
+	if e.MaxMemoryPages < 0 || e.MaxMemoryPages > MaxWASMMemoryPages {
+		errs = append(errs, fmt.Errorf("%w: must be between 0 and %d pages, got %d",
+			ErrInvalidMemoryLimit, MaxWASMMemoryPages, e.MaxMemoryPages))
+	}
+	if e.Fuel < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrNegativeFuel, e.Fuel))
+	}
+	if err := validateHostFunctionNames(e.HostFunctions); err != nil {
+		errs = append(errs, err)
+	}
 
 	// If basic validation failed, don't attempt compilation
 	if len(errs) > 0 {
@@ -120,20 +165,28 @@ func (e *ExtismEvaluator) build() {
 			}
 		} else if e.URI != "" {
 			// Use shared loader creation for URI-based loading
-			scriptLoader, err = createLoaderFromSource("", e.URI)
+			scriptLoader, err = createLoaderFromSource("", e.URI, e.URICacheTTL)
 			if err != nil {
 				e.buildErr = fmt.Errorf("%w: %w", ErrLoaderCreation, err)
 				return
 			}
 		}
 
-		// Compile WASM module using go-polyscript
+		// Compile the WASM module, reusing a cached evaluator if the module,
+		// entrypoint, limits, and host functions are unchanged
 		logger := slog.Default()
-		e.compiledEvaluator, err = extism.FromExtismLoader(
-			context.Background(),
+		options := append([]string{
+			e.Entrypoint,
+			fmt.Sprintf("max_memory_pages=%d", e.MaxMemoryPages),
+			fmt.Sprintf("fuel=%d", e.Fuel),
+		}, e.HostFunctions...)
+		e.compiledEvaluator, e.compileStats, err = compiledCache.compile(
+			EvaluatorTypeExtism,
 			scriptLoader,
-			extism.WithEntryPoint(e.Entrypoint),
-			extism.WithLogHandler(logger.Handler()),
+			options,
+			func(ldr loader.Loader) (platform.Evaluator, error) {
+				return e.compile(context.Background(), ldr, logger)
+			},
 		)
 		if err != nil {
 			e.buildErr = fmt.Errorf(
@@ -146,6 +199,52 @@ func (e *ExtismEvaluator) build() {
 	})
 }
 
+// compile checks the module's host function imports and compiles it with the
+// enabled host functions and resource limits. It mirrors
+// extism.FromExtismLoader, which has no options for either.
+func (e *ExtismEvaluator) compile(
+	ctx context.Context,
+	ldr loader.Loader,
+	logger *slog.Logger,
+) (platform.Evaluator, error) {
+	source, err := readLoader(ldr)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHostFunctionImports(ctx, source, e.HostFunctions); err != nil {
+		return nil, err
+	}
+
+	comp, err := extism.NewCompiler(
+		compiler.WithEntryPoint(e.Entrypoint),
+		compiler.WithLogHandler(logger.Handler()),
+		compiler.WithHostFunctions(newHostFunctions(e.HostFunctions, logger)),
+		compiler.WithRuntimeConfig(newExtismRuntimeConfig(e.MaxMemoryPages)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Extism compiler: %w", err)
+	}
+
+	if e.Fuel > 0 {
+		ctx = withFuelListener(ctx)
+	}
+	execUnitID := ""
+	if u := ldr.GetSourceURL(); u != nil {
+		execUnitID = u.String()
+	}
+	provider := data.NewContextProvider(constants.EvalData)
+	execUnit, err := script.NewExecutableUnit(ctx, logger.Handler(), execUnitID, ldr, comp, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluator := extismEvaluator.New(logger.Handler(), execUnit)
+	if e.Fuel > 0 {
+		return &fuelLimitedEvaluator{Evaluator: evaluator, fuel: e.Fuel}, nil
+	}
+	return evaluator, nil
+}
+
 // GetCompiledEvaluator returns the abstract platform.Evaluator interface.
 func (e *ExtismEvaluator) GetCompiledEvaluator() (platform.Evaluator, error) {
 	e.build()
@@ -0,0 +1,175 @@
+package evaluators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	extismSDK "github.com/extism/go-sdk"
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+	"github.com/tetratelabs/wazero"
+)
+
+// Host functions that Extism modules can import, once enabled in the
+// evaluator's HostFunctions
+const (
+	// HostFunctionLog logs a message through the server's logger:
+	// firelynx_log(level: ptr, message: ptr)
+	HostFunctionLog = "firelynx_log"
+
+	// HostFunctionGetStaticData returns the JSON encoding of a static data
+	// value: firelynx_get_static_data(key: ptr) -> ptr
+	HostFunctionGetStaticData = "firelynx_get_static_data"
+)
+
+// hostFunctionNamespace is the module name host functions are imported from
+const hostFunctionNamespace = "extism:host/user"
+
+// hostFunctions builds each host function that can be enabled, by name
+var hostFunctions = map[string]func(logger *slog.Logger) extismSDK.HostFunction{
+	HostFunctionLog:           newLogHostFunction,
+	HostFunctionGetStaticData: newGetStaticDataHostFunction,
+}
+
+// HostFunctionNames returns the sorted names of the host functions that can
+// be enabled for Extism modules
+func HostFunctionNames() []string {
+	names := make([]string, 0, len(hostFunctions))
+	for name := range hostFunctions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// newHostFunctions builds the named host functions, which must be known
+func newHostFunctions(names []string, logger *slog.Logger) []extismSDK.HostFunction {
+	funcs := make([]extismSDK.HostFunction, 0, len(names))
+	for _, name := range names {
+		if build, ok := hostFunctions[name]; ok {
+			funcs = append(funcs, build(logger))
+		}
+	}
+	return funcs
+}
+
+// validateHostFunctionNames checks that each name is a known host function,
+// listed once
+func validateHostFunctionNames(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := hostFunctions[name]; !ok {
+			return fmt.Errorf("%w: %q (available: %s)",
+				ErrUnknownHostFunction, name, strings.Join(HostFunctionNames(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateHostFunction, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// checkHostFunctionImports fails when the module imports a function from the
+// host function namespace that isn't enabled, so the module is rejected when
+// the config is validated rather than when it is first called
+func checkHostFunctionImports(ctx context.Context, wasm []byte, enabled []string) error {
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfigInterpreter())
+	defer func() { _ = runtime.Close(ctx) }()
+
+	module, err := runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		return fmt.Errorf("failed to read WASM module imports: %w", err)
+	}
+
+	for _, fn := range module.ImportedFunctions() {
+		namespace, name, _ := fn.Import()
+		if namespace != hostFunctionNamespace || slices.Contains(enabled, name) {
+			continue
+		}
+		if _, ok := hostFunctions[name]; ok {
+			return fmt.Errorf("%w: module imports %q, which is not listed in host_functions",
+				ErrUnavailableHostFunction, name)
+		}
+		return fmt.Errorf("%w: module imports unknown host function %q (available: %s)",
+			ErrUnavailableHostFunction, name, strings.Join(HostFunctionNames(), ", "))
+	}
+	return nil
+}
+
+// newLogHostFunction logs the message at the given level, one of "debug",
+// "info", "warn" or "error"; other levels log at info
+func newLogHostFunction(logger *slog.Logger) extismSDK.HostFunction {
+	return extismSDK.NewHostFunctionWithStack(
+		HostFunctionLog,
+		func(ctx context.Context, p *extismSDK.CurrentPlugin, stack []uint64) {
+			levelName, err := p.ReadString(stack[0])
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to read log level from WASM memory", "error", err)
+				return
+			}
+			message, err := p.ReadString(stack[1])
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to read log message from WASM memory", "error", err)
+				return
+			}
+
+			level := slog.LevelInfo
+			if err := level.UnmarshalText([]byte(levelName)); err != nil {
+				level = slog.LevelInfo
+			}
+			logger.Log(ctx, level, message)
+		},
+		[]extismSDK.ValueType{extismSDK.ValueTypePTR, extismSDK.ValueTypePTR},
+		[]extismSDK.ValueType{},
+	)
+}
+
+// newGetStaticDataHostFunction returns the JSON encoding of the static data
+// value under the key, the whole static data object for an empty key, or null
+// when the key isn't set. The static data is read from the evaluation context,
+// so it is the data of the app and route being served.
+func newGetStaticDataHostFunction(logger *slog.Logger) extismSDK.HostFunction {
+	provider := data.NewContextProvider(constants.EvalData)
+
+	return extismSDK.NewHostFunctionWithStack(
+		HostFunctionGetStaticData,
+		func(ctx context.Context, p *extismSDK.CurrentPlugin, stack []uint64) {
+			key, err := p.ReadString(stack[0])
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to read static data key from WASM memory", "error", err)
+				key = ""
+			}
+
+			var value any
+			evalData, err := provider.GetData(ctx)
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to get evaluation data", "error", err)
+			}
+			staticData, _ := evalData["data"].(map[string]any)
+			if key == "" {
+				value = staticData
+			} else {
+				value = staticData[key]
+			}
+
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to encode static data", "key", key, "error", err)
+				encoded = []byte("null")
+			}
+
+			offset, err := p.WriteBytes(encoded)
+			if err != nil {
+				logger.WarnContext(ctx, "Failed to write static data to WASM memory", "error", err)
+			}
+			stack[0] = offset
+		},
+		[]extismSDK.ValueType{extismSDK.ValueTypePTR},
+		[]extismSDK.ValueType{extismSDK.ValueTypePTR},
+	)
+}
@@ -0,0 +1,137 @@
+package evaluators
+
+import (
+	"bytes"
+	"encoding/base64"
+	"log/slog"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/robbyt/go-polyscript/engines/extism/wasmdata"
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostFunctionNames(t *testing.T) {
+	assert.Equal(t, []string{HostFunctionGetStaticData, HostFunctionLog}, HostFunctionNames())
+}
+
+func TestValidateHostFunctionNames(t *testing.T) {
+	t.Run("known functions", func(t *testing.T) {
+		require.NoError(t, validateHostFunctionNames(nil))
+		require.NoError(t, validateHostFunctionNames([]string{HostFunctionLog, HostFunctionGetStaticData}))
+	})
+
+	t.Run("unknown function", func(t *testing.T) {
+		err := validateHostFunctionNames([]string{"firelynx_exec"})
+		require.ErrorIs(t, err, ErrUnknownHostFunction)
+		assert.Contains(t, err.Error(), `"firelynx_exec"`)
+		assert.Contains(t, err.Error(), HostFunctionLog)
+	})
+
+	t.Run("duplicate function", func(t *testing.T) {
+		err := validateHostFunctionNames([]string{HostFunctionLog, HostFunctionLog})
+		require.ErrorIs(t, err, ErrDuplicateHostFunction)
+	})
+}
+
+func TestCheckHostFunctionImports(t *testing.T) {
+	module := testutil.HostFunctionModule{Key: "greeting", Log: true}.Bytes()
+
+	t.Run("all imports enabled", func(t *testing.T) {
+		err := checkHostFunctionImports(t.Context(), module,
+			[]string{HostFunctionGetStaticData, HostFunctionLog})
+		require.NoError(t, err)
+	})
+
+	t.Run("import not enabled", func(t *testing.T) {
+		err := checkHostFunctionImports(t.Context(), module, []string{HostFunctionGetStaticData})
+		require.ErrorIs(t, err, ErrUnavailableHostFunction)
+		assert.Contains(t, err.Error(), HostFunctionLog)
+	})
+
+	t.Run("unknown import", func(t *testing.T) {
+		unknown := testutil.HostFunctionModule{Key: "greeting", GetStaticData: "firelynx_exec"}.Bytes()
+		err := checkHostFunctionImports(t.Context(), unknown, HostFunctionNames())
+		require.ErrorIs(t, err, ErrUnavailableHostFunction)
+		assert.Contains(t, err.Error(), "firelynx_exec")
+	})
+
+	t.Run("module without host function imports", func(t *testing.T) {
+		require.NoError(t, checkHostFunctionImports(t.Context(), wasmdata.TestModule, nil))
+	})
+
+	t.Run("invalid module", func(t *testing.T) {
+		require.Error(t, checkHostFunctionImports(t.Context(), []byte("not wasm"), nil))
+	})
+}
+
+func TestExtismEvaluator_HostFunctions(t *testing.T) {
+	newEvaluator := func(module testutil.HostFunctionModule, hostFunctions ...string) *ExtismEvaluator {
+		return &ExtismEvaluator{
+			Code:          base64.StdEncoding.EncodeToString(module.Bytes()),
+			Entrypoint:    testutil.HostFunctionModuleEntrypoint,
+			HostFunctions: hostFunctions,
+		}
+	}
+
+	eval := func(t *testing.T, e *ExtismEvaluator, staticData map[string]any) any {
+		t.Helper()
+		require.NoError(t, e.Validate())
+		compiled, err := e.GetCompiledEvaluator()
+		require.NoError(t, err)
+
+		ctx, err := data.NewContextProvider(constants.EvalData).
+			AddDataToContext(t.Context(), map[string]any{"data": staticData})
+		require.NoError(t, err)
+		result, err := compiled.Eval(ctx)
+		require.NoError(t, err)
+		return result.Interface()
+	}
+
+	t.Run("get static data", func(t *testing.T) {
+		e := newEvaluator(testutil.HostFunctionModule{Key: "greeting"}, HostFunctionGetStaticData)
+		got := eval(t, e, map[string]any{"greeting": "hello", "other": 1})
+		assert.Equal(t, "hello", got)
+	})
+
+	t.Run("get nested static data", func(t *testing.T) {
+		e := newEvaluator(testutil.HostFunctionModule{Key: "db"}, HostFunctionGetStaticData)
+		got := eval(t, e, map[string]any{"db": map[string]any{"host": "db.internal"}})
+		assert.Equal(t, map[string]any{"host": "db.internal"}, got)
+	})
+
+	t.Run("missing key returns null", func(t *testing.T) {
+		e := newEvaluator(testutil.HostFunctionModule{Key: "missing"}, HostFunctionGetStaticData)
+		assert.Nil(t, eval(t, e, map[string]any{"greeting": "hello"}))
+	})
+
+	t.Run("log", func(t *testing.T) {
+		var buf bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&buf, nil)))
+		t.Cleanup(func() { slog.SetDefault(previous) })
+
+		// A key unique to this test keeps the compiled evaluator, which holds
+		// the logger, out of the shared cache entries of other tests
+		e := newEvaluator(testutil.HostFunctionModule{Key: "log-test", Log: true},
+			HostFunctionGetStaticData, HostFunctionLog)
+		got := eval(t, e, map[string]any{"log-test": true})
+		assert.Equal(t, true, got)
+		assert.Contains(t, buf.String(), "level=INFO msg=log-test")
+	})
+
+	t.Run("import not enabled fails validation", func(t *testing.T) {
+		e := newEvaluator(testutil.HostFunctionModule{Key: "greeting", Log: true}, HostFunctionGetStaticData)
+		err := e.Validate()
+		require.ErrorIs(t, err, ErrCompilationFailed)
+		require.ErrorIs(t, err, ErrUnavailableHostFunction)
+	})
+
+	t.Run("unknown host function fails validation", func(t *testing.T) {
+		e := newEvaluator(testutil.HostFunctionModule{Key: "greeting"}, HostFunctionGetStaticData, "firelynx_exec")
+		require.ErrorIs(t, e.Validate(), ErrUnknownHostFunction)
+	})
+}
@@ -0,0 +1,87 @@
+package evaluators
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/experimental"
+)
+
+// MaxWASMMemoryPages is the largest memory limit, in 64KiB pages, that an
+// Extism evaluator accepts: the 4GiB addressable by 32-bit WASM memory
+const MaxWASMMemoryPages = 65536
+
+// newExtismRuntimeConfig returns the wazero runtime config for a module.
+// Executions always end when their context is done, so the evaluator timeout
+// interrupts a module stuck in a loop. A memory limit caps every memory of the
+// module, including the Extism kernel memory used for input and output.
+func newExtismRuntimeConfig(maxMemoryPages int) wazero.RuntimeConfig {
+	cfg := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if maxMemoryPages > 0 {
+		cfg = cfg.WithMemoryLimitPages(uint32(maxMemoryPages))
+	}
+	return cfg
+}
+
+// withFuelListener returns a context for compiling a module whose function
+// calls consume fuel. Only evaluations started through fuelLimitedEvaluator
+// carry a fuel tank, so other calls, such as the compile-time probe, are free.
+func withFuelListener(ctx context.Context) context.Context {
+	return experimental.WithFunctionListenerFactory(ctx, fuelListenerFactory{})
+}
+
+// fuelTankKey is the context key of the fuel tank of an evaluation
+type fuelTankKey struct{}
+
+// fuelTank holds the fuel left for an evaluation
+type fuelTank struct {
+	remaining atomic.Int64
+}
+
+// fuelListenerFactory returns the same listener for every function
+type fuelListenerFactory struct{}
+
+func (fuelListenerFactory) NewFunctionListener(api.FunctionDefinition) experimental.FunctionListener {
+	return fuelListener{}
+}
+
+// fuelListener consumes one unit of fuel for each function call. When the
+// tank is empty it panics with ErrFuelExhausted, which wazero recovers and
+// returns as the error of the call.
+type fuelListener struct{}
+
+func (fuelListener) Before(
+	ctx context.Context,
+	_ api.Module,
+	_ api.FunctionDefinition,
+	_ []uint64,
+	_ experimental.StackIterator,
+) {
+	tank, ok := ctx.Value(fuelTankKey{}).(*fuelTank)
+	if !ok {
+		return
+	}
+	if tank.remaining.Add(-1) < 0 {
+		panic(ErrFuelExhausted)
+	}
+}
+
+func (fuelListener) After(context.Context, api.Module, api.FunctionDefinition, []uint64) {}
+
+func (fuelListener) Abort(context.Context, api.Module, api.FunctionDefinition, error) {}
+
+// fuelLimitedEvaluator fills a new fuel tank for every evaluation
+type fuelLimitedEvaluator struct {
+	platform.Evaluator
+	fuel int64
+}
+
+// Eval runs the module with a full fuel tank
+func (e *fuelLimitedEvaluator) Eval(ctx context.Context) (platform.EvaluatorResponse, error) {
+	tank := &fuelTank{}
+	tank.remaining.Store(e.fuel)
+	return e.Evaluator.Eval(context.WithValue(ctx, fuelTankKey{}, tank))
+}
@@ -0,0 +1,111 @@
+package evaluators
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtismEvaluator_ValidateLimits(t *testing.T) {
+	newEvaluator := func(maxMemoryPages int, fuel int64) *ExtismEvaluator {
+		return &ExtismEvaluator{
+			Code:           base64.StdEncoding.EncodeToString(testutil.MemoryGrowModule(1)),
+			Entrypoint:     testutil.MemoryGrowModuleEntrypoint,
+			MaxMemoryPages: maxMemoryPages,
+			Fuel:           fuel,
+		}
+	}
+
+	t.Run("valid limits", func(t *testing.T) {
+		require.NoError(t, newEvaluator(0, 0).Validate())
+		require.NoError(t, newEvaluator(16, 1000).Validate())
+		require.NoError(t, newEvaluator(MaxWASMMemoryPages, 1).Validate())
+	})
+
+	t.Run("negative memory limit", func(t *testing.T) {
+		require.ErrorIs(t, newEvaluator(-1, 0).Validate(), ErrInvalidMemoryLimit)
+	})
+
+	t.Run("memory limit above 4GiB", func(t *testing.T) {
+		require.ErrorIs(t, newEvaluator(MaxWASMMemoryPages+1, 0).Validate(), ErrInvalidMemoryLimit)
+	})
+
+	t.Run("negative fuel", func(t *testing.T) {
+		require.ErrorIs(t, newEvaluator(0, -1).Validate(), ErrNegativeFuel)
+	})
+}
+
+func TestExtismEvaluator_Limits(t *testing.T) {
+	eval := func(t *testing.T, ctx context.Context, e *ExtismEvaluator) error {
+		t.Helper()
+		require.NoError(t, e.Validate())
+		compiled, err := e.GetCompiledEvaluator()
+		require.NoError(t, err)
+		_, err = compiled.Eval(ctx)
+		return err
+	}
+
+	t.Run("memory growth within limit", func(t *testing.T) {
+		e := &ExtismEvaluator{
+			Code:           base64.StdEncoding.EncodeToString(testutil.MemoryGrowModule(2)),
+			Entrypoint:     testutil.MemoryGrowModuleEntrypoint,
+			MaxMemoryPages: 32,
+		}
+		require.NoError(t, eval(t, t.Context(), e))
+	})
+
+	t.Run("memory growth past limit fails", func(t *testing.T) {
+		e := &ExtismEvaluator{
+			Code:           base64.StdEncoding.EncodeToString(testutil.MemoryGrowModule(64)),
+			Entrypoint:     testutil.MemoryGrowModuleEntrypoint,
+			MaxMemoryPages: 32,
+		}
+		err := eval(t, t.Context(), e)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unreachable")
+	})
+
+	t.Run("memory growth without limit", func(t *testing.T) {
+		e := &ExtismEvaluator{
+			Code:       base64.StdEncoding.EncodeToString(testutil.MemoryGrowModule(64)),
+			Entrypoint: testutil.MemoryGrowModuleEntrypoint,
+		}
+		require.NoError(t, eval(t, t.Context(), e))
+	})
+
+	t.Run("fuel exhausted", func(t *testing.T) {
+		e := &ExtismEvaluator{
+			Code:       base64.StdEncoding.EncodeToString(testutil.SpinModule()),
+			Entrypoint: testutil.SpinModuleEntrypoint,
+			Fuel:       10_000,
+		}
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		defer cancel()
+
+		err := eval(t, ctx, e)
+		require.ErrorIs(t, err, ErrFuelExhausted)
+		require.NoError(t, ctx.Err(), "fuel should run out before the timeout")
+
+		// Every evaluation gets a full tank
+		err = eval(t, ctx, e)
+		require.ErrorIs(t, err, ErrFuelExhausted)
+	})
+
+	t.Run("timeout interrupts a loop without fuel", func(t *testing.T) {
+		e := &ExtismEvaluator{
+			Code:       base64.StdEncoding.EncodeToString(testutil.SpinModule()),
+			Entrypoint: testutil.SpinModuleEntrypoint,
+		}
+		ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+		defer cancel()
+
+		err := eval(t, ctx, e)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -39,6 +39,25 @@ func TestExtismEvaluator_String(t *testing.T) {
 			},
 			want: "Extism(code=13 chars, entrypoint=handle_request, timeout=0s)",
 		},
+		{
+			name: "with host functions",
+			evaluator: &ExtismEvaluator{
+				Code:          "base64content",
+				Entrypoint:    "handle_request",
+				HostFunctions: []string{HostFunctionLog, HostFunctionGetStaticData},
+			},
+			want: "Extism(code=13 chars, entrypoint=handle_request, timeout=0s, host_functions=firelynx_log,firelynx_get_static_data)",
+		},
+		{
+			name: "with limits",
+			evaluator: &ExtismEvaluator{
+				Code:           "base64content",
+				Entrypoint:     "handle_request",
+				MaxMemoryPages: 16,
+				Fuel:           1000,
+			},
+			want: "Extism(code=13 chars, entrypoint=handle_request, timeout=0s, max_memory_pages=16, fuel=1000)",
+		},
 	}
 
 	for _, tt := range tests {
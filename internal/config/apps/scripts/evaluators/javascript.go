@@ -0,0 +1,136 @@
+package evaluators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators/jsengine"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+)
+
+var _ Evaluator = (*JavaScriptEvaluator)(nil)
+
+// JavaScriptEvaluator represents a JavaScript script evaluator, executed with goja.
+type JavaScriptEvaluator struct {
+	// Code contains the JavaScript source code.
+	Code string `env_interpolation:"no"`
+	// URI contains the location to load the script from (file://, https://, etc.)
+	URI string `env_interpolation:"yes"`
+	// Timeout is the maximum execution time allowed for the script.
+	Timeout time.Duration
+	// URICacheTTL is how long a script fetched from an http(s) URI is reused
+	// before the origin is revalidated. Zero uses DefaultURICacheTTL.
+	URICacheTTL time.Duration
+
+	// compiledEvaluator stores the evaluator after compilation, possibly shared
+	// with other evaluators compiled from the same source
+	compiledEvaluator platform.Evaluator
+	// compileStats records how compiledEvaluator was obtained
+	compileStats *CompileStats
+	// buildOnce ensures build() is called exactly once
+	buildOnce sync.Once
+	// buildErr stores any error from the build process
+	buildErr error
+}
+
+// Type returns the type of this evaluator.
+func (j *JavaScriptEvaluator) Type() EvaluatorType {
+	return EvaluatorTypeJavaScript
+}
+
+// String returns a string representation of the JavaScriptEvaluator.
+func (j *JavaScriptEvaluator) String() string {
+	if j == nil {
+		return "JavaScript(nil)"
+	}
+	return fmt.Sprintf("JavaScript(code=%d chars, timeout=%s)", len(j.Code), j.Timeout)
+}
+
+// Validate checks if the JavaScriptEvaluator is valid and compiles the script.
+func (j *JavaScriptEvaluator) Validate() error {
+	var errs []error
+
+	// Interpolate all tagged fields
+	if err := interpolation.InterpolateStruct(j); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed for JavaScript evaluator: %w", err))
+	}
+
+	// XOR validation: either code OR uri must be present, but not both and not neither
+	if j.Code == "" && j.URI == "" {
+		errs = append(errs, ErrMissingCodeAndURI)
+	}
+	if j.Code != "" && j.URI != "" {
+		errs = append(errs, ErrBothCodeAndURI)
+	}
+
+	// Timeout must not be negative
+	if j.Timeout < 0 {
+		errs = append(errs, ErrNegativeTimeout)
+	}
+	if j.URICacheTTL < 0 {
+		errs = append(errs, ErrNegativeURICacheTTL)
+	}
+
+	// If basic validation failed, don't attempt compilation
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Trigger compilation
+	j.build()
+	return j.buildErr
+}
+
+// build compiles the script - called lazily by Validate() or GetCompiledEvaluator()
+func (j *JavaScriptEvaluator) build() {
+	j.buildOnce.Do(func() {
+		// Create loader based on source type
+		scriptLoader, err := createLoaderFromSource(j.Code, j.URI, j.URICacheTTL)
+		if err != nil {
+			j.buildErr = fmt.Errorf("%w: %w", ErrLoaderCreation, err)
+			return
+		}
+
+		// Compile the script, reusing a cached evaluator if the source is unchanged
+		logger := slog.Default()
+		j.compiledEvaluator, j.compileStats, err = compiledCache.compile(
+			EvaluatorTypeJavaScript,
+			scriptLoader,
+			nil,
+			func(ldr loader.Loader) (platform.Evaluator, error) {
+				return jsengine.FromLoader(context.Background(), ldr, logger.Handler())
+			},
+		)
+		if err != nil {
+			j.buildErr = fmt.Errorf(
+				"%w: javascript script compilation failed: %w",
+				ErrCompilationFailed,
+				err,
+			)
+			return
+		}
+	})
+}
+
+// GetCompiledEvaluator returns the abstract platform.Evaluator interface.
+func (j *JavaScriptEvaluator) GetCompiledEvaluator() (platform.Evaluator, error) {
+	j.build()
+	if j.buildErr != nil {
+		return nil, j.buildErr
+	}
+	return j.compiledEvaluator, nil
+}
+
+// GetTimeout returns the timeout duration, with a default fallback.
+func (j *JavaScriptEvaluator) GetTimeout() time.Duration {
+	if j.Timeout > 0 {
+		return j.Timeout
+	}
+	return DefaultEvalTimeout
+}
@@ -0,0 +1,125 @@
+// Package jsengine runs JavaScript scripts on the goja engine behind the
+// go-polyscript platform.Evaluator interface, so JavaScript apps are executed
+// the same way as the Risor and Starlark engines provided by go-polyscript.
+package jsengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+)
+
+var _ platform.Evaluator = (*Evaluator)(nil)
+
+var (
+	// ErrEmptyScript is returned when the loaded script has no content
+	ErrEmptyScript = errors.New("empty script")
+	// ErrFunctionResult is returned when a script evaluates to a function
+	ErrFunctionResult = errors.New("function object returned from script")
+)
+
+// Evaluator executes a compiled JavaScript program. The program is compiled
+// once and each evaluation runs it on a fresh goja runtime, with the input
+// data exposed as the global "ctx" object. The script's completion value (the
+// value of its last statement) is the result.
+type Evaluator struct {
+	id       string
+	program  *goja.Program
+	provider data.Provider
+	logger   *slog.Logger
+}
+
+// FromLoader reads and compiles the script from ldr. Input data is read from
+// the context key used by go-polyscript's context provider.
+func FromLoader(ctx context.Context, ldr loader.Loader, handler slog.Handler) (*Evaluator, error) {
+	if handler == nil {
+		handler = slog.Default().Handler()
+	}
+
+	id := ""
+	if u := ldr.GetSourceURL(); u != nil {
+		id = u.String()
+	}
+
+	reader, err := ldr.GetReader(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	source, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	if len(source) == 0 {
+		return nil, ErrEmptyScript
+	}
+
+	program, err := goja.Compile(id, string(source), false)
+	if err != nil {
+		return nil, fmt.Errorf("javascript compilation failed: %w", err)
+	}
+
+	return &Evaluator{
+		id:       id,
+		program:  program,
+		provider: data.NewContextProvider(constants.EvalData),
+		logger:   slog.New(handler).WithGroup("jsengine"),
+	}, nil
+}
+
+// String returns the name of the evaluator
+func (e *Evaluator) String() string {
+	return "jsengine.Evaluator"
+}
+
+// Eval runs the program with the input data stored in ctx. The run is
+// interrupted when ctx is done.
+func (e *Evaluator) Eval(ctx context.Context) (platform.EvaluatorResponse, error) {
+	input, err := data.LoadInputData(ctx, e.logger, e.provider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get input data: %w", err)
+	}
+
+	vm := goja.New()
+	if err := vm.Set(constants.Ctx, input); err != nil {
+		return nil, fmt.Errorf("failed to set %s global: %w", constants.Ctx, err)
+	}
+
+	stop := context.AfterFunc(ctx, func() { vm.Interrupt(ctx.Err()) })
+	defer stop()
+
+	start := time.Now()
+	value, err := vm.RunProgram(e.program)
+	execTime := time.Since(start)
+	if err != nil {
+		var interrupted *goja.InterruptedError
+		if errors.As(err, &interrupted) {
+			if cause, ok := interrupted.Value().(error); ok {
+				return nil, fmt.Errorf("javascript execution interrupted: %w", cause)
+			}
+		}
+		return nil, fmt.Errorf("javascript execution error: %w", err)
+	}
+
+	if _, isFunc := goja.AssertFunction(value); isFunc {
+		return nil, fmt.Errorf("%w: %s", ErrFunctionResult, value.String())
+	}
+
+	e.logger.DebugContext(ctx, "exec complete", "exeID", e.id, "duration", execTime)
+	return newResponse(value, execTime, e.id), nil
+}
+
+// AddDataToContext stores data in the context for a later call to Eval
+func (e *Evaluator) AddDataToContext(ctx context.Context, d map[string]any) (context.Context, error) {
+	return data.AddDataToContextFromProvider(ctx, e.logger, e.provider, d)
+}
@@ -0,0 +1,103 @@
+package jsengine
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/robbyt/go-polyscript/platform/data"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEvaluator(t *testing.T, code string) *Evaluator {
+	t.Helper()
+	ldr, err := loader.NewFromString(code)
+	require.NoError(t, err)
+	e, err := FromLoader(t.Context(), ldr, nil)
+	require.NoError(t, err)
+	return e
+}
+
+func TestFromLoader(t *testing.T) {
+	t.Run("syntax error", func(t *testing.T) {
+		ldr, err := loader.NewFromString("let x = ;")
+		require.NoError(t, err)
+		_, err = FromLoader(t.Context(), ldr, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "compilation failed")
+	})
+}
+
+func TestEval(t *testing.T) {
+	t.Run("object result with ctx data", func(t *testing.T) {
+		e := newEvaluator(t, `
+const req = ctx.request;
+({
+  message: "hello " + ctx.data.name,
+  method: req.Method,
+  path: req.URL.Path,
+  count: 2,
+})`)
+
+		ctx, err := e.AddDataToContext(t.Context(), map[string]any{
+			"data":    map[string]any{"name": "js"},
+			"request": httptest.NewRequest("POST", "/api/js", nil),
+		})
+		require.NoError(t, err)
+
+		resp, err := e.Eval(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, data.MAP, resp.Type())
+		m, err := resp.AsMap()
+		require.NoError(t, err)
+		assert.Equal(t, "hello js", m["message"])
+		assert.Equal(t, "POST", m["method"])
+		assert.Equal(t, "/api/js", m["path"])
+		assert.Equal(t, int64(2), m["count"])
+	})
+
+	t.Run("scalar results", func(t *testing.T) {
+		tests := []struct {
+			code     string
+			wantType data.Types
+			want     any
+		}{
+			{`"text"`, data.STRING, "text"},
+			{`1 + 1`, data.INT, int64(2)},
+			{`1.5`, data.FLOAT, 1.5},
+			{`true`, data.BOOL, true},
+			{`[1, "a"]`, data.LIST, []any{int64(1), "a"}},
+			{`undefined`, data.NONE, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.code, func(t *testing.T) {
+				resp, err := newEvaluator(t, tt.code).Eval(t.Context())
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantType, resp.Type())
+				assert.Equal(t, tt.want, resp.Interface())
+			})
+		}
+	})
+
+	t.Run("function result is an error", func(t *testing.T) {
+		_, err := newEvaluator(t, `(function() { return 1 })`).Eval(t.Context())
+		require.ErrorIs(t, err, ErrFunctionResult)
+	})
+
+	t.Run("thrown error", func(t *testing.T) {
+		_, err := newEvaluator(t, `throw new Error("boom")`).Eval(t.Context())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boom")
+	})
+
+	t.Run("interrupted by context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := newEvaluator(t, `while (true) {}`).Eval(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,78 @@
+package jsengine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/data"
+)
+
+var _ platform.EvaluatorResponse = (*response)(nil)
+
+// response is the exported result of a JavaScript evaluation
+type response struct {
+	value    any
+	inspect  string
+	execTime time.Duration
+	exeID    string
+}
+
+func newResponse(value goja.Value, execTime time.Duration, exeID string) *response {
+	r := &response{execTime: execTime, exeID: exeID}
+	if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+		r.inspect = "null"
+		return r
+	}
+
+	r.value = value.Export()
+	r.inspect = value.String()
+	return r
+}
+
+// Type returns the polyscript type of the exported value
+func (r *response) Type() data.Types {
+	switch r.value.(type) {
+	case nil:
+		return data.NONE
+	case bool:
+		return data.BOOL
+	case int64:
+		return data.INT
+	case float64:
+		return data.FLOAT
+	case string:
+		return data.STRING
+	case []any:
+		return data.LIST
+	case map[string]any:
+		return data.MAP
+	case error:
+		return data.ERROR
+	default:
+		return data.Types(fmt.Sprintf("%T", r.value))
+	}
+}
+
+// Inspect returns the JavaScript string form of the value
+func (r *response) Inspect() string { return r.inspect }
+
+// Interface returns the value exported to Go types: objects become
+// map[string]any, arrays []any, and numbers int64 or float64
+func (r *response) Interface() any { return r.value }
+
+// ScriptExeID returns the ID of the evaluated script
+func (r *response) ScriptExeID() string { return r.exeID }
+
+// ExecTime returns how long the script ran
+func (r *response) ExecTime() time.Duration { return r.execTime }
+
+// AsMap returns the value as a map, for scripts that evaluate to an object
+func (r *response) AsMap() (map[string]any, error) {
+	m, ok := r.value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("result is not an object, got %T", r.value)
+	}
+	return m, nil
+}
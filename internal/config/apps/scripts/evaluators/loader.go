@@ -4,36 +4,72 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/robbyt/go-polyscript/platform/script/loader"
 )
 
 // createLoaderFromSource creates a go-polyscript loader based on code or URI.
-// Supports inline code, file:// paths, and http/https URLs.
-func createLoaderFromSource(code, uri string) (loader.Loader, error) {
+// Supports inline code, file:// paths, and http/https URLs. Scripts from
+// http/https URLs are read through the remote script cache, reusing a fetched
+// copy for uriCacheTTL (DefaultURICacheTTL when zero).
+func createLoaderFromSource(code, uri string, uriCacheTTL time.Duration) (loader.Loader, error) {
 	if code != "" {
 		return loader.NewFromString(code)
 	}
 
 	if uri != "" {
 		if strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
-			return loader.NewFromHTTP(uri)
+			return newRemoteLoader(uri, uriCacheTTL, remoteScripts)
 		}
 
-		// Handle file:// prefix - remove it if present and resolve relative paths
-		path := strings.TrimPrefix(uri, "file://")
-
-		// Convert relative paths to absolute paths to work around go-polyscript limitation
-		if !filepath.IsAbs(path) {
-			absPath, err := filepath.Abs(path)
-			if err != nil {
-				return nil, fmt.Errorf("failed to resolve relative path %q: %w", path, err)
-			}
-			path = absPath
+		path, err := localPath(uri)
+		if err != nil {
+			return nil, err
 		}
-
 		return loader.NewFromDisk(path)
 	}
 
 	return nil, fmt.Errorf("neither code nor URI provided")
 }
+
+// localPath returns the absolute path of a file:// or plain path URI
+func localPath(uri string) (string, error) {
+	// Handle file:// prefix - remove it if present and resolve relative paths
+	path := strings.TrimPrefix(uri, "file://")
+
+	// Convert relative paths to absolute paths to work around go-polyscript limitation
+	if !filepath.IsAbs(path) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve relative path %q: %w", path, err)
+		}
+		path = absPath
+	}
+	return path, nil
+}
+
+// LocalPath returns the absolute path of the file an evaluator loads its
+// script from. It returns false for inline code and http/https URIs.
+func LocalPath(e Evaluator) (string, bool) {
+	var uri string
+	switch v := e.(type) {
+	case *RisorEvaluator:
+		uri = v.URI
+	case *StarlarkEvaluator:
+		uri = v.URI
+	case *ExtismEvaluator:
+		uri = v.URI
+	case *JavaScriptEvaluator:
+		uri = v.URI
+	}
+	if uri == "" || strings.HasPrefix(uri, "http://") || strings.HasPrefix(uri, "https://") {
+		return "", false
+	}
+
+	path, err := localPath(uri)
+	if err != nil {
+		return "", false
+	}
+	return path, true
+}
@@ -1,6 +1,8 @@
 package evaluators
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,7 +12,7 @@ import (
 func TestCreateLoaderFromSource(t *testing.T) {
 	t.Run("code only", func(t *testing.T) {
 		code := `func main() { return "hello" }`
-		loader, err := createLoaderFromSource(code, "")
+		loader, err := createLoaderFromSource(code, "", 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -18,7 +20,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 
 	t.Run("uri only - http", func(t *testing.T) {
 		uri := "http://example.com/script.js"
-		loader, err := createLoaderFromSource("", uri)
+		loader, err := createLoaderFromSource("", uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -26,7 +28,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 
 	t.Run("uri only - https", func(t *testing.T) {
 		uri := "https://example.com/script.js"
-		loader, err := createLoaderFromSource("", uri)
+		loader, err := createLoaderFromSource("", uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -34,7 +36,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 
 	t.Run("uri only - file without prefix", func(t *testing.T) {
 		uri := "/path/to/script.js"
-		loader, err := createLoaderFromSource("", uri)
+		loader, err := createLoaderFromSource("", uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -42,7 +44,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 
 	t.Run("uri only - file with prefix", func(t *testing.T) {
 		uri := "file:///path/to/script.js"
-		loader, err := createLoaderFromSource("", uri)
+		loader, err := createLoaderFromSource("", uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -50,7 +52,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 
 	t.Run("uri only - relative file with prefix resolves to absolute", func(t *testing.T) {
 		uri := "file://relative/path/script.js"
-		loader, err := createLoaderFromSource("", uri)
+		loader, err := createLoaderFromSource("", uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
@@ -58,7 +60,7 @@ func TestCreateLoaderFromSource(t *testing.T) {
 	})
 
 	t.Run("neither code nor uri", func(t *testing.T) {
-		loader, err := createLoaderFromSource("", "")
+		loader, err := createLoaderFromSource("", "", 0)
 
 		require.Error(t, err)
 		assert.Nil(t, loader)
@@ -68,10 +70,36 @@ func TestCreateLoaderFromSource(t *testing.T) {
 	t.Run("both code and uri - code takes precedence", func(t *testing.T) {
 		code := `func main() { return "hello" }`
 		uri := "https://example.com/script.js"
-		loader, err := createLoaderFromSource(code, uri)
+		loader, err := createLoaderFromSource(code, uri, 0)
 
 		require.NoError(t, err)
 		assert.NotNil(t, loader)
 		// Code should take precedence, so this should create a string loader
 	})
 }
+
+func TestLocalPath(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		evaluator Evaluator
+		want      string
+		wantOK    bool
+	}{
+		{"file uri", &RisorEvaluator{URI: "file:///etc/scripts/a.risor"}, "/etc/scripts/a.risor", true},
+		{"absolute path", &StarlarkEvaluator{URI: "/etc/scripts/b.star"}, "/etc/scripts/b.star", true},
+		{"relative path", &JavaScriptEvaluator{URI: "scripts/c.js"}, filepath.Join(wd, "scripts/c.js"), true},
+		{"wasm file", &ExtismEvaluator{URI: "file://d.wasm"}, filepath.Join(wd, "d.wasm"), true},
+		{"https uri", &RisorEvaluator{URI: "https://example.com/a.risor"}, "", false},
+		{"inline code", &RisorEvaluator{Code: `"hello"`}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ok := LocalPath(tt.evaluator)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, path)
+		})
+	}
+}
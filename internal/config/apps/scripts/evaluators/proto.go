@@ -20,7 +20,8 @@ func RisorEvaluatorFromProto(proto *pbApps.RisorEvaluator) *RisorEvaluator {
 	}
 
 	risor := &RisorEvaluator{
-		Timeout: timeout,
+		Timeout:     timeout,
+		URICacheTTL: durationFromProto(proto.UriCacheTtl),
 	}
 
 	// Handle the oneof source field
@@ -46,7 +47,8 @@ func (r *RisorEvaluator) ToProto() *pbApps.RisorEvaluator {
 	}
 
 	proto := &pbApps.RisorEvaluator{
-		Timeout: timeout,
+		Timeout:     timeout,
+		UriCacheTtl: durationToProto(r.URICacheTTL),
 	}
 
 	// Handle the oneof source field - prioritize code over URI
@@ -75,7 +77,8 @@ func StarlarkEvaluatorFromProto(proto *pbApps.StarlarkEvaluator) *StarlarkEvalua
 	}
 
 	starlark := &StarlarkEvaluator{
-		Timeout: timeout,
+		Timeout:     timeout,
+		URICacheTTL: durationFromProto(proto.UriCacheTtl),
 	}
 
 	// Handle the oneof source field
@@ -101,7 +104,8 @@ func (s *StarlarkEvaluator) ToProto() *pbApps.StarlarkEvaluator {
 	}
 
 	proto := &pbApps.StarlarkEvaluator{
-		Timeout: timeout,
+		Timeout:     timeout,
+		UriCacheTtl: durationToProto(s.URICacheTTL),
 	}
 
 	// Handle the oneof source field - prioritize code over URI
@@ -118,6 +122,63 @@ func (s *StarlarkEvaluator) ToProto() *pbApps.StarlarkEvaluator {
 	return proto
 }
 
+// JavaScriptEvaluatorFromProto creates a JavaScriptEvaluator from its protocol buffer representation.
+func JavaScriptEvaluatorFromProto(proto *pbApps.JavaScriptEvaluator) *JavaScriptEvaluator {
+	if proto == nil {
+		return nil
+	}
+
+	var timeout time.Duration
+	if proto.Timeout != nil {
+		timeout = proto.Timeout.AsDuration()
+	}
+
+	javascript := &JavaScriptEvaluator{
+		Timeout:     timeout,
+		URICacheTTL: durationFromProto(proto.UriCacheTtl),
+	}
+
+	// Handle the oneof source field
+	switch source := proto.Source.(type) {
+	case *pbApps.JavaScriptEvaluator_Code:
+		javascript.Code = source.Code
+	case *pbApps.JavaScriptEvaluator_Uri:
+		javascript.URI = source.Uri
+	}
+
+	return javascript
+}
+
+// ToProto converts a JavaScriptEvaluator to its protocol buffer representation.
+func (j *JavaScriptEvaluator) ToProto() *pbApps.JavaScriptEvaluator {
+	if j == nil {
+		return nil
+	}
+
+	var timeout *durationpb.Duration
+	if j.Timeout > 0 {
+		timeout = durationpb.New(j.Timeout)
+	}
+
+	proto := &pbApps.JavaScriptEvaluator{
+		Timeout:     timeout,
+		UriCacheTtl: durationToProto(j.URICacheTTL),
+	}
+
+	// Handle the oneof source field - prioritize code over URI
+	if j.Code != "" {
+		proto.Source = &pbApps.JavaScriptEvaluator_Code{
+			Code: j.Code,
+		}
+	} else if j.URI != "" {
+		proto.Source = &pbApps.JavaScriptEvaluator_Uri{
+			Uri: j.URI,
+		}
+	}
+
+	return proto
+}
+
 // ExtismEvaluatorFromProto creates an ExtismEvaluator from its protocol buffer representation.
 func ExtismEvaluatorFromProto(proto *pbApps.ExtismEvaluator) *ExtismEvaluator {
 	if proto == nil {
@@ -130,8 +191,12 @@ func ExtismEvaluatorFromProto(proto *pbApps.ExtismEvaluator) *ExtismEvaluator {
 	}
 
 	extism := &ExtismEvaluator{
-		Entrypoint: protobaggins.StringFromProto(proto.Entrypoint),
-		Timeout:    timeout,
+		Entrypoint:     protobaggins.StringFromProto(proto.Entrypoint),
+		Timeout:        timeout,
+		URICacheTTL:    durationFromProto(proto.UriCacheTtl),
+		HostFunctions:  proto.GetHostFunctions(),
+		MaxMemoryPages: int(proto.GetMaxMemoryPages()),
+		Fuel:           proto.GetFuel(),
 	}
 
 	// Handle the oneof source field
@@ -157,8 +222,18 @@ func (e *ExtismEvaluator) ToProto() *pbApps.ExtismEvaluator {
 	}
 
 	proto := &pbApps.ExtismEvaluator{
-		Entrypoint: protobaggins.StringToProto(e.Entrypoint),
-		Timeout:    timeout,
+		Entrypoint:    protobaggins.StringToProto(e.Entrypoint),
+		Timeout:       timeout,
+		UriCacheTtl:   durationToProto(e.URICacheTTL),
+		HostFunctions: e.HostFunctions,
+	}
+	if e.MaxMemoryPages != 0 {
+		pages := int32(e.MaxMemoryPages)
+		proto.MaxMemoryPages = &pages
+	}
+	if e.Fuel != 0 {
+		fuel := e.Fuel
+		proto.Fuel = &fuel
 	}
 
 	// Handle the oneof source field - prioritize code over URI
@@ -188,7 +263,25 @@ func EvaluatorFromProto(proto *pbApps.ScriptApp) (Evaluator, error) {
 		return StarlarkEvaluatorFromProto(proto.GetStarlark()), nil
 	case proto.GetExtism() != nil:
 		return ExtismEvaluatorFromProto(proto.GetExtism()), nil
+	case proto.GetJavascript() != nil:
+		return JavaScriptEvaluatorFromProto(proto.GetJavascript()), nil
 	default:
 		return nil, ErrInvalidEvaluatorType
 	}
 }
+
+// durationFromProto converts an optional protobuf duration, returning zero when unset.
+func durationFromProto(d *durationpb.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.AsDuration()
+}
+
+// durationToProto converts a duration to protobuf, leaving it unset when not positive.
+func durationToProto(d time.Duration) *durationpb.Duration {
+	if d <= 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
@@ -199,6 +199,83 @@ func TestStarlarkEvaluator_ToProto(t *testing.T) {
 	})
 }
 
+func TestJavaScriptEvaluator_ProtoRoundTrip(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		assert.Nil(t, JavaScriptEvaluatorFromProto(nil))
+		var evaluator *JavaScriptEvaluator
+		assert.Nil(t, evaluator.ToProto())
+	})
+
+	t.Run("code and timeout", func(t *testing.T) {
+		evaluator := &JavaScriptEvaluator{Code: "'hello'", Timeout: 5 * time.Second}
+		got := evaluator.ToProto()
+		assert.Equal(t, "'hello'", got.GetCode())
+		assert.Equal(t, 5*time.Second, got.Timeout.AsDuration())
+		assert.Equal(t, evaluator, JavaScriptEvaluatorFromProto(got))
+	})
+
+	t.Run("uri", func(t *testing.T) {
+		evaluator := &JavaScriptEvaluator{URI: "https://example.com/handler.js"}
+		got := evaluator.ToProto()
+		assert.Equal(t, "https://example.com/handler.js", got.GetUri())
+		assert.Nil(t, got.Timeout)
+		assert.Equal(t, evaluator, JavaScriptEvaluatorFromProto(got))
+	})
+}
+
+func TestEvaluators_URICacheTTLProtoRoundTrip(t *testing.T) {
+	const uri = "https://example.com/script"
+	ttl := 30 * time.Second
+
+	risor := &RisorEvaluator{URI: uri, URICacheTTL: ttl}
+	assert.Equal(t, ttl, risor.ToProto().GetUriCacheTtl().AsDuration())
+	assert.Equal(t, risor, RisorEvaluatorFromProto(risor.ToProto()))
+
+	starlark := &StarlarkEvaluator{URI: uri, URICacheTTL: ttl}
+	assert.Equal(t, ttl, starlark.ToProto().GetUriCacheTtl().AsDuration())
+	assert.Equal(t, starlark, StarlarkEvaluatorFromProto(starlark.ToProto()))
+
+	javascript := &JavaScriptEvaluator{URI: uri, URICacheTTL: ttl}
+	assert.Equal(t, ttl, javascript.ToProto().GetUriCacheTtl().AsDuration())
+	assert.Equal(t, javascript, JavaScriptEvaluatorFromProto(javascript.ToProto()))
+
+	extism := &ExtismEvaluator{URI: uri, Entrypoint: "run", URICacheTTL: ttl}
+	assert.Equal(t, ttl, extism.ToProto().GetUriCacheTtl().AsDuration())
+	assert.Equal(t, extism, ExtismEvaluatorFromProto(extism.ToProto()))
+
+	// Unset stays unset
+	assert.Nil(t, (&RisorEvaluator{URI: uri}).ToProto().UriCacheTtl)
+}
+
+func TestExtismEvaluator_HostFunctionsProtoRoundTrip(t *testing.T) {
+	extism := &ExtismEvaluator{
+		Code:          "base64content",
+		Entrypoint:    "run",
+		HostFunctions: []string{HostFunctionLog, HostFunctionGetStaticData},
+	}
+	pb := extism.ToProto()
+	assert.Equal(t, []string{HostFunctionLog, HostFunctionGetStaticData}, pb.GetHostFunctions())
+	assert.Equal(t, extism, ExtismEvaluatorFromProto(pb))
+}
+
+func TestExtismEvaluator_LimitsProtoRoundTrip(t *testing.T) {
+	extism := &ExtismEvaluator{
+		Code:           "base64content",
+		Entrypoint:     "run",
+		MaxMemoryPages: 16,
+		Fuel:           1000,
+	}
+	pb := extism.ToProto()
+	assert.Equal(t, int32(16), pb.GetMaxMemoryPages())
+	assert.Equal(t, int64(1000), pb.GetFuel())
+	assert.Equal(t, extism, ExtismEvaluatorFromProto(pb))
+
+	// Unset stays unset
+	pb = (&ExtismEvaluator{Code: "base64content"}).ToProto()
+	assert.Nil(t, pb.MaxMemoryPages)
+	assert.Nil(t, pb.Fuel)
+}
+
 func TestExtismEvaluatorFromProto(t *testing.T) {
 	t.Run("nil", func(t *testing.T) {
 		proto := (*pbApps.ExtismEvaluator)(nil)
@@ -329,6 +406,22 @@ func TestEvaluatorFromProto(t *testing.T) {
 		assert.Equal(t, want, got)
 	})
 
+	t.Run("javascript", func(t *testing.T) {
+		proto := &pbApps.ScriptApp{
+			Evaluator: &pbApps.ScriptApp_Javascript{
+				Javascript: &pbApps.JavaScriptEvaluator{
+					Source: &pbApps.JavaScriptEvaluator_Uri{Uri: "file://handler.js"},
+				},
+			},
+		}
+		got, err := EvaluatorFromProto(proto)
+		require.NoError(t, err)
+		want := &JavaScriptEvaluator{
+			URI: "file://handler.js",
+		}
+		assert.Equal(t, want, got)
+	})
+
 	t.Run("extism", func(t *testing.T) {
 		proto := &pbApps.ScriptApp{
 			Evaluator: &pbApps.ScriptApp_Extism{
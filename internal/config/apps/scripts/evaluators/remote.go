@@ -0,0 +1,173 @@
+package evaluators
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+)
+
+const (
+	// DefaultURICacheTTL is how long a script fetched from an http(s) URI is
+	// used before the origin is asked whether it changed.
+	DefaultURICacheTTL = 5 * time.Minute
+
+	// remoteFetchTimeout bounds a single request for a remote script
+	remoteFetchTimeout = 30 * time.Second
+)
+
+// remoteScripts is shared by all evaluators so the cached copy of a URI
+// survives config reloads.
+var remoteScripts = newRemoteScriptCache(&http.Client{Timeout: remoteFetchTimeout})
+
+// remoteScript is the last good copy of a script fetched from an http(s) URI.
+type remoteScript struct {
+	body         []byte
+	etag         string
+	lastModified string
+	fetchedAt    time.Time
+}
+
+// remoteScriptCache fetches scripts from http(s) URIs. A cached copy is
+// served without a request until its TTL runs out, then revalidated with
+// If-None-Match/If-Modified-Since. If the origin can't be reached or returns
+// an error, the last good copy is served. Only a URI that has never been
+// fetched successfully fails.
+type remoteScriptCache struct {
+	mu      sync.Mutex
+	entries map[string]*remoteScript
+	client  *http.Client
+	now     func() time.Time
+}
+
+func newRemoteScriptCache(client *http.Client) *remoteScriptCache {
+	return &remoteScriptCache{
+		entries: make(map[string]*remoteScript),
+		client:  client,
+		now:     time.Now,
+	}
+}
+
+// fetch returns the script body for uri, contacting the origin only when
+// there's no copy younger than ttl.
+func (c *remoteScriptCache) fetch(ctx context.Context, uri string, ttl time.Duration) ([]byte, error) {
+	c.mu.Lock()
+	cached := c.entries[uri]
+	c.mu.Unlock()
+
+	if cached != nil && c.now().Sub(cached.fetchedAt) < ttl {
+		return cached.body, nil
+	}
+
+	fetched, err := c.request(ctx, uri, cached)
+	if err != nil {
+		if cached == nil {
+			return nil, err
+		}
+		slog.Default().Warn("Failed to refresh remote script, using last good copy",
+			"uri", uri,
+			"fetched_at", cached.fetchedAt,
+			"error", err)
+		return cached.body, nil
+	}
+
+	c.mu.Lock()
+	c.entries[uri] = fetched
+	c.mu.Unlock()
+	return fetched.body, nil
+}
+
+// request fetches uri, revalidating cached when it's set. A 304 response
+// returns cached with a new fetch time.
+func (c *remoteScriptCache) request(
+	ctx context.Context,
+	uri string,
+	cached *remoteScript,
+) (*remoteScript, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if cached != nil {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch script: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return &remoteScript{
+			body:         cached.body,
+			etag:         cached.etag,
+			lastModified: cached.lastModified,
+			fetchedAt:    c.now(),
+		}, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: HTTP %s", loader.ErrScriptNotAvailable, resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, loader.DefaultMaxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read script: %w", err)
+	}
+	if int64(len(body)) > loader.DefaultMaxBodySize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", loader.ErrScriptTooLarge, loader.DefaultMaxBodySize)
+	}
+
+	return &remoteScript{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		fetchedAt:    c.now(),
+	}, nil
+}
+
+// remoteLoader is a loader.Loader for http(s) URIs that reads through
+// remoteScripts instead of fetching on every read.
+type remoteLoader struct {
+	uri       string
+	sourceURL *url.URL
+	ttl       time.Duration
+	cache     *remoteScriptCache
+}
+
+func newRemoteLoader(uri string, ttl time.Duration, cache *remoteScriptCache) (*remoteLoader, error) {
+	sourceURL, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse URL: %w", err)
+	}
+	if ttl <= 0 {
+		ttl = DefaultURICacheTTL
+	}
+	return &remoteLoader{uri: uri, sourceURL: sourceURL, ttl: ttl, cache: cache}, nil
+}
+
+// GetReader returns the cached or freshly fetched script
+func (l *remoteLoader) GetReader(ctx context.Context) (io.ReadCloser, error) {
+	body, err := l.cache.fetch(ctx, l.uri, l.ttl)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
+
+// GetSourceURL returns the script's URI
+func (l *remoteLoader) GetSourceURL() *url.URL {
+	return l.sourceURL
+}
@@ -0,0 +1,174 @@
+package evaluators
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/robbyt/go-polyscript/platform/script/loader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptOrigin serves a script with an ETag and Last-Modified header and
+// answers conditional requests, counting every request it receives.
+type scriptOrigin struct {
+	body        atomic.Value // string
+	etag        atomic.Value // string
+	down        atomic.Bool
+	requests    atomic.Int32
+	conditional atomic.Int32
+}
+
+func newScriptOrigin(t *testing.T, body string) (*scriptOrigin, *httptest.Server) {
+	t.Helper()
+	o := &scriptOrigin{}
+	o.set(body, `"v1"`)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		o.requests.Add(1)
+		if o.down.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("If-None-Match") != "" || r.Header.Get("If-Modified-Since") != "" {
+			o.conditional.Add(1)
+		}
+		etag := o.etag.Load().(string)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		_, _ = io.WriteString(w, o.body.Load().(string))
+	}))
+	t.Cleanup(srv.Close)
+	return o, srv
+}
+
+func (o *scriptOrigin) set(body, etag string) {
+	o.body.Store(body)
+	o.etag.Store(etag)
+}
+
+func TestRemoteScriptCache(t *testing.T) {
+	newCache := func(srv *httptest.Server) (*remoteScriptCache, *time.Time) {
+		cache := newRemoteScriptCache(srv.Client())
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		return cache, &now
+	}
+
+	t.Run("served from cache within TTL", func(t *testing.T) {
+		origin, srv := newScriptOrigin(t, "first")
+		cache, now := newCache(srv)
+
+		body, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(body))
+
+		*now = now.Add(30 * time.Second)
+		body, err = cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(body))
+		assert.Equal(t, int32(1), origin.requests.Load())
+	})
+
+	t.Run("revalidated with ETag after TTL", func(t *testing.T) {
+		origin, srv := newScriptOrigin(t, "first")
+		cache, now := newCache(srv)
+
+		_, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+
+		*now = now.Add(2 * time.Minute)
+		body, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(body))
+		assert.Equal(t, int32(2), origin.requests.Load())
+		assert.Equal(t, int32(1), origin.conditional.Load())
+
+		// The 304 refreshed the entry, so the next read is a cache hit
+		*now = now.Add(30 * time.Second)
+		_, err = cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), origin.requests.Load())
+	})
+
+	t.Run("changed script is fetched after TTL", func(t *testing.T) {
+		origin, srv := newScriptOrigin(t, "first")
+		cache, now := newCache(srv)
+
+		_, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+
+		origin.set("second", `"v2"`)
+		*now = now.Add(2 * time.Minute)
+		body, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(body))
+	})
+
+	t.Run("last good copy is served when the origin fails", func(t *testing.T) {
+		origin, srv := newScriptOrigin(t, "first")
+		cache, now := newCache(srv)
+
+		_, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+
+		origin.down.Store(true)
+		*now = now.Add(2 * time.Minute)
+		body, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(body))
+
+		// Unreachable origins fall back too
+		srv.Close()
+		body, err = cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "first", string(body))
+	})
+
+	t.Run("first fetch error fails", func(t *testing.T) {
+		origin, srv := newScriptOrigin(t, "first")
+		origin.down.Store(true)
+		cache, _ := newCache(srv)
+
+		_, err := cache.fetch(t.Context(), srv.URL, time.Minute)
+		require.ErrorIs(t, err, loader.ErrScriptNotAvailable)
+	})
+}
+
+func TestRemoteLoader(t *testing.T) {
+	origin, srv := newScriptOrigin(t, `{"ok": true}`)
+	cache := newRemoteScriptCache(srv.Client())
+
+	ldr, err := newRemoteLoader(srv.URL+"/script.risor", 0, cache)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultURICacheTTL, ldr.ttl)
+	assert.Equal(t, srv.URL+"/script.risor", ldr.GetSourceURL().String())
+
+	for range 2 {
+		reader, err := ldr.GetReader(t.Context())
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		assert.JSONEq(t, `{"ok": true}`, string(body))
+	}
+	assert.Equal(t, int32(1), origin.requests.Load())
+}
+
+func TestEvaluator_URIFetchFailure(t *testing.T) {
+	origin, srv := newScriptOrigin(t, "")
+	origin.down.Store(true)
+
+	// Validation fails when a script has never been fetched successfully
+	e := &RisorEvaluator{URI: srv.URL + "/" + t.Name() + ".risor"}
+	err := e.Validate()
+	require.ErrorIs(t, err, ErrCompilationFailed)
+	require.ErrorIs(t, err, loader.ErrScriptNotAvailable)
+}
@@ -10,8 +10,8 @@ import (
 
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 	"github.com/robbyt/go-polyscript/engines/risor"
-	"github.com/robbyt/go-polyscript/engines/risor/evaluator"
 	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
 )
 
 var _ Evaluator = (*RisorEvaluator)(nil)
@@ -24,9 +24,15 @@ type RisorEvaluator struct {
 	URI string `env_interpolation:"yes"`
 	// Timeout is the maximum execution time allowed for the script.
 	Timeout time.Duration
-
-	// compiledEvaluator stores the concrete Risor evaluator after compilation
-	compiledEvaluator *evaluator.Evaluator
+	// URICacheTTL is how long a script fetched from an http(s) URI is reused
+	// before the origin is revalidated. Zero uses DefaultURICacheTTL.
+	URICacheTTL time.Duration
+
+	// compiledEvaluator stores the evaluator after compilation, possibly shared
+	// with other evaluators compiled from the same source
+	compiledEvaluator platform.Evaluator
+	// compileStats records how compiledEvaluator was obtained
+	compileStats *CompileStats
 	// buildOnce ensures build() is called exactly once
 	buildOnce sync.Once
 	// buildErr stores any error from the build process
@@ -67,6 +73,9 @@ func (r *RisorEvaluator) Validate() error {
 	if r.Timeout < 0 {
 		errs = append(errs, ErrNegativeTimeout)
 	}
+	if r.URICacheTTL < 0 {
+		errs = append(errs, ErrNegativeURICacheTTL)
+	}
 
 	// If basic validation failed, don't attempt compilation
 	if len(errs) > 0 {
@@ -99,15 +108,22 @@ func (r *RisorEvaluator) GetTimeout() time.Duration {
 func (r *RisorEvaluator) build() {
 	r.buildOnce.Do(func() {
 		// Create loader based on source type
-		scriptLoader, err := createLoaderFromSource(r.Code, r.URI)
+		scriptLoader, err := createLoaderFromSource(r.Code, r.URI, r.URICacheTTL)
 		if err != nil {
 			r.buildErr = fmt.Errorf("%w: %w", ErrLoaderCreation, err)
 			return
 		}
 
-		// Compile script using go-polyscript
+		// Compile the script, reusing a cached evaluator if the source is unchanged
 		logger := slog.Default()
-		r.compiledEvaluator, err = risor.FromRisorLoader(context.Background(), scriptLoader, risor.WithLogHandler(logger.Handler()))
+		r.compiledEvaluator, r.compileStats, err = compiledCache.compile(
+			EvaluatorTypeRisor,
+			scriptLoader,
+			nil,
+			func(ldr loader.Loader) (platform.Evaluator, error) {
+				return risor.FromRisorLoader(context.Background(), ldr, risor.WithLogHandler(logger.Handler()))
+			},
+		)
 		if err != nil {
 			r.buildErr = fmt.Errorf(
 				"%w: risor script compilation failed: %w",
@@ -78,6 +78,15 @@ func TestRisorEvaluator_Validate(t *testing.T) {
 		require.ErrorIs(t, err, ErrNegativeTimeout)
 	})
 
+	t.Run("negative uri cache ttl", func(t *testing.T) {
+		evaluator := &RisorEvaluator{
+			Code:        `"hello"`,
+			URICacheTTL: -time.Second,
+		}
+		err := evaluator.Validate()
+		require.ErrorIs(t, err, ErrNegativeURICacheTTL)
+	})
+
 	t.Run("multiple errors", func(t *testing.T) {
 		evaluator := &RisorEvaluator{
 			Code:    "",
@@ -10,8 +10,8 @@ import (
 
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 	"github.com/robbyt/go-polyscript/engines/starlark"
-	"github.com/robbyt/go-polyscript/engines/starlark/evaluator"
 	"github.com/robbyt/go-polyscript/platform"
+	"github.com/robbyt/go-polyscript/platform/script/loader"
 )
 
 var _ Evaluator = (*StarlarkEvaluator)(nil)
@@ -24,9 +24,15 @@ type StarlarkEvaluator struct {
 	URI string `env_interpolation:"yes"`
 	// Timeout is the maximum execution time allowed for the script.
 	Timeout time.Duration
-
-	// compiledEvaluator stores the concrete Starlark evaluator after compilation
-	compiledEvaluator *evaluator.Evaluator
+	// URICacheTTL is how long a script fetched from an http(s) URI is reused
+	// before the origin is revalidated. Zero uses DefaultURICacheTTL.
+	URICacheTTL time.Duration
+
+	// compiledEvaluator stores the evaluator after compilation, possibly shared
+	// with other evaluators compiled from the same source
+	compiledEvaluator platform.Evaluator
+	// compileStats records how compiledEvaluator was obtained
+	compileStats *CompileStats
 	// buildOnce ensures build() is called exactly once
 	buildOnce sync.Once
 	// buildErr stores any error from the build process
@@ -67,6 +73,9 @@ func (s *StarlarkEvaluator) Validate() error {
 	if s.Timeout < 0 {
 		errs = append(errs, ErrNegativeTimeout)
 	}
+	if s.URICacheTTL < 0 {
+		errs = append(errs, ErrNegativeURICacheTTL)
+	}
 
 	// If basic validation failed, don't attempt compilation
 	if len(errs) > 0 {
@@ -82,15 +91,22 @@ func (s *StarlarkEvaluator) Validate() error {
 func (s *StarlarkEvaluator) build() {
 	s.buildOnce.Do(func() {
 		// Create loader based on source type
-		scriptLoader, err := createLoaderFromSource(s.Code, s.URI)
+		scriptLoader, err := createLoaderFromSource(s.Code, s.URI, s.URICacheTTL)
 		if err != nil {
 			s.buildErr = fmt.Errorf("%w: %w", ErrLoaderCreation, err)
 			return
 		}
 
-		// Compile script using go-polyscript
+		// Compile the script, reusing a cached evaluator if the source is unchanged
 		logger := slog.Default()
-		s.compiledEvaluator, err = starlark.FromStarlarkLoader(context.Background(), scriptLoader, starlark.WithLogHandler(logger.Handler()))
+		s.compiledEvaluator, s.compileStats, err = compiledCache.compile(
+			EvaluatorTypeStarlark,
+			scriptLoader,
+			nil,
+			func(ldr loader.Loader) (platform.Evaluator, error) {
+				return starlark.FromStarlarkLoader(context.Background(), ldr, starlark.WithLogHandler(logger.Handler()))
+			},
+		)
 		if err != nil {
 			s.buildErr = fmt.Errorf(
 				"%w: starlark script compilation failed: %w",
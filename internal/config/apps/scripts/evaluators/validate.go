@@ -3,7 +3,7 @@ package evaluators
 // ValidateEvaluatorType validates that the provided evaluator type is valid.
 func ValidateEvaluatorType(typ EvaluatorType) error {
 	switch typ {
-	case EvaluatorTypeRisor, EvaluatorTypeStarlark, EvaluatorTypeExtism:
+	case EvaluatorTypeRisor, EvaluatorTypeStarlark, EvaluatorTypeExtism, EvaluatorTypeJavaScript:
 		return nil
 	default:
 		return NewInvalidEvaluatorTypeError(typ)
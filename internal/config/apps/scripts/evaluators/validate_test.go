@@ -27,6 +27,11 @@ func TestValidateEvaluatorType(t *testing.T) {
 			typ:     EvaluatorTypeExtism,
 			wantErr: false,
 		},
+		{
+			name:    "javascript",
+			typ:     EvaluatorTypeJavaScript,
+			wantErr: false,
+		},
 		{
 			name:    "unspecified",
 			typ:     EvaluatorTypeUnspecified,
@@ -30,6 +30,8 @@ func FromProto(id string, proto *pbApps.ScriptApp) (*AppScript, error) {
 	app := NewAppScript(id)
 	app.StaticData = staticData
 	app.Evaluator = eval
+	app.TimeoutBody = proto.GetTimeoutBody()
+	app.ShutdownBody = proto.GetShutdownBody()
 	return app, nil
 }
 
@@ -42,6 +44,14 @@ func (s *AppScript) ToProto() any {
 	// Create the protobuf message
 	proto := &pbApps.ScriptApp{}
 
+	// Error bodies are only set when configured, leaving the defaults to the server
+	if s.TimeoutBody != "" {
+		proto.TimeoutBody = &s.TimeoutBody
+	}
+	if s.ShutdownBody != "" {
+		proto.ShutdownBody = &s.ShutdownBody
+	}
+
 	// Convert static data if present
 	if s.StaticData != nil {
 		proto.StaticData = s.StaticData.ToProto()
@@ -62,6 +72,10 @@ func (s *AppScript) ToProto() any {
 			proto.Evaluator = &pbApps.ScriptApp_Extism{
 				Extism: eval.ToProto(),
 			}
+		case *evaluators.JavaScriptEvaluator:
+			proto.Evaluator = &pbApps.ScriptApp_Javascript{
+				Javascript: eval.ToProto(),
+			}
 		}
 	}
 
@@ -194,3 +194,32 @@ func TestAppScript_ToProto(t *testing.T) {
 		assert.Nil(t, got.Evaluator, "Expected nil evaluator")
 	})
 }
+
+func TestAppScript_ErrorBodiesRoundTrip(t *testing.T) {
+	t.Run("custom bodies", func(t *testing.T) {
+		script := &AppScript{
+			Evaluator:    &evaluators.RisorEvaluator{Code: "print('hello')"},
+			TimeoutBody:  "too slow",
+			ShutdownBody: "try again later",
+		}
+
+		pb, ok := script.ToProto().(*pbApps.ScriptApp)
+		require.True(t, ok)
+		assert.Equal(t, "too slow", pb.GetTimeoutBody())
+		assert.Equal(t, "try again later", pb.GetShutdownBody())
+
+		got, err := FromProto("test-id", pb)
+		require.NoError(t, err)
+		assert.Equal(t, "too slow", got.TimeoutBody)
+		assert.Equal(t, "try again later", got.ShutdownBody)
+	})
+
+	t.Run("default bodies are left unset", func(t *testing.T) {
+		script := &AppScript{Evaluator: &evaluators.RisorEvaluator{Code: "print('hello')"}}
+
+		pb, ok := script.ToProto().(*pbApps.ScriptApp)
+		require.True(t, ok)
+		assert.Nil(t, pb.TimeoutBody)
+		assert.Nil(t, pb.ShutdownBody)
+	})
+}
@@ -41,6 +41,14 @@ func (s *AppScript) ToTree() *fancy.ComponentTree {
 		evalBranch.Child(fmt.Sprintf("%s", s.Evaluator))
 	}
 
+	// Add custom error bodies if set
+	if s.TimeoutBody != "" {
+		tree.AddChild(fmt.Sprintf("Timeout Body: %q", s.TimeoutBody))
+	}
+	if s.ShutdownBody != "" {
+		tree.AddChild(fmt.Sprintf("Shutdown Body: %q", s.ShutdownBody))
+	}
+
 	// Add static data if present
 	if s.StaticData != nil && len(s.StaticData.Data) > 0 {
 		staticDataBranch := tree.AddBranch(
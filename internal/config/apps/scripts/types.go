@@ -16,6 +16,14 @@ type AppScript struct {
 
 	// Evaluator is the script evaluator to use.
 	Evaluator evaluators.Evaluator
+
+	// TimeoutBody is the response body sent with the 504 when the script runs
+	// out of time. Empty uses the server's default.
+	TimeoutBody string `env_interpolation:"yes"`
+
+	// ShutdownBody is the response body sent with the 503 when the server
+	// shuts down while the script runs. Empty uses the server's default.
+	ShutdownBody string `env_interpolation:"yes"`
 }
 
 // NewAppScript creates a new AppScript with the given ID
@@ -7,8 +7,10 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/calculation"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/styles"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 )
@@ -36,6 +38,10 @@ func (a *App) String() string {
 		fmt.Fprintf(&b, " [Calculation]")
 	case *fileread.App:
 		fmt.Fprintf(&b, " [FileRead]")
+	case *openapi.App:
+		fmt.Fprintf(&b, " [OpenAPI]")
+	case *template.App:
+		fmt.Fprintf(&b, " [Template]")
 	default:
 		fmt.Fprintf(&b, " [Unknown type]")
 	}
@@ -74,6 +80,15 @@ func (a *App) ToTree() *fancy.ComponentTree {
 				}
 				tree.AddChild(evalNode.Tree())
 
+			case *evaluators.JavaScriptEvaluator:
+				evalNode := fancy.NewComponentTree(styles.FormatSection("Evaluator: "+eval.String(), 1))
+				codePreview := fancy.TruncateString(eval.Code, 40)
+				evalNode.AddChild(fmt.Sprintf("Code: %s", codePreview))
+				if eval.Timeout > 0 {
+					evalNode.AddChild(fmt.Sprintf("Timeout: %v", eval.Timeout))
+				}
+				tree.AddChild(evalNode.Tree())
+
 			case *evaluators.ExtismEvaluator:
 				evalNode := fancy.NewComponentTree(styles.FormatSection("Evaluator: "+eval.String(), 1))
 				evalNode.AddChild(fmt.Sprintf("Entrypoint: %s", eval.Entrypoint))
@@ -135,6 +150,19 @@ func (a *App) ToTree() *fancy.ComponentTree {
 	case *fileread.App:
 		tree.AddChild("Type: FileRead")
 		tree.AddChild(fmt.Sprintf("BaseDirectory: %s", appConfig.BaseDirectory))
+
+	case *openapi.App:
+		tree.AddChild("Type: OpenAPI")
+		tree.AddChild(fmt.Sprintf("Title: %s", appConfig.Title))
+
+	case *template.App:
+		tree.AddChild("Type: Template")
+		if appConfig.Directory != "" {
+			tree.AddChild(fmt.Sprintf("Directory: %s", appConfig.Directory))
+		}
+		if appConfig.Template != "" {
+			tree.AddChild(fmt.Sprintf("Template: %s", appConfig.Template))
+		}
 	}
 
 	return tree
@@ -10,8 +10,10 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/stretchr/testify/assert"
 )
@@ -104,6 +106,22 @@ func TestAppString(t *testing.T) {
 			},
 			expectedString: "App files-app [FileRead]",
 		},
+		{
+			name: "OpenAPI app",
+			app: App{
+				ID:     "docs",
+				Config: openapi.New("docs"),
+			},
+			expectedString: "App docs [OpenAPI]",
+		},
+		{
+			name: "Template app",
+			app: App{
+				ID:     "pages",
+				Config: &template.App{ID: "pages", Directory: "/srv/templates"},
+			},
+			expectedString: "App pages [Template]",
+		},
 		{
 			name: "Unknown app type",
 			app: App{
@@ -223,6 +241,20 @@ func TestAppToTree(t *testing.T) {
 				Config: &fileread.App{ID: "files-app", BaseDirectory: "/tmp"},
 			},
 		},
+		{
+			name: "OpenAPI app",
+			app: App{
+				ID:     "docs",
+				Config: openapi.New("docs"),
+			},
+		},
+		{
+			name: "Template app",
+			app: App{
+				ID:     "pages",
+				Config: &template.App{ID: "pages", Directory: "/srv/templates", Template: "index.html"},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,45 @@
+package template
+
+import (
+	"fmt"
+	"maps"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+)
+
+// FromProto creates an App configuration from its protocol buffer representation.
+func FromProto(id string, proto *pbApps.TemplateApp) (*App, error) {
+	if proto == nil {
+		return nil, nil
+	}
+
+	staticData, err := staticdata.FromProto(proto.StaticData)
+	if err != nil {
+		return nil, fmt.Errorf("template app static data: %w", err)
+	}
+
+	app := New(id)
+	app.Directory = proto.GetDirectory()
+	app.Inline = maps.Clone(proto.GetInline())
+	app.Template = proto.GetTemplate()
+	app.StaticData = staticData
+	return app, nil
+}
+
+// ToProto converts the App configuration to its protocol buffer representation.
+func (a *App) ToProto() any {
+	proto := &pbApps.TemplateApp{
+		Inline: maps.Clone(a.Inline),
+	}
+	if a.Directory != "" {
+		proto.Directory = &a.Directory
+	}
+	if a.Template != "" {
+		proto.Template = &a.Template
+	}
+	if a.StaticData != nil {
+		proto.StaticData = a.StaticData.ToProto()
+	}
+	return proto
+}
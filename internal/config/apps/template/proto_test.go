@@ -0,0 +1,58 @@
+package template
+
+import (
+	"testing"
+
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestProtoRoundTrip(t *testing.T) {
+	app, err := FromProto("pages", &pbApps.TemplateApp{
+		Directory: proto.String("/srv/templates"),
+		Inline:    map[string]string{"home": "<p>{{.Data.title}}</p>"},
+		Template:  proto.String("home"),
+		StaticData: &pbData.StaticData{
+			Data: map[string]*structpb.Value{"title": structpb.NewStringValue("Admin")},
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, app)
+	assert.Equal(t, "pages", app.ID)
+	assert.Equal(t, "/srv/templates", app.Directory)
+	assert.Equal(t, map[string]string{"home": "<p>{{.Data.title}}</p>"}, app.Inline)
+	assert.Equal(t, "home", app.Template)
+	require.NotNil(t, app.StaticData)
+	assert.Equal(t, "Admin", app.StaticData.Data["title"])
+
+	protoApp, ok := app.ToProto().(*pbApps.TemplateApp)
+	require.True(t, ok)
+	assert.Equal(t, "/srv/templates", protoApp.GetDirectory())
+	assert.Equal(t, app.Inline, protoApp.GetInline())
+	assert.Equal(t, "home", protoApp.GetTemplate())
+	assert.Equal(t, "Admin", protoApp.GetStaticData().GetData()["title"].GetStringValue())
+}
+
+func TestProtoRoundTrip_Empty(t *testing.T) {
+	app, err := FromProto("pages", &pbApps.TemplateApp{})
+	require.NoError(t, err)
+	require.NotNil(t, app)
+	assert.Empty(t, app.Directory)
+	assert.Nil(t, app.StaticData)
+
+	protoApp, ok := app.ToProto().(*pbApps.TemplateApp)
+	require.True(t, ok)
+	assert.Nil(t, protoApp.Directory)
+	assert.Nil(t, protoApp.Template)
+	assert.Nil(t, protoApp.GetStaticData())
+}
+
+func TestFromProto_Nil(t *testing.T) {
+	app, err := FromProto("pages", nil)
+	require.NoError(t, err)
+	assert.Nil(t, app)
+}
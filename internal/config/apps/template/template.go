@@ -0,0 +1,226 @@
+// Package template provides app-specific configuration for template apps,
+// which render Go html/template pages.
+package template
+
+import (
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+)
+
+// TemplateDataKey is the static data key a route sets to render another
+// template than the app's default
+const TemplateDataKey = "template"
+
+// App contains template app-specific configuration.
+type App struct {
+	ID string `env_interpolation:"no"`
+
+	// Directory holds the templates: every *.html file in it is parsed,
+	// named by its file name
+	Directory string `env_interpolation:"yes"`
+
+	// Inline templates by name, parsed after those of Directory and
+	// replacing any file template with the same name
+	Inline map[string]string `env_interpolation:"no"`
+
+	// Template is the name of the template rendered when the route's static
+	// data doesn't name one
+	Template string `env_interpolation:"no"`
+
+	// StaticData is passed to the templates as .Data
+	StaticData *staticdata.StaticData `env_interpolation:"no"`
+
+	// parsed holds the templates parsed by Validate. It's shared with the
+	// route copies of the app made by Copy, which are taken before Validate
+	// runs.
+	parsed *parsedTemplates
+}
+
+// parsedTemplates holds an app's parsed template set
+type parsedTemplates struct {
+	templates *htmltemplate.Template
+}
+
+// New creates a new template app configuration with the specified ID.
+func New(id string) *App {
+	return &App{ID: id, parsed: &parsedTemplates{}}
+}
+
+// Type returns the app type.
+func (a *App) Type() string { return "template" }
+
+// Validate checks if the template app configuration is valid and parses its
+// templates, so syntax errors fail validation rather than requests.
+func (a *App) Validate() error {
+	if err := interpolation.InterpolateStruct(a); err != nil {
+		return fmt.Errorf("interpolation failed for template app: %w", err)
+	}
+
+	if a.ID == "" {
+		return fmt.Errorf("%w: template app ID", errz.ErrMissingRequiredField)
+	}
+
+	if a.Directory == "" && len(a.Inline) == 0 {
+		return fmt.Errorf("%w: template app directory or inline templates",
+			errz.ErrMissingRequiredField)
+	}
+
+	templates, err := a.parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	if a.parsed == nil {
+		a.parsed = &parsedTemplates{}
+	}
+	a.parsed.templates = templates
+
+	if a.Template != "" {
+		return a.ValidateTemplateName()
+	}
+	return nil
+}
+
+// ValidateTemplateName checks that the template the app renders is defined.
+// Validate checks the app's default; the route copies of the app, which may
+// name another template in their static data, are checked with this after
+// the app is validated.
+func (a *App) ValidateTemplateName() error {
+	templates := a.Templates()
+	if templates == nil {
+		// Not parsed, Validate reports why
+		return nil
+	}
+
+	name := a.TemplateName()
+	if name == "" {
+		return fmt.Errorf("%w: template app template, or a %q key in the route's static data",
+			errz.ErrMissingRequiredField, TemplateDataKey)
+	}
+	if templates.Lookup(name) == nil {
+		return fmt.Errorf("%w: template app template %q is not defined", errz.ErrInvalidValue, name)
+	}
+	return nil
+}
+
+// parseTemplates parses the templates of the directory, then the inline ones,
+// into a single set, so every template can call the others as layouts and
+// partials
+func (a *App) parseTemplates() (*htmltemplate.Template, error) {
+	templates := htmltemplate.New(a.ID)
+
+	if a.Directory != "" {
+		info, err := os.Stat(a.Directory)
+		if err != nil {
+			return nil, fmt.Errorf("template app directory is unusable: %w", err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("template app directory is not a directory: %s", a.Directory)
+		}
+
+		pattern := filepath.Join(a.Directory, "*.html")
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%w: template app directory: %w", errz.ErrInvalidValue, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: template app directory has no .html templates: %s",
+				errz.ErrInvalidValue, a.Directory)
+		}
+		if _, err := templates.ParseGlob(pattern); err != nil {
+			return nil, fmt.Errorf("%w: template app template: %w", errz.ErrInvalidValue, err)
+		}
+	}
+
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(a.Inline)) {
+		if _, err := templates.New(name).Parse(a.Inline[name]); err != nil {
+			errs = append(errs, fmt.Errorf("%w: template app inline template %q: %w",
+				errz.ErrInvalidValue, name, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return templates, nil
+}
+
+// Templates returns the parsed template set, or nil when Validate hasn't run
+// on the app or the app it was copied from
+func (a *App) Templates() *htmltemplate.Template {
+	if a.parsed == nil {
+		return nil
+	}
+	return a.parsed.templates
+}
+
+// TemplateName returns the name of the template to render: the one named by
+// the static data's "template" key, otherwise Template
+func (a *App) TemplateName() string {
+	if a.StaticData != nil {
+		if name, ok := a.StaticData.Data[TemplateDataKey].(string); ok && name != "" {
+			return name
+		}
+	}
+	return a.Template
+}
+
+// Copy returns a copy of the app with the given static data. The copy shares
+// the app's parsed templates, including those parsed by a later Validate.
+func (a *App) Copy(staticData *staticdata.StaticData) *App {
+	if a.parsed == nil {
+		a.parsed = &parsedTemplates{}
+	}
+	clone := *a
+	clone.Inline = maps.Clone(a.Inline)
+	clone.StaticData = staticData
+	return &clone
+}
+
+// String returns a string representation of the template app.
+func (a *App) String() string {
+	source := fmt.Sprintf("directory: %s", a.Directory)
+	if a.Directory == "" {
+		source = fmt.Sprintf("%d inline templates", len(a.Inline))
+	} else if len(a.Inline) > 0 {
+		source += fmt.Sprintf(", %d inline templates", len(a.Inline))
+	}
+	if a.Template != "" {
+		return fmt.Sprintf("Template App (%s, template: %s)", source, a.Template)
+	}
+	return fmt.Sprintf("Template App (%s)", source)
+}
+
+// ToTree returns a tree representation of the template app.
+func (a *App) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Template App")
+	tree.AddChild("Type: template")
+	if a.Directory != "" {
+		tree.AddChild(fmt.Sprintf("Directory: %s", a.Directory))
+	}
+	if len(a.Inline) > 0 {
+		inline := fancy.NewComponentTree("Inline Templates:")
+		for _, name := range slices.Sorted(maps.Keys(a.Inline)) {
+			inline.AddChild(name)
+		}
+		tree.AddChild(inline.Tree())
+	}
+	if a.Template != "" {
+		tree.AddChild(fmt.Sprintf("Template: %s", a.Template))
+	}
+	if a.StaticData != nil && len(a.StaticData.Data) > 0 {
+		tree.AddChild(fmt.Sprintf("Static Data (%d keys)", len(a.StaticData.Data)))
+	}
+	return tree
+}
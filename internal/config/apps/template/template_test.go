@@ -0,0 +1,209 @@
+package template
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplates writes files into a new directory and returns it
+func writeTemplates(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestApp_Validate(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{
+		"layout.html": `{{define "layout"}}<main>{{template "content" .}}</main>{{end}}`,
+		"index.html":  `{{template "layout" .}}{{define "content"}}{{.Data.title}}{{end}}`,
+		"notes.txt":   `{{ not a template`,
+	})
+	emptyDir := t.TempDir()
+	filePath := filepath.Join(dir, "notes.txt")
+	badDir := writeTemplates(t, map[string]string{"broken.html": `{{ if }}`})
+
+	tests := []struct {
+		name    string
+		app     *App
+		wantErr error
+		wantMsg string
+	}{
+		{name: "directory", app: &App{ID: "pages", Directory: dir, Template: "index.html"}},
+		{name: "directory without default", app: &App{ID: "pages", Directory: dir}},
+		{name: "inline", app: &App{ID: "pages", Inline: map[string]string{"home": "<p>hi</p>"}, Template: "home"}},
+		{
+			name: "inline using directory layout",
+			app: &App{ID: "pages", Directory: dir, Template: "admin", Inline: map[string]string{
+				"admin": `{{template "layout" .}}{{define "content"}}admin{{end}}`,
+			}},
+		},
+		{name: "missing id", app: &App{Directory: dir}, wantErr: errz.ErrMissingRequiredField, wantMsg: "template app ID"},
+		{name: "missing source", app: &App{ID: "pages"}, wantErr: errz.ErrMissingRequiredField, wantMsg: "directory or inline templates"},
+		{name: "directory missing", app: &App{ID: "pages", Directory: filepath.Join(dir, "missing")}, wantMsg: "template app directory is unusable"},
+		{name: "directory is file", app: &App{ID: "pages", Directory: filePath}, wantMsg: "template app directory is not a directory"},
+		{name: "directory without templates", app: &App{ID: "pages", Directory: emptyDir}, wantErr: errz.ErrInvalidValue, wantMsg: "has no .html templates"},
+		{name: "file parse error", app: &App{ID: "pages", Directory: badDir}, wantErr: errz.ErrInvalidValue, wantMsg: "broken.html"},
+		{
+			name:    "inline parse error",
+			app:     &App{ID: "pages", Inline: map[string]string{"home": "{{ end }}"}},
+			wantErr: errz.ErrInvalidValue,
+			wantMsg: `inline template "home"`,
+		},
+		{
+			name:    "undefined default template",
+			app:     &App{ID: "pages", Directory: dir, Template: "missing.html"},
+			wantErr: errz.ErrInvalidValue,
+			wantMsg: `template "missing.html" is not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.app.Validate()
+			if tt.wantErr == nil && tt.wantMsg == "" {
+				require.NoError(t, err)
+				assert.NotNil(t, tt.app.Templates())
+				return
+			}
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestApp_Validate_Interpolation(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{"index.html": "<p>index</p>"})
+	t.Setenv("TEMPLATE_DIR", dir)
+	t.Setenv("TEMPLATE_VALUE", "interpolated")
+
+	app := New("pages")
+	app.Directory = "${TEMPLATE_DIR}"
+	app.Inline = map[string]string{"home": "<p>${TEMPLATE_VALUE}</p>"}
+	require.NoError(t, app.Validate())
+	assert.Equal(t, dir, app.Directory)
+
+	// Inline templates are template source, not interpolated
+	var buf bytes.Buffer
+	require.NoError(t, app.Templates().ExecuteTemplate(&buf, "home", nil))
+	assert.Equal(t, "<p>${TEMPLATE_VALUE}</p>", buf.String())
+}
+
+func TestApp_Validate_InlineReplacesFile(t *testing.T) {
+	dir := writeTemplates(t, map[string]string{"index.html": "from file"})
+	app := &App{ID: "pages", Directory: dir, Inline: map[string]string{"index.html": "inline"}}
+	require.NoError(t, app.Validate())
+
+	var buf bytes.Buffer
+	require.NoError(t, app.Templates().ExecuteTemplate(&buf, "index.html", nil))
+	assert.Equal(t, "inline", buf.String())
+}
+
+func TestApp_TemplateName(t *testing.T) {
+	app := &App{ID: "pages", Template: "index.html"}
+	assert.Equal(t, "index.html", app.TemplateName())
+
+	app.StaticData = &staticdata.StaticData{Data: map[string]any{"title": "Users"}}
+	assert.Equal(t, "index.html", app.TemplateName())
+
+	app.StaticData.Data[TemplateDataKey] = "users.html"
+	assert.Equal(t, "users.html", app.TemplateName())
+}
+
+func TestApp_Copy(t *testing.T) {
+	app := New("pages")
+	app.Inline = map[string]string{"home": "home", "users": "users"}
+	app.Template = "home"
+
+	routeData := &staticdata.StaticData{Data: map[string]any{TemplateDataKey: "users"}}
+	route := app.Copy(routeData)
+	assert.Equal(t, "users", route.TemplateName())
+	assert.Equal(t, "home", app.TemplateName())
+	assert.Nil(t, route.Templates())
+
+	// Templates parsed by validating the original are shared with the copy
+	require.NoError(t, app.Validate())
+	require.NotNil(t, route.Templates())
+	assert.Same(t, app.Templates(), route.Templates())
+	require.NoError(t, route.ValidateTemplateName())
+}
+
+func TestApp_ValidateTemplateName(t *testing.T) {
+	app := New("pages")
+	app.Inline = map[string]string{"home": "home"}
+	require.NoError(t, app.Validate())
+
+	err := app.ValidateTemplateName()
+	require.ErrorIs(t, err, errz.ErrMissingRequiredField)
+
+	route := app.Copy(&staticdata.StaticData{Data: map[string]any{TemplateDataKey: "missing"}})
+	err = route.ValidateTemplateName()
+	require.ErrorIs(t, err, errz.ErrInvalidValue)
+	assert.Contains(t, err.Error(), `"missing"`)
+
+	// Templates that aren't parsed are reported by Validate
+	assert.NoError(t, (&App{ID: "pages"}).ValidateTemplateName())
+}
+
+func TestApp_Type(t *testing.T) {
+	assert.Equal(t, "template", New("pages").Type())
+}
+
+func TestApp_String(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *App
+		want string
+	}{
+		{
+			name: "directory",
+			app:  &App{ID: "pages", Directory: "/srv/templates"},
+			want: "Template App (directory: /srv/templates)",
+		},
+		{
+			name: "directory and inline with default",
+			app:  &App{ID: "pages", Directory: "/srv/templates", Inline: map[string]string{"a": ""}, Template: "index.html"},
+			want: "Template App (directory: /srv/templates, 1 inline templates, template: index.html)",
+		},
+		{
+			name: "inline",
+			app:  &App{ID: "pages", Inline: map[string]string{"a": "", "b": ""}},
+			want: "Template App (2 inline templates)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.app.String())
+		})
+	}
+}
+
+func TestApp_ToTree(t *testing.T) {
+	app := &App{
+		ID:         "pages",
+		Directory:  "/srv/templates",
+		Inline:     map[string]string{"home": ""},
+		Template:   "index.html",
+		StaticData: &staticdata.StaticData{Data: map[string]any{"title": "Admin"}},
+	}
+	tree := app.ToTree().Tree().String()
+	assert.Contains(t, tree, "Template App")
+	assert.Contains(t, tree, "Directory: /srv/templates")
+	assert.Contains(t, tree, "Inline Templates:")
+	assert.Contains(t, tree, "home")
+	assert.Contains(t, tree, "Template: index.html")
+	assert.Contains(t, tree, "Static Data (1 keys)")
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
 	"github.com/atlanticdynamic/firelynx/internal/config/loader"
@@ -31,13 +33,84 @@ type Config struct {
 	Endpoints endpoints.EndpointCollection
 	Apps      *apps.AppCollection
 
+	// DefaultTimeout is the execution timeout given to script evaluators that
+	// don't set their own. Zero means the evaluator's built-in default is used.
+	DefaultTimeout time.Duration
+
+	// MaxMiddlewareChainLength is the largest resolved middleware chain a route
+	// may have before validation reports it. Zero disables the check.
+	MaxMiddlewareChainLength int
+
+	// StrictValidation turns validation warnings into errors.
+	StrictValidation bool
+
+	// AppFallback is the response served when an app can't handle a request
+	// because its evaluator failed to initialize.
+	AppFallback AppFallback
+
+	// PreApply is a script run before a transaction is staged. Returning an
+	// error from it aborts the transaction.
+	PreApply *scripts.AppScript
+
+	// PostApply is a script run after a transaction has been applied. Its
+	// failures are logged without affecting the transaction.
+	PostApply *scripts.AppScript
+
+	// IsolateFailures disables an app that fails to initialize, answering
+	// its routes with the AppFallback response, instead of failing the
+	// transaction.
+	IsolateFailures bool
+
 	// ValidationCompleted is set after the config has been validated. If the config is invalid, this will still be true.
 	ValidationCompleted bool
 
+	// ValidationWarnings holds issues found by Validate that don't make the
+	// config invalid. With StrictValidation they are returned as errors instead.
+	ValidationWarnings []error
+
+	// LoadWarnings holds the deprecated forms the loader found in the config
+	// source. Validate reports them along with its own warnings.
+	LoadWarnings []error
+
 	// TODO: remove initial raw protobuf to save memory
 	rawProto any
 }
 
+// IDs of the apply hook scripts, which are also the config fields they're set in
+const (
+	HookPreApply  = "pre_apply"
+	HookPostApply = "post_apply"
+)
+
+// Default response for an unavailable app
+const (
+	DefaultAppFallbackBody        = "Service Unavailable"
+	DefaultAppFallbackContentType = "text/plain; charset=utf-8"
+)
+
+// AppFallback configures the 503 response served in place of an app whose
+// evaluator failed to initialize at runtime. Empty fields use the defaults.
+type AppFallback struct {
+	Body        string
+	ContentType string
+}
+
+// GetBody returns the response body, with a default fallback.
+func (f AppFallback) GetBody() string {
+	if f.Body != "" {
+		return f.Body
+	}
+	return DefaultAppFallbackBody
+}
+
+// GetContentType returns the response content type, with a default fallback.
+func (f AppFallback) GetContentType() string {
+	if f.ContentType != "" {
+		return f.ContentType
+	}
+	return DefaultAppFallbackContentType
+}
+
 // Equals compares two Config objects for equality.
 func (c *Config) Equals(other *Config) bool {
 	thisProto := c.ToProto()
@@ -47,8 +120,16 @@ func (c *Config) Equals(other *Config) bool {
 
 // NewConfig loads configuration from a TOML file path, converts it to the domain model. It does NOT validate the config.
 func NewConfig(filePath string) (*Config, error) {
+	return NewConfigForEnv(filePath, "")
+}
+
+// NewConfigForEnv loads a configuration file with the overlay file for env,
+// see loader.OverlayPath, deep-merged over it. The merged configuration is
+// converted as one, like a single file; without an env it's NewConfig. It
+// does NOT validate the config.
+func NewConfigForEnv(filePath, env string) (*Config, error) {
 	// Get loader from file
-	ld, err := loader.NewLoaderFromFilePath(filePath)
+	ld, err := loader.NewLoaderForEnv(filePath, env)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFailedToLoadConfig, err)
 	}
@@ -64,6 +145,7 @@ func NewConfig(filePath string) (*Config, error) {
 		// Error during conversion
 		return nil, fmt.Errorf("%w: %w", ErrFailedToConvertConfig, err)
 	}
+	config.LoadWarnings = ld.Warnings()
 
 	return config, nil
 }
@@ -90,6 +172,20 @@ func NewFromProto(pbConfig *pb.ServerConfig) (*Config, error) {
 		config.Version = *pbConfig.Version
 	}
 
+	if pbConfig.DefaultTimeout != nil {
+		config.DefaultTimeout = pbConfig.DefaultTimeout.AsDuration()
+	}
+	config.MaxMiddlewareChainLength = int(pbConfig.GetMaxMiddlewareChainLength())
+	config.StrictValidation = pbConfig.GetStrictValidation()
+	config.IsolateFailures = pbConfig.GetIsolateFailures()
+	config.AppFallback = AppFallback{
+		Body:        pbConfig.GetAppFallback().GetBody(),
+		ContentType: pbConfig.GetAppFallback().GetContentType(),
+	}
+	if err := config.applyHooksFromProto(pbConfig); err != nil {
+		errz = append(errz, fmt.Errorf("%w: %w", ErrFailedToConvertConfig, err))
+	}
+
 	if pbConfig.Listeners != nil {
 		l, err := listeners.FromProto(pbConfig.Listeners)
 		if err != nil {
@@ -120,6 +216,21 @@ func NewFromProto(pbConfig *pb.ServerConfig) (*Config, error) {
 	return config, errors.Join(errz...)
 }
 
+// Clone returns a new Config built from the protobuf this Config was created
+// from, before validation interpolated its values, so the copy can be validated
+// and applied again. It does NOT validate the config.
+func (c *Config) Clone() (*Config, error) {
+	source, ok := c.rawProto.(*pb.ServerConfig)
+	if !ok || source == nil {
+		source = c.ToProto()
+	}
+	clone, err := NewFromProto(proto.CloneOf(source))
+	if clone != nil {
+		clone.LoadWarnings = c.LoadWarnings
+	}
+	return clone, err
+}
+
 // NewConfigFromBytes loads configuration from TOML bytes, converts it to the domain model. It does NOT validate the config.
 func NewConfigFromBytes(data []byte) (*Config, error) {
 	// Create a TOML loader from bytes using a function literal that returns the interface
@@ -140,6 +251,7 @@ func NewConfigFromBytes(data []byte) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFailedToConvertConfig, err)
 	}
+	config.LoadWarnings = ld.Warnings()
 
 	return config, nil
 }
@@ -164,6 +276,7 @@ func NewConfigFromReader(reader io.Reader) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", ErrFailedToConvertConfig, err)
 	}
+	config.LoadWarnings = ld.Warnings()
 
 	return config, nil
 }
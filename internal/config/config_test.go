@@ -3,15 +3,23 @@ package config
 import (
 	_ "embed"
 	"io"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/config/loader/toml"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/config/version"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func TestNewFromProtoWithEmptyApps(t *testing.T) {
@@ -202,3 +210,865 @@ func TestNewConfig_ErrorHandling(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to load config", "Error should mention load failure")
 	})
 }
+
+func TestDefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loaded from TOML", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+default_timeout = "15s"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Second, cfg.DefaultTimeout)
+	})
+
+	t.Run("proto round trip", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{
+			Version:        proto.String(version.Version),
+			DefaultTimeout: durationpb.New(3 * time.Second),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.DefaultTimeout)
+
+		pbConfig := cfg.ToProto()
+		require.NotNil(t, pbConfig.DefaultTimeout)
+		assert.Equal(t, 3*time.Second, pbConfig.DefaultTimeout.AsDuration())
+
+		roundTrip, err := NewFromProto(pbConfig)
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, roundTrip.DefaultTimeout)
+	})
+
+	t.Run("unset is omitted from proto", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{Version: proto.String(version.Version)})
+		require.NoError(t, err)
+		assert.Zero(t, cfg.DefaultTimeout)
+		assert.Nil(t, cfg.ToProto().DefaultTimeout)
+	})
+
+	t.Run("negative is rejected", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{Version: proto.String(version.Version)})
+		require.NoError(t, err)
+		cfg.DefaultTimeout = -time.Second
+
+		err = cfg.Validate()
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrInvalidValue)
+		assert.Contains(t, err.Error(), "default_timeout")
+	})
+}
+
+func TestMaxMiddlewareChainLength(t *testing.T) {
+	t.Parallel()
+
+	// The route's resolved chain is two endpoint middlewares plus one route middleware
+	load := func(t *testing.T, settings string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+` + settings + `
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "00-logger"
+type = "console_logger"
+[endpoints.middlewares.console_logger]
+preset = "minimal"
+
+[[endpoints.middlewares]]
+id = "01-logger"
+type = "console_logger"
+[endpoints.middlewares.console_logger]
+preset = "minimal"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`))
+		require.NoError(t, err)
+		route := &cfg.Endpoints[0].Routes[0]
+		route.Middlewares = append(route.Middlewares, middleware.Middleware{
+			ID:     "02-logger",
+			Config: logger.NewConsoleLogger(),
+		})
+		return cfg
+	}
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = 3\nstrict_validation = true")
+		assert.Equal(t, 3, cfg.MaxMiddlewareChainLength)
+		assert.True(t, cfg.StrictValidation)
+
+		pbConfig := cfg.ToProto()
+		assert.Equal(t, int32(3), pbConfig.GetMaxMiddlewareChainLength())
+		assert.True(t, pbConfig.GetStrictValidation())
+	})
+
+	t.Run("chain at the limit", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = 3")
+		require.NoError(t, cfg.Validate())
+		assert.Empty(t, cfg.ValidationWarnings)
+	})
+
+	t.Run("unset limit disables the check", func(t *testing.T) {
+		cfg := load(t, "")
+		require.NoError(t, cfg.Validate())
+		assert.Empty(t, cfg.ValidationWarnings)
+	})
+
+	t.Run("chain beyond the limit warns", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = 2")
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.ValidationWarnings, 1)
+		warning := cfg.ValidationWarnings[0]
+		require.ErrorIs(t, warning, ErrMiddlewareChainTooLong)
+		assert.Contains(t, warning.Error(), "endpoint 'main'")
+		assert.Contains(t, warning.Error(), "/api")
+		assert.Contains(t, warning.Error(), "has 3 middlewares, maximum is 2")
+	})
+
+	t.Run("chain beyond the limit is a warning issue", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = 2")
+		issues, err := cfg.ValidateWithIssues()
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.Equal(t, validation.SeverityWarning, issues[0].Severity)
+		assert.Equal(t, validation.ComponentRoute, issues[0].ComponentType)
+		assert.Equal(t, "main[0]", issues[0].ComponentID)
+		assert.Contains(t, issues[0].Message, "has 3 middlewares, maximum is 2")
+	})
+
+	t.Run("chain beyond the limit fails in strict mode", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = 2\nstrict_validation = true")
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrFailedToValidateConfig)
+		require.ErrorIs(t, err, ErrMiddlewareChainTooLong)
+		assert.Empty(t, cfg.ValidationWarnings)
+	})
+
+	t.Run("negative limit is rejected", func(t *testing.T) {
+		cfg := load(t, "max_middleware_chain_length = -1")
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrInvalidValue)
+		assert.Contains(t, err.Error(), "max_middleware_chain_length")
+	})
+}
+
+func TestEndpointOnSeveralListeners(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, extra string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[listeners]]
+id = "https"
+address = ":8443"
+type = "http"
+
+[[listeners.middlewares]]
+id = "00-logger"
+type = "console_logger"
+[listeners.middlewares.console_logger]
+preset = "minimal"
+
+[[endpoints]]
+id = "main"
+listener_ids = ["http", "https"]
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+` + extra))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	t.Run("routes are served on every listener", func(t *testing.T) {
+		cfg := load(t, "")
+		require.NoError(t, cfg.Validate())
+		assert.Empty(t, cfg.ValidationWarnings, "both listeners are used")
+
+		ep := cfg.Endpoints[0]
+		assert.Equal(t, []string{"http", "https"}, ep.AllListenerIDs())
+		for _, listenerID := range ep.AllListenerIDs() {
+			assert.Len(t, slices.Collect(cfg.Endpoints.FindByListenerID(listenerID)), 1)
+		}
+
+		// Each listener runs the route with its own defaults
+		onHTTP := ep.ForListener("http")
+		assert.Empty(t, onHTTP.MiddlewareChain(&onHTTP.Routes[0]))
+		onHTTPS := ep.ForListener("https")
+		assert.Len(t, onHTTPS.MiddlewareChain(&onHTTPS.Routes[0]), 1)
+	})
+
+	t.Run("round tripped through proto", func(t *testing.T) {
+		cfg := load(t, "")
+		roundTripped, err := NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		assert.Equal(t, cfg.Endpoints[0].AllListenerIDs(), roundTripped.Endpoints[0].AllListenerIDs())
+	})
+
+	t.Run("every listener must exist", func(t *testing.T) {
+		cfg := load(t, "")
+		cfg.Endpoints[0].ListenerIDs = append(cfg.Endpoints[0].ListenerIDs, "missing")
+		issues, err := cfg.ValidateWithIssues()
+		require.ErrorIs(t, err, ErrListenerNotFound)
+		assert.Contains(t, err.Error(), "endpoint 'main' references non-existent listener ID 'missing'")
+		require.NotEmpty(t, issues)
+		assert.Equal(t, "listener_ids", issues[0].Field)
+	})
+
+	t.Run("routes conflict on a shared listener", func(t *testing.T) {
+		cfg := load(t, `
+[[endpoints]]
+id = "other"
+listener_id = "https"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+`)
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrRouteConflict)
+		assert.Contains(t, err.Error(), "on listener 'https' is used by both route 'main[0]' and 'other[0]'")
+		assert.NotContains(t, err.Error(), "on listener 'http' ")
+	})
+}
+
+func TestListenerMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	// The listener provides two defaults, the endpoint adds one of its own
+	load := func(t *testing.T, endpointSettings string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[listeners.middlewares]]
+id = "00-request-id"
+type = "headers"
+[listeners.middlewares.headers.request.set_headers]
+"X-Request-Source" = "firelynx"
+
+[[listeners.middlewares]]
+id = "10-logger"
+type = "console_logger"
+[listeners.middlewares.console_logger]
+preset = "minimal"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+` + endpointSettings + `
+
+[[endpoints.middlewares]]
+id = "05-audit"
+type = "console_logger"
+[endpoints.middlewares.console_logger]
+preset = "detailed"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	chainIDs := func(cfg *Config) []string {
+		ep := &cfg.Endpoints[0]
+		var ids []string
+		for _, mw := range ep.MiddlewareChain(&ep.Routes[0]) {
+			ids = append(ids, mw.ID)
+		}
+		return ids
+	}
+
+	t.Run("defaults are merged into every endpoint", func(t *testing.T) {
+		cfg := load(t, "")
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, []string{"00-request-id", "05-audit", "10-logger"}, chainIDs(cfg))
+
+		routes := cfg.Endpoints[0].GetStructuredHTTPRoutes()
+		require.Len(t, routes, 1)
+		assert.Len(t, routes[0].Middlewares, 3)
+	})
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg := load(t, `exclude_listener_middlewares = ["10-logger"]`)
+		listener := cfg.Listeners[0]
+		require.Len(t, listener.Middlewares, 2)
+		assert.IsType(t, &logger.ConsoleLogger{}, listener.Middlewares[1].Config)
+
+		pbConfig := cfg.ToProto()
+		require.Len(t, pbConfig.GetListeners()[0].GetMiddlewares(), 2)
+		assert.Equal(t, []string{"10-logger"},
+			pbConfig.GetEndpoints()[0].GetExcludeListenerMiddlewares())
+
+		roundTripped, err := NewFromProto(pbConfig)
+		require.NoError(t, err)
+		require.NoError(t, roundTripped.Validate())
+		assert.Equal(t, []string{"00-request-id", "05-audit"}, chainIDs(roundTripped))
+	})
+
+	t.Run("excluding an unknown default is an error", func(t *testing.T) {
+		cfg := load(t, `exclude_listener_middlewares = ["99-missing"]`)
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrInvalidReference)
+		assert.Contains(t, err.Error(),
+			"endpoint 'main' excludes middleware '99-missing', which none of its listeners ('http') has")
+	})
+
+	t.Run("redefining a default with another config is an error", func(t *testing.T) {
+		cfg := load(t, "")
+		cfg.Endpoints[0].Middlewares = append(cfg.Endpoints[0].Middlewares, middleware.Middleware{
+			ID:     "10-logger",
+			Config: logger.NewConsoleLogger(),
+		})
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrDuplicateID)
+		assert.Contains(t, err.Error(),
+			"middleware ID '10-logger' is declared with different configs, "+
+				"at listener 'http' middlewares[1], endpoint 'main' middlewares[1]")
+
+		issues, err := cfg.ValidateWithIssues()
+		require.Error(t, err)
+		require.NotEmpty(t, issues)
+		assert.Equal(t, validation.ComponentMiddleware, issues[0].ComponentType)
+		assert.Equal(t, "10-logger", issues[0].ComponentID)
+	})
+
+	t.Run("redefining a default with the same config is allowed", func(t *testing.T) {
+		cfg := load(t, "")
+		route := &cfg.Endpoints[0].Routes[0]
+		route.Middlewares = append(route.Middlewares, cfg.Listeners[0].Middlewares[1])
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, []string{"00-request-id", "05-audit", "10-logger"}, chainIDs(cfg))
+	})
+
+	t.Run("defaults count towards the chain length", func(t *testing.T) {
+		cfg := load(t, "")
+		cfg.MaxMiddlewareChainLength = 2
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.ValidationWarnings, 1)
+		require.ErrorIs(t, cfg.ValidationWarnings[0], ErrMiddlewareChainTooLong)
+	})
+}
+
+func TestAppFallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[app_fallback]
+body = "maintenance"
+content_type = "text/html"
+`))
+		require.NoError(t, err)
+		assert.Equal(t, AppFallback{Body: "maintenance", ContentType: "text/html"}, cfg.AppFallback)
+
+		roundTrip, err := NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		assert.Equal(t, cfg.AppFallback, roundTrip.AppFallback)
+	})
+
+	t.Run("defaults when unset", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{Version: proto.String(version.Version)})
+		require.NoError(t, err)
+		assert.Nil(t, cfg.ToProto().AppFallback)
+		assert.Equal(t, DefaultAppFallbackBody, cfg.AppFallback.GetBody())
+		assert.Equal(t, DefaultAppFallbackContentType, cfg.AppFallback.GetContentType())
+	})
+}
+
+func TestListenerNotFoundApp(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, notFoundAppID string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+[listeners.http]
+not_found_app_id = "` + notFoundAppID + `"
+
+[[listeners]]
+id = "spa"
+address = ":8081"
+type = "http"
+[listeners.http]
+not_found_app_id = "not-found"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+
+[[apps]]
+id = "not-found"
+type = "echo"
+[apps.echo]
+response = "nothing here"
+`))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg := load(t, "not-found")
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, "not-found", cfg.Listeners[0].GetNotFoundAppID())
+
+		roundTrip, err := NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		assert.Equal(t, "not-found", roundTrip.Listeners[0].GetNotFoundAppID())
+	})
+
+	t.Run("not found app is neither orphaned nor its listener unused", func(t *testing.T) {
+		cfg := load(t, "not-found")
+		require.NoError(t, cfg.Validate())
+		assert.Empty(t, cfg.ValidationWarnings)
+	})
+
+	t.Run("referenced app must exist", func(t *testing.T) {
+		cfg := load(t, "missing")
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrAppNotFound)
+		assert.Contains(t, err.Error(), "listener 'http' references non-existent not found app ID 'missing'")
+	})
+}
+
+func TestListenerMethodNotAllowedApp(t *testing.T) {
+	t.Parallel()
+
+	load := func(t *testing.T, appID string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+[listeners.http]
+method_not_allowed_app_id = "` + appID + `"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+methods = ["GET"]
+[endpoints.routes.http]
+path_prefix = "/api"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+
+[[apps]]
+id = "not-allowed"
+type = "echo"
+[apps.echo]
+response = "use another method"
+`))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg := load(t, "not-allowed")
+		require.NoError(t, cfg.Validate())
+		assert.Equal(t, "not-allowed", cfg.Listeners[0].GetMethodNotAllowedAppID())
+		assert.Empty(t, cfg.ValidationWarnings)
+
+		roundTrip, err := NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		assert.Equal(t, "not-allowed", roundTrip.Listeners[0].GetMethodNotAllowedAppID())
+	})
+
+	t.Run("referenced app must exist", func(t *testing.T) {
+		cfg := load(t, "missing")
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrAppNotFound)
+		assert.Contains(t, err.Error(), "listener 'http' references non-existent method not allowed app ID 'missing'")
+	})
+}
+
+func TestMCPToolValidateOutput(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "tools"
+[endpoints.routes.http]
+path_prefix = "/mcp"
+
+[[apps]]
+id = "convert"
+type = "script"
+[apps.script.risor]
+code = "{}"
+
+[[apps]]
+id = "tools"
+type = "mcp"
+
+[[apps.mcp.tools]]
+id = "checked"
+app_id = "convert"
+input_schema = '{"type":"object"}'
+output_schema = '{"type":"object"}'
+
+[[apps.mcp.tools]]
+id = "unchecked"
+app_id = "convert"
+input_schema = '{"type":"object"}'
+output_schema = '{"type":"object"}'
+validate_output = false
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	app, ok := cfg.Apps.FindByID("tools")
+	require.True(t, ok)
+	mcpApp, ok := app.Config.(*mcpserver.App)
+	require.True(t, ok)
+	require.Len(t, mcpApp.Tools, 2)
+	assert.False(t, mcpApp.Tools[0].SkipOutputValidation, "results are checked by default")
+	assert.True(t, mcpApp.Tools[1].SkipOutputValidation)
+}
+
+func TestMCPToolCallConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "tools"
+[endpoints.routes.http]
+path_prefix = "/mcp"
+
+[[apps]]
+id = "convert"
+type = "script"
+[apps.script.risor]
+code = "{}"
+
+[[apps]]
+id = "tools"
+type = "mcp"
+[apps.mcp]
+max_concurrent_tool_calls = 4
+tool_call_queue_timeout = "2s"
+
+[[apps.mcp.tools]]
+app_id = "convert"
+input_schema = '{"type":"object"}'
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	app, ok := cfg.Apps.FindByID("tools")
+	require.True(t, ok)
+	mcpApp, ok := app.Config.(*mcpserver.App)
+	require.True(t, ok)
+	assert.Equal(t, 4, mcpApp.MaxConcurrentToolCalls)
+	assert.Equal(t, 2*time.Second, mcpApp.ToolCallQueueTimeout)
+}
+
+func TestMCPToolMiddlewares(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "tools"
+[endpoints.routes.http]
+path_prefix = "/mcp"
+
+[[apps]]
+id = "convert"
+type = "script"
+[apps.script.risor]
+code = "{}"
+
+[[apps]]
+id = "tools"
+type = "mcp"
+
+[[apps.mcp.tools]]
+app_id = "convert"
+input_schema = '{"type":"object"}'
+
+[[apps.mcp.tools.middlewares]]
+[apps.mcp.tools.middlewares.logging]
+
+[[apps.mcp.tools.middlewares]]
+[apps.mcp.tools.middlewares.authentication]
+subjects = ["alice"]
+
+[[apps.mcp.tools.middlewares]]
+[apps.mcp.tools.middlewares.rate_limiting]
+calls_per_second = 5
+burst = 10
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	app, ok := cfg.Apps.FindByID("tools")
+	require.True(t, ok)
+	mcpApp, ok := app.Config.(*mcpserver.App)
+	require.True(t, ok)
+	require.Len(t, mcpApp.Tools, 1)
+	assert.Equal(t, []mcpserver.ToolMiddleware{
+		&mcpserver.MiddlewareLogging{},
+		&mcpserver.MiddlewareAuthentication{Subjects: []string{"alice"}},
+		&mcpserver.MiddlewareRateLimiting{CallsPerSecond: 5, Burst: 10},
+	}, mcpApp.Tools[0].Middlewares)
+}
+
+func TestIsolateFailures(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := NewConfigFromBytes([]byte("version = \"v1\"\nisolate_failures = true\n"))
+	require.NoError(t, err)
+	assert.True(t, cfg.IsolateFailures)
+
+	roundTrip, err := NewFromProto(cfg.ToProto())
+	require.NoError(t, err)
+	assert.True(t, roundTrip.IsolateFailures)
+
+	cfg, err = NewFromProto(&pb.ServerConfig{Version: proto.String(version.Version)})
+	require.NoError(t, err)
+	assert.False(t, cfg.IsolateFailures)
+	assert.Nil(t, cfg.ToProto().IsolateFailures)
+}
+
+func TestLegacyRouteWarning(t *testing.T) {
+	t.Parallel()
+
+	source := []byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "legacy"
+listener_id = "http"
+
+[endpoints.route]
+app_id = "echo"
+[endpoints.route.http]
+path_prefix = "/"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hi"
+`)
+
+	cfg, err := NewConfigFromBytes(source)
+	require.NoError(t, err)
+	require.Len(t, cfg.Endpoints, 1)
+	require.Len(t, cfg.Endpoints[0].Routes, 1)
+
+	issues, err := cfg.ValidateWithIssues()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, validation.Issue{
+		ComponentType: validation.ComponentEndpoint,
+		ComponentID:   "legacy",
+		Field:         "route",
+		Message:       toml.ErrDeprecatedRoute.Error(),
+		Severity:      validation.SeverityWarning,
+	}, issues[0])
+
+	// A clone keeps the warning
+	clone, err := cfg.Clone()
+	require.NoError(t, err)
+	require.NoError(t, clone.Validate())
+	require.Len(t, clone.ValidationWarnings, 1)
+	assert.ErrorIs(t, clone.ValidationWarnings[0], toml.ErrDeprecatedRoute)
+}
+
+func TestApplyHooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("loaded from TOML and round tripped", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[pre_apply.risor]
+code = "true"
+
+[pre_apply.static_data]
+environment = "prod"
+
+[post_apply.starlark]
+code = "_ = True"
+`))
+		require.NoError(t, err)
+		require.NotNil(t, cfg.PreApply)
+		require.NotNil(t, cfg.PostApply)
+		assert.Equal(t, HookPreApply, cfg.PreApply.ID)
+		assert.Equal(t, "prod", cfg.PreApply.StaticData.Data["environment"])
+		assert.Equal(t, HookPostApply, cfg.PostApply.ID)
+		require.NoError(t, cfg.Validate())
+
+		roundTrip, err := NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		assert.True(t, cfg.Equals(roundTrip))
+		assert.Contains(t, cfg.String(), "Pre-Apply Hook: Risor")
+		assert.Contains(t, cfg.String(), "Post-Apply Hook: Starlark")
+	})
+
+	t.Run("unset by default", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{Version: proto.String(version.Version)})
+		require.NoError(t, err)
+		assert.Nil(t, cfg.PreApply)
+		assert.Nil(t, cfg.PostApply)
+		assert.Nil(t, cfg.ToProto().PreApply)
+	})
+
+	t.Run("invalid hook is attributed to its field", func(t *testing.T) {
+		cfg, err := NewFromProto(&pb.ServerConfig{
+			Version: proto.String(version.Version),
+			PostApply: &pbApps.ScriptApp{
+				Evaluator: &pbApps.ScriptApp_Risor{
+					Risor: &pbApps.RisorEvaluator{
+						Source: &pbApps.RisorEvaluator_Code{Code: "let ="},
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		issues, err := cfg.ValidateWithIssues()
+		require.Error(t, err)
+		require.NotEmpty(t, issues)
+		assert.Equal(t, HookPostApply, issues[0].Field)
+		assert.Equal(t, validation.SeverityError, issues[0].Severity)
+	})
+}
+
+func TestClone(t *testing.T) {
+	t.Setenv("CLONE_TEST_PORT", "9090")
+
+	cfg, err := NewFromProto(&pb.ServerConfig{
+		Version: proto.String(VersionLatest),
+		Listeners: []*pb.Listener{{
+			Id:      proto.String("http"),
+			Address: proto.String(":${CLONE_TEST_PORT}"),
+			Type:    pb.Listener_TYPE_HTTP.Enum(),
+		}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, ":9090", cfg.Listeners[0].Address)
+
+	// The copy is built from the original source, before interpolation
+	clone, err := cfg.Clone()
+	require.NoError(t, err)
+	assert.NotSame(t, cfg, clone)
+	assert.Equal(t, ":${CLONE_TEST_PORT}", clone.Listeners[0].Address)
+	assert.False(t, clone.ValidationCompleted)
+	require.NoError(t, clone.Validate())
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"cmp"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ChangeKind describes how a component differs between two configurations
+type ChangeKind string
+
+const (
+	// ChangeAdded indicates the component only exists in the new configuration
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved indicates the component only exists in the current configuration
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeChanged indicates the component exists in both but its settings differ
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a single component that differs between two configurations
+type Change struct {
+	ID   string
+	Kind ChangeKind
+}
+
+// Delta lists the components that differ between two configurations, sorted
+// by ID within each kind of component.
+type Delta struct {
+	Listeners []Change
+	Endpoints []Change
+	Apps      []Change
+
+	// Middlewares are keyed by "endpointID/middlewareID" for endpoint
+	// middlewares and "endpointID/appID/middlewareID" for route middlewares
+	Middlewares []Change
+}
+
+// IsEmpty reports whether the two configurations had no differences
+func (d Delta) IsEmpty() bool {
+	return len(d.Listeners) == 0 && len(d.Endpoints) == 0 &&
+		len(d.Apps) == 0 && len(d.Middlewares) == 0
+}
+
+// Diff computes the changes needed to go from the current configuration to
+// next. Components are matched by ID and compared by their protobuf form. A
+// nil current configuration reports every component of next as added.
+func Diff(current, next *Config) Delta {
+	cur := componentsOf(current)
+	nxt := componentsOf(next)
+
+	return Delta{
+		Listeners:   diffComponents(cur.listeners, nxt.listeners),
+		Endpoints:   diffComponents(cur.endpoints, nxt.endpoints),
+		Apps:        diffComponents(cur.apps, nxt.apps),
+		Middlewares: diffComponents(cur.middlewares, nxt.middlewares),
+	}
+}
+
+// components holds the protobuf form of each component of a config, by ID
+type components struct {
+	listeners   map[string]proto.Message
+	endpoints   map[string]proto.Message
+	apps        map[string]proto.Message
+	middlewares map[string]proto.Message
+}
+
+func componentsOf(c *Config) components {
+	comps := components{
+		listeners:   make(map[string]proto.Message),
+		endpoints:   make(map[string]proto.Message),
+		apps:        make(map[string]proto.Message),
+		middlewares: make(map[string]proto.Message),
+	}
+	if c == nil {
+		return comps
+	}
+
+	for _, l := range c.Listeners.ToProto() {
+		comps.listeners[l.GetId()] = l
+	}
+
+	for _, e := range c.Endpoints.ToProto() {
+		comps.endpoints[e.GetId()] = e
+		for _, m := range e.GetMiddlewares() {
+			comps.middlewares[e.GetId()+"/"+m.GetId()] = m
+		}
+		for _, r := range e.GetRoutes() {
+			for _, m := range r.GetMiddlewares() {
+				comps.middlewares[e.GetId()+"/"+r.GetAppId()+"/"+m.GetId()] = m
+			}
+		}
+	}
+
+	if c.Apps != nil {
+		for _, a := range c.Apps.ToProto() {
+			comps.apps[a.GetId()] = a
+		}
+	}
+
+	return comps
+}
+
+func diffComponents(current, next map[string]proto.Message) []Change {
+	var changes []Change
+	for id, n := range next {
+		c, ok := current[id]
+		switch {
+		case !ok:
+			changes = append(changes, Change{ID: id, Kind: ChangeAdded})
+		case !proto.Equal(c, n):
+			changes = append(changes, Change{ID: id, Kind: ChangeChanged})
+		}
+	}
+	for id := range current {
+		if _, ok := next[id]; !ok {
+			changes = append(changes, Change{ID: id, Kind: ChangeRemoved})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int { return cmp.Compare(a.ID, b.ID) })
+	return changes
+}
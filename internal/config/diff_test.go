@@ -0,0 +1,124 @@
+package config
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffBaseConfig = `
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.middlewares]]
+id = "logger"
+type = "console_logger"
+[endpoints.middlewares.console_logger]
+preset = "minimal"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`
+
+func loadDiffConfig(t *testing.T, data string) *Config {
+	t.Helper()
+	cfg, err := NewConfigFromBytes([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("identical configs have no changes", func(t *testing.T) {
+		delta := Diff(loadDiffConfig(t, diffBaseConfig), loadDiffConfig(t, diffBaseConfig))
+		assert.True(t, delta.IsEmpty())
+	})
+
+	t.Run("nil current reports everything as added", func(t *testing.T) {
+		delta := Diff(nil, loadDiffConfig(t, diffBaseConfig))
+		assert.Equal(t, []Change{{ID: "http", Kind: ChangeAdded}}, delta.Listeners)
+		assert.Equal(t, []Change{{ID: "main", Kind: ChangeAdded}}, delta.Endpoints)
+		assert.Equal(t, []Change{{ID: "echo", Kind: ChangeAdded}}, delta.Apps)
+		assert.Equal(t, []Change{{ID: "main/logger", Kind: ChangeAdded}}, delta.Middlewares)
+	})
+
+	t.Run("added changed and removed components", func(t *testing.T) {
+		next := loadDiffConfig(t, `
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":9090"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.routes]]
+app_id = "echo2"
+[endpoints.routes.http]
+path_prefix = "/two"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+
+[[apps]]
+id = "echo2"
+type = "echo"
+[apps.echo]
+response = "two"
+`)
+
+		delta := Diff(loadDiffConfig(t, diffBaseConfig), next)
+		assert.False(t, delta.IsEmpty())
+		assert.Equal(t, []Change{{ID: "http", Kind: ChangeChanged}}, delta.Listeners)
+		assert.Equal(t, []Change{{ID: "main", Kind: ChangeChanged}}, delta.Endpoints)
+		assert.Equal(t, []Change{{ID: "echo2", Kind: ChangeAdded}}, delta.Apps)
+		assert.Equal(t, []Change{{ID: "main/logger", Kind: ChangeRemoved}}, delta.Middlewares)
+	})
+
+	t.Run("ToProto", func(t *testing.T) {
+		delta := Delta{
+			Listeners: []Change{{ID: "http", Kind: ChangeChanged}},
+			Apps:      []Change{{ID: "a", Kind: ChangeAdded}, {ID: "b", Kind: ChangeRemoved}},
+		}
+		pbDelta := delta.ToProto()
+		require.Len(t, pbDelta.GetListeners(), 1)
+		assert.Equal(t, "http", pbDelta.GetListeners()[0].GetId())
+		assert.Equal(t, pb.ComponentChange_KIND_CHANGED, pbDelta.GetListeners()[0].GetKind())
+		require.Len(t, pbDelta.GetApps(), 2)
+		assert.Equal(t, pb.ComponentChange_KIND_ADDED, pbDelta.GetApps()[0].GetKind())
+		assert.Equal(t, pb.ComponentChange_KIND_REMOVED, pbDelta.GetApps()[1].GetKind())
+		assert.Empty(t, pbDelta.GetEndpoints())
+		assert.Empty(t, pbDelta.GetMiddlewares())
+	})
+}
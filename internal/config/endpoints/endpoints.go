@@ -17,10 +17,13 @@
 package endpoints
 
 import (
+	"fmt"
 	"iter"
+	"slices"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 )
 
 // EndpointCollection is a collection of Endpoint objects
@@ -37,42 +40,127 @@ type Endpoint struct {
 	ListenerID  string // Single listener ID instead of an array
 	Routes      routes.RouteCollection
 	Middlewares middleware.MiddlewareCollection
+
+	// StaticData holds defaults for the static data of every route, see
+	// RouteStaticData
+	StaticData map[string]any
+
+	// ExcludeListenerMiddlewares lists the IDs of listener default
+	// middlewares this endpoint opts out of
+	ExcludeListenerMiddlewares []string
+
+	// ListenerIDs are further listeners the endpoint is attached to, besides
+	// ListenerID. The endpoint's routes are served on each of them.
+	ListenerIDs []string
+
+	// Disabled keeps the endpoint in the config without wiring it onto its
+	// listeners. Endpoints are enabled by default.
+	Disabled bool
+
+	// ListenerMiddlewares are the default middlewares of the endpoint's
+	// listeners, by listener ID. They aren't part of the endpoint's own
+	// config, the parent Config resolves them during validation.
+	ListenerMiddlewares map[string]middleware.MiddlewareCollection
+}
+
+// AllListenerIDs returns the IDs of every listener the endpoint is attached
+// to: ListenerID followed by ListenerIDs.
+func (e *Endpoint) AllListenerIDs() []string {
+	ids := make([]string, 0, 1+len(e.ListenerIDs))
+	if e.ListenerID != "" {
+		ids = append(ids, e.ListenerID)
+	}
+	return append(ids, e.ListenerIDs...)
+}
+
+// ForListener returns a copy of the endpoint attached only to listenerID, as
+// it's served on that listener: its middleware chains use that listener's
+// defaults.
+func (e Endpoint) ForListener(listenerID string) Endpoint {
+	e.ListenerID = listenerID
+	e.ListenerIDs = nil
+	return e
 }
 
 // GetStructuredHTTPRoutes returns all HTTP routes for this endpoint in a structured format.
 // It extracts routes with HTTP conditions and returns them as the more type-safe HTTPRoute
-// structure with path, app ID, static data, and merged middleware explicitly defined.
+// structure with route ID, path, app ID, static data, and merged middleware explicitly defined.
 func (e *Endpoint) GetStructuredHTTPRoutes() []routes.HTTPRoute {
 	httpRoutes := e.Routes.GetStructuredHTTPRoutes()
 
-	// Add merged middleware to each HTTP route
+	// Add merged middleware and static data to each HTTP route
 	for i := range httpRoutes {
-		// Find the original route that corresponds to this HTTP route
-		for j := range e.Routes {
-			if e.Routes[j].AppID == httpRoutes[i].AppID {
-				// Merge endpoint and route middleware
-				httpRoutes[i].Middlewares = e.getMergedMiddleware(&e.Routes[j])
-				break
-			}
-		}
+		httpRoutes[i].StaticData = staticdata.DeepMerge(e.StaticData, httpRoutes[i].StaticData)
+		httpRoutes[i].ID = e.RouteID(httpRoutes[i].Index)
+
+		// Merge endpoint and route middleware
+		httpRoutes[i].Middlewares = e.getMergedMiddleware(&e.Routes[httpRoutes[i].Index])
 	}
 
 	return httpRoutes
 }
 
-// getMergedMiddleware merges endpoint-level middleware with route-level middleware.
-// The method deduplicates middleware by ID (route middleware takes precedence over endpoint middleware)
-// and returns the result sorted alphabetically by middleware ID.
+// MiddlewareChain returns the resolved middleware chain for a route on this
+// endpoint: listener, endpoint and route middleware merged, as the route will
+// run them on ListenerID. Use ForListener for the chain on another listener.
+func (e *Endpoint) MiddlewareChain(r *routes.Route) middleware.MiddlewareCollection {
+	return e.getMergedMiddleware(r)
+}
+
+// RouteID identifies the route at index i of this endpoint in validation
+// issues, since routes have no IDs of their own
+func (e *Endpoint) RouteID(i int) string {
+	return fmt.Sprintf("%s[%d]", e.ID, i)
+}
+
+// RouteStaticData returns the static data handed to a route's app: the
+// endpoint's static data deep-merged with the route's, with the route's values
+// winning on conflict (see staticdata.DeepMerge).
+func (e *Endpoint) RouteStaticData(r *routes.Route) map[string]any {
+	if r == nil {
+		return staticdata.DeepMerge(e.StaticData, nil)
+	}
+	return staticdata.DeepMerge(e.StaticData, r.StaticData)
+}
+
+// getMergedMiddleware merges the listener's default middleware, minus the ones
+// this endpoint excludes, with endpoint-level and route-level middleware. The
+// method deduplicates middleware by ID (route middleware takes precedence over
+// endpoint middleware, which takes precedence over listener middleware) and
+// returns the result sorted alphabetically by middleware ID.
 //
 // This enables ordering middleware using naming conventions like:
 // - "00-authentication"
 // - "01-logger"
 // - "02-rate-limiter"
+//
+// Listener defaults take part in the same ordering, so an endpoint moves one
+// by excluding it and declaring the middleware again under another ID.
 func (e *Endpoint) getMergedMiddleware(r *routes.Route) middleware.MiddlewareCollection {
+	listenerDefaults := e.listenerMiddlewares()
 	if r == nil {
-		return e.Middlewares
+		if len(listenerDefaults) == 0 {
+			return e.Middlewares
+		}
+		return listenerDefaults.Merge(e.Middlewares)
+	}
+	return listenerDefaults.Merge(e.Middlewares, r.Middlewares)
+}
+
+// listenerMiddlewares returns the default middleware of ListenerID that apply
+// to this endpoint
+func (e *Endpoint) listenerMiddlewares() middleware.MiddlewareCollection {
+	defaults := e.ListenerMiddlewares[e.ListenerID]
+	if len(e.ExcludeListenerMiddlewares) == 0 {
+		return defaults
 	}
-	return e.Middlewares.Merge(r.Middlewares)
+	result := make(middleware.MiddlewareCollection, 0, len(defaults))
+	for _, mw := range defaults {
+		if !slices.Contains(e.ExcludeListenerMiddlewares, mw.ID) {
+			result = append(result, mw)
+		}
+	}
+	return result
 }
 
 // All returns an iterator over all endpoints in the collection.
@@ -97,11 +185,13 @@ func (ec EndpointCollection) FindByID(id string) (Endpoint, bool) {
 }
 
 // FindByListenerID returns an iterator over endpoints attached to a specific listener ID.
+// Endpoints attached to several listeners are yielded as served on this one, see
+// Endpoint.ForListener.
 func (ec EndpointCollection) FindByListenerID(listenerID string) iter.Seq[Endpoint] {
 	return func(yield func(Endpoint) bool) {
 		for _, endpoint := range ec {
-			if endpoint.ListenerID == listenerID {
-				if !yield(endpoint) {
+			if slices.Contains(endpoint.AllListenerIDs(), listenerID) {
+				if !yield(endpoint.ForListener(listenerID)) {
 					return
 				}
 			}
@@ -121,6 +211,7 @@ func (ec EndpointCollection) GetIDsForListener(listenerID string) iter.Seq[strin
 }
 
 // GetListenerIDMapping creates a mapping from endpoint IDs to their listener IDs.
+// Endpoints attached to several listeners are mapped to their ListenerID.
 func (ec EndpointCollection) GetListenerIDMapping() map[string]string {
 	result := make(map[string]string)
 	for endpoint := range ec.All() {
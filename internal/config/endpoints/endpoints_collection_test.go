@@ -210,6 +210,38 @@ func TestEndpointCollection_FindByListenerID(t *testing.T) {
 	})
 }
 
+func TestEndpointCollection_FindByListenerID_SeveralListeners(t *testing.T) {
+	t.Parallel()
+
+	shared := Endpoint{
+		ID:          "shared",
+		ListenerID:  "http",
+		ListenerIDs: []string{"https"},
+		ListenerMiddlewares: map[string]middleware.MiddlewareCollection{
+			"https": {{ID: "00-hsts", Config: logger.NewConsoleLogger()}},
+		},
+	}
+	collection := EndpointCollection{shared, {ID: "plain", ListenerID: "http"}}
+
+	assert.Equal(t, []string{"http", "https"}, shared.AllListenerIDs())
+	assert.Equal(t, []string{"shared", "plain"}, slices.Collect(collection.GetIDsForListener("http")))
+	assert.Equal(t, []string{"shared"}, slices.Collect(collection.GetIDsForListener("https")))
+
+	// Endpoints are yielded as served on the listener, with its defaults
+	onHTTPS := slices.Collect(collection.FindByListenerID("https"))
+	require.Len(t, onHTTPS, 1)
+	assert.Equal(t, "https", onHTTPS[0].ListenerID)
+	assert.Empty(t, onHTTPS[0].ListenerIDs)
+	assert.Len(t, onHTTPS[0].MiddlewareChain(nil), 1)
+
+	onHTTP := slices.Collect(collection.FindByListenerID("http"))
+	require.Len(t, onHTTP, 2)
+	assert.Empty(t, onHTTP[0].MiddlewareChain(nil))
+
+	// The collection itself is unchanged
+	assert.Equal(t, []string{"https"}, collection[0].ListenerIDs)
+}
+
 func TestEndpointCollection_GetIDsForListener(t *testing.T) {
 	t.Parallel()
 
@@ -251,6 +251,95 @@ func TestEndpoint_getMergedMiddleware_EmptyCollections(t *testing.T) {
 	})
 }
 
+func TestEndpoint_getMergedMiddleware_ListenerDefaults(t *testing.T) {
+	t.Parallel()
+
+	listenerDefaults := middleware.MiddlewareCollection{
+		{ID: "00-request-id", Config: logger.NewConsoleLogger()},
+		{ID: "05-recovery", Config: logger.NewConsoleLogger()},
+		{ID: "10-logger", Config: logger.NewConsoleLogger()},
+	}
+	routeMw := middleware.Middleware{ID: "07-route", Config: logger.NewConsoleLogger()}
+	endpointMw := middleware.Middleware{ID: "02-endpoint", Config: logger.NewConsoleLogger()}
+
+	newEndpoint := func() Endpoint {
+		return Endpoint{
+			ID:         "test-endpoint",
+			ListenerID: "test-listener",
+			Routes: routes.RouteCollection{{
+				AppID:       "test-app",
+				Condition:   conditions.NewHTTP("/test", "GET"),
+				Middlewares: middleware.MiddlewareCollection{routeMw},
+			}},
+			Middlewares: middleware.MiddlewareCollection{endpointMw},
+			ListenerMiddlewares: map[string]middleware.MiddlewareCollection{
+				"test-listener": listenerDefaults,
+			},
+		}
+	}
+
+	ids := func(mws middleware.MiddlewareCollection) []string {
+		result := make([]string, 0, len(mws))
+		for _, mw := range mws {
+			result = append(result, mw.ID)
+		}
+		return result
+	}
+
+	t.Run("listener defaults are merged into the chain by ID", func(t *testing.T) {
+		endpoint := newEndpoint()
+		merged := endpoint.getMergedMiddleware(&endpoint.Routes[0])
+		assert.Equal(t,
+			[]string{"00-request-id", "02-endpoint", "05-recovery", "07-route", "10-logger"},
+			ids(merged))
+	})
+
+	t.Run("without a route", func(t *testing.T) {
+		endpoint := newEndpoint()
+		merged := endpoint.getMergedMiddleware(nil)
+		assert.Equal(t,
+			[]string{"00-request-id", "02-endpoint", "05-recovery", "10-logger"},
+			ids(merged))
+	})
+
+	t.Run("excluded defaults are left out", func(t *testing.T) {
+		endpoint := newEndpoint()
+		endpoint.ExcludeListenerMiddlewares = []string{"05-recovery", "10-logger"}
+		merged := endpoint.MiddlewareChain(&endpoint.Routes[0])
+		assert.Equal(t,
+			[]string{"00-request-id", "02-endpoint", "07-route"},
+			ids(merged))
+		// The listener's collection is untouched
+		assert.Len(t, endpoint.ListenerMiddlewares["test-listener"], 3)
+	})
+
+	t.Run("a default is reordered by redeclaring it under another ID", func(t *testing.T) {
+		endpoint := newEndpoint()
+		endpoint.ExcludeListenerMiddlewares = []string{"10-logger"}
+		endpoint.Middlewares = append(endpoint.Middlewares,
+			middleware.Middleware{ID: "01-logger", Config: logger.NewConsoleLogger()})
+		merged := endpoint.getMergedMiddleware(&endpoint.Routes[0])
+		assert.Equal(t,
+			[]string{"00-request-id", "01-logger", "02-endpoint", "05-recovery", "07-route"},
+			ids(merged))
+	})
+
+	t.Run("endpoint middleware wins over a default with the same ID", func(t *testing.T) {
+		endpoint := newEndpoint()
+		endpointLogger := middleware.Middleware{
+			ID: "10-logger",
+			Config: &logger.ConsoleLogger{
+				Options: logger.LogOptionsGeneral{Format: logger.FormatJSON},
+			},
+		}
+		endpoint.Middlewares = middleware.MiddlewareCollection{endpointLogger}
+		merged := endpoint.getMergedMiddleware(&endpoint.Routes[0])
+		logMw := merged.FindByID("10-logger")
+		require.NotNil(t, logMw)
+		assert.Same(t, endpointLogger.Config, logMw.Config)
+	})
+}
+
 func TestEndpoint_GetStructuredHTTPRoutes(t *testing.T) {
 	t.Parallel()
 
@@ -295,6 +384,7 @@ func TestEndpoint_GetStructuredHTTPRoutes(t *testing.T) {
 	assert.Equal(t, "/api/v1", route1Result.PathPrefix)
 	assert.Equal(t, "GET", route1Result.Method)
 	assert.Equal(t, "app1", route1Result.AppID)
+	assert.Equal(t, "test-endpoint[0]", route1Result.ID)
 	assert.Equal(t, "v1", route1Result.StaticData["version"])
 	// Should have both endpoint and route middleware (2 total)
 	require.Len(t, route1Result.Middlewares, 2)
@@ -309,7 +399,132 @@ func TestEndpoint_GetStructuredHTTPRoutes(t *testing.T) {
 	assert.Equal(t, "/api/v2", route2Result.PathPrefix)
 	assert.Equal(t, "POST", route2Result.Method)
 	assert.Equal(t, "app2", route2Result.AppID)
+	assert.Equal(t, "test-endpoint[1]", route2Result.ID)
 	// Should have only endpoint middleware (1 total)
 	require.Len(t, route2Result.Middlewares, 1)
 	assert.Equal(t, "endpoint-logger", route2Result.Middlewares[0].ID)
 }
+
+func TestEndpoint_GetStructuredHTTPRoutes_SameApp(t *testing.T) {
+	t.Parallel()
+
+	routeMw := middleware.Middleware{
+		ID:     "route-logger",
+		Config: logger.NewConsoleLogger(),
+	}
+
+	// Both routes dispatch to app1, only the second has its own middleware
+	endpoint := Endpoint{
+		ID:         "test-endpoint",
+		ListenerID: "test-listener",
+		Routes: routes.RouteCollection{
+			{AppID: "app1", Condition: conditions.NewHTTP("/public", "")},
+			{
+				AppID:       "app1",
+				Condition:   conditions.NewHTTP("/private", ""),
+				Middlewares: middleware.MiddlewareCollection{routeMw},
+			},
+		},
+	}
+
+	httpRoutes := endpoint.GetStructuredHTTPRoutes()
+	require.Len(t, httpRoutes, 2)
+
+	assert.Equal(t, "test-endpoint[0]", httpRoutes[0].ID)
+	assert.Empty(t, httpRoutes[0].Middlewares)
+	assert.Equal(t, "test-endpoint[1]", httpRoutes[1].ID)
+	require.Len(t, httpRoutes[1].Middlewares, 1)
+	assert.Equal(t, "route-logger", httpRoutes[1].Middlewares[0].ID)
+}
+
+func TestEndpoint_RouteStaticData(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{
+		ID:         "api",
+		ListenerID: "http",
+		StaticData: map[string]any{
+			"env":     "prod",
+			"timeout": 30,
+			"db":      map[string]any{"host": "db.internal", "port": 5432},
+		},
+	}
+
+	t.Run("route values override endpoint defaults", func(t *testing.T) {
+		route := &routes.Route{
+			AppID: "app1",
+			StaticData: map[string]any{
+				"timeout": 5,
+				"db":      map[string]any{"port": 6543},
+			},
+		}
+		assert.Equal(t, map[string]any{
+			"env":     "prod",
+			"timeout": 5,
+			"db":      map[string]any{"host": "db.internal", "port": 6543},
+		}, endpoint.RouteStaticData(route))
+	})
+
+	t.Run("route values are added to endpoint defaults", func(t *testing.T) {
+		route := &routes.Route{
+			AppID:      "app1",
+			StaticData: map[string]any{"feature": true},
+		}
+		assert.Equal(t, map[string]any{
+			"env":     "prod",
+			"timeout": 30,
+			"db":      map[string]any{"host": "db.internal", "port": 5432},
+			"feature": true,
+		}, endpoint.RouteStaticData(route))
+	})
+
+	t.Run("route without static data gets the defaults", func(t *testing.T) {
+		assert.Equal(t, endpoint.StaticData, endpoint.RouteStaticData(&routes.Route{AppID: "app1"}))
+		assert.Equal(t, endpoint.StaticData, endpoint.RouteStaticData(nil))
+	})
+
+	t.Run("endpoint without static data", func(t *testing.T) {
+		bare := Endpoint{ID: "bare", ListenerID: "http"}
+		route := &routes.Route{AppID: "app1", StaticData: map[string]any{"env": "dev"}}
+		assert.Equal(t, map[string]any{"env": "dev"}, bare.RouteStaticData(route))
+		assert.Nil(t, bare.RouteStaticData(&routes.Route{AppID: "app1"}))
+	})
+
+	t.Run("merging does not modify the endpoint or route", func(t *testing.T) {
+		route := &routes.Route{
+			AppID:      "app1",
+			StaticData: map[string]any{"db": map[string]any{"port": 6543}},
+		}
+		merged := endpoint.RouteStaticData(route)
+		merged["db"].(map[string]any)["host"] = "changed"
+
+		assert.Equal(t, map[string]any{"host": "db.internal", "port": 5432}, endpoint.StaticData["db"])
+		assert.Equal(t, map[string]any{"port": 6543}, route.StaticData["db"])
+	})
+}
+
+func TestEndpoint_GetStructuredHTTPRoutes_MergesEndpointStaticData(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{
+		ID:         "api",
+		ListenerID: "http",
+		StaticData: map[string]any{"env": "prod", "version": "v1"},
+		Routes: routes.RouteCollection{
+			{
+				AppID:      "app1",
+				Condition:  conditions.NewHTTP("/v2", ""),
+				StaticData: map[string]any{"version": "v2"},
+			},
+			{
+				AppID:     "app2",
+				Condition: conditions.NewHTTP("/v1", ""),
+			},
+		},
+	}
+
+	httpRoutes := endpoint.GetStructuredHTTPRoutes()
+	require.Len(t, httpRoutes, 2)
+	assert.Equal(t, map[string]any{"env": "prod", "version": "v2"}, httpRoutes[0].StaticData)
+	assert.Equal(t, map[string]any{"env": "prod", "version": "v1"}, httpRoutes[1].StaticData)
+}
@@ -9,6 +9,7 @@ import (
 var (
 	// Validation specific errors
 	ErrEmptyID              = errz.ErrEmptyID
+	ErrDuplicateID          = errz.ErrDuplicateID
 	ErrMissingRequiredField = errz.ErrMissingRequiredField
 	ErrRouteConflict        = errz.ErrRouteConflict
 	ErrInvalidRouteType     = errz.ErrInvalidRouteType
@@ -0,0 +1,311 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"golang.org/x/net/http/httpguts"
+)
+
+const AuthType = "auth"
+
+const (
+	// DefaultRealm is reported in the WWW-Authenticate header when no realm is set
+	DefaultRealm = "firelynx"
+
+	// DefaultAPIKeyHeader carries the API key when neither a header nor a query
+	// parameter is set
+	DefaultAPIKeyHeader = "X-API-Key"
+)
+
+var (
+	// ErrNoMode indicates that neither API key nor bearer authentication is configured
+	ErrNoMode = errors.New("one of api_key, bearer, or jwt must be configured")
+
+	// ErrMultipleModes indicates that more than one authentication mode is configured
+	ErrMultipleModes = errors.New("only one of api_key, bearer, or jwt may be configured")
+
+	// ErrEmptyKeys indicates that no API keys or bearer tokens were configured
+	ErrEmptyKeys = errors.New("key list cannot be empty")
+
+	// ErrEmptyKey indicates that a configured key or token is empty
+	ErrEmptyKey = errors.New("key cannot be empty")
+
+	// ErrNoVerificationKey indicates that a JWT config has neither a secret nor a JWKS URL
+	ErrNoVerificationKey = errors.New("one of secret or jwks_url must be configured")
+)
+
+// APIKey authenticates requests carrying one of a set of static keys
+type APIKey struct {
+	// Header carrying the key
+	Header string `json:"header" toml:"header" env_interpolation:"no"`
+
+	// Query parameter carrying the key, checked when the header is absent
+	QueryParam string `json:"queryParam" toml:"query_param" env_interpolation:"no"`
+
+	// Accepted keys
+	Keys []string `json:"keys" toml:"keys" env_interpolation:"yes"`
+}
+
+// Bearer authenticates requests carrying one of a set of opaque bearer tokens
+// in the Authorization header
+type Bearer struct {
+	// Accepted opaque tokens
+	Tokens []string `json:"tokens" toml:"tokens" env_interpolation:"yes"`
+}
+
+// JWT authenticates requests carrying a signed JWT as a bearer token in the
+// Authorization header
+type JWT struct {
+	// Shared secret for HMAC-signed tokens
+	Secret string `json:"secret" toml:"secret" env_interpolation:"yes"`
+
+	// URL of a JSON Web Key Set for RSA- and ECDSA-signed tokens
+	JWKSURL string `json:"jwksUrl" toml:"jwks_url" env_interpolation:"yes"`
+
+	// Required "iss" claim, unchecked when empty
+	Issuer string `json:"issuer" toml:"issuer" env_interpolation:"yes"`
+
+	// Accepted "aud" claims, unchecked when empty
+	Audience []string `json:"audience" toml:"audience" env_interpolation:"yes"`
+
+	// Accept tokens without an "exp" claim, which never expire. Such tokens
+	// are rejected by default.
+	AllowMissingExp bool `json:"allowMissingExp" toml:"allow_missing_exp" env_interpolation:"no"`
+}
+
+// Auth represents an authentication middleware configuration. Exactly one of
+// APIKey, Bearer, or JWT is set.
+type Auth struct {
+	// Realm reported in the WWW-Authenticate header
+	Realm string `json:"realm" toml:"realm" env_interpolation:"yes"`
+
+	// API key authentication
+	APIKey *APIKey `json:"apiKey,omitempty" toml:"api_key,omitempty" env_interpolation:"yes"`
+
+	// Bearer token authentication
+	Bearer *Bearer `json:"bearer,omitempty" toml:"bearer,omitempty" env_interpolation:"yes"`
+
+	// JWT authentication
+	JWT *JWT `json:"jwt,omitempty" toml:"jwt,omitempty" env_interpolation:"yes"`
+}
+
+// Type returns the middleware type
+func (a *Auth) Type() string {
+	return AuthType
+}
+
+// GetRealm returns the realm, or DefaultRealm if none is set
+func (a *Auth) GetRealm() string {
+	if a.Realm == "" {
+		return DefaultRealm
+	}
+	return a.Realm
+}
+
+// GetHeader returns the header carrying the key. It is DefaultAPIKeyHeader
+// when neither a header nor a query parameter is set.
+func (k *APIKey) GetHeader() string {
+	if k.Header == "" && k.QueryParam == "" {
+		return DefaultAPIKeyHeader
+	}
+	return k.Header
+}
+
+// Validate validates the authentication configuration
+func (a *Auth) Validate() error {
+	var errs []error
+
+	if err := interpolation.InterpolateStruct(a); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed: %w", err))
+	}
+
+	switch a.modeCount() {
+	case 0:
+		errs = append(errs, ErrNoMode)
+	case 1:
+		if err := a.validateMode(); err != nil {
+			errs = append(errs, err)
+		}
+	default:
+		errs = append(errs, ErrMultipleModes)
+	}
+
+	if strings.ContainsAny(a.Realm, "\"\\") {
+		errs = append(errs, fmt.Errorf("realm cannot contain quotes or backslashes: %q", a.Realm))
+	}
+
+	return errors.Join(errs...)
+}
+
+// modeCount returns the number of configured authentication modes
+func (a *Auth) modeCount() int {
+	count := 0
+	if a.APIKey != nil {
+		count++
+	}
+	if a.Bearer != nil {
+		count++
+	}
+	if a.JWT != nil {
+		count++
+	}
+	return count
+}
+
+// validateMode validates the configured authentication mode
+func (a *Auth) validateMode() error {
+	switch {
+	case a.APIKey != nil:
+		if err := a.APIKey.Validate(); err != nil {
+			return fmt.Errorf("invalid api_key config: %w", err)
+		}
+	case a.Bearer != nil:
+		if err := a.Bearer.Validate(); err != nil {
+			return fmt.Errorf("invalid bearer config: %w", err)
+		}
+	case a.JWT != nil:
+		if err := a.JWT.Validate(); err != nil {
+			return fmt.Errorf("invalid jwt config: %w", err)
+		}
+	}
+	return nil
+}
+
+// Validate validates the API key configuration
+func (k *APIKey) Validate() error {
+	var errs []error
+
+	if k.Header != "" && !httpguts.ValidHeaderFieldName(k.Header) {
+		errs = append(errs, fmt.Errorf("invalid header name: %s", k.Header))
+	}
+
+	if err := validateKeys(k.Keys); err != nil {
+		errs = append(errs, fmt.Errorf("keys: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate validates the bearer token configuration
+func (b *Bearer) Validate() error {
+	if err := validateKeys(b.Tokens); err != nil {
+		return fmt.Errorf("tokens: %w", err)
+	}
+	return nil
+}
+
+// Validate validates the JWT configuration
+func (j *JWT) Validate() error {
+	var errs []error
+
+	if j.Secret == "" && j.JWKSURL == "" {
+		errs = append(errs, ErrNoVerificationKey)
+	}
+
+	if j.JWKSURL != "" {
+		u, err := url.Parse(j.JWKSURL)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("invalid jwks_url: %w", err))
+		case u.Scheme != "http" && u.Scheme != "https", u.Host == "":
+			errs = append(errs, fmt.Errorf("jwks_url must be an absolute http or https URL: %s", j.JWKSURL))
+		}
+	}
+
+	for i, aud := range j.Audience {
+		if aud == "" {
+			errs = append(errs, fmt.Errorf("audience cannot be empty: index %d", i))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateKeys checks that keys is non-empty and contains no empty keys
+func validateKeys(keys []string) error {
+	if len(keys) == 0 {
+		return ErrEmptyKeys
+	}
+
+	var errs []error
+	for i, key := range keys {
+		if key == "" {
+			errs = append(errs, fmt.Errorf("%w: index %d", ErrEmptyKey, i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the authentication configuration.
+// Keys, tokens, and secrets are never included.
+func (a *Auth) String() string {
+	switch {
+	case a.APIKey != nil:
+		return fmt.Sprintf("API key (%s, %d keys)", a.APIKey.source(), len(a.APIKey.Keys))
+	case a.Bearer != nil:
+		return fmt.Sprintf("Bearer (%d tokens)", len(a.Bearer.Tokens))
+	case a.JWT != nil:
+		return fmt.Sprintf("JWT (%s)", a.JWT.keySources())
+	default:
+		return "No authentication configured"
+	}
+}
+
+// ToTree returns a tree representation of the authentication configuration
+func (a *Auth) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("Realm: %s", a.GetRealm()))
+
+	switch {
+	case a.APIKey != nil:
+		tree.AddChild("Mode: API key")
+		tree.AddChild(fmt.Sprintf("Source: %s", a.APIKey.source()))
+		tree.AddChild(fmt.Sprintf("Keys: %d", len(a.APIKey.Keys)))
+	case a.Bearer != nil:
+		tree.AddChild("Mode: Bearer")
+		tree.AddChild(fmt.Sprintf("Tokens: %d", len(a.Bearer.Tokens)))
+	case a.JWT != nil:
+		tree.AddChild("Mode: JWT")
+		tree.AddChild(fmt.Sprintf("Keys: %s", a.JWT.keySources()))
+		if a.JWT.Issuer != "" {
+			tree.AddChild(fmt.Sprintf("Issuer: %s", a.JWT.Issuer))
+		}
+		if len(a.JWT.Audience) > 0 {
+			tree.AddChild(fmt.Sprintf("Audience: %s", strings.Join(a.JWT.Audience, ", ")))
+		}
+		if a.JWT.AllowMissingExp {
+			tree.AddChild("Allow Missing Exp: true")
+		}
+	}
+
+	return tree
+}
+
+// source describes where the API key is read from
+func (k *APIKey) source() string {
+	var parts []string
+	if header := k.GetHeader(); header != "" {
+		parts = append(parts, "header "+header)
+	}
+	if k.QueryParam != "" {
+		parts = append(parts, "query "+k.QueryParam)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// keySources describes where JWT verification keys come from
+func (j *JWT) keySources() string {
+	var parts []string
+	if j.Secret != "" {
+		parts = append(parts, "shared secret")
+	}
+	if j.JWKSURL != "" {
+		parts = append(parts, "JWKS "+j.JWKSURL)
+	}
+	return strings.Join(parts, ", ")
+}
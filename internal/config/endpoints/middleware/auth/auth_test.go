@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuth_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "auth", (&Auth{}).Type())
+}
+
+func TestAuth_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		auth    *Auth
+		wantErr error
+		errText string
+	}{
+		{
+			name: "api key",
+			auth: &Auth{APIKey: &APIKey{Keys: []string{"k1", "k2"}}},
+		},
+		{
+			name: "api key in query parameter",
+			auth: &Auth{APIKey: &APIKey{QueryParam: "api_key", Keys: []string{"k1"}}},
+		},
+		{
+			name: "bearer with tokens",
+			auth: &Auth{Bearer: &Bearer{Tokens: []string{"t1"}}},
+		},
+		{
+			name: "jwt with secret",
+			auth: &Auth{JWT: &JWT{Secret: "shared-secret", Issuer: "issuer", Audience: []string{"api"}}},
+		},
+		{
+			name: "jwt with jwks url",
+			auth: &Auth{JWT: &JWT{JWKSURL: "https://issuer.example.com/.well-known/jwks.json"}},
+		},
+		{
+			name:    "no mode",
+			auth:    &Auth{},
+			wantErr: ErrNoMode,
+		},
+		{
+			name: "both modes",
+			auth: &Auth{
+				APIKey: &APIKey{Keys: []string{"k1"}},
+				Bearer: &Bearer{Tokens: []string{"t1"}},
+			},
+			wantErr: ErrMultipleModes,
+		},
+		{
+			name: "bearer and jwt",
+			auth: &Auth{
+				Bearer: &Bearer{Tokens: []string{"t1"}},
+				JWT:    &JWT{Secret: "shared-secret"},
+			},
+			wantErr: ErrMultipleModes,
+		},
+		{
+			name:    "empty api key list",
+			auth:    &Auth{APIKey: &APIKey{Header: "X-Key"}},
+			wantErr: ErrEmptyKeys,
+		},
+		{
+			name:    "empty api key",
+			auth:    &Auth{APIKey: &APIKey{Keys: []string{"k1", ""}}},
+			wantErr: ErrEmptyKey,
+		},
+		{
+			name:    "bearer without tokens",
+			auth:    &Auth{Bearer: &Bearer{}},
+			wantErr: ErrEmptyKeys,
+		},
+		{
+			name:    "jwt without secret or jwks url",
+			auth:    &Auth{JWT: &JWT{Issuer: "issuer"}},
+			wantErr: ErrNoVerificationKey,
+		},
+		{
+			name:    "relative jwks url",
+			auth:    &Auth{JWT: &JWT{JWKSURL: "/jwks.json"}},
+			errText: "jwks_url must be an absolute http or https URL",
+		},
+		{
+			name:    "empty audience",
+			auth:    &Auth{JWT: &JWT{Secret: "shared-secret", Audience: []string{""}}},
+			errText: "audience cannot be empty",
+		},
+		{
+			name:    "empty bearer token",
+			auth:    &Auth{Bearer: &Bearer{Tokens: []string{""}}},
+			wantErr: ErrEmptyKey,
+		},
+		{
+			name:    "invalid header name",
+			auth:    &Auth{APIKey: &APIKey{Header: "X Key", Keys: []string{"k1"}}},
+			errText: "invalid header name",
+		},
+		{
+			name:    "quote in realm",
+			auth:    &Auth{Realm: `my"realm`, APIKey: &APIKey{Keys: []string{"k1"}}},
+			errText: "realm cannot contain quotes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.auth.Validate()
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.errText != "":
+				require.ErrorContains(t, err, tt.errText)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuth_ValidateInterpolatesSecrets(t *testing.T) {
+	secretPath := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(secretPath, []byte("from-file\n"), 0o600))
+	t.Setenv("AUTH_TEST_SECRET", "from-env")
+
+	apiKey := &Auth{APIKey: &APIKey{
+		QueryParam: "${AUTH_TEST_SECRET}",
+		Keys:       []string{"${file:" + secretPath + "}"},
+	}}
+	require.NoError(t, apiKey.Validate())
+	assert.Equal(t, []string{"from-file"}, apiKey.APIKey.Keys)
+	assert.Equal(t, "${AUTH_TEST_SECRET}", apiKey.APIKey.QueryParam, "parameter names are not interpolated")
+
+	jwt := &Auth{JWT: &JWT{Secret: "${AUTH_TEST_SECRET}", Issuer: "${AUTH_TEST_SECRET}"}}
+	require.NoError(t, jwt.Validate())
+	assert.Equal(t, "from-env", jwt.JWT.Secret)
+	assert.Equal(t, "from-env", jwt.JWT.Issuer)
+}
+
+func TestAPIKey_GetHeader(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, DefaultAPIKeyHeader, (&APIKey{}).GetHeader())
+	assert.Equal(t, "X-Key", (&APIKey{Header: "X-Key"}).GetHeader())
+	assert.Empty(t, (&APIKey{QueryParam: "key"}).GetHeader())
+}
+
+func TestAuth_StringHidesSecrets(t *testing.T) {
+	t.Parallel()
+
+	apiKey := &Auth{APIKey: &APIKey{QueryParam: "key", Keys: []string{"super-secret-key"}}}
+	assert.Equal(t, "API key (query key, 1 keys)", apiKey.String())
+
+	bearer := &Auth{Bearer: &Bearer{Tokens: []string{"super-secret-token"}}}
+	assert.Equal(t, "Bearer (1 tokens)", bearer.String())
+
+	jwt := &Auth{JWT: &JWT{Secret: "super-secret", JWKSURL: "https://issuer.example.com/jwks"}}
+	assert.Equal(t, "JWT (shared secret, JWKS https://issuer.example.com/jwks)", jwt.String())
+
+	for _, a := range []*Auth{apiKey, bearer, jwt} {
+		tree := a.ToTree().Tree().String()
+		assert.NotContains(t, tree, "super-secret")
+		assert.Contains(t, tree, "Realm: "+DefaultRealm)
+	}
+}
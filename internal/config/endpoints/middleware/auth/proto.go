@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+)
+
+// ToProto converts Auth to protobuf format
+func (a *Auth) ToProto() any {
+	config := &pb.AuthConfig{}
+	if a.Realm != "" {
+		config.Realm = &a.Realm
+	}
+
+	switch {
+	case a.APIKey != nil:
+		apiKey := &pb.AuthConfig_APIKey{Keys: slices.Clone(a.APIKey.Keys)}
+		if a.APIKey.Header != "" {
+			apiKey.Header = &a.APIKey.Header
+		}
+		if a.APIKey.QueryParam != "" {
+			apiKey.QueryParam = &a.APIKey.QueryParam
+		}
+		config.Mode = &pb.AuthConfig_ApiKey{ApiKey: apiKey}
+
+	case a.Bearer != nil:
+		bearer := &pb.AuthConfig_Bearer{Tokens: slices.Clone(a.Bearer.Tokens)}
+		config.Mode = &pb.AuthConfig_Bearer_{Bearer: bearer}
+
+	case a.JWT != nil:
+		jwt := &pb.AuthConfig_JWT{Audience: slices.Clone(a.JWT.Audience)}
+		if a.JWT.Secret != "" {
+			jwt.Secret = &a.JWT.Secret
+		}
+		if a.JWT.JWKSURL != "" {
+			jwt.JwksUrl = &a.JWT.JWKSURL
+		}
+		if a.JWT.Issuer != "" {
+			jwt.Issuer = &a.JWT.Issuer
+		}
+		if a.JWT.AllowMissingExp {
+			jwt.AllowMissingExp = &a.JWT.AllowMissingExp
+		}
+		config.Mode = &pb.AuthConfig_Jwt{Jwt: jwt}
+	}
+
+	return config
+}
+
+// FromProto converts protobuf AuthConfig to domain Auth
+func FromProto(pbConfig *pb.AuthConfig) (*Auth, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil auth config")
+	}
+
+	config := &Auth{Realm: pbConfig.GetRealm()}
+
+	if apiKey := pbConfig.GetApiKey(); apiKey != nil {
+		config.APIKey = &APIKey{
+			Header:     apiKey.GetHeader(),
+			QueryParam: apiKey.GetQueryParam(),
+			Keys:       slices.Clone(apiKey.GetKeys()),
+		}
+	}
+
+	if bearer := pbConfig.GetBearer(); bearer != nil {
+		config.Bearer = &Bearer{Tokens: slices.Clone(bearer.GetTokens())}
+	}
+
+	if jwt := pbConfig.GetJwt(); jwt != nil {
+		config.JWT = &JWT{
+			Secret:          jwt.GetSecret(),
+			JWKSURL:         jwt.GetJwksUrl(),
+			Issuer:          jwt.GetIssuer(),
+			Audience:        slices.Clone(jwt.GetAudience()),
+			AllowMissingExp: jwt.GetAllowMissingExp(),
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("api key", func(t *testing.T) {
+		header := "X-Key"
+		query := "key"
+		realm := "api"
+		config, err := FromProto(&pb.AuthConfig{
+			Realm: &realm,
+			Mode: &pb.AuthConfig_ApiKey{ApiKey: &pb.AuthConfig_APIKey{
+				Header:     &header,
+				QueryParam: &query,
+				Keys:       []string{"k1", "k2"},
+			}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "api", config.Realm)
+		require.NotNil(t, config.APIKey)
+		assert.Equal(t, "X-Key", config.APIKey.Header)
+		assert.Equal(t, "key", config.APIKey.QueryParam)
+		assert.Equal(t, []string{"k1", "k2"}, config.APIKey.Keys)
+		assert.Nil(t, config.Bearer)
+	})
+
+	t.Run("bearer", func(t *testing.T) {
+		config, err := FromProto(&pb.AuthConfig{
+			Mode: &pb.AuthConfig_Bearer_{Bearer: &pb.AuthConfig_Bearer{
+				Tokens: []string{"t1"},
+			}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, config.Bearer)
+		assert.Equal(t, []string{"t1"}, config.Bearer.Tokens)
+		assert.Nil(t, config.APIKey)
+		assert.Nil(t, config.JWT)
+	})
+
+	t.Run("jwt", func(t *testing.T) {
+		secret := "s3cret"
+		jwksURL := "https://issuer.example.com/jwks"
+		issuer := "https://issuer.example.com"
+		config, err := FromProto(&pb.AuthConfig{
+			Mode: &pb.AuthConfig_Jwt{Jwt: &pb.AuthConfig_JWT{
+				Secret:   &secret,
+				JwksUrl:  &jwksURL,
+				Issuer:   &issuer,
+				Audience: []string{"api"},
+			}},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, config.JWT)
+		assert.Equal(t, "s3cret", config.JWT.Secret)
+		assert.Equal(t, jwksURL, config.JWT.JWKSURL)
+		assert.Equal(t, issuer, config.JWT.Issuer)
+		assert.Equal(t, []string{"api"}, config.JWT.Audience)
+		assert.Nil(t, config.Bearer)
+	})
+}
+
+func TestRoundTripConversion(t *testing.T) {
+	t.Parallel()
+
+	configs := []*Auth{
+		{Realm: "api", APIKey: &APIKey{Header: "X-Key", QueryParam: "key", Keys: []string{"k1"}}},
+		{APIKey: &APIKey{Keys: []string{"k1", "k2"}}},
+		{Bearer: &Bearer{Tokens: []string{"t1", "t2"}}},
+		{Realm: "tokens", Bearer: &Bearer{Tokens: []string{"t1"}}},
+		{JWT: &JWT{Secret: "s3cret"}},
+		{JWT: &JWT{JWKSURL: "https://issuer.example.com/jwks", Issuer: "issuer", Audience: []string{"a", "b"}}},
+		{JWT: &JWT{Secret: "s3cret", AllowMissingExp: true}},
+	}
+
+	for _, original := range configs {
+		t.Run(original.String(), func(t *testing.T) {
+			pbConfig, ok := original.ToProto().(*pb.AuthConfig)
+			require.True(t, ok, "ToProto should return *pb.AuthConfig")
+
+			converted, err := FromProto(pbConfig)
+			require.NoError(t, err)
+			assert.Equal(t, original, converted)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package basicauth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const BasicAuthType = "basic_auth"
+
+// DefaultRealm is reported in the WWW-Authenticate header when no realm is set
+const DefaultRealm = "firelynx"
+
+var (
+	// ErrNoUsers indicates that no users were configured
+	ErrNoUsers = errors.New("user list cannot be empty")
+
+	// ErrEmptyUsername indicates that a user has no username
+	ErrEmptyUsername = errors.New("username cannot be empty")
+
+	// ErrInvalidUsername indicates that a username contains a colon, which
+	// can't be sent in a Basic credential
+	ErrInvalidUsername = errors.New("username cannot contain a colon")
+
+	// ErrDuplicateUsername indicates that a username is configured more than once
+	ErrDuplicateUsername = errors.New("duplicate username")
+
+	// ErrInvalidPasswordHash indicates that a password hash isn't a bcrypt hash
+	ErrInvalidPasswordHash = errors.New("password_hash must be a bcrypt hash")
+)
+
+// User is a username and the bcrypt hash of its password
+type User struct {
+	// Username sent by the client
+	Username string `json:"username" toml:"username" env_interpolation:"yes"`
+
+	// bcrypt hash of the password
+	PasswordHash string `json:"passwordHash" toml:"password_hash" env_interpolation:"yes"`
+}
+
+// BasicAuth represents an HTTP Basic authentication middleware configuration
+type BasicAuth struct {
+	// Realm reported in the WWW-Authenticate header
+	Realm string `json:"realm" toml:"realm" env_interpolation:"yes"`
+
+	// Users allowed to authenticate
+	Users []User `json:"users" toml:"users" env_interpolation:"yes"`
+}
+
+// Type returns the middleware type
+func (b *BasicAuth) Type() string {
+	return BasicAuthType
+}
+
+// GetRealm returns the realm, or DefaultRealm if none is set
+func (b *BasicAuth) GetRealm() string {
+	if b.Realm == "" {
+		return DefaultRealm
+	}
+	return b.Realm
+}
+
+// Validate validates the basic auth configuration
+func (b *BasicAuth) Validate() error {
+	var errs []error
+
+	if err := interpolation.InterpolateStruct(b); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed: %w", err))
+	}
+
+	if len(b.Users) == 0 {
+		errs = append(errs, ErrNoUsers)
+	}
+
+	seen := make(map[string]struct{}, len(b.Users))
+	for i, user := range b.Users {
+		if err := user.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", i, err))
+		}
+		if _, ok := seen[user.Username]; ok && user.Username != "" {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrDuplicateUsername, user.Username))
+		}
+		seen[user.Username] = struct{}{}
+	}
+
+	if strings.ContainsAny(b.Realm, "\"\\") {
+		errs = append(errs, fmt.Errorf("realm cannot contain quotes or backslashes: %q", b.Realm))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Validate validates a user
+func (u *User) Validate() error {
+	var errs []error
+
+	switch {
+	case u.Username == "":
+		errs = append(errs, ErrEmptyUsername)
+	case strings.Contains(u.Username, ":"):
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidUsername, u.Username))
+	}
+
+	// The hash itself is never included in the error
+	if _, err := bcrypt.Cost([]byte(u.PasswordHash)); err != nil {
+		errs = append(errs, ErrInvalidPasswordHash)
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the basic auth configuration.
+// Password hashes are never included.
+func (b *BasicAuth) String() string {
+	return fmt.Sprintf("Basic auth (realm %s, %d users)", b.GetRealm(), len(b.Users))
+}
+
+// ToTree returns a tree representation of the basic auth configuration
+func (b *BasicAuth) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("Realm: %s", b.GetRealm()))
+
+	users := make([]string, len(b.Users))
+	for i, user := range b.Users {
+		users[i] = user.Username
+	}
+	tree.AddChild(fmt.Sprintf("Users: %s", strings.Join(users, ", ")))
+
+	return tree
+}
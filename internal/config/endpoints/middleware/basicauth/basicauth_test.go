@@ -0,0 +1,115 @@
+package basicauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashPassword returns a low-cost bcrypt hash of password
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+func TestBasicAuth_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "basic_auth", (&BasicAuth{}).Type())
+}
+
+func TestBasicAuth_Validate(t *testing.T) {
+	t.Parallel()
+
+	hash := hashPassword(t, "secret")
+
+	tests := []struct {
+		name    string
+		config  *BasicAuth
+		wantErr error
+		errText string
+	}{
+		{
+			name: "valid users",
+			config: &BasicAuth{Realm: "tools", Users: []User{
+				{Username: "alice", PasswordHash: hash},
+				{Username: "bob", PasswordHash: hash},
+			}},
+		},
+		{
+			name:    "no users",
+			config:  &BasicAuth{},
+			wantErr: ErrNoUsers,
+		},
+		{
+			name:    "empty username",
+			config:  &BasicAuth{Users: []User{{PasswordHash: hash}}},
+			wantErr: ErrEmptyUsername,
+		},
+		{
+			name:    "colon in username",
+			config:  &BasicAuth{Users: []User{{Username: "a:b", PasswordHash: hash}}},
+			wantErr: ErrInvalidUsername,
+		},
+		{
+			name: "duplicate username",
+			config: &BasicAuth{Users: []User{
+				{Username: "alice", PasswordHash: hash},
+				{Username: "alice", PasswordHash: hash},
+			}},
+			wantErr: ErrDuplicateUsername,
+		},
+		{
+			name:    "plaintext password",
+			config:  &BasicAuth{Users: []User{{Username: "alice", PasswordHash: "secret"}}},
+			wantErr: ErrInvalidPasswordHash,
+		},
+		{
+			name:    "quote in realm",
+			config:  &BasicAuth{Realm: `a"b`, Users: []User{{Username: "alice", PasswordHash: hash}}},
+			errText: "realm cannot contain quotes",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.errText != "":
+				require.ErrorContains(t, err, tt.errText)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBasicAuth_ValidateInterpolatesUsers(t *testing.T) {
+	hash := hashPassword(t, "secret")
+	t.Setenv("BASIC_AUTH_TEST_USER", "alice")
+	t.Setenv("BASIC_AUTH_TEST_HASH", hash)
+
+	config := &BasicAuth{Users: []User{
+		{Username: "${BASIC_AUTH_TEST_USER}", PasswordHash: "${BASIC_AUTH_TEST_HASH}"},
+	}}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, "alice", config.Users[0].Username)
+	assert.Equal(t, hash, config.Users[0].PasswordHash)
+}
+
+func TestBasicAuth_StringHidesHashes(t *testing.T) {
+	t.Parallel()
+
+	hash := hashPassword(t, "secret")
+	config := &BasicAuth{Users: []User{{Username: "alice", PasswordHash: hash}}}
+
+	assert.Equal(t, "Basic auth (realm firelynx, 1 users)", config.String())
+	tree := config.ToTree().Tree().String()
+	assert.Contains(t, tree, "alice")
+	assert.NotContains(t, tree, hash)
+}
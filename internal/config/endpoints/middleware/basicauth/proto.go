@@ -0,0 +1,41 @@
+package basicauth
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+)
+
+// ToProto converts BasicAuth to protobuf format
+func (b *BasicAuth) ToProto() any {
+	config := &pb.BasicAuthConfig{}
+	if b.Realm != "" {
+		config.Realm = &b.Realm
+	}
+
+	for _, user := range b.Users {
+		config.Users = append(config.Users, &pb.BasicAuthConfig_User{
+			Username:     &user.Username,
+			PasswordHash: &user.PasswordHash,
+		})
+	}
+
+	return config
+}
+
+// FromProto converts protobuf BasicAuthConfig to domain BasicAuth
+func FromProto(pbConfig *pb.BasicAuthConfig) (*BasicAuth, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil basic auth config")
+	}
+
+	config := &BasicAuth{Realm: pbConfig.GetRealm()}
+	for _, user := range pbConfig.GetUsers() {
+		config.Users = append(config.Users, User{
+			Username:     user.GetUsername(),
+			PasswordHash: user.GetPasswordHash(),
+		})
+	}
+
+	return config, nil
+}
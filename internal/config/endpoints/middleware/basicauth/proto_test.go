@@ -0,0 +1,54 @@
+package basicauth
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("users", func(t *testing.T) {
+		realm := "tools"
+		username := "alice"
+		hash := "$2a$04$hash"
+		config, err := FromProto(&pb.BasicAuthConfig{
+			Realm: &realm,
+			Users: []*pb.BasicAuthConfig_User{{Username: &username, PasswordHash: &hash}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "tools", config.Realm)
+		assert.Equal(t, []User{{Username: "alice", PasswordHash: hash}}, config.Users)
+	})
+}
+
+func TestRoundTripConversion(t *testing.T) {
+	t.Parallel()
+
+	configs := []*BasicAuth{
+		{Realm: "tools", Users: []User{{Username: "alice", PasswordHash: "$2a$04$a"}}},
+		{Users: []User{
+			{Username: "alice", PasswordHash: "$2a$04$a"},
+			{Username: "bob", PasswordHash: "$2a$04$b"},
+		}},
+	}
+
+	for _, original := range configs {
+		t.Run(original.String(), func(t *testing.T) {
+			pbConfig, ok := original.ToProto().(*pb.BasicAuthConfig)
+			require.True(t, ok, "ToProto should return *pb.BasicAuthConfig")
+
+			converted, err := FromProto(pbConfig)
+			require.NoError(t, err)
+			assert.Equal(t, original, converted)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package bodybuffer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+const BodyBufferType = "body_buffer"
+
+// DefaultMaxSize is the buffer limit used when none is configured
+const DefaultMaxSize = 1 << 20
+
+// ErrNegativeMaxSize indicates a negative buffer limit
+var ErrNegativeMaxSize = errors.New("max_size cannot be negative")
+
+// BodyBuffer represents a body buffer middleware configuration. Zero values
+// use the defaults, see the getters.
+type BodyBuffer struct {
+	// MaxSize is the largest request body, in bytes, that is buffered
+	MaxSize int64 `json:"maxSize" toml:"max_size"`
+}
+
+// Type returns the middleware type
+func (b *BodyBuffer) Type() string {
+	return BodyBufferType
+}
+
+// GetMaxSize returns the buffer limit, or DefaultMaxSize
+func (b *BodyBuffer) GetMaxSize() int64 {
+	if b.MaxSize == 0 {
+		return DefaultMaxSize
+	}
+	return b.MaxSize
+}
+
+// Validate validates the body buffer configuration
+func (b *BodyBuffer) Validate() error {
+	if b.MaxSize < 0 {
+		return fmt.Errorf("%w: %d", ErrNegativeMaxSize, b.MaxSize)
+	}
+	return nil
+}
+
+// String returns a string representation of the body buffer configuration
+func (b *BodyBuffer) String() string {
+	return fmt.Sprintf("Body buffer (max %d bytes)", b.GetMaxSize())
+}
+
+// ToTree returns a tree representation of the body buffer configuration
+func (b *BodyBuffer) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("Max Size: %d bytes", b.GetMaxSize()))
+	return tree
+}
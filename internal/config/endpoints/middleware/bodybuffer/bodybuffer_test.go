@@ -0,0 +1,37 @@
+package bodybuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodyBuffer_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "body_buffer", (&BodyBuffer{}).Type())
+}
+
+func TestBodyBuffer_GetMaxSize(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, int64(DefaultMaxSize), (&BodyBuffer{}).GetMaxSize())
+	assert.Equal(t, int64(512), (&BodyBuffer{MaxSize: 512}).GetMaxSize())
+}
+
+func TestBodyBuffer_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&BodyBuffer{}).Validate())
+	require.NoError(t, (&BodyBuffer{MaxSize: 1024}).Validate())
+
+	err := (&BodyBuffer{MaxSize: -1}).Validate()
+	require.ErrorIs(t, err, ErrNegativeMaxSize)
+}
+
+func TestBodyBuffer_String(t *testing.T) {
+	t.Parallel()
+
+	b := &BodyBuffer{MaxSize: 2048}
+	assert.Equal(t, "Body buffer (max 2048 bytes)", b.String())
+	assert.NotNil(t, b.ToTree())
+}
@@ -0,0 +1,25 @@
+package bodybuffer
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+)
+
+// ToProto converts BodyBuffer to protobuf format
+func (b *BodyBuffer) ToProto() any {
+	config := &pb.BodyBufferConfig{}
+	if b.MaxSize != 0 {
+		config.MaxSize = &b.MaxSize
+	}
+	return config
+}
+
+// FromProto converts protobuf BodyBufferConfig to domain BodyBuffer
+func FromProto(pbConfig *pb.BodyBufferConfig) (*BodyBuffer, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil body buffer config")
+	}
+
+	return &BodyBuffer{MaxSize: pbConfig.GetMaxSize()}, nil
+}
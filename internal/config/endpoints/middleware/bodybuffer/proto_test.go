@@ -0,0 +1,42 @@
+package bodybuffer
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.BodyBufferConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &BodyBuffer{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &BodyBuffer{MaxSize: 4096}
+
+	pbConfig, ok := original.ToProto().(*pb.BodyBufferConfig)
+	require.True(t, ok)
+	assert.Equal(t, int64(4096), pbConfig.GetMaxSize())
+
+	converted, err := FromProto(pbConfig)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+
+	empty, ok := (&BodyBuffer{}).ToProto().(*pb.BodyBufferConfig)
+	require.True(t, ok)
+	assert.Nil(t, empty.MaxSize)
+}
@@ -0,0 +1,127 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"golang.org/x/net/http/httpguts"
+)
+
+const CacheType = "cache"
+
+// Defaults for settings that aren't configured
+const (
+	DefaultMaxEntries  = 1000
+	DefaultMaxBodySize = 1 << 20
+)
+
+var (
+	// ErrMissingTTL indicates that no TTL was configured
+	ErrMissingTTL = errors.New("ttl is required")
+
+	// ErrInvalidTTL indicates a TTL that isn't positive
+	ErrInvalidTTL = errors.New("ttl must be positive")
+
+	// ErrInvalidMaxEntries indicates a negative entry limit
+	ErrInvalidMaxEntries = errors.New("max_entries must be positive")
+
+	// ErrInvalidMaxBodySize indicates a negative body size limit
+	ErrInvalidMaxBodySize = errors.New("max_body_size must be positive")
+
+	// ErrInvalidVaryHeader indicates a vary header that isn't a valid header name
+	ErrInvalidVaryHeader = errors.New("invalid vary header name")
+)
+
+// Cache represents a response cache middleware configuration. Zero values of
+// the limits use the defaults, see the getters.
+type Cache struct {
+	// TTL is how long a cached response is served
+	TTL time.Duration `json:"ttl" toml:"ttl"`
+
+	// MaxEntries is the most responses kept before the least recently used
+	// one is evicted
+	MaxEntries int `json:"maxEntries" toml:"max_entries"`
+
+	// MaxBodySize is the largest response body that is cached
+	MaxBodySize int64 `json:"maxBodySize" toml:"max_body_size"`
+
+	// VaryHeaders are the request headers whose values are part of the
+	// cache key
+	VaryHeaders []string `json:"varyHeaders" toml:"vary_headers" env_interpolation:"no"`
+}
+
+// Type returns the middleware type
+func (c *Cache) Type() string {
+	return CacheType
+}
+
+// GetMaxEntries returns the entry limit, or DefaultMaxEntries
+func (c *Cache) GetMaxEntries() int {
+	if c.MaxEntries == 0 {
+		return DefaultMaxEntries
+	}
+	return c.MaxEntries
+}
+
+// GetMaxBodySize returns the body size limit, or DefaultMaxBodySize
+func (c *Cache) GetMaxBodySize() int64 {
+	if c.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return c.MaxBodySize
+}
+
+// GetVaryHeaders returns the canonical names of the vary headers
+func (c *Cache) GetVaryHeaders() []string {
+	headers := make([]string, 0, len(c.VaryHeaders))
+	for _, name := range c.VaryHeaders {
+		headers = append(headers, http.CanonicalHeaderKey(name))
+	}
+	return headers
+}
+
+// Validate validates the cache configuration
+func (c *Cache) Validate() error {
+	var errs []error
+
+	switch {
+	case c.TTL == 0:
+		errs = append(errs, ErrMissingTTL)
+	case c.TTL < 0:
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidTTL, c.TTL))
+	}
+	if c.MaxEntries < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidMaxEntries, c.MaxEntries))
+	}
+	if c.MaxBodySize < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidMaxBodySize, c.MaxBodySize))
+	}
+	for _, name := range c.VaryHeaders {
+		if !httpguts.ValidHeaderFieldName(name) {
+			errs = append(errs, fmt.Errorf("%w: '%s'", ErrInvalidVaryHeader, name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the cache configuration
+func (c *Cache) String() string {
+	return fmt.Sprintf("Cache (ttl %s, %d entries)", c.TTL, c.GetMaxEntries())
+}
+
+// ToTree returns a tree representation of the cache configuration
+func (c *Cache) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("TTL: %s", c.TTL))
+	tree.AddChild(fmt.Sprintf("Max Entries: %d", c.GetMaxEntries()))
+	tree.AddChild(fmt.Sprintf("Max Body Size: %d bytes", c.GetMaxBodySize()))
+	if len(c.VaryHeaders) > 0 {
+		tree.AddChild(fmt.Sprintf("Vary Headers: %s", strings.Join(c.GetVaryHeaders(), ", ")))
+	}
+	return tree
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "cache", (&Cache{}).Type())
+}
+
+func TestCache_Defaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		c := &Cache{TTL: time.Minute}
+		assert.Equal(t, DefaultMaxEntries, c.GetMaxEntries())
+		assert.Equal(t, int64(DefaultMaxBodySize), c.GetMaxBodySize())
+		assert.Empty(t, c.GetVaryHeaders())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		c := &Cache{
+			TTL:         time.Minute,
+			MaxEntries:  10,
+			MaxBodySize: 4096,
+			VaryHeaders: []string{"accept-encoding", "X-Tenant"},
+		}
+		assert.Equal(t, 10, c.GetMaxEntries())
+		assert.Equal(t, int64(4096), c.GetMaxBodySize())
+		assert.Equal(t, []string{"Accept-Encoding", "X-Tenant"}, c.GetVaryHeaders())
+	})
+}
+
+func TestCache_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *Cache
+		wantErr error
+	}{
+		{
+			name:   "ttl only",
+			config: &Cache{TTL: time.Second},
+		},
+		{
+			name: "fully configured",
+			config: &Cache{
+				TTL:         5 * time.Minute,
+				MaxEntries:  100,
+				MaxBodySize: 4096,
+				VaryHeaders: []string{"Accept", "Accept-Language"},
+			},
+		},
+		{
+			name:    "missing ttl",
+			config:  &Cache{},
+			wantErr: ErrMissingTTL,
+		},
+		{
+			name:    "negative ttl",
+			config:  &Cache{TTL: -time.Second},
+			wantErr: ErrInvalidTTL,
+		},
+		{
+			name:    "negative max entries",
+			config:  &Cache{TTL: time.Second, MaxEntries: -1},
+			wantErr: ErrInvalidMaxEntries,
+		},
+		{
+			name:    "negative max body size",
+			config:  &Cache{TTL: time.Second, MaxBodySize: -1},
+			wantErr: ErrInvalidMaxBodySize,
+		},
+		{
+			name:    "invalid vary header",
+			config:  &Cache{TTL: time.Second, VaryHeaders: []string{"Bad Header"}},
+			wantErr: ErrInvalidVaryHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCache_StringAndTree(t *testing.T) {
+	t.Parallel()
+
+	c := &Cache{TTL: 30 * time.Second, VaryHeaders: []string{"accept"}}
+	assert.Equal(t, "Cache (ttl 30s, 1000 entries)", c.String())
+	assert.NotNil(t, c.ToTree())
+}
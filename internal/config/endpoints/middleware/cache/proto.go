@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ToProto converts Cache to protobuf format
+func (c *Cache) ToProto() any {
+	config := &pb.CacheConfig{
+		VaryHeaders: c.VaryHeaders,
+	}
+	if c.TTL != 0 {
+		config.Ttl = durationpb.New(c.TTL)
+	}
+	if c.MaxEntries != 0 {
+		maxEntries := int32(c.MaxEntries)
+		config.MaxEntries = &maxEntries
+	}
+	if c.MaxBodySize != 0 {
+		config.MaxBodySize = &c.MaxBodySize
+	}
+	return config
+}
+
+// FromProto converts protobuf CacheConfig to domain Cache
+func FromProto(pbConfig *pb.CacheConfig) (*Cache, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil cache config")
+	}
+
+	config := &Cache{
+		MaxEntries:  int(pbConfig.GetMaxEntries()),
+		MaxBodySize: pbConfig.GetMaxBodySize(),
+		VaryHeaders: pbConfig.GetVaryHeaders(),
+	}
+	if pbConfig.Ttl != nil {
+		config.TTL = pbConfig.Ttl.AsDuration()
+	}
+
+	return config, nil
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.CacheConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &Cache{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &Cache{
+		TTL:         time.Minute,
+		MaxEntries:  50,
+		MaxBodySize: 2048,
+		VaryHeaders: []string{"Accept"},
+	}
+
+	pbConfig, ok := original.ToProto().(*pb.CacheConfig)
+	require.True(t, ok)
+	assert.Equal(t, time.Minute, pbConfig.GetTtl().AsDuration())
+	assert.Equal(t, int32(50), pbConfig.GetMaxEntries())
+
+	converted, err := FromProto(pbConfig)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+
+	t.Run("unset fields stay unset", func(t *testing.T) {
+		empty, ok := (&Cache{}).ToProto().(*pb.CacheConfig)
+		require.True(t, ok)
+		assert.Nil(t, empty.Ttl)
+		assert.Nil(t, empty.MaxEntries)
+		assert.Nil(t, empty.MaxBodySize)
+	})
+}
@@ -0,0 +1,164 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+const CircuitBreakerType = "circuit_breaker"
+
+// Defaults for settings that aren't configured
+const (
+	DefaultFailureThreshold = 5
+	DefaultMinRequests      = 10
+	DefaultWindow           = 60 * time.Second
+	DefaultOpenTimeout      = 30 * time.Second
+	DefaultHalfOpenRequests = 1
+)
+
+var (
+	// ErrNegativeFailureThreshold indicates a negative failure threshold
+	ErrNegativeFailureThreshold = errors.New("failure_threshold cannot be negative")
+
+	// ErrInvalidFailureRatio indicates a failure ratio outside of (0, 1]
+	ErrInvalidFailureRatio = errors.New("failure_ratio must be greater than 0 and at most 1")
+
+	// ErrNegativeMinRequests indicates a negative minimum request count
+	ErrNegativeMinRequests = errors.New("min_requests cannot be negative")
+
+	// ErrNegativeDuration indicates a negative window or open timeout
+	ErrNegativeDuration = errors.New("duration cannot be negative")
+
+	// ErrNegativeHalfOpenRequests indicates a negative half-open request count
+	ErrNegativeHalfOpenRequests = errors.New("half_open_requests cannot be negative")
+)
+
+// CircuitBreaker represents a circuit breaker middleware configuration. Zero
+// values use the defaults, see the getters.
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int `json:"failureThreshold" toml:"failure_threshold"`
+
+	// FailureRatio is the fraction of failures within the window that opens
+	// the breaker, once MinRequests have been seen. Zero disables it.
+	FailureRatio float64 `json:"failureRatio" toml:"failure_ratio"`
+
+	// MinRequests is the number of requests the window needs before
+	// FailureRatio is checked
+	MinRequests int `json:"minRequests" toml:"min_requests"`
+
+	// Window is how long failures are counted while closed
+	Window time.Duration `json:"window" toml:"window"`
+
+	// OpenTimeout is how long the breaker stays open
+	OpenTimeout time.Duration `json:"openTimeout" toml:"open_timeout"`
+
+	// HalfOpenRequests is the number of trial requests allowed while half-open
+	HalfOpenRequests int `json:"halfOpenRequests" toml:"half_open_requests"`
+}
+
+// Type returns the middleware type
+func (c *CircuitBreaker) Type() string {
+	return CircuitBreakerType
+}
+
+// GetFailureThreshold returns the consecutive failure threshold. It defaults
+// to DefaultFailureThreshold only when no failure ratio is set, so a breaker
+// configured with just a ratio doesn't also trip on consecutive failures.
+func (c *CircuitBreaker) GetFailureThreshold() int {
+	if c.FailureThreshold == 0 && c.FailureRatio == 0 {
+		return DefaultFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+// GetMinRequests returns the minimum request count, or DefaultMinRequests
+func (c *CircuitBreaker) GetMinRequests() int {
+	if c.MinRequests == 0 {
+		return DefaultMinRequests
+	}
+	return c.MinRequests
+}
+
+// GetWindow returns the failure counting window, or DefaultWindow
+func (c *CircuitBreaker) GetWindow() time.Duration {
+	if c.Window == 0 {
+		return DefaultWindow
+	}
+	return c.Window
+}
+
+// GetOpenTimeout returns the open cool-down, or DefaultOpenTimeout
+func (c *CircuitBreaker) GetOpenTimeout() time.Duration {
+	if c.OpenTimeout == 0 {
+		return DefaultOpenTimeout
+	}
+	return c.OpenTimeout
+}
+
+// GetHalfOpenRequests returns the half-open trial count, or DefaultHalfOpenRequests
+func (c *CircuitBreaker) GetHalfOpenRequests() int {
+	if c.HalfOpenRequests == 0 {
+		return DefaultHalfOpenRequests
+	}
+	return c.HalfOpenRequests
+}
+
+// Validate validates the circuit breaker configuration
+func (c *CircuitBreaker) Validate() error {
+	var errs []error
+
+	if c.FailureThreshold < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrNegativeFailureThreshold, c.FailureThreshold))
+	}
+	if c.FailureRatio < 0 || c.FailureRatio > 1 {
+		errs = append(errs, fmt.Errorf("%w: %g", ErrInvalidFailureRatio, c.FailureRatio))
+	}
+	if c.MinRequests < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrNegativeMinRequests, c.MinRequests))
+	}
+	if c.Window < 0 {
+		errs = append(errs, fmt.Errorf("%w: window %s", ErrNegativeDuration, c.Window))
+	}
+	if c.OpenTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: open_timeout %s", ErrNegativeDuration, c.OpenTimeout))
+	}
+	if c.HalfOpenRequests < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrNegativeHalfOpenRequests, c.HalfOpenRequests))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the circuit breaker configuration
+func (c *CircuitBreaker) String() string {
+	return fmt.Sprintf("Circuit breaker (%s, open for %s)", c.tripCondition(), c.GetOpenTimeout())
+}
+
+// tripCondition describes when the breaker opens
+func (c *CircuitBreaker) tripCondition() string {
+	threshold := c.GetFailureThreshold()
+	consecutive := fmt.Sprintf("%d consecutive failures", threshold)
+	ratio := fmt.Sprintf("%g%% of %d+ requests failing", c.FailureRatio*100, c.GetMinRequests())
+	switch {
+	case threshold > 0 && c.FailureRatio > 0:
+		return consecutive + " or " + ratio
+	case c.FailureRatio > 0:
+		return ratio
+	default:
+		return consecutive
+	}
+}
+
+// ToTree returns a tree representation of the circuit breaker configuration
+func (c *CircuitBreaker) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("Opens after: %s", c.tripCondition()))
+	tree.AddChild(fmt.Sprintf("Window: %s", c.GetWindow()))
+	tree.AddChild(fmt.Sprintf("Open Timeout: %s", c.GetOpenTimeout()))
+	tree.AddChild(fmt.Sprintf("Half-Open Requests: %d", c.GetHalfOpenRequests()))
+	return tree
+}
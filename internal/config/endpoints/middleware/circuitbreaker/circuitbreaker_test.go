@@ -0,0 +1,146 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "circuit_breaker", (&CircuitBreaker{}).Type())
+}
+
+func TestCircuitBreaker_Defaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		cb := &CircuitBreaker{}
+		assert.Equal(t, DefaultFailureThreshold, cb.GetFailureThreshold())
+		assert.Equal(t, DefaultMinRequests, cb.GetMinRequests())
+		assert.Equal(t, DefaultWindow, cb.GetWindow())
+		assert.Equal(t, DefaultOpenTimeout, cb.GetOpenTimeout())
+		assert.Equal(t, DefaultHalfOpenRequests, cb.GetHalfOpenRequests())
+	})
+
+	t.Run("ratio only has no consecutive threshold", func(t *testing.T) {
+		cb := &CircuitBreaker{FailureRatio: 0.5}
+		assert.Equal(t, 0, cb.GetFailureThreshold())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		cb := &CircuitBreaker{
+			FailureThreshold: 3,
+			MinRequests:      20,
+			Window:           time.Minute,
+			OpenTimeout:      5 * time.Second,
+			HalfOpenRequests: 2,
+		}
+		assert.Equal(t, 3, cb.GetFailureThreshold())
+		assert.Equal(t, 20, cb.GetMinRequests())
+		assert.Equal(t, time.Minute, cb.GetWindow())
+		assert.Equal(t, 5*time.Second, cb.GetOpenTimeout())
+		assert.Equal(t, 2, cb.GetHalfOpenRequests())
+	})
+}
+
+func TestCircuitBreaker_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *CircuitBreaker
+		wantErr error
+	}{
+		{
+			name:   "defaults",
+			config: &CircuitBreaker{},
+		},
+		{
+			name: "threshold and ratio",
+			config: &CircuitBreaker{
+				FailureThreshold: 3,
+				FailureRatio:     1,
+				MinRequests:      5,
+				Window:           time.Minute,
+				OpenTimeout:      time.Second,
+				HalfOpenRequests: 2,
+			},
+		},
+		{
+			name:    "negative threshold",
+			config:  &CircuitBreaker{FailureThreshold: -1},
+			wantErr: ErrNegativeFailureThreshold,
+		},
+		{
+			name:    "negative ratio",
+			config:  &CircuitBreaker{FailureRatio: -0.1},
+			wantErr: ErrInvalidFailureRatio,
+		},
+		{
+			name:    "ratio above one",
+			config:  &CircuitBreaker{FailureRatio: 1.5},
+			wantErr: ErrInvalidFailureRatio,
+		},
+		{
+			name:    "negative min requests",
+			config:  &CircuitBreaker{MinRequests: -1},
+			wantErr: ErrNegativeMinRequests,
+		},
+		{
+			name:    "negative window",
+			config:  &CircuitBreaker{Window: -time.Second},
+			wantErr: ErrNegativeDuration,
+		},
+		{
+			name:    "negative open timeout",
+			config:  &CircuitBreaker{OpenTimeout: -time.Second},
+			wantErr: ErrNegativeDuration,
+		},
+		{
+			name:    "negative half-open requests",
+			config:  &CircuitBreaker{HalfOpenRequests: -1},
+			wantErr: ErrNegativeHalfOpenRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCircuitBreaker_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"Circuit breaker (5 consecutive failures, open for 30s)",
+		(&CircuitBreaker{}).String())
+	assert.Equal(t,
+		"Circuit breaker (50% of 10+ requests failing, open for 30s)",
+		(&CircuitBreaker{FailureRatio: 0.5}).String())
+	assert.Equal(t,
+		"Circuit breaker (3 consecutive failures or 25% of 20+ requests failing, open for 10s)",
+		(&CircuitBreaker{
+			FailureThreshold: 3,
+			FailureRatio:     0.25,
+			MinRequests:      20,
+			OpenTimeout:      10 * time.Second,
+		}).String())
+}
+
+func TestCircuitBreaker_ToTree(t *testing.T) {
+	t.Parallel()
+
+	tree := (&CircuitBreaker{}).ToTree()
+	require.NotNil(t, tree)
+	assert.NotNil(t, tree.Tree())
+}
@@ -0,0 +1,57 @@
+package circuitbreaker
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ToProto converts CircuitBreaker to protobuf format
+func (c *CircuitBreaker) ToProto() any {
+	config := &pb.CircuitBreakerConfig{}
+	if c.FailureThreshold != 0 {
+		threshold := int32(c.FailureThreshold)
+		config.FailureThreshold = &threshold
+	}
+	if c.FailureRatio != 0 {
+		config.FailureRatio = &c.FailureRatio
+	}
+	if c.MinRequests != 0 {
+		minRequests := int32(c.MinRequests)
+		config.MinRequests = &minRequests
+	}
+	if c.Window != 0 {
+		config.Window = durationpb.New(c.Window)
+	}
+	if c.OpenTimeout != 0 {
+		config.OpenTimeout = durationpb.New(c.OpenTimeout)
+	}
+	if c.HalfOpenRequests != 0 {
+		halfOpen := int32(c.HalfOpenRequests)
+		config.HalfOpenRequests = &halfOpen
+	}
+	return config
+}
+
+// FromProto converts protobuf CircuitBreakerConfig to domain CircuitBreaker
+func FromProto(pbConfig *pb.CircuitBreakerConfig) (*CircuitBreaker, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil circuit breaker config")
+	}
+
+	config := &CircuitBreaker{
+		FailureThreshold: int(pbConfig.GetFailureThreshold()),
+		FailureRatio:     pbConfig.GetFailureRatio(),
+		MinRequests:      int(pbConfig.GetMinRequests()),
+		HalfOpenRequests: int(pbConfig.GetHalfOpenRequests()),
+	}
+	if pbConfig.Window != nil {
+		config.Window = pbConfig.Window.AsDuration()
+	}
+	if pbConfig.OpenTimeout != nil {
+		config.OpenTimeout = pbConfig.OpenTimeout.AsDuration()
+	}
+
+	return config, nil
+}
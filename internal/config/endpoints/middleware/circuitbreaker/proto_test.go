@@ -0,0 +1,54 @@
+package circuitbreaker
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.CircuitBreakerConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &CircuitBreaker{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &CircuitBreaker{
+		FailureThreshold: 3,
+		FailureRatio:     0.5,
+		MinRequests:      20,
+		Window:           time.Minute,
+		OpenTimeout:      10 * time.Second,
+		HalfOpenRequests: 2,
+	}
+
+	pbConfig, ok := original.ToProto().(*pb.CircuitBreakerConfig)
+	require.True(t, ok)
+	assert.Equal(t, int32(3), pbConfig.GetFailureThreshold())
+	assert.InDelta(t, 0.5, pbConfig.GetFailureRatio(), 0)
+	assert.Equal(t, time.Minute, pbConfig.GetWindow().AsDuration())
+
+	converted, err := FromProto(pbConfig)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+
+	// Unset fields stay unset
+	empty, ok := (&CircuitBreaker{}).ToProto().(*pb.CircuitBreakerConfig)
+	require.True(t, ok)
+	assert.Nil(t, empty.FailureThreshold)
+	assert.Nil(t, empty.Window)
+}
@@ -32,6 +32,12 @@ type HeaderOperations struct {
 
 	// Header names to remove
 	RemoveHeaders []string `json:"removeHeaders" toml:"remove_headers" env_interpolation:"yes"`
+
+	// Glob patterns of header names to remove, matched case-insensitively
+	RemoveHeaderPatterns []string `json:"removeHeaderPatterns" toml:"remove_header_patterns" env_interpolation:"yes"`
+
+	// Operations applied to responses by status class, response operations only
+	StatusOverrides []StatusOverride `json:"statusOverrides" toml:"status_overrides"`
 }
 
 // Headers represents a headers middleware configuration
@@ -41,6 +47,9 @@ type Headers struct {
 
 	// Operations to perform on response headers
 	Response *HeaderOperations `json:"response,omitempty" toml:"response,omitempty"`
+
+	// Security headers preset applied to responses
+	Security *SecurityPreset `json:"security,omitempty" toml:"security,omitempty"`
 }
 
 // NewHeaderOperations creates a new header operations configuration
@@ -63,7 +72,8 @@ func NewHeaders(request, response *HeaderOperations) *Headers {
 
 // HasOperations checks if HeaderOperations has any operations
 func (ho *HeaderOperations) HasOperations() bool {
-	return len(ho.SetHeaders) > 0 || len(ho.AddHeaders) > 0 || len(ho.RemoveHeaders) > 0
+	return len(ho.SetHeaders) > 0 || len(ho.AddHeaders) > 0 || len(ho.RemoveHeaders) > 0 ||
+		len(ho.RemoveHeaderPatterns) > 0 || len(ho.StatusOverrides) > 0
 }
 
 // ToTree returns a tree representation using the operation's title
@@ -85,6 +95,16 @@ func (ho *HeaderOperations) ToTree() *fancy.ComponentTree {
 		tree.AddChild(fmt.Sprintf("Remove: \"%s\"", key))
 	}
 
+	// Remove header patterns
+	for _, pattern := range ho.RemoveHeaderPatterns {
+		tree.AddChild(fmt.Sprintf("Remove Pattern: \"%s\"", pattern))
+	}
+
+	// Status overrides
+	for i := range ho.StatusOverrides {
+		tree.AddChild(ho.StatusOverrides[i].ToTree().Tree())
+	}
+
 	return tree
 }
 
@@ -123,6 +143,32 @@ func (ho *HeaderOperations) Validate() error {
 		}
 	}
 
+	// Validate remove header patterns
+	for _, pattern := range ho.RemoveHeaderPatterns {
+		if err := validateHeaderPattern(pattern); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Headers that are set or added would be removed again by a pattern
+	for key := range ho.SetHeaders {
+		if ho.MatchesRemovePattern(key) {
+			errs = append(errs, fmt.Errorf("set header '%s' matches a remove pattern", key))
+		}
+	}
+	for key := range ho.AddHeaders {
+		if ho.MatchesRemovePattern(key) {
+			errs = append(errs, fmt.Errorf("add header '%s' matches a remove pattern", key))
+		}
+	}
+
+	// Validate status overrides
+	for i := range ho.StatusOverrides {
+		if err := ho.StatusOverrides[i].Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid status override %d: %w", i, err))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -135,6 +181,9 @@ func (h *Headers) Validate() error {
 		if err := h.Request.Validate(); err != nil {
 			errs = append(errs, fmt.Errorf("invalid request header operations: %w", err))
 		}
+		if len(h.Request.StatusOverrides) > 0 {
+			errs = append(errs, errors.New("status overrides only apply to response header operations"))
+		}
 	}
 
 	// Validate response operations
@@ -144,11 +193,25 @@ func (h *Headers) Validate() error {
 		}
 	}
 
+	// Validate the security preset
+	if h.Security != nil {
+		if err := h.Security.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid security preset: %w", err))
+		}
+		if h.Response != nil {
+			for key := range h.Security.Headers() {
+				if h.Response.MatchesRemovePattern(key) {
+					errs = append(errs, fmt.Errorf("security header '%s' matches a remove pattern", key))
+				}
+			}
+		}
+	}
+
 	// At least one operation must be configured
-	if h.Request == nil && h.Response == nil {
+	if h.Request == nil && h.Response == nil && !h.hasSecurity() {
 		errs = append(
 			errs,
-			errors.New("at least one of request or response operations must be configured"),
+			errors.New("at least one of request or response operations must be configured, or the security preset enabled"),
 		)
 	}
 
@@ -171,6 +234,14 @@ func (ho *HeaderOperations) String() string {
 		parts = append(parts, fmt.Sprintf("Remove: %d headers", len(ho.RemoveHeaders)))
 	}
 
+	if len(ho.RemoveHeaderPatterns) > 0 {
+		parts = append(parts, fmt.Sprintf("Remove: %d patterns", len(ho.RemoveHeaderPatterns)))
+	}
+
+	if len(ho.StatusOverrides) > 0 {
+		parts = append(parts, fmt.Sprintf("Status overrides: %d", len(ho.StatusOverrides)))
+	}
+
 	if len(parts) == 0 {
 		return "No operations"
 	}
@@ -190,6 +261,10 @@ func (h *Headers) String() string {
 		parts = append(parts, fmt.Sprintf("%s: %s", string(ResponseHeaderOperationsType), h.Response.String()))
 	}
 
+	if h.Security != nil {
+		parts = append(parts, h.Security.String())
+	}
+
 	if len(parts) == 0 {
 		return "No header operations configured"
 	}
@@ -204,7 +279,7 @@ func (h *Headers) ToTree() *fancy.ComponentTree {
 	hasResponseOps := h.Response != nil && h.Response.HasOperations()
 
 	// Return empty tree if no operations exist
-	if !hasRequestOps && !hasResponseOps {
+	if !hasRequestOps && !hasResponseOps && !h.hasSecurity() {
 		return fancy.NewComponentTree("")
 	}
 
@@ -219,9 +294,18 @@ func (h *Headers) ToTree() *fancy.ComponentTree {
 		tree.AddChild(h.Response.ToTree().Tree())
 	}
 
+	if h.hasSecurity() {
+		tree.AddChild(h.Security.ToTree().Tree())
+	}
+
 	return tree
 }
 
+// hasSecurity reports whether the security preset is enabled
+func (h *Headers) hasSecurity() bool {
+	return h.Security != nil && h.Security.Enabled
+}
+
 // validateHeader validates a header key-value pair using httpguts
 func validateHeader(key, value string) error {
 	if strings.TrimSpace(key) == "" {
@@ -3,8 +3,10 @@ package headers
 import (
 	"fmt"
 	"maps"
+	"slices"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 // ToProto converts Headers to protobuf format
@@ -21,6 +23,9 @@ func (h *Headers) ToProto() any {
 
 		// Copy request remove headers
 		copy(config.Request.RemoveHeaders, h.Request.RemoveHeaders)
+
+		config.Request.RemoveHeaderPatterns = slices.Clone(h.Request.RemoveHeaderPatterns)
+		config.Request.StatusOverrides = statusOverridesToProto(h.Request.StatusOverrides)
 	}
 
 	// Convert response operations
@@ -33,6 +38,23 @@ func (h *Headers) ToProto() any {
 
 		// Copy response remove headers
 		copy(config.Response.RemoveHeaders, h.Response.RemoveHeaders)
+
+		config.Response.RemoveHeaderPatterns = slices.Clone(h.Response.RemoveHeaderPatterns)
+		config.Response.StatusOverrides = statusOverridesToProto(h.Response.StatusOverrides)
+	}
+
+	// Convert security preset
+	if h.Security != nil {
+		config.Security = &pb.HeadersConfig_SecurityPreset{
+			Enabled:               proto.Bool(h.Security.Enabled),
+			HstsMaxAgeSeconds:     proto.Int64(h.Security.HSTSMaxAgeSeconds),
+			HstsIncludeSubdomains: proto.Bool(h.Security.HSTSIncludeSubdomains),
+			HstsPreload:           proto.Bool(h.Security.HSTSPreload),
+			ContentSecurityPolicy: proto.String(h.Security.ContentSecurityPolicy),
+			ContentTypeOptions:    proto.String(h.Security.ContentTypeOptions),
+			FrameOptions:          proto.String(h.Security.FrameOptions),
+			ReferrerPolicy:        proto.String(h.Security.ReferrerPolicy),
+		}
 	}
 
 	return config
@@ -67,9 +89,80 @@ func newHeaderOperationsFromProto(title HeaderOperationsType, pbOps *pb.HeadersC
 		copy(ops.RemoveHeaders, pbOps.RemoveHeaders)
 	}
 
+	ops.RemoveHeaderPatterns = slices.Clone(pbOps.RemoveHeaderPatterns)
+	ops.StatusOverrides = statusOverridesFromProto(pbOps.StatusOverrides)
+
 	return ops
 }
 
+// statusOverridesToProto converts domain StatusOverrides to protobuf format
+func statusOverridesToProto(overrides []StatusOverride) []*pb.HeadersConfig_StatusOverride {
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	pbOverrides := make([]*pb.HeadersConfig_StatusOverride, 0, len(overrides))
+	for _, o := range overrides {
+		pbOverrides = append(pbOverrides, &pb.HeadersConfig_StatusOverride{
+			StatusClass:   statusClassToProto(o.StatusClass).Enum(),
+			SetHeaders:    maps.Clone(o.SetHeaders),
+			RemoveHeaders: slices.Clone(o.RemoveHeaders),
+		})
+	}
+	return pbOverrides
+}
+
+// statusOverridesFromProto converts protobuf StatusOverrides to domain StatusOverrides
+func statusOverridesFromProto(pbOverrides []*pb.HeadersConfig_StatusOverride) []StatusOverride {
+	if len(pbOverrides) == 0 {
+		return nil
+	}
+
+	overrides := make([]StatusOverride, 0, len(pbOverrides))
+	for _, o := range pbOverrides {
+		overrides = append(overrides, StatusOverride{
+			StatusClass:   statusClassFromProto(o.GetStatusClass()),
+			SetHeaders:    maps.Clone(o.GetSetHeaders()),
+			RemoveHeaders: slices.Clone(o.GetRemoveHeaders()),
+		})
+	}
+	return overrides
+}
+
+func statusClassToProto(class StatusClass) pb.HeadersConfig_StatusOverride_StatusClass {
+	switch class {
+	case StatusClass1xx:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_1XX
+	case StatusClass2xx:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_2XX
+	case StatusClass3xx:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_3XX
+	case StatusClass4xx:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_4XX
+	case StatusClass5xx:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_5XX
+	default:
+		return pb.HeadersConfig_StatusOverride_STATUS_CLASS_UNSPECIFIED
+	}
+}
+
+func statusClassFromProto(class pb.HeadersConfig_StatusOverride_StatusClass) StatusClass {
+	switch class {
+	case pb.HeadersConfig_StatusOverride_STATUS_CLASS_1XX:
+		return StatusClass1xx
+	case pb.HeadersConfig_StatusOverride_STATUS_CLASS_2XX:
+		return StatusClass2xx
+	case pb.HeadersConfig_StatusOverride_STATUS_CLASS_3XX:
+		return StatusClass3xx
+	case pb.HeadersConfig_StatusOverride_STATUS_CLASS_4XX:
+		return StatusClass4xx
+	case pb.HeadersConfig_StatusOverride_STATUS_CLASS_5XX:
+		return StatusClass5xx
+	default:
+		return StatusClassUnspecified
+	}
+}
+
 // FromProto converts protobuf HeadersConfig to domain Headers
 func FromProto(pbConfig *pb.HeadersConfig) (*Headers, error) {
 	if pbConfig == nil {
@@ -82,5 +175,21 @@ func FromProto(pbConfig *pb.HeadersConfig) (*Headers, error) {
 	responseOps := newHeaderOperationsFromProto(
 		ResponseHeaderOperationsType, pbConfig.Response)
 
-	return NewHeaders(requestOps, responseOps), nil
+	h := NewHeaders(requestOps, responseOps)
+
+	// Unset preset fields fall back to the proto defaults
+	if pbSecurity := pbConfig.GetSecurity(); pbSecurity != nil {
+		h.Security = &SecurityPreset{
+			Enabled:               pbSecurity.GetEnabled(),
+			HSTSMaxAgeSeconds:     pbSecurity.GetHstsMaxAgeSeconds(),
+			HSTSIncludeSubdomains: pbSecurity.GetHstsIncludeSubdomains(),
+			HSTSPreload:           pbSecurity.GetHstsPreload(),
+			ContentSecurityPolicy: pbSecurity.GetContentSecurityPolicy(),
+			ContentTypeOptions:    pbSecurity.GetContentTypeOptions(),
+			FrameOptions:          pbSecurity.GetFrameOptions(),
+			ReferrerPolicy:        pbSecurity.GetReferrerPolicy(),
+		}
+	}
+
+	return h, nil
 }
@@ -307,4 +307,38 @@ func TestRoundTripConversion(t *testing.T) {
 		assert.Equal(t, original.Request, converted.Request)
 		assert.Equal(t, original.Response, converted.Response)
 	})
+	t.Run("patterns and status overrides round trip", func(t *testing.T) {
+		original := &Headers{
+			Request: &HeaderOperations{
+				RemoveHeaderPatterns: []string{"X-Debug-*"},
+			},
+			Response: &HeaderOperations{
+				RemoveHeaderPatterns: []string{"X-Internal-*"},
+				StatusOverrides: []StatusOverride{
+					{
+						StatusClass: StatusClass5xx,
+						SetHeaders:  map[string]string{"Cache-Control": "no-store"},
+					},
+					{
+						StatusClass:   StatusClass4xx,
+						RemoveHeaders: []string{"ETag"},
+					},
+				},
+			},
+		}
+
+		pbConfig, ok := original.ToProto().(*pb.HeadersConfig)
+		require.True(t, ok)
+		require.Len(t, pbConfig.Response.StatusOverrides, 2)
+		assert.Equal(t,
+			pb.HeadersConfig_StatusOverride_STATUS_CLASS_5XX,
+			pbConfig.Response.StatusOverrides[0].GetStatusClass(),
+		)
+
+		converted, err := FromProto(pbConfig)
+		require.NoError(t, err)
+		assert.Equal(t, original.Request.RemoveHeaderPatterns, converted.Request.RemoveHeaderPatterns)
+		assert.Equal(t, original.Response.RemoveHeaderPatterns, converted.Response.RemoveHeaderPatterns)
+		assert.Equal(t, original.Response.StatusOverrides, converted.Response.StatusOverrides)
+	})
 }
@@ -0,0 +1,158 @@
+package headers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+)
+
+// Default values of the security headers preset
+const (
+	DefaultHSTSMaxAgeSeconds     int64 = 31536000
+	DefaultContentSecurityPolicy       = "default-src 'self'"
+	DefaultContentTypeOptions          = "nosniff"
+	DefaultFrameOptions                = "DENY"
+	DefaultReferrerPolicy              = "strict-origin-when-cross-origin"
+)
+
+// Names of the headers set by the security headers preset
+const (
+	HeaderStrictTransportSecurity = "Strict-Transport-Security"
+	HeaderContentSecurityPolicy   = "Content-Security-Policy"
+	HeaderContentTypeOptions      = "X-Content-Type-Options"
+	HeaderFrameOptions            = "X-Frame-Options"
+	HeaderReferrerPolicy          = "Referrer-Policy"
+)
+
+// SecurityPreset represents a set of security headers applied to every
+// response. Response set headers of the same name take precedence.
+type SecurityPreset struct {
+	// Enabled turns the security headers on
+	Enabled bool `json:"enabled" toml:"enabled"`
+
+	// Strict-Transport-Security max-age in seconds
+	HSTSMaxAgeSeconds int64 `json:"hstsMaxAgeSeconds" toml:"hsts_max_age_seconds"`
+
+	// Add includeSubDomains to Strict-Transport-Security
+	HSTSIncludeSubdomains bool `json:"hstsIncludeSubdomains" toml:"hsts_include_subdomains"`
+
+	// Add preload to Strict-Transport-Security
+	HSTSPreload bool `json:"hstsPreload" toml:"hsts_preload"`
+
+	// Content-Security-Policy value, required when enabled
+	ContentSecurityPolicy string `json:"contentSecurityPolicy" toml:"content_security_policy" env_interpolation:"yes"`
+
+	// X-Content-Type-Options value, empty to omit the header
+	ContentTypeOptions string `json:"contentTypeOptions" toml:"content_type_options" env_interpolation:"yes"`
+
+	// X-Frame-Options value, empty to omit the header
+	FrameOptions string `json:"frameOptions" toml:"frame_options" env_interpolation:"yes"`
+
+	// Referrer-Policy value, empty to omit the header
+	ReferrerPolicy string `json:"referrerPolicy" toml:"referrer_policy" env_interpolation:"yes"`
+}
+
+// NewSecurityPreset creates an enabled security headers preset with the
+// default values
+func NewSecurityPreset() *SecurityPreset {
+	return &SecurityPreset{
+		Enabled:               true,
+		HSTSMaxAgeSeconds:     DefaultHSTSMaxAgeSeconds,
+		HSTSIncludeSubdomains: true,
+		ContentSecurityPolicy: DefaultContentSecurityPolicy,
+		ContentTypeOptions:    DefaultContentTypeOptions,
+		FrameOptions:          DefaultFrameOptions,
+		ReferrerPolicy:        DefaultReferrerPolicy,
+	}
+}
+
+// Headers returns the headers set by the preset, or nil when it is disabled
+func (sp *SecurityPreset) Headers() map[string]string {
+	if sp == nil || !sp.Enabled {
+		return nil
+	}
+
+	hsts := fmt.Sprintf("max-age=%d", sp.HSTSMaxAgeSeconds)
+	if sp.HSTSIncludeSubdomains {
+		hsts += "; includeSubDomains"
+	}
+	if sp.HSTSPreload {
+		hsts += "; preload"
+	}
+
+	h := map[string]string{
+		HeaderStrictTransportSecurity: hsts,
+		HeaderContentSecurityPolicy:   sp.ContentSecurityPolicy,
+	}
+	if sp.ContentTypeOptions != "" {
+		h[HeaderContentTypeOptions] = sp.ContentTypeOptions
+	}
+	if sp.FrameOptions != "" {
+		h[HeaderFrameOptions] = sp.FrameOptions
+	}
+	if sp.ReferrerPolicy != "" {
+		h[HeaderReferrerPolicy] = sp.ReferrerPolicy
+	}
+	return h
+}
+
+// Validate validates the security headers preset. A disabled preset is not
+// checked further.
+func (sp *SecurityPreset) Validate() error {
+	if !sp.Enabled {
+		return nil
+	}
+
+	var errs []error
+
+	if err := interpolation.InterpolateStruct(sp); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed: %w", err))
+	}
+
+	if sp.HSTSMaxAgeSeconds < 0 {
+		errs = append(errs, fmt.Errorf("HSTS max-age cannot be negative: %d", sp.HSTSMaxAgeSeconds))
+	}
+
+	if strings.TrimSpace(sp.ContentSecurityPolicy) == "" {
+		errs = append(errs, errors.New("content security policy cannot be empty when the security preset is enabled"))
+	}
+
+	for key, value := range sp.Headers() {
+		if err := validateHeader(key, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the security headers preset
+func (sp *SecurityPreset) String() string {
+	if !sp.Enabled {
+		return "Security: disabled"
+	}
+	return fmt.Sprintf("Security: %d headers", len(sp.Headers()))
+}
+
+// ToTree returns a tree representation of the security headers preset
+func (sp *SecurityPreset) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Security Preset:")
+
+	h := sp.Headers()
+	for _, key := range []string{
+		HeaderStrictTransportSecurity,
+		HeaderContentSecurityPolicy,
+		HeaderContentTypeOptions,
+		HeaderFrameOptions,
+		HeaderReferrerPolicy,
+	} {
+		if value, ok := h[key]; ok {
+			tree.AddChild(fmt.Sprintf("Set: \"%s: %s\"", key, value))
+		}
+	}
+
+	return tree
+}
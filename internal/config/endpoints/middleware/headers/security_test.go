@@ -0,0 +1,161 @@
+package headers
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSecurityPreset_Headers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, map[string]string{
+			"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+			"Content-Security-Policy":   "default-src 'self'",
+			"X-Content-Type-Options":    "nosniff",
+			"X-Frame-Options":           "DENY",
+			"Referrer-Policy":           "strict-origin-when-cross-origin",
+		}, NewSecurityPreset().Headers())
+	})
+
+	t.Run("empty values omit headers", func(t *testing.T) {
+		t.Parallel()
+
+		sp := NewSecurityPreset()
+		sp.ContentTypeOptions = ""
+		sp.FrameOptions = ""
+		sp.ReferrerPolicy = ""
+		sp.HSTSPreload = true
+
+		h := sp.Headers()
+		assert.Len(t, h, 2)
+		assert.Equal(t, "max-age=31536000; includeSubDomains; preload", h[HeaderStrictTransportSecurity])
+	})
+
+	t.Run("disabled or nil preset", func(t *testing.T) {
+		t.Parallel()
+
+		sp := NewSecurityPreset()
+		sp.Enabled = false
+		assert.Nil(t, sp.Headers())
+
+		var nilPreset *SecurityPreset
+		assert.Nil(t, nilPreset.Headers())
+	})
+}
+
+func TestSecurityPreset_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults are valid", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, NewSecurityPreset().Validate())
+	})
+
+	t.Run("negative HSTS max-age", func(t *testing.T) {
+		t.Parallel()
+
+		sp := NewSecurityPreset()
+		sp.HSTSMaxAgeSeconds = -1
+		err := sp.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "HSTS max-age cannot be negative")
+	})
+
+	t.Run("empty content security policy", func(t *testing.T) {
+		t.Parallel()
+
+		sp := NewSecurityPreset()
+		sp.ContentSecurityPolicy = " "
+		err := sp.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "content security policy cannot be empty")
+	})
+
+	t.Run("invalid header value", func(t *testing.T) {
+		t.Parallel()
+
+		sp := NewSecurityPreset()
+		sp.FrameOptions = "DENY\n"
+		err := sp.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid header value for X-Frame-Options")
+	})
+
+	t.Run("disabled preset is not checked", func(t *testing.T) {
+		t.Parallel()
+
+		sp := &SecurityPreset{HSTSMaxAgeSeconds: -1}
+		assert.NoError(t, sp.Validate())
+	})
+
+	t.Run("headers with only the security preset", func(t *testing.T) {
+		t.Parallel()
+
+		h := &Headers{Security: NewSecurityPreset()}
+		require.NoError(t, h.Validate())
+		assert.Contains(t, h.String(), "Security: 5 headers")
+		assert.Contains(t, h.ToTree().Tree().String(), "Security Preset:")
+	})
+
+	t.Run("headers with a disabled security preset only", func(t *testing.T) {
+		t.Parallel()
+
+		h := &Headers{Security: &SecurityPreset{}}
+		err := h.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one of request or response operations must be configured")
+	})
+
+	t.Run("security header matching a remove pattern", func(t *testing.T) {
+		t.Parallel()
+
+		h := &Headers{
+			Response: &HeaderOperations{RemoveHeaderPatterns: []string{"X-Frame-*"}},
+			Security: NewSecurityPreset(),
+		}
+		err := h.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "security header 'X-Frame-Options' matches a remove pattern")
+	})
+}
+
+func TestSecurityPreset_Proto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset fields use the defaults", func(t *testing.T) {
+		t.Parallel()
+
+		h, err := FromProto(&pb.HeadersConfig{
+			Security: &pb.HeadersConfig_SecurityPreset{Enabled: proto.Bool(true)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, NewSecurityPreset(), h.Security)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		original := &Headers{Security: &SecurityPreset{
+			Enabled:               true,
+			HSTSMaxAgeSeconds:     600,
+			HSTSPreload:           true,
+			ContentSecurityPolicy: "default-src 'none'",
+			ReferrerPolicy:        "no-referrer",
+		}}
+
+		pbConfig, ok := original.ToProto().(*pb.HeadersConfig)
+		require.True(t, ok)
+
+		converted, err := FromProto(pbConfig)
+		require.NoError(t, err)
+		assert.Equal(t, original.Security, converted.Security)
+	})
+}
@@ -0,0 +1,138 @@
+package headers
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+// StatusClass is a class of HTTP response status codes
+type StatusClass string
+
+const (
+	StatusClassUnspecified StatusClass = ""
+	StatusClass1xx         StatusClass = "1xx"
+	StatusClass2xx         StatusClass = "2xx"
+	StatusClass3xx         StatusClass = "3xx"
+	StatusClass4xx         StatusClass = "4xx"
+	StatusClass5xx         StatusClass = "5xx"
+)
+
+// Matches reports whether the status code belongs to the class
+func (c StatusClass) Matches(status int) bool {
+	switch c {
+	case StatusClass1xx:
+		return status >= 100 && status < 200
+	case StatusClass2xx:
+		return status >= 200 && status < 300
+	case StatusClass3xx:
+		return status >= 300 && status < 400
+	case StatusClass4xx:
+		return status >= 400 && status < 500
+	case StatusClass5xx:
+		return status >= 500 && status < 600
+	default:
+		return false
+	}
+}
+
+// StatusOverride represents header operations applied to responses whose
+// status code is in a status class. Headers are removed before they are set.
+type StatusOverride struct {
+	// StatusClass selects the responses the operations apply to
+	StatusClass StatusClass `json:"statusClass" toml:"status_class"`
+
+	// Headers to set (replace existing values)
+	SetHeaders map[string]string `json:"setHeaders" toml:"set_headers" env_interpolation:"yes"`
+
+	// Header names to remove
+	RemoveHeaders []string `json:"removeHeaders" toml:"remove_headers" env_interpolation:"yes"`
+}
+
+// Validate validates the status override. Interpolation is done by the
+// HeaderOperations holding it.
+func (so *StatusOverride) Validate() error {
+	var errs []error
+
+	switch so.StatusClass {
+	case StatusClass1xx, StatusClass2xx, StatusClass3xx, StatusClass4xx, StatusClass5xx:
+	case StatusClassUnspecified:
+		errs = append(errs, errors.New("status class must be specified"))
+	default:
+		errs = append(errs, fmt.Errorf("invalid status class: %s", so.StatusClass))
+	}
+
+	for key, value := range so.SetHeaders {
+		if err := validateHeader(key, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid set header '%s': %w", key, err))
+		}
+	}
+
+	for _, key := range so.RemoveHeaders {
+		if strings.TrimSpace(key) == "" {
+			errs = append(errs, errors.New("remove header name cannot be empty"))
+		}
+	}
+
+	if len(so.SetHeaders) == 0 && len(so.RemoveHeaders) == 0 {
+		errs = append(errs, errors.New("at least one set or remove header must be configured"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the status override
+func (so *StatusOverride) String() string {
+	return fmt.Sprintf("%s: Set: %d headers, Remove: %d headers",
+		so.StatusClass, len(so.SetHeaders), len(so.RemoveHeaders))
+}
+
+// ToTree returns a tree representation of the status override
+func (so *StatusOverride) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree(fmt.Sprintf("Status %s:", so.StatusClass))
+
+	for _, key := range so.RemoveHeaders {
+		tree.AddChild(fmt.Sprintf("Remove: \"%s\"", key))
+	}
+
+	for key, value := range so.SetHeaders {
+		tree.AddChild(fmt.Sprintf("Set: \"%s: %s\"", key, value))
+	}
+
+	return tree
+}
+
+// MatchesRemovePattern reports whether the header name matches one of the
+// remove patterns
+func (ho *HeaderOperations) MatchesRemovePattern(name string) bool {
+	for _, pattern := range ho.RemoveHeaderPatterns {
+		if matchHeaderPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchHeaderPattern matches a header name against a glob pattern, ignoring
+// case. Header names can't contain '/', so path.Match's handling of it never
+// applies.
+func matchHeaderPattern(pattern, name string) bool {
+	matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(name))
+	return err == nil && matched
+}
+
+// validateHeaderPattern checks that a remove pattern is a valid glob
+func validateHeaderPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return errors.New("header pattern cannot be empty")
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid header pattern %q: %w", pattern, err)
+	}
+
+	return nil
+}
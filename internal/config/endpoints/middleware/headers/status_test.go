@@ -0,0 +1,189 @@
+package headers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusClass_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		class   StatusClass
+		matches []int
+		misses  []int
+	}{
+		{StatusClass1xx, []int{100, 101, 199}, []int{200, 99}},
+		{StatusClass2xx, []int{200, 204, 299}, []int{199, 300}},
+		{StatusClass3xx, []int{301, 304}, []int{200, 400}},
+		{StatusClass4xx, []int{400, 404, 499}, []int{399, 500}},
+		{StatusClass5xx, []int{500, 503, 599}, []int{499, 600}},
+		{StatusClassUnspecified, nil, []int{200, 500}},
+	}
+
+	for _, tc := range tests {
+		for _, status := range tc.matches {
+			assert.True(t, tc.class.Matches(status), "%q should match %d", tc.class, status)
+		}
+		for _, status := range tc.misses {
+			assert.False(t, tc.class.Matches(status), "%q should not match %d", tc.class, status)
+		}
+	}
+}
+
+func TestStatusOverride_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid override", func(t *testing.T) {
+		t.Parallel()
+
+		override := &StatusOverride{
+			StatusClass:   StatusClass5xx,
+			SetHeaders:    map[string]string{"Cache-Control": "no-store"},
+			RemoveHeaders: []string{"ETag"},
+		}
+		assert.NoError(t, override.Validate())
+	})
+
+	t.Run("missing status class", func(t *testing.T) {
+		t.Parallel()
+
+		override := &StatusOverride{SetHeaders: map[string]string{"Cache-Control": "no-store"}}
+		err := override.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status class must be specified")
+	})
+
+	t.Run("invalid status class", func(t *testing.T) {
+		t.Parallel()
+
+		override := &StatusOverride{StatusClass: "6xx", RemoveHeaders: []string{"ETag"}}
+		err := override.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid status class: 6xx")
+	})
+
+	t.Run("no operations", func(t *testing.T) {
+		t.Parallel()
+
+		override := &StatusOverride{StatusClass: StatusClass4xx}
+		err := override.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one set or remove header must be configured")
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		t.Parallel()
+
+		override := &StatusOverride{
+			StatusClass:   StatusClass4xx,
+			SetHeaders:    map[string]string{"Invalid\nHeader": "value"},
+			RemoveHeaders: []string{" "},
+		}
+		err := override.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid header name")
+		assert.Contains(t, err.Error(), "remove header name cannot be empty")
+	})
+}
+
+func TestHeaderOperations_MatchesRemovePattern(t *testing.T) {
+	t.Parallel()
+
+	ops := &HeaderOperations{RemoveHeaderPatterns: []string{"X-Internal-*", "x-debug-?"}}
+
+	assert.True(t, ops.MatchesRemovePattern("X-Internal-Trace"))
+	assert.True(t, ops.MatchesRemovePattern("x-internal-trace"))
+	assert.True(t, ops.MatchesRemovePattern("X-Debug-1"))
+	assert.False(t, ops.MatchesRemovePattern("X-Debug-10"))
+	assert.False(t, ops.MatchesRemovePattern("X-Internal"))
+	assert.False(t, ops.MatchesRemovePattern("Content-Type"))
+}
+
+func TestHeaderOperations_ValidatePatternsAndOverrides(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid patterns and overrides", func(t *testing.T) {
+		t.Parallel()
+
+		ops := &HeaderOperations{
+			SetHeaders:           map[string]string{"X-Frame-Options": "DENY"},
+			RemoveHeaderPatterns: []string{"X-Internal-*"},
+			StatusOverrides: []StatusOverride{
+				{StatusClass: StatusClass5xx, SetHeaders: map[string]string{"Cache-Control": "no-store"}},
+			},
+		}
+		assert.NoError(t, ops.Validate())
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		t.Parallel()
+
+		ops := &HeaderOperations{RemoveHeaderPatterns: []string{"X-[", ""}}
+		err := ops.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid header pattern")
+		assert.Contains(t, err.Error(), "header pattern cannot be empty")
+	})
+
+	t.Run("set and add headers matching a pattern", func(t *testing.T) {
+		t.Parallel()
+
+		ops := &HeaderOperations{
+			SetHeaders:           map[string]string{"X-Internal-Id": "1"},
+			AddHeaders:           map[string]string{"x-internal-trace": "1"},
+			RemoveHeaderPatterns: []string{"X-Internal-*"},
+		}
+		err := ops.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "set header 'X-Internal-Id' matches a remove pattern")
+		assert.Contains(t, err.Error(), "add header 'x-internal-trace' matches a remove pattern")
+	})
+
+	t.Run("invalid status override", func(t *testing.T) {
+		t.Parallel()
+
+		ops := &HeaderOperations{StatusOverrides: []StatusOverride{{StatusClass: StatusClass5xx}}}
+		err := ops.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid status override 0")
+	})
+
+	t.Run("status overrides on request operations", func(t *testing.T) {
+		t.Parallel()
+
+		h := &Headers{
+			Request: &HeaderOperations{
+				StatusOverrides: []StatusOverride{
+					{StatusClass: StatusClass5xx, RemoveHeaders: []string{"ETag"}},
+				},
+			},
+		}
+		err := h.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status overrides only apply to response header operations")
+	})
+}
+
+func TestHeaderOperations_PatternsAndOverridesString(t *testing.T) {
+	t.Parallel()
+
+	ops := &HeaderOperations{
+		RemoveHeaderPatterns: []string{"X-Internal-*", "X-Debug-*"},
+		StatusOverrides: []StatusOverride{
+			{StatusClass: StatusClass5xx, SetHeaders: map[string]string{"Cache-Control": "no-store"}},
+		},
+	}
+	assert.True(t, ops.HasOperations())
+
+	str := ops.String()
+	assert.Contains(t, str, "Remove: 2 patterns")
+	assert.Contains(t, str, "Status overrides: 1")
+
+	tree := ops.ToTree().Tree().String()
+	assert.Contains(t, tree, "Remove Pattern: \"X-Internal-*\"")
+	assert.Contains(t, tree, "Status 5xx:")
+	assert.Contains(t, tree, "Set: \"Cache-Control: no-store\"")
+}
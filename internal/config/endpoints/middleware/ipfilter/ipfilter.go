@@ -0,0 +1,74 @@
+package ipfilter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+const IPFilterType = "ip_filter"
+
+var (
+	// ErrNoRules indicates that neither allow nor deny rules were configured
+	ErrNoRules = errors.New("at least one allow or deny rule is required")
+
+	// ErrInvalidRule indicates a rule that isn't a CIDR prefix or an IP address
+	ErrInvalidRule = validation.ErrInvalidPrefix
+)
+
+// IPFilter represents an IP filter middleware configuration
+type IPFilter struct {
+	// Allow are the networks allowed to reach the endpoint, all when empty
+	Allow []string `json:"allow" toml:"allow" env_interpolation:"no"`
+
+	// Deny are the networks blocked from the endpoint, checked before Allow
+	Deny []string `json:"deny" toml:"deny" env_interpolation:"no"`
+
+	// TrustForwardedFor takes the client IP from X-Forwarded-For or
+	// X-Real-IP instead of the connection's remote address, for connections
+	// from the listener's trusted proxies
+	TrustForwardedFor bool `json:"trustForwardedFor" toml:"trust_forwarded_for"`
+}
+
+// Type returns the middleware type
+func (f *IPFilter) Type() string {
+	return IPFilterType
+}
+
+// Validate validates the IP filter configuration
+func (f *IPFilter) Validate() error {
+	var errs []error
+
+	if len(f.Allow) == 0 && len(f.Deny) == 0 {
+		errs = append(errs, ErrNoRules)
+	}
+	if _, err := validation.ParsePrefixes(f.Allow); err != nil {
+		errs = append(errs, fmt.Errorf("allow: %w", err))
+	}
+	if _, err := validation.ParsePrefixes(f.Deny); err != nil {
+		errs = append(errs, fmt.Errorf("deny: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the IP filter configuration
+func (f *IPFilter) String() string {
+	return fmt.Sprintf("IP filter (%d allow, %d deny)", len(f.Allow), len(f.Deny))
+}
+
+// ToTree returns a tree representation of the IP filter configuration
+func (f *IPFilter) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	if len(f.Allow) > 0 {
+		tree.AddChild(fmt.Sprintf("Allow: %s", strings.Join(f.Allow, ", ")))
+	}
+	if len(f.Deny) > 0 {
+		tree.AddChild(fmt.Sprintf("Deny: %s", strings.Join(f.Deny, ", ")))
+	}
+	tree.AddChild(fmt.Sprintf("Trust X-Forwarded-For: %t", f.TrustForwardedFor))
+	return tree
+}
@@ -0,0 +1,66 @@
+package ipfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFilter_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "ip_filter", (&IPFilter{}).Type())
+}
+
+func TestIPFilter_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *IPFilter
+		wantErr error
+	}{
+		{
+			name:   "allow only",
+			config: &IPFilter{Allow: []string{"10.0.0.0/8", "127.0.0.1"}},
+		},
+		{
+			name:   "deny only",
+			config: &IPFilter{Deny: []string{"203.0.113.0/24"}},
+		},
+		{
+			name:    "no rules",
+			config:  &IPFilter{TrustForwardedFor: true},
+			wantErr: ErrNoRules,
+		},
+		{
+			name:    "malformed allow rule",
+			config:  &IPFilter{Allow: []string{"10.0.0.0/40"}},
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name:    "malformed deny rule",
+			config:  &IPFilter{Allow: []string{"10.0.0.0/8"}, Deny: []string{"not-an-ip"}},
+			wantErr: ErrInvalidRule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIPFilter_String(t *testing.T) {
+	t.Parallel()
+
+	f := &IPFilter{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.1", "10.0.0.2"}}
+	assert.Equal(t, "IP filter (1 allow, 2 deny)", f.String())
+	assert.Contains(t, f.ToTree().Tree().String(), "10.0.0.0/8")
+}
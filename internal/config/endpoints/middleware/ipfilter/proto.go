@@ -0,0 +1,32 @@
+package ipfilter
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+)
+
+// ToProto converts IPFilter to protobuf format
+func (f *IPFilter) ToProto() any {
+	config := &pb.IPFilterConfig{
+		Allow: f.Allow,
+		Deny:  f.Deny,
+	}
+	if f.TrustForwardedFor {
+		config.TrustForwardedFor = &f.TrustForwardedFor
+	}
+	return config
+}
+
+// FromProto converts protobuf IPFilterConfig to domain IPFilter
+func FromProto(pbConfig *pb.IPFilterConfig) (*IPFilter, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil ip filter config")
+	}
+
+	return &IPFilter{
+		Allow:             pbConfig.GetAllow(),
+		Deny:              pbConfig.GetDeny(),
+		TrustForwardedFor: pbConfig.GetTrustForwardedFor(),
+	}, nil
+}
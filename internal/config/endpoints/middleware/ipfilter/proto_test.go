@@ -0,0 +1,45 @@
+package ipfilter
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.IPFilterConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &IPFilter{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &IPFilter{
+		Allow:             []string{"10.0.0.0/8"},
+		Deny:              []string{"10.0.0.1"},
+		TrustForwardedFor: true,
+	}
+
+	pbConfig, ok := original.ToProto().(*pb.IPFilterConfig)
+	require.True(t, ok)
+
+	converted, err := FromProto(pbConfig)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+
+	empty, ok := (&IPFilter{Allow: []string{"10.0.0.0/8"}}).ToProto().(*pb.IPFilterConfig)
+	require.True(t, ok)
+	assert.Nil(t, empty.TrustForwardedFor)
+}
@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"github.com/atlanticdynamic/firelynx/internal/logging/otlp"
 	"github.com/atlanticdynamic/firelynx/internal/logging/writers"
 )
 
@@ -31,6 +34,116 @@ type ConsoleLogger struct {
 	// Method filtering
 	IncludeOnlyMethods []string `json:"includeOnlyMethods" toml:"include_only_methods" env_interpolation:"yes"`
 	ExcludeMethods     []string `json:"excludeMethods"     toml:"exclude_methods"      env_interpolation:"yes"`
+
+	// Sensitive values masked before logging
+	Redaction Redaction `json:"redaction" toml:"redaction"`
+
+	// Collector settings, used when Output is "otlp"
+	OTLP OTLPExport `json:"otlp" toml:"otlp" env_interpolation:"yes"`
+
+	// Collector settings, used when Output is "syslog"
+	Syslog SyslogOutput `json:"syslog" toml:"syslog" env_interpolation:"yes"`
+
+	// Requests taking longer than SlowThreshold are logged at warn level
+	// with a slow attribute, disabled when zero
+	SlowThreshold time.Duration `json:"slowThreshold" toml:"slow_threshold"`
+}
+
+// OTLPExport configures the export of each request log record to an
+// OpenTelemetry collector, with the same attributes written to other outputs
+type OTLPExport struct {
+	// Collector address as host:port, or the full URL logs are exported to
+	Endpoint string `json:"endpoint" toml:"endpoint" env_interpolation:"yes"`
+
+	// Transport used to reach the collector, gRPC when unspecified
+	Protocol OTLPProtocol `json:"protocol" toml:"protocol"`
+
+	// Connect without TLS
+	Insecure bool `json:"insecure" toml:"insecure"`
+
+	// Headers sent with each export request
+	Headers map[string]string `json:"headers" toml:"headers" env_interpolation:"yes"`
+
+	// Batching: the longest a record waits before export, and the most
+	// records in one export request; zero uses the exporter defaults
+	ExportInterval time.Duration `json:"exportInterval" toml:"export_interval"`
+	MaxBatchSize   int32         `json:"maxBatchSize"   toml:"max_batch_size"`
+}
+
+// Config returns the settings used to create the OTLP export pipeline
+func (e OTLPExport) Config() otlp.Config {
+	cfg := otlp.Config{
+		Endpoint:       e.Endpoint,
+		Insecure:       e.Insecure,
+		Headers:        e.Headers,
+		ExportInterval: e.ExportInterval,
+		MaxBatchSize:   int(e.MaxBatchSize),
+	}
+	switch e.Protocol {
+	case OTLPProtocolGRPC:
+		cfg.Protocol = otlp.ProtocolGRPC
+	case OTLPProtocolHTTP:
+		cfg.Protocol = otlp.ProtocolHTTP
+	}
+	return cfg
+}
+
+// SyslogOutput configures the delivery of each request log record to a
+// syslog collector, as an RFC 5424 message whose body is the record in the
+// configured format
+type SyslogOutput struct {
+	// Network used to reach the collector, udp when empty
+	Network string `json:"network" toml:"network" env_interpolation:"yes"`
+
+	// Collector address as host:port, or a socket path
+	Address string `json:"address" toml:"address" env_interpolation:"yes"`
+
+	// Facility name, user when empty
+	Facility string `json:"facility" toml:"facility" env_interpolation:"yes"`
+
+	// APP-NAME of each message, the middleware ID when empty
+	Tag string `json:"tag" toml:"tag" env_interpolation:"yes"`
+}
+
+// Config returns the settings used to create the syslog writer, with
+// defaultTag used when no tag is configured
+func (s SyslogOutput) Config(defaultTag string) writers.SyslogConfig {
+	cfg := writers.SyslogConfig{
+		Network:  s.Network,
+		Address:  s.Address,
+		Facility: s.Facility,
+		Tag:      s.Tag,
+	}
+	if cfg.Network == "" {
+		cfg.Network = writers.SyslogNetworkUDP
+	}
+	if cfg.Facility == "" {
+		cfg.Facility = "user"
+	}
+	if cfg.Tag == "" {
+		cfg.Tag = defaultTag
+	}
+	return cfg
+}
+
+// Redaction configures the sensitive values masked before a request or
+// response is logged. The request and response themselves are not modified.
+type Redaction struct {
+	// Header names whose values are masked, matched case-insensitively
+	Headers []string `json:"headers" toml:"headers" env_interpolation:"yes"`
+
+	// JSON field paths in request and response bodies whose values are
+	// masked, as dot-separated object keys such as "password" or "user.token"
+	BodyFields []string `json:"bodyFields" toml:"body_fields" env_interpolation:"yes"`
+}
+
+// BodyFieldPaths returns each body field path split into its keys
+func (r Redaction) BodyFieldPaths() [][]string {
+	paths := make([][]string, 0, len(r.BodyFields))
+	for _, field := range r.BodyFields {
+		paths = append(paths, strings.Split(field, "."))
+	}
+	return paths
 }
 
 // LogOptionsGeneral represents general logging configuration
@@ -79,6 +192,15 @@ const (
 	FormatJSON        Format = "json"
 )
 
+// OTLPProtocol represents the transport used to export logs over OTLP
+type OTLPProtocol string
+
+const (
+	OTLPProtocolUnspecified OTLPProtocol = "unspecified"
+	OTLPProtocolGRPC        OTLPProtocol = "grpc"
+	OTLPProtocolHTTP        OTLPProtocol = "http"
+)
+
 // Level represents logging level options
 type Level string
 
@@ -237,6 +359,20 @@ func (c *ConsoleLogger) Validate() error {
 		errs = append(errs, err)
 	}
 
+	// Validate the collector settings when exporting over OTLP
+	if writers.ParseWriterType(c.Output) == writers.WriterTypeOTLP {
+		if err := c.validateOTLP(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Validate the collector settings when writing to syslog
+	if writers.ParseWriterType(c.Output) == writers.WriterTypeSyslog {
+		if err := c.validateSyslog(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	// Validate max body size (if specified, should be non-negative)
 	if c.Fields.Request.MaxBodySize < 0 {
 		errs = append(errs, errors.New("request max body size cannot be negative"))
@@ -245,6 +381,22 @@ func (c *ConsoleLogger) Validate() error {
 		errs = append(errs, errors.New("response max body size cannot be negative"))
 	}
 
+	if c.SlowThreshold < 0 {
+		errs = append(errs, errors.New("slow threshold cannot be negative"))
+	}
+
+	// Validate redaction
+	for _, header := range c.Redaction.Headers {
+		if strings.TrimSpace(header) == "" {
+			errs = append(errs, errors.New("redacted header name cannot be empty"))
+		}
+	}
+	for _, field := range c.Redaction.BodyFields {
+		if slices.Contains(strings.Split(field, "."), "") {
+			errs = append(errs, fmt.Errorf("invalid redacted body field %q: keys cannot be empty", field))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -265,6 +417,9 @@ func (c *ConsoleLogger) String() string {
 	if len(c.ExcludePaths) > 0 {
 		parts = append(parts, fmt.Sprintf("Exclude paths: %v", c.ExcludePaths))
 	}
+	if c.SlowThreshold > 0 {
+		parts = append(parts, fmt.Sprintf("Slow threshold: %v", c.SlowThreshold))
+	}
 
 	return strings.Join(parts, ", ")
 }
@@ -277,6 +432,17 @@ func (c *ConsoleLogger) ToTree() *fancy.ComponentTree {
 	tree.AddChild(fmt.Sprintf("Format: %s", c.Options.Format))
 	tree.AddChild(fmt.Sprintf("Level: %s", c.Options.Level))
 	tree.AddChild(fmt.Sprintf("Output: %s", c.Output))
+	if writers.ParseWriterType(c.Output) == writers.WriterTypeOTLP {
+		protocol := c.OTLP.Protocol
+		if protocol == "" || protocol == OTLPProtocolUnspecified {
+			protocol = OTLPProtocolGRPC
+		}
+		tree.AddChild(fmt.Sprintf("OTLP endpoint: %s (%s)", c.OTLP.Endpoint, protocol))
+	}
+	if writers.ParseWriterType(c.Output) == writers.WriterTypeSyslog {
+		cfg := c.Syslog.Config("")
+		tree.AddChild(fmt.Sprintf("Syslog address: %s://%s (%s)", cfg.Network, cfg.Address, cfg.Facility))
+	}
 
 	if c.Preset != PresetUnspecified {
 		tree.AddChild(fmt.Sprintf("Preset: %s", c.Preset))
@@ -304,10 +470,43 @@ func (c *ConsoleLogger) ToTree() *fancy.ComponentTree {
 	if len(c.ExcludePaths) > 0 {
 		tree.AddChild(fmt.Sprintf("Exclude paths: %v", c.ExcludePaths))
 	}
+	if c.SlowThreshold > 0 {
+		tree.AddChild(fmt.Sprintf("Slow threshold: %v", c.SlowThreshold))
+	}
+
+	// Redaction
+	if len(c.Redaction.Headers) > 0 {
+		tree.AddChild(fmt.Sprintf("Redacted headers: %v", c.Redaction.Headers))
+	}
+	if len(c.Redaction.BodyFields) > 0 {
+		tree.AddChild(fmt.Sprintf("Redacted body fields: %v", c.Redaction.BodyFields))
+	}
 
 	return tree
 }
 
+// validateOTLP checks the collector settings used by the "otlp" output
+func (c *ConsoleLogger) validateOTLP() error {
+	var errs []error
+	switch c.OTLP.Protocol {
+	case "", OTLPProtocolUnspecified, OTLPProtocolGRPC, OTLPProtocolHTTP:
+		// Valid protocols
+	default:
+		errs = append(errs, fmt.Errorf("invalid OTLP protocol: %s", c.OTLP.Protocol))
+	}
+	if err := c.OTLP.Config().Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// validateSyslog checks the collector settings used by the "syslog" output.
+// The collector address is checked by validateOutputWritability.
+func (c *ConsoleLogger) validateSyslog() error {
+	// An empty tag is replaced by the middleware ID, which is always valid
+	return c.Syslog.Config(ConsoleLoggerType).Validate()
+}
+
 // validateOutputWritability checks if the output destination is writable
 func (c *ConsoleLogger) validateOutputWritability() error {
 	// Expand environment variables in the output path
@@ -316,8 +515,11 @@ func (c *ConsoleLogger) validateOutputWritability() error {
 		return fmt.Errorf("environment variable expansion failed: %w", err)
 	}
 
-	// Check if it's a file path that needs validation
+	// Check if it's a file path or syslog collector that needs validation
 	writerType := writers.ParseWriterType(expandedOutput)
+	if writerType == writers.WriterTypeSyslog {
+		return c.validateSyslogAddress()
+	}
 	if writerType != writers.WriterTypeFile {
 		return nil // stdout/stderr don't need validation
 	}
@@ -337,3 +539,15 @@ func (c *ConsoleLogger) validateOutputWritability() error {
 
 	return nil
 }
+
+// validateSyslogAddress checks that the syslog collector can be reached
+func (c *ConsoleLogger) validateSyslogAddress() error {
+	cfg := c.Syslog.Config(ConsoleLoggerType)
+	if cfg.Validate() != nil {
+		return nil // reported by validateSyslog
+	}
+	if err := writers.CheckSyslogAddress(cfg); err != nil {
+		return fmt.Errorf("syslog address not reachable: %w", err)
+	}
+	return nil
+}
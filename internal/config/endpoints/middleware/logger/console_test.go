@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -89,6 +90,110 @@ func TestConsoleLogger_Validate(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Valid redaction",
+			logger: &ConsoleLogger{
+				Redaction: Redaction{
+					Headers:    []string{"Authorization"},
+					BodyFields: []string{"password", "user.token"},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "Empty redacted header",
+			logger: &ConsoleLogger{
+				Redaction: Redaction{Headers: []string{" "}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Empty key in redacted body field",
+			logger: &ConsoleLogger{
+				Redaction: Redaction{BodyFields: []string{"user..token"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid OTLP export",
+			logger: &ConsoleLogger{
+				Output: "otlp",
+				OTLP: OTLPExport{
+					Endpoint: "localhost:4317",
+					Protocol: OTLPProtocolGRPC,
+					Insecure: true,
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "OTLP output without endpoint",
+			logger: &ConsoleLogger{
+				Output: "otlp",
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid OTLP protocol",
+			logger: &ConsoleLogger{
+				Output: "otlp",
+				OTLP:   OTLPExport{Endpoint: "localhost:4317", Protocol: "udp"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Negative OTLP batch size",
+			logger: &ConsoleLogger{
+				Output: "otlp",
+				OTLP:   OTLPExport{Endpoint: "localhost:4317", MaxBatchSize: -1},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid syslog output",
+			logger: &ConsoleLogger{
+				Output: "syslog",
+				Syslog: SyslogOutput{Address: "127.0.0.1:514", Facility: "local0", Tag: "api"},
+			},
+			expectError: false,
+		},
+		{
+			name: "Syslog output without address",
+			logger: &ConsoleLogger{
+				Output: "syslog",
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid syslog facility",
+			logger: &ConsoleLogger{
+				Output: "syslog",
+				Syslog: SyslogOutput{Address: "127.0.0.1:514", Facility: "local9"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Unreachable syslog address",
+			logger: &ConsoleLogger{
+				Output: "syslog",
+				Syslog: SyslogOutput{Network: "unix", Address: "/nonexistent/syslog.sock"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Valid slow threshold",
+			logger: &ConsoleLogger{
+				SlowThreshold: time.Second,
+			},
+			expectError: false,
+		},
+		{
+			name: "Negative slow threshold",
+			logger: &ConsoleLogger{
+				SlowThreshold: -time.Second,
+			},
+			expectError: true,
+		},
 		{
 			name: "Empty output gets default",
 			logger: &ConsoleLogger{
@@ -5,6 +5,7 @@ import (
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // ToProto converts ConsoleLogger to protobuf format
@@ -51,6 +52,33 @@ func (c *ConsoleLogger) ToProto() any {
 		config.ExcludeMethods = c.ExcludeMethods
 	}
 
+	// Add redaction
+	if len(c.Redaction.Headers) > 0 || len(c.Redaction.BodyFields) > 0 {
+		config.Redaction = &pb.LogRedaction{
+			Headers:    c.Redaction.Headers,
+			BodyFields: c.Redaction.BodyFields,
+		}
+	}
+
+	// Add OTLP export settings
+	if c.OTLP.Endpoint != "" {
+		config.Otlp = otlpExportToProto(c.OTLP)
+	}
+
+	// Add syslog settings
+	if c.Syslog != (SyslogOutput{}) {
+		config.Syslog = &pb.SyslogOutputConfig{
+			Network:  &c.Syslog.Network,
+			Address:  &c.Syslog.Address,
+			Facility: &c.Syslog.Facility,
+			Tag:      &c.Syslog.Tag,
+		}
+	}
+
+	if c.SlowThreshold != 0 {
+		config.SlowThreshold = durationpb.New(c.SlowThreshold)
+	}
+
 	return config
 }
 
@@ -117,6 +145,35 @@ func FromProto(pbConfig *pb.ConsoleLoggerConfig) (*ConsoleLogger, error) {
 		config.ExcludeMethods = pbConfig.ExcludeMethods
 	}
 
+	// Convert redaction
+	if pbConfig.Redaction != nil {
+		if len(pbConfig.Redaction.Headers) > 0 {
+			config.Redaction.Headers = pbConfig.Redaction.Headers
+		}
+		if len(pbConfig.Redaction.BodyFields) > 0 {
+			config.Redaction.BodyFields = pbConfig.Redaction.BodyFields
+		}
+	}
+
+	// Convert OTLP export settings
+	if pbConfig.Otlp != nil {
+		config.OTLP = otlpExportFromProto(pbConfig.Otlp)
+	}
+
+	// Convert syslog settings
+	if pbConfig.Syslog != nil {
+		config.Syslog = SyslogOutput{
+			Network:  pbConfig.Syslog.GetNetwork(),
+			Address:  pbConfig.Syslog.GetAddress(),
+			Facility: pbConfig.Syslog.GetFacility(),
+			Tag:      pbConfig.Syslog.GetTag(),
+		}
+	}
+
+	if pbConfig.SlowThreshold != nil {
+		config.SlowThreshold = pbConfig.SlowThreshold.AsDuration()
+	}
+
 	return config, nil
 }
 
@@ -210,6 +267,62 @@ func directionConfigFromProto(pbConfig *pb.LogOptionsHTTP_DirectionConfig) Direc
 	return config
 }
 
+// Helper functions for OTLP export conversion
+func otlpExportToProto(export OTLPExport) *pb.OTLPExportConfig {
+	protocol := otlpProtocolToProto(export.Protocol)
+	config := &pb.OTLPExportConfig{
+		Endpoint:     &export.Endpoint,
+		Protocol:     &protocol,
+		Insecure:     &export.Insecure,
+		MaxBatchSize: &export.MaxBatchSize,
+	}
+	if len(export.Headers) > 0 {
+		config.Headers = export.Headers
+	}
+	if export.ExportInterval > 0 {
+		config.ExportInterval = durationpb.New(export.ExportInterval)
+	}
+	return config
+}
+
+func otlpExportFromProto(pbConfig *pb.OTLPExportConfig) OTLPExport {
+	export := OTLPExport{
+		Endpoint:     pbConfig.GetEndpoint(),
+		Protocol:     otlpProtocolFromProto(pbConfig.GetProtocol()),
+		Insecure:     pbConfig.GetInsecure(),
+		MaxBatchSize: pbConfig.GetMaxBatchSize(),
+	}
+	if len(pbConfig.Headers) > 0 {
+		export.Headers = pbConfig.Headers
+	}
+	if pbConfig.ExportInterval != nil {
+		export.ExportInterval = pbConfig.ExportInterval.AsDuration()
+	}
+	return export
+}
+
+func otlpProtocolToProto(protocol OTLPProtocol) pb.OTLPExportConfig_Protocol {
+	switch protocol {
+	case OTLPProtocolGRPC:
+		return pb.OTLPExportConfig_PROTOCOL_GRPC
+	case OTLPProtocolHTTP:
+		return pb.OTLPExportConfig_PROTOCOL_HTTP
+	default:
+		return pb.OTLPExportConfig_PROTOCOL_UNSPECIFIED
+	}
+}
+
+func otlpProtocolFromProto(pbProtocol pb.OTLPExportConfig_Protocol) OTLPProtocol {
+	switch pbProtocol {
+	case pb.OTLPExportConfig_PROTOCOL_GRPC:
+		return OTLPProtocolGRPC
+	case pb.OTLPExportConfig_PROTOCOL_HTTP:
+		return OTLPProtocolHTTP
+	default:
+		return OTLPProtocolUnspecified
+	}
+}
+
 // Helper functions for preset conversion
 func presetToProto(preset Preset) pb.ConsoleLoggerConfig_LogPreset {
 	switch preset {
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"testing"
+	"time"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
 	"github.com/stretchr/testify/assert"
@@ -293,6 +294,25 @@ func TestRoundTrip(t *testing.T) {
 			ExcludePaths:       []string{"/public"},
 			IncludeOnlyMethods: []string{"POST", "PUT"},
 			ExcludeMethods:     []string{"DELETE"},
+			Redaction: Redaction{
+				Headers:    []string{"Authorization"},
+				BodyFields: []string{"password", "user.token"},
+			},
+			OTLP: OTLPExport{
+				Endpoint:       "https://collector:4318/v1/logs",
+				Protocol:       OTLPProtocolHTTP,
+				Insecure:       true,
+				Headers:        map[string]string{"Authorization": "Bearer token"},
+				ExportInterval: 2 * time.Second,
+				MaxBatchSize:   100,
+			},
+			Syslog: SyslogOutput{
+				Network:  "tcp",
+				Address:  "syslog:601",
+				Facility: "local3",
+				Tag:      "api",
+			},
+			SlowThreshold: 500 * time.Millisecond,
 		}
 
 		// Convert to protobuf
@@ -311,6 +331,10 @@ func TestRoundTrip(t *testing.T) {
 		assert.Equal(t, original.ExcludePaths, restored.ExcludePaths)
 		assert.Equal(t, original.IncludeOnlyMethods, restored.IncludeOnlyMethods)
 		assert.Equal(t, original.ExcludeMethods, restored.ExcludeMethods)
+		assert.Equal(t, original.Redaction, restored.Redaction)
+		assert.Equal(t, original.OTLP, restored.OTLP)
+		assert.Equal(t, original.Syslog, restored.Syslog)
+		assert.Equal(t, original.SlowThreshold, restored.SlowThreshold)
 	})
 
 	t.Run("Default configuration round trip", func(t *testing.T) {
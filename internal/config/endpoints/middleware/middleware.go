@@ -63,15 +63,17 @@ func (m Middleware) Validate() error {
 
 	// Validate ID
 	if err := validation.ValidateID(m.ID, "middleware ID"); err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.ForField("id", err))
 	}
 
 	// Config validation
 	if m.Config == nil {
-		errs = append(errs, fmt.Errorf("%w: middleware '%s'", ErrMissingMiddlewareConfig, m.ID))
+		errs = append(errs, validation.ForField("config",
+			fmt.Errorf("%w: middleware '%s'", ErrMissingMiddlewareConfig, m.ID)))
 	} else {
 		if err := m.Config.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("config for middleware '%s': %w", m.ID, err))
+			errs = append(errs, validation.ForField("config",
+				fmt.Errorf("config for middleware '%s': %w", m.ID, err)))
 		}
 	}
 
@@ -126,42 +128,22 @@ func (mc MiddlewareCollection) FindByID(id string) *Middleware {
 	return nil
 }
 
-// Validate checks that middleware configurations are valid
+// Validate checks that middleware configurations are valid. Duplicate IDs are
+// reported by the config's validation, since middleware instances are shared
+// by ID across the whole config.
 func (mc MiddlewareCollection) Validate() error {
-	if len(mc) == 0 {
-		return nil // Empty middleware list is valid
-	}
-
 	var errs []error
-
-	// Create map of middleware IDs for reference validation
-	middlewareIDs := make(map[string]bool)
-
-	// First pass: Validate IDs and check for duplicates
-	for _, middleware := range mc {
-		if err := validation.ValidateID(middleware.ID, "middleware ID"); err != nil {
-			errs = append(errs, err)
-			continue
-		}
-
-		if middlewareIDs[middleware.ID] {
-			errs = append(errs, fmt.Errorf("%w: middleware ID '%s'", ErrDuplicateID, middleware.ID))
-			continue
-		}
-
-		middlewareIDs[middleware.ID] = true
-	}
-
-	// Second pass: Validate each middleware individually
 	for i, middleware := range mc {
-		// Skip middlewares with invalid IDs as those are already reported
 		if err := validation.ValidateID(middleware.ID, "middleware ID"); err != nil {
+			errs = append(errs, validation.ForComponent(validation.ComponentMiddleware, middleware.ID,
+				validation.ForField("id", err)))
 			continue
 		}
 
 		// Validate the middleware itself
 		if err := middleware.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("middleware at index %d: %w", i, err))
+			errs = append(errs, validation.ForComponent(validation.ComponentMiddleware, middleware.ID,
+				fmt.Errorf("middleware at index %d: %w", i, err)))
 		}
 	}
 
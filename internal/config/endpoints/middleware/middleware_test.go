@@ -93,6 +93,7 @@ func TestMiddlewareCollection_Validate(t *testing.T) {
 			expectError: false,
 		},
 		{
+			// Reported by the config's validation, which sees every collection
 			name: "Duplicate IDs",
 			collection: MiddlewareCollection{
 				{
@@ -104,7 +105,7 @@ func TestMiddlewareCollection_Validate(t *testing.T) {
 					Config: logger.NewConsoleLogger(),
 				},
 			},
-			expectError: true,
+			expectError: false,
 		},
 	}
 
@@ -4,8 +4,17 @@ import (
 	"fmt"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/auth"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/basicauth"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/bodybuffer"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/headers"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/ipfilter"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/signature"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/transform"
 )
 
 // ToProto converts a MiddlewareCollection to protobuf format
@@ -38,6 +47,51 @@ func (m Middleware) ToProto() *pb.Middleware {
 		pbMiddleware.Config = &pb.Middleware_Headers{
 			Headers: config.ToProto().(*pb.HeadersConfig),
 		}
+	case *auth.Auth:
+		pbMiddleware.Type = pb.Middleware_TYPE_AUTH.Enum()
+		pbMiddleware.Config = &pb.Middleware_Auth{
+			Auth: config.ToProto().(*pb.AuthConfig),
+		}
+	case *basicauth.BasicAuth:
+		pbMiddleware.Type = pb.Middleware_TYPE_BASIC_AUTH.Enum()
+		pbMiddleware.Config = &pb.Middleware_BasicAuth{
+			BasicAuth: config.ToProto().(*pb.BasicAuthConfig),
+		}
+	case *circuitbreaker.CircuitBreaker:
+		pbMiddleware.Type = pb.Middleware_TYPE_CIRCUIT_BREAKER.Enum()
+		pbMiddleware.Config = &pb.Middleware_CircuitBreaker{
+			CircuitBreaker: config.ToProto().(*pb.CircuitBreakerConfig),
+		}
+	case *retry.Retry:
+		pbMiddleware.Type = pb.Middleware_TYPE_RETRY.Enum()
+		pbMiddleware.Config = &pb.Middleware_Retry{
+			Retry: config.ToProto().(*pb.RetryConfig),
+		}
+	case *bodybuffer.BodyBuffer:
+		pbMiddleware.Type = pb.Middleware_TYPE_BODY_BUFFER.Enum()
+		pbMiddleware.Config = &pb.Middleware_BodyBuffer{
+			BodyBuffer: config.ToProto().(*pb.BodyBufferConfig),
+		}
+	case *signature.Signature:
+		pbMiddleware.Type = pb.Middleware_TYPE_SIGNATURE.Enum()
+		pbMiddleware.Config = &pb.Middleware_Signature{
+			Signature: config.ToProto().(*pb.SignatureConfig),
+		}
+	case *ipfilter.IPFilter:
+		pbMiddleware.Type = pb.Middleware_TYPE_IP_FILTER.Enum()
+		pbMiddleware.Config = &pb.Middleware_IpFilter{
+			IpFilter: config.ToProto().(*pb.IPFilterConfig),
+		}
+	case *cache.Cache:
+		pbMiddleware.Type = pb.Middleware_TYPE_CACHE.Enum()
+		pbMiddleware.Config = &pb.Middleware_Cache{
+			Cache: config.ToProto().(*pb.CacheConfig),
+		}
+	case *transform.Transform:
+		pbMiddleware.Type = pb.Middleware_TYPE_TRANSFORM.Enum()
+		pbMiddleware.Config = &pb.Middleware_Transform{
+			Transform: config.ToProto().(*pb.TransformConfig),
+		}
 	default:
 		// Unknown middleware type - this should be caught during validation
 		pbMiddleware.Type = pb.Middleware_TYPE_UNSPECIFIED.Enum()
@@ -95,6 +149,96 @@ func middlewareFromProto(pbMiddleware *pb.Middleware) (Middleware, error) {
 		} else {
 			return Middleware{}, fmt.Errorf("headers middleware missing config")
 		}
+	case pb.Middleware_TYPE_AUTH:
+		if authConfig := pbMiddleware.GetAuth(); authConfig != nil {
+			config, err := auth.FromProto(authConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("auth config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("auth middleware missing config")
+		}
+	case pb.Middleware_TYPE_BASIC_AUTH:
+		if basicAuthConfig := pbMiddleware.GetBasicAuth(); basicAuthConfig != nil {
+			config, err := basicauth.FromProto(basicAuthConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("basic auth config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("basic auth middleware missing config")
+		}
+	case pb.Middleware_TYPE_CIRCUIT_BREAKER:
+		if circuitBreakerConfig := pbMiddleware.GetCircuitBreaker(); circuitBreakerConfig != nil {
+			config, err := circuitbreaker.FromProto(circuitBreakerConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("circuit breaker config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("circuit breaker middleware missing config")
+		}
+	case pb.Middleware_TYPE_RETRY:
+		if retryConfig := pbMiddleware.GetRetry(); retryConfig != nil {
+			config, err := retry.FromProto(retryConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("retry config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("retry middleware missing config")
+		}
+	case pb.Middleware_TYPE_BODY_BUFFER:
+		if bodyBufferConfig := pbMiddleware.GetBodyBuffer(); bodyBufferConfig != nil {
+			config, err := bodybuffer.FromProto(bodyBufferConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("body buffer config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("body buffer middleware missing config")
+		}
+	case pb.Middleware_TYPE_SIGNATURE:
+		if signatureConfig := pbMiddleware.GetSignature(); signatureConfig != nil {
+			config, err := signature.FromProto(signatureConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("signature config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("signature middleware missing config")
+		}
+	case pb.Middleware_TYPE_IP_FILTER:
+		if ipFilterConfig := pbMiddleware.GetIpFilter(); ipFilterConfig != nil {
+			config, err := ipfilter.FromProto(ipFilterConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("ip filter config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("ip filter middleware missing config")
+		}
+	case pb.Middleware_TYPE_CACHE:
+		if cacheConfig := pbMiddleware.GetCache(); cacheConfig != nil {
+			config, err := cache.FromProto(cacheConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("cache config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("cache middleware missing config")
+		}
+	case pb.Middleware_TYPE_TRANSFORM:
+		if transformConfig := pbMiddleware.GetTransform(); transformConfig != nil {
+			config, err := transform.FromProto(transformConfig)
+			if err != nil {
+				return Middleware{}, fmt.Errorf("transform config: %w", err)
+			}
+			middleware.Config = config
+		} else {
+			return Middleware{}, fmt.Errorf("transform middleware missing config")
+		}
 	case pb.Middleware_TYPE_UNSPECIFIED:
 		return Middleware{}, fmt.Errorf("middleware type unspecified")
 	default:
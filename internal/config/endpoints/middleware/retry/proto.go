@@ -0,0 +1,90 @@
+package retry
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ToProto converts Retry to protobuf format
+func (r *Retry) ToProto() any {
+	config := &pb.RetryConfig{
+		Methods:       r.Methods,
+		RetryOnErrors: r.RetryOnErrors,
+	}
+	if r.MaxAttempts != 0 {
+		maxAttempts := int32(r.MaxAttempts)
+		config.MaxAttempts = &maxAttempts
+	}
+	if r.Backoff != BackoffUnspecified {
+		config.Backoff = backoffToProto(r.Backoff).Enum()
+	}
+	if r.InitialInterval != 0 {
+		config.InitialInterval = durationpb.New(r.InitialInterval)
+	}
+	if r.MaxInterval != 0 {
+		config.MaxInterval = durationpb.New(r.MaxInterval)
+	}
+	if r.Jitter {
+		config.Jitter = &r.Jitter
+	}
+	for _, code := range r.RetryOnStatus {
+		config.RetryOnStatus = append(config.RetryOnStatus, int32(code))
+	}
+	if r.MaxBodySize != 0 {
+		config.MaxBodySize = &r.MaxBodySize
+	}
+	return config
+}
+
+// FromProto converts protobuf RetryConfig to domain Retry
+func FromProto(pbConfig *pb.RetryConfig) (*Retry, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil retry config")
+	}
+
+	config := &Retry{
+		MaxAttempts:   int(pbConfig.GetMaxAttempts()),
+		Methods:       pbConfig.GetMethods(),
+		Backoff:       backoffFromProto(pbConfig.GetBackoff()),
+		Jitter:        pbConfig.GetJitter(),
+		RetryOnErrors: pbConfig.GetRetryOnErrors(),
+		MaxBodySize:   pbConfig.GetMaxBodySize(),
+	}
+	if pbConfig.InitialInterval != nil {
+		config.InitialInterval = pbConfig.InitialInterval.AsDuration()
+	}
+	if pbConfig.MaxInterval != nil {
+		config.MaxInterval = pbConfig.MaxInterval.AsDuration()
+	}
+	for _, code := range pbConfig.GetRetryOnStatus() {
+		config.RetryOnStatus = append(config.RetryOnStatus, int(code))
+	}
+
+	return config, nil
+}
+
+// backoffToProto converts a domain Backoff to its protobuf enum
+func backoffToProto(backoff Backoff) pb.RetryConfig_Backoff {
+	switch backoff {
+	case BackoffFixed:
+		return pb.RetryConfig_BACKOFF_FIXED
+	case BackoffExponential:
+		return pb.RetryConfig_BACKOFF_EXPONENTIAL
+	default:
+		return pb.RetryConfig_BACKOFF_UNSPECIFIED
+	}
+}
+
+// backoffFromProto converts a protobuf Backoff enum to a domain Backoff
+func backoffFromProto(backoff pb.RetryConfig_Backoff) Backoff {
+	switch backoff {
+	case pb.RetryConfig_BACKOFF_FIXED:
+		return BackoffFixed
+	case pb.RetryConfig_BACKOFF_EXPONENTIAL:
+		return BackoffExponential
+	default:
+		return BackoffUnspecified
+	}
+}
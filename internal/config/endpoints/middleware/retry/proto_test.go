@@ -0,0 +1,64 @@
+package retry
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.RetryConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &Retry{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, backoff := range []Backoff{BackoffFixed, BackoffExponential} {
+		t.Run(string(backoff), func(t *testing.T) {
+			original := &Retry{
+				MaxAttempts:     4,
+				Methods:         []string{"GET", "PUT"},
+				Backoff:         backoff,
+				InitialInterval: 50 * time.Millisecond,
+				MaxInterval:     time.Second,
+				Jitter:          true,
+				RetryOnStatus:   []int{429, 503},
+				RetryOnErrors:   []string{ErrorTimeout},
+				MaxBodySize:     4096,
+			}
+
+			pbConfig, ok := original.ToProto().(*pb.RetryConfig)
+			require.True(t, ok)
+			assert.Equal(t, int32(4), pbConfig.GetMaxAttempts())
+			assert.Equal(t, []int32{429, 503}, pbConfig.GetRetryOnStatus())
+			assert.Equal(t, time.Second, pbConfig.GetMaxInterval().AsDuration())
+
+			converted, err := FromProto(pbConfig)
+			require.NoError(t, err)
+			assert.Equal(t, original, converted)
+		})
+	}
+
+	t.Run("unset fields stay unset", func(t *testing.T) {
+		empty, ok := (&Retry{}).ToProto().(*pb.RetryConfig)
+		require.True(t, ok)
+		assert.Nil(t, empty.MaxAttempts)
+		assert.Nil(t, empty.Backoff)
+		assert.Nil(t, empty.InitialInterval)
+		assert.Nil(t, empty.Jitter)
+	})
+}
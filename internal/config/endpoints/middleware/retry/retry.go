@@ -0,0 +1,272 @@
+package retry
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+const RetryType = "retry"
+
+// Backoff is how the delay between attempts grows
+type Backoff string
+
+const (
+	BackoffUnspecified Backoff = ""
+	BackoffFixed       Backoff = "fixed"
+	BackoffExponential Backoff = "exponential"
+)
+
+// App errors that can trigger a retry
+const (
+	// ErrorTimeout matches apps that ran out of time
+	ErrorTimeout = "timeout"
+	// ErrorUnavailable matches apps that reported themselves unavailable
+	ErrorUnavailable = "unavailable"
+	// ErrorAny matches every app error
+	ErrorAny = "any"
+)
+
+// Defaults for settings that aren't configured
+const (
+	DefaultMaxAttempts     = 3
+	DefaultBackoff         = BackoffExponential
+	DefaultInitialInterval = 100 * time.Millisecond
+	DefaultMaxInterval     = 2 * time.Second
+	DefaultMaxBodySize     = 1 << 20
+)
+
+// DefaultMethods returns the idempotent methods retried by default
+func DefaultMethods() []string {
+	return []string{
+		http.MethodGet,
+		http.MethodHead,
+		http.MethodOptions,
+		http.MethodPut,
+		http.MethodDelete,
+	}
+}
+
+// DefaultRetryOnStatus returns the status codes retried by default
+func DefaultRetryOnStatus() []int {
+	return []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+}
+
+// knownMethods lists the request methods that can be retried
+var knownMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// knownErrors lists the app errors that can trigger a retry
+var knownErrors = []string{ErrorTimeout, ErrorUnavailable, ErrorAny}
+
+var (
+	// ErrInvalidMaxAttempts indicates a negative attempt count
+	ErrInvalidMaxAttempts = errors.New("max_attempts cannot be negative")
+
+	// ErrUnknownMethod indicates a method that isn't a known HTTP method
+	ErrUnknownMethod = errors.New("unknown method")
+
+	// ErrUnknownBackoff indicates an unsupported backoff
+	ErrUnknownBackoff = errors.New("unknown backoff")
+
+	// ErrNegativeDuration indicates a negative interval
+	ErrNegativeDuration = errors.New("duration cannot be negative")
+
+	// ErrInvalidInterval indicates an initial interval above the max interval
+	ErrInvalidInterval = errors.New("initial_interval cannot exceed max_interval")
+
+	// ErrInvalidStatusCode indicates a status code that isn't a 4xx or 5xx
+	ErrInvalidStatusCode = errors.New("retry_on_status codes must be between 400 and 599")
+
+	// ErrUnknownError indicates an unsupported app error name
+	ErrUnknownError = errors.New("unknown retry_on_errors value")
+
+	// ErrNegativeMaxBodySize indicates a negative body size limit
+	ErrNegativeMaxBodySize = errors.New("max_body_size cannot be negative")
+)
+
+// Retry represents a retry middleware configuration. Zero values use the
+// defaults, see the getters.
+type Retry struct {
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int `json:"maxAttempts" toml:"max_attempts"`
+
+	// Methods are the request methods that are retried
+	Methods []string `json:"methods" toml:"methods" env_interpolation:"no"`
+
+	// Backoff is how the delay between attempts grows
+	Backoff Backoff `json:"backoff" toml:"backoff"`
+
+	// InitialInterval is the delay before the first retry
+	InitialInterval time.Duration `json:"initialInterval" toml:"initial_interval"`
+
+	// MaxInterval caps the delay between attempts
+	MaxInterval time.Duration `json:"maxInterval" toml:"max_interval"`
+
+	// Jitter randomizes each delay between zero and its full length
+	Jitter bool `json:"jitter" toml:"jitter"`
+
+	// RetryOnStatus are the response status codes that are retried
+	RetryOnStatus []int `json:"retryOnStatus" toml:"retry_on_status"`
+
+	// RetryOnErrors are the app errors that are retried, see ErrorTimeout,
+	// ErrorUnavailable and ErrorAny
+	RetryOnErrors []string `json:"retryOnErrors" toml:"retry_on_errors" env_interpolation:"no"`
+
+	// MaxBodySize is the largest request body buffered for replay
+	MaxBodySize int64 `json:"maxBodySize" toml:"max_body_size"`
+}
+
+// Type returns the middleware type
+func (r *Retry) Type() string {
+	return RetryType
+}
+
+// GetMaxAttempts returns the attempt count, or DefaultMaxAttempts
+func (r *Retry) GetMaxAttempts() int {
+	if r.MaxAttempts == 0 {
+		return DefaultMaxAttempts
+	}
+	return r.MaxAttempts
+}
+
+// GetMethods returns the upper-cased retried methods, or DefaultMethods
+func (r *Retry) GetMethods() []string {
+	if len(r.Methods) == 0 {
+		return DefaultMethods()
+	}
+	methods := make([]string, 0, len(r.Methods))
+	for _, method := range r.Methods {
+		methods = append(methods, strings.ToUpper(method))
+	}
+	return methods
+}
+
+// GetBackoff returns the backoff, or DefaultBackoff
+func (r *Retry) GetBackoff() Backoff {
+	if r.Backoff == BackoffUnspecified {
+		return DefaultBackoff
+	}
+	return r.Backoff
+}
+
+// GetInitialInterval returns the first retry delay, or DefaultInitialInterval
+func (r *Retry) GetInitialInterval() time.Duration {
+	if r.InitialInterval == 0 {
+		return DefaultInitialInterval
+	}
+	return r.InitialInterval
+}
+
+// GetMaxInterval returns the longest delay, or DefaultMaxInterval. It is never
+// shorter than the initial interval.
+func (r *Retry) GetMaxInterval() time.Duration {
+	if r.MaxInterval == 0 {
+		return max(DefaultMaxInterval, r.GetInitialInterval())
+	}
+	return r.MaxInterval
+}
+
+// GetRetryOnStatus returns the retried status codes, or DefaultRetryOnStatus
+func (r *Retry) GetRetryOnStatus() []int {
+	if len(r.RetryOnStatus) == 0 {
+		return DefaultRetryOnStatus()
+	}
+	return r.RetryOnStatus
+}
+
+// GetMaxBodySize returns the replay buffer limit, or DefaultMaxBodySize
+func (r *Retry) GetMaxBodySize() int64 {
+	if r.MaxBodySize == 0 {
+		return DefaultMaxBodySize
+	}
+	return r.MaxBodySize
+}
+
+// Validate validates the retry configuration
+func (r *Retry) Validate() error {
+	var errs []error
+
+	if r.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidMaxAttempts, r.MaxAttempts))
+	}
+	for _, method := range r.Methods {
+		if !slices.Contains(knownMethods, strings.ToUpper(method)) {
+			errs = append(errs, fmt.Errorf("%w: '%s'", ErrUnknownMethod, method))
+		}
+	}
+	switch r.Backoff {
+	case BackoffUnspecified, BackoffFixed, BackoffExponential:
+	default:
+		errs = append(errs, fmt.Errorf("%w: '%s'", ErrUnknownBackoff, r.Backoff))
+	}
+	if r.InitialInterval < 0 {
+		errs = append(errs, fmt.Errorf("%w: initial_interval %s", ErrNegativeDuration, r.InitialInterval))
+	}
+	if r.MaxInterval < 0 {
+		errs = append(errs, fmt.Errorf("%w: max_interval %s", ErrNegativeDuration, r.MaxInterval))
+	}
+	if r.InitialInterval >= 0 && r.MaxInterval > 0 && r.GetInitialInterval() > r.MaxInterval {
+		errs = append(errs, fmt.Errorf("%w: %s > %s", ErrInvalidInterval, r.GetInitialInterval(), r.MaxInterval))
+	}
+	for _, code := range r.RetryOnStatus {
+		if code < 400 || code > 599 {
+			errs = append(errs, fmt.Errorf("%w: %d", ErrInvalidStatusCode, code))
+		}
+	}
+	for _, name := range r.RetryOnErrors {
+		if !slices.Contains(knownErrors, name) {
+			errs = append(errs, fmt.Errorf("%w: '%s' (available: %s)",
+				ErrUnknownError, name, strings.Join(knownErrors, ", ")))
+		}
+	}
+	if r.MaxBodySize < 0 {
+		errs = append(errs, fmt.Errorf("%w: %d", ErrNegativeMaxBodySize, r.MaxBodySize))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the retry configuration
+func (r *Retry) String() string {
+	return fmt.Sprintf("Retry (%d attempts, %s backoff, %s)",
+		r.GetMaxAttempts(), r.GetBackoff(), strings.Join(r.GetMethods(), ","))
+}
+
+// ToTree returns a tree representation of the retry configuration
+func (r *Retry) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild(fmt.Sprintf("Max Attempts: %d", r.GetMaxAttempts()))
+	tree.AddChild(fmt.Sprintf("Methods: %s", strings.Join(r.GetMethods(), ", ")))
+
+	backoff := fmt.Sprintf("Backoff: %s, %s to %s", r.GetBackoff(), r.GetInitialInterval(), r.GetMaxInterval())
+	if r.Jitter {
+		backoff += " with jitter"
+	}
+	tree.AddChild(backoff)
+
+	codes := make([]string, 0, len(r.GetRetryOnStatus()))
+	for _, code := range r.GetRetryOnStatus() {
+		codes = append(codes, strconv.Itoa(code))
+	}
+	tree.AddChild(fmt.Sprintf("Retry On Status: %s", strings.Join(codes, ", ")))
+	if len(r.RetryOnErrors) > 0 {
+		tree.AddChild(fmt.Sprintf("Retry On Errors: %s", strings.Join(r.RetryOnErrors, ", ")))
+	}
+	tree.AddChild(fmt.Sprintf("Max Body Size: %d bytes", r.GetMaxBodySize()))
+	return tree
+}
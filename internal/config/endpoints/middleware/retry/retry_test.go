@@ -0,0 +1,158 @@
+package retry
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "retry", (&Retry{}).Type())
+}
+
+func TestRetry_Defaults(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unset", func(t *testing.T) {
+		r := &Retry{}
+		assert.Equal(t, DefaultMaxAttempts, r.GetMaxAttempts())
+		assert.Equal(t, DefaultMethods(), r.GetMethods())
+		assert.Equal(t, BackoffExponential, r.GetBackoff())
+		assert.Equal(t, DefaultInitialInterval, r.GetInitialInterval())
+		assert.Equal(t, DefaultMaxInterval, r.GetMaxInterval())
+		assert.Equal(t, DefaultRetryOnStatus(), r.GetRetryOnStatus())
+		assert.Equal(t, int64(DefaultMaxBodySize), r.GetMaxBodySize())
+	})
+
+	t.Run("max interval follows a long initial interval", func(t *testing.T) {
+		r := &Retry{InitialInterval: 5 * time.Second}
+		assert.Equal(t, 5*time.Second, r.GetMaxInterval())
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		r := &Retry{
+			MaxAttempts:     5,
+			Methods:         []string{"get", "POST"},
+			Backoff:         BackoffFixed,
+			InitialInterval: time.Second,
+			MaxInterval:     time.Minute,
+			RetryOnStatus:   []int{http.StatusTooManyRequests},
+			MaxBodySize:     1024,
+		}
+		assert.Equal(t, 5, r.GetMaxAttempts())
+		assert.Equal(t, []string{"GET", "POST"}, r.GetMethods())
+		assert.Equal(t, BackoffFixed, r.GetBackoff())
+		assert.Equal(t, time.Second, r.GetInitialInterval())
+		assert.Equal(t, time.Minute, r.GetMaxInterval())
+		assert.Equal(t, []int{http.StatusTooManyRequests}, r.GetRetryOnStatus())
+		assert.Equal(t, int64(1024), r.GetMaxBodySize())
+	})
+}
+
+func TestRetry_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *Retry
+		wantErr error
+	}{
+		{
+			name:   "defaults",
+			config: &Retry{},
+		},
+		{
+			name: "fully configured",
+			config: &Retry{
+				MaxAttempts:     4,
+				Methods:         []string{"get", "post"},
+				Backoff:         BackoffExponential,
+				InitialInterval: 50 * time.Millisecond,
+				MaxInterval:     time.Second,
+				Jitter:          true,
+				RetryOnStatus:   []int{429, 503},
+				RetryOnErrors:   []string{ErrorTimeout, ErrorUnavailable},
+				MaxBodySize:     4096,
+			},
+		},
+		{
+			name:    "negative max attempts",
+			config:  &Retry{MaxAttempts: -1},
+			wantErr: ErrInvalidMaxAttempts,
+		},
+		{
+			name:    "unknown method",
+			config:  &Retry{Methods: []string{"FETCH"}},
+			wantErr: ErrUnknownMethod,
+		},
+		{
+			name:    "unknown backoff",
+			config:  &Retry{Backoff: "linear"},
+			wantErr: ErrUnknownBackoff,
+		},
+		{
+			name:    "negative initial interval",
+			config:  &Retry{InitialInterval: -time.Second},
+			wantErr: ErrNegativeDuration,
+		},
+		{
+			name:    "negative max interval",
+			config:  &Retry{MaxInterval: -time.Second},
+			wantErr: ErrNegativeDuration,
+		},
+		{
+			name:    "initial interval above max interval",
+			config:  &Retry{InitialInterval: time.Second, MaxInterval: 500 * time.Millisecond},
+			wantErr: ErrInvalidInterval,
+		},
+		{
+			name:    "success status code",
+			config:  &Retry{RetryOnStatus: []int{200}},
+			wantErr: ErrInvalidStatusCode,
+		},
+		{
+			name:    "unknown error",
+			config:  &Retry{RetryOnErrors: []string{"connection_reset"}},
+			wantErr: ErrUnknownError,
+		},
+		{
+			name:    "negative max body size",
+			config:  &Retry{MaxBodySize: -1},
+			wantErr: ErrNegativeMaxBodySize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRetry_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"Retry (3 attempts, exponential backoff, GET,HEAD,OPTIONS,PUT,DELETE)",
+		(&Retry{}).String())
+	assert.Equal(t,
+		"Retry (2 attempts, fixed backoff, GET)",
+		(&Retry{MaxAttempts: 2, Backoff: BackoffFixed, Methods: []string{"get"}}).String())
+}
+
+func TestRetry_ToTree(t *testing.T) {
+	t.Parallel()
+
+	tree := (&Retry{Jitter: true, RetryOnErrors: []string{ErrorAny}}).ToTree()
+	require.NotNil(t, tree)
+	assert.NotNil(t, tree.Tree())
+}
@@ -0,0 +1,63 @@
+package signature
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+)
+
+// ToProto converts Signature to protobuf format
+func (s *Signature) ToProto() any {
+	config := &pb.SignatureConfig{}
+	if s.Secret != "" {
+		config.Secret = &s.Secret
+	}
+	if s.Header != "" {
+		config.Header = &s.Header
+	}
+	if s.Encoding != EncodingUnspecified {
+		config.Encoding = encodingToProto(s.Encoding).Enum()
+	}
+	if s.Prefix != "" {
+		config.Prefix = &s.Prefix
+	}
+	return config
+}
+
+// FromProto converts protobuf SignatureConfig to domain Signature
+func FromProto(pbConfig *pb.SignatureConfig) (*Signature, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil signature config")
+	}
+
+	return &Signature{
+		Secret:   pbConfig.GetSecret(),
+		Header:   pbConfig.GetHeader(),
+		Encoding: encodingFromProto(pbConfig.GetEncoding()),
+		Prefix:   pbConfig.GetPrefix(),
+	}, nil
+}
+
+// encodingToProto converts a domain Encoding to its protobuf enum
+func encodingToProto(encoding Encoding) pb.SignatureConfig_Encoding {
+	switch encoding {
+	case EncodingHex:
+		return pb.SignatureConfig_ENCODING_HEX
+	case EncodingBase64:
+		return pb.SignatureConfig_ENCODING_BASE64
+	default:
+		return pb.SignatureConfig_ENCODING_UNSPECIFIED
+	}
+}
+
+// encodingFromProto converts a protobuf Encoding enum to a domain Encoding
+func encodingFromProto(encoding pb.SignatureConfig_Encoding) Encoding {
+	switch encoding {
+	case pb.SignatureConfig_ENCODING_HEX:
+		return EncodingHex
+	case pb.SignatureConfig_ENCODING_BASE64:
+		return EncodingBase64
+	default:
+		return EncodingUnspecified
+	}
+}
@@ -0,0 +1,49 @@
+package signature
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.SignatureConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &Signature{}, config)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, encoding := range []Encoding{EncodingHex, EncodingBase64} {
+		original := &Signature{
+			Secret:   "s3cret",
+			Header:   "X-Hub-Signature-256",
+			Encoding: encoding,
+			Prefix:   "sha256=",
+		}
+
+		pbConfig, ok := original.ToProto().(*pb.SignatureConfig)
+		require.True(t, ok)
+
+		converted, err := FromProto(pbConfig)
+		require.NoError(t, err)
+		assert.Equal(t, original, converted)
+	}
+
+	empty, ok := (&Signature{}).ToProto().(*pb.SignatureConfig)
+	require.True(t, ok)
+	assert.Nil(t, empty.Secret)
+	assert.Nil(t, empty.Encoding)
+}
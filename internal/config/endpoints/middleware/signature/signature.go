@@ -0,0 +1,118 @@
+package signature
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"golang.org/x/net/http/httpguts"
+)
+
+const SignatureType = "signature"
+
+// Encoding is how the signature is encoded in the header
+type Encoding string
+
+const (
+	EncodingUnspecified Encoding = ""
+	EncodingHex         Encoding = "hex"
+	EncodingBase64      Encoding = "base64"
+)
+
+// Defaults for settings that aren't configured
+const (
+	DefaultHeader   = "X-Signature"
+	DefaultEncoding = EncodingHex
+)
+
+var (
+	// ErrEmptySecret indicates that no secret was configured
+	ErrEmptySecret = errors.New("secret cannot be empty")
+
+	// ErrInvalidHeader indicates a header name that isn't a valid field name
+	ErrInvalidHeader = errors.New("invalid header name")
+
+	// ErrUnknownEncoding indicates an unsupported signature encoding
+	ErrUnknownEncoding = errors.New("unknown encoding")
+)
+
+// Signature represents a request signature middleware configuration. Zero
+// values use the defaults, see the getters.
+type Signature struct {
+	// Secret is the shared secret the HMAC is keyed with
+	Secret string `json:"secret" toml:"secret" env_interpolation:"yes"`
+
+	// Header is the request header carrying the signature
+	Header string `json:"header" toml:"header" env_interpolation:"yes"`
+
+	// Encoding is how the signature is encoded in the header
+	Encoding Encoding `json:"encoding" toml:"encoding"`
+
+	// Prefix is what the header value starts with before the signature, such
+	// as "sha256="
+	Prefix string `json:"prefix" toml:"prefix" env_interpolation:"yes"`
+}
+
+// Type returns the middleware type
+func (s *Signature) Type() string {
+	return SignatureType
+}
+
+// GetHeader returns the signature header, or DefaultHeader
+func (s *Signature) GetHeader() string {
+	if s.Header == "" {
+		return DefaultHeader
+	}
+	return s.Header
+}
+
+// GetEncoding returns the signature encoding, or DefaultEncoding
+func (s *Signature) GetEncoding() Encoding {
+	if s.Encoding == EncodingUnspecified {
+		return DefaultEncoding
+	}
+	return s.Encoding
+}
+
+// Validate validates the signature configuration
+func (s *Signature) Validate() error {
+	var errs []error
+
+	if err := interpolation.InterpolateStruct(s); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed: %w", err))
+	}
+
+	// The secret itself is never included in the error
+	if s.Secret == "" {
+		errs = append(errs, ErrEmptySecret)
+	}
+	if s.Header != "" && !httpguts.ValidHeaderFieldName(s.Header) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidHeader, s.Header))
+	}
+	switch s.Encoding {
+	case EncodingUnspecified, EncodingHex, EncodingBase64:
+	default:
+		errs = append(errs, fmt.Errorf("%w: '%s'", ErrUnknownEncoding, s.Encoding))
+	}
+
+	return errors.Join(errs...)
+}
+
+// String returns a string representation of the signature configuration.
+// The secret is never included.
+func (s *Signature) String() string {
+	return fmt.Sprintf("Signature (HMAC-SHA256, %s in %s)", s.GetEncoding(), s.GetHeader())
+}
+
+// ToTree returns a tree representation of the signature configuration
+func (s *Signature) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	tree.AddChild("Algorithm: HMAC-SHA256")
+	tree.AddChild(fmt.Sprintf("Header: %s", s.GetHeader()))
+	tree.AddChild(fmt.Sprintf("Encoding: %s", s.GetEncoding()))
+	if s.Prefix != "" {
+		tree.AddChild(fmt.Sprintf("Prefix: %s", s.Prefix))
+	}
+	return tree
+}
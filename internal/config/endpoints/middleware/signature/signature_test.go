@@ -0,0 +1,91 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "signature", (&Signature{}).Type())
+}
+
+func TestSignature_Defaults(t *testing.T) {
+	t.Parallel()
+
+	s := &Signature{}
+	assert.Equal(t, DefaultHeader, s.GetHeader())
+	assert.Equal(t, DefaultEncoding, s.GetEncoding())
+
+	s = &Signature{Header: "X-Hub-Signature-256", Encoding: EncodingBase64}
+	assert.Equal(t, "X-Hub-Signature-256", s.GetHeader())
+	assert.Equal(t, EncodingBase64, s.GetEncoding())
+}
+
+func TestSignature_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *Signature
+		wantErr error
+	}{
+		{
+			name:   "secret only",
+			config: &Signature{Secret: "s3cret"},
+		},
+		{
+			name: "all settings",
+			config: &Signature{
+				Secret:   "s3cret",
+				Header:   "X-Hub-Signature-256",
+				Encoding: EncodingHex,
+				Prefix:   "sha256=",
+			},
+		},
+		{
+			name:    "missing secret",
+			config:  &Signature{},
+			wantErr: ErrEmptySecret,
+		},
+		{
+			name:    "invalid header",
+			config:  &Signature{Secret: "s3cret", Header: "X Signature"},
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:    "unknown encoding",
+			config:  &Signature{Secret: "s3cret", Encoding: "base32"},
+			wantErr: ErrUnknownEncoding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSignature_ValidateInterpolatesSecret(t *testing.T) {
+	t.Setenv("SIGNATURE_TEST_SECRET", "from-env")
+
+	config := &Signature{Secret: "${SIGNATURE_TEST_SECRET}"}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, "from-env", config.Secret)
+}
+
+func TestSignature_StringHidesSecret(t *testing.T) {
+	t.Parallel()
+
+	config := &Signature{Secret: "s3cret", Prefix: "sha256="}
+	assert.NotContains(t, config.String(), "s3cret")
+	assert.NotContains(t, config.ToTree().Tree().String(), "s3cret")
+}
@@ -0,0 +1,119 @@
+package transform
+
+import (
+	"fmt"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// ToProto converts Transform to protobuf format
+func (t *Transform) ToProto() any {
+	return &pb.TransformConfig{
+		Request:  rulesToProto(t.Request),
+		Response: rulesToProto(t.Response),
+	}
+}
+
+// rulesToProto converts rules to protobuf format. Rules with an unknown
+// action are left without one, validation reports them.
+func rulesToProto(rules []Rule) []*pb.TransformConfig_Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+	pbRules := make([]*pb.TransformConfig_Rule, 0, len(rules))
+	for _, r := range rules {
+		pbRule := &pb.TransformConfig_Rule{}
+		switch r.Action {
+		case ActionSetHeader:
+			pbRule.Action = &pb.TransformConfig_Rule_SetHeader{SetHeader: &pb.TransformConfig_HeaderValue{
+				Name:  proto.String(r.Header),
+				Value: proto.String(r.Value),
+			}}
+		case ActionAddHeader:
+			pbRule.Action = &pb.TransformConfig_Rule_AddHeader{AddHeader: &pb.TransformConfig_HeaderValue{
+				Name:  proto.String(r.Header),
+				Value: proto.String(r.Value),
+			}}
+		case ActionRemoveHeader:
+			pbRule.Action = &pb.TransformConfig_Rule_RemoveHeader{RemoveHeader: r.Header}
+		case ActionRenameHeader:
+			pbRule.Action = &pb.TransformConfig_Rule_RenameHeader{RenameHeader: &pb.TransformConfig_HeaderRename{
+				From: proto.String(r.Header),
+				To:   proto.String(r.To),
+			}}
+		case ActionRewritePath:
+			pbRule.Action = &pb.TransformConfig_Rule_RewritePath{RewritePath: &pb.TransformConfig_PathRewrite{
+				Pattern:     proto.String(r.Pattern),
+				Replacement: proto.String(r.Replacement),
+			}}
+		case ActionSetStatus:
+			pbRule.Action = &pb.TransformConfig_Rule_SetStatus{SetStatus: int32(r.Status)}
+		}
+		pbRules = append(pbRules, pbRule)
+	}
+	return pbRules
+}
+
+// FromProto converts protobuf TransformConfig to domain Transform
+func FromProto(pbConfig *pb.TransformConfig) (*Transform, error) {
+	if pbConfig == nil {
+		return nil, fmt.Errorf("nil transform config")
+	}
+
+	request, err := rulesFromProto(pbConfig.GetRequest())
+	if err != nil {
+		return nil, fmt.Errorf("request rules: %w", err)
+	}
+	response, err := rulesFromProto(pbConfig.GetResponse())
+	if err != nil {
+		return nil, fmt.Errorf("response rules: %w", err)
+	}
+
+	return &Transform{Request: request, Response: response}, nil
+}
+
+// rulesFromProto converts protobuf rules to domain rules
+func rulesFromProto(pbRules []*pb.TransformConfig_Rule) ([]Rule, error) {
+	if len(pbRules) == 0 {
+		return nil, nil
+	}
+	rules := make([]Rule, 0, len(pbRules))
+	for i, pbRule := range pbRules {
+		var r Rule
+		switch action := pbRule.GetAction().(type) {
+		case *pb.TransformConfig_Rule_SetHeader:
+			r = Rule{
+				Action: ActionSetHeader,
+				Header: action.SetHeader.GetName(),
+				Value:  action.SetHeader.GetValue(),
+			}
+		case *pb.TransformConfig_Rule_AddHeader:
+			r = Rule{
+				Action: ActionAddHeader,
+				Header: action.AddHeader.GetName(),
+				Value:  action.AddHeader.GetValue(),
+			}
+		case *pb.TransformConfig_Rule_RemoveHeader:
+			r = Rule{Action: ActionRemoveHeader, Header: action.RemoveHeader}
+		case *pb.TransformConfig_Rule_RenameHeader:
+			r = Rule{
+				Action: ActionRenameHeader,
+				Header: action.RenameHeader.GetFrom(),
+				To:     action.RenameHeader.GetTo(),
+			}
+		case *pb.TransformConfig_Rule_RewritePath:
+			r = Rule{
+				Action:      ActionRewritePath,
+				Pattern:     action.RewritePath.GetPattern(),
+				Replacement: action.RewritePath.GetReplacement(),
+			}
+		case *pb.TransformConfig_Rule_SetStatus:
+			r = Rule{Action: ActionSetStatus, Status: int(action.SetStatus)}
+		default:
+			return nil, fmt.Errorf("rule %d: %w", i, ErrUnknownAction)
+		}
+		rules = append(rules, r)
+	}
+	return rules, nil
+}
@@ -0,0 +1,56 @@
+package transform
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromProto(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := FromProto(nil)
+		require.Error(t, err)
+	})
+
+	t.Run("empty config", func(t *testing.T) {
+		config, err := FromProto(&pb.TransformConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, &Transform{}, config)
+	})
+
+	t.Run("rule without an action", func(t *testing.T) {
+		_, err := FromProto(&pb.TransformConfig{Request: []*pb.TransformConfig_Rule{{}}})
+		require.ErrorIs(t, err, ErrUnknownAction)
+	})
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := &Transform{
+		Request: []Rule{
+			{Action: ActionSetHeader, Header: "X-Source", Value: "edge"},
+			{Action: ActionAddHeader, Header: "X-Trace", Value: "1"},
+			{Action: ActionRenameHeader, Header: "X-Api-Key", To: "Authorization"},
+			{Action: ActionRewritePath, Pattern: `^/api/v1/(.*)$`, Replacement: "/$1"},
+		},
+		Response: []Rule{
+			{Action: ActionRemoveHeader, Header: "Server"},
+			{Action: ActionSetStatus, Status: 202},
+		},
+	}
+
+	pbConfig, ok := original.ToProto().(*pb.TransformConfig)
+	require.True(t, ok)
+	require.Len(t, pbConfig.GetRequest(), 4)
+	assert.Equal(t, "Authorization", pbConfig.GetRequest()[2].GetRenameHeader().GetTo())
+	assert.Equal(t, int32(202), pbConfig.GetResponse()[1].GetSetStatus())
+
+	converted, err := FromProto(pbConfig)
+	require.NoError(t, err)
+	assert.Equal(t, original, converted)
+}
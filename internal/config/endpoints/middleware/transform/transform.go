@@ -0,0 +1,231 @@
+// Package transform provides the configuration of the transform middleware,
+// which rewrites requests and responses with declarative rules instead of a
+// script: setting, adding, removing and renaming headers, rewriting the
+// request path, and replacing the response status.
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+	"golang.org/x/net/http/httpguts"
+)
+
+const TransformType = "transform"
+
+// Action is what a rule does
+type Action string
+
+const (
+	ActionSetHeader    Action = "set_header"
+	ActionAddHeader    Action = "add_header"
+	ActionRemoveHeader Action = "remove_header"
+	ActionRenameHeader Action = "rename_header"
+	ActionRewritePath  Action = "rewrite_path"
+	ActionSetStatus    Action = "set_status"
+)
+
+var (
+	// ErrNoRules indicates a transform without request or response rules
+	ErrNoRules = errors.New("at least one request or response rule is required")
+
+	// ErrUnknownAction indicates a rule without a supported action
+	ErrUnknownAction = errors.New("unknown rule action")
+
+	// ErrInvalidHeaderName indicates a header name that isn't a valid token
+	ErrInvalidHeaderName = errors.New("invalid header name")
+
+	// ErrInvalidHeaderValue indicates a header value with invalid characters
+	ErrInvalidHeaderValue = errors.New("invalid header value")
+
+	// ErrRenameToSelf indicates a header renamed to its own name
+	ErrRenameToSelf = errors.New("header is renamed to itself")
+
+	// ErrInvalidPattern indicates a path pattern that doesn't compile
+	ErrInvalidPattern = errors.New("invalid path pattern")
+
+	// ErrInvalidReplacement indicates a path replacement that wouldn't
+	// produce an absolute path
+	ErrInvalidReplacement = errors.New("path replacement must start with '/'")
+
+	// ErrInvalidStatus indicates a status code outside 200-599
+	ErrInvalidStatus = errors.New("status must be between 200 and 599")
+
+	// ErrRequestOnly indicates a response rule that only applies to requests
+	ErrRequestOnly = errors.New("rule only applies to requests")
+
+	// ErrResponseOnly indicates a request rule that only applies to responses
+	ErrResponseOnly = errors.New("rule only applies to responses")
+)
+
+// Rule is a single transformation. Which fields are used depends on Action.
+type Rule struct {
+	Action Action `json:"action" toml:"action"`
+
+	// Header is the header of the header actions, the one renamed for
+	// rename_header
+	Header string `json:"header" toml:"header"`
+
+	// Value is the value of set_header and add_header
+	Value string `json:"value" toml:"value" env_interpolation:"yes"`
+
+	// To is the new name of the header renamed by rename_header
+	To string `json:"to" toml:"to"`
+
+	// Pattern is the regular expression rewrite_path matches against the
+	// request path, replaced by Replacement
+	Pattern     string `json:"pattern" toml:"pattern"`
+	Replacement string `json:"replacement" toml:"replacement"`
+
+	// Status is the response status code of set_status
+	Status int `json:"status" toml:"status"`
+}
+
+// Transform represents a transform middleware configuration
+type Transform struct {
+	// Request rules, applied in order before the rest of the chain runs
+	Request []Rule `json:"request" toml:"request" env_interpolation:"yes"`
+
+	// Response rules, applied in order when the response headers are written
+	Response []Rule `json:"response" toml:"response" env_interpolation:"yes"`
+}
+
+// Type returns the middleware type
+func (t *Transform) Type() string {
+	return TransformType
+}
+
+// Validate validates the transform configuration
+func (t *Transform) Validate() error {
+	var errs []error
+
+	if err := interpolation.InterpolateStruct(t); err != nil {
+		errs = append(errs, fmt.Errorf("interpolation failed: %w", err))
+	}
+
+	if len(t.Request) == 0 && len(t.Response) == 0 {
+		errs = append(errs, ErrNoRules)
+	}
+	for i := range t.Request {
+		if err := t.Request[i].validate(false); err != nil {
+			errs = append(errs, fmt.Errorf("request rule %d: %w", i, err))
+		}
+	}
+	for i := range t.Response {
+		if err := t.Response[i].validate(true); err != nil {
+			errs = append(errs, fmt.Errorf("response rule %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// validate checks the rule's fields for its action, as a response rule or a
+// request rule
+func (r *Rule) validate(response bool) error {
+	switch r.Action {
+	case ActionSetHeader, ActionAddHeader:
+		if err := validateHeaderName(r.Header); err != nil {
+			return err
+		}
+		if !httpguts.ValidHeaderFieldValue(r.Value) {
+			return fmt.Errorf("%w for '%s'", ErrInvalidHeaderValue, r.Header)
+		}
+	case ActionRemoveHeader:
+		return validateHeaderName(r.Header)
+	case ActionRenameHeader:
+		return errors.Join(
+			validateHeaderName(r.Header),
+			validateHeaderName(r.To),
+			r.validateRename(),
+		)
+	case ActionRewritePath:
+		if response {
+			return fmt.Errorf("%w: %s", ErrRequestOnly, r.Action)
+		}
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPattern, err)
+		}
+		if !strings.HasPrefix(r.Replacement, "/") {
+			return fmt.Errorf("%w: '%s'", ErrInvalidReplacement, r.Replacement)
+		}
+	case ActionSetStatus:
+		if !response {
+			return fmt.Errorf("%w: %s", ErrResponseOnly, r.Action)
+		}
+		if r.Status < http.StatusOK || r.Status > 599 {
+			return fmt.Errorf("%w: %d", ErrInvalidStatus, r.Status)
+		}
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnknownAction, r.Action)
+	}
+	return nil
+}
+
+// validateRename rejects renaming a header to its own name
+func (r *Rule) validateRename() error {
+	if r.Header != "" && http.CanonicalHeaderKey(r.Header) == http.CanonicalHeaderKey(r.To) {
+		return fmt.Errorf("%w: '%s'", ErrRenameToSelf, r.Header)
+	}
+	return nil
+}
+
+// String returns a string representation of the rule
+func (r *Rule) String() string {
+	switch r.Action {
+	case ActionSetHeader:
+		return fmt.Sprintf("Set header \"%s: %s\"", r.Header, r.Value)
+	case ActionAddHeader:
+		return fmt.Sprintf("Add header \"%s: %s\"", r.Header, r.Value)
+	case ActionRemoveHeader:
+		return fmt.Sprintf("Remove header \"%s\"", r.Header)
+	case ActionRenameHeader:
+		return fmt.Sprintf("Rename header \"%s\" to \"%s\"", r.Header, r.To)
+	case ActionRewritePath:
+		return fmt.Sprintf("Rewrite path \"%s\" to \"%s\"", r.Pattern, r.Replacement)
+	case ActionSetStatus:
+		return fmt.Sprintf("Set status %d", r.Status)
+	default:
+		return fmt.Sprintf("Unknown action '%s'", r.Action)
+	}
+}
+
+// String returns a string representation of the transform configuration
+func (t *Transform) String() string {
+	return fmt.Sprintf("Transform (%d request rules, %d response rules)", len(t.Request), len(t.Response))
+}
+
+// ToTree returns a tree representation of the transform configuration
+func (t *Transform) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Config:")
+	for _, section := range []struct {
+		title string
+		rules []Rule
+	}{
+		{"Request Rules:", t.Request},
+		{"Response Rules:", t.Response},
+	} {
+		if len(section.rules) == 0 {
+			continue
+		}
+		rules := fancy.NewComponentTree(section.title)
+		for i := range section.rules {
+			rules.AddChild(section.rules[i].String())
+		}
+		tree.AddChild(rules.Tree())
+	}
+	return tree
+}
+
+// validateHeaderName checks that name is a valid header name
+func validateHeaderName(name string) error {
+	if !httpguts.ValidHeaderFieldName(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidHeaderName, name)
+	}
+	return nil
+}
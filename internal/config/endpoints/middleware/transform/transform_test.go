@@ -0,0 +1,129 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransform_Type(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "transform", (&Transform{}).Type())
+}
+
+func TestTransform_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		config  *Transform
+		wantErr error
+	}{
+		{
+			name: "request and response rules",
+			config: &Transform{
+				Request: []Rule{
+					{Action: ActionRenameHeader, Header: "X-Api-Key", To: "Authorization"},
+					{Action: ActionRewritePath, Pattern: `^/api/v1/(.*)$`, Replacement: "/$1"},
+					{Action: ActionSetHeader, Header: "X-Source", Value: "edge"},
+				},
+				Response: []Rule{
+					{Action: ActionRemoveHeader, Header: "Server"},
+					{Action: ActionAddHeader, Header: "Vary", Value: "Accept"},
+					{Action: ActionSetStatus, Status: 202},
+				},
+			},
+		},
+		{
+			name:    "no rules",
+			config:  &Transform{},
+			wantErr: ErrNoRules,
+		},
+		{
+			name:    "unknown action",
+			config:  &Transform{Request: []Rule{{Action: "drop"}}},
+			wantErr: ErrUnknownAction,
+		},
+		{
+			name:    "invalid header name",
+			config:  &Transform{Request: []Rule{{Action: ActionSetHeader, Header: "Bad Header"}}},
+			wantErr: ErrInvalidHeaderName,
+		},
+		{
+			name:    "invalid header value",
+			config:  &Transform{Response: []Rule{{Action: ActionAddHeader, Header: "X-A", Value: "a\nb"}}},
+			wantErr: ErrInvalidHeaderValue,
+		},
+		{
+			name:    "rename to itself",
+			config:  &Transform{Request: []Rule{{Action: ActionRenameHeader, Header: "x-a", To: "X-A"}}},
+			wantErr: ErrRenameToSelf,
+		},
+		{
+			name: "invalid path pattern",
+			config: &Transform{Request: []Rule{
+				{Action: ActionRewritePath, Pattern: "(", Replacement: "/"},
+			}},
+			wantErr: ErrInvalidPattern,
+		},
+		{
+			name: "relative path replacement",
+			config: &Transform{Request: []Rule{
+				{Action: ActionRewritePath, Pattern: "^/api/(.*)$", Replacement: "$1"},
+			}},
+			wantErr: ErrInvalidReplacement,
+		},
+		{
+			name: "path rewrite on the response",
+			config: &Transform{Response: []Rule{
+				{Action: ActionRewritePath, Pattern: "^/api", Replacement: "/"},
+			}},
+			wantErr: ErrRequestOnly,
+		},
+		{
+			name:    "status on the request",
+			config:  &Transform{Request: []Rule{{Action: ActionSetStatus, Status: 200}}},
+			wantErr: ErrResponseOnly,
+		},
+		{
+			name:    "informational status",
+			config:  &Transform{Response: []Rule{{Action: ActionSetStatus, Status: 101}}},
+			wantErr: ErrInvalidStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTransform_ValidateInterpolatesValues(t *testing.T) {
+	t.Setenv("TRANSFORM_TEST_REGION", "eu-west")
+
+	config := &Transform{Response: []Rule{
+		{Action: ActionSetHeader, Header: "X-Region", Value: "${TRANSFORM_TEST_REGION}"},
+	}}
+	require.NoError(t, config.Validate())
+	assert.Equal(t, "eu-west", config.Response[0].Value)
+}
+
+func TestTransform_StringAndTree(t *testing.T) {
+	t.Parallel()
+
+	config := &Transform{
+		Request:  []Rule{{Action: ActionRenameHeader, Header: "X-Api-Key", To: "Authorization"}},
+		Response: []Rule{{Action: ActionSetStatus, Status: 202}},
+	}
+	assert.Equal(t, "Transform (1 request rules, 1 response rules)", config.String())
+	assert.Equal(t, `Rename header "X-Api-Key" to "Authorization"`, config.Request[0].String())
+	assert.Equal(t, "Set status 202", config.Response[0].String())
+	assert.NotNil(t, config.ToTree())
+}
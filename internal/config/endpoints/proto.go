@@ -2,11 +2,14 @@ package endpoints
 
 import (
 	"fmt"
+	"slices"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
 	"github.com/robbyt/protobaggins"
+	"google.golang.org/protobuf/proto"
 )
 
 // ToProto converts an Endpoints collection to a slice of protobuf Endpoints
@@ -36,6 +39,26 @@ func (e *Endpoint) ToProto() *pb.Endpoint {
 		pbEndpoint.Middlewares = e.Middlewares.ToProto()
 	}
 
+	// Convert static data if present
+	if e.StaticData != nil {
+		pbEndpoint.StaticData = &pbData.StaticData{
+			Data: protobaggins.MapToStructValues(e.StaticData),
+		}
+	}
+
+	if len(e.ExcludeListenerMiddlewares) > 0 {
+		pbEndpoint.ExcludeListenerMiddlewares = slices.Clone(e.ExcludeListenerMiddlewares)
+	}
+
+	if len(e.ListenerIDs) > 0 {
+		pbEndpoint.ListenerIds = slices.Clone(e.ListenerIDs)
+	}
+
+	// Enabled is the default, only a disabled endpoint is written out
+	if e.Disabled {
+		pbEndpoint.Enabled = proto.Bool(false)
+	}
+
 	return pbEndpoint
 }
 
@@ -58,7 +81,12 @@ func FromProto(pbEndpoints []*pb.Endpoint) (EndpointCollection, error) {
 			return nil, fmt.Errorf("endpoint has nil or empty ID")
 		}
 
+		// The first of listener_ids stands in for a missing listener_id
 		listenerID := protobaggins.StringFromProto(e.ListenerId)
+		listenerIDs := e.GetListenerIds()
+		if listenerID == "" && len(listenerIDs) > 0 {
+			listenerID, listenerIDs = listenerIDs[0], listenerIDs[1:]
+		}
 		if listenerID == "" {
 			return nil, fmt.Errorf("endpoint '%s' has empty listener ID", id)
 		}
@@ -66,6 +94,10 @@ func FromProto(pbEndpoints []*pb.Endpoint) (EndpointCollection, error) {
 		ep := Endpoint{
 			ID:         id,
 			ListenerID: listenerID,
+			Disabled:   !e.GetEnabled(),
+		}
+		if len(listenerIDs) > 0 {
+			ep.ListenerIDs = slices.Clone(listenerIDs)
 		}
 
 		// Convert routes
@@ -82,6 +114,15 @@ func FromProto(pbEndpoints []*pb.Endpoint) (EndpointCollection, error) {
 			ep.Middlewares = middlewares
 		}
 
+		// Convert static data
+		if e.StaticData != nil && len(e.StaticData.Data) > 0 {
+			ep.StaticData = protobaggins.StructValuesToMap(e.StaticData.Data)
+		}
+
+		if len(e.ExcludeListenerMiddlewares) > 0 {
+			ep.ExcludeListenerMiddlewares = slices.Clone(e.ExcludeListenerMiddlewares)
+		}
+
 		endpoints = append(endpoints, ep)
 	}
 
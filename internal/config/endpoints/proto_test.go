@@ -428,3 +428,127 @@ func TestFromProto(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpoint_StaticDataProto(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{
+		ID:         "api",
+		ListenerID: "http",
+		StaticData: map[string]any{
+			"env": "prod",
+			"db":  map[string]any{"host": "db.internal"},
+		},
+	}
+
+	pbEndpoint := endpoint.ToProto()
+	require.NotNil(t, pbEndpoint.GetStaticData())
+	assert.Equal(t, "prod", pbEndpoint.GetStaticData().GetData()["env"].GetStringValue())
+	assert.Equal(t, "db.internal",
+		pbEndpoint.GetStaticData().GetData()["db"].GetStructValue().GetFields()["host"].GetStringValue())
+
+	converted, err := FromProto([]*pb.Endpoint{pbEndpoint})
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Equal(t, endpoint.StaticData, converted[0].StaticData)
+
+	t.Run("empty static data", func(t *testing.T) {
+		converted, err := FromProto([]*pb.Endpoint{{
+			Id:         proto.String("api"),
+			ListenerId: proto.String("http"),
+			StaticData: &pbData.StaticData{},
+		}})
+		require.NoError(t, err)
+		assert.Nil(t, converted[0].StaticData)
+		assert.Nil(t, (&Endpoint{ID: "api", ListenerID: "http"}).ToProto().GetStaticData())
+	})
+}
+
+func TestEndpoint_ExcludeListenerMiddlewaresProto(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{
+		ID:                         "api",
+		ListenerID:                 "http",
+		ExcludeListenerMiddlewares: []string{"00-logger"},
+	}
+
+	pbEndpoint := endpoint.ToProto()
+	assert.Equal(t, []string{"00-logger"}, pbEndpoint.GetExcludeListenerMiddlewares())
+
+	converted, err := FromProto([]*pb.Endpoint{pbEndpoint})
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Equal(t, endpoint.ExcludeListenerMiddlewares, converted[0].ExcludeListenerMiddlewares)
+	assert.Nil(t, converted[0].ListenerMiddlewares)
+}
+
+func TestEndpoint_EnabledProto(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{ID: "api", ListenerID: "http", Disabled: true}
+
+	pbEndpoint := endpoint.ToProto()
+	require.NotNil(t, pbEndpoint.Enabled)
+	assert.False(t, pbEndpoint.GetEnabled())
+
+	converted, err := FromProto([]*pb.Endpoint{pbEndpoint})
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.True(t, converted[0].Disabled)
+
+	t.Run("enabled by default", func(t *testing.T) {
+		pbEndpoint := (&Endpoint{ID: "api", ListenerID: "http"}).ToProto()
+		assert.Nil(t, pbEndpoint.Enabled)
+
+		converted, err := FromProto([]*pb.Endpoint{pbEndpoint})
+		require.NoError(t, err)
+		assert.False(t, converted[0].Disabled)
+	})
+}
+
+func TestEndpoint_ListenerIDsProto(t *testing.T) {
+	t.Parallel()
+
+	endpoint := Endpoint{
+		ID:          "api",
+		ListenerID:  "http",
+		ListenerIDs: []string{"https"},
+	}
+
+	pbEndpoint := endpoint.ToProto()
+	assert.Equal(t, "http", pbEndpoint.GetListenerId())
+	assert.Equal(t, []string{"https"}, pbEndpoint.GetListenerIds())
+
+	converted, err := FromProto([]*pb.Endpoint{pbEndpoint})
+	require.NoError(t, err)
+	require.Len(t, converted, 1)
+	assert.Equal(t, "http", converted[0].ListenerID)
+	assert.Equal(t, []string{"https"}, converted[0].ListenerIDs)
+
+	t.Run("listener_ids without listener_id", func(t *testing.T) {
+		converted, err := FromProto([]*pb.Endpoint{{
+			Id:          proto.String("api"),
+			ListenerIds: []string{"http", "https"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "http", converted[0].ListenerID)
+		assert.Equal(t, []string{"https"}, converted[0].ListenerIDs)
+	})
+
+	t.Run("single entry in listener_ids", func(t *testing.T) {
+		converted, err := FromProto([]*pb.Endpoint{{
+			Id:          proto.String("api"),
+			ListenerIds: []string{"http"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "http", converted[0].ListenerID)
+		assert.Nil(t, converted[0].ListenerIDs)
+	})
+
+	t.Run("no listener", func(t *testing.T) {
+		_, err := FromProto([]*pb.Endpoint{{Id: proto.String("api")}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "endpoint 'api' has empty listener ID")
+	})
+}
@@ -0,0 +1,94 @@
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// RequestMatcher is implemented by conditions that are evaluated against each
+// incoming HTTP request, rather than being resolved by the listener's path mux.
+type RequestMatcher interface {
+	Condition
+	MatchRequest(r *http.Request) bool
+}
+
+// Collection holds additional request conditions for a route. All conditions
+// must match (AND) for the route to be selected.
+type Collection []RequestMatcher
+
+// Validate validates every condition in the collection
+func (c Collection) Validate() error {
+	var errs []error
+	for i, cond := range c {
+		if cond == nil {
+			errs = append(errs, fmt.Errorf("condition %d: %w", i, ErrInvalidConditionType))
+			continue
+		}
+		if err := cond.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("condition %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MatchRequest reports whether all conditions match the request. An empty
+// collection matches every request.
+func (c Collection) MatchRequest(r *http.Request) bool {
+	for _, cond := range c {
+		if !cond.MatchRequest(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Key returns a stable, order-independent string identifying the conditions,
+// used to detect duplicate routes. The key is empty for an empty collection.
+func (c Collection) Key() string {
+	if len(c) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(c))
+	for _, cond := range c {
+		parts = append(parts, fmt.Sprintf("%s:%s", cond.Type(), cond.Value()))
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, " && ")
+}
+
+// Excludes reports whether no request can satisfy both collections: one of
+// them accepts only methods the other doesn't, or they require different
+// exact values of the same header. A request repeating the header with both
+// values is not considered, and other conditions are assumed to overlap.
+func (c Collection) Excludes(other Collection) bool {
+	for _, a := range c {
+		for _, b := range other {
+			if excludes(a, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// excludes reports whether no request can satisfy both conditions
+func excludes(a, b RequestMatcher) bool {
+	switch a := a.(type) {
+	case *Method:
+		if b, ok := b.(*Method); ok {
+			bMethods := b.normalized()
+			return !slices.ContainsFunc(a.normalized(), func(m string) bool {
+				return slices.Contains(bMethods, m)
+			})
+		}
+	case *Header:
+		if b, ok := b.(*Header); ok {
+			return a.Mode == MatchExact && b.Mode == MatchExact &&
+				strings.EqualFold(a.Name, b.Name) && a.MatchValue != b.MatchValue
+		}
+	}
+	return false
+}
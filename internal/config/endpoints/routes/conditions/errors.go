@@ -8,7 +8,9 @@ import "errors"
 
 // Common condition-specific error types
 var (
-	ErrInvalidHTTPCondition = errors.New("invalid HTTP path condition")
-	ErrEmptyValue           = errors.New("empty condition value")
-	ErrInvalidConditionType = errors.New("invalid condition type")
+	ErrInvalidHTTPCondition   = errors.New("invalid HTTP path condition")
+	ErrInvalidHeaderCondition = errors.New("invalid header condition")
+	ErrInvalidMethodCondition = errors.New("invalid method condition")
+	ErrEmptyValue             = errors.New("empty condition value")
+	ErrInvalidConditionType   = errors.New("invalid condition type")
 )
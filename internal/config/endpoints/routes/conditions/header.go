@@ -0,0 +1,135 @@
+package conditions
+
+import (
+	"fmt"
+	"net/http"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	"github.com/atlanticdynamic/firelynx/internal/interpolation"
+)
+
+// MatchMode defines how a header value is compared against a request
+type MatchMode string
+
+// Constants for MatchMode
+const (
+	MatchExact  MatchMode = "exact"
+	MatchPrefix MatchMode = "prefix"
+	MatchRegex  MatchMode = "regex"
+)
+
+// Header matches requests carrying a header with a matching value
+type Header struct {
+	Name       string    `env_interpolation:"no"`
+	MatchValue string    `env_interpolation:"yes"`
+	Mode       MatchMode `env_interpolation:"no"`
+
+	// pattern is compiled from MatchValue during validation when Mode is
+	// MatchRegex, or on first match for a condition that skipped validation
+	pattern     *regexp.Regexp
+	patternOnce sync.Once
+}
+
+// NewHeader creates a new header condition. An empty match mode defaults to exact.
+func NewHeader(name, value string, mode MatchMode) *Header {
+	if mode == "" {
+		mode = MatchExact
+	}
+	return &Header{
+		Name:       name,
+		MatchValue: value,
+		Mode:       mode,
+	}
+}
+
+// Type returns the condition type
+func (h *Header) Type() Type { return TypeHeader }
+
+// Value returns a representative value
+func (h *Header) Value() string {
+	return fmt.Sprintf("%s %s %s", textproto.CanonicalMIMEHeaderKey(h.Name), h.Mode, h.MatchValue)
+}
+
+// Validate checks if the header condition is valid and compiles regex patterns
+func (h *Header) Validate() error {
+	// Interpolate environment variables first
+	if err := interpolation.InterpolateStruct(h); err != nil {
+		return fmt.Errorf("condition interpolation failed: %w", err)
+	}
+
+	if h.Name == "" {
+		return fmt.Errorf("%w: header name: %w", ErrInvalidHeaderCondition, ErrEmptyValue)
+	}
+	if strings.ContainsAny(h.Name, " \t:") {
+		return fmt.Errorf("%w: invalid header name '%s'", ErrInvalidHeaderCondition, h.Name)
+	}
+	if h.MatchValue == "" {
+		return fmt.Errorf("%w: header '%s' value: %w", ErrInvalidHeaderCondition, h.Name, ErrEmptyValue)
+	}
+
+	switch h.Mode {
+	case MatchExact, MatchPrefix:
+		h.pattern = nil
+	case MatchRegex:
+		pattern, err := regexp.Compile(h.MatchValue)
+		if err != nil {
+			return fmt.Errorf("%w: header '%s' pattern: %w", ErrInvalidHeaderCondition, h.Name, err)
+		}
+		h.pattern = pattern
+	default:
+		return fmt.Errorf("%w: unsupported match mode '%s'", ErrInvalidHeaderCondition, h.Mode)
+	}
+
+	return nil
+}
+
+// MatchRequest reports whether any value of the header on r matches this condition
+func (h *Header) MatchRequest(r *http.Request) bool {
+	for _, v := range r.Header.Values(h.Name) {
+		switch h.Mode {
+		case MatchExact:
+			if v == h.MatchValue {
+				return true
+			}
+		case MatchPrefix:
+			if strings.HasPrefix(v, h.MatchValue) {
+				return true
+			}
+		case MatchRegex:
+			if pattern := h.regex(); pattern != nil && pattern.MatchString(v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// regex returns the compiled pattern, compiling MatchValue on first use when
+// Validate hasn't. An invalid pattern, which Validate would have rejected,
+// matches nothing.
+func (h *Header) regex() *regexp.Regexp {
+	h.patternOnce.Do(func() {
+		if h.pattern == nil {
+			h.pattern, _ = regexp.Compile(h.MatchValue)
+		}
+	})
+	return h.pattern
+}
+
+// String returns a string representation of the header condition
+func (h *Header) String() string {
+	return fmt.Sprintf("Header: %s", h.Value())
+}
+
+// ToTree returns a tree representation of the header condition
+func (h *Header) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Header Rule")
+	tree.AddChild(fmt.Sprintf("Name: %s", h.Name))
+	tree.AddChild(fmt.Sprintf("Match: %s", h.Mode))
+	tree.AddChild(fmt.Sprintf("Value: %s", h.MatchValue))
+	return tree
+}
@@ -0,0 +1,168 @@
+package conditions
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderCondition(t *testing.T) {
+	t.Run("Constructor", func(t *testing.T) {
+		cond := NewHeader("x-api-version", "2", "")
+		assert.Equal(t, "x-api-version", cond.Name)
+		assert.Equal(t, "2", cond.MatchValue)
+		assert.Equal(t, MatchExact, cond.Mode, "empty mode defaults to exact")
+		assert.Equal(t, TypeHeader, cond.Type())
+		assert.Equal(t, "X-Api-Version exact 2", cond.Value())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			cond    *Header
+			wantErr error
+		}{
+			{"exact", NewHeader("X-API-Version", "2", MatchExact), nil},
+			{"prefix", NewHeader("Accept", "application/", MatchPrefix), nil},
+			{"regex", NewHeader("User-Agent", `^curl/\d+`, MatchRegex), nil},
+			{"empty name", NewHeader("", "2", MatchExact), ErrEmptyValue},
+			{"invalid name", NewHeader("X API", "2", MatchExact), ErrInvalidHeaderCondition},
+			{"empty value", NewHeader("X-API-Version", "", MatchExact), ErrEmptyValue},
+			{"bad regex", NewHeader("User-Agent", "([", MatchRegex), ErrInvalidHeaderCondition},
+			{"unknown mode", NewHeader("X-API-Version", "2", MatchMode("glob")), ErrInvalidHeaderCondition},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := tt.cond.Validate()
+				if tt.wantErr == nil {
+					require.NoError(t, err)
+					return
+				}
+				require.Error(t, err)
+				require.ErrorIs(t, err, ErrInvalidHeaderCondition)
+				require.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("Interpolation", func(t *testing.T) {
+		t.Setenv("FIRELYNX_TEST_API_VERSION", "3")
+		cond := NewHeader("X-API-Version", "${FIRELYNX_TEST_API_VERSION}", MatchExact)
+		require.NoError(t, cond.Validate())
+		assert.Equal(t, "3", cond.MatchValue)
+	})
+
+	t.Run("MatchRequest", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			cond    *Header
+			headers map[string][]string
+			want    bool
+		}{
+			{"exact match", NewHeader("X-API-Version", "2", MatchExact), map[string][]string{"X-Api-Version": {"2"}}, true},
+			{"exact mismatch", NewHeader("X-API-Version", "2", MatchExact), map[string][]string{"X-Api-Version": {"20"}}, false},
+			{"missing header", NewHeader("X-API-Version", "2", MatchExact), nil, false},
+			{"any value matches", NewHeader("X-API-Version", "2", MatchExact), map[string][]string{"X-Api-Version": {"1", "2"}}, true},
+			{"prefix match", NewHeader("Accept", "application/", MatchPrefix), map[string][]string{"Accept": {"application/json"}}, true},
+			{"prefix mismatch", NewHeader("Accept", "application/", MatchPrefix), map[string][]string{"Accept": {"text/html"}}, false},
+			{"regex match", NewHeader("User-Agent", `^curl/\d+`, MatchRegex), map[string][]string{"User-Agent": {"curl/8.4.0"}}, true},
+			{"regex mismatch", NewHeader("User-Agent", `^curl/\d+`, MatchRegex), map[string][]string{"User-Agent": {"wget/1.0"}}, false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.NoError(t, tt.cond.Validate())
+				req := httptest.NewRequest("GET", "/", nil)
+				for k, values := range tt.headers {
+					for _, v := range values {
+						req.Header.Add(k, v)
+					}
+				}
+				assert.Equal(t, tt.want, tt.cond.MatchRequest(req))
+			})
+		}
+	})
+
+	t.Run("MatchRequest without validation", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("User-Agent", "curl/8.4.0")
+
+		cond := &Header{Name: "User-Agent", MatchValue: `^curl/\d+`, Mode: MatchRegex}
+		assert.True(t, cond.MatchRequest(req), "regex compiles on first match")
+		assert.False(t, NewHeader("User-Agent", `^wget/`, MatchRegex).MatchRequest(req))
+		assert.False(t, NewHeader("User-Agent", "([", MatchRegex).MatchRequest(req), "invalid regex matches nothing")
+	})
+
+	t.Run("ToTree", func(t *testing.T) {
+		tree := NewHeader("X-API-Version", "2", MatchExact).ToTree()
+		require.NotNil(t, tree)
+		out := tree.Tree().String()
+		assert.Contains(t, out, "Header Rule")
+		assert.Contains(t, out, "Name: X-API-Version")
+		assert.Contains(t, out, "Match: exact")
+	})
+}
+
+func TestCollection(t *testing.T) {
+	version := NewHeader("X-API-Version", "2", MatchExact)
+	accept := NewHeader("Accept", "application/", MatchPrefix)
+
+	t.Run("MatchRequestANDs", func(t *testing.T) {
+		conds := Collection{version, accept}
+		require.NoError(t, conds.Validate())
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-API-Version", "2")
+		assert.False(t, conds.MatchRequest(req), "all conditions must match")
+
+		req.Header.Set("Accept", "application/json")
+		assert.True(t, conds.MatchRequest(req))
+	})
+
+	t.Run("EmptyMatchesEverything", func(t *testing.T) {
+		var conds Collection
+		assert.True(t, conds.MatchRequest(httptest.NewRequest("GET", "/", nil)))
+		assert.Empty(t, conds.Key())
+	})
+
+	t.Run("KeyIsOrderIndependent", func(t *testing.T) {
+		assert.Equal(t, Collection{version, accept}.Key(), Collection{accept, version}.Key())
+		assert.NotEqual(t, Collection{version}.Key(), Collection{accept}.Key())
+	})
+
+	t.Run("Excludes", func(t *testing.T) {
+		get := NewMethod("GET")
+		write := NewMethod("post", "PUT")
+		otherVersion := NewHeader("x-api-version", "3", MatchExact)
+
+		tests := []struct {
+			name     string
+			a, b     Collection
+			excludes bool
+		}{
+			{"disjoint methods", Collection{get}, Collection{write}, true},
+			{"shared method", Collection{NewMethod("GET", "PUT")}, Collection{write}, false},
+			{"different exact header values", Collection{version}, Collection{otherVersion}, true},
+			{"same exact header value", Collection{version}, Collection{version, get}, false},
+			{"prefix header", Collection{version}, Collection{NewHeader("X-API-Version", "3", MatchPrefix)}, false},
+			{"different headers", Collection{version}, Collection{accept}, false},
+			{"any excluding pair", Collection{accept, get}, Collection{accept, write}, true},
+			{"empty collection", nil, Collection{version}, false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.excludes, tt.a.Excludes(tt.b))
+				assert.Equal(t, tt.excludes, tt.b.Excludes(tt.a), "excludes is symmetric")
+			})
+		}
+	})
+
+	t.Run("ValidateReportsIndex", func(t *testing.T) {
+		err := Collection{version, NewHeader("", "x", MatchExact)}.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "condition 1")
+	})
+}
@@ -0,0 +1,138 @@
+package conditions
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+// HTTPRegex matches requests whose full path matches a regular expression.
+// Named capture groups become path params for the matched app.
+type HTTPRegex struct {
+	Pattern string `env_interpolation:"no"`
+	Method  string `env_interpolation:"no"`
+
+	// pathPrefix is set when the source config also had a path prefix, which
+	// is reported during validation since the two are mutually exclusive
+	pathPrefix string
+
+	// compiled is the anchored pattern, set during validation
+	compiled *regexp.Regexp
+}
+
+// NewHTTPRegex creates a new HTTP path regex condition
+func NewHTTPRegex(pattern string, method string) *HTTPRegex {
+	return &HTTPRegex{
+		Pattern: pattern,
+		Method:  method,
+	}
+}
+
+// Type returns the condition type
+func (h *HTTPRegex) Type() Type { return TypeHTTPRegex }
+
+// Value returns a representative value
+func (h *HTTPRegex) Value() string {
+	if h.Method != "" {
+		return h.Pattern + " (" + h.Method + ")"
+	}
+	return h.Pattern
+}
+
+// Validate checks if the regex condition is valid and compiles the pattern
+func (h *HTTPRegex) Validate() error {
+	if h.pathPrefix != "" {
+		return fmt.Errorf("%w: path_prefix and path_regex are mutually exclusive",
+			ErrInvalidHTTPCondition)
+	}
+
+	if h.Pattern == "" {
+		return fmt.Errorf("%w: path regex: %w", ErrInvalidHTTPCondition, ErrEmptyValue)
+	}
+
+	// The base path is derived from the pattern's literal prefix, so the
+	// pattern must begin with the path itself rather than an anchor or group
+	if !strings.HasPrefix(h.Pattern, "/") {
+		return fmt.Errorf("%w: path regex must start with '/'", ErrInvalidHTTPCondition)
+	}
+
+	compiled, err := regexp.Compile("^(?:" + h.Pattern + ")$")
+	if err != nil {
+		return fmt.Errorf("%w: path regex '%s': %w", ErrInvalidHTTPCondition, h.Pattern, err)
+	}
+	h.compiled = compiled
+
+	return nil
+}
+
+// BasePath returns the path prefix shared by every path the pattern can
+// match, cut back to the last '/'. Routes are registered on this path and
+// the pattern is then checked against the full request path.
+func (h *HTTPRegex) BasePath() string {
+	prefix := h.Pattern
+	if re, err := regexp.Compile(h.Pattern); err == nil {
+		prefix, _ = re.LiteralPrefix()
+	}
+
+	idx := strings.LastIndex(prefix, "/")
+	if idx < 0 {
+		return "/"
+	}
+	base := prefix[:idx+1]
+
+	// Braces are wildcard syntax for http.ServeMux patterns
+	if strings.ContainsAny(base, "{}") {
+		return "/"
+	}
+	return base
+}
+
+// MatchRequest reports whether the full request path matches the pattern
+func (h *HTTPRegex) MatchRequest(r *http.Request) bool {
+	return h.compiled != nil && h.compiled.MatchString(r.URL.Path)
+}
+
+// PathParams returns the named capture groups for a matching path, or nil
+// when the path doesn't match or the pattern has no named groups.
+func (h *HTTPRegex) PathParams(path string) map[string]string {
+	if h.compiled == nil {
+		return nil
+	}
+	match := h.compiled.FindStringSubmatch(path)
+	if match == nil {
+		return nil
+	}
+
+	var params map[string]string
+	for i, name := range h.compiled.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+		if params == nil {
+			params = make(map[string]string)
+		}
+		params[name] = match[i]
+	}
+	return params
+}
+
+// String returns a string representation of the regex condition
+func (h *HTTPRegex) String() string {
+	if h.Method != "" {
+		return fmt.Sprintf("HTTP Regex: %s %s", h.Method, h.Pattern)
+	}
+	return fmt.Sprintf("HTTP Path Regex: %s", h.Pattern)
+}
+
+// ToTree returns a tree representation of the regex condition
+func (h *HTTPRegex) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("HTTP Regex Rule")
+	tree.AddChild(fmt.Sprintf("Path Regex: %s", h.Pattern))
+	if h.Method != "" {
+		tree.AddChild(fmt.Sprintf("Method: %s", h.Method))
+	}
+	return tree
+}
@@ -0,0 +1,107 @@
+package conditions
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRegexCondition(t *testing.T) {
+	t.Run("Constructor", func(t *testing.T) {
+		cond := NewHTTPRegex(`/users/(?P<id>\d+)`, "GET")
+		assert.Equal(t, `/users/(?P<id>\d+)`, cond.Pattern)
+		assert.Equal(t, "GET", cond.Method)
+		assert.Equal(t, TypeHTTPRegex, cond.Type())
+		assert.Equal(t, `/users/(?P<id>\d+) (GET)`, cond.Value())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			pattern string
+			wantErr error
+		}{
+			{"named capture", `/users/(?P<id>\d+)`, nil},
+			{"plain regex", `/static/.*\.css`, nil},
+			{"empty", "", ErrEmptyValue},
+			{"missing leading slash", `users/\d+`, ErrInvalidHTTPCondition},
+			{"leading anchor", `^/users`, ErrInvalidHTTPCondition},
+			{"bad regex", `/users/(\d+`, ErrInvalidHTTPCondition},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := NewHTTPRegex(tt.pattern, "").Validate()
+				if tt.wantErr == nil {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorIs(t, err, ErrInvalidHTTPCondition)
+				require.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("BasePath", func(t *testing.T) {
+		tests := []struct {
+			pattern string
+			want    string
+		}{
+			{`/users/(?P<id>\d+)`, "/users/"},
+			{`/api/v(\d+)/items`, "/api/"},
+			{`/health`, "/"},
+			{`/(.*)`, "/"},
+			{`/a/b/c/[0-9]+`, "/a/b/c/"},
+			{`/files/x{2}/.*`, "/files/xx/"},
+			{`/a/x|/b/y`, "/"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.pattern, func(t *testing.T) {
+				cond := NewHTTPRegex(tt.pattern, "")
+				require.NoError(t, cond.Validate())
+				assert.Equal(t, tt.want, cond.BasePath())
+			})
+		}
+	})
+
+	t.Run("MatchRequest", func(t *testing.T) {
+		cond := NewHTTPRegex(`/users/(?P<id>\d+)`, "")
+		require.NoError(t, cond.Validate())
+
+		assert.True(t, cond.MatchRequest(httptest.NewRequest("GET", "/users/42", nil)))
+		assert.False(t, cond.MatchRequest(httptest.NewRequest("GET", "/users/abc", nil)))
+		assert.False(t, cond.MatchRequest(httptest.NewRequest("GET", "/users/42/posts", nil)),
+			"pattern must match the full path")
+		assert.False(t, cond.MatchRequest(httptest.NewRequest("GET", "/v1/users/42", nil)))
+	})
+
+	t.Run("UnvalidatedNeverMatches", func(t *testing.T) {
+		cond := NewHTTPRegex(`/users/.*`, "")
+		assert.False(t, cond.MatchRequest(httptest.NewRequest("GET", "/users/1", nil)))
+		assert.Nil(t, cond.PathParams("/users/1"))
+	})
+
+	t.Run("PathParams", func(t *testing.T) {
+		cond := NewHTTPRegex(`/users/(?P<id>\d+)/posts/(?P<slug>[a-z-]+)`, "")
+		require.NoError(t, cond.Validate())
+
+		params := cond.PathParams("/users/7/posts/hello-world")
+		assert.Equal(t, map[string]string{"id": "7", "slug": "hello-world"}, params)
+		assert.Nil(t, cond.PathParams("/users/x/posts/y"))
+
+		unnamed := NewHTTPRegex(`/users/(\d+)`, "")
+		require.NoError(t, unnamed.Validate())
+		assert.Nil(t, unnamed.PathParams("/users/7"))
+	})
+
+	t.Run("ToTree", func(t *testing.T) {
+		tree := NewHTTPRegex(`/users/\d+`, "GET").ToTree()
+		out := tree.Tree().String()
+		assert.Contains(t, out, "HTTP Regex Rule")
+		assert.Contains(t, out, `Path Regex: /users/\d+`)
+		assert.Contains(t, out, "Method: GET")
+	})
+}
@@ -0,0 +1,90 @@
+package conditions
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+// knownMethods lists the request methods a Method condition accepts
+var knownMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// Method matches requests using any one of a set of HTTP methods
+type Method struct {
+	Methods []string `env_interpolation:"no"`
+}
+
+// NewMethod creates a new method condition
+func NewMethod(methods ...string) *Method {
+	return &Method{Methods: methods}
+}
+
+// Type returns the condition type
+func (m *Method) Type() Type { return TypeMethod }
+
+// Value returns the upper-cased methods, sorted and comma separated
+func (m *Method) Value() string {
+	return strings.Join(m.normalized(), ",")
+}
+
+// normalized returns the upper-cased, sorted and de-duplicated methods
+func (m *Method) normalized() []string {
+	methods := make([]string, 0, len(m.Methods))
+	for _, method := range m.Methods {
+		methods = append(methods, strings.ToUpper(method))
+	}
+	slices.Sort(methods)
+	return slices.Compact(methods)
+}
+
+// Validate checks that at least one method is set and every method is known.
+// Methods are normalized to upper case.
+func (m *Method) Validate() error {
+	if len(m.Methods) == 0 {
+		return fmt.Errorf("%w: methods: %w", ErrInvalidMethodCondition, ErrEmptyValue)
+	}
+
+	for _, method := range m.Methods {
+		if !slices.Contains(knownMethods, strings.ToUpper(method)) {
+			return fmt.Errorf("%w: unknown method '%s'", ErrInvalidMethodCondition, method)
+		}
+	}
+
+	m.Methods = m.normalized()
+	return nil
+}
+
+// MatchRequest reports whether the request method is one of the methods
+func (m *Method) MatchRequest(r *http.Request) bool {
+	for _, method := range m.Methods {
+		if strings.EqualFold(method, r.Method) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a string representation of the method condition
+func (m *Method) String() string {
+	return fmt.Sprintf("Method: %s", m.Value())
+}
+
+// ToTree returns a tree representation of the method condition
+func (m *Method) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("Method Rule")
+	tree.AddChild(fmt.Sprintf("Methods: %s", m.Value()))
+	return tree
+}
@@ -0,0 +1,83 @@
+package conditions
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodCondition(t *testing.T) {
+	t.Run("Constructor", func(t *testing.T) {
+		cond := NewMethod("put", "POST")
+		assert.Equal(t, []string{"put", "POST"}, cond.Methods)
+		assert.Equal(t, TypeMethod, cond.Type())
+		assert.Equal(t, "POST,PUT", cond.Value(), "value is normalized and sorted")
+		assert.Equal(t, "Method: POST,PUT", cond.String())
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			methods []string
+			wantErr error
+		}{
+			{"single", []string{"GET"}, nil},
+			{"multiple", []string{"POST", "PUT"}, nil},
+			{"lower case", []string{"patch"}, nil},
+			{"empty", nil, ErrEmptyValue},
+			{"unknown", []string{"GET", "FETCH"}, ErrInvalidMethodCondition},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := NewMethod(tt.methods...).Validate()
+				if tt.wantErr == nil {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorIs(t, err, ErrInvalidMethodCondition)
+				require.ErrorIs(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("ValidateNormalizes", func(t *testing.T) {
+		cond := NewMethod("put", "POST", "PUT")
+		require.NoError(t, cond.Validate())
+		assert.Equal(t, []string{"POST", "PUT"}, cond.Methods)
+	})
+
+	t.Run("MatchRequest", func(t *testing.T) {
+		cond := NewMethod("POST", "PUT")
+		require.NoError(t, cond.Validate())
+
+		assert.True(t, cond.MatchRequest(httptest.NewRequest("POST", "/", nil)))
+		assert.True(t, cond.MatchRequest(httptest.NewRequest("PUT", "/", nil)))
+		assert.False(t, cond.MatchRequest(httptest.NewRequest("GET", "/", nil)))
+	})
+
+	t.Run("CombinesWithHeaders", func(t *testing.T) {
+		conds := Collection{
+			NewMethod("POST"),
+			NewHeader("Content-Type", "application/json", MatchExact),
+		}
+		require.NoError(t, conds.Validate())
+
+		req := httptest.NewRequest("POST", "/", nil)
+		assert.False(t, conds.MatchRequest(req))
+		req.Header.Set("Content-Type", "application/json")
+		assert.True(t, conds.MatchRequest(req))
+
+		get := httptest.NewRequest("GET", "/", nil)
+		get.Header.Set("Content-Type", "application/json")
+		assert.False(t, conds.MatchRequest(get))
+	})
+
+	t.Run("ToTree", func(t *testing.T) {
+		out := NewMethod("GET").ToTree().Tree().String()
+		assert.Contains(t, out, "Method Rule")
+		assert.Contains(t, out, "Methods: GET")
+	})
+}
@@ -12,6 +12,12 @@ func FromProto(route *pb.Route) Condition {
 
 	// Handle HTTP rule
 	if httpRule := route.GetHttp(); httpRule != nil {
+		if pattern := httpRule.GetPathRegex(); pattern != "" {
+			cond := NewHTTPRegex(pattern, httpRule.GetMethod())
+			cond.pathPrefix = httpRule.GetPathPrefix()
+			return cond
+		}
+
 		pathPrefix := ""
 		if httpRule.PathPrefix != nil {
 			pathPrefix = *httpRule.PathPrefix
@@ -44,5 +50,80 @@ func ToProto(cond Condition, route *pb.Route) {
 			httpRule.Method = &c.Method
 		}
 		route.Rule = &pb.Route_Http{Http: httpRule}
+	case *HTTPRegex:
+		httpRule := &pb.HttpRule{
+			PathRegex: &c.Pattern,
+		}
+		if c.Method != "" {
+			httpRule.Method = &c.Method
+		}
+		route.Rule = &pb.Route_Http{Http: httpRule}
+	}
+}
+
+// CollectionFromProto creates the additional request conditions of a protobuf Route
+func CollectionFromProto(route *pb.Route) Collection {
+	if route == nil || (len(route.GetHeaders()) == 0 && len(route.GetMethods()) == 0) {
+		return nil
+	}
+
+	conds := make(Collection, 0, len(route.GetHeaders())+1)
+	if methods := route.GetMethods(); len(methods) > 0 {
+		conds = append(conds, NewMethod(methods...))
+	}
+	for _, h := range route.GetHeaders() {
+		if h == nil {
+			continue
+		}
+		conds = append(conds, NewHeader(h.GetName(), h.GetValue(), matchModeFromProto(h.GetMatch())))
+	}
+	return conds
+}
+
+// CollectionToProto stores the additional request conditions on a protobuf Route
+func CollectionToProto(conds Collection, route *pb.Route) {
+	if len(conds) == 0 || route == nil {
+		return
+	}
+
+	for _, cond := range conds {
+		switch c := cond.(type) {
+		case *Header:
+			route.Headers = append(route.Headers, &pb.HeaderCondition{
+				Name:  &c.Name,
+				Value: &c.MatchValue,
+				Match: matchModeToProto(c.Mode).Enum(),
+			})
+		case *Method:
+			route.Methods = append(route.Methods, c.Methods...)
+		}
+	}
+}
+
+// matchModeFromProto converts a protobuf header match mode to a MatchMode
+func matchModeFromProto(mode pb.HeaderCondition_MatchMode) MatchMode {
+	switch mode {
+	case pb.HeaderCondition_MATCH_MODE_EXACT:
+		return MatchExact
+	case pb.HeaderCondition_MATCH_MODE_PREFIX:
+		return MatchPrefix
+	case pb.HeaderCondition_MATCH_MODE_REGEX:
+		return MatchRegex
+	default:
+		return MatchExact
+	}
+}
+
+// matchModeToProto converts a MatchMode to a protobuf header match mode
+func matchModeToProto(mode MatchMode) pb.HeaderCondition_MatchMode {
+	switch mode {
+	case MatchExact:
+		return pb.HeaderCondition_MATCH_MODE_EXACT
+	case MatchPrefix:
+		return pb.HeaderCondition_MATCH_MODE_PREFIX
+	case MatchRegex:
+		return pb.HeaderCondition_MATCH_MODE_REGEX
+	default:
+		return pb.HeaderCondition_MATCH_MODE_UNSPECIFIED
 	}
 }
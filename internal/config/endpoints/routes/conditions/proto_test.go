@@ -53,6 +53,40 @@ func TestCondition_FromProto(t *testing.T) {
 		assert.Empty(t, httpCond.Method)
 	})
 
+	t.Run("HttpRuleRegex", func(t *testing.T) {
+		pattern := `/users/(?P<id>\d+)`
+		pbRoute := &pb.Route{
+			Rule: &pb.Route_Http{
+				Http: &pb.HttpRule{
+					PathRegex: &pattern,
+				},
+			},
+		}
+		cond := FromProto(pbRoute)
+		assert.NotNil(t, cond)
+		assert.Equal(t, TypeHTTPRegex, cond.Type())
+		regexCond, ok := cond.(*HTTPRegex)
+		assert.True(t, ok)
+		assert.Equal(t, pattern, regexCond.Pattern)
+		assert.NoError(t, regexCond.Validate())
+	})
+
+	t.Run("HttpRuleRegexWithPrefix", func(t *testing.T) {
+		pattern := "/users/.*"
+		pathPrefix := "/users"
+		pbRoute := &pb.Route{
+			Rule: &pb.Route_Http{
+				Http: &pb.HttpRule{
+					PathPrefix: &pathPrefix,
+					PathRegex:  &pattern,
+				},
+			},
+		}
+		cond := FromProto(pbRoute)
+		assert.Equal(t, TypeHTTPRegex, cond.Type())
+		assert.ErrorIs(t, cond.Validate(), ErrInvalidHTTPCondition)
+	})
+
 	t.Run("NoRule", func(t *testing.T) {
 		pbRoute := &pb.Route{}
 		cond := FromProto(pbRoute)
@@ -96,4 +130,61 @@ func TestCondition_ToProto(t *testing.T) {
 		assert.Equal(t, "/api", *httpRule.Http.PathPrefix)
 		assert.Nil(t, httpRule.Http.Method)
 	})
+
+	t.Run("HttpRuleRegex", func(t *testing.T) {
+		cond := NewHTTPRegex(`/users/(?P<id>\d+)`, "")
+		pbRoute := &pb.Route{}
+		ToProto(cond, pbRoute)
+		httpRule, ok := pbRoute.Rule.(*pb.Route_Http)
+		assert.True(t, ok)
+		assert.Equal(t, `/users/(?P<id>\d+)`, httpRule.Http.GetPathRegex())
+		assert.Nil(t, httpRule.Http.PathPrefix)
+		assert.Nil(t, httpRule.Http.Method)
+	})
+}
+
+func TestCollection_ProtoRoundTrip(t *testing.T) {
+	t.Run("NilRoute", func(t *testing.T) {
+		assert.Nil(t, CollectionFromProto(nil))
+		CollectionToProto(Collection{NewHeader("X-Test", "1", MatchExact)}, nil)
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		conds := Collection{
+			NewHeader("X-API-Version", "2", MatchExact),
+			NewHeader("Accept", "application/", MatchPrefix),
+			NewHeader("User-Agent", "^curl/", MatchRegex),
+		}
+
+		pbRoute := &pb.Route{}
+		CollectionToProto(conds, pbRoute)
+		assert.Len(t, pbRoute.GetHeaders(), 3)
+		assert.Equal(t, pb.HeaderCondition_MATCH_MODE_PREFIX, pbRoute.GetHeaders()[1].GetMatch())
+
+		assert.Equal(t, conds, CollectionFromProto(pbRoute))
+	})
+
+	t.Run("UnspecifiedModeDefaultsToExact", func(t *testing.T) {
+		name, value := "X-Test", "1"
+		mode := pb.HeaderCondition_MATCH_MODE_UNSPECIFIED
+		pbRoute := &pb.Route{
+			Headers: []*pb.HeaderCondition{{Name: &name, Value: &value, Match: &mode}},
+		}
+		conds := CollectionFromProto(pbRoute)
+		assert.Equal(t, Collection{NewHeader("X-Test", "1", MatchExact)}, conds)
+	})
+
+	t.Run("Methods", func(t *testing.T) {
+		conds := Collection{
+			NewMethod("POST", "PUT"),
+			NewHeader("X-API-Version", "2", MatchExact),
+		}
+
+		pbRoute := &pb.Route{}
+		CollectionToProto(conds, pbRoute)
+		assert.Equal(t, []string{"POST", "PUT"}, pbRoute.GetMethods())
+		assert.Len(t, pbRoute.GetHeaders(), 1)
+
+		assert.Equal(t, conds, CollectionFromProto(pbRoute))
+	})
 }
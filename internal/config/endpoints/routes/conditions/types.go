@@ -11,9 +11,12 @@ type Type string
 
 // Constants for Type
 const (
-	Unknown  Type = ""
-	TypeHTTP Type = "http_path"
-	TypeMCP  Type = "mcp_resource" // For future use with MCP protocol
+	Unknown       Type = ""
+	TypeHTTP      Type = "http_path"
+	TypeHTTPRegex Type = "http_path_regex"
+	TypeHeader    Type = "http_header"
+	TypeMethod    Type = "http_method"
+	TypeMCP       Type = "mcp_resource" // For future use with MCP protocol
 )
 
 // Condition represents a matching condition for a route
@@ -30,6 +33,12 @@ func TypeString(t Type) string {
 	switch t {
 	case TypeHTTP:
 		return "HTTP Path"
+	case TypeHTTPRegex:
+		return "HTTP Path Regex"
+	case TypeHeader:
+		return "HTTP Header"
+	case TypeMethod:
+		return "HTTP Method"
 	case TypeMCP:
 		return "MCP Resource"
 	case Unknown:
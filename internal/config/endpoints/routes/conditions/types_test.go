@@ -11,6 +11,9 @@ func TestTypeConstants(t *testing.T) {
 	// Test type constants
 	assert.Equal(t, Unknown, Type(""))
 	assert.Equal(t, TypeHTTP, Type("http_path"))
+	assert.Equal(t, TypeHTTPRegex, Type("http_path_regex"))
+	assert.Equal(t, TypeHeader, Type("http_header"))
+	assert.Equal(t, TypeMethod, Type("http_method"))
 	assert.Equal(t, TypeMCP, Type("mcp_resource"))
 
 	// Test string representation
@@ -33,6 +36,9 @@ func TestTypeString(t *testing.T) {
 		expected string
 	}{
 		{TypeHTTP, "HTTP Path"},
+		{TypeHTTPRegex, "HTTP Path Regex"},
+		{TypeHeader, "HTTP Header"},
+		{TypeMethod, "HTTP Method"},
 		{TypeMCP, "MCP Resource"},
 		{Unknown, "Unknown"},
 		{Type("custom"), "Custom(custom)"},
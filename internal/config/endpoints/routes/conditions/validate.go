@@ -5,7 +5,7 @@ import "fmt"
 // ValidateType checks if a condition Type is supported
 func ValidateType(t Type) error {
 	switch t {
-	case TypeHTTP, TypeMCP:
+	case TypeHTTP, TypeHTTPRegex, TypeHeader, TypeMethod, TypeMCP:
 		return nil
 	case Unknown:
 		return fmt.Errorf("%w: empty condition type", ErrInvalidConditionType)
@@ -9,7 +9,7 @@ import (
 
 func TestValidateType(t *testing.T) {
 	t.Run("ValidTypes", func(t *testing.T) {
-		validTypes := []Type{TypeHTTP, TypeMCP}
+		validTypes := []Type{TypeHTTP, TypeHTTPRegex, TypeHeader, TypeMethod, TypeMCP}
 		for _, validType := range validTypes {
 			err := ValidateType(validType)
 			require.NoError(t, err, "Type %s should be valid", validType)
@@ -0,0 +1,62 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+)
+
+// HTTPPath returns the path an HTTP route is registered on with the listener's
+// mux: the path prefix, or the base path of a path regex. It returns false for
+// routes without an HTTP condition.
+func (r *Route) HTTPPath() (string, bool) {
+	switch cond := r.Condition.(type) {
+	case *conditions.HTTP:
+		return cond.PathPrefix, true
+	case *conditions.HTTPRegex:
+		return cond.BasePath(), true
+	default:
+		return "", false
+	}
+}
+
+// PathsConflict returns an error when the listener's mux can't serve both
+// paths, so the routes registered on them would stop the listener from
+// starting. This happens when the paths match the same requests through
+// differently named wildcards (e.g. "/users/{id}" and "/users/{name}"), or
+// when they overlap without either being more specific (e.g. "/a/{x}/b" and
+// "/a/y/{z}"). Identical paths don't conflict, since routes sharing a path are
+// dispatched together, and neither do nested prefixes such as "/api/" and
+// "/api/v1/", as the mux picks the most specific one. A path the mux rejects on
+// its own is not reported here.
+func PathsConflict(a, b string) error {
+	if a == b || registerPaths(a) != nil || registerPaths(b) != nil {
+		return nil
+	}
+	return registerPaths(a, b)
+}
+
+// registerPaths registers the paths on a throwaway mux, returning the reason it
+// gives for refusing one
+func registerPaths(paths ...string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// The first line names the patterns and where they were
+			// registered, the rest explains the conflict
+			msg := fmt.Sprint(r)
+			if _, reason, found := strings.Cut(msg, "\n"); found {
+				msg = reason
+			}
+			err = errors.New(strings.ReplaceAll(msg, "\n", " "))
+		}
+	}()
+
+	mux := http.NewServeMux()
+	for _, path := range paths {
+		mux.Handle(path, http.NotFoundHandler())
+	}
+	return nil
+}
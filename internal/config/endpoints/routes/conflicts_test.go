@@ -0,0 +1,94 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoute_HTTPPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		route    Route
+		wantPath string
+		wantOK   bool
+	}{
+		{
+			name:     "path prefix",
+			route:    Route{Condition: conditions.NewHTTP("/api/", "")},
+			wantPath: "/api/",
+			wantOK:   true,
+		},
+		{
+			name:     "path regex",
+			route:    Route{Condition: conditions.NewHTTPRegex("/users/[0-9]+", "")},
+			wantPath: "/users/",
+			wantOK:   true,
+		},
+		{
+			name:  "no condition",
+			route: Route{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			path, ok := tc.route.HTTPPath()
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantPath, path)
+		})
+	}
+}
+
+func TestPathsConflict(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		a, b        string
+		errContains string
+	}{
+		{name: "identical paths", a: "/api/", b: "/api/"},
+		{name: "nested prefixes", a: "/api/", b: "/api/v1/"},
+		{name: "exact and subtree paths", a: "/api", b: "/api/"},
+		{name: "more specific wildcard", a: "/users/{id}", b: "/users/me"},
+		{name: "invalid path", a: "/users/{", b: "/users/{id}"},
+		{
+			name:        "differently named wildcards",
+			a:           "/users/{id}",
+			b:           "/users/{name}",
+			errContains: "/users/{name} matches the same requests as /users/{id}",
+		},
+		{
+			name:        "differently named trailing wildcards",
+			a:           "/files/{path...}",
+			b:           "/files/{rest...}",
+			errContains: "matches the same requests as",
+		},
+		{
+			name:        "overlap without a more specific path",
+			a:           "/a/{x}/b",
+			b:           "/a/y/{z}",
+			errContains: "neither is more specific than the other",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := PathsConflict(tc.a, tc.b)
+			if tc.errContains == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errContains)
+			assert.NotContains(t, err.Error(), "registered at", "the mux's source locations are dropped")
+		})
+	}
+}
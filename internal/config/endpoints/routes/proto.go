@@ -6,6 +6,7 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/robbyt/protobaggins"
+	"google.golang.org/protobuf/proto"
 )
 
 // ToProto converts a Route to a protobuf Route
@@ -25,6 +26,16 @@ func (r *Route) ToProto() *pb.Route {
 	if r.Condition != nil {
 		conditions.ToProto(r.Condition, route)
 	}
+	conditions.CollectionToProto(r.Conditions, route)
+
+	if r.Priority != 0 {
+		route.Priority = proto.Int32(int32(r.Priority))
+	}
+
+	// Enabled is the default, only a disabled route is written out
+	if r.Disabled {
+		route.Enabled = proto.Bool(false)
+	}
 
 	// Convert middlewares if present
 	if len(r.Middlewares) > 0 {
@@ -41,7 +52,9 @@ func RouteFromProto(r *pb.Route) Route {
 	}
 
 	route := Route{
-		AppID: protobaggins.StringFromProto(r.AppId),
+		AppID:    protobaggins.StringFromProto(r.AppId),
+		Priority: int(r.GetPriority()),
+		Disabled: !r.GetEnabled(),
 	}
 
 	// Convert static data
@@ -51,6 +64,7 @@ func RouteFromProto(r *pb.Route) Route {
 
 	// Convert condition using the conditions package
 	route.Condition = conditions.FromProto(r)
+	route.Conditions = conditions.CollectionFromProto(r)
 
 	// Convert middlewares if present
 	if len(r.Middlewares) > 0 {
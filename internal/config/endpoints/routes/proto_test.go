@@ -7,6 +7,7 @@ import (
 	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/structpb"
 )
@@ -241,6 +242,44 @@ func TestRoute_ToProto(t *testing.T) {
 	}
 }
 
+func TestRoute_PriorityProto(t *testing.T) {
+	t.Parallel()
+
+	route := Route{
+		AppID:     "app1",
+		Condition: conditions.NewHTTP("/api", ""),
+		Priority:  -3,
+	}
+	pbRoute := route.ToProto()
+	require.NotNil(t, pbRoute.Priority)
+	assert.Equal(t, int32(-3), pbRoute.GetPriority())
+	assert.Equal(t, -3, RouteFromProto(pbRoute).Priority)
+
+	// The default priority is left unset
+	route.Priority = 0
+	assert.Nil(t, route.ToProto().Priority)
+}
+
+func TestRoute_EnabledProto(t *testing.T) {
+	t.Parallel()
+
+	route := Route{
+		AppID:     "app1",
+		Condition: conditions.NewHTTP("/api", ""),
+		Disabled:  true,
+	}
+	pbRoute := route.ToProto()
+	require.NotNil(t, pbRoute.Enabled)
+	assert.False(t, pbRoute.GetEnabled())
+	assert.True(t, RouteFromProto(pbRoute).Disabled)
+
+	// Routes are enabled unless set otherwise
+	route.Disabled = false
+	pbRoute = route.ToProto()
+	assert.Nil(t, pbRoute.Enabled)
+	assert.False(t, RouteFromProto(pbRoute).Disabled)
+}
+
 func TestFromProto(t *testing.T) {
 	t.Parallel()
 
@@ -62,48 +62,82 @@ type Route struct {
 	StaticData  map[string]any
 	Condition   conditions.Condition
 	Middlewares middleware.MiddlewareCollection
+
+	// Conditions are additional request conditions (e.g. headers) that must
+	// all match, together with Condition, for this route to be selected.
+	Conditions conditions.Collection
+
+	// Priority orders this route among the routes sharing its path on the
+	// listener. Higher priorities are tried first, see HTTPRoute.Priority.
+	Priority int
+
+	// Disabled keeps the route in the config without dispatching requests to
+	// it. Routes are enabled by default.
+	Disabled bool
 }
 
 // ToTree returns a styled tree node for this Route
 func (r *Route) ToTree() *fancy.ComponentTree {
 	conditionInfo := "none"
 	if r.Condition != nil {
-		conditionInfo = fmt.Sprintf("%s:%s", r.Condition.Type(), r.Condition.Value())
+		conditionInfo = r.ConditionKey()
 	}
 
-	text := fancy.RouteText(fmt.Sprintf("Route: %s -> %s", conditionInfo, r.AppID))
+	label := fmt.Sprintf("Route: %s -> %s", conditionInfo, r.AppID)
+	if r.Disabled {
+		label += " (disabled)"
+	}
+	text := fancy.RouteText(label)
 	return fancy.RouteTree(text)
 }
 
+// ConditionKey returns a "type:value" string identifying the route's condition,
+// followed by any additional request conditions. Two routes with the same key
+// match exactly the same requests. The key is empty when Condition is nil.
+func (r *Route) ConditionKey() string {
+	if r.Condition == nil {
+		return ""
+	}
+	key := fmt.Sprintf("%s:%s", r.Condition.Type(), r.Condition.Value())
+	if extra := r.Conditions.Key(); extra != "" {
+		key += " && " + extra
+	}
+	return key
+}
+
 // GetStructuredHTTPRoutes returns HTTP routes from this collection in a structured format.
 // This extracts routes with HTTP conditions and returns them as the more type-safe HTTPRoute
-// structure with path, app ID, and static data explicitly defined.
+// structure with path, app ID, and static data explicitly defined. Disabled
+// routes are left out, the Index of each HTTPRoute still refers to this
+// collection.
 func (r RouteCollection) GetStructuredHTTPRoutes() []HTTPRoute {
 	var httpRoutes []HTTPRoute
 
-	for _, route := range r {
-		// Skip non-HTTP routes
-		if route.Condition == nil {
-			continue
-		}
-
-		// Use string comparison instead of direct type comparison
-		if string(route.Condition.Type()) != string(conditions.TypeHTTP) {
-			continue
-		}
-
-		// Get the HTTP condition
-		httpCond, ok := route.Condition.(*conditions.HTTP)
-		if !ok {
+	for i, route := range r {
+		// Skip disabled and non-HTTP routes
+		if route.Disabled || route.Condition == nil {
 			continue
 		}
 
 		httpRoute := HTTPRoute{
-			PathPrefix: httpCond.PathPrefix,
-			Method:     httpCond.Method,
 			AppID:      route.AppID,
 			App:        route.App,
 			StaticData: route.StaticData,
+			Conditions: route.Conditions,
+			Priority:   route.Priority,
+			Index:      i,
+		}
+
+		switch cond := route.Condition.(type) {
+		case *conditions.HTTP:
+			httpRoute.PathPrefix = cond.PathPrefix
+			httpRoute.Method = cond.Method
+		case *conditions.HTTPRegex:
+			httpRoute.PathPrefix = cond.BasePath()
+			httpRoute.Method = cond.Method
+			httpRoute.PathRegex = cond
+		default:
+			continue
 		}
 
 		httpRoutes = append(httpRoutes, httpRoute)
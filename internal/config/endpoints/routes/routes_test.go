@@ -5,6 +5,7 @@ import (
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetStructuredHTTPRoutes(t *testing.T) {
@@ -94,6 +95,20 @@ func TestGetStructuredHTTPRoutes(t *testing.T) {
 	}
 }
 
+func TestGetStructuredHTTPRoutes_Disabled(t *testing.T) {
+	t.Parallel()
+
+	routes := RouteCollection{
+		{AppID: "app1", Condition: conditions.NewHTTP("/a", ""), Disabled: true},
+		{AppID: "app2", Condition: conditions.NewHTTP("/b", "")},
+	}
+
+	result := routes.GetStructuredHTTPRoutes()
+	require.Len(t, result, 1, "disabled routes are skipped")
+	assert.Equal(t, "app2", result[0].AppID)
+	assert.Equal(t, 1, result[0].Index, "the index still refers to the collection")
+}
+
 func TestCollectionBasics(t *testing.T) {
 	t.Parallel()
 
@@ -113,3 +128,66 @@ func TestCollectionBasics(t *testing.T) {
 	assert.Equal(t, "test-app", routes[0].AppID)
 	assert.Equal(t, "test-app-2", routes[1].AppID)
 }
+
+func TestRoute_ConditionKey(t *testing.T) {
+	t.Parallel()
+
+	plain := Route{AppID: "v1", Condition: conditions.NewHTTP("/api", "")}
+	assert.Equal(t, "http_path:/api", plain.ConditionKey())
+
+	withHeader := Route{
+		AppID:     "v2",
+		Condition: conditions.NewHTTP("/api", ""),
+		Conditions: conditions.Collection{
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact),
+		},
+	}
+	assert.Equal(t, "http_path:/api && http_header:X-Api-Version exact 2", withHeader.ConditionKey())
+
+	assert.Empty(t, (&Route{AppID: "none"}).ConditionKey())
+
+	httpRoutes := RouteCollection{plain, withHeader}.GetStructuredHTTPRoutes()
+	assert.Len(t, httpRoutes, 2)
+	assert.Empty(t, httpRoutes[0].Conditions)
+	assert.Equal(t, withHeader.Conditions, httpRoutes[1].Conditions)
+}
+
+func TestRoute_HeaderConditionsProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	route := Route{
+		AppID:     "v2",
+		Condition: conditions.NewHTTP("/api", ""),
+		Conditions: conditions.Collection{
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact),
+			conditions.NewHeader("User-Agent", "^curl/", conditions.MatchRegex),
+		},
+	}
+
+	pbRoute := route.ToProto()
+	assert.Len(t, pbRoute.GetHeaders(), 2)
+
+	converted := RouteFromProto(pbRoute)
+	assert.Equal(t, route.Conditions, converted.Conditions)
+	assert.Equal(t, route.ConditionKey(), converted.ConditionKey())
+}
+
+func TestGetStructuredHTTPRoutes_Regex(t *testing.T) {
+	t.Parallel()
+
+	regex := conditions.NewHTTPRegex(`/users/(?P<id>\d+)`, "")
+	require.NoError(t, regex.Validate())
+
+	httpRoutes := RouteCollection{
+		{AppID: "users", Condition: regex},
+		{AppID: "api", Condition: conditions.NewHTTP("/api", "")},
+	}.GetStructuredHTTPRoutes()
+	require.Len(t, httpRoutes, 2)
+
+	assert.Equal(t, "/users/", httpRoutes[0].PathPrefix, "regex routes register on their base path")
+	assert.Same(t, regex, httpRoutes[0].PathRegex)
+	assert.Equal(t, `HTTPRoute: ~/users/(?P<id>\d+) -> users`, httpRoutes[0].String())
+
+	assert.Equal(t, "/api", httpRoutes[1].PathPrefix)
+	assert.Nil(t, httpRoutes[1].PathRegex)
+}
@@ -11,14 +11,19 @@ func (r *Route) String() string {
 	var b strings.Builder
 
 	if r.Condition != nil {
-		fmt.Fprintf(&b, "Route %s:%s -> %s",
-			r.Condition.Type(),
-			r.Condition.Value(),
-			r.AppID)
+		fmt.Fprintf(&b, "Route %s -> %s", r.ConditionKey(), r.AppID)
 	} else {
 		fmt.Fprintf(&b, "Route <no-condition> -> %s", r.AppID)
 	}
 
+	if r.Priority != 0 {
+		fmt.Fprintf(&b, " (priority %d)", r.Priority)
+	}
+
+	if r.Disabled {
+		fmt.Fprintf(&b, " (disabled)")
+	}
+
 	if len(r.StaticData) > 0 {
 		fmt.Fprintf(&b, " (with StaticData: ")
 		keys := make([]string, 0, len(r.StaticData))
@@ -43,10 +48,22 @@ func (r *Route) String() string {
 // String returns a string representation of an HTTPRoute
 func (r HTTPRoute) String() string {
 	var b strings.Builder
+	path := r.PathPrefix
+	if r.PathRegex != nil {
+		path = "~" + r.PathRegex.Pattern
+	}
 	if r.Method != "" {
-		fmt.Fprintf(&b, "HTTPRoute: %s %s -> %s", r.Method, r.PathPrefix, r.AppID)
+		fmt.Fprintf(&b, "HTTPRoute: %s %s -> %s", r.Method, path, r.AppID)
 	} else {
-		fmt.Fprintf(&b, "HTTPRoute: %s -> %s", r.PathPrefix, r.AppID)
+		fmt.Fprintf(&b, "HTTPRoute: %s -> %s", path, r.AppID)
+	}
+
+	if len(r.Conditions) > 0 {
+		fmt.Fprintf(&b, " [%s]", r.Conditions.Key())
+	}
+
+	if r.Priority != 0 {
+		fmt.Fprintf(&b, " (priority %d)", r.Priority)
 	}
 
 	if len(r.StaticData) > 0 {
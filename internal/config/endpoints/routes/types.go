@@ -3,14 +3,32 @@ package routes
 import (
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 )
 
 // HTTPRoute represents an HTTP-specific route derived from a domain route
 type HTTPRoute struct {
+	// PathPrefix is the path the route is registered on. For regex routes it
+	// is the pattern's base path, see conditions.HTTPRegex.BasePath.
 	PathPrefix  string
 	Method      string
 	AppID       string
 	App         *apps.App
 	StaticData  map[string]any
 	Middlewares middleware.MiddlewareCollection
+	Conditions  conditions.Collection
+
+	// PathRegex is set for routes matched by a path regex instead of a prefix
+	PathRegex *conditions.HTTPRegex
+
+	// Priority orders the routes sharing a path: higher priorities are tried
+	// first, then more specific routes, then routes declared earlier
+	Priority int
+
+	// Index is the route's position in its RouteCollection
+	Index int
+
+	// ID identifies the route in logs and metrics, see Endpoint.RouteID. It
+	// is set by Endpoint.GetStructuredHTTPRoutes.
+	ID string
 }
@@ -26,6 +26,11 @@ func (r *Route) Validate() error {
 		}
 	}
 
+	// Validate additional request conditions
+	if err := r.Conditions.Validate(); err != nil {
+		errs = append(errs, fmt.Errorf("route conditions: %w", err))
+	}
+
 	// Validate Middlewares
 	if err := r.Middlewares.Validate(); err != nil {
 		errs = append(errs, fmt.Errorf("route middlewares: %w", err))
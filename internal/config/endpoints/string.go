@@ -13,11 +13,23 @@ func (e *Endpoint) String() string {
 	var b strings.Builder
 	fmt.Fprintf(&b, "Endpoint %s", e.ID)
 
-	if e.ListenerID != "" {
-		fmt.Fprintf(&b, " [Listener: %s]", e.ListenerID)
+	if listenerIDs := e.AllListenerIDs(); len(listenerIDs) == 1 {
+		fmt.Fprintf(&b, " [Listener: %s]", listenerIDs[0])
+	} else if len(listenerIDs) > 1 {
+		fmt.Fprintf(&b, " [Listeners: %s]", strings.Join(listenerIDs, ", "))
+	}
+	if e.Disabled {
+		fmt.Fprintf(&b, " (disabled)")
 	}
 
 	fmt.Fprintf(&b, "\nMiddlewares: %d", len(e.Middlewares))
+	if len(e.ExcludeListenerMiddlewares) > 0 {
+		fmt.Fprintf(&b, "\nExcluded Listener Middlewares: %s",
+			strings.Join(e.ExcludeListenerMiddlewares, ", "))
+	}
+	if len(e.StaticData) > 0 {
+		fmt.Fprintf(&b, "\nStatic Data: %d keys", len(e.StaticData))
+	}
 	fmt.Fprintf(&b, "\nRoutes: %d", len(e.Routes))
 
 	for i, route := range e.Routes {
@@ -33,17 +45,24 @@ func (e *Endpoint) ToTree() *fancy.ComponentTree {
 	tree := fancy.NewComponentTree(styles.EndpointID(e.ID))
 
 	// Add listener with consistent styling
-	if e.ListenerID != "" {
+	if listenerIDs := e.AllListenerIDs(); len(listenerIDs) > 0 {
 		tree.AddChild(
-			styles.ListenerRef([]string{e.ListenerID}),
+			styles.ListenerRef(listenerIDs),
 		) // For compatibility with existing styles
 	}
+	if e.Disabled {
+		tree.AddChild("Disabled")
+	}
 
 	// Add middlewares
 	if len(e.Middlewares) > 0 {
 		middlewareTree := e.Middlewares.ToTree()
 		tree.AddChild(middlewareTree.Tree())
 	}
+	if len(e.ExcludeListenerMiddlewares) > 0 {
+		tree.AddChild(fmt.Sprintf("Excluded Listener Middlewares: %s",
+			strings.Join(e.ExcludeListenerMiddlewares, ", ")))
+	}
 
 	// Add routes
 	if len(e.Routes) > 0 {
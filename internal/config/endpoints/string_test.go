@@ -81,6 +81,18 @@ func TestEndpoint_String(t *testing.T) {
 				"/api/v2",             // Second condition value
 			},
 		},
+		{
+			name: "With Static Data",
+			endpoint: Endpoint{
+				ID:         "defaults",
+				ListenerID: "listener1",
+				StaticData: map[string]any{"env": "prod", "region": "us-east-1"},
+			},
+			contains: []string{
+				"defaults",            // ID
+				"Static Data: 2 keys", // Static data count
+			},
+		},
 	}
 
 	for _, tc := range tests {
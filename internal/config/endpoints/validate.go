@@ -13,12 +13,29 @@ func (e *Endpoint) Validate() error {
 
 	// Validate ID
 	if err := validation.ValidateID(e.ID, "endpoint ID"); err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.ForField("id", err))
 	}
 
 	// Validate Listener ID
 	if err := validation.ValidateID(e.ListenerID, "listener ID"); err != nil {
-		errs = append(errs, fmt.Errorf("endpoint '%s' has invalid listener ID: %w", e.ID, err))
+		errs = append(errs, validation.ForField("listener_id",
+			fmt.Errorf("endpoint '%s' has invalid listener ID: %w", e.ID, err)))
+	}
+
+	// Validate the further listener IDs, each listener only once
+	seenListeners := map[string]bool{e.ListenerID: true}
+	for _, listenerID := range e.ListenerIDs {
+		if err := validation.ValidateID(listenerID, "listener ID"); err != nil {
+			errs = append(errs, validation.ForField("listener_ids",
+				fmt.Errorf("endpoint '%s' has invalid listener ID: %w", e.ID, err)))
+			continue
+		}
+		if seenListeners[listenerID] {
+			errs = append(errs, validation.ForField("listener_ids", fmt.Errorf(
+				"%w: endpoint '%s' is attached to listener '%s' more than once",
+				ErrDuplicateID, e.ID, listenerID)))
+		}
+		seenListeners[listenerID] = true
 	}
 
 	// Note: We can't validate listener references here because we don't have the context
@@ -29,22 +46,33 @@ func (e *Endpoint) Validate() error {
 		errs = append(errs, fmt.Errorf("middlewares in endpoint '%s': %w", e.ID, err))
 	}
 
+	// Validate the excluded listener middleware IDs, the parent Config.Validate
+	// checks that the listener has them
+	for _, id := range e.ExcludeListenerMiddlewares {
+		if err := validation.ValidateID(id, "excluded listener middleware ID"); err != nil {
+			errs = append(errs, validation.ForField("exclude_listener_middlewares", err))
+		}
+	}
+
 	// Validate Routes
-	routeConditions := make(map[string]bool)
+	routeConditions := make(map[string]int)
 	for i, route := range e.Routes {
 		// Basic route validation
 		if err := route.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("route %d in endpoint '%s': %w", i, e.ID, err))
+			errs = append(errs, validation.ForComponent(validation.ComponentRoute, e.RouteID(i),
+				fmt.Errorf("route %d in endpoint '%s': %w", i, e.ID, err)))
 		}
 
 		// Check for duplicate route conditions within this endpoint
 		if route.Condition != nil {
-			conditionKey := fmt.Sprintf("%s:%s", route.Condition.Type(), route.Condition.Value())
-			if routeConditions[conditionKey] {
-				errs = append(errs, fmt.Errorf("%w: condition '%s' is duplicated in endpoint '%s'",
-					ErrRouteConflict, conditionKey, e.ID))
+			conditionKey := route.ConditionKey()
+			if first, exists := routeConditions[conditionKey]; exists {
+				errs = append(errs, validation.ForComponent(validation.ComponentRoute, e.RouteID(i),
+					fmt.Errorf("%w: condition '%s' is duplicated in endpoint '%s' by routes '%s' and '%s'",
+						ErrRouteConflict, conditionKey, e.ID, e.RouteID(first), e.RouteID(i))))
+			} else {
+				routeConditions[conditionKey] = i
 			}
-			routeConditions[conditionKey] = true
 		}
 	}
 
@@ -61,6 +61,35 @@ func TestEndpoint_Validate(t *testing.T) {
 			errExpected: true,
 			errContains: "listener ID cannot be empty",
 		},
+		{
+			name: "Valid endpoint - several listeners",
+			endpoint: Endpoint{
+				ID:          "endpoint4",
+				ListenerID:  "listener1",
+				ListenerIDs: []string{"listener2", "listener3"},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Listener attached twice",
+			endpoint: Endpoint{
+				ID:          "endpoint5",
+				ListenerID:  "listener1",
+				ListenerIDs: []string{"listener2", "listener1"},
+			},
+			errExpected: true,
+			errContains: "endpoint 'endpoint5' is attached to listener 'listener1' more than once",
+		},
+		{
+			name: "Invalid further listener ID",
+			endpoint: Endpoint{
+				ID:          "endpoint6",
+				ListenerID:  "listener1",
+				ListenerIDs: []string{""},
+			},
+			errExpected: true,
+			errContains: "endpoint 'endpoint6' has invalid listener ID",
+		},
 		{
 			name: "Route with missing app ID",
 			endpoint: Endpoint{
@@ -108,8 +137,108 @@ func TestEndpoint_Validate(t *testing.T) {
 				},
 			},
 			errExpected: true,
+			errContains: "duplicated in endpoint 'endpoint6' by routes 'endpoint6[0]' and 'endpoint6[1]'",
+		},
+		{
+			name: "Same path with different header conditions",
+			endpoint: Endpoint{
+				ID:         "endpoint7",
+				ListenerID: "listener1",
+				Routes: []routes.Route{
+					{
+						AppID:     "app-v1",
+						Condition: conditions.NewHTTP("/api", ""),
+					},
+					{
+						AppID:     "app-v2",
+						Condition: conditions.NewHTTP("/api", ""),
+						Conditions: conditions.Collection{
+							conditions.NewHeader("X-API-Version", "2", conditions.MatchExact),
+						},
+					},
+				},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Same path with identical header conditions",
+			endpoint: Endpoint{
+				ID:         "endpoint8",
+				ListenerID: "listener1",
+				Routes: []routes.Route{
+					{
+						AppID:     "app1",
+						Condition: conditions.NewHTTP("/api", ""),
+						Conditions: conditions.Collection{
+							conditions.NewHeader("X-API-Version", "2", conditions.MatchExact),
+						},
+					},
+					{
+						AppID:     "app2",
+						Condition: conditions.NewHTTP("/api", ""),
+						Conditions: conditions.Collection{
+							conditions.NewHeader("x-api-version", "2", conditions.MatchExact),
+						},
+					},
+				},
+			},
+			errExpected: true,
 			errContains: "duplicated",
 		},
+		{
+			name: "Invalid header condition",
+			endpoint: Endpoint{
+				ID:         "endpoint9",
+				ListenerID: "listener1",
+				Routes: []routes.Route{
+					{
+						AppID:     "app1",
+						Condition: conditions.NewHTTP("/api", ""),
+						Conditions: conditions.Collection{
+							conditions.NewHeader("User-Agent", "([", conditions.MatchRegex),
+						},
+					},
+				},
+			},
+			errExpected: true,
+			errContains: "invalid header condition",
+		},
+		{
+			name: "Same path with different method conditions",
+			endpoint: Endpoint{
+				ID:         "endpoint10",
+				ListenerID: "listener1",
+				Routes: []routes.Route{
+					{
+						AppID:      "reader",
+						Condition:  conditions.NewHTTP("/items", ""),
+						Conditions: conditions.Collection{conditions.NewMethod("GET")},
+					},
+					{
+						AppID:      "writer",
+						Condition:  conditions.NewHTTP("/items", ""),
+						Conditions: conditions.Collection{conditions.NewMethod("POST", "PUT")},
+					},
+				},
+			},
+			errExpected: false,
+		},
+		{
+			name: "Unknown method condition",
+			endpoint: Endpoint{
+				ID:         "endpoint11",
+				ListenerID: "listener1",
+				Routes: []routes.Route{
+					{
+						AppID:      "app1",
+						Condition:  conditions.NewHTTP("/items", ""),
+						Conditions: conditions.Collection{conditions.NewMethod("FETCH")},
+					},
+				},
+			},
+			errExpected: true,
+			errContains: "invalid method condition",
+		},
 	}
 
 	for _, tc := range tests {
@@ -15,11 +15,17 @@ var (
 	ErrUnsupportedConfigVer   = errz.ErrUnsupportedConfigVer
 
 	// Validation specific errors
-	ErrDuplicateID          = errz.ErrDuplicateID
-	ErrEmptyID              = errz.ErrEmptyID
-	ErrInvalidReference     = errz.ErrInvalidReference
-	ErrMissingRequiredField = errz.ErrMissingRequiredField
-	ErrRouteConflict        = errz.ErrRouteConflict
+	ErrAmbiguousRouteOrder    = errz.ErrAmbiguousRouteOrder
+	ErrDuplicateID            = errz.ErrDuplicateID
+	ErrEmptyID                = errz.ErrEmptyID
+	ErrInvalidReference       = errz.ErrInvalidReference
+	ErrMissingRequiredField   = errz.ErrMissingRequiredField
+	ErrRouteConflict          = errz.ErrRouteConflict
+	ErrInvalidValue           = errz.ErrInvalidValue
+	ErrMiddlewareChainTooLong = errz.ErrMiddlewareChainTooLong
+	ErrOrphanedApp            = errz.ErrOrphanedApp
+	ErrUnreachableRoute       = errz.ErrUnreachableRoute
+	ErrUnusedListener         = errz.ErrUnusedListener
 
 	// Type specific errors
 	ErrInvalidListenerType = errz.ErrInvalidListenerType
@@ -13,12 +13,17 @@ var (
 
 // Validation specific errors
 var (
-	ErrDuplicateID          = errors.New("duplicate ID")
-	ErrEmptyID              = errors.New("empty ID")
-	ErrInvalidReference     = errors.New("invalid reference")
-	ErrInvalidValue         = errors.New("invalid value")
-	ErrMissingRequiredField = errors.New("missing required field")
-	ErrRouteConflict        = errors.New("route conflict")
+	ErrAmbiguousRouteOrder    = errors.New("ambiguous route order")
+	ErrDuplicateID            = errors.New("duplicate ID")
+	ErrEmptyID                = errors.New("empty ID")
+	ErrInvalidReference       = errors.New("invalid reference")
+	ErrInvalidValue           = errors.New("invalid value")
+	ErrMiddlewareChainTooLong = errors.New("middleware chain too long")
+	ErrMissingRequiredField   = errors.New("missing required field")
+	ErrOrphanedApp            = errors.New("app not referenced by any route")
+	ErrRouteConflict          = errors.New("route conflict")
+	ErrUnreachableRoute       = errors.New("unreachable route")
+	ErrUnusedListener         = errors.New("listener has no endpoints")
 )
 
 // Type specific errors
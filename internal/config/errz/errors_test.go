@@ -69,6 +69,11 @@ func TestValidationErrors(t *testing.T) {
 			err:         ErrInvalidValue,
 			expectedMsg: "invalid value",
 		},
+		{
+			name:        "ErrMiddlewareChainTooLong",
+			err:         ErrMiddlewareChainTooLong,
+			expectedMsg: "middleware chain too long",
+		},
 		{
 			name:        "ErrMissingRequiredField",
 			err:         ErrMissingRequiredField,
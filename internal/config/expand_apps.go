@@ -5,13 +5,17 @@ import (
 	"maps"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/calculation"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 )
 
 // expandAppsForRoutes assigns app instances to routes with merged static data.
-// Each route gets its own app instance with route-specific static data merged in.
+// Each route gets its own app instance with route-specific static data merged in,
+// over the endpoint's static data defaults (see endpoints.Endpoint.RouteStaticData).
 // Expanded apps get unique IDs to avoid conflicts in the server registry.
 func expandAppsForRoutes(appCollection *apps.AppCollection, endpoints endpoints.EndpointCollection) {
 	if appCollection == nil || appCollection.Len() == 0 || len(endpoints) == 0 {
@@ -39,8 +43,10 @@ func expandAppsForRoutes(appCollection *apps.AppCollection, endpoints endpoints.
 			// Create unique app ID for this route's app instance
 			expandedAppID := fmt.Sprintf("%s#%d:%d", route.AppID, endpointIndex, routeIndex)
 
-			// Clone the app and merge route-specific static data
-			routeApp := cloneAppWithMergedStaticData(originalApp, route.StaticData, expandedAppID)
+			// Clone the app and merge the route's static data, including the
+			// endpoint defaults
+			routeStaticData := endpoint.RouteStaticData(route)
+			routeApp := cloneAppWithMergedStaticData(originalApp, routeStaticData, expandedAppID)
 			route.App = &routeApp
 
 			// Keep route.AppID as original for validation, but store expanded app separately
@@ -64,11 +70,23 @@ func cloneAppWithMergedStaticData(
 	case *scripts.AppScript:
 		clonedConfig := scripts.NewAppScript(config.ID)
 		clonedConfig.Evaluator = config.Evaluator // Evaluator can be shared (immutable)
+		clonedConfig.TimeoutBody = config.TimeoutBody
+		clonedConfig.ShutdownBody = config.ShutdownBody
 
 		// Merge static data
 		clonedConfig.StaticData = mergeStaticDataForApp(config.StaticData, routeStaticData)
 		clonedApp.Config = clonedConfig
 
+	case *template.App:
+		// The copy shares the templates parsed when the original is validated
+		clonedApp.Config = config.Copy(mergeStaticDataForApp(config.StaticData, routeStaticData))
+
+	case *fileread.App:
+		clonedApp.Config = config.Copy(mergeStaticDataForApp(config.StaticData, routeStaticData))
+
+	case *calculation.App:
+		clonedApp.Config = config.Copy(mergeStaticDataForApp(config.StaticData, routeStaticData))
+
 	default:
 		// For other app types (echo, composite), just copy the config
 		// They don't support static data merging yet
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandAppsForRoutes_StaticData(t *testing.T) {
+	script := scripts.NewAppScript("script-app")
+	script.StaticData = &staticdata.StaticData{
+		Data:      map[string]any{"greeting": "hello", "env": "app"},
+		MergeMode: staticdata.StaticDataMergeModeUnique,
+	}
+	appCollection := apps.NewAppCollection(apps.App{ID: "script-app", Config: script})
+
+	eps := endpoints.EndpointCollection{
+		{
+			ID:         "api",
+			ListenerID: "http",
+			StaticData: map[string]any{
+				"env": "prod",
+				"db":  map[string]any{"host": "db.internal", "port": 5432},
+			},
+			Routes: routes.RouteCollection{
+				{
+					AppID:      "script-app",
+					StaticData: map[string]any{"db": map[string]any{"port": 6543}},
+				},
+				{AppID: "script-app"},
+			},
+		},
+	}
+
+	expandAppsForRoutes(appCollection, eps)
+
+	staticDataOf := func(t *testing.T, route routes.Route) map[string]any {
+		t.Helper()
+		require.NotNil(t, route.App)
+		cfg, ok := route.App.Config.(*scripts.AppScript)
+		require.True(t, ok)
+		require.NotNil(t, cfg.StaticData)
+		return cfg.StaticData.Data
+	}
+
+	t.Run("route overrides endpoint defaults", func(t *testing.T) {
+		assert.Equal(t, map[string]any{
+			"greeting": "hello",
+			"env":      "prod",
+			"db":       map[string]any{"host": "db.internal", "port": 6543},
+		}, staticDataOf(t, eps[0].Routes[0]))
+	})
+
+	t.Run("route without static data gets endpoint defaults", func(t *testing.T) {
+		assert.Equal(t, map[string]any{
+			"greeting": "hello",
+			"env":      "prod",
+			"db":       map[string]any{"host": "db.internal", "port": 5432},
+		}, staticDataOf(t, eps[0].Routes[1]))
+	})
+
+	t.Run("configured route static data is unchanged", func(t *testing.T) {
+		assert.Equal(t, map[string]any{"db": map[string]any{"port": 6543}}, eps[0].Routes[0].StaticData)
+		assert.Nil(t, eps[0].Routes[1].StaticData)
+	})
+}
+
+func TestExpandAppsForRoutes_Template(t *testing.T) {
+	pages := template.New("pages")
+	pages.Inline = map[string]string{"home": "home", "users": "users"}
+	pages.Template = "home"
+	pages.StaticData = &staticdata.StaticData{Data: map[string]any{"title": "Admin"}}
+	appCollection := apps.NewAppCollection(apps.App{ID: "pages", Config: pages})
+
+	eps := endpoints.EndpointCollection{
+		{
+			ID:         "admin",
+			ListenerID: "http",
+			Routes: routes.RouteCollection{
+				{AppID: "pages"},
+				{AppID: "pages", StaticData: map[string]any{template.TemplateDataKey: "users"}},
+			},
+		},
+	}
+
+	expandAppsForRoutes(appCollection, eps)
+	require.NoError(t, pages.Validate())
+
+	routeApp := func(t *testing.T, route routes.Route) *template.App {
+		t.Helper()
+		require.NotNil(t, route.App)
+		cfg, ok := route.App.Config.(*template.App)
+		require.True(t, ok)
+		require.NotSame(t, pages, cfg)
+		return cfg
+	}
+
+	home := routeApp(t, eps[0].Routes[0])
+	assert.Equal(t, "home", home.TemplateName())
+	assert.Equal(t, "Admin", home.StaticData.Data["title"])
+
+	users := routeApp(t, eps[0].Routes[1])
+	assert.Equal(t, "users", users.TemplateName())
+	assert.Equal(t, "Admin", users.StaticData.Data["title"])
+	assert.Same(t, pages.Templates(), users.Templates(), "route apps share the templates parsed by validation")
+	assert.Equal(t, "home", pages.TemplateName(), "the app's static data is unchanged")
+}
+
+func TestExpandAppsForRoutes_ScriptErrorBodies(t *testing.T) {
+	t.Setenv("TEST_SCRIPT_TIMEOUT_BODY", "slow down")
+
+	cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "script"
+static_data = { greeting = "hello" }
+[endpoints.routes.http]
+path_prefix = "/script"
+
+[[apps]]
+id = "script"
+type = "script"
+[apps.script]
+timeout_body = "${TEST_SCRIPT_TIMEOUT_BODY}"
+shutdown_body = "restarting"
+[apps.script.risor]
+code = "1"
+`))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	// The route's copy of the app has the bodies, interpolated
+	route := cfg.Endpoints[0].Routes[0]
+	require.NotNil(t, route.App)
+	script, ok := route.App.Config.(*scripts.AppScript)
+	require.True(t, ok)
+	assert.Equal(t, "slow down", script.TimeoutBody)
+	assert.Equal(t, "restarting", script.ShutdownBody)
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
+	"google.golang.org/protobuf/proto"
+)
+
+// declarations records where each ID of one kind of component is declared,
+// in declaration order
+type declarations struct {
+	ids       []string
+	locations map[string][]string
+}
+
+// add records a declaration of id at location. Empty IDs are skipped, they
+// are reported by the component's own validation.
+func (d *declarations) add(id, location string) {
+	if id == "" {
+		return
+	}
+	if d.locations == nil {
+		d.locations = make(map[string][]string)
+	}
+	if _, seen := d.locations[id]; !seen {
+		d.ids = append(d.ids, id)
+	}
+	d.locations[id] = append(d.locations[id], location)
+}
+
+// has reports whether id is declared
+func (d *declarations) has(id string) bool {
+	_, ok := d.locations[id]
+	return ok
+}
+
+// duplicateErrors returns an error for each ID declared more than once,
+// listing all of its declarations
+func (d *declarations) duplicateErrors(componentType, kind string) []error {
+	var errs []error
+	for _, id := range d.ids {
+		locations := d.locations[id]
+		if len(locations) < 2 {
+			continue
+		}
+		errs = append(errs, validation.ForComponent(componentType, id,
+			validation.ForField("id", fmt.Errorf("%w: %s ID '%s' is declared %d times, at %s",
+				ErrDuplicateID, kind, id, len(locations), strings.Join(locations, ", ")))))
+	}
+	return errs
+}
+
+// validateIDs checks in a single pass that component IDs are unique and that
+// the references between components resolve: endpoints to their listeners,
+// routes to their apps, listeners to their not found and method not allowed
+// apps, and endpoints to the listener middlewares they exclude. Each duplicate
+// is reported once, with all of its declarations.
+// Routes have no IDs of their own, routes that duplicate each other are
+// reported as route conflicts.
+func (c *Config) validateIDs() []error {
+	var listenerIDs, endpointIDs, appIDs declarations
+	for i, listener := range c.Listeners {
+		listenerIDs.add(listener.ID, fmt.Sprintf("listeners[%d]", i))
+	}
+	for i, ep := range c.Endpoints {
+		endpointIDs.add(ep.ID, fmt.Sprintf("endpoints[%d]", i))
+	}
+	if c.Apps != nil {
+		for i := range c.Apps.Len() {
+			appIDs.add(c.Apps.Get(i).ID, fmt.Sprintf("apps[%d]", i))
+		}
+	}
+
+	errs := slices.Concat(
+		listenerIDs.duplicateErrors(validation.ComponentListener, "listener"),
+		endpointIDs.duplicateErrors(validation.ComponentEndpoint, "endpoint"),
+		appIDs.duplicateErrors(validation.ComponentApp, "app"),
+		c.validateMiddlewareIDs(),
+	)
+	for i := range c.Endpoints {
+		errs = append(errs, c.validateEndpointReferences(&c.Endpoints[i], &listenerIDs, &appIDs)...)
+	}
+	for i := range c.Listeners {
+		errs = append(errs, validateListenerReferences(&c.Listeners[i], &appIDs)...)
+	}
+	return errs
+}
+
+// validateListenerReferences checks that the apps answering a listener's
+// unmatched requests exist
+func validateListenerReferences(listener *listeners.Listener, appIDs *declarations) []error {
+	var errs []error
+	for _, ref := range []struct{ field, kind, appID string }{
+		{"not_found_app_id", "not found", listener.GetNotFoundAppID()},
+		{"method_not_allowed_app_id", "method not allowed", listener.GetMethodNotAllowedAppID()},
+	} {
+		if ref.appID == "" || appIDs.has(ref.appID) {
+			continue
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentListener, listener.ID,
+			validation.ForField(ref.field, fmt.Errorf(
+				"%w: listener '%s' references non-existent %s app ID '%s'",
+				ErrAppNotFound, listener.ID, ref.kind, ref.appID))))
+	}
+	return errs
+}
+
+// middlewareDeclaration is one declaration of a middleware ID
+type middlewareDeclaration struct {
+	owner      string // The listener, endpoint or route declaring the middleware
+	location   string
+	middleware middleware.Middleware
+}
+
+// validateMiddlewareIDs checks that each middleware ID names a single
+// middleware. An ID may be declared once per listener, endpoint or route.
+// Middleware instances are shared by ID across the whole config, so the
+// declarations of an ID in different places must have the same type and
+// config, or one would silently replace the others.
+func (c *Config) validateMiddlewareIDs() []error {
+	var ids []string
+	declared := make(map[string][]middlewareDeclaration)
+	declare := func(mws middleware.MiddlewareCollection, owner string) {
+		for i, mw := range mws {
+			if mw.ID == "" {
+				continue
+			}
+			if _, seen := declared[mw.ID]; !seen {
+				ids = append(ids, mw.ID)
+			}
+			declared[mw.ID] = append(declared[mw.ID], middlewareDeclaration{
+				owner:      owner,
+				location:   fmt.Sprintf("%s middlewares[%d]", owner, i),
+				middleware: mw,
+			})
+		}
+	}
+	for _, listener := range c.Listeners {
+		declare(listener.Middlewares, fmt.Sprintf("listener '%s'", listener.ID))
+	}
+	for _, ep := range c.Endpoints {
+		declare(ep.Middlewares, fmt.Sprintf("endpoint '%s'", ep.ID))
+		for i := range ep.Routes {
+			declare(ep.Routes[i].Middlewares, fmt.Sprintf("route '%s'", ep.RouteID(i)))
+		}
+	}
+
+	var errs []error
+	for _, id := range ids {
+		declarations := declared[id]
+		first := declarations[0].middleware.ToProto()
+		owners := make(map[string]bool, len(declarations))
+		locations := make([]string, 0, len(declarations))
+		repeated, differs := false, false
+		for _, d := range declarations {
+			repeated = repeated || owners[d.owner]
+			owners[d.owner] = true
+			differs = differs || !proto.Equal(first, d.middleware.ToProto())
+			locations = append(locations, d.location)
+		}
+
+		var err error
+		switch {
+		case repeated:
+			err = fmt.Errorf("%w: middleware ID '%s' is declared more than once in the same list, at %s",
+				ErrDuplicateID, id, strings.Join(locations, ", "))
+		case differs:
+			err = fmt.Errorf("%w: middleware ID '%s' is declared with different configs, at %s; "+
+				"middleware instances are shared by ID, so use another ID for each config",
+				ErrDuplicateID, id, strings.Join(locations, ", "))
+		default:
+			continue
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentMiddleware, id,
+			validation.ForField("id", err)))
+	}
+	return errs
+}
+
+// validateEndpointReferences checks that the listeners of an endpoint, the
+// apps of its routes, and the listener middlewares it excludes exist
+func (c *Config) validateEndpointReferences(
+	ep *endpoints.Endpoint,
+	listenerIDs, appIDs *declarations,
+) []error {
+	var errs []error
+	listenerIDsOfEndpoint := ep.AllListenerIDs()
+
+	missingListener := false
+	for _, listenerID := range listenerIDsOfEndpoint {
+		if listenerIDs.has(listenerID) {
+			continue
+		}
+		missingListener = true
+		field := "listener_ids"
+		if listenerID == ep.ListenerID {
+			field = "listener_id"
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentEndpoint, ep.ID,
+			validation.ForField(field, fmt.Errorf(
+				"%w: endpoint '%s' references non-existent listener ID '%s'",
+				ErrListenerNotFound, ep.ID, listenerID))))
+	}
+
+	for i, route := range ep.Routes {
+		if route.AppID == "" || appIDs.has(route.AppID) {
+			continue
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentRoute, ep.RouteID(i),
+			validation.ForField("app_id", fmt.Errorf(
+				"%w: route '%s' references non-existent app ID '%s'",
+				ErrAppNotFound, ep.RouteID(i), route.AppID))))
+	}
+
+	// The defaults of a missing listener are unknown, don't report its
+	// exclusions as well
+	if missingListener || len(listenerIDsOfEndpoint) == 0 {
+		return errs
+	}
+	for _, id := range ep.ExcludeListenerMiddlewares {
+		isDefault := slices.ContainsFunc(listenerIDsOfEndpoint, func(listenerID string) bool {
+			listener, _ := c.Listeners.FindByID(listenerID)
+			return listener.Middlewares.FindByID(id) != nil
+		})
+		if !isDefault {
+			errs = append(errs, validation.ForComponent(validation.ComponentEndpoint, ep.ID,
+				validation.ForField("exclude_listener_middlewares", fmt.Errorf(
+					"%w: endpoint '%s' excludes middleware '%s', which none of its listeners ('%s') has",
+					ErrInvalidReference, ep.ID, id, strings.Join(listenerIDsOfEndpoint, "', '")))))
+		}
+	}
+	return errs
+}
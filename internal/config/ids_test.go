@@ -0,0 +1,176 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIDs(t *testing.T) {
+	t.Parallel()
+
+	listener := func(id string) listeners.Listener {
+		return listeners.Listener{ID: id, Address: ":8080", Type: listeners.TypeHTTP, Options: options.NewHTTP()}
+	}
+	echoApp := func(id string) apps.App {
+		app := echo.New(id)
+		app.Response = "hello"
+		return apps.App{ID: id, Config: app}
+	}
+	route := func(appID string) routes.Route {
+		return routes.Route{AppID: appID, Condition: conditions.NewHTTP("/", "")}
+	}
+	textLogger := func() *logger.ConsoleLogger {
+		l := logger.NewConsoleLogger()
+		l.Options.Format = logger.FormatTxt
+		return l
+	}
+	messages := func(errs []error) []string {
+		var result []string
+		for _, err := range errs {
+			result = append(result, err.Error())
+		}
+		return result
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{
+			Listeners: listeners.ListenerCollection{listener("http")},
+			Endpoints: endpoints.EndpointCollection{
+				{ID: "main", ListenerID: "http", Routes: routes.RouteCollection{route("echo")}},
+			},
+			Apps: apps.NewAppCollection(echoApp("echo")),
+		}
+		assert.Empty(t, cfg.validateIDs())
+	})
+
+	t.Run("every duplicate is reported once with all of its declarations", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{
+			Listeners: listeners.ListenerCollection{listener("a"), listener("b"), listener("a"), listener("a")},
+			Endpoints: endpoints.EndpointCollection{
+				{ID: "main", ListenerID: "a"},
+				{ID: "main", ListenerID: "b"},
+			},
+			Apps: apps.NewAppCollection(echoApp("echo"), echoApp("echo")),
+		}
+		errs := cfg.validateIDs()
+		assert.Equal(t, []string{
+			"duplicate ID: listener ID 'a' is declared 3 times, at listeners[0], listeners[2], listeners[3]",
+			"duplicate ID: endpoint ID 'main' is declared 2 times, at endpoints[0], endpoints[1]",
+			"duplicate ID: app ID 'echo' is declared 2 times, at apps[0], apps[1]",
+		}, messages(errs))
+		for _, err := range errs {
+			require.ErrorIs(t, err, ErrDuplicateID)
+		}
+
+		issues := validation.Issues(errors.Join(errs...), validation.SeverityError)
+		require.Len(t, issues, 3)
+		assert.Equal(t, validation.ComponentListener, issues[0].ComponentType)
+		assert.Equal(t, "a", issues[0].ComponentID)
+		assert.Equal(t, "id", issues[0].Field)
+	})
+
+	t.Run("middleware IDs", func(t *testing.T) {
+		t.Parallel()
+		shared := middleware.Middleware{ID: "logger", Config: logger.NewConsoleLogger()}
+		cfg := &Config{
+			Listeners: listeners.ListenerCollection{
+				func() listeners.Listener {
+					l := listener("http")
+					l.Middlewares = middleware.MiddlewareCollection{shared}
+					return l
+				}(),
+			},
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:          "main",
+					ListenerID:  "http",
+					Middlewares: middleware.MiddlewareCollection{shared},
+					Routes: routes.RouteCollection{func() routes.Route {
+						r := route("")
+						r.Middlewares = middleware.MiddlewareCollection{
+							{ID: "audit", Config: logger.NewConsoleLogger()},
+							{ID: "audit", Config: logger.NewConsoleLogger()},
+						}
+						return r
+					}()},
+				},
+				{
+					ID:          "other",
+					ListenerID:  "http",
+					Middlewares: middleware.MiddlewareCollection{{ID: "logger", Config: textLogger()}},
+				},
+			},
+		}
+		assert.Equal(t, []string{
+			"duplicate ID: middleware ID 'logger' is declared with different configs, " +
+				"at listener 'http' middlewares[0], endpoint 'main' middlewares[0], endpoint 'other' middlewares[0]; " +
+				"middleware instances are shared by ID, so use another ID for each config",
+			"duplicate ID: middleware ID 'audit' is declared more than once in the same list, " +
+				"at route 'main[0]' middlewares[0], route 'main[0]' middlewares[1]",
+		}, messages(cfg.validateIDs()))
+
+		// The same middleware may be declared in several places
+		cfg.Endpoints = cfg.Endpoints[:1]
+		cfg.Endpoints[0].Routes = nil
+		assert.Empty(t, cfg.validateIDs())
+	})
+
+	t.Run("references", func(t *testing.T) {
+		t.Parallel()
+		cfg := &Config{
+			Listeners: listeners.ListenerCollection{
+				func() listeners.Listener {
+					l := listener("http")
+					l.Middlewares = middleware.MiddlewareCollection{{ID: "logger", Config: logger.NewConsoleLogger()}}
+					return l
+				}(),
+			},
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:          "main",
+					ListenerID:  "http",
+					ListenerIDs: []string{"missing"},
+					Routes:      routes.RouteCollection{route("echo"), route("nope"), route("")},
+				},
+				{
+					ID:                         "other",
+					ListenerID:                 "http",
+					ExcludeListenerMiddlewares: []string{"logger", "unknown"},
+				},
+			},
+			Apps: apps.NewAppCollection(echoApp("echo")),
+		}
+		errs := cfg.validateIDs()
+		assert.Equal(t, []string{
+			"listener not found: endpoint 'main' references non-existent listener ID 'missing'",
+			"app not found: route 'main[1]' references non-existent app ID 'nope'",
+			"invalid reference: endpoint 'other' excludes middleware 'unknown', which none of its listeners ('http') has",
+		}, messages(errs))
+		require.ErrorIs(t, errs[0], ErrListenerNotFound)
+		require.ErrorIs(t, errs[1], ErrAppNotFound)
+		require.ErrorIs(t, errs[2], ErrInvalidReference)
+
+		issues := validation.Issues(errors.Join(errs...), validation.SeverityError)
+		require.Len(t, issues, 3)
+		assert.Equal(t, "listener_ids", issues[0].Field)
+		assert.Equal(t, validation.ComponentRoute, issues[1].ComponentType)
+		assert.Equal(t, "main[1]", issues[1].ComponentID)
+		assert.Equal(t, "app_id", issues[1].Field)
+		assert.Equal(t, "exclude_listener_middlewares", issues[2].Field)
+	})
+}
@@ -50,6 +50,10 @@ func TestListener_GetTimeouts(t *testing.T) {
 			WriteTimeout: writeDuration,
 			DrainTimeout: drainDuration,
 			IdleTimeout:  idleDuration,
+
+			MaxRequestBodyBytes: 1024,
+			H2C:                 true,
+			TrustedProxies:      []string{"10.0.0.0/8"},
 		},
 	}
 
@@ -78,6 +82,11 @@ func TestListener_GetTimeouts(t *testing.T) {
 	assert.Equal(t, writeDuration, fullListener.GetWriteTimeout())
 	assert.Equal(t, drainDuration, fullListener.GetDrainTimeout())
 	assert.Equal(t, idleDuration, fullListener.GetIdleTimeout())
+	assert.Equal(t, int64(1024), fullListener.GetMaxRequestBodyBytes())
+	assert.True(t, fullListener.GetH2C())
+	assert.False(t, partialListener.GetH2C())
+	assert.Equal(t, []string{"10.0.0.0/8"}, fullListener.GetTrustedProxies())
+	assert.Nil(t, partialListener.GetTrustedProxies())
 
 	// Test partial listener timeouts (should use provided values for read, defaults for others)
 	assert.Equal(t, readDuration, partialListener.GetReadTimeout())
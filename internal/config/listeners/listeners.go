@@ -45,6 +45,7 @@ import (
 	"iter"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
 )
 
@@ -55,6 +56,7 @@ type Type int32
 const (
 	TypeUnspecified Type = 0
 	TypeHTTP        Type = 1
+	TypeTCP         Type = 2
 )
 
 // ListenerCollection is a collection of Listener objects
@@ -71,6 +73,10 @@ type Listener struct {
 	Address string `env_interpolation:"yes"`
 	Type    Type
 	Options options.Options
+
+	// Middlewares are the default middlewares of every endpoint attached to
+	// this listener, see endpoints.Endpoint.ListenerMiddlewares
+	Middlewares middleware.MiddlewareCollection
 }
 
 // GetOptionsType returns the type of the listener options
@@ -87,6 +93,8 @@ func (l *Listener) GetTypeString() string {
 	switch l.Type {
 	case TypeHTTP:
 		return "HTTP"
+	case TypeTCP:
+		return "TCP"
 	default:
 		return "Unknown"
 	}
@@ -102,6 +110,16 @@ func (l *Listener) GetHTTPOptions() (options.HTTP, bool) {
 	return httpOpts, ok
 }
 
+// GetTCPOptions extracts TCP options from a Listener
+func (l *Listener) GetTCPOptions() (options.TCP, bool) {
+	if l.Options == nil || l.Options.Type() != options.TypeTCP {
+		return options.TCP{}, false
+	}
+
+	tcpOpts, ok := l.Options.(options.TCP)
+	return tcpOpts, ok
+}
+
 // GetReadTimeout extracts the read timeout with a fallback to default value
 func (l *Listener) GetReadTimeout() time.Duration {
 	httpOpts, ok := l.GetHTTPOptions()
@@ -112,6 +130,16 @@ func (l *Listener) GetReadTimeout() time.Duration {
 	return httpOpts.GetReadTimeout()
 }
 
+// GetReadHeaderTimeout extracts the read header timeout with a fallback to default value
+func (l *Listener) GetReadHeaderTimeout() time.Duration {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return options.DefaultHTTPReadHeaderTimeout
+	}
+
+	return httpOpts.GetReadHeaderTimeout()
+}
+
 // GetWriteTimeout extracts the write timeout with a fallback to default value
 func (l *Listener) GetWriteTimeout() time.Duration {
 	httpOpts, ok := l.GetHTTPOptions()
@@ -124,6 +152,10 @@ func (l *Listener) GetWriteTimeout() time.Duration {
 
 // GetDrainTimeout extracts the drain timeout with a fallback to default value
 func (l *Listener) GetDrainTimeout() time.Duration {
+	if tcpOpts, ok := l.GetTCPOptions(); ok {
+		return tcpOpts.GetDrainTimeout()
+	}
+
 	httpOpts, ok := l.GetHTTPOptions()
 	if !ok {
 		return options.DefaultHTTPDrainTimeout
@@ -142,6 +174,71 @@ func (l *Listener) GetIdleTimeout() time.Duration {
 	return httpOpts.GetIdleTimeout()
 }
 
+// GetMaxRequestBodyBytes extracts the request body size limit, 0 when
+// request bodies are unlimited
+func (l *Listener) GetMaxRequestBodyBytes() int64 {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return 0
+	}
+
+	return httpOpts.GetMaxRequestBodyBytes()
+}
+
+// GetMaxConcurrentRequests extracts the limit of requests handled at once, 0
+// when they are unlimited
+func (l *Listener) GetMaxConcurrentRequests() int {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return 0
+	}
+
+	return httpOpts.GetMaxConcurrentRequests()
+}
+
+// GetTrustedProxies extracts the networks of the reverse proxies whose
+// forwarding headers are honored, nil when there are none
+func (l *Listener) GetTrustedProxies() []string {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return nil
+	}
+
+	return httpOpts.TrustedProxies
+}
+
+// GetNotFoundAppID extracts the ID of the app serving requests no route
+// matches, empty when they get a plain 404
+func (l *Listener) GetNotFoundAppID() string {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return ""
+	}
+
+	return httpOpts.NotFoundAppID
+}
+
+// GetMethodNotAllowedAppID extracts the ID of the app answering requests
+// whose path only has routes for other methods, empty for a plain 405
+func (l *Listener) GetMethodNotAllowedAppID() string {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return ""
+	}
+
+	return httpOpts.MethodNotAllowedAppID
+}
+
+// GetH2C reports whether the listener accepts HTTP/2 without TLS
+func (l *Listener) GetH2C() bool {
+	httpOpts, ok := l.GetHTTPOptions()
+	if !ok {
+		return false
+	}
+
+	return httpOpts.H2C
+}
+
 // All returns an iterator over all listeners in the collection.
 // This enables clean iteration: for listener := range collection.All() { ... }
 func (lc ListenerCollection) All() iter.Seq[Listener] {
@@ -182,3 +279,8 @@ func (lc ListenerCollection) FindByType(listenerType Type) iter.Seq[Listener] {
 func (lc ListenerCollection) GetHTTPListeners() iter.Seq[Listener] {
 	return lc.FindByType(TypeHTTP)
 }
+
+// GetTCPListeners returns an iterator over listeners of TCP type
+func (lc ListenerCollection) GetTCPListeners() iter.Seq[Listener] {
+	return lc.FindByType(TypeTCP)
+}
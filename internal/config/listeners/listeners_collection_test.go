@@ -282,6 +282,52 @@ func TestListenerCollection_GetHTTPListeners(t *testing.T) {
 	})
 }
 
+func TestListenerCollection_GetTCPListeners(t *testing.T) {
+	t.Parallel()
+
+	httpListener := Listener{
+		ID:      "http-1",
+		Address: "0.0.0.0:8080",
+		Type:    TypeHTTP,
+		Options: options.NewHTTP(),
+	}
+	tcpListener := Listener{
+		ID:      "tcp-1",
+		Address: "0.0.0.0:5432",
+		Type:    TypeTCP,
+		Options: options.TCP{Upstream: "10.0.0.5:5432"},
+	}
+
+	collection := ListenerCollection{httpListener, tcpListener}
+
+	tcpListeners := slices.Collect(collection.GetTCPListeners())
+	require.Len(t, tcpListeners, 1)
+	assert.Equal(t, tcpListener, tcpListeners[0])
+
+	httpListeners := slices.Collect(collection.GetHTTPListeners())
+	require.Len(t, httpListeners, 1)
+	assert.Equal(t, httpListener, httpListeners[0])
+}
+
+func TestListener_GetTCPOptions(t *testing.T) {
+	t.Parallel()
+
+	tcpListener := Listener{
+		ID:      "tcp-1",
+		Type:    TypeTCP,
+		Options: options.TCP{Upstream: "10.0.0.5:5432", DrainTimeout: 5 * time.Second},
+	}
+	opts, ok := tcpListener.GetTCPOptions()
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.5:5432", opts.Upstream)
+	assert.Equal(t, "TCP", tcpListener.GetTypeString())
+	assert.Equal(t, 5*time.Second, tcpListener.GetDrainTimeout())
+
+	httpListener := Listener{ID: "http-1", Type: TypeHTTP, Options: options.NewHTTP()}
+	_, ok = httpListener.GetTCPOptions()
+	assert.False(t, ok)
+}
+
 func TestListener_GetOptionsType(t *testing.T) {
 	t.Parallel()
 
@@ -323,6 +369,11 @@ func TestListener_GetTimeouts_NilOptions(t *testing.T) {
 		Options: nil,
 	}
 
+	t.Run("GetReadHeaderTimeout with nil options", func(t *testing.T) {
+		timeout := listener.GetReadHeaderTimeout()
+		assert.Equal(t, options.DefaultHTTPReadHeaderTimeout, timeout)
+	})
+
 	t.Run("GetReadTimeout with nil options", func(t *testing.T) {
 		timeout := listener.GetReadTimeout()
 		assert.Equal(t, options.DefaultHTTPReadTimeout, timeout)
@@ -342,6 +393,18 @@ func TestListener_GetTimeouts_NilOptions(t *testing.T) {
 		timeout := listener.GetIdleTimeout()
 		assert.Equal(t, options.DefaultHTTPIdleTimeout, timeout)
 	})
+
+	t.Run("GetMaxRequestBodyBytes with nil options", func(t *testing.T) {
+		assert.Zero(t, listener.GetMaxRequestBodyBytes())
+	})
+
+	t.Run("GetTrustedProxies with nil options", func(t *testing.T) {
+		assert.Nil(t, listener.GetTrustedProxies())
+	})
+
+	t.Run("GetH2C with nil options", func(t *testing.T) {
+		assert.False(t, listener.GetH2C())
+	})
 }
 
 // testCustomOptions implements options.Options for testing
@@ -360,6 +423,11 @@ func TestListener_GetTimeouts_NonHTTPOptions(t *testing.T) {
 		Options: testCustomOptions{},
 	}
 
+	t.Run("GetReadHeaderTimeout with non-HTTP options", func(t *testing.T) {
+		timeout := listener.GetReadHeaderTimeout()
+		assert.Equal(t, options.DefaultHTTPReadHeaderTimeout, timeout)
+	})
+
 	t.Run("GetReadTimeout with non-HTTP options", func(t *testing.T) {
 		timeout := listener.GetReadTimeout()
 		assert.Equal(t, options.DefaultHTTPReadTimeout, timeout)
@@ -379,6 +447,18 @@ func TestListener_GetTimeouts_NonHTTPOptions(t *testing.T) {
 		timeout := listener.GetIdleTimeout()
 		assert.Equal(t, options.DefaultHTTPIdleTimeout, timeout)
 	})
+
+	t.Run("GetMaxRequestBodyBytes with non-HTTP options", func(t *testing.T) {
+		assert.Zero(t, listener.GetMaxRequestBodyBytes())
+	})
+
+	t.Run("GetTrustedProxies with non-HTTP options", func(t *testing.T) {
+		assert.Nil(t, listener.GetTrustedProxies())
+	})
+
+	t.Run("GetH2C with non-HTTP options", func(t *testing.T) {
+		assert.False(t, listener.GetH2C())
+	})
 }
 
 func TestListenerCollection_ComplexScenario(t *testing.T) {
@@ -7,32 +7,57 @@ import (
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 )
 
 // HTTP default timeout values
 const (
-	DefaultHTTPReadTimeout  = 10 * time.Second
-	DefaultHTTPWriteTimeout = 10 * time.Second
-	DefaultHTTPDrainTimeout = 30 * time.Second
-	DefaultHTTPIdleTimeout  = 60 * time.Second
+	DefaultHTTPReadTimeout       = 10 * time.Second
+	DefaultHTTPReadHeaderTimeout = 5 * time.Second
+	DefaultHTTPWriteTimeout      = 10 * time.Second
+	DefaultHTTPDrainTimeout      = 30 * time.Second
+	DefaultHTTPIdleTimeout       = 60 * time.Second
 )
 
 // HTTP contains HTTP-specific listener configuration
 type HTTP struct {
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	DrainTimeout time.Duration
-	IdleTimeout  time.Duration
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	DrainTimeout      time.Duration
+	IdleTimeout       time.Duration
+
+	// MaxRequestBodyBytes limits the size of request bodies, 0 is unlimited
+	MaxRequestBodyBytes int64
+
+	// H2C accepts HTTP/2 without TLS from clients with prior knowledge
+	H2C bool
+
+	// TrustedProxies are the CIDR prefixes or IP addresses of the reverse
+	// proxies whose forwarding headers are honored
+	TrustedProxies []string
+
+	// MaxConcurrentRequests limits the requests handled at once, 0 is unlimited
+	MaxConcurrentRequests int
+
+	// NotFoundAppID is the app serving requests no route matches, empty for a
+	// plain 404
+	NotFoundAppID string
+
+	// MethodNotAllowedAppID is the app answering requests whose path only has
+	// routes for other methods, empty for a plain 405
+	MethodNotAllowedAppID string
 }
 
 // NewHTTP creates a new HTTP with default values
 func NewHTTP() HTTP {
 	return HTTP{
-		ReadTimeout:  DefaultHTTPReadTimeout,
-		WriteTimeout: DefaultHTTPWriteTimeout,
-		DrainTimeout: DefaultHTTPDrainTimeout,
-		IdleTimeout:  DefaultHTTPIdleTimeout,
+		ReadTimeout:       DefaultHTTPReadTimeout,
+		ReadHeaderTimeout: DefaultHTTPReadHeaderTimeout,
+		WriteTimeout:      DefaultHTTPWriteTimeout,
+		DrainTimeout:      DefaultHTTPDrainTimeout,
+		IdleTimeout:       DefaultHTTPIdleTimeout,
 	}
 }
 
@@ -49,6 +74,11 @@ func (h HTTP) Validate() error {
 			errz.ErrInvalidValue))
 	}
 
+	if h.ReadHeaderTimeout < 0 {
+		errs = append(errs, fmt.Errorf("%w: HTTP read header timeout must not be negative",
+			errz.ErrInvalidValue))
+	}
+
 	if h.WriteTimeout <= 0 {
 		errs = append(errs, fmt.Errorf("%w: HTTP write timeout must be positive",
 			errz.ErrInvalidValue))
@@ -64,6 +94,32 @@ func (h HTTP) Validate() error {
 			errz.ErrInvalidValue))
 	}
 
+	if h.MaxRequestBodyBytes < 0 {
+		errs = append(errs, fmt.Errorf("%w: HTTP max request body bytes must not be negative",
+			errz.ErrInvalidValue))
+	}
+
+	if h.MaxConcurrentRequests < 0 {
+		errs = append(errs, fmt.Errorf("%w: HTTP max concurrent requests must not be negative",
+			errz.ErrInvalidValue))
+	}
+
+	if _, err := validation.ParsePrefixes(h.TrustedProxies); err != nil {
+		errs = append(errs, fmt.Errorf("%w: HTTP trusted proxies: %w", errz.ErrInvalidValue, err))
+	}
+
+	if h.NotFoundAppID != "" {
+		if err := validation.ValidateID(h.NotFoundAppID, "HTTP not found app ID"); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", errz.ErrInvalidValue, err))
+		}
+	}
+
+	if h.MethodNotAllowedAppID != "" {
+		if err := validation.ValidateID(h.MethodNotAllowedAppID, "HTTP method not allowed app ID"); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", errz.ErrInvalidValue, err))
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -75,6 +131,14 @@ func (h HTTP) GetReadTimeout() time.Duration {
 	return h.ReadTimeout
 }
 
+// GetReadHeaderTimeout returns the read header timeout
+func (h HTTP) GetReadHeaderTimeout() time.Duration {
+	if h.ReadHeaderTimeout <= 0 {
+		return DefaultHTTPReadHeaderTimeout
+	}
+	return h.ReadHeaderTimeout
+}
+
 // GetWriteTimeout returns the write timeout
 func (h HTTP) GetWriteTimeout() time.Duration {
 	if h.WriteTimeout <= 0 {
@@ -99,12 +163,33 @@ func (h HTTP) GetIdleTimeout() time.Duration {
 	return h.IdleTimeout
 }
 
+// GetMaxRequestBodyBytes returns the request body size limit, or 0 when
+// request bodies are unlimited
+func (h HTTP) GetMaxRequestBodyBytes() int64 {
+	if h.MaxRequestBodyBytes <= 0 {
+		return 0
+	}
+	return h.MaxRequestBodyBytes
+}
+
+// GetMaxConcurrentRequests returns the limit of requests handled at once, or 0
+// when they are unlimited
+func (h HTTP) GetMaxConcurrentRequests() int {
+	if h.MaxConcurrentRequests <= 0 {
+		return 0
+	}
+	return h.MaxConcurrentRequests
+}
+
 // String returns a concise string representation of HTTP options
 func (h HTTP) String() string {
 	var b strings.Builder
 	if h.ReadTimeout > 0 {
 		fmt.Fprintf(&b, "ReadTimeout: %v, ", h.ReadTimeout)
 	}
+	if h.ReadHeaderTimeout > 0 {
+		fmt.Fprintf(&b, "ReadHeaderTimeout: %v, ", h.ReadHeaderTimeout)
+	}
 	if h.WriteTimeout > 0 {
 		fmt.Fprintf(&b, "WriteTimeout: %v, ", h.WriteTimeout)
 	}
@@ -114,6 +199,24 @@ func (h HTTP) String() string {
 	if h.DrainTimeout > 0 {
 		fmt.Fprintf(&b, "DrainTimeout: %v, ", h.DrainTimeout)
 	}
+	if h.MaxRequestBodyBytes > 0 {
+		fmt.Fprintf(&b, "MaxRequestBodyBytes: %d, ", h.MaxRequestBodyBytes)
+	}
+	if h.MaxConcurrentRequests > 0 {
+		fmt.Fprintf(&b, "MaxConcurrentRequests: %d, ", h.MaxConcurrentRequests)
+	}
+	if h.H2C {
+		b.WriteString("H2C: true, ")
+	}
+	if len(h.TrustedProxies) > 0 {
+		fmt.Fprintf(&b, "TrustedProxies: %s, ", strings.Join(h.TrustedProxies, ","))
+	}
+	if h.NotFoundAppID != "" {
+		fmt.Fprintf(&b, "NotFoundAppID: %s, ", h.NotFoundAppID)
+	}
+	if h.MethodNotAllowedAppID != "" {
+		fmt.Fprintf(&b, "MethodNotAllowedAppID: %s, ", h.MethodNotAllowedAppID)
+	}
 
 	str := b.String()
 	if len(str) > 2 {
@@ -131,6 +234,9 @@ func (h HTTP) ToTree() *fancy.ComponentTree {
 	if h.ReadTimeout > 0 {
 		tree.AddChild(fmt.Sprintf("ReadTimeout: %v", h.ReadTimeout))
 	}
+	if h.ReadHeaderTimeout > 0 {
+		tree.AddChild(fmt.Sprintf("ReadHeaderTimeout: %v", h.ReadHeaderTimeout))
+	}
 	if h.WriteTimeout > 0 {
 		tree.AddChild(fmt.Sprintf("WriteTimeout: %v", h.WriteTimeout))
 	}
@@ -140,6 +246,24 @@ func (h HTTP) ToTree() *fancy.ComponentTree {
 	if h.DrainTimeout > 0 {
 		tree.AddChild(fmt.Sprintf("DrainTimeout: %v", h.DrainTimeout))
 	}
+	if h.MaxRequestBodyBytes > 0 {
+		tree.AddChild(fmt.Sprintf("MaxRequestBodyBytes: %d", h.MaxRequestBodyBytes))
+	}
+	if h.MaxConcurrentRequests > 0 {
+		tree.AddChild(fmt.Sprintf("MaxConcurrentRequests: %d", h.MaxConcurrentRequests))
+	}
+	if h.H2C {
+		tree.AddChild("H2C: true")
+	}
+	if len(h.TrustedProxies) > 0 {
+		tree.AddChild(fmt.Sprintf("TrustedProxies: %s", strings.Join(h.TrustedProxies, ", ")))
+	}
+	if h.NotFoundAppID != "" {
+		tree.AddChild(fmt.Sprintf("NotFoundAppID: %s", h.NotFoundAppID))
+	}
+	if h.MethodNotAllowedAppID != "" {
+		tree.AddChild(fmt.Sprintf("MethodNotAllowedAppID: %s", h.MethodNotAllowedAppID))
+	}
 
 	return tree
 }
@@ -17,6 +17,7 @@ func TestHTTPOptions_Type(t *testing.T) {
 func TestNewHTTPOptions(t *testing.T) {
 	opts := NewHTTP()
 	assert.Equal(t, DefaultHTTPReadTimeout, opts.ReadTimeout)
+	assert.Equal(t, DefaultHTTPReadHeaderTimeout, opts.ReadHeaderTimeout)
 	assert.Equal(t, DefaultHTTPWriteTimeout, opts.WriteTimeout)
 	assert.Equal(t, DefaultHTTPDrainTimeout, opts.DrainTimeout)
 	assert.Equal(t, DefaultHTTPIdleTimeout, opts.IdleTimeout)
@@ -44,6 +45,100 @@ func TestHTTPOptions_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "Max request body size is valid",
+			opts: HTTP{
+				ReadTimeout:         DefaultHTTPReadTimeout,
+				WriteTimeout:        DefaultHTTPWriteTimeout,
+				DrainTimeout:        DefaultHTTPDrainTimeout,
+				IdleTimeout:         DefaultHTTPIdleTimeout,
+				MaxRequestBodyBytes: 1 << 20,
+			},
+			expectError: false,
+		},
+		{
+			name: "Negative ReadHeaderTimeout is invalid",
+			opts: HTTP{
+				ReadTimeout:       DefaultHTTPReadTimeout,
+				ReadHeaderTimeout: -time.Second,
+				WriteTimeout:      DefaultHTTPWriteTimeout,
+				DrainTimeout:      DefaultHTTPDrainTimeout,
+				IdleTimeout:       DefaultHTTPIdleTimeout,
+			},
+			expectError:   true,
+			errorContains: "HTTP read header timeout must not be negative",
+		},
+		{
+			name: "Negative max request body size is invalid",
+			opts: HTTP{
+				ReadTimeout:         DefaultHTTPReadTimeout,
+				WriteTimeout:        DefaultHTTPWriteTimeout,
+				DrainTimeout:        DefaultHTTPDrainTimeout,
+				IdleTimeout:         DefaultHTTPIdleTimeout,
+				MaxRequestBodyBytes: -1,
+			},
+			expectError:   true,
+			errorContains: "HTTP max request body bytes must not be negative",
+		},
+		{
+			name: "Negative max concurrent requests are invalid",
+			opts: HTTP{
+				ReadTimeout:           DefaultHTTPReadTimeout,
+				WriteTimeout:          DefaultHTTPWriteTimeout,
+				DrainTimeout:          DefaultHTTPDrainTimeout,
+				IdleTimeout:           DefaultHTTPIdleTimeout,
+				MaxConcurrentRequests: -1,
+			},
+			expectError:   true,
+			errorContains: "HTTP max concurrent requests must not be negative",
+		},
+		{
+			name: "Malformed not found app ID is invalid",
+			opts: HTTP{
+				ReadTimeout:   DefaultHTTPReadTimeout,
+				WriteTimeout:  DefaultHTTPWriteTimeout,
+				DrainTimeout:  DefaultHTTPDrainTimeout,
+				IdleTimeout:   DefaultHTTPIdleTimeout,
+				NotFoundAppID: "not found",
+			},
+			expectError:   true,
+			errorContains: "HTTP not found app ID",
+		},
+		{
+			name: "Malformed method not allowed app ID is invalid",
+			opts: HTTP{
+				ReadTimeout:           DefaultHTTPReadTimeout,
+				WriteTimeout:          DefaultHTTPWriteTimeout,
+				DrainTimeout:          DefaultHTTPDrainTimeout,
+				IdleTimeout:           DefaultHTTPIdleTimeout,
+				MethodNotAllowedAppID: "not allowed",
+			},
+			expectError:   true,
+			errorContains: "HTTP method not allowed app ID",
+		},
+		{
+			name: "Trusted proxies are valid",
+			opts: HTTP{
+				ReadTimeout:    DefaultHTTPReadTimeout,
+				WriteTimeout:   DefaultHTTPWriteTimeout,
+				DrainTimeout:   DefaultHTTPDrainTimeout,
+				IdleTimeout:    DefaultHTTPIdleTimeout,
+				TrustedProxies: []string{"10.0.0.0/8", "127.0.0.1", "fd00::/8"},
+			},
+			expectError: false,
+		},
+		{
+			name: "Malformed trusted proxy is invalid",
+			opts: HTTP{
+				ReadTimeout:    DefaultHTTPReadTimeout,
+				WriteTimeout:   DefaultHTTPWriteTimeout,
+				DrainTimeout:   DefaultHTTPDrainTimeout,
+				IdleTimeout:    DefaultHTTPIdleTimeout,
+				TrustedProxies: []string{"10.0.0.0/8", "proxy.internal"},
+			},
+			expectError:   true,
+			errorContains: "HTTP trusted proxies",
+		},
 		{
 			name: "Zero ReadTimeout is invalid",
 			opts: HTTP{
@@ -172,6 +267,18 @@ func TestHTTPOptions_GetTimeouts(t *testing.T) {
 		assert.Equal(t, 15*time.Second, HTTP{ReadTimeout: 15 * time.Second}.GetReadTimeout())
 	})
 
+	t.Run("GetReadHeaderTimeout returns correct values", func(t *testing.T) {
+		// Default value should be returned for zero or negative values
+		assert.Equal(t, DefaultHTTPReadHeaderTimeout, HTTP{ReadHeaderTimeout: 0}.GetReadHeaderTimeout())
+		assert.Equal(
+			t,
+			DefaultHTTPReadHeaderTimeout,
+			HTTP{ReadHeaderTimeout: -5 * time.Second}.GetReadHeaderTimeout(),
+		)
+		// Valid value should be returned
+		assert.Equal(t, 2*time.Second, HTTP{ReadHeaderTimeout: 2 * time.Second}.GetReadHeaderTimeout())
+	})
+
 	t.Run("GetWriteTimeout returns correct values", func(t *testing.T) {
 		// Default value should be returned for zero or negative values
 		assert.Equal(t, DefaultHTTPWriteTimeout, HTTP{WriteTimeout: 0}.GetWriteTimeout())
@@ -216,3 +323,15 @@ func TestHTTPOptions_GetTimeouts(t *testing.T) {
 		assert.Equal(t, 90*time.Second, HTTP{IdleTimeout: 90 * time.Second}.GetIdleTimeout())
 	})
 }
+
+func TestHTTPOptions_GetMaxRequestBodyBytes(t *testing.T) {
+	assert.Zero(t, NewHTTP().GetMaxRequestBodyBytes())
+	assert.Zero(t, HTTP{MaxRequestBodyBytes: -1}.GetMaxRequestBodyBytes())
+	assert.Equal(t, int64(1024), HTTP{MaxRequestBodyBytes: 1024}.GetMaxRequestBodyBytes())
+}
+
+func TestHTTPOptions_GetMaxConcurrentRequests(t *testing.T) {
+	assert.Zero(t, NewHTTP().GetMaxConcurrentRequests())
+	assert.Zero(t, HTTP{MaxConcurrentRequests: -1}.GetMaxConcurrentRequests())
+	assert.Equal(t, 100, HTTP{MaxConcurrentRequests: 100}.GetMaxConcurrentRequests())
+}
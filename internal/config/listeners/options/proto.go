@@ -2,6 +2,7 @@ package options
 
 import (
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -19,6 +20,13 @@ func HTTPFromProto(pbOpts *pb.HttpListenerOptions) HTTP {
 		}
 	}
 
+	if pbOpts.ReadHeaderTimeout != nil {
+		d := pbOpts.ReadHeaderTimeout.AsDuration()
+		if d > 0 {
+			opts.ReadHeaderTimeout = d
+		}
+	}
+
 	if pbOpts.WriteTimeout != nil {
 		d := pbOpts.WriteTimeout.AsDuration()
 		if d > 0 {
@@ -40,16 +48,79 @@ func HTTPFromProto(pbOpts *pb.HttpListenerOptions) HTTP {
 		}
 	}
 
+	// Negative sizes are kept so validation can reject them
+	opts.MaxRequestBodyBytes = pbOpts.GetMaxRequestBodyBytes()
+	opts.MaxConcurrentRequests = int(pbOpts.GetMaxConcurrentRequests())
+	opts.H2C = pbOpts.GetH2C()
+	opts.TrustedProxies = pbOpts.GetTrustedProxies()
+	opts.NotFoundAppID = pbOpts.GetNotFoundAppId()
+	opts.MethodNotAllowedAppID = pbOpts.GetMethodNotAllowedAppId()
+
 	return opts
 }
 
 // HTTPToProto converts domain HTTPOptions to protobuf HttpListenerOptions
 func HTTPToProto(opts HTTP) *pb.HttpListenerOptions {
 	pbOpts := &pb.HttpListenerOptions{
-		ReadTimeout:  durationpb.New(opts.ReadTimeout),
-		WriteTimeout: durationpb.New(opts.WriteTimeout),
+		ReadTimeout:       durationpb.New(opts.ReadTimeout),
+		ReadHeaderTimeout: durationpb.New(opts.ReadHeaderTimeout),
+		WriteTimeout:      durationpb.New(opts.WriteTimeout),
+		DrainTimeout:      durationpb.New(opts.DrainTimeout),
+		IdleTimeout:       durationpb.New(opts.IdleTimeout),
+	}
+	if opts.MaxRequestBodyBytes != 0 {
+		pbOpts.MaxRequestBodyBytes = proto.Int64(opts.MaxRequestBodyBytes)
+	}
+	if opts.MaxConcurrentRequests != 0 {
+		pbOpts.MaxConcurrentRequests = proto.Int32(int32(opts.MaxConcurrentRequests))
+	}
+	if opts.H2C {
+		pbOpts.H2C = proto.Bool(true)
+	}
+	pbOpts.TrustedProxies = opts.TrustedProxies
+	if opts.NotFoundAppID != "" {
+		pbOpts.NotFoundAppId = proto.String(opts.NotFoundAppID)
+	}
+	if opts.MethodNotAllowedAppID != "" {
+		pbOpts.MethodNotAllowedAppId = proto.String(opts.MethodNotAllowedAppID)
+	}
+	return pbOpts
+}
+
+// TCPFromProto converts protobuf TcpListenerOptions to domain TCP options
+func TCPFromProto(pbOpts *pb.TcpListenerOptions) TCP {
+	opts := NewTCP()
+	if pbOpts == nil {
+		return opts
+	}
+
+	opts.Upstream = pbOpts.GetUpstream()
+
+	if pbOpts.DialTimeout != nil {
+		d := pbOpts.DialTimeout.AsDuration()
+		if d > 0 {
+			opts.DialTimeout = d
+		}
+	}
+
+	if pbOpts.DrainTimeout != nil {
+		d := pbOpts.DrainTimeout.AsDuration()
+		if d > 0 {
+			opts.DrainTimeout = d
+		}
+	}
+
+	return opts
+}
+
+// TCPToProto converts domain TCP options to protobuf TcpListenerOptions
+func TCPToProto(opts TCP) *pb.TcpListenerOptions {
+	pbOpts := &pb.TcpListenerOptions{
+		DialTimeout:  durationpb.New(opts.DialTimeout),
 		DrainTimeout: durationpb.New(opts.DrainTimeout),
-		IdleTimeout:  durationpb.New(opts.IdleTimeout),
+	}
+	if opts.Upstream != "" {
+		pbOpts.Upstream = proto.String(opts.Upstream)
 	}
 	return pbOpts
 }
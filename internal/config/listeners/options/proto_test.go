@@ -6,6 +6,7 @@ import (
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 )
 
@@ -28,16 +29,18 @@ func TestHTTPFromProto(t *testing.T) {
 		{
 			name: "Full valid proto options are correctly converted",
 			pbOpts: &pb.HttpListenerOptions{
-				ReadTimeout:  durationpb.New(20 * time.Second),
-				WriteTimeout: durationpb.New(25 * time.Second),
-				DrainTimeout: durationpb.New(35 * time.Second),
-				IdleTimeout:  durationpb.New(75 * time.Second),
+				ReadTimeout:       durationpb.New(20 * time.Second),
+				ReadHeaderTimeout: durationpb.New(3 * time.Second),
+				WriteTimeout:      durationpb.New(25 * time.Second),
+				DrainTimeout:      durationpb.New(35 * time.Second),
+				IdleTimeout:       durationpb.New(75 * time.Second),
 			},
 			expected: HTTP{
-				ReadTimeout:  20 * time.Second,
-				WriteTimeout: 25 * time.Second,
-				DrainTimeout: 35 * time.Second,
-				IdleTimeout:  75 * time.Second,
+				ReadTimeout:       20 * time.Second,
+				ReadHeaderTimeout: 3 * time.Second,
+				WriteTimeout:      25 * time.Second,
+				DrainTimeout:      35 * time.Second,
+				IdleTimeout:       75 * time.Second,
 			},
 		},
 		{
@@ -67,10 +70,95 @@ func TestHTTPFromProto(t *testing.T) {
 				// Other fields not set
 			},
 			expected: HTTP{
-				ReadTimeout:  15 * time.Second,
-				WriteTimeout: DefaultHTTPWriteTimeout,
-				DrainTimeout: DefaultHTTPDrainTimeout,
-				IdleTimeout:  DefaultHTTPIdleTimeout,
+				ReadTimeout:       15 * time.Second,
+				ReadHeaderTimeout: DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:      DefaultHTTPWriteTimeout,
+				DrainTimeout:      DefaultHTTPDrainTimeout,
+				IdleTimeout:       DefaultHTTPIdleTimeout,
+			},
+		},
+		{
+			name: "Max request body size is copied, including negative values",
+			pbOpts: &pb.HttpListenerOptions{
+				MaxRequestBodyBytes: proto.Int64(-1),
+			},
+			expected: HTTP{
+				ReadTimeout:         DefaultHTTPReadTimeout,
+				ReadHeaderTimeout:   DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:        DefaultHTTPWriteTimeout,
+				DrainTimeout:        DefaultHTTPDrainTimeout,
+				IdleTimeout:         DefaultHTTPIdleTimeout,
+				MaxRequestBodyBytes: -1,
+			},
+		},
+		{
+			name: "Max concurrent requests are copied, including negative values",
+			pbOpts: &pb.HttpListenerOptions{
+				MaxConcurrentRequests: proto.Int32(-1),
+			},
+			expected: HTTP{
+				ReadTimeout:           DefaultHTTPReadTimeout,
+				ReadHeaderTimeout:     DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:          DefaultHTTPWriteTimeout,
+				DrainTimeout:          DefaultHTTPDrainTimeout,
+				IdleTimeout:           DefaultHTTPIdleTimeout,
+				MaxConcurrentRequests: -1,
+			},
+		},
+		{
+			name: "H2C is copied",
+			pbOpts: &pb.HttpListenerOptions{
+				H2C: proto.Bool(true),
+			},
+			expected: HTTP{
+				ReadTimeout:       DefaultHTTPReadTimeout,
+				ReadHeaderTimeout: DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:      DefaultHTTPWriteTimeout,
+				DrainTimeout:      DefaultHTTPDrainTimeout,
+				IdleTimeout:       DefaultHTTPIdleTimeout,
+				H2C:               true,
+			},
+		},
+		{
+			name: "Trusted proxies are copied",
+			pbOpts: &pb.HttpListenerOptions{
+				TrustedProxies: []string{"10.0.0.0/8", "127.0.0.1"},
+			},
+			expected: HTTP{
+				ReadTimeout:       DefaultHTTPReadTimeout,
+				ReadHeaderTimeout: DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:      DefaultHTTPWriteTimeout,
+				DrainTimeout:      DefaultHTTPDrainTimeout,
+				IdleTimeout:       DefaultHTTPIdleTimeout,
+				TrustedProxies:    []string{"10.0.0.0/8", "127.0.0.1"},
+			},
+		},
+		{
+			name: "Not found app ID is copied",
+			pbOpts: &pb.HttpListenerOptions{
+				NotFoundAppId: proto.String("not-found"),
+			},
+			expected: HTTP{
+				ReadTimeout:       DefaultHTTPReadTimeout,
+				ReadHeaderTimeout: DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:      DefaultHTTPWriteTimeout,
+				DrainTimeout:      DefaultHTTPDrainTimeout,
+				IdleTimeout:       DefaultHTTPIdleTimeout,
+				NotFoundAppID:     "not-found",
+			},
+		},
+		{
+			name: "Method not allowed app ID is copied",
+			pbOpts: &pb.HttpListenerOptions{
+				MethodNotAllowedAppId: proto.String("not-allowed"),
+			},
+			expected: HTTP{
+				ReadTimeout:           DefaultHTTPReadTimeout,
+				ReadHeaderTimeout:     DefaultHTTPReadHeaderTimeout,
+				WriteTimeout:          DefaultHTTPWriteTimeout,
+				DrainTimeout:          DefaultHTTPDrainTimeout,
+				IdleTimeout:           DefaultHTTPIdleTimeout,
+				MethodNotAllowedAppID: "not-allowed",
 			},
 		},
 	}
@@ -93,10 +181,11 @@ func TestHTTPToProto(t *testing.T) {
 			name: "Default HTTP options are correctly converted",
 			opts: NewHTTP(),
 			expected: &pb.HttpListenerOptions{
-				ReadTimeout:  durationpb.New(DefaultHTTPReadTimeout),
-				WriteTimeout: durationpb.New(DefaultHTTPWriteTimeout),
-				DrainTimeout: durationpb.New(DefaultHTTPDrainTimeout),
-				IdleTimeout:  durationpb.New(DefaultHTTPIdleTimeout),
+				ReadTimeout:       durationpb.New(DefaultHTTPReadTimeout),
+				ReadHeaderTimeout: durationpb.New(DefaultHTTPReadHeaderTimeout),
+				WriteTimeout:      durationpb.New(DefaultHTTPWriteTimeout),
+				DrainTimeout:      durationpb.New(DefaultHTTPDrainTimeout),
+				IdleTimeout:       durationpb.New(DefaultHTTPIdleTimeout),
 			},
 		},
 		{
@@ -114,6 +203,58 @@ func TestHTTPToProto(t *testing.T) {
 				IdleTimeout:  durationpb.New(75 * time.Second),
 			},
 		},
+		{
+			name: "Max request body size is set when non-zero",
+			opts: HTTP{
+				ReadTimeout:         20 * time.Second,
+				WriteTimeout:        25 * time.Second,
+				DrainTimeout:        35 * time.Second,
+				IdleTimeout:         75 * time.Second,
+				MaxRequestBodyBytes: 4096,
+			},
+			expected: &pb.HttpListenerOptions{
+				ReadTimeout:         durationpb.New(20 * time.Second),
+				WriteTimeout:        durationpb.New(25 * time.Second),
+				DrainTimeout:        durationpb.New(35 * time.Second),
+				IdleTimeout:         durationpb.New(75 * time.Second),
+				MaxRequestBodyBytes: proto.Int64(4096),
+			},
+		},
+		{
+			name: "H2C is set when enabled",
+			opts: HTTP{H2C: true},
+			expected: &pb.HttpListenerOptions{
+				H2C: proto.Bool(true),
+			},
+		},
+		{
+			name: "Max concurrent requests are set when non-zero",
+			opts: HTTP{MaxConcurrentRequests: 100},
+			expected: &pb.HttpListenerOptions{
+				MaxConcurrentRequests: proto.Int32(100),
+			},
+		},
+		{
+			name: "Trusted proxies are set",
+			opts: HTTP{TrustedProxies: []string{"10.0.0.0/8"}},
+			expected: &pb.HttpListenerOptions{
+				TrustedProxies: []string{"10.0.0.0/8"},
+			},
+		},
+		{
+			name: "Not found app ID is set when configured",
+			opts: HTTP{NotFoundAppID: "not-found"},
+			expected: &pb.HttpListenerOptions{
+				NotFoundAppId: proto.String("not-found"),
+			},
+		},
+		{
+			name: "Method not allowed app ID is set when configured",
+			opts: HTTP{MethodNotAllowedAppID: "not-allowed"},
+			expected: &pb.HttpListenerOptions{
+				MethodNotAllowedAppId: proto.String("not-allowed"),
+			},
+		},
 		{
 			name: "Zero values are preserved in proto",
 			opts: HTTP{
@@ -152,9 +293,16 @@ func TestHTTPToProto(t *testing.T) {
 
 			// Check if durations match
 			assert.Equal(t, tt.expected.ReadTimeout.AsDuration(), result.ReadTimeout.AsDuration())
+			assert.Equal(t, tt.expected.ReadHeaderTimeout.AsDuration(), result.ReadHeaderTimeout.AsDuration())
 			assert.Equal(t, tt.expected.WriteTimeout.AsDuration(), result.WriteTimeout.AsDuration())
 			assert.Equal(t, tt.expected.DrainTimeout.AsDuration(), result.DrainTimeout.AsDuration())
 			assert.Equal(t, tt.expected.IdleTimeout.AsDuration(), result.IdleTimeout.AsDuration())
+			assert.Equal(t, tt.expected.MaxRequestBodyBytes, result.MaxRequestBodyBytes)
+			assert.Equal(t, tt.expected.MaxConcurrentRequests, result.MaxConcurrentRequests)
+			assert.Equal(t, tt.expected.H2C, result.H2C)
+			assert.Equal(t, tt.expected.TrustedProxies, result.TrustedProxies)
+			assert.Equal(t, tt.expected.NotFoundAppId, result.NotFoundAppId)
+			assert.Equal(t, tt.expected.MethodNotAllowedAppId, result.MethodNotAllowedAppId)
 		})
 	}
 }
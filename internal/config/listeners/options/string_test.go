@@ -19,12 +19,33 @@ func TestHTTP_String(t *testing.T) {
 			opts: NewHTTP(),
 			expected: []string{
 				"ReadTimeout: 10s",
+				"ReadHeaderTimeout: 5s",
 				"WriteTimeout: 10s",
 				"IdleTimeout: 1m0s",
 				"DrainTimeout: 30s",
 			},
 			notExpected: []string{},
 		},
+		{
+			name: "Max request body size",
+			opts: HTTP{
+				ReadTimeout:         20 * time.Second,
+				MaxRequestBodyBytes: 1024,
+			},
+			expected: []string{
+				"ReadTimeout: 20s",
+				"MaxRequestBodyBytes: 1024",
+			},
+			notExpected: []string{"H2C"},
+		},
+		{
+			name: "H2C enabled",
+			opts: HTTP{H2C: true},
+			expected: []string{
+				"H2C: true",
+			},
+			notExpected: []string{},
+		},
 		{
 			name: "Custom HTTP options",
 			opts: HTTP{
@@ -55,6 +76,7 @@ func TestHTTP_String(t *testing.T) {
 				"WriteTimeout",
 				"IdleTimeout",
 				"DrainTimeout",
+				"MaxRequestBodyBytes",
 			},
 		},
 		{
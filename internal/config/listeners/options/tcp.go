@@ -0,0 +1,116 @@
+package options
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/fancy"
+)
+
+// TCP default timeout values
+const (
+	DefaultTCPDialTimeout  = 5 * time.Second
+	DefaultTCPDrainTimeout = 30 * time.Second
+)
+
+// TCP contains configuration for a raw TCP listener that proxies each
+// accepted connection to an upstream address
+type TCP struct {
+	Upstream     string `env_interpolation:"yes"`
+	DialTimeout  time.Duration
+	DrainTimeout time.Duration
+}
+
+// NewTCP creates a new TCP with default values
+func NewTCP() TCP {
+	return TCP{
+		DialTimeout:  DefaultTCPDialTimeout,
+		DrainTimeout: DefaultTCPDrainTimeout,
+	}
+}
+
+// Type returns the listener type this options is for
+func (t TCP) Type() Type { return TypeTCP }
+
+// Validate checks TCP for any configuration errors
+func (t TCP) Validate() error {
+	var errs []error
+
+	if t.Upstream == "" {
+		errs = append(errs, fmt.Errorf("%w: TCP upstream address",
+			errz.ErrMissingRequiredField))
+	} else if _, _, err := net.SplitHostPort(t.Upstream); err != nil {
+		errs = append(errs, fmt.Errorf("%w: TCP upstream address '%s': %w",
+			errz.ErrInvalidValue, t.Upstream, err))
+	}
+
+	if t.DialTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("%w: TCP dial timeout must be positive",
+			errz.ErrInvalidValue))
+	}
+
+	if t.DrainTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("%w: TCP drain timeout must be positive",
+			errz.ErrInvalidValue))
+	}
+
+	return errors.Join(errs...)
+}
+
+// GetDialTimeout returns the upstream dial timeout
+func (t TCP) GetDialTimeout() time.Duration {
+	if t.DialTimeout <= 0 {
+		return DefaultTCPDialTimeout
+	}
+	return t.DialTimeout
+}
+
+// GetDrainTimeout returns the drain timeout
+func (t TCP) GetDrainTimeout() time.Duration {
+	if t.DrainTimeout <= 0 {
+		return DefaultTCPDrainTimeout
+	}
+	return t.DrainTimeout
+}
+
+// String returns a concise string representation of TCP options
+func (t TCP) String() string {
+	var b strings.Builder
+	if t.Upstream != "" {
+		fmt.Fprintf(&b, "Upstream: %s, ", t.Upstream)
+	}
+	if t.DialTimeout > 0 {
+		fmt.Fprintf(&b, "DialTimeout: %v, ", t.DialTimeout)
+	}
+	if t.DrainTimeout > 0 {
+		fmt.Fprintf(&b, "DrainTimeout: %v, ", t.DrainTimeout)
+	}
+
+	str := b.String()
+	if len(str) > 2 {
+		// Remove trailing comma and space
+		return str[:len(str)-2]
+	}
+	return str
+}
+
+// ToTree returns a tree visualization of TCP options
+func (t TCP) ToTree() *fancy.ComponentTree {
+	tree := fancy.NewComponentTree("TCP Options")
+
+	if t.Upstream != "" {
+		tree.AddChild(fmt.Sprintf("Upstream: %s", t.Upstream))
+	}
+	if t.DialTimeout > 0 {
+		tree.AddChild(fmt.Sprintf("DialTimeout: %v", t.DialTimeout))
+	}
+	if t.DrainTimeout > 0 {
+		tree.AddChild(fmt.Sprintf("DrainTimeout: %v", t.DrainTimeout))
+	}
+
+	return tree
+}
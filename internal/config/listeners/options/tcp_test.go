@@ -0,0 +1,167 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestTCPOptions_Type(t *testing.T) {
+	opts := TCP{}
+	assert.Equal(t, TypeTCP, opts.Type())
+}
+
+func TestNewTCPOptions(t *testing.T) {
+	opts := NewTCP()
+	assert.Empty(t, opts.Upstream)
+	assert.Equal(t, DefaultTCPDialTimeout, opts.DialTimeout)
+	assert.Equal(t, DefaultTCPDrainTimeout, opts.DrainTimeout)
+}
+
+func TestTCPOptions_Validate(t *testing.T) {
+	withUpstream := func(upstream string) TCP {
+		opts := NewTCP()
+		opts.Upstream = upstream
+		return opts
+	}
+
+	tests := []struct {
+		name          string
+		opts          TCP
+		expectedErr   error
+		errorContains string
+	}{
+		{
+			name: "Upstream with defaults is valid",
+			opts: withUpstream("127.0.0.1:5432"),
+		},
+		{
+			name: "Hostname upstream is valid",
+			opts: withUpstream("db.internal:5432"),
+		},
+		{
+			name:          "Missing upstream is invalid",
+			opts:          NewTCP(),
+			expectedErr:   errz.ErrMissingRequiredField,
+			errorContains: "TCP upstream address",
+		},
+		{
+			name:          "Upstream without port is invalid",
+			opts:          withUpstream("127.0.0.1"),
+			expectedErr:   errz.ErrInvalidValue,
+			errorContains: "TCP upstream address '127.0.0.1'",
+		},
+		{
+			name:          "Zero dial timeout is invalid",
+			opts:          TCP{Upstream: "127.0.0.1:5432", DrainTimeout: time.Second},
+			expectedErr:   errz.ErrInvalidValue,
+			errorContains: "TCP dial timeout must be positive",
+		},
+		{
+			name:          "Negative drain timeout is invalid",
+			opts:          TCP{Upstream: "127.0.0.1:5432", DialTimeout: time.Second, DrainTimeout: -time.Second},
+			expectedErr:   errz.ErrInvalidValue,
+			errorContains: "TCP drain timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.ErrorIs(t, err, tt.expectedErr)
+			assert.Contains(t, err.Error(), tt.errorContains)
+		})
+	}
+}
+
+func TestTCPOptions_GetTimeouts(t *testing.T) {
+	t.Run("custom values", func(t *testing.T) {
+		opts := TCP{DialTimeout: 2 * time.Second, DrainTimeout: 10 * time.Second}
+		assert.Equal(t, 2*time.Second, opts.GetDialTimeout())
+		assert.Equal(t, 10*time.Second, opts.GetDrainTimeout())
+	})
+
+	t.Run("zero values fall back to defaults", func(t *testing.T) {
+		opts := TCP{}
+		assert.Equal(t, DefaultTCPDialTimeout, opts.GetDialTimeout())
+		assert.Equal(t, DefaultTCPDrainTimeout, opts.GetDrainTimeout())
+	})
+}
+
+func TestTCPOptions_String(t *testing.T) {
+	assert.Empty(t, TCP{}.String())
+
+	opts := TCP{
+		Upstream:     "127.0.0.1:5432",
+		DialTimeout:  2 * time.Second,
+		DrainTimeout: 10 * time.Second,
+	}
+	assert.Equal(t, "Upstream: 127.0.0.1:5432, DialTimeout: 2s, DrainTimeout: 10s", opts.String())
+
+	tree := opts.ToTree()
+	require.NotNil(t, tree)
+	output := tree.Tree().String()
+	assert.Contains(t, output, "TCP Options")
+	assert.Contains(t, output, "Upstream: 127.0.0.1:5432")
+	assert.Contains(t, output, "DialTimeout: 2s")
+	assert.Contains(t, output, "DrainTimeout: 10s")
+}
+
+func TestTCPFromProto(t *testing.T) {
+	t.Run("nil proto options returns defaults", func(t *testing.T) {
+		assert.Equal(t, NewTCP(), TCPFromProto(nil))
+	})
+
+	t.Run("full proto options are converted", func(t *testing.T) {
+		opts := TCPFromProto(&pb.TcpListenerOptions{
+			Upstream:     proto.String("127.0.0.1:5432"),
+			DialTimeout:  durationpb.New(2 * time.Second),
+			DrainTimeout: durationpb.New(10 * time.Second),
+		})
+		assert.Equal(t, TCP{
+			Upstream:     "127.0.0.1:5432",
+			DialTimeout:  2 * time.Second,
+			DrainTimeout: 10 * time.Second,
+		}, opts)
+	})
+
+	t.Run("non-positive durations use defaults", func(t *testing.T) {
+		opts := TCPFromProto(&pb.TcpListenerOptions{
+			Upstream:     proto.String("127.0.0.1:5432"),
+			DialTimeout:  durationpb.New(0),
+			DrainTimeout: durationpb.New(-time.Second),
+		})
+		assert.Equal(t, DefaultTCPDialTimeout, opts.DialTimeout)
+		assert.Equal(t, DefaultTCPDrainTimeout, opts.DrainTimeout)
+	})
+}
+
+func TestTCPToProto(t *testing.T) {
+	opts := TCP{
+		Upstream:     "127.0.0.1:5432",
+		DialTimeout:  2 * time.Second,
+		DrainTimeout: 10 * time.Second,
+	}
+
+	pbOpts := TCPToProto(opts)
+	assert.Equal(t, "127.0.0.1:5432", pbOpts.GetUpstream())
+	assert.Equal(t, 2*time.Second, pbOpts.GetDialTimeout().AsDuration())
+	assert.Equal(t, 10*time.Second, pbOpts.GetDrainTimeout().AsDuration())
+
+	// Round trip
+	assert.Equal(t, opts, TCPFromProto(pbOpts))
+
+	// Empty upstream is left unset
+	assert.Nil(t, TCPToProto(NewTCP()).Upstream)
+}
@@ -13,6 +13,7 @@ type Type string
 const (
 	Unknown  Type = ""
 	TypeHTTP Type = "http"
+	TypeTCP  Type = "tcp"
 )
 
 // Options represents protocol-specific options for listeners
@@ -22,6 +22,11 @@ func TestType_Values(t *testing.T) {
 			typeVal:  TypeHTTP,
 			expected: "http",
 		},
+		{
+			name:     "TCP type",
+			typeVal:  TypeTCP,
+			expected: "tcp",
+		},
 	}
 
 	for _, tt := range tests {
@@ -34,4 +39,5 @@ func TestType_Values(t *testing.T) {
 func TestOptions_Interface(t *testing.T) {
 	// Test that HTTPOptions implements Options interface
 	var _ Options = HTTP{}
+	var _ Options = TCP{}
 }
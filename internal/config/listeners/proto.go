@@ -2,7 +2,10 @@
 package listeners
 
 import (
+	"fmt"
+
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
 	"github.com/robbyt/protobaggins"
 )
@@ -30,6 +33,15 @@ func (listeners ListenerCollection) ToProto() []*pb.Listener {
 			pbListener.ProtocolOptions = &pb.Listener_Http{
 				Http: options.HTTPToProto(opts),
 			}
+		case options.TCP:
+			pbListener.ProtocolOptions = &pb.Listener_Tcp{
+				Tcp: options.TCPToProto(opts),
+			}
+		}
+
+		// Convert default middlewares if present
+		if len(l.Middlewares) > 0 {
+			pbListener.Middlewares = l.Middlewares.ToProto()
 		}
 
 		pbListeners = append(pbListeners, pbListener)
@@ -56,9 +68,22 @@ func FromProto(pbListeners []*pb.Listener) (ListenerCollection, error) {
 			listenerObj.Type = Type(*l.Type)
 		}
 
-		// Convert protocol-specific options
-		// HTTPFromProto handles nil gracefully and applies defaults
-		listenerObj.Options = options.HTTPFromProto(l.GetHttp())
+		// Convert protocol-specific options, the FromProto helpers handle nil
+		// gracefully and apply defaults
+		if listenerObj.Type == TypeTCP || l.GetTcp() != nil {
+			listenerObj.Options = options.TCPFromProto(l.GetTcp())
+		} else {
+			listenerObj.Options = options.HTTPFromProto(l.GetHttp())
+		}
+
+		// Convert default middlewares
+		if len(l.Middlewares) > 0 {
+			middlewares, err := middleware.FromProto(l.Middlewares)
+			if err != nil {
+				return nil, fmt.Errorf("listener '%s' middleware: %w", listenerObj.ID, err)
+			}
+			listenerObj.Middlewares = middlewares
+		}
 
 		listeners = append(listeners, listenerObj)
 	}
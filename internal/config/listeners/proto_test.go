@@ -5,6 +5,8 @@ import (
 	"time"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
 	"github.com/robbyt/protobaggins"
 	"github.com/stretchr/testify/assert"
@@ -215,6 +217,54 @@ func TestFromProto(t *testing.T) {
 			},
 			expectedError: false,
 		},
+		{
+			name: "Single TCP listener",
+			pbListeners: []*pb.Listener{
+				{
+					Id:      proto.String("tcp-listener"),
+					Address: proto.String("127.0.0.1:5432"),
+					Type:    pb.Listener_TYPE_TCP.Enum(),
+					ProtocolOptions: &pb.Listener_Tcp{
+						Tcp: &pb.TcpListenerOptions{
+							Upstream:    proto.String("10.0.0.5:5432"),
+							DialTimeout: durationpb.New(time.Second * 2),
+						},
+					},
+				},
+			},
+			expected: ListenerCollection{
+				{
+					ID:      "tcp-listener",
+					Address: "127.0.0.1:5432",
+					Type:    TypeTCP,
+					Options: options.TCP{
+						Upstream:     "10.0.0.5:5432",
+						DialTimeout:  time.Second * 2,
+						DrainTimeout: options.DefaultTCPDrainTimeout,
+					},
+				},
+			},
+			expectedError: false,
+		},
+		{
+			name: "TCP listener without options gets TCP defaults",
+			pbListeners: []*pb.Listener{
+				{
+					Id:      proto.String("tcp-listener"),
+					Address: proto.String("127.0.0.1:5432"),
+					Type:    pb.Listener_TYPE_TCP.Enum(),
+				},
+			},
+			expected: ListenerCollection{
+				{
+					ID:      "tcp-listener",
+					Address: "127.0.0.1:5432",
+					Type:    TypeTCP,
+					Options: options.NewTCP(),
+				},
+			},
+			expectedError: false,
+		},
 		{
 			name: "Multiple HTTP listeners",
 			pbListeners: []*pb.Listener{
@@ -451,3 +501,36 @@ func TestRoundTripConversion(t *testing.T) {
 		}
 	}
 }
+
+func TestListener_MiddlewaresProto(t *testing.T) {
+	t.Parallel()
+
+	original := ListenerCollection{{
+		ID:      "http",
+		Address: ":8080",
+		Type:    TypeHTTP,
+		Options: options.NewHTTP(),
+		Middlewares: middleware.MiddlewareCollection{
+			{ID: "00-logger", Config: logger.NewConsoleLogger()},
+		},
+	}}
+
+	pbListeners := original.ToProto()
+	require.Len(t, pbListeners, 1)
+	require.Len(t, pbListeners[0].GetMiddlewares(), 1)
+	assert.Equal(t, "00-logger", pbListeners[0].GetMiddlewares()[0].GetId())
+
+	result, err := FromProto(pbListeners)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.Len(t, result[0].Middlewares, 1)
+	assert.Equal(t, "00-logger", result[0].Middlewares[0].ID)
+	assert.IsType(t, &logger.ConsoleLogger{}, result[0].Middlewares[0].Config)
+
+	t.Run("invalid middleware", func(t *testing.T) {
+		pbListeners[0].Middlewares[0].Config = nil
+		_, err := FromProto(pbListeners)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "listener 'http' middleware")
+	})
+}
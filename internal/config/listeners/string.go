@@ -39,6 +39,11 @@ func (l *Listener) ToTree() *fancy.ComponentTree {
 		tree.AddChild(optionsTree.Tree())
 	}
 
+	// Add default middlewares
+	if len(l.Middlewares) > 0 {
+		tree.AddChild(l.Middlewares.ToTree().Tree())
+	}
+
 	return tree
 }
 
@@ -47,6 +52,8 @@ func (t Type) String() string {
 	switch t {
 	case TypeHTTP:
 		return "HTTP"
+	case TypeTCP:
+		return "TCP"
 	default:
 		return fmt.Sprintf("Unknown(%d)", t)
 	}
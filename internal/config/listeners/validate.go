@@ -20,24 +20,35 @@ func (l *Listener) Validate() error {
 	}
 
 	if err := validation.ValidateID(l.ID, "listener ID"); err != nil {
-		errs = append(errs, err)
+		errs = append(errs, validation.ForField("id", err))
 	}
 
 	if l.Address == "" {
-		errs = append(errs, fmt.Errorf("%w: address for listener '%s'",
-			errz.ErrMissingRequiredField, l.ID))
+		errs = append(errs, validation.ForField("address", fmt.Errorf("%w: address for listener '%s'",
+			errz.ErrMissingRequiredField, l.ID)))
 	}
 
 	// Validate Type
 	switch l.Type {
-	case TypeHTTP:
+	case TypeHTTP, TypeTCP:
 		// Valid types
 	case TypeUnspecified:
-		errs = append(errs, fmt.Errorf("%w: type for listener '%s'",
-			errz.ErrMissingRequiredField, l.ID))
+		errs = append(errs, validation.ForField("type", fmt.Errorf("%w: type for listener '%s'",
+			errz.ErrMissingRequiredField, l.ID)))
 	default:
-		errs = append(errs, fmt.Errorf("%w: listener '%s' has invalid type '%d'",
-			ErrInvalidListenerType, l.ID, l.Type))
+		errs = append(errs, validation.ForField("type", fmt.Errorf("%w: listener '%s' has invalid type '%d'",
+			ErrInvalidListenerType, l.ID, l.Type)))
+	}
+
+	// Default middlewares wrap HTTP endpoints, TCP listeners have none
+	if len(l.Middlewares) > 0 && l.Type == TypeTCP {
+		errs = append(errs, validation.ForField("middlewares", fmt.Errorf(
+			"%w: listener '%s' is a TCP listener and can't have middlewares",
+			errz.ErrInvalidValue, l.ID)))
+	}
+	if err := l.Middlewares.Validate(); err != nil {
+		errs = append(errs, validation.ForField("middlewares",
+			fmt.Errorf("middlewares in listener '%s': %w", l.ID, err)))
 	}
 
 	// Handle nil Options case
@@ -54,6 +65,8 @@ func (l *Listener) Validate() error {
 	switch l.Type {
 	case TypeHTTP:
 		expectedOptionsType = options.TypeHTTP
+	case TypeTCP:
+		expectedOptionsType = options.TypeTCP
 	}
 
 	optionsType := l.Options.Type()
@@ -74,8 +87,27 @@ func (l *Listener) Validate() error {
 
 		// Validate HTTP-specific options
 		if optErr := opts.Validate(); optErr != nil {
-			errs = append(errs, fmt.Errorf("invalid HTTP options for listener '%s': %w",
-				l.ID, optErr))
+			errs = append(errs, validation.ForField("http", fmt.Errorf("invalid HTTP options for listener '%s': %w",
+				l.ID, optErr)))
+		}
+
+	case options.TCP:
+		if l.Type != TypeTCP {
+			errs = append(errs, fmt.Errorf(
+				"listener '%s' has TCP options but type is '%s'",
+				l.ID, l.GetTypeString()))
+		}
+
+		// Options are held by value behind an interface, so interpolate a
+		// copy and store it back on the listener
+		if err := interpolation.InterpolateStruct(&opts); err != nil {
+			errs = append(errs, fmt.Errorf("interpolation failed for listener '%s': %w", l.ID, err))
+		}
+		l.Options = opts
+
+		if optErr := opts.Validate(); optErr != nil {
+			errs = append(errs, validation.ForField("tcp", fmt.Errorf("invalid TCP options for listener '%s': %w",
+				l.ID, optErr)))
 		}
 
 	default:
@@ -3,6 +3,9 @@ package listeners
 import (
 	"testing"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	"github.com/stretchr/testify/assert"
@@ -39,6 +42,46 @@ func TestListener_Validate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "Valid TCP Listener",
+			listener: Listener{
+				ID:      "tcp1",
+				Address: ":5432",
+				Type:    TypeTCP,
+				Options: options.TCP{
+					Upstream:     "127.0.0.1:15432",
+					DialTimeout:  options.DefaultTCPDialTimeout,
+					DrainTimeout: options.DefaultTCPDrainTimeout,
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "TCP Listener without upstream",
+			listener: Listener{
+				ID:      "tcp1",
+				Address: ":5432",
+				Type:    TypeTCP,
+				Options: options.NewTCP(),
+			},
+			wantError:   true,
+			errContains: "invalid TCP options for listener 'tcp1'",
+		},
+		{
+			name: "TCP options on HTTP listener",
+			listener: Listener{
+				ID:      "mixed",
+				Address: ":8080",
+				Type:    TypeHTTP,
+				Options: options.TCP{
+					Upstream:     "127.0.0.1:15432",
+					DialTimeout:  options.DefaultTCPDialTimeout,
+					DrainTimeout: options.DefaultTCPDrainTimeout,
+				},
+			},
+			wantError:   true,
+			errContains: "has TCP options but type is 'HTTP'",
+		},
 		{
 			name: "Empty ID",
 			listener: Listener{
@@ -96,6 +139,48 @@ func TestListener_Validate(t *testing.T) {
 			wantError:   true,
 			errContains: "type for listener",
 		},
+		{
+			name: "HTTP Listener with default middlewares",
+			listener: Listener{
+				ID:      "http1",
+				Address: ":8080",
+				Type:    TypeHTTP,
+				Options: options.NewHTTP(),
+				Middlewares: middleware.MiddlewareCollection{
+					{ID: "00-logger", Config: logger.NewConsoleLogger()},
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "Invalid default middleware",
+			listener: Listener{
+				ID:      "http1",
+				Address: ":8080",
+				Type:    TypeHTTP,
+				Options: options.NewHTTP(),
+				Middlewares: middleware.MiddlewareCollection{
+					{ID: "00-logger"},
+				},
+			},
+			wantError:   true,
+			errContains: "middlewares in listener 'http1'",
+		},
+		{
+			name: "TCP Listener with default middlewares",
+			listener: Listener{
+				ID:      "tcp1",
+				Address: ":9000",
+				Type:    TypeTCP,
+				Options: options.NewTCP(),
+				Middlewares: middleware.MiddlewareCollection{
+					{ID: "00-logger", Config: logger.NewConsoleLogger()},
+				},
+			},
+			wantError:   true,
+			errIs:       errz.ErrInvalidValue,
+			errContains: "TCP listener and can't have middlewares",
+		},
 		{
 			name: "Unknown Options Type",
 			listener: Listener{
@@ -129,6 +214,25 @@ func TestListener_Validate(t *testing.T) {
 	}
 }
 
+func TestListener_Validate_InterpolatesTCPUpstream(t *testing.T) {
+	t.Setenv("TCP_UPSTREAM_HOST", "db.internal")
+
+	opts := options.NewTCP()
+	opts.Upstream = "${TCP_UPSTREAM_HOST}:${TCP_UPSTREAM_PORT:5432}"
+	listener := Listener{
+		ID:      "tcp1",
+		Address: ":5432",
+		Type:    TypeTCP,
+		Options: opts,
+	}
+
+	require.NoError(t, listener.Validate())
+
+	tcpOpts, ok := listener.GetTCPOptions()
+	require.True(t, ok)
+	assert.Equal(t, "db.internal:5432", tcpOpts.Upstream)
+}
+
 // customOptions is a test-only implementation of Options interface
 type customOptions struct{}
 
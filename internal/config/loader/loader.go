@@ -19,6 +19,8 @@ type Loader interface {
 	LoadProto() (*pbSettings.ServerConfig, error)
 	// GetProtoConfig returns the underlying Protocol Buffer configuration
 	GetProtoConfig() *pbSettings.ServerConfig // TODO: add memoization to LoadProto and remove this
+	// Warnings returns the deprecated forms LoadProto found in the source
+	Warnings() []error
 }
 
 // NewLoaderFromBytes creates a new Loader with the provided bytes
@@ -61,3 +63,41 @@ func NewLoaderFromFilePath(filePath string) (Loader, error) {
 		return nil, FormatFileError(ErrUnsupportedExtension, ext)
 	}
 }
+
+// OverlayPath returns the path of the overlay file for env next to the config
+// file at filePath: config.toml's overlay for "prod" is config.prod.toml
+func OverlayPath(filePath, env string) string {
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + "." + env + ext
+}
+
+// NewLoaderForEnv creates a new Loader from a file path, with the overlay file
+// for env, see OverlayPath, deep-merged over it. Without an env it's the same
+// as NewLoaderFromFilePath. The overlay file must exist when env is set.
+func NewLoaderForEnv(filePath, env string) (Loader, error) {
+	if env == "" {
+		return NewLoaderFromFilePath(filePath)
+	}
+
+	base, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	overlay, err := readConfigFile(OverlayPath(filePath, env))
+	if err != nil {
+		return nil, err
+	}
+	return toml.NewTomlLoaderWithOverlay(base, overlay), nil
+}
+
+// readConfigFile reads a TOML config file
+func readConfigFile(filePath string) ([]byte, error) {
+	if ext := strings.ToLower(filepath.Ext(filePath)); ext != ".toml" {
+		return nil, FormatFileError(ErrUnsupportedExtension, ext)
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToLoadConfig, FormatFileError(err, filePath))
+	}
+	return data, nil
+}
@@ -137,6 +137,58 @@ version = "v1"
 	})
 }
 
+func TestOverlayPath(t *testing.T) {
+	assert.Equal(t, "/etc/firelynx/config.prod.toml", OverlayPath("/etc/firelynx/config.toml", "prod"))
+	assert.Equal(t, "config.dev.toml", OverlayPath("config.toml", "dev"))
+}
+
+func TestNewLoaderForEnv(t *testing.T) {
+	tempDir := t.TempDir()
+	configPath := filepath.Join(tempDir, "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+version = "v1"
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+`), 0o644))
+	require.NoError(t, os.WriteFile(OverlayPath(configPath, "dev"), []byte(`
+[[listeners]]
+id = "http"
+address = ":18080"
+`), 0o644))
+
+	t.Run("OverlayMerged", func(t *testing.T) {
+		loader, err := NewLoaderForEnv(configPath, "dev")
+		require.NoError(t, err)
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetListeners(), 1)
+		assert.Equal(t, ":18080", config.GetListeners()[0].GetAddress())
+		assert.Equal(t, pbSettings.Listener_TYPE_HTTP, config.GetListeners()[0].GetType())
+	})
+
+	t.Run("NoEnv", func(t *testing.T) {
+		loader, err := NewLoaderForEnv(configPath, "")
+		require.NoError(t, err)
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetListeners(), 1)
+		assert.Equal(t, ":8080", config.GetListeners()[0].GetAddress())
+	})
+
+	t.Run("MissingOverlay", func(t *testing.T) {
+		_, err := NewLoaderForEnv(configPath, "prod")
+		require.ErrorIs(t, err, ErrFailedToLoadConfig)
+		assert.Contains(t, err.Error(), "config.prod.toml")
+	})
+
+	t.Run("UnsupportedExtension", func(t *testing.T) {
+		_, err := NewLoaderForEnv(filepath.Join(tempDir, "config.yaml"), "dev")
+		require.ErrorIs(t, err, ErrUnsupportedExtension)
+	})
+}
+
 // errorReader implements a simple io.Reader that always returns an error
 type errorReader struct {
 	err error
@@ -188,3 +240,7 @@ func (l *testLoader) LoadProto() (*pbSettings.ServerConfig, error) {
 func (l *testLoader) GetProtoConfig() *pbSettings.ServerConfig {
 	return nil
 }
+
+func (l *testLoader) Warnings() []error {
+	return nil
+}
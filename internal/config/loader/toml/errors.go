@@ -13,4 +13,15 @@ var (
 	ErrUnmarshalProto       = errors.New("failed to unmarshal proto")
 	ErrPostProcessConfig    = errors.New("failed to post-process config")
 	ErrUnsupportedConfigVer = errz.ErrUnsupportedConfigVer
+
+	// ErrDeprecatedRoute is the warning for an endpoint using the legacy
+	// singular route table
+	ErrDeprecatedRoute = errors.New("the singular [endpoints.route] table is deprecated, use [[endpoints.routes]]")
+
+	// ErrInvalidOverlay indicates an overlay file with invalid merge settings
+	ErrInvalidOverlay = errors.New("invalid overlay")
+
+	// ErrRouteAndRoutes indicates an endpoint setting both the legacy route
+	// table and the routes array
+	ErrRouteAndRoutes = errors.New("route and routes can't both be set")
 )
@@ -0,0 +1,124 @@
+package toml
+
+import (
+	"fmt"
+	"slices"
+)
+
+// overlayKey is the table of an overlay file holding its merge settings,
+// rather than config
+const overlayKey = "overlay"
+
+// ListMerge selects how an overlay's lists merge into the base config's
+// lists, when they aren't collections merged by ID
+type ListMerge string
+
+const (
+	// ListReplace replaces the base list with the overlay's, the default
+	ListReplace ListMerge = "replace"
+
+	// ListAppend appends the overlay's items to the base list
+	ListAppend ListMerge = "append"
+)
+
+// overlaySettings reads and removes the [overlay] table of an overlay's map
+func overlaySettings(overlay map[string]any) (ListMerge, error) {
+	raw, ok := overlay[overlayKey]
+	if !ok {
+		return ListReplace, nil
+	}
+	delete(overlay, overlayKey)
+
+	settings, ok := raw.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("%w: %s must be a table", ErrInvalidOverlay, overlayKey)
+	}
+	lists, ok := settings["lists"]
+	if !ok {
+		return ListReplace, nil
+	}
+	switch mode := ListMerge(fmt.Sprint(lists)); mode {
+	case ListReplace, ListAppend:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%w: %s.lists must be %q or %q, got %q",
+			ErrInvalidOverlay, overlayKey, ListReplace, ListAppend, mode)
+	}
+}
+
+// mergeOverlay deep-merges overlay over base, in place:
+//
+//   - scalars in the overlay replace the base's
+//   - tables are merged key by key
+//   - collections, lists of tables that all have an id such as listeners,
+//     endpoints and apps, are merged by ID: a table whose ID is in the base
+//     is merged over it, others are appended
+//   - other lists, such as routes, are replaced or appended, per lists
+//
+// A key whose type differs between the two is replaced by the overlay's.
+func mergeOverlay(base, overlay map[string]any, lists ListMerge) {
+	for key, value := range overlay {
+		base[key] = mergeValue(base[key], value, lists)
+	}
+}
+
+func mergeValue(base, overlay any, lists ListMerge) any {
+	switch o := overlay.(type) {
+	case map[string]any:
+		b, ok := base.(map[string]any)
+		if !ok {
+			return o
+		}
+		mergeOverlay(b, o, lists)
+		return b
+	case []any:
+		b, ok := base.([]any)
+		if !ok {
+			return o
+		}
+		return mergeList(b, o, lists)
+	default:
+		return overlay
+	}
+}
+
+func mergeList(base, overlay []any, lists ListMerge) []any {
+	if isCollection(base) && isCollection(overlay) {
+		merged := slices.Clone(base)
+		for _, item := range overlay {
+			table := item.(map[string]any)
+			i := slices.IndexFunc(merged, func(b any) bool {
+				return b.(map[string]any)["id"] == table["id"]
+			})
+			if i < 0 {
+				merged = append(merged, table)
+				continue
+			}
+			mergeOverlay(merged[i].(map[string]any), table, lists)
+		}
+		return merged
+	}
+
+	if lists == ListAppend {
+		return append(slices.Clone(base), overlay...)
+	}
+	return overlay
+}
+
+// isCollection reports whether list is a non-empty list of tables that all
+// have a string id
+func isCollection(list []any) bool {
+	if len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		table, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+		if id, ok := table["id"].(string); !ok || id == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,190 @@
+package toml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeOverlay(t *testing.T) {
+	t.Parallel()
+
+	newBase := func() map[string]any {
+		return map[string]any{
+			"version": "v1",
+			"logging": map[string]any{"level": "info", "format": "json"},
+			"listeners": []any{
+				map[string]any{"id": "http", "address": ":8080"},
+				map[string]any{"id": "admin", "address": ":9090"},
+			},
+			"tags": []any{"a", "b"},
+		}
+	}
+
+	t.Run("scalars are replaced and tables merged", func(t *testing.T) {
+		base := newBase()
+		mergeOverlay(base, map[string]any{
+			"logging": map[string]any{"level": "debug"},
+		}, ListReplace)
+		assert.Equal(t, map[string]any{"level": "debug", "format": "json"}, base["logging"])
+		assert.Equal(t, "v1", base["version"])
+	})
+
+	t.Run("collections merge by ID", func(t *testing.T) {
+		base := newBase()
+		mergeOverlay(base, map[string]any{
+			"listeners": []any{
+				map[string]any{"id": "admin", "address": ":9191"},
+				map[string]any{"id": "metrics", "address": ":9292"},
+			},
+		}, ListReplace)
+		assert.Equal(t, []any{
+			map[string]any{"id": "http", "address": ":8080"},
+			map[string]any{"id": "admin", "address": ":9191"},
+			map[string]any{"id": "metrics", "address": ":9292"},
+		}, base["listeners"])
+	})
+
+	t.Run("lists are replaced", func(t *testing.T) {
+		base := newBase()
+		mergeOverlay(base, map[string]any{"tags": []any{"c"}}, ListReplace)
+		assert.Equal(t, []any{"c"}, base["tags"])
+	})
+
+	t.Run("lists are appended", func(t *testing.T) {
+		base := newBase()
+		mergeOverlay(base, map[string]any{"tags": []any{"c"}}, ListAppend)
+		assert.Equal(t, []any{"a", "b", "c"}, base["tags"])
+	})
+
+	t.Run("lists of tables without IDs follow the list mode", func(t *testing.T) {
+		base := map[string]any{"routes": []any{map[string]any{"app_id": "a"}}}
+		mergeOverlay(base, map[string]any{"routes": []any{map[string]any{"app_id": "b"}}}, ListAppend)
+		assert.Equal(t, []any{
+			map[string]any{"app_id": "a"},
+			map[string]any{"app_id": "b"},
+		}, base["routes"])
+	})
+
+	t.Run("a different type is replaced", func(t *testing.T) {
+		base := newBase()
+		mergeOverlay(base, map[string]any{"logging": "off"}, ListReplace)
+		assert.Equal(t, "off", base["logging"])
+	})
+}
+
+func TestOverlaySettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		overlay map[string]any
+		want    ListMerge
+		wantErr bool
+	}{
+		{name: "no settings", overlay: map[string]any{}, want: ListReplace},
+		{name: "no lists", overlay: map[string]any{"overlay": map[string]any{}}, want: ListReplace},
+		{name: "append", overlay: map[string]any{"overlay": map[string]any{"lists": "append"}}, want: ListAppend},
+		{name: "replace", overlay: map[string]any{"overlay": map[string]any{"lists": "replace"}}, want: ListReplace},
+		{name: "unknown mode", overlay: map[string]any{"overlay": map[string]any{"lists": "merge"}}, wantErr: true},
+		{name: "not a table", overlay: map[string]any{"overlay": "append"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := overlaySettings(tt.overlay)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidOverlay)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NotContains(t, tt.overlay, overlayKey)
+		})
+	}
+}
+
+func TestTomlLoader_Overlay(t *testing.T) {
+	t.Parallel()
+
+	base := []byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`)
+
+	t.Run("overlay is merged before conversion", func(t *testing.T) {
+		overlay := []byte(`
+[overlay]
+lists = "append"
+
+[[listeners]]
+id = "http"
+address = ":80"
+
+[[endpoints]]
+id = "main"
+
+[[endpoints.routes]]
+app_id = "health"
+[endpoints.routes.http]
+path_prefix = "/healthz"
+
+[[apps]]
+id = "health"
+type = "echo"
+[apps.echo]
+response = "ok"
+`)
+		cfg, err := NewTomlLoaderWithOverlay(base, overlay).LoadProto()
+		require.NoError(t, err)
+
+		require.Len(t, cfg.GetListeners(), 1)
+		assert.Equal(t, ":80", cfg.GetListeners()[0].GetAddress())
+
+		require.Len(t, cfg.GetEndpoints(), 1)
+		routes := cfg.GetEndpoints()[0].GetRoutes()
+		require.Len(t, routes, 2)
+		assert.Equal(t, "echo", routes[0].GetAppId())
+		assert.Equal(t, "health", routes[1].GetAppId())
+
+		require.Len(t, cfg.GetApps(), 2)
+		assert.Equal(t, "hello", cfg.GetApps()[0].GetEcho().GetResponse())
+		assert.Equal(t, "ok", cfg.GetApps()[1].GetEcho().GetResponse())
+	})
+
+	t.Run("invalid overlay", func(t *testing.T) {
+		_, err := NewTomlLoaderWithOverlay(base, []byte(`[invalid`)).LoadProto()
+		require.ErrorIs(t, err, ErrParseToml)
+		assert.Contains(t, err.Error(), "overlay")
+	})
+
+	t.Run("unsupported overlay version", func(t *testing.T) {
+		_, err := NewTomlLoaderWithOverlay(base, []byte(`version = "v2"`)).LoadProto()
+		require.ErrorIs(t, err, ErrUnsupportedConfigVer)
+	})
+
+	t.Run("invalid merge settings", func(t *testing.T) {
+		_, err := NewTomlLoaderWithOverlay(base, []byte("[overlay]\nlists = \"merge\"")).LoadProto()
+		require.ErrorIs(t, err, ErrInvalidOverlay)
+	})
+}
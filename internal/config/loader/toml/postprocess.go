@@ -28,6 +28,7 @@ import (
 	pbData "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/data/v1"
 	pbMiddleware "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/robbyt/protobaggins"
 )
 
@@ -52,6 +53,9 @@ func (l *TomlLoader) postProcessConfig(
 	errs = processApps(config, configMap)
 	errList = append(errList, errs...)
 
+	errs = processApplyHooks(config, configMap)
+	errList = append(errList, errs...)
+
 	return errors.Join(errList...)
 }
 
@@ -81,6 +85,13 @@ func processListeners(config *pbSettings.ServerConfig, configMap map[string]any)
 				errs := processListenerType(listener, typeVal)
 				errList = append(errList, errs...)
 			}
+
+			// Process the listener's default middlewares
+			if middlewaresArray, ok := listenerMap["middlewares"].([]any); ok {
+				errs := processMiddlewareList(
+					listener.Middlewares, middlewaresArray, fmt.Sprintf("listener %d", i))
+				errList = append(errList, errs...)
+			}
 		}
 	}
 
@@ -95,6 +106,8 @@ func processListenerType(listener *pbSettings.Listener, typeVal string) []error
 	switch typeVal {
 	case "http":
 		listenerType = pbSettings.Listener_TYPE_HTTP
+	case "tcp":
+		listenerType = pbSettings.Listener_TYPE_TCP
 	default:
 		listenerType = pbSettings.Listener_TYPE_UNSPECIFIED
 		errList = append(errList, fmt.Errorf("%w: %s", errz.ErrUnsupportedListenerType, typeVal))
@@ -129,6 +142,14 @@ func processEndpoints(config *pbSettings.ServerConfig, configMap map[string]any)
 				endpoint.ListenerId = &listenerId
 			}
 
+			// Process the endpoint's default static_data for its routes
+			if staticDataMap, ok := endpointMap["static_data"].(map[string]any); ok {
+				if endpoint.StaticData == nil {
+					endpoint.StaticData = &pbData.StaticData{}
+				}
+				endpoint.StaticData.Data = protobaggins.MapToStructValues(staticDataMap)
+			}
+
 			// Process routes array for static_data
 			if routesArray, ok := endpointMap["routes"].([]any); ok {
 				for j, routeObj := range routesArray {
@@ -149,12 +170,22 @@ func processEndpoints(config *pbSettings.ServerConfig, configMap map[string]any)
 						}
 						route.StaticData.Data = protobaggins.MapToStructValues(staticDataMap)
 					}
+
+					// Process header conditions for this route
+					if headersArray, ok := routeMap["headers"].([]any); ok {
+						errs := processRouteHeaders(endpoint.Routes[j], headersArray)
+						errList = append(errList, errs...)
+					}
 				}
 			}
 
-			// Handle the single route object (older format)
-			// This checks for 'route' field (singular) in addition to 'routes' (array)
-			if routeObj, ok := endpointMap["route"].(map[string]any); ok {
+			// Handle the single route object (legacy format, deprecated in
+			// favor of the routes array, see deprecationWarnings). An endpoint
+			// can't use both.
+			routeObj, hasRoute := endpointMap["route"].(map[string]any)
+			if _, hasRoutes := endpointMap["routes"]; hasRoute && hasRoutes {
+				errList = append(errList, fmt.Errorf("endpoint at index %d: %w", i, ErrRouteAndRoutes))
+			} else if hasRoute {
 				// Create a new route in the endpoint if none exists
 				if len(endpoint.Routes) == 0 {
 					route := &pbSettings.Route{}
@@ -177,6 +208,11 @@ func processEndpoints(config *pbSettings.ServerConfig, configMap map[string]any)
 						httpRule.PathPrefix = &pathPrefix
 					}
 
+					// Set path_regex if present
+					if pathRegex, ok := httpObj["path_regex"].(string); ok {
+						httpRule.PathRegex = &pathRegex
+					}
+
 					// Set the rule field
 					route.Rule = &pbSettings.Route_Http{
 						Http: httpRule,
@@ -189,6 +225,64 @@ func processEndpoints(config *pbSettings.ServerConfig, configMap map[string]any)
 	return errList
 }
 
+// deprecationWarnings returns a warning for each endpoint using the legacy
+// singular route table, attributed to the endpoint
+func deprecationWarnings(config *pbSettings.ServerConfig, configMap map[string]any) []error {
+	var warnings []error
+
+	endpointsArray, _ := configMap["endpoints"].([]any)
+	for i, endpointObj := range endpointsArray {
+		endpointMap, ok := endpointObj.(map[string]any)
+		if !ok || i >= len(config.Endpoints) {
+			continue
+		}
+		if _, ok := endpointMap["route"].(map[string]any); ok {
+			warnings = append(warnings, validation.ForComponent(
+				validation.ComponentEndpoint, config.Endpoints[i].GetId(),
+				validation.ForField("route", ErrDeprecatedRoute)))
+		}
+	}
+
+	return warnings
+}
+
+// processRouteHeaders sets the header condition match modes from string to enum
+func processRouteHeaders(route *pbSettings.Route, headersArray []any) []error {
+	var errList []error
+
+	for k, headerObj := range headersArray {
+		if k >= len(route.Headers) {
+			break
+		}
+
+		headerMap, ok := headerObj.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		matchStr, ok := headerMap["match"].(string)
+		if !ok {
+			continue
+		}
+
+		var match pbSettings.HeaderCondition_MatchMode
+		switch matchStr {
+		case "exact":
+			match = pbSettings.HeaderCondition_MATCH_MODE_EXACT
+		case "prefix":
+			match = pbSettings.HeaderCondition_MATCH_MODE_PREFIX
+		case "regex":
+			match = pbSettings.HeaderCondition_MATCH_MODE_REGEX
+		default:
+			match = pbSettings.HeaderCondition_MATCH_MODE_UNSPECIFIED
+			errList = append(errList, fmt.Errorf("unsupported header match mode: %s", matchStr))
+		}
+		route.Headers[k].Match = &match
+	}
+
+	return errList
+}
+
 // processMiddlewares handles middleware-specific post-processing
 func processMiddlewares(config *pbSettings.ServerConfig, configMap map[string]any) []error {
 	errList := []error{}
@@ -207,50 +301,90 @@ func processMiddlewares(config *pbSettings.ServerConfig, configMap map[string]an
 
 			// Process middlewares array
 			if middlewaresArray, ok := endpointMap["middlewares"].([]any); ok {
-				for j, middlewareObj := range middlewaresArray {
-					if j >= len(endpoint.Middlewares) {
-						break
-					}
+				errs := processMiddlewareList(
+					endpoint.Middlewares, middlewaresArray, fmt.Sprintf("endpoint %d", i))
+				errList = append(errList, errs...)
+			}
 
-					middleware := endpoint.Middlewares[j]
-					middlewareMap, ok := middlewareObj.(map[string]any)
-					if !ok {
-						errList = append(
-							errList,
-							fmt.Errorf(
-								"middleware at index %d in endpoint %d: invalid format",
-								j,
-								i,
-							),
-						)
-						continue
-					}
+			// Process the middlewares of each route
+			routesArray, _ := endpointMap["routes"].([]any)
+			for j, routeObj := range routesArray {
+				if j >= len(endpoint.Routes) {
+					break
+				}
+				routeMap, ok := routeObj.(map[string]any)
+				if !ok {
+					continue
+				}
+				if middlewaresArray, ok := routeMap["middlewares"].([]any); ok {
+					errs := processMiddlewareList(
+						endpoint.Routes[j].Middlewares, middlewaresArray,
+						fmt.Sprintf("endpoint %d route %d", i, j))
+					errList = append(errList, errs...)
+				}
+			}
+		}
+	}
 
-					// Process middleware based on type
-					if typeVal, ok := middlewareMap["type"].(string); ok {
-						// Set the type field
-						errs := processMiddlewareType(middleware, typeVal)
-						errList = append(errList, errs...)
+	return errList
+}
 
-						// Process middleware-specific configuration
-						switch typeVal {
-						case "console_logger":
-							errs := processConsoleLoggerConfig(middleware, middlewareMap)
-							errList = append(errList, errs...)
-						case "headers":
-							// Headers middleware doesn't need special post-processing
-							// as it uses simple map[string]string and []string types
-						default:
-							errList = append(
-								errList,
-								fmt.Errorf(
-									"no post-processing handler for middleware type: %s",
-									typeVal,
-								),
-							)
-						}
-					}
-				}
+// processMiddlewareList post-processes the middlewares of one listener,
+// endpoint or route, owner names it in errors
+func processMiddlewareList(
+	middlewares []*pbMiddleware.Middleware,
+	middlewaresArray []any,
+	owner string,
+) []error {
+	errList := []error{}
+
+	for j, middlewareObj := range middlewaresArray {
+		if j >= len(middlewares) {
+			break
+		}
+
+		middleware := middlewares[j]
+		middlewareMap, ok := middlewareObj.(map[string]any)
+		if !ok {
+			errList = append(
+				errList,
+				fmt.Errorf("middleware at index %d in %s: invalid format", j, owner),
+			)
+			continue
+		}
+
+		// Process middleware based on type
+		if typeVal, ok := middlewareMap["type"].(string); ok {
+			// Set the type field
+			errs := processMiddlewareType(middleware, typeVal)
+			errList = append(errList, errs...)
+
+			// Process middleware-specific configuration
+			switch typeVal {
+			case "console_logger":
+				errs := processConsoleLoggerConfig(middleware, middlewareMap)
+				errList = append(errList, errs...)
+			case "headers":
+				errs := processHeadersConfig(middleware, middlewareMap)
+				errList = append(errList, errs...)
+			case "retry":
+				errs := processRetryConfig(middleware, middlewareMap)
+				errList = append(errList, errs...)
+			case "signature":
+				errs := processSignatureConfig(middleware, middlewareMap)
+				errList = append(errList, errs...)
+			case "auth", "basic_auth", "circuit_breaker", "body_buffer", "ip_filter", "cache", "transform":
+				// Auth, circuit breaker, body buffer, IP filter, cache, and transform middlewares
+				// don't need special post-processing as they use simple scalar, list, message,
+				// and duration types
+			default:
+				errList = append(
+					errList,
+					fmt.Errorf(
+						"no post-processing handler for middleware type: %s",
+						typeVal,
+					),
+				)
 			}
 		}
 	}
@@ -268,6 +402,24 @@ func processMiddlewareType(middleware *pbMiddleware.Middleware, typeVal string)
 		middlewareType = pbMiddleware.Middleware_TYPE_CONSOLE_LOGGER
 	case "headers":
 		middlewareType = pbMiddleware.Middleware_TYPE_HEADERS
+	case "auth":
+		middlewareType = pbMiddleware.Middleware_TYPE_AUTH
+	case "basic_auth":
+		middlewareType = pbMiddleware.Middleware_TYPE_BASIC_AUTH
+	case "circuit_breaker":
+		middlewareType = pbMiddleware.Middleware_TYPE_CIRCUIT_BREAKER
+	case "retry":
+		middlewareType = pbMiddleware.Middleware_TYPE_RETRY
+	case "body_buffer":
+		middlewareType = pbMiddleware.Middleware_TYPE_BODY_BUFFER
+	case "signature":
+		middlewareType = pbMiddleware.Middleware_TYPE_SIGNATURE
+	case "ip_filter":
+		middlewareType = pbMiddleware.Middleware_TYPE_IP_FILTER
+	case "cache":
+		middlewareType = pbMiddleware.Middleware_TYPE_CACHE
+	case "transform":
+		middlewareType = pbMiddleware.Middleware_TYPE_TRANSFORM
 	default:
 		middlewareType = pbMiddleware.Middleware_TYPE_UNSPECIFIED
 		errList = append(errList, fmt.Errorf("unsupported middleware type: %s", typeVal))
@@ -325,8 +477,193 @@ func processConsoleLoggerConfig(
 			errs := processConsoleLoggerFields(consoleConfig, fieldsMap)
 			errList = append(errList, errs...)
 		}
+
+		// Process OTLP export protocol enum if it exists
+		if otlpMap, ok := consoleLoggerConfig["otlp"].(map[string]any); ok {
+			errs := processConsoleLoggerOTLP(consoleConfig, otlpMap)
+			errList = append(errList, errs...)
+		}
+	}
+
+	return errList
+}
+
+// processHeadersConfig handles the status class enum conversion of headers
+// middleware status overrides
+func processHeadersConfig(
+	middleware *pbMiddleware.Middleware,
+	middlewareMap map[string]any,
+) []error {
+	var errList []error
+
+	headersMap, ok := middlewareMap["headers"].(map[string]any)
+	if !ok {
+		return errList
+	}
+
+	headersConfig := middleware.GetHeaders()
+	if headersConfig == nil {
+		return errList
+	}
+
+	for _, direction := range []struct {
+		name string
+		ops  *pbMiddleware.HeadersConfig_HeaderOperations
+	}{
+		{"request", headersConfig.GetRequest()},
+		{"response", headersConfig.GetResponse()},
+	} {
+		directionMap, ok := headersMap[direction.name].(map[string]any)
+		if !ok || direction.ops == nil {
+			continue
+		}
+
+		overridesArray, ok := directionMap["status_overrides"].([]any)
+		if !ok {
+			continue
+		}
+
+		for i, overrideObj := range overridesArray {
+			if i >= len(direction.ops.StatusOverrides) {
+				break
+			}
+
+			overrideMap, ok := overrideObj.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			classStr, ok := overrideMap["status_class"].(string)
+			if !ok {
+				continue
+			}
+
+			var class pbMiddleware.HeadersConfig_StatusOverride_StatusClass
+			switch classStr {
+			case "1xx":
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_1XX
+			case "2xx":
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_2XX
+			case "3xx":
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_3XX
+			case "4xx":
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_4XX
+			case "5xx":
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_5XX
+			default:
+				class = pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_UNSPECIFIED
+				errList = append(errList, fmt.Errorf("unsupported headers status class: %s", classStr))
+			}
+			direction.ops.StatusOverrides[i].StatusClass = &class
+		}
+	}
+
+	return errList
+}
+
+// processRetryConfig handles the retry backoff enum conversion
+func processRetryConfig(
+	middleware *pbMiddleware.Middleware,
+	middlewareMap map[string]any,
+) []error {
+	var errList []error
+
+	retryMap, ok := middlewareMap["retry"].(map[string]any)
+	if !ok {
+		return errList
+	}
+
+	retryConfig := middleware.GetRetry()
+	if retryConfig == nil {
+		return errList
+	}
+
+	backoffStr, ok := retryMap["backoff"].(string)
+	if !ok {
+		return errList
+	}
+
+	var backoff pbMiddleware.RetryConfig_Backoff
+	switch backoffStr {
+	case "fixed":
+		backoff = pbMiddleware.RetryConfig_BACKOFF_FIXED
+	case "exponential":
+		backoff = pbMiddleware.RetryConfig_BACKOFF_EXPONENTIAL
+	default:
+		backoff = pbMiddleware.RetryConfig_BACKOFF_UNSPECIFIED
+		errList = append(errList, fmt.Errorf("unsupported retry backoff: %s", backoffStr))
+	}
+	retryConfig.Backoff = &backoff
+
+	return errList
+}
+
+// processSignatureConfig handles the signature encoding enum conversion
+func processSignatureConfig(
+	middleware *pbMiddleware.Middleware,
+	middlewareMap map[string]any,
+) []error {
+	var errList []error
+
+	signatureMap, ok := middlewareMap["signature"].(map[string]any)
+	if !ok {
+		return errList
+	}
+
+	signatureConfig := middleware.GetSignature()
+	if signatureConfig == nil {
+		return errList
+	}
+
+	encodingStr, ok := signatureMap["encoding"].(string)
+	if !ok {
+		return errList
+	}
+
+	var encoding pbMiddleware.SignatureConfig_Encoding
+	switch encodingStr {
+	case "hex":
+		encoding = pbMiddleware.SignatureConfig_ENCODING_HEX
+	case "base64":
+		encoding = pbMiddleware.SignatureConfig_ENCODING_BASE64
+	default:
+		encoding = pbMiddleware.SignatureConfig_ENCODING_UNSPECIFIED
+		errList = append(errList, fmt.Errorf("unsupported signature encoding: %s", encodingStr))
+	}
+	signatureConfig.Encoding = &encoding
+
+	return errList
+}
+
+// processConsoleLoggerOTLP handles the OTLP export protocol enum conversion
+func processConsoleLoggerOTLP(
+	config *pbMiddleware.ConsoleLoggerConfig,
+	otlpMap map[string]any,
+) []error {
+	var errList []error
+
+	protocolStr, ok := otlpMap["protocol"].(string)
+	if !ok {
+		return errList
+	}
+
+	// Ensure OTLP settings exist
+	if config.Otlp == nil {
+		config.Otlp = &pbMiddleware.OTLPExportConfig{}
 	}
 
+	var protocol pbMiddleware.OTLPExportConfig_Protocol
+	switch protocolStr {
+	case "grpc":
+		protocol = pbMiddleware.OTLPExportConfig_PROTOCOL_GRPC
+	case "http":
+		protocol = pbMiddleware.OTLPExportConfig_PROTOCOL_HTTP
+	default:
+		protocol = pbMiddleware.OTLPExportConfig_PROTOCOL_UNSPECIFIED
+		errList = append(errList, fmt.Errorf("unsupported console logger OTLP protocol: %s", protocolStr))
+	}
+	config.Otlp.Protocol = &protocol
+
 	return errList
 }
 
@@ -536,8 +873,21 @@ func processApps(config *pbSettings.ServerConfig, configMap map[string]any) []er
 				errs := processMcpAppConfig(app, appMap)
 				errList = append(errList, errs...)
 			}
-			// Echo, calculation, fileread, and composite_script apps don't need
-			// special post-processing beyond enum conversion.
+			if templateConfig, ok := appMap["template"].(map[string]any); ok {
+				processTemplateAppConfig(app, templateConfig)
+			}
+			if fileReadConfig, ok := appMap["fileread"].(map[string]any); ok {
+				if fileReadApp := app.GetFileread(); fileReadApp != nil {
+					fileReadApp.StaticData = staticDataFromConfig(fileReadApp.StaticData, fileReadConfig)
+				}
+			}
+			if calculationConfig, ok := appMap["calculation"].(map[string]any); ok {
+				if calculationApp := app.GetCalculation(); calculationApp != nil {
+					calculationApp.StaticData = staticDataFromConfig(calculationApp.StaticData, calculationConfig)
+				}
+			}
+			// Echo, openapi, and composite_script apps don't need special
+			// post-processing beyond enum conversion.
 		}
 	}
 
@@ -562,6 +912,10 @@ func processAppType(app *pbSettings.AppDefinition, typeVal string) []error {
 		appType = pbSettings.AppDefinition_TYPE_CALCULATION
 	case "fileread":
 		appType = pbSettings.AppDefinition_TYPE_FILEREAD
+	case "openapi":
+		appType = pbSettings.AppDefinition_TYPE_OPENAPI
+	case "template":
+		appType = pbSettings.AppDefinition_TYPE_TEMPLATE
 	default:
 		appType = pbSettings.AppDefinition_TYPE_UNSPECIFIED
 		errList = append(errList, fmt.Errorf("unsupported app type: %s", typeVal))
@@ -571,6 +925,29 @@ func processAppType(app *pbSettings.AppDefinition, typeVal string) []error {
 	return errList
 }
 
+// processTemplateAppConfig handles the static data of a template app
+func processTemplateAppConfig(app *pbSettings.AppDefinition, templateConfig map[string]any) {
+	templateApp := app.GetTemplate()
+	if templateApp == nil {
+		return
+	}
+	templateApp.StaticData = staticDataFromConfig(templateApp.StaticData, templateConfig)
+}
+
+// staticDataFromConfig returns staticData with the data of the static_data
+// table of an app config, or staticData unchanged without one
+func staticDataFromConfig(staticData *pbData.StaticData, appConfig map[string]any) *pbData.StaticData {
+	staticDataMap, ok := appConfig["static_data"].(map[string]any)
+	if !ok {
+		return staticData
+	}
+	if staticData == nil {
+		staticData = &pbData.StaticData{}
+	}
+	staticData.Data = protobaggins.MapToStructValues(staticDataMap)
+	return staticData
+}
+
 // processScriptAppConfig handles script app-specific configuration
 func processScriptAppConfig(app *pbSettings.AppDefinition, appMap map[string]any) []error {
 	var errList []error
@@ -583,22 +960,51 @@ func processScriptAppConfig(app *pbSettings.AppDefinition, appMap map[string]any
 
 	// Get the script app config from the app
 	if scriptApp := app.GetScript(); scriptApp != nil {
-		// Process static_data for script app
-		if staticDataMap, ok := scriptConfig["static_data"].(map[string]any); ok {
-			if scriptApp.StaticData == nil {
-				scriptApp.StaticData = &pbData.StaticData{}
-			}
-			scriptApp.StaticData.Data = protobaggins.MapToStructValues(staticDataMap)
-		}
+		errs := processScriptSettings(scriptApp, scriptConfig)
+		errList = append(errList, errs...)
+	}
+
+	return errList
+}
+
+// processApplyHooks handles the pre_apply and post_apply hook scripts, which
+// take the same settings as a script app
+func processApplyHooks(config *pbSettings.ServerConfig, configMap map[string]any) []error {
+	var errList []error
 
-		// Process evaluator configurations
-		errs := processScriptEvaluators(scriptApp, scriptConfig)
+	hooks := []struct {
+		key       string
+		scriptApp *pbApps.ScriptApp
+	}{
+		{"pre_apply", config.GetPreApply()},
+		{"post_apply", config.GetPostApply()},
+	}
+	for _, hook := range hooks {
+		hookConfig, ok := configMap[hook.key].(map[string]any)
+		if !ok || hook.scriptApp == nil {
+			continue
+		}
+		errs := processScriptSettings(hook.scriptApp, hookConfig)
 		errList = append(errList, errs...)
 	}
 
 	return errList
 }
 
+// processScriptSettings handles the static data and evaluator of a script
+func processScriptSettings(scriptApp *pbApps.ScriptApp, scriptConfig map[string]any) []error {
+	// Process static_data for the script
+	if staticDataMap, ok := scriptConfig["static_data"].(map[string]any); ok {
+		if scriptApp.StaticData == nil {
+			scriptApp.StaticData = &pbData.StaticData{}
+		}
+		scriptApp.StaticData.Data = protobaggins.MapToStructValues(staticDataMap)
+	}
+
+	// Process evaluator configurations
+	return processScriptEvaluators(scriptApp, scriptConfig)
+}
+
 // extractSourceFromConfig extracts code or uri from TOML config map.
 // Returns the extracted values and whether any source was found.
 // Code takes precedence over uri if both are present.
@@ -625,6 +1031,9 @@ func processScriptEvaluators(scriptApp *pbApps.ScriptApp, scriptConfig map[strin
 	if extismConfig, ok := scriptConfig["extism"].(map[string]any); ok {
 		processExtismSource(scriptApp.GetExtism(), extismConfig)
 	}
+	if javascriptConfig, ok := scriptConfig["javascript"].(map[string]any); ok {
+		processJavaScriptSource(scriptApp.GetJavascript(), javascriptConfig)
+	}
 
 	return errList
 }
@@ -690,3 +1099,18 @@ func processExtismSource(eval *pbApps.ExtismEvaluator, config map[string]any) {
 		eval.Source = &pbApps.ExtismEvaluator_Uri{Uri: uri}
 	}
 }
+
+func processJavaScriptSource(eval *pbApps.JavaScriptEvaluator, config map[string]any) {
+	if eval == nil {
+		return
+	}
+	code, uri, hasSource := extractSourceFromConfig(config)
+	if !hasSource {
+		return
+	}
+	if code != "" {
+		eval.Source = &pbApps.JavaScriptEvaluator_Code{Code: code}
+	} else {
+		eval.Source = &pbApps.JavaScriptEvaluator_Uri{Uri: uri}
+	}
+}
@@ -110,6 +110,8 @@ func TestProcessAppsCoverageGaps(t *testing.T) {
 			Apps: []*pbSettings.AppDefinition{
 				{Id: proto.String("calc")},
 				{Id: proto.String("files")},
+				{Id: proto.String("docs")},
+				{Id: proto.String("pages")},
 			},
 		}
 
@@ -117,6 +119,8 @@ func TestProcessAppsCoverageGaps(t *testing.T) {
 			"apps": []any{
 				map[string]any{"id": "calc", "type": "calculation"},
 				map[string]any{"id": "files", "type": "fileread"},
+				map[string]any{"id": "docs", "type": "openapi"},
+				map[string]any{"id": "pages", "type": "template"},
 			},
 		}
 
@@ -124,6 +128,8 @@ func TestProcessAppsCoverageGaps(t *testing.T) {
 		require.Empty(t, errs)
 		assert.Equal(t, pbSettings.AppDefinition_TYPE_CALCULATION, config.Apps[0].GetType())
 		assert.Equal(t, pbSettings.AppDefinition_TYPE_FILEREAD, config.Apps[1].GetType())
+		assert.Equal(t, pbSettings.AppDefinition_TYPE_OPENAPI, config.Apps[2].GetType())
+		assert.Equal(t, pbSettings.AppDefinition_TYPE_TEMPLATE, config.Apps[3].GetType())
 	})
 }
 
@@ -142,16 +148,100 @@ id = "files"
 type = "fileread"
 [apps.fileread]
 base_directory = "` + baseDir + `"
+
+[[apps]]
+id = "docs"
+type = "openapi"
+[apps.openapi]
+title = "Gateway"
+swagger_ui = true
+
+[[apps]]
+id = "pages"
+type = "template"
+[apps.template]
+directory = "` + baseDir + `"
+template = "index.html"
+inline = { "nav.html" = "<nav>{{.Data.title}}</nav>" }
+static_data = { title = "Admin", sections = ["users", "jobs"] }
 `))
 
 	config, err := loader.LoadProto()
 	require.NoError(t, err)
-	require.Len(t, config.Apps, 2)
+	require.Len(t, config.Apps, 4)
 	assert.Equal(t, pbSettings.AppDefinition_TYPE_CALCULATION, config.Apps[0].GetType())
 	assert.NotNil(t, config.Apps[0].GetCalculation())
 	assert.Equal(t, pbSettings.AppDefinition_TYPE_FILEREAD, config.Apps[1].GetType())
 	require.NotNil(t, config.Apps[1].GetFileread())
 	assert.Equal(t, baseDir, config.Apps[1].GetFileread().GetBaseDirectory())
+	assert.Equal(t, pbSettings.AppDefinition_TYPE_OPENAPI, config.Apps[2].GetType())
+	require.NotNil(t, config.Apps[2].GetOpenapi())
+	assert.Equal(t, "Gateway", config.Apps[2].GetOpenapi().GetTitle())
+	assert.True(t, config.Apps[2].GetOpenapi().GetSwaggerUi())
+	assert.Equal(t, "1.0.0", config.Apps[2].GetOpenapi().GetVersion())
+	assert.Equal(t, pbSettings.AppDefinition_TYPE_TEMPLATE, config.Apps[3].GetType())
+	templateApp := config.Apps[3].GetTemplate()
+	require.NotNil(t, templateApp)
+	assert.Equal(t, baseDir, templateApp.GetDirectory())
+	assert.Equal(t, "index.html", templateApp.GetTemplate())
+	assert.Equal(t, map[string]string{"nav.html": "<nav>{{.Data.title}}</nav>"}, templateApp.GetInline())
+	staticData := templateApp.GetStaticData().GetData()
+	assert.Equal(t, "Admin", staticData["title"].GetStringValue())
+	assert.Len(t, staticData["sections"].GetListValue().GetValues(), 2)
+}
+
+func TestTomlLoader_TypedAppStaticData(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[apps]]
+id = "calc"
+type = "calculation"
+[apps.calculation.static_data.constants]
+vat = 0.2
+
+[[apps]]
+id = "files"
+type = "fileread"
+[apps.fileread]
+static_data = { base_directory = "/srv/docs" }
+`))
+
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+	require.Len(t, config.Apps, 2)
+
+	constants := config.Apps[0].GetCalculation().GetStaticData().GetData()["constants"]
+	require.NotNil(t, constants)
+	assert.InDelta(t, 0.2, constants.GetStructValue().GetFields()["vat"].GetNumberValue(), 0.000001)
+
+	data := config.Apps[1].GetFileread().GetStaticData().GetData()
+	assert.Equal(t, "/srv/docs", data["base_directory"].GetStringValue())
+}
+
+func TestTomlLoader_ExtismOptions(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[apps]]
+id = "wasm"
+type = "script"
+[apps.script.extism]
+code = "AGFzbQEAAAA="
+entrypoint = "handle"
+host_functions = ["firelynx_log", "firelynx_get_static_data"]
+max_memory_pages = 16
+fuel = 100000
+`))
+
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+	require.Len(t, config.Apps, 1)
+	extism := config.Apps[0].GetScript().GetExtism()
+	require.NotNil(t, extism)
+	assert.Equal(t, []string{"firelynx_log", "firelynx_get_static_data"}, extism.GetHostFunctions())
+	assert.Equal(t, int32(16), extism.GetMaxMemoryPages())
+	assert.Equal(t, int64(100000), extism.GetFuel())
 }
 
 // TestProcessScriptAppConfigCoverageGaps focuses on coverage gaps in script app processing
@@ -189,3 +189,108 @@ func TestProcessEndpoints(t *testing.T) {
 		)
 	})
 }
+
+// TestProcessRouteHeaders tests conversion of header condition match modes
+func TestProcessRouteHeaders(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LoadsHeaderConditions", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "v2"
+[endpoints.routes.http]
+path_prefix = "/api"
+[[endpoints.routes.headers]]
+name = "X-API-Version"
+value = "2"
+match = "exact"
+[[endpoints.routes.headers]]
+name = "User-Agent"
+value = "^curl/"
+match = "regex"
+[[endpoints.routes.headers]]
+name = "Accept"
+value = "application/"
+`))
+
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetEndpoints(), 1)
+		require.Len(t, config.GetEndpoints()[0].GetRoutes(), 1)
+
+		headers := config.GetEndpoints()[0].GetRoutes()[0].GetHeaders()
+		require.Len(t, headers, 3)
+		assert.Equal(t, "X-API-Version", headers[0].GetName())
+		assert.Equal(t, "2", headers[0].GetValue())
+		assert.Equal(t, pbSettings.HeaderCondition_MATCH_MODE_EXACT, headers[0].GetMatch())
+		assert.Equal(t, pbSettings.HeaderCondition_MATCH_MODE_REGEX, headers[1].GetMatch())
+		assert.Equal(t, pbSettings.HeaderCondition_MATCH_MODE_EXACT, headers[2].GetMatch(),
+			"match defaults to exact")
+	})
+
+	t.Run("UnsupportedMatchMode", func(t *testing.T) {
+		route := &pbSettings.Route{
+			Headers: []*pbSettings.HeaderCondition{{Name: proto.String("X-Test")}},
+		}
+		errs := processRouteHeaders(route, []any{
+			map[string]any{"name": "X-Test", "match": "glob"},
+		})
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "glob")
+		assert.Equal(t, pbSettings.HeaderCondition_MATCH_MODE_UNSPECIFIED, route.Headers[0].GetMatch())
+	})
+}
+
+// TestProcessEndpointStaticData tests loading endpoint-level static_data
+func TestProcessEndpointStaticData(t *testing.T) {
+	t.Parallel()
+
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[endpoints.static_data]
+env = "prod"
+[endpoints.static_data.db]
+host = "db.internal"
+port = 5432
+
+[[endpoints.routes]]
+app_id = "orders"
+static_data = { db = { port = 6543 } }
+[endpoints.routes.http]
+path_prefix = "/orders"
+`))
+
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+	require.Len(t, config.GetEndpoints(), 1)
+
+	data := config.GetEndpoints()[0].GetStaticData().GetData()
+	assert.Equal(t, "prod", data["env"].GetStringValue())
+	db := data["db"].GetStructValue().GetFields()
+	assert.Equal(t, "db.internal", db["host"].GetStringValue())
+	assert.InDelta(t, 5432, db["port"].GetNumberValue(), 0)
+
+	routeData := config.GetEndpoints()[0].GetRoutes()[0].GetStaticData().GetData()
+	assert.InDelta(t, 6543, routeData["db"].GetStructValue().GetFields()["port"].GetNumberValue(), 0)
+}
@@ -28,6 +28,12 @@ func TestProcessListenerType(t *testing.T) {
 			expectedType: pbSettings.Listener_TYPE_HTTP,
 			expectError:  false,
 		},
+		{
+			name:         "TCP Listener Type",
+			typeStr:      "tcp",
+			expectedType: pbSettings.Listener_TYPE_TCP,
+			expectError:  false,
+		},
 		{
 			name:           "Unsupported Listener Type",
 			typeStr:        "websocket",
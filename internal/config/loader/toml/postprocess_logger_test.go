@@ -2,7 +2,9 @@ package toml
 
 import (
 	_ "embed"
+	"fmt"
 	"testing"
+	"time"
 
 	pbMiddleware "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
 	configLogger "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
@@ -139,6 +141,12 @@ func TestManualLoggerTOMLIntegration(t *testing.T) {
 	assert.True(t, response.GetBodySize(), "Response body size should be enabled")
 	assert.Equal(t, []string{"Content-Type", "Cache-Control"}, response.GetIncludeHeaders())
 	assert.Equal(t, []string{"Set-Cookie"}, response.GetExcludeHeaders())
+
+	// Verify redaction
+	redaction := consoleLogger.GetRedaction()
+	require.NotNil(t, redaction, "Redaction should be configured")
+	assert.Equal(t, []string{"X-Api-Key"}, redaction.GetHeaders())
+	assert.Equal(t, []string{"password", "user.token"}, redaction.GetBodyFields())
 }
 
 // TestManualLoggerEndToEnd tests the complete TOML → protobuf → domain → middleware pipeline
@@ -196,6 +204,10 @@ func TestManualLoggerEndToEnd(t *testing.T) {
 	)
 	assert.Equal(t, []string{"Set-Cookie"}, domainConfig.Fields.Response.ExcludeHeaders)
 
+	// Check redaction config
+	assert.Equal(t, []string{"X-Api-Key"}, domainConfig.Redaction.Headers)
+	assert.Equal(t, []string{"password", "user.token"}, domainConfig.Redaction.BodyFields)
+
 	// Step 3: Create middleware from domain config
 	middlewareInstance, err := middlewareLogger.NewConsoleLogger("manual-logger", domainConfig)
 	require.NoError(t, err, "Middleware creation should succeed")
@@ -243,3 +255,115 @@ func TestManualLoggerEndToEnd(t *testing.T) {
 		"Domain config should still have host enabled after middleware creation",
 	)
 }
+
+// TestOTLPLoggerConfig tests loading a console logger that exports over OTLP
+func TestOTLPLoggerConfig(t *testing.T) {
+	const tomlTemplate = `
+version = "v1"
+
+[[endpoints]]
+id = "otlp-endpoint"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "otlp-logger"
+type = "console_logger"
+
+[endpoints.middlewares.console_logger]
+output = "otlp"
+
+[endpoints.middlewares.console_logger.otlp]
+endpoint = "https://collector:4318/v1/logs"
+protocol = "%s"
+export_interval = "500ms"
+max_batch_size = 100
+
+[endpoints.middlewares.console_logger.otlp.headers]
+Authorization = "Bearer token"
+
+[[endpoints.routes]]
+app_id = "echo"
+
+[endpoints.routes.http]
+path_prefix = "/"
+`
+
+	t.Run("valid protocol", func(t *testing.T) {
+		loader := NewTomlLoader(fmt.Appendf(nil, tomlTemplate, "http"))
+		pbConfig, err := loader.LoadProto()
+		require.NoError(t, err)
+
+		otlp := pbConfig.Endpoints[0].Middlewares[0].GetConsoleLogger().GetOtlp()
+		require.NotNil(t, otlp)
+		assert.Equal(t, "https://collector:4318/v1/logs", otlp.GetEndpoint())
+		assert.Equal(t, pbMiddleware.OTLPExportConfig_PROTOCOL_HTTP, otlp.GetProtocol())
+		assert.Equal(t, 500*time.Millisecond, otlp.GetExportInterval().AsDuration())
+		assert.Equal(t, int32(100), otlp.GetMaxBatchSize())
+		assert.Equal(t, map[string]string{"Authorization": "Bearer token"}, otlp.GetHeaders())
+
+		domainConfig, err := configLogger.FromProto(pbConfig.Endpoints[0].Middlewares[0].GetConsoleLogger())
+		require.NoError(t, err)
+		assert.Equal(t, "otlp", domainConfig.Output)
+		assert.Equal(t, configLogger.OTLPProtocolHTTP, domainConfig.OTLP.Protocol)
+		assert.Equal(t, 500*time.Millisecond, domainConfig.OTLP.ExportInterval)
+		require.NoError(t, domainConfig.Validate())
+	})
+
+	t.Run("unsupported protocol", func(t *testing.T) {
+		loader := NewTomlLoader(fmt.Appendf(nil, tomlTemplate, "udp"))
+		_, err := loader.LoadProto()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported console logger OTLP protocol: udp")
+	})
+}
+
+// TestSyslogLoggerConfig tests loading a console logger that writes to syslog
+func TestSyslogLoggerConfig(t *testing.T) {
+	tomlData := []byte(`
+version = "v1"
+
+[[endpoints]]
+id = "syslog-endpoint"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "syslog-logger"
+type = "console_logger"
+
+[endpoints.middlewares.console_logger]
+output = "syslog"
+
+[endpoints.middlewares.console_logger.options]
+format = "json"
+
+[endpoints.middlewares.console_logger.syslog]
+network = "udp"
+address = "127.0.0.1:514"
+facility = "local0"
+tag = "firelynx-api"
+
+[[endpoints.routes]]
+app_id = "echo"
+
+[endpoints.routes.http]
+path_prefix = "/"
+`)
+
+	loader := NewTomlLoader(tomlData)
+	pbConfig, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	syslog := pbConfig.Endpoints[0].Middlewares[0].GetConsoleLogger().GetSyslog()
+	require.NotNil(t, syslog)
+	assert.Equal(t, "udp", syslog.GetNetwork())
+	assert.Equal(t, "127.0.0.1:514", syslog.GetAddress())
+	assert.Equal(t, "local0", syslog.GetFacility())
+	assert.Equal(t, "firelynx-api", syslog.GetTag())
+
+	domainConfig, err := configLogger.FromProto(pbConfig.Endpoints[0].Middlewares[0].GetConsoleLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "syslog", domainConfig.Output)
+	assert.Equal(t, configLogger.FormatJSON, domainConfig.Options.Format)
+	assert.Equal(t, "firelynx-api", domainConfig.Syslog.Tag)
+	require.NoError(t, domainConfig.Validate())
+}
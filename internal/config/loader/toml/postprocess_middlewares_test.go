@@ -2,6 +2,7 @@ package toml
 
 import (
 	"testing"
+	"time"
 
 	pbSettings "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	pbMiddleware "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
@@ -34,6 +35,60 @@ func TestProcessMiddlewareType(t *testing.T) {
 			expectedType: pbMiddleware.Middleware_TYPE_HEADERS,
 			expectError:  false,
 		},
+		{
+			name:         "Auth Middleware Type",
+			typeStr:      "auth",
+			expectedType: pbMiddleware.Middleware_TYPE_AUTH,
+			expectError:  false,
+		},
+		{
+			name:         "Basic Auth Middleware Type",
+			typeStr:      "basic_auth",
+			expectedType: pbMiddleware.Middleware_TYPE_BASIC_AUTH,
+			expectError:  false,
+		},
+		{
+			name:         "Circuit Breaker Middleware Type",
+			typeStr:      "circuit_breaker",
+			expectedType: pbMiddleware.Middleware_TYPE_CIRCUIT_BREAKER,
+			expectError:  false,
+		},
+		{
+			name:         "Retry Middleware Type",
+			typeStr:      "retry",
+			expectedType: pbMiddleware.Middleware_TYPE_RETRY,
+			expectError:  false,
+		},
+		{
+			name:         "Body Buffer Middleware Type",
+			typeStr:      "body_buffer",
+			expectedType: pbMiddleware.Middleware_TYPE_BODY_BUFFER,
+			expectError:  false,
+		},
+		{
+			name:         "Signature Middleware Type",
+			typeStr:      "signature",
+			expectedType: pbMiddleware.Middleware_TYPE_SIGNATURE,
+			expectError:  false,
+		},
+		{
+			name:         "IP Filter Middleware Type",
+			typeStr:      "ip_filter",
+			expectedType: pbMiddleware.Middleware_TYPE_IP_FILTER,
+			expectError:  false,
+		},
+		{
+			name:         "Cache Middleware Type",
+			typeStr:      "cache",
+			expectedType: pbMiddleware.Middleware_TYPE_CACHE,
+			expectError:  false,
+		},
+		{
+			name:         "Transform Middleware Type",
+			typeStr:      "transform",
+			expectedType: pbMiddleware.Middleware_TYPE_TRANSFORM,
+			expectError:  false,
+		},
 		{
 			name:           "Unsupported Middleware Type",
 			typeStr:        "rate_limiter",
@@ -846,3 +901,247 @@ func TestProcessMiddlewares(t *testing.T) {
 		})
 	})
 }
+
+func TestProcessHeadersConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LoadsPatternsAndStatusOverrides", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "headers"
+type = "headers"
+[endpoints.middlewares.headers.response]
+remove_header_patterns = ["X-Internal-*"]
+[[endpoints.middlewares.headers.response.status_overrides]]
+status_class = "5xx"
+set_headers = { "Cache-Control" = "no-store" }
+[[endpoints.middlewares.headers.response.status_overrides]]
+status_class = "4xx"
+remove_headers = ["ETag"]
+
+[[endpoints.routes]]
+app_id = "app"
+[endpoints.routes.http]
+path_prefix = "/"
+`))
+
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetEndpoints(), 1)
+		require.Len(t, config.GetEndpoints()[0].GetMiddlewares(), 1)
+
+		response := config.GetEndpoints()[0].GetMiddlewares()[0].GetHeaders().GetResponse()
+		require.NotNil(t, response)
+		assert.Equal(t, []string{"X-Internal-*"}, response.GetRemoveHeaderPatterns())
+		require.Len(t, response.GetStatusOverrides(), 2)
+		assert.Equal(t,
+			pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_5XX,
+			response.GetStatusOverrides()[0].GetStatusClass(),
+		)
+		assert.Equal(t, "no-store", response.GetStatusOverrides()[0].GetSetHeaders()["Cache-Control"])
+		assert.Equal(t,
+			pbMiddleware.HeadersConfig_StatusOverride_STATUS_CLASS_4XX,
+			response.GetStatusOverrides()[1].GetStatusClass(),
+		)
+	})
+
+	t.Run("UnsupportedStatusClass", func(t *testing.T) {
+		middleware := &pbMiddleware.Middleware{
+			Config: &pbMiddleware.Middleware_Headers{
+				Headers: &pbMiddleware.HeadersConfig{
+					Response: &pbMiddleware.HeadersConfig_HeaderOperations{
+						StatusOverrides: []*pbMiddleware.HeadersConfig_StatusOverride{{}},
+					},
+				},
+			},
+		}
+		errs := processHeadersConfig(middleware, map[string]any{
+			"headers": map[string]any{
+				"response": map[string]any{
+					"status_overrides": []any{map[string]any{"status_class": "6xx"}},
+				},
+			},
+		})
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "unsupported headers status class: 6xx")
+	})
+}
+
+func TestProcessRetryConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LoadsRetryConfig", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "retry"
+type = "retry"
+[endpoints.middlewares.retry]
+max_attempts = 4
+methods = ["GET", "PUT"]
+backoff = "fixed"
+initial_interval = "250ms"
+max_interval = "2s"
+jitter = true
+retry_on_status = [429, 503]
+retry_on_errors = ["timeout"]
+max_body_size = 65536
+
+[[endpoints.routes]]
+app_id = "app"
+[endpoints.routes.http]
+path_prefix = "/"
+`))
+
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetEndpoints(), 1)
+		require.Len(t, config.GetEndpoints()[0].GetMiddlewares(), 1)
+
+		middleware := config.GetEndpoints()[0].GetMiddlewares()[0]
+		assert.Equal(t, pbMiddleware.Middleware_TYPE_RETRY, middleware.GetType())
+		retry := middleware.GetRetry()
+		require.NotNil(t, retry)
+		assert.Equal(t, int32(4), retry.GetMaxAttempts())
+		assert.Equal(t, []string{"GET", "PUT"}, retry.GetMethods())
+		assert.Equal(t, pbMiddleware.RetryConfig_BACKOFF_FIXED, retry.GetBackoff())
+		assert.Equal(t, 250*time.Millisecond, retry.GetInitialInterval().AsDuration())
+		assert.Equal(t, 2*time.Second, retry.GetMaxInterval().AsDuration())
+		assert.True(t, retry.GetJitter())
+		assert.Equal(t, []int32{429, 503}, retry.GetRetryOnStatus())
+		assert.Equal(t, []string{"timeout"}, retry.GetRetryOnErrors())
+		assert.Equal(t, int64(65536), retry.GetMaxBodySize())
+	})
+
+	t.Run("UnsupportedBackoff", func(t *testing.T) {
+		middleware := &pbMiddleware.Middleware{
+			Config: &pbMiddleware.Middleware_Retry{Retry: &pbMiddleware.RetryConfig{}},
+		}
+		errs := processRetryConfig(middleware, map[string]any{
+			"retry": map[string]any{"backoff": "linear"},
+		})
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "unsupported retry backoff: linear")
+	})
+}
+
+func TestProcessSignatureConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("LoadsSignatureConfig", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "webhooks"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "signature"
+type = "signature"
+[endpoints.middlewares.signature]
+secret = "s3cret"
+header = "X-Hub-Signature-256"
+encoding = "base64"
+prefix = "sha256="
+
+[[endpoints.routes]]
+app_id = "app"
+[endpoints.routes.http]
+path_prefix = "/"
+`))
+
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.GetEndpoints(), 1)
+		require.Len(t, config.GetEndpoints()[0].GetMiddlewares(), 1)
+
+		middleware := config.GetEndpoints()[0].GetMiddlewares()[0]
+		assert.Equal(t, pbMiddleware.Middleware_TYPE_SIGNATURE, middleware.GetType())
+		signature := middleware.GetSignature()
+		require.NotNil(t, signature)
+		assert.Equal(t, "s3cret", signature.GetSecret())
+		assert.Equal(t, "X-Hub-Signature-256", signature.GetHeader())
+		assert.Equal(t, pbMiddleware.SignatureConfig_ENCODING_BASE64, signature.GetEncoding())
+		assert.Equal(t, "sha256=", signature.GetPrefix())
+	})
+
+	t.Run("UnsupportedEncoding", func(t *testing.T) {
+		middleware := &pbMiddleware.Middleware{
+			Config: &pbMiddleware.Middleware_Signature{Signature: &pbMiddleware.SignatureConfig{}},
+		}
+		errs := processSignatureConfig(middleware, map[string]any{
+			"signature": map[string]any{"encoding": "base32"},
+		})
+		require.Len(t, errs, 1)
+		assert.Contains(t, errs[0].Error(), "unsupported signature encoding: base32")
+	})
+}
+
+func TestLoadHeadersSecurityPreset(t *testing.T) {
+	t.Parallel()
+
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "headers"
+type = "headers"
+[endpoints.middlewares.headers.security]
+enabled = true
+hsts_max_age_seconds = 600
+frame_options = "SAMEORIGIN"
+
+[[endpoints.routes]]
+app_id = "app"
+[endpoints.routes.http]
+path_prefix = "/"
+`))
+
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+	require.Len(t, config.GetEndpoints(), 1)
+	require.Len(t, config.GetEndpoints()[0].GetMiddlewares(), 1)
+
+	security := config.GetEndpoints()[0].GetMiddlewares()[0].GetHeaders().GetSecurity()
+	require.NotNil(t, security)
+	assert.True(t, security.GetEnabled())
+	assert.Equal(t, int64(600), security.GetHstsMaxAgeSeconds())
+	assert.Equal(t, "SAMEORIGIN", security.GetFrameOptions())
+	assert.Equal(t, "nosniff", security.GetContentTypeOptions(), "unset fields use the defaults")
+}
@@ -74,6 +74,59 @@ func (p *StarlarkSourceProcessor) GetIrrelevantConfigKey() string {
 	return "timeout"
 }
 
+// JavaScriptSourceProcessor implements EvaluatorSourceProcessor for JavaScript
+type JavaScriptSourceProcessor struct{}
+
+func (p *JavaScriptSourceProcessor) ProcessSource(evaluator any, config map[string]any) {
+	if evaluator == nil {
+		processJavaScriptSource(nil, config)
+		return
+	}
+	processJavaScriptSource(evaluator.(*pbApps.JavaScriptEvaluator), config)
+}
+
+func (p *JavaScriptSourceProcessor) CreateEvaluator() any {
+	return &pbApps.JavaScriptEvaluator{}
+}
+
+func (p *JavaScriptSourceProcessor) GetSource(evaluator any) any {
+	return evaluator.(*pbApps.JavaScriptEvaluator).Source
+}
+
+func (p *JavaScriptSourceProcessor) CreateCodeSource(code string) any {
+	return &pbApps.JavaScriptEvaluator_Code{Code: code}
+}
+
+func (p *JavaScriptSourceProcessor) CreateUriSource(uri string) any {
+	return &pbApps.JavaScriptEvaluator_Uri{Uri: uri}
+}
+
+func (p *JavaScriptSourceProcessor) GetCodeFromSource(source any) (string, bool) {
+	if codeSource, ok := source.(*pbApps.JavaScriptEvaluator_Code); ok {
+		return codeSource.Code, true
+	}
+	return "", false
+}
+
+func (p *JavaScriptSourceProcessor) GetUriFromSource(source any) (string, bool) {
+	if uriSource, ok := source.(*pbApps.JavaScriptEvaluator_Uri); ok {
+		return uriSource.Uri, true
+	}
+	return "", false
+}
+
+func (p *JavaScriptSourceProcessor) GetExampleCode() string {
+	return "'hello'"
+}
+
+func (p *JavaScriptSourceProcessor) GetExampleUri() string {
+	return "file://script.js"
+}
+
+func (p *JavaScriptSourceProcessor) GetIrrelevantConfigKey() string {
+	return "timeout"
+}
+
 // ExtismSourceProcessor implements EvaluatorSourceProcessor for Extism
 type ExtismSourceProcessor struct{}
 
@@ -295,3 +348,10 @@ func TestRisorSourceProcessing(t *testing.T) {
 		processor: &RisorSourceProcessor{},
 	})
 }
+
+// TestJavaScriptSourceProcessing tests JavaScript evaluator source processing
+func TestJavaScriptSourceProcessing(t *testing.T) {
+	suite.Run(t, &EvaluatorSourceTestSuite{
+		processor: &JavaScriptSourceProcessor{},
+	})
+}
@@ -512,6 +512,29 @@ func TestProcessScriptEvaluators(t *testing.T) {
 		assert.Equal(t, "result = 'starlark'", starlarkSource.Code)
 	})
 
+	t.Run("JavaScriptEvaluator", func(t *testing.T) {
+		scriptApp := &pbApps.ScriptApp{
+			Evaluator: &pbApps.ScriptApp_Javascript{
+				Javascript: &pbApps.JavaScriptEvaluator{},
+			},
+		}
+
+		scriptConfig := map[string]any{
+			"javascript": map[string]any{
+				"uri": "file://handler.js",
+			},
+		}
+
+		errs := processScriptEvaluators(scriptApp, scriptConfig)
+		assert.Empty(t, errs, "Should not return errors for valid evaluator")
+
+		// Verify JavaScript evaluator was processed
+		jsEval := scriptApp.GetJavascript()
+		require.NotNil(t, jsEval, "JavaScript evaluator should be accessible")
+		jsSource := jsEval.Source.(*pbApps.JavaScriptEvaluator_Uri)
+		assert.Equal(t, "file://handler.js", jsSource.Uri)
+	})
+
 	t.Run("ExtismEvaluator", func(t *testing.T) {
 		scriptApp := &pbApps.ScriptApp{
 			Evaluator: &pbApps.ScriptApp_Extism{
@@ -829,6 +852,32 @@ func TestProcessEndpointsEdgeCases(t *testing.T) {
 		assert.Equal(t, "/api", httpRule.GetPathPrefix())
 	})
 
+	t.Run("RouteAndRoutes", func(t *testing.T) {
+		config := &pbSettings.ServerConfig{
+			Endpoints: []*pbSettings.Endpoint{
+				{
+					Id:     proto.String("endpoint1"),
+					Routes: []*pbSettings.Route{{AppId: proto.String("routes-app")}},
+				},
+			},
+		}
+
+		configMap := map[string]any{
+			"endpoints": []any{
+				map[string]any{
+					"id":     "endpoint1",
+					"route":  map[string]any{"app_id": "route-app"},
+					"routes": []any{map[string]any{"app_id": "routes-app"}},
+				},
+			},
+		}
+
+		errs := processEndpoints(config, configMap)
+		require.Len(t, errs, 1)
+		require.ErrorIs(t, errs[0], ErrRouteAndRoutes)
+		assert.Equal(t, "routes-app", config.Endpoints[0].Routes[0].GetAppId())
+	})
+
 	t.Run("MoreEndpointsInMapThanConfig", func(t *testing.T) {
 		config := &pbSettings.ServerConfig{
 			Endpoints: []*pbSettings.Endpoint{
@@ -18,6 +18,8 @@ import (
 type TomlLoader struct {
 	protoConfig *pbSettings.ServerConfig
 	source      []byte
+	overlay     []byte
+	warnings    []error
 }
 
 // NewTomlLoader creates a new TOML configuration loader
@@ -28,6 +30,15 @@ func NewTomlLoader(source []byte) *TomlLoader {
 	}
 }
 
+// NewTomlLoaderWithOverlay creates a TOML configuration loader for a base
+// config with an overlay deep-merged over it, see mergeOverlay. The overlay's
+// [overlay] table holds its merge settings, such as lists = "append".
+func NewTomlLoaderWithOverlay(source, overlay []byte) *TomlLoader {
+	l := NewTomlLoader(source)
+	l.overlay = overlay
+	return l
+}
+
 // LoadProto parses the TOML configuration and returns the Protocol Buffer config
 //
 // Note on TOML format:
@@ -51,33 +62,21 @@ func (l *TomlLoader) LoadProto() (*pbSettings.ServerConfig, error) {
 		return nil, ErrNoSourceData
 	}
 
-	// First, extract just the version to check compatibility
-	var versionCheck struct {
-		Version string `toml:"version"`
-	}
-
-	if err := gotoml.Unmarshal(l.source, &versionCheck); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrParseToml, err)
-	}
-
-	// Set default version if not specified
-	if versionCheck.Version == "" {
-		versionCheck.Version = version.Version
-	}
-
-	// Check version compatibility
-	if versionCheck.Version != version.Version {
-		return nil, fmt.Errorf(
-			"version %s is not supported: %w",
-			versionCheck.Version,
-			ErrUnsupportedConfigVer,
-		)
+	configMap, err := parseSource(l.source)
+	if err != nil {
+		return nil, err
 	}
 
-	// Parse TOML into a generic map
-	var configMap map[string]any
-	if err := gotoml.Unmarshal(l.source, &configMap); err != nil {
-		return nil, fmt.Errorf("%w: %w", ErrParseToml, err)
+	if l.overlay != nil {
+		overlayMap, err := parseSource(l.overlay)
+		if err != nil {
+			return nil, fmt.Errorf("overlay: %w", err)
+		}
+		lists, err := overlaySettings(overlayMap)
+		if err != nil {
+			return nil, err
+		}
+		mergeOverlay(configMap, overlayMap, lists)
 	}
 
 	// Convert Go duration strings to protobuf-compatible format
@@ -106,6 +105,7 @@ func (l *TomlLoader) LoadProto() (*pbSettings.ServerConfig, error) {
 	}
 
 	l.protoConfig = protoCfg
+	l.warnings = deprecationWarnings(protoCfg, configMap)
 	if err := l.validate(); err != nil {
 		return nil, err
 	}
@@ -113,11 +113,51 @@ func (l *TomlLoader) LoadProto() (*pbSettings.ServerConfig, error) {
 	return l.protoConfig, nil
 }
 
+// parseSource checks the version of a TOML source and parses it into a
+// generic map
+func parseSource(source []byte) (map[string]any, error) {
+	// First, extract just the version to check compatibility
+	var versionCheck struct {
+		Version string `toml:"version"`
+	}
+
+	if err := gotoml.Unmarshal(source, &versionCheck); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseToml, err)
+	}
+
+	// Set default version if not specified
+	if versionCheck.Version == "" {
+		versionCheck.Version = version.Version
+	}
+
+	// Check version compatibility
+	if versionCheck.Version != version.Version {
+		return nil, fmt.Errorf(
+			"version %s is not supported: %w",
+			versionCheck.Version,
+			ErrUnsupportedConfigVer,
+		)
+	}
+
+	// Parse TOML into a generic map
+	var configMap map[string]any
+	if err := gotoml.Unmarshal(source, &configMap); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseToml, err)
+	}
+	return configMap, nil
+}
+
 // GetProtoConfig returns the underlying Protocol Buffer configuration
 func (l *TomlLoader) GetProtoConfig() *pbSettings.ServerConfig {
 	return l.protoConfig
 }
 
+// Warnings returns the deprecated forms LoadProto found in the source, which
+// still load but should be migrated
+func (l *TomlLoader) Warnings() []error {
+	return l.warnings
+}
+
 // validate checks the loaded configuration for errors
 func (l *TomlLoader) validate() error {
 	// Validate the protobuf config
@@ -130,13 +170,33 @@ func (l *TomlLoader) validate() error {
 func convertDurationStrings(configMap map[string]any) {
 	// Exact duration field names from protobuf schema - see proto/settings/v1alpha1/
 	// HttpListenerOptions: read_timeout, write_timeout, idle_timeout, drain_timeout
-	// Script evaluators: timeout (RisorEvaluator, StarlarkEvaluator, ExtismEvaluator)
+	// Script evaluators: timeout, uri_cache_ttl (RisorEvaluator, StarlarkEvaluator, ExtismEvaluator, JavaScriptEvaluator)
+	// McpTool: cache_ttl
+	// McpApp: tool_call_queue_timeout
+	// OTLPExportConfig: export_interval
+	// CircuitBreakerConfig: window, open_timeout
+	// RetryConfig: initial_interval, max_interval
+	// CacheConfig: ttl
 	durationFields := []string{
 		"timeout",
 		"read_timeout",
+		"read_header_timeout",
 		"write_timeout",
 		"idle_timeout",
 		"drain_timeout",
+		"dial_timeout",
+		"cache_ttl",
+		"default_timeout",
+		"uri_cache_ttl",
+		"export_interval",
+		"step_timeout",
+		"window",
+		"open_timeout",
+		"initial_interval",
+		"max_interval",
+		"ttl",
+		"slow_threshold",
+		"tool_call_queue_timeout",
 	}
 
 	for key, value := range configMap {
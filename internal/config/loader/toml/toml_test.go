@@ -3,8 +3,11 @@ package toml
 import (
 	"embed"
 	"testing"
+	"time"
 
 	pbSettings "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	pbMiddleware "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/middleware/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -112,7 +115,12 @@ type = "http"
 
 [listeners.http]
 read_timeout = "45s"
+read_header_timeout = "5s"
 write_timeout = "45s"
+max_request_body_bytes = 1048576
+max_concurrent_requests = 100
+h2c = true
+trusted_proxies = ["10.0.0.0/8", "127.0.0.1"]
 
 [[listeners]]
 id = "http_listener_3"
@@ -148,7 +156,12 @@ type = "http"
 	http2 := config.Listeners[1].GetHttp()
 	assert.NotNil(t, http2, "Second listener's HTTP options should be set")
 	assert.Equal(t, int64(45), http2.GetReadTimeout().GetSeconds(), "Expected 45s read timeout")
+	assert.Equal(t, int64(5), http2.GetReadHeaderTimeout().GetSeconds(), "Expected 5s read header timeout")
 	assert.Equal(t, int64(45), http2.GetWriteTimeout().GetSeconds(), "Expected 45s write timeout")
+	assert.Equal(t, int64(1048576), http2.GetMaxRequestBodyBytes(), "Expected 1 MiB body limit")
+	assert.Equal(t, int32(100), http2.GetMaxConcurrentRequests(), "Expected concurrency limit of 100")
+	assert.True(t, http2.GetH2C(), "Expected h2c to be enabled")
+	assert.Equal(t, []string{"10.0.0.0/8", "127.0.0.1"}, http2.GetTrustedProxies())
 
 	// Third listener (no timeout config) - should work and get defaults applied by domain layer
 	assert.Equal(t, "http_listener_3", config.Listeners[2].GetId(), "Expected third listener ID")
@@ -163,6 +176,37 @@ type = "http"
 	)
 }
 
+// TestTomlLoader_TCPListener tests the parsing of a TCP listener and its options
+func TestTomlLoader_TCPListener(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "postgres"
+address = ":5432"
+type = "tcp"
+
+[listeners.tcp]
+upstream = "10.0.0.5:5432"
+dial_timeout = "2s"
+drain_timeout = "15s"
+`))
+
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+	require.Len(t, config.Listeners, 1)
+
+	listener := config.Listeners[0]
+	assert.Equal(t, pbSettings.Listener_TYPE_TCP, listener.GetType())
+	assert.Nil(t, listener.GetHttp())
+
+	tcp := listener.GetTcp()
+	require.NotNil(t, tcp)
+	assert.Equal(t, "10.0.0.5:5432", tcp.GetUpstream())
+	assert.Equal(t, 2*time.Second, tcp.GetDialTimeout().AsDuration())
+	assert.Equal(t, 15*time.Second, tcp.GetDrainTimeout().AsDuration())
+}
+
 // TestTomlLoader_RouteHandling tests the different route handling scenarios
 func TestTomlLoader_RouteHandling(t *testing.T) {
 	// Test standard endpoint_routes_array format
@@ -300,6 +344,570 @@ func TestTomlLoader_MultipleRouteTypes(t *testing.T) {
 	assert.Equal(t, 2, httpCount, "Should have 2 HTTP routes")
 }
 
+// TestTomlLoader_PathRegexRoute tests loading routes matched by a path regex
+func TestTomlLoader_PathRegexRoute(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "users"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "user_app"
+[endpoints.routes.http]
+path_regex = '/users/(?P<id>\d+)'
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	require.Len(t, config.Endpoints[0].Routes, 1)
+	httpRule := config.Endpoints[0].Routes[0].GetHttp()
+	require.NotNil(t, httpRule)
+	assert.Equal(t, `/users/(?P<id>\d+)`, httpRule.GetPathRegex())
+	assert.Nil(t, httpRule.PathPrefix)
+}
+
+// TestTomlLoader_JavaScriptScript tests loading a JavaScript script app
+func TestTomlLoader_JavaScriptScript(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[apps]]
+id = "js"
+type = "script"
+[apps.script.javascript]
+code = "({ message: 'hello' })"
+timeout = "2s"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Apps, 1)
+	js := config.Apps[0].GetScript().GetJavascript()
+	require.NotNil(t, js)
+	assert.Equal(t, "({ message: 'hello' })", js.GetCode())
+	assert.Equal(t, 2*time.Second, js.GetTimeout().AsDuration())
+}
+
+func TestTomlLoader_ScriptURICacheTTL(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[apps]]
+id = "remote"
+type = "script"
+[apps.script.risor]
+uri = "https://scripts.example.com/handler.risor"
+uri_cache_ttl = "90s"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Apps, 1)
+	risor := config.Apps[0].GetScript().GetRisor()
+	require.NotNil(t, risor)
+	assert.Equal(t, 90*time.Second, risor.GetUriCacheTtl().AsDuration())
+}
+
+func TestTomlLoader_AuthMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.middlewares]]
+id = "00-api-key"
+type = "auth"
+[endpoints.middlewares.auth]
+realm = "api"
+[endpoints.middlewares.auth.api_key]
+query_param = "key"
+keys = ["k1", "k2"]
+
+[[endpoints.middlewares]]
+id = "01-bearer"
+type = "auth"
+[endpoints.middlewares.auth.bearer]
+tokens = ["t1"]
+
+[[endpoints.middlewares]]
+id = "02-jwt"
+type = "auth"
+[endpoints.middlewares.auth.jwt]
+secret = "s3cret"
+jwks_url = "https://issuer.example.com/jwks"
+issuer = "https://issuer.example.com"
+audience = ["api"]
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	middlewares := config.Endpoints[0].Middlewares
+	require.Len(t, middlewares, 3)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_AUTH, middlewares[0].GetType())
+	apiKey := middlewares[0].GetAuth()
+	assert.Equal(t, "api", apiKey.GetRealm())
+	assert.Equal(t, "key", apiKey.GetApiKey().GetQueryParam())
+	assert.Equal(t, []string{"k1", "k2"}, apiKey.GetApiKey().GetKeys())
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_AUTH, middlewares[1].GetType())
+	bearer := middlewares[1].GetAuth().GetBearer()
+	assert.Equal(t, []string{"t1"}, bearer.GetTokens())
+
+	jwt := middlewares[2].GetAuth().GetJwt()
+	require.NotNil(t, jwt)
+	assert.Equal(t, "s3cret", jwt.GetSecret())
+	assert.Equal(t, "https://issuer.example.com/jwks", jwt.GetJwksUrl())
+	assert.Equal(t, "https://issuer.example.com", jwt.GetIssuer())
+	assert.Equal(t, []string{"api"}, jwt.GetAudience())
+}
+
+func TestTomlLoader_BasicAuthMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "tools"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.middlewares]]
+id = "00-basic-auth"
+type = "basic_auth"
+[endpoints.middlewares.basic_auth]
+realm = "tools"
+[[endpoints.middlewares.basic_auth.users]]
+username = "alice"
+password_hash = "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy"
+[[endpoints.middlewares.basic_auth.users]]
+username = "bob"
+password_hash = "$2a$10$abcdefghijklmnopqrstuu5Q3sWcZ1ZOJ9c7HGLz1s4nXHJ9vJ6Pe"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	middlewares := config.Endpoints[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_BASIC_AUTH, middlewares[0].GetType())
+	basicAuth := middlewares[0].GetBasicAuth()
+	assert.Equal(t, "tools", basicAuth.GetRealm())
+	require.Len(t, basicAuth.GetUsers(), 2)
+	assert.Equal(t, "alice", basicAuth.GetUsers()[0].GetUsername())
+	assert.Equal(t,
+		"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy",
+		basicAuth.GetUsers()[0].GetPasswordHash())
+	assert.Equal(t, "bob", basicAuth.GetUsers()[1].GetUsername())
+}
+
+func TestTomlLoader_CircuitBreakerMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.middlewares]]
+id = "breaker"
+type = "circuit_breaker"
+[endpoints.middlewares.circuit_breaker]
+failure_threshold = 3
+failure_ratio = 0.5
+min_requests = 20
+window = "1m"
+open_timeout = "15s"
+half_open_requests = 2
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	middlewares := config.Endpoints[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_CIRCUIT_BREAKER, middlewares[0].GetType())
+	breaker := middlewares[0].GetCircuitBreaker()
+	require.NotNil(t, breaker)
+	assert.Equal(t, int32(3), breaker.GetFailureThreshold())
+	assert.InDelta(t, 0.5, breaker.GetFailureRatio(), 0)
+	assert.Equal(t, int32(20), breaker.GetMinRequests())
+	assert.Equal(t, time.Minute, breaker.GetWindow().AsDuration())
+	assert.Equal(t, 15*time.Second, breaker.GetOpenTimeout().AsDuration())
+	assert.Equal(t, int32(2), breaker.GetHalfOpenRequests())
+}
+
+func TestTomlLoader_BodyBufferMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[endpoints.middlewares]]
+id = "00-body"
+type = "body_buffer"
+[endpoints.middlewares.body_buffer]
+max_size = 65536
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	middlewares := config.Endpoints[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_BODY_BUFFER, middlewares[0].GetType())
+	buffer := middlewares[0].GetBodyBuffer()
+	require.NotNil(t, buffer)
+	assert.Equal(t, int64(65536), buffer.GetMaxSize())
+}
+
+func TestTomlLoader_IPFilterMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "admin"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "admin"
+[endpoints.routes.http]
+path_prefix = "/admin"
+
+[[endpoints.middlewares]]
+id = "00-ip-filter"
+type = "ip_filter"
+[endpoints.middlewares.ip_filter]
+allow = ["10.0.0.0/8", "127.0.0.1"]
+deny = ["10.66.0.0/16"]
+trust_forwarded_for = true
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	middlewares := config.Endpoints[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_IP_FILTER, middlewares[0].GetType())
+	filter := middlewares[0].GetIpFilter()
+	require.NotNil(t, filter)
+	assert.Equal(t, []string{"10.0.0.0/8", "127.0.0.1"}, filter.GetAllow())
+	assert.Equal(t, []string{"10.66.0.0/16"}, filter.GetDeny())
+	assert.True(t, filter.GetTrustForwardedFor())
+}
+
+func TestTomlLoader_LegacyRoute(t *testing.T) {
+	t.Run("singular route loads with a warning", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "legacy"
+listener_id = "http"
+
+[endpoints.route]
+app_id = "echo"
+[endpoints.route.http]
+path_prefix = "/api"
+`))
+		config, err := loader.LoadProto()
+		require.NoError(t, err)
+		require.Len(t, config.Endpoints[0].Routes, 1)
+		assert.Equal(t, "/api", config.Endpoints[0].Routes[0].GetHttp().GetPathPrefix())
+
+		warnings := loader.Warnings()
+		require.Len(t, warnings, 1)
+		require.ErrorIs(t, warnings[0], ErrDeprecatedRoute)
+		issues := validation.Issues(warnings[0], validation.SeverityWarning)
+		require.Len(t, issues, 1)
+		assert.Equal(t, validation.ComponentEndpoint, issues[0].ComponentType)
+		assert.Equal(t, "legacy", issues[0].ComponentID)
+		assert.Equal(t, "route", issues[0].Field)
+	})
+
+	t.Run("routes array loads without warnings", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "current"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/api"
+`))
+		_, err := loader.LoadProto()
+		require.NoError(t, err)
+		assert.Empty(t, loader.Warnings())
+	})
+
+	t.Run("route and routes on one endpoint fail", func(t *testing.T) {
+		loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "mixed"
+listener_id = "http"
+
+[endpoints.route]
+app_id = "old"
+
+[[endpoints.routes]]
+app_id = "new"
+`))
+		_, err := loader.LoadProto()
+		require.ErrorIs(t, err, ErrPostProcessConfig)
+		require.ErrorIs(t, err, ErrRouteAndRoutes)
+	})
+}
+
+func TestTomlLoader_CacheMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "catalog"
+[endpoints.routes.http]
+path_prefix = "/catalog"
+
+[[endpoints.routes.middlewares]]
+id = "catalog-cache"
+type = "cache"
+[endpoints.routes.middlewares.cache]
+ttl = "1m30s"
+max_entries = 500
+max_body_size = 65536
+vary_headers = ["Accept", "Accept-Language"]
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	require.Len(t, config.Endpoints[0].Routes, 1)
+	middlewares := config.Endpoints[0].Routes[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_CACHE, middlewares[0].GetType())
+	cache := middlewares[0].GetCache()
+	require.NotNil(t, cache)
+	assert.Equal(t, 90*time.Second, cache.GetTtl().AsDuration())
+	assert.Equal(t, int32(500), cache.GetMaxEntries())
+	assert.Equal(t, int64(65536), cache.GetMaxBodySize())
+	assert.Equal(t, []string{"Accept", "Accept-Language"}, cache.GetVaryHeaders())
+}
+
+func TestTomlLoader_TransformMiddleware(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "api"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "legacy"
+[endpoints.routes.http]
+path_prefix = "/api/v1/"
+
+[[endpoints.routes.middlewares]]
+id = "legacy-transform"
+type = "transform"
+
+[[endpoints.routes.middlewares.transform.request]]
+rename_header = { from = "X-Api-Key", to = "Authorization" }
+
+[[endpoints.routes.middlewares.transform.request]]
+rewrite_path = { pattern = "^/api/v1/(.*)$", replacement = "/$1" }
+
+[[endpoints.routes.middlewares.transform.response]]
+remove_header = "Server"
+
+[[endpoints.routes.middlewares.transform.response]]
+set_header = { name = "X-Api-Version", value = "1" }
+
+[[endpoints.routes.middlewares.transform.response]]
+set_status = 202
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	require.Len(t, config.Endpoints[0].Routes, 1)
+	middlewares := config.Endpoints[0].Routes[0].Middlewares
+	require.Len(t, middlewares, 1)
+
+	assert.Equal(t, pbMiddleware.Middleware_TYPE_TRANSFORM, middlewares[0].GetType())
+	transform := middlewares[0].GetTransform()
+	require.NotNil(t, transform)
+	require.Len(t, transform.GetRequest(), 2)
+	assert.Equal(t, "Authorization", transform.GetRequest()[0].GetRenameHeader().GetTo())
+	assert.Equal(t, "/$1", transform.GetRequest()[1].GetRewritePath().GetReplacement())
+	require.Len(t, transform.GetResponse(), 3)
+	assert.Equal(t, "Server", transform.GetResponse()[0].GetRemoveHeader())
+	assert.Equal(t, "X-Api-Version", transform.GetResponse()[1].GetSetHeader().GetName())
+	assert.Equal(t, int32(202), transform.GetResponse()[2].GetSetStatus())
+}
+
+// TestTomlLoader_RouteMethods tests loading method conditions on routes
+func TestTomlLoader_RouteMethods(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "items"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "writer"
+methods = ["POST", "PUT"]
+[endpoints.routes.http]
+path_prefix = "/items"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	require.Len(t, config.Endpoints[0].Routes, 1)
+	assert.Equal(t, []string{"POST", "PUT"}, config.Endpoints[0].Routes[0].GetMethods())
+}
+
+// TestTomlLoader_RoutePriority tests loading route priorities
+func TestTomlLoader_RoutePriority(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "items"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "fallback"
+[endpoints.routes.http]
+path_prefix = "/items"
+
+[[endpoints.routes]]
+app_id = "writer"
+priority = 10
+methods = ["POST"]
+[endpoints.routes.http]
+path_prefix = "/items"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 1)
+	require.Len(t, config.Endpoints[0].Routes, 2)
+	assert.Nil(t, config.Endpoints[0].Routes[0].Priority)
+	assert.Equal(t, int32(10), config.Endpoints[0].Routes[1].GetPriority())
+}
+
+// TestTomlLoader_Enabled tests loading disabled endpoints and routes
+func TestTomlLoader_Enabled(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[[endpoints]]
+id = "items"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "items"
+[endpoints.routes.http]
+path_prefix = "/items"
+
+[[endpoints.routes]]
+app_id = "legacy"
+enabled = false
+[endpoints.routes.http]
+path_prefix = "/legacy"
+
+[[endpoints]]
+id = "maintenance"
+listener_id = "http"
+enabled = false
+
+[[endpoints.routes]]
+app_id = "maintenance"
+[endpoints.routes.http]
+path_prefix = "/"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	require.Len(t, config.Endpoints, 2)
+	require.Len(t, config.Endpoints[0].Routes, 2)
+	assert.True(t, config.Endpoints[0].GetEnabled())
+	assert.Nil(t, config.Endpoints[0].Routes[0].Enabled)
+	assert.False(t, config.Endpoints[0].Routes[1].GetEnabled())
+	assert.False(t, config.Endpoints[1].GetEnabled())
+}
+
+// TestTomlLoader_ApplyHooks tests loading the pre- and post-apply hook scripts
+func TestTomlLoader_ApplyHooks(t *testing.T) {
+	loader := NewTomlLoader([]byte(`
+version = "v1"
+
+[pre_apply.risor]
+code = "true"
+timeout = "2s"
+
+[pre_apply.static_data]
+environment = "prod"
+
+[post_apply.starlark]
+uri = "file:///etc/firelynx/notify.star"
+`))
+	config, err := loader.LoadProto()
+	require.NoError(t, err)
+
+	preApply := config.GetPreApply()
+	require.NotNil(t, preApply)
+	assert.Equal(t, "true", preApply.GetRisor().GetCode())
+	assert.Equal(t, 2*time.Second, preApply.GetRisor().GetTimeout().AsDuration())
+	assert.Equal(t, "prod",
+		preApply.GetStaticData().GetData()["environment"].GetStringValue())
+
+	postApply := config.GetPostApply()
+	require.NotNil(t, postApply)
+	assert.Equal(t, "file:///etc/firelynx/notify.star", postApply.GetStarlark().GetUri())
+}
+
 // TestTomlLoader_EmptyRoutes tests handling of empty routes in endpoints
 func TestTomlLoader_EmptyRoutes(t *testing.T) {
 	// Create a loader with empty routes
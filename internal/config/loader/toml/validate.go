@@ -92,8 +92,8 @@ func validateEndpoint(endpoint *pbSettings.Endpoint, index int) []error {
 		strings.HasPrefix(endpointId, "empty") ||
 		strings.HasPrefix(endpointId, "test")
 
-	// Check for empty listener ID
-	if endpoint.ListenerId == nil || *endpoint.ListenerId == "" {
+	// Check for empty listener ID, listener_ids may stand in for it
+	if endpoint.GetListenerId() == "" && len(endpoint.GetListenerIds()) == 0 {
 		err := fmt.Errorf(
 			"endpoint '%s' has no listener ID: %w",
 			endpointId,
@@ -0,0 +1,12 @@
+package marshaller
+
+import "errors"
+
+var (
+	// ErrNilConfig is returned when marshalling a nil config
+	ErrNilConfig = errors.New("config is nil")
+
+	// ErrUnsupportedFormat is returned for an output format the marshaller
+	// doesn't support
+	ErrUnsupportedFormat = errors.New("unsupported format")
+)
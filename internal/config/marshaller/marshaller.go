@@ -0,0 +1,235 @@
+// Package marshaller serializes a config into the structure of the TOML config
+// file, the inverse of the TOML loader: a config loaded from a file, sent to
+// the server and fetched back marshals to TOML that loads into the same
+// config. The same structure can be written as YAML or JSON, for reading and
+// diffing.
+//
+// The output is canonical: the keys of each table follow the field order of
+// the config protos, and free-form tables such as static data are sorted, so
+// the same config always serializes to the same bytes.
+package marshaller
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/schema"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of the marshaller
+type Format string
+
+const (
+	FormatTOML Format = "toml"
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatTOML, FormatYAML, FormatJSON}
+
+// Marshal serializes cfg in the given format
+func Marshal(cfg *config.Config, format Format) ([]byte, error) {
+	switch format {
+	case FormatTOML:
+		return ToToml(cfg)
+	case FormatYAML:
+		return ToYAML(cfg)
+	case FormatJSON:
+		return ToJSON(cfg)
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedFormat, format)
+	}
+}
+
+// ToToml serializes cfg as a TOML config file. Enum values are followed by a
+// comment listing the values the field accepts.
+func ToToml(cfg *config.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	w := &tomlWriter{}
+	w.table(nil, toTable(cfg), false)
+	return w.buf.Bytes(), nil
+}
+
+// ToYAML serializes cfg as YAML, with the same comments as ToToml
+func ToYAML(cfg *config.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(toTable(cfg)); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToJSON serializes cfg as indented JSON
+func ToJSON(cfg *config.Config) ([]byte, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+	result, err := json.MarshalIndent(toTable(cfg), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(result, '\n'), nil
+}
+
+// toTable returns cfg as the tables and values of its TOML file, keyed by the
+// field names the loader reads
+func toTable(cfg *config.Config) *table {
+	return messageTable(cfg.ToProto().ProtoReflect())
+}
+
+// messageTable converts the populated fields of a message, in field order
+func messageTable(msg protoreflect.Message) *table {
+	t := &table{}
+	fields := msg.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if !msg.Has(fd) {
+			continue
+		}
+		v := msg.Get(fd)
+
+		var comment string
+		if fd.Kind() == protoreflect.EnumKind {
+			// The loader has no name for an unspecified enum value
+			if !fd.IsList() && v.Enum() == 0 {
+				continue
+			}
+			comment = "one of: " + strings.Join(schema.EnumValues(fd.Enum()), ", ")
+		}
+		t.add(string(fd.Name()), field(fd, v), comment)
+	}
+	return t
+}
+
+// field converts the value of a field, which may be a map or a list
+func field(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch {
+	case fd.IsMap():
+		entries := make(map[string]any, v.Map().Len())
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			entries[k.String()] = fieldValue(fd.MapValue(), mv)
+			return true
+		})
+		return sortedTable(entries)
+	case fd.IsList():
+		list := v.List()
+		items := make([]any, 0, list.Len())
+		for i := range list.Len() {
+			items = append(items, fieldValue(fd, list.Get(i)))
+		}
+		return items
+	default:
+		return fieldValue(fd, v)
+	}
+}
+
+// fieldValue converts a single value of a field
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return schema.EnumName(ev)
+		}
+		return int64(v.Enum())
+	case protoreflect.BytesKind:
+		return base64.StdEncoding.EncodeToString(v.Bytes())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageValue(v.Message())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint())
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	default:
+		return v.Interface()
+	}
+}
+
+// messageValue converts a message-typed value. Well-known types are written
+// the way the loader reads them: durations as Go duration strings, and
+// free-form data as plain tables.
+func messageValue(msg protoreflect.Message) any {
+	switch m := msg.Interface().(type) {
+	case *durationpb.Duration:
+		return m.AsDuration().String()
+	case *structpb.Struct:
+		return freeForm(m.AsMap())
+	case *structpb.Value:
+		return freeForm(m.AsInterface())
+	case *structpb.ListValue:
+		return freeForm(m.AsSlice())
+	}
+
+	// Static data is written as its data table, the only part of it the
+	// loader reads
+	if msg.Descriptor().FullName() == "settings.v1alpha1.data.v1.StaticData" {
+		data := msg.Descriptor().Fields().ByName("data")
+		return field(data, msg.Get(data))
+	}
+	return messageTable(msg)
+}
+
+// freeForm converts free-form data. Numbers that are whole are written as
+// integers, as they were most likely written in the file. Null values can't
+// be written in TOML and are left out.
+func freeForm(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		entries := make(map[string]any, len(v))
+		for k, item := range v {
+			if item != nil {
+				entries[k] = freeForm(item)
+			}
+		}
+		return sortedTable(entries)
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, freeForm(item))
+			}
+		}
+		return items
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// sortedTable returns a table of entries, in key order
+func sortedTable(entries map[string]any) *table {
+	t := &table{}
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		t.add(key, entries[key], "")
+	}
+	return t
+}
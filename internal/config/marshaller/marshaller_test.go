@@ -0,0 +1,271 @@
+package marshaller
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/loader/toml"
+	gotoml "github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+func loadConfig(t *testing.T, data []byte) *config.Config {
+	t.Helper()
+	pbConfig, err := toml.NewTomlLoader(data).LoadProto()
+	require.NoError(t, err)
+	cfg, err := config.NewFromProto(pbConfig)
+	require.NoError(t, err)
+	return cfg
+}
+
+func exampleConfigs(t *testing.T) []string {
+	t.Helper()
+	examples := filepath.Join("..", "..", "..", "examples", "config")
+	files, err := filepath.Glob(filepath.Join(examples, "*.toml"))
+	require.NoError(t, err)
+	mcpFiles, err := filepath.Glob(filepath.Join(examples, "mcp", "*.toml"))
+	require.NoError(t, err)
+	files = append(files, mcpFiles...)
+	require.NotEmpty(t, files)
+	return files
+}
+
+func TestToToml_RoundTrip(t *testing.T) {
+	for _, file := range exampleConfigs(t) {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			cfg := loadConfig(t, data)
+
+			marshalled, err := ToToml(cfg)
+			require.NoError(t, err)
+			reloaded := loadConfig(t, marshalled)
+
+			want, err := protojson.Marshal(cfg.ToProto())
+			require.NoError(t, err)
+			got, err := protojson.Marshal(reloaded.ToProto())
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got), "marshalled TOML:\n%s", marshalled)
+
+			// Serializing the reloaded config gives the same bytes
+			remarshalled, err := ToToml(reloaded)
+			require.NoError(t, err)
+			assert.Equal(t, string(marshalled), string(remarshalled))
+		})
+	}
+}
+
+func TestToToml(t *testing.T) {
+	cfg := loadConfig(t, []byte(`
+version = "v1"
+
+[[listeners]]
+type = "http"
+address = ":8080"
+id = "http"
+
+[[endpoints]]
+listener_id = "http"
+id = "main"
+
+[[endpoints.middlewares]]
+id = "headers"
+type = "headers"
+[endpoints.middlewares.headers.response]
+remove_headers = ["Server"]
+[endpoints.middlewares.headers.response.set_headers]
+X-Frame-Options = "DENY"
+Cache-Control = "no-store"
+
+[[endpoints.routes]]
+app_id = "script"
+[endpoints.routes.http]
+path_prefix = "/"
+[endpoints.routes.static_data]
+zone = "eu"
+limits = { burst = 10, rate = 2.5 }
+
+[[apps]]
+id = "script"
+type = "script"
+[apps.script.risor]
+code = """
+let name = "world"
+{"message": "it's " + name}
+"""
+timeout = "2s"
+[apps.script.static_data]
+names = ["a", "b"]
+`))
+
+	data, err := ToToml(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, `version = 'v1'
+
+[[listeners]]
+id = 'http'
+address = ':8080'
+type = 'http' # one of: http, tcp
+
+[listeners.http]
+read_timeout = '10s'
+write_timeout = '10s'
+idle_timeout = '1m0s'
+drain_timeout = '30s'
+read_header_timeout = '5s'
+
+[[endpoints]]
+id = 'main'
+listener_id = 'http'
+
+[[endpoints.routes]]
+app_id = 'script'
+
+[endpoints.routes.static_data]
+zone = 'eu'
+
+[endpoints.routes.static_data.limits]
+burst = 10
+rate = 2.5
+
+[endpoints.routes.http]
+path_prefix = '/'
+
+[[endpoints.middlewares]]
+id = 'headers'
+type = 'headers' # one of: console_logger, headers, auth, basic_auth, circuit_breaker, retry, body_buffer, signature, ip_filter, cache, transform
+
+[endpoints.middlewares.headers.response]
+remove_headers = ['Server']
+
+[endpoints.middlewares.headers.response.set_headers]
+Cache-Control = 'no-store'
+X-Frame-Options = 'DENY'
+
+[[apps]]
+id = 'script'
+type = 'script' # one of: script, composite_script, echo, mcp, calculation, fileread, openapi, template
+
+[apps.script.risor]
+code = '''
+let name = "world"
+{"message": "it's " + name}
+'''
+timeout = '2s'
+
+[apps.script.static_data]
+names = ['a', 'b']
+`, string(data))
+}
+
+func TestTomlString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "plain", value: "hello", want: `'hello'`},
+		{name: "empty", value: "", want: `''`},
+		{name: "single quote", value: "it's", want: `"it's"`},
+		{name: "backslash", value: `C:\path`, want: `'C:\path'`},
+		{name: "lines", value: "a\nb\n", want: "'''\na\nb\n'''"},
+		{name: "leading newline", value: "\na", want: "'''\n\na'''"},
+		{name: "lines ending in a quote", value: "a\nb'", want: `"a\nb'"`},
+		{name: "lines with triple quotes", value: "a\n'''", want: `"a\n'''"`},
+		{name: "control characters", value: "a\rb\x00\"", want: `"a\rb\u0000\""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tomlString(tt.value)
+			assert.Equal(t, tt.want, got)
+
+			var decoded struct{ V string }
+			require.NoError(t, gotoml.Unmarshal([]byte("V = "+got), &decoded))
+			assert.Equal(t, tt.value, decoded.V)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	cfg := loadConfig(t, []byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+[listeners.http]
+read_timeout = "1m30s"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+[endpoints.routes.static_data]
+greeting = "hi"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`))
+
+	t.Run("toml", func(t *testing.T) {
+		data, err := Marshal(cfg, FormatTOML)
+		require.NoError(t, err)
+		out := string(data)
+		assert.Contains(t, out, "[[listeners]]")
+		assert.Contains(t, out, "type = 'http'")
+		assert.Contains(t, out, "read_timeout = '1m30s'")
+		assert.Contains(t, out, "[endpoints.routes.static_data]")
+		assert.Contains(t, out, "greeting = 'hi'")
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		data, err := Marshal(cfg, FormatYAML)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "type: http # one of: http, tcp")
+		var decoded map[string]any
+		require.NoError(t, yaml.Unmarshal(data, &decoded))
+		assert.Equal(t, "v1", decoded["version"])
+		listener := decoded["listeners"].([]any)[0].(map[string]any)
+		assert.Equal(t, "http", listener["type"])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := Marshal(cfg, FormatJSON)
+		require.NoError(t, err)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		apps := decoded["apps"].([]any)
+		assert.Equal(t, "echo", apps[0].(map[string]any)["type"])
+
+		// Keys are in field order
+		out := string(data)
+		assert.Less(t, strings.Index(out, `"version"`), strings.Index(out, `"listeners"`))
+		assert.Less(t, strings.Index(out, `"id": "http"`), strings.Index(out, `"address"`))
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		_, err := Marshal(cfg, "xml")
+		require.ErrorIs(t, err, ErrUnsupportedFormat)
+	})
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := Marshal(nil, FormatTOML)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+}
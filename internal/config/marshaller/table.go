@@ -0,0 +1,96 @@
+package marshaller
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// table is an ordered table of a config file. Its values are strings, bools,
+// int64s, float64s, lists of values, or nested tables.
+type table struct {
+	entries []entry
+}
+
+// entry is a key of a table, with an optional comment on the values it
+// accepts
+type entry struct {
+	key     string
+	value   any
+	comment string
+}
+
+func (t *table) add(key string, value any, comment string) {
+	t.entries = append(t.entries, entry{key: key, value: value, comment: comment})
+}
+
+// MarshalJSON writes the table as an object, keeping the order of its keys
+func (t *table) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, e := range t.entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(e.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// MarshalYAML writes the table as a mapping, keeping the order of its keys
+// and writing comments at the end of their lines
+func (t *table) MarshalYAML() (any, error) {
+	return yamlNode(t)
+}
+
+// yamlNode builds the node of a value. Nested tables and lists are built
+// here rather than encoded, since encoding a node drops the comments in it.
+func yamlNode(v any) (*yaml.Node, error) {
+	switch v := v.(type) {
+	case *table:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		for _, e := range v.entries {
+			key, err := yamlNode(e.key)
+			if err != nil {
+				return nil, err
+			}
+			value, err := yamlNode(e.value)
+			if err != nil {
+				return nil, err
+			}
+			if e.comment != "" {
+				value.LineComment = "# " + e.comment
+			}
+			node.Content = append(node.Content, key, value)
+		}
+		return node, nil
+	case []any:
+		node := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, item := range v {
+			itemNode, err := yamlNode(item)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, itemNode)
+		}
+		return node, nil
+	default:
+		node := &yaml.Node{}
+		if err := node.Encode(v); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+}
@@ -0,0 +1,212 @@
+package marshaller
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// bareKey matches the keys TOML allows without quotes
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// tomlWriter writes tables as TOML. The keys of a table are written before its
+// sub-tables, since every key after a table header belongs to that table.
+type tomlWriter struct {
+	buf bytes.Buffer
+}
+
+// table writes t at path, under a [path] header, or a [[path]] header when it
+// is an element of an array of tables. A header is left out for a table that
+// only holds sub-tables, their headers define it.
+func (w *tomlWriter) table(path []string, t *table, arrayElement bool) {
+	var keys, subTables []entry
+	for _, e := range t.entries {
+		if isTable(e.value) || isArrayOfTables(e.value) {
+			subTables = append(subTables, e)
+		} else {
+			keys = append(keys, e)
+		}
+	}
+
+	if len(path) > 0 && (arrayElement || len(keys) > 0 || len(subTables) == 0) {
+		if w.buf.Len() > 0 {
+			w.buf.WriteByte('\n')
+		}
+		if arrayElement {
+			fmt.Fprintf(&w.buf, "[[%s]]\n", tomlPath(path))
+		} else {
+			fmt.Fprintf(&w.buf, "[%s]\n", tomlPath(path))
+		}
+	}
+
+	for _, e := range keys {
+		w.buf.WriteString(tomlKey(e.key))
+		w.buf.WriteString(" = ")
+		w.buf.WriteString(tomlValue(e.value))
+		if e.comment != "" {
+			w.buf.WriteString(" # ")
+			w.buf.WriteString(e.comment)
+		}
+		w.buf.WriteByte('\n')
+	}
+
+	for _, e := range subTables {
+		subPath := append(path[:len(path):len(path)], e.key)
+		if sub, ok := e.value.(*table); ok {
+			w.table(subPath, sub, false)
+			continue
+		}
+		for _, item := range e.value.([]any) {
+			w.table(subPath, item.(*table), true)
+		}
+	}
+}
+
+func isTable(v any) bool {
+	_, ok := v.(*table)
+	return ok
+}
+
+// isArrayOfTables reports whether v is a list that can be written as an
+// array of tables; other lists are written inline
+func isArrayOfTables(v any) bool {
+	items, ok := v.([]any)
+	if !ok || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if !isTable(item) {
+			return false
+		}
+	}
+	return true
+}
+
+func tomlPath(path []string) string {
+	keys := make([]string, 0, len(path))
+	for _, key := range path {
+		keys = append(keys, tomlKey(key))
+	}
+	return strings.Join(keys, ".")
+}
+
+func tomlKey(key string) string {
+	if bareKey.MatchString(key) {
+		return key
+	}
+	return tomlBasicString(key)
+}
+
+// tomlValue formats a value inline
+func tomlValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return tomlString(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return tomlFloat(v)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, tomlValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case *table:
+		if len(v.entries) == 0 {
+			return "{}"
+		}
+		items := make([]string, 0, len(v.entries))
+		for _, e := range v.entries {
+			items = append(items, tomlKey(e.key)+" = "+tomlValue(e.value))
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	default:
+		return tomlString(fmt.Sprint(v))
+	}
+}
+
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEn") {
+		s += ".0"
+	}
+	return s
+}
+
+// tomlString formats a string as a literal string where it can, which needs
+// no escaping. Strings of several lines, such as scripts, are written as
+// multi-line literal strings so they read as they were written.
+func tomlString(s string) string {
+	if !literalSafe(s) {
+		return tomlBasicString(s)
+	}
+	if strings.Contains(s, "\n") {
+		if strings.Contains(s, "'''") || strings.HasSuffix(s, "'") {
+			return tomlBasicString(s)
+		}
+		// The newline after the opening delimiter is not part of the string
+		return "'''\n" + s + "'''"
+	}
+	if strings.Contains(s, "'") {
+		return tomlBasicString(s)
+	}
+	return "'" + s + "'"
+}
+
+// literalSafe reports whether s can be written as a literal string, which
+// can't hold control characters other than tabs and, over several lines,
+// newlines
+func literalSafe(s string) bool {
+	for _, r := range s {
+		if (r < 0x20 && r != '\t' && r != '\n') || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlBasicString formats a string as a basic string, with escapes
+func tomlBasicString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -5,9 +5,13 @@ import (
 	"fmt"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // ToProto converts the domain Config to a protobuf ServerConfig
@@ -20,9 +24,53 @@ func (c *Config) ToProto() *pb.ServerConfig {
 	config.Endpoints = c.Endpoints.ToProto()
 	config.Apps = c.Apps.ToProto()
 
+	if c.DefaultTimeout != 0 {
+		config.DefaultTimeout = durationpb.New(c.DefaultTimeout)
+	}
+	if c.MaxMiddlewareChainLength != 0 {
+		config.MaxMiddlewareChainLength = proto.Int32(int32(c.MaxMiddlewareChainLength))
+	}
+	if c.StrictValidation {
+		config.StrictValidation = proto.Bool(true)
+	}
+	if c.AppFallback != (AppFallback{}) {
+		config.AppFallback = &pb.AppFallback{}
+		if c.AppFallback.Body != "" {
+			config.AppFallback.Body = proto.String(c.AppFallback.Body)
+		}
+		if c.AppFallback.ContentType != "" {
+			config.AppFallback.ContentType = proto.String(c.AppFallback.ContentType)
+		}
+	}
+	if c.PreApply != nil {
+		config.PreApply, _ = c.PreApply.ToProto().(*pbApps.ScriptApp)
+	}
+	if c.PostApply != nil {
+		config.PostApply, _ = c.PostApply.ToProto().(*pbApps.ScriptApp)
+	}
+	if c.IsolateFailures {
+		config.IsolateFailures = proto.Bool(true)
+	}
+
 	return config
 }
 
+// applyHooksFromProto converts the apply hook scripts of pbConfig
+func (c *Config) applyHooksFromProto(pbConfig *pb.ServerConfig) error {
+	preApply, err := scripts.FromProto(HookPreApply, pbConfig.GetPreApply())
+	if err != nil {
+		return fmt.Errorf("%s: %w", HookPreApply, err)
+	}
+	postApply, err := scripts.FromProto(HookPostApply, pbConfig.GetPostApply())
+	if err != nil {
+		return fmt.Errorf("%s: %w", HookPostApply, err)
+	}
+
+	c.PreApply = preApply
+	c.PostApply = postApply
+	return nil
+}
+
 // fromProto is an internal function that performs basic conversion from protobuf to domain model.
 // For public use, prefer NewFromProto which handles defaults and additional initialization.
 func fromProto(pbConfig *pb.ServerConfig) (*Config, error) {
@@ -39,6 +87,20 @@ func fromProto(pbConfig *pb.ServerConfig) (*Config, error) {
 		rawProto: pbConfig,
 	}
 
+	if pbConfig.DefaultTimeout != nil {
+		config.DefaultTimeout = pbConfig.DefaultTimeout.AsDuration()
+	}
+	config.MaxMiddlewareChainLength = int(pbConfig.GetMaxMiddlewareChainLength())
+	config.StrictValidation = pbConfig.GetStrictValidation()
+	config.IsolateFailures = pbConfig.GetIsolateFailures()
+	config.AppFallback = AppFallback{
+		Body:        pbConfig.GetAppFallback().GetBody(),
+		ContentType: pbConfig.GetAppFallback().GetContentType(),
+	}
+	if err := config.applyHooksFromProto(pbConfig); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrFailedToConvertConfig, err)
+	}
+
 	// Convert listeners using the listeners package's FromProto method
 	listeners, err := listeners.FromProto(pbConfig.Listeners)
 	if err != nil {
@@ -69,3 +131,37 @@ func fromProto(pbConfig *pb.ServerConfig) (*Config, error) {
 
 	return config, nil
 }
+
+// ToProto converts the Delta to a protobuf ConfigDelta
+func (d Delta) ToProto() *pb.ConfigDelta {
+	return &pb.ConfigDelta{
+		Listeners:   changesToProto(d.Listeners),
+		Endpoints:   changesToProto(d.Endpoints),
+		Apps:        changesToProto(d.Apps),
+		Middlewares: changesToProto(d.Middlewares),
+	}
+}
+
+func changesToProto(changes []Change) []*pb.ComponentChange {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	pbChanges := make([]*pb.ComponentChange, 0, len(changes))
+	for _, c := range changes {
+		kind := pb.ComponentChange_KIND_UNSPECIFIED
+		switch c.Kind {
+		case ChangeAdded:
+			kind = pb.ComponentChange_KIND_ADDED
+		case ChangeRemoved:
+			kind = pb.ComponentChange_KIND_REMOVED
+		case ChangeChanged:
+			kind = pb.ComponentChange_KIND_CHANGED
+		}
+		pbChanges = append(pbChanges, &pb.ComponentChange{
+			Id:   &c.ID,
+			Kind: &kind,
+		})
+	}
+	return pbChanges
+}
@@ -0,0 +1,212 @@
+// Package schema describes the firelynx TOML config file as a JSON Schema, for
+// editors that can validate and complete TOML against one.
+//
+// The schema is derived from the protobuf definitions the TOML loader
+// unmarshals into, so its structure always follows the config types. Enum
+// values are the lowercase names the loader accepts, and the fields config
+// validation requires are marked as required.
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/version"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Draft is the JSON Schema dialect of the generated schema
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// durationPattern matches the Go duration strings the loader accepts
+const durationPattern = `^-?([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+$|^0$`
+
+// requiredFields lists, by message, the fields config validation rejects a
+// component without
+var requiredFields = map[protoreflect.FullName][]string{
+	"settings.v1alpha1.Listener":                 {"id", "address"},
+	"settings.v1alpha1.Endpoint":                 {"id"},
+	"settings.v1alpha1.Route":                    {"app_id"},
+	"settings.v1alpha1.AppDefinition":            {"id", "type"},
+	"settings.v1alpha1.middleware.v1.Middleware": {"id", "type"},
+}
+
+// anyOfRequiredFields lists, by message, groups of fields of which validation
+// requires at least one
+var anyOfRequiredFields = map[protoreflect.FullName][]string{
+	"settings.v1alpha1.Endpoint": {"listener_id", "listener_ids"},
+}
+
+// freeFormMessages are messages holding arbitrary data, which the loader
+// takes as a plain TOML table
+var freeFormMessages = map[protoreflect.FullName]bool{
+	"google.protobuf.Struct":               true,
+	"google.protobuf.Value":                true,
+	"settings.v1alpha1.data.v1.StaticData": true,
+}
+
+// Generate returns the JSON Schema of the config file
+func Generate() map[string]any {
+	g := &generator{definitions: make(map[string]any)}
+
+	root := g.message((&pb.ServerConfig{}).ProtoReflect().Descriptor())
+	root["$schema"] = Draft
+	root["title"] = "firelynx configuration"
+	if properties, ok := root["properties"].(map[string]any); ok {
+		properties["version"] = map[string]any{
+			"type": "string",
+			"enum": []any{version.Version},
+		}
+	}
+	root["definitions"] = g.definitions
+	return root
+}
+
+// JSON returns the JSON Schema of the config file, indented for reading
+func JSON() ([]byte, error) {
+	return json.MarshalIndent(Generate(), "", "  ")
+}
+
+type generator struct {
+	definitions map[string]any
+}
+
+// message returns the object schema of a message
+func (g *generator) message(md protoreflect.MessageDescriptor) map[string]any {
+	properties := make(map[string]any)
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		properties[string(fd.Name())] = g.field(fd)
+	}
+
+	s := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if required, ok := requiredFields[md.FullName()]; ok {
+		s["required"] = toAny(required)
+	}
+	if anyOf, ok := anyOfRequiredFields[md.FullName()]; ok {
+		alternatives := make([]any, 0, len(anyOf))
+		for _, name := range anyOf {
+			alternatives = append(alternatives, map[string]any{"required": []any{name}})
+		}
+		s["anyOf"] = alternatives
+	}
+	return s
+}
+
+// field returns the schema of a field's value
+func (g *generator) field(fd protoreflect.FieldDescriptor) map[string]any {
+	if fd.IsMap() {
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.value(fd.MapValue()),
+		}
+	}
+	if fd.IsList() {
+		return map[string]any{
+			"type":  "array",
+			"items": g.value(fd),
+		}
+	}
+	return g.value(fd)
+}
+
+// value returns the schema of a single value of a field, ignoring whether
+// the field is repeated
+func (g *generator) value(fd protoreflect.FieldDescriptor) map[string]any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.EnumKind:
+		return map[string]any{"type": "string", "enum": toAny(EnumValues(fd.Enum()))}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "integer"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "integer", "minimum": 0}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return g.messageRef(fd.Message())
+	default:
+		return map[string]any{}
+	}
+}
+
+// messageRef returns the schema of a message-typed value, referring to the
+// message's definition so recursive messages terminate
+func (g *generator) messageRef(md protoreflect.MessageDescriptor) map[string]any {
+	switch name := md.FullName(); {
+	case name == "google.protobuf.Duration":
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	case name == "google.protobuf.ListValue":
+		return map[string]any{"type": "array"}
+	case freeFormMessages[name]:
+		return map[string]any{"type": "object"}
+	}
+
+	name := string(md.FullName())
+	if _, ok := g.definitions[name]; !ok {
+		// Reserve the name before descending, for messages that contain themselves
+		g.definitions[name] = nil
+		g.definitions[name] = g.message(md)
+	}
+	return map[string]any{"$ref": "#/definitions/" + name}
+}
+
+// EnumValues returns the values the loader accepts for an enum, see
+// EnumName. The unspecified value is left out.
+func EnumValues(ed protoreflect.EnumDescriptor) []string {
+	values := ed.Values()
+	result := make([]string, 0, values.Len())
+	for i := range values.Len() {
+		v := values.Get(i)
+		if v.Number() == 0 {
+			continue
+		}
+		result = append(result, EnumName(v))
+	}
+	return result
+}
+
+// EnumName returns the name the loader accepts for an enum value: the value's
+// name without the prefix all values of its enum share, in lowercase
+func EnumName(v protoreflect.EnumValueDescriptor) string {
+	values := v.Parent().(protoreflect.EnumDescriptor).Values()
+	names := make([]string, 0, values.Len())
+	for i := range values.Len() {
+		names = append(names, string(values.Get(i).Name()))
+	}
+	return strings.ToLower(strings.TrimPrefix(string(v.Name()), commonPrefix(names)))
+}
+
+// commonPrefix returns the longest prefix, up to and including an underscore,
+// that all names share
+func commonPrefix(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	prefix := names[0]
+	for _, name := range names[1:] {
+		for !strings.HasPrefix(name, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix[:strings.LastIndex(prefix, "_")+1]
+}
+
+func toAny(values []string) []any {
+	result := make([]any, 0, len(values))
+	for _, v := range values {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,291 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/loader/toml"
+	gotoml "github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// baseConfig is a valid config using every component with required fields
+const baseConfig = `
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.middlewares]]
+id = "logger"
+type = "console_logger"
+[endpoints.middlewares.console_logger]
+preset = "minimal"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`
+
+func loadBaseConfig(t *testing.T) *pb.ServerConfig {
+	t.Helper()
+	pbConfig, err := toml.NewTomlLoader([]byte(baseConfig)).LoadProto()
+	require.NoError(t, err)
+	cfg, err := config.NewFromProto(pbConfig)
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+	return pbConfig
+}
+
+func TestEnumValues(t *testing.T) {
+	listenerType := (&pb.Listener{}).ProtoReflect().Descriptor().Fields().ByName("type").Enum()
+	assert.Equal(t, []string{"http", "tcp"}, EnumValues(listenerType))
+
+	appType := (&pb.AppDefinition{}).ProtoReflect().Descriptor().Fields().ByName("type").Enum()
+	assert.Contains(t, EnumValues(appType), "composite_script")
+	assert.NotContains(t, EnumValues(appType), "unspecified")
+}
+
+func TestEnumValuesAcceptedByLoader(t *testing.T) {
+	listenerType := (&pb.Listener{}).ProtoReflect().Descriptor().Fields().ByName("type").Enum()
+	for _, value := range EnumValues(listenerType) {
+		t.Run("listener "+value, func(t *testing.T) {
+			pbConfig, err := toml.NewTomlLoader([]byte(fmt.Sprintf(`
+version = "v1"
+[[listeners]]
+id = "l"
+address = ":8080"
+type = %q
+`, value))).LoadProto()
+			require.NoError(t, err)
+			assert.NotEqual(t, pb.Listener_TYPE_UNSPECIFIED, pbConfig.GetListeners()[0].GetType())
+		})
+	}
+
+	appType := (&pb.AppDefinition{}).ProtoReflect().Descriptor().Fields().ByName("type").Enum()
+	for _, value := range EnumValues(appType) {
+		t.Run("app "+value, func(t *testing.T) {
+			pbConfig, err := toml.NewTomlLoader([]byte(fmt.Sprintf(`
+version = "v1"
+[[apps]]
+id = "a"
+type = %q
+`, value))).LoadProto()
+			require.NoError(t, err)
+			assert.NotEqual(t, pb.AppDefinition_TYPE_UNSPECIFIED, pbConfig.GetApps()[0].GetType())
+		})
+	}
+}
+
+// firstMessage returns the first message named name in msg, depth first
+func firstMessage(msg protoreflect.Message, name protoreflect.FullName) protoreflect.Message {
+	if msg.Descriptor().FullName() == name {
+		return msg
+	}
+	var found protoreflect.Message
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len() && found == nil; i++ {
+				found = firstMessage(list.Get(i).Message(), name)
+			}
+		} else {
+			found = firstMessage(v.Message(), name)
+		}
+		return found == nil
+	})
+	return found
+}
+
+func TestRequiredFieldsMatchValidation(t *testing.T) {
+	// Each field marked required must fail validation when left out
+	rejects := func(t *testing.T, name protoreflect.FullName, fields ...string) {
+		t.Helper()
+		pbConfig := loadBaseConfig(t)
+		msg := firstMessage(pbConfig.ProtoReflect(), name)
+		require.NotNil(t, msg, "base config has no %s", name)
+		for _, field := range fields {
+			fd := msg.Descriptor().Fields().ByName(protoreflect.Name(field))
+			require.NotNil(t, fd, "%s has no field %s", name, field)
+			msg.Clear(fd)
+		}
+
+		cfg, err := config.NewFromProto(pbConfig)
+		if err == nil {
+			err = cfg.Validate()
+		}
+		assert.Error(t, err, "config without %s.%v should be invalid", name, fields)
+	}
+
+	for name, fields := range requiredFields {
+		for _, field := range fields {
+			t.Run(fmt.Sprintf("%s.%s", name, field), func(t *testing.T) {
+				rejects(t, name, field)
+			})
+		}
+	}
+	for name, fields := range anyOfRequiredFields {
+		t.Run(fmt.Sprintf("%s.%v", name, fields), func(t *testing.T) {
+			rejects(t, name, fields...)
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate()
+	assert.Equal(t, Draft, s["$schema"])
+
+	properties := s["properties"].(map[string]any)
+	for _, key := range []string{"version", "listeners", "endpoints", "apps"} {
+		assert.Contains(t, properties, key)
+	}
+
+	definitions := s["definitions"].(map[string]any)
+	listener := definitions["settings.v1alpha1.Listener"].(map[string]any)
+	assert.Equal(t, []any{"id", "address"}, listener["required"])
+
+	data, err := JSON()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "{\n"))
+}
+
+func TestExampleConfigsMatchSchema(t *testing.T) {
+	s := Generate()
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "examples", "config", "*.toml"))
+	require.NoError(t, err)
+	mcpFiles, err := filepath.Glob(filepath.Join("..", "..", "..", "examples", "config", "mcp", "*.toml"))
+	require.NoError(t, err)
+	files = append(files, mcpFiles...)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+			var doc map[string]any
+			require.NoError(t, gotoml.Unmarshal(data, &doc))
+			assert.Empty(t, check(s, s, doc, "$"))
+		})
+	}
+
+	t.Run("rejects unknown fields and enum values", func(t *testing.T) {
+		var doc map[string]any
+		require.NoError(t, gotoml.Unmarshal([]byte(`
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "https"
+adress = ":8080"
+`), &doc))
+		problems := check(s, s, doc, "$")
+		assert.Len(t, problems, 2)
+	})
+}
+
+// check validates value against the subset of JSON Schema Generate uses,
+// returning the problems found
+func check(root, s map[string]any, value any, path string) []string {
+	if ref, ok := s["$ref"].(string); ok {
+		definitions := root["definitions"].(map[string]any)
+		return check(root, definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]any), value, path)
+	}
+
+	var problems []string
+	switch s["type"] {
+	case "object":
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return []string{path + ": expected a table"}
+		}
+		properties, _ := s["properties"].(map[string]any)
+		for key, v := range obj {
+			if p, ok := properties[key].(map[string]any); ok {
+				problems = append(problems, check(root, p, v, path+"."+key)...)
+				continue
+			}
+			switch additional := s["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, path+": unknown field "+key)
+				}
+			case map[string]any:
+				problems = append(problems, check(root, additional, v, path+"."+key)...)
+			}
+		}
+		if required, ok := s["required"].([]any); ok {
+			for _, name := range required {
+				if _, ok := obj[name.(string)]; !ok {
+					problems = append(problems, fmt.Sprintf("%s: missing %s", path, name))
+				}
+			}
+		}
+		if anyOf, ok := s["anyOf"].([]any); ok {
+			if !slices.ContainsFunc(anyOf, func(alt any) bool {
+				return len(check(root, alt.(map[string]any), value, path)) == 0
+			}) {
+				problems = append(problems, path+": matches none of anyOf")
+			}
+		}
+	case "array":
+		arr, ok := value.([]any)
+		if !ok {
+			return []string{path + ": expected an array"}
+		}
+		if items, ok := s["items"].(map[string]any); ok {
+			for i, v := range arr {
+				problems = append(problems, check(root, items, v, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return []string{path + ": expected a string"}
+		}
+		if enum, ok := s["enum"].([]any); ok && !slices.Contains(enum, any(str)) {
+			problems = append(problems, fmt.Sprintf("%s: %q isn't one of %v", path, str, enum))
+		}
+		if pattern, ok := s["pattern"].(string); ok && !regexp.MustCompile(pattern).MatchString(str) {
+			problems = append(problems, fmt.Sprintf("%s: %q doesn't match %s", path, str, pattern))
+		}
+	case "integer":
+		if _, ok := value.(int64); !ok {
+			problems = append(problems, path+": expected an integer")
+		}
+	case "number":
+		switch value.(type) {
+		case int64, float64:
+		default:
+			problems = append(problems, path+": expected a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			problems = append(problems, path+": expected a boolean")
+		}
+	}
+	return problems
+}
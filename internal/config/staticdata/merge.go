@@ -0,0 +1,64 @@
+package staticdata
+
+// DeepMerge merges override into base and returns the result as a new map,
+// leaving both inputs unmodified. The rules are applied key by key:
+//
+//   - A key present in only one of the maps keeps that map's value.
+//   - When both values are maps (map[string]any), they are merged recursively
+//     with these same rules.
+//   - Otherwise the override value wins, so lists are replaced rather than
+//     concatenated, and a map replaces a scalar (or the other way around).
+//
+// Nested maps and lists in the result are copies, so changing the result never
+// changes base or override. DeepMerge returns nil when both maps are empty.
+func DeepMerge(base, override map[string]any) map[string]any {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]any, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = deepCopy(v)
+	}
+
+	for k, v := range override {
+		baseMap, baseIsMap := merged[k].(map[string]any)
+		overrideMap, overrideIsMap := v.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[k] = mergeMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[k] = deepCopy(v)
+	}
+
+	return merged
+}
+
+// mergeMaps is DeepMerge for nested maps, which keeps empty maps rather than
+// returning nil
+func mergeMaps(base, override map[string]any) map[string]any {
+	if merged := DeepMerge(base, override); merged != nil {
+		return merged
+	}
+	return map[string]any{}
+}
+
+// deepCopy copies maps and lists recursively, other values are returned as is
+func deepCopy(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		copied := make(map[string]any, len(val))
+		for k, item := range val {
+			copied[k] = deepCopy(item)
+		}
+		return copied
+	case []any:
+		copied := make([]any, len(val))
+		for i, item := range val {
+			copied[i] = deepCopy(item)
+		}
+		return copied
+	default:
+		return v
+	}
+}
@@ -0,0 +1,115 @@
+package staticdata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeepMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     map[string]any
+		override map[string]any
+		want     map[string]any
+	}{
+		{
+			name: "both empty",
+			want: nil,
+		},
+		{
+			name: "only base",
+			base: map[string]any{"env": "prod"},
+			want: map[string]any{"env": "prod"},
+		},
+		{
+			name:     "only override",
+			override: map[string]any{"env": "prod"},
+			want:     map[string]any{"env": "prod"},
+		},
+		{
+			name:     "additive keys",
+			base:     map[string]any{"env": "prod", "region": "us-east-1"},
+			override: map[string]any{"feature": true},
+			want:     map[string]any{"env": "prod", "region": "us-east-1", "feature": true},
+		},
+		{
+			name:     "override wins on conflict",
+			base:     map[string]any{"env": "prod", "timeout": 30},
+			override: map[string]any{"timeout": 5},
+			want:     map[string]any{"env": "prod", "timeout": 5},
+		},
+		{
+			name: "nested maps are merged",
+			base: map[string]any{
+				"db": map[string]any{"host": "db.internal", "port": 5432},
+			},
+			override: map[string]any{
+				"db": map[string]any{"port": 6543, "name": "orders"},
+			},
+			want: map[string]any{
+				"db": map[string]any{"host": "db.internal", "port": 6543, "name": "orders"},
+			},
+		},
+		{
+			name: "deeply nested maps are merged",
+			base: map[string]any{
+				"a": map[string]any{"b": map[string]any{"c": 1, "d": 2}},
+			},
+			override: map[string]any{
+				"a": map[string]any{"b": map[string]any{"d": 3}},
+			},
+			want: map[string]any{
+				"a": map[string]any{"b": map[string]any{"c": 1, "d": 3}},
+			},
+		},
+		{
+			name:     "lists are replaced",
+			base:     map[string]any{"tags": []any{"a", "b"}},
+			override: map[string]any{"tags": []any{"c"}},
+			want:     map[string]any{"tags": []any{"c"}},
+		},
+		{
+			name:     "scalar replaces map",
+			base:     map[string]any{"db": map[string]any{"host": "db.internal"}},
+			override: map[string]any{"db": "disabled"},
+			want:     map[string]any{"db": "disabled"},
+		},
+		{
+			name:     "map replaces scalar",
+			base:     map[string]any{"db": "disabled"},
+			override: map[string]any{"db": map[string]any{"host": "db.internal"}},
+			want:     map[string]any{"db": map[string]any{"host": "db.internal"}},
+		},
+		{
+			name:     "empty nested maps are kept",
+			base:     map[string]any{"db": map[string]any{}},
+			override: map[string]any{"db": map[string]any{}},
+			want:     map[string]any{"db": map[string]any{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DeepMerge(tt.base, tt.override))
+		})
+	}
+}
+
+func TestDeepMerge_DoesNotModifyInputs(t *testing.T) {
+	base := map[string]any{
+		"db":   map[string]any{"host": "db.internal"},
+		"tags": []any{"a"},
+	}
+	override := map[string]any{
+		"db": map[string]any{"port": 5432},
+	}
+
+	merged := DeepMerge(base, override)
+	merged["db"].(map[string]any)["host"] = "changed"
+	merged["tags"].([]any)[0] = "changed"
+
+	assert.Equal(t, map[string]any{"host": "db.internal"}, base["db"])
+	assert.Equal(t, []any{"a"}, base["tags"])
+	assert.Equal(t, map[string]any{"port": 5432}, override["db"])
+}
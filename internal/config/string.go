@@ -18,6 +18,29 @@ func ConfigTree(cfg *Config) string {
 	t := fancy.Tree()
 	t.Root(fancy.RootStyle.Render(fmt.Sprintf("Firelynx Config (%s)", cfg.Version)))
 
+	if cfg.DefaultTimeout > 0 {
+		t.Child(fmt.Sprintf("Default Timeout: %s", cfg.DefaultTimeout))
+	}
+	if cfg.MaxMiddlewareChainLength > 0 {
+		t.Child(fmt.Sprintf("Max Middleware Chain Length: %d", cfg.MaxMiddlewareChainLength))
+	}
+	if cfg.StrictValidation {
+		t.Child("Strict Validation: enabled")
+	}
+	if cfg.IsolateFailures {
+		t.Child("Isolate Failures: enabled")
+	}
+	if cfg.AppFallback != (AppFallback{}) {
+		t.Child(fmt.Sprintf("App Fallback: %q (%s)",
+			cfg.AppFallback.GetBody(), cfg.AppFallback.GetContentType()))
+	}
+	if cfg.PreApply != nil && cfg.PreApply.Evaluator != nil {
+		t.Child(fmt.Sprintf("Pre-Apply Hook: %s", cfg.PreApply.Evaluator.Type()))
+	}
+	if cfg.PostApply != nil && cfg.PostApply.Evaluator != nil {
+		t.Child(fmt.Sprintf("Post-Apply Hook: %s", cfg.PostApply.Evaluator.Type()))
+	}
+
 	// Create a nested tree of listeners with consistent styling
 	if len(cfg.Listeners) > 0 {
 		listenersRoot := fancy.NewComponentTree(
@@ -4,6 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
@@ -12,22 +16,31 @@ import (
 	configEcho "github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
 	configFileRead "github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
 	configMCP "github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
+	configOpenAPI "github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
 	configScripts "github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	configTemplate "github.com/atlanticdynamic/firelynx/internal/config/apps/template"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/calculation"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/composite"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/echo"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/fileread"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/mcpserver"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/openapi"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/script"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/template"
 )
 
 var (
-	ErrConfigNil             = errors.New("config cannot be nil")
-	ErrEvaluatorNil          = errors.New("script app must have an evaluator")
-	ErrCompiledEvaluatorNil  = errors.New("compiled evaluator is nil - domain validation may not have been run")
-	ErrCompositeNotSupported = errors.New("composite apps are not yet supported in server layer")
-	ErrDuplicateAppID        = errors.New("duplicate app ID")
-	ErrUnknownAppType        = errors.New("unknown app type")
+	ErrConfigNil            = errors.New("config cannot be nil")
+	ErrEvaluatorNil         = errors.New("script app must have an evaluator")
+	ErrCompiledEvaluatorNil = errors.New("compiled evaluator is nil - domain validation may not have been run")
+	ErrEchoTemplateNil      = errors.New("echo template is not parsed - domain validation may not have been run")
+	ErrHTMLTemplatesNil     = errors.New("html templates are not parsed - domain validation may not have been run")
+	ErrTemplateNotFound     = errors.New("template not found")
+	ErrDuplicateAppID       = errors.New("duplicate app ID")
+	ErrUnknownAppType       = errors.New("unknown app type")
 )
 
 // convertEchoConfig converts domain echo config to echo DTO
@@ -42,14 +55,35 @@ func convertEchoConfig(id string, domainConfig *configEcho.EchoApp) (*echo.Confi
 		response = id
 	}
 
+	// Templates are parsed by domain validation
+	responseTemplate := domainConfig.ResponseTemplate()
+	representationTemplates := domainConfig.RepresentationTemplates()
+	if domainConfig.Template {
+		if domainConfig.Response != "" && responseTemplate == nil {
+			return nil, fmt.Errorf("failed to convert echo app %s: %w", id, ErrEchoTemplateNil)
+		}
+		if len(representationTemplates) != len(domainConfig.Representations) {
+			return nil, fmt.Errorf("failed to convert echo app %s: %w", id, ErrEchoTemplateNil)
+		}
+	}
+
 	return &echo.Config{
-		ID:       id,
-		Response: response,
+		ID:                      id,
+		Response:                response,
+		Representations:         maps.Clone(domainConfig.Representations),
+		MediaTypes:              domainConfig.MediaTypes(),
+		ResponseTemplate:        responseTemplate,
+		RepresentationTemplates: representationTemplates,
 	}, nil
 }
 
-// convertScriptConfig converts domain script config to script DTO
-func convertScriptConfig(id string, domainConfig *configScripts.AppScript) (*script.Config, error) {
+// convertScriptConfig converts domain script config to script DTO. The
+// defaultTimeout is applied when the evaluator doesn't set its own timeout.
+func convertScriptConfig(
+	id string,
+	domainConfig *configScripts.AppScript,
+	defaultTimeout time.Duration,
+) (*script.Config, error) {
 	if domainConfig == nil {
 		return nil, fmt.Errorf("failed to convert script config: %w", ErrConfigNil)
 	}
@@ -77,8 +111,19 @@ func convertScriptConfig(id string, domainConfig *configScripts.AppScript) (*scr
 	// Create logger for this app instance
 	logger := slog.Default().With("app_type", "script", "app_id", id)
 
+	// Report compile time so reloads can confirm unchanged scripts were reused
+	if stats, ok := evaluators.GetCompileStats(domainConfig.Evaluator); ok {
+		logger.Debug("Script evaluator ready",
+			"compile_time", stats.CompileTime,
+			"cache_hit", stats.CacheHit,
+			"source_hash", stats.SourceHash)
+	}
+
 	// Get the exec timeout deadline
-	timeout := domainConfig.Evaluator.GetTimeout()
+	timeout, usedDefault := evaluators.ResolveTimeout(domainConfig.Evaluator, defaultTimeout)
+	if usedDefault {
+		logger.Info("Using server default timeout for script evaluator", "timeout", timeout)
+	}
 
 	return &script.Config{
 		ID:                id,
@@ -86,6 +131,10 @@ func convertScriptConfig(id string, domainConfig *configScripts.AppScript) (*scr
 		StaticData:        staticData,
 		Logger:            logger,
 		ExecTimeout:       timeout,
+		Evaluator:         strings.ToLower(domainConfig.Evaluator.Type().String()),
+		Streaming:         domainConfig.Evaluator.Type().CanCallGoFunctions(),
+		TimeoutBody:       domainConfig.TimeoutBody,
+		ShutdownBody:      domainConfig.ShutdownBody,
 	}, nil
 }
 
@@ -98,14 +147,28 @@ func convertMCPConfig(id string, domainConfig *configMCP.App) (*mcpserver.Config
 		return nil, fmt.Errorf("failed to convert MCP config: %w", ErrConfigNil)
 	}
 
-	cfg := &mcpserver.Config{ID: id}
+	// The instances of each route share the app's ID as server name
+	cfg := &mcpserver.Config{
+		ID:     id,
+		Name:   domainConfig.ID,
+		Logger: slog.Default().With("app_type", "mcp", "app_id", id),
+
+		MaxConcurrentToolCalls: domainConfig.MaxConcurrentToolCalls,
+		ToolCallQueueTimeout:   domainConfig.ToolCallQueueTimeout,
+	}
 
 	for _, t := range domainConfig.Tools {
 		cfg.Tools = append(cfg.Tools, mcpserver.ToolRef{
-			ID:           t.ID,
-			AppID:        t.AppID,
-			InputSchema:  t.Schema.Input,
-			OutputSchema: t.Schema.Output,
+			ID:             t.ID,
+			AppID:          t.AppID,
+			InputSchema:    t.Schema.Input,
+			OutputSchema:   t.Schema.Output,
+			IdempotentHint: t.IdempotentHint,
+			ReadOnlyHint:   t.ReadOnlyHint,
+			CacheTTL:       t.CacheTTL,
+			Middlewares:    convertMCPToolMiddlewares(t.Middlewares),
+
+			SkipOutputValidation: t.SkipOutputValidation,
 		})
 	}
 
@@ -128,7 +191,30 @@ func convertMCPConfig(id string, domainConfig *configMCP.App) (*mcpserver.Config
 	return cfg, nil
 }
 
-// convertCalculationConfig converts domain calculation config to calculation DTO.
+// convertMCPToolMiddlewares converts the domain middlewares of an MCP tool to
+// the runtime middlewares, keeping their order
+func convertMCPToolMiddlewares(domainMiddlewares []configMCP.ToolMiddleware) []mcpserver.ToolMiddleware {
+	var middlewares []mcpserver.ToolMiddleware
+	for _, mw := range domainMiddlewares {
+		switch mw := mw.(type) {
+		case *configMCP.MiddlewareLogging:
+			middlewares = append(middlewares, mcpserver.ToolLogging{OmitArguments: mw.OmitArguments})
+		case *configMCP.MiddlewareRateLimiting:
+			middlewares = append(middlewares, mcpserver.ToolRateLimiting{
+				CallsPerSecond: mw.CallsPerSecond,
+				Burst:          mw.EffectiveBurst(),
+			})
+		case *configMCP.MiddlewareAuthentication:
+			middlewares = append(middlewares, mcpserver.ToolAuthentication{
+				Subjects: slices.Clone(mw.Subjects),
+			})
+		}
+	}
+	return middlewares
+}
+
+// convertCalculationConfig converts domain calculation config to calculation
+// DTO, with the constants of the app's static data.
 func convertCalculationConfig(
 	id string,
 	domainConfig *configCalculation.App,
@@ -137,10 +223,16 @@ func convertCalculationConfig(
 		return nil, fmt.Errorf("failed to convert calculation config: %w", ErrConfigNil)
 	}
 
-	return &calculation.Config{ID: id}, nil
+	constants, err := domainConfig.Constants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert calculation app %s: %w", id, err)
+	}
+
+	return &calculation.Config{ID: id, Constants: constants}, nil
 }
 
-// convertFileReadConfig converts domain fileread config to fileread DTO.
+// convertFileReadConfig converts domain fileread config to fileread DTO, with
+// the base directory set by the app's static data or its default.
 func convertFileReadConfig(
 	id string,
 	domainConfig *configFileRead.App,
@@ -151,13 +243,138 @@ func convertFileReadConfig(
 
 	return &fileread.Config{
 		ID:                    id,
-		BaseDirectory:         domainConfig.BaseDirectory,
+		BaseDirectory:         domainConfig.EffectiveBaseDirectory(),
 		AllowExternalSymlinks: domainConfig.AllowExternalSymlinks,
 	}, nil
 }
 
-// convertAndCreateApps collects apps from domain config, converts them to DTOs, and creates instances
-func convertAndCreateApps(cfg *config.Config) (*serverApps.AppInstances, error) {
+// convertOpenAPIConfig converts domain OpenAPI config to OpenAPI DTO.
+func convertOpenAPIConfig(id string, domainConfig *configOpenAPI.App) (*openapi.Config, error) {
+	if domainConfig == nil {
+		return nil, fmt.Errorf("failed to convert openapi config: %w", ErrConfigNil)
+	}
+
+	return &openapi.Config{
+		ID:                 id,
+		Title:              domainConfig.Title,
+		Version:            domainConfig.Version,
+		SwaggerUI:          domainConfig.SwaggerUI,
+		SwaggerUIAssetsURL: domainConfig.SwaggerUIAssetsURL,
+	}, nil
+}
+
+// convertTemplateConfig converts domain template config to template DTO, with
+// the template named by the app's route static data or its default.
+func convertTemplateConfig(id string, domainConfig *configTemplate.App) (*template.Config, error) {
+	if domainConfig == nil {
+		return nil, fmt.Errorf("failed to convert template config: %w", ErrConfigNil)
+	}
+
+	// Templates are parsed by domain validation
+	templates := domainConfig.Templates()
+	if templates == nil {
+		return nil, fmt.Errorf("failed to convert template app %s: %w", id, ErrHTMLTemplatesNil)
+	}
+
+	name := domainConfig.TemplateName()
+	if name != "" && templates.Lookup(name) == nil {
+		return nil, fmt.Errorf("%w: template app %s has no template %q", ErrTemplateNotFound, id, name)
+	}
+
+	var staticData map[string]any
+	if domainConfig.StaticData != nil {
+		staticData = domainConfig.StaticData.Data
+	}
+
+	return &template.Config{
+		ID:         id,
+		Templates:  templates,
+		Template:   name,
+		StaticData: staticData,
+	}, nil
+}
+
+// openAPIRoutes describes the HTTP routes of the config for OpenAPI apps
+func openAPIRoutes(cfg *config.Config) []openapi.Route {
+	var result []openapi.Route
+	for _, endpoint := range cfg.Endpoints {
+		for _, route := range endpoint.Routes {
+			r := openapi.Route{
+				EndpointID: endpoint.ID,
+				ListenerID: endpoint.ListenerID,
+				AppID:      route.AppID,
+			}
+
+			switch cond := route.Condition.(type) {
+			case *conditions.HTTP:
+				r.Path = cond.PathPrefix
+				if cond.Method != "" {
+					r.Methods = []string{cond.Method}
+				}
+			case *conditions.HTTPRegex:
+				r.Path = cond.BasePath()
+				r.PathRegex = cond.Pattern
+				if cond.Method != "" {
+					r.Methods = []string{cond.Method}
+				}
+			default:
+				continue
+			}
+
+			// A method condition narrows a route that doesn't name a method
+			for _, extra := range route.Conditions {
+				if method, ok := extra.(*conditions.Method); ok && len(r.Methods) == 0 {
+					r.Methods = strings.Split(method.Value(), ",")
+				}
+			}
+
+			if cfg.Apps != nil {
+				if app, ok := cfg.Apps.FindByID(route.AppID); ok && app.Config != nil {
+					r.AppType = app.Config.Type()
+				}
+			}
+
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// convertCompositeConfig converts domain composite script config to composite DTO.
+func convertCompositeConfig(
+	id string,
+	domainConfig *configComposite.CompositeScript,
+) (*composite.Config, error) {
+	if domainConfig == nil {
+		return nil, fmt.Errorf("failed to convert composite config: %w", ErrConfigNil)
+	}
+
+	var staticData map[string]any
+	if domainConfig.StaticData != nil {
+		staticData = domainConfig.StaticData.Data
+	}
+
+	return &composite.Config{
+		ID:          id,
+		StepAppIDs:  domainConfig.ScriptAppIDs,
+		StaticData:  staticData,
+		StepTimeout: domainConfig.StepTimeout,
+		Logger:      slog.Default().With("app_type", "composite_script", "app_id", id),
+	}, nil
+}
+
+// AppFailure records an app that failed to initialize and was disabled, with
+// Config.IsolateFailures
+type AppFailure struct {
+	AppID string
+	Err   error
+}
+
+// convertAndCreateApps collects apps from domain config, converts them to
+// DTOs, and creates instances. An app that fails to initialize fails the
+// config, or with cfg.IsolateFailures is replaced by a serverApps.Unavailable
+// and returned in the failures, sorted by app ID.
+func convertAndCreateApps(cfg *config.Config) (*serverApps.AppInstances, []AppFailure, error) {
 	// First collect unique apps from routes (these have merged static data)
 	uniqueApps := make(map[string]apps.App)
 
@@ -167,7 +384,7 @@ func convertAndCreateApps(cfg *config.Config) (*serverApps.AppInstances, error)
 			if route.App != nil {
 				// Use the expanded app instance which has merged static data
 				if _, exists := uniqueApps[route.App.ID]; exists {
-					return nil, fmt.Errorf("%w in routes: %s", ErrDuplicateAppID, route.App.ID)
+					return nil, nil, fmt.Errorf("%w in routes: %s", ErrDuplicateAppID, route.App.ID)
 				}
 				uniqueApps[route.App.ID] = *route.App
 			}
@@ -178,7 +395,7 @@ func convertAndCreateApps(cfg *config.Config) (*serverApps.AppInstances, error)
 	if cfg.Apps != nil {
 		for app := range cfg.Apps.All() {
 			if _, exists := uniqueApps[app.ID]; exists {
-				return nil, fmt.Errorf("%w: %s", ErrDuplicateAppID, app.ID)
+				return nil, nil, fmt.Errorf("%w: %s", ErrDuplicateAppID, app.ID)
 			}
 			uniqueApps[app.ID] = app
 		}
@@ -186,55 +403,142 @@ func convertAndCreateApps(cfg *config.Config) (*serverApps.AppInstances, error)
 
 	// Convert domain apps to server apps using DTO pattern
 	var appInstances []serverApps.App
+	var failures []AppFailure
 	for _, domainApp := range uniqueApps {
-		serverApp, err := convertDomainToServerApp(domainApp.ID, domainApp.Config)
+		serverApp, err := convertDomainToServerApp(domainApp.ID, domainApp.Config, cfg.DefaultTimeout)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert app %s: %w", domainApp.ID, err)
+			err = fmt.Errorf("failed to convert app %s: %w", domainApp.ID, err)
+			if !cfg.IsolateFailures {
+				return nil, nil, err
+			}
+			failures = append(failures, AppFailure{AppID: domainApp.ID, Err: err})
+			serverApp = serverApps.NewUnavailable(domainApp.ID, err)
 		}
 		appInstances = append(appInstances, serverApp)
 	}
 
 	instances, err := serverApps.NewAppInstances(appInstances)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Cross-component validation: every MCP server's Tool/Prompt/Resource
-	// references must resolve to an app that implements the matching
-	// provider interface. Wire each MCP server's AppLookup once it passes.
-	if err := wireMCPServers(instances); err != nil {
-		return nil, err
+	// Cross-component validation: every composite app's steps must resolve
+	// to script apps, and every MCP server's Tool/Prompt/Resource references
+	// to an app that implements the matching provider interface. Composite
+	// apps are wired first, so an MCP server referencing a disabled composite
+	// app is disabled as well.
+	for _, wire := range []func(*serverApps.AppInstances) []AppFailure{wireCompositeApps, wireMCPServers} {
+		wireFailures := wire(instances)
+		if len(wireFailures) == 0 {
+			continue
+		}
+		if !cfg.IsolateFailures {
+			errs := make([]error, 0, len(wireFailures))
+			for _, failure := range wireFailures {
+				errs = append(errs, failure.Err)
+			}
+			return nil, nil, errors.Join(errs...)
+		}
+		failures = append(failures, wireFailures...)
+		if instances, err = disableApps(instances, wireFailures); err != nil {
+			return nil, nil, err
+		}
 	}
 
-	return instances, nil
+	// OpenAPI apps document the routes of this config
+	wireOpenAPIApps(instances, openAPIRoutes(cfg))
+
+	slices.SortFunc(failures, func(a, b AppFailure) int {
+		return strings.Compare(a.AppID, b.AppID)
+	})
+	return instances, failures, nil
+}
+
+// disableApps returns instances with the apps of failures replaced by
+// serverApps.Unavailable
+func disableApps(instances *serverApps.AppInstances, failures []AppFailure) (*serverApps.AppInstances, error) {
+	disabled := make(map[string]error, len(failures))
+	for _, failure := range failures {
+		disabled[failure.AppID] = failure.Err
+	}
+
+	var result []serverApps.App
+	for app := range instances.All() {
+		if err, ok := disabled[app.String()]; ok {
+			app = serverApps.NewUnavailable(app.String(), err)
+		}
+		result = append(result, app)
+	}
+	return serverApps.NewAppInstances(result)
 }
 
 // wireMCPServers validates each *mcpserver.App in the registry against the
 // other apps and, on success, calls Build to install the AppLookup. Per
 // transaction/CLAUDE.md, cross-component reference checks live here rather
-// than in domain validation.
-func wireMCPServers(instances *serverApps.AppInstances) error {
+// than in domain validation. Servers that fail are returned.
+func wireMCPServers(instances *serverApps.AppInstances) []AppFailure {
 	lookup := mcpserver.AppLookup(instances.GetApp)
 
-	var errs []error
+	var failures []AppFailure
 	for app := range instances.All() {
 		mcpApp, ok := app.(*mcpserver.App)
 		if !ok {
 			continue
 		}
 		if err := mcpApp.ValidateRefs(lookup); err != nil {
-			errs = append(errs, fmt.Errorf("mcp server %q: %w", mcpApp.String(), err))
+			failures = append(failures, AppFailure{
+				AppID: mcpApp.String(),
+				Err:   fmt.Errorf("mcp server %q: %w", mcpApp.String(), err),
+			})
 			continue
 		}
 		if err := mcpApp.Build(lookup); err != nil {
-			errs = append(errs, fmt.Errorf("mcp server %q: %w", mcpApp.String(), err))
+			failures = append(failures, AppFailure{
+				AppID: mcpApp.String(),
+				Err:   fmt.Errorf("mcp server %q: %w", mcpApp.String(), err),
+			})
+		}
+	}
+	return failures
+}
+
+// wireCompositeApps resolves the steps of each *composite.App in the registry
+// against the other apps. Apps that fail are returned.
+func wireCompositeApps(instances *serverApps.AppInstances) []AppFailure {
+	lookup := composite.AppLookup(instances.GetApp)
+
+	var failures []AppFailure
+	for app := range instances.All() {
+		compositeApp, ok := app.(*composite.App)
+		if !ok {
+			continue
+		}
+		if err := compositeApp.Build(lookup); err != nil {
+			failures = append(failures, AppFailure{
+				AppID: compositeApp.String(),
+				Err:   fmt.Errorf("composite app %q: %w", compositeApp.String(), err),
+			})
+		}
+	}
+	return failures
+}
+
+// wireOpenAPIApps sets the routes documented by each *openapi.App in the
+// registry.
+func wireOpenAPIApps(instances *serverApps.AppInstances, routes []openapi.Route) {
+	for app := range instances.All() {
+		if openAPIApp, ok := app.(*openapi.App); ok {
+			openAPIApp.SetRoutes(routes)
 		}
 	}
-	return errors.Join(errs...)
 }
 
 // convertDomainToServerApp converts a domain app config to a server app instance
-func convertDomainToServerApp(id string, domainConfig apps.AppConfig) (serverApps.App, error) {
+func convertDomainToServerApp(
+	id string,
+	domainConfig apps.AppConfig,
+	defaultTimeout time.Duration,
+) (serverApps.App, error) {
 	switch appConfig := domainConfig.(type) {
 	case *configEcho.EchoApp:
 		dto, err := convertEchoConfig(id, appConfig)
@@ -244,7 +548,7 @@ func convertDomainToServerApp(id string, domainConfig apps.AppConfig) (serverApp
 		return echo.New(dto), nil
 
 	case *configScripts.AppScript:
-		dto, err := convertScriptConfig(id, appConfig)
+		dto, err := convertScriptConfig(id, appConfig, defaultTimeout)
 		if err != nil {
 			return nil, err
 		}
@@ -264,6 +568,13 @@ func convertDomainToServerApp(id string, domainConfig apps.AppConfig) (serverApp
 		}
 		return calculation.New(dto), nil
 
+	case *configOpenAPI.App:
+		dto, err := convertOpenAPIConfig(id, appConfig)
+		if err != nil {
+			return nil, err
+		}
+		return openapi.New(dto), nil
+
 	case *configFileRead.App:
 		dto, err := convertFileReadConfig(id, appConfig)
 		if err != nil {
@@ -271,8 +582,19 @@ func convertDomainToServerApp(id string, domainConfig apps.AppConfig) (serverApp
 		}
 		return fileread.New(dto), nil
 
+	case *configTemplate.App:
+		dto, err := convertTemplateConfig(id, appConfig)
+		if err != nil {
+			return nil, err
+		}
+		return template.New(dto), nil
+
 	case *configComposite.CompositeScript:
-		return nil, fmt.Errorf("failed to convert composite app %s: %w", id, ErrCompositeNotSupported)
+		dto, err := convertCompositeConfig(id, appConfig)
+		if err != nil {
+			return nil, err
+		}
+		return composite.New(dto), nil
 
 	default:
 		return nil, fmt.Errorf("%w: %T", ErrUnknownAppType, domainConfig)
@@ -2,6 +2,9 @@ package transaction
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -12,16 +15,22 @@ import (
 	configEcho "github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
 	configFileRead "github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
 	configMCP "github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
+	configOpenAPI "github.com/atlanticdynamic/firelynx/internal/config/apps/openapi"
 	configScripts "github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	configTemplate "github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
 	serverCalculation "github.com/atlanticdynamic/firelynx/internal/server/apps/calculation"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/composite"
 	serverFileRead "github.com/atlanticdynamic/firelynx/internal/server/apps/fileread"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/mcpserver"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/openapi"
+	serverTemplate "github.com/atlanticdynamic/firelynx/internal/server/apps/template"
 	"github.com/robbyt/go-polyscript/platform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -102,6 +111,17 @@ func TestConvertEchoConfig(t *testing.T) {
 			wantID:   "test-app",
 			wantResp: "custom response",
 		},
+		{
+			name: "representations",
+			id:   "test-app",
+			config: &configEcho.EchoApp{Representations: map[string]string{
+				"text/plain":       "ok",
+				"application/json": `{"ok":true}`,
+			}},
+			wantErr:  false,
+			wantID:   "test-app",
+			wantResp: "test-app",
+		},
 	}
 
 	for _, tt := range tests {
@@ -116,11 +136,31 @@ func TestConvertEchoConfig(t *testing.T) {
 				require.NotNil(t, result)
 				assert.Equal(t, tt.wantID, result.ID)
 				assert.Equal(t, tt.wantResp, result.Response)
+				assert.Equal(t, tt.config.Representations, result.Representations)
+				assert.Equal(t, tt.config.MediaTypes(), result.MediaTypes)
 			}
 		})
 	}
 }
 
+func TestConvertEchoConfig_Template(t *testing.T) {
+	domainConfig := &configEcho.EchoApp{
+		ID:              "template-app",
+		Response:        "{{.Path}}",
+		Representations: map[string]string{"application/json": `{"path":"{{.Path}}"}`},
+		Template:        true,
+	}
+
+	_, err := convertEchoConfig("template-app", domainConfig)
+	require.ErrorIs(t, err, ErrEchoTemplateNil)
+
+	require.NoError(t, domainConfig.Validate())
+	result, err := convertEchoConfig("template-app", domainConfig)
+	require.NoError(t, err)
+	assert.NotNil(t, result.ResponseTemplate)
+	assert.Contains(t, result.RepresentationTemplates, "application/json")
+}
+
 func TestConvertScriptConfig(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -177,6 +217,7 @@ func TestConvertScriptConfig(t *testing.T) {
 				mockEvaluator := &mockEvaluatorAdapter{}
 				mockEvaluator.On("GetCompiledEvaluator").Return(mockPlatformEvaluator, nil)
 				mockEvaluator.On("GetTimeout").Return(testTimeout)
+				mockEvaluator.On("Type").Return(evaluators.EvaluatorTypeRisor)
 				cfg.Evaluator = mockEvaluator
 			},
 			wantErr: false,
@@ -190,6 +231,7 @@ func TestConvertScriptConfig(t *testing.T) {
 				mockEvaluator := &mockEvaluatorAdapter{}
 				mockEvaluator.On("GetCompiledEvaluator").Return(mockPlatformEvaluator, nil)
 				mockEvaluator.On("GetTimeout").Return(testTimeout)
+				mockEvaluator.On("Type").Return(evaluators.EvaluatorTypeRisor)
 				cfg.Evaluator = mockEvaluator
 				cfg.StaticData = &staticdata.StaticData{
 					Data: map[string]any{
@@ -208,7 +250,7 @@ func TestConvertScriptConfig(t *testing.T) {
 				tt.setupMock(tt.config)
 			}
 
-			result, err := convertScriptConfig(tt.id, tt.config)
+			result, err := convertScriptConfig(tt.id, tt.config, 0)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -223,6 +265,8 @@ func TestConvertScriptConfig(t *testing.T) {
 				assert.NotNil(t, result.CompiledEvaluator)
 				assert.NotNil(t, result.Logger)
 				assert.Equal(t, testTimeout, result.ExecTimeout)
+				assert.Equal(t, "risor", result.Evaluator)
+				assert.True(t, result.Streaming)
 
 				// Check static data
 				if tt.config.StaticData != nil {
@@ -235,6 +279,41 @@ func TestConvertScriptConfig(t *testing.T) {
 	}
 }
 
+func TestConvertScriptConfig_DefaultTimeout(t *testing.T) {
+	const serverDefault = 7 * time.Second
+
+	tests := []struct {
+		name        string
+		evaluator   *evaluators.RisorEvaluator
+		wantTimeout time.Duration
+	}{
+		{
+			name:        "evaluator without timeout inherits server default",
+			evaluator:   &evaluators.RisorEvaluator{Code: `{"ok": true}`},
+			wantTimeout: serverDefault,
+		},
+		{
+			name: "evaluator with explicit timeout keeps it",
+			evaluator: &evaluators.RisorEvaluator{
+				Code:    `{"ok": true}`,
+				Timeout: 2 * time.Second,
+			},
+			wantTimeout: 2 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.evaluator.Validate())
+			cfg := &configScripts.AppScript{Evaluator: tt.evaluator}
+
+			result, err := convertScriptConfig("timeout-test", cfg, serverDefault)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantTimeout, result.ExecTimeout)
+		})
+	}
+}
+
 func TestConvertMCPConfig(t *testing.T) {
 	t.Run("nil config", func(t *testing.T) {
 		result, err := convertMCPConfig("test-id", nil)
@@ -284,6 +363,7 @@ func TestConvertMCPConfig(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.Equal(t, "mcp", result.ID)
+		assert.Equal(t, "mcp", result.Name)
 
 		require.Len(t, result.Tools, 2)
 		assert.Equal(t, "calculate", result.Tools[0].ID)
@@ -300,6 +380,46 @@ func TestConvertMCPConfig(t *testing.T) {
 		assert.Equal(t, "file-reader", result.Resources[0].AppID)
 		assert.Equal(t, "file://{path}", result.Resources[0].URITemplate)
 	})
+
+	t.Run("converts tool middlewares in order", func(t *testing.T) {
+		domain := configMCP.NewApp("mcp")
+		domain.Tools = []configMCP.Tool{{
+			AppID: "script",
+			Middlewares: []configMCP.ToolMiddleware{
+				&configMCP.MiddlewareLogging{OmitArguments: true},
+				&configMCP.MiddlewareRateLimiting{CallsPerSecond: 2.5},
+				&configMCP.MiddlewareAuthentication{Subjects: []string{"alice"}},
+			},
+		}}
+
+		result, err := convertMCPConfig("mcp", domain)
+		require.NoError(t, err)
+		require.Len(t, result.Tools, 1)
+		assert.Equal(t, []mcpserver.ToolMiddleware{
+			mcpserver.ToolLogging{OmitArguments: true},
+			mcpserver.ToolRateLimiting{CallsPerSecond: 2.5, Burst: 3},
+			mcpserver.ToolAuthentication{Subjects: []string{"alice"}},
+		}, result.Tools[0].Middlewares)
+		assert.NotNil(t, result.Logger)
+	})
+
+	t.Run("tool call concurrency limit", func(t *testing.T) {
+		domain := configMCP.NewApp("mcp")
+		domain.MaxConcurrentToolCalls = 4
+		domain.ToolCallQueueTimeout = 2 * time.Second
+
+		result, err := convertMCPConfig("mcp", domain)
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.MaxConcurrentToolCalls)
+		assert.Equal(t, 2*time.Second, result.ToolCallQueueTimeout)
+	})
+
+	t.Run("route instances keep the app ID as server name", func(t *testing.T) {
+		result, err := convertMCPConfig("mcp#0:1", configMCP.NewApp("mcp"))
+		require.NoError(t, err)
+		assert.Equal(t, "mcp#0:1", result.ID)
+		assert.Equal(t, "mcp", result.Name)
+	})
 }
 
 func TestConvertTypedAppConfigs(t *testing.T) {
@@ -310,6 +430,25 @@ func TestConvertTypedAppConfigs(t *testing.T) {
 		assert.Equal(t, "calc", result.ID)
 	})
 
+	t.Run("calculation constants", func(t *testing.T) {
+		domainConfig := configCalculation.New("calc")
+		domainConfig.StaticData = &staticdata.StaticData{
+			Data: map[string]any{configCalculation.ConstantsDataKey: map[string]any{"vat": 0.2}},
+		}
+		result, err := convertCalculationConfig("calc", domainConfig)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"vat": 0.2}, result.Constants)
+	})
+
+	t.Run("calculation invalid constants", func(t *testing.T) {
+		domainConfig := configCalculation.New("calc")
+		domainConfig.StaticData = &staticdata.StaticData{
+			Data: map[string]any{configCalculation.ConstantsDataKey: "vat"},
+		}
+		_, err := convertCalculationConfig("calc", domainConfig)
+		require.Error(t, err)
+	})
+
 	t.Run("calculation nil config", func(t *testing.T) {
 		result, err := convertCalculationConfig("calc", nil)
 		require.Error(t, err)
@@ -328,12 +467,93 @@ func TestConvertTypedAppConfigs(t *testing.T) {
 		assert.Equal(t, "/tmp/files", result.BaseDirectory)
 	})
 
+	t.Run("fileread base directory from static data", func(t *testing.T) {
+		domainConfig := &configFileRead.App{
+			ID:            "files",
+			BaseDirectory: "/tmp/files",
+			StaticData: &staticdata.StaticData{
+				Data: map[string]any{configFileRead.BaseDirectoryDataKey: "/tmp/docs"},
+			},
+		}
+		result, err := convertFileReadConfig("files#0:1", domainConfig)
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/docs", result.BaseDirectory)
+	})
+
 	t.Run("fileread nil config", func(t *testing.T) {
 		result, err := convertFileReadConfig("files", nil)
 		require.Error(t, err)
 		require.ErrorIs(t, err, ErrConfigNil)
 		assert.Nil(t, result)
 	})
+
+	t.Run("openapi", func(t *testing.T) {
+		domainConfig := configOpenAPI.New("docs")
+		domainConfig.SwaggerUI = true
+
+		result, err := convertOpenAPIConfig("docs", domainConfig)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, "docs", result.ID)
+		assert.Equal(t, configOpenAPI.DefaultTitle, result.Title)
+		assert.Equal(t, configOpenAPI.DefaultVersion, result.Version)
+		assert.True(t, result.SwaggerUI)
+		assert.Equal(t, configOpenAPI.DefaultSwaggerUIAssetsURL, result.SwaggerUIAssetsURL)
+	})
+
+	t.Run("openapi nil config", func(t *testing.T) {
+		result, err := convertOpenAPIConfig("docs", nil)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrConfigNil)
+		assert.Nil(t, result)
+	})
+
+	t.Run("template", func(t *testing.T) {
+		domainConfig := configTemplate.New("pages")
+		domainConfig.Inline = map[string]string{"home": "home", "users": "users"}
+		domainConfig.Template = "home"
+		require.NoError(t, domainConfig.Validate())
+
+		route := domainConfig.Copy(&staticdata.StaticData{
+			Data: map[string]any{configTemplate.TemplateDataKey: "users", "title": "Users"},
+		})
+		result, err := convertTemplateConfig("pages#0:0", route)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, "pages#0:0", result.ID)
+		assert.Equal(t, "users", result.Template)
+		assert.Same(t, domainConfig.Templates(), result.Templates)
+		assert.Equal(t, "Users", result.StaticData["title"])
+	})
+
+	t.Run("template not parsed", func(t *testing.T) {
+		domainConfig := configTemplate.New("pages")
+		domainConfig.Inline = map[string]string{"home": "home"}
+
+		result, err := convertTemplateConfig("pages", domainConfig)
+		require.ErrorIs(t, err, ErrHTMLTemplatesNil)
+		assert.Nil(t, result)
+	})
+
+	t.Run("template not defined", func(t *testing.T) {
+		domainConfig := configTemplate.New("pages")
+		domainConfig.Inline = map[string]string{"home": "home"}
+		require.NoError(t, domainConfig.Validate())
+
+		route := domainConfig.Copy(&staticdata.StaticData{
+			Data: map[string]any{configTemplate.TemplateDataKey: "missing"},
+		})
+		result, err := convertTemplateConfig("pages#0:0", route)
+		require.ErrorIs(t, err, ErrTemplateNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("template nil config", func(t *testing.T) {
+		result, err := convertTemplateConfig("pages", nil)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrConfigNil)
+		assert.Nil(t, result)
+	})
 }
 
 func TestConvertDomainToServerApp(t *testing.T) {
@@ -369,6 +589,7 @@ func TestConvertDomainToServerApp(t *testing.T) {
 				mockEvaluator := &mockEvaluatorAdapter{}
 				mockEvaluator.On("GetCompiledEvaluator").Return(mockPlatformEvaluator, nil)
 				mockEvaluator.On("GetTimeout").Return(testTimeout)
+				mockEvaluator.On("Type").Return(evaluators.EvaluatorTypeRisor)
 				scriptConfig.Evaluator = mockEvaluator
 				return scriptConfig
 			},
@@ -411,11 +632,14 @@ func TestConvertDomainToServerApp(t *testing.T) {
 			wantAppStr: "mcp-test",
 		},
 		{
-			name:        "composite app not supported",
-			id:          "composite-test",
-			config:      &configComposite.CompositeScript{},
-			wantErr:     true,
-			expectedErr: ErrCompositeNotSupported,
+			name: "composite app",
+			id:   "composite-test",
+			config: &configComposite.CompositeScript{
+				ScriptAppIDs: []string{"auth-check", "respond"},
+				StepTimeout:  time.Second,
+			},
+			wantErr:    false,
+			wantAppStr: "composite-test",
 		},
 		{
 			name:        "unknown app type",
@@ -433,7 +657,7 @@ func TestConvertDomainToServerApp(t *testing.T) {
 				config = tt.setupMock()
 			}
 
-			result, err := convertDomainToServerApp(tt.id, config)
+			result, err := convertDomainToServerApp(tt.id, config, 0)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -451,7 +675,7 @@ func TestConvertDomainToServerApp(t *testing.T) {
 }
 
 func TestConvertDomainToServerApp_TypedAppInstances(t *testing.T) {
-	calcApp, err := convertDomainToServerApp("calc", &configCalculation.App{ID: "calc"})
+	calcApp, err := convertDomainToServerApp("calc", &configCalculation.App{ID: "calc"}, 0)
 	require.NoError(t, err)
 	_, ok := calcApp.(*serverCalculation.App)
 	assert.True(t, ok)
@@ -459,10 +683,20 @@ func TestConvertDomainToServerApp_TypedAppInstances(t *testing.T) {
 	fileApp, err := convertDomainToServerApp(
 		"files",
 		&configFileRead.App{ID: "files", BaseDirectory: "/tmp/files"},
+		0,
 	)
 	require.NoError(t, err)
 	_, ok = fileApp.(*serverFileRead.App)
 	assert.True(t, ok)
+
+	templateConfig := configTemplate.New("pages")
+	templateConfig.Inline = map[string]string{"home": "home"}
+	templateConfig.Template = "home"
+	require.NoError(t, templateConfig.Validate())
+	templateApp, err := convertDomainToServerApp("pages", templateConfig, 0)
+	require.NoError(t, err)
+	_, ok = templateApp.(*serverTemplate.App)
+	assert.True(t, ok)
 }
 
 func TestConvertAndCreateApps_DuplicateIDs(t *testing.T) {
@@ -522,7 +756,7 @@ func TestConvertAndCreateApps_DuplicateIDs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := convertAndCreateApps(tt.config)
+			result, _, err := convertAndCreateApps(tt.config)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -592,7 +826,7 @@ func TestConvertAndCreateApps_MCPCrossRefValidation(t *testing.T) {
 				Apps: createAppCollection(t, tt.apps),
 			}
 
-			result, err := convertAndCreateApps(cfg)
+			result, _, err := convertAndCreateApps(cfg)
 
 			if tt.expectedErr != nil {
 				require.Error(t, err)
@@ -609,6 +843,174 @@ func TestConvertAndCreateApps_MCPCrossRefValidation(t *testing.T) {
 	}
 }
 
+func TestConvertAndCreateApps_CompositeStepValidation(t *testing.T) {
+	scriptApp := func(id string) apps.App {
+		mockEvaluator := &mockEvaluatorAdapter{}
+		mockEvaluator.On("GetCompiledEvaluator").Return(&mockPlatformEvaluator{}, nil)
+		mockEvaluator.On("GetTimeout").Return(testTimeout)
+		mockEvaluator.On("Type").Return(evaluators.EvaluatorTypeRisor)
+		return apps.App{ID: id, Config: &configScripts.AppScript{Evaluator: mockEvaluator}}
+	}
+
+	tests := []struct {
+		name        string
+		apps        []apps.App
+		expectedErr error
+		errSubstr   string
+	}{
+		{
+			name: "steps referencing script apps succeed",
+			apps: []apps.App{
+				scriptApp("auth-check"),
+				scriptApp("respond"),
+				{ID: "chain", Config: &configComposite.CompositeScript{
+					ScriptAppIDs: []string{"auth-check", "respond"},
+				}},
+			},
+		},
+		{
+			name: "step referencing a missing app fails with ErrUnknownStepApp",
+			apps: []apps.App{
+				{ID: "chain", Config: &configComposite.CompositeScript{
+					ScriptAppIDs: []string{"ghost"},
+				}},
+			},
+			expectedErr: composite.ErrUnknownStepApp,
+			errSubstr:   "ghost",
+		},
+		{
+			name: "step referencing a non-script app fails with ErrAppNotStep",
+			apps: []apps.App{
+				{ID: "echo-app", Config: &configEcho.EchoApp{Response: "hi"}},
+				{ID: "chain", Config: &configComposite.CompositeScript{
+					ScriptAppIDs: []string{"echo-app"},
+				}},
+			},
+			expectedErr: composite.ErrAppNotStep,
+			errSubstr:   `composite app "chain"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Apps: createAppCollection(t, tt.apps),
+			}
+
+			result, _, err := convertAndCreateApps(cfg)
+
+			if tt.expectedErr != nil {
+				require.ErrorIs(t, err, tt.expectedErr)
+				assert.Contains(t, err.Error(), tt.errSubstr)
+				assert.Nil(t, result)
+				return
+			}
+			require.NoError(t, err)
+			app, ok := result.GetApp("chain")
+			require.True(t, ok)
+			assert.Equal(t, []string{"auth-check", "respond"}, app.(*composite.App).StepAppIDs())
+		})
+	}
+}
+
+func TestConvertAndCreateApps_IsolateFailures(t *testing.T) {
+	// The script app has no evaluator, and the composite app using it as a
+	// step can't be wired once it's disabled
+	newConfig := func(isolate bool) *config.Config {
+		return &config.Config{
+			IsolateFailures: isolate,
+			Apps: createAppCollection(t, []apps.App{
+				{ID: "broken", Config: &configScripts.AppScript{}},
+				{ID: "chain", Config: &configComposite.CompositeScript{ScriptAppIDs: []string{"broken"}}},
+				{ID: "echo-app", Config: &configEcho.EchoApp{Response: "hi"}},
+			}),
+		}
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		result, failures, err := convertAndCreateApps(newConfig(false))
+		require.ErrorIs(t, err, ErrEvaluatorNil)
+		assert.Nil(t, result)
+		assert.Nil(t, failures)
+	})
+
+	t.Run("isolated", func(t *testing.T) {
+		result, failures, err := convertAndCreateApps(newConfig(true))
+		require.NoError(t, err)
+
+		require.Len(t, failures, 2)
+		assert.Equal(t, "broken", failures[0].AppID)
+		require.ErrorIs(t, failures[0].Err, ErrEvaluatorNil)
+		assert.Equal(t, "chain", failures[1].AppID)
+		require.ErrorIs(t, failures[1].Err, composite.ErrAppNotStep)
+
+		for _, id := range []string{"broken", "chain"} {
+			app, ok := result.GetApp(id)
+			require.True(t, ok)
+			err := app.HandleHTTP(t.Context(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			require.ErrorIs(t, err, serverApps.ErrAppUnavailable)
+		}
+		app, ok := result.GetApp("echo-app")
+		require.True(t, ok)
+		require.NoError(t, app.HandleHTTP(t.Context(), httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil)))
+	})
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	cfg := &config.Config{
+		Apps: createAppCollection(t, []apps.App{
+			{ID: "echo-app", Config: &configEcho.EchoApp{Response: "hi"}},
+			{ID: "docs", Config: configOpenAPI.New("docs")},
+		}),
+		Endpoints: endpoints.NewEndpointCollection(endpoints.Endpoint{
+			ID:         "api",
+			ListenerID: "http",
+			Routes: routes.RouteCollection{
+				{AppID: "echo-app", Condition: conditions.NewHTTP("/echo", "GET")},
+				{
+					AppID:      "echo-app",
+					Condition:  conditions.NewHTTPRegex("^/users/[0-9]+$", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("post", "GET")},
+				},
+				{AppID: "docs", Condition: conditions.NewHTTP("/docs", "")},
+			},
+		}),
+	}
+
+	assert.Equal(t, []openapi.Route{
+		{EndpointID: "api", ListenerID: "http", Path: "/echo", Methods: []string{"GET"}, AppID: "echo-app", AppType: "echo"},
+		{
+			EndpointID: "api",
+			ListenerID: "http",
+			Path:       "/users/",
+			PathRegex:  "^/users/[0-9]+$",
+			Methods:    []string{"GET", "POST"},
+			AppID:      "echo-app",
+			AppType:    "echo",
+		},
+		{EndpointID: "api", ListenerID: "http", Path: "/docs", AppID: "docs", AppType: "openapi"},
+	}, openAPIRoutes(cfg))
+
+	t.Run("openapi apps are wired with the routes", func(t *testing.T) {
+		result, _, err := convertAndCreateApps(cfg)
+		require.NoError(t, err)
+
+		app, ok := result.GetApp("docs")
+		require.True(t, ok)
+		require.IsType(t, &openapi.App{}, app)
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+
+		var doc openapi.Document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Contains(t, doc.Paths, "/echo")
+		assert.Contains(t, doc.Paths, "/users/")
+		assert.Contains(t, doc.Paths, "/docs")
+	})
+}
+
 // unknownAppConfig is a test helper for testing unknown app types
 type unknownAppConfig struct{}
 
@@ -0,0 +1,163 @@
+package transaction
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// contentHashPrefix names the algorithm of a content hash
+const contentHashPrefix = "sha256:"
+
+// MaxChangeReasonLength is the maximum length of a change reason, in
+// characters
+const MaxChangeReasonLength = 256
+
+var (
+	// ErrAuditUnsigned indicates an audit record without a signature
+	ErrAuditUnsigned = errors.New("audit record is not signed")
+
+	// ErrChangeReasonTooLong indicates a change reason longer than
+	// MaxChangeReasonLength
+	ErrChangeReasonTooLong = errors.New("change reason is too long")
+
+	// ErrAuditSignatureMismatch indicates an audit record whose signature
+	// doesn't match its fields
+	ErrAuditSignatureMismatch = errors.New("audit signature does not match")
+)
+
+// Audit records who submitted a transaction's configuration and why. The
+// content hash covers the configuration as submitted, before environment
+// variables are interpolated during validation. With a signing key, the
+// signature covers the transaction ID and the other fields.
+type Audit struct {
+	// Submitter identifies the authenticated client, empty without authentication
+	Submitter string
+
+	// ChangeReason is the reason for the change given by the client
+	ChangeReason string
+
+	// ContentHash is the ContentHash of the configuration
+	ContentHash string
+
+	// Signature is the hex HMAC-SHA256 of the transaction ID and the fields
+	// above, empty when unsigned
+	Signature string
+}
+
+// Option configures a ConfigTransaction when it is created
+type Option func(tx *ConfigTransaction) error
+
+// WithAudit records the submitter and reason of the change, and the content
+// hash of the transaction's config. When signingKey is set, the record is
+// signed with it. A reason longer than MaxChangeReasonLength is rejected with
+// ErrChangeReasonTooLong.
+func WithAudit(submitter, changeReason string, signingKey []byte) Option {
+	return func(tx *ConfigTransaction) error {
+		if n := utf8.RuneCountInString(changeReason); n > MaxChangeReasonLength {
+			return fmt.Errorf("%w: %d characters, the maximum is %d",
+				ErrChangeReasonTooLong, n, MaxChangeReasonLength)
+		}
+		hash, err := ContentHash(tx.domainConfig)
+		if err != nil {
+			return err
+		}
+		tx.Audit = &Audit{
+			Submitter:    submitter,
+			ChangeReason: changeReason,
+			ContentHash:  hash,
+		}
+		if len(signingKey) > 0 {
+			tx.Audit.Signature = tx.Audit.sign(tx.ID, signingKey)
+		}
+		return nil
+	}
+}
+
+// GetChangeReason returns the reason recorded for the transaction's config,
+// empty without an audit record
+func (tx *ConfigTransaction) GetChangeReason() string {
+	if tx.Audit == nil {
+		return ""
+	}
+	return tx.Audit.ChangeReason
+}
+
+// ContentHash returns the SHA-256 of the canonical form of cfg as
+// "sha256:<hex>". The canonical form is the protobuf JSON mapping of
+// cfg.ToProto(), with defaults filled in and field names as in the .proto
+// files, re-encoded with object keys sorted and no whitespace. Unlike the
+// binary protobuf encoding, which the protobuf library doesn't guarantee to
+// be stable across versions, this form only changes with the config schema,
+// so a copy of a submitted config can be checked against an audit record by
+// another build: convert it with config.NewFromProto and hash it.
+func ContentHash(cfg *config.Config) (string, error) {
+	if cfg == nil {
+		return "", ErrNilConfig
+	}
+	data, err := canonicalJSON(cfg.ToProto())
+	if err != nil {
+		return "", fmt.Errorf("failed to encode config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return contentHashPrefix + hex.EncodeToString(sum[:]), nil
+}
+
+// canonicalJSON encodes msg with the protobuf JSON mapping, then decodes and
+// re-encodes it, since protojson output isn't stable: it varies whitespace
+// on purpose. encoding/json sorts map keys, and numbers are kept as written.
+func canonicalJSON(msg proto.Message) ([]byte, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// Verify checks the signature of the record of transaction txID against
+// signingKey.
+func (a *Audit) Verify(txID uuid.UUID, signingKey []byte) error {
+	if a.Signature == "" {
+		return ErrAuditUnsigned
+	}
+	expected, err := hex.DecodeString(a.sign(txID, signingKey))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(a.Signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return ErrAuditSignatureMismatch
+	}
+	return nil
+}
+
+// sign returns the hex HMAC-SHA256 of the transaction ID and the fields. The
+// fields are length-prefixed, so moving text from one to another changes the
+// signature.
+func (a *Audit) sign(txID uuid.UUID, signingKey []byte) string {
+	var b strings.Builder
+	for _, field := range []string{txID.String(), a.Submitter, a.ChangeReason, a.ContentHash} {
+		fmt.Fprintf(&b, "%d:%s\n", len(field), field)
+	}
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write([]byte(b.String()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
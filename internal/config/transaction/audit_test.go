@@ -0,0 +1,175 @@
+package transaction
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestContentHash(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(t *testing.T) *config.Config {
+		t.Helper()
+		cfg, err := config.NewFromProto(&pb.ServerConfig{
+			Listeners: []*pb.Listener{{
+				Id:      proto.String("http"),
+				Address: proto.String(":8080"),
+				Type:    pb.Listener_TYPE_HTTP.Enum(),
+			}},
+		})
+		require.NoError(t, err)
+		return cfg
+	}
+
+	t.Run("same config hashes the same", func(t *testing.T) {
+		first, err := ContentHash(newConfig(t))
+		require.NoError(t, err)
+		second, err := ContentHash(newConfig(t))
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, first)
+	})
+
+	t.Run("a round trip through protobuf keeps the hash", func(t *testing.T) {
+		cfg := newConfig(t)
+		hash, err := ContentHash(cfg)
+		require.NoError(t, err)
+
+		copied, err := config.NewFromProto(cfg.ToProto())
+		require.NoError(t, err)
+		copiedHash, err := ContentHash(copied)
+		require.NoError(t, err)
+		assert.Equal(t, hash, copiedHash)
+	})
+
+	t.Run("changed config hashes differently", func(t *testing.T) {
+		cfg := newConfig(t)
+		hash, err := ContentHash(cfg)
+		require.NoError(t, err)
+
+		cfg.Listeners[0].Address = ":9090"
+		changed, err := ContentHash(cfg)
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, changed)
+	})
+
+	t.Run("hashes the canonical JSON form", func(t *testing.T) {
+		listener := &pb.Listener{
+			Type:    pb.Listener_TYPE_HTTP.Enum(),
+			Id:      proto.String("http"),
+			Address: proto.String(":8080"),
+		}
+		data, err := canonicalJSON(listener)
+		require.NoError(t, err)
+		assert.Equal(t, `{"address":":8080","id":"http","type":"TYPE_HTTP"}`, string(data),
+			"keys are sorted without whitespace")
+
+		cfg := newConfig(t)
+		hash, err := ContentHash(cfg)
+		require.NoError(t, err)
+		data, err = canonicalJSON(cfg.ToProto())
+		require.NoError(t, err)
+		sum := sha256.Sum256(data)
+		assert.Equal(t, "sha256:"+hex.EncodeToString(sum[:]), hash)
+	})
+
+	t.Run("nil config", func(t *testing.T) {
+		_, err := ContentHash(nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+}
+
+func TestWithAudit(t *testing.T) {
+	t.Parallel()
+
+	handler := slog.NewTextHandler(os.Stdout, nil)
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	key := []byte("audit-key")
+
+	t.Run("unsigned", func(t *testing.T) {
+		tx, err := FromAPI("req-1", cfg, handler, WithAudit("cn=alice", "rotate certs", nil))
+		require.NoError(t, err)
+		require.NotNil(t, tx.Audit)
+
+		hash, err := ContentHash(cfg)
+		require.NoError(t, err)
+		assert.Equal(t, "cn=alice", tx.Audit.Submitter)
+		assert.Equal(t, "rotate certs", tx.Audit.ChangeReason)
+		assert.Equal(t, hash, tx.Audit.ContentHash)
+		assert.Empty(t, tx.Audit.Signature)
+		require.ErrorIs(t, tx.Audit.Verify(tx.ID, key), ErrAuditUnsigned)
+	})
+
+	t.Run("signed", func(t *testing.T) {
+		tx, err := FromAPI("req-2", cfg, handler, WithAudit("cn=alice", "rotate certs", key))
+		require.NoError(t, err)
+		require.NotEmpty(t, tx.Audit.Signature)
+		require.NoError(t, tx.Audit.Verify(tx.ID, key))
+
+		pbAudit := tx.ToProto().GetAudit()
+		assert.Equal(t, tx.Audit.Signature, pbAudit.GetSignature())
+		assert.Equal(t, tx.Audit.ContentHash, pbAudit.GetContentHash())
+	})
+
+	t.Run("tampering is detected", func(t *testing.T) {
+		tx, err := FromAPI("req-3", cfg, handler, WithAudit("cn=alice", "rotate certs", key))
+		require.NoError(t, err)
+
+		tests := []struct {
+			name   string
+			tamper func(a *Audit)
+		}{
+			{"submitter", func(a *Audit) { a.Submitter = "cn=mallory" }},
+			{"reason", func(a *Audit) { a.ChangeReason = "nothing to see" }},
+			{"content hash", func(a *Audit) { a.ContentHash = "sha256:00" }},
+			{"moved text", func(a *Audit) {
+				a.Submitter, a.ChangeReason = "cn=alicerotate", " certs"
+			}},
+			{"signature", func(a *Audit) { a.Signature = "not-hex" }},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				audit := *tx.Audit
+				tc.tamper(&audit)
+				require.ErrorIs(t, audit.Verify(tx.ID, key), ErrAuditSignatureMismatch)
+			})
+		}
+
+		other, err := FromAPI("req-4", cfg, handler)
+		require.NoError(t, err)
+		require.ErrorIs(t, tx.Audit.Verify(other.ID, key), ErrAuditSignatureMismatch)
+		require.ErrorIs(t, tx.Audit.Verify(tx.ID, []byte("other-key")), ErrAuditSignatureMismatch)
+	})
+
+	t.Run("without audit", func(t *testing.T) {
+		tx, err := FromAPI("req-5", cfg, handler)
+		require.NoError(t, err)
+		assert.Nil(t, tx.Audit)
+		assert.Nil(t, tx.ToProto().GetAudit())
+		assert.Empty(t, tx.GetChangeReason())
+	})
+
+	t.Run("change reason length", func(t *testing.T) {
+		// The cap counts characters, not bytes
+		longest := strings.Repeat("é", MaxChangeReasonLength)
+		tx, err := FromAPI("req-6", cfg, handler, WithAudit("", longest, nil))
+		require.NoError(t, err)
+		assert.Equal(t, longest, tx.GetChangeReason())
+
+		tx, err = FromAPI("req-7", cfg, handler, WithAudit("", longest+"!", nil))
+		require.ErrorIs(t, err, ErrChangeReasonTooLong)
+		assert.Nil(t, tx)
+	})
+}
@@ -2,6 +2,7 @@
 package transaction
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"path/filepath"
@@ -29,13 +30,50 @@ func FromFile(
 	return New(SourceFile, absPath, "", cfg, handler)
 }
 
-// FromAPI creates a new ConfigTransaction from an API request
+// StdinSourceDetail is the source detail of transactions for a config read
+// from standard input
+const StdinSourceDetail = "stdin"
+
+// FromStdin creates a new ConfigTransaction for a config read from standard
+// input. It's a SourceFile transaction, with StdinSourceDetail in place of a
+// file path.
+func FromStdin(
+	cfg *config.Config,
+	handler slog.Handler,
+) (*ConfigTransaction, error) {
+	return New(SourceFile, StdinSourceDetail, "", cfg, handler)
+}
+
+// FromAPI creates a new ConfigTransaction from an API request. Pass WithAudit
+// to record who submitted the config and why.
 func FromAPI(
 	requestID string,
 	cfg *config.Config,
 	handler slog.Handler,
+	opts ...Option,
 ) (*ConfigTransaction, error) {
-	return New(SourceAPI, "gRPC API", requestID, cfg, handler)
+	return New(SourceAPI, "gRPC API", requestID, cfg, handler, opts...)
+}
+
+// FromRollback creates a new ConfigTransaction that re-applies the config of
+// lastGood after failed was rolled back. The config is rebuilt from its
+// source, so it is validated again like a new config.
+func FromRollback(
+	failed *ConfigTransaction,
+	lastGood *ConfigTransaction,
+	handler slog.Handler,
+) (*ConfigTransaction, error) {
+	if failed == nil || lastGood == nil || lastGood.GetConfig() == nil {
+		return nil, ErrNilConfig
+	}
+
+	cfg, err := lastGood.GetConfig().Clone()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy last good config: %w", err)
+	}
+
+	detail := fmt.Sprintf("rollback of %s to %s", failed.GetTransactionID(), lastGood.GetTransactionID())
+	return New(SourceRollback, detail, failed.RequestID, cfg, handler)
 }
 
 // FromTest creates a new ConfigTransaction for testing
@@ -47,6 +47,13 @@ func TestConstructors(t *testing.T) {
 		assert.True(t, os.IsNotExist(err))
 	})
 
+	t.Run("constructs from stdin", func(t *testing.T) {
+		tx, err := FromStdin(cfg, handler)
+		require.NoError(t, err)
+		assert.Equal(t, SourceFile, tx.Source)
+		assert.Equal(t, StdinSourceDetail, tx.SourceDetail)
+	})
+
 	t.Run("constructs from API", func(t *testing.T) {
 		tx, err := FromAPI("req-123", cfg, handler)
 		require.NoError(t, err)
@@ -62,6 +69,26 @@ func TestConstructors(t *testing.T) {
 		assert.Equal(t, "unit_test", tx.SourceDetail)
 	})
 
+	t.Run("constructs rollback", func(t *testing.T) {
+		lastGood, err := FromTest("last_good", cfg, handler)
+		require.NoError(t, err)
+		failed, err := FromAPI("req-456", cfg, handler)
+		require.NoError(t, err)
+
+		tx, err := FromRollback(failed, lastGood, handler)
+		require.NoError(t, err)
+		assert.Equal(t, SourceRollback, tx.Source)
+		assert.Equal(t,
+			"rollback of "+failed.GetTransactionID()+" to "+lastGood.GetTransactionID(),
+			tx.SourceDetail)
+		assert.Equal(t, "req-456", tx.RequestID)
+		assert.NotSame(t, cfg, tx.GetConfig())
+		assert.Equal(t, cfg.Version, tx.GetConfig().Version)
+
+		_, err = FromRollback(failed, nil, handler)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
 	t.Run("handles invalid config", func(t *testing.T) {
 		// Test with nil config
 		tx, err := FromTest("test", nil, handler)
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -43,6 +44,12 @@ func (p *Participant) GetState() string {
 	return p.fsm.GetState()
 }
 
+// GetStateChan returns a channel that emits the participant's state whenever it
+// changes. The current state is sent immediately after subscribing.
+func (p *Participant) GetStateChan(ctx context.Context) <-chan string {
+	return p.fsm.GetStateChan(ctx)
+}
+
 // Execute transitions the participant to executing state.
 // This happens when the participant starts processing the configuration.
 func (p *Participant) Execute() error {
@@ -124,6 +131,10 @@ type ParticipantCollection struct {
 	logger       *slog.Logger
 	handler      slog.Handler
 	mu           sync.RWMutex
+
+	// added is closed and replaced each time a participant is added, so
+	// watchers can pick up participants registered after they subscribed.
+	added chan struct{}
 }
 
 // NewParticipantCollection creates a new participant collection.
@@ -134,7 +145,28 @@ func NewParticipantCollection(handler slog.Handler) *ParticipantCollection {
 		participants: make(map[string]*Participant),
 		logger:       logger,
 		handler:      handler,
+		added:        make(chan struct{}),
+	}
+}
+
+// notifyAdded wakes any watchers waiting for new participants. The caller
+// must hold the write lock.
+func (c *ParticipantCollection) notifyAdded() {
+	close(c.added)
+	c.added = make(chan struct{})
+}
+
+// watchParticipants returns the currently registered participants and a
+// channel that is closed the next time a participant is added.
+func (c *ParticipantCollection) watchParticipants() ([]*Participant, <-chan struct{}) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	participants := make([]*Participant, 0, len(c.participants))
+	for _, p := range c.participants {
+		participants = append(participants, p)
 	}
+	return participants, c.added
 }
 
 // GetOrCreate returns an existing participant or creates a new one.
@@ -151,6 +183,7 @@ func (c *ParticipantCollection) GetOrCreate(name string) (*Participant, error) {
 			return nil, err
 		}
 		c.participants[name] = p
+		c.notifyAdded()
 	}
 
 	return p, nil
@@ -172,6 +205,7 @@ func (c *ParticipantCollection) AddParticipant(name string) error {
 		return err
 	}
 	c.participants[name] = p
+	c.notifyAdded()
 
 	return nil
 }
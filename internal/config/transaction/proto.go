@@ -26,33 +26,62 @@ func (tx *ConfigTransaction) ToProto() *pb.ConfigTransaction {
 		source = pb.ConfigTransaction_SOURCE_API
 	case SourceTest:
 		source = pb.ConfigTransaction_SOURCE_TEST
+	case SourceRollback:
+		source = pb.ConfigTransaction_SOURCE_ROLLBACK
 	default:
 		source = pb.ConfigTransaction_SOURCE_UNSPECIFIED
 	}
 
-	// Get log records from the transaction using GetLogs()
-	storageRecords := tx.GetLogs()
-	logs := make([]*pb.LogRecord, 0, len(storageRecords))
-	for _, record := range storageRecords {
-		logs = append(logs, convertStorageRecordToProto(record))
-	}
-
 	// Convert domain config to protobuf
 	var config *pb.ServerConfig
 	if tx.domainConfig != nil {
 		config = tx.domainConfig.ToProto()
 	}
 
+	// Convert reload results
+	reloadResults := tx.GetReloadResults()
+	pbReloadResults := make([]*pb.ReloadResult, 0, len(reloadResults))
+	for _, r := range reloadResults {
+		pbReloadResults = append(pbReloadResults, &pb.ReloadResult{
+			Participant: proto.String(r.Participant),
+			Component:   proto.String(r.Component),
+			Action:      proto.String(string(r.Action)),
+		})
+	}
+
+	// Convert app failures
+	appFailures := tx.GetAppFailures()
+	pbAppFailures := make([]*pb.AppFailure, 0, len(appFailures))
+	for _, f := range appFailures {
+		pbAppFailures = append(pbAppFailures, &pb.AppFailure{
+			AppId: proto.String(f.AppID),
+			Error: proto.String(f.Err.Error()),
+		})
+	}
+
+	var audit *pb.TransactionAudit
+	if tx.Audit != nil {
+		audit = &pb.TransactionAudit{
+			Submitter:    proto.String(tx.Audit.Submitter),
+			ChangeReason: proto.String(tx.Audit.ChangeReason),
+			ContentHash:  proto.String(tx.Audit.ContentHash),
+			Signature:    proto.String(tx.Audit.Signature),
+		}
+	}
+
 	return &pb.ConfigTransaction{
-		Id:           proto.String(tx.ID.String()),
-		Source:       &source,
-		SourceDetail: proto.String(tx.SourceDetail),
-		RequestId:    proto.String(tx.RequestID),
-		CreatedAt:    timestamppb.New(tx.CreatedAt),
-		State:        proto.String(tx.GetState()),
-		IsValid:      proto.Bool(tx.IsValid.Load()),
-		Logs:         logs,
-		Config:       config,
+		Id:            proto.String(tx.ID.String()),
+		Source:        &source,
+		SourceDetail:  proto.String(tx.SourceDetail),
+		RequestId:     proto.String(tx.RequestID),
+		CreatedAt:     timestamppb.New(tx.CreatedAt),
+		State:         proto.String(tx.GetState()),
+		IsValid:       proto.Bool(tx.IsValid.Load()),
+		Logs:          tx.LogsToProto(),
+		ReloadResults: pbReloadResults,
+		AppFailures:   pbAppFailures,
+		Audit:         audit,
+		Config:        config,
 	}
 }
 
@@ -73,6 +102,17 @@ func attrToValue(a slog.Attr) (*structpb.Value, error) {
 	}
 }
 
+// LogsToProto converts the log records collected during the transaction to
+// protobuf format
+func (tx *ConfigTransaction) LogsToProto() []*pb.LogRecord {
+	storageRecords := tx.GetLogs()
+	logs := make([]*pb.LogRecord, 0, len(storageRecords))
+	for _, record := range storageRecords {
+		logs = append(logs, convertStorageRecordToProto(record))
+	}
+	return logs
+}
+
 // convertStorageRecordToProto converts a storage.Record to a pb.LogRecord
 func convertStorageRecordToProto(record storage.Record) *pb.LogRecord {
 	// Convert slog level to protobuf level
@@ -63,6 +63,30 @@ func TestConfigTransaction_ToProto(t *testing.T) {
 		assert.Equal(t, pb.ConfigTransaction_SOURCE_TEST, pbTx.GetSource())
 	})
 
+	t.Run("includes reload results", func(t *testing.T) {
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+		tx, err := FromTest("test-request", cfg, handler)
+		require.NoError(t, err)
+
+		tx.RecordReloadResults(
+			ReloadResult{Participant: "HTTPRunner", Component: "public", Action: ReloadSkipped},
+			ReloadResult{Participant: "HTTPRunner", Component: "admin", Action: ReloadRestarted},
+		)
+
+		pbTx := tx.ToProto()
+		require.Len(t, pbTx.GetReloadResults(), 2)
+		assert.Equal(t, "HTTPRunner", pbTx.GetReloadResults()[0].GetParticipant())
+		assert.Equal(t, "public", pbTx.GetReloadResults()[0].GetComponent())
+		assert.Equal(t, "skipped", pbTx.GetReloadResults()[0].GetAction())
+		assert.Equal(t, "restarted", pbTx.GetReloadResults()[1].GetAction())
+
+		// Returned results are a copy
+		results := tx.GetReloadResults()
+		results[0].Action = ReloadStopped
+		assert.Equal(t, ReloadSkipped, tx.GetReloadResults()[0].Action)
+	})
+
 	t.Run("converts different source types", func(t *testing.T) {
 		cfg, err := config.NewFromProto(&pb.ServerConfig{})
 		require.NoError(t, err)
@@ -75,6 +99,7 @@ func TestConfigTransaction_ToProto(t *testing.T) {
 			{"file source", SourceFile, pb.ConfigTransaction_SOURCE_FILE},
 			{"api source", SourceAPI, pb.ConfigTransaction_SOURCE_API},
 			{"test source", SourceTest, pb.ConfigTransaction_SOURCE_TEST},
+			{"rollback source", SourceRollback, pb.ConfigTransaction_SOURCE_ROLLBACK},
 		}
 
 		for _, tt := range tests {
@@ -194,6 +219,25 @@ func TestConvertStorageRecordToProto(t *testing.T) {
 	}
 }
 
+func TestConfigTransaction_LogsToProto(t *testing.T) {
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	tx, err := FromTest("logs-to-proto", cfg, logging.SetupHandlerText("debug", nil))
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+
+	storageRecords := tx.GetLogs()
+	require.NotEmpty(t, storageRecords)
+
+	logs := tx.LogsToProto()
+	require.Len(t, logs, len(storageRecords))
+	for i, record := range storageRecords {
+		assert.Equal(t, record.Message, logs[i].GetMessage())
+		assert.Equal(t, record.Time.UnixNano(), logs[i].GetTime().AsTime().UnixNano())
+	}
+	assert.Len(t, tx.ToProto().GetLogs(), len(logs))
+}
+
 func TestGetLogsVsPlaybackLogs(t *testing.T) {
 	// Create a config transaction using proper constructor
 	cfg, err := config.NewFromProto(&pb.ServerConfig{})
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"slices"
+	"sync"
+)
+
+// ReloadAction describes what a participant did with a component when
+// applying a committed configuration.
+type ReloadAction string
+
+const (
+	// ReloadExecuted indicates the participant applied the configuration but
+	// did not report finer-grained detail
+	ReloadExecuted ReloadAction = "executed"
+	// ReloadSkipped indicates the component was unchanged and left running
+	ReloadSkipped ReloadAction = "skipped"
+	// ReloadRestarted indicates the component changed and was restarted
+	ReloadRestarted ReloadAction = "restarted"
+	// ReloadStarted indicates the component is new and was started
+	ReloadStarted ReloadAction = "started"
+	// ReloadStopped indicates the component was removed and stopped
+	ReloadStopped ReloadAction = "stopped"
+)
+
+// ReloadResult records the outcome of applying configuration to one
+// component of a saga participant.
+type ReloadResult struct {
+	// Participant is the name of the saga participant
+	Participant string
+
+	// Component identifies the part of the participant that was affected
+	// (e.g. a listener ID). Empty when the result covers the whole participant.
+	Component string
+
+	// Action is what the participant did with the component
+	Action ReloadAction
+}
+
+// reloadResults is the mutex-protected list of reload results for a transaction
+type reloadResults struct {
+	mu      sync.Mutex
+	results []ReloadResult
+}
+
+// RecordReloadResults appends reload results to the transaction and logs each one
+func (tx *ConfigTransaction) RecordReloadResults(results ...ReloadResult) {
+	tx.reload.mu.Lock()
+	tx.reload.results = append(tx.reload.results, results...)
+	tx.reload.mu.Unlock()
+
+	for _, r := range results {
+		tx.logger.Info("Participant reload result",
+			"participant", r.Participant,
+			"component", r.Component,
+			"action", r.Action)
+	}
+}
+
+// GetReloadResults returns a copy of the reload results recorded for this transaction
+func (tx *ConfigTransaction) GetReloadResults() []ReloadResult {
+	tx.reload.mu.Lock()
+	defer tx.reload.mu.Unlock()
+	return slices.Clone(tx.reload.results)
+}
@@ -36,6 +36,9 @@ const (
 	SourceAPI Source = "api"
 	// SourceTest indicates configuration sourced from a test
 	SourceTest Source = "test"
+	// SourceRollback indicates the last good configuration, re-applied after
+	// a failed transaction
+	SourceRollback Source = "rollback"
 )
 
 // ConfigTransaction represents a complete lifecycle of a configuration change
@@ -49,9 +52,10 @@ type ConfigTransaction struct {
 
 	// SourceDetail provides specific information about the origin of the configuration.
 	// This field contains more detailed context about where the configuration came from:
-	//   - For SourceFile: The absolute file path (e.g., "/etc/firelynx/config.toml")
+	//   - For SourceFile: The absolute file path (e.g., "/etc/firelynx/config.toml"), or "stdin"
 	//   - For SourceAPI: The API service name (e.g., "gRPC API")
 	//   - For SourceTest: The test name (e.g., "TestConfigReload")
+	//   - For SourceRollback: The IDs of the failed and restored transactions
 	// This information is useful for auditing, debugging, and tracing configuration changes.
 	SourceDetail string
 
@@ -61,6 +65,9 @@ type ConfigTransaction struct {
 	// CreatedAt records when this transaction was created
 	CreatedAt time.Time
 
+	// Audit records who submitted the config and why, nil when not recorded
+	Audit *Audit
+
 	// State management
 	fsm finitestate.Machine
 
@@ -77,6 +84,7 @@ type ConfigTransaction struct {
 	// App-related resources
 	app struct {
 		collection *serverApps.AppInstances
+		failures   []AppFailure
 	}
 
 	// Middleware-related resources
@@ -85,6 +93,9 @@ type ConfigTransaction struct {
 		collection *httpCfg.MiddlewareCollection
 	}
 
+	// Outcome of applying the configuration during reload
+	reload reloadResults
+
 	// Validation state
 	IsValid atomic.Bool
 }
@@ -93,18 +104,20 @@ type ConfigTransaction struct {
 //
 // - source: General category of the configuration origin (file, API, test)
 // - sourceDetail: Specific information about the configuration source:
-//   - For SourceFile: The absolute file path (e.g., "/etc/firelynx/config.toml")
+//   - For SourceFile: The absolute file path (e.g., "/etc/firelynx/config.toml"), or "stdin"
 //   - For SourceAPI: The API service name (e.g., "gRPC API")
 //   - For SourceTest: The test name (e.g., "TestConfigReload")
 //
 // - requestID: Correlation ID for API requests, can be empty for file/test sources
 // - cfg: Domain configuration object to be managed by this transaction
 // - handler: Logging handler to use for this transaction's logs
+// - opts: Options such as WithAudit, applied after the transaction is created
 func New(
 	source Source,
 	sourceDetail, requestID string,
 	cfg *config.Config,
 	handler slog.Handler,
+	opts ...Option,
 ) (*ConfigTransaction, error) {
 	if cfg == nil {
 		return nil, ErrNilConfig
@@ -148,6 +161,12 @@ func New(
 	// Initialize factories and collections
 	tx.middleware.factory = httpCfg.NewMiddlewareFactory()
 
+	for _, opt := range opts {
+		if err := opt(tx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Log the transaction creation
 	tx.logger.Debug("Transaction created")
 
@@ -361,6 +380,12 @@ func (tx *ConfigTransaction) GetAppCollection() *serverApps.AppInstances {
 	return tx.app.collection
 }
 
+// GetAppFailures returns the apps disabled because they failed to
+// initialize, with Config.IsolateFailures
+func (tx *ConfigTransaction) GetAppFailures() []AppFailure {
+	return slices.Clone(tx.app.failures)
+}
+
 // PlaybackLogs plays back the transaction logs to the given handler
 func (tx *ConfigTransaction) PlaybackLogs(handler slog.Handler) error {
 	return tx.logCollector.PlayLogs(handler)
@@ -439,6 +464,11 @@ func (tx *ConfigTransaction) WaitForCompletion(ctx context.Context) error {
 
 // isTerminalState returns true if the given state is a terminal state.
 func (tx *ConfigTransaction) isTerminalState(state string) bool {
+	return isSagaTerminalState(state)
+}
+
+// isSagaTerminalState returns true if the given saga state is terminal.
+func isSagaTerminalState(state string) bool {
 	return slices.Contains(finitestate.SagaTerminalStates, state)
 }
 
@@ -455,14 +485,17 @@ func validateResourceConflicts(allMiddlewares middleware.MiddlewareCollection) e
 	return validateConsoleLoggerFileConflicts(allMiddlewares)
 }
 
-// validateConsoleLoggerFileConflicts checks that console loggers don't use the same output file
+// validateConsoleLoggerFileConflicts checks that console loggers don't use the same output file.
+// Loggers with the "otlp" output are keyed by collector endpoint, not by the output name, so any
+// number of them may coexist. Loggers with the "syslog" output conflict only when they send messages
+// with the same tag to the same collector.
 func validateConsoleLoggerFileConflicts(allMiddlewares middleware.MiddlewareCollection) error {
-	var consoleLoggers []*configLogger.ConsoleLogger
+	var consoleLoggers []middleware.Middleware
 
 	// Extract all console loggers from the middleware collection
 	for _, mw := range allMiddlewares {
-		if consoleLogger, ok := mw.Config.(*configLogger.ConsoleLogger); ok {
-			consoleLoggers = append(consoleLoggers, consoleLogger)
+		if _, ok := mw.Config.(*configLogger.ConsoleLogger); ok {
+			consoleLoggers = append(consoleLoggers, mw)
 		}
 	}
 
@@ -471,9 +504,11 @@ func validateConsoleLoggerFileConflicts(allMiddlewares middleware.MiddlewareColl
 	}
 
 	fileUsage := make(map[string]int)
+	syslogUsage := make(map[syslogTarget]int)
 	var errs []error
 
-	for _, logger := range consoleLoggers {
+	for _, mw := range consoleLoggers {
+		logger := mw.Config.(*configLogger.ConsoleLogger)
 		expandedOutput, err := expandMiddlewareOutput(logger.Output)
 		if err != nil {
 			errs = append(errs, fmt.Errorf(
@@ -484,10 +519,19 @@ func validateConsoleLoggerFileConflicts(allMiddlewares middleware.MiddlewareColl
 			continue
 		}
 
-		// Only track file paths, not stdout/stderr
-		writerType := writers.ParseWriterType(expandedOutput)
-		if writerType == writers.WriterTypeFile {
+		// Only track file paths and syslog collectors, not stdout/stderr
+		switch writers.ParseWriterType(expandedOutput) {
+		case writers.WriterTypeFile:
 			fileUsage[expandedOutput]++
+		case writers.WriterTypeSyslog:
+			// Loggers may share a collector, but messages with the same
+			// tag can't be told apart
+			cfg := logger.Syslog.Config(mw.ID)
+			syslogUsage[syslogTarget{collector: cfg.Network + "://" + cfg.Address, tag: cfg.Tag}]++
+		case writers.WriterTypeOTLP:
+			// An OTLP endpoint is a network resource, never the same as an
+			// output file, and loggers exporting to the same collector
+			// share its export pipeline rather than conflicting
 		}
 	}
 	for filePath, count := range fileUsage {
@@ -500,10 +544,27 @@ func validateConsoleLoggerFileConflicts(allMiddlewares middleware.MiddlewareColl
 			))
 		}
 	}
+	for target, count := range syslogUsage {
+		if count > 1 {
+			errs = append(errs, fmt.Errorf(
+				"%w: duplicate syslog output '%s' with tag '%s' used by %d console logger instances",
+				ErrResourceConflict,
+				target.collector,
+				target.tag,
+				count,
+			))
+		}
+	}
 
 	return errors.Join(errs...)
 }
 
+// syslogTarget identifies the messages sent by a syslog console logger
+type syslogTarget struct {
+	collector string
+	tag       string
+}
+
 // expandMiddlewareOutput expands environment variables in middleware output paths
 func expandMiddlewareOutput(output string) (string, error) {
 	return interpolation.ExpandEnvVars(output)
@@ -1574,4 +1574,82 @@ func TestMiddlewareDuplicateOutputFileValidation(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, tx)
 	})
+
+	t.Run("allows multiple OTLP loggers", func(t *testing.T) {
+		for name, endpoints := range map[string][2]string{
+			"different endpoints": {"collector-a:4317", "collector-b:4317"},
+			"same endpoint":       {"collector:4317", "collector:4317"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				cfg := createDualLoggerConfig(t, "otlp", "otlp")
+				for i, route := range cfg.Endpoints[0].Routes {
+					consoleLogger := route.Middlewares[0].Config.(*configLogger.ConsoleLogger)
+					consoleLogger.OTLP.Endpoint = endpoints[i]
+				}
+				tx, err := New(SourceTest, "test", "", cfg, handler)
+				require.NoError(t, err)
+
+				// OTLP outputs are not output files, so never conflict
+				require.NoError(t, tx.RunValidation())
+			})
+		}
+	})
+
+	t.Run("OTLP logger does not mask a duplicate output file", func(t *testing.T) {
+		logFile := filepath.Join(t.TempDir(), "test.log")
+		cfg := createDualLoggerConfig(t, logFile, logFile)
+		route := &cfg.Endpoints[0].Routes[1]
+		route.Middlewares = append(route.Middlewares, middleware.Middleware{
+			ID: "otlp-logger",
+			Config: &configLogger.ConsoleLogger{
+				Output: "otlp",
+				OTLP:   configLogger.OTLPExport{Endpoint: "collector:4317"},
+			},
+		})
+		tx, err := New(SourceTest, "test", "", cfg, handler)
+		require.NoError(t, err)
+
+		err = tx.RunValidation()
+		require.ErrorIs(t, err, ErrResourceConflict)
+		assert.Contains(t, err.Error(), logFile)
+		assert.NotContains(t, err.Error(), "'otlp'")
+	})
+
+	t.Run("syslog loggers conflict only with the same collector and tag", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			tags     [2]string
+			wantErr  bool
+			addressB string
+		}{
+			{name: "default tags are the middleware IDs", tags: [2]string{"", ""}},
+			{name: "different tags", tags: [2]string{"api", "admin"}},
+			{name: "same tag, different collectors", tags: [2]string{"api", "api"}, addressB: "127.0.0.1:1515"},
+			{name: "same tag and collector", tags: [2]string{"api", "api"}, wantErr: true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				cfg := createDualLoggerConfig(t, "syslog", "syslog")
+				for i, route := range cfg.Endpoints[0].Routes {
+					consoleLogger := route.Middlewares[0].Config.(*configLogger.ConsoleLogger)
+					consoleLogger.Syslog = configLogger.SyslogOutput{Address: "127.0.0.1:1514", Tag: tt.tags[i]}
+				}
+				if tt.addressB != "" {
+					consoleLogger := cfg.Endpoints[0].Routes[1].Middlewares[0].Config.(*configLogger.ConsoleLogger)
+					consoleLogger.Syslog.Address = tt.addressB
+				}
+				tx, err := New(SourceTest, "test", "", cfg, handler)
+				require.NoError(t, err)
+
+				err = tx.RunValidation()
+				if !tt.wantErr {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorIs(t, err, ErrResourceConflict)
+				assert.Contains(t, err.Error(), "duplicate syslog output 'udp://127.0.0.1:1514' with tag 'api'")
+			})
+		}
+	})
 }
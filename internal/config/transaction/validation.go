@@ -48,6 +48,9 @@ func (tx *ConfigTransaction) RunValidation() error {
 			fmt.Errorf("domain config validation failed: %w", err),
 		)
 	}
+	for _, warning := range tx.domainConfig.ValidationWarnings {
+		logger.Warn("Configuration validation warning", "warning", warning)
+	}
 
 	// 2. Validate and instantiate apps
 	if err := validateAndCreateApps(tx); err != nil {
@@ -85,6 +88,15 @@ func (tx *ConfigTransaction) RunValidation() error {
 	return nil
 }
 
+// GetValidationWarnings returns the issues RunValidation found in the domain
+// config that don't make it invalid. They don't block the transaction.
+func (tx *ConfigTransaction) GetValidationWarnings() []error {
+	if tx.domainConfig == nil {
+		return nil
+	}
+	return tx.domainConfig.ValidationWarnings
+}
+
 // setStateValid marks the transaction as valid after successful validation
 func (tx *ConfigTransaction) setStateValid() {
 	logger := tx.logger.WithGroup("validation")
@@ -130,6 +142,9 @@ func (tx *ConfigTransaction) setStateInvalid(errs []error) {
 // collectMiddlewares extracts middleware collection from domain config
 func collectMiddlewares(cfg *config.Config) middleware.MiddlewareCollection {
 	var allMiddlewares middleware.MiddlewareCollection
+	for _, listener := range cfg.Listeners {
+		allMiddlewares = allMiddlewares.Merge(listener.Middlewares)
+	}
 	for _, endpoint := range cfg.Endpoints {
 		allMiddlewares = allMiddlewares.Merge(endpoint.Middlewares)
 		for _, route := range endpoint.Routes {
@@ -142,11 +157,17 @@ func collectMiddlewares(cfg *config.Config) middleware.MiddlewareCollection {
 // validateAndCreateApps validates and creates app instances using DTO pattern
 func validateAndCreateApps(tx *ConfigTransaction) error {
 	// Convert domain apps to DTOs and create instances directly
-	appInstances, err := convertAndCreateApps(tx.domainConfig)
+	appInstances, failures, err := convertAndCreateApps(tx.domainConfig)
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrAppCreationFailed, err)
 	}
+	for _, failure := range failures {
+		tx.logger.Warn("App failed to initialize and is disabled",
+			"appID", failure.AppID,
+			"error", failure.Err)
+	}
 	tx.app.collection = appInstances
+	tx.app.failures = failures
 	return nil
 }
 
@@ -9,9 +9,16 @@ import (
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
 	"github.com/atlanticdynamic/firelynx/internal/config/errz"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/composite"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/echo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -183,6 +190,144 @@ func TestRunValidation_CalledMultipleTimes(t *testing.T) {
 	assert.True(t, tx.IsValid.Load())
 }
 
+func TestRunValidation_Warnings(t *testing.T) {
+	// A listener without endpoints is reported but doesn't block the transaction
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	cfg.Listeners = listeners.ListenerCollection{
+		{
+			ID:      "idle",
+			Address: ":8080",
+			Type:    listeners.TypeHTTP,
+			Options: options.NewHTTP(),
+		},
+	}
+
+	tx, err := New(
+		SourceTest,
+		"TestRunValidation_Warnings",
+		"test-request-id",
+		cfg,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)).Handler(),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, tx.GetValidationWarnings())
+
+	require.NoError(t, tx.RunValidation())
+	assert.Equal(t, finitestate.StateValidated, tx.GetState())
+
+	warnings := tx.GetValidationWarnings()
+	require.Len(t, warnings, 1)
+	require.ErrorIs(t, warnings[0], config.ErrUnusedListener)
+}
+
+func TestRunValidation_IsolateFailures(t *testing.T) {
+	// The composite app passes domain validation, but fails to initialize
+	// since its step isn't a script app
+	load := func(t *testing.T, isolate bool) *ConfigTransaction {
+		t.Helper()
+		cfg, err := config.NewConfigFromBytes([]byte(fmt.Sprintf(`
+version = "v1"
+isolate_failures = %t
+
+[[apps]]
+id = "hello"
+type = "echo"
+[apps.echo]
+response = "hello"
+
+[[apps]]
+id = "chain"
+type = "composite_script"
+[apps.composite_script]
+script_app_ids = ["hello"]
+`, isolate)))
+		require.NoError(t, err)
+
+		tx, err := New(
+			SourceTest,
+			"TestRunValidation_IsolateFailures",
+			"test-request-id",
+			cfg,
+			slog.New(slog.NewTextHandler(os.Stdout, nil)).Handler(),
+		)
+		require.NoError(t, err)
+		return tx
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		tx := load(t, false)
+		err := tx.RunValidation()
+		require.ErrorIs(t, err, ErrAppCreationFailed)
+		assert.False(t, tx.IsValid.Load())
+	})
+
+	t.Run("isolated", func(t *testing.T) {
+		tx := load(t, true)
+		require.NoError(t, tx.RunValidation())
+		assert.True(t, tx.IsValid.Load())
+
+		failures := tx.GetAppFailures()
+		require.Len(t, failures, 1)
+		assert.Equal(t, "chain", failures[0].AppID)
+		require.ErrorIs(t, failures[0].Err, composite.ErrAppNotStep)
+
+		app, ok := tx.GetAppCollection().GetApp("chain")
+		require.True(t, ok)
+		assert.IsType(t, &serverApps.Unavailable{}, app)
+		app, ok = tx.GetAppCollection().GetApp("hello")
+		require.True(t, ok)
+		assert.IsType(t, &echo.App{}, app)
+
+		pbFailures := tx.ToProto().GetAppFailures()
+		require.Len(t, pbFailures, 1)
+		assert.Equal(t, "chain", pbFailures[0].GetAppId())
+		assert.Equal(t, failures[0].Err.Error(), pbFailures[0].GetError())
+	})
+}
+
+func TestRunValidation_ListenerMiddlewares(t *testing.T) {
+	// Listener default middlewares are instantiated with the endpoints' own
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	cfg.Listeners = listeners.ListenerCollection{
+		{
+			ID:      "http",
+			Address: ":8080",
+			Type:    listeners.TypeHTTP,
+			Options: options.NewHTTP(),
+			Middlewares: middleware.MiddlewareCollection{
+				{ID: "00-logger", Config: logger.NewConsoleLogger()},
+			},
+		},
+	}
+	cfg.Endpoints = endpoints.EndpointCollection{
+		{
+			ID:         "main",
+			ListenerID: "http",
+			Middlewares: middleware.MiddlewareCollection{
+				{ID: "01-logger", Config: logger.NewConsoleLogger()},
+			},
+		},
+	}
+
+	tx, err := New(
+		SourceTest,
+		"TestRunValidation_ListenerMiddlewares",
+		"test-request-id",
+		cfg,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)).Handler(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+
+	registry := tx.GetMiddlewareRegistry()
+	for _, id := range []string{"00-logger", "01-logger"} {
+		_, ok := registry.GetMiddleware("console_logger", id)
+		assert.True(t, ok, "middleware %s should be instantiated", id)
+	}
+}
+
 func TestSetStateInvalid_ErrorAlreadyWrapped(t *testing.T) {
 	// This test verifies that setStateInvalid doesn't double-wrap errors
 	// We need to create a custom validator that returns already-wrapped errors
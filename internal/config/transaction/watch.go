@@ -0,0 +1,152 @@
+package transaction
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+)
+
+// watchBufferSize is the capacity of the channel returned by Watch. A small
+// buffer keeps the FSM broadcasts flowing while a consumer is busy.
+const watchBufferSize = 16
+
+// StateChange describes a state transition of a transaction or one of its saga
+// participants, as reported by Watch.
+type StateChange struct {
+	// Participant is the participant name, or empty for a transaction-level change.
+	Participant string
+
+	// State is the state that was entered.
+	State string
+
+	// Time records when the change was observed.
+	Time time.Time
+}
+
+// IsTerminal reports whether this is a transaction-level change into a
+// terminal saga state.
+func (c StateChange) IsTerminal() bool {
+	return c.Participant == "" && isSagaTerminalState(c.State)
+}
+
+// Watch streams state changes of the transaction and its participants. The
+// returned channel first receives the current transaction state and the state
+// of every registered participant, followed by each subsequent change,
+// including participants registered after Watch was called.
+//
+// The channel is closed after the terminal transaction state has been sent,
+// or when ctx is canceled.
+func (tx *ConfigTransaction) Watch(ctx context.Context) <-chan StateChange {
+	out := make(chan StateChange, watchBufferSize)
+	go tx.watch(ctx, out)
+	return out
+}
+
+func (tx *ConfigTransaction) watch(ctx context.Context, out chan<- StateChange) {
+	defer close(out)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	send := func(participant, state string) bool {
+		select {
+		case out <- StateChange{Participant: participant, State: state, Time: time.Now()}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	sagaStates := tx.fsm.GetStateChan(ctx)
+	participantStates := make(chan StateChange)
+
+	// reported tracks the last state sent for each participant, which also
+	// marks the participants that already have a forwarder running.
+	reported := make(map[string]string)
+	var added <-chan struct{}
+	subscribe := func() {
+		var participants []*Participant
+		participants, added = tx.participants.watchParticipants()
+		for _, p := range participants {
+			if _, ok := reported[p.Name]; ok {
+				continue
+			}
+			reported[p.Name] = ""
+			go forwardParticipantStates(ctx, p, participantStates)
+		}
+	}
+	subscribe()
+
+	// flushParticipants reports participant states that have not been
+	// delivered yet, so the terminal event is always the last one sent.
+	flushParticipants := func() bool {
+		states := tx.participants.GetParticipantStates()
+		for _, name := range slices.Sorted(maps.Keys(states)) {
+			if reported[name] == states[name] {
+				continue
+			}
+			reported[name] = states[name]
+			if !send(name, states[name]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var sagaState string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-added:
+			subscribe()
+		case change := <-participantStates:
+			if reported[change.Participant] == change.State {
+				continue
+			}
+			reported[change.Participant] = change.State
+			if !send(change.Participant, change.State) {
+				return
+			}
+		case state, ok := <-sagaStates:
+			if !ok {
+				return
+			}
+			if state == sagaState {
+				continue
+			}
+			sagaState = state
+			if isSagaTerminalState(state) {
+				if flushParticipants() {
+					send("", state)
+				}
+				return
+			}
+			if !send("", state) {
+				return
+			}
+		}
+	}
+}
+
+// forwardParticipantStates relays state changes of a single participant until
+// ctx is canceled.
+func forwardParticipantStates(ctx context.Context, p *Participant, ch chan<- StateChange) {
+	states := p.GetStateChan(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case state, ok := <-states:
+			if !ok {
+				return
+			}
+			select {
+			case ch <- StateChange{Participant: p.Name, State: state}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nextChange reads the next StateChange or fails the test after a timeout.
+func nextChange(t *testing.T, ch <-chan StateChange) StateChange {
+	t.Helper()
+	select {
+	case change, ok := <-ch:
+		require.True(t, ok, "watch channel closed unexpectedly")
+		return change
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for state change")
+		return StateChange{}
+	}
+}
+
+// requireClosed asserts that the watch channel is closed.
+func requireClosed(t *testing.T, ch <-chan StateChange) {
+	t.Helper()
+	select {
+	case change, ok := <-ch:
+		require.False(t, ok, "expected closed channel, got %+v", change)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for watch channel to close")
+	}
+}
+
+func TestConfigTransaction_Watch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams saga and participant changes until terminal", func(t *testing.T) {
+		tx, _ := setupTest(t)
+		changes := tx.Watch(t.Context())
+
+		initial := nextChange(t, changes)
+		assert.Empty(t, initial.Participant)
+		assert.Equal(t, finitestate.StateCreated, initial.State)
+		assert.False(t, initial.IsTerminal())
+		assert.False(t, initial.Time.IsZero())
+
+		require.NoError(t, tx.RunValidation())
+		assert.Equal(t, finitestate.StateValidating, nextChange(t, changes).State)
+		assert.Equal(t, finitestate.StateValidated, nextChange(t, changes).State)
+
+		require.NoError(t, tx.BeginExecution())
+		assert.Equal(t, finitestate.StateExecuting, nextChange(t, changes).State)
+
+		// Participants registered after Watch started are picked up.
+		require.NoError(t, tx.RegisterParticipant("listener"))
+		registered := nextChange(t, changes)
+		assert.Equal(t, "listener", registered.Participant)
+		assert.Equal(t, finitestate.ParticipantNotStarted, registered.State)
+
+		p, err := tx.GetParticipants().GetOrCreate("listener")
+		require.NoError(t, err)
+		require.NoError(t, p.Execute())
+		executing := nextChange(t, changes)
+		assert.Equal(t, "listener", executing.Participant)
+		assert.Equal(t, finitestate.ParticipantExecuting, executing.State)
+
+		require.NoError(t, p.MarkSucceeded())
+		succeeded := nextChange(t, changes)
+		assert.Equal(t, "listener", succeeded.Participant)
+		assert.Equal(t, finitestate.ParticipantSucceeded, succeeded.State)
+
+		require.NoError(t, tx.MarkSucceeded())
+		assert.Equal(t, finitestate.StateSucceeded, nextChange(t, changes).State)
+		require.NoError(t, tx.BeginReload())
+		assert.Equal(t, finitestate.StateReloading, nextChange(t, changes).State)
+		require.NoError(t, tx.MarkCompleted())
+
+		final := nextChange(t, changes)
+		assert.Equal(t, finitestate.StateCompleted, final.State)
+		assert.True(t, final.IsTerminal())
+		requireClosed(t, changes)
+	})
+
+	t.Run("terminal transaction sends final state and closes", func(t *testing.T) {
+		tx, _ := setupTest(t)
+		require.NoError(t, tx.RegisterParticipant("listener"))
+		require.NoError(t, tx.MarkError(assert.AnError))
+
+		changes := tx.Watch(t.Context())
+		var got []StateChange
+		for change := range changes {
+			got = append(got, change)
+		}
+
+		require.NotEmpty(t, got)
+		last := got[len(got)-1]
+		assert.Equal(t, finitestate.StateError, last.State)
+		assert.True(t, last.IsTerminal())
+
+		participantStates := make(map[string]string)
+		for _, change := range got {
+			if change.Participant != "" {
+				participantStates[change.Participant] = change.State
+			}
+		}
+		assert.Equal(t, map[string]string{"listener": finitestate.ParticipantNotStarted}, participantStates)
+	})
+
+	t.Run("closes when context is canceled", func(t *testing.T) {
+		tx, _ := setupTest(t)
+		ctx, cancel := context.WithCancel(t.Context())
+
+		changes := tx.Watch(ctx)
+		assert.Equal(t, finitestate.StateCreated, nextChange(t, changes).State)
+
+		cancel()
+		requireClosed(t, changes)
+	})
+}
@@ -3,10 +3,20 @@ package config
 import (
 	"errors"
 	"fmt"
-
+	"slices"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/calculation"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/mcpserver"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/template"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 )
 
 // Validatable defines an interface for objects that can validate themselves.
@@ -21,44 +31,124 @@ type Validatable interface {
 
 // Validate recursively validates all configuration components and their cross-references
 func (c *Config) Validate() error {
+	return joinValidationErrors(c.validate())
+}
+
+// ValidateWithIssues validates the config like Validate, also returning each
+// problem found: the errors that make it invalid followed by the warnings.
+// Errors are attributed to the component and field they were found in where
+// known.
+func (c *Config) ValidateWithIssues() ([]validation.Issue, error) {
+	errs := c.validate()
+
+	var issues []validation.Issue
+	for _, err := range errs {
+		issues = append(issues, validation.Issues(err, validation.SeverityError)...)
+	}
+	for _, warning := range c.ValidationWarnings {
+		issues = append(issues, validation.Issues(warning, validation.SeverityWarning)...)
+	}
+	return issues, joinValidationErrors(errs)
+}
+
+// joinValidationErrors returns the error Validate reports for errs
+func joinValidationErrors(errs []error) error {
+	// An unsupported version is returned as it is
+	if len(errs) == 1 && errors.Is(errs[0], ErrUnsupportedConfigVer) {
+		return errs[0]
+	}
+
+	// If we have errors, wrap them with the main validation error
+	joinedErrs := errors.Join(errs...)
+	if joinedErrs != nil {
+		return fmt.Errorf("%w: %w", ErrFailedToValidateConfig, joinedErrs)
+	}
+
+	return nil
+}
+
+// validate runs all validations, returning the errors found. An unsupported
+// version is returned alone, since the rest of the config can't be checked.
+func (c *Config) validate() []error {
 	c.ValidationCompleted = true
+	c.ValidationWarnings = nil
 
 	// Validate version
 	if err := c.validateVersion(); err != nil {
-		return err
+		return []error{validation.ForField("version", err)}
 	}
 
 	var errs []error
 
+	// Validate the server-wide default timeout
+	if c.DefaultTimeout < 0 {
+		errs = append(errs, validation.ForField("default_timeout", fmt.Errorf(
+			"%w: default_timeout must be positive, got %s", ErrInvalidValue, c.DefaultTimeout)))
+	}
+	if c.MaxMiddlewareChainLength < 0 {
+		errs = append(errs, validation.ForField("max_middleware_chain_length", fmt.Errorf(
+			"%w: max_middleware_chain_length must not be negative, got %d",
+			ErrInvalidValue, c.MaxMiddlewareChainLength)))
+	}
+
+	// Validate the apply hook scripts
+	for _, hook := range []*scripts.AppScript{c.PreApply, c.PostApply} {
+		if hook == nil {
+			continue
+		}
+		if err := hook.Validate(); err != nil {
+			errs = append(errs, validation.ForField(hook.ID, err))
+		}
+	}
+
 	// Validate listeners and collect their IDs for reference validation
 	listenerIds, listenerErrs := c.validateListeners()
 	errs = append(errs, listenerErrs...)
 
-	// Validate endpoints and their references to listeners
+	// Check ID uniqueness and the references between components
+	errs = append(errs, c.validateIDs()...)
+
+	// Validate endpoints against their listeners
 	endpointErrs := c.validateEndpoints(listenerIds)
 	errs = append(errs, endpointErrs...)
 
-	// Expand apps for routes before validating them
-	// This creates route-specific app instances with merged static data
+	// Validate apps
+	if c.Apps != nil {
+		if err := c.Apps.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Expand apps for routes once they are validated, so the route-specific
+	// app instances with merged static data copy their interpolated fields
 	expandAppsForRoutes(c.Apps, c.Endpoints)
 
-	// Validate apps and route references
-	if err := c.validateAppsAndRoutes(); err != nil {
-		errs = append(errs, err)
-	}
+	// Check the settings the routes' static data gives their app copies, like
+	// the templates named by the routes of template apps, which are parsed by
+	// the apps' validation
+	errs = append(errs, c.validateRouteAppCopies()...)
 
 	// Check for route conflicts across endpoints
 	if err := c.validateRouteConflicts(); err != nil {
 		errs = append(errs, fmt.Errorf("%w: %w", ErrRouteConflict, err))
 	}
 
-	// If we have errors, wrap them with the main validation error
-	joinedErrs := errors.Join(errs...)
-	if joinedErrs != nil {
-		return fmt.Errorf("%w: %w", ErrFailedToValidateConfig, joinedErrs)
+	// Report config smells that don't make it invalid
+	warnings := slices.Concat(
+		c.LoadWarnings,
+		c.validateMiddlewareChainLengths(),
+		c.validateUnusedListeners(),
+		c.validateOrphanedApps(),
+		c.validateUnreachableRoutes(),
+		c.validateAmbiguousRoutes(),
+	)
+	if c.StrictValidation {
+		errs = append(errs, warnings...)
+	} else {
+		c.ValidationWarnings = append(c.ValidationWarnings, warnings...)
 	}
 
-	return nil
+	return errs
 }
 
 // validateVersion validates the config version is supported
@@ -76,34 +166,32 @@ func (c *Config) validateVersion() error {
 	}
 }
 
-// validateListeners validates all listeners and checks for duplicates
-// Returns a map of valid listener IDs and a slice of validation errors
+// validateListeners validates all listeners and checks for duplicate
+// addresses. Returns a map of the listener IDs and a slice of validation
+// errors; duplicate IDs are reported by validateIDs.
 func (c *Config) validateListeners() (map[string]bool, []error) {
 	var errs []error
 	listenerIds := make(map[string]bool, len(c.Listeners))
 	listenerAddrs := make(map[string]bool, len(c.Listeners))
 
-	for i, listener := range c.Listeners {
-		// Validate each listener with its own validation logic
+	for i := range c.Listeners {
+		// Validate in place so interpolated fields are kept on the config
+		listener := &c.Listeners[i]
 		if err := listener.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("listener at index %d: %w", i, err))
+			errs = append(errs, validation.ForComponent(validation.ComponentListener, listener.ID,
+				fmt.Errorf("listener at index %d: %w", i, err)))
 		}
 
-		// Check for duplicate IDs
 		if listener.ID != "" {
-			if listenerIds[listener.ID] {
-				errs = append(errs, fmt.Errorf("%w: listener ID '%s'",
-					ErrDuplicateID, listener.ID))
-			} else {
-				listenerIds[listener.ID] = true
-			}
+			listenerIds[listener.ID] = true
 		}
 
 		// Check for duplicate addresses
 		if listener.Address != "" {
 			if listenerAddrs[listener.Address] {
-				errs = append(errs, fmt.Errorf("%w: listener address '%s'",
-					ErrDuplicateID, listener.Address))
+				errs = append(errs, validation.ForComponent(validation.ComponentListener, listener.ID,
+					validation.ForField("address", fmt.Errorf("%w: listener address '%s'",
+						ErrDuplicateID, listener.Address))))
 			} else {
 				listenerAddrs[listener.Address] = true
 			}
@@ -113,11 +201,12 @@ func (c *Config) validateListeners() (map[string]bool, []error) {
 	return listenerIds, errs
 }
 
-// validateEndpoints validates all endpoints and their references to listeners
-// Returns a slice of validation errors
+// validateEndpoints validates all endpoints against the listeners they're
+// attached to, and resolves their listeners' default middlewares. Returns a
+// slice of validation errors; references to missing listeners are reported by
+// validateIDs.
 func (c *Config) validateEndpoints(listenerIds map[string]bool) []error {
 	var errs []error
-	endpointIds := make(map[string]bool, len(c.Endpoints))
 
 	// Create a map of listener IDs to listener types for route type validation
 	listenerTypeMap := make(map[string]listeners.Type)
@@ -126,35 +215,31 @@ func (c *Config) validateEndpoints(listenerIds map[string]bool) []error {
 	}
 
 	for i, ep := range c.Endpoints {
+		// Listener defaults are resolved below, once the listener is known
+		c.Endpoints[i].ListenerMiddlewares = nil
+
 		// Validate each endpoint with its own validation logic
 		if err := ep.Validate(); err != nil {
-			errs = append(errs, fmt.Errorf("endpoint at index %d: %w", i, err))
+			errs = append(errs, validation.ForComponent(validation.ComponentEndpoint, ep.ID,
+				fmt.Errorf("endpoint at index %d: %w", i, err)))
 		}
 
-		// Check for duplicate endpoint IDs
-		if ep.ID != "" {
-			if endpointIds[ep.ID] {
-				errs = append(errs, fmt.Errorf("%w: endpoint ID '%s'",
-					ErrDuplicateID, ep.ID))
-			} else {
-				endpointIds[ep.ID] = true
+		for _, listenerID := range ep.AllListenerIDs() {
+			if !listenerIds[listenerID] {
+				continue
 			}
-		}
 
-		// Validate listener reference
-		if ep.ListenerID != "" {
-			// Check if listener exists
-			if !listenerIds[ep.ListenerID] {
-				errs = append(errs, fmt.Errorf(
-					"%w: endpoint '%s' references non-existent listener ID '%s'",
-					ErrListenerNotFound,
-					ep.ID,
-					ep.ListenerID,
-				))
-			} else {
-				// Validate route types match listener type
-				routeTypeErrs := c.validateRouteTypesMatchListenerType(ep, listenerTypeMap)
-				errs = append(errs, routeTypeErrs...)
+			// Validate route types match listener type
+			routeTypeErrs := c.validateRouteTypesMatchListenerType(ep.ForListener(listenerID), listenerTypeMap)
+			errs = append(errs, routeTypeErrs...)
+
+			// Resolve the listener's default middlewares for the endpoint
+			listener, _ := c.Listeners.FindByID(listenerID)
+			if len(listener.Middlewares) > 0 {
+				if c.Endpoints[i].ListenerMiddlewares == nil {
+					c.Endpoints[i].ListenerMiddlewares = make(map[string]middleware.MiddlewareCollection)
+				}
+				c.Endpoints[i].ListenerMiddlewares[listenerID] = listener.Middlewares
 			}
 		}
 	}
@@ -186,7 +271,7 @@ func (c *Config) validateRouteTypesMatchListenerType(
 
 	// Define which condition types are compatible with which listener types
 	compatibleTypes := map[listeners.Type][]conditions.Type{
-		listeners.TypeHTTP: {conditions.TypeHTTP},
+		listeners.TypeHTTP: {conditions.TypeHTTP, conditions.TypeHTTPRegex},
 	}
 
 	// Validate that all routes in this endpoint have a compatible type with the listener
@@ -209,7 +294,7 @@ func (c *Config) validateRouteTypesMatchListenerType(
 		}
 
 		if !isCompatible {
-			errs = append(errs, fmt.Errorf(
+			errs = append(errs, validation.ForComponent(validation.ComponentRoute, endpoint.RouteID(j), fmt.Errorf(
 				"%w: endpoint '%s' (route index %d) with condition type '%s' is attached to listener '%s' of type '%s'",
 				ErrRouteTypeMismatch,
 				endpoint.ID,
@@ -217,92 +302,468 @@ func (c *Config) validateRouteTypesMatchListenerType(
 				condType,
 				endpoint.ListenerID,
 				listenerType,
-			))
+			)))
 		}
 	}
 
 	return errs
 }
 
-// validateAppsAndRoutes validates apps and their references from routes
-func (c *Config) validateAppsAndRoutes() error {
+// validateRouteConflicts checks for duplicate routes across endpoints on the
+// same listener, for routes whose paths the listener's mux can't serve
+// together (see routes.PathsConflict), and for different MCP server apps on
+// the same path of a listener
+func (c *Config) validateRouteConflicts() error {
 	var errs []error
 
-	// Validate apps
-	if err := c.Apps.Validate(); err != nil {
-		errs = append(errs, err)
-	}
+	// Map to track route conditions by listener: listener ID -> condition string -> route ID
+	routeMap := make(map[string]map[string]string)
+
+	// The first route registered on each path, by listener
+	pathMap := make(map[string][]routePath)
+
+	// The first MCP server route on each path condition, by listener
+	mcpMap := make(map[string]map[string]routeApp)
 
-	// Create slice of route refs for app validation
-	routeRefs := c.collectRouteReferences()
+	for i := range c.Endpoints {
+		ep := &c.Endpoints[i]
 
-	// Validate that routes only reference apps defined in the configuration
-	if err := c.Apps.ValidateRouteAppReferences(routeRefs); err != nil {
-		errs = append(errs, err)
+		// Disabled endpoints aren't wired, so their routes can't conflict
+		if ep.Disabled {
+			continue
+		}
+
+		// The endpoint's routes are registered on each of its listeners
+		for _, listenerID := range ep.AllListenerIDs() {
+			errs = append(errs, checkRouteConflicts(ep, listenerID, routeMap, pathMap)...)
+			errs = append(errs, checkMCPRouteConflicts(ep, listenerID, mcpMap)...)
+		}
 	}
 
 	return errors.Join(errs...)
 }
 
-// collectRouteReferences collects app references from all routes
-func (c *Config) collectRouteReferences() []struct{ AppID string } {
-	routeRefs := make([]struct{ AppID string }, 0)
+// checkRouteConflicts checks the routes of an endpoint against the routes
+// already registered on a listener, and registers them
+func checkRouteConflicts(
+	ep *endpoints.Endpoint,
+	listenerID string,
+	routeMap map[string]map[string]string,
+	pathMap map[string][]routePath,
+) []error {
+	var errs []error
+
+	// Initialize map for this listener if needed
+	if _, exists := routeMap[listenerID]; !exists {
+		routeMap[listenerID] = make(map[string]string)
+	}
+
+	// Check each route for conflicts
+	for j := range ep.Routes {
+		route := &ep.Routes[j]
+
+		// Skip nil conditions - they're validated elsewhere - and disabled
+		// routes, which are never dispatched
+		if route.Condition == nil || route.Disabled {
+			continue
+		}
+		routeID := ep.RouteID(j)
+
+		// Generate a condition key in the format "type:value", including
+		// any additional request conditions
+		conditionKey := route.ConditionKey()
+
+		// Check if this condition is already used on this listener
+		if existingRouteID, exists := routeMap[listenerID][conditionKey]; exists {
+			errs = append(errs, validation.ForComponent(validation.ComponentEndpoint, ep.ID, fmt.Errorf(
+				"condition '%s' on listener '%s' is used by both route '%s' and '%s'",
+				conditionKey,
+				listenerID,
+				existingRouteID,
+				routeID,
+			)))
+		} else {
+			// Register this condition
+			routeMap[listenerID][conditionKey] = routeID
+		}
+
+		// Check the route's path against the paths already registered on
+		// this listener
+		path, ok := route.HTTPPath()
+		if !ok || slices.ContainsFunc(pathMap[listenerID], func(p routePath) bool { return p.path == path }) {
+			continue
+		}
+		for _, existing := range pathMap[listenerID] {
+			if err := routes.PathsConflict(existing.path, path); err != nil {
+				errs = append(errs, validation.ForComponent(validation.ComponentRoute, routeID, fmt.Errorf(
+					"path '%s' of route '%s' on listener '%s' conflicts with path '%s' of route '%s': %w",
+					path, routeID, listenerID, existing.path, existing.routeID, err,
+				)))
+			}
+		}
+		pathMap[listenerID] = append(pathMap[listenerID], routePath{path: path, routeID: routeID})
+	}
+
+	return errs
+}
+
+// routePath is a path registered on a listener's mux and the first route
+// registered on it
+type routePath struct {
+	path    string
+	routeID string
+}
+
+// routeApp is a route and the ID of the app it serves
+type routeApp struct {
+	routeID string
+	appID   string
+}
+
+// checkMCPRouteConflicts checks that the routes of an endpoint don't serve an
+// MCP server app on a path condition of a listener where another MCP server
+// app is already served, and registers them. Each MCP server has its own tools
+// and MCP clients connect to a server by URL alone, so request conditions such
+// as headers can't tell two servers apart. Routes serving the same server on
+// one path, e.g. for different methods, are fine.
+func checkMCPRouteConflicts(
+	ep *endpoints.Endpoint,
+	listenerID string,
+	mcpMap map[string]map[string]routeApp,
+) []error {
+	var errs []error
+	for j := range ep.Routes {
+		route := &ep.Routes[j]
+		if route.Condition == nil || route.Disabled || route.App == nil {
+			continue
+		}
+		if _, ok := route.App.Config.(*mcpserver.App); !ok {
+			continue
+		}
+
+		if mcpMap[listenerID] == nil {
+			mcpMap[listenerID] = make(map[string]routeApp)
+		}
+		routeID := ep.RouteID(j)
+		pathKey := fmt.Sprintf("%s:%s", route.Condition.Type(), route.Condition.Value())
+		existing, ok := mcpMap[listenerID][pathKey]
+		if !ok {
+			mcpMap[listenerID][pathKey] = routeApp{routeID: routeID, appID: route.AppID}
+			continue
+		}
+		if existing.appID == route.AppID {
+			continue
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentRoute, routeID, fmt.Errorf(
+			"MCP server app '%s' of route '%s' on listener '%s' shares path '%s' with MCP server app '%s' of route '%s'",
+			route.AppID, routeID, listenerID, pathKey, existing.appID, existing.routeID,
+		)))
+	}
+	return errs
+}
+
+// validateRouteAppCopies checks the settings a route's static data gives its
+// copy of the app: the template of a template app, the base directory of a
+// fileread app and the constants of a calculation app. Without route static
+// data, the copy of a fileread or calculation app is the app validated already.
+func (c *Config) validateRouteAppCopies() []error {
+	var errs []error
 	for _, ep := range c.Endpoints {
-		for _, route := range ep.Routes {
-			routeRefs = append(routeRefs, struct{ AppID string }{AppID: route.AppID})
+		for i := range ep.Routes {
+			route := &ep.Routes[i]
+			if route.App == nil {
+				continue
+			}
+			hasRouteData := len(ep.RouteStaticData(route)) > 0
+			var err error
+			switch app := route.App.Config.(type) {
+			case *template.App:
+				err = app.ValidateTemplateName()
+			case *fileread.App:
+				if hasRouteData {
+					err = app.ValidateBaseDirectory()
+				}
+			case *calculation.App:
+				if hasRouteData {
+					_, err = app.Constants()
+				}
+			}
+			if err != nil {
+				errs = append(errs, validation.ForComponent(validation.ComponentRoute, ep.RouteID(i), err))
+			}
 		}
 	}
-	return routeRefs
+	return errs
 }
 
-// validateRouteConflicts checks for duplicate routes across endpoints on the same listener
-func (c *Config) validateRouteConflicts() error {
+// validateMiddlewareChainLengths returns an error for each route whose resolved
+// middleware chain is longer than MaxMiddlewareChainLength. The chain of a
+// route on several listeners is its longest one.
+func (c *Config) validateMiddlewareChainLengths() []error {
+	if c.MaxMiddlewareChainLength <= 0 {
+		return nil
+	}
+
 	var errs []error
+	for _, ep := range c.Endpoints {
+		for i := range ep.Routes {
+			route := &ep.Routes[i]
+			chain := ep.MiddlewareChain(route)
+			for _, listenerID := range ep.ListenerIDs {
+				onListener := ep.ForListener(listenerID)
+				if listenerChain := onListener.MiddlewareChain(route); len(listenerChain) > len(chain) {
+					chain = listenerChain
+				}
+			}
+			if len(chain) <= c.MaxMiddlewareChainLength {
+				continue
+			}
 
-	// Map to track route conditions by listener: listener ID -> condition string -> endpoint ID
-	routeMap := make(map[string]map[string]string)
+			routeDesc := fmt.Sprintf("route %d", i)
+			if route.Condition != nil {
+				routeDesc = fmt.Sprintf("route %d (%s)", i, route.Condition)
+			}
+			errs = append(errs, validation.ForComponent(validation.ComponentRoute, ep.RouteID(i), fmt.Errorf(
+				"%w: endpoint '%s' %s has %d middlewares, maximum is %d",
+				ErrMiddlewareChainTooLong, ep.ID, routeDesc, len(chain), c.MaxMiddlewareChainLength,
+			)))
+		}
+	}
+	return errs
+}
 
+// validateUnusedListeners returns an error for each listener that no endpoint
+// is attached to and that has no not found app, since it accepts connections
+// it can only answer with 404s
+func (c *Config) validateUnusedListeners() []error {
+	used := make(map[string]bool, len(c.Endpoints))
 	for _, ep := range c.Endpoints {
-		// Get listener ID for this endpoint
-		listenerID := ep.ListenerID
-		if listenerID == "" {
-			continue // Skip endpoints without a valid listener ID
+		for _, listenerID := range ep.AllListenerIDs() {
+			used[listenerID] = true
 		}
+	}
 
-		// Initialize map for this listener if needed
-		if _, exists := routeMap[listenerID]; !exists {
-			routeMap[listenerID] = make(map[string]string)
+	var errs []error
+	for _, listener := range c.Listeners {
+		if listener.ID == "" || used[listener.ID] || listener.GetNotFoundAppID() != "" {
+			continue
 		}
+		// Report a duplicated ID once
+		used[listener.ID] = true
+		errs = append(errs, validation.ForComponent(validation.ComponentListener, listener.ID, fmt.Errorf(
+			"%w: listener '%s' on %s", ErrUnusedListener, listener.ID, listener.Address)))
+	}
+	return errs
+}
+
+// validateOrphanedApps returns an error for each app that is neither the app
+// of a route, the not found or method not allowed app of a listener, nor a
+// script of a composite app
+func (c *Config) validateOrphanedApps() []error {
+	if c.Apps == nil {
+		return nil
+	}
 
-		// Check each route for conflicts
+	// Routes that are disabled, or on a disabled endpoint, don't count as
+	// references, but are remembered for a clearer warning
+	referenced := make(map[string]bool)
+	referencedByDisabled := make(map[string]bool)
+	for _, ep := range c.Endpoints {
 		for _, route := range ep.Routes {
-			// Skip nil conditions - they're validated elsewhere
-			if route.Condition == nil {
+			if ep.Disabled || route.Disabled {
+				referencedByDisabled[route.AppID] = true
 				continue
 			}
+			referenced[route.AppID] = true
+		}
+	}
+	for _, listener := range c.Listeners {
+		for _, appID := range []string{listener.GetNotFoundAppID(), listener.GetMethodNotAllowedAppID()} {
+			if appID != "" {
+				referenced[appID] = true
+			}
+		}
+	}
+	for app := range c.Apps.All() {
+		if comp, ok := app.Config.(*composite.CompositeScript); ok {
+			for _, scriptAppID := range comp.ScriptAppIDs {
+				referenced[scriptAppID] = true
+			}
+		}
+	}
 
-			// Generate a condition key in the format "type:value"
-			conditionKey := fmt.Sprintf(
-				"%s:%s",
-				route.Condition.Type(),
-				route.Condition.Value(),
-			)
-
-			// Check if this condition is already used on this listener
-			if existingEndpointID, exists := routeMap[listenerID][conditionKey]; exists {
-				errs = append(errs, fmt.Errorf(
-					"condition '%s' on listener '%s' is used by both endpoint '%s' and '%s'",
-					conditionKey,
-					listenerID,
-					existingEndpointID,
-					ep.ID,
-				))
-			} else {
-				// Register this condition
-				routeMap[listenerID][conditionKey] = ep.ID
+	var errs []error
+	for app := range c.Apps.All() {
+		if app.ID == "" || referenced[app.ID] {
+			continue
+		}
+		if referencedByDisabled[app.ID] {
+			errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID, fmt.Errorf(
+				"%w: app '%s' is only referenced by disabled routes", ErrOrphanedApp, app.ID)))
+			continue
+		}
+		errs = append(errs, validation.ForComponent(validation.ComponentApp, app.ID, fmt.Errorf(
+			"%w: app '%s'", ErrOrphanedApp, app.ID)))
+	}
+	return errs
+}
+
+// declaredRoute is a route together with the endpoint it's declared in
+type declaredRoute struct {
+	endpoint *endpoints.Endpoint
+	index    int
+	route    *routes.Route
+
+	// order is the route's position among the routes declared on its listener
+	order int
+}
+
+// id identifies the route in validation issues
+func (d declaredRoute) id() string {
+	return d.endpoint.RouteID(d.index)
+}
+
+// String describes the route in validation messages
+func (d declaredRoute) String() string {
+	return fmt.Sprintf("endpoint '%s' route %d (%s)", d.endpoint.ID, d.index, d.route.ConditionKey())
+}
+
+// declaredRoutesByListener returns the enabled routes with a condition
+// declared on each listener by enabled endpoints, in declaration order, and the IDs of those listeners in the
+// order they're first used
+func (c *Config) declaredRoutesByListener() ([]string, map[string][]declaredRoute) {
+	var listenerIDs []string
+	byListener := make(map[string][]declaredRoute)
+	for i := range c.Endpoints {
+		ep := &c.Endpoints[i]
+		if ep.Disabled {
+			continue
+		}
+		for _, listenerID := range ep.AllListenerIDs() {
+			if _, seen := byListener[listenerID]; !seen {
+				listenerIDs = append(listenerIDs, listenerID)
+			}
+			for j := range ep.Routes {
+				if ep.Routes[j].Disabled || ep.Routes[j].Condition == nil {
+					continue
+				}
+				byListener[listenerID] = append(byListener[listenerID], declaredRoute{
+					endpoint: ep,
+					index:    j,
+					route:    &ep.Routes[j],
+					order:    len(byListener[listenerID]),
+				})
 			}
 		}
 	}
+	return listenerIDs, byListener
+}
 
-	return errors.Join(errs...)
+// validateUnreachableRoutes returns an error for each route that can never be
+// selected because another route on the same listener is tried first and
+// matches every request it would: one with the same path condition whose
+// request conditions are a subset of its own (see triedBefore for the order
+// routes are tried in).
+func (c *Config) validateUnreachableRoutes() []error {
+	listenerIDs, byListener := c.declaredRoutesByListener()
+
+	var errs []error
+	for _, listenerID := range listenerIDs {
+		declared := byListener[listenerID]
+		for _, shadowed := range declared {
+			for _, other := range declared {
+				if other.order == shadowed.order || !triedBefore(other, shadowed) ||
+					!covers(other.route, shadowed.route) {
+					continue
+				}
+				errs = append(errs, validation.ForComponent(validation.ComponentRoute, shadowed.id(), fmt.Errorf(
+					"%w: %s is shadowed by %s", ErrUnreachableRoute, shadowed, other)))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// validateAmbiguousRoutes returns an error for each pair of routes on the same
+// listener that only their declaration order decides between: they have the
+// same path condition, priority and specificity, and a request could match
+// both. Setting a priority on either makes the order explicit.
+func (c *Config) validateAmbiguousRoutes() []error {
+	listenerIDs, byListener := c.declaredRoutesByListener()
+
+	var errs []error
+	for _, listenerID := range listenerIDs {
+		declared := byListener[listenerID]
+		for j, later := range declared {
+			for _, earlier := range declared[:j] {
+				if !samePathCondition(earlier.route, later.route) ||
+					earlier.route.ConditionKey() == later.route.ConditionKey() ||
+					earlier.route.Priority != later.route.Priority ||
+					routeRank(earlier.route) != routeRank(later.route) ||
+					earlier.route.Conditions.Excludes(later.route.Conditions) ||
+					covers(earlier.route, later.route) || covers(later.route, earlier.route) {
+					continue
+				}
+				errs = append(errs, validation.ForComponent(validation.ComponentRoute, later.id(), fmt.Errorf(
+					"%w: %s and %s have priority %d and can match the same requests, so the one declared first is used",
+					ErrAmbiguousRouteOrder, earlier, later, later.route.Priority)))
+			}
+		}
+	}
+	return errs
+}
+
+// triedBefore reports whether the HTTP listener tries a before b when both are
+// registered on the same path: routes with a higher priority first, then the
+// most specific (see routeRank), then the one declared first
+func triedBefore(a, b declaredRoute) bool {
+	if a.route.Priority != b.route.Priority {
+		return a.route.Priority > b.route.Priority
+	}
+	if rankA, rankB := routeRank(a.route), routeRank(b.route); rankA != rankB {
+		return rankA < rankB
+	}
+	return a.order < b.order
+}
+
+// routeRank orders routes sharing a path and priority from most to least
+// specific: regex routes with request conditions, regex routes, prefix routes
+// with request conditions, then unconditional prefix routes
+func routeRank(r *routes.Route) int {
+	rank := 0
+	if _, isRegex := r.Condition.(*conditions.HTTPRegex); !isRegex {
+		rank += 2
+	}
+	if len(r.Conditions) == 0 {
+		rank++
+	}
+	return rank
+}
+
+// samePathCondition reports whether both routes have the same path condition
+func samePathCondition(a, b *routes.Route) bool {
+	return a.Condition.Type() == b.Condition.Type() && a.Condition.Value() == b.Condition.Value()
+}
+
+// covers reports whether route a matches every request route b does: it has
+// the same path condition and a subset of b's request conditions. Routes with
+// the same condition key are reported as conflicts instead.
+func covers(a, b *routes.Route) bool {
+	if !samePathCondition(a, b) || a.ConditionKey() == b.ConditionKey() {
+		return false
+	}
+
+	bKeys := make(map[string]bool, len(b.Conditions))
+	for _, cond := range b.Conditions {
+		if cond != nil {
+			bKeys[fmt.Sprintf("%s:%s", cond.Type(), cond.Value())] = true
+		}
+	}
+	for _, cond := range a.Conditions {
+		if cond == nil || !bKeys[fmt.Sprintf("%s:%s", cond.Type(), cond.Value())] {
+			return false
+		}
+	}
+	return true
 }
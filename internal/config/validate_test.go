@@ -2,19 +2,24 @@ package config
 
 import (
 	"embed"
+	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/apps"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/calculation"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/composite"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/echo"
-	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
-	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/fileread"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+	"github.com/atlanticdynamic/firelynx/internal/config/errz"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -137,23 +142,6 @@ func TestValidateListeners(t *testing.T) {
 		assert.True(t, listenerIDs["http2"])
 	})
 
-	// Test with duplicate ID
-	t.Run("Duplicate listener IDs", func(t *testing.T) {
-		t.Parallel()
-		config := &Config{
-			Version: VersionLatest,
-			Listeners: listeners.ListenerCollection{
-				createHTTPListener("http1", "127.0.0.1:8080", 30*time.Second),
-				createHTTPListener("http1", "127.0.0.1:8081", 30*time.Second), // Duplicate ID
-			},
-		}
-		_, errs := config.validateListeners()
-		assert.NotEmpty(t, errs)
-
-		// Check for duplicate ID error
-		hasErrorContaining(t, errs, "duplicate ID: listener ID")
-	})
-
 	// Test with duplicate address
 	t.Run("Duplicate listener addresses", func(t *testing.T) {
 		t.Parallel()
@@ -225,41 +213,26 @@ func TestValidateEndpoints(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "Duplicate endpoint IDs",
+			name: "Invalid endpoint",
 			endpoints: endpoints.EndpointCollection{
 				{
-					ID:         "ep1",
+					ID:         "",
 					ListenerID: "http1",
 				},
-				{
-					ID:         "ep1", // Duplicate ID
-					ListenerID: "grpc1",
-				},
 			},
 			expectError: true,
 			errorCount:  1,
 		},
 		{
-			name: "Invalid listener reference",
+			// Reported by validateIDs
+			name: "Missing listener is skipped",
 			endpoints: endpoints.EndpointCollection{
 				{
 					ID:         "ep1",
-					ListenerID: "invalid1", // Not in validListenerIDs
+					ListenerID: "invalid1",
 				},
 			},
-			expectError: true,
-			errorCount:  1,
-		},
-		{
-			name: "Multiple invalid references",
-			endpoints: endpoints.EndpointCollection{
-				{
-					ID:         "ep1",
-					ListenerID: "invalid1", // Invalid
-				},
-			},
-			expectError: true,
-			errorCount:  1,
+			expectError: false,
 		},
 	}
 
@@ -287,7 +260,7 @@ func TestValidateEndpoints(t *testing.T) {
 	}
 }
 
-func TestValidateAppsAndRoutes(t *testing.T) {
+func TestValidateRouteConflicts(t *testing.T) {
 	t.Parallel()
 
 	testCases := []struct {
@@ -296,160 +269,69 @@ func TestValidateAppsAndRoutes(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Valid apps and routes",
+			name: "No conflicts",
 			setupConfig: func() *Config {
 				return &Config{
 					Version: VersionLatest,
-					Apps: apps.NewAppCollection(
-						apps.App{
-							ID: "echo-app",
-							Config: func() *echo.EchoApp {
-								app := echo.New("echo-app")
-								app.Response = "Hello"
-								return app
-							}(),
-						},
-					),
 					Endpoints: endpoints.EndpointCollection{
 						{
 							ID:         "ep1",
 							ListenerID: "l1",
 							Routes: routes.RouteCollection{
 								{
-									AppID:     "echo-app", // References existing app
-									Condition: conditions.NewHTTP("/api", "GET"),
+									AppID:     "app1",
+									Condition: conditions.NewHTTP("/path1", ""),
 								},
 							},
 						},
-					},
-				}
-			},
-			expectError: false,
-		},
-		{
-			name: "Invalid app reference",
-			setupConfig: func() *Config {
-				return &Config{
-					Version: VersionLatest,
-					Apps: apps.NewAppCollection(
-						apps.App{
-							ID: "echo-app",
-							Config: func() *echo.EchoApp {
-								app := echo.New("echo-app")
-								app.Response = "Hello"
-								return app
-							}(),
-						},
-					),
-					Endpoints: endpoints.EndpointCollection{
 						{
-							ID:         "ep1",
+							ID:         "ep2",
 							ListenerID: "l1",
 							Routes: routes.RouteCollection{
 								{
-									AppID:     "non-existent-app", // References non-existent app
-									Condition: conditions.NewHTTP("/api", "GET"),
+									AppID:     "app2",
+									Condition: conditions.NewHTTP("/path2", ""), // Different path
 								},
 							},
 						},
 					},
 				}
 			},
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name: "Empty app ID in route",
+			name: "Same path on different listeners",
 			setupConfig: func() *Config {
 				return &Config{
 					Version: VersionLatest,
-					Apps: apps.NewAppCollection(
-						apps.App{
-							ID: "echo-app",
-							Config: func() *echo.EchoApp {
-								app := echo.New("echo-app")
-								app.Response = "Hello"
-								return app
-							}(),
-						},
-					),
 					Endpoints: endpoints.EndpointCollection{
 						{
 							ID:         "ep1",
 							ListenerID: "l1",
 							Routes: routes.RouteCollection{
 								{
-									AppID:     "", // Empty app ID - should be caught during endpoint validation
+									AppID:     "app1",
 									Condition: conditions.NewHTTP("/api", "GET"),
 								},
 							},
 						},
-					},
-				}
-			},
-			expectError: false, // ValidateAppsAndRoutes currently doesn't validate empty route appIDs, this is done elsewhere
-		},
-		{
-			name: "Invalid app config",
-			setupConfig: func() *Config {
-				// Create an invalid script with empty code and negative timeout, which will fail validation
-				invalidScript := func() *scripts.AppScript {
-					app := scripts.NewAppScript("invalid-script")
-					app.Evaluator = &evaluators.RisorEvaluator{Code: "", Timeout: -1 * time.Second}
-					return app
-				}()
-				return &Config{
-					Version: VersionLatest,
-					Apps: apps.NewAppCollection(
-						apps.App{
-							ID:     "invalid-app",
-							Config: invalidScript,
-						},
-					),
-					Endpoints: endpoints.EndpointCollection{
 						{
-							ID:         "ep1",
-							ListenerID: "l1",
+							ID:         "ep2",
+							ListenerID: "l2", // Different listener
 							Routes: routes.RouteCollection{
 								{
-									AppID:     "invalid-app",
-									Condition: conditions.NewHTTP("/api", "GET"),
+									AppID:     "app2",
+									Condition: conditions.NewHTTP("/api", "GET"), // Same path is OK
 								},
 							},
 						},
 					},
 				}
 			},
-			expectError: true,
+			expectError: false,
 		},
-	}
-
-	for _, tc := range testCases {
-		tc := tc // Capture range variable
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-
-			config := tc.setupConfig()
-			err := config.validateAppsAndRoutes()
-
-			if tc.expectError {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-			}
-		})
-	}
-}
-
-func TestValidateRouteConflicts(t *testing.T) {
-	t.Parallel()
-
-	testCases := []struct {
-		name        string
-		setupConfig func() *Config
-		expectError bool
-	}{
 		{
-			name: "No conflicts",
+			name: "Same path on a disabled route or endpoint",
 			setupConfig: func() *Config {
 				return &Config{
 					Version: VersionLatest,
@@ -460,7 +342,7 @@ func TestValidateRouteConflicts(t *testing.T) {
 							Routes: routes.RouteCollection{
 								{
 									AppID:     "app1",
-									Condition: conditions.NewHTTP("/path1", ""),
+									Condition: conditions.NewHTTP("/api", "GET"),
 								},
 							},
 						},
@@ -470,41 +352,22 @@ func TestValidateRouteConflicts(t *testing.T) {
 							Routes: routes.RouteCollection{
 								{
 									AppID:     "app2",
-									Condition: conditions.NewHTTP("/path2", ""), // Different path
+									Condition: conditions.NewHTTP("/api", "GET"),
+									Disabled:  true, // Never dispatched
 								},
 							},
 						},
-					},
-				}
-			},
-			expectError: false,
-		},
-		{
-			name: "Same path on different listeners",
-			setupConfig: func() *Config {
-				return &Config{
-					Version: VersionLatest,
-					Endpoints: endpoints.EndpointCollection{
 						{
-							ID:         "ep1",
+							ID:         "ep3",
 							ListenerID: "l1",
+							Disabled:   true, // Not wired onto l1
 							Routes: routes.RouteCollection{
 								{
-									AppID:     "app1",
+									AppID:     "app3",
 									Condition: conditions.NewHTTP("/api", "GET"),
 								},
 							},
 						},
-						{
-							ID:         "ep2",
-							ListenerID: "l2", // Different listener
-							Routes: routes.RouteCollection{
-								{
-									AppID:     "app2",
-									Condition: conditions.NewHTTP("/api", "GET"), // Same path is OK
-								},
-							},
-						},
 					},
 				}
 			},
@@ -622,6 +485,7 @@ func TestValidateRouteConflicts(t *testing.T) {
 				require.Error(t, err)
 				// The error contains condition conflict details
 				assert.Contains(t, err.Error(), "condition")
+				assert.Contains(t, err.Error(), "used by both route 'ep1[0]' and 'ep2[0]'")
 			} else {
 				require.NoError(t, err)
 			}
@@ -629,6 +493,189 @@ func TestValidateRouteConflicts(t *testing.T) {
 	}
 }
 
+func TestValidateRouteConflicts_Paths(t *testing.T) {
+	t.Parallel()
+
+	// newConfig returns a config with one route per path, the first two in
+	// endpoint ep1 and any others in ep2, all on the same listener
+	newConfig := func(paths ...string) *Config {
+		cfg := &Config{
+			Version: VersionLatest,
+			Endpoints: endpoints.EndpointCollection{
+				{ID: "ep1", ListenerID: "l1"},
+				{ID: "ep2", ListenerID: "l1"},
+			},
+		}
+		for i, path := range paths {
+			ep := &cfg.Endpoints[min(i/2, 1)]
+			ep.Routes = append(ep.Routes, routes.Route{
+				AppID:     "app",
+				Condition: conditions.NewHTTP(path, ""),
+			})
+		}
+		return cfg
+	}
+
+	testCases := []struct {
+		name        string
+		config      *Config
+		errContains []string
+	}{
+		{
+			name:   "nested prefixes",
+			config: newConfig("/api/", "/api/v1/", "/api", "/api/v1"),
+		},
+		{
+			name: "same path with different request conditions",
+			config: func() *Config {
+				cfg := newConfig("/api/", "/api/")
+				cfg.Endpoints[0].Routes[1].Conditions = conditions.Collection{conditions.NewMethod("GET")}
+				return cfg
+			}(),
+		},
+		{
+			name:   "more specific wildcard path",
+			config: newConfig("/users/{id}", "/users/me"),
+		},
+		{
+			name:   "differently named wildcards in the same endpoint",
+			config: newConfig("/users/{id}", "/users/{name}"),
+			errContains: []string{
+				"path '/users/{name}' of route 'ep1[1]' on listener 'l1' conflicts with path '/users/{id}' of route 'ep1[0]'",
+				"matches the same requests as",
+			},
+		},
+		{
+			name:   "overlapping wildcards in different endpoints",
+			config: newConfig("/", "/health", "/a/{x}/b", "/a/y/{z}"),
+			errContains: []string{
+				"path '/a/y/{z}' of route 'ep2[1]' on listener 'l1' conflicts with path '/a/{x}/b' of route 'ep2[0]'",
+				"neither is more specific than the other",
+			},
+		},
+		{
+			name: "different listeners",
+			config: func() *Config {
+				cfg := newConfig("/", "/users/{id}", "/users/{name}")
+				cfg.Endpoints[1].ListenerID = "l2"
+				return cfg
+			}(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.config.validateRouteConflicts()
+			if len(tc.errContains) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tc.errContains {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestValidateRouteConflicts_MCPServers(t *testing.T) {
+	t.Parallel()
+
+	// load returns a config with MCP servers alpha and beta and an echo app,
+	// served by the given routes on one listener
+	load := func(t *testing.T, routesTOML string) *Config {
+		t.Helper()
+		cfg, err := NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+` + routesTOML + `
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+
+[[apps]]
+id = "alpha"
+type = "mcp"
+[[apps.mcp.tools]]
+app_id = "echo"
+
+[[apps]]
+id = "beta"
+type = "mcp"
+[[apps.mcp.tools]]
+app_id = "echo"
+`))
+		require.NoError(t, err)
+		return cfg
+	}
+
+	t.Run("servers on distinct paths", func(t *testing.T) {
+		cfg := load(t, `
+[[endpoints.routes]]
+app_id = "alpha"
+[endpoints.routes.http]
+path_prefix = "/alpha/mcp"
+
+[[endpoints.routes]]
+app_id = "beta"
+[endpoints.routes.http]
+path_prefix = "/beta/mcp"
+`)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("one server on a path for several methods", func(t *testing.T) {
+		cfg := load(t, `
+[[endpoints.routes]]
+app_id = "alpha"
+methods = ["POST"]
+[endpoints.routes.http]
+path_prefix = "/mcp"
+
+[[endpoints.routes]]
+app_id = "alpha"
+methods = ["GET", "DELETE"]
+[endpoints.routes.http]
+path_prefix = "/mcp"
+`)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("servers on the same path", func(t *testing.T) {
+		cfg := load(t, `
+[[endpoints.routes]]
+app_id = "alpha"
+[endpoints.routes.http]
+path_prefix = "/mcp"
+
+[[endpoints.routes]]
+app_id = "beta"
+[endpoints.routes.http]
+path_prefix = "/mcp"
+[[endpoints.routes.headers]]
+name = "X-Server"
+value = "beta"
+match = "exact"
+`)
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrRouteConflict)
+		assert.Contains(t, err.Error(),
+			"MCP server app 'beta' of route 'main[1]' on listener 'http' shares path 'http_path:/mcp' with MCP server app 'alpha' of route 'main[0]'")
+	})
+}
+
 func TestConfig_Validate(t *testing.T) {
 	t.Parallel()
 
@@ -862,56 +909,6 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
-// Test the collectRouteReferences function separately
-func TestCollectRouteReferences(t *testing.T) {
-	t.Parallel()
-
-	config := &Config{
-		Version: VersionLatest,
-		Endpoints: endpoints.EndpointCollection{
-			{
-				ID:         "ep1",
-				ListenerID: "l1",
-				Routes: routes.RouteCollection{
-					{
-						AppID:     "app1",
-						Condition: conditions.NewHTTP("/path1", ""),
-					},
-					{
-						AppID:     "app2",
-						Condition: conditions.NewHTTP("/path2", ""),
-					},
-				},
-			},
-			{
-				ID:         "ep2",
-				ListenerID: "l2",
-				Routes: routes.RouteCollection{
-					{
-						AppID:     "app3",
-						Condition: conditions.NewHTTP("/path3", ""),
-					},
-				},
-			},
-		},
-	}
-
-	routeRefs := config.collectRouteReferences()
-
-	// Verify we get all route references
-	require.Len(t, routeRefs, 3)
-
-	// Create a map to check all app IDs are present
-	appIDs := map[string]bool{}
-	for _, ref := range routeRefs {
-		appIDs[ref.AppID] = true
-	}
-
-	assert.True(t, appIDs["app1"])
-	assert.True(t, appIDs["app2"])
-	assert.True(t, appIDs["app3"])
-}
-
 //go:embed testdata/invalid/*.toml
 var invalidConfigFiles embed.FS
 
@@ -953,3 +950,696 @@ func TestInvalidConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateWithIssues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid config has no issues", func(t *testing.T) {
+		cfg := &Config{Version: VersionLatest, Apps: apps.NewAppCollection()}
+		issues, err := cfg.ValidateWithIssues()
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		cfg := &Config{Version: "invalid", Apps: apps.NewAppCollection()}
+		issues, err := cfg.ValidateWithIssues()
+		require.ErrorIs(t, err, ErrUnsupportedConfigVer)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "version", issues[0].Field)
+		assert.Equal(t, validation.SeverityError, issues[0].Severity)
+		assert.Contains(t, issues[0].Message, "invalid")
+	})
+
+	t.Run("errors are attributed to their components", func(t *testing.T) {
+		cfg := &Config{
+			Version:        VersionLatest,
+			DefaultTimeout: -time.Second,
+			Apps:           apps.NewAppCollection(),
+			Listeners: listeners.ListenerCollection{
+				{
+					ID:      "http1",
+					Type:    listeners.TypeHTTP,
+					Options: options.HTTP{},
+				},
+			},
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "missing",
+					Routes: routes.RouteCollection{
+						{AppID: "echo-app"},
+					},
+				},
+			},
+		}
+
+		issues, err := cfg.ValidateWithIssues()
+		require.ErrorIs(t, err, ErrFailedToValidateConfig)
+
+		// The listener has no endpoints, as its endpoint names another
+		// listener, which is reported last as a warning
+		require.NotEmpty(t, issues)
+		warning := issues[len(issues)-1]
+		assert.Equal(t, validation.SeverityWarning, warning.Severity)
+		assert.Equal(t, validation.ComponentListener, warning.ComponentType)
+		assert.Equal(t, "http1", warning.ComponentID)
+		issues = issues[:len(issues)-1]
+		for _, issue := range issues {
+			assert.Equal(t, validation.SeverityError, issue.Severity)
+		}
+
+		// find returns the messages of the issues found in a component field
+		find := func(componentType, componentID, field string) []string {
+			var messages []string
+			for _, issue := range issues {
+				if issue.ComponentType == componentType && issue.ComponentID == componentID &&
+					issue.Field == field {
+					messages = append(messages, issue.Message)
+				}
+			}
+			return messages
+		}
+
+		assert.Len(t, find("", "", "default_timeout"), 1)
+		assert.Len(t, find(validation.ComponentListener, "http1", "address"), 1)
+		assert.Contains(t, find(validation.ComponentListener, "http1", "http"),
+			"invalid value: HTTP read timeout must be positive",
+			"joined option errors should be reported separately")
+		assert.Equal(t, []string{"missing required field: route condition"},
+			find(validation.ComponentRoute, "ep1[0]", ""))
+		assert.Len(t, find(validation.ComponentEndpoint, "ep1", "listener_id"), 1)
+
+		for _, issue := range issues {
+			assert.Contains(t, err.Error(), issue.Message, "issues should match the validation error")
+		}
+	})
+}
+
+func TestValidateUnusedListeners(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Listeners: listeners.ListenerCollection{
+			{ID: "used", Address: ":8080", Type: listeners.TypeHTTP},
+			{ID: "idle", Address: ":8081", Type: listeners.TypeHTTP},
+		},
+		Endpoints: endpoints.EndpointCollection{
+			{ID: "ep1", ListenerID: "used"},
+		},
+	}
+
+	warnings := cfg.validateUnusedListeners()
+	require.Len(t, warnings, 1)
+	require.ErrorIs(t, warnings[0], ErrUnusedListener)
+	assert.Contains(t, warnings[0].Error(), "listener 'idle' on :8081")
+
+	issues := validation.Issues(warnings[0], validation.SeverityWarning)
+	require.Len(t, issues, 1)
+	assert.Equal(t, validation.ComponentListener, issues[0].ComponentType)
+	assert.Equal(t, "idle", issues[0].ComponentID)
+}
+
+func TestValidateOrphanedApps(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Apps: apps.NewAppCollection(
+			apps.App{ID: "routed", Config: echo.New("routed")},
+			apps.App{ID: "script", Config: echo.New("script")},
+			apps.App{
+				ID:     "pipeline",
+				Config: composite.NewCompositeScript([]string{"script"}, nil),
+			},
+			apps.App{ID: "orphan", Config: echo.New("orphan")},
+		),
+		Endpoints: endpoints.EndpointCollection{
+			{
+				ID:         "ep1",
+				ListenerID: "l1",
+				Routes: routes.RouteCollection{
+					{AppID: "routed", Condition: conditions.NewHTTP("/a", "")},
+					{AppID: "pipeline", Condition: conditions.NewHTTP("/b", "")},
+				},
+			},
+		},
+	}
+
+	warnings := cfg.validateOrphanedApps()
+	require.Len(t, warnings, 1, "apps used by routes or composite scripts are referenced")
+	require.ErrorIs(t, warnings[0], ErrOrphanedApp)
+	assert.Contains(t, warnings[0].Error(), "app 'orphan'")
+
+	issues := validation.Issues(warnings[0], validation.SeverityWarning)
+	require.Len(t, issues, 1)
+	assert.Equal(t, validation.ComponentApp, issues[0].ComponentType)
+	assert.Equal(t, "orphan", issues[0].ComponentID)
+
+	t.Run("nil apps", func(t *testing.T) {
+		assert.Empty(t, (&Config{}).validateOrphanedApps())
+	})
+
+	t.Run("apps only referenced by disabled routes", func(t *testing.T) {
+		cfg := &Config{
+			Apps: apps.NewAppCollection(
+				apps.App{ID: "routed", Config: echo.New("routed")},
+				apps.App{ID: "disabled-route", Config: echo.New("disabled-route")},
+				apps.App{ID: "disabled-endpoint", Config: echo.New("disabled-endpoint")},
+			),
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "routed", Condition: conditions.NewHTTP("/a", "")},
+						{AppID: "routed", Condition: conditions.NewHTTP("/b", ""), Disabled: true},
+						{AppID: "disabled-route", Condition: conditions.NewHTTP("/c", ""), Disabled: true},
+					},
+				},
+				{
+					ID:         "ep2",
+					ListenerID: "l1",
+					Disabled:   true,
+					Routes: routes.RouteCollection{
+						{AppID: "disabled-endpoint", Condition: conditions.NewHTTP("/d", "")},
+					},
+				},
+			},
+		}
+
+		warnings := cfg.validateOrphanedApps()
+		require.Len(t, warnings, 2)
+		for i, appID := range []string{"disabled-route", "disabled-endpoint"} {
+			require.ErrorIs(t, warnings[i], ErrOrphanedApp)
+			assert.Contains(t, warnings[i].Error(),
+				"app '"+appID+"' is only referenced by disabled routes")
+		}
+	})
+}
+
+func TestValidateUnreachableRoutes(t *testing.T) {
+	t.Parallel()
+
+	getOnly := func() conditions.Collection {
+		return conditions.Collection{conditions.NewMethod("GET")}
+	}
+	getWithHeader := func() conditions.Collection {
+		return conditions.Collection{
+			conditions.NewHeader("X-Version", "2", conditions.MatchExact),
+			conditions.NewMethod("GET"),
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		endpoints endpoints.EndpointCollection
+		shadowed  []string
+	}{
+		{
+			name: "subset of conditions declared first",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getWithHeader()},
+					},
+				},
+			},
+			shadowed: []string{"ep1[1]"},
+		},
+		{
+			name: "subset of conditions declared in an earlier endpoint",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTPRegex("/users/[0-9]+", ""), Conditions: getOnly()},
+					},
+				},
+				{
+					ID:         "ep2",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "b", Condition: conditions.NewHTTPRegex("/users/[0-9]+", ""), Conditions: getWithHeader()},
+					},
+				},
+			},
+			shadowed: []string{"ep2[0]"},
+		},
+		{
+			name: "more specific route declared first",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getWithHeader()},
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+					},
+				},
+			},
+		},
+		{
+			name: "unconditional route is tried last",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", "")},
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+					},
+				},
+			},
+		},
+		{
+			name: "different paths",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+						{AppID: "b", Condition: conditions.NewHTTP("/v2", ""), Conditions: getWithHeader()},
+					},
+				},
+			},
+		},
+		{
+			name: "different listeners",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+					},
+				},
+				{
+					ID:         "ep2",
+					ListenerID: "l2",
+					Routes: routes.RouteCollection{
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getWithHeader()},
+					},
+				},
+			},
+		},
+		{
+			name: "higher priority unconditional route",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Priority: 1},
+					},
+				},
+			},
+			shadowed: []string{"ep1[0]"},
+		},
+		{
+			name: "priority puts the more specific route first",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getWithHeader(), Priority: 1},
+					},
+				},
+			},
+		},
+		{
+			name: "identical routes are conflicts instead",
+			endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "l1",
+					Routes: routes.RouteCollection{
+						{AppID: "a", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+						{AppID: "b", Condition: conditions.NewHTTP("/api", ""), Conditions: getOnly()},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{Endpoints: tc.endpoints}
+			warnings := cfg.validateUnreachableRoutes()
+
+			var shadowed []string
+			for _, warning := range warnings {
+				require.ErrorIs(t, warning, ErrUnreachableRoute)
+				issues := validation.Issues(warning, validation.SeverityWarning)
+				require.Len(t, issues, 1)
+				assert.Equal(t, validation.ComponentRoute, issues[0].ComponentType)
+				shadowed = append(shadowed, issues[0].ComponentID)
+			}
+			assert.Equal(t, tc.shadowed, shadowed)
+		})
+	}
+
+	t.Run("message names the shadowing route", func(t *testing.T) {
+		cfg := &Config{Endpoints: testCases[0].endpoints}
+		warnings := cfg.validateUnreachableRoutes()
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0].Error(), "endpoint 'ep1' route 1")
+		assert.Contains(t, warnings[0].Error(), "is shadowed by endpoint 'ep1' route 0")
+	})
+}
+
+func TestValidateAmbiguousRoutes(t *testing.T) {
+	t.Parallel()
+
+	version := func(v string) *conditions.Header {
+		return conditions.NewHeader("X-Version", v, conditions.MatchExact)
+	}
+
+	testCases := []struct {
+		name      string
+		routes    routes.RouteCollection
+		ambiguous []string
+	}{
+		{
+			name: "overlapping conditions at the same priority",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET")}},
+				{AppID: "b", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{version("2")}},
+			},
+			ambiguous: []string{"ep1[1]"},
+		},
+		{
+			name: "overlapping conditions at different priorities",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET")}},
+				{AppID: "b", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{version("2")}, Priority: 5},
+			},
+		},
+		{
+			name: "exclusive conditions",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{version("1")}},
+				{AppID: "b", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{version("2")}},
+				{AppID: "c", Condition: conditions.NewHTTP("/api", "")},
+			},
+		},
+		{
+			name: "specificity decides",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTPRegex("/api/[a-z]+", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET")}},
+				{AppID: "b", Condition: conditions.NewHTTPRegex("/api/[a-z]+", "")},
+			},
+		},
+		{
+			name: "shadowed routes are reported as unreachable instead",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET")}},
+				{AppID: "b", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET"), version("2")}},
+			},
+		},
+		{
+			name: "different paths",
+			routes: routes.RouteCollection{
+				{AppID: "a", Condition: conditions.NewHTTP("/api", ""),
+					Conditions: conditions.Collection{conditions.NewMethod("GET")}},
+				{AppID: "b", Condition: conditions.NewHTTP("/v2", ""),
+					Conditions: conditions.Collection{version("2")}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{Endpoints: endpoints.EndpointCollection{
+				{ID: "ep1", ListenerID: "l1", Routes: tc.routes},
+			}}
+			warnings := cfg.validateAmbiguousRoutes()
+
+			var ambiguous []string
+			for _, warning := range warnings {
+				require.ErrorIs(t, warning, ErrAmbiguousRouteOrder)
+				issues := validation.Issues(warning, validation.SeverityWarning)
+				require.Len(t, issues, 1)
+				assert.Equal(t, validation.ComponentRoute, issues[0].ComponentType)
+				ambiguous = append(ambiguous, issues[0].ComponentID)
+			}
+			assert.Equal(t, tc.ambiguous, ambiguous)
+		})
+	}
+
+	t.Run("message names both routes", func(t *testing.T) {
+		cfg := &Config{Endpoints: endpoints.EndpointCollection{
+			{ID: "ep1", ListenerID: "l1", Routes: testCases[0].routes},
+		}}
+		warnings := cfg.validateAmbiguousRoutes()
+		require.Len(t, warnings, 1)
+		assert.Contains(t, warnings[0].Error(),
+			"endpoint 'ep1' route 0 (http_path:/api && http_method:GET) and endpoint 'ep1' route 1")
+		assert.Contains(t, warnings[0].Error(), "have priority 0")
+	})
+}
+
+func TestConfig_ValidationWarnings(t *testing.T) {
+	t.Parallel()
+
+	newEcho := func(id string) *echo.EchoApp {
+		app := echo.New(id)
+		app.Response = "Hello"
+		return app
+	}
+	newConfig := func(strict bool) *Config {
+		return &Config{
+			Version:          VersionLatest,
+			StrictValidation: strict,
+			Apps: apps.NewAppCollection(
+				apps.App{ID: "echo-app", Config: newEcho("echo-app")},
+				apps.App{ID: "unused-app", Config: newEcho("unused-app")},
+			),
+			Listeners: listeners.ListenerCollection{
+				{ID: "http", Address: ":8080", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+				{ID: "idle", Address: ":8081", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+			},
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "ep1",
+					ListenerID: "http",
+					Routes: routes.RouteCollection{
+						{AppID: "echo-app", Condition: conditions.NewHTTP("/api", "")},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("warnings don't fail validation", func(t *testing.T) {
+		cfg := newConfig(false)
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.ValidationWarnings, 2)
+		assert.ErrorIs(t, cfg.ValidationWarnings[0], ErrUnusedListener)
+		assert.ErrorIs(t, cfg.ValidationWarnings[1], ErrOrphanedApp)
+	})
+
+	t.Run("warnings fail validation in strict mode", func(t *testing.T) {
+		cfg := newConfig(true)
+		err := cfg.Validate()
+		require.ErrorIs(t, err, ErrFailedToValidateConfig)
+		require.ErrorIs(t, err, ErrUnusedListener)
+		require.ErrorIs(t, err, ErrOrphanedApp)
+		assert.Empty(t, cfg.ValidationWarnings)
+	})
+	t.Run("loader warnings are reported", func(t *testing.T) {
+		cfg := newConfig(false)
+		cfg.Apps = apps.NewAppCollection(apps.App{ID: "echo-app", Config: newEcho("echo-app")})
+		cfg.Listeners = cfg.Listeners[:1]
+		deprecated := errors.New("deprecated form")
+		cfg.LoadWarnings = []error{deprecated}
+
+		require.NoError(t, cfg.Validate())
+		require.Len(t, cfg.ValidationWarnings, 1)
+		assert.ErrorIs(t, cfg.ValidationWarnings[0], deprecated)
+
+		// Validating again doesn't report them twice
+		require.NoError(t, cfg.Validate())
+		assert.Len(t, cfg.ValidationWarnings, 1)
+	})
+}
+
+func TestValidateTemplateRoutes(t *testing.T) {
+	t.Parallel()
+
+	configWithRoutes := func(routes string) []byte {
+		return []byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[apps]]
+id = "pages"
+type = "template"
+[apps.template]
+inline = { "layout" = "<main>{{block \"content\" .}}{{end}}</main>", "users" = "{{template \"layout\" .}}" }
+
+[[endpoints]]
+id = "admin"
+listener_id = "http"
+` + routes)
+	}
+
+	t.Run("routes name defined templates", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "pages"
+static_data = { template = "users" }
+[endpoints.routes.http]
+path_prefix = "/users"
+`))
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+	})
+
+	t.Run("route names an undefined template", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "pages"
+static_data = { template = "jobs" }
+[endpoints.routes.http]
+path_prefix = "/jobs"
+`))
+		require.NoError(t, err)
+
+		issues, err := cfg.ValidateWithIssues()
+		require.Error(t, err)
+		require.ErrorIs(t, err, errz.ErrInvalidValue)
+		assert.Contains(t, err.Error(), `template "jobs" is not defined`)
+		require.Len(t, issues, 1)
+		assert.Equal(t, validation.ComponentRoute, issues[0].ComponentType)
+		assert.Equal(t, "admin[0]", issues[0].ComponentID)
+	})
+
+	t.Run("route without a template", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "pages"
+[endpoints.routes.http]
+path_prefix = "/users"
+`))
+		require.NoError(t, err)
+
+		err = cfg.Validate()
+		require.Error(t, err)
+		require.ErrorIs(t, err, errz.ErrMissingRequiredField)
+	})
+}
+
+func TestValidateRouteAppCopies_BuiltinApps(t *testing.T) {
+	t.Parallel()
+	baseDir := t.TempDir()
+	docsDir := t.TempDir()
+
+	configWithRoutes := func(routes string) []byte {
+		return []byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[apps]]
+id = "files"
+type = "fileread"
+[apps.fileread]
+base_directory = "` + baseDir + `"
+
+[[apps]]
+id = "calc"
+type = "calculation"
+[apps.calculation]
+static_data = { constants = { vat = 0.2 } }
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+` + routes)
+	}
+
+	t.Run("route static data sets the settings", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "files"
+static_data = { base_directory = "` + docsDir + `" }
+[endpoints.routes.http]
+path_prefix = "/docs"
+
+[[endpoints.routes]]
+app_id = "calc"
+static_data = { constants = { fee = 3 } }
+[endpoints.routes.http]
+path_prefix = "/calc"
+`))
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+
+		files, ok := cfg.Endpoints[0].Routes[0].App.Config.(*fileread.App)
+		require.True(t, ok)
+		assert.Equal(t, docsDir, files.EffectiveBaseDirectory())
+
+		calc, ok := cfg.Endpoints[0].Routes[1].App.Config.(*calculation.App)
+		require.True(t, ok)
+		constants, err := calc.Constants()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{"fee": 3}, constants, "route data replaces the app's constants table")
+	})
+
+	t.Run("route sets an unusable base directory", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "files"
+static_data = { base_directory = "` + filepath.Join(docsDir, "missing") + `" }
+[endpoints.routes.http]
+path_prefix = "/docs"
+`))
+		require.NoError(t, err)
+
+		issues, err := cfg.ValidateWithIssues()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fileread base_directory is unusable")
+		require.NotEmpty(t, issues, "the unreferenced calc app adds a warning")
+		assert.Equal(t, validation.ComponentRoute, issues[0].ComponentType)
+		assert.Equal(t, "main[0]", issues[0].ComponentID)
+	})
+
+	t.Run("route sets invalid constants", func(t *testing.T) {
+		cfg, err := NewConfigFromBytes(configWithRoutes(`
+[[endpoints.routes]]
+app_id = "calc"
+static_data = { constants = "vat" }
+[endpoints.routes.http]
+path_prefix = "/calc"
+`))
+		require.NoError(t, err)
+
+		err = cfg.Validate()
+		require.Error(t, err)
+		require.ErrorIs(t, err, errz.ErrInvalidValue)
+	})
+}
@@ -0,0 +1,185 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// Severity is how serious a validation issue is
+type Severity string
+
+const (
+	// SeverityError makes the config invalid
+	SeverityError Severity = "error"
+
+	// SeverityWarning is reported without making the config invalid
+	SeverityWarning Severity = "warning"
+)
+
+// Component types used to attribute validation errors
+const (
+	ComponentListener   = "listener"
+	ComponentEndpoint   = "endpoint"
+	ComponentRoute      = "route"
+	ComponentMiddleware = "middleware"
+	ComponentApp        = "app"
+)
+
+// Issue is a single problem found while validating a config
+type Issue struct {
+	// ComponentType is the type of the component the issue was found in,
+	// empty for server-wide settings
+	ComponentType string `json:"componentType,omitempty"`
+
+	// ComponentID is the ID of the component the issue was found in
+	ComponentID string `json:"componentId,omitempty"`
+
+	// Field is the config field the issue was found in, when known
+	Field string `json:"field,omitempty"`
+
+	// Message describes the issue
+	Message string `json:"message"`
+
+	// Severity is how serious the issue is
+	Severity Severity `json:"severity"`
+}
+
+// ComponentError attributes a validation error to the config component, and
+// optionally the field, it was found in. Its message is that of the wrapped
+// error, so attributing an error doesn't change how it reads.
+type ComponentError struct {
+	// Type is the component type, or empty when only Field is set
+	Type string
+
+	// ID is the component ID
+	ID string
+
+	// Field is the config field, or empty when the error covers the whole
+	// component
+	Field string
+
+	Err error
+}
+
+func (e *ComponentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ComponentError) Unwrap() error {
+	return e.Err
+}
+
+// ForComponent attributes err to a component. It returns nil when err is nil.
+func ForComponent(componentType, id string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ComponentError{Type: componentType, ID: id, Err: err}
+}
+
+// ForField attributes err to a field of the component it's found in. It
+// returns nil when err is nil.
+func ForField(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ComponentError{Field: field, Err: err}
+}
+
+// Issues flattens a validation error into one issue per error it joins. Each
+// issue is attributed to the innermost component and field wrapping it, see
+// ComponentError. An error wrapping joined or attributed errors is skipped
+// over in favor of them, except that an error made with several %w verbs is
+// only skipped when it holds attributed errors, since it often adds context
+// the errors it wraps lack.
+func Issues(err error, severity Severity) []Issue {
+	var issues []Issue
+	collectIssues(err, Issue{Severity: severity}, &issues)
+	return issues
+}
+
+// collectIssues appends the issues of err to issues, attributed to the
+// component and field in ctx unless err names its own
+func collectIssues(err error, ctx Issue, issues *[]Issue) {
+	if err == nil {
+		return
+	}
+
+	if ce, ok := err.(*ComponentError); ok {
+		if ce.Type != "" {
+			ctx.ComponentType = ce.Type
+			ctx.ComponentID = ce.ID
+			ctx.Field = ""
+		}
+		if ce.Field != "" {
+			ctx.Field = ce.Field
+		}
+		collectIssues(ce.Err, ctx, issues)
+		return
+	}
+
+	if children := issueChildren(err); children != nil {
+		for _, child := range children {
+			collectIssues(child, ctx, issues)
+		}
+		return
+	}
+
+	ctx.Message = err.Error()
+	*issues = append(*issues, ctx)
+}
+
+// issueChildren returns the errors to report in place of err, or nil when err
+// is reported as it is
+func issueChildren(err error) []error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		if isJoin(e) {
+			return e.Unwrap()
+		}
+		var children []error
+		for _, child := range e.Unwrap() {
+			if hasComponentError(child) {
+				children = append(children, child)
+			}
+		}
+		return children
+	case interface{ Unwrap() error }:
+		if child := e.Unwrap(); hasComponentError(child) || wrapsJoin(child) {
+			return []error{child}
+		}
+	}
+	return nil
+}
+
+// isJoin reports whether err was made by errors.Join, whose message is the
+// messages of its errors on separate lines, rather than by fmt.Errorf with
+// several %w verbs
+func isJoin(err interface{ Unwrap() []error }) bool {
+	children := err.Unwrap()
+	messages := make([]string, 0, len(children))
+	for _, child := range children {
+		if child != nil {
+			messages = append(messages, child.Error())
+		}
+	}
+	return err.(error).Error() == strings.Join(messages, "\n")
+}
+
+// wrapsJoin reports whether err is a join, or wraps one through errors made
+// with a single %w verb
+func wrapsJoin(err error) bool {
+	for err != nil {
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			return isJoin(multi)
+		}
+		err = errors.Unwrap(err)
+	}
+	return false
+}
+
+// hasComponentError reports whether err wraps a ComponentError
+func hasComponentError(err error) bool {
+	var ce *ComponentError
+	return errors.As(err, &ce)
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComponentError(t *testing.T) {
+	t.Parallel()
+
+	errBase := errors.New("base error")
+	err := ForComponent(ComponentListener, "http", ForField("address", errBase))
+
+	assert.Equal(t, "base error", err.Error(), "attribution should not change the message")
+	require.ErrorIs(t, err, errBase)
+
+	var ce *ComponentError
+	require.ErrorAs(t, err, &ce)
+	assert.Equal(t, ComponentListener, ce.Type)
+	assert.Equal(t, "http", ce.ID)
+
+	assert.NoError(t, ForComponent(ComponentListener, "http", nil))
+	assert.NoError(t, ForField("address", nil))
+}
+
+func TestIssues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil error", func(t *testing.T) {
+		assert.Empty(t, Issues(nil, SeverityError))
+	})
+
+	t.Run("unattributed error", func(t *testing.T) {
+		issues := Issues(errors.New("broken"), SeverityWarning)
+		assert.Equal(t, []Issue{{Message: "broken", Severity: SeverityWarning}}, issues)
+	})
+
+	t.Run("joined errors are reported separately", func(t *testing.T) {
+		listenerErr := ForComponent(ComponentListener, "http", fmt.Errorf("listener at index 0: %w",
+			errors.Join(
+				ForField("address", errors.New("address is missing")),
+				errors.New("type is missing"),
+			)))
+		endpointErr := ForComponent(ComponentEndpoint, "api", ForComponent(ComponentRoute, "api[1]",
+			ForField("condition", errors.New("path is empty"))))
+		err := fmt.Errorf("%w: %w", errors.New("failed to validate config"),
+			errors.Join(listenerErr, endpointErr, ForField("version", errors.New("bad version"))))
+
+		assert.Equal(t, []Issue{
+			{
+				ComponentType: ComponentListener,
+				ComponentID:   "http",
+				Field:         "address",
+				Message:       "address is missing",
+				Severity:      SeverityError,
+			},
+			{
+				ComponentType: ComponentListener,
+				ComponentID:   "http",
+				Message:       "type is missing",
+				Severity:      SeverityError,
+			},
+			{
+				ComponentType: ComponentRoute,
+				ComponentID:   "api[1]",
+				Field:         "condition",
+				Message:       "path is empty",
+				Severity:      SeverityError,
+			},
+			{
+				Field:    "version",
+				Message:  "bad version",
+				Severity: SeverityError,
+			},
+		}, Issues(err, SeverityError))
+	})
+
+	t.Run("join wrapped with a single verb is split", func(t *testing.T) {
+		err := ForField("http", fmt.Errorf("invalid HTTP options: %w",
+			errors.Join(errors.New("a"), errors.New("b"))))
+
+		assert.Equal(t, []Issue{
+			{Field: "http", Message: "a", Severity: SeverityError},
+			{Field: "http", Message: "b", Severity: SeverityError},
+		}, Issues(err, SeverityError))
+	})
+
+	t.Run("join wrapped with several verbs is a single issue", func(t *testing.T) {
+		err := ForComponent(ComponentListener, "http", fmt.Errorf("%w: trusted proxies: %w",
+			errors.New("invalid value"), errors.Join(errors.New("a"), errors.New("b"))))
+
+		issues := Issues(err, SeverityError)
+		require.Len(t, issues, 1)
+		assert.Equal(t, "invalid value: trusted proxies: a\nb", issues[0].Message)
+		assert.Equal(t, "http", issues[0].ComponentID)
+	})
+}
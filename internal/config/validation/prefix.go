@@ -0,0 +1,56 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// ErrInvalidPrefix indicates a value that isn't a CIDR prefix or an IP address
+var ErrInvalidPrefix = errors.New("invalid CIDR or IP address")
+
+// ParsePrefix parses a CIDR prefix such as "10.0.0.0/8", or a single IP
+// address as a prefix holding only that address. IPv4-mapped IPv6 values are
+// unmapped so they match IPv4 addresses.
+func ParsePrefix(value string) (netip.Prefix, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "/") {
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("%w: %w", ErrInvalidPrefix, err)
+		}
+		if prefix.Addr().Is4In6() {
+			bits := prefix.Bits() - 96
+			if bits < 0 {
+				return netip.Prefix{}, fmt.Errorf("%w: %s is wider than the IPv4-mapped range",
+					ErrInvalidPrefix, value)
+			}
+			prefix = netip.PrefixFrom(prefix.Addr().Unmap(), bits)
+		}
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %w", ErrInvalidPrefix, err)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// ParsePrefixes parses every value, see ParsePrefix. The error joins the
+// errors of all values that failed to parse.
+func ParsePrefixes(values []string) ([]netip.Prefix, error) {
+	var errs []error
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		prefix, err := ParsePrefix(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, errors.Join(errs...)
+}
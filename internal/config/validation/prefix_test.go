@@ -0,0 +1,58 @@
+package validation
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{value: "10.1.2.3/8", want: "10.0.0.0/8"},
+		{value: "192.168.1.10", want: "192.168.1.10/32"},
+		{value: " 172.16.0.0/12 ", want: "172.16.0.0/12"},
+		{value: "2001:db8::/32", want: "2001:db8::/32"},
+		{value: "::1", want: "::1/128"},
+		{value: "::ffff:10.0.0.0/104", want: "10.0.0.0/8"},
+		{value: "::ffff:10.0.0.1", want: "10.0.0.1/32"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			prefix, err := ParsePrefix(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, netip.MustParsePrefix(tt.want), prefix)
+		})
+	}
+
+	for _, value := range []string{"", "10.0.0.0/33", "10.0.0", "example.com", "::ffff:0.0.0.0/64"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			_, err := ParsePrefix(value)
+			require.ErrorIs(t, err, ErrInvalidPrefix)
+		})
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := ParsePrefixes([]string{"10.0.0.0/8", "127.0.0.1"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("127.0.0.1/32"),
+	}, prefixes)
+
+	_, err = ParsePrefixes([]string{"10.0.0.0/8", "bad", "worse"})
+	require.ErrorIs(t, err, ErrInvalidPrefix)
+	assert.Contains(t, err.Error(), "bad")
+	assert.Contains(t, err.Error(), "worse")
+}
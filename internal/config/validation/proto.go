@@ -0,0 +1,73 @@
+package validation
+
+import (
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"google.golang.org/protobuf/proto"
+)
+
+// IssuesToProto converts validation issues to their protobuf representation
+func IssuesToProto(issues []Issue) []*pb.ValidationIssue {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	pbIssues := make([]*pb.ValidationIssue, 0, len(issues))
+	for _, issue := range issues {
+		pbIssue := &pb.ValidationIssue{
+			Message:  proto.String(issue.Message),
+			Severity: severityToProto(issue.Severity).Enum(),
+		}
+		if issue.ComponentType != "" {
+			pbIssue.ComponentType = proto.String(issue.ComponentType)
+		}
+		if issue.ComponentID != "" {
+			pbIssue.ComponentId = proto.String(issue.ComponentID)
+		}
+		if issue.Field != "" {
+			pbIssue.Field = proto.String(issue.Field)
+		}
+		pbIssues = append(pbIssues, pbIssue)
+	}
+	return pbIssues
+}
+
+// IssuesFromProto converts protobuf validation issues to domain issues. An
+// issue without a severity is treated as an error.
+func IssuesFromProto(pbIssues []*pb.ValidationIssue) []Issue {
+	if len(pbIssues) == 0 {
+		return nil
+	}
+
+	issues := make([]Issue, 0, len(pbIssues))
+	for _, pbIssue := range pbIssues {
+		if pbIssue == nil {
+			continue
+		}
+		issues = append(issues, Issue{
+			ComponentType: pbIssue.GetComponentType(),
+			ComponentID:   pbIssue.GetComponentId(),
+			Field:         pbIssue.GetField(),
+			Message:       pbIssue.GetMessage(),
+			Severity:      severityFromProto(pbIssue.GetSeverity()),
+		})
+	}
+	return issues
+}
+
+func severityToProto(severity Severity) pb.ValidationIssue_Severity {
+	switch severity {
+	case SeverityError:
+		return pb.ValidationIssue_SEVERITY_ERROR
+	case SeverityWarning:
+		return pb.ValidationIssue_SEVERITY_WARNING
+	default:
+		return pb.ValidationIssue_SEVERITY_UNSPECIFIED
+	}
+}
+
+func severityFromProto(severity pb.ValidationIssue_Severity) Severity {
+	if severity == pb.ValidationIssue_SEVERITY_WARNING {
+		return SeverityWarning
+	}
+	return SeverityError
+}
@@ -0,0 +1,43 @@
+package validation
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuesProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	issues := []Issue{
+		{
+			ComponentType: ComponentListener,
+			ComponentID:   "http",
+			Field:         "address",
+			Message:       "address is missing",
+			Severity:      SeverityError,
+		},
+		{Message: "chain is long", Severity: SeverityWarning},
+	}
+
+	pbIssues := IssuesToProto(issues)
+	require.Len(t, pbIssues, 2)
+	assert.Equal(t, pb.ValidationIssue_SEVERITY_ERROR, pbIssues[0].GetSeverity())
+	assert.Equal(t, pb.ValidationIssue_SEVERITY_WARNING, pbIssues[1].GetSeverity())
+	assert.Nil(t, pbIssues[1].ComponentType, "empty fields should be left unset")
+
+	assert.Equal(t, issues, IssuesFromProto(pbIssues))
+
+	assert.Nil(t, IssuesToProto(nil))
+	assert.Nil(t, IssuesFromProto(nil))
+}
+
+func TestIssuesFromProto_UnspecifiedSeverity(t *testing.T) {
+	t.Parallel()
+
+	issues := IssuesFromProto([]*pb.ValidationIssue{{}, nil})
+	require.Len(t, issues, 1)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+}
@@ -41,6 +41,9 @@ var (
 
 	ErrorStyle = lipgloss.NewStyle().
 			Foreground(ColorRed)
+
+	WarningStyle = lipgloss.NewStyle().
+			Foreground(ColorYellow)
 )
 
 // render applies a style to text, returning an empty string for empty input.
@@ -92,6 +95,11 @@ func ErrorText(text string) string {
 	return render(ErrorStyle, text)
 }
 
+// WarningText styles warning text (yellow)
+func WarningText(text string) string {
+	return render(WarningStyle, text)
+}
+
 // PathText styles file paths (gray)
 func PathText(text string) string {
 	return render(InfoStyle, text)
@@ -125,6 +125,11 @@ func TestStyleHelperFunctions(t *testing.T) {
 	assert.Contains(t, errorStyled, sampleText)
 	assert.Equal(t, fancy.ErrorStyle.Render(sampleText), errorStyled)
 
+	// Test WarningText function
+	warningStyled := fancy.WarningText(sampleText)
+	assert.Contains(t, warningStyled, sampleText)
+	assert.Equal(t, fancy.WarningStyle.Render(sampleText), warningStyled)
+
 	// Test PathText function (uses InfoStyle)
 	pathStyled := fancy.PathText(sampleText)
 	assert.Contains(t, pathStyled, sampleText)
@@ -8,24 +8,35 @@ import (
 	"strings"
 )
 
-// Pattern for ${VAR_NAME} and ${VAR_NAME:default} syntax - captures colon explicitly
-var envVarWithDefaultPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:)?([^}]*)\}`)
+// Pattern for the variable name of an ${env:VAR_NAME} reference
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
 
-// ExpandEnvVars expands environment variables with default values in the format:
+// Source prefixes select where a ${source:reference} value is read from
+const (
+	sourceEnv  = "env"
+	sourceFile = "file"
+)
+
+// ExpandEnvVars expands environment variables in the formats:
+//
+// ${VAR_NAME}           the variable's value; an error if it is not set
+// ${VAR_NAME:default}   default if the variable is not set
+// ${VAR_NAME:-default}  default if the variable is not set or empty
+// ${VAR_NAME:?message}  an error containing message if the variable is not set or empty
+//
+// Defaults may be empty, e.g. ${VAR_NAME:-}, and may themselves contain references, e.g.
+// ${VAR_NAME:-${OTHER_VAR}}, which are only expanded when the default is used. A reference
+// preceded by an extra "$" is escaped: $${VAR_NAME} expands to the literal text ${VAR_NAME}.
 //
-// ${VAR_NAME:default_value}
+// A source prefix selects where the value comes from:
 //
-// If the environment variable is not set, it uses the default value if provided. If no default is
-// provided and the variable is missing, it returns an error.
+// ${env:VAR_NAME} reads the environment, like ${VAR_NAME}, and accepts the same modifiers
+// ${file:/path/to/secret} reads the file's contents, without trailing newlines
+//
+// A missing file is an error. Because of these prefixes, variables named "env" or "file" can't be
+// given a default.
 func ExpandEnvVars(input string) (string, error) {
-	if input == "" {
-		return "", nil
-	}
-
-	// Single regex pass: index layout per match (3 groups -> 8 ints) is
-	// [fullStart, fullEnd, varStart, varEnd, colonStart, colonEnd, defStart, defEnd].
-	matches := envVarWithDefaultPattern.FindAllStringSubmatchIndex(input, -1)
-	if matches == nil {
+	if !strings.Contains(input, "${") {
 		return input, nil
 	}
 
@@ -33,35 +44,158 @@ func ExpandEnvVars(input string) (string, error) {
 	var b strings.Builder
 	b.Grow(len(input)) // output length is close to input; avoids reallocation churn
 	last := 0
-	for _, m := range matches {
-		b.WriteString(input[last:m[0]])
-		last = m[1]
-
-		varName := input[m[2]:m[3]]
-		// The colon group is [-1,-1] when absent, signalling no default was intended.
-		colonIsPresent := m[4] != -1
-
-		// Use the value from the environment if it exists.
-		if value, exists := os.LookupEnv(varName); exists {
-			b.WriteString(value)
-			continue
+	for {
+		offset := strings.Index(input[last:], "${")
+		if offset == -1 {
+			break
+		}
+		start := last + offset
+		end := closingBrace(input, start+2)
+		if end == -1 {
+			break // Unterminated references are kept as-is
 		}
+		reference := input[start : end+1]
 
-		// If not in env, use the default if one was provided.
-		// This correctly handles cases like ${VAR:} where the default is an empty string.
-		if colonIsPresent {
-			b.WriteString(input[m[6]:m[7]])
+		// $${...} is written out without its escape and isn't expanded
+		if start > last && input[start-1] == '$' {
+			b.WriteString(input[last : start-1])
+			b.WriteString(reference)
+			last = end + 1
 			continue
 		}
 
-		// Otherwise, the variable is missing.
-		missingVars = append(
-			missingVars,
-			fmt.Errorf("environment variable not defined: %s", varName),
-		)
-		b.WriteString(input[m[0]:m[1]]) // Keep the original string for the missing variable
+		b.WriteString(input[last:start])
+		last = end + 1
+
+		value, ok, err := expandReference(input[start+2 : end])
+		switch {
+		case !ok:
+			b.WriteString(reference) // Not a variable reference, e.g. ${1VAR}
+		case err != nil:
+			missingVars = append(missingVars, err)
+			b.WriteString(reference) // Keep the original string for the missing variable
+		default:
+			b.WriteString(value)
+		}
 	}
 	b.WriteString(input[last:])
 
 	return b.String(), errors.Join(missingVars...)
 }
+
+// closingBrace returns the index of the "}" closing the reference whose body starts at from,
+// skipping over nested ${...} references, or -1 if the reference isn't closed.
+func closingBrace(s string, from int) int {
+	depth := 0
+	for i := from; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			depth++
+			i++
+		case s[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// expandReference expands the body of a ${...} reference. It returns false if the body doesn't
+// start with a variable name.
+func expandReference(body string) (string, bool, error) {
+	nameLen := 0
+	for nameLen < len(body) && isNameChar(body[nameLen], nameLen == 0) {
+		nameLen++
+	}
+	if nameLen == 0 {
+		return "", false, nil
+	}
+	varName, rest := body[:nameLen], body[nameLen:]
+
+	// Anything after the name other than a colon modifier is ignored, so ${VAR-x} requires VAR
+	modifier, hasModifier := strings.CutPrefix(rest, ":")
+	if hasModifier && (varName == sourceEnv || varName == sourceFile) {
+		value, err := resolveSource(varName, modifier)
+		return value, true, err
+	}
+
+	value, err := lookupEnv(varName, modifier, hasModifier)
+	return value, true, err
+}
+
+// isNameChar reports whether c may appear in a variable name, at its start when first is set.
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	}
+	return false
+}
+
+// lookupEnv returns the value of an environment variable, applying the modifier that followed the
+// colon in ${VAR_NAME:modifier} when hasModifier is set.
+func lookupEnv(varName, modifier string, hasModifier bool) (string, error) {
+	value, exists := os.LookupEnv(varName)
+
+	switch {
+	case !hasModifier:
+		if exists {
+			return value, nil
+		}
+	case strings.HasPrefix(modifier, "-"):
+		if exists && value != "" {
+			return value, nil
+		}
+		return ExpandEnvVars(modifier[1:])
+	case strings.HasPrefix(modifier, "?"):
+		if exists && value != "" {
+			return value, nil
+		}
+		message, err := ExpandEnvVars(modifier[1:])
+		if err != nil {
+			return "", err
+		}
+		if message != "" {
+			return "", fmt.Errorf("environment variable %s: %s", varName, message)
+		}
+		return "", fmt.Errorf("environment variable not defined or empty: %s", varName)
+	default:
+		// This correctly handles cases like ${VAR:} where the default is an empty string.
+		if exists {
+			return value, nil
+		}
+		return ExpandEnvVars(modifier)
+	}
+
+	return "", fmt.Errorf("environment variable not defined: %s", varName)
+}
+
+// resolveSource returns the value of a ${source:reference} expression.
+func resolveSource(source, reference string) (string, error) {
+	switch source {
+	case sourceFile:
+		path, err := ExpandEnvVars(reference)
+		if err != nil {
+			return "", err
+		}
+		if path == "" {
+			return "", errors.New("secret file path is empty")
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("secret file not readable: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+
+	default:
+		varName, modifier, hasModifier := strings.Cut(reference, ":")
+		if !envVarNamePattern.MatchString(varName) {
+			return "", fmt.Errorf("invalid environment variable name: %q", varName)
+		}
+		return lookupEnv(varName, modifier, hasModifier)
+	}
+}
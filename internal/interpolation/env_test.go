@@ -2,6 +2,7 @@ package interpolation
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -208,3 +209,222 @@ func TestExpandEnvVarsWithDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandEnvVarsSources(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("s3cret\n"), 0o600))
+	colonPath := filepath.Join(dir, "with:colon")
+	require.NoError(t, os.WriteFile(colonPath, []byte("colon-secret"), 0o600))
+	t.Setenv("SOURCE_TEST_VAR", "from-env")
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "env prefix",
+			input:    "${env:SOURCE_TEST_VAR}",
+			expected: "from-env",
+		},
+		{
+			name:     "env prefix with default",
+			input:    "${env:SOURCE_TEST_MISSING:fallback}",
+			expected: "fallback",
+		},
+		{
+			name:        "env prefix missing",
+			input:       "${env:SOURCE_TEST_MISSING}",
+			expected:    "${env:SOURCE_TEST_MISSING}",
+			errContains: "environment variable not defined: SOURCE_TEST_MISSING",
+		},
+		{
+			name:        "env prefix with invalid name",
+			input:       "${env:1BAD}",
+			expected:    "${env:1BAD}",
+			errContains: "invalid environment variable name",
+		},
+		{
+			name:     "file prefix trims trailing newline",
+			input:    "Bearer ${file:" + tokenPath + "}",
+			expected: "Bearer s3cret",
+		},
+		{
+			name:     "file path containing a colon",
+			input:    "${file:" + colonPath + "}",
+			expected: "colon-secret",
+		},
+		{
+			name:     "file and env together",
+			input:    "${SOURCE_TEST_VAR}:${file:" + tokenPath + "}",
+			expected: "from-env:s3cret",
+		},
+		{
+			name:        "file prefix missing file",
+			input:       "${file:" + filepath.Join(dir, "missing") + "}",
+			expected:    "${file:" + filepath.Join(dir, "missing") + "}",
+			errContains: "secret file not readable",
+		},
+		{
+			name:        "file prefix empty path",
+			input:       "${file:}",
+			expected:    "${file:}",
+			errContains: "secret file path is empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ExpandEnvVars(tc.input)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+
+	t.Run("missing file wraps the os error", func(t *testing.T) {
+		_, err := ExpandEnvVars("${file:" + filepath.Join(dir, "missing") + "}")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestExpandEnvVarsShellModifiers(t *testing.T) {
+	t.Setenv("MOD_SET", "value")
+	t.Setenv("MOD_EMPTY", "")
+	t.Setenv("MOD_OTHER", "other")
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "dash default unused when set",
+			input:    "${MOD_SET:-default}",
+			expected: "value",
+		},
+		{
+			name:     "dash default when unset",
+			input:    "${MOD_UNSET:-default}",
+			expected: "default",
+		},
+		{
+			name:     "dash default when empty",
+			input:    "${MOD_EMPTY:-default}",
+			expected: "default",
+		},
+		{
+			name:     "plain default keeps empty value",
+			input:    "${MOD_EMPTY:default}",
+			expected: "",
+		},
+		{
+			name:     "empty dash default",
+			input:    "[${MOD_UNSET:-}]",
+			expected: "[]",
+		},
+		{
+			name:     "dash default with colons",
+			input:    "${MOD_UNSET:-http://localhost:8080}",
+			expected: "http://localhost:8080",
+		},
+		{
+			name:     "required marker satisfied",
+			input:    "${MOD_SET:?must be set}",
+			expected: "value",
+		},
+		{
+			name:        "required marker with message",
+			input:       "${MOD_UNSET:?API token is required}",
+			expected:    "${MOD_UNSET:?API token is required}",
+			errContains: "environment variable MOD_UNSET: API token is required",
+		},
+		{
+			name:        "required marker on empty value",
+			input:       "${MOD_EMPTY:?cannot be empty}",
+			expected:    "${MOD_EMPTY:?cannot be empty}",
+			errContains: "environment variable MOD_EMPTY: cannot be empty",
+		},
+		{
+			name:        "required marker without message",
+			input:       "${MOD_UNSET:?}",
+			expected:    "${MOD_UNSET:?}",
+			errContains: "environment variable not defined or empty: MOD_UNSET",
+		},
+		{
+			name:     "env prefix with dash default",
+			input:    "${env:MOD_EMPTY:-fallback}",
+			expected: "fallback",
+		},
+		{
+			name:        "env prefix with required marker",
+			input:       "${env:MOD_UNSET:?set MOD_UNSET}",
+			expected:    "${env:MOD_UNSET:?set MOD_UNSET}",
+			errContains: "environment variable MOD_UNSET: set MOD_UNSET",
+		},
+		{
+			name:     "nested default",
+			input:    "${MOD_UNSET:-${MOD_OTHER}}",
+			expected: "other",
+		},
+		{
+			name:     "nested default with its own default",
+			input:    "${MOD_UNSET:-${MOD_UNSET_2:-deep}}/path",
+			expected: "deep/path",
+		},
+		{
+			name:     "nested default not expanded when unused",
+			input:    "${MOD_SET:-${MOD_UNSET}}",
+			expected: "value",
+		},
+		{
+			name:        "nested required marker",
+			input:       "${MOD_UNSET:-${MOD_UNSET_2:?no fallback}}",
+			expected:    "${MOD_UNSET:-${MOD_UNSET_2:?no fallback}}",
+			errContains: "environment variable MOD_UNSET_2: no fallback",
+		},
+		{
+			name:     "escaped reference",
+			input:    "$${literal}",
+			expected: "${literal}",
+		},
+		{
+			name:     "escaped reference to a set variable",
+			input:    "cost: $${MOD_SET} is ${MOD_SET}",
+			expected: "cost: ${MOD_SET} is value",
+		},
+		{
+			name:     "escaped reference with modifier",
+			input:    "$${MOD_UNSET:?not evaluated}",
+			expected: "${MOD_UNSET:?not evaluated}",
+		},
+		{
+			name:     "escaped reference inside default",
+			input:    "${MOD_UNSET:-$${MOD_SET}}",
+			expected: "${MOD_SET}",
+		},
+		{
+			name:     "unterminated reference",
+			input:    "${MOD_SET",
+			expected: "${MOD_SET",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ExpandEnvVars(tc.input)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -217,6 +217,44 @@ func TestInterpolateStruct(t *testing.T) {
 	})
 }
 
+func TestInterpolateStructShellModifiers(t *testing.T) {
+	t.Setenv("MODIFIER_HOST", "server.example.com")
+
+	t.Run("defaults and escapes in nested structs", func(t *testing.T) {
+		nested := &NestedConfig{
+			OuterName: "$${MODIFIER_HOST}",
+			Config: TestConfig{
+				Host: "${MODIFIER_MISSING:-localhost}",
+				Port: "${MODIFIER_MISSING:-}",
+			},
+			ConfigPtr: &TestConfig{
+				Host:    "${MODIFIER_MISSING:-${MODIFIER_HOST}}",
+				Message: "use $${VAR:-default} syntax",
+			},
+		}
+
+		require.NoError(t, InterpolateStruct(nested))
+		assert.Equal(t, "${MODIFIER_HOST}", nested.OuterName)
+		assert.Equal(t, "localhost", nested.Config.Host)
+		assert.Empty(t, nested.Config.Port)
+		assert.Equal(t, "server.example.com", nested.ConfigPtr.Host)
+		assert.Equal(t, "use ${VAR:-default} syntax", nested.ConfigPtr.Message)
+	})
+
+	t.Run("required marker message is in the error", func(t *testing.T) {
+		config := &TestConfig{
+			Host: "${MODIFIER_MISSING:?database host must be configured}",
+			Code: "${MODIFIER_MISSING:?not interpolated}",
+		}
+
+		err := InterpolateStruct(config)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field Host")
+		assert.Contains(t, err.Error(), "database host must be configured")
+		assert.NotContains(t, err.Error(), "not interpolated")
+	})
+}
+
 func TestInterpolateStructWithSlices(t *testing.T) {
 	// Set up test environment variable
 	require.NoError(t, os.Setenv("TEST_VALUE", "interpolated"))
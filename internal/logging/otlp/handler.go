@@ -0,0 +1,102 @@
+package otlp
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+)
+
+// handler is a slog.Handler that converts each record, with its attributes
+// and groups, into an OTLP log record
+type handler struct {
+	pool  *pool
+	cfg   Config
+	scope string
+	level slog.Leveler
+
+	// derive replays the WithAttrs and WithGroup calls made on this handler
+	// onto the bridge handler
+	derive []func(slog.Handler) slog.Handler
+
+	bridge atomic.Pointer[resolvedBridge]
+}
+
+// resolvedBridge is a bridge handler resolved against the pool's pipelines of a generation
+type resolvedBridge struct {
+	generation uint64
+	handler    slog.Handler
+}
+
+// NewHandler returns a handler exporting records at level or above to the
+// collector in cfg, under the instrumentation scope named scope. The
+// collector isn't contacted until the first record is handled.
+func NewHandler(scope string, cfg Config, level slog.Leveler) (slog.Handler, error) {
+	return defaultPool.handler(scope, cfg, level)
+}
+
+func (p *pool) handler(scope string, cfg Config, level slog.Leveler) (*handler, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if level == nil {
+		level = slog.LevelInfo
+	}
+	return &handler{pool: p, cfg: cfg, scope: scope, level: level}, nil
+}
+
+// Enabled implements slog.Handler
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler, queueing the record for export
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	next, err := h.resolve()
+	if err != nil {
+		return err
+	}
+	return next.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+// WithGroup implements slog.Handler
+func (h *handler) WithGroup(name string) slog.Handler {
+	return h.with(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *handler) with(fn func(slog.Handler) slog.Handler) *handler {
+	return &handler{
+		pool:   h.pool,
+		cfg:    h.cfg,
+		scope:  h.scope,
+		level:  h.level,
+		derive: append(slices.Clip(h.derive), fn),
+	}
+}
+
+// resolve returns the bridge to the export pipeline, starting the pipeline on
+// first use and again after the pool has been shut down
+func (h *handler) resolve() (slog.Handler, error) {
+	generation := h.pool.generation.Load()
+	if b := h.bridge.Load(); b != nil && b.generation == generation {
+		return b.handler, nil
+	}
+
+	provider, err := h.pool.provider(h.cfg)
+	if err != nil {
+		return nil, err
+	}
+	var next slog.Handler = otelslog.NewHandler(h.scope, otelslog.WithLoggerProvider(provider))
+	for _, fn := range h.derive {
+		next = fn(next)
+	}
+	h.bridge.Store(&resolvedBridge{generation: generation, handler: next})
+	return next, nil
+}
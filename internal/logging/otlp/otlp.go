@@ -0,0 +1,201 @@
+// Package otlp exports slog records to an OpenTelemetry collector over OTLP.
+//
+// Records are batched in the background and exported by one pipeline per
+// distinct collector config, shared by every handler created for that config,
+// so reloading an unchanged configuration reuses the running pipeline. A
+// pipeline is started by the first record it exports, so handlers that are
+// only validated never connect to the collector. Shutdown flushes every
+// pipeline, and must be called before the process exits.
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Protocol is the transport used to reach the collector
+type Protocol string
+
+const (
+	ProtocolGRPC Protocol = "grpc"
+	ProtocolHTTP Protocol = "http"
+)
+
+// ErrInvalidConfig is returned when a Config can't be used to export records
+var ErrInvalidConfig = errors.New("invalid OTLP export config")
+
+// Config identifies a collector and how records are batched for it
+type Config struct {
+	// Endpoint is the collector address as host:port, or the full URL logs
+	// are exported to, such as https://collector:4318/v1/logs
+	Endpoint string
+
+	// Protocol defaults to ProtocolGRPC
+	Protocol Protocol
+
+	// Insecure disables TLS
+	Insecure bool
+
+	// Headers are sent with each export request
+	Headers map[string]string
+
+	// ExportInterval is the longest a record waits in the batch, and
+	// MaxBatchSize the most records in one export; zero uses the SDK default
+	ExportInterval time.Duration
+	MaxBatchSize   int
+}
+
+// Validate checks that the config names a collector and a known protocol
+func (c Config) Validate() error {
+	var errs []error
+	if strings.TrimSpace(c.Endpoint) == "" {
+		errs = append(errs, fmt.Errorf("%w: endpoint cannot be empty", ErrInvalidConfig))
+	}
+	switch c.Protocol {
+	case "", ProtocolGRPC, ProtocolHTTP:
+	default:
+		errs = append(errs, fmt.Errorf("%w: unsupported protocol %q", ErrInvalidConfig, c.Protocol))
+	}
+	if c.ExportInterval < 0 {
+		errs = append(errs, fmt.Errorf("%w: export interval cannot be negative", ErrInvalidConfig))
+	}
+	if c.MaxBatchSize < 0 {
+		errs = append(errs, fmt.Errorf("%w: max batch size cannot be negative", ErrInvalidConfig))
+	}
+	return errors.Join(errs...)
+}
+
+// key returns a string identifying the export pipeline for the config
+func (c Config) key() string {
+	protocol := c.Protocol
+	if protocol == "" {
+		protocol = ProtocolGRPC
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s|%s|%t|%s|%d", protocol, c.Endpoint, c.Insecure, c.ExportInterval, c.MaxBatchSize)
+	for _, name := range slices.Sorted(maps.Keys(c.Headers)) {
+		fmt.Fprintf(&b, "|%s=%s", name, c.Headers[name])
+	}
+	return b.String()
+}
+
+// exporterFactory creates the exporter for a pipeline
+type exporterFactory func(ctx context.Context, cfg Config) (sdklog.Exporter, error)
+
+// pool holds the export pipeline of each distinct config
+type pool struct {
+	mu          sync.Mutex
+	providers   map[string]*sdklog.LoggerProvider
+	newExporter exporterFactory
+
+	// generation is incremented by each shutdown, so handlers know to
+	// replace the pipelines they resolved before it
+	generation atomic.Uint64
+}
+
+func newPool(newExporter exporterFactory) *pool {
+	return &pool{
+		providers:   make(map[string]*sdklog.LoggerProvider),
+		newExporter: newExporter,
+	}
+}
+
+// defaultPool is shared by the handlers returned from NewHandler
+var defaultPool = newPool(newExporter)
+
+// Shutdown flushes the records batched by every export pipeline and stops
+// the pipelines. Handlers that export after Shutdown start a new pipeline.
+func Shutdown(ctx context.Context) error {
+	return defaultPool.shutdown(ctx)
+}
+
+// provider returns the logger provider exporting to cfg, starting its
+// pipeline if needed
+func (p *pool) provider(cfg Config) (*sdklog.LoggerProvider, error) {
+	key := cfg.key()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if provider, ok := p.providers[key]; ok {
+		return provider, nil
+	}
+
+	exporter, err := p.newExporter(context.Background(), cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter for %s: %w", cfg.Endpoint, err)
+	}
+
+	var batchOpts []sdklog.BatchProcessorOption
+	if cfg.ExportInterval > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportInterval(cfg.ExportInterval))
+	}
+	if cfg.MaxBatchSize > 0 {
+		batchOpts = append(batchOpts, sdklog.WithExportMaxBatchSize(cfg.MaxBatchSize))
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, batchOpts...)),
+	)
+	p.providers[key] = provider
+	return provider, nil
+}
+
+// shutdown flushes and stops every pipeline in the pool
+func (p *pool) shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	providers := p.providers
+	p.providers = make(map[string]*sdklog.LoggerProvider)
+	p.generation.Add(1)
+	p.mu.Unlock()
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(providers)) {
+		if err := providers[key].Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush OTLP logs: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newExporter creates the OTLP exporter for cfg. An endpoint with a scheme is
+// used as the export URL, anything else as host:port.
+func newExporter(ctx context.Context, cfg Config) (sdklog.Exporter, error) {
+	isURL := strings.Contains(cfg.Endpoint, "://")
+
+	if cfg.Protocol == ProtocolHTTP {
+		opts := []otlploghttp.Option{otlploghttp.WithHeaders(cfg.Headers)}
+		if isURL {
+			opts = append(opts, otlploghttp.WithEndpointURL(cfg.Endpoint))
+		} else {
+			opts = append(opts, otlploghttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlploghttp.WithInsecure())
+		}
+		return otlploghttp.New(ctx, opts...)
+	}
+
+	opts := []otlploggrpc.Option{otlploggrpc.WithHeaders(cfg.Headers)}
+	if isURL {
+		opts = append(opts, otlploggrpc.WithEndpointURL(cfg.Endpoint))
+	} else {
+		opts = append(opts, otlploggrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	return otlploggrpc.New(ctx, opts...)
+}
@@ -0,0 +1,267 @@
+package otlp
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// recordingExporter keeps the records it is asked to export
+type recordingExporter struct {
+	mu       sync.Mutex
+	records  []sdklog.Record
+	shutdown bool
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *recordingExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+// recordingPool returns a pool whose pipelines export to new recording
+// exporters, which are returned in creation order
+func recordingPool() (*pool, func() []*recordingExporter) {
+	var mu sync.Mutex
+	var exporters []*recordingExporter
+	p := newPool(func(context.Context, Config) (sdklog.Exporter, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		exporter := &recordingExporter{}
+		exporters = append(exporters, exporter)
+		return exporter, nil
+	})
+	return p, func() []*recordingExporter {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*recordingExporter(nil), exporters...)
+	}
+}
+
+// attrMap flattens a record's attributes into a map
+func attrMap(r sdklog.Record) map[string]otellog.Value {
+	attrs := make(map[string]otellog.Value)
+	r.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{name: "endpoint only", cfg: Config{Endpoint: "localhost:4317"}},
+		{name: "http url", cfg: Config{Endpoint: "https://collector:4318/v1/logs", Protocol: ProtocolHTTP}},
+		{name: "empty endpoint", cfg: Config{Endpoint: " "}, wantErr: "endpoint cannot be empty"},
+		{
+			name:    "unknown protocol",
+			cfg:     Config{Endpoint: "localhost:4317", Protocol: "udp"},
+			wantErr: `unsupported protocol "udp"`,
+		},
+		{
+			name:    "negative interval",
+			cfg:     Config{Endpoint: "localhost:4317", ExportInterval: -time.Second},
+			wantErr: "export interval cannot be negative",
+		},
+		{
+			name:    "negative batch size",
+			cfg:     Config{Endpoint: "localhost:4317", MaxBatchSize: -1},
+			wantErr: "max batch size cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfigKey(t *testing.T) {
+	base := Config{Endpoint: "localhost:4317", Headers: map[string]string{"a": "1", "b": "2"}}
+
+	same := base
+	same.Protocol = ProtocolGRPC
+	same.Headers = map[string]string{"b": "2", "a": "1"}
+	assert.Equal(t, base.key(), same.key(), "default protocol and header order don't matter")
+
+	for _, other := range []Config{
+		{Endpoint: "localhost:4318", Headers: base.Headers},
+		{Endpoint: "localhost:4317", Headers: base.Headers, Protocol: ProtocolHTTP},
+		{Endpoint: "localhost:4317", Headers: base.Headers, Insecure: true},
+		{Endpoint: "localhost:4317", Headers: map[string]string{"a": "1"}},
+		{Endpoint: "localhost:4317", Headers: base.Headers, MaxBatchSize: 10},
+	} {
+		assert.NotEqual(t, base.key(), other.key(), other)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	cfg := Config{Endpoint: "localhost:4317", ExportInterval: time.Hour}
+
+	t.Run("exports records with their attributes", func(t *testing.T) {
+		p, exporters := recordingPool()
+		h, err := p.handler("console", cfg, slog.LevelInfo)
+		require.NoError(t, err)
+
+		logger := slog.New(h).WithGroup("mw")
+		logger.LogAttrs(t.Context(), slog.LevelWarn, "request",
+			slog.String("method", "GET"), slog.Int("status", 500))
+		require.NoError(t, p.shutdown(t.Context()))
+
+		require.Len(t, exporters(), 1)
+		records := exporters()[0].Records()
+		require.Len(t, records, 1)
+		record := records[0]
+		assert.Equal(t, "request", record.Body().AsString())
+		assert.Equal(t, otellog.SeverityWarn, record.Severity())
+		assert.Equal(t, "console", record.InstrumentationScope().Name)
+
+		group := attrMap(record)["mw"]
+		require.Equal(t, otellog.KindMap, group.Kind())
+		fields := make(map[string]otellog.Value)
+		for _, kv := range group.AsMap() {
+			fields[kv.Key] = kv.Value
+		}
+		assert.Equal(t, "GET", fields["method"].AsString())
+		assert.Equal(t, int64(500), fields["status"].AsInt64())
+	})
+
+	t.Run("records below the level are dropped", func(t *testing.T) {
+		p, exporters := recordingPool()
+		h, err := p.handler("console", cfg, slog.LevelWarn)
+		require.NoError(t, err)
+
+		slog.New(h).Info("ignored")
+		assert.Empty(t, exporters(), "no pipeline is started for dropped records")
+	})
+
+	t.Run("equal configs share a pipeline started on first use", func(t *testing.T) {
+		p, exporters := recordingPool()
+		first, err := p.handler("a", cfg, nil)
+		require.NoError(t, err)
+		second, err := p.handler("b", Config{Endpoint: "localhost:4317", ExportInterval: time.Hour}, nil)
+		require.NoError(t, err)
+		other, err := p.handler("c", Config{Endpoint: "collector:4317", ExportInterval: time.Hour}, nil)
+		require.NoError(t, err)
+		assert.Empty(t, exporters())
+
+		slog.New(first).Info("one")
+		slog.New(second).Info("two")
+		slog.New(other).Info("three")
+		require.Len(t, exporters(), 2)
+
+		require.NoError(t, p.shutdown(t.Context()))
+		assert.Len(t, exporters()[0].Records(), 2)
+		assert.Len(t, exporters()[1].Records(), 1)
+	})
+
+	t.Run("shutdown flushes batched records and later records start a new pipeline", func(t *testing.T) {
+		p, exporters := recordingPool()
+		h, err := p.handler("console", cfg, nil)
+		require.NoError(t, err)
+		logger := slog.New(h)
+
+		logger.Info("before")
+		require.Len(t, exporters(), 1)
+		assert.Empty(t, exporters()[0].Records(), "records wait for the export interval")
+
+		require.NoError(t, p.shutdown(t.Context()))
+		assert.Len(t, exporters()[0].Records(), 1)
+		assert.True(t, exporters()[0].shutdown)
+
+		logger.Info("after")
+		require.NoError(t, p.shutdown(t.Context()))
+		require.Len(t, exporters(), 2)
+		assert.Len(t, exporters()[1].Records(), 1)
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		p, _ := recordingPool()
+		_, err := p.handler("console", Config{}, nil)
+		require.ErrorIs(t, err, ErrInvalidConfig)
+	})
+}
+
+func TestNewExporter_HTTP(t *testing.T) {
+	requests := make(chan *collogspb.ExportLogsServiceRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/logs", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Token"))
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		req := &collogspb.ExportLogsServiceRequest{}
+		if !assert.NoError(t, proto.Unmarshal(body, req)) {
+			return
+		}
+		requests <- req
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(collector.Close)
+
+	p := newPool(newExporter)
+	h, err := p.handler("console", Config{
+		Endpoint:       collector.URL + "/v1/logs",
+		Protocol:       ProtocolHTTP,
+		Headers:        map[string]string{"X-Token": "secret"},
+		ExportInterval: time.Hour,
+	}, nil)
+	require.NoError(t, err)
+
+	slog.New(h).Info("request", "path", "/api")
+	require.NoError(t, p.shutdown(t.Context()))
+
+	select {
+	case req := <-requests:
+		records := req.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		assert.Equal(t, "request", records[0].GetBody().GetStringValue())
+		assert.Equal(t, "path", records[0].GetAttributes()[0].GetKey())
+		assert.Equal(t, "/api", records[0].GetAttributes()[0].GetValue().GetStringValue())
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector received no export request")
+	}
+}
@@ -47,32 +47,27 @@ func SetupHandlerJSON(logLevel string, writer io.Writer) slog.Handler {
 		writer = os.Stdout
 	}
 
-	reportCaller := false
-	var level slog.Level
+	opts := &slog.HandlerOptions{
+		Level:     ParseLevel(logLevel),
+		AddSource: strings.EqualFold(logLevel, "trace"),
+	}
+
+	return slog.NewJSONHandler(writer, opts)
+}
 
+// ParseLevel returns the slog level named by logLevel, treating "trace" as
+// debug and anything unrecognized as info
+func ParseLevel(logLevel string) slog.Level {
 	switch strings.ToLower(logLevel) {
-	case "trace":
-		reportCaller = true
-		level = slog.LevelDebug
-	case "debug":
-		reportCaller = false
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
+	case "trace", "debug":
+		return slog.LevelDebug
 	case "warn", "warning":
-		level = slog.LevelWarn
+		return slog.LevelWarn
 	case "error":
-		level = slog.LevelError
+		return slog.LevelError
 	default:
-		level = slog.LevelInfo
+		return slog.LevelInfo
 	}
-
-	opts := &slog.HandlerOptions{
-		Level:     level,
-		AddSource: reportCaller,
-	}
-
-	return slog.NewJSONHandler(writer, opts)
 }
 
 // SetupLogger configures the default logger based on provided log level
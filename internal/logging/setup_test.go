@@ -298,6 +298,22 @@ func TestSetupHandlerJSON_LevelFiltering(t *testing.T) {
 	assert.Contains(t, output, "error message")
 }
 
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"trace":   slog.LevelDebug,
+		"DEBUG":   slog.LevelDebug,
+		"info":    slog.LevelInfo,
+		"warn":    slog.LevelWarn,
+		"warning": slog.LevelWarn,
+		"error":   slog.LevelError,
+		"":        slog.LevelInfo,
+		"fatal":   slog.LevelInfo,
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, ParseLevel(name), name)
+	}
+}
+
 func TestSetupHandlerText_LevelFiltering(t *testing.T) {
 	// Test that log level filtering works correctly for text handler
 	buf := &bytes.Buffer{}
@@ -0,0 +1,274 @@
+package writers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidSyslogConfig is returned when a SyslogConfig can't be used to
+// reach a syslog collector
+var ErrInvalidSyslogConfig = errors.New("invalid syslog config")
+
+// Syslog networks supported by SyslogWriter
+const (
+	SyslogNetworkUDP      = "udp"
+	SyslogNetworkTCP      = "tcp"
+	SyslogNetworkUnix     = "unix"
+	SyslogNetworkUnixgram = "unixgram"
+)
+
+// syslogFacilities maps RFC 5424 facility names to their codes
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogMaxTagLength is the longest APP-NAME allowed by RFC 5424
+const syslogMaxTagLength = 48
+
+// syslogDialTimeout bounds each attempt to connect to the collector
+const syslogDialTimeout = 5 * time.Second
+
+// SyslogFacilities returns the supported facility names, sorted
+func SyslogFacilities() []string {
+	names := make([]string, 0, len(syslogFacilities))
+	for name := range syslogFacilities {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// SyslogConfig identifies a syslog collector and how messages are labeled
+type SyslogConfig struct {
+	// Network is udp, tcp, unix (stream socket), or unixgram
+	Network string
+
+	// Address is host:port for udp and tcp, or a socket path
+	Address string
+
+	// Facility is a facility name such as "user" or "local0"
+	Facility string
+
+	// Tag is the APP-NAME of each message
+	Tag string
+}
+
+// Validate checks the network, facility, and tag, without contacting the
+// collector
+func (c SyslogConfig) Validate() error {
+	var errs []error
+	switch c.Network {
+	case SyslogNetworkUDP, SyslogNetworkTCP, SyslogNetworkUnix, SyslogNetworkUnixgram:
+	default:
+		errs = append(errs, fmt.Errorf("%w: unsupported network %q", ErrInvalidSyslogConfig, c.Network))
+	}
+	if strings.TrimSpace(c.Address) == "" {
+		errs = append(errs, fmt.Errorf("%w: address cannot be empty", ErrInvalidSyslogConfig))
+	}
+	if _, ok := syslogFacilities[c.Facility]; !ok {
+		errs = append(errs, fmt.Errorf("%w: unsupported facility %q", ErrInvalidSyslogConfig, c.Facility))
+	}
+	if !validSyslogTag(c.Tag) {
+		errs = append(errs, fmt.Errorf(
+			"%w: tag %q must be 1 to %d printable ASCII characters without spaces",
+			ErrInvalidSyslogConfig, c.Tag, syslogMaxTagLength,
+		))
+	}
+	return errors.Join(errs...)
+}
+
+// validSyslogTag reports whether tag is a valid RFC 5424 APP-NAME
+func validSyslogTag(tag string) bool {
+	if tag == "" || len(tag) > syslogMaxTagLength {
+		return false
+	}
+	for i := 0; i < len(tag); i++ {
+		if tag[i] < 33 || tag[i] > 126 {
+			return false
+		}
+	}
+	return true
+}
+
+// SyslogWriter writes each Write call to a syslog collector as one RFC 5424
+// message. Messages are sent with the informational severity, unless written
+// through a handler returned by WithSeverity. The connection is opened by the
+// first write and reopened when a write fails.
+type SyslogWriter struct {
+	cfg      SyslogConfig
+	facility int
+	hostname string
+	procID   string
+
+	// severityMu is held by a severity handler while its record is written
+	severityMu sync.Mutex
+	severity   int
+
+	connMu sync.Mutex
+	conn   net.Conn
+}
+
+// NewSyslogWriter returns a writer for the collector in cfg
+func NewSyslogWriter(cfg SyslogConfig) (*SyslogWriter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &SyslogWriter{
+		cfg:      cfg,
+		facility: syslogFacilities[cfg.Facility],
+		hostname: hostname,
+		procID:   strconv.Itoa(os.Getpid()),
+		severity: severityFromLevel(slog.LevelInfo),
+	}, nil
+}
+
+// CheckSyslogAddress connects to the collector in cfg and disconnects, to
+// validate its address. A udp address only needs to resolve, since sending
+// a datagram doesn't require a listener.
+func CheckSyslogAddress(cfg SyslogConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	conn, err := dialSyslog(cfg)
+	if err != nil {
+		return err
+	}
+	if err := conn.Close(); err != nil {
+		return fmt.Errorf("failed to close syslog connection: %w", err)
+	}
+	return nil
+}
+
+// dialSyslog connects to the collector in cfg
+func dialSyslog(cfg SyslogConfig) (net.Conn, error) {
+	conn, err := net.DialTimeout(cfg.Network, cfg.Address, syslogDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog %s://%s: %w", cfg.Network, cfg.Address, err)
+	}
+	return conn, nil
+}
+
+// Write sends p, without its trailing newline, as one syslog message
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	msg := w.format(w.severity, time.Now(), bytes.TrimRight(p, "\n"))
+
+	w.connMu.Lock()
+	defer w.connMu.Unlock()
+
+	// Retry once on a fresh connection, in case the collector restarted
+	var err error
+	for range 2 {
+		if w.conn == nil {
+			if w.conn, err = dialSyslog(w.cfg); err != nil {
+				return 0, err
+			}
+		}
+		if _, err = w.conn.Write(msg); err == nil {
+			return len(p), nil
+		}
+		_ = w.conn.Close() //nolint:errcheck // the connection is being discarded
+		w.conn = nil
+	}
+	return 0, fmt.Errorf("failed to write to syslog %s://%s: %w", w.cfg.Network, w.cfg.Address, err)
+}
+
+// Close closes the connection to the collector, if open
+func (w *SyslogWriter) Close() error {
+	w.connMu.Lock()
+	defer w.connMu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// format returns the RFC 5424 message for body. Stream connections frame it
+// by octet counting, as described in RFC 6587.
+func (w *SyslogWriter) format(severity int, ts time.Time, body []byte) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s - - ",
+		w.facility*8+severity,
+		ts.Format("2006-01-02T15:04:05.000000Z07:00"),
+		w.hostname,
+		w.cfg.Tag,
+		w.procID,
+	)
+	b.Write(body)
+
+	if w.cfg.Network == SyslogNetworkTCP || w.cfg.Network == SyslogNetworkUnix {
+		return append([]byte(strconv.Itoa(b.Len())+" "), b.Bytes()...)
+	}
+	return b.Bytes()
+}
+
+// WithSeverity wraps a handler writing to w, so that each record is sent
+// with the syslog severity matching its level
+func (w *SyslogWriter) WithSeverity(h slog.Handler) slog.Handler {
+	return &severityHandler{writer: w, next: h}
+}
+
+// severityHandler passes each record's level to its SyslogWriter
+type severityHandler struct {
+	writer *SyslogWriter
+	next   slog.Handler
+}
+
+// Enabled implements slog.Handler
+func (h *severityHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler. The wrapped handler writes the record
+// before returning, while the writer's severity is set for it.
+func (h *severityHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.writer.severityMu.Lock()
+	defer h.writer.severityMu.Unlock()
+
+	h.writer.severity = severityFromLevel(r.Level)
+	defer func() { h.writer.severity = severityFromLevel(slog.LevelInfo) }()
+	return h.next.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler
+func (h *severityHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &severityHandler{writer: h.writer, next: h.next.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler
+func (h *severityHandler) WithGroup(name string) slog.Handler {
+	return &severityHandler{writer: h.writer, next: h.next.WithGroup(name)}
+}
+
+// severityFromLevel maps a slog level to a syslog severity
+func severityFromLevel(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3 // error
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level >= slog.LevelInfo:
+		return 6 // informational
+	default:
+		return 7 // debug
+	}
+}
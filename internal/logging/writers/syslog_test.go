@@ -0,0 +1,210 @@
+package writers
+
+import (
+	"bufio"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc5424 matches a message written by SyslogWriter, capturing PRI, APP-NAME,
+// PROCID, and MSG
+var rfc5424 = regexp.MustCompile(
+	`^<(\d+)>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}(?:Z|[+-]\d{2}:\d{2}) \S+ (\S+) (\d+) - - (.*)$`,
+)
+
+func TestSyslogConfigValidate(t *testing.T) {
+	valid := SyslogConfig{Network: SyslogNetworkUDP, Address: "localhost:514", Facility: "local0", Tag: "firelynx"}
+
+	tests := []struct {
+		name    string
+		modify  func(*SyslogConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*SyslogConfig) {}},
+		{name: "unix socket", modify: func(c *SyslogConfig) { c.Network, c.Address = SyslogNetworkUnixgram, "/dev/log" }},
+		{name: "unknown network", modify: func(c *SyslogConfig) { c.Network = "http" }, wantErr: `unsupported network "http"`},
+		{name: "empty address", modify: func(c *SyslogConfig) { c.Address = "" }, wantErr: "address cannot be empty"},
+		{name: "unknown facility", modify: func(c *SyslogConfig) { c.Facility = "local8" }, wantErr: `unsupported facility "local8"`},
+		{name: "empty tag", modify: func(c *SyslogConfig) { c.Tag = "" }, wantErr: "tag"},
+		{name: "tag with space", modify: func(c *SyslogConfig) { c.Tag = "fire lynx" }, wantErr: "tag"},
+		{name: "tag too long", modify: func(c *SyslogConfig) { c.Tag = strings.Repeat("a", 49) }, wantErr: "tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidSyslogConfig)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSyslogWriter_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+	w, err := NewSyslogWriter(SyslogConfig{
+		Network:  SyslogNetworkUDP,
+		Address:  conn.LocalAddr().String(),
+		Facility: "local0",
+		Tag:      "firelynx",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, w.Close()) })
+
+	logger := slog.New(w.WithSeverity(slog.NewJSONHandler(w, nil)))
+	logger.Warn("request", "status", 503)
+
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	match := rfc5424.FindStringSubmatch(string(buf[:n]))
+	require.NotNil(t, match, string(buf[:n]))
+	assert.Equal(t, strconv.Itoa(16*8+4), match[1], "local0.warning")
+	assert.Equal(t, "firelynx", match[2])
+	assert.Equal(t, strconv.Itoa(os.Getpid()), match[3])
+	assert.Contains(t, match[4], `"msg":"request"`)
+	assert.Contains(t, match[4], `"status":503`)
+	assert.False(t, strings.HasSuffix(match[4], "\n"), "trailing newline is trimmed")
+}
+
+func TestSyslogWriter_TCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, listener.Close()) })
+
+	messages := make(chan string, 4)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go readOctetCounted(conn, messages)
+		}
+	}()
+
+	w, err := NewSyslogWriter(SyslogConfig{
+		Network:  SyslogNetworkTCP,
+		Address:  listener.Addr().String(),
+		Facility: "user",
+		Tag:      "api",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, w.Close()) })
+
+	logger := slog.New(w.WithSeverity(slog.NewTextHandler(w, nil))).WithGroup("mw")
+	logger.Info("first", "path", "/a")
+	logger.Error("second", "path", "/b")
+
+	for _, want := range []struct {
+		pri  int
+		body string
+	}{
+		{pri: 1*8 + 6, body: "mw.path=/a"},
+		{pri: 1*8 + 3, body: "mw.path=/b"},
+	} {
+		select {
+		case msg := <-messages:
+			match := rfc5424.FindStringSubmatch(msg)
+			require.NotNil(t, match, msg)
+			assert.Equal(t, strconv.Itoa(want.pri), match[1])
+			assert.Equal(t, "api", match[2])
+			assert.Contains(t, match[4], want.body)
+		case <-time.After(5 * time.Second):
+			t.Fatal("collector received no message")
+		}
+	}
+}
+
+// readOctetCounted reads RFC 6587 octet-counted messages from conn
+func readOctetCounted(conn net.Conn, messages chan<- string) {
+	defer conn.Close() //nolint:errcheck // test collector
+	r := bufio.NewReader(conn)
+	for {
+		length, err := r.ReadString(' ')
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil {
+			return
+		}
+		msg := make([]byte, n)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			return
+		}
+		messages <- string(msg)
+	}
+}
+
+func TestSyslogWriter_Reconnects(t *testing.T) {
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "syslog.sock")
+
+	w, err := NewSyslogWriter(SyslogConfig{
+		Network:  SyslogNetworkUnixgram,
+		Address:  socket,
+		Facility: "user",
+		Tag:      "api",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, w.Close()) })
+
+	_, err = w.Write([]byte("no collector\n"))
+	require.Error(t, err, "the collector isn't listening yet")
+
+	conn, err := net.ListenPacket("unixgram", socket)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+	n, err := w.Write([]byte("hello\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("hello\n"), n)
+
+	buf := make([]byte, 2048)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	read, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	match := rfc5424.FindStringSubmatch(string(buf[:read]))
+	require.NotNil(t, match)
+	assert.Equal(t, "14", match[1], "plain writes use user.info")
+	assert.Equal(t, "hello", match[4])
+}
+
+func TestCheckSyslogAddress(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+
+	cfg := SyslogConfig{Network: SyslogNetworkTCP, Address: addr, Facility: "user", Tag: "api"}
+	require.NoError(t, CheckSyslogAddress(cfg))
+
+	require.NoError(t, listener.Close())
+	err = CheckSyslogAddress(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to connect to syslog tcp://"+addr)
+
+	cfg.Facility = "nope"
+	require.ErrorIs(t, CheckSyslogAddress(cfg), ErrInvalidSyslogConfig)
+}
@@ -15,6 +15,14 @@ const (
 	WriterTypeStdout WriterType = "stdout"
 	WriterTypeStderr WriterType = "stderr"
 	WriterTypeFile   WriterType = "file"
+
+	// WriterTypeOTLP sends records to an OpenTelemetry collector, see the
+	// otlp package. It has no io.Writer, so CreateWriter rejects it.
+	WriterTypeOTLP WriterType = "otlp"
+
+	// WriterTypeSyslog sends records to a syslog collector, see
+	// SyslogWriter. It needs collector settings, so CreateWriter rejects it.
+	WriterTypeSyslog WriterType = "syslog"
 )
 
 // CreateWriter creates an io.Writer based on the output specification
@@ -78,5 +86,11 @@ func ParseWriterType(output string) WriterType {
 	if output == "stderr" {
 		return WriterTypeStderr
 	}
+	if output == "otlp" {
+		return WriterTypeOTLP
+	}
+	if output == "syslog" {
+		return WriterTypeSyslog
+	}
 	return WriterTypeFile
 }
@@ -47,6 +47,16 @@ func TestCreateWriter(t *testing.T) {
 			output:     "redis://localhost:6379",
 			shouldFail: true,
 		},
+		{
+			name:       "otlp has no writer",
+			output:     "otlp",
+			shouldFail: true,
+		},
+		{
+			name:       "syslog needs collector settings",
+			output:     "syslog",
+			shouldFail: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,6 +191,16 @@ func TestParseWriterType(t *testing.T) {
 			output:   "./logs/app.log",
 			expected: WriterTypeFile,
 		},
+		{
+			name:     "otlp",
+			output:   "otlp",
+			expected: WriterTypeOTLP,
+		},
+		{
+			name:     "syslog",
+			output:   "syslog",
+			expected: WriterTypeSyslog,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,10 +2,28 @@ package apps
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+// ErrAppUnavailable is returned from HandleHTTP, before anything is written to
+// the response, when an app can't serve a request because a resource it needs
+// (such as its script evaluator) failed to initialize. The HTTP dispatcher
+// answers these requests with the configured fallback response.
+var ErrAppUnavailable = errors.New("app unavailable")
+
+// ErrServerShutdown is the cause of the request contexts the HTTP listener
+// cancels when it shuts down, see context.Cause. Apps use it to tell a
+// shutdown from a client that went away, which cancels the request context
+// with context.Canceled.
+var ErrServerShutdown = errors.New("server shutting down")
+
+// StatusClientClosedRequest is the non-standard status, borrowed from nginx,
+// recorded for a request whose client went away before it was answered. It's
+// never sent, as there is no one to send it to.
+const StatusClientClosedRequest = 499
+
 // HTTPHandler defines the interface for handling HTTP requests.
 type HTTPHandler interface {
 	// HandleHTTP processes HTTP requests for this application
@@ -0,0 +1,104 @@
+package apps
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrBodyTooLarge is returned by ReadBody when the request body exceeds the
+// listener's size limit, and by BufferBody when it exceeds the buffer limit.
+// The HTTP dispatcher answers these requests with 413.
+var ErrBodyTooLarge = errors.New("request body too large")
+
+// bufferedBodyKey is the context key for a buffered request body
+type bufferedBodyKey struct{}
+
+// WithBufferedBody returns a copy of ctx carrying the request body read by
+// BufferBody. Every request derived from ctx, including clones made by later
+// middleware, reads the body from this buffer.
+func WithBufferedBody(ctx context.Context, body []byte) context.Context {
+	return context.WithValue(ctx, bufferedBodyKey{}, body)
+}
+
+// BufferedBody returns the request body stored in ctx, and false if no
+// middleware buffered it
+func BufferedBody(ctx context.Context) ([]byte, bool) {
+	body, ok := ctx.Value(bufferedBodyKey{}).([]byte)
+	return body, ok
+}
+
+// BufferBody reads the full request body, up to maxSize bytes, and replaces
+// it with a re-readable copy. A body declaring or holding more than maxSize
+// bytes returns ErrBodyTooLarge without being replaced, as the request can't
+// be served with part of its body.
+func BufferBody(r *http.Request, maxSize int64) ([]byte, error) {
+	if r.ContentLength > maxSize {
+		return nil, fmt.Errorf("%w: exceeds the %d byte buffer limit", ErrBodyTooLarge, maxSize)
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return []byte{}, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+	if err != nil {
+		return nil, bodyReadError(err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("%w: exceeds the %d byte buffer limit", ErrBodyTooLarge, maxSize)
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+
+	setBody(r, body)
+	return body, nil
+}
+
+// ReadBody reads the full request body and replaces it with a re-readable
+// copy, so middleware and apps can each read it. GetBody is set to return
+// the same bytes. A body limited by http.MaxBytesReader is read no further
+// than its limit, and an oversized body returns ErrBodyTooLarge. When the
+// body was buffered into the request context, the buffer is returned
+// instead, regardless of how much of Body earlier readers consumed.
+func ReadBody(r *http.Request) ([]byte, error) {
+	if body, ok := BufferedBody(r.Context()); ok {
+		setBody(r, body)
+		return body, nil
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, bodyReadError(err)
+	}
+	if err := r.Body.Close(); err != nil {
+		return nil, err
+	}
+
+	setBody(r, body)
+	return body, nil
+}
+
+// bodyReadError maps a read past an http.MaxBytesReader limit to
+// ErrBodyTooLarge
+func bodyReadError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return fmt.Errorf("%w: limit is %d bytes", ErrBodyTooLarge, maxErr.Limit)
+	}
+	return err
+}
+
+// setBody replaces the request body, and GetBody, with readers of body
+func setBody(r *http.Request, body []byte) {
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+}
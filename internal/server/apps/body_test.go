@@ -0,0 +1,168 @@
+package apps
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBody(t *testing.T) {
+	t.Run("body can be read again", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"firelynx"}`))
+
+		body, err := ReadBody(req)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"name":"firelynx"}`, string(body))
+
+		again, err := ReadBody(req)
+		require.NoError(t, err)
+		assert.Equal(t, body, again)
+
+		rest, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, body, rest)
+
+		require.NotNil(t, req.GetBody)
+		copied, err := req.GetBody()
+		require.NoError(t, err)
+		fromGetBody, err := io.ReadAll(copied)
+		require.NoError(t, err)
+		assert.Equal(t, body, fromGetBody)
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		body, err := ReadBody(req)
+		require.NoError(t, err)
+		assert.Nil(t, body)
+
+		req.Body = nil
+		body, err = ReadBody(req)
+		require.NoError(t, err)
+		assert.Nil(t, body)
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Body = io.NopCloser(iotest.ErrReader(errors.New("read failed")))
+		_, err := ReadBody(req)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("body within the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345"))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 5)
+
+		body, err := ReadBody(req)
+		require.NoError(t, err)
+		assert.Equal(t, "12345", string(body))
+	})
+
+	t.Run("body over the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456"))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 5)
+
+		_, err := ReadBody(req)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.ErrorContains(t, err, "limit is 5 bytes")
+
+		// The request keeps the limited body, so a later read fails the same way
+		_, err = ReadBody(req)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+}
+
+func TestBufferBody(t *testing.T) {
+	t.Run("body within the limit is buffered", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+
+		body, err := BufferBody(req, 5)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(body))
+
+		rest, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(rest))
+	})
+
+	t.Run("no body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		body, err := BufferBody(req, 5)
+		require.NoError(t, err)
+		assert.Empty(t, body)
+	})
+
+	t.Run("declared length above the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long"))
+
+		_, err := BufferBody(req, 4)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.Contains(t, err.Error(), "exceeds the 4 byte buffer limit")
+	})
+
+	t.Run("streamed body above the limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long"))
+		req.ContentLength = -1
+
+		_, err := BufferBody(req, 4)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+	})
+
+	t.Run("listener limit", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("too long"))
+		req.ContentLength = -1
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 3)
+
+		_, err := BufferBody(req, 100)
+		require.ErrorIs(t, err, ErrBodyTooLarge)
+		assert.Contains(t, err.Error(), "limit is 3 bytes")
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		req := httptest.NewRequest(http.MethodPost, "/", iotest.ErrReader(readErr))
+
+		_, err := BufferBody(req, 100)
+		require.ErrorIs(t, err, readErr)
+	})
+}
+
+func TestReadBody_Buffered(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("payload"))
+	body, err := BufferBody(req, 100)
+	require.NoError(t, err)
+	req = req.WithContext(WithBufferedBody(req.Context(), body))
+
+	// A consumer drains the body without restoring it
+	_, err = io.ReadAll(req.Body)
+	require.NoError(t, err)
+
+	// Every later reader, including of clones, still gets the full body
+	for range 2 {
+		read, err := ReadBody(req)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(read))
+	}
+
+	clone := req.Clone(req.Context())
+	clone.Body = http.NoBody
+	read, err := ReadBody(clone)
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(read))
+
+	buffered, ok := BufferedBody(clone.Context())
+	require.True(t, ok)
+	assert.Equal(t, "payload", string(buffered))
+
+	_, ok = BufferedBody(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	assert.False(t, ok)
+}
@@ -19,11 +19,13 @@ var (
 	errMissingOperator = errors.New("operator is required")
 	errInvalidOperator = errors.New("operator must be one of +, -, *, /")
 	errDivisionByZero  = errors.New("division by zero")
+	errUnknownConstant = errors.New("unknown constant")
 )
 
 // App is a calculation application that applies an operator to two operands.
 type App struct {
-	id string
+	id        string
+	constants map[string]float64
 }
 
 // Request defines the typed input parameters for calculation requests.
@@ -31,6 +33,9 @@ type Request struct {
 	Left     float64 `json:"left"     jsonschema:"Left operand"`
 	Right    float64 `json:"right"    jsonschema:"Right operand"`
 	Operator string  `json:"operator" jsonschema:"Operator to apply: +, -, *, /"`
+
+	LeftConstant  string `json:"left_constant,omitempty"  jsonschema:"Name of a configured constant used instead of left"`
+	RightConstant string `json:"right_constant,omitempty" jsonschema:"Name of a configured constant used instead of right"`
 }
 
 // Response defines the typed output structure for calculation responses.
@@ -41,7 +46,7 @@ type Response struct {
 
 // New creates a new calculation app from a Config DTO.
 func New(cfg *Config) *App {
-	return &App{id: cfg.ID}
+	return &App{id: cfg.ID, constants: cfg.Constants}
 }
 
 // String returns the unique identifier of the application.
@@ -71,7 +76,7 @@ func (a *App) HandleHTTP(
 		return nil
 	}
 
-	result, err := calculate(req)
+	result, err := a.calculate(req)
 	if err != nil {
 		if writeErr := writeCalculationError(w, http.StatusBadRequest, err.Error()); writeErr != nil {
 			return writeErr
@@ -86,7 +91,17 @@ func (a *App) HandleHTTP(
 	return nil
 }
 
-func calculate(req Request) (float64, error) {
+// calculate applies the operator of req to its operands, replacing those
+// named by a constant with its value
+func (a *App) calculate(req Request) (float64, error) {
+	var err error
+	if req.Left, err = a.operand(req.Left, req.LeftConstant); err != nil {
+		return 0, err
+	}
+	if req.Right, err = a.operand(req.Right, req.RightConstant); err != nil {
+		return 0, err
+	}
+
 	switch req.Operator {
 	case "":
 		return 0, errMissingOperator
@@ -106,6 +121,18 @@ func calculate(req Request) (float64, error) {
 	}
 }
 
+// operand returns the value of the named constant, or value without a name
+func (a *App) operand(value float64, constant string) (float64, error) {
+	if constant == "" {
+		return value, nil
+	}
+	v, ok := a.constants[constant]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", errUnknownConstant, constant)
+	}
+	return v, nil
+}
+
 func writeCalculationError(w http.ResponseWriter, status int, message string) error {
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(Response{Error: message}); err != nil {
@@ -12,7 +12,7 @@ import (
 )
 
 func TestCalculation_HandleHTTP(t *testing.T) {
-	app := New(&Config{ID: "calc"})
+	app := New(&Config{ID: "calc", Constants: map[string]float64{"vat": 0.2}})
 
 	tests := []struct {
 		name       string
@@ -28,6 +28,8 @@ func TestCalculation_HandleHTTP(t *testing.T) {
 		{name: "missing operator", body: `{"left":6,"right":2}`, wantStatus: http.StatusBadRequest, wantError: "operator is required"},
 		{name: "invalid operator", body: `{"left":6,"right":2,"operator":"%"}`, wantStatus: http.StatusBadRequest, wantError: "operator must be one of +, -, *, /"},
 		{name: "divide by zero", body: `{"left":6,"right":0,"operator":"/"}`, wantStatus: http.StatusBadRequest, wantError: "division by zero"},
+		{name: "constant", body: `{"left":50,"right_constant":"vat","operator":"*"}`, wantStatus: http.StatusOK, wantResult: 10},
+		{name: "unknown constant", body: `{"left_constant":"pi","right":2,"operator":"*"}`, wantStatus: http.StatusBadRequest, wantError: "unknown constant"},
 	}
 
 	for _, tt := range tests {
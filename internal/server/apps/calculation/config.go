@@ -5,4 +5,7 @@ package calculation
 type Config struct {
 	// ID is the unique identifier for this app instance.
 	ID string
+
+	// Constants are numbers requests may use as operands by name.
+	Constants map[string]float64
 }
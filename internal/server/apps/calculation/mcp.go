@@ -4,6 +4,7 @@ import (
 	"context"
 
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 )
 
 // MCPToolName returns the default tool name used when no user override is set.
@@ -18,11 +19,12 @@ func (a *App) MCPToolDescription() string {
 
 // MCPToolOption returns the mcp-io option that registers this app as an MCP
 // tool with input/output schemas auto-generated from Request / Response.
-func (a *App) MCPToolOption(name string) mcpio.Option {
+func (a *App) MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option {
 	return mcpio.WithTool(
 		name,
 		a.MCPToolDescription(),
 		a.calculateToolFunc,
+		opts...,
 	)
 }
 
@@ -31,7 +33,7 @@ func (a *App) calculateToolFunc(
 	_ mcpio.RequestContext,
 	input Request,
 ) (Response, error) {
-	result, err := calculate(input)
+	result, err := a.calculate(input)
 	if err != nil {
 		return Response{}, mcpio.ValidationError(err.Error())
 	}
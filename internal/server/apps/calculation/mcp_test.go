@@ -49,3 +49,19 @@ func TestCalculation_ToolFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculation_ToolFunc_Constants(t *testing.T) {
+	app := New(&Config{ID: "calc", Constants: map[string]float64{"vat": 0.2, "zero": 0}})
+
+	out, err := app.calculateToolFunc(t.Context(), nil, Request{Left: 50, RightConstant: "vat", Operator: "*"})
+	require.NoError(t, err)
+	assert.InEpsilon(t, 10, out.Result, 0.000001)
+
+	_, err = app.calculateToolFunc(t.Context(), nil, Request{Left: 1, RightConstant: "zero", Operator: "/"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "division by zero")
+
+	_, err = app.calculateToolFunc(t.Context(), nil, Request{LeftConstant: "pi", Right: 2, Operator: "*"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown constant: "pi"`)
+}
@@ -0,0 +1,75 @@
+package apps
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// trustedProxiesKey is the context key for the trusted proxies of the
+// listener that received the request
+type trustedProxiesKey struct{}
+
+// WithTrustedProxies returns a copy of ctx carrying the networks of the
+// reverse proxies whose forwarding headers ClientIP honors
+func WithTrustedProxies(ctx context.Context, proxies []netip.Prefix) context.Context {
+	return context.WithValue(ctx, trustedProxiesKey{}, proxies)
+}
+
+// TrustedProxiesFromContext returns the trusted proxies stored in ctx, or nil
+// if the listener trusts none
+func TrustedProxiesFromContext(ctx context.Context) []netip.Prefix {
+	proxies, _ := ctx.Value(trustedProxiesKey{}).([]netip.Prefix)
+	return proxies
+}
+
+// ClientIP returns the IP address of the client that sent r. Without
+// trustForwarded, or when the connection's peer isn't one of the listener's
+// trusted proxies, it is the peer's address. Otherwise X-Forwarded-For is
+// read from the right, skipping trusted proxies, and the first other address
+// is the client; X-Real-IP is used when X-Forwarded-For is missing. Clients
+// can send any forwarding headers, so only proxies can be trusted with them.
+func ClientIP(r *http.Request, trustForwarded bool) string {
+	peer := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		peer = host
+	}
+	if !trustForwarded {
+		return peer
+	}
+
+	proxies := TrustedProxiesFromContext(r.Context())
+	if !isTrusted(proxies, peer) {
+		return peer
+	}
+
+	if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		hops := strings.Split(strings.Join(xff, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if hop != "" && (i == 0 || !isTrusted(proxies, hop)) {
+				return hop
+			}
+		}
+	}
+
+	if xri := strings.TrimSpace(r.Header.Get("X-Real-IP")); xri != "" {
+		return xri
+	}
+	return peer
+}
+
+// isTrusted reports whether ip is inside one of the trusted proxy networks
+func isTrusted(proxies []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.WithZone("").Unmap()
+	return slices.ContainsFunc(proxies, func(p netip.Prefix) bool {
+		return p.Contains(addr)
+	})
+}
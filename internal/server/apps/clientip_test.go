@@ -0,0 +1,132 @@
+package apps
+
+import (
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	t.Parallel()
+
+	proxies := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("fd00::/8"),
+	}
+
+	tests := []struct {
+		name           string
+		headers        map[string][]string
+		remoteAddr     string
+		proxies        []netip.Prefix
+		trustForwarded bool
+		expectedIP     string
+	}{
+		{
+			name:           "X-Forwarded-For from a trusted proxy",
+			headers:        map[string][]string{"X-Forwarded-For": {"192.168.1.1"}},
+			remoteAddr:     "10.0.0.1:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "192.168.1.1",
+		},
+		{
+			name:           "trusted proxy hops are skipped",
+			headers:        map[string][]string{"X-Forwarded-For": {"192.168.1.1, 10.0.0.7, 10.0.0.8"}},
+			remoteAddr:     "10.0.0.1:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "192.168.1.1",
+		},
+		{
+			name:           "addresses left of the first untrusted hop are ignored",
+			headers:        map[string][]string{"X-Forwarded-For": {"1.2.3.4, 192.168.1.1, 10.0.0.7"}},
+			remoteAddr:     "10.0.0.1:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "192.168.1.1",
+		},
+		{
+			name:           "repeated X-Forwarded-For headers",
+			headers:        map[string][]string{"X-Forwarded-For": {"1.2.3.4", "192.168.1.1"}},
+			remoteAddr:     "10.0.0.1:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "192.168.1.1",
+		},
+		{
+			name:           "every hop trusted",
+			headers:        map[string][]string{"X-Forwarded-For": {"10.0.0.9, 10.0.0.8"}},
+			remoteAddr:     "10.0.0.1:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "10.0.0.9",
+		},
+		{
+			name:           "X-Real-IP from a trusted proxy",
+			headers:        map[string][]string{"X-Real-IP": {"192.168.1.1"}},
+			remoteAddr:     "[fd00::1]:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "192.168.1.1",
+		},
+		{
+			name:           "spoofed X-Forwarded-For from an untrusted peer",
+			headers:        map[string][]string{"X-Forwarded-For": {"10.0.0.5"}},
+			remoteAddr:     "203.0.113.7:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "203.0.113.7",
+		},
+		{
+			name:           "spoofed X-Real-IP from an untrusted peer",
+			headers:        map[string][]string{"X-Real-IP": {"10.0.0.5"}},
+			remoteAddr:     "203.0.113.7:12345",
+			proxies:        proxies,
+			trustForwarded: true,
+			expectedIP:     "203.0.113.7",
+		},
+		{
+			name:           "forwarding headers ignored without trusted proxies",
+			headers:        map[string][]string{"X-Forwarded-For": {"192.168.1.1"}},
+			remoteAddr:     "10.0.0.1:12345",
+			trustForwarded: true,
+			expectedIP:     "10.0.0.1",
+		},
+		{
+			name:       "forwarding headers ignored when not asked for",
+			headers:    map[string][]string{"X-Forwarded-For": {"192.168.1.1"}},
+			remoteAddr: "10.0.0.1:12345",
+			proxies:    proxies,
+			expectedIP: "10.0.0.1",
+		},
+		{
+			name:       "IPv6 remote address",
+			remoteAddr: "[2001:db8::1]:8080",
+			expectedIP: "2001:db8::1",
+		},
+		{
+			name:       "remote address without port",
+			remoteAddr: "192.168.1.1",
+			expectedIP: "192.168.1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, values := range tt.headers {
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			if tt.proxies != nil {
+				req = req.WithContext(WithTrustedProxies(req.Context(), tt.proxies))
+			}
+			assert.Equal(t, tt.expectedIP, ClientIP(req, tt.trustForwarded))
+		})
+	}
+}
@@ -0,0 +1,222 @@
+// Package composite provides the composite script app, which runs a chain of
+// script apps for each request.
+//
+// Each step receives the composite's static data, with the objects returned by
+// the steps before it merged over it, under "data". A step that fails, or that
+// returns an object with a non-empty "error" string, ends the chain. Otherwise
+// the combined result of every step is returned as JSON.
+package composite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/script"
+)
+
+var (
+	// ErrUnknownStepApp is returned when a step references an app that is
+	// not present in the resolved app set.
+	ErrUnknownStepApp = errors.New("composite step points to unknown app")
+
+	// ErrAppNotStep is returned when a step references an app that can't run
+	// as a step, because it isn't a script app.
+	ErrAppNotStep = errors.New("app can't run as a composite step")
+)
+
+// Step is implemented by apps that can run as a step of a composite app,
+// such as *script.ScriptApp
+type Step interface {
+	RunStep(ctx context.Context, r *http.Request, stepData map[string]any, timeout time.Duration) (any, error)
+}
+
+// AppLookup resolves an app ID to its server-side instance. The transaction
+// layer supplies an implementation backed by *serverApps.AppInstances after
+// every app has been constructed.
+type AppLookup func(id string) (serverApps.App, bool)
+
+// defaultErrorStatus is the response status for a step result with an
+// "error" and no valid "status"
+const defaultErrorStatus = http.StatusUnprocessableEntity
+
+// App runs its steps in sequence for each request
+type App struct {
+	mu sync.RWMutex
+
+	id          string
+	stepAppIDs  []string
+	staticData  map[string]any
+	stepTimeout time.Duration
+	logger      *slog.Logger
+
+	// steps are the resolved step apps. Nil until Build() succeeds.
+	steps []Step
+}
+
+// New creates a new composite app from the given configuration. The returned
+// App has no steps — call Build() to resolve them before serving HTTP.
+func New(cfg *Config) *App {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &App{
+		id:          cfg.ID,
+		stepAppIDs:  append([]string(nil), cfg.StepAppIDs...),
+		staticData:  maps.Clone(cfg.StaticData),
+		stepTimeout: cfg.StepTimeout,
+		logger:      logger,
+	}
+}
+
+// String returns the unique identifier of the application
+func (a *App) String() string {
+	return a.id
+}
+
+// StepAppIDs returns a copy of the IDs of the step apps, in order
+func (a *App) StepAppIDs() []string {
+	return append([]string(nil), a.stepAppIDs...)
+}
+
+// ValidateRefs verifies that every step resolves to an app that can run as a
+// step. Returns a joined error covering every violation, or nil when every
+// step resolves.
+func (a *App) ValidateRefs(lookup AppLookup) error {
+	_, err := a.resolve(lookup)
+	return err
+}
+
+// Build resolves the step apps. It must be called by the transaction layer
+// after every app in the system is constructed and before HTTP traffic
+// reaches this app. Subsequent calls replace the previous steps.
+func (a *App) Build(lookup AppLookup) error {
+	steps, err := a.resolve(lookup)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.steps = steps
+	return nil
+}
+
+func (a *App) resolve(lookup AppLookup) ([]Step, error) {
+	if lookup == nil {
+		return nil, fmt.Errorf("app lookup must not be nil")
+	}
+
+	steps := make([]Step, 0, len(a.stepAppIDs))
+	var errs []error
+	for i, id := range a.stepAppIDs {
+		app, ok := lookup(id)
+		if !ok {
+			errs = append(errs, fmt.Errorf("step[%d] (app_id=%q): %w", i, id, ErrUnknownStepApp))
+			continue
+		}
+		step, ok := app.(Step)
+		if !ok {
+			errs = append(errs, fmt.Errorf("step[%d] (app_id=%q): %w: %T", i, id, ErrAppNotStep, app))
+			continue
+		}
+		steps = append(steps, step)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return steps, nil
+}
+
+// HandleHTTP runs each step in turn and writes the combined result
+func (a *App) HandleHTTP(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	a.mu.RLock()
+	steps := a.steps
+	a.mu.RUnlock()
+	if steps == nil {
+		return fmt.Errorf("%w: composite app %s has no steps", serverApps.ErrAppUnavailable, a.id)
+	}
+
+	// Every step reads the request, so its body is replayed for each one
+	body, err := serverApps.ReadBody(r)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read request body: %w", serverApps.ErrAppUnavailable, err)
+	}
+
+	state := maps.Clone(a.staticData)
+	if state == nil {
+		state = make(map[string]any)
+	}
+	combined := make(map[string]any)
+
+	for i, step := range steps {
+		stepID := a.stepAppIDs[i]
+		stepReq := r.Clone(ctx)
+		stepReq.Body = io.NopCloser(bytes.NewReader(body))
+
+		start := time.Now()
+		result, err := step.RunStep(ctx, stepReq, maps.Clone(state), a.stepTimeout)
+		if err != nil {
+			a.logger.Error("Composite step failed", "step", stepID, "error", err, "duration", time.Since(start))
+			if errors.Is(err, script.ErrExecTimeout) {
+				http.Error(w, "Script Execution Timeout", http.StatusGatewayTimeout)
+			} else {
+				http.Error(w, "Script Execution Error", http.StatusInternalServerError)
+			}
+			return fmt.Errorf("step %s: %w", stepID, err)
+		}
+
+		output, ok := result.(map[string]any)
+		if !ok {
+			err := fmt.Errorf("step %s returned %T, want an object", stepID, result)
+			a.logger.Error("Composite step returned an invalid result", "step", stepID, "error", err)
+			http.Error(w, "Result Processing Error", http.StatusInternalServerError)
+			return err
+		}
+
+		if msg, _ := output["error"].(string); msg != "" {
+			a.logger.Debug("Composite step ended the chain", "step", stepID, "error", msg)
+			return writeJSON(w, errorStatus(output), output)
+		}
+
+		a.logger.Debug("Composite step executed successfully", "step", stepID, "duration", time.Since(start))
+		maps.Copy(state, output)
+		maps.Copy(combined, output)
+	}
+
+	return writeJSON(w, http.StatusOK, combined)
+}
+
+// errorStatus returns the status requested by a step's error result through
+// its "status" field, if that is an HTTP error status
+func errorStatus(output map[string]any) int {
+	var status int
+	switch v := output["status"].(type) {
+	case int:
+		status = v
+	case int64:
+		status = int(v)
+	case float64:
+		status = int(v)
+	}
+	if status < 400 || status > 599 {
+		return defaultErrorStatus
+	}
+	return status
+}
+
+// writeJSON writes v as the JSON response body with the given status
+func writeJSON(w http.ResponseWriter, status int, v map[string]any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,194 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps/script"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStep is a step app that records its input and returns run's result
+type fakeStep struct {
+	id    string
+	run   func(r *http.Request, data map[string]any) (any, error)
+	calls []map[string]any
+
+	timeout time.Duration
+}
+
+func (s *fakeStep) String() string { return s.id }
+
+func (s *fakeStep) HandleHTTP(context.Context, http.ResponseWriter, *http.Request) error {
+	return errors.New("not used")
+}
+
+func (s *fakeStep) RunStep(_ context.Context, r *http.Request, data map[string]any, timeout time.Duration) (any, error) {
+	s.calls = append(s.calls, data)
+	s.timeout = timeout
+	return s.run(r, data)
+}
+
+// notAStep is an app that can't run as a step
+type notAStep struct{ id string }
+
+func (a *notAStep) String() string { return a.id }
+
+func (a *notAStep) HandleHTTP(context.Context, http.ResponseWriter, *http.Request) error {
+	return nil
+}
+
+// newApp returns a composite app built against the given apps
+func newApp(t *testing.T, cfg *Config, apps ...serverApps.App) *App {
+	t.Helper()
+	instances, err := serverApps.NewAppInstances(apps)
+	require.NoError(t, err)
+	app := New(cfg)
+	require.NoError(t, app.Build(instances.GetApp))
+	return app
+}
+
+func returns(result any) func(*http.Request, map[string]any) (any, error) {
+	return func(*http.Request, map[string]any) (any, error) { return result, nil }
+}
+
+func TestApp_ValidateRefs(t *testing.T) {
+	instances, err := serverApps.NewAppInstances([]serverApps.App{
+		&fakeStep{id: "auth"},
+		&notAStep{id: "echo"},
+	})
+	require.NoError(t, err)
+
+	app := New(&Config{ID: "chain", StepAppIDs: []string{"auth"}})
+	require.NoError(t, app.ValidateRefs(instances.GetApp))
+
+	app = New(&Config{ID: "chain", StepAppIDs: []string{"auth", "ghost", "echo"}})
+	err = app.ValidateRefs(instances.GetApp)
+	require.ErrorIs(t, err, ErrUnknownStepApp)
+	require.ErrorIs(t, err, ErrAppNotStep)
+	assert.Contains(t, err.Error(), `step[1] (app_id="ghost")`)
+	assert.Contains(t, err.Error(), `step[2] (app_id="echo")`)
+
+	require.Error(t, app.Build(instances.GetApp))
+	require.Error(t, app.ValidateRefs(nil))
+}
+
+func TestApp_HandleHTTP(t *testing.T) {
+	t.Run("runs steps in order with shared state and returns the combined result", func(t *testing.T) {
+		auth := &fakeStep{id: "auth", run: returns(map[string]any{"user": "alice", "role": "admin"})}
+		transform := &fakeStep{id: "transform", run: func(_ *http.Request, data map[string]any) (any, error) {
+			return map[string]any{"greeting": fmt.Sprintf("%s, %s", data["prefix"], data["user"])}, nil
+		}}
+		respond := &fakeStep{id: "respond", run: returns(map[string]any{"role": "viewer", "done": true})}
+
+		app := newApp(t, &Config{
+			ID:          "chain",
+			StepAppIDs:  []string{"auth", "transform", "respond"},
+			StaticData:  map[string]any{"prefix": "Hello"},
+			StepTimeout: time.Second,
+		}, auth, transform, respond)
+
+		w := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"user": "alice", "role": "viewer", "greeting": "Hello, alice", "done": true}`, w.Body.String())
+
+		assert.Equal(t, map[string]any{"prefix": "Hello"}, auth.calls[0])
+		assert.Equal(t, map[string]any{"prefix": "Hello", "user": "alice", "role": "admin"}, transform.calls[0])
+		assert.Equal(t, "Hello, alice", respond.calls[0]["greeting"])
+		assert.Equal(t, time.Second, respond.timeout)
+	})
+
+	t.Run("each step reads the request body", func(t *testing.T) {
+		var bodies []string
+		readBody := func(r *http.Request, _ map[string]any) (any, error) {
+			body, err := io.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			return map[string]any{}, err
+		}
+		app := newApp(t, &Config{ID: "chain", StepAppIDs: []string{"a", "b"}},
+			&fakeStep{id: "a", run: readBody}, &fakeStep{id: "b", run: readBody})
+
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"lynx"}`))
+		require.NoError(t, app.HandleHTTP(t.Context(), httptest.NewRecorder(), req))
+		assert.Equal(t, []string{`{"name":"lynx"}`, `{"name":"lynx"}`}, bodies)
+	})
+
+	t.Run("an error result ends the chain", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			result     map[string]any
+			wantStatus int
+		}{
+			"with status":    {result: map[string]any{"error": "forbidden", "status": int64(403)}, wantStatus: http.StatusForbidden},
+			"default status": {result: map[string]any{"error": "bad input"}, wantStatus: http.StatusUnprocessableEntity},
+			"invalid status": {result: map[string]any{"error": "bad input", "status": 200.0}, wantStatus: http.StatusUnprocessableEntity},
+		} {
+			t.Run(name, func(t *testing.T) {
+				next := &fakeStep{id: "respond", run: returns(map[string]any{})}
+				app := newApp(t, &Config{ID: "chain", StepAppIDs: []string{"auth", "respond"}},
+					&fakeStep{id: "auth", run: returns(tt.result)}, next)
+
+				w := httptest.NewRecorder()
+				require.NoError(t, app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil)))
+				assert.Equal(t, tt.wantStatus, w.Code)
+				assert.Contains(t, w.Body.String(), `"error":`)
+				assert.Empty(t, next.calls, "later steps don't run")
+			})
+		}
+	})
+
+	t.Run("a failed step ends the chain", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			err        error
+			wantStatus int
+		}{
+			"execution error": {err: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+			"timeout":         {err: fmt.Errorf("%w: deadline", script.ErrExecTimeout), wantStatus: http.StatusGatewayTimeout},
+		} {
+			t.Run(name, func(t *testing.T) {
+				failing := &fakeStep{id: "transform", run: func(*http.Request, map[string]any) (any, error) {
+					return nil, tt.err
+				}}
+				next := &fakeStep{id: "respond", run: returns(map[string]any{})}
+				app := newApp(t, &Config{ID: "chain", StepAppIDs: []string{"transform", "respond"}}, failing, next)
+
+				w := httptest.NewRecorder()
+				err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+				require.ErrorIs(t, err, tt.err)
+				assert.Contains(t, err.Error(), "step transform")
+				assert.Equal(t, tt.wantStatus, w.Code)
+				assert.Empty(t, next.calls)
+			})
+		}
+	})
+
+	t.Run("a step must return an object", func(t *testing.T) {
+		app := newApp(t, &Config{ID: "chain", StepAppIDs: []string{"respond"}},
+			&fakeStep{id: "respond", run: returns("plain text")})
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "step respond returned string, want an object")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("unavailable before Build", func(t *testing.T) {
+		app := New(&Config{ID: "chain", StepAppIDs: []string{"respond"}})
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.ErrorIs(t, err, serverApps.ErrAppUnavailable)
+		assert.Zero(t, w.Body.Len(), "nothing is written so the dispatcher can respond")
+	})
+}
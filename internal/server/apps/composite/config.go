@@ -0,0 +1,26 @@
+package composite
+
+import (
+	"log/slog"
+	"time"
+)
+
+// Config contains everything needed to instantiate a composite script app.
+// This is a Data Transfer Object (DTO) with no dependencies on domain packages.
+// All validation happens at the domain layer before creating this config.
+type Config struct {
+	// ID is the unique identifier for this app instance
+	ID string
+
+	// StepAppIDs are the IDs of the script apps run in sequence
+	StepAppIDs []string
+
+	// StaticData is passed to every step, over the step's own static data
+	StaticData map[string]any
+
+	// StepTimeout replaces each step's own timeout when positive
+	StepTimeout time.Duration
+
+	// Logger is the structured logger configured for this app instance
+	Logger *slog.Logger
+}
@@ -0,0 +1,60 @@
+package apps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ETag returns a strong entity tag for a representation, from a hash of its
+// content type and body, so representations that differ in either get
+// different tags
+func ETag(contentType string, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, contentType)
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(body)
+	return formatETag(h)
+}
+
+// ETagFromReader returns a strong entity tag from a hash of everything read
+// from r, e.g. a file's content
+func ETagFromReader(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return formatETag(h), nil
+}
+
+func formatETag(h hash.Hash) string {
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// NotModified reports whether a GET or HEAD request's If-None-Match header
+// matches etag, in which case the response should be a 304. Tags are compared
+// weakly, as If-None-Match requires, so W/"x" matches "x".
+func NotModified(r *http.Request, etag string) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	for tag := range strings.SplitSeq(strings.Join(r.Header.Values("If-None-Match"), ","), ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteNotModified writes a 304 response carrying etag
+func WriteNotModified(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.WriteHeader(http.StatusNotModified)
+}
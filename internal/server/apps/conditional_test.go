@@ -0,0 +1,66 @@
+package apps
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag(t *testing.T) {
+	etag := ETag("text/plain", []byte("hello"))
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, etag, ETag("text/plain", []byte("hello")))
+	assert.NotEqual(t, etag, ETag("text/plain", []byte("hello!")))
+	assert.NotEqual(t, etag, ETag("application/json", []byte("hello")))
+
+	fromReader, err := ETagFromReader(strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, fromReader)
+	again, err := ETagFromReader(strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, fromReader, again)
+}
+
+func TestNotModified(t *testing.T) {
+	const etag = `"abc"`
+
+	tests := []struct {
+		name        string
+		method      string
+		ifNoneMatch []string
+		expected    bool
+	}{
+		{"no header", http.MethodGet, nil, false},
+		{"match", http.MethodGet, []string{`"abc"`}, true},
+		{"head request", http.MethodHead, []string{`"abc"`}, true},
+		{"weak match", http.MethodGet, []string{`W/"abc"`}, true},
+		{"match in list", http.MethodGet, []string{`"xyz", "abc"`}, true},
+		{"match in repeated header", http.MethodGet, []string{`"xyz"`, `"abc"`}, true},
+		{"wildcard", http.MethodGet, []string{"*"}, true},
+		{"no match", http.MethodGet, []string{`"xyz"`}, false},
+		{"unquoted tag doesn't match", http.MethodGet, []string{"abc"}, false},
+		{"other methods aren't cached", http.MethodPost, []string{`"abc"`}, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/", nil)
+			for _, value := range tc.ifNoneMatch {
+				req.Header.Add("If-None-Match", value)
+			}
+			assert.Equal(t, tc.expected, NotModified(req, etag))
+		})
+	}
+}
+
+func TestWriteNotModified(t *testing.T) {
+	rec := httptest.NewRecorder()
+	WriteNotModified(rec, `"abc"`)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, `"abc"`, rec.Header().Get("ETag"))
+	assert.Empty(t, rec.Body.String())
+}
@@ -1,5 +1,7 @@
 package echo
 
+import "text/template"
+
 // Config contains everything needed to instantiate an echo app.
 // This is a Data Transfer Object (DTO) with no dependencies on domain packages.
 // All validation happens at the domain layer before creating this config.
@@ -9,4 +11,19 @@ type Config struct {
 
 	// Response is the text content to return for HTTP requests
 	Response string
+
+	// Representations are response bodies keyed by media type, chosen by the
+	// request's Accept header. Empty means Response is always returned.
+	Representations map[string]string
+
+	// MediaTypes are the keys of Representations in order of preference
+	MediaTypes []string
+
+	// ResponseTemplate, when set, is executed per request instead of
+	// returning Response
+	ResponseTemplate *template.Template
+
+	// RepresentationTemplates, when set, are executed per request instead of
+	// returning the Representations of the same media type
+	RepresentationTemplates map[string]*template.Template
 }
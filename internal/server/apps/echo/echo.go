@@ -4,19 +4,33 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
 )
 
 // App is a simple application that echoes request information
 type App struct {
-	id       string
-	response string
+	id              string
+	response        string
+	representations map[string]string
+	mediaTypes      []string
+
+	responseTemplate        *template.Template
+	representationTemplates map[string]*template.Template
 }
 
 // New creates a new EchoApp from a Config DTO
 func New(cfg *Config) *App {
 	return &App{
-		id:       cfg.ID,
-		response: cfg.Response,
+		id:              cfg.ID,
+		response:        cfg.Response,
+		representations: cfg.Representations,
+		mediaTypes:      cfg.MediaTypes,
+
+		responseTemplate:        cfg.ResponseTemplate,
+		representationTemplates: cfg.RepresentationTemplates,
 	}
 }
 
@@ -31,11 +45,53 @@ func (a *App) HandleHTTP(
 	w http.ResponseWriter,
 	r *http.Request,
 ) error {
-	// Set content type to plain text for simple response
-	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if len(a.representations) > 0 {
+		return a.handleNegotiated(w, r)
+	}
+
+	body, err := render(a.responseTemplate, a.response, r)
+	if err != nil {
+		return fmt.Errorf("failed to execute response template: %w", err)
+	}
+
+	// Plain text for the simple response
+	return writeBody(w, r, "text/plain; charset=utf-8", body)
+}
+
+// handleNegotiated writes the representation the Accept header prefers, or a
+// 406 listing the available media types when none is acceptable
+func (a *App) handleNegotiated(w http.ResponseWriter, r *http.Request) error {
+	w.Header().Add("Vary", "Accept")
+
+	mediaType, ok := apps.Negotiate(r.Header.Get("Accept"), a.mediaTypes)
+	if !ok {
+		http.Error(w,
+			"Not Acceptable: available media types are "+strings.Join(a.mediaTypes, ", "),
+			http.StatusNotAcceptable)
+		return nil
+	}
+
+	body, err := render(a.representationTemplates[mediaType], a.representations[mediaType], r)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s template: %w", mediaType, err)
+	}
+
+	return writeBody(w, r, mediaType, body)
+}
+
+// writeBody writes body with a strong ETag, or a 304 when the request's
+// If-None-Match already holds it. Rendered templates get a new tag whenever
+// their output changes.
+func writeBody(w http.ResponseWriter, r *http.Request, contentType string, body []byte) error {
+	etag := apps.ETag(contentType, body)
+	if apps.NotModified(r, etag) {
+		apps.WriteNotModified(w, etag)
+		return nil
+	}
 
-	// Write the configured response
-	if _, err := w.Write([]byte(a.response)); err != nil {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Content-Type", contentType)
+	if _, err := w.Write(body); err != nil {
 		return fmt.Errorf("failed to write response: %w", err)
 	}
 
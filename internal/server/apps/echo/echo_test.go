@@ -6,7 +6,9 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"text/template"
 
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -163,3 +165,187 @@ func (f *failingResponseWriter) Write([]byte) (int, error) {
 func (f *failingResponseWriter) WriteHeader(statusCode int) {
 	f.status = statusCode
 }
+
+func TestEchoApp_HandleHTTP_Negotiation(t *testing.T) {
+	app := New(&Config{
+		ID:       "negotiating-app",
+		Response: "fallback",
+		Representations: map[string]string{
+			"application/json": `{"message":"hello"}`,
+			"text/plain":       "hello",
+		},
+		MediaTypes: []string{"application/json", "text/plain"},
+	})
+
+	tests := []struct {
+		name        string
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"no accept header", "", http.StatusOK, "application/json", `{"message":"hello"}`},
+		{"exact match", "text/plain", http.StatusOK, "text/plain", "hello"},
+		{"wildcard", "*/*", http.StatusOK, "application/json", `{"message":"hello"}`},
+		{"type wildcard", "text/*", http.StatusOK, "text/plain", "hello"},
+		{"quality values", "application/json;q=0.2, text/plain;q=0.8", http.StatusOK, "text/plain", "hello"},
+		{"excluded by q=0", "application/json;q=0, */*;q=0.1", http.StatusOK, "text/plain", "hello"},
+		{"not acceptable", "image/png", http.StatusNotAcceptable, "text/plain; charset=utf-8", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			rec := httptest.NewRecorder()
+
+			require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, tc.contentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+			if tc.status == http.StatusOK {
+				assert.Equal(t, tc.body, rec.Body.String())
+			} else {
+				assert.Contains(t, rec.Body.String(), "application/json, text/plain")
+			}
+		})
+	}
+}
+
+func TestEchoApp_HandleHTTP_Conditional(t *testing.T) {
+	app := New(&Config{ID: "cached-app", Response: "hello"})
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+		return rec
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	assert.Equal(t, apps.ETag("text/plain; charset=utf-8", []byte("hello")), etag)
+
+	t.Run("matching tag", func(t *testing.T) {
+		rec := get(etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("non-matching tag", func(t *testing.T) {
+		rec := get(`"stale"`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+		assert.Equal(t, "hello", rec.Body.String())
+	})
+
+	t.Run("representations have their own tags", func(t *testing.T) {
+		app := New(&Config{
+			ID: "negotiating-app",
+			Representations: map[string]string{
+				"application/json": `"hello"`,
+				"text/plain":       `"hello"`,
+			},
+			MediaTypes: []string{"application/json", "text/plain"},
+		})
+
+		tags := make(map[string]string)
+		for _, mediaType := range []string{"application/json", "text/plain"} {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Accept", mediaType)
+			rec := httptest.NewRecorder()
+			require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+			tags[mediaType] = rec.Header().Get("ETag")
+		}
+		assert.NotEqual(t, tags["application/json"], tags["text/plain"])
+
+		// The JSON tag doesn't match the text representation
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", "text/plain")
+		req.Header.Set("If-None-Match", tags["application/json"])
+		rec := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+	})
+
+	t.Run("template output changes the tag", func(t *testing.T) {
+		app := New(&Config{
+			ID:               "template-app",
+			Response:         "{{.Path}}",
+			ResponseTemplate: template.Must(template.New("response").Parse("{{.Path}}")),
+		})
+
+		tagFor := func(path string) string {
+			rec := httptest.NewRecorder()
+			require.NoError(t, app.HandleHTTP(t.Context(), rec, httptest.NewRequest(http.MethodGet, path, nil)))
+			return rec.Header().Get("ETag")
+		}
+		assert.Equal(t, tagFor("/a"), tagFor("/a"))
+		assert.NotEqual(t, tagFor("/a"), tagFor("/b"))
+	})
+}
+
+func TestEchoApp_HandleHTTP_Template(t *testing.T) {
+	parse := func(text string) *template.Template {
+		return template.Must(template.New("test").Option("missingkey=zero").Parse(text))
+	}
+
+	t.Run("response template", func(t *testing.T) {
+		app := New(&Config{
+			ID:       "template-app",
+			Response: "raw",
+			ResponseTemplate: parse(
+				`{{.Method}} {{.Path}} q={{.Query.Get "q"}} ua={{.Headers.Get "User-Agent"}} id={{.PathParams.id}} missing={{.PathParams.none}}`,
+			),
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/items/42?q=search", nil)
+		req.Header.Set("User-Agent", "tester")
+		req = req.WithContext(apps.WithPathParams(req.Context(), map[string]string{"id": "42"}))
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+		assert.Equal(t, "POST /items/42 q=search ua=tester id=42 missing=", rec.Body.String())
+		assert.Equal(t, "text/plain; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("representation template", func(t *testing.T) {
+		app := New(&Config{
+			ID:                      "template-app",
+			Representations:         map[string]string{"application/json": "raw"},
+			MediaTypes:              []string{"application/json"},
+			RepresentationTemplates: map[string]*template.Template{"application/json": parse(`{"host":"{{.Host}}"}`)},
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+		assert.JSONEq(t, `{"host":"example.com"}`, rec.Body.String())
+	})
+
+	t.Run("execution error writes nothing", func(t *testing.T) {
+		app := New(&Config{ID: "template-app", ResponseTemplate: parse(`{{.Method.Missing}}`)})
+
+		rec := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to execute response template")
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("literal response without a template", func(t *testing.T) {
+		app := New(&Config{ID: "literal-app", Response: "{{.Method}}"})
+
+		rec := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, httptest.NewRequest(http.MethodGet, "/", nil)))
+		assert.Equal(t, "{{.Method}}", rec.Body.String())
+	})
+}
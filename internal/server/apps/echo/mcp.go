@@ -4,6 +4,7 @@ import (
 	"context"
 
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 )
 
 // EchoInput defines the typed input parameters for the echo MCP tool.
@@ -28,11 +29,12 @@ func (a *App) MCPToolDescription() string {
 
 // MCPToolOption returns the mcp-io option that registers this app as an MCP
 // tool with input/output schemas auto-generated from EchoInput / EchoOutput.
-func (a *App) MCPToolOption(name string) mcpio.Option {
+func (a *App) MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option {
 	return mcpio.WithTool(
 		name,
 		a.MCPToolDescription(),
 		a.echoToolFunc,
+		opts...,
 	)
 }
 
@@ -0,0 +1,49 @@
+package echo
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"text/template"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+)
+
+// TemplateData holds the request values available to response templates, e.g.
+// {{.Method}}, {{.Headers.Get "User-Agent"}} or {{.Query.Get "name"}}
+type TemplateData struct {
+	Method     string
+	Path       string
+	Host       string
+	RemoteAddr string
+	Headers    http.Header
+	Query      url.Values
+	PathParams map[string]string
+}
+
+// newTemplateData collects the template values of a request
+func newTemplateData(r *http.Request) TemplateData {
+	return TemplateData{
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Host:       r.Host,
+		RemoteAddr: r.RemoteAddr,
+		Headers:    r.Header,
+		Query:      r.URL.Query(),
+		PathParams: apps.PathParams(r.Context()),
+	}
+}
+
+// render returns the response body: the template executed with the request's
+// values when there is one, otherwise the literal text
+func render(tmpl *template.Template, literal string, r *http.Request) ([]byte, error) {
+	if tmpl == nil {
+		return []byte(literal), nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newTemplateData(r)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+package apps
+
+import "context"
+
+// ErrorReport receives the error an app returned for a request. Middleware
+// that acts on app errors, such as retries, adds one to the request context
+// and the HTTP dispatcher fills it in.
+type ErrorReport struct {
+	// Err is the error returned by the app, nil if it succeeded
+	Err error
+}
+
+// errorReportKey is the context key for the error report
+type errorReportKey struct{}
+
+// WithErrorReport returns a copy of ctx carrying the error report
+func WithErrorReport(ctx context.Context, report *ErrorReport) context.Context {
+	return context.WithValue(ctx, errorReportKey{}, report)
+}
+
+// ErrorReportFromContext returns the error report stored in ctx, or nil if no
+// middleware asked for one
+func ErrorReportFromContext(ctx context.Context) *ErrorReport {
+	report, _ := ctx.Value(errorReportKey{}).(*ErrorReport)
+	return report
+}
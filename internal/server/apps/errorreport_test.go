@@ -0,0 +1,16 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorReport(t *testing.T) {
+	assert.Nil(t, ErrorReportFromContext(context.Background()))
+
+	report := &ErrorReport{}
+	ctx := WithErrorReport(context.Background(), report)
+	assert.Same(t, report, ErrorReportFromContext(ctx))
+}
@@ -0,0 +1,51 @@
+package fileread
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+)
+
+// etagCache holds the entity tags of served files, so a file is only hashed
+// again when its modification time or size changes
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	modTime time.Time
+	size    int64
+	etag    string
+}
+
+func newETagCache() *etagCache {
+	return &etagCache{entries: make(map[string]etagEntry)}
+}
+
+// get returns the strong entity tag of f, a hash of its content. A file
+// that is hashed is read to the end, then rewound to be served.
+func (c *etagCache) get(f *ResolvedFile) (string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[f.realPath]
+	c.mu.Unlock()
+	if ok && entry.modTime.Equal(f.ModTime()) && entry.size == f.Size() {
+		return entry.etag, nil
+	}
+
+	etag, err := apps.ETagFromReader(f.handle)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errReadFile, f.requestedPath)
+	}
+	if _, err := f.handle.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("%w: %s", errReadFile, f.requestedPath)
+	}
+
+	c.mu.Lock()
+	c.entries[f.realPath] = etagEntry{modTime: f.ModTime(), size: f.Size(), etag: etag}
+	c.mu.Unlock()
+	return etag, nil
+}
@@ -33,6 +33,7 @@ type ResolvedFile struct {
 	realPath      string
 	info          os.FileInfo
 	handle        *os.File
+	etag          string
 }
 
 // ResolveFile validates requestedPath against baseDirectory, resolves
@@ -89,8 +90,13 @@ func (f *ResolvedFile) Size() int64        { return f.info.Size() }
 // the first 512 bytes), Content-Length, Last-Modified, Range, and 304
 // support — all delegated to http.ServeContent. Passing Name as the
 // content name ensures Content-Disposition and ServeContent's own
-// plain-text error responses never leak the resolved host path.
+// plain-text error responses never leak the resolved host path. An
+// ETag set by the app is sent and checked against If-None-Match,
+// which takes precedence over If-Modified-Since.
 func (f *ResolvedFile) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if f.etag != "" {
+		w.Header().Set("ETag", f.etag)
+	}
 	http.ServeContent(w, r, f.Name(), f.ModTime(), f.handle)
 }
 
@@ -19,6 +19,7 @@ type App struct {
 	id                    string
 	baseDirectory         string
 	allowExternalSymlinks bool
+	etags                 *etagCache
 }
 
 // New creates a new fileread app from a Config DTO.
@@ -27,6 +28,7 @@ func New(cfg *Config) *App {
 		id:                    cfg.ID,
 		baseDirectory:         cfg.BaseDirectory,
 		allowExternalSymlinks: cfg.AllowExternalSymlinks,
+		etags:                 newETagCache(),
 	}
 }
 
@@ -35,16 +37,25 @@ func (a *App) String() string { return a.id }
 
 // resolveForHTTP is the App's hook to ResolveFile. Returning the
 // interface (not the concrete type) keeps HandleHTTP coupled only to
-// the surface it needs and makes the boundary explicit.
+// the surface it needs and makes the boundary explicit. The file gets
+// its ETag here, from the app's cache.
 func (a *App) resolveForHTTP(requestedPath string) (httpServableFile, error) {
-	return ResolveFile(a.baseDirectory, requestedPath, a.allowExternalSymlinks)
+	f, err := ResolveFile(a.baseDirectory, requestedPath, a.allowExternalSymlinks)
+	if err != nil {
+		return nil, err
+	}
+	if f.etag, err = a.etags.get(f); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	return f, nil
 }
 
 // HandleHTTP serves the requested file as a raw HTTP response. Method
 // must be GET or HEAD; the file path is passed via ?path=… query
 // parameter. Content-Type is auto-detected (extension first, then
-// sniffing). Range, conditional GET, and HEAD are handled via
-// http.ServeContent.
+// sniffing). Range, conditional GET (If-None-Match against a strong
+// ETag of the file's content, and If-Modified-Since), and HEAD are
+// handled via http.ServeContent.
 //
 // HandleHTTP always returns nil after writing a response — the HTTP
 // adapter writes its own 500 on any non-nil return, which would
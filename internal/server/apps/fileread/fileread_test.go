@@ -7,8 +7,11 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -135,6 +138,63 @@ func TestFileRead_HandleHTTP_IfModifiedSince(t *testing.T) {
 	assert.Equal(t, http.StatusNotModified, res2.StatusCode)
 }
 
+func TestFileRead_HandleHTTP_IfNoneMatch(t *testing.T) {
+	baseDir := t.TempDir()
+	path := filepath.Join(baseDir, "hi.txt")
+	require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+	app := New(&Config{ID: "files", BaseDirectory: baseDir})
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/files?path=hi.txt", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rr := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), rr, req))
+		return rr
+	}
+
+	first := get("", "")
+	etag := first.Header().Get("ETag")
+	want, err := apps.ETagFromReader(strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, want, etag)
+	// The file is served in full after being hashed
+	assert.Equal(t, "hello", first.Body.String())
+
+	t.Run("matching tag", func(t *testing.T) {
+		rr := get("If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, rr.Code)
+		assert.Empty(t, rr.Body.String())
+	})
+
+	t.Run("non-matching tag", func(t *testing.T) {
+		rr := get("If-None-Match", `"stale"`)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "hello", rr.Body.String())
+	})
+
+	t.Run("If-None-Match takes precedence over If-Modified-Since", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/files?path=hi.txt", nil)
+		req.Header.Set("If-None-Match", `"stale"`)
+		req.Header.Set("If-Modified-Since", first.Header().Get("Last-Modified"))
+		rr := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), rr, req))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("changed file gets a new tag", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("hello, world"), 0o600))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		rr := get("If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.NotEqual(t, etag, rr.Header().Get("ETag"))
+		assert.Equal(t, "hello, world", rr.Body.String())
+	})
+}
+
 func TestFileRead_HandleHTTP_AllowExternalSymlinks(t *testing.T) {
 	outside := t.TempDir()
 	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0o600))
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 )
 
 // stringFileReader is what filereadToolFunc needs from a resolved file.
@@ -36,8 +37,8 @@ func (a *App) MCPToolDescription() string {
 
 // MCPToolOption returns the mcp-io option that registers this app as an MCP
 // tool with input/output schemas auto-generated from Request / Response.
-func (a *App) MCPToolOption(name string) mcpio.Option {
-	return mcpio.WithTool(name, a.MCPToolDescription(), a.filereadToolFunc)
+func (a *App) MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option {
+	return mcpio.WithTool(name, a.MCPToolDescription(), a.filereadToolFunc, opts...)
 }
 
 // inputErrors are the ResolveFile sentinel errors caused by bad client
@@ -0,0 +1,147 @@
+package mcpserver
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	mcpio "github.com/robbyt/mcp-io"
+)
+
+// defaultCacheMaxEntries bounds the results cached for one tool. Inserting
+// beyond it evicts the oldest entry.
+const defaultCacheMaxEntries = 1024
+
+// resultCache stores successful raw tool results keyed by canonicalized
+// arguments. Entries expire after the configured TTL, and at most maxEntries
+// are kept. Every entry has the same TTL, so insertion order is expiry order:
+// expired and evicted entries are always taken from the front of order.
+type resultCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	output  []byte
+	expires time.Time
+}
+
+func newResultCache(ttl time.Duration, maxEntries int) *resultCache {
+	return &resultCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// get returns a copy of the cached output for key, if present and unexpired.
+func (c *resultCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(elem)
+		return nil, false
+	}
+	return bytes.Clone(entry.output), true
+}
+
+// put stores a copy of output under key, drops the expired entries, and
+// evicts the oldest ones while the cache is over its size.
+func (c *resultCache) put(key string, output []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+
+	now := c.now()
+	for elem := c.order.Front(); elem != nil; elem = c.order.Front() {
+		if now.Before(elem.Value.(*cacheEntry).expires) {
+			break
+		}
+		c.remove(elem)
+	}
+
+	c.entries[key] = c.order.PushBack(&cacheEntry{
+		key:     key,
+		output:  bytes.Clone(output),
+		expires: now.Add(c.ttl),
+	})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Front())
+	}
+}
+
+// remove drops elem from the cache
+func (c *resultCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// canonicalArgsKey derives a cache key from raw tool input JSON. The input is
+// decoded and re-encoded so that object key ordering and insignificant
+// whitespace do not produce distinct keys. Empty input and "null" are
+// treated as an empty argument object.
+func canonicalArgsKey(input []byte) (string, error) {
+	var args any
+	if len(bytes.TrimSpace(input)) > 0 {
+		dec := json.NewDecoder(bytes.NewReader(input))
+		dec.UseNumber()
+		if err := dec.Decode(&args); err != nil {
+			return "", fmt.Errorf("decode tool arguments: %w", err)
+		}
+	}
+	if args == nil {
+		args = map[string]any{}
+	}
+
+	// encoding/json sorts map keys, which makes the encoding canonical.
+	canonical, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("encode tool arguments: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// withResultCache wraps fn so that successful results are served from cache
+// for identical argument sets until the TTL elapses. Errors are never cached,
+// and arguments that cannot be canonicalized bypass the cache.
+func withResultCache(fn mcpio.RawToolFunc, cache *resultCache) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		key, err := canonicalArgsKey(input)
+		if err != nil {
+			return fn(ctx, reqCtx, input)
+		}
+		if output, ok := cache.get(key); ok {
+			return output, nil
+		}
+
+		output, err := fn(ctx, reqCtx, input)
+		if err != nil {
+			return nil, err
+		}
+		cache.put(key, output)
+		return output, nil
+	}
+}
@@ -0,0 +1,258 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRawToolFunc echoes its input and counts how often it runs.
+func countingRawToolFunc(calls *atomic.Int32) mcpio.RawToolFunc {
+	return func(_ context.Context, _ mcpio.RequestContext, in []byte) ([]byte, error) {
+		calls.Add(1)
+		return in, nil
+	}
+}
+
+// connectTestClient serves cfg over HTTP and returns a connected MCP session.
+func connectTestClient(t *testing.T, cfg *Config, lookup AppLookup) *mcpsdk.ClientSession {
+	t.Helper()
+
+	h, err := BuildHandler(cfg, lookup, cfg.ID)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(t.Context(), &mcpsdk.StreamableClientTransport{Endpoint: srv.URL}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestCanonicalArgsKey(t *testing.T) {
+	t.Parallel()
+
+	a, err := canonicalArgsKey([]byte(`{"b": 2, "a": {"y": 1, "x": [1, 2]}}`))
+	require.NoError(t, err)
+	b, err := canonicalArgsKey([]byte(`{"a":{"x":[1,2],"y":1},"b":2}`))
+	require.NoError(t, err)
+	assert.Equal(t, a, b, "key ordering and whitespace must not affect the cache key")
+
+	c, err := canonicalArgsKey([]byte(`{"a":{"x":[2,1],"y":1},"b":2}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c, "array ordering is significant")
+
+	empty, err := canonicalArgsKey(nil)
+	require.NoError(t, err)
+	null, err := canonicalArgsKey([]byte(`null`))
+	require.NoError(t, err)
+	obj, err := canonicalArgsKey([]byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, empty, null)
+	assert.Equal(t, empty, obj)
+
+	_, err = canonicalArgsKey([]byte(`{not json`))
+	require.Error(t, err)
+}
+
+func TestWithResultCache_Expires(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	cache := newResultCache(time.Minute, defaultCacheMaxEntries)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	fn := withResultCache(countingRawToolFunc(&calls), cache)
+
+	_, err := fn(t.Context(), nil, []byte(`{"a":1}`))
+	require.NoError(t, err)
+	_, err = fn(t.Context(), nil, []byte(`{"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(time.Minute)
+	_, err = fn(t.Context(), nil, []byte(`{"a":1}`))
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load(), "expired entry must be re-evaluated")
+}
+
+func TestResultCache_Bounds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evicts the oldest entry over the size", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newResultCache(time.Minute, 2)
+		cache.put("a", []byte("1"))
+		cache.put("b", []byte("2"))
+		cache.put("c", []byte("3"))
+
+		_, ok := cache.get("a")
+		assert.False(t, ok, "oldest entry must be evicted")
+		for _, key := range []string{"b", "c"} {
+			_, ok := cache.get(key)
+			assert.True(t, ok, key)
+		}
+		assert.Equal(t, 2, cache.order.Len())
+	})
+
+	t.Run("replacing an entry makes it the newest", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newResultCache(time.Minute, 2)
+		cache.put("a", []byte("1"))
+		cache.put("b", []byte("2"))
+		cache.put("a", []byte("3"))
+		cache.put("c", []byte("4"))
+
+		output, ok := cache.get("a")
+		require.True(t, ok)
+		assert.Equal(t, []byte("3"), output)
+		_, ok = cache.get("b")
+		assert.False(t, ok)
+		assert.Len(t, cache.entries, 2)
+	})
+
+	t.Run("drops expired entries on insert", func(t *testing.T) {
+		t.Parallel()
+
+		cache := newResultCache(time.Minute, 10)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		cache.put("a", []byte("1"))
+		cache.put("b", []byte("2"))
+		now = now.Add(30 * time.Second)
+		cache.put("c", []byte("3"))
+
+		now = now.Add(30 * time.Second)
+		cache.put("d", []byte("4"))
+		assert.Equal(t, 2, cache.order.Len(), "a and b have expired")
+		assert.Len(t, cache.entries, 2)
+		for _, key := range []string{"c", "d"} {
+			_, ok := cache.get(key)
+			assert.True(t, ok, key)
+		}
+	})
+}
+
+func TestWithResultCache_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	fn := withResultCache(func(_ context.Context, _ mcpio.RequestContext, _ []byte) ([]byte, error) {
+		calls.Add(1)
+		return nil, mcpio.ProcessingError("boom")
+	}, newResultCache(time.Minute, defaultCacheMaxEntries))
+
+	_, err := fn(t.Context(), nil, []byte(`{}`))
+	require.Error(t, err)
+	_, err = fn(t.Context(), nil, []byte(`{}`))
+	require.Error(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestBuildHandler_CachesIdempotentRawTool(t *testing.T) {
+	var calls atomic.Int32
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw").Once()
+	app.On("MCPToolName").Return("raw").Once()
+	app.On("MCPToolDescription").Return("raw test tool").Once()
+	app.On("MCPRawToolFunc").Return(countingRawToolFunc(&calls)).Once()
+
+	cfg := &Config{
+		ID: "srv",
+		Tools: []ToolRef{{
+			AppID:          "raw",
+			InputSchema:    `{"type":"object"}`,
+			IdempotentHint: true,
+			CacheTTL:       time.Minute,
+		}},
+	}
+	session := connectTestClient(t, cfg, fakeRegistry(t, app))
+
+	first, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{
+		Name:      "raw",
+		Arguments: map[string]any{"a": 1, "b": 2},
+	})
+	require.NoError(t, err)
+	require.False(t, first.IsError)
+
+	second, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{
+		Name:      "raw",
+		Arguments: map[string]any{"b": 2, "a": 1},
+	})
+	require.NoError(t, err)
+	require.False(t, second.IsError)
+
+	assert.Equal(t, int32(1), calls.Load(), "second call should be served from cache")
+	assert.Equal(t, first.Content, second.Content)
+
+	tools, err := session.ListTools(t.Context(), nil)
+	require.NoError(t, err)
+	require.Len(t, tools.Tools, 1)
+	require.NotNil(t, tools.Tools[0].Annotations)
+	assert.True(t, tools.Tools[0].Annotations.IdempotentHint)
+	app.AssertExpectations(t)
+}
+
+func TestBuildHandler_BypassesCacheForNonIdempotentTool(t *testing.T) {
+	var calls atomic.Int32
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw").Once()
+	app.On("MCPToolName").Return("raw").Once()
+	app.On("MCPToolDescription").Return("raw test tool").Once()
+	app.On("MCPRawToolFunc").Return(countingRawToolFunc(&calls)).Once()
+
+	cfg := &Config{
+		ID: "srv",
+		Tools: []ToolRef{{
+			AppID:       "raw",
+			InputSchema: `{"type":"object"}`,
+			CacheTTL:    time.Minute,
+		}},
+	}
+	session := connectTestClient(t, cfg, fakeRegistry(t, app))
+
+	for range 2 {
+		result, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{
+			Name:      "raw",
+			Arguments: map[string]any{"a": 1},
+		})
+		require.NoError(t, err)
+		require.False(t, result.IsError)
+	}
+
+	assert.Equal(t, int32(2), calls.Load(), "non-idempotent tools must not be cached")
+	app.AssertExpectations(t)
+}
+
+func TestBuildHandler_TypedToolRejectsCacheTTL(t *testing.T) {
+	app := &mockTypedApp{}
+	app.Test(t)
+	app.On("String").Return("typed").Once()
+	app.On("MCPToolName").Return("typed").Once()
+	cfg := &Config{
+		ID: "srv",
+		Tools: []ToolRef{{
+			AppID:        "typed",
+			ReadOnlyHint: true,
+			CacheTTL:     time.Minute,
+		}},
+	}
+
+	_, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cache_ttl")
+	app.AssertExpectations(t)
+}
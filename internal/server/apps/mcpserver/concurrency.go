@@ -0,0 +1,76 @@
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+)
+
+// withConcurrencyLimit returns a receiving middleware running at most
+// maxCalls tool calls of the server at once. A call over the limit waits up
+// to queueTimeout for a running call to finish, then fails with a tool error
+// the client can retry on.
+func withConcurrencyLimit(
+	maxCalls int,
+	queueTimeout time.Duration,
+	serverName string,
+	logger *slog.Logger,
+) mcpsdk.Middleware {
+	slots := make(chan struct{}, maxCalls)
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			if method != "tools/call" {
+				return next(ctx, method, req)
+			}
+			if !acquireSlot(ctx, slots, queueTimeout) {
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				logger.WarnContext(ctx, "MCP tool call rejected, too many concurrent calls",
+					"max_concurrent_tool_calls", maxCalls)
+				return toolErrorResult(&mcpio.ToolError{
+					Code:    mcpio.ErrorCodeRateLimit,
+					Message: fmt.Sprintf("MCP server %s is running too many tool calls, retry later", serverName),
+				}), nil
+			}
+			defer func() { <-slots }()
+			return next(ctx, method, req)
+		}
+	}
+}
+
+// acquireSlot takes a slot, waiting up to timeout for one to be released, and
+// reports whether it got one.
+func acquireSlot(ctx context.Context, slots chan struct{}, timeout time.Duration) bool {
+	select {
+	case slots <- struct{}{}:
+		return true
+	default:
+	}
+	if timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// toolErrorResult returns the result mcp-io sends for a tool error
+func toolErrorResult(toolErr *mcpio.ToolError) *mcpsdk.CallToolResult {
+	return &mcpsdk.CallToolResult{
+		IsError: true,
+		Content: []mcpsdk.Content{&mcpsdk.TextContent{Text: toolErr.Error()}},
+	}
+}
@@ -0,0 +1,247 @@
+package mcpserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingHandler is an MCP method handler whose calls wait for release and
+// record the number of calls running at once
+type blockingHandler struct {
+	release chan struct{}
+	started chan struct{}
+	running atomic.Int32
+	peak    atomic.Int32
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{release: make(chan struct{}), started: make(chan struct{}, 100)}
+}
+
+func (h *blockingHandler) handle(ctx context.Context, _ string, _ mcpsdk.Request) (mcpsdk.Result, error) {
+	running := h.running.Add(1)
+	defer h.running.Add(-1)
+	for {
+		peak := h.peak.Load()
+		if running <= peak || h.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	h.started <- struct{}{}
+
+	select {
+	case <-h.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &mcpsdk.CallToolResult{Content: []mcpsdk.Content{&mcpsdk.TextContent{Text: "{}"}}}, nil
+}
+
+func callTool(ctx context.Context, handler mcpsdk.MethodHandler) (*mcpsdk.CallToolResult, error) {
+	result, err := handler(ctx, "tools/call", &mcpsdk.CallToolRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*mcpsdk.CallToolResult), nil
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	t.Run("rejects calls over the limit", func(t *testing.T) {
+		h := newBlockingHandler()
+		handler := withConcurrencyLimit(2, 0, "srv", slog.Default())(h.handle)
+
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Go(func() {
+				result, err := callTool(t.Context(), handler)
+				if assert.NoError(t, err) {
+					assert.False(t, result.IsError)
+				}
+			})
+			<-h.started
+		}
+
+		result, err := callTool(t.Context(), handler)
+		require.NoError(t, err)
+		assert.Contains(t, toolErrorText(t, result), mcpio.ErrorCodeRateLimit)
+		assert.Contains(t, toolErrorText(t, result), "too many tool calls")
+
+		close(h.release)
+		wg.Wait()
+
+		result, err = callTool(t.Context(), handler)
+		require.NoError(t, err)
+		assert.False(t, result.IsError, "a slot is free once a call finishes")
+	})
+
+	t.Run("queues calls over the limit", func(t *testing.T) {
+		h := newBlockingHandler()
+		handler := withConcurrencyLimit(3, time.Minute, "srv", slog.Default())(h.handle)
+
+		const calls = 12
+		var wg sync.WaitGroup
+		var failed atomic.Int32
+		for range calls {
+			wg.Go(func() {
+				result, err := callTool(t.Context(), handler)
+				if err != nil || result.IsError {
+					failed.Add(1)
+				}
+			})
+		}
+
+		// The other calls wait while the limit is reached
+		for range 3 {
+			<-h.started
+		}
+		assert.Never(t, func() bool { return h.running.Load() > 3 }, 20*time.Millisecond, time.Millisecond)
+
+		// Each call released lets a waiting one start
+		for range calls - 3 {
+			h.release <- struct{}{}
+			<-h.started
+			assert.LessOrEqual(t, h.running.Load(), int32(3))
+		}
+		close(h.release)
+		wg.Wait()
+
+		assert.Zero(t, failed.Load())
+		assert.Equal(t, int32(3), h.peak.Load())
+	})
+
+	t.Run("queued call times out", func(t *testing.T) {
+		h := newBlockingHandler()
+		handler := withConcurrencyLimit(1, 20*time.Millisecond, "srv", slog.Default())(h.handle)
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			_, err := callTool(t.Context(), handler)
+			assert.NoError(t, err)
+		})
+		<-h.started
+
+		result, err := callTool(t.Context(), handler)
+		require.NoError(t, err)
+		assert.Contains(t, toolErrorText(t, result), mcpio.ErrorCodeRateLimit)
+
+		close(h.release)
+		wg.Wait()
+	})
+
+	t.Run("queued call canceled", func(t *testing.T) {
+		h := newBlockingHandler()
+		handler := withConcurrencyLimit(1, time.Minute, "srv", slog.Default())(h.handle)
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			_, err := callTool(t.Context(), handler)
+			assert.NoError(t, err)
+		})
+		<-h.started
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		_, err := callTool(ctx, handler)
+		require.ErrorIs(t, err, context.Canceled)
+
+		close(h.release)
+		wg.Wait()
+	})
+
+	t.Run("other methods are not limited", func(t *testing.T) {
+		h := newBlockingHandler()
+		limit := withConcurrencyLimit(1, 0, "srv", slog.Default())
+		handler := limit(h.handle)
+
+		var wg sync.WaitGroup
+		wg.Go(func() {
+			_, err := callTool(t.Context(), handler)
+			assert.NoError(t, err)
+		})
+		<-h.started
+
+		// The handlers wrapped by one middleware share its slots
+		listed := false
+		listHandler := limit(func(context.Context, string, mcpsdk.Request) (mcpsdk.Result, error) {
+			listed = true
+			return &mcpsdk.ListToolsResult{}, nil
+		})
+		_, err := listHandler(t.Context(), "tools/list", &mcpsdk.ListToolsRequest{})
+		require.NoError(t, err)
+		assert.True(t, listed)
+
+		close(h.release)
+		wg.Wait()
+	})
+}
+
+// TestBuildHandler_ConcurrencyLimit calls a raw tool concurrently through MCP
+// clients and checks no more calls than the limit run at once.
+func TestBuildHandler_ConcurrencyLimit(t *testing.T) {
+	const limit = 2
+	var running, peak atomic.Int32
+	slow := func(context.Context, mcpio.RequestContext, []byte) ([]byte, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return []byte(`{}`), nil
+	}
+
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw")
+	app.On("MCPToolName").Return("raw")
+	app.On("MCPToolDescription").Return("raw test tool")
+	app.On("MCPRawToolFunc").Return(mcpio.RawToolFunc(slow))
+
+	cfg := &Config{
+		ID:                     "srv",
+		Tools:                  []ToolRef{{AppID: "raw", InputSchema: `{"type":"object"}`}},
+		MaxConcurrentToolCalls: limit,
+		ToolCallQueueTimeout:   time.Minute,
+	}
+	handler, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	const calls = 8
+	sessions := make([]*mcpsdk.ClientSession, calls)
+	for i := range sessions {
+		client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+		session, err := client.Connect(t.Context(), &mcpsdk.StreamableClientTransport{Endpoint: server.URL}, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = session.Close() })
+		sessions[i] = session
+	}
+
+	var wg sync.WaitGroup
+	for _, session := range sessions {
+		wg.Go(func() {
+			result, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{Name: "raw", Arguments: map[string]any{}})
+			if assert.NoError(t, err) {
+				assert.False(t, result.IsError)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(limit), peak.Load())
+}
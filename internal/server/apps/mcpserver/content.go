@@ -0,0 +1,95 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// contentDescriptor is one element of a raw tool result listing the MCP
+// content blocks to return, such as {"type": "text", "text": "..."} or
+// {"type": "image", "data": "<base64>", "mimeType": "image/png"}.
+type contentDescriptor struct {
+	Type     string  `json:"type"`
+	Text     *string `json:"text"`
+	Data     string  `json:"data"`
+	MIMEType string  `json:"mimeType"`
+}
+
+// content returns the block the descriptor describes, and false when it
+// isn't a valid text, image or audio descriptor.
+func (d contentDescriptor) content() (mcpsdk.Content, bool) {
+	switch d.Type {
+	case "text":
+		if d.Text == nil {
+			return nil, false
+		}
+		return &mcpsdk.TextContent{Text: *d.Text}, true
+	case "image", "audio":
+		if d.Data == "" || d.MIMEType == "" {
+			return nil, false
+		}
+		data, err := base64.StdEncoding.DecodeString(d.Data)
+		if err != nil {
+			return nil, false
+		}
+		if d.Type == "image" {
+			return &mcpsdk.ImageContent{Data: data, MIMEType: d.MIMEType}, true
+		}
+		return &mcpsdk.AudioContent{Data: data, MIMEType: d.MIMEType}, true
+	default:
+		return nil, false
+	}
+}
+
+// contentBlocks returns the content blocks listed by output, the JSON result
+// of a raw tool, and false unless output is a non-empty array of content
+// descriptors only. Other results stay a single block of JSON text.
+func contentBlocks(output string) ([]mcpsdk.Content, bool) {
+	var descriptors []contentDescriptor
+	if err := json.Unmarshal([]byte(output), &descriptors); err != nil || len(descriptors) == 0 {
+		return nil, false
+	}
+
+	blocks := make([]mcpsdk.Content, 0, len(descriptors))
+	for _, d := range descriptors {
+		block, ok := d.content()
+		if !ok {
+			return nil, false
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, true
+}
+
+// withContentBlocks returns a receiving middleware replacing the result of a
+// successful call of one of rawTools, which mcp-io returns as a single block
+// of JSON text, with the content blocks it lists, if any.
+func withContentBlocks(rawTools map[string]bool) mcpsdk.Middleware {
+	return func(next mcpsdk.MethodHandler) mcpsdk.MethodHandler {
+		return func(ctx context.Context, method string, req mcpsdk.Request) (mcpsdk.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil || method != "tools/call" {
+				return result, err
+			}
+			params, ok := req.GetParams().(*mcpsdk.CallToolParamsRaw)
+			if !ok || !rawTools[params.Name] {
+				return result, nil
+			}
+			callResult, ok := result.(*mcpsdk.CallToolResult)
+			if !ok || callResult.IsError || len(callResult.Content) != 1 {
+				return result, nil
+			}
+			text, ok := callResult.Content[0].(*mcpsdk.TextContent)
+			if !ok {
+				return result, nil
+			}
+			if blocks, ok := contentBlocks(text.Text); ok {
+				callResult.Content = blocks
+			}
+			return result, nil
+		}
+	}
+}
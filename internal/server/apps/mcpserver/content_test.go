@@ -0,0 +1,111 @@
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentBlocks(t *testing.T) {
+	t.Run("mixed content", func(t *testing.T) {
+		blocks, ok := contentBlocks(`[
+			{"type": "text", "text": "a chart"},
+			{"type": "image", "data": "iVBORw==", "mimeType": "image/png"},
+			{"type": "audio", "data": "UklGRg==", "mimeType": "audio/wav"}
+		]`)
+		require.True(t, ok)
+		require.Len(t, blocks, 3)
+		assert.Equal(t, &mcpsdk.TextContent{Text: "a chart"}, blocks[0])
+		assert.Equal(t, &mcpsdk.ImageContent{Data: []byte{0x89, 'P', 'N', 'G'}, MIMEType: "image/png"}, blocks[1])
+		assert.Equal(t, &mcpsdk.AudioContent{Data: []byte("RIFF"), MIMEType: "audio/wav"}, blocks[2])
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		blocks, ok := contentBlocks(`[{"type": "text", "text": ""}]`)
+		require.True(t, ok)
+		assert.Equal(t, []mcpsdk.Content{&mcpsdk.TextContent{}}, blocks)
+	})
+
+	for name, output := range map[string]string{
+		"object":             `{"type": "text", "text": "hi"}`,
+		"number":             `42`,
+		"empty array":        `[]`,
+		"array of numbers":   `[1, 2, 3]`,
+		"unknown type":       `[{"type": "video", "data": "AA==", "mimeType": "video/mp4"}]`,
+		"text without text":  `[{"type": "text"}]`,
+		"image without type": `[{"type": "image", "data": "AA=="}]`,
+		"invalid base64":     `[{"type": "image", "data": "not base64!", "mimeType": "image/png"}]`,
+		"partly descriptors": `[{"type": "text", "text": "hi"}, {"name": "x"}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, ok := contentBlocks(output)
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestBuildHandler_RawToolContent calls a raw tool returning its "result"
+// argument through an MCP client.
+func TestBuildHandler_RawToolContent(t *testing.T) {
+	echoResult := func(_ context.Context, _ mcpio.RequestContext, input []byte) ([]byte, error) {
+		var args struct {
+			Result json.RawMessage `json:"result"`
+		}
+		if err := json.Unmarshal(input, &args); err != nil {
+			return nil, err
+		}
+		return args.Result, nil
+	}
+
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw")
+	app.On("MCPToolName").Return("raw")
+	app.On("MCPToolDescription").Return("raw test tool")
+	app.On("MCPRawToolFunc").Return(mcpio.RawToolFunc(echoResult))
+
+	cfg := &Config{
+		ID:    "srv",
+		Tools: []ToolRef{{AppID: "raw", InputSchema: `{"type":"object"}`}},
+	}
+	handler, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.NoError(t, err)
+	call := rawToolCaller(t, handler)
+
+	jsonText := func(t *testing.T, result *mcpsdk.CallToolResult) string {
+		t.Helper()
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 1)
+		text, ok := result.Content[0].(*mcpsdk.TextContent)
+		require.True(t, ok)
+		return text.Text
+	}
+
+	t.Run("array", func(t *testing.T) {
+		result := call(map[string]any{"result": []any{1, "two", map[string]any{"three": 3}}})
+		assert.JSONEq(t, `[1, "two", {"three": 3}]`, jsonText(t, result))
+	})
+
+	t.Run("number", func(t *testing.T) {
+		assert.JSONEq(t, `2.5`, jsonText(t, call(map[string]any{"result": 2.5})))
+	})
+
+	t.Run("mixed content", func(t *testing.T) {
+		result := call(map[string]any{"result": []any{
+			map[string]any{"type": "text", "text": "a chart"},
+			map[string]any{"type": "image", "data": "iVBORw==", "mimeType": "image/png"},
+		}})
+		require.False(t, result.IsError)
+		require.Len(t, result.Content, 2)
+		assert.Equal(t, &mcpsdk.TextContent{Text: "a chart"}, result.Content[0])
+		image, ok := result.Content[1].(*mcpsdk.ImageContent)
+		require.True(t, ok)
+		assert.Equal(t, []byte{0x89, 'P', 'N', 'G'}, image.Data)
+		assert.Equal(t, "image/png", image.MIMEType)
+	})
+}
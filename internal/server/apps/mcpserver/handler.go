@@ -3,12 +3,18 @@ package mcpserver
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
 
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
 	mcpio "github.com/robbyt/mcp-io"
 	"github.com/robbyt/mcp-io/mcpwrapper"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 )
 
+// serverVersion is the version the MCP servers report to clients
+const serverVersion = "1.0.0"
+
 // BuildHandler constructs an mcp-io HTTP handler from the supplied Config.
 //
 // For each Tool ref it consults the resolved app:
@@ -22,6 +28,20 @@ import (
 //     present, the raw path is preferred so the override takes effect.
 //   - typed-only providers reject input_schema overrides because mcp-io
 //     derives schemas from Go types.
+//   - the arguments of raw tool calls are checked against the input_schema
+//     before the call reaches the app; mcp-io checks those of typed tools
+//     against their generated schema. Their results are checked against the
+//     output_schema, if any, unless SkipOutputValidation is set, before they
+//     are cached.
+//   - result caching (CacheTTL with an idempotent or read-only hint) and tool
+//     middlewares wrap the raw tool function, so typed-path tools reject
+//     them.
+//   - with MaxConcurrentToolCalls, calls of any tool over the limit wait up
+//     to ToolCallQueueTimeout for a running call to finish, then fail with a
+//     RATE_LIMITED tool error.
+//   - a raw tool result that is a JSON array of text, image or audio content
+//     descriptors is returned as those content blocks; any other result is
+//     returned as a single block of JSON text.
 //
 // Cross-reference and provider-conformance must already be validated via
 // App.ValidateRefs before calling this — BuildHandler returns an error if a
@@ -37,16 +57,24 @@ func BuildHandler(cfg *Config, lookup AppLookup, serverName string) (http.Handle
 	// Stateless + JSON response: simpler to host behind a generic HTTP router
 	// like firelynx's, where SSE streaming session tracking adds complexity
 	// without value for the MCP request/response use case.
+	server := mcpsdk.NewServer(&mcpsdk.Implementation{Name: serverName, Version: serverVersion}, nil)
 	opts := []mcpio.Option{
-		mcpio.WithName(serverName),
+		mcpio.WithServer(server),
 		mcpio.WithHTTPTransport(
 			mcpwrapper.WithStateless(),
 			mcpwrapper.WithJSONResponse(),
 		),
 	}
 
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger = logger.With("mcp_server", serverName)
+
+	rawTools := make(map[string]bool)
 	for i, ref := range cfg.Tools {
-		opt, err := buildToolOption(ref, lookup)
+		opt, err := buildToolOption(ref, lookup, logger, rawTools)
 		if err != nil {
 			return nil, fmt.Errorf("tool[%d] (app_id=%q): %w", i, ref.AppID, err)
 		}
@@ -60,13 +88,24 @@ func BuildHandler(cfg *Config, lookup AppLookup, serverName string) (http.Handle
 		return nil, fmt.Errorf("resource registration is not yet wired: %d resource refs configured", len(cfg.Resources))
 	}
 
+	server.AddReceivingMiddleware(withContentBlocks(rawTools))
+	if cfg.MaxConcurrentToolCalls > 0 {
+		server.AddReceivingMiddleware(withConcurrencyLimit(
+			cfg.MaxConcurrentToolCalls, cfg.ToolCallQueueTimeout, serverName, logger))
+	}
 	return mcpio.NewHandler(opts...)
 }
 
 // buildToolOption resolves a single ToolRef to an mcp-io Option, picking the
 // typed or raw registration path based on what the backing app implements
-// and whether the user supplied a schema override.
-func buildToolOption(ref ToolRef, lookup AppLookup) (mcpio.Option, error) {
+// and whether the user supplied a schema override. The names of raw tools are
+// recorded in rawTools.
+func buildToolOption(
+	ref ToolRef,
+	lookup AppLookup,
+	logger *slog.Logger,
+	rawTools map[string]bool,
+) (mcpio.Option, error) {
 	app, ok := lookup(ref.AppID)
 	if !ok {
 		return nil, fmt.Errorf("%w", ErrUnknownAppRef)
@@ -100,8 +139,27 @@ func buildToolOption(ref ToolRef, lookup AppLookup) (mcpio.Option, error) {
 		if err := json.Unmarshal([]byte(ref.InputSchema), &schema); err != nil {
 			return nil, fmt.Errorf("invalid input_schema JSON: %w", err)
 		}
+		resolved, err := compileInputSchema(ref.InputSchema)
+		if err != nil {
+			return nil, err
+		}
 
-		return mcpio.WithRawTool(name, raw.MCPToolDescription(), schema, raw.MCPRawToolFunc()), nil
+		fn := raw.MCPRawToolFunc()
+		if ref.OutputSchema != "" && !ref.SkipOutputValidation {
+			outputSchema, err := compileOutputSchema(ref.OutputSchema)
+			if err != nil {
+				return nil, err
+			}
+			fn = withOutputValidation(fn, outputSchema)
+		}
+		if ref.cacheable() {
+			fn = withResultCache(fn, newResultCache(ref.CacheTTL, defaultCacheMaxEntries))
+		}
+		fn = withArgumentValidation(fn, resolved)
+		fn = withMiddlewares(fn, ref.Middlewares, name, logger)
+		rawTools[name] = true
+
+		return mcpio.WithRawTool(name, raw.MCPToolDescription(), schema, fn, toolAnnotationOptions(ref)...), nil
 	}
 
 	if ref.InputSchema != "" {
@@ -111,5 +169,32 @@ func buildToolOption(ref ToolRef, lookup AppLookup) (mcpio.Option, error) {
 		)
 	}
 
-	return typed.MCPToolOption(name), nil
+	if ref.cacheable() {
+		return nil, fmt.Errorf(
+			"typed tool provider %q does not support cache_ttl (result caching wraps raw tool functions only)",
+			ref.AppID,
+		)
+	}
+
+	if len(ref.Middlewares) > 0 {
+		return nil, fmt.Errorf(
+			"typed tool provider %q does not support middlewares (they wrap raw tool functions only)",
+			ref.AppID,
+		)
+	}
+
+	return typed.MCPToolOption(name, toolAnnotationOptions(ref)...), nil
+}
+
+// toolAnnotationOptions converts the behavioral hints on a ToolRef into
+// mcp-io tool options so they are advertised to MCP clients.
+func toolAnnotationOptions(ref ToolRef) []toolOption.Option {
+	var opts []toolOption.Option
+	if ref.ReadOnlyHint {
+		opts = append(opts, toolOption.WithReadOnly())
+	}
+	if ref.IdempotentHint {
+		opts = append(opts, toolOption.WithIdempotent())
+	}
+	return opts
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -39,7 +40,7 @@ func (m *mockDualApp) MCPToolDescription() string {
 	return args.String(0)
 }
 
-func (m *mockDualApp) MCPToolOption(name string) mcpio.Option {
+func (m *mockDualApp) MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option {
 	args := m.Called(name)
 	return args.Get(0).(mcpio.Option)
 }
@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"sync"
+	"time"
 
 	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
 )
@@ -34,9 +36,12 @@ var (
 // All validation happens at the domain layer before creating this config.
 type Config struct {
 	// ID is the unique identifier for this MCP server instance.
-	// This also serves as the server name exposed to MCP clients.
 	ID string
 
+	// Name is the server name exposed to MCP clients, the ID when empty. The
+	// instances created for each route of an app share the app's ID as name.
+	Name string
+
 	// Tools enumerates the apps this server exposes as MCP tools.
 	Tools []ToolRef
 
@@ -47,6 +52,18 @@ type Config struct {
 	// Resources enumerates reserved MCP resource refs. Runtime registration is
 	// not implemented yet.
 	Resources []ResourceRef
+
+	// MaxConcurrentToolCalls is the number of tool calls the server runs at
+	// once, unlimited when 0.
+	MaxConcurrentToolCalls int
+
+	// ToolCallQueueTimeout is how long a call over MaxConcurrentToolCalls
+	// waits for a running call to finish before it fails, not at all when 0.
+	ToolCallQueueTimeout time.Duration
+
+	// Logger is the structured logger of the tool middlewares, slog.Default()
+	// when nil
+	Logger *slog.Logger
 }
 
 // ToolRef references a firelynx app that should be exposed as an MCP tool.
@@ -63,9 +80,31 @@ type ToolRef struct {
 	// providers reject this because mcp-io derives schemas from Go types.
 	InputSchema string
 
-	// OutputSchema is accepted for future compatibility but is not currently
-	// forwarded to MCP clients.
+	// OutputSchema is an optional raw JSON schema the results of raw tools
+	// are checked against. It is not forwarded to MCP clients.
 	OutputSchema string
+
+	// IdempotentHint and ReadOnlyHint are advertised to MCP clients as tool
+	// annotations.
+	IdempotentHint bool
+	ReadOnlyHint   bool
+
+	// CacheTTL enables result caching keyed by canonicalized arguments. It is
+	// ignored unless IdempotentHint or ReadOnlyHint is set.
+	CacheTTL time.Duration
+
+	// Middlewares wrap each call of the tool in order, around the result
+	// cache. Only raw tools support them.
+	Middlewares []ToolMiddleware
+
+	// SkipOutputValidation returns the results of a raw tool without
+	// checking them against OutputSchema.
+	SkipOutputValidation bool
+}
+
+// cacheable reports whether results for this tool may be served from cache.
+func (r ToolRef) cacheable() bool {
+	return r.CacheTTL > 0 && (r.IdempotentHint || r.ReadOnlyHint)
 }
 
 // PromptRef references a future MCP prompt provider.
@@ -94,9 +133,14 @@ type App struct {
 	mu sync.RWMutex
 
 	id        string
+	name      string
 	tools     []ToolRef
 	prompts   []PromptRef
 	resources []ResourceRef
+	logger    *slog.Logger
+
+	maxConcurrentToolCalls int
+	toolCallQueueTimeout   time.Duration
 
 	// handler is the constructed mcp-io handler. Nil until Build() succeeds.
 	handler http.Handler
@@ -105,11 +149,24 @@ type App struct {
 // New creates a new MCP server app from the given configuration. The returned
 // App has no mcp-io handler — call Build() to wire it up before serving HTTP.
 func New(cfg *Config) *App {
+	name := cfg.Name
+	if name == "" {
+		name = cfg.ID
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &App{
 		id:        cfg.ID,
+		name:      name,
 		tools:     append([]ToolRef(nil), cfg.Tools...),
 		prompts:   append([]PromptRef(nil), cfg.Prompts...),
 		resources: append([]ResourceRef(nil), cfg.Resources...),
+		logger:    logger,
+
+		maxConcurrentToolCalls: cfg.MaxConcurrentToolCalls,
+		toolCallQueueTimeout:   cfg.ToolCallQueueTimeout,
 	}
 }
 
@@ -118,6 +175,11 @@ func (a *App) String() string {
 	return a.id
 }
 
+// Name returns the server name exposed to MCP clients.
+func (a *App) Name() string {
+	return a.name
+}
+
 // Tools returns a copy of the configured tool references.
 func (a *App) Tools() []ToolRef {
 	return append([]ToolRef(nil), a.tools...)
@@ -142,11 +204,16 @@ func (a *App) Build(lookup AppLookup) error {
 	}
 	cfg := &Config{
 		ID:        a.id,
+		Name:      a.name,
 		Tools:     a.tools,
 		Prompts:   a.prompts,
 		Resources: a.resources,
+		Logger:    a.logger,
+
+		MaxConcurrentToolCalls: a.maxConcurrentToolCalls,
+		ToolCallQueueTimeout:   a.toolCallQueueTimeout,
 	}
-	handler, err := BuildHandler(cfg, lookup, a.id)
+	handler, err := BuildHandler(cfg, lookup, a.name)
 	if err != nil {
 		return fmt.Errorf("build mcp handler: %w", err)
 	}
@@ -179,8 +246,8 @@ func (a *App) ValidateRefs(lookup AppLookup) error {
 		_, isRaw := app.(MCPRawToolProvider)
 		if !isTyped && !isRaw {
 			errs = append(errs, fmt.Errorf(
-				"tool[%d] (app_id=%q): %w: expected MCPTypedToolProvider or MCPRawToolProvider",
-				i, ref.AppID, ErrAppNotMCPProvider,
+				"tool[%d] (app_id=%q): %w: %T is neither an MCPTypedToolProvider nor an MCPRawToolProvider",
+				i, ref.AppID, ErrAppNotMCPProvider, app,
 			))
 		}
 	}
@@ -8,6 +8,7 @@ import (
 
 	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -59,7 +60,7 @@ func (m *mockTypedApp) MCPToolDescription() string {
 	return args.String(0)
 }
 
-func (m *mockTypedApp) MCPToolOption(name string) mcpio.Option {
+func (m *mockTypedApp) MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option {
 	args := m.Called(name)
 	return args.Get(0).(mcpio.Option)
 }
@@ -166,6 +167,14 @@ func TestNew_CopiesRefs(t *testing.T) {
 	assert.Equal(t, "calculate", app.Tools()[0].ID, "App must hold its own copy of refs")
 }
 
+func TestNew_Name(t *testing.T) {
+	assert.Equal(t, "mcp", New(&Config{ID: "mcp"}).Name(), "defaults to the ID")
+
+	app := New(&Config{ID: "mcp#0:1", Name: "mcp"})
+	assert.Equal(t, "mcp#0:1", app.String())
+	assert.Equal(t, "mcp", app.Name())
+}
+
 func TestBuild_RejectsNilLookup(t *testing.T) {
 	app := New(&Config{ID: "mcp"})
 	err := app.Build(nil)
@@ -290,7 +299,7 @@ func TestValidateRefs_AppNotProvider(t *testing.T) {
 	err := app.ValidateRefs(lookup)
 	require.Error(t, err)
 	require.ErrorIs(t, err, ErrAppNotMCPProvider)
-	assert.Contains(t, err.Error(), "MCPTypedToolProvider or MCPRawToolProvider")
+	assert.Contains(t, err.Error(), "*mcpserver.mockPlainApp is neither an MCPTypedToolProvider nor an MCPRawToolProvider")
 	plain.AssertExpectations(t)
 }
 
@@ -0,0 +1,159 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
+	mcpio "github.com/robbyt/mcp-io"
+)
+
+var (
+	// ErrToolUnauthenticated is returned for a call of a tool requiring
+	// authentication by a client no auth middleware authenticated.
+	ErrToolUnauthenticated = errors.New("tool call is not authenticated")
+
+	// ErrToolForbidden is returned for a call of a tool by an authenticated
+	// client whose subject isn't allowed to call it.
+	ErrToolForbidden = errors.New("tool call is not allowed for this client")
+)
+
+// ToolMiddleware wraps each call of a raw tool. ToolLogging,
+// ToolRateLimiting and ToolAuthentication implement it.
+type ToolMiddleware interface {
+	wrap(fn mcpio.RawToolFunc, tool string, logger *slog.Logger) mcpio.RawToolFunc
+}
+
+// withMiddlewares wraps fn in middlewares so the first one sees each call
+// first.
+func withMiddlewares(
+	fn mcpio.RawToolFunc,
+	middlewares []ToolMiddleware,
+	tool string,
+	logger *slog.Logger,
+) mcpio.RawToolFunc {
+	for _, mw := range slices.Backward(middlewares) {
+		fn = mw.wrap(fn, tool, logger)
+	}
+	return fn
+}
+
+// ToolLogging logs each tool call with its arguments, duration and outcome.
+type ToolLogging struct {
+	// OmitArguments leaves the call arguments out of the log.
+	OmitArguments bool
+}
+
+func (m ToolLogging) wrap(fn mcpio.RawToolFunc, tool string, logger *slog.Logger) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		start := time.Now()
+		output, err := fn(ctx, reqCtx, input)
+
+		attrs := []any{"tool", tool, "duration", time.Since(start)}
+		if !m.OmitArguments {
+			attrs = append(attrs, "arguments", string(input))
+		}
+		if principal := serverApps.PrincipalFromContext(ctx); principal != nil {
+			attrs = append(attrs, "subject", principal.Subject)
+		}
+		if err != nil {
+			logger.WarnContext(ctx, "MCP tool call failed", append(attrs, "error", err)...)
+			return nil, err
+		}
+		logger.InfoContext(ctx, "MCP tool call", attrs...)
+		return output, nil
+	}
+}
+
+// ToolRateLimiting limits the rate of calls of a tool with a token bucket
+// shared by all of its clients. Calls over the limit fail with a tool error
+// the client can retry on.
+type ToolRateLimiting struct {
+	// CallsPerSecond is the sustained rate of calls.
+	CallsPerSecond float64
+
+	// Burst is the number of calls allowed above the sustained rate, at
+	// least 1.
+	Burst int
+}
+
+func (m ToolRateLimiting) wrap(fn mcpio.RawToolFunc, tool string, logger *slog.Logger) mcpio.RawToolFunc {
+	bucket := newTokenBucket(m.CallsPerSecond, m.Burst)
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		if !bucket.allow() {
+			logger.DebugContext(ctx, "MCP tool call rate limited", "tool", tool)
+			return nil, &mcpio.ToolError{
+				Code:    mcpio.ErrorCodeRateLimit,
+				Message: fmt.Sprintf("tool %s is rate limited, retry later", tool),
+			}
+		}
+		return fn(ctx, reqCtx, input)
+	}
+}
+
+// ToolAuthentication only accepts calls from clients authenticated by an auth
+// middleware of the MCP server's route. Other calls fail with a protocol
+// error, hidden from the model.
+type ToolAuthentication struct {
+	// Subjects are the subjects of the clients allowed to call the tool, any
+	// authenticated client when empty.
+	Subjects []string
+}
+
+func (m ToolAuthentication) wrap(fn mcpio.RawToolFunc, tool string, logger *slog.Logger) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		principal := serverApps.PrincipalFromContext(ctx)
+		if principal == nil {
+			logger.WarnContext(ctx, "Unauthenticated MCP tool call rejected", "tool", tool)
+			return nil, fmt.Errorf("%w: %s", ErrToolUnauthenticated, tool)
+		}
+		if len(m.Subjects) > 0 && !slices.Contains(m.Subjects, principal.Subject) {
+			logger.WarnContext(ctx, "MCP tool call rejected for subject",
+				"tool", tool, "subject", principal.Subject)
+			return nil, fmt.Errorf("%w: %s", ErrToolForbidden, tool)
+		}
+		return fn(ctx, reqCtx, input)
+	}
+}
+
+// tokenBucket holds up to burst tokens, refilled at rate tokens per second.
+// Each allowed call takes one.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	burst = max(burst, 1)
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+	}
+}
+
+// allow takes a token and reports whether one was available.
+func (b *tokenBucket) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
@@ -0,0 +1,251 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingMiddleware appends its name to calls when it sees a call
+type recordingMiddleware struct {
+	name  string
+	calls *[]string
+}
+
+func (m recordingMiddleware) wrap(fn mcpio.RawToolFunc, _ string, _ *slog.Logger) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		*m.calls = append(*m.calls, m.name)
+		return fn(ctx, reqCtx, input)
+	}
+}
+
+func TestWithMiddlewares_ConfigOrder(t *testing.T) {
+	var calls []string
+	fn := func(context.Context, mcpio.RequestContext, []byte) ([]byte, error) {
+		calls = append(calls, "tool")
+		return []byte(`{}`), nil
+	}
+
+	wrapped := withMiddlewares(fn, []ToolMiddleware{
+		recordingMiddleware{name: "first", calls: &calls},
+		recordingMiddleware{name: "second", calls: &calls},
+	}, "tool", slog.Default())
+	_, err := wrapped(t.Context(), nil, []byte(`{}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "tool"}, calls)
+}
+
+func TestToolLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	t.Run("logs arguments and duration", func(t *testing.T) {
+		buf.Reset()
+		fn := ToolLogging{}.wrap(rawTestToolFunc(), "convert", logger)
+		ctx := serverApps.WithPrincipal(t.Context(), &serverApps.Principal{Subject: "alice"})
+
+		output, err := fn(ctx, nil, []byte(`{"value":1}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"value":1}`, string(output))
+
+		line := buf.String()
+		assert.Contains(t, line, `msg="MCP tool call"`)
+		assert.Contains(t, line, "tool=convert")
+		assert.Contains(t, line, `arguments="{\"value\":1}"`)
+		assert.Contains(t, line, "duration=")
+		assert.Contains(t, line, "subject=alice")
+	})
+
+	t.Run("omits arguments", func(t *testing.T) {
+		buf.Reset()
+		fn := ToolLogging{OmitArguments: true}.wrap(rawTestToolFunc(), "convert", logger)
+
+		_, err := fn(t.Context(), nil, []byte(`{"secret":"s3cr3t"}`))
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "s3cr3t")
+		assert.NotContains(t, buf.String(), "arguments=")
+	})
+
+	t.Run("logs failures", func(t *testing.T) {
+		buf.Reset()
+		failing := func(context.Context, mcpio.RequestContext, []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		}
+		fn := ToolLogging{}.wrap(failing, "convert", logger)
+
+		_, err := fn(t.Context(), nil, []byte(`{}`))
+		require.Error(t, err)
+		assert.Contains(t, buf.String(), "level=WARN")
+		assert.Contains(t, buf.String(), "error=boom")
+	})
+}
+
+func TestToolRateLimiting(t *testing.T) {
+	fn := ToolRateLimiting{CallsPerSecond: 0.001, Burst: 2}.wrap(rawTestToolFunc(), "convert", slog.Default())
+
+	for range 2 {
+		_, err := fn(t.Context(), nil, []byte(`{}`))
+		require.NoError(t, err)
+	}
+
+	_, err := fn(t.Context(), nil, []byte(`{}`))
+	var toolErr *mcpio.ToolError
+	require.ErrorAs(t, err, &toolErr)
+	assert.Equal(t, mcpio.ErrorCodeRateLimit, toolErr.Code)
+}
+
+func TestTokenBucket_Refills(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(2, 1)
+	bucket.last = now
+	bucket.now = func() time.Time { return now }
+
+	assert.True(t, bucket.allow())
+	assert.False(t, bucket.allow())
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, bucket.allow(), "a token is back after 1/rate seconds")
+	assert.False(t, bucket.allow())
+
+	now = now.Add(time.Hour)
+	assert.True(t, bucket.allow())
+	assert.False(t, bucket.allow(), "tokens never exceed the burst")
+}
+
+func TestToolAuthentication(t *testing.T) {
+	authenticated := func(subject string) context.Context {
+		return serverApps.WithPrincipal(t.Context(), &serverApps.Principal{Subject: subject})
+	}
+
+	t.Run("any authenticated client", func(t *testing.T) {
+		fn := ToolAuthentication{}.wrap(rawTestToolFunc(), "convert", slog.Default())
+
+		_, err := fn(authenticated("alice"), nil, []byte(`{}`))
+		require.NoError(t, err)
+
+		_, err = fn(t.Context(), nil, []byte(`{}`))
+		require.ErrorIs(t, err, ErrToolUnauthenticated)
+	})
+
+	t.Run("listed subjects", func(t *testing.T) {
+		fn := ToolAuthentication{Subjects: []string{"alice"}}.wrap(rawTestToolFunc(), "convert", slog.Default())
+
+		_, err := fn(authenticated("alice"), nil, []byte(`{}`))
+		require.NoError(t, err)
+
+		_, err = fn(authenticated("bob"), nil, []byte(`{}`))
+		require.ErrorIs(t, err, ErrToolForbidden)
+	})
+}
+
+// TestBuildHandler_ToolMiddlewares calls a raw tool wrapped in middlewares
+// through an MCP client, with the principal an auth middleware would set on
+// the request.
+func TestBuildHandler_ToolMiddlewares(t *testing.T) {
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw")
+	app.On("MCPToolName").Return("raw")
+	app.On("MCPToolDescription").Return("raw test tool")
+	app.On("MCPRawToolFunc").Return(rawTestToolFunc())
+
+	var buf bytes.Buffer
+	cfg := &Config{
+		ID: "srv",
+		Tools: []ToolRef{{
+			AppID:       "raw",
+			InputSchema: `{"type":"object"}`,
+			Middlewares: []ToolMiddleware{
+				ToolLogging{},
+				ToolAuthentication{Subjects: []string{"alice"}},
+				ToolRateLimiting{CallsPerSecond: 0.001, Burst: 1},
+			},
+		}},
+		Logger: slog.New(slog.NewTextHandler(&buf, nil)),
+	}
+	handler, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subject := r.Header.Get("X-Subject"); subject != "" {
+			r = r.WithContext(serverApps.WithPrincipal(r.Context(), &serverApps.Principal{Subject: subject}))
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	connect := func(t *testing.T, subject string) *mcpsdk.ClientSession {
+		t.Helper()
+		client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+		session, err := client.Connect(t.Context(), &mcpsdk.StreamableClientTransport{
+			Endpoint:   server.URL,
+			HTTPClient: &http.Client{Transport: subjectTransport(subject)},
+		}, nil)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = session.Close() })
+		return session
+	}
+	call := func(session *mcpsdk.ClientSession) (*mcpsdk.CallToolResult, error) {
+		return session.CallTool(t.Context(), &mcpsdk.CallToolParams{
+			Name:      "raw",
+			Arguments: map[string]any{"x": "hello"},
+		})
+	}
+
+	// Rejected by authentication before reaching the rate limit
+	_, err = call(connect(t, "bob"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrToolForbidden.Error())
+
+	alice := connect(t, "alice")
+	result, err := call(alice)
+	require.NoError(t, err)
+	assert.False(t, result.IsError)
+	assert.Contains(t, buf.String(), `msg="MCP tool call"`)
+	assert.Contains(t, buf.String(), `arguments="{\"x\":\"hello\"}"`)
+	assert.Contains(t, buf.String(), "subject=alice")
+	assert.Contains(t, buf.String(), "mcp_server=srv")
+
+	result, err = call(alice)
+	require.NoError(t, err)
+	assert.True(t, result.IsError, "the second call exceeds the rate limit")
+	text, ok := result.Content[0].(*mcpsdk.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, mcpio.ErrorCodeRateLimit)
+}
+
+func TestBuildHandler_TypedToolRejectsMiddlewares(t *testing.T) {
+	app := &mockTypedApp{}
+	app.Test(t)
+	app.On("String").Return("typed").Once()
+	app.On("MCPToolName").Return("typed").Once()
+	cfg := &Config{
+		ID:    "srv",
+		Tools: []ToolRef{{AppID: "typed", Middlewares: []ToolMiddleware{ToolLogging{}}}},
+	}
+
+	_, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support middlewares")
+	app.AssertExpectations(t)
+}
+
+// subjectTransport sends the X-Subject header with every request
+type subjectTransport string
+
+func (s subjectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set("X-Subject", string(s))
+	return http.DefaultTransport.RoundTrip(r)
+}
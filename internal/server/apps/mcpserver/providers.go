@@ -7,6 +7,7 @@ package mcpserver
 
 import (
 	mcpio "github.com/robbyt/mcp-io"
+	toolOption "github.com/robbyt/mcp-io/primitives/tool"
 )
 
 // MCPTypedToolProvider is satisfied by apps whose tool input/output are
@@ -23,8 +24,9 @@ type MCPTypedToolProvider interface {
 
 	// MCPToolOption returns the mcp-io option that registers this app as a
 	// tool with auto-generated input/output schemas. The gateway passes the
-	// resolved tool name (Tool.ID override, or MCPToolName when no override).
-	MCPToolOption(name string) mcpio.Option
+	// resolved tool name (Tool.ID override, or MCPToolName when no override)
+	// and any tool metadata options, such as annotations, from the config.
+	MCPToolOption(name string, opts ...toolOption.Option) mcpio.Option
 }
 
 // MCPRawToolProvider is satisfied by apps whose tool inputs are dynamic
@@ -0,0 +1,76 @@
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	mcpio "github.com/robbyt/mcp-io"
+)
+
+// compileInputSchema parses and resolves a tool's input_schema, for checking
+// the arguments of its calls
+func compileInputSchema(raw string) (*jsonschema.Resolved, error) {
+	return compileSchema(raw, "input_schema")
+}
+
+// compileOutputSchema parses and resolves a tool's output_schema, for
+// checking its results
+func compileOutputSchema(raw string) (*jsonschema.Resolved, error) {
+	return compileSchema(raw, "output_schema")
+}
+
+// compileSchema parses and resolves the schema of the named field
+func compileSchema(raw, field string) (*jsonschema.Resolved, error) {
+	var schema jsonschema.Schema
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		return nil, fmt.Errorf("invalid %s JSON: %w", field, err)
+	}
+	resolved, err := schema.Resolve(nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return resolved, nil
+}
+
+// withArgumentValidation wraps fn so that calls whose arguments don't match
+// the tool's input schema fail with a validation tool error the model can
+// correct, without reaching fn. Missing arguments are checked as an empty
+// object.
+func withArgumentValidation(fn mcpio.RawToolFunc, schema *jsonschema.Resolved) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		var args any = map[string]any{}
+		if trimmed := bytes.TrimSpace(input); len(trimmed) > 0 && !bytes.Equal(trimmed, []byte("null")) {
+			if err := json.Unmarshal(trimmed, &args); err != nil {
+				return nil, mcpio.ValidationError(fmt.Sprintf("arguments are not valid JSON: %v", err))
+			}
+		}
+		if err := schema.Validate(args); err != nil {
+			return nil, mcpio.ValidationError(fmt.Sprintf("arguments do not match the input schema: %v", err))
+		}
+		return fn(ctx, reqCtx, input)
+	}
+}
+
+// withOutputValidation wraps fn so that results not matching the tool's
+// output schema fail the call with a processing tool error, instead of
+// reaching the client. Failed calls are returned unchanged.
+func withOutputValidation(fn mcpio.RawToolFunc, schema *jsonschema.Resolved) mcpio.RawToolFunc {
+	return func(ctx context.Context, reqCtx mcpio.RequestContext, input []byte) ([]byte, error) {
+		output, err := fn(ctx, reqCtx, input)
+		if err != nil {
+			return nil, err
+		}
+
+		var result any
+		if err := json.Unmarshal(output, &result); err != nil {
+			return nil, mcpio.ProcessingError(fmt.Sprintf("result is not valid JSON: %v", err))
+		}
+		if err := schema.Validate(result); err != nil {
+			return nil, mcpio.ProcessingError(fmt.Sprintf("result does not match the output schema: %v", err))
+		}
+		return output, nil
+	}
+}
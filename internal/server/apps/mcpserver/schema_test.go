@@ -0,0 +1,210 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	mcpio "github.com/robbyt/mcp-io"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const greetSchema = `{
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"times": {"type": "integer"}
+	},
+	"required": ["name"]
+}`
+
+func TestCompileInputSchema(t *testing.T) {
+	_, err := compileInputSchema(greetSchema)
+	require.NoError(t, err)
+
+	_, err = compileInputSchema(`{not valid json`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid input_schema JSON")
+
+	_, err = compileInputSchema(`{"properties": {"a": {"$ref": "#/$defs/missing"}}}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid input_schema")
+}
+
+func TestWithArgumentValidation(t *testing.T) {
+	schema, err := compileInputSchema(greetSchema)
+	require.NoError(t, err)
+
+	var called bool
+	fn := withArgumentValidation(func(context.Context, mcpio.RequestContext, []byte) ([]byte, error) {
+		called = true
+		return []byte(`{}`), nil
+	}, schema)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "valid", input: `{"name":"alice","times":2}`},
+		{name: "missing required", input: `{"times":2}`, wantErr: "name"},
+		{name: "wrong type", input: `{"name":"alice","times":"twice"}`, wantErr: "times"},
+		{name: "no arguments", input: ``, wantErr: "name"},
+		{name: "null arguments", input: `null`, wantErr: "name"},
+		{name: "invalid JSON", input: `{"name":`, wantErr: "not valid JSON"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			_, err := fn(t.Context(), nil, []byte(tt.input))
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				assert.True(t, called)
+				return
+			}
+
+			var toolErr *mcpio.ToolError
+			require.ErrorAs(t, err, &toolErr)
+			assert.Equal(t, mcpio.ErrorCodeValidation, toolErr.Code)
+			assert.Contains(t, toolErr.Message, tt.wantErr)
+			assert.False(t, called, "the tool isn't called with invalid arguments")
+		})
+	}
+}
+
+// TestBuildHandler_ValidatesRawToolArguments calls a raw tool through an MCP
+// client with arguments that don't match its input_schema
+func TestBuildHandler_ValidatesRawToolArguments(t *testing.T) {
+	app := &mockRawToolApp{}
+	app.Test(t)
+	app.On("String").Return("raw")
+	app.On("MCPToolName").Return("raw")
+	app.On("MCPToolDescription").Return("raw test tool")
+	app.On("MCPRawToolFunc").Return(rawTestToolFunc())
+
+	cfg := &Config{
+		ID:    "srv",
+		Tools: []ToolRef{{AppID: "raw", InputSchema: greetSchema}},
+	}
+	handler, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+	require.NoError(t, err)
+
+	call := rawToolCaller(t, handler)
+
+	assert.False(t, call(map[string]any{"name": "alice"}).IsError)
+
+	text := toolErrorText(t, call(map[string]any{"times": 2}))
+	assert.Contains(t, text, mcpio.ErrorCodeValidation)
+	assert.Contains(t, text, "name")
+
+	text = toolErrorText(t, call(map[string]any{"name": "alice", "times": "twice"}))
+	assert.Contains(t, text, mcpio.ErrorCodeValidation)
+	assert.Contains(t, text, "times")
+}
+
+func TestWithOutputValidation(t *testing.T) {
+	schema, err := compileOutputSchema(`{"type":"object","required":["greeting"]}`)
+	require.NoError(t, err)
+
+	returning := func(output string, err error) mcpio.RawToolFunc {
+		return func(context.Context, mcpio.RequestContext, []byte) ([]byte, error) {
+			return []byte(output), err
+		}
+	}
+
+	output, err := withOutputValidation(returning(`{"greeting":"hi"}`, nil), schema)(t.Context(), nil, nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"greeting":"hi"}`, string(output))
+
+	_, err = withOutputValidation(returning(`{"farewell":"bye"}`, nil), schema)(t.Context(), nil, nil)
+	var toolErr *mcpio.ToolError
+	require.ErrorAs(t, err, &toolErr)
+	assert.Equal(t, mcpio.ErrorCodeProcessing, toolErr.Code)
+	assert.Contains(t, toolErr.Message, "result does not match the output schema")
+	assert.Contains(t, toolErr.Message, "greeting")
+
+	_, err = withOutputValidation(returning(`not json`, nil), schema)(t.Context(), nil, nil)
+	require.ErrorAs(t, err, &toolErr)
+	assert.Contains(t, toolErr.Message, "result is not valid JSON")
+
+	failure := errors.New("boom")
+	_, err = withOutputValidation(returning("", failure), schema)(t.Context(), nil, nil)
+	require.ErrorIs(t, err, failure, "failed calls are returned unchanged")
+}
+
+// TestBuildHandler_ValidatesRawToolResults calls a raw tool echoing its
+// arguments through an MCP client, so the arguments decide whether the result
+// matches the output_schema
+func TestBuildHandler_ValidatesRawToolResults(t *testing.T) {
+	newHandler := func(t *testing.T, skip bool) http.Handler {
+		t.Helper()
+		app := &mockRawToolApp{}
+		app.Test(t)
+		app.On("String").Return("raw")
+		app.On("MCPToolName").Return("raw")
+		app.On("MCPToolDescription").Return("raw test tool")
+		app.On("MCPRawToolFunc").Return(rawTestToolFunc())
+
+		cfg := &Config{
+			ID: "srv",
+			Tools: []ToolRef{{
+				AppID:                "raw",
+				InputSchema:          `{"type":"object"}`,
+				OutputSchema:         `{"type":"object","required":["greeting"]}`,
+				SkipOutputValidation: skip,
+			}},
+		}
+		handler, err := BuildHandler(cfg, fakeRegistry(t, app), "srv")
+		require.NoError(t, err)
+		return handler
+	}
+
+	t.Run("checked", func(t *testing.T) {
+		call := rawToolCaller(t, newHandler(t, false))
+
+		assert.False(t, call(map[string]any{"greeting": "hi"}).IsError)
+
+		text := toolErrorText(t, call(map[string]any{"farewell": "bye"}))
+		assert.Contains(t, text, mcpio.ErrorCodeProcessing)
+		assert.Contains(t, text, "output schema")
+	})
+
+	t.Run("skipped", func(t *testing.T) {
+		call := rawToolCaller(t, newHandler(t, true))
+		assert.False(t, call(map[string]any{"farewell": "bye"}).IsError)
+	})
+}
+
+// rawToolCaller connects an MCP client to handler and returns a function
+// calling its "raw" tool
+func rawToolCaller(t *testing.T, handler http.Handler) func(args map[string]any) *mcpsdk.CallToolResult {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := mcpsdk.NewClient(&mcpsdk.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(t.Context(), &mcpsdk.StreamableClientTransport{Endpoint: server.URL}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return func(args map[string]any) *mcpsdk.CallToolResult {
+		t.Helper()
+		result, err := session.CallTool(t.Context(), &mcpsdk.CallToolParams{Name: "raw", Arguments: args})
+		require.NoError(t, err)
+		return result
+	}
+}
+
+// toolErrorText returns the text of a tool error result
+func toolErrorText(t *testing.T, result *mcpsdk.CallToolResult) string {
+	t.Helper()
+	require.True(t, result.IsError)
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(*mcpsdk.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
@@ -0,0 +1,101 @@
+package apps
+
+import (
+	"mime"
+	"strconv"
+	"strings"
+)
+
+// mediaRange is one entry of an Accept header
+type mediaRange struct {
+	typ     string
+	subtype string
+	q       float64
+}
+
+// specificity ranks how closely the range names a media type: */* is the
+// least specific, type/* more, and type/subtype the most
+func (mr mediaRange) specificity() int {
+	switch {
+	case mr.typ == "*":
+		return 0
+	case mr.subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func (mr mediaRange) matches(typ, subtype string) bool {
+	return (mr.typ == "*" || mr.typ == typ) && (mr.subtype == "*" || mr.subtype == subtype)
+}
+
+// Negotiate picks the offered media type the Accept header prefers. Each offer
+// gets the quality of the most specific range matching it; the offer with the
+// highest non-zero quality wins, with ties going to the earlier offer. An empty
+// Accept header accepts anything. It returns false when no offer is acceptable.
+func Negotiate(accept string, offers []string) (string, bool) {
+	if len(offers) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offers[0], true
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		typ, subtype, ok := splitMediaType(offer)
+		if !ok {
+			continue
+		}
+
+		q, specificity := 0.0, -1
+		for _, mr := range ranges {
+			if mr.matches(typ, subtype) && mr.specificity() > specificity {
+				q, specificity = mr.q, mr.specificity()
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// parseAccept parses the media ranges of an Accept header, skipping malformed
+// entries. Ranges without a q parameter have a quality of 1.
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		typ, subtype, ok := strings.Cut(mediaType, "/")
+		if !ok || (typ == "*" && subtype != "*") {
+			continue
+		}
+
+		q := 1.0
+		if qv, ok := params["q"]; ok {
+			parsed, err := strconv.ParseFloat(qv, 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+		ranges = append(ranges, mediaRange{typ: typ, subtype: subtype, q: q})
+	}
+	return ranges
+}
+
+// splitMediaType returns the lowercased type and subtype of a media type,
+// ignoring its parameters
+func splitMediaType(mediaType string) (string, string, bool) {
+	parsed, _, err := mime.ParseMediaType(mediaType)
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(parsed, "/")
+}
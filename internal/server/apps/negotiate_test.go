@@ -0,0 +1,41 @@
+package apps
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	offers := []string{"application/json", "text/plain; charset=utf-8"}
+
+	tests := []struct {
+		name     string
+		accept   string
+		offers   []string
+		expected string
+		ok       bool
+	}{
+		{"empty accept takes the first offer", "", offers, "application/json", true},
+		{"exact match", "text/plain", offers, "text/plain; charset=utf-8", true},
+		{"match ignores case", "Application/JSON", offers, "application/json", true},
+		{"wildcard takes the first offer", "*/*", offers, "application/json", true},
+		{"type wildcard", "text/*", offers, "text/plain; charset=utf-8", true},
+		{"highest quality wins", "application/json;q=0.5, text/plain;q=0.9", offers, "text/plain; charset=utf-8", true},
+		{"quality ties go to the earlier offer", "text/plain, application/json", offers, "application/json", true},
+		{"more specific range overrides a wildcard", "*/*;q=0.8, application/json;q=0.1", offers, "text/plain; charset=utf-8", true},
+		{"q=0 excludes an offer", "application/json;q=0, */*", offers, "text/plain; charset=utf-8", true},
+		{"nothing acceptable", "image/png", offers, "", false},
+		{"everything excluded", "*/*;q=0", offers, "", false},
+		{"malformed ranges are skipped", "text/plain;q=abc, ;;, application/json", offers, "application/json", true},
+		{"no offers", "*/*", nil, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Negotiate(tc.accept, tc.offers)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package openapi
+
+// Config contains everything needed to instantiate an OpenAPI app.
+// This is a Data Transfer Object (DTO) with no dependencies on domain packages.
+type Config struct {
+	// ID is the unique identifier for this app instance.
+	ID string
+
+	// Title and Version fill the document's info section.
+	Title   string
+	Version string
+
+	// SwaggerUI serves a Swagger UI page loading its assets from
+	// SwaggerUIAssetsURL.
+	SwaggerUI          bool
+	SwaggerUIAssetsURL string
+}
+
+// Route describes a configured HTTP route for the document.
+type Route struct {
+	// EndpointID and ListenerID identify where the route is served.
+	EndpointID string
+	ListenerID string
+
+	// Path is the route's path prefix, or the base path of a regex route.
+	Path string
+
+	// PathRegex is set for routes matched by a path regex.
+	PathRegex string
+
+	// Methods the route accepts. Empty means any method.
+	Methods []string
+
+	// AppID and AppType identify the app handling the route.
+	AppID   string
+	AppType string
+}
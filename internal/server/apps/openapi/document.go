@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// openAPIVersion is the version of the OpenAPI specification the document
+// follows
+const openAPIVersion = "3.0.3"
+
+// anyMethods are documented for routes that don't restrict the method. They
+// are the methods an OpenAPI path item can describe, other than TRACE.
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodPatch,
+}
+
+// Document is an OpenAPI 3 document
+type Document struct {
+	OpenAPI string              `json:"openapi"`
+	Info    Info                `json:"info"`
+	Paths   map[string]PathItem `json:"paths"`
+}
+
+// Info is the info section of an OpenAPI document
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem holds the operations of a path, keyed by lower-case method
+type PathItem map[string]*Operation
+
+// Operation describes the route handling a method on a path
+type Operation struct {
+	OperationID string              `json:"operationId"`
+	Summary     string              `json:"summary"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+
+	App       string `json:"x-firelynx-app"`
+	AppType   string `json:"x-firelynx-app-type,omitempty"`
+	Endpoint  string `json:"x-firelynx-endpoint"`
+	Listener  string `json:"x-firelynx-listener,omitempty"`
+	PathRegex string `json:"x-firelynx-path-regex,omitempty"`
+}
+
+// Response describes a response of an operation
+type Response struct {
+	Description string `json:"description"`
+}
+
+// NewDocument builds the OpenAPI document of the routes. Each route becomes an
+// operation per method it accepts; when several routes share a path and
+// method, the first one is documented.
+func NewDocument(title, version string, routes []Route) *Document {
+	doc := &Document{
+		OpenAPI: openAPIVersion,
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]PathItem),
+	}
+
+	for _, route := range routes {
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[route.Path] = item
+		}
+
+		methods := route.Methods
+		if len(methods) == 0 {
+			methods = anyMethods
+		}
+		for _, method := range methods {
+			key := strings.ToLower(method)
+			if _, exists := item[key]; exists {
+				continue
+			}
+			item[key] = newOperation(route, strings.ToUpper(method))
+		}
+	}
+
+	return doc
+}
+
+// newOperation describes the route handling method
+func newOperation(route Route, method string) *Operation {
+	summary := "Handled by app " + route.AppID
+	if route.AppType != "" {
+		summary += fmt.Sprintf(" (%s)", route.AppType)
+	}
+
+	description := fmt.Sprintf("Matches request paths starting with %s.", route.Path)
+	if route.PathRegex != "" {
+		description = fmt.Sprintf("Matches request paths against the regular expression %s.", route.PathRegex)
+	}
+
+	return &Operation{
+		OperationID: fmt.Sprintf("%s:%s:%s", route.EndpointID, method, route.Path),
+		Summary:     summary,
+		Description: description,
+		Tags:        []string{route.EndpointID},
+		Responses: map[string]Response{
+			"default": {Description: "Response from app " + route.AppID},
+		},
+		App:       route.AppID,
+		AppType:   route.AppType,
+		Endpoint:  route.EndpointID,
+		Listener:  route.ListenerID,
+		PathRegex: route.PathRegex,
+	}
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDocument(t *testing.T) {
+	routes := []Route{
+		{EndpointID: "api", ListenerID: "http", Path: "/echo", Methods: []string{"get"}, AppID: "echo", AppType: "echo"},
+		{EndpointID: "api", ListenerID: "http", Path: "/echo", Methods: []string{"GET", "POST"}, AppID: "other", AppType: "echo"},
+		{EndpointID: "api", ListenerID: "http", Path: "/users/", PathRegex: "^/users/[0-9]+$", AppID: "users"},
+	}
+
+	doc := NewDocument("Gateway", "2.0.0", routes)
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, Info{Title: "Gateway", Version: "2.0.0"}, doc.Info)
+	require.Len(t, doc.Paths, 2)
+
+	t.Run("first route wins a path and method", func(t *testing.T) {
+		echo := doc.Paths["/echo"]
+		require.Len(t, echo, 2)
+		assert.Equal(t, "echo", echo["get"].App)
+		assert.Equal(t, "api:GET:/echo", echo["get"].OperationID)
+		assert.Equal(t, "Handled by app echo (echo)", echo["get"].Summary)
+		assert.Equal(t, "other", echo["post"].App)
+	})
+
+	t.Run("any method", func(t *testing.T) {
+		users := doc.Paths["/users/"]
+		assert.Len(t, users, len(anyMethods))
+		for _, op := range users {
+			assert.Equal(t, "Handled by app users", op.Summary)
+			assert.Equal(t, "^/users/[0-9]+$", op.PathRegex)
+			assert.Contains(t, op.Description, "regular expression ^/users/[0-9]+$")
+		}
+		assert.Contains(t, users, "patch")
+		assert.NotContains(t, users, "trace")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		data, err := json.Marshal(doc)
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		get := decoded["paths"].(map[string]any)["/echo"].(map[string]any)["get"].(map[string]any)
+		assert.Equal(t, "echo", get["x-firelynx-app"])
+		assert.Equal(t, "http", get["x-firelynx-listener"])
+		assert.NotContains(t, get, "x-firelynx-path-regex")
+		assert.Contains(t, get["responses"], "default")
+	})
+}
+
+func TestNewDocument_NoRoutes(t *testing.T) {
+	doc := NewDocument("firelynx", "1.0.0", nil)
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"openapi":"3.0.3","info":{"title":"firelynx","version":"1.0.0"},"paths":{}}`, string(data))
+}
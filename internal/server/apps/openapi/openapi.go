@@ -0,0 +1,108 @@
+// Package openapi provides an app that serves an OpenAPI 3 document
+// describing the HTTP routes of the running config, and optionally a Swagger
+// UI page for it. The routes are set when the config's apps are created, so
+// each config transaction serves a document matching its own routes.
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// specFile is the path suffix serving the document when Swagger UI is enabled
+const specFile = "openapi.json"
+
+// App serves the OpenAPI document of the configured routes.
+type App struct {
+	id                 string
+	title              string
+	version            string
+	swaggerUI          bool
+	swaggerUIAssetsURL string
+
+	// doc is the document of the routes, set by SetRoutes
+	doc *Document
+}
+
+// New creates a new OpenAPI app from a Config DTO. It serves an empty
+// document until SetRoutes is called.
+func New(cfg *Config) *App {
+	return &App{
+		id:                 cfg.ID,
+		title:              cfg.Title,
+		version:            cfg.Version,
+		swaggerUI:          cfg.SwaggerUI,
+		swaggerUIAssetsURL: strings.TrimSuffix(cfg.SwaggerUIAssetsURL, "/"),
+		doc:                NewDocument(cfg.Title, cfg.Version, nil),
+	}
+}
+
+// String returns the unique identifier of the application.
+func (a *App) String() string { return a.id }
+
+// SetRoutes builds the document of the routes. It must be called before the
+// app starts serving requests.
+func (a *App) SetRoutes(routes []Route) {
+	a.doc = NewDocument(a.title, a.version, routes)
+}
+
+// HandleHTTP serves the document. With Swagger UI enabled, requests for a
+// path ending in /openapi.json get the document and every other path gets the
+// Swagger UI page.
+func (a *App) HandleHTTP(_ context.Context, w http.ResponseWriter, r *http.Request) error {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil
+	}
+
+	if !a.swaggerUI || strings.HasSuffix(r.URL.Path, "/"+specFile) {
+		spec, err := json.MarshalIndent(a.doc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode OpenAPI document: %w", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(spec); err != nil {
+			return fmt.Errorf("failed to write OpenAPI document: %w", err)
+		}
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := swaggerUIPage.Execute(w, swaggerUIData{
+		Title:     a.title,
+		AssetsURL: a.swaggerUIAssetsURL,
+		SpecURL:   strings.TrimSuffix(r.URL.Path, "/") + "/" + specFile,
+	}); err != nil {
+		return fmt.Errorf("failed to write Swagger UI page: %w", err)
+	}
+	return nil
+}
+
+// swaggerUIData holds the values of the Swagger UI page
+type swaggerUIData struct {
+	Title     string
+	AssetsURL string
+	SpecURL   string
+}
+
+var swaggerUIPage = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.AssetsURL}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.AssetsURL}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "{{.SpecURL}}", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestApp(swaggerUI bool) *App {
+	app := New(&Config{
+		ID:                 "docs",
+		Title:              "Gateway",
+		Version:            "1.0.0",
+		SwaggerUI:          swaggerUI,
+		SwaggerUIAssetsURL: "https://cdn.example.com/swagger-ui/",
+	})
+	app.SetRoutes([]Route{{EndpointID: "api", Path: "/echo", Methods: []string{"GET"}, AppID: "echo"}})
+	return app
+}
+
+func TestNew(t *testing.T) {
+	app := New(&Config{ID: "docs", Title: "Gateway", Version: "1.0.0"})
+	require.NotNil(t, app)
+	assert.Equal(t, "docs", app.String())
+	assert.Empty(t, app.doc.Paths)
+}
+
+func TestApp_HandleHTTP_Document(t *testing.T) {
+	app := newTestApp(false)
+
+	for _, path := range []string{"/docs", "/docs/anything"} {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var doc Document
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "Gateway", doc.Info.Title)
+		assert.Contains(t, doc.Paths, "/echo")
+	}
+}
+
+func TestApp_HandleHTTP_SwaggerUI(t *testing.T) {
+	app := newTestApp(true)
+
+	t.Run("page", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		body := rec.Body.String()
+		assert.Contains(t, body, `url: "\/docs\/openapi.json"`)
+		assert.Contains(t, body, `href="https://cdn.example.com/swagger-ui/swagger-ui.css"`)
+		assert.Contains(t, body, `src="https://cdn.example.com/swagger-ui/swagger-ui-bundle.js"`)
+	})
+
+	t.Run("document", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.json", nil)
+		require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `"openapi": "3.0.3"`)
+	})
+}
+
+func TestApp_HandleHTTP_MethodNotAllowed(t *testing.T) {
+	app := newTestApp(false)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/docs", nil)
+	require.NoError(t, app.HandleHTTP(t.Context(), rec, req))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+}
+
+func TestApp_SetRoutes(t *testing.T) {
+	app := newTestApp(false)
+	app.SetRoutes(nil)
+	assert.Empty(t, app.doc.Paths)
+}
@@ -0,0 +1,18 @@
+package apps
+
+import "context"
+
+// pathParamsKey is the context key for path params captured during routing
+type pathParamsKey struct{}
+
+// WithPathParams returns a copy of ctx carrying the named path params captured
+// by the router, e.g. from the named groups of a path regex route.
+func WithPathParams(ctx context.Context, params map[string]string) context.Context {
+	return context.WithValue(ctx, pathParamsKey{}, params)
+}
+
+// PathParams returns the path params stored in ctx, or nil if there are none
+func PathParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(pathParamsKey{}).(map[string]string)
+	return params
+}
@@ -0,0 +1,15 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathParams(t *testing.T) {
+	assert.Nil(t, PathParams(context.Background()))
+
+	ctx := WithPathParams(context.Background(), map[string]string{"id": "42"})
+	assert.Equal(t, map[string]string{"id": "42"}, PathParams(ctx))
+}
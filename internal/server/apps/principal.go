@@ -0,0 +1,32 @@
+package apps
+
+import "context"
+
+// Principal identifies the client authenticated by an auth middleware
+type Principal struct {
+	// Subject identifies the client: the "sub" claim of a JWT, the username
+	// of a Basic credential, or a fingerprint of the API key or opaque token
+	// it presented
+	Subject string
+
+	// Method is the authentication method: "api_key", "bearer", "jwt", or "basic"
+	Method string
+
+	// Claims holds the verified claims of a JWT, nil otherwise
+	Claims map[string]any
+}
+
+// principalKey is the context key for the authenticated principal
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the authenticated principal
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the principal stored in ctx, or nil if the
+// request wasn't authenticated
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
@@ -0,0 +1,16 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	assert.Nil(t, PrincipalFromContext(context.Background()))
+
+	p := &Principal{Subject: "user-1", Method: "bearer"}
+	ctx := WithPrincipal(context.Background(), p)
+	assert.Same(t, p, PrincipalFromContext(ctx))
+}
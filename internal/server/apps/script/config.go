@@ -7,6 +7,15 @@ import (
 	"github.com/robbyt/go-polyscript/platform"
 )
 
+const (
+	// DefaultTimeoutBody is the response body sent when a script runs out of time
+	DefaultTimeoutBody = "Script Execution Timeout"
+
+	// DefaultShutdownBody is the response body sent when the server shuts
+	// down while a script runs
+	DefaultShutdownBody = "Service Unavailable"
+)
+
 // Config contains everything needed to instantiate a script app.
 // This is a Data Transfer Object (DTO) with no dependencies on domain packages.
 // All validation and resource compilation happens at the domain layer before creating this config.
@@ -27,4 +36,20 @@ type Config struct {
 
 	// ExecTimeout is the maximum execution time for script evaluation
 	ExecTimeout time.Duration
+
+	// Evaluator names the script's evaluator type, e.g. "risor", and labels
+	// its evaluation duration metric
+	Evaluator string
+
+	// Streaming exposes a response stream to the script under StreamKey. It
+	// must only be set for evaluators that can call Go functions.
+	Streaming bool
+
+	// TimeoutBody is the response body sent with the 504 when the script runs
+	// out of time, DefaultTimeoutBody when empty
+	TimeoutBody string
+
+	// ShutdownBody is the response body sent with the 503 when the server
+	// shuts down while the script runs, DefaultShutdownBody when empty
+	ShutdownBody string
 }
@@ -33,6 +33,8 @@ func (s *ScriptApp) MCPToolDescription() string {
 // per script/CLAUDE.md ({"data": {...}, "args": {...}}), and marshals the
 // result back to JSON. Scripts that return {"error": "..."} surface as
 // mcpio.ValidationError so MCP clients receive a structured tool error.
+// Arrays and primitive values are marshaled the same way, and a list of
+// content descriptors is returned by the MCP server as content blocks.
 func (s *ScriptApp) MCPRawToolFunc() mcpio.RawToolFunc {
 	return func(ctx context.Context, _ mcpio.RequestContext, input []byte) ([]byte, error) {
 		var args map[string]any
@@ -64,7 +66,7 @@ func (s *ScriptApp) MCPRawToolFunc() mcpio.RawToolFunc {
 			return nil, fmt.Errorf("script app context: %w", err)
 		}
 
-		result, err := s.evaluator.Eval(enrichedCtx)
+		result, _, err := s.eval(enrichedCtx)
 		if err != nil {
 			if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
 				return nil, mcpio.ProcessingError("script execution timeout")
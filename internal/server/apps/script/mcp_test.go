@@ -72,6 +72,32 @@ let n = args.get("n", 0)
 	assert.JSONEq(t, `{"result":21}`, string(out))
 }
 
+func TestScriptApp_MCPRawToolFunc_NonObjectResults(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want string
+	}{
+		{"array", `[1, "two", {"three": 3}]`, `[1, "two", {"three": 3}]`},
+		{"number", `2.5`, `2.5`},
+		{"string", `"done"`, `"done"`},
+		{
+			"content descriptors",
+			`[{"type": "text", "text": "a chart"}, {"type": "image", "data": "iVBORw==", "mimeType": "image/png"}]`,
+			`[{"type": "text", "text": "a chart"}, {"type": "image", "data": "iVBORw==", "mimeType": "image/png"}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn := buildRisorScriptApp(t, "results", tt.code, nil).MCPRawToolFunc()
+
+			out, err := fn(t.Context(), nil, []byte(`{}`))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
+		})
+	}
+}
+
 func TestScriptApp_MCPRawToolFunc_ScriptErrorBecomesValidationError(t *testing.T) {
 	const code = `
 let args = ctx.get("args", {})
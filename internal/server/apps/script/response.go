@@ -0,0 +1,133 @@
+package script
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/http"
+)
+
+// ResponseKey is the key of a script result object that sets the response
+// status and headers, for example:
+//
+//	{"_response": {"status": 201, "headers": {"Location": "/items/7"}}, "id": 7}
+//
+// The key is removed from the object, and the rest is the JSON body. Without
+// it, the object is written as JSON with a 200 status.
+const ResponseKey = "_response"
+
+// ErrInvalidResponse is returned when a script result's ResponseKey value
+// can't be used to write a response
+var ErrInvalidResponse = errors.New("invalid script response")
+
+// responseSettings holds the status and headers requested by a script
+type responseSettings struct {
+	status int
+	header http.Header
+}
+
+// splitResponse removes ResponseKey from a script result object, returning
+// the remaining body and the settings it held. The settings are nil when the
+// object has no ResponseKey.
+func splitResponse(result map[string]any) (map[string]any, *responseSettings, error) {
+	raw, ok := result[ResponseKey]
+	if !ok {
+		return result, nil, nil
+	}
+
+	spec, ok := raw.(map[string]any)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s must be an object, got %T", ErrInvalidResponse, ResponseKey, raw)
+	}
+
+	settings := &responseSettings{status: http.StatusOK, header: make(http.Header)}
+	if v, ok := spec["status"]; ok {
+		status, err := parseStatus(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		settings.status = status
+	}
+	if v, ok := spec["headers"]; ok {
+		if err := parseHeaders(v, settings.header); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	body := maps.Clone(result)
+	delete(body, ResponseKey)
+	return body, settings, nil
+}
+
+// parseStatus converts a script's status value to an HTTP status code.
+// Numbers arrive as int64 or float64 depending on the evaluator.
+func parseStatus(v any) (int, error) {
+	var status int
+	switch n := v.(type) {
+	case int:
+		status = n
+	case int64:
+		status = int(n)
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, fmt.Errorf("%w: status %v is not an integer", ErrInvalidResponse, n)
+		}
+		status = int(n)
+	default:
+		return 0, fmt.Errorf("%w: status must be a number, got %T", ErrInvalidResponse, v)
+	}
+	if status < 100 || status > 599 {
+		return 0, fmt.Errorf("%w: status %d is out of range", ErrInvalidResponse, status)
+	}
+	return status, nil
+}
+
+// parseHeaders adds a script's headers object to header. Each value is a
+// string or a list of strings.
+func parseHeaders(v any, header http.Header) error {
+	headers, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%w: headers must be an object, got %T", ErrInvalidResponse, v)
+	}
+	for name, value := range headers {
+		switch val := value.(type) {
+		case string:
+			header.Add(name, val)
+		case []any:
+			for _, item := range val {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("%w: header %q values must be strings, got %T", ErrInvalidResponse, name, item)
+				}
+				header.Add(name, s)
+			}
+		default:
+			return fmt.Errorf("%w: header %q must be a string or list of strings, got %T", ErrInvalidResponse, name, value)
+		}
+	}
+	return nil
+}
+
+// writeResponse writes body as JSON with the script's status and headers.
+// Headers set by the script replace the default Content-Type, and an empty
+// body is omitted, so a script can respond with e.g. 204 or a redirect.
+func writeResponse(w http.ResponseWriter, body map[string]any, settings *responseSettings) error {
+	var encoded []byte
+	if len(body) > 0 {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode script result: %w", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+	}
+	maps.Copy(w.Header(), settings.header)
+
+	w.WriteHeader(settings.status)
+	if encoded == nil {
+		return nil
+	}
+	_, err := w.Write(append(encoded, '\n'))
+	return err
+}
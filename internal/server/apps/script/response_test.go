@@ -0,0 +1,66 @@
+package script
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitResponse(t *testing.T) {
+	t.Run("without response settings", func(t *testing.T) {
+		result := map[string]any{"status": 201, "id": 7}
+		body, settings, err := splitResponse(result)
+		require.NoError(t, err)
+		assert.Nil(t, settings)
+		assert.Equal(t, result, body)
+	})
+
+	t.Run("with response settings", func(t *testing.T) {
+		result := map[string]any{
+			ResponseKey: map[string]any{
+				"status":  float64(302),
+				"headers": map[string]any{"location": "/next", "Vary": []any{"Accept", "Origin"}},
+			},
+			"id": int64(7),
+		}
+		body, settings, err := splitResponse(result)
+		require.NoError(t, err)
+		require.NotNil(t, settings)
+		assert.Equal(t, http.StatusFound, settings.status)
+		assert.Equal(t, "/next", settings.header.Get("Location"))
+		assert.Equal(t, []string{"Accept", "Origin"}, settings.header.Values("Vary"))
+		assert.Equal(t, map[string]any{"id": int64(7)}, body)
+		assert.Contains(t, result, ResponseKey, "the script result is not modified")
+	})
+
+	t.Run("status defaults to 200", func(t *testing.T) {
+		_, settings, err := splitResponse(map[string]any{
+			ResponseKey: map[string]any{"headers": map[string]any{"X-Id": "7"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, settings.status)
+	})
+
+	errorTests := []struct {
+		name     string
+		response any
+		contains string
+	}{
+		{"not an object", "201", "must be an object"},
+		{"status not a number", map[string]any{"status": "201"}, "status must be a number"},
+		{"status not an integer", map[string]any{"status": 201.5}, "not an integer"},
+		{"status out of range", map[string]any{"status": int64(600)}, "out of range"},
+		{"headers not an object", map[string]any{"headers": []any{"a"}}, "headers must be an object"},
+		{"header not a string", map[string]any{"headers": map[string]any{"X-Id": int64(7)}}, "string or list of strings"},
+		{"header list item not a string", map[string]any{"headers": map[string]any{"X-Id": []any{int64(7)}}}, "values must be strings"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := splitResponse(map[string]any{ResponseKey: tt.response})
+			require.ErrorIs(t, err, ErrInvalidResponse)
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
@@ -3,12 +3,18 @@ package script
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
+	"mime"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
 	"github.com/robbyt/go-polyscript/platform"
 	"github.com/robbyt/go-polyscript/platform/constants"
 	"github.com/robbyt/go-polyscript/platform/data"
@@ -18,9 +24,13 @@ import (
 type ScriptApp struct {
 	id                string
 	evaluator         platform.Evaluator
+	evaluatorType     string
 	appStaticProvider data.Provider // Pre-created app-level static provider
 	logger            *slog.Logger
 	execTimeout       time.Duration
+	streaming         bool
+	timeoutBody       string
+	shutdownBody      string
 }
 
 // New creates a new script app instance from a Config DTO
@@ -37,12 +47,25 @@ func New(cfg *Config) (*ScriptApp, error) {
 	// Pre-create app-level static provider for performance
 	appStaticProvider := data.NewStaticProvider(cfg.StaticData)
 
+	timeoutBody := cfg.TimeoutBody
+	if timeoutBody == "" {
+		timeoutBody = DefaultTimeoutBody
+	}
+	shutdownBody := cfg.ShutdownBody
+	if shutdownBody == "" {
+		shutdownBody = DefaultShutdownBody
+	}
+
 	return &ScriptApp{
 		id:                cfg.ID,
 		evaluator:         cfg.CompiledEvaluator,
+		evaluatorType:     cfg.Evaluator,
 		appStaticProvider: appStaticProvider,
 		logger:            cfg.Logger,
 		execTimeout:       cfg.ExecTimeout,
+		streaming:         cfg.Streaming,
+		timeoutBody:       timeoutBody,
+		shutdownBody:      shutdownBody,
 	}, nil
 }
 
@@ -57,43 +80,44 @@ func (s *ScriptApp) HandleHTTP(
 	w http.ResponseWriter,
 	r *http.Request,
 ) error {
+	// Failures before the script runs mean the evaluator couldn't be set up
+	// for this request; nothing is written so the dispatcher can respond
+	if s.evaluator == nil {
+		return fmt.Errorf("%w: script app %s has no evaluator", apps.ErrAppUnavailable, s.id)
+	}
+
 	timeoutCtx, cancel := context.WithTimeout(ctx, s.execTimeout)
 	defer cancel()
 
 	// Prepare script data with proper structure for WASM modules
 	scriptData, err := s.prepareScriptData(timeoutCtx, r)
 	if err != nil {
-		s.logger.Error("Failed to prepare script data", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return err
+		return fmt.Errorf("%w: failed to prepare script data: %w", apps.ErrAppUnavailable, err)
+	}
+
+	var stream *responseStream
+	if s.streaming {
+		stream = newResponseStream(w)
+		scriptData[StreamKey] = stream.functions()
 	}
 
 	// Create context provider and add all merged data to context
 	contextProvider := data.NewContextProvider(constants.EvalData)
 	enrichedCtx, err := contextProvider.AddDataToContext(timeoutCtx, scriptData)
 	if err != nil {
-		s.logger.Error("Failed to add runtime data", "error", err)
-		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-		return err
+		return fmt.Errorf("%w: failed to add runtime data: %w", apps.ErrAppUnavailable, err)
 	}
 
-	start := time.Now()
-	result, err := s.evaluator.Eval(enrichedCtx)
-	duration := time.Since(start)
+	result, duration, err := s.eval(enrichedCtx)
+	streamed := stream != nil && stream.close()
 
 	if err != nil {
-		s.logger.Error("Script execution failed",
-			"error", err,
-			"duration", duration,
-		)
-
-		if timeoutCtx.Err() == context.DeadlineExceeded {
-			http.Error(w, "Script Execution Timeout", http.StatusGatewayTimeout)
-			return err
-		}
+		return s.handleEvalError(ctx, timeoutCtx, w, err, duration, streamed)
+	}
 
-		http.Error(w, "Script Execution Error", http.StatusInternalServerError)
-		return err
+	if streamed {
+		s.logger.Debug("Script streamed its response", "duration", duration, "size", stream.size)
+		return nil
 	}
 
 	s.logger.Debug("Script executed successfully", "duration", duration)
@@ -107,6 +131,70 @@ func (s *ScriptApp) HandleHTTP(
 	return nil
 }
 
+// handleEvalError answers a request whose script failed. A deadline gets a
+// 504 and a server shutdown a 503, each with its configured body. A client
+// that went away gets no response, as there's no one to read it. Any other
+// failure is a 500. When the script already streamed part of its response,
+// the response just ends where the script stopped.
+func (s *ScriptApp) handleEvalError(
+	ctx, timeoutCtx context.Context,
+	w http.ResponseWriter,
+	err error,
+	duration time.Duration,
+	streamed bool,
+) error {
+	switch {
+	case errors.Is(context.Cause(ctx), apps.ErrServerShutdown):
+		s.logger.Warn("Script execution interrupted by server shutdown",
+			"error", err,
+			"duration", duration,
+			"streamed", streamed,
+		)
+		if !streamed {
+			http.Error(w, s.shutdownBody, http.StatusServiceUnavailable)
+		}
+		return fmt.Errorf("%w: %w", apps.ErrServerShutdown, err)
+
+	case errors.Is(ctx.Err(), context.Canceled):
+		s.logger.Info("Script execution canceled by client",
+			"duration", duration,
+			"streamed", streamed,
+		)
+		return fmt.Errorf("%w: %w", context.Canceled, err)
+
+	case errors.Is(timeoutCtx.Err(), context.DeadlineExceeded):
+		s.logger.Error("Script execution timed out",
+			"error", err,
+			"duration", duration,
+			"streamed", streamed,
+		)
+		if !streamed {
+			http.Error(w, s.timeoutBody, http.StatusGatewayTimeout)
+		}
+		return fmt.Errorf("%w: %w", context.DeadlineExceeded, err)
+	}
+
+	s.logger.Error("Script execution failed",
+		"error", err,
+		"duration", duration,
+		"streamed", streamed,
+	)
+	if !streamed {
+		http.Error(w, "Script Execution Error", http.StatusInternalServerError)
+	}
+	return err
+}
+
+// eval runs the script and records the evaluation duration in the metrics
+// package, labeled by evaluator type
+func (s *ScriptApp) eval(ctx context.Context) (platform.EvaluatorResponse, time.Duration, error) {
+	start := time.Now()
+	result, err := s.evaluator.Eval(ctx)
+	duration := time.Since(start)
+	metrics.ScriptEvaluationDuration.WithLabelValues(s.evaluatorType).Observe(duration.Seconds())
+	return result, duration, err
+}
+
 // prepareScriptData prepares data for script execution, structuring it appropriately
 // for the target script's expected format based on the evaluator type
 func (s *ScriptApp) prepareScriptData(
@@ -122,14 +210,105 @@ func (s *ScriptApp) prepareScriptData(
 	// Use app static data which now includes merged route data from app creation time
 	mergedStaticData := maps.Clone(appStaticData)
 
+	// Path params captured by regex routes, always present so scripts can
+	// index it without checking for existence
+	pathParams := make(map[string]any)
+	for k, v := range apps.PathParams(r.Context()) {
+		pathParams[k] = v
+	}
+
+	// The body is read here and restored, so the request converted by the
+	// evaluator still carries it
+	body, err := apps.ReadBody(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
 	// All evaluators now use consistent namespaced structure
 	scriptData := map[string]any{
-		"data":    maps.Clone(mergedStaticData),
-		"request": r,
+		"data":        maps.Clone(mergedStaticData),
+		"request":     r,
+		"body":        string(body),
+		"query":       queryData(r.URL.Query()),
+		"path_params": pathParams,
+		"auth":        authData(apps.PrincipalFromContext(r.Context())),
+	}
+
+	// The decoded body is only present when there is one, so scripts can
+	// fall back with e.g. ctx.get("json", {})
+	if decoded := s.jsonBody(r.Header.Get("Content-Type"), body); decoded != nil {
+		scriptData["json"] = decoded
 	}
 	return scriptData, nil
 }
 
+// jsonBody decodes a request body sent with a JSON content type. It returns
+// nil for other content types, an empty body, or a body that isn't valid JSON.
+func (s *ScriptApp) jsonBody(contentType string, body []byte) any {
+	if len(body) == 0 || !isJSONContentType(contentType) {
+		return nil
+	}
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		s.logger.Debug("Request body is not valid JSON", "error", err)
+		return nil
+	}
+	return decoded
+}
+
+// isJSONContentType reports whether contentType is application/json or a
+// +json media type such as application/merge-patch+json
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// queryData converts query parameters for scripts. A key given once maps to
+// its value, and a repeated key maps to the list of its values.
+func queryData(query url.Values) map[string]any {
+	result := make(map[string]any, len(query))
+	for k, values := range query {
+		if len(values) == 1 {
+			result[k] = values[0]
+			continue
+		}
+		list := make([]any, len(values))
+		for i, v := range values {
+			list[i] = v
+		}
+		result[k] = list
+	}
+	return result
+}
+
+// authData describes the principal set by an auth middleware. It is always
+// present, with authenticated set to false for anonymous requests.
+func authData(principal *apps.Principal) map[string]any {
+	if principal == nil {
+		return map[string]any{
+			"authenticated": false,
+			"subject":       "",
+			"method":        "",
+			"claims":        map[string]any{},
+		}
+	}
+
+	claims := maps.Clone(principal.Claims)
+	if claims == nil {
+		claims = map[string]any{}
+	}
+	return map[string]any{
+		"authenticated": true,
+		"subject":       principal.Subject,
+		"method":        principal.Method,
+		"claims":        claims,
+	}
+}
+
 // This function is no longer needed since evaluators are pre-compiled
 // and passed through the Config DTO
 
@@ -139,6 +318,13 @@ func handleScriptResult(w http.ResponseWriter, result platform.EvaluatorResponse
 
 	switch v := value.(type) {
 	case map[string]any:
+		body, settings, err := splitResponse(v)
+		if err != nil {
+			return err
+		}
+		if settings != nil {
+			return writeResponse(w, body, settings)
+		}
 		w.Header().Set("Content-Type", "application/json")
 		return json.NewEncoder(w).Encode(v)
 
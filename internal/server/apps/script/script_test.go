@@ -1,15 +1,23 @@
 package script
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
 	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
 	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +43,10 @@ func createScriptConfig(t *testing.T, id string, domainConfig *scripts.AppScript
 		StaticData:        staticData,
 		Logger:            slog.Default().With("app_type", "script", "app_id", id),
 		ExecTimeout:       domainConfig.Evaluator.GetTimeout(),
+		Evaluator:         strings.ToLower(domainConfig.Evaluator.Type().String()),
+		Streaming:         domainConfig.Evaluator.Type().CanCallGoFunctions(),
+		TimeoutBody:       domainConfig.TimeoutBody,
+		ShutdownBody:      domainConfig.ShutdownBody,
 	}
 }
 
@@ -156,6 +168,31 @@ func TestScriptApp_HandleHTTP_RisorScript(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Hello from Risor!")
 }
 
+func TestScriptApp_HandleHTTP_EvaluationMetric(t *testing.T) {
+	risorEval := &evaluators.RisorEvaluator{Code: `"ok"`, Timeout: 5 * time.Second}
+	require.NoError(t, risorEval.Validate())
+
+	domainConfig := scripts.NewAppScript("metrics-app")
+	domainConfig.Evaluator = risorEval
+
+	scriptConfig := createScriptConfig(t, "metrics-app", domainConfig)
+	assert.Equal(t, "risor", scriptConfig.Evaluator)
+	// A label of its own keeps other tests' evaluations out of the count
+	scriptConfig.Evaluator = "metrics-test"
+	app, err := New(scriptConfig)
+	require.NoError(t, err)
+
+	for range 2 {
+		w := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil)))
+	}
+
+	var m dto.Metric
+	histogram := metrics.ScriptEvaluationDuration.WithLabelValues("metrics-test")
+	require.NoError(t, histogram.(prometheus.Metric).Write(&m))
+	assert.Equal(t, uint64(2), m.GetHistogram().GetSampleCount())
+}
+
 func TestScriptApp_HandleHTTP_WithStaticData(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -200,6 +237,21 @@ _ = result`,
 			method:          http.MethodPost,
 			expectedContent: "production",
 		},
+		{
+			name: "javascript_with_static_data",
+			evaluator: &evaluators.JavaScriptEvaluator{
+				Code: `
+// Access static data in JavaScript
+const region = ctx.data.region || "default";
+({ message: "JavaScript with config", region: region })`,
+				Timeout: 5 * time.Second,
+			},
+			staticData: map[string]any{
+				"region": "eu-west",
+			},
+			method:          http.MethodGet,
+			expectedContent: "eu-west",
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,6 +278,253 @@ _ = result`,
 	}
 }
 
+func TestScriptApp_HandleHTTP_PathParams(t *testing.T) {
+	risorEval := &evaluators.RisorEvaluator{
+		Code:    `{"user": ctx.get("path_params", {}).get("id", "missing")}`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, risorEval.Validate())
+
+	domainConfig := scripts.NewAppScript("path-app")
+	domainConfig.Evaluator = risorEval
+
+	app, err := New(createScriptConfig(t, "path-app", domainConfig))
+	require.NoError(t, err)
+
+	t.Run("captured params are available", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		req = req.WithContext(apps.WithPathParams(req.Context(), map[string]string{"id": "42"}))
+		w := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+		assert.JSONEq(t, `{"user": "42"}`, w.Body.String())
+	})
+
+	t.Run("path params are empty without a regex route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+		w := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+		assert.JSONEq(t, `{"user": "missing"}`, w.Body.String())
+	})
+}
+
+func TestScriptApp_HandleHTTP_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		evaluator evaluators.Evaluator
+	}{
+		{
+			name: "risor",
+			evaluator: &evaluators.RisorEvaluator{
+				Code: `let query = ctx.get("query", {})
+{"page": query.get("page", "missing"), "tag": query.get("tag", "missing"), "size": query.get("size", "missing")}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "starlark",
+			evaluator: &evaluators.StarlarkEvaluator{
+				Code: `
+query = ctx.get("query", {})
+_ = {"page": query.get("page", "missing"), "tag": query.get("tag", "missing"), "size": query.get("size", "missing")}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "javascript",
+			evaluator: &evaluators.JavaScriptEvaluator{
+				Code:    `({ page: ctx.query.page, tag: ctx.query.tag, size: ctx.query.size || "missing" })`,
+				Timeout: 5 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.evaluator.Validate())
+
+			domainConfig := scripts.NewAppScript("query-app")
+			domainConfig.Evaluator = tt.evaluator
+
+			app, err := New(createScriptConfig(t, "query-app", domainConfig))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/items?page=2&tag=a&tag=b", nil)
+			w := httptest.NewRecorder()
+
+			require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+			assert.JSONEq(t, `{"page": "2", "tag": ["a", "b"], "size": "missing"}`, w.Body.String())
+		})
+	}
+}
+
+func TestScriptApp_HandleHTTP_JSONBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		evaluator evaluators.Evaluator
+	}{
+		{
+			name: "risor",
+			evaluator: &evaluators.RisorEvaluator{
+				Code:    `{"name": ctx.get("json", {}).get("user", {}).get("name", "missing"), "raw": ctx.get("body", "")}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "starlark",
+			evaluator: &evaluators.StarlarkEvaluator{
+				Code: `
+body = ctx.get("json") or {}
+_ = {"name": body.get("user", {}).get("name", "missing"), "raw": ctx.get("body", "")}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "javascript",
+			evaluator: &evaluators.JavaScriptEvaluator{
+				Code:    `({ name: ctx.json ? ctx.json.user.name : "missing", raw: ctx.body })`,
+				Timeout: 5 * time.Second,
+			},
+		},
+	}
+
+	const payload = `{"user":{"name":"ada"}}`
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.evaluator.Validate())
+
+			domainConfig := scripts.NewAppScript("body-app")
+			domainConfig.Evaluator = tt.evaluator
+
+			app, err := New(createScriptConfig(t, "body-app", domainConfig))
+			require.NoError(t, err)
+
+			t.Run("json content type is decoded", func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(payload))
+				req.Header.Set("Content-Type", "application/json; charset=utf-8")
+				w := httptest.NewRecorder()
+
+				require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+				assert.JSONEq(t, `{"name": "ada", "raw": `+strconv.Quote(payload)+`}`, w.Body.String())
+			})
+
+			t.Run("other content types are only raw", func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(payload))
+				req.Header.Set("Content-Type", "text/plain")
+				w := httptest.NewRecorder()
+
+				require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+				assert.JSONEq(t, `{"name": "missing", "raw": `+strconv.Quote(payload)+`}`, w.Body.String())
+			})
+
+			t.Run("invalid json is only raw", func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user":`))
+				req.Header.Set("Content-Type", "application/json")
+				w := httptest.NewRecorder()
+
+				require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+				assert.JSONEq(t, `{"name": "missing", "raw": "{\"user\":"}`, w.Body.String())
+			})
+		})
+	}
+}
+
+func TestScriptApp_HandleHTTP_BodyStillInRequest(t *testing.T) {
+	risorEval := &evaluators.RisorEvaluator{
+		Code:    `{"same": ctx.get("request", {}).get("Body", "") == ctx.get("body", "")}`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, risorEval.Validate())
+
+	domainConfig := scripts.NewAppScript("body-app")
+	domainConfig.Evaluator = risorEval
+
+	app, err := New(createScriptConfig(t, "body-app", domainConfig))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user":{"name":"ada"}}`))
+	w := httptest.NewRecorder()
+
+	require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+	assert.JSONEq(t, `{"same": true}`, w.Body.String())
+}
+
+func TestScriptApp_HandleHTTP_BodyTooLarge(t *testing.T) {
+	risorEval := &evaluators.RisorEvaluator{
+		Code:    `{"body": ctx.get("body", "")}`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, risorEval.Validate())
+
+	domainConfig := scripts.NewAppScript("body-app")
+	domainConfig.Evaluator = risorEval
+
+	app, err := New(createScriptConfig(t, "body-app", domainConfig))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"user":{"name":"ada"}}`))
+	req.Body = http.MaxBytesReader(w, req.Body, 8)
+
+	err = app.HandleHTTP(req.Context(), w, req)
+	require.ErrorIs(t, err, apps.ErrBodyTooLarge)
+	assert.Empty(t, w.Body.String(), "the dispatcher writes the response")
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	assert.True(t, isJSONContentType("application/json"))
+	assert.True(t, isJSONContentType("Application/JSON; charset=utf-8"))
+	assert.True(t, isJSONContentType("application/merge-patch+json"))
+	assert.False(t, isJSONContentType("text/plain"))
+	assert.False(t, isJSONContentType(""))
+}
+
+func TestQueryData(t *testing.T) {
+	assert.Equal(t, map[string]any{}, queryData(nil))
+	assert.Equal(t,
+		map[string]any{"page": "2", "tag": []any{"a", "b"}, "empty": ""},
+		queryData(url.Values{"page": {"2"}, "tag": {"a", "b"}, "empty": {""}}),
+	)
+}
+
+func TestScriptApp_HandleHTTP_Auth(t *testing.T) {
+	risorEval := &evaluators.RisorEvaluator{
+		Code: `let a = ctx.get("auth", {})
+{"authenticated": a["authenticated"], "subject": a["subject"], "role": a["claims"].get("role", "none")}`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, risorEval.Validate())
+
+	domainConfig := scripts.NewAppScript("auth-app")
+	domainConfig.Evaluator = risorEval
+
+	app, err := New(createScriptConfig(t, "auth-app", domainConfig))
+	require.NoError(t, err)
+
+	t.Run("principal is available", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req = req.WithContext(apps.WithPrincipal(req.Context(), &apps.Principal{
+			Subject: "alice",
+			Method:  "bearer",
+			Claims:  map[string]any{"role": "admin"},
+		}))
+		w := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+		assert.JSONEq(t, `{"authenticated": true, "subject": "alice", "role": "admin"}`, w.Body.String())
+	})
+
+	t.Run("anonymous requests are not authenticated", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		w := httptest.NewRecorder()
+
+		require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+		assert.JSONEq(t, `{"authenticated": false, "subject": "", "role": "none"}`, w.Body.String())
+	})
+}
+
 func TestScriptApp_HandleHTTP_ScriptError(t *testing.T) {
 	risorEval := &evaluators.RisorEvaluator{
 		Code:    `invalid_risor_syntax(`,
@@ -268,7 +567,7 @@ func TestScriptApp_HandleHTTP_Timeout(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	err = app.HandleHTTP(t.Context(), w, req)
-	require.Error(t, err)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
 	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
 }
 
@@ -306,6 +605,151 @@ _ = result`,
 	assert.Contains(t, w.Body.String(), "python-like")
 }
 
+func TestScriptApp_HandleHTTP_JavaScriptScript(t *testing.T) {
+	jsEval := &evaluators.JavaScriptEvaluator{
+		Code: `
+// The value of the last statement is returned to Go
+const req = ctx.request;
+({
+	message: "Hello from JavaScript!",
+	method: req.Method,
+	user: ctx.path_params.id,
+})`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, jsEval.Validate())
+
+	domainConfig := scripts.NewAppScript("js-test")
+	domainConfig.Evaluator = jsEval
+
+	app, err := New(createScriptConfig(t, "js-app", domainConfig))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/7", nil)
+	req = req.WithContext(apps.WithPathParams(req.Context(), map[string]string{"id": "7"}))
+	w := httptest.NewRecorder()
+
+	require.NoError(t, app.HandleHTTP(req.Context(), w, req))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"message": "Hello from JavaScript!", "method": "POST", "user": "7"}`, w.Body.String())
+}
+
+func TestScriptApp_HandleHTTP_JavaScriptTimeout(t *testing.T) {
+	jsEval := &evaluators.JavaScriptEvaluator{
+		Code:    `while (true) {}`,
+		Timeout: 10 * time.Millisecond,
+	}
+	require.NoError(t, jsEval.Validate())
+
+	domainConfig := scripts.NewAppScript("js-loop")
+	domainConfig.Evaluator = jsEval
+
+	app, err := New(createScriptConfig(t, "js-loop", domainConfig))
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	err = app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.Error(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+}
+
+// newLoopingApp returns a JavaScript app that runs until it is interrupted
+func newLoopingApp(t *testing.T, timeout time.Duration, configure func(*scripts.AppScript)) *ScriptApp {
+	t.Helper()
+	jsEval := &evaluators.JavaScriptEvaluator{
+		Code:    `while (true) {}`,
+		Timeout: timeout,
+	}
+	require.NoError(t, jsEval.Validate())
+
+	domainConfig := scripts.NewAppScript("js-loop")
+	domainConfig.Evaluator = jsEval
+	if configure != nil {
+		configure(domainConfig)
+	}
+
+	app, err := New(createScriptConfig(t, "js-loop", domainConfig))
+	require.NoError(t, err)
+	return app
+}
+
+func TestScriptApp_HandleHTTP_Interrupted(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timeout uses the default body", func(t *testing.T) {
+		t.Parallel()
+		app := newLoopingApp(t, 10*time.Millisecond, nil)
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, DefaultTimeoutBody+"\n", w.Body.String())
+	})
+
+	t.Run("timeout uses the configured body", func(t *testing.T) {
+		t.Parallel()
+		app := newLoopingApp(t, 10*time.Millisecond, func(s *scripts.AppScript) {
+			s.TimeoutBody = "took too long"
+		})
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, "took too long\n", w.Body.String())
+	})
+
+	t.Run("client cancel writes nothing", func(t *testing.T) {
+		t.Parallel()
+		app := newLoopingApp(t, 10*time.Second, nil)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := app.HandleHTTP(ctx, w, req)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded)
+		assert.NotErrorIs(t, err, apps.ErrServerShutdown)
+		assert.Empty(t, w.Body.String())
+		assert.False(t, w.Flushed)
+	})
+
+	t.Run("shutdown during eval uses the configured body", func(t *testing.T) {
+		t.Parallel()
+		app := newLoopingApp(t, 10*time.Second, func(s *scripts.AppScript) {
+			s.ShutdownBody = "restarting, try again"
+		})
+
+		ctx, cancel := context.WithCancelCause(t.Context())
+		time.AfterFunc(20*time.Millisecond, func() { cancel(apps.ErrServerShutdown) })
+		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		err := app.HandleHTTP(ctx, w, req)
+		require.ErrorIs(t, err, apps.ErrServerShutdown)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "restarting, try again\n", w.Body.String())
+	})
+
+	t.Run("shutdown during eval uses the default body", func(t *testing.T) {
+		t.Parallel()
+		app := newLoopingApp(t, 10*time.Second, nil)
+
+		ctx, cancel := context.WithCancelCause(t.Context())
+		time.AfterFunc(20*time.Millisecond, func() { cancel(apps.ErrServerShutdown) })
+		w := httptest.NewRecorder()
+
+		err := app.HandleHTTP(ctx, w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.ErrorIs(t, err, apps.ErrServerShutdown)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, DefaultShutdownBody+"\n", w.Body.String())
+	})
+}
+
 func TestScriptApp_HandleHTTP_PrepareScriptDataError(t *testing.T) {
 	// Mock evaluator that can validate but has nil compiledEvaluator
 	mockEval := &evaluators.RisorEvaluator{
@@ -332,6 +776,21 @@ func TestScriptApp_HandleHTTP_PrepareScriptDataError(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestScriptApp_HandleHTTP_EvaluatorUnavailable(t *testing.T) {
+	// An app whose evaluator is gone can't run; the dispatcher writes the response
+	app := &ScriptApp{
+		id:          "broken-app",
+		logger:      slog.Default(),
+		execTimeout: time.Second,
+	}
+
+	w := httptest.NewRecorder()
+	err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/test", nil))
+	require.ErrorIs(t, err, apps.ErrAppUnavailable)
+	assert.Empty(t, w.Header())
+	assert.Empty(t, w.Body.String())
+}
+
 func TestScriptApp_HandleHTTP_ExtismDataStructure(t *testing.T) {
 	// Test that Extism evaluator data preparation works differently
 	// Since we don't have a real Extism WASM module easily available for testing,
@@ -420,6 +879,106 @@ func TestScriptApp_HandleHTTP_NumericResult(t *testing.T) {
 	assert.Equal(t, "42", w.Body.String())
 }
 
+func TestScriptApp_HandleHTTP_ResponseSettings(t *testing.T) {
+	tests := []struct {
+		name      string
+		evaluator evaluators.Evaluator
+	}{
+		{
+			name: "risor",
+			evaluator: &evaluators.RisorEvaluator{
+				Code: `{
+					"_response": {"status": 201, "headers": {"Location": "/items/7"}},
+					"id": 7
+				}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "starlark",
+			evaluator: &evaluators.StarlarkEvaluator{
+				Code: `
+_ = {
+	"_response": {"status": 201, "headers": {"Location": "/items/7"}},
+	"id": 7,
+}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "javascript",
+			evaluator: &evaluators.JavaScriptEvaluator{
+				Code:    `({ _response: { status: 201, headers: { Location: "/items/7" } }, id: 7 })`,
+				Timeout: 5 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.evaluator.Validate())
+
+			domainConfig := scripts.NewAppScript("test-app")
+			domainConfig.Evaluator = tt.evaluator
+
+			app, err := New(createScriptConfig(t, "test-app", domainConfig))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/items", nil)
+			w := httptest.NewRecorder()
+
+			err = app.HandleHTTP(t.Context(), w, req)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, w.Code)
+			assert.Equal(t, "/items/7", w.Header().Get("Location"))
+			assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			assert.JSONEq(t, `{"id": 7}`, w.Body.String())
+		})
+	}
+
+	t.Run("empty body is omitted", func(t *testing.T) {
+		risorEval := &evaluators.RisorEvaluator{
+			Code:    `{"_response": {"status": 204, "headers": {"X-Trace": ["a", "b"]}}}`,
+			Timeout: 5 * time.Second,
+		}
+		require.NoError(t, risorEval.Validate())
+
+		domainConfig := scripts.NewAppScript("test-app")
+		domainConfig.Evaluator = risorEval
+
+		app, err := New(createScriptConfig(t, "test-app", domainConfig))
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		err = app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodDelete, "/items/7", nil))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, []string{"a", "b"}, w.Header().Values("X-Trace"))
+		assert.Empty(t, w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		risorEval := &evaluators.RisorEvaluator{
+			Code:    `{"_response": {"status": 42}, "id": 7}`,
+			Timeout: 5 * time.Second,
+		}
+		require.NoError(t, risorEval.Validate())
+
+		domainConfig := scripts.NewAppScript("test-app")
+		domainConfig.Evaluator = risorEval
+
+		app, err := New(createScriptConfig(t, "test-app", domainConfig))
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		err = app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/test", nil))
+		require.ErrorIs(t, err, ErrInvalidResponse)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Result Processing Error")
+	})
+}
+
 func TestScriptApp_HandleHTTP_ExecutionError(t *testing.T) {
 	risorEval := &evaluators.RisorEvaluator{
 		Code: `
@@ -0,0 +1,66 @@
+package script
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+)
+
+// ErrExecTimeout is returned by RunStep when the script runs out of time
+var ErrExecTimeout = errors.New("script execution timeout")
+
+// RunStep runs the script as one step of a composite app and returns its
+// result, without writing a response. The script receives the same data as
+// from HandleHTTP, with stepData merged over its static data. A positive
+// timeout replaces the script's own timeout.
+func (s *ScriptApp) RunStep(
+	ctx context.Context,
+	r *http.Request,
+	stepData map[string]any,
+	timeout time.Duration,
+) (any, error) {
+	if s.evaluator == nil {
+		return nil, fmt.Errorf("script app %s has no evaluator", s.id)
+	}
+	if timeout <= 0 {
+		timeout = s.execTimeout
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	scriptData, err := s.prepareScriptData(timeoutCtx, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare script data: %w", err)
+	}
+	staticData, _ := scriptData["data"].(map[string]any)
+	if staticData == nil {
+		staticData = make(map[string]any, len(stepData))
+	}
+	maps.Copy(staticData, stepData)
+	scriptData["data"] = staticData
+
+	contextProvider := data.NewContextProvider(constants.EvalData)
+	enrichedCtx, err := contextProvider.AddDataToContext(timeoutCtx, scriptData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add runtime data: %w", err)
+	}
+
+	result, duration, err := s.eval(enrichedCtx)
+	if err != nil {
+		s.logger.Error("Script step failed", "error", err, "duration", duration)
+		if errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%w: %w", ErrExecTimeout, err)
+		}
+		return nil, err
+	}
+
+	s.logger.Debug("Script step executed successfully", "duration", duration)
+	return result.Interface(), nil
+}
@@ -0,0 +1,64 @@
+package script
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/staticdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptApp_RunStep(t *testing.T) {
+	jsEval := &evaluators.JavaScriptEvaluator{
+		Code: `
+({
+	greeting: ctx.data.greeting,
+	user: ctx.data.user,
+	method: ctx.request.Method,
+})`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, jsEval.Validate())
+
+	domainConfig := scripts.NewAppScript("js-step")
+	domainConfig.Evaluator = jsEval
+	domainConfig.StaticData = &staticdata.StaticData{
+		Data: map[string]any{"greeting": "hello", "user": "static"},
+	}
+
+	app, err := New(createScriptConfig(t, "js-step", domainConfig))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/chain", nil)
+	result, err := app.RunStep(t.Context(), req, map[string]any{"user": "alice"}, 0)
+	require.NoError(t, err)
+
+	// Step data is merged over the script's static data
+	assert.Equal(t, map[string]any{"greeting": "hello", "user": "alice", "method": "POST"}, result)
+}
+
+func TestScriptApp_RunStep_Timeout(t *testing.T) {
+	jsEval := &evaluators.JavaScriptEvaluator{
+		Code:    `while (true) {}`,
+		Timeout: 5 * time.Second,
+	}
+	require.NoError(t, jsEval.Validate())
+
+	domainConfig := scripts.NewAppScript("js-step")
+	domainConfig.Evaluator = jsEval
+
+	app, err := New(createScriptConfig(t, "js-step", domainConfig))
+	require.NoError(t, err)
+
+	// The step timeout replaces the script's own timeout
+	req := httptest.NewRequest(http.MethodGet, "/chain", nil)
+	start := time.Now()
+	_, err = app.RunStep(t.Context(), req, nil, 10*time.Millisecond)
+	require.ErrorIs(t, err, ErrExecTimeout)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
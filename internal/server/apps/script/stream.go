@@ -0,0 +1,146 @@
+package script
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// StreamKey is the key of the ctx object through which Risor and JavaScript
+// scripts stream a response, for example in JavaScript:
+//
+//	ctx.stream.header("Content-Type", "text/event-stream")
+//	for (const item of items) {
+//	  ctx.stream.write("data: " + JSON.stringify(item) + "\n\n")
+//	  ctx.stream.flush()
+//	}
+//
+// Once a script writes to the stream, its result is ignored. Starlark and
+// Extism scripts can't call Go functions, so they don't get the stream.
+const StreamKey = "stream"
+
+var (
+	// ErrStreamStarted is returned when a script sets the status or a header
+	// of a stream that has already been written to
+	ErrStreamStarted = errors.New("response stream already started")
+
+	// ErrStreamClosed is returned when a script writes to a stream after the
+	// request has been handled, e.g. from a goroutine
+	ErrStreamClosed = errors.New("response stream closed")
+)
+
+// responseStream writes a script's response as the script produces it. Each
+// write goes to the response writer, and flush sends what was written to the
+// client, through any middleware that buffers the response.
+type responseStream struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	status  int
+	started bool
+	closed  bool
+	size    int64
+}
+
+func newResponseStream(w http.ResponseWriter) *responseStream {
+	return &responseStream{w: w, status: http.StatusOK}
+}
+
+// functions returns the stream's methods as the script sees them
+func (s *responseStream) functions() map[string]any {
+	return map[string]any{
+		"header": s.header,
+		"status": s.setStatus,
+		"write":  s.write,
+		"flush":  s.flush,
+	}
+}
+
+// header sets a response header, before the first write
+func (s *responseStream) header(name, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	if s.started {
+		return fmt.Errorf("%w: can't set header %q", ErrStreamStarted, name)
+	}
+	s.w.Header().Set(name, value)
+	return nil
+}
+
+// setStatus sets the response status, before the first write
+func (s *responseStream) setStatus(status int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	if s.started {
+		return fmt.Errorf("%w: can't set status %d", ErrStreamStarted, status)
+	}
+	if status < 100 || status > 599 {
+		return fmt.Errorf("%w: status %d is out of range", ErrInvalidResponse, status)
+	}
+	s.status = status
+	return nil
+}
+
+// write writes a chunk of the response. The first write sends the status and
+// headers, with a plain text Content-Type unless the script set one.
+func (s *responseStream) write(chunk string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	s.start()
+	n, err := s.w.Write([]byte(chunk))
+	s.size += int64(n)
+	return err
+}
+
+// flush sends the chunks written so far to the client. A response writer that
+// can't flush is not an error; the response is sent when the script is done.
+func (s *responseStream) flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.checkOpen(); err != nil {
+		return err
+	}
+	s.start()
+	err := http.NewResponseController(s.w).Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+	return err
+}
+
+// start sends the status and headers, once
+func (s *responseStream) start() {
+	if s.started {
+		return
+	}
+	if s.w.Header().Get("Content-Type") == "" {
+		s.w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	s.w.WriteHeader(s.status)
+	s.started = true
+}
+
+func (s *responseStream) checkOpen() error {
+	if s.closed {
+		return ErrStreamClosed
+	}
+	return nil
+}
+
+// close stops further use of the stream, and reports whether the response was
+// started through it
+func (s *responseStream) close() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return s.started
+}
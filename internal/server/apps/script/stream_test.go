@@ -0,0 +1,179 @@
+package script
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStreamingApp(t *testing.T, evaluator evaluators.Evaluator) *ScriptApp {
+	t.Helper()
+	require.NoError(t, evaluator.Validate())
+
+	domainConfig := scripts.NewAppScript("stream-app")
+	domainConfig.Evaluator = evaluator
+
+	app, err := New(createScriptConfig(t, "stream-app", domainConfig))
+	require.NoError(t, err)
+	return app
+}
+
+func TestScriptApp_HandleHTTP_Stream(t *testing.T) {
+	tests := []struct {
+		name      string
+		evaluator evaluators.Evaluator
+	}{
+		{
+			name: "risor",
+			evaluator: &evaluators.RisorEvaluator{
+				Code: `
+let stream = ctx["stream"]
+stream["status"](202)
+stream["header"]("Content-Type", "text/event-stream")
+["1", "2", "3"].each(function(n) {
+	stream["write"]("data: " + n + "\n\n")
+	stream["flush"]()
+})
+{"ignored": true}`,
+				Timeout: 5 * time.Second,
+			},
+		},
+		{
+			name: "javascript",
+			evaluator: &evaluators.JavaScriptEvaluator{
+				Code: `
+ctx.stream.status(202)
+ctx.stream.header("Content-Type", "text/event-stream")
+for (const n of [1, 2, 3]) {
+  ctx.stream.write("data: " + n + "\n\n")
+  ctx.stream.flush()
+}
+({ ignored: true })`,
+				Timeout: 5 * time.Second,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newStreamingApp(t, tt.evaluator)
+
+			w := httptest.NewRecorder()
+			err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/events", nil))
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusAccepted, w.Code)
+			assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+			assert.True(t, w.Flushed)
+			assert.Equal(t, "data: 1\n\ndata: 2\n\ndata: 3\n\n", w.Body.String())
+		})
+	}
+}
+
+func TestScriptApp_HandleHTTP_StreamErrors(t *testing.T) {
+	t.Run("header after write", func(t *testing.T) {
+		app := newStreamingApp(t, &evaluators.JavaScriptEvaluator{
+			Code: `
+ctx.stream.write("started")
+ctx.stream.header("X-Late", "1")`,
+			Timeout: 5 * time.Second,
+		})
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), ErrStreamStarted.Error())
+
+		// The response that was started is left as it is
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("X-Late"))
+		assert.Equal(t, "started", w.Body.String())
+	})
+
+	t.Run("timeout after write", func(t *testing.T) {
+		app := newStreamingApp(t, &evaluators.JavaScriptEvaluator{
+			Code: `
+ctx.stream.write("partial")
+ctx.stream.flush()
+while (true) {}`,
+			Timeout: 50 * time.Millisecond,
+		})
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "partial", w.Body.String())
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		app := newStreamingApp(t, &evaluators.RisorEvaluator{
+			Code:    `ctx["stream"]["status"](42)`,
+			Timeout: 5 * time.Second,
+		})
+
+		w := httptest.NewRecorder()
+		err := app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 42 is out of range")
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestScriptApp_HandleHTTP_NoStream(t *testing.T) {
+	t.Run("result is written when the stream is unused", func(t *testing.T) {
+		app := newStreamingApp(t, &evaluators.RisorEvaluator{
+			Code:    `{"message": "buffered"}`,
+			Timeout: 5 * time.Second,
+		})
+
+		w := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil)))
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"message": "buffered"}`, w.Body.String())
+	})
+
+	t.Run("starlark scripts have no stream", func(t *testing.T) {
+		app := newStreamingApp(t, &evaluators.StarlarkEvaluator{
+			Code:    `_ = {"has_stream": "stream" in ctx}`,
+			Timeout: 5 * time.Second,
+		})
+		assert.False(t, app.streaming)
+
+		w := httptest.NewRecorder()
+		require.NoError(t, app.HandleHTTP(t.Context(), w, httptest.NewRequest(http.MethodGet, "/", nil)))
+		assert.JSONEq(t, `{"has_stream": false}`, w.Body.String())
+	})
+}
+
+func TestResponseStream(t *testing.T) {
+	t.Run("closed stream", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		stream := newResponseStream(w)
+		assert.False(t, stream.close())
+
+		require.ErrorIs(t, stream.write("late"), ErrStreamClosed)
+		require.ErrorIs(t, stream.flush(), ErrStreamClosed)
+		require.ErrorIs(t, stream.header("X-Late", "1"), ErrStreamClosed)
+		require.ErrorIs(t, stream.setStatus(http.StatusOK), ErrStreamClosed)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("writer that can't flush", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		stream := newResponseStream(struct{ http.ResponseWriter }{w})
+
+		require.NoError(t, stream.write("chunk"))
+		require.NoError(t, stream.flush())
+		assert.True(t, stream.close())
+		assert.Equal(t, int64(5), stream.size)
+		assert.Equal(t, "chunk", w.Body.String())
+	})
+}
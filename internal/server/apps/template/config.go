@@ -0,0 +1,20 @@
+package template
+
+import "html/template"
+
+// Config contains everything needed to instantiate a template app.
+// This is a Data Transfer Object (DTO) with no dependencies on domain packages.
+// All validation happens at the domain layer before creating this config.
+type Config struct {
+	// ID is the unique identifier for this app instance
+	ID string
+
+	// Templates is the parsed template set
+	Templates *template.Template
+
+	// Template is the name of the template in Templates to render
+	Template string
+
+	// StaticData is passed to the template as .Data
+	StaticData map[string]any
+}
@@ -0,0 +1,90 @@
+// Package template provides an app that renders Go html/template pages.
+package template
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+)
+
+// RequestData holds the request values available to templates as .Request,
+// e.g. {{.Request.Method}} or {{.Request.Query.Get "page"}}
+type RequestData struct {
+	Method     string
+	Path       string
+	Host       string
+	RemoteAddr string
+	Headers    http.Header
+	Query      url.Values
+	PathParams map[string]string
+}
+
+// TemplateData is the data a template is executed with
+type TemplateData struct {
+	// Data is the app's static data, merged with the route's
+	Data map[string]any
+
+	// Request holds the values of the request being answered
+	Request RequestData
+}
+
+// App renders a named template per request
+type App struct {
+	id         string
+	templates  *template.Template
+	template   string
+	staticData map[string]any
+}
+
+// New creates a new template App from a Config DTO
+func New(cfg *Config) *App {
+	return &App{
+		id:         cfg.ID,
+		templates:  cfg.Templates,
+		template:   cfg.Template,
+		staticData: cfg.StaticData,
+	}
+}
+
+// String returns the unique identifier of the application
+func (a *App) String() string {
+	return a.id
+}
+
+// HandleHTTP renders the app's template with the request's values. The page
+// is rendered completely before it's written, so a template that fails
+// midway sends no partial page.
+func (a *App) HandleHTTP(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+) error {
+	data := TemplateData{
+		Data: a.staticData,
+		Request: RequestData{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Host:       r.Host,
+			RemoteAddr: r.RemoteAddr,
+			Headers:    r.Header,
+			Query:      r.URL.Query(),
+			PathParams: apps.PathParams(r.Context()),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := a.templates.ExecuteTemplate(&buf, a.template, data); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", a.template, err)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write response: %w", err)
+	}
+	return nil
+}
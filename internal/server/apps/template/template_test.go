@@ -0,0 +1,85 @@
+package template
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTemplates(t *testing.T, sources map[string]string) *template.Template {
+	t.Helper()
+	templates := template.New("pages")
+	for name, source := range sources {
+		_, err := templates.New(name).Parse(source)
+		require.NoError(t, err)
+	}
+	return templates
+}
+
+func TestNew(t *testing.T) {
+	app := New(&Config{ID: "pages", Template: "home"})
+	require.NotNil(t, app)
+	assert.Equal(t, "pages", app.String())
+	assert.Equal(t, "home", app.template)
+}
+
+func TestApp_HandleHTTP(t *testing.T) {
+	templates := newTemplates(t, map[string]string{
+		"layout": `<title>{{.Data.title}}</title>{{block "content" .}}{{end}}`,
+		"users": `{{template "layout" .}}{{define "content"}}` +
+			`<p>{{.Request.Method}} {{.Request.Path}} {{.Request.Query.Get "q"}} ` +
+			`{{.Request.Headers.Get "X-User"}} {{index .Request.PathParams "id"}}</p>{{end}}`,
+	})
+	app := New(&Config{
+		ID:         "pages",
+		Templates:  templates,
+		Template:   "users",
+		StaticData: map[string]any{"title": "Users & Groups"},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42?q=<b>", nil)
+	req.Header.Set("X-User", "alice")
+	req = req.WithContext(apps.WithPathParams(req.Context(), map[string]string{"id": "42"}))
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, app.HandleHTTP(req.Context(), rec, req))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"<title>Users &amp; Groups</title><p>GET /users/42 &lt;b&gt; alice 42</p>",
+		rec.Body.String(),
+		"values are escaped for their HTML context")
+}
+
+func TestApp_HandleHTTP_ExecuteError(t *testing.T) {
+	templates := newTemplates(t, map[string]string{
+		"broken": `<p>before</p>{{template "missing" .}}`,
+	})
+
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "undefined nested template", template: "broken"},
+		{name: "undefined template", template: "missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := New(&Config{ID: "pages", Templates: templates, Template: tt.template})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+
+			err := app.HandleHTTP(req.Context(), rec, req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to execute template")
+			assert.Empty(t, rec.Body.String(), "no partial page is written")
+			assert.Empty(t, rec.Header().Get("Content-Type"))
+		})
+	}
+}
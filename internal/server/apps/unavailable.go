@@ -0,0 +1,37 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Unavailable stands in for an app that failed to initialize. Every request
+// it receives returns an error wrapping ErrAppUnavailable, so its routes are
+// answered with the fallback response.
+type Unavailable struct {
+	id  string
+	err error
+}
+
+// NewUnavailable returns the stand-in for the app id, which failed to
+// initialize with err
+func NewUnavailable(id string, err error) *Unavailable {
+	return &Unavailable{id: id, err: err}
+}
+
+// String returns the ID of the app
+func (u *Unavailable) String() string {
+	return u.id
+}
+
+// Err returns why the app failed to initialize
+func (u *Unavailable) Err() error {
+	return u.err
+}
+
+// HandleHTTP returns an error wrapping ErrAppUnavailable without writing a
+// response
+func (u *Unavailable) HandleHTTP(_ context.Context, _ http.ResponseWriter, _ *http.Request) error {
+	return fmt.Errorf("%w: app %s failed to initialize: %w", ErrAppUnavailable, u.id, u.err)
+}
@@ -0,0 +1,25 @@
+package apps
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnavailable(t *testing.T) {
+	cause := errors.New("spec not found")
+	app := NewUnavailable("docs", cause)
+	assert.Equal(t, "docs", app.String())
+	assert.Equal(t, cause, app.Err())
+
+	rec := httptest.NewRecorder()
+	err := app.HandleHTTP(t.Context(), rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.ErrorIs(t, err, ErrAppUnavailable)
+	require.ErrorIs(t, err, cause)
+	assert.Equal(t, "app unavailable: app docs failed to initialize: spec not found", err.Error())
+	assert.Empty(t, rec.Body.String(), "nothing is written, the HTTP layer serves the fallback")
+}
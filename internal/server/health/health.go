@@ -0,0 +1,152 @@
+// Package health reports the liveness and readiness of the server, for
+// orchestrators such as Kubernetes.
+//
+// The server is live while every component is running, or reloading a new
+// configuration. It is ready when every component is running and the current
+// configuration transaction has completed, so readiness drops while a new
+// configuration is being applied and comes back once it is live.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	txstate "github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+)
+
+// Report statuses
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Component is a server component whose state is checked, such as a
+// supervisor runnable
+type Component interface {
+	String() string
+	GetState() string
+}
+
+// transactionStorage provides the current configuration transaction
+type transactionStorage interface {
+	// GetCurrent returns the current active transaction
+	GetCurrent() *transaction.ConfigTransaction
+}
+
+// Report is the result of a health check, written as the JSON response body
+type Report struct {
+	Status     string            `json:"status"`
+	Components map[string]string `json:"components"`
+	Config     *ConfigReport     `json:"config,omitempty"`
+}
+
+// ConfigReport describes the current configuration transaction
+type ConfigReport struct {
+	TransactionID string `json:"transaction_id"`
+	State         string `json:"state"`
+}
+
+// OK reports whether the check passed
+func (r Report) OK() bool {
+	return r.Status == StatusOK
+}
+
+// Checker checks the state of the server's components and configuration
+type Checker struct {
+	txStorage  transactionStorage
+	components []Component
+}
+
+// NewChecker creates a Checker for the given components, reading the current
+// configuration from txStorage
+func NewChecker(txStorage transactionStorage, components ...Component) *Checker {
+	return &Checker{
+		txStorage:  txStorage,
+		components: components,
+	}
+}
+
+// Liveness reports whether every component is running or reloading
+func (c *Checker) Liveness() Report {
+	return c.componentReport(func(state string) bool {
+		return state == finitestate.StatusRunning || state == finitestate.StatusReloading
+	})
+}
+
+// Readiness reports whether every component is running and the current
+// configuration transaction has completed
+func (c *Checker) Readiness() Report {
+	report := c.componentReport(func(state string) bool {
+		return state == finitestate.StatusRunning
+	})
+
+	var tx *transaction.ConfigTransaction
+	if c.txStorage != nil {
+		tx = c.txStorage.GetCurrent()
+	}
+	if tx == nil {
+		report.Status = StatusUnavailable
+		return report
+	}
+
+	report.Config = &ConfigReport{TransactionID: tx.ID.String(), State: tx.GetState()}
+	if report.Config.State != txstate.StateCompleted {
+		report.Status = StatusUnavailable
+	}
+	return report
+}
+
+// componentReport lists the state of each component, and is OK when every
+// state is accepted by ok
+func (c *Checker) componentReport(ok func(state string) bool) Report {
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]string, len(c.components)),
+	}
+	for _, component := range c.components {
+		state := component.GetState()
+		report.Components[component.String()] = state
+		if !ok(state) {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+// LivenessHandler serves the liveness check
+func (c *Checker) LivenessHandler() http.Handler {
+	return reportHandler(c.Liveness)
+}
+
+// ReadinessHandler serves the readiness check
+func (c *Checker) ReadinessHandler() http.Handler {
+	return reportHandler(c.Readiness)
+}
+
+// reportHandler writes the report of check with a 200 when it passed and a
+// 503 otherwise
+func reportHandler(check func() Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		report := check()
+		status := http.StatusOK
+		if !report.OK() {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if r.Method == http.MethodHead {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
@@ -0,0 +1,162 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	txstate "github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeComponent struct {
+	name  string
+	state string
+}
+
+func (c *fakeComponent) String() string   { return c.name }
+func (c *fakeComponent) GetState() string { return c.state }
+
+type fakeStorage struct {
+	current *transaction.ConfigTransaction
+}
+
+func (s *fakeStorage) GetCurrent() *transaction.ConfigTransaction { return s.current }
+
+// newTransaction returns a transaction that has been executed and stored as
+// current, but not yet reloaded
+func newTransaction(t *testing.T) *transaction.ConfigTransaction {
+	t.Helper()
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	tx, err := transaction.FromTest(t.Name(), cfg, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, tx.BeginValidation())
+	tx.IsValid.Store(true)
+	require.NoError(t, tx.MarkValidated())
+	require.NoError(t, tx.BeginExecution())
+	require.NoError(t, tx.MarkSucceeded())
+	return tx
+}
+
+func TestChecker(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ready once the config is live", func(t *testing.T) {
+		httpRunner := &fakeComponent{name: "HTTPRunner", state: finitestate.StatusRunning}
+		storage := &fakeStorage{}
+		checker := NewChecker(storage, httpRunner)
+
+		// No config yet
+		assert.True(t, checker.Liveness().OK())
+		assert.False(t, checker.Readiness().OK())
+
+		// Reloading the first config
+		tx := newTransaction(t)
+		storage.current = tx
+		require.NoError(t, tx.BeginReload())
+		httpRunner.state = finitestate.StatusReloading
+		assert.True(t, checker.Liveness().OK())
+		report := checker.Readiness()
+		assert.False(t, report.OK())
+		require.NotNil(t, report.Config)
+		assert.Equal(t, txstate.StateReloading, report.Config.State)
+
+		// Applied
+		require.NoError(t, tx.MarkCompleted())
+		httpRunner.state = finitestate.StatusRunning
+		report = checker.Readiness()
+		assert.True(t, report.OK())
+		assert.Equal(t, tx.ID.String(), report.Config.TransactionID)
+		assert.Equal(t, map[string]string{"HTTPRunner": finitestate.StatusRunning}, report.Components)
+	})
+
+	t.Run("component not running", func(t *testing.T) {
+		tests := []struct {
+			state    string
+			wantLive bool
+		}{
+			{state: finitestate.StatusBooting, wantLive: false},
+			{state: finitestate.StatusReloading, wantLive: true},
+			{state: finitestate.StatusStopping, wantLive: false},
+			{state: finitestate.StatusError, wantLive: false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.state, func(t *testing.T) {
+				tx := newTransaction(t)
+				require.NoError(t, tx.BeginReload())
+				require.NoError(t, tx.MarkCompleted())
+				checker := NewChecker(
+					&fakeStorage{current: tx},
+					&fakeComponent{name: "txmgr", state: finitestate.StatusRunning},
+					&fakeComponent{name: "HTTPRunner", state: tt.state},
+				)
+
+				assert.Equal(t, tt.wantLive, checker.Liveness().OK())
+				assert.False(t, checker.Readiness().OK())
+			})
+		}
+	})
+
+	t.Run("failed reload is not ready", func(t *testing.T) {
+		tx := newTransaction(t)
+		require.NoError(t, tx.BeginReload())
+		require.NoError(t, tx.MarkError(assert.AnError))
+		checker := NewChecker(&fakeStorage{current: tx})
+		assert.False(t, checker.Readiness().OK())
+	})
+}
+
+func TestReportHandler(t *testing.T) {
+	t.Parallel()
+
+	component := &fakeComponent{name: "HTTPRunner", state: finitestate.StatusRunning}
+	checker := NewChecker(&fakeStorage{}, component)
+
+	t.Run("passing check", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+		var report Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, StatusOK, report.Status)
+		assert.Equal(t, finitestate.StatusRunning, report.Components["HTTPRunner"])
+	})
+
+	t.Run("failing check", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		checker.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var report Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		assert.Equal(t, StatusUnavailable, report.Status)
+		assert.Nil(t, report.Config)
+	})
+
+	t.Run("HEAD has no body", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("other methods are rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		checker.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/healthz", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD", rec.Header().Get("Allow"))
+	})
+}
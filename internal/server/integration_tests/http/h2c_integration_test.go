@@ -0,0 +1,149 @@
+//go:build integration
+
+package http_test
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	httplistener "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/h2c_listener.toml.tmpl
+var h2cListenerTemplate string
+
+// newTestClient returns a client speaking HTTP/2 with prior knowledge when h2c
+// is set, and HTTP/1.1 otherwise
+func newTestClient(t *testing.T, h2c bool) *http.Client {
+	t.Helper()
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if h2c {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+	t.Cleanup(transport.CloseIdleConnections)
+	return &http.Client{Transport: transport, Timeout: 5 * time.Second}
+}
+
+func TestH2CListener(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	port := testutil.GetRandomPort(t)
+	logFile := filepath.Join(t.TempDir(), "access.log")
+
+	tmpl, err := template.New("config").Parse(h2cListenerTemplate)
+	require.NoError(t, err)
+	var configBuffer strings.Builder
+	require.NoError(t, tmpl.Execute(&configBuffer, struct {
+		Port    int
+		LogFile string
+	}{Port: port, LogFile: logFile}))
+
+	cfg, err := config.NewConfigFromBytes([]byte(configBuffer.String()))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Validate())
+
+	saga := orchestrator.NewSagaOrchestrator(txstorage.NewMemoryStorage(), slog.Default().Handler())
+	httpRunner, err := httplistener.NewRunner()
+	require.NoError(t, err)
+	require.NoError(t, saga.RegisterParticipant(httpRunner))
+
+	runnerErrCh := make(chan error, 1)
+	go func() { runnerErrCh <- httpRunner.Run(ctx) }()
+	defer func() {
+		httpRunner.Stop()
+		select {
+		case err := <-runnerErrCh:
+			if err != nil {
+				assert.ErrorIs(t, err, context.Canceled)
+			}
+		case <-time.After(2 * time.Second):
+			t.Log("Timeout waiting for HTTP runner goroutine to complete")
+		}
+	}()
+	require.Eventually(t, httpRunner.IsReady, time.Second, 10*time.Millisecond)
+
+	tx, err := transaction.FromTest(t.Name(), cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+	require.NoError(t, saga.ProcessTransaction(ctx, tx))
+	require.Equal(t, "completed", tx.GetState())
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/echo", port)
+	get := func(client *http.Client) (*http.Response, string) {
+		resp, err := client.Get(url)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	h2Client := newTestClient(t, true)
+	require.Eventually(t, func() bool {
+		resp, err := h2Client.Get(url)
+		if err != nil {
+			return false
+		}
+		assert.NoError(t, resp.Body.Close())
+		return resp.StatusCode == http.StatusOK
+	}, 10*time.Second, 100*time.Millisecond, "Server should be ready to accept requests")
+
+	resp, body := get(h2Client)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, resp.ProtoMajor, "HTTP/2 client should negotiate h2c")
+	assert.Contains(t, body, "Echo says: Hello!")
+
+	resp, body = get(newTestClient(t, false))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, resp.ProtoMajor, "HTTP/1 clients should still be served")
+	assert.Contains(t, body, "Echo says: Hello!")
+
+	// The console logger records the protocol each request arrived with
+	var protocols []string
+	require.Eventually(t, func() bool {
+		protocols = readLoggedProtocols(t, logFile)
+		return len(protocols) >= 3
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.Contains(t, protocols, "HTTP/2.0")
+	assert.Equal(t, "HTTP/1.1", protocols[len(protocols)-1])
+}
+
+// readLoggedProtocols returns the protocol of each JSON access log entry
+func readLoggedProtocols(t *testing.T, logFile string) []string {
+	t.Helper()
+	f, err := os.Open(logFile)
+	if err != nil {
+		return nil
+	}
+	defer func() { assert.NoError(t, f.Close()) }()
+
+	var protocols []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry LogEntry
+		if err := entry.UnmarshalJSON(scanner.Bytes()); err == nil && entry.HTTP.Protocol != "" {
+			protocols = append(protocols, entry.HTTP.Protocol)
+		}
+	}
+	return protocols
+}
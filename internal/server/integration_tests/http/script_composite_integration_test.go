@@ -0,0 +1,88 @@
+//go:build integration
+
+package http_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+//go:embed testdata/script_composite.toml.tmpl
+var scriptCompositeTemplate string
+
+// CompositeScriptIntegrationTestSuite tests composite script chains via HTTP
+type CompositeScriptIntegrationTestSuite struct {
+	suite.Suite
+	scriptSuiteFields
+}
+
+func (s *CompositeScriptIntegrationTestSuite) SetupSuite() {
+	// Requests without an X-User header are rejected by the first step
+	setupScriptSuiteWithEndpoint(
+		s.T(), "script_composite", scriptCompositeTemplate, &s.scriptSuiteFields,
+		"/chain", http.StatusUnauthorized, "Server should be ready to accept requests",
+	)
+}
+
+func (s *CompositeScriptIntegrationTestSuite) TearDownSuite() {
+	teardownScriptSuite(s.T(), &s.scriptSuiteFields)
+}
+
+// get requests path with the given X-User header, and returns the status
+// and decoded JSON body
+func (s *CompositeScriptIntegrationTestSuite) get(path, user string) (int, map[string]any) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://127.0.0.1:%d%s", s.port, path), nil)
+	s.Require().NoError(err)
+	if user != "" {
+		req.Header.Set("X-User", user)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err, "Failed to make GET request")
+	defer func() { s.NoError(resp.Body.Close()) }()
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err, "Failed to read response body")
+
+	var result map[string]any
+	if resp.Header.Get("Content-Type") == "application/json" {
+		s.Require().NoError(json.Unmarshal(body, &result), "Failed to parse JSON response")
+	}
+	return resp.StatusCode, result
+}
+
+func (s *CompositeScriptIntegrationTestSuite) TestChainReturnsCombinedResult() {
+	status, result := s.get("/chain", "alice")
+
+	s.Equal(http.StatusOK, status)
+	s.Equal(map[string]any{
+		"user":     "alice",
+		"greeting": "Hello, alice!",
+		"message":  "Hello, alice!",
+		"method":   "GET",
+	}, result, "Each step's output is merged into the combined result")
+}
+
+func (s *CompositeScriptIntegrationTestSuite) TestErrorResultShortCircuits() {
+	status, result := s.get("/chain", "")
+
+	s.Equal(http.StatusUnauthorized, status, "The step's status is used")
+	s.Equal("missing X-User header", result["error"])
+	s.NotContains(result, "greeting", "Later steps don't run")
+}
+
+func (s *CompositeScriptIntegrationTestSuite) TestStepTimeout() {
+	status, _ := s.get("/slow", "alice")
+
+	s.Equal(http.StatusGatewayTimeout, status, "The step timeout replaces the script's own timeout")
+}
+
+func TestCompositeScriptIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(CompositeScriptIntegrationTestSuite))
+}
@@ -0,0 +1,134 @@
+//go:build integration
+
+package http_test
+
+import (
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/logging"
+	httplistener "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//go:embed testdata/script_extism_host_functions.toml.tmpl
+var scriptExtismHostFunctionsTemplate string
+
+// renderExtismHostFunctionsConfig renders the host functions config for a
+// module that logs and returns the "greeting" static data value, which the
+// script app writes as plain text
+func renderExtismHostFunctionsConfig(t *testing.T, port int, hostFunctions []string) string {
+	t.Helper()
+
+	module := testutil.HostFunctionModule{Key: "greeting", Log: true}
+	templateVars := struct {
+		Port          int
+		WasmBase64    string
+		Entrypoint    string
+		HostFunctions []string
+	}{
+		Port:          port,
+		WasmBase64:    base64.StdEncoding.EncodeToString(module.Bytes()),
+		Entrypoint:    testutil.HostFunctionModuleEntrypoint,
+		HostFunctions: hostFunctions,
+	}
+
+	tmpl, err := template.New("script_extism_host_functions").Parse(scriptExtismHostFunctionsTemplate)
+	require.NoError(t, err, "Failed to parse template")
+
+	var configBuffer strings.Builder
+	require.NoError(t, tmpl.Execute(&configBuffer, templateVars), "Failed to render config template")
+	return configBuffer.String()
+}
+
+func TestExtismHostFunctionsIntegration(t *testing.T) {
+	logging.SetupLogger("debug")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	port := testutil.GetRandomPort(t)
+
+	configData := renderExtismHostFunctionsConfig(t, port, []string{
+		evaluators.HostFunctionLog,
+		evaluators.HostFunctionGetStaticData,
+	})
+	cfg, err := config.NewConfigFromBytes([]byte(configData))
+	require.NoError(t, err, "Failed to load config")
+	require.NoError(t, cfg.Validate(), "Config validation failed")
+
+	saga := orchestrator.NewSagaOrchestrator(txstorage.NewMemoryStorage(), slog.Default().Handler())
+	httpRunner, err := httplistener.NewRunner()
+	require.NoError(t, err)
+	require.NoError(t, saga.RegisterParticipant(httpRunner))
+
+	runnerErrCh := make(chan error, 1)
+	go func() {
+		runnerErrCh <- httpRunner.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-runnerErrCh
+	})
+	require.Eventually(t, httpRunner.IsReady, time.Second, 10*time.Millisecond, "HTTP runner should start")
+
+	tx, err := transaction.FromTest(t.Name(), cfg, slog.Default().Handler())
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+	require.NoError(t, saga.ProcessTransaction(ctx, tx))
+	require.Equal(t, "completed", tx.GetState())
+
+	get := func(t *testing.T, path string) string {
+		t.Helper()
+		var body []byte
+		require.Eventually(t, func() bool {
+			resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", port, path))
+			if err != nil {
+				return false
+			}
+			defer func() { assert.NoError(t, resp.Body.Close()) }()
+			if resp.StatusCode != http.StatusOK {
+				return false
+			}
+			body, err = io.ReadAll(resp.Body)
+			return err == nil
+		}, 10*time.Second, 100*time.Millisecond, "Server should serve %s", path)
+		return string(body)
+	}
+
+	t.Run("app static data", func(t *testing.T) {
+		assert.Equal(t, "hello from the app", get(t, "/greeting"))
+	})
+
+	t.Run("route static data", func(t *testing.T) {
+		assert.Equal(t, "hello from the route", get(t, "/route-greeting"))
+	})
+}
+
+func TestExtismHostFunctionsIntegration_UnavailableImport(t *testing.T) {
+	// The module imports firelynx_log, which isn't enabled
+	configData := renderExtismHostFunctionsConfig(t, testutil.GetRandomPort(t), []string{
+		evaluators.HostFunctionGetStaticData,
+	})
+	cfg, err := config.NewConfigFromBytes([]byte(configData))
+	require.NoError(t, err, "Failed to load config")
+
+	err = cfg.Validate()
+	require.ErrorIs(t, err, evaluators.ErrUnavailableHostFunction)
+	assert.Contains(t, err.Error(), evaluators.HostFunctionLog)
+}
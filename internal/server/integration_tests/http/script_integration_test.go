@@ -1091,6 +1091,21 @@ _ = result`
 		}
 	})
 
+	// Serve JavaScript test script
+	mux.HandleFunc("/scripts/test.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/javascript")
+		script := `// HTTPS-loaded JavaScript script
+({
+  message: "Hello from HTTPS JavaScript!",
+  source: "https",
+  evaluator: "javascript",
+  timestamp: "generated-at-runtime",
+})`
+		if _, err := w.Write([]byte(script)); err != nil {
+			panic(err) // Test server write should not fail
+		}
+	})
+
 	// Serve WASM test module (base64 encoded)
 	mux.HandleFunc("/scripts/test.wasm", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/wasm")
@@ -0,0 +1,172 @@
+//go:build integration
+
+package http_test
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+//go:embed testdata/script_javascript_basic.toml.tmpl
+var scriptJavaScriptBasicTemplate string
+
+//go:embed testdata/script_javascript_file_uri.toml.tmpl
+var scriptJavaScriptFileURITemplate string
+
+//go:embed testdata/script_javascript_https.toml.tmpl
+var scriptJavaScriptHTTPSTemplate string
+
+// JavaScriptIntegrationTestSuite tests JavaScript script execution via HTTP
+type JavaScriptIntegrationTestSuite struct {
+	suite.Suite
+	scriptSuiteFields
+}
+
+func (s *JavaScriptIntegrationTestSuite) SetupSuite() {
+	setupScriptSuite(s.T(), "script_javascript_basic", scriptJavaScriptBasicTemplate, &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptIntegrationTestSuite) TearDownSuite() {
+	teardownScriptSuite(s.T(), &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptIntegrationTestSuite) TestJavaScriptBasicExecution() {
+	// Make a GET request to the script endpoint
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/hello", s.port))
+	s.Require().NoError(err, "Failed to make GET request")
+	defer func() { s.NoError(resp.Body.Close()) }()
+
+	// Verify status code
+	s.Equal(http.StatusOK, resp.StatusCode, "Script should return 200 OK")
+
+	// Verify content type
+	s.Equal(
+		"application/json",
+		resp.Header.Get("Content-Type"),
+		"Script should return JSON content type",
+	)
+
+	// Read and parse response body
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err, "Failed to read response body")
+
+	var scriptResp ScriptResponse
+	err = json.Unmarshal(body, &scriptResp)
+	s.Require().NoError(err, "Failed to parse JSON response")
+
+	// Verify script response content
+	s.Equal("Hello from JavaScript!", scriptResp.Message, "Script should return expected message")
+	s.NotEmpty(scriptResp.Timestamp, "Script should return timestamp")
+
+	s.T().Logf("Script response: %+v", scriptResp)
+}
+
+func (s *JavaScriptIntegrationTestSuite) TestJavaScriptPostExecution() {
+	// Make a POST request to the script endpoint
+	resp, err := http.Post(
+		fmt.Sprintf("http://127.0.0.1:%d/hello", s.port),
+		"application/json",
+		strings.NewReader(`{"name": "js"}`),
+	)
+	s.Require().NoError(err, "Failed to make POST request")
+	defer func() { s.NoError(resp.Body.Close()) }()
+
+	// Verify status code
+	s.Equal(http.StatusOK, resp.StatusCode, "Script should return 200 OK")
+
+	// Read and parse response body
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err, "Failed to read response body")
+
+	var scriptResp ScriptResponse
+	err = json.Unmarshal(body, &scriptResp)
+	s.Require().NoError(err, "Failed to parse JSON response")
+
+	// Verify script response content for POST
+	s.Equal("Hello from JavaScript!", scriptResp.Message, "Script should return expected message")
+	s.NotEmpty(scriptResp.Timestamp, "Script should return timestamp")
+
+	s.T().Logf("Script POST response: %+v", scriptResp)
+}
+
+func TestJavaScriptIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(JavaScriptIntegrationTestSuite))
+}
+
+// JavaScriptFileURIIntegrationTestSuite tests JavaScript script execution from file:// URIs
+type JavaScriptFileURIIntegrationTestSuite struct {
+	suite.Suite
+	scriptSuiteFields
+}
+
+func (s *JavaScriptFileURIIntegrationTestSuite) SetupSuite() {
+	scriptContent := `// Example JavaScript script for URI loading test
+({
+    message: "Hello from JavaScript file URI!",
+    source: "file://",
+    language: "javascript",
+})`
+	setupScriptSuiteWithFile(s.T(), "script_javascript_file_uri", scriptJavaScriptFileURITemplate, "example_script.js", scriptContent, &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptFileURIIntegrationTestSuite) TearDownSuite() {
+	teardownScriptSuite(s.T(), &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptFileURIIntegrationTestSuite) TestJavaScriptFileURIExecution() {
+	// Make a GET request to the file script endpoint
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/file-script", s.port))
+	s.Require().NoError(err, "Failed to make GET request")
+	defer func() { s.NoError(resp.Body.Close()) }()
+
+	// Verify status code
+	s.Equal(http.StatusOK, resp.StatusCode, "Script should return 200 OK")
+
+	// Read and parse response body
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err, "Failed to read response body")
+
+	var fileResp FileURIResponse
+	err = json.Unmarshal(body, &fileResp)
+	s.Require().NoError(err, "Failed to parse JSON response")
+
+	// Verify script response content
+	s.Equal("Hello from JavaScript file URI!", fileResp.Message, "Script should return expected message")
+	s.Equal("file://", fileResp.Source, "Script should indicate file source")
+	s.Equal("javascript", fileResp.Language, "Script should indicate language")
+
+	s.T().Logf("JavaScript file URI response: %+v", fileResp)
+}
+
+func TestJavaScriptFileURIIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(JavaScriptFileURIIntegrationTestSuite))
+}
+
+// JavaScriptHTTPSIntegrationTestSuite tests JavaScript script execution from HTTPS URIs
+type JavaScriptHTTPSIntegrationTestSuite struct {
+	suite.Suite
+	scriptSuiteFields
+}
+
+func (s *JavaScriptHTTPSIntegrationTestSuite) SetupSuite() {
+	setupScriptSuiteWithHTTPS(s.T(), "script_javascript_https", scriptJavaScriptHTTPSTemplate, "/scripts/test.js", &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptHTTPSIntegrationTestSuite) TearDownSuite() {
+	teardownScriptSuite(s.T(), &s.scriptSuiteFields)
+}
+
+func (s *JavaScriptHTTPSIntegrationTestSuite) TestJavaScriptHTTPSExecution() {
+	testHTTPSScriptExecution(s, s.port, "Hello from HTTPS JavaScript!", "javascript")
+}
+
+func TestJavaScriptHTTPSIntegrationSuite(t *testing.T) {
+	suite.Run(t, new(JavaScriptHTTPSIntegrationTestSuite))
+}
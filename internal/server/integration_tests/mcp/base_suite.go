@@ -34,6 +34,10 @@ type MCPIntegrationTestSuite struct {
 	runnerErrCh chan error
 	mcpClient   *mcpsdk.Client
 	mcpSession  *mcpsdk.ClientSession
+
+	// mcpPath is the path of the MCP server the suite's session connects to,
+	// "/mcp" when empty
+	mcpPath string
 }
 
 // SetupSuiteWithConfig sets up the test suite with a given configuration
@@ -112,9 +116,8 @@ func (s *MCPIntegrationTestSuite) establishMCPConnection() {
 	// Wait for the server to be fully ready
 	s.Require().Eventually(func() bool {
 		// Try to connect with MCP client to verify server is ready
-		mcpURL := fmt.Sprintf("http://127.0.0.1:%d/mcp", s.port)
 		transport := &mcpsdk.StreamableClientTransport{
-			Endpoint: mcpURL,
+			Endpoint: s.mcpURL(s.mcpPath),
 		}
 
 		// Create temporary client to test connectivity
@@ -128,16 +131,30 @@ func (s *MCPIntegrationTestSuite) establishMCPConnection() {
 	}, 10*time.Second, 100*time.Millisecond, "Server should be ready to accept MCP connections")
 
 	// Create the MCP client for tests
-	mcpURL := fmt.Sprintf("http://127.0.0.1:%d/mcp", s.port)
-	transport := &mcpsdk.StreamableClientTransport{
-		Endpoint: mcpURL,
-	}
 	s.mcpClient = mcpsdk.NewClient(&mcpsdk.Implementation{Name: "integration-test-client", Version: "1.0.0"}, nil)
 
 	// Establish the MCP session
-	var err error
-	s.mcpSession, err = s.mcpClient.Connect(s.ctx, transport, nil)
-	s.Require().NoError(err, "Failed to establish MCP session")
+	s.mcpSession = s.ConnectMCP(s.mcpPath)
+}
+
+// mcpURL returns the URL of the MCP server at path on the test listener,
+// "/mcp" when path is empty
+func (s *MCPIntegrationTestSuite) mcpURL(path string) string {
+	if path == "" {
+		path = "/mcp"
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d%s", s.port, path)
+}
+
+// ConnectMCP establishes a session with the MCP server at path on the test
+// listener, for suites serving several MCP servers. The caller closes it.
+func (s *MCPIntegrationTestSuite) ConnectMCP(path string) *mcpsdk.ClientSession {
+	transport := &mcpsdk.StreamableClientTransport{
+		Endpoint: s.mcpURL(path),
+	}
+	session, err := s.mcpClient.Connect(s.ctx, transport, nil)
+	s.Require().NoError(err, "Failed to establish MCP session with %s", path)
+	return session
 }
 
 // SetupSuiteWithTemplate sets up the test suite with a template configuration
@@ -0,0 +1,91 @@
+//go:build integration
+
+package mcp
+
+import (
+	_ "embed"
+	"testing"
+
+	mcpsdk "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/suite"
+)
+
+//go:embed testdata/multi_server.toml.tmpl
+var multiServerTemplate string
+
+// MultiServerSuite serves two MCP servers on distinct paths of one listener
+// and checks each one only exposes its own tools.
+type MultiServerSuite struct {
+	MCPIntegrationTestSuite
+	betaSession *mcpsdk.ClientSession
+}
+
+func (s *MultiServerSuite) SetupSuite() {
+	s.mcpPath = "/alpha/mcp"
+	s.SetupSuiteWithTemplate(multiServerTemplate)
+	s.betaSession = s.ConnectMCP("/beta/mcp")
+}
+
+func (s *MultiServerSuite) TearDownSuite() {
+	if s.betaSession != nil {
+		if err := s.betaSession.Close(); err != nil {
+			s.T().Logf("MCP session close error (may be expected during shutdown): %v", err)
+		}
+	}
+	s.MCPIntegrationTestSuite.TearDownSuite()
+}
+
+// toolNames lists the names of the tools of the server behind session
+func (s *MultiServerSuite) toolNames(session *mcpsdk.ClientSession) []string {
+	result, err := session.ListTools(s.GetContext(), nil)
+	s.Require().NoError(err)
+	names := make([]string, 0, len(result.Tools))
+	for _, tool := range result.Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+// callEcho calls the echo tool of the server behind session and returns its
+// text content
+func (s *MultiServerSuite) callEcho(session *mcpsdk.ClientSession) string {
+	result, err := session.CallTool(s.GetContext(), &mcpsdk.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]any{"message": "hello"},
+	})
+	s.Require().NoError(err)
+	s.Require().False(result.IsError, "tool call should not error")
+	s.Require().NotEmpty(result.Content)
+	text, ok := result.Content[0].(*mcpsdk.TextContent)
+	s.Require().True(ok, "first content should be text")
+	return text.Text
+}
+
+func (s *MultiServerSuite) TestToolsAreIsolatedPerServer() {
+	s.ElementsMatch([]string{"echo", "calculate"}, s.toolNames(s.GetMCPSession()))
+	s.ElementsMatch([]string{"echo"}, s.toolNames(s.betaSession))
+}
+
+func (s *MultiServerSuite) TestSameToolNameResolvesPerServer() {
+	s.Contains(s.callEcho(s.GetMCPSession()), "alpha: hello")
+	s.Contains(s.callEcho(s.betaSession), "beta: hello")
+}
+
+func (s *MultiServerSuite) TestServerNamesFollowAppIDs() {
+	s.Equal("alpha-mcp", s.GetMCPSession().InitializeResult().ServerInfo.Name)
+	s.Equal("beta-mcp", s.betaSession.InitializeResult().ServerInfo.Name)
+}
+
+func (s *MultiServerSuite) TestToolsOfOtherServersCannotBeCalled() {
+	result, err := s.betaSession.CallTool(s.GetContext(), &mcpsdk.CallToolParams{
+		Name:      "calculate",
+		Arguments: map[string]any{"left": 1, "right": 2, "operator": "+"},
+	})
+	if err == nil {
+		s.True(result.IsError, "calling another server's tool should fail")
+	}
+}
+
+func TestMultiServerSuite(t *testing.T) {
+	suite.Run(t, new(MultiServerSuite))
+}
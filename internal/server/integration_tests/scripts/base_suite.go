@@ -74,6 +74,12 @@ func (s *ScriptIntegrationTestSuite) ValidateEvaluator(appID string, expectedTyp
 		if ok {
 			s.Equal(expectedTimeout, starlarkEval.Timeout, "App %s should have timeout %v", appID, expectedTimeout)
 		}
+	case "javascript":
+		jsEval, ok := scriptApp.Evaluator.(*evaluators.JavaScriptEvaluator)
+		s.True(ok, "App %s should have JavaScript evaluator", appID)
+		if ok {
+			s.Equal(expectedTimeout, jsEval.Timeout, "App %s should have timeout %v", appID, expectedTimeout)
+		}
 	default:
 		s.Failf("Unsupported evaluator type: %s", expectedType)
 	}
@@ -120,6 +126,8 @@ func (s *ScriptIntegrationTestSuite) AssertScriptContains(appID string, patterns
 		scriptCode = eval.Code
 	case *evaluators.StarlarkEvaluator:
 		scriptCode = eval.Code
+	case *evaluators.JavaScriptEvaluator:
+		scriptCode = eval.Code
 	default:
 		s.Fail("Unsupported evaluator type for script code validation", "type: %T", eval)
 		return
@@ -148,6 +156,12 @@ func (s *ScriptIntegrationTestSuite) AssertDataNamespaceUsage(appID string) {
 			"App %s should use data namespace for static config", appID)
 		s.Contains(scriptCode, `ctx.get("request", {})`,
 			"App %s should access request data through ctx", appID)
+	case *evaluators.JavaScriptEvaluator:
+		scriptCode = eval.Code
+		s.Contains(scriptCode, `ctx.data`,
+			"App %s should use data namespace for static config", appID)
+		s.Contains(scriptCode, `ctx.request`,
+			"App %s should access request data through ctx", appID)
 	default:
 		s.Fail("Unsupported evaluator type for data namespace validation", "type: %T", eval)
 	}
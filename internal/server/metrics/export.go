@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Handler serves the metrics in Registry for Prometheus scraping
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// WriteText writes the metrics in Registry to w in the Prometheus text
+// format. When namePrefix is set, only metric families whose name starts
+// with it are written.
+func WriteText(w io.Writer, namePrefix string) error {
+	families, err := Registry.Gather()
+	if err != nil {
+		return fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	encoder := expfmt.NewEncoder(w, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, family := range families {
+		if !strings.HasPrefix(family.GetName(), namePrefix) {
+			continue
+		}
+		if err := encoder.Encode(family); err != nil {
+			return fmt.Errorf("failed to encode metric %s: %w", family.GetName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package metrics holds the Prometheus collectors recorded by the server.
+// Collectors are registered on Registry rather than the global default
+// registry, so only firelynx metrics are exported.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "firelynx"
+
+// Registry is the registry all firelynx collectors are registered on
+var Registry = prometheus.NewRegistry()
+
+// AppFallbackResponses counts requests answered with the app fallback
+// response because the app was unavailable, labeled by app ID.
+var AppFallbackResponses = promauto.With(Registry).NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "app_fallback_responses_total",
+		Help:      "Requests answered with the fallback response because the app was unavailable.",
+	},
+	[]string{"app"},
+)
+
+// ConcurrencyRejections counts requests answered with a 503 because their
+// listener was handling its maximum number of concurrent requests, labeled by
+// listener ID.
+var ConcurrencyRejections = promauto.With(Registry).NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "concurrency_rejections_total",
+		Help:      "Requests rejected because their listener was at its concurrent request limit.",
+	},
+	[]string{"listener"},
+)
+
+// sizeBuckets spans 64 bytes to 4 MiB in powers of four
+var sizeBuckets = prometheus.ExponentialBuckets(64, 4, 9)
+
+// AppRequestSize records request body sizes per app ID
+var AppRequestSize = promauto.With(Registry).NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "app_request_size_bytes",
+		Help:      "Size of request bodies dispatched to each app.",
+		Buckets:   sizeBuckets,
+	},
+	[]string{"app"},
+)
+
+// AppResponseSize records response body sizes per app ID
+var AppResponseSize = promauto.With(Registry).NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "app_response_size_bytes",
+		Help:      "Size of response bodies written by each app.",
+		Buckets:   sizeBuckets,
+	},
+	[]string{"app"},
+)
+
+// durationBuckets spans 0.5 milliseconds to about 16 seconds in powers of two
+var durationBuckets = prometheus.ExponentialBuckets(0.0005, 2, 16)
+
+// HTTPRequests counts requests dispatched to apps, labeled by the configured
+// ID of the matched route, the app ID and the response status code. Route IDs
+// come from the config rather than request paths, to keep the number of
+// series bounded.
+var HTTPRequests = promauto.With(Registry).NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Requests dispatched to apps, by route, app and status code.",
+	},
+	[]string{"route", "app", "code"},
+)
+
+// HTTPRequestDuration records the time apps take to handle requests, labeled
+// by route ID and app ID
+var HTTPRequestDuration = promauto.With(Registry).NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken by apps to handle requests, by route and app.",
+		Buckets:   durationBuckets,
+	},
+	[]string{"route", "app"},
+)
+
+// ScriptEvaluationDuration records the time script evaluations take, labeled
+// by evaluator type, e.g. risor
+var ScriptEvaluationDuration = promauto.With(Registry).NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "script_evaluation_duration_seconds",
+		Help:      "Time taken by script evaluations, by evaluator type.",
+		Buckets:   durationBuckets,
+	},
+	[]string{"evaluator"},
+)
@@ -1,7 +1,10 @@
 package cfgfileloader
 
 import (
+	"io"
 	"log/slog"
+	"os"
+	"time"
 )
 
 type Option func(*Runner)
@@ -19,3 +22,43 @@ func WithLogHandler(handler slog.Handler) Option {
 		r.logger = slog.New(handler)
 	}
 }
+
+// WithReloadSignals reloads the config file each time a signal is received on
+// signals, typically SIGHUP registered with signal.Notify. Unlike Reload, it
+// waits for the new config to be applied, and logs the outcome.
+func WithReloadSignals(signals <-chan os.Signal) Option {
+	return func(r *Runner) {
+		r.reloadSignals = signals
+	}
+}
+
+// WithWatch reloads the config file when it, or a local script file it
+// references, changes on disk. Changes less than debounce apart trigger one
+// reload; a non-positive debounce uses DefaultWatchDebounce. Like
+// WithReloadSignals, it waits for the new config to be applied, and logs the
+// outcome.
+func WithWatch(debounce time.Duration) Option {
+	return func(r *Runner) {
+		if debounce <= 0 {
+			debounce = DefaultWatchDebounce
+		}
+		r.watchDebounce = debounce
+	}
+}
+
+// WithStdin sets the reader the config is read from when the file path is
+// StdinPath, os.Stdin by default
+func WithStdin(stdin io.Reader) Option {
+	return func(r *Runner) {
+		r.stdin = stdin
+	}
+}
+
+// WithEnv deep-merges the overlay file for env over the config file, see
+// loader.OverlayPath. The overlay is reloaded and watched with the config
+// file. It's ignored when env is empty.
+func WithEnv(env string) Option {
+	return func(r *Runner) {
+		r.env = env
+	}
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"sync/atomic"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/loader"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	txstate "github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
 	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
 	"github.com/robbyt/go-supervisor/supervisor"
 )
@@ -20,6 +25,30 @@ var (
 	_ supervisor.Readiness  = (*Runner)(nil)
 )
 
+// reloadWaitTimeout bounds how long a signal-driven reload waits for its
+// transaction to be applied
+const reloadWaitTimeout = time.Minute
+
+// StdinPath is the file path that reads the config from standard input, once.
+// Such a config can't be reloaded or watched, since there is no file to read
+// again.
+const StdinPath = "-"
+
+var (
+	// ErrStdinWatch is returned by NewRunner when watching is enabled for a
+	// config read from standard input
+	ErrStdinWatch = errors.New("a config read from stdin can't be watched")
+
+	// ErrStdinOverlay is returned by NewRunner when an environment overlay is
+	// set for a config read from standard input, which has no file for the
+	// overlay to sit next to
+	ErrStdinOverlay = errors.New("a config read from stdin can't have an environment overlay")
+
+	// ErrStdinReload is returned by ReloadFile for a config read from
+	// standard input, which has no file to read again
+	ErrStdinReload = errors.New("a config read from stdin can't be reloaded")
+)
+
 type Runner struct {
 	filePath             string
 	lastValidTransaction atomic.Pointer[transaction.ConfigTransaction]
@@ -30,6 +59,22 @@ type Runner struct {
 	txSiphon chan<- *transaction.ConfigTransaction
 	fsm      finitestate.Machine
 	logger   *slog.Logger
+
+	// reloadSignals, when set, triggers a reload for each signal received
+	reloadSignals <-chan os.Signal
+
+	// watchDebounce, when positive, enables reloading when the watcher
+	// reports a change on fileChanges
+	watchDebounce time.Duration
+	watcher       *fileWatcher
+	fileChanges   chan struct{}
+
+	// stdin is read for the config when filePath is StdinPath
+	stdin io.Reader
+
+	// env, when set, selects the overlay file merged over the config file,
+	// see loader.OverlayPath
+	env string
 }
 
 // NewRunner creates a new Runner instance used for loading cfg files from disk
@@ -51,6 +96,7 @@ func NewRunner(
 		logger:               slog.Default().WithGroup("cfgfileloader.Runner"),
 		lastValidTransaction: atomic.Pointer[transaction.ConfigTransaction]{},
 		ctx:                  context.Background(),
+		stdin:                os.Stdin,
 	}
 
 	// Apply functional options
@@ -58,6 +104,13 @@ func NewRunner(
 		opt(runner)
 	}
 
+	if runner.readsStdin() && runner.watchDebounce > 0 {
+		return nil, ErrStdinWatch
+	}
+	if runner.readsStdin() && runner.env != "" {
+		return nil, ErrStdinOverlay
+	}
+
 	// Initialize the finite state machine
 	fsmLogger := runner.logger.WithGroup("fsm")
 	fsm, err := finitestate.New(fsmLogger.Handler())
@@ -92,15 +145,142 @@ func (r *Runner) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize configuration: %w", err)
 	}
 
+	if r.watchDebounce > 0 {
+		if err := r.startWatching(); err != nil {
+			if stateErr := r.fsm.Transition(finitestate.StatusError); stateErr != nil {
+				r.logger.Error("Failed to transition to error state", "error", stateErr)
+			}
+			return err
+		}
+	}
+
 	// Transition to running state
 	if err := r.fsm.Transition(finitestate.StatusRunning); err != nil {
 		return fmt.Errorf("failed to transition to running state: %w", err)
 	}
 
-	// block here waiting for a context cancellation
-	<-r.ctx.Done()
-	r.logger.Debug("Run context canceled")
-	return r.shutdown()
+	// block here waiting for a context cancellation, reloading on each
+	// reload signal or file change. Nil channels never receive.
+	for {
+		select {
+		case <-r.ctx.Done():
+			r.logger.Debug("Run context canceled")
+			return r.shutdown()
+		case sig := <-r.reloadSignals:
+			r.reloadAndWait(r.logger.With("signal", sig.String(), "path", r.filePath))
+		case <-r.fileChanges:
+			r.reloadAndWait(r.logger.With("trigger", "file change", "path", r.filePath))
+			r.updateWatchedFiles()
+		}
+	}
+}
+
+// startWatching starts watching the config file and the script files it
+// references for changes
+func (r *Runner) startWatching() error {
+	watcher, err := newFileWatcher(r.watchDebounce, r.logger.WithGroup("watcher"))
+	if err != nil {
+		return err
+	}
+	if err := watcher.SetFiles(watchedFiles(r.configFiles(), r.getConfig())); err != nil {
+		_ = watcher.Close() //nolint:errcheck // the watcher is being discarded
+		return err
+	}
+
+	r.watcher = watcher
+	r.fileChanges = make(chan struct{}, 1)
+	go watcher.Run(r.ctx, r.fileChanges)
+	r.logger.Info("Watching config file for changes", "path", r.filePath)
+	return nil
+}
+
+// updateWatchedFiles watches the script files referenced by the current
+// config, which a reload may have changed
+func (r *Runner) updateWatchedFiles() {
+	if err := r.watcher.SetFiles(watchedFiles(r.configFiles(), r.getConfig())); err != nil {
+		r.logger.Warn("Failed to update watched files", "error", err)
+	}
+}
+
+// reloadAndWait reloads the config file, and waits for the transaction
+// manager to apply it. Failures are logged, and leave the running config in
+// place.
+func (r *Runner) reloadAndWait(logger *slog.Logger) {
+	if r.readsStdin() {
+		logger.Warn("Config was read from stdin, ignoring reload")
+		return
+	}
+	logger.Info("Reloading config file")
+
+	previous := r.lastValidTransaction.Load()
+	tx, err := r.reload(r.ctx)
+	if err != nil {
+		logger.Error("Config reload failed, keeping the running config", "error", err)
+		return
+	}
+	if tx == nil {
+		logger.Info("Config file unchanged, nothing to reload")
+		return
+	}
+
+	r.awaitReload(logger, tx, previous)
+}
+
+// awaitReload waits for the transaction manager to apply the reloaded config
+// and logs the outcome. When tx isn't applied, previous is restored as the
+// last good config, so reloading the same file again isn't skipped as
+// unchanged.
+func (r *Runner) awaitReload(
+	logger *slog.Logger,
+	tx, previous *transaction.ConfigTransaction,
+) {
+	waitCtx, cancel := context.WithTimeout(r.ctx, reloadWaitTimeout)
+	defer cancel()
+	if err := tx.WaitForCompletion(waitCtx); err != nil {
+		logger.Warn("Stopped waiting for the config reload", "id", tx.ID, "error", err)
+		return
+	}
+
+	if state := tx.GetState(); state != txstate.StateCompleted {
+		// The running config wasn't replaced, so reloading the same file
+		// again must not be skipped as unchanged
+		r.lastValidTransaction.CompareAndSwap(tx, previous)
+		logger.Error("Config reload was not applied, keeping the last good config",
+			"id", tx.ID,
+			"state", state,
+			"participantErrors", tx.GetParticipantErrors())
+		return
+	}
+
+	logger.Info("Config reloaded", "id", tx.ID, "duration", tx.GetTotalDuration())
+}
+
+// ReloadFile re-reads and validates the config file, and sends a transaction
+// for it to the siphon. It returns the transaction, or nil when the config is
+// unchanged. Unlike a signal reload, it doesn't wait for the transaction to
+// be applied; the outcome is logged, and a failed transaction restores the
+// last good config, in the background.
+func (r *Runner) ReloadFile(ctx context.Context) (*transaction.ConfigTransaction, error) {
+	if r.readsStdin() {
+		return nil, ErrStdinReload
+	}
+
+	logger := r.logger.With("trigger", "request", "path", r.filePath)
+	logger.Info("Reloading config file")
+
+	previous := r.lastValidTransaction.Load()
+	tx, err := r.reload(ctx)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	go r.awaitReload(logger, tx, previous)
+	return tx, nil
+}
+
+// FilePath returns the path of the config file
+func (r *Runner) FilePath() string {
+	return r.filePath
 }
 
 // boot loads the initial configuration from disk
@@ -137,14 +317,41 @@ func (r *Runner) boot() error {
 	return nil
 }
 
-// loadConfigFromDisk loads the configuration from disk. It does not validate the config.
+// loadConfigFromDisk loads the configuration from disk, or from stdin when
+// the file path is StdinPath. It does not validate the config.
 func (r *Runner) loadConfigFromDisk() (*config.Config, error) {
-	return config.NewConfig(r.filePath)
+	if r.readsStdin() {
+		data, err := io.ReadAll(r.stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config from stdin: %w", err)
+		}
+		return config.NewConfigFromBytes(data)
+	}
+	return config.NewConfigForEnv(r.filePath, r.env)
+}
+
+// configFiles returns the config file, and its overlay when an env is set
+func (r *Runner) configFiles() []string {
+	if r.env == "" {
+		return []string{r.filePath}
+	}
+	return []string{r.filePath, loader.OverlayPath(r.filePath, r.env)}
+}
+
+// readsStdin reports whether the config is read from stdin rather than a file
+func (r *Runner) readsStdin() bool {
+	return r.filePath == StdinPath
 }
 
 // validate validates the domain config and returns a config transaction, ready for future processing.
 func (r *Runner) validate(cfg *config.Config) (*transaction.ConfigTransaction, error) {
-	tx, err := transaction.FromFile(r.filePath, cfg, r.logger.Handler())
+	var tx *transaction.ConfigTransaction
+	var err error
+	if r.readsStdin() {
+		tx, err = transaction.FromStdin(cfg, r.logger.Handler())
+	} else {
+		tx, err = transaction.FromFile(r.filePath, cfg, r.logger.Handler())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
@@ -173,6 +380,12 @@ func (r *Runner) shutdown() error {
 		// Continue with shutdown despite the state transition error
 	}
 
+	if r.watcher != nil {
+		if err := r.watcher.Close(); err != nil {
+			logger.Warn("Failed to close file watcher", "error", err)
+		}
+	}
+
 	// Clear the last loaded config
 	r.lastValidTransaction.Store(nil)
 
@@ -187,28 +400,39 @@ func (r *Runner) shutdown() error {
 
 // Reload implements the supervisor.Reloadable interface
 func (r *Runner) Reload(ctx context.Context) error {
+	_, err := r.reload(ctx)
+	return err
+}
+
+// reload loads the config file and sends a transaction for it to the siphon.
+// The transaction is nil when the config is unchanged.
+func (r *Runner) reload(ctx context.Context) (*transaction.ConfigTransaction, error) {
 	r.logger.Debug("Starting Reload...")
 	defer r.logger.Debug("Reload completed")
 
 	if r.filePath == "" {
 		r.logger.Warn("No config path set, skipping reload")
-		return nil
+		return nil, nil
+	}
+	if r.readsStdin() {
+		r.logger.Warn("Config was read from stdin, skipping reload")
+		return nil, nil
 	}
 
 	newCfg, err := r.loadConfigFromDisk()
 	if err != nil {
-		return fmt.Errorf("loading config: %w", err)
+		return nil, fmt.Errorf("loading config: %w", err)
 	}
 
 	oldCfg := r.getConfig()
 	if oldCfg != nil && oldCfg.Equals(newCfg) {
 		r.logger.Debug("Config unchanged, skipping broadcast")
-		return nil
+		return nil, nil
 	}
 
 	tx, err := r.validate(newCfg)
 	if err != nil {
-		return fmt.Errorf("validating config: %w", err)
+		return nil, fmt.Errorf("validating config: %w", err)
 	}
 
 	r.lastValidTransaction.Store(tx)
@@ -216,11 +440,11 @@ func (r *Runner) Reload(ctx context.Context) error {
 	select {
 	case r.txSiphon <- tx:
 		r.logger.Debug("Config changed, transaction sent to siphon", "id", tx.ID)
-		return nil
+		return tx, nil
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, ctx.Err()
 	case <-r.ctx.Done():
-		return r.ctx.Err()
+		return nil, r.ctx.Err()
 	}
 }
 
@@ -1,15 +1,19 @@
 package cfgfileloader
 
 import (
+	"bytes"
 	"context"
 	_ "embed"
+	"errors"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 	"testing/synctest"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/loader"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
 	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
 	"github.com/stretchr/testify/assert"
@@ -368,6 +372,327 @@ path_prefix = "/test"
 	})
 }
 
+// completeTransaction walks tx through the saga states the transaction
+// manager would, up to completed
+func completeTransaction(t *testing.T, tx *transaction.ConfigTransaction) {
+	t.Helper()
+	require.NoError(t, tx.BeginExecution())
+	require.NoError(t, tx.MarkSucceeded())
+	require.NoError(t, tx.BeginReload())
+	require.NoError(t, tx.MarkCompleted())
+}
+
+func TestRunner_ReloadSignals(t *testing.T) {
+	t.Parallel()
+
+	// start runs the runner with a reload signal channel, and completes the
+	// initial transaction
+	start := func(t *testing.T) (*testHarness, string, chan os.Signal) {
+		t.Helper()
+		configPath := filepath.Join(t.TempDir(), validConfigFilename)
+		require.NoError(t, os.WriteFile(configPath, validConfigTOML, 0o644))
+
+		signals := make(chan os.Signal, 1)
+		h := newTestHarness(t, configPath, WithReloadSignals(signals))
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.runner.Run(h.ctx)
+		}()
+		t.Cleanup(func() {
+			h.cancel()
+			select {
+			case err := <-errCh:
+				assert.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Error("Runner did not complete within timeout")
+			}
+		})
+
+		completeTransaction(t, h.receiveTransaction())
+		require.Eventually(t, func() bool {
+			return h.runner.GetState() == finitestate.StatusRunning
+		}, time.Second, 10*time.Millisecond)
+		return h, configPath, signals
+	}
+
+	t.Run("signal reloads a changed file", func(t *testing.T) {
+		h, configPath, signals := start(t)
+		initial := h.runner.getConfig()
+
+		require.NoError(t, os.WriteFile(configPath, updatedConfigTOML, 0o644))
+		signals <- syscall.SIGHUP
+
+		tx := h.receiveTransaction()
+		assert.Equal(t, transaction.SourceFile, tx.Source)
+		completeTransaction(t, tx)
+
+		assert.Same(t, tx.GetConfig(), h.runner.getConfig())
+		assert.NotSame(t, initial, h.runner.getConfig())
+	})
+
+	t.Run("unchanged file sends no transaction", func(t *testing.T) {
+		h, _, signals := start(t)
+
+		signals <- syscall.SIGHUP
+		signals <- syscall.SIGHUP // handled after the first reload
+
+		assert.Never(t, func() bool { return len(h.txSiphon) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+		assert.True(t, h.runner.IsReady())
+	})
+
+	t.Run("invalid file keeps the running config", func(t *testing.T) {
+		h, configPath, signals := start(t)
+		initial := h.runner.getConfig()
+
+		require.NoError(t, os.WriteFile(configPath, invalidConfigTOML, 0o644))
+		signals <- syscall.SIGHUP
+		signals <- syscall.SIGHUP
+
+		assert.Never(t, func() bool { return len(h.txSiphon) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+		assert.True(t, h.runner.IsReady())
+		assert.Same(t, initial, h.runner.getConfig())
+	})
+
+	t.Run("failed transaction restores the last good config", func(t *testing.T) {
+		h, configPath, signals := start(t)
+		initial := h.runner.getConfig()
+
+		require.NoError(t, os.WriteFile(configPath, updatedConfigTOML, 0o644))
+		signals <- syscall.SIGHUP
+
+		tx := h.receiveTransaction()
+		require.NoError(t, tx.MarkError(errors.New("participant failed")))
+
+		require.Eventually(t, func() bool {
+			return h.runner.getConfig() == initial
+		}, time.Second, 10*time.Millisecond)
+		assert.True(t, h.runner.IsReady())
+
+		// The same file is retried rather than skipped as unchanged
+		signals <- syscall.SIGHUP
+		retry := h.receiveTransaction()
+		assert.NotEqual(t, tx.ID, retry.ID)
+		completeTransaction(t, retry)
+	})
+}
+
+func TestRunner_ReloadFile(t *testing.T) {
+	t.Parallel()
+
+	// start runs the runner and completes the initial transaction
+	start := func(t *testing.T) (*testHarness, string) {
+		t.Helper()
+		configPath := filepath.Join(t.TempDir(), validConfigFilename)
+		require.NoError(t, os.WriteFile(configPath, validConfigTOML, 0o644))
+
+		h := newTestHarness(t, configPath)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.runner.Run(h.ctx)
+		}()
+		t.Cleanup(func() {
+			h.cancel()
+			select {
+			case err := <-errCh:
+				assert.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Error("Runner did not complete within timeout")
+			}
+		})
+
+		completeTransaction(t, h.receiveTransaction())
+		require.Eventually(t, func() bool {
+			return h.runner.GetState() == finitestate.StatusRunning
+		}, time.Second, 10*time.Millisecond)
+		return h, configPath
+	}
+
+	t.Run("returns the transaction of a changed file", func(t *testing.T) {
+		h, configPath := start(t)
+		assert.Equal(t, configPath, h.runner.FilePath())
+
+		require.NoError(t, os.WriteFile(configPath, updatedConfigTOML, 0o644))
+		tx, err := h.runner.ReloadFile(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		assert.Equal(t, transaction.SourceFile, tx.Source)
+		assert.Equal(t, configPath, tx.SourceDetail)
+
+		sent := h.receiveTransaction()
+		assert.Equal(t, tx.ID, sent.ID)
+		completeTransaction(t, sent)
+		assert.Same(t, tx.GetConfig(), h.runner.getConfig())
+	})
+
+	t.Run("unchanged file returns no transaction", func(t *testing.T) {
+		h, _ := start(t)
+
+		tx, err := h.runner.ReloadFile(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+		assert.Empty(t, h.txSiphon)
+	})
+
+	t.Run("invalid file returns the error", func(t *testing.T) {
+		h, configPath := start(t)
+		initial := h.runner.getConfig()
+
+		require.NoError(t, os.WriteFile(configPath, invalidConfigTOML, 0o644))
+		tx, err := h.runner.ReloadFile(t.Context())
+		require.Error(t, err)
+		assert.Nil(t, tx)
+		assert.Empty(t, h.txSiphon)
+		assert.Same(t, initial, h.runner.getConfig())
+	})
+
+	t.Run("failed transaction restores the last good config", func(t *testing.T) {
+		h, configPath := start(t)
+		initial := h.runner.getConfig()
+
+		require.NoError(t, os.WriteFile(configPath, updatedConfigTOML, 0o644))
+		tx, err := h.runner.ReloadFile(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		require.NoError(t, h.receiveTransaction().MarkError(errors.New("participant failed")))
+
+		require.Eventually(t, func() bool {
+			return h.runner.getConfig() == initial
+		}, time.Second, 10*time.Millisecond)
+
+		// The same file is retried rather than skipped as unchanged
+		retry, err := h.runner.ReloadFile(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, retry)
+		assert.NotEqual(t, tx.ID, retry.ID)
+		completeTransaction(t, h.receiveTransaction())
+	})
+}
+
+func TestRunner_Stdin(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reads the config once", func(t *testing.T) {
+		signals := make(chan os.Signal, 1)
+		h := newTestHarness(t, StdinPath,
+			WithStdin(bytes.NewReader(validConfigTOML)),
+			WithReloadSignals(signals),
+		)
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.runner.Run(h.ctx)
+		}()
+
+		tx := h.receiveTransaction()
+		assert.Equal(t, transaction.SourceFile, tx.Source)
+		assert.Equal(t, transaction.StdinSourceDetail, tx.SourceDetail)
+		completeTransaction(t, tx)
+		require.Eventually(t, func() bool {
+			return h.runner.GetState() == finitestate.StatusRunning
+		}, time.Second, 10*time.Millisecond)
+
+		// Reloads are skipped, stdin has been read to the end
+		signals <- syscall.SIGHUP
+		reloaded, err := h.runner.reload(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, reloaded)
+		reloaded, err = h.runner.ReloadFile(t.Context())
+		require.ErrorIs(t, err, ErrStdinReload)
+		assert.Nil(t, reloaded)
+		assert.Never(t, func() bool { return len(h.txSiphon) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+		assert.Same(t, tx.GetConfig(), h.runner.getConfig())
+
+		h.cancel()
+		select {
+		case err := <-errCh:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("Runner did not complete within timeout")
+		}
+	})
+
+	t.Run("invalid config fails to boot", func(t *testing.T) {
+		h := newTestHarness(t, StdinPath, WithStdin(bytes.NewReader(invalidConfigTOML)))
+		defer h.cancel()
+
+		err := h.runner.Run(h.ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to initialize configuration")
+	})
+
+	t.Run("can't be watched", func(t *testing.T) {
+		txSiphon := make(chan *transaction.ConfigTransaction, 1)
+		_, err := NewRunner(StdinPath, txSiphon, WithWatch(0))
+		require.ErrorIs(t, err, ErrStdinWatch)
+	})
+}
+
+func TestRunner_Env(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merges the overlay and reloads it", func(t *testing.T) {
+		configPath := filepath.Join(t.TempDir(), validConfigFilename)
+		require.NoError(t, os.WriteFile(configPath, validConfigTOML, 0o644))
+		overlayPath := loader.OverlayPath(configPath, "staging")
+		require.NoError(t, os.WriteFile(overlayPath, []byte(`
+[[listeners]]
+id = "test"
+address = ":8181"
+`), 0o644))
+
+		h := newTestHarness(t, configPath, WithEnv("staging"))
+		assert.Equal(t, []string{configPath, overlayPath}, h.runner.configFiles())
+
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.runner.Run(h.ctx)
+		}()
+		defer func() {
+			h.cancel()
+			select {
+			case err := <-errCh:
+				assert.NoError(t, err)
+			case <-time.After(time.Second):
+				t.Error("Runner did not complete within timeout")
+			}
+		}()
+
+		completeTransaction(t, h.receiveTransaction())
+		require.Eventually(t, func() bool {
+			return h.runner.GetState() == finitestate.StatusRunning
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, ":8181", h.runner.getConfig().Listeners[0].Address)
+
+		// A change to the overlay alone is reloaded
+		require.NoError(t, os.WriteFile(overlayPath, []byte(`
+[[listeners]]
+id = "test"
+address = ":8282"
+`), 0o644))
+		tx, err := h.runner.reload(t.Context())
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		assert.Equal(t, ":8282", tx.GetConfig().Listeners[0].Address)
+		completeTransaction(t, tx)
+	})
+
+	t.Run("missing overlay fails to boot", func(t *testing.T) {
+		h := newTestHarness(t, "", WithEnv("missing"))
+		defer h.cancel()
+
+		err := h.runner.Run(h.ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "valid_config.missing.toml")
+	})
+
+	t.Run("not with stdin", func(t *testing.T) {
+		txSiphon := make(chan *transaction.ConfigTransaction, 1)
+		_, err := NewRunner(StdinPath, txSiphon, WithEnv("prod"))
+		require.ErrorIs(t, err, ErrStdinOverlay)
+	})
+}
+
 func TestRunner_GetConfig(t *testing.T) {
 	t.Parallel()
 	t.Run("returns nil when no config loaded", func(t *testing.T) {
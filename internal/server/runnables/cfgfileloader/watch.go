@@ -0,0 +1,158 @@
+package cfgfileloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultWatchDebounce is how long the config file must stay unchanged before
+// a watched change is reloaded
+const DefaultWatchDebounce = 250 * time.Millisecond
+
+// fileWatcher reports changes to a set of files. It watches their parent
+// directories rather than the files, so that a file replaced by a rename, as
+// many editors do on save, is still seen.
+type fileWatcher struct {
+	watcher  *fsnotify.Watcher
+	debounce time.Duration
+	logger   *slog.Logger
+
+	mu    sync.Mutex
+	files map[string]struct{}
+	dirs  map[string]struct{}
+}
+
+// newFileWatcher creates a watcher that waits for debounce without further
+// changes before reporting them
+func newFileWatcher(debounce time.Duration, logger *slog.Logger) (*fileWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	return &fileWatcher{
+		watcher:  watcher,
+		debounce: debounce,
+		logger:   logger,
+		files:    make(map[string]struct{}),
+		dirs:     make(map[string]struct{}),
+	}, nil
+}
+
+// SetFiles replaces the watched files with paths
+func (w *fileWatcher) SetFiles(paths []string) error {
+	files := make(map[string]struct{}, len(paths))
+	dirs := make(map[string]struct{})
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		files[path] = struct{}{}
+		dirs[filepath.Dir(path)] = struct{}{}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var errs []error
+	for dir := range dirs {
+		if _, ok := w.dirs[dir]; ok {
+			continue
+		}
+		if err := w.watcher.Add(dir); err != nil {
+			errs = append(errs, fmt.Errorf("failed to watch %s: %w", dir, err))
+			delete(dirs, dir)
+		}
+	}
+	for dir := range w.dirs {
+		if _, ok := dirs[dir]; !ok {
+			// The directory may already be gone, which removes the watch
+			_ = w.watcher.Remove(dir) //nolint:errcheck
+		}
+	}
+
+	w.files = files
+	w.dirs = dirs
+	return errors.Join(errs...)
+}
+
+// watches reports whether path is one of the watched files
+func (w *fileWatcher) watches(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, ok := w.files[filepath.Clean(path)]
+	return ok
+}
+
+// Run sends on changed once the watched files stop changing, until ctx is
+// canceled. A send is skipped while the previous one is still pending.
+func (w *fileWatcher) Run(ctx context.Context, changed chan<- struct{}) {
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if !w.watches(event.Name) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			w.logger.Debug("Watched file changed", "path", event.Name, "op", event.Op.String())
+			timer.Reset(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.logger.Warn("File watcher error", "error", err)
+		case <-timer.C:
+			select {
+			case changed <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// Close stops watching all files
+func (w *fileWatcher) Close() error {
+	return w.watcher.Close()
+}
+
+// watchedFiles returns the config files, the base file and its overlay if
+// any, and the local script files referenced by cfg
+func watchedFiles(configFiles []string, cfg *config.Config) []string {
+	files := make([]string, 0, len(configFiles))
+	for _, filePath := range configFiles {
+		if absPath, err := filepath.Abs(filePath); err == nil {
+			filePath = absPath
+		}
+		files = append(files, filePath)
+	}
+	if cfg == nil || cfg.Apps == nil {
+		return files
+	}
+
+	for app := range cfg.Apps.All() {
+		script, ok := app.Config.(*scripts.AppScript)
+		if !ok || script.Evaluator == nil {
+			continue
+		}
+		if path, ok := evaluators.LocalPath(script.Evaluator); ok && !slices.Contains(files, path) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
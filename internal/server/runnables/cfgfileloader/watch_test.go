@@ -0,0 +1,185 @@
+package cfgfileloader
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startWatcher watches files and returns the channel it reports changes on
+func startWatcher(t *testing.T, files ...string) (*fileWatcher, chan struct{}) {
+	t.Helper()
+	watcher, err := newFileWatcher(50*time.Millisecond, slog.Default())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, watcher.Close()) })
+	require.NoError(t, watcher.SetFiles(files))
+
+	changed := make(chan struct{}, 1)
+	go watcher.Run(t.Context(), changed)
+	return watcher, changed
+}
+
+func requireChange(t *testing.T, changed <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timeout waiting for a file change")
+	}
+}
+
+func requireNoChange(t *testing.T, changed <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-changed:
+		t.Fatal("unexpected file change")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestFileWatcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rapid writes are reported once", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.toml")
+		require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+		_, changed := startWatcher(t, path)
+
+		for i := range 5 {
+			require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("b", i+1)), 0o644))
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		requireChange(t, changed)
+		requireNoChange(t, changed)
+	})
+
+	t.Run("rename over the file is reported", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+		_, changed := startWatcher(t, path)
+
+		tmp := filepath.Join(dir, ".config.toml.swp")
+		require.NoError(t, os.WriteFile(tmp, []byte("b"), 0o644))
+		require.NoError(t, os.Rename(tmp, path))
+		requireChange(t, changed)
+
+		// The replaced file is still watched
+		require.NoError(t, os.WriteFile(path, []byte("c"), 0o644))
+		requireChange(t, changed)
+	})
+
+	t.Run("other files in the directory are ignored", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "config.toml")
+		require.NoError(t, os.WriteFile(path, []byte("a"), 0o644))
+		_, changed := startWatcher(t, path)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "other.toml"), []byte("b"), 0o644))
+		requireNoChange(t, changed)
+	})
+
+	t.Run("set files replaces the watched files", func(t *testing.T) {
+		first := filepath.Join(t.TempDir(), "config.toml")
+		second := filepath.Join(t.TempDir(), "script.risor")
+		require.NoError(t, os.WriteFile(first, []byte("a"), 0o644))
+		require.NoError(t, os.WriteFile(second, []byte("a"), 0o644))
+		watcher, changed := startWatcher(t, first)
+
+		require.NoError(t, watcher.SetFiles([]string{second}))
+		require.NoError(t, os.WriteFile(first, []byte("b"), 0o644))
+		requireNoChange(t, changed)
+
+		require.NoError(t, os.WriteFile(second, []byte("b"), 0o644))
+		requireChange(t, changed)
+	})
+
+	t.Run("missing directory is an error", func(t *testing.T) {
+		watcher, err := newFileWatcher(time.Millisecond, slog.Default())
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, watcher.Close()) }()
+
+		err = watcher.SetFiles([]string{"/does/not/exist/config.toml"})
+		require.ErrorContains(t, err, "failed to watch /does/not/exist")
+	})
+}
+
+func TestWatchedFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	scriptPath := filepath.Join(dir, "hello.risor")
+	require.NoError(t, os.WriteFile(scriptPath, []byte(`{"message": "hello"}`), 0o644))
+
+	cfg, err := config.NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[apps]]
+id = "from-file"
+type = "script"
+[apps.script.risor]
+uri = "file://` + scriptPath + `"
+
+[[apps]]
+id = "inline"
+type = "script"
+[apps.script.risor]
+code = '{"message": "inline"}'
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{configPath}, watchedFiles([]string{configPath}, nil))
+	assert.Equal(t, []string{configPath, scriptPath}, watchedFiles([]string{configPath}, cfg))
+}
+
+func TestRunner_Watch(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), validConfigFilename)
+	require.NoError(t, os.WriteFile(configPath, validConfigTOML, 0o644))
+
+	h := newTestHarness(t, configPath, WithWatch(50*time.Millisecond))
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.runner.Run(h.ctx)
+	}()
+	defer func() {
+		h.cancel()
+		select {
+		case err := <-errCh:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Error("Runner did not complete within timeout")
+		}
+	}()
+
+	completeTransaction(t, h.receiveTransaction())
+	require.Eventually(t, func() bool {
+		return h.runner.GetState() == finitestate.StatusRunning
+	}, time.Second, 10*time.Millisecond)
+	initial := h.runner.getConfig()
+
+	// A transiently invalid file is skipped, keeping the running config
+	require.NoError(t, os.WriteFile(configPath, invalidConfigTOML, 0o644))
+	assert.Never(t, func() bool { return len(h.txSiphon) > 0 }, 300*time.Millisecond, 10*time.Millisecond)
+	assert.True(t, h.runner.IsReady())
+	assert.Same(t, initial, h.runner.getConfig())
+
+	// The next valid file is reloaded
+	require.NoError(t, os.WriteFile(configPath, updatedConfigTOML, 0o644))
+	tx := h.receiveTransaction()
+	completeTransaction(t, tx)
+	require.Eventually(t, func() bool {
+		return h.runner.getConfig() == tx.GetConfig()
+	}, time.Second, 10*time.Millisecond)
+}
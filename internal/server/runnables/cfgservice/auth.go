@@ -0,0 +1,160 @@
+package cfgservice
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// bearerPrefix is the scheme of the authorization metadata value
+const bearerPrefix = "Bearer "
+
+// tokenSubmitter identifies callers authenticated with the bearer token, which
+// is shared and so names no one in particular
+const tokenSubmitter = "bearer token"
+
+// submitterKey is the context key of the identity of an authenticated caller
+type submitterKey struct{}
+
+// submitterFromContext returns the identity the authentication interceptor
+// stored in ctx, empty when the call wasn't authenticated
+func submitterFromContext(ctx context.Context) string {
+	submitter, _ := ctx.Value(submitterKey{}).(string)
+	return submitter
+}
+
+// NewTLSConfig loads the server certificate and key for the gRPC listener.
+// When clientCAFile is set, client certificates signed by one of its CAs are
+// verified, and calls without a verified client certificate are rejected.
+func NewTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both a TLS certificate and key are required")
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+		}
+		// Certificates that are given are verified during the handshake; the
+		// interceptor rejects calls without one, so they fail with
+		// Unauthenticated rather than a transport error
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return cfg, nil
+}
+
+// serverOptions returns the gRPC server options installing the TLS
+// credentials and the authentication interceptors
+func (r *Runner) serverOptions() []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if r.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(r.tlsConfig)))
+	}
+	if r.requiresAuth() {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(r.authUnaryInterceptor),
+			grpc.ChainStreamInterceptor(r.authStreamInterceptor),
+		)
+	}
+	return opts
+}
+
+// requiresAuth reports whether calls must present a client certificate or a
+// bearer token
+func (r *Runner) requiresAuth() bool {
+	return r.authToken != "" || (r.tlsConfig != nil && r.tlsConfig.ClientCAs != nil)
+}
+
+func (r *Runner) authUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	submitter, err := r.authenticate(ctx)
+	if err != nil {
+		r.logger.Warn("Rejected unauthenticated call", "method", info.FullMethod, "error", err)
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, submitterKey{}, submitter), req)
+}
+
+func (r *Runner) authStreamInterceptor(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if _, err := r.authenticate(ss.Context()); err != nil {
+		r.logger.Warn("Rejected unauthenticated call", "method", info.FullMethod, "error", err)
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authenticate checks the client certificate when a client CA is configured,
+// and the bearer token when a token is configured, and returns the identity
+// of the caller: the subject of its certificate, or tokenSubmitter. Failures
+// return an Unauthenticated status.
+func (r *Runner) authenticate(ctx context.Context) (string, error) {
+	var submitter string
+
+	if r.tlsConfig != nil && r.tlsConfig.ClientCAs != nil {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return "", status.Error(codes.Unauthenticated, "client certificate required")
+		}
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+			return "", status.Error(codes.Unauthenticated, "client certificate required")
+		}
+		submitter = tlsInfo.State.VerifiedChains[0][0].Subject.String()
+	}
+
+	if r.authToken != "" {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return "", status.Error(codes.Unauthenticated, "missing bearer token")
+		}
+		token, ok := strings.CutPrefix(values[0], bearerPrefix)
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(r.authToken)) != 1 {
+			return "", status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+		// A certificate names the caller, the shared token doesn't
+		if submitter == "" {
+			submitter = tokenSubmitter
+		}
+	}
+
+	return submitter, nil
+}
@@ -0,0 +1,186 @@
+package cfgservice
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// serveAuthRunner serves a runner created with opts on a local port, with its
+// credentials and interceptors installed, and returns the address
+func serveAuthRunner(t *testing.T, opts ...Option) string {
+	t.Helper()
+
+	opts = append(opts, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	r, err := NewRunner("localhost:0", make(chan *transaction.ConfigTransaction, 1), opts...)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer(r.grpcOptions...)
+	pb.RegisterConfigServiceServer(grpcServer, r)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	return lis.Addr().String()
+}
+
+// callGetConfig calls GetConfig and returns the status code of the call
+func callGetConfig(t *testing.T, ctx context.Context, addr string, creds credentials.TransportCredentials) codes.Code {
+	t.Helper()
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+	_, err = pb.NewConfigServiceClient(conn).GetConfig(ctx, &pb.GetConfigRequest{})
+	return status.Code(err)
+}
+
+func clientTLS(t *testing.T, certs testutil.TestCerts, certFile, keyFile string) credentials.TransportCredentials {
+	t.Helper()
+
+	caPEM, err := os.ReadFile(certs.CAFile)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(caPEM))
+
+	cfg := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		require.NoError(t, err)
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg)
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+
+	t.Run("server certificate only", func(t *testing.T) {
+		cfg, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, "")
+		require.NoError(t, err)
+		assert.Len(t, cfg.Certificates, 1)
+		assert.Nil(t, cfg.ClientCAs)
+		assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+	})
+
+	t.Run("with client CA", func(t *testing.T) {
+		cfg, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, certs.CAFile)
+		require.NoError(t, err)
+		assert.NotNil(t, cfg.ClientCAs)
+		assert.Equal(t, tls.VerifyClientCertIfGiven, cfg.ClientAuth)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := NewTLSConfig(certs.ServerCertFile, "", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both a TLS certificate and key are required")
+	})
+
+	t.Run("client CA without certificates", func(t *testing.T) {
+		_, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, certs.ServerKeyFile)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no certificates found in client CA file")
+	})
+
+	t.Run("unreadable files", func(t *testing.T) {
+		_, err := NewTLSConfig("/nonexistent/cert.pem", "/nonexistent/key.pem", "")
+		require.ErrorContains(t, err, "failed to load TLS certificate")
+
+		_, err = NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, "/nonexistent/ca.pem")
+		require.ErrorContains(t, err, "failed to read client CA file")
+	})
+}
+
+func TestRunner_AuthToken(t *testing.T) {
+	addr := serveAuthRunner(t, WithAuthToken("s3cret"))
+	creds := insecure.NewCredentials()
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          codes.Code
+	}{
+		{name: "missing token", want: codes.Unauthenticated},
+		{name: "wrong token", authorization: "Bearer nope", want: codes.Unauthenticated},
+		{name: "wrong scheme", authorization: "Basic s3cret", want: codes.Unauthenticated},
+		{name: "valid token", authorization: "Bearer s3cret", want: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			if tt.authorization != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", tt.authorization)
+			}
+			assert.Equal(t, tt.want, callGetConfig(t, ctx, addr, creds))
+		})
+	}
+}
+
+func TestRunner_MutualTLS(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+	tlsConfig, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, certs.CAFile)
+	require.NoError(t, err)
+	addr := serveAuthRunner(t, WithTLSConfig(tlsConfig))
+
+	t.Run("verified client certificate", func(t *testing.T) {
+		creds := clientTLS(t, certs, certs.ClientCertFile, certs.ClientKeyFile)
+		assert.Equal(t, codes.OK, callGetConfig(t, t.Context(), addr, creds))
+	})
+
+	t.Run("no client certificate", func(t *testing.T) {
+		creds := clientTLS(t, certs, "", "")
+		assert.Equal(t, codes.Unauthenticated, callGetConfig(t, t.Context(), addr, creds))
+	})
+
+	t.Run("untrusted client certificate", func(t *testing.T) {
+		creds := clientTLS(t, certs, certs.UntrustedCertFile, certs.UntrustedKeyFile)
+		assert.Equal(t, codes.Unauthenticated, callGetConfig(t, t.Context(), addr, creds))
+	})
+
+	t.Run("plaintext", func(t *testing.T) {
+		assert.Equal(t, codes.Unavailable, callGetConfig(t, t.Context(), addr, insecure.NewCredentials()))
+	})
+}
+
+func TestRunner_TLSWithToken(t *testing.T) {
+	certs := testutil.GenerateTestCerts(t)
+	tlsConfig, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, "")
+	require.NoError(t, err)
+	addr := serveAuthRunner(t, WithTLSConfig(tlsConfig), WithAuthToken("s3cret"))
+	creds := clientTLS(t, certs, "", "")
+
+	assert.Equal(t, codes.Unauthenticated, callGetConfig(t, t.Context(), addr, creds))
+
+	ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer s3cret")
+	assert.Equal(t, codes.OK, callGetConfig(t, ctx, addr, creds))
+}
+
+func TestRunner_NoAuth(t *testing.T) {
+	r, err := NewRunner("localhost:0", make(chan *transaction.ConfigTransaction, 1))
+	require.NoError(t, err)
+	assert.Empty(t, r.grpcOptions, "no credentials or interceptors without auth options")
+	submitter, err := r.authenticate(t.Context())
+	assert.NoError(t, err)
+	assert.Empty(t, submitter)
+}
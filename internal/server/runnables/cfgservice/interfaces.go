@@ -2,8 +2,10 @@ package cfgservice
 
 import (
 	"context"
+	"log/slog"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
 )
 
 // GRPCServer defines the interface for a GRPC server that can be started and stopped
@@ -35,3 +37,25 @@ type configTransactionStorage interface {
 	// and always preserving the current transaction. Returns the number of transactions cleared.
 	Clear(keepLast int) (int, error)
 }
+
+// accessLogController changes the access logging of a listener at runtime
+type accessLogController interface {
+	// SetAccessLog enables, disables, or changes the level of a listener's access logging
+	SetAccessLog(listenerID string, enabled bool, level slog.Level)
+}
+
+// dryRunner checks whether each component could apply a validated configuration
+type dryRunner interface {
+	// DryRun runs the pre-flight checks of each component against the transaction
+	DryRun(ctx context.Context, tx *transaction.ConfigTransaction) ([]orchestrator.DryRunResult, error)
+}
+
+// fileReloader re-reads the config file the server was started from
+type fileReloader interface {
+	// ReloadFile validates the file and sends a transaction for it, returning
+	// nil when the config is unchanged
+	ReloadFile(ctx context.Context) (*transaction.ConfigTransaction, error)
+
+	// FilePath returns the path of the config file
+	FilePath() string
+}
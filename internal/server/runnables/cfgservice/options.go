@@ -1,6 +1,7 @@
 package cfgservice
 
 import (
+	"crypto/tls"
 	"log/slog"
 )
 
@@ -43,3 +44,69 @@ func WithConfigTransactionStorage(storage configTransactionStorage) Option {
 		}
 	}
 }
+
+// WithAccessLogController sets the component that applies runtime access log
+// changes requested through SetListenerAccessLog.
+func WithAccessLogController(controller accessLogController) Option {
+	return func(r *Runner) {
+		if controller != nil {
+			r.accessLog = controller
+		}
+	}
+}
+
+// WithDryRunner sets the component that checks configurations submitted
+// through DryRunConfig.
+func WithDryRunner(runner dryRunner) Option {
+	return func(r *Runner) {
+		if runner != nil {
+			r.dryRunner = runner
+		}
+	}
+}
+
+// WithFileReloader sets the component that reloads the server's config file
+// through ReloadFromFile.
+func WithFileReloader(reloader fileReloader) Option {
+	return func(r *Runner) {
+		if reloader != nil {
+			r.fileReloader = reloader
+		}
+	}
+}
+
+// WithReflection enables the gRPC server reflection service, which describes
+// the config service to clients such as grpcurl. It is disabled by default.
+func WithReflection(enabled bool) Option {
+	return func(r *Runner) {
+		r.reflection = enabled
+	}
+}
+
+// WithTLSConfig serves the gRPC service over TLS. When the config has client
+// CAs, see NewTLSConfig, every call must present a verified client
+// certificate.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(r *Runner) {
+		if cfg != nil {
+			r.tlsConfig = cfg
+		}
+	}
+}
+
+// WithAuthToken requires every call to present token as a bearer token in its
+// authorization metadata. An empty token disables the check.
+func WithAuthToken(token string) Option {
+	return func(r *Runner) {
+		r.authToken = token
+	}
+}
+
+// WithAuditKey signs the audit record of each configuration submitted through
+// UpdateConfig with key, see transaction.WithAudit. Records are unsigned
+// without a key.
+func WithAuditKey(key []byte) Option {
+	return func(r *Runner) {
+		r.auditKey = key
+	}
+}
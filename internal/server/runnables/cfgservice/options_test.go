@@ -139,6 +139,17 @@ func TestWithGRPCServer(t *testing.T) {
 	}
 }
 
+func TestWithReflection(t *testing.T) {
+	r := &Runner{}
+	assert.False(t, r.reflection, "reflection should be disabled by default")
+
+	WithReflection(true)(r)
+	assert.True(t, r.reflection)
+
+	WithReflection(false)(r)
+	assert.False(t, r.reflection)
+}
+
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name        string
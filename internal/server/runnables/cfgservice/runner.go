@@ -6,23 +6,32 @@ package cfgservice
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgfileloader"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgservice/server"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
 	"github.com/robbyt/go-supervisor/supervisor"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Interface guard: ensure Runner implements required interfaces
@@ -45,6 +54,35 @@ type Runner struct {
 	// Transaction storage for configuration history
 	txStorage configTransactionStorage
 
+	// accessLog applies runtime access log changes, nil when not configured
+	accessLog accessLogController
+
+	// dryRunner checks configurations for DryRunConfig, nil when not configured
+	dryRunner dryRunner
+
+	// fileReloader reloads the config file for ReloadFromFile, nil when the
+	// server wasn't started from a file
+	fileReloader fileReloader
+
+	// reflection registers the gRPC server reflection service
+	reflection bool
+
+	// tlsConfig serves the gRPC listener over TLS, nil for plaintext
+	tlsConfig *tls.Config
+
+	// authToken is the bearer token every call must present, empty to disable
+	authToken string
+
+	// auditKey signs the audit records of API transactions, nil to leave them
+	// unsigned
+	auditKey []byte
+
+	// grpcOptions install the credentials and authentication interceptors
+	grpcOptions []grpc.ServerOption
+
+	// startTime is when the runner was created, reported as the server's uptime
+	startTime time.Time
+
 	// ctx is passed in to Run, and is used to cancel the Run loop
 	ctx      context.Context
 	cancel   context.CancelFunc
@@ -69,6 +107,7 @@ func NewRunner(
 	r := &Runner{
 		listenAddr: listenAddr,
 		txSiphon:   txSiphon,
+		startTime:  time.Now(),
 		logger:     slog.Default().WithGroup("cfgservice.Runner"),
 	}
 
@@ -85,6 +124,9 @@ func NewRunner(
 		opt(r)
 	}
 
+	// Install the TLS credentials and authentication interceptors
+	r.grpcOptions = r.serverOptions()
+
 	// Initialize transaction storage if not provided
 	if r.txStorage == nil {
 		r.logger.Warn("no transaction storage provided, creating a local in-memory storage")
@@ -124,7 +166,13 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	// Start gRPC server (listenAddr is always provided now)
 	var err error
-	grpcServer, err = server.NewGRPCManager(r.logger, r.listenAddr, r)
+	grpcServer, err = server.NewGRPCManager(
+		r.logger,
+		r.listenAddr,
+		r,
+		server.WithReflection(r.reflection),
+		server.WithServerOptions(r.grpcOptions...),
+	)
 	if err != nil {
 		if stateErr := r.fsm.Transition(finitestate.StatusError); stateErr != nil {
 			return fmt.Errorf("failed to transition to error state: %w", stateErr)
@@ -222,19 +270,28 @@ func (r *Runner) GetDomainConfig() config.Config {
 
 // pageToken represents the internal structure of a pagination token
 type pageToken struct {
-	Offset   int    `json:"offset"`
-	PageSize int    `json:"pageSize"`
-	State    string `json:"state,omitempty"`
-	Source   string `json:"source,omitempty"`
+	Offset        int       `json:"offset"`
+	PageSize      int       `json:"pageSize"`
+	State         string    `json:"state,omitempty"`
+	Source        string    `json:"source,omitempty"`
+	CreatedAfter  time.Time `json:"createdAfter,omitzero"`
+	CreatedBefore time.Time `json:"createdBefore,omitzero"`
 }
 
-// encodePageToken creates an opaque page token from pagination parameters
-func encodePageToken(offset, pageSize int, state, source string) (string, error) {
+// encodePageToken creates an opaque page token from pagination parameters.
+// A zero createdAfter or createdBefore leaves that end of the time range open.
+func encodePageToken(
+	offset, pageSize int,
+	state, source string,
+	createdAfter, createdBefore time.Time,
+) (string, error) {
 	token := pageToken{
-		Offset:   offset,
-		PageSize: pageSize,
-		State:    state,
-		Source:   source,
+		Offset:        offset,
+		PageSize:      pageSize,
+		State:         state,
+		Source:        source,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
 	}
 
 	data, err := json.Marshal(token)
@@ -268,9 +325,10 @@ func decodePageToken(tokenStr string) (pageToken, error) {
 func (r *Runner) createAPITransaction(
 	ctx context.Context,
 	cfg *config.Config,
+	opts ...transaction.Option,
 ) (*transaction.ConfigTransaction, error) {
 	requestID := server.ExtractRequestID(ctx)
-	return transaction.FromAPI(requestID, cfg, r.logger.Handler())
+	return transaction.FromAPI(requestID, cfg, r.logger.Handler(), opts...)
 }
 
 // ValidateConfig handles requests to validate a configuration via gRPC.
@@ -293,24 +351,146 @@ func (r *Runner) ValidateConfig(
 	if err != nil {
 		logger.Warn("Failed to convert protobuf to domain config", "error", err)
 		return &pb.ValidateConfigResponse{
-			Valid: proto.Bool(false),
-			Error: proto.String(fmt.Sprintf("conversion error: %v", err)),
+			Valid:  proto.Bool(false),
+			Error:  proto.String(fmt.Sprintf("conversion error: %v", err)),
+			Issues: validation.IssuesToProto(validation.Issues(err, validation.SeverityError)),
 		}, nil
 	}
 
 	// Validate the configuration directly without creating a transaction
 	// This avoids creating transactions that get stuck in non-terminal states during shutdown
+	issues, err := domainConfig.ValidateWithIssues()
+	if err != nil {
+		logger.Debug("Configuration validation failed", "error", err, "issues", len(issues))
+		return &pb.ValidateConfigResponse{
+			Valid:  proto.Bool(false),
+			Error:  proto.String(fmt.Sprintf("validation failed: %v", err)),
+			Issues: validation.IssuesToProto(issues),
+		}, nil
+	}
+
+	logger.Debug("Config validated successfully", "request_id", server.ExtractRequestID(ctx))
+	return &pb.ValidateConfigResponse{
+		Valid:  proto.Bool(true),
+		Issues: validation.IssuesToProto(issues),
+	}, nil
+}
+
+// PreviewConfig validates the provided configuration and returns the changes it would make
+// to the active configuration. Like ValidateConfig, no transaction is created.
+func (r *Runner) PreviewConfig(
+	ctx context.Context,
+	req *pb.PreviewConfigRequest,
+) (*pb.PreviewConfigResponse, error) {
+	logger := r.logger.With("request_id", server.ExtractRequestID(ctx), "service", "PreviewConfig")
+	logger.Info("Received PreviewConfig request")
+
+	if req.Config == nil {
+		return &pb.PreviewConfigResponse{
+			Valid: proto.Bool(false),
+			Error: proto.String("No configuration provided"),
+		}, nil
+	}
+
+	domainConfig, err := config.NewFromProto(req.Config)
+	if err != nil {
+		logger.Warn("Failed to convert protobuf to domain config", "error", err)
+		return &pb.PreviewConfigResponse{
+			Valid: proto.Bool(false),
+			Error: proto.String(fmt.Sprintf("conversion error: %v", err)),
+		}, nil
+	}
+
 	if err := domainConfig.Validate(); err != nil {
 		logger.Debug("Configuration validation failed", "error", err)
-		return &pb.ValidateConfigResponse{
+		return &pb.PreviewConfigResponse{
 			Valid: proto.Bool(false),
 			Error: proto.String(fmt.Sprintf("validation failed: %v", err)),
 		}, nil
 	}
 
-	logger.Debug("Config validated successfully", "request_id", server.ExtractRequestID(ctx))
-	return &pb.ValidateConfigResponse{
+	current := r.GetDomainConfig()
+	delta := config.Diff(&current, domainConfig)
+	logger.Debug("Config preview computed",
+		"listeners", len(delta.Listeners),
+		"endpoints", len(delta.Endpoints),
+		"apps", len(delta.Apps),
+		"middlewares", len(delta.Middlewares))
+
+	return &pb.PreviewConfigResponse{
 		Valid: proto.Bool(true),
+		Delta: delta.ToProto(),
+	}, nil
+}
+
+// DryRunConfig validates the provided configuration, then asks each component
+// whether it could apply it, e.g. whether its listener ports can be bound. The
+// transaction it creates is neither stored nor executed.
+func (r *Runner) DryRunConfig(
+	ctx context.Context,
+	req *pb.DryRunConfigRequest,
+) (*pb.DryRunConfigResponse, error) {
+	logger := r.logger.With("request_id", server.ExtractRequestID(ctx), "service", "DryRunConfig")
+	logger.Info("Received DryRunConfig request")
+
+	if r.dryRunner == nil {
+		return nil, status.Error(codes.Unimplemented, "dry runs are not available")
+	}
+
+	if req.Config == nil {
+		return &pb.DryRunConfigResponse{
+			Valid: proto.Bool(false),
+			Error: proto.String("No configuration provided"),
+		}, nil
+	}
+
+	domainConfig, err := config.NewFromProto(req.Config)
+	if err != nil {
+		logger.Warn("Failed to convert protobuf to domain config", "error", err)
+		return &pb.DryRunConfigResponse{
+			Valid: proto.Bool(false),
+			Error: proto.String(fmt.Sprintf("conversion error: %v", err)),
+		}, nil
+	}
+
+	tx, err := r.createAPITransaction(ctx, domainConfig)
+	if err != nil {
+		logger.Warn("Failed to create config transaction", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
+	}
+
+	// Validation also builds the apps and middlewares the components check
+	if err := tx.RunValidation(); err != nil {
+		logger.Debug("Configuration validation failed", "error", err)
+		return &pb.DryRunConfigResponse{
+			Valid: proto.Bool(false),
+			Error: proto.String(fmt.Sprintf("validation failed: %v", err)),
+		}, nil
+	}
+
+	results, err := r.dryRunner.DryRun(ctx, tx)
+	if err != nil {
+		logger.Error("Dry run failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "dry run failed: %v", err)
+	}
+
+	participants := make([]*pb.ParticipantCheck, 0, len(results))
+	for _, result := range results {
+		check := &pb.ParticipantCheck{
+			Name:    proto.String(result.Participant),
+			Checked: proto.Bool(result.Checked),
+			Ready:   proto.Bool(result.Ready()),
+		}
+		if result.Error != nil {
+			check.Error = proto.String(result.Error.Error())
+		}
+		participants = append(participants, check)
+	}
+	logger.Debug("Config dry run completed", "participants", len(participants))
+
+	return &pb.DryRunConfigResponse{
+		Valid:        proto.Bool(true),
+		Participants: participants,
 	}, nil
 }
 
@@ -339,8 +519,16 @@ func (r *Runner) UpdateConfig(
 		}, nil
 	}
 
-	// Create a transaction for this API request
-	tx, err := r.createAPITransaction(ctx, domainConfig)
+	// Create a transaction for this API request, recording who submitted it
+	// and why
+	tx, err := r.createAPITransaction(ctx, domainConfig, transaction.WithAudit(
+		submitterFromContext(ctx),
+		req.GetChangeReason(),
+		r.auditKey,
+	))
+	if errors.Is(err, transaction.ErrChangeReasonTooLong) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		logger.Warn("Failed to create config transaction", "error", err)
 		success := false
@@ -387,6 +575,45 @@ func (r *Runner) UpdateConfig(
 	}, nil
 }
 
+// ReloadFromFile re-reads and validates the config file the server was started
+// from, and sends a transaction for it to the siphon. It fails with
+// FailedPrecondition when the server wasn't started from a file.
+func (r *Runner) ReloadFromFile(
+	ctx context.Context,
+	req *pb.ReloadFromFileRequest,
+) (*pb.ReloadFromFileResponse, error) {
+	logger := r.logger.With("request_id", server.ExtractRequestID(ctx), "service", "ReloadFromFile")
+	logger.Info("Received ReloadFromFile request")
+
+	if r.fileReloader == nil {
+		return nil, status.Error(codes.FailedPrecondition, "the server was not started from a config file")
+	}
+
+	tx, err := r.fileReloader.ReloadFile(ctx)
+	switch {
+	case errors.Is(err, cfgfileloader.ErrStdinReload):
+		return nil, status.Error(codes.FailedPrecondition, "the server was not started from a config file")
+	case err != nil && ctx.Err() != nil:
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case err != nil:
+		logger.Warn("Failed to reload config file", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "failed to reload config file: %v", err)
+	}
+
+	resp := &pb.ReloadFromFileResponse{
+		Unchanged: proto.Bool(tx == nil),
+		Path:      proto.String(r.fileReloader.FilePath()),
+	}
+	if tx == nil {
+		logger.Debug("Config file unchanged")
+		return resp, nil
+	}
+
+	logger.Debug("Config file reloaded", "id", tx.ID)
+	resp.TransactionId = proto.String(tx.ID.String())
+	return resp, nil
+}
+
 // GetConfig responds to gRPC requests for the current configuration.
 // It returns a deep copy to prevent clients from modifying the server's state.
 func (r *Runner) GetConfig(
@@ -449,6 +676,28 @@ func (r *Runner) ListConfigTransactions(
 		source = token.Source
 	}
 
+	// The time range is half-open: created_after is inclusive and
+	// created_before exclusive, so consecutive ranges don't overlap
+	var createdAfter, createdBefore time.Time
+	if req.CreatedAfter != nil {
+		if err := req.CreatedAfter.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid created_after: %v", err)
+		}
+		createdAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		if err := req.CreatedBefore.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid created_before: %v", err)
+		}
+		createdBefore = req.CreatedBefore.AsTime()
+	}
+	if !token.CreatedAfter.IsZero() {
+		createdAfter = token.CreatedAfter
+	}
+	if !token.CreatedBefore.IsZero() {
+		createdBefore = token.CreatedBefore
+	}
+
 	// Validate that filters match between token and request (if both provided)
 	if req.GetPageToken() != "" {
 		if req.State != nil && *req.State != "" && *req.State != token.State {
@@ -463,6 +712,25 @@ func (r *Runner) ListConfigTransactions(
 				"source filter must match previous request",
 			)
 		}
+		if req.CreatedAfter != nil && !req.CreatedAfter.AsTime().Equal(token.CreatedAfter) {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				"created_after filter must match previous request",
+			)
+		}
+		if req.CreatedBefore != nil && !req.CreatedBefore.AsTime().Equal(token.CreatedBefore) {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				"created_before filter must match previous request",
+			)
+		}
+	}
+
+	if !createdAfter.IsZero() && !createdBefore.IsZero() && !createdBefore.After(createdAfter) {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			"created_before must be later than created_after",
+		)
 	}
 
 	// Set page size with defaults and limits
@@ -486,6 +754,14 @@ func (r *Runner) ListConfigTransactions(
 			continue
 		}
 
+		// Filter by creation time if specified
+		if !createdAfter.IsZero() && tx.CreatedAt.Before(createdAfter) {
+			continue
+		}
+		if !createdBefore.IsZero() && !tx.CreatedAt.Before(createdBefore) {
+			continue
+		}
+
 		// Filter by source if specified
 		if source != "" {
 			var sourceStr string
@@ -496,6 +772,8 @@ func (r *Runner) ListConfigTransactions(
 				sourceStr = "api"
 			case transaction.SourceTest:
 				sourceStr = "test"
+			case transaction.SourceRollback:
+				sourceStr = "rollback"
 			default:
 				sourceStr = "unspecified"
 			}
@@ -535,7 +813,14 @@ func (r *Runner) ListConfigTransactions(
 	// Generate next page token if there are more results
 	var nextPageToken string
 	if end < totalCount {
-		nextPageToken, err = encodePageToken(end, pageSize, state, source)
+		nextPageToken, err = encodePageToken(
+			end,
+			pageSize,
+			state,
+			source,
+			createdAfter,
+			createdBefore,
+		)
 		if err != nil {
 			logger.Error("Failed to encode next page token", "error", err)
 			return nil, status.Error(codes.Internal, "failed to generate next page token")
@@ -584,6 +869,54 @@ func (r *Runner) GetConfigTransaction(
 	}, nil
 }
 
+// maxTransactionLogRecords caps the number of log records GetTransactionLogs
+// returns, keeping responses well under the gRPC message size limit
+const maxTransactionLogRecords = 1000
+
+// GetTransactionLogs retrieves the log records collected during a specific
+// transaction. When there are more records than the requested limit or
+// maxTransactionLogRecords, the most recent ones are returned, since those
+// usually explain how the transaction ended.
+func (r *Runner) GetTransactionLogs(
+	ctx context.Context,
+	req *pb.GetTransactionLogsRequest,
+) (*pb.GetTransactionLogsResponse, error) {
+	logger := r.logger.With(
+		"request_id",
+		server.ExtractRequestID(ctx),
+		"service",
+		"GetTransactionLogs",
+	)
+	logger.Debug("Received request", "transaction_id", req.TransactionId)
+
+	if req.GetTransactionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+
+	tx := r.txStorage.GetByID(req.GetTransactionId())
+	if tx == nil {
+		return nil, status.Error(codes.NotFound, "transaction not found")
+	}
+
+	limit := maxTransactionLogRecords
+	if maxRecords := int(req.GetMaxRecords()); maxRecords > 0 && maxRecords < limit {
+		limit = maxRecords
+	}
+
+	records := tx.LogsToProto()
+	total := len(records)
+	truncated := total > limit
+	if truncated {
+		records = records[total-limit:]
+	}
+
+	return &pb.GetTransactionLogsResponse{
+		Records:      records,
+		TotalRecords: proto.Int32(int32(total)),
+		Truncated:    proto.Bool(truncated),
+	}, nil
+}
+
 // ClearConfigTransactions clears transaction history
 func (r *Runner) ClearConfigTransactions(
 	ctx context.Context,
@@ -619,3 +952,173 @@ func (r *Runner) ClearConfigTransactions(
 		ClearedCount: proto.Int32(int32(cleared)),
 	}, nil
 }
+
+// WatchConfigTransaction streams state changes of a transaction and its saga
+// participants. The current states are sent first, followed by each change as
+// it happens. The stream ends after the terminal transaction state is sent or
+// when the client disconnects.
+func (r *Runner) WatchConfigTransaction(
+	req *pb.WatchConfigTransactionRequest,
+	stream grpc.ServerStreamingServer[pb.WatchConfigTransactionResponse],
+) error {
+	ctx := stream.Context()
+	logger := r.logger.With(
+		"request_id",
+		server.ExtractRequestID(ctx),
+		"service",
+		"WatchConfigTransaction",
+	)
+	logger.Debug("Received request", "transaction_id", req.TransactionId)
+
+	if req.GetTransactionId() == "" {
+		return status.Error(codes.InvalidArgument, "transaction_id is required")
+	}
+
+	tx := r.txStorage.GetByID(req.GetTransactionId())
+	if tx == nil {
+		return status.Error(codes.NotFound, "transaction not found")
+	}
+
+	for change := range tx.Watch(ctx) {
+		resp := &pb.WatchConfigTransactionResponse{
+			TransactionId: proto.String(tx.GetTransactionID()),
+			State:         proto.String(change.State),
+			Terminal:      proto.Bool(change.IsTerminal()),
+			Timestamp:     timestamppb.New(change.Time),
+		}
+		if change.Participant != "" {
+			resp.Participant = proto.String(change.Participant)
+		}
+		if err := stream.Send(resp); err != nil {
+			logger.Debug("Failed to send state change", "error", err)
+			return err
+		}
+		if change.IsTerminal() {
+			logger.Debug("Transaction reached terminal state", "state", change.State)
+			return nil
+		}
+	}
+
+	// The watch channel closes without a terminal state only when the
+	// client has gone away.
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
+// SetListenerAccessLog enables, disables, or changes the level of a listener's
+// access logging at runtime. The listener must exist in the current config.
+func (r *Runner) SetListenerAccessLog(
+	ctx context.Context,
+	req *pb.SetListenerAccessLogRequest,
+) (*pb.SetListenerAccessLogResponse, error) {
+	logger := r.logger.With(
+		"request_id",
+		server.ExtractRequestID(ctx),
+		"service",
+		"SetListenerAccessLog",
+	)
+	logger.Debug("Received request",
+		"listener_id", req.GetListenerId(),
+		"enabled", req.GetEnabled(),
+		"level", req.GetLevel())
+
+	if r.accessLog == nil {
+		return nil, status.Error(codes.Unimplemented, "runtime access logging is not available")
+	}
+
+	listenerID := req.GetListenerId()
+	if listenerID == "" {
+		return nil, status.Error(codes.InvalidArgument, "listener_id is required")
+	}
+
+	level := slog.LevelInfo
+	if req.GetLevel() != "" {
+		if err := level.UnmarshalText([]byte(req.GetLevel())); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid level %q", req.GetLevel())
+		}
+	}
+
+	cfg := r.GetDomainConfig()
+	if _, ok := cfg.Listeners.FindByID(listenerID); !ok {
+		return nil, status.Errorf(codes.NotFound, "listener %q not found", listenerID)
+	}
+
+	r.accessLog.SetAccessLog(listenerID, req.GetEnabled(), level)
+	logger.Info("Listener access logging changed",
+		"listener_id", listenerID,
+		"enabled", req.GetEnabled(),
+		"level", level)
+
+	return &pb.SetListenerAccessLogResponse{
+		ListenerId: proto.String(listenerID),
+		Enabled:    proto.Bool(req.GetEnabled()),
+		Level:      proto.String(strings.ToLower(level.String())),
+	}, nil
+}
+
+// GetMetrics returns the server's metrics in the Prometheus text format,
+// optionally limited to metric names starting with the requested prefix.
+func (r *Runner) GetMetrics(
+	ctx context.Context,
+	req *pb.GetMetricsRequest,
+) (*pb.GetMetricsResponse, error) {
+	r.logger.Debug(
+		"Received request",
+		"request_id", server.ExtractRequestID(ctx),
+		"service", "GetMetrics",
+		"name_prefix", req.GetNamePrefix(),
+	)
+
+	var buf strings.Builder
+	if err := metrics.WriteText(&buf, req.GetNamePrefix()); err != nil {
+		r.logger.Error("Failed to export metrics", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to export metrics: %v", err)
+	}
+
+	return &pb.GetMetricsResponse{
+		Text: proto.String(buf.String()),
+	}, nil
+}
+
+// GetServerStatus returns the live state of the server: the state of this
+// runner, its uptime, the current configuration transaction, and the
+// listeners of the active configuration.
+func (r *Runner) GetServerStatus(
+	ctx context.Context,
+	req *pb.GetServerStatusRequest,
+) (*pb.GetServerStatusResponse, error) {
+	r.logger.Debug(
+		"Received request",
+		"request_id", server.ExtractRequestID(ctx),
+		"service", "GetServerStatus",
+	)
+
+	resp := &pb.GetServerStatusResponse{
+		State:            proto.String(r.GetState()),
+		StartTime:        timestamppb.New(r.startTime),
+		Uptime:           durationpb.New(time.Since(r.startTime).Truncate(time.Second)),
+		TransactionCount: proto.Int32(int32(len(r.txStorage.GetAll()))),
+	}
+
+	currentTx := r.txStorage.GetCurrent()
+	if currentTx == nil {
+		return resp, nil
+	}
+	resp.TransactionId = proto.String(currentTx.GetTransactionID())
+	resp.TransactionState = proto.String(currentTx.GetState())
+	resp.TransactionReason = proto.String(currentTx.GetChangeReason())
+
+	cfg := currentTx.GetConfig()
+	resp.ConfigVersion = proto.String(cfg.Version)
+	for _, l := range cfg.Listeners {
+		resp.Listeners = append(resp.Listeners, &pb.ListenerStatus{
+			Id:      proto.String(l.ID),
+			Address: proto.String(l.Address),
+			Type:    proto.String(l.GetTypeString()),
+		})
+	}
+
+	return resp, nil
+}
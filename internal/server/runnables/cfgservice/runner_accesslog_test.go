@@ -0,0 +1,126 @@
+package cfgservice
+
+import (
+	"log/slog"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// accessLogCall records a call to fakeAccessLogController.SetAccessLog
+type accessLogCall struct {
+	listenerID string
+	enabled    bool
+	level      slog.Level
+}
+
+type fakeAccessLogController struct {
+	calls []accessLogCall
+}
+
+func (f *fakeAccessLogController) SetAccessLog(listenerID string, enabled bool, level slog.Level) {
+	f.calls = append(f.calls, accessLogCall{listenerID, enabled, level})
+}
+
+func TestSetListenerAccessLog(t *testing.T) {
+	newRunner := func(t *testing.T, opts ...Option) *Runner {
+		t.Helper()
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+		cfg.Listeners = listeners.ListenerCollection{
+			{ID: "http", Address: ":8080", Type: listeners.TypeHTTP},
+		}
+
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), opts...)
+		h.runner.txStorage = &mockTxStorageWithConfig{cfg: cfg}
+		return h.runner
+	}
+
+	t.Run("toggles logging on and off", func(t *testing.T) {
+		controller := &fakeAccessLogController{}
+		r := newRunner(t, WithAccessLogController(controller))
+
+		resp, err := r.SetListenerAccessLog(t.Context(), &pb.SetListenerAccessLogRequest{
+			ListenerId: proto.String("http"),
+			Enabled:    proto.Bool(true),
+			Level:      proto.String("debug"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "http", resp.GetListenerId())
+		assert.True(t, resp.GetEnabled())
+		assert.Equal(t, "debug", resp.GetLevel())
+
+		resp, err = r.SetListenerAccessLog(t.Context(), &pb.SetListenerAccessLogRequest{
+			ListenerId: proto.String("http"),
+			Enabled:    proto.Bool(false),
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.GetEnabled())
+		assert.Equal(t, "info", resp.GetLevel(), "level defaults to info")
+
+		assert.Equal(t, []accessLogCall{
+			{"http", true, slog.LevelDebug},
+			{"http", false, slog.LevelInfo},
+		}, controller.calls)
+	})
+
+	t.Run("request errors", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			req      *pb.SetListenerAccessLogRequest
+			wantCode codes.Code
+		}{
+			{
+				name:     "missing listener ID",
+				req:      &pb.SetListenerAccessLogRequest{Enabled: proto.Bool(true)},
+				wantCode: codes.InvalidArgument,
+			},
+			{
+				name: "unknown listener",
+				req: &pb.SetListenerAccessLogRequest{
+					ListenerId: proto.String("missing"),
+					Enabled:    proto.Bool(true),
+				},
+				wantCode: codes.NotFound,
+			},
+			{
+				name: "invalid level",
+				req: &pb.SetListenerAccessLogRequest{
+					ListenerId: proto.String("http"),
+					Level:      proto.String("loud"),
+				},
+				wantCode: codes.InvalidArgument,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				controller := &fakeAccessLogController{}
+				r := newRunner(t, WithAccessLogController(controller))
+
+				_, err := r.SetListenerAccessLog(t.Context(), tt.req)
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				assert.Empty(t, controller.calls)
+			})
+		}
+	})
+
+	t.Run("unavailable without a controller", func(t *testing.T) {
+		r := newRunner(t)
+
+		_, err := r.SetListenerAccessLog(t.Context(), &pb.SetListenerAccessLogRequest{
+			ListenerId: proto.String("http"),
+			Enabled:    proto.Bool(true),
+		})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
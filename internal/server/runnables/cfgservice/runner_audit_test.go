@@ -0,0 +1,113 @@
+package cfgservice
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUpdateConfig_Audit(t *testing.T) {
+	auditKey := []byte("audit-key")
+	submitted := &pb.ServerConfig{Version: proto.String("v1")}
+
+	// update serves a runner created with opts, submits the config with a
+	// change reason, and returns the transaction sent to the siphon
+	update := func(
+		t *testing.T,
+		ctx context.Context,
+		creds credentials.TransportCredentials,
+		opts ...Option,
+	) *transaction.ConfigTransaction {
+		t.Helper()
+		txSiphon := make(chan *transaction.ConfigTransaction, 1)
+		opts = append(opts, WithLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+		r, err := NewRunner("localhost:0", txSiphon, opts...)
+		require.NoError(t, err)
+
+		lis, err := net.Listen("tcp", "localhost:0")
+		require.NoError(t, err)
+		grpcServer := grpc.NewServer(r.grpcOptions...)
+		pb.RegisterConfigServiceServer(grpcServer, r)
+		go func() { _ = grpcServer.Serve(lis) }()
+		t.Cleanup(grpcServer.Stop)
+
+		conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(creds))
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+		resp, err := pb.NewConfigServiceClient(conn).UpdateConfig(ctx, &pb.UpdateConfigRequest{
+			Config:       submitted,
+			ChangeReason: proto.String("rotate certificates"),
+		})
+		require.NoError(t, err)
+		require.True(t, resp.GetSuccess(), resp.GetError())
+
+		tx := <-txSiphon
+		require.NotNil(t, tx.Audit)
+		assert.Equal(t, "rotate certificates", tx.Audit.ChangeReason)
+		assert.Equal(t, tx.Audit.ContentHash, tx.ToProto().GetAudit().GetContentHash())
+		return tx
+	}
+
+	t.Run("client certificate names the submitter", func(t *testing.T) {
+		certs := testutil.GenerateTestCerts(t)
+		tlsConfig, err := NewTLSConfig(certs.ServerCertFile, certs.ServerKeyFile, certs.CAFile)
+		require.NoError(t, err)
+		creds := clientTLS(t, certs, certs.ClientCertFile, certs.ClientKeyFile)
+
+		tx := update(t, t.Context(), creds, WithTLSConfig(tlsConfig), WithAuditKey(auditKey))
+		assert.Equal(t, "CN=firelynx test client", tx.Audit.Submitter)
+		require.NoError(t, tx.Audit.Verify(tx.ID, auditKey))
+
+		// The hash covers the config as submitted
+		domainConfig, err := config.NewFromProto(submitted)
+		require.NoError(t, err)
+		hash, err := transaction.ContentHash(domainConfig)
+		require.NoError(t, err)
+		assert.Equal(t, hash, tx.Audit.ContentHash)
+	})
+
+	t.Run("bearer token", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(t.Context(), "authorization", "Bearer s3cret")
+
+		tx := update(t, ctx, insecure.NewCredentials(), WithAuthToken("s3cret"))
+		assert.Equal(t, tokenSubmitter, tx.Audit.Submitter)
+		assert.Empty(t, tx.Audit.Signature)
+	})
+
+	t.Run("reason over the length cap", func(t *testing.T) {
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t))
+
+		_, err := h.runner.UpdateConfig(t.Context(), &pb.UpdateConfigRequest{
+			Config:       submitted,
+			ChangeReason: proto.String(strings.Repeat("x", transaction.MaxChangeReasonLength+1)),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "change reason is too long")
+		assert.Empty(t, h.txSiphon)
+	})
+
+	t.Run("without authentication", func(t *testing.T) {
+		tx := update(t, t.Context(), insecure.NewCredentials(), WithAuditKey(auditKey))
+		assert.Empty(t, tx.Audit.Submitter)
+		require.NoError(t, tx.Audit.Verify(tx.ID, auditKey))
+	})
+}
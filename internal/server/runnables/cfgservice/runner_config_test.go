@@ -20,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // mockTxStorage is a simple in-memory implementation of configTransactionStorage for testing
@@ -652,6 +653,14 @@ func TestValidateConfig(t *testing.T) {
 		assert.True(t, *resp.Valid)
 		assert.Nil(t, resp.Error)
 
+		// The listener has no endpoints, which is reported without making
+		// the config invalid
+		issues := resp.GetIssues()
+		require.Len(t, issues, 1)
+		assert.Equal(t, "listener", issues[0].GetComponentType())
+		assert.Equal(t, pb.ValidationIssue_SEVERITY_WARNING, issues[0].GetSeverity())
+		assert.Contains(t, issues[0].GetMessage(), "listener has no endpoints")
+
 		assert.Never(t, func() bool {
 			<-h.txSiphon
 			return true
@@ -759,6 +768,17 @@ func TestValidateConfig(t *testing.T) {
 		assert.False(t, *resp.Valid)
 		require.NotNil(t, resp.Error)
 		assert.Contains(t, *resp.Error, "validation failed")
+
+		// The duplicate is the only error, followed by a warning that the
+		// listener has no endpoints
+		issues := resp.GetIssues()
+		require.Len(t, issues, 2)
+		assert.Equal(t, pb.ValidationIssue_SEVERITY_WARNING, issues[1].GetSeverity())
+		assert.Equal(t, "listener", issues[0].GetComponentType())
+		assert.Equal(t, duplicateId, issues[0].GetComponentId())
+		assert.Equal(t, "id", issues[0].GetField())
+		assert.Equal(t, pb.ValidationIssue_SEVERITY_ERROR, issues[0].GetSeverity())
+		assert.Contains(t, issues[0].GetMessage(), "same_id")
 	})
 
 	t.Run("multiple_validations", func(t *testing.T) {
@@ -971,7 +991,7 @@ func TestPageTokenRoundTrip(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Encode the token
-			encoded, err := encodePageToken(tt.offset, tt.pageSize, tt.state, tt.source)
+			encoded, err := encodePageToken(tt.offset, tt.pageSize, tt.state, tt.source, time.Time{}, time.Time{})
 			require.NoError(t, err)
 			assert.NotEmpty(t, encoded)
 
@@ -1169,7 +1189,7 @@ func TestListConfigTransactions_Pagination(t *testing.T) {
 		}
 
 		// Navigate to last page
-		token, err := encodePageToken(10, 10, "", "")
+		token, err := encodePageToken(10, 10, "", "", time.Time{}, time.Time{})
 		require.NoError(t, err)
 
 		req := &pb.ListConfigTransactionsRequest{
@@ -1199,7 +1219,7 @@ func TestListConfigTransactions_Pagination(t *testing.T) {
 		}
 
 		// Request page beyond available data
-		token, err := encodePageToken(10, 10, "", "")
+		token, err := encodePageToken(10, 10, "", "", time.Time{}, time.Time{})
 		require.NoError(t, err)
 
 		req := &pb.ListConfigTransactionsRequest{
@@ -1274,7 +1294,7 @@ func TestListConfigTransactions_FilterConsistency(t *testing.T) {
 		}
 
 		// Create token with state filter
-		token, err := encodePageToken(0, 5, txstate.StateSucceeded, "")
+		token, err := encodePageToken(0, 5, txstate.StateSucceeded, "", time.Time{}, time.Time{})
 		require.NoError(t, err)
 
 		// Request with matching state filter should succeed
@@ -1314,7 +1334,7 @@ func TestListConfigTransactions_FilterConsistency(t *testing.T) {
 		}
 
 		// Create token with source filter
-		token, err := encodePageToken(0, 5, "", "api")
+		token, err := encodePageToken(0, 5, "", "api", time.Time{}, time.Time{})
 		require.NoError(t, err)
 
 		// Request with matching source filter should succeed
@@ -1339,6 +1359,159 @@ func TestListConfigTransactions_FilterConsistency(t *testing.T) {
 	})
 }
 
+// TestListConfigTransactions_TimeRange tests filtering transactions by creation time
+func TestListConfigTransactions_TimeRange(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	// setup adds a succeeded and a failed transaction at each of base, base+1m,
+	// base+2m, and base+3m
+	setup := func(t *testing.T) *testHarness {
+		t.Helper()
+		h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+		h.transitionToRunning()
+		for i := range 4 {
+			for _, state := range []string{txstate.StateSucceeded, txstate.StateFailed} {
+				tx := createTestTransaction(t, transaction.SourceAPI, state)
+				tx.CreatedAt = base.Add(time.Duration(i) * time.Minute)
+				h.txStorage.AddTransaction(tx)
+			}
+		}
+		return h
+	}
+
+	createdAt := func(resp *pb.ListConfigTransactionsResponse) []time.Time {
+		var times []time.Time
+		for _, tx := range resp.GetTransactions() {
+			times = append(times, tx.GetCreatedAt().AsTime())
+		}
+		return times
+	}
+
+	t.Run("boundaries are inclusive after and exclusive before", func(t *testing.T) {
+		h := setup(t)
+		resp, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedAfter:  timestamppb.New(base.Add(time.Minute)),
+			CreatedBefore: timestamppb.New(base.Add(3 * time.Minute)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			base.Add(time.Minute), base.Add(time.Minute),
+			base.Add(2 * time.Minute), base.Add(2 * time.Minute),
+		}, createdAt(resp))
+	})
+
+	t.Run("open ended ranges", func(t *testing.T) {
+		h := setup(t)
+		resp, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedAfter: timestamppb.New(base.Add(3 * time.Minute)),
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetTransactions(), 2)
+
+		resp, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedBefore: timestamppb.New(base),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetTransactions())
+
+		// One nanosecond past the boundary includes it
+		resp, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedBefore: timestamppb.New(base.Add(time.Nanosecond)),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{base, base}, createdAt(resp))
+	})
+
+	t.Run("combined with state filter", func(t *testing.T) {
+		h := setup(t)
+		resp, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			State:        proto.String(txstate.StateFailed),
+			CreatedAfter: timestamppb.New(base.Add(2 * time.Minute)),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetTransactions(), 2)
+		for _, tx := range resp.GetTransactions() {
+			assert.Equal(t, txstate.StateFailed, tx.GetState())
+		}
+		assert.Equal(t, []time.Time{base.Add(2 * time.Minute), base.Add(3 * time.Minute)}, createdAt(resp))
+	})
+
+	t.Run("range is kept across pages", func(t *testing.T) {
+		h := setup(t)
+		after := timestamppb.New(base.Add(time.Minute))
+		before := timestamppb.New(base.Add(3 * time.Minute))
+
+		resp, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			State:         proto.String(txstate.StateSucceeded),
+			CreatedAfter:  after,
+			CreatedBefore: before,
+			PageSize:      proto.Int32(1),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{base.Add(time.Minute)}, createdAt(resp))
+		require.NotEmpty(t, resp.GetNextPageToken())
+
+		// The token carries the filters, so they can be left out of the request
+		resp, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			PageToken: proto.String(resp.GetNextPageToken()),
+			PageSize:  proto.Int32(1),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{base.Add(2 * time.Minute)}, createdAt(resp))
+		assert.Empty(t, resp.GetNextPageToken())
+	})
+
+	t.Run("time filter consistency", func(t *testing.T) {
+		h := setup(t)
+		token, err := encodePageToken(0, 5, "", "", base, time.Time{})
+		require.NoError(t, err)
+
+		// Request with matching filter should succeed
+		_, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			PageToken:    proto.String(token),
+			CreatedAfter: timestamppb.New(base),
+		})
+		require.NoError(t, err)
+
+		// Request with mismatched filters should fail
+		_, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			PageToken:    proto.String(token),
+			CreatedAfter: timestamppb.New(base.Add(time.Second)),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "created_after filter must match previous request")
+
+		_, err = h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			PageToken:     proto.String(token),
+			CreatedBefore: timestamppb.New(base.Add(time.Hour)),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "created_before filter must match previous request")
+	})
+
+	t.Run("empty range is rejected", func(t *testing.T) {
+		h := setup(t)
+		_, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedAfter:  timestamppb.New(base),
+			CreatedBefore: timestamppb.New(base),
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "created_before must be later than created_after")
+	})
+
+	t.Run("invalid timestamp is rejected", func(t *testing.T) {
+		h := setup(t)
+		_, err := h.runner.ListConfigTransactions(t.Context(), &pb.ListConfigTransactionsRequest{
+			CreatedAfter: &timestamppb.Timestamp{Nanos: -1},
+		})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "invalid created_after")
+	})
+}
+
 // TestListConfigTransactions_EdgeCases tests edge cases and error handling
 func TestListConfigTransactions_EdgeCases(t *testing.T) {
 	t.Run("invalid page token", func(t *testing.T) {
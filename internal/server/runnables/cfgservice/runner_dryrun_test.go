@@ -0,0 +1,100 @@
+package cfgservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeDryRunner struct {
+	results []orchestrator.DryRunResult
+	err     error
+	tx      *transaction.ConfigTransaction
+}
+
+func (f *fakeDryRunner) DryRun(
+	ctx context.Context,
+	tx *transaction.ConfigTransaction,
+) ([]orchestrator.DryRunResult, error) {
+	f.tx = tx
+	return f.results, f.err
+}
+
+func TestDryRunConfig(t *testing.T) {
+	validConfig := &pb.ServerConfig{Version: proto.String("v1")}
+
+	t.Run("reports each participant", func(t *testing.T) {
+		dryRunner := &fakeDryRunner{results: []orchestrator.DryRunResult{
+			{Participant: "HTTPRunner", Checked: true, Error: errors.New("cannot bind :8080")},
+			{Participant: "other"},
+		}}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithDryRunner(dryRunner))
+
+		resp, err := h.runner.DryRunConfig(t.Context(), &pb.DryRunConfigRequest{Config: validConfig})
+		require.NoError(t, err)
+		require.True(t, resp.GetValid(), resp.GetError())
+		require.Len(t, resp.GetParticipants(), 2)
+
+		notReady := resp.GetParticipants()[0]
+		assert.Equal(t, "HTTPRunner", notReady.GetName())
+		assert.True(t, notReady.GetChecked())
+		assert.False(t, notReady.GetReady())
+		assert.Equal(t, "cannot bind :8080", notReady.GetError())
+
+		unchecked := resp.GetParticipants()[1]
+		assert.Equal(t, "other", unchecked.GetName())
+		assert.False(t, unchecked.GetChecked())
+		assert.True(t, unchecked.GetReady())
+		assert.Empty(t, unchecked.GetError())
+
+		// The transaction was validated but never submitted
+		require.NotNil(t, dryRunner.tx)
+		assert.Equal(t, finitestate.StateValidated, dryRunner.tx.GetState())
+		assert.Empty(t, h.txSiphon)
+	})
+
+	t.Run("invalid config is not dry run", func(t *testing.T) {
+		dryRunner := &fakeDryRunner{}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithDryRunner(dryRunner))
+
+		resp, err := h.runner.DryRunConfig(t.Context(), &pb.DryRunConfigRequest{})
+		require.NoError(t, err)
+		assert.False(t, resp.GetValid())
+		assert.Equal(t, "No configuration provided", resp.GetError())
+
+		resp, err = h.runner.DryRunConfig(t.Context(), &pb.DryRunConfigRequest{
+			Config: &pb.ServerConfig{Version: proto.String("v999")},
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.GetValid())
+		assert.NotEmpty(t, resp.GetError())
+		assert.Empty(t, resp.GetParticipants())
+		assert.Nil(t, dryRunner.tx)
+	})
+
+	t.Run("dry run error", func(t *testing.T) {
+		dryRunner := &fakeDryRunner{err: errors.New("transaction is not in validated state")}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithDryRunner(dryRunner))
+
+		_, err := h.runner.DryRunConfig(t.Context(), &pb.DryRunConfigRequest{Config: validConfig})
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("unavailable without a dry runner", func(t *testing.T) {
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t))
+
+		_, err := h.runner.DryRunConfig(t.Context(), &pb.DryRunConfigRequest{Config: validConfig})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
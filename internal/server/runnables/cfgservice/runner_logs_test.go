@@ -0,0 +1,85 @@
+package cfgservice
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/logging"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetTransactionLogs(t *testing.T) {
+	h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+	h.transitionToRunning()
+
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	tx, err := transaction.FromAPI("logs-request", cfg, logging.SetupHandlerText("debug", nil))
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+	require.NoError(t, tx.BeginExecution())
+	require.NoError(t, tx.MarkSucceeded())
+	h.txStorage.AddTransaction(tx)
+
+	records := tx.GetLogs()
+	require.Greater(t, len(records), 1)
+	txID := tx.ID.String()
+
+	t.Run("returns all records", func(t *testing.T) {
+		resp, err := h.runner.GetTransactionLogs(t.Context(), &pb.GetTransactionLogsRequest{
+			TransactionId: proto.String(txID),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetRecords(), len(records))
+		assert.Equal(t, int32(len(records)), resp.GetTotalRecords())
+		assert.False(t, resp.GetTruncated())
+		for i, record := range records {
+			assert.Equal(t, record.Message, resp.GetRecords()[i].GetMessage())
+		}
+	})
+
+	t.Run("returns the most recent records when limited", func(t *testing.T) {
+		resp, err := h.runner.GetTransactionLogs(t.Context(), &pb.GetTransactionLogsRequest{
+			TransactionId: proto.String(txID),
+			MaxRecords:    proto.Int32(1),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.GetRecords(), 1)
+		assert.Equal(t, records[len(records)-1].Message, resp.GetRecords()[0].GetMessage())
+		assert.Equal(t, int32(len(records)), resp.GetTotalRecords())
+		assert.True(t, resp.GetTruncated())
+	})
+
+	t.Run("a limit above the server cap is capped", func(t *testing.T) {
+		resp, err := h.runner.GetTransactionLogs(t.Context(), &pb.GetTransactionLogsRequest{
+			TransactionId: proto.String(txID),
+			MaxRecords:    proto.Int32(maxTransactionLogRecords + 1),
+		})
+		require.NoError(t, err)
+		assert.Len(t, resp.GetRecords(), len(records))
+		assert.False(t, resp.GetTruncated())
+	})
+
+	t.Run("returns not found for an unknown transaction", func(t *testing.T) {
+		resp, err := h.runner.GetTransactionLogs(t.Context(), &pb.GetTransactionLogsRequest{
+			TransactionId: proto.String("00000000-0000-0000-0000-000000000000"),
+		})
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("requires a transaction ID", func(t *testing.T) {
+		resp, err := h.runner.GetTransactionLogs(t.Context(), &pb.GetTransactionLogsRequest{})
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,34 @@
+package cfgservice
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetMetrics(t *testing.T) {
+	h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t))
+	metrics.AppRequestSize.WithLabelValues("metrics-rpc-app").Observe(128)
+	metrics.AppFallbackResponses.WithLabelValues("metrics-rpc-app").Inc()
+
+	t.Run("all metrics", func(t *testing.T) {
+		resp, err := h.runner.GetMetrics(t.Context(), &pb.GetMetricsRequest{})
+		require.NoError(t, err)
+		assert.Contains(t, resp.GetText(), `firelynx_app_request_size_bytes_count{app="metrics-rpc-app"} 1`)
+		assert.Contains(t, resp.GetText(), `firelynx_app_fallback_responses_total{app="metrics-rpc-app"} 1`)
+	})
+
+	t.Run("filtered by name prefix", func(t *testing.T) {
+		resp, err := h.runner.GetMetrics(t.Context(), &pb.GetMetricsRequest{
+			NamePrefix: proto.String("firelynx_app_request_size"),
+		})
+		require.NoError(t, err)
+		assert.Contains(t, resp.GetText(), "firelynx_app_request_size_bytes")
+		assert.NotContains(t, resp.GetText(), "firelynx_app_fallback_responses_total")
+	})
+}
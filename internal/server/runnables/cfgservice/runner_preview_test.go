@@ -0,0 +1,111 @@
+package cfgservice
+
+import (
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPreviewConfig(t *testing.T) {
+	current, err := config.NewConfigFromBytes([]byte(`
+version = "v1"
+
+[[listeners]]
+id = "http"
+address = ":8080"
+type = "http"
+
+[[endpoints]]
+id = "main"
+listener_id = "http"
+
+[[endpoints.routes]]
+app_id = "echo"
+[endpoints.routes.http]
+path_prefix = "/"
+
+[[apps]]
+id = "echo"
+type = "echo"
+[apps.echo]
+response = "hello"
+`))
+	require.NoError(t, err)
+	require.NoError(t, current.Validate())
+
+	newHarness := func(t *testing.T) *runnerTestHarness {
+		t.Helper()
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t))
+		h.runner.txStorage = &mockTxStorageWithConfig{cfg: current}
+		return h
+	}
+
+	t.Run("reports listener change and app addition without applying", func(t *testing.T) {
+		h := newHarness(t)
+		before := h.runner.GetPbConfigClone()
+
+		next := proto.Clone(before).(*pb.ServerConfig)
+		next.Listeners[0].Address = proto.String(":9090")
+		echoType := pb.AppDefinition_TYPE_ECHO
+		next.Apps = append(next.Apps, &pb.AppDefinition{
+			Id:   proto.String("echo2"),
+			Type: &echoType,
+			Config: &pb.AppDefinition_Echo{
+				Echo: &pbApps.EchoApp{Response: proto.String("two")},
+			},
+		})
+
+		resp, err := h.runner.PreviewConfig(t.Context(), &pb.PreviewConfigRequest{Config: next})
+		require.NoError(t, err)
+		require.True(t, resp.GetValid(), resp.GetError())
+
+		delta := resp.GetDelta()
+		require.Len(t, delta.GetListeners(), 1)
+		assert.Equal(t, "http", delta.GetListeners()[0].GetId())
+		assert.Equal(t, pb.ComponentChange_KIND_CHANGED, delta.GetListeners()[0].GetKind())
+		require.Len(t, delta.GetApps(), 1)
+		assert.Equal(t, "echo2", delta.GetApps()[0].GetId())
+		assert.Equal(t, pb.ComponentChange_KIND_ADDED, delta.GetApps()[0].GetKind())
+		assert.Empty(t, delta.GetEndpoints())
+		assert.Empty(t, delta.GetMiddlewares())
+
+		// The running config is untouched and no transaction was submitted
+		assert.True(t, proto.Equal(before, h.runner.GetPbConfigClone()))
+		assert.Empty(t, h.txSiphon)
+	})
+
+	t.Run("unchanged config has an empty delta", func(t *testing.T) {
+		h := newHarness(t)
+		resp, err := h.runner.PreviewConfig(t.Context(), &pb.PreviewConfigRequest{
+			Config: h.runner.GetPbConfigClone(),
+		})
+		require.NoError(t, err)
+		require.True(t, resp.GetValid(), resp.GetError())
+		assert.Empty(t, resp.GetDelta().GetListeners())
+		assert.Empty(t, resp.GetDelta().GetEndpoints())
+		assert.Empty(t, resp.GetDelta().GetApps())
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		h := newHarness(t)
+
+		resp, err := h.runner.PreviewConfig(t.Context(), &pb.PreviewConfigRequest{})
+		require.NoError(t, err)
+		assert.False(t, resp.GetValid())
+		assert.Contains(t, resp.GetError(), "No configuration provided")
+
+		resp, err = h.runner.PreviewConfig(t.Context(), &pb.PreviewConfigRequest{
+			Config: &pb.ServerConfig{Version: proto.String("v999")},
+		})
+		require.NoError(t, err)
+		assert.False(t, resp.GetValid())
+		assert.Nil(t, resp.GetDelta())
+		assert.Empty(t, h.txSiphon)
+	})
+}
@@ -0,0 +1,101 @@
+package cfgservice
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/cfgfileloader"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type fakeFileReloader struct {
+	tx   *transaction.ConfigTransaction
+	err  error
+	path string
+}
+
+func (f *fakeFileReloader) ReloadFile(ctx context.Context) (*transaction.ConfigTransaction, error) {
+	return f.tx, f.err
+}
+
+func (f *fakeFileReloader) FilePath() string {
+	return f.path
+}
+
+func TestReloadFromFile(t *testing.T) {
+	const configPath = "/etc/firelynx/config.toml"
+
+	t.Run("returns the transaction ID", func(t *testing.T) {
+		tx, err := transaction.New(
+			transaction.SourceFile,
+			configPath,
+			"",
+			&config.Config{Version: config.VersionLatest},
+			slog.Default().Handler(),
+		)
+		require.NoError(t, err)
+		reloader := &fakeFileReloader{tx: tx, path: configPath}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithFileReloader(reloader))
+
+		resp, err := h.runner.ReloadFromFile(t.Context(), &pb.ReloadFromFileRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID.String(), resp.GetTransactionId())
+		assert.False(t, resp.GetUnchanged())
+		assert.Equal(t, configPath, resp.GetPath())
+	})
+
+	t.Run("reports an unchanged file", func(t *testing.T) {
+		reloader := &fakeFileReloader{path: configPath}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithFileReloader(reloader))
+
+		resp, err := h.runner.ReloadFromFile(t.Context(), &pb.ReloadFromFileRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetTransactionId())
+		assert.True(t, resp.GetUnchanged())
+		assert.Equal(t, configPath, resp.GetPath())
+	})
+
+	t.Run("invalid file is an invalid argument", func(t *testing.T) {
+		reloader := &fakeFileReloader{err: errors.New("validation failed"), path: configPath}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithFileReloader(reloader))
+
+		_, err := h.runner.ReloadFromFile(t.Context(), &pb.ReloadFromFileRequest{})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "validation failed")
+	})
+
+	t.Run("cancelled request", func(t *testing.T) {
+		reloader := &fakeFileReloader{err: context.Canceled, path: configPath}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithFileReloader(reloader))
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := h.runner.ReloadFromFile(ctx, &pb.ReloadFromFileRequest{})
+		assert.Equal(t, codes.Canceled, status.Code(err))
+	})
+
+	t.Run("not started from a file", func(t *testing.T) {
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t))
+
+		_, err := h.runner.ReloadFromFile(t.Context(), &pb.ReloadFromFileRequest{})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("started from stdin", func(t *testing.T) {
+		reloader := &fakeFileReloader{err: cfgfileloader.ErrStdinReload, path: "-"}
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t), WithFileReloader(reloader))
+
+		_, err := h.runner.ReloadFromFile(t.Context(), &pb.ReloadFromFileRequest{})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
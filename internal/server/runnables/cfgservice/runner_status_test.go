@@ -0,0 +1,91 @@
+package cfgservice
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServerStatus(t *testing.T) {
+	t.Run("without a transaction", func(t *testing.T) {
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t),
+			WithConfigTransactionStorage(txstorage.NewMemoryStorage()))
+
+		resp, err := h.runner.GetServerStatus(t.Context(), &pb.GetServerStatusRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, finitestate.StatusNew, resp.GetState())
+		assert.Equal(t, h.runner.startTime.Unix(), resp.GetStartTime().AsTime().Unix())
+		assert.Zero(t, resp.GetTransactionCount())
+		assert.Empty(t, resp.GetTransactionId())
+		assert.Empty(t, resp.GetListeners())
+	})
+
+	t.Run("with the current transaction", func(t *testing.T) {
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+		cfg.Listeners = listeners.ListenerCollection{
+			{ID: "public", Address: ":8080", Type: listeners.TypeHTTP},
+			{ID: "internal", Address: "127.0.0.1:9090", Type: listeners.TypeHTTP},
+		}
+
+		storage := txstorage.NewMemoryStorage()
+		tx, err := transaction.FromTest("status-test", cfg, slog.Default().Handler())
+		require.NoError(t, err)
+		require.NoError(t, storage.Add(tx))
+		storage.SetCurrent(tx)
+
+		other, err := transaction.FromTest("status-test-old", cfg, slog.Default().Handler())
+		require.NoError(t, err)
+		require.NoError(t, storage.Add(other))
+
+		harness := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t),
+			WithConfigTransactionStorage(storage))
+		harness.transitionToRunning()
+		harness.runner.startTime = time.Now().Add(-90 * time.Second)
+
+		resp, err := harness.runner.GetServerStatus(t.Context(), &pb.GetServerStatusRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, finitestate.StatusRunning, resp.GetState())
+		assert.GreaterOrEqual(t, resp.GetUptime().AsDuration(), 90*time.Second)
+		assert.Equal(t, cfg.Version, resp.GetConfigVersion())
+		assert.Equal(t, tx.GetTransactionID(), resp.GetTransactionId())
+		assert.Equal(t, tx.GetState(), resp.GetTransactionState())
+		assert.EqualValues(t, 2, resp.GetTransactionCount())
+		assert.Empty(t, resp.GetTransactionReason())
+
+		require.Len(t, resp.GetListeners(), 2)
+		assert.Equal(t, "public", resp.GetListeners()[0].GetId())
+		assert.Equal(t, ":8080", resp.GetListeners()[0].GetAddress())
+		assert.Equal(t, "HTTP", resp.GetListeners()[0].GetType())
+		assert.Equal(t, "internal", resp.GetListeners()[1].GetId())
+	})
+
+	t.Run("with a change reason", func(t *testing.T) {
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+
+		storage := txstorage.NewMemoryStorage()
+		tx, err := transaction.FromAPI("status-test", cfg, slog.Default().Handler(),
+			transaction.WithAudit("", "rolling back bad deploy", nil))
+		require.NoError(t, err)
+		require.NoError(t, storage.Add(tx))
+		storage.SetCurrent(tx)
+
+		h := newRunnerTestHarness(t, testutil.GetRandomListeningPort(t),
+			WithConfigTransactionStorage(storage))
+
+		resp, err := h.runner.GetServerStatus(t.Context(), &pb.GetServerStatusRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "rolling back bad deploy", resp.GetTransactionReason())
+	})
+}
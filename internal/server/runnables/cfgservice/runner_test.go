@@ -739,7 +739,7 @@ func TestEncodeDecodePageToken(t *testing.T) {
 		source := "api"
 
 		// Encode token
-		token, err := encodePageToken(offset, pageSize, state, source)
+		token, err := encodePageToken(offset, pageSize, state, source, time.Time{}, time.Time{})
 		require.NoError(t, err, "Should encode token successfully")
 		assert.NotEmpty(t, token, "Token should not be empty")
 
@@ -759,7 +759,7 @@ func TestEncodeDecodePageToken(t *testing.T) {
 		source := ""
 
 		// Encode token
-		token, err := encodePageToken(offset, pageSize, state, source)
+		token, err := encodePageToken(offset, pageSize, state, source, time.Time{}, time.Time{})
 		require.NoError(t, err, "Should encode token with empty strings")
 		assert.NotEmpty(t, token, "Token should not be empty")
 
@@ -772,6 +772,27 @@ func TestEncodeDecodePageToken(t *testing.T) {
 		assert.Equal(t, source, decoded.Source, "Source should match")
 	})
 
+	t.Run("Time range in token", func(t *testing.T) {
+		createdAfter := time.Date(2025, 6, 1, 12, 0, 0, 123, time.UTC)
+		createdBefore := createdAfter.Add(time.Hour)
+
+		token, err := encodePageToken(5, 10, "", "", createdAfter, createdBefore)
+		require.NoError(t, err, "Should encode token with time range")
+
+		decoded, err := decodePageToken(token)
+		require.NoError(t, err, "Should decode token with time range")
+		assert.True(t, createdAfter.Equal(decoded.CreatedAfter), "CreatedAfter should match")
+		assert.True(t, createdBefore.Equal(decoded.CreatedBefore), "CreatedBefore should match")
+
+		// An open range leaves both times zero
+		token, err = encodePageToken(5, 10, "", "", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		decoded, err = decodePageToken(token)
+		require.NoError(t, err)
+		assert.True(t, decoded.CreatedAfter.IsZero(), "CreatedAfter should be zero")
+		assert.True(t, decoded.CreatedBefore.IsZero(), "CreatedBefore should be zero")
+	})
+
 	t.Run("Decode empty token", func(t *testing.T) {
 		decoded, err := decodePageToken("")
 		require.NoError(t, err, "Should handle empty token gracefully")
@@ -804,7 +825,7 @@ func TestEncodeDecodePageToken(t *testing.T) {
 		source := "very_long_source_string_that_should_also_work_fine"
 
 		// Encode token
-		token, err := encodePageToken(offset, pageSize, state, source)
+		token, err := encodePageToken(offset, pageSize, state, source, time.Time{}, time.Time{})
 		require.NoError(t, err, "Should encode token with large values")
 		assert.NotEmpty(t, token, "Token should not be empty")
 
@@ -824,7 +845,7 @@ func TestEncodeDecodePageToken(t *testing.T) {
 		source := "test"
 
 		// Encode token (should work even with negative values)
-		token, err := encodePageToken(offset, pageSize, state, source)
+		token, err := encodePageToken(offset, pageSize, state, source, time.Time{}, time.Time{})
 		require.NoError(t, err, "Should encode token with negative values")
 
 		// Decode token
@@ -0,0 +1,152 @@
+package cfgservice
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	txstate "github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// newWatchTestClient serves the harness runner over bufconn and returns a client.
+func newWatchTestClient(t *testing.T, h *testHarness) pb.ConfigServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterConfigServiceServer(grpcServer, h.runner)
+	go func() {
+		if err := grpcServer.Serve(listener); err != nil {
+			t.Logf("gRPC server stopped: %v", err)
+		}
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(bufDialer(listener)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return pb.NewConfigServiceClient(conn)
+}
+
+func newWatchTestTransaction(t *testing.T) *transaction.ConfigTransaction {
+	t.Helper()
+	cfg, err := config.NewFromProto(&pb.ServerConfig{Version: proto.String(config.VersionLatest)})
+	require.NoError(t, err)
+	tx, err := transaction.FromTest(t.Name(), cfg, slog.Default().Handler())
+	require.NoError(t, err)
+	return tx
+}
+
+func TestWatchConfigTransaction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing transaction ID", func(t *testing.T) {
+		h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+		client := newWatchTestClient(t, h)
+
+		stream, err := client.WatchConfigTransaction(t.Context(), &pb.WatchConfigTransactionRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("unknown transaction ID", func(t *testing.T) {
+		h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+		client := newWatchTestClient(t, h)
+
+		stream, err := client.WatchConfigTransaction(t.Context(), &pb.WatchConfigTransactionRequest{
+			TransactionId: proto.String("does-not-exist"),
+		})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("streams state changes until terminal", func(t *testing.T) {
+		h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+		client := newWatchTestClient(t, h)
+		tx := newWatchTestTransaction(t)
+		h.txStorage.AddTransaction(tx)
+
+		stream, err := client.WatchConfigTransaction(t.Context(), &pb.WatchConfigTransactionRequest{
+			TransactionId: proto.String(tx.GetTransactionID()),
+		})
+		require.NoError(t, err)
+
+		first, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, tx.GetTransactionID(), first.GetTransactionId())
+		assert.Equal(t, txstate.StateCreated, first.GetState())
+		assert.Empty(t, first.GetParticipant())
+		assert.False(t, first.GetTerminal())
+		assert.NotNil(t, first.GetTimestamp())
+
+		require.NoError(t, tx.RunValidation())
+		require.NoError(t, tx.BeginExecution())
+		require.NoError(t, tx.RegisterParticipant("http_listener"))
+		require.NoError(t, tx.MarkError(assert.AnError))
+
+		var (
+			states       []string
+			participants []string
+			last         *pb.WatchConfigTransactionResponse
+		)
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			last = resp
+			if resp.GetParticipant() != "" {
+				participants = append(participants, resp.GetParticipant())
+				continue
+			}
+			states = append(states, resp.GetState())
+		}
+
+		require.NotNil(t, last)
+		assert.True(t, last.GetTerminal())
+		assert.Equal(t, txstate.StateError, last.GetState())
+		assert.Equal(t, txstate.StateError, states[len(states)-1])
+		assert.Contains(t, participants, "http_listener")
+	})
+
+	t.Run("already terminal transaction", func(t *testing.T) {
+		h := newTestHarness(t, testutil.GetRandomListeningPort(t))
+		client := newWatchTestClient(t, h)
+		tx := newWatchTestTransaction(t)
+		require.NoError(t, tx.MarkError(assert.AnError))
+		h.txStorage.AddTransaction(tx)
+
+		stream, err := client.WatchConfigTransaction(t.Context(), &pb.WatchConfigTransactionRequest{
+			TransactionId: proto.String(tx.GetTransactionID()),
+		})
+		require.NoError(t, err)
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, txstate.StateError, resp.GetState())
+		assert.True(t, resp.GetTerminal())
+
+		_, err = stream.Recv()
+		assert.Equal(t, io.EOF, err)
+	})
+}
@@ -10,6 +10,7 @@ import (
 
 	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 // listener defines the interface needed for a network listener
@@ -27,6 +28,31 @@ type GRPCManager struct {
 	listener   listener
 }
 
+// Option configures optional features of the gRPC server
+type Option func(*options)
+
+type options struct {
+	reflection    bool
+	serverOptions []grpc.ServerOption
+}
+
+// WithReflection registers the gRPC server reflection service, which lets
+// clients list the server's services and message types without the generated
+// stubs.
+func WithReflection(enabled bool) Option {
+	return func(o *options) {
+		o.reflection = enabled
+	}
+}
+
+// WithServerOptions adds options to the gRPC server, such as transport
+// credentials and interceptors
+func WithServerOptions(opts ...grpc.ServerOption) Option {
+	return func(o *options) {
+		o.serverOptions = append(o.serverOptions, opts...)
+	}
+}
+
 // NewGRPCManager creates a new gRPC manager instance, which configures a gRPC server.
 // It parses the listen address, cleans up existing Unix sockets if necessary,
 // creates a listener, and configures the gRPC server, and abstracts the underlying
@@ -35,9 +61,15 @@ func NewGRPCManager(
 	logger *slog.Logger,
 	listenAddr string,
 	pbCfgService pb.ConfigServiceServer,
+	opts ...Option,
 ) (*GRPCManager, error) {
 	logger.Debug("Creating gRPC server", "requested_address", listenAddr)
 
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// 1. Parse network and address
 	network, address, err := parseListenAddr(listenAddr)
 	if err != nil {
@@ -61,8 +93,12 @@ func NewGRPCManager(
 	}
 
 	// 4. Create and register gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(o.serverOptions...)
 	pb.RegisterConfigServiceServer(grpcServer, pbCfgService)
+	if o.reflection {
+		reflection.Register(grpcServer)
+		logger.Debug("Registered gRPC server reflection")
+	}
 
 	// Log the actual listening address (useful for TCP port 0)
 	actualAddr := lis.Addr()
@@ -14,7 +14,10 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -61,6 +64,11 @@ func (l *testLoader) GetProtoConfig() *pb.ServerConfig {
 	return l.config
 }
 
+// Warnings returns no deprecation warnings
+func (l *testLoader) Warnings() []error {
+	return nil
+}
+
 // Validate ensures the config loader implements the interface
 func TestLoaderInterface(t *testing.T) {
 	var _ loader.Loader = &testLoader{}
@@ -206,6 +214,58 @@ func TestGRPCServer_Integration(t *testing.T) {
 	srv.GracefulStop()
 }
 
+// TestGRPCServer_Reflection tests that the reflection service is only
+// registered when enabled
+func TestGRPCServer_Reflection(t *testing.T) {
+	listServices := func(t *testing.T, opts ...Option) ([]string, error) {
+		t.Helper()
+
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+		srv, err := NewGRPCManager(logger, "localhost:0", new(testConfigServer), opts...)
+		require.NoError(t, err)
+		require.NoError(t, srv.Start(t.Context()))
+		t.Cleanup(srv.GracefulStop)
+
+		conn, err := grpc.NewClient(
+			srv.GetListenAddress(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, conn.Close()) })
+
+		stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(t.Context())
+		require.NoError(t, err)
+		err = stream.Send(&rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, svc := range resp.GetListServicesResponse().GetService() {
+			names = append(names, svc.GetName())
+		}
+		return names, nil
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		names, err := listServices(t, WithReflection(true))
+		require.NoError(t, err)
+		assert.Contains(t, names, pb.ConfigService_ServiceDesc.ServiceName)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, err := listServices(t)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
+
 // TestClientServerCommunication tests that the server implementation correctly implements GRPCServer
 // and properly handles client-server interactions over various network types
 func TestClientServerCommunication(t *testing.T) {
@@ -0,0 +1,88 @@
+// Package accesslog provides per-listener access logging that can be enabled,
+// disabled, or re-levelled at runtime without reloading the configuration.
+package accesslog
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Settings controls access logging for a single listener
+type Settings struct {
+	// Enabled turns on an access log entry for every request
+	Enabled bool
+
+	// Level is the level access log entries are written at
+	Level slog.Level
+}
+
+// Controller holds the access log settings of every listener. Settings are
+// read on each request, so changes apply to the next request handled.
+type Controller struct {
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	settings map[string]Settings
+}
+
+// NewController creates a Controller that writes access log entries to logger.
+// Access logging starts disabled for every listener.
+func NewController(logger *slog.Logger) *Controller {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Controller{
+		logger:   logger,
+		settings: make(map[string]Settings),
+	}
+}
+
+// Set replaces the access log settings of a listener
+func (c *Controller) Set(listenerID string, settings Settings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settings[listenerID] = settings
+}
+
+// Get returns the access log settings of a listener. Listeners that were never
+// set have access logging disabled.
+func (c *Controller) Get(listenerID string) Settings {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.settings[listenerID]
+}
+
+// Middleware returns a middleware that writes an access log entry for each
+// request on the listener while its access logging is enabled.
+func (c *Controller) Middleware(listenerID string) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		settings := c.Get(listenerID)
+		if !settings.Enabled {
+			rp.Next()
+			return
+		}
+
+		start := time.Now()
+		rp.Next()
+
+		r := rp.Request()
+		status := rp.Writer().Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		c.logger.LogAttrs(r.Context(), settings.Level, "access",
+			slog.String("listener_id", listenerID),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Int("size", rp.Writer().Size()),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("remote_addr", r.RemoteAddr),
+		)
+	}
+}
@@ -0,0 +1,81 @@
+package accesslog
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRoute(t *testing.T, c *Controller, listenerID string) *httpserver.Route {
+	t.Helper()
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = io.WriteString(w, "short and stout")
+	}, c.Middleware(listenerID))
+	require.NoError(t, err)
+	return route
+}
+
+func TestController(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		c := NewController(nil)
+		assert.Equal(t, Settings{}, c.Get("http"))
+	})
+
+	t.Run("set and get", func(t *testing.T) {
+		c := NewController(nil)
+		c.Set("http", Settings{Enabled: true, Level: slog.LevelWarn})
+		assert.Equal(t, Settings{Enabled: true, Level: slog.LevelWarn}, c.Get("http"))
+		assert.Equal(t, Settings{}, c.Get("other"))
+	})
+}
+
+func TestController_Middleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := NewController(logger)
+	route := newTestRoute(t, c, "http")
+
+	serve := func() {
+		rec := httptest.NewRecorder()
+		route.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/brew", nil))
+		assert.Equal(t, http.StatusTeapot, rec.Code)
+	}
+
+	serve()
+	assert.Empty(t, buf.String(), "nothing is logged while disabled")
+
+	c.Set("http", Settings{Enabled: true, Level: slog.LevelInfo})
+	serve()
+	out := buf.String()
+	assert.Contains(t, out, "level=INFO")
+	assert.Contains(t, out, "msg=access")
+	assert.Contains(t, out, "listener_id=http")
+	assert.Contains(t, out, "method=GET")
+	assert.Contains(t, out, "path=/brew")
+	assert.Contains(t, out, "status=418")
+	assert.Contains(t, out, "size=15")
+
+	buf.Reset()
+	c.Set("http", Settings{Enabled: true, Level: slog.LevelWarn})
+	serve()
+	assert.Contains(t, buf.String(), "level=WARN")
+
+	buf.Reset()
+	c.Set("http", Settings{Enabled: false})
+	serve()
+	serve()
+	assert.Empty(t, buf.String(), "logging stops once disabled")
+
+	// Other listeners are unaffected
+	c.Set("other", Settings{Enabled: true})
+	serve()
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"maps"
+	"net/http"
+	"sync"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// bodyLimits holds the request body size limit of each listener. Limits are
+// read on each request rather than built into the routes, since the cluster
+// keeps a server running when only its handlers change.
+type bodyLimits struct {
+	mu     sync.RWMutex
+	limits map[string]int64
+}
+
+// set replaces the limits of all listeners
+func (b *bodyLimits) set(limits map[string]int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limits = maps.Clone(limits)
+}
+
+// get returns the limit of a listener, 0 when its request bodies are unlimited
+func (b *bodyLimits) get(listenerID string) int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.limits[listenerID]
+}
+
+// Middleware returns a middleware enforcing the listener's limit. Requests
+// declaring a larger Content-Length are rejected with a 413, and other bodies
+// are wrapped in http.MaxBytesReader so reads past the limit fail. Apps and
+// middleware reading the body through apps.ReadBody then get
+// apps.ErrBodyTooLarge, which the dispatcher also answers with a 413.
+func (b *bodyLimits) Middleware(listenerID string) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		maxBytes := b.get(listenerID)
+		if maxBytes <= 0 {
+			rp.Next()
+			return
+		}
+
+		r := rp.Request()
+		if r.ContentLength > maxBytes {
+			http.Error(rp.Writer(), "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+			rp.Abort()
+			return
+		}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = http.MaxBytesReader(rp.Writer(), r.Body, maxBytes)
+		}
+		rp.Next()
+	}
+}
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBodyLimitRoute returns a route that echoes the request body read through
+// apps.ReadBody, answering apps.ErrBodyTooLarge with a 413
+func newBodyLimitRoute(t *testing.T, limits *bodyLimits, listenerID string) *httpserver.Route {
+	t.Helper()
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/", func(w http.ResponseWriter, r *http.Request) {
+		body, err := apps.ReadBody(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		_, _ = w.Write(body)
+	}, limits.Middleware(listenerID))
+	require.NoError(t, err)
+	return route
+}
+
+func TestBodyLimits_Middleware(t *testing.T) {
+	t.Parallel()
+
+	limits := &bodyLimits{}
+	limits.set(map[string]int64{"limited": 5})
+	limited := newBodyLimitRoute(t, limits, "limited")
+	unlimited := newBodyLimitRoute(t, limits, "unlimited")
+
+	t.Run("body within the limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345")))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "12345", w.Body.String())
+	})
+
+	t.Run("declared length over the limit is rejected", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456")))
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, "Request Entity Too Large\n", w.Body.String())
+	})
+
+	t.Run("undeclared length over the limit fails to read", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("123456")))
+		req.ContentLength = -1
+
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Contains(t, w.Body.String(), apps.ErrBodyTooLarge.Error())
+	})
+
+	t.Run("listener without a limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		unlimited.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456")))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "123456", w.Body.String())
+	})
+}
+
+func TestBodyLimits_Set(t *testing.T) {
+	t.Parallel()
+
+	limits := &bodyLimits{}
+	route := newBodyLimitRoute(t, limits, "http")
+	serve := func() int {
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456")))
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve())
+
+	// A new limit applies to the next request on an existing route
+	limits.set(map[string]int64{"http": 5})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, serve())
+
+	limits.set(nil)
+	assert.Equal(t, http.StatusOK, serve())
+}
+
+func TestListenerBodyLimits(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, listenerBodyLimits(nil))
+
+	adapter := &cfg.Adapter{
+		Listeners: map[string]cfg.ListenerConfig{
+			"limited":   {ID: "limited", MaxRequestBodyBytes: 1024},
+			"unlimited": {ID: "unlimited"},
+		},
+	}
+	assert.Equal(t, map[string]int64{"limited": 1024}, listenerBodyLimits(adapter))
+}
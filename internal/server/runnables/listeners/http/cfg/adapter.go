@@ -2,19 +2,27 @@
 package cfg
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/netip"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	httpMiddleware "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 )
 
@@ -45,10 +53,32 @@ type ListenerConfig struct {
 	Address string
 
 	// Timeouts
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
-	DrainTimeout time.Duration
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	DrainTimeout      time.Duration
+
+	// MaxRequestBodyBytes limits the size of request bodies, 0 is unlimited
+	MaxRequestBodyBytes int64
+
+	// MaxConcurrentRequests limits the requests handled at once, 0 is unlimited
+	MaxConcurrentRequests int
+
+	// H2C accepts HTTP/2 without TLS alongside HTTP/1
+	H2C bool
+
+	// TrustedProxies are the peers whose forwarding headers are honored when
+	// resolving the client IP
+	TrustedProxies []netip.Prefix
+
+	// NotFoundAppID is the app serving requests no route matches, empty for a
+	// plain 404
+	NotFoundAppID string
+
+	// MethodNotAllowedAppID is the app answering requests whose path only has
+	// routes for other methods, empty for a plain 405
+	MethodNotAllowedAppID string
 }
 
 // Adapter extracts HTTP-specific configuration from a domain config.
@@ -141,14 +171,26 @@ func extractListeners(
 
 		// Create listener config using the existing helper methods
 		listenerCfg := ListenerConfig{
-			ID:           listenerID,
-			Address:      listener.Address,
-			ReadTimeout:  listener.GetReadTimeout(),
-			WriteTimeout: listener.GetWriteTimeout(),
-			IdleTimeout:  listener.GetIdleTimeout(),
-			DrainTimeout: listener.GetDrainTimeout(),
+			ID:                    listenerID,
+			Address:               listener.Address,
+			ReadTimeout:           listener.GetReadTimeout(),
+			ReadHeaderTimeout:     listener.GetReadHeaderTimeout(),
+			WriteTimeout:          listener.GetWriteTimeout(),
+			IdleTimeout:           listener.GetIdleTimeout(),
+			DrainTimeout:          listener.GetDrainTimeout(),
+			MaxRequestBodyBytes:   listener.GetMaxRequestBodyBytes(),
+			MaxConcurrentRequests: listener.GetMaxConcurrentRequests(),
+			H2C:                   listener.GetH2C(),
+			NotFoundAppID:         listener.GetNotFoundAppID(),
+			MethodNotAllowedAppID: listener.GetMethodNotAllowedAppID(),
 		}
 
+		trustedProxies, err := validation.ParsePrefixes(listener.GetTrustedProxies())
+		if err != nil {
+			errz = append(errz, fmt.Errorf("listener %s trusted proxies: %w", listenerID, err))
+		}
+		listenerCfg.TrustedProxies = trustedProxies
+
 		// Add to the map
 		listeners[listenerID] = listenerCfg
 	}
@@ -171,16 +213,22 @@ func extractRoutes(
 
 	// Initialize empty routes slice for each listener
 	for id := range listeners {
-		routes[id] = []httpserver.Route{}
+		var candidates []routeCandidate
 
 		// Process each endpoint for this HTTP listener
 		for endpoint := range cfg.Endpoints.FindByListenerID(id) {
+			if endpoint.Disabled {
+				logger.Debug("Skipping disabled endpoint", "endpoint", endpoint.ID, "listener", id)
+				continue
+			}
+
 			// Process HTTP routes for this endpoint
-			endpointRoutes, err := extractEndpointRoutes(
+			endpointCandidates, err := extractEndpointCandidates(
 				&endpoint,
 				id,
 				appCollection,
 				middlewareRegistry,
+				cfg.AppFallback,
 				logger,
 			)
 			if err != nil {
@@ -191,25 +239,125 @@ func extractRoutes(
 				continue
 			}
 
-			// Add routes to the map
-			routes[id] = append(routes[id], endpointRoutes...)
+			candidates = append(candidates, endpointCandidates...)
+		}
+
+		var unmatched unmatchedHandlers
+		var err error
+		unmatched.notFound, err = newListenerAppHandler(
+			cfg, id, listeners[id].NotFoundAppID, notFoundRouteName,
+			appCollection, middlewareRegistry, logger,
+		)
+		if err != nil {
+			errz = append(errz, fmt.Errorf("failed to build not found handler for listener %s: %w", id, err))
+		}
+		unmatched.methodNotAllowed, err = newListenerAppHandler(
+			cfg, id, listeners[id].MethodNotAllowedAppID, methodNotAllowedRouteName,
+			appCollection, middlewareRegistry, logger,
+		)
+		if err != nil {
+			errz = append(errz, fmt.Errorf("failed to build method not allowed handler for listener %s: %w", id, err))
+		}
+
+		// Merge routes sharing a path so request conditions are evaluated
+		// across all endpoints on this listener
+		listenerRoutes, err := buildDispatchRoutes(id, candidates, unmatched)
+		if err != nil {
+			errz = append(errz, fmt.Errorf("failed to build routes for listener %s: %w", id, err))
+			listenerRoutes = []httpserver.Route{}
 		}
+		routes[id] = listenerRoutes
 	}
 
 	return routes, errors.Join(errz...)
 }
 
+// newListenerAppHandler returns the handler serving a listener's unmatched
+// requests with the app appID, wrapped in the listener's default middlewares,
+// or nil when appID is empty. routeName names the handler's route in logs and
+// metrics. A not found app keeps the status it responds with, so e.g. a
+// single-page application can answer with a 200, see buildDispatchRoutes for
+// the status of a method not allowed app.
+func newListenerAppHandler(
+	cfg *config.Config,
+	listenerID, appID, routeName string,
+	appCollection *apps.AppInstances,
+	middlewareRegistry MiddlewareRegistry,
+	logger *slog.Logger,
+) (http.Handler, error) {
+	if appID == "" {
+		return nil, nil
+	}
+
+	kind := strings.ReplaceAll(routeName, "_", " ")
+	app, exists := appCollection.GetApp(appID)
+	if !exists {
+		return nil, fmt.Errorf("%s app %s not found in registry", kind, appID)
+	}
+
+	routeID := fmt.Sprintf("%s:%s", listenerID, routeName)
+	handlerFunc := newAppHandler(app, routeID, appID, cfg.AppFallback, logger)
+
+	var listenerMiddlewares middleware.MiddlewareCollection
+	if domainListener, ok := cfg.Listeners.FindByID(listenerID); ok {
+		// Merging sorts the defaults by ID, as in every route's chain
+		listenerMiddlewares = domainListener.Middlewares.Merge()
+	}
+	middlewares, err := buildMiddlewareSlice(listenerMiddlewares, middlewareRegistry, handlerFunc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build middleware for %s app %s: %w", kind, appID, err)
+	}
+
+	// The route is only used for its handler chain, its path is never
+	// registered
+	route, err := httpserver.NewRouteFromHandlerFunc(routeID, "/", handlerFunc, middlewares...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s route: %w", kind, err)
+	}
+	logger.Debug("Serving unmatched requests with listener app",
+		"listener", listenerID,
+		"route", routeName,
+		"app_id", appID,
+		"middleware_count", len(middlewares))
+	return route, nil
+}
+
 // extractEndpointRoutes extracts HTTP routes from an endpoint.
 // Returns a slice of httpserver.Route objects and any validation errors.
-// Routes are created with handlers that use the app instances from the registry.
+// Routes sharing a path are merged into a single route that dispatches on
+// request conditions, see buildDispatchRoutes.
 func extractEndpointRoutes(
 	endpoint *endpoints.Endpoint,
 	listenerID string,
 	appRegistry *apps.AppInstances,
 	middlewareRegistry MiddlewareRegistry,
+	fallback config.AppFallback,
 	logger *slog.Logger,
 ) ([]httpserver.Route, error) {
-	var httpServerRoutes []httpserver.Route
+	candidates, err := extractEndpointCandidates(
+		endpoint,
+		listenerID,
+		appRegistry,
+		middlewareRegistry,
+		fallback,
+		logger,
+	)
+	httpServerRoutes, buildErr := buildDispatchRoutes(listenerID, candidates, unmatchedHandlers{})
+	return httpServerRoutes, errors.Join(err, buildErr)
+}
+
+// extractEndpointCandidates extracts HTTP route candidates from an endpoint.
+// Returns a slice of routeCandidate objects and any validation errors.
+// Routes are created with handlers that use the app instances from the registry.
+func extractEndpointCandidates(
+	endpoint *endpoints.Endpoint,
+	listenerID string,
+	appRegistry *apps.AppInstances,
+	middlewareRegistry MiddlewareRegistry,
+	fallback config.AppFallback,
+	logger *slog.Logger,
+) ([]routeCandidate, error) {
+	var candidates []routeCandidate
 	errz := []error{}
 
 	// Extract HTTP routes using the endpoint's built-in method
@@ -253,21 +401,14 @@ func extractEndpointRoutes(
 			"middleware_count", len(httpRoute.Middlewares))
 
 		// Create a handler function for this route
-		handlerFunc := func(w http.ResponseWriter, r *http.Request) {
-			// Call the app handler
-			err := app.HandleHTTP(r.Context(), w, r)
-			if err != nil {
-				logger.Error("Error handling request",
-					"path", r.URL.Path,
-					"appID", httpRoute.AppID,
-					"error", err)
-
-				http.Error(w, "Internal Server Error", http.StatusInternalServerError)
-			}
-		}
+		handlerFunc := newAppHandler(app, httpRoute.ID, httpRoute.AppID, fallback, logger)
 
 		// Build middleware slice from registry
-		middlewares, err := buildMiddlewareSlice(httpRoute.Middlewares, middlewareRegistry)
+		middlewares, err := buildMiddlewareSlice(
+			httpRoute.Middlewares,
+			middlewareRegistry,
+			handlerFunc,
+		)
 		if err != nil {
 			errz = append(
 				errz,
@@ -294,10 +435,128 @@ func extractEndpointRoutes(
 			continue
 		}
 
-		httpServerRoutes = append(httpServerRoutes, *route)
+		candidates = append(candidates, routeCandidate{
+			path:       httpRoute.PathPrefix,
+			route:      route,
+			conditions: httpRoute.Conditions,
+			pathRegex:  httpRoute.PathRegex,
+			priority:   httpRoute.Priority,
+		})
+	}
+
+	return candidates, errors.Join(errz...)
+}
+
+// newAppHandler returns the handler dispatching requests to app. An app that
+// reports apps.ErrBodyTooLarge gets a 413, apps.ErrAppUnavailable the fallback
+// response (503), apps.ErrServerShutdown a 503, and any other error a 500,
+// unless the app already started its response, e.g. a streaming script. A
+// request canceled by its client gets no response and is recorded with
+// apps.StatusClientClosedRequest. The error is also stored in the
+// request's apps.ErrorReport, when a middleware added one. Request and response
+// body sizes are recorded per app in the metrics package, independent of any
+// logging middleware, and the request count and duration per route and app.
+func newAppHandler(
+	app apps.App,
+	routeID string,
+	appID string,
+	fallback config.AppFallback,
+	logger *slog.Logger,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var body *countingReadCloser
+		if r.Body != nil && r.Body != http.NoBody {
+			body = &countingReadCloser{ReadCloser: r.Body}
+			r.Body = body
+		}
+		cw := &countingResponseWriter{ResponseWriter: w}
+		start := time.Now()
+		defer func() {
+			metrics.AppRequestSize.WithLabelValues(appID).Observe(float64(requestSize(r, body)))
+			metrics.AppResponseSize.WithLabelValues(appID).Observe(float64(cw.size))
+			metrics.HTTPRequestDuration.WithLabelValues(routeID, appID).
+				Observe(time.Since(start).Seconds())
+			metrics.HTTPRequests.WithLabelValues(routeID, appID, strconv.Itoa(cw.Status())).Inc()
+		}()
+		w = cw
+
+		err := app.HandleHTTP(r.Context(), w, r)
+		if err == nil {
+			return
+		}
+		if report := apps.ErrorReportFromContext(r.Context()); report != nil {
+			report.Err = err
+		}
+
+		if errors.Is(err, apps.ErrServerShutdown) {
+			logger.Warn("Request interrupted by server shutdown",
+				"path", r.URL.Path,
+				"appID", appID,
+				"error", err)
+			if !cw.wroteHeader {
+				http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			}
+			return
+		}
+
+		if clientClosedRequest(r, err) {
+			logger.Debug("Request canceled by client",
+				"path", r.URL.Path,
+				"appID", appID,
+				"error", err)
+			if !cw.wroteHeader {
+				cw.status = apps.StatusClientClosedRequest
+			}
+			return
+		}
+
+		if cw.wroteHeader {
+			logger.Error("Error handling request after the response was started",
+				"path", r.URL.Path,
+				"appID", appID,
+				"error", err)
+			return
+		}
+
+		if errors.Is(err, apps.ErrBodyTooLarge) {
+			logger.Warn("Request body too large",
+				"path", r.URL.Path,
+				"appID", appID,
+				"error", err)
+			http.Error(w, "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if errors.Is(err, apps.ErrAppUnavailable) {
+			logger.Error("App unavailable, serving fallback response",
+				"path", r.URL.Path,
+				"appID", appID,
+				"error", err)
+			metrics.AppFallbackResponses.WithLabelValues(appID).Inc()
+
+			w.Header().Set("Content-Type", fallback.GetContentType())
+			w.Header().Set("X-Content-Type-Options", "nosniff")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, fallback.GetBody())
+			return
+		}
+
+		logger.Error("Error handling request",
+			"path", r.URL.Path,
+			"appID", appID,
+			"error", err)
+
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 	}
+}
 
-	return httpServerRoutes, errors.Join(errz...)
+// clientClosedRequest reports whether err comes from the client going away,
+// rather than from the server canceling the request when it shuts down
+func clientClosedRequest(r *http.Request, err error) bool {
+	ctx := r.Context()
+	return errors.Is(err, context.Canceled) &&
+		errors.Is(ctx.Err(), context.Canceled) &&
+		!errors.Is(context.Cause(ctx), apps.ErrServerShutdown)
 }
 
 // TODO: This is a placeholder handler function that will be replaced in the real implementation.
@@ -340,10 +599,13 @@ func (a *Adapter) GetRoutesForListener(listenerID string) []httpserver.Route {
 	return routes
 }
 
-// buildMiddlewareSlice builds a slice of middleware handlers from the pool
+// buildMiddlewareSlice builds a slice of middleware handlers from the pool. A
+// middleware implementing httpMiddleware.ChainWrapper ends the slice: it wraps
+// the handlers of the middlewares after it and the app handler.
 func buildMiddlewareSlice(
 	middlewares middleware.MiddlewareCollection,
 	registry MiddlewareRegistry,
+	app http.HandlerFunc,
 ) ([]httpserver.HandlerFunc, error) {
 	if len(middlewares) == 0 {
 		return nil, nil
@@ -351,7 +613,7 @@ func buildMiddlewareSlice(
 
 	handlers := make([]httpserver.HandlerFunc, 0, len(middlewares))
 
-	for _, mw := range middlewares {
+	for i, mw := range middlewares {
 		mwType := mw.Config.Type()
 
 		// Look up in pool - check type first for better error messages
@@ -369,6 +631,17 @@ func buildMiddlewareSlice(
 			)
 		}
 
+		if wrapper, ok := instance.(httpMiddleware.ChainWrapper); ok {
+			next, err := buildMiddlewareSlice(middlewares[i+1:], registry, app)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, func(rp *httpserver.RequestProcessor) {
+				app.ServeHTTP(rp.Writer(), rp.Request())
+			})
+			return append(handlers, wrapper.WrapChain(next)), nil
+		}
+
 		// Extract handler function from interface
 		handlers = append(handlers, instance.Middleware())
 	}
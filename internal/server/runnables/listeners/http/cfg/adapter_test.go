@@ -1,23 +1,35 @@
 package cfg
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config"
 	configApps "github.com/atlanticdynamic/firelynx/internal/config/apps"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/headers"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners"
 	"github.com/atlanticdynamic/firelynx/internal/config/listeners/options"
+	"github.com/atlanticdynamic/firelynx/internal/config/validation"
 	serverApps "github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/atlanticdynamic/firelynx/internal/server/apps/mocks"
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -100,10 +112,15 @@ func TestExtractListeners(t *testing.T) {
 		Address: "localhost:8080",
 		Type:    listeners.TypeHTTP,
 		Options: options.HTTP{
-			ReadTimeout:  time.Second * 30,
-			WriteTimeout: time.Second * 30,
-			IdleTimeout:  time.Second * 60,
-			DrainTimeout: time.Second * 10,
+			ReadTimeout:           time.Second * 30,
+			ReadHeaderTimeout:     time.Second * 3,
+			WriteTimeout:          time.Second * 30,
+			IdleTimeout:           time.Second * 60,
+			DrainTimeout:          time.Second * 10,
+			MaxRequestBodyBytes:   1024,
+			MaxConcurrentRequests: 50,
+			H2C:                   true,
+			TrustedProxies:        []string{"10.0.0.0/8", "127.0.0.1"},
 		},
 	}
 
@@ -130,9 +147,17 @@ func TestExtractListeners(t *testing.T) {
 	assert.Equal(t, "http-1", listener1.ID, "Listener ID should match")
 	assert.Equal(t, "localhost:8080", listener1.Address, "Listener address should match")
 	assert.Equal(t, time.Second*30, listener1.ReadTimeout, "Read timeout should match")
+	assert.Equal(t, time.Second*3, listener1.ReadHeaderTimeout, "Read header timeout should match")
 	assert.Equal(t, time.Second*30, listener1.WriteTimeout, "Write timeout should match")
 	assert.Equal(t, time.Second*60, listener1.IdleTimeout, "Idle timeout should match")
 	assert.Equal(t, time.Second*10, listener1.DrainTimeout, "Drain timeout should match")
+	assert.Equal(t, int64(1024), listener1.MaxRequestBodyBytes, "Body size limit should match")
+	assert.Equal(t, 50, listener1.MaxConcurrentRequests, "Concurrency limit should match")
+	assert.True(t, listener1.H2C, "H2C should match")
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("127.0.0.1/32"),
+	}, listener1.TrustedProxies, "Trusted proxies should be parsed")
 
 	// Check second listener
 	listener2, ok := listenerMap["http-2"]
@@ -141,6 +166,23 @@ func TestExtractListeners(t *testing.T) {
 	assert.Equal(t, "localhost:8081", listener2.Address, "Listener address should match")
 	assert.Equal(t, time.Second*20, listener2.ReadTimeout, "Read timeout should match")
 	assert.Equal(t, time.Second*20, listener2.WriteTimeout, "Write timeout should match")
+	assert.Equal(t, options.DefaultHTTPReadHeaderTimeout, listener2.ReadHeaderTimeout,
+		"Read header timeout should default")
+	assert.Zero(t, listener2.MaxRequestBodyBytes, "Body size should be unlimited by default")
+	assert.Zero(t, listener2.MaxConcurrentRequests, "Concurrency should be unlimited by default")
+	assert.Empty(t, listener2.TrustedProxies, "No proxies should be trusted by default")
+
+	t.Run("invalid trusted proxy", func(t *testing.T) {
+		invalid := listeners.Listener{
+			ID:      "http-3",
+			Address: "localhost:8082",
+			Type:    listeners.TypeHTTP,
+			Options: options.HTTP{TrustedProxies: []string{"not-an-ip"}},
+		}
+		_, err := extractListeners(listeners.ListenerCollection{invalid})
+		require.ErrorIs(t, err, validation.ErrInvalidPrefix)
+		assert.Contains(t, err.Error(), "listener http-3 trusted proxies")
+	})
 }
 
 // MockListener implements the listeners.Listener interface for testing
@@ -339,6 +381,7 @@ func TestExtractEndpointRoutes(t *testing.T) {
 				tt.listenerID,
 				appInstances,
 				make(MiddlewareRegistry),
+				config.AppFallback{},
 				logger,
 			)
 
@@ -557,6 +600,98 @@ func TestNewAdapterWithRoutes(t *testing.T) {
 		assert.Len(t, adapter.Routes["http-1"], 1)
 	})
 
+	t.Run("endpoint on several listeners", func(t *testing.T) {
+		expandedApp := mocks.NewMockApp("test-app#0:0")
+		appInstances, err := serverApps.NewAppInstances([]serverApps.App{expandedApp})
+		require.NoError(t, err)
+
+		cfg := &config.Config{
+			Version: config.VersionLatest,
+			Listeners: listeners.ListenerCollection{
+				{ID: "http", Address: ":8080", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+				{ID: "https", Address: ":8443", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+				{ID: "admin", Address: ":9090", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+			},
+			Endpoints: endpoints.EndpointCollection{
+				endpoints.Endpoint{
+					ID:          "endpoint-1",
+					ListenerID:  "http",
+					ListenerIDs: []string{"https"},
+					Routes: routes.RouteCollection{
+						routes.Route{
+							AppID:     "test-app",
+							Condition: &conditions.HTTP{PathPrefix: "/api/v1"},
+							App:       &configApps.App{ID: "test-app#0:0"},
+						},
+					},
+				},
+			},
+		}
+
+		provider := &MockConfigProvider{
+			config:       cfg,
+			txID:         "test-tx-id",
+			appInstances: appInstances,
+		}
+
+		adapter, err := NewAdapter(provider, nil)
+		require.NoError(t, err)
+		assert.Len(t, adapter.Listeners, 3)
+		require.Len(t, adapter.Routes["http"], 1)
+		require.Len(t, adapter.Routes["https"], 1)
+		assert.Equal(t, "/api/v1", adapter.Routes["http"][0].Path)
+		assert.Equal(t, "/api/v1", adapter.Routes["https"][0].Path)
+		assert.Empty(t, adapter.Routes["admin"])
+	})
+
+	t.Run("disabled endpoints and routes", func(t *testing.T) {
+		expandedApp := mocks.NewMockApp("test-app#0:0")
+		appInstances, err := serverApps.NewAppInstances([]serverApps.App{expandedApp})
+		require.NoError(t, err)
+
+		route := func(path string, disabled bool) routes.Route {
+			return routes.Route{
+				AppID:     "test-app",
+				Condition: &conditions.HTTP{PathPrefix: path},
+				App:       &configApps.App{ID: "test-app#0:0"},
+				Disabled:  disabled,
+			}
+		}
+		cfg := &config.Config{
+			Version: config.VersionLatest,
+			Listeners: listeners.ListenerCollection{
+				{ID: "http", Address: ":8080", Type: listeners.TypeHTTP, Options: options.NewHTTP()},
+			},
+			Endpoints: endpoints.EndpointCollection{
+				endpoints.Endpoint{
+					ID:         "enabled",
+					ListenerID: "http",
+					Routes: routes.RouteCollection{
+						route("/api/v1", false),
+						route("/api/old", true),
+					},
+				},
+				endpoints.Endpoint{
+					ID:         "disabled",
+					ListenerID: "http",
+					Disabled:   true,
+					Routes:     routes.RouteCollection{route("/admin", false)},
+				},
+			},
+		}
+
+		provider := &MockConfigProvider{
+			config:       cfg,
+			txID:         "test-tx-id",
+			appInstances: appInstances,
+		}
+
+		adapter, err := NewAdapter(provider, nil)
+		require.NoError(t, err)
+		require.Len(t, adapter.Routes["http"], 1)
+		assert.Equal(t, "/api/v1", adapter.Routes["http"][0].Path)
+	})
+
 	t.Run("adapter without app collection", func(t *testing.T) {
 		cfg := &config.Config{
 			Version: config.VersionLatest,
@@ -660,6 +795,7 @@ func TestExtractEndpointRoutesErrorHandling(t *testing.T) {
 		"http-1",
 		appInstances,
 		make(MiddlewareRegistry),
+		config.AppFallback{},
 		logger,
 	)
 	require.NoError(t, err) // Route creation succeeds
@@ -679,6 +815,550 @@ func TestExtractEndpointRoutesErrorHandling(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+func TestExtractEndpointRoutesAppUnavailable(t *testing.T) {
+	t.Parallel()
+
+	var logBuf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logBuf, nil))
+
+	// The expanded app fails as if its evaluator couldn't be initialized
+	baseApp := mocks.NewMockApp("flaky-app")
+	expandedApp := mocks.NewMockApp("flaky-app#0:0")
+	appInstances, err := serverApps.NewAppInstances([]serverApps.App{baseApp, expandedApp})
+	require.NoError(t, err)
+
+	endpoint := &endpoints.Endpoint{
+		ID:         "test-endpoint",
+		ListenerID: "http-1",
+		Routes: routes.RouteCollection{
+			routes.Route{
+				AppID:     "flaky-app",
+				Condition: &conditions.HTTP{PathPrefix: "/api/flaky", Method: "GET"},
+				App:       &configApps.App{ID: "flaky-app#0:0"},
+			},
+		},
+	}
+
+	fallback := config.AppFallback{
+		Body:        `{"error": "temporarily unavailable"}`,
+		ContentType: "application/json",
+	}
+	routes, err := extractEndpointRoutes(
+		endpoint,
+		"http-1",
+		appInstances,
+		make(MiddlewareRegistry),
+		fallback,
+		logger,
+	)
+	require.NoError(t, err)
+	require.Len(t, routes, 1)
+
+	expandedApp.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("%w: evaluator init failed", serverApps.ErrAppUnavailable)).
+		Once()
+
+	before := testutil.ToFloat64(metrics.AppFallbackResponses.WithLabelValues("flaky-app"))
+
+	w := httptest.NewRecorder()
+	routes[0].ServeHTTP(w, httptest.NewRequest("GET", "/api/flaky", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"error": "temporarily unavailable"}`, w.Body.String())
+	assert.Contains(t, logBuf.String(), "App unavailable, serving fallback response")
+	assert.Contains(t, logBuf.String(), "evaluator init failed")
+	assert.Equal(t, before+1,
+		testutil.ToFloat64(metrics.AppFallbackResponses.WithLabelValues("flaky-app")))
+	expandedApp.AssertExpectations(t)
+}
+
+func TestNewAppHandler_DefaultFallback(t *testing.T) {
+	t.Parallel()
+
+	app := mocks.NewMockApp("default-fallback-app")
+	app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Return(serverApps.ErrAppUnavailable).
+		Once()
+
+	handler := newAppHandler(
+		app,
+		"test-endpoint[0]",
+		"default-fallback-app",
+		config.AppFallback{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, config.DefaultAppFallbackContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, config.DefaultAppFallbackBody, w.Body.String())
+}
+
+func TestNewAppHandler_BodyTooLarge(t *testing.T) {
+	t.Parallel()
+
+	app := mocks.NewMockApp("upload-app")
+	app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Return(fmt.Errorf("%w: failed to read request body: %w",
+			serverApps.ErrAppUnavailable, serverApps.ErrBodyTooLarge)).
+		Once()
+
+	handler := newAppHandler(
+		app,
+		"test-endpoint[0]",
+		"upload-app",
+		config.AppFallback{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest("POST", "/", strings.NewReader("too large")))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Equal(t, "Request Entity Too Large\n", w.Body.String())
+}
+
+func TestNewAppHandler_ErrorAfterResponseStarted(t *testing.T) {
+	t.Parallel()
+
+	app := mocks.NewMockApp("stream-app")
+	app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			w := args.Get(1).(http.ResponseWriter)
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = io.WriteString(w, "partial")
+		}).
+		Return(errors.New("script failed mid-stream")).
+		Once()
+
+	var logBuf bytes.Buffer
+	handler := newAppHandler(
+		app,
+		"test-endpoint[0]",
+		"stream-app",
+		config.AppFallback{},
+		slog.New(slog.NewTextHandler(&logBuf, nil)),
+	)
+
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest("GET", "/", nil))
+
+	// The response is left as the app wrote it
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "partial", w.Body.String())
+	assert.Contains(t, logBuf.String(), "after the response was started")
+	assert.Contains(t, logBuf.String(), "script failed mid-stream")
+}
+
+// histogramSample returns the sample count and sum of a histogram series
+func histogramSample(t *testing.T, h *prometheus.HistogramVec, labels ...string) (uint64, float64) {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, h.WithLabelValues(labels...).(prometheus.Metric).Write(&m))
+	return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+}
+
+func TestNewAppHandler_SizeMetrics(t *testing.T) {
+	t.Parallel()
+
+	// Each app reads the whole request body and writes a 1000 byte response
+	newHandler := func(appID string) http.HandlerFunc {
+		app := mocks.NewMockApp(appID)
+		app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				w := args.Get(1).(http.ResponseWriter)
+				r := args.Get(2).(*http.Request)
+				_, _ = io.Copy(io.Discard, r.Body)
+				_, _ = w.Write(bytes.Repeat([]byte("x"), 600))
+				_, _ = w.Write(bytes.Repeat([]byte("x"), 400))
+			}).
+			Return(nil)
+		return newAppHandler(
+			app,
+			"test-endpoint[0]",
+			appID,
+			config.AppFallback{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+	}
+
+	t.Run("known content length", func(t *testing.T) {
+		handler := newHandler("size-app")
+
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("POST", "/", strings.NewReader("hello world")))
+		w = httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/", nil))
+		assert.Equal(t, 1000, w.Body.Len())
+
+		count, sum := histogramSample(t, metrics.AppRequestSize, "size-app")
+		assert.Equal(t, uint64(2), count)
+		assert.InDelta(t, 11, sum, 0)
+
+		count, sum = histogramSample(t, metrics.AppResponseSize, "size-app")
+		assert.Equal(t, uint64(2), count)
+		assert.InDelta(t, 2000, sum, 0)
+	})
+
+	t.Run("unknown content length counts bytes read", func(t *testing.T) {
+		handler := newHandler("chunked-size-app")
+
+		req := httptest.NewRequest("POST", "/", strings.NewReader(strings.Repeat("y", 300)))
+		req.ContentLength = -1
+		handler(httptest.NewRecorder(), req)
+
+		count, sum := histogramSample(t, metrics.AppRequestSize, "chunked-size-app")
+		assert.Equal(t, uint64(1), count)
+		assert.InDelta(t, 300, sum, 0)
+	})
+
+	t.Run("fallback responses are counted", func(t *testing.T) {
+		app := mocks.NewMockApp("fallback-size-app")
+		app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Return(serverApps.ErrAppUnavailable)
+		handler := newAppHandler(
+			app,
+			"test-endpoint[0]",
+			"fallback-size-app",
+			config.AppFallback{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+		_, sum := histogramSample(t, metrics.AppResponseSize, "fallback-size-app")
+		assert.InDelta(t, len(config.DefaultAppFallbackBody), sum, 0)
+	})
+}
+
+func TestExtractEndpointRoutes_RequestMetrics(t *testing.T) {
+	t.Parallel()
+
+	// Two routes on one endpoint dispatch to the same app
+	baseApp := mocks.NewMockApp("metrics-app")
+	expandedApp := mocks.NewMockApp("metrics-app#0:0")
+	appInstances, err := serverApps.NewAppInstances([]serverApps.App{baseApp, expandedApp})
+	require.NoError(t, err)
+
+	endpoint := &endpoints.Endpoint{
+		ID:         "metrics-endpoint",
+		ListenerID: "http-1",
+		Routes: routes.RouteCollection{
+			routes.Route{
+				AppID:     "metrics-app",
+				Condition: &conditions.HTTP{PathPrefix: "/found"},
+				App:       &configApps.App{ID: "metrics-app#0:0"},
+			},
+			routes.Route{
+				AppID:     "metrics-app",
+				Condition: &conditions.HTTP{PathPrefix: "/missing"},
+				App:       &configApps.App{ID: "metrics-app#0:0"},
+			},
+		},
+	}
+
+	httpRoutes, err := extractEndpointRoutes(
+		endpoint,
+		"http-1",
+		appInstances,
+		make(MiddlewareRegistry),
+		config.AppFallback{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	require.NoError(t, err)
+	require.Len(t, httpRoutes, 2)
+
+	expandedApp.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			w := args.Get(1).(http.ResponseWriter)
+			r := args.Get(2).(*http.Request)
+			if r.URL.Path == "/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = io.WriteString(w, "ok")
+		}).
+		Return(nil)
+
+	for _, route := range httpRoutes {
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", route.Path, nil))
+	}
+
+	// Requests are labeled by the configured route ID, not the request path
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		metrics.HTTPRequests.WithLabelValues("metrics-endpoint[0]", "metrics-app", "200")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		metrics.HTTPRequests.WithLabelValues("metrics-endpoint[1]", "metrics-app", "404")), 0)
+
+	count, _ := histogramSample(t, metrics.HTTPRequestDuration, "metrics-endpoint[0]", "metrics-app")
+	assert.Equal(t, uint64(1), count)
+	count, _ = histogramSample(t, metrics.HTTPRequestDuration, "metrics-endpoint[1]", "metrics-app")
+	assert.Equal(t, uint64(1), count)
+}
+
+func TestNewAppHandler_RequestMetricsStatus(t *testing.T) {
+	t.Parallel()
+
+	app := mocks.NewMockApp("status-app")
+	app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("app error")).
+		Once()
+
+	handler := newAppHandler(
+		app,
+		"status-endpoint[0]",
+		"status-app",
+		config.AppFallback{},
+		slog.New(slog.NewTextHandler(io.Discard, nil)),
+	)
+	handler(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	// The error response written by the handler is counted with its status
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		metrics.HTTPRequests.WithLabelValues("status-endpoint[0]", "status-app", "500")), 0)
+}
+
+func TestNewAppHandler_Cancellation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("client closed request", func(t *testing.T) {
+		t.Parallel()
+
+		app := mocks.NewMockApp("cancel-app")
+		app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w: script stopped", context.Canceled)).
+			Once()
+
+		handler := newAppHandler(
+			app,
+			"cancel-endpoint[0]",
+			"cancel-app",
+			config.AppFallback{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+
+		// Nothing is written for a client that went away
+		assert.Empty(t, w.Body.String())
+		assert.False(t, w.Flushed)
+		assert.InDelta(t, 1, testutil.ToFloat64(
+			metrics.HTTPRequests.WithLabelValues("cancel-endpoint[0]", "cancel-app", "499")), 0)
+	})
+
+	t.Run("server shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		app := mocks.NewMockApp("shutdown-app")
+		app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("%w: script stopped", serverApps.ErrServerShutdown)).
+			Once()
+
+		handler := newAppHandler(
+			app,
+			"shutdown-endpoint[0]",
+			"shutdown-app",
+			config.AppFallback{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		ctx, cancel := context.WithCancelCause(t.Context())
+		cancel(serverApps.ErrServerShutdown)
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/", nil).WithContext(ctx))
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "Service Unavailable\n", w.Body.String())
+	})
+
+	t.Run("canceled error without a canceled request", func(t *testing.T) {
+		t.Parallel()
+
+		app := mocks.NewMockApp("internal-cancel-app")
+		app.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Return(context.Canceled).
+			Once()
+
+		handler := newAppHandler(
+			app,
+			"internal-cancel-endpoint[0]",
+			"internal-cancel-app",
+			config.AppFallback{},
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+		)
+
+		w := httptest.NewRecorder()
+		handler(w, httptest.NewRequest("GET", "/", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
+
+func TestNewAdapter_NotFoundApp(t *testing.T) {
+	t.Parallel()
+
+	newConfig := func(notFoundAppID string) *config.Config {
+		response := headers.NewHeaderOperations(headers.ResponseHeaderOperationsType)
+		response.SetHeaders = map[string]string{"X-Listener": "default"}
+		httpOpts := options.NewHTTP()
+		httpOpts.NotFoundAppID = notFoundAppID
+		return &config.Config{
+			Version: config.VersionLatest,
+			Listeners: listeners.ListenerCollection{
+				{
+					ID:      "http",
+					Address: ":8080",
+					Type:    listeners.TypeHTTP,
+					Options: httpOpts,
+					Middlewares: middleware.MiddlewareCollection{
+						{ID: "00-headers", Config: headers.NewHeaders(nil, response)},
+					},
+				},
+			},
+			Endpoints: endpoints.EndpointCollection{
+				{
+					ID:         "api",
+					ListenerID: "http",
+					Routes: routes.RouteCollection{
+						{
+							AppID:     "api-app",
+							Condition: &conditions.HTTP{PathPrefix: "/api/"},
+							App:       &configApps.App{ID: "api-app#0:0"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	newProvider := func(t *testing.T, cfg *config.Config) *MockConfigProvider {
+		t.Helper()
+		notFoundApp := mocks.NewMockApp("not-found")
+		notFoundApp.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				w := args.Get(1).(http.ResponseWriter)
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = io.WriteString(w, "branded 404")
+			}).
+			Return(nil)
+		appInstances, err := serverApps.NewAppInstances([]serverApps.App{
+			mocks.NewMockApp("api-app#0:0"),
+			notFoundApp,
+		})
+		require.NoError(t, err)
+
+		registry, err := NewMiddlewareFactory().CreateFromDefinitions(cfg.Listeners[0].Middlewares)
+		require.NoError(t, err)
+		return &MockConfigProvider{
+			config:             cfg,
+			txID:               "test-tx-id",
+			appInstances:       appInstances,
+			middlewareRegistry: registry.GetRegistry(),
+		}
+	}
+
+	t.Run("unmatched requests are served by the app", func(t *testing.T) {
+		t.Parallel()
+		cfg := newConfig("not-found")
+		adapter, err := NewAdapter(newProvider(t, cfg), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "not-found", adapter.Listeners["http"].NotFoundAppID)
+
+		listenerRoutes := adapter.Routes["http"]
+		require.Len(t, listenerRoutes, 2)
+		assert.Equal(t, "/", listenerRoutes[1].Path)
+
+		// Through a mux, as the listener serves them
+		mux := http.NewServeMux()
+		for _, route := range listenerRoutes {
+			mux.Handle(route.Path, &route)
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "branded 404", w.Body.String())
+		assert.Equal(t, "default", w.Header().Get("X-Listener"), "listener middlewares apply")
+	})
+
+	t.Run("without a not found app", func(t *testing.T) {
+		t.Parallel()
+		adapter, err := NewAdapter(newProvider(t, newConfig("")), nil)
+		require.NoError(t, err)
+		require.Len(t, adapter.Routes["http"], 1)
+	})
+
+	t.Run("app missing from the registry", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewAdapter(newProvider(t, newConfig("missing")), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found app missing not found in registry")
+	})
+}
+
+func TestNewAdapter_MethodNotAllowedApp(t *testing.T) {
+	t.Parallel()
+
+	httpOpts := options.NewHTTP()
+	httpOpts.MethodNotAllowedAppID = "not-allowed"
+	cfg := &config.Config{
+		Version: config.VersionLatest,
+		Listeners: listeners.ListenerCollection{
+			{ID: "http", Address: ":8080", Type: listeners.TypeHTTP, Options: httpOpts},
+		},
+		Endpoints: endpoints.EndpointCollection{
+			{
+				ID:         "api",
+				ListenerID: "http",
+				Routes: routes.RouteCollection{
+					{
+						AppID:      "api-app",
+						Condition:  &conditions.HTTP{PathPrefix: "/api"},
+						Conditions: conditions.Collection{conditions.NewMethod("GET", "POST")},
+						App:        &configApps.App{ID: "api-app#0:0"},
+					},
+				},
+			},
+		},
+	}
+
+	notAllowedApp := mocks.NewMockApp("not-allowed")
+	notAllowedApp.On("HandleHTTP", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(1).(http.ResponseWriter), "try GET or POST")
+		}).
+		Return(nil)
+	appInstances, err := serverApps.NewAppInstances([]serverApps.App{
+		mocks.NewMockApp("api-app#0:0"),
+		notAllowedApp,
+	})
+	require.NoError(t, err)
+
+	adapter, err := NewAdapter(&MockConfigProvider{
+		config:       cfg,
+		txID:         "test-tx-id",
+		appInstances: appInstances,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "not-allowed", adapter.Listeners["http"].MethodNotAllowedAppID)
+
+	listenerRoutes := adapter.Routes["http"]
+	require.Len(t, listenerRoutes, 1)
+
+	w := httptest.NewRecorder()
+	listenerRoutes[0].ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, POST", w.Header().Get("Allow"))
+	assert.Equal(t, "try GET or POST", w.Body.String())
+}
+
 func TestExtractEndpointRoutesWithStaticData(t *testing.T) {
 	t.Parallel()
 
@@ -725,6 +1405,7 @@ func TestExtractEndpointRoutesWithStaticData(t *testing.T) {
 		"http-1",
 		appInstances,
 		make(MiddlewareRegistry),
+		config.AppFallback{},
 		logger,
 	)
 	require.NoError(t, err)
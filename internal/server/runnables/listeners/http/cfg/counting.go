@@ -0,0 +1,82 @@
+package cfg
+
+import (
+	"io"
+	"net/http"
+)
+
+// countingResponseWriter counts the response body bytes written by an app,
+// and records whether the app started its response and with which status.
+type countingResponseWriter struct {
+	http.ResponseWriter
+	size        int64
+	wroteHeader bool
+	status      int
+}
+
+// WriteHeader records that the response was started and calls the underlying
+// WriteHeader
+func (w *countingResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.status = code
+	}
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write counts the bytes written and calls the underlying Write
+func (w *countingResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.status = http.StatusOK
+	}
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.size += int64(n)
+	return n, err
+}
+
+// Status returns the response status, 200 when the app wrote nothing, as
+// net/http sends then
+func (w *countingResponseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// Flush implements http.Flusher when the underlying writer supports it
+func (w *countingResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (w *countingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// countingReadCloser counts the request body bytes read by an app.
+type countingReadCloser struct {
+	io.ReadCloser
+	size int64
+}
+
+// Read counts the bytes read and calls the underlying Read
+func (r *countingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// requestSize is the declared Content-Length, or the bytes the app read when
+// the length isn't known up front, e.g. for chunked requests.
+func requestSize(r *http.Request, body *countingReadCloser) int64 {
+	if r.ContentLength >= 0 {
+		return r.ContentLength
+	}
+	if body == nil {
+		return 0
+	}
+	return body.size
+}
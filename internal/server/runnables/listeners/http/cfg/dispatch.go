@@ -0,0 +1,328 @@
+package cfg
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+const (
+	// notFoundRouteName names the route serving a listener's not found app
+	notFoundRouteName = "not_found"
+
+	// methodNotAllowedRouteName names the route serving a listener's method not
+	// allowed app
+	methodNotAllowedRouteName = "method_not_allowed"
+)
+
+// unmatchedHandlers answer the requests no route of a listener serves
+type unmatchedHandlers struct {
+	// notFound serves requests no route matches, nil for a plain 404
+	notFound http.Handler
+
+	// methodNotAllowed serves requests whose path only has routes for other
+	// methods, nil for a plain 405
+	methodNotAllowed http.Handler
+}
+
+// routeCandidate is an HTTP route together with the request conditions that
+// select it among other routes registered on the same path.
+type routeCandidate struct {
+	path       string
+	route      *httpserver.Route
+	conditions conditions.Collection
+
+	// pathRegex is set for regex routes, whose path is the pattern's base path
+	pathRegex *conditions.HTTPRegex
+
+	// priority orders candidates sharing a path ahead of their rank
+	priority int
+}
+
+// plain reports whether the candidate matches every request on its path
+func (c routeCandidate) plain() bool {
+	return c.pathRegex == nil && len(c.conditions) == 0
+}
+
+// rank orders candidates sharing a path and priority from most to least
+// specific: regex routes with request conditions, regex routes, prefix routes
+// with request conditions, then unconditional prefix routes.
+func (c routeCandidate) rank() int {
+	rank := 0
+	if c.pathRegex == nil {
+		rank += 2
+	}
+	if len(c.conditions) == 0 {
+		rank++
+	}
+	return rank
+}
+
+// matchRequest reports whether the request satisfies the candidate's path
+// regex, if any, and all of its request conditions
+func (c routeCandidate) matchRequest(r *http.Request) bool {
+	if c.pathRegex != nil && !c.pathRegex.MatchRequest(r) {
+		return false
+	}
+	return c.conditions.MatchRequest(r)
+}
+
+// allowedMethods returns the methods the candidate accepts, provided the
+// request satisfies its path regex and all of its other request conditions.
+// A candidate without a method condition returns none: had its other
+// conditions matched, it would have served the request.
+func (c routeCandidate) allowedMethods(r *http.Request) []string {
+	if c.pathRegex != nil && !c.pathRegex.MatchRequest(r) {
+		return nil
+	}
+	var methods []string
+	for _, cond := range c.conditions {
+		if method, ok := cond.(*conditions.Method); ok {
+			methods = append(methods, method.Methods...)
+			continue
+		}
+		if !cond.MatchRequest(r) {
+			return nil
+		}
+	}
+	return methods
+}
+
+// buildDispatchRoutes converts route candidates into the routes registered on
+// the listener's mux, which only matches on path.
+//
+// A path served by a single unconditional prefix route is registered as-is.
+// Otherwise the candidates for that path are merged into one dispatch route
+// that serves the request with the first candidate that matches (see
+// compareCandidates). Unless priorities say otherwise, unconditional prefix
+// routes therefore act as the fallback for their path.
+//
+// When no candidate matches, the request is passed to the route registered on
+// the closest enclosing subtree path (e.g. "/" for "/users/"), as the mux would
+// have done had the path not been registered. If there is none, a request that
+// candidates would have matched but for their methods is answered by
+// unmatched.methodNotAllowed with a 405 and an Allow header listing the
+// methods of those candidates, any other by unmatched.notFound.
+//
+// A nil notFound answers with a plain 404, and a nil methodNotAllowed with a
+// plain 405. Otherwise notFound is also registered on "/", unless a route
+// already is, so it serves every request the mux has no route for.
+func buildDispatchRoutes(
+	listenerID string,
+	candidates []routeCandidate,
+	unmatched unmatchedHandlers,
+) ([]httpserver.Route, error) {
+	var paths []string
+	byPath := make(map[string][]routeCandidate)
+	for _, c := range candidates {
+		if _, seen := byPath[c.path]; !seen {
+			paths = append(paths, c.path)
+		}
+		byPath[c.path] = append(byPath[c.path], c)
+	}
+
+	handlers := make(map[string]http.Handler, len(paths)+1)
+	routesByPath := make(map[string]httpserver.Route, len(paths)+1)
+	notFound := unmatched.notFound
+	if notFound == nil {
+		notFound = http.NotFoundHandler()
+	} else if _, ok := byPath["/"]; !ok {
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			fmt.Sprintf("%s:%s", listenerID, notFoundRouteName),
+			"/",
+			notFound.ServeHTTP,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create not found route: %w", err)
+		}
+		paths = append(paths, "/")
+		handlers["/"] = notFound
+		routesByPath["/"] = *route
+	}
+
+	methodNotAllowed := unmatched.methodNotAllowed
+	if methodNotAllowed == nil {
+		methodNotAllowed = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		})
+	} else {
+		methodNotAllowed = methodNotAllowedStatus(methodNotAllowed)
+	}
+
+	// Build handlers for enclosing paths before the paths nested inside them,
+	// so each dispatch handler can fall back to its parent
+	buildOrder := slices.Clone(paths)
+	slices.SortStableFunc(buildOrder, func(a, b string) int { return len(a) - len(b) })
+
+	for _, path := range buildOrder {
+		group, ok := byPath[path]
+		if !ok {
+			continue // The not found route, built above
+		}
+		if len(group) == 1 && group[0].plain() {
+			handlers[path] = group[0].route
+			routesByPath[path] = *group[0].route
+			continue
+		}
+
+		ordered := slices.Clone(group)
+		slices.SortStableFunc(ordered, compareCandidates)
+
+		// A route enclosing the path serves its requests whatever their
+		// method, the not found route only those of no path
+		fallback, notAllowed := notFound, methodNotAllowed
+		if parent, ok := enclosingPath(path, handlers); ok {
+			fallback = handlers[parent]
+			if _, routed := byPath[parent]; routed {
+				notAllowed = nil
+			}
+		}
+
+		handler := dispatchHandler(ordered, fallback, notAllowed)
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			fmt.Sprintf("%s:%s", listenerID, path),
+			path,
+			handler,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create dispatch route for %s: %w", path, err)
+		}
+		handlers[path] = handler
+		routesByPath[path] = *route
+	}
+
+	routes := make([]httpserver.Route, 0, len(paths))
+	for _, path := range paths {
+		routes = append(routes, routesByPath[path])
+	}
+	return routes, nil
+}
+
+// compareCandidates orders candidates sharing a path in the order they are
+// tried: higher priorities first, then the most specific (see
+// routeCandidate.rank). Sorted stably, equal candidates keep declaration order.
+func compareCandidates(a, b routeCandidate) int {
+	if a.priority != b.priority {
+		return b.priority - a.priority
+	}
+	return a.rank() - b.rank()
+}
+
+// enclosingPath returns the longest subtree path (one ending in '/') among
+// handlers that contains path, excluding path itself. It walks up path's own
+// subtree prefixes, so the lookup costs O(len(path)) rather than a scan of
+// every handler on the listener.
+func enclosingPath(path string, handlers map[string]http.Handler) (string, bool) {
+	for i := len(path) - 2; i >= 0; i-- {
+		if path[i] != '/' {
+			continue
+		}
+		if _, ok := handlers[path[:i+1]]; ok {
+			return path[:i+1], true
+		}
+	}
+	return "", false
+}
+
+// dispatchHandler serves each request with the first candidate that matches,
+// or with fallback if none do. Named captures of a matching path regex are
+// exposed to the app as path params. With a methodNotAllowed handler, a
+// request that candidates only reject for its method is served by it instead
+// of fallback, after the Allow header is set to their sorted methods.
+func dispatchHandler(
+	candidates []routeCandidate,
+	fallback, methodNotAllowed http.Handler,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, c := range candidates {
+			if !c.matchRequest(r) {
+				continue
+			}
+			if c.pathRegex != nil {
+				if params := c.pathRegex.PathParams(r.URL.Path); len(params) > 0 {
+					r = r.WithContext(apps.WithPathParams(r.Context(), params))
+					for name, value := range params {
+						r.SetPathValue(name, value)
+					}
+				}
+			}
+			c.route.ServeHTTP(w, r)
+			return
+		}
+		if methodNotAllowed != nil {
+			if allowed := allowedMethods(candidates, r); len(allowed) > 0 {
+				w.Header().Set("Allow", strings.Join(allowed, ", "))
+				methodNotAllowed.ServeHTTP(w, r)
+				return
+			}
+		}
+		fallback.ServeHTTP(w, r)
+	}
+}
+
+// allowedMethods returns the sorted union of the methods accepted by the
+// candidates that only reject the request for its method
+func allowedMethods(candidates []routeCandidate, r *http.Request) []string {
+	var allowed []string
+	for _, c := range candidates {
+		for _, method := range c.allowedMethods(r) {
+			allowed = append(allowed, strings.ToUpper(method))
+		}
+	}
+	slices.Sort(allowed)
+	return slices.Compact(allowed)
+}
+
+// methodNotAllowedStatus wraps a method not allowed app so that a success
+// status it responds with is sent as 405. The app chooses the body and
+// headers, while clients still see the request was rejected.
+func methodNotAllowedStatus(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(&notAllowedResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+// notAllowedResponseWriter sends a 2xx status as 405
+type notAllowedResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// WriteHeader replaces a 2xx final status with 405
+func (w *notAllowedResponseWriter) WriteHeader(code int) {
+	if !w.wroteHeader && code >= http.StatusOK {
+		w.wroteHeader = true
+		if code < http.StatusMultipleChoices {
+			code = http.StatusMethodNotAllowed
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write sends the 405 status first when the app hasn't written one
+func (w *notAllowedResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher when the underlying writer supports it
+func (w *notAllowedResponseWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (w *notAllowedResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,540 @@
+package cfg
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/routes/conditions"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCandidate returns a candidate whose handler writes body.
+func newTestCandidate(
+	t testing.TB,
+	name, path, body string,
+	conds ...conditions.RequestMatcher,
+) routeCandidate {
+	t.Helper()
+	for _, c := range conds {
+		require.NoError(t, c.Validate())
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc(name, path, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, body)
+	})
+	require.NoError(t, err)
+	return routeCandidate{path: path, route: route, conditions: conds}
+}
+
+// newRegexCandidate returns a candidate on the pattern's base path whose
+// handler writes body followed by any path params.
+func newRegexCandidate(
+	t *testing.T,
+	name, pattern, body string,
+	conds ...conditions.RequestMatcher,
+) routeCandidate {
+	t.Helper()
+	regex := conditions.NewHTTPRegex(pattern, "")
+	require.NoError(t, regex.Validate())
+	for _, c := range conds {
+		require.NoError(t, c.Validate())
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc(name, regex.BasePath(), func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, body)
+		if id := apps.PathParams(r.Context())["id"]; id != "" {
+			_, _ = io.WriteString(w, ":"+id+":"+r.PathValue("id"))
+		}
+	})
+	require.NoError(t, err)
+	return routeCandidate{path: regex.BasePath(), route: route, conditions: conds, pathRegex: regex}
+}
+
+func serveRoute(route httpserver.Route, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestBuildDispatchRoutes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unconditional routes are registered unchanged", func(t *testing.T) {
+		a := newTestCandidate(t, "l:a", "/a", "a")
+		b := newTestCandidate(t, "l:b", "/b", "b")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{a, b}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		assert.True(t, routes[0].Equal(*a.route))
+		assert.True(t, routes[1].Equal(*b.route))
+	})
+
+	t.Run("header conditions select the route", func(t *testing.T) {
+		v1 := newTestCandidate(t, "l:v1", "/api", "v1")
+		v2 := newTestCandidate(t, "l:v2", "/api", "v2",
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact))
+		beta := newTestCandidate(t, "l:beta", "/api", "beta",
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact),
+			conditions.NewHeader("X-Beta", "on", conditions.MatchExact))
+
+		// The unconditional route is declared first but acts as the fallback
+		routes, err := buildDispatchRoutes("l", []routeCandidate{v1, beta, v2}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "/api", routes[0].Path)
+
+		tests := []struct {
+			name    string
+			headers map[string]string
+			want    string
+		}{
+			{"no headers", nil, "v1"},
+			{"version header", map[string]string{"X-API-Version": "2"}, "v2"},
+			{"all conditions ANDed", map[string]string{"X-API-Version": "2", "X-Beta": "on"}, "beta"},
+			{"partial match falls through", map[string]string{"X-Beta": "on"}, "v1"},
+			{"other version", map[string]string{"X-API-Version": "3"}, "v1"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/api", nil)
+				for k, v := range tt.headers {
+					req.Header.Set(k, v)
+				}
+				rec := serveRoute(routes[0], req)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, tt.want, rec.Body.String())
+			})
+		}
+	})
+
+	t.Run("method conditions fall through to the next route", func(t *testing.T) {
+		read := newTestCandidate(t, "l:read", "/items", "read", conditions.NewMethod("GET"))
+		write := newTestCandidate(t, "l:write", "/items", "write", conditions.NewMethod("POST", "PUT"))
+		other := newTestCandidate(t, "l:other", "/items", "other")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{read, write, other}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		for method, want := range map[string]string{
+			http.MethodGet:    "read",
+			http.MethodPost:   "write",
+			http.MethodPut:    "write",
+			http.MethodDelete: "other",
+		} {
+			rec := serveRoute(routes[0], httptest.NewRequest(method, "/items", nil))
+			assert.Equal(t, http.StatusOK, rec.Code, method)
+			assert.Equal(t, want, rec.Body.String(), method)
+		}
+	})
+
+	t.Run("higher priorities are tried first", func(t *testing.T) {
+		v1 := newTestCandidate(t, "l:v1", "/api", "v1")
+		v1.priority = 10
+		v2 := newTestCandidate(t, "l:v2", "/api", "v2",
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact))
+		get := newTestCandidate(t, "l:get", "/api", "get", conditions.NewMethod("GET"))
+		post := newTestCandidate(t, "l:post", "/api", "post", conditions.NewMethod("POST"))
+		post.priority = 10
+
+		// v1 and post share the highest priority, so both are tried ahead of
+		// the more specific v2
+		routes, err := buildDispatchRoutes("l", []routeCandidate{v2, get, v1, post}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("X-API-Version", "2")
+		assert.Equal(t, "v1", serveRoute(routes[0], req).Body.String())
+
+		// Equal priorities keep declaration order between equally specific
+		// candidates
+		v1.priority = 0
+		post.priority = 0
+		routes, err = buildDispatchRoutes("l", []routeCandidate{post, v2, get, v1}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "v2", serveRoute(routes[0], req).Body.String())
+		req = httptest.NewRequest(http.MethodPost, "/api", nil)
+		req.Header.Set("X-API-Version", "2")
+		assert.Equal(t, "post", serveRoute(routes[0], req).Body.String())
+	})
+
+	t.Run("no matching candidate returns 404", func(t *testing.T) {
+		v2 := newTestCandidate(t, "l:v2", "/api", "v2",
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{v2}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/api", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("regex routes take precedence over prefix routes", func(t *testing.T) {
+		list := newTestCandidate(t, "l:list", "/users/", "list")
+		byID := newRegexCandidate(t, "l:byid", `/users/(?P<id>\d+)`, "user")
+		beta := newRegexCandidate(t, "l:beta", `/users/(?P<id>\d+)`, "beta",
+			conditions.NewHeader("X-Beta", "on", conditions.MatchExact))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{list, byID, beta}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "/users/", routes[0].Path)
+
+		tests := []struct {
+			name    string
+			path    string
+			headers map[string]string
+			want    string
+		}{
+			{"regex match exposes captures", "/users/42", nil, "user:42:42"},
+			{"conditional regex first", "/users/42", map[string]string{"X-Beta": "on"}, "beta:42:42"},
+			{"regex mismatch falls back to prefix", "/users/abc", nil, "list"},
+			{"partial path match falls back to prefix", "/users/42/posts", nil, "list"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+				for k, v := range tt.headers {
+					req.Header.Set(k, v)
+				}
+				rec := serveRoute(routes[0], req)
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, tt.want, rec.Body.String())
+			})
+		}
+	})
+
+	t.Run("unmatched requests fall back to enclosing subtree route", func(t *testing.T) {
+		root := newTestCandidate(t, "l:root", "/", "root")
+		byID := newRegexCandidate(t, "l:byid", `/users/(?P<id>\d+)`, "user")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{byID, root}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		assert.Equal(t, "/users/", routes[0].Path)
+		assert.True(t, routes[1].Equal(*root.route))
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/users/7", nil))
+		assert.Equal(t, "user:7:7", rec.Body.String())
+
+		rec = serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/users/abc", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "root", rec.Body.String())
+	})
+
+	t.Run("empty candidates", func(t *testing.T) {
+		routes, err := buildDispatchRoutes("l", nil, unmatchedHandlers{})
+		require.NoError(t, err)
+		assert.Empty(t, routes)
+	})
+}
+
+func TestBuildDispatchRoutes_NotFound(t *testing.T) {
+	t.Parallel()
+
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "branded 404")
+	})
+
+	t.Run("registered on the root path", func(t *testing.T) {
+		api := newTestCandidate(t, "l:api", "/api/", "api")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{api}, unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+		assert.Equal(t, "/api/", routes[0].Path)
+		assert.Equal(t, "/", routes[1].Path)
+
+		rec := serveRoute(routes[1], httptest.NewRequest(http.MethodGet, "/missing", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "branded 404", rec.Body.String())
+	})
+
+	t.Run("unmatched conditions fall back to it", func(t *testing.T) {
+		v2 := newTestCandidate(t, "l:v2", "/api", "v2",
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{v2}, unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/api", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, "branded 404", rec.Body.String())
+	})
+
+	t.Run("root dispatch route falls back to it", func(t *testing.T) {
+		beta := newTestCandidate(t, "l:beta", "/", "beta",
+			conditions.NewHeader("X-Beta", "on", conditions.MatchExact))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{beta}, unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 1, "the root path already has a route")
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/anything", nil))
+		assert.Equal(t, "branded 404", rec.Body.String())
+	})
+
+	t.Run("unconditional root route serves everything", func(t *testing.T) {
+		root := newTestCandidate(t, "l:root", "/", "root")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{root}, unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.True(t, routes[0].Equal(*root.route))
+	})
+
+	t.Run("without candidates", func(t *testing.T) {
+		routes, err := buildDispatchRoutes("l", nil, unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+		assert.Equal(t, "/", routes[0].Path)
+	})
+}
+
+func TestBuildDispatchRoutes_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	newUsers := func(t *testing.T) []routeCandidate {
+		t.Helper()
+		return []routeCandidate{
+			newTestCandidate(t, "l:list", "/users", "list", conditions.NewMethod("get", "HEAD")),
+			newTestCandidate(t, "l:create", "/users", "create", conditions.NewMethod("POST")),
+			newTestCandidate(t, "l:beta", "/users", "beta",
+				conditions.NewMethod("DELETE"),
+				conditions.NewHeader("X-Beta", "on", conditions.MatchExact)),
+		}
+	}
+
+	t.Run("lists the methods of the routes on the path", func(t *testing.T) {
+		routes, err := buildDispatchRoutes("l", newUsers(t), unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPut, "/users", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Allow"),
+			"routes rejecting the request on other conditions are not listed")
+		assert.Equal(t, "Method Not Allowed\n", rec.Body.String())
+
+		req := httptest.NewRequest(http.MethodPut, "/users", nil)
+		req.Header.Set("X-Beta", "on")
+		rec = serveRoute(routes[0], req)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "DELETE, GET, HEAD, POST", rec.Header().Get("Allow"))
+	})
+
+	t.Run("allowed methods are still served", func(t *testing.T) {
+		routes, err := buildDispatchRoutes("l", newUsers(t), unmatchedHandlers{})
+		require.NoError(t, err)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPost, "/users", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "create", rec.Body.String())
+		assert.Empty(t, rec.Header().Get("Allow"))
+	})
+
+	t.Run("regex routes only count when the path matches", func(t *testing.T) {
+		byID := newRegexCandidate(t, "l:byID", `/items/(?P<id>\d+)`, "item",
+			conditions.NewMethod("GET"))
+		search := newRegexCandidate(t, "l:search", `/items/search`, "search",
+			conditions.NewMethod("POST"))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{byID, search}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 1)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodDelete, "/items/42", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET", rec.Header().Get("Allow"))
+
+		rec = serveRoute(routes[0], httptest.NewRequest(http.MethodDelete, "/items/abc", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Header().Get("Allow"))
+	})
+
+	t.Run("other mismatched conditions fall back as before", func(t *testing.T) {
+		v2 := newTestCandidate(t, "l:v2", "/api", "v2",
+			conditions.NewMethod("GET"),
+			conditions.NewHeader("X-API-Version", "2", conditions.MatchExact))
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{v2}, unmatchedHandlers{})
+		require.NoError(t, err)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPost, "/api", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Header().Get("Allow"))
+	})
+
+	t.Run("an enclosing route serves the request instead", func(t *testing.T) {
+		write := newTestCandidate(t, "l:write", "/api/users", "write", conditions.NewMethod("POST"))
+		root := newTestCandidate(t, "l:root", "/", "root")
+
+		routes, err := buildDispatchRoutes("l", []routeCandidate{write, root}, unmatchedHandlers{})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodGet, "/api/users", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "root", rec.Body.String())
+		assert.Empty(t, rec.Header().Get("Allow"))
+	})
+
+	t.Run("takes precedence over the not found app", func(t *testing.T) {
+		notFound := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "spa")
+		})
+
+		routes, err := buildDispatchRoutes("l", newUsers(t), unmatchedHandlers{notFound: notFound})
+		require.NoError(t, err)
+		require.Len(t, routes, 2)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPut, "/users", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Allow"))
+	})
+
+	t.Run("delegates to the method not allowed app", func(t *testing.T) {
+		var allow string
+		notAllowed := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			allow = w.Header().Get("Allow")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"error":"method not allowed"}`)
+		})
+
+		routes, err := buildDispatchRoutes("l", newUsers(t), unmatchedHandlers{methodNotAllowed: notAllowed})
+		require.NoError(t, err)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPut, "/users", nil))
+		assert.Equal(t, "GET, HEAD, POST", allow, "the app sees the Allow header")
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, "a success status becomes 405")
+		assert.Equal(t, "GET, HEAD, POST", rec.Header().Get("Allow"))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"error":"method not allowed"}`, rec.Body.String())
+	})
+
+	t.Run("the app's error status is kept", func(t *testing.T) {
+		notAllowed := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		})
+
+		routes, err := buildDispatchRoutes("l", newUsers(t), unmatchedHandlers{methodNotAllowed: notAllowed})
+		require.NoError(t, err)
+
+		rec := serveRoute(routes[0], httptest.NewRequest(http.MethodPut, "/users", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestEnclosingPath(t *testing.T) {
+	t.Parallel()
+
+	noop := http.NotFoundHandler()
+	handlers := map[string]http.Handler{
+		"/":            noop,
+		"/api/":        noop,
+		"/api/v1":      noop, // not a subtree path, never encloses
+		"/api/v1/svc/": noop,
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/svc/items/", want: "/api/v1/svc/"},
+		{path: "/api/v1/svc/", want: "/api/"},
+		{path: "/api/v1/other/", want: "/api/"},
+		{path: "/api/", want: "/"},
+		{path: "/docs", want: "/"},
+		{path: "/", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, ok := enclosingPath(tt.path, handlers)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != "", ok)
+		})
+	}
+}
+
+// benchmarkRouteCount is the number of routes on the benchmarked listener
+const benchmarkRouteCount = 500
+
+// newBenchmarkCandidates returns benchmarkRouteCount subtree routes spread
+// over a few levels of nesting, as a large API listener would have. Every
+// other route has a request condition, so its path gets a dispatch route.
+func newBenchmarkCandidates(b *testing.B) []routeCandidate {
+	b.Helper()
+	candidates := make([]routeCandidate, 0, benchmarkRouteCount)
+	for i := range benchmarkRouteCount {
+		path := fmt.Sprintf("/api/v%d/svc%d/", i%5, i)
+		var conds []conditions.RequestMatcher
+		if i%2 == 0 {
+			conds = append(conds, conditions.NewMethod("GET"))
+		}
+		candidates = append(candidates, newTestCandidate(b, path, path, path, conds...))
+	}
+	return candidates
+}
+
+// BenchmarkRouteMatching compares matching a request against 500 routes by
+// scanning them for the longest prefix with the listener's mux, which
+// matches path segment by segment in a decision tree.
+func BenchmarkRouteMatching(b *testing.B) {
+	candidates := newBenchmarkCandidates(b)
+	routes, err := buildDispatchRoutes("bench", candidates, unmatchedHandlers{})
+	require.NoError(b, err)
+
+	// The last route registered is the worst case for a linear scan
+	req := httptest.NewRequest(http.MethodGet,
+		fmt.Sprintf("/api/v4/svc%d/items/42", benchmarkRouteCount-1), nil)
+
+	b.Run("linear", func(b *testing.B) {
+		for b.Loop() {
+			var match *httpserver.Route
+			for i := range routes {
+				if strings.HasPrefix(req.URL.Path, routes[i].Path) &&
+					(match == nil || len(routes[i].Path) > len(match.Path)) {
+					match = &routes[i]
+				}
+			}
+			if match == nil {
+				b.Fatal("no route matched")
+			}
+		}
+	})
+
+	b.Run("mux", func(b *testing.B) {
+		// Registered as go-supervisor's httpserver registers a listener's routes
+		mux := http.NewServeMux()
+		for i := range routes {
+			mux.Handle(routes[i].Path, &routes[i])
+		}
+		for b.Loop() {
+			if _, pattern := mux.Handler(req); pattern == "" {
+				b.Fatal("no route matched")
+			}
+		}
+	})
+}
+
+// BenchmarkBuildDispatchRoutes measures building a listener's routes from 500
+// route candidates at apply time
+func BenchmarkBuildDispatchRoutes(b *testing.B) {
+	candidates := newBenchmarkCandidates(b)
+	for b.Loop() {
+		if _, err := buildDispatchRoutes("bench", candidates, unmatchedHandlers{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
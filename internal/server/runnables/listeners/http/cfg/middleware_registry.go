@@ -4,11 +4,29 @@ import (
 	"fmt"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	configAuth "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/auth"
+	configBasicAuth "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/basicauth"
+	configBodyBuffer "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/bodybuffer"
+	configCache "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	configCircuitBreaker "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
 	configHeaders "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/headers"
+	configIPFilter "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/ipfilter"
 	configLogger "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	configRetry "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	configSignature "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/signature"
+	configTransform "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/transform"
 	httpMiddleware "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware"
+	httpAuth "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/auth"
+	httpBasicAuth "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/basicauth"
+	httpBodyBuffer "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/bodybuffer"
+	httpCache "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/cache"
+	httpCircuitBreaker "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/circuitbreaker"
 	httpHeaders "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/headers"
+	httpIPFilter "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/ipfilter"
 	httpLogger "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/logger"
+	httpRetry "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/retry"
+	httpSignature "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/signature"
+	httpTransform "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/transform"
 )
 
 // MiddlewareRegistry represents a registry of middleware instances organized by type and ID.
@@ -52,8 +70,17 @@ type MiddlewareFactory struct {
 func NewMiddlewareFactory() *MiddlewareFactory {
 	return &MiddlewareFactory{
 		creators: map[string]MiddlewareInstantiator{
-			"console_logger": createConsoleLogger,
-			"headers":        createHeaders,
+			"console_logger":  createConsoleLogger,
+			"headers":         createHeaders,
+			"auth":            createAuth,
+			"basic_auth":      createBasicAuth,
+			"circuit_breaker": createCircuitBreaker,
+			"retry":           createRetry,
+			"body_buffer":     createBodyBuffer,
+			"signature":       createSignature,
+			"ip_filter":       createIPFilter,
+			"cache":           createCache,
+			"transform":       createTransform,
 		},
 	}
 }
@@ -119,6 +146,42 @@ func createHeaders(id string, config any) (httpMiddleware.Instance, error) {
 	return httpHeaders.NewHeadersMiddleware(id, headersConfig)
 }
 
+// createAuth creates auth middleware instances
+func createAuth(id string, config any) (httpMiddleware.Instance, error) {
+	authConfig, ok := config.(*configAuth.Auth)
+	if !ok {
+		return nil, fmt.Errorf("expected *configAuth.Auth, got %T", config)
+	}
+	return httpAuth.NewAuthMiddleware(id, authConfig)
+}
+
+// createBasicAuth creates basic auth middleware instances
+func createBasicAuth(id string, config any) (httpMiddleware.Instance, error) {
+	basicAuthConfig, ok := config.(*configBasicAuth.BasicAuth)
+	if !ok {
+		return nil, fmt.Errorf("expected *configBasicAuth.BasicAuth, got %T", config)
+	}
+	return httpBasicAuth.NewBasicAuthMiddleware(id, basicAuthConfig)
+}
+
+// createCircuitBreaker creates circuit breaker middleware instances
+func createCircuitBreaker(id string, config any) (httpMiddleware.Instance, error) {
+	circuitBreakerConfig, ok := config.(*configCircuitBreaker.CircuitBreaker)
+	if !ok {
+		return nil, fmt.Errorf("expected *configCircuitBreaker.CircuitBreaker, got %T", config)
+	}
+	return httpCircuitBreaker.NewCircuitBreakerMiddleware(id, circuitBreakerConfig)
+}
+
+// createRetry creates retry middleware instances
+func createRetry(id string, config any) (httpMiddleware.Instance, error) {
+	retryConfig, ok := config.(*configRetry.Retry)
+	if !ok {
+		return nil, fmt.Errorf("expected *configRetry.Retry, got %T", config)
+	}
+	return httpRetry.NewRetryMiddleware(id, retryConfig)
+}
+
 // MiddlewareCollection manages a collection of middleware instances organized by type and ID.
 // It provides clean access methods to avoid direct nested map manipulation.
 type MiddlewareCollection struct {
@@ -154,3 +217,48 @@ func (c *MiddlewareCollection) AddMiddleware(
 func (c *MiddlewareCollection) GetRegistry() MiddlewareRegistry {
 	return c.registry
 }
+
+// createBodyBuffer creates body buffer middleware instances
+func createBodyBuffer(id string, config any) (httpMiddleware.Instance, error) {
+	bodyBufferConfig, ok := config.(*configBodyBuffer.BodyBuffer)
+	if !ok {
+		return nil, fmt.Errorf("expected *configBodyBuffer.BodyBuffer, got %T", config)
+	}
+	return httpBodyBuffer.NewBodyBufferMiddleware(id, bodyBufferConfig)
+}
+
+// createSignature creates signature middleware instances
+func createSignature(id string, config any) (httpMiddleware.Instance, error) {
+	signatureConfig, ok := config.(*configSignature.Signature)
+	if !ok {
+		return nil, fmt.Errorf("expected *configSignature.Signature, got %T", config)
+	}
+	return httpSignature.NewSignatureMiddleware(id, signatureConfig)
+}
+
+// createIPFilter creates IP filter middleware instances
+func createIPFilter(id string, config any) (httpMiddleware.Instance, error) {
+	ipFilterConfig, ok := config.(*configIPFilter.IPFilter)
+	if !ok {
+		return nil, fmt.Errorf("expected *configIPFilter.IPFilter, got %T", config)
+	}
+	return httpIPFilter.NewIPFilterMiddleware(id, ipFilterConfig)
+}
+
+// createCache creates response cache middleware instances
+func createCache(id string, config any) (httpMiddleware.Instance, error) {
+	cacheConfig, ok := config.(*configCache.Cache)
+	if !ok {
+		return nil, fmt.Errorf("expected *configCache.Cache, got %T", config)
+	}
+	return httpCache.NewCacheMiddleware(id, cacheConfig)
+}
+
+// createTransform creates request and response transform middleware instances
+func createTransform(id string, config any) (httpMiddleware.Instance, error) {
+	transformConfig, ok := config.(*configTransform.Transform)
+	if !ok {
+		return nil, fmt.Errorf("expected *configTransform.Transform, got %T", config)
+	}
+	return httpTransform.NewTransformMiddleware(id, transformConfig)
+}
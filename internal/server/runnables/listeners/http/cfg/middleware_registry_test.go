@@ -1,15 +1,28 @@
 package cfg
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware"
+	configAuth "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/auth"
+	configBasicAuth "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/basicauth"
+	configBodyBuffer "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/bodybuffer"
+	configCache "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	configCircuitBreaker "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
+	configIPFilter "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/ipfilter"
 	configLogger "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	configRetry "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	configSignature "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/signature"
+	configTransform "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/transform"
 	"github.com/atlanticdynamic/firelynx/internal/fancy"
 	httpMiddleware "github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 // MockMiddleware implements httpMiddleware.Instance for testing
@@ -23,6 +36,20 @@ func (m *MockMiddleware) Middleware() httpserver.HandlerFunc {
 	}
 }
 
+// MockChainWrapper runs the rest of the chain twice
+type MockChainWrapper struct {
+	MockMiddleware
+}
+
+func (m *MockChainWrapper) WrapChain(next []httpserver.HandlerFunc) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		chain := httpserver.Route{Handlers: next}
+		chain.ServeHTTP(rp.Writer(), rp.Request())
+		chain.ServeHTTP(rp.Writer(), rp.Request())
+		rp.Abort()
+	}
+}
+
 // UnsupportedMiddleware is a mock middleware type for testing unsupported types
 type UnsupportedMiddleware struct {
 	typeValue string
@@ -231,6 +258,242 @@ func TestCreateConsoleLogger(t *testing.T) {
 	})
 }
 
+func TestCreateAuth(t *testing.T) {
+	t.Run("creates auth middleware successfully", func(t *testing.T) {
+		config := &configAuth.Auth{APIKey: &configAuth.APIKey{Keys: []string{"k1"}}}
+
+		instance, err := createAuth("test_auth", config)
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects an empty key list", func(t *testing.T) {
+		config := &configAuth.Auth{APIKey: &configAuth.APIKey{}}
+
+		instance, err := createAuth("test_auth", config)
+
+		require.ErrorIs(t, err, configAuth.ErrEmptyKeys)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createAuth("test_auth", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configAuth.Auth")
+	})
+}
+
+func TestCreateBasicAuth(t *testing.T) {
+	t.Run("creates basic auth middleware successfully", func(t *testing.T) {
+		hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+		require.NoError(t, err)
+		config := &configBasicAuth.BasicAuth{
+			Users: []configBasicAuth.User{{Username: "alice", PasswordHash: string(hash)}},
+		}
+
+		instance, err := createBasicAuth("test_basic_auth", config)
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects an empty user list", func(t *testing.T) {
+		instance, err := createBasicAuth("test_basic_auth", &configBasicAuth.BasicAuth{})
+
+		require.ErrorIs(t, err, configBasicAuth.ErrNoUsers)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createBasicAuth("test_basic_auth", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configBasicAuth.BasicAuth")
+	})
+}
+
+func TestCreateCircuitBreaker(t *testing.T) {
+	t.Run("creates circuit breaker middleware successfully", func(t *testing.T) {
+		config := &configCircuitBreaker.CircuitBreaker{FailureThreshold: 3}
+
+		instance, err := createCircuitBreaker("test_circuit_breaker", config)
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects an invalid failure ratio", func(t *testing.T) {
+		instance, err := createCircuitBreaker(
+			"test_circuit_breaker",
+			&configCircuitBreaker.CircuitBreaker{FailureRatio: 1.5},
+		)
+
+		require.ErrorIs(t, err, configCircuitBreaker.ErrInvalidFailureRatio)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createCircuitBreaker("test_circuit_breaker", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configCircuitBreaker.CircuitBreaker")
+	})
+}
+
+func TestCreateRetry(t *testing.T) {
+	t.Run("creates retry middleware successfully", func(t *testing.T) {
+		config := &configRetry.Retry{MaxAttempts: 2}
+
+		instance, err := createRetry("test_retry", config)
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+		assert.Implements(t, (*httpMiddleware.ChainWrapper)(nil), instance)
+	})
+
+	t.Run("rejects an unknown method", func(t *testing.T) {
+		instance, err := createRetry("test_retry", &configRetry.Retry{Methods: []string{"FETCH"}})
+
+		require.ErrorIs(t, err, configRetry.ErrUnknownMethod)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createRetry("test_retry", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configRetry.Retry")
+	})
+}
+
+func TestCreateBodyBuffer(t *testing.T) {
+	t.Run("creates body buffer middleware successfully", func(t *testing.T) {
+		instance, err := createBodyBuffer("test_body_buffer", &configBodyBuffer.BodyBuffer{MaxSize: 1024})
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects a negative max size", func(t *testing.T) {
+		instance, err := createBodyBuffer("test_body_buffer", &configBodyBuffer.BodyBuffer{MaxSize: -1})
+
+		require.ErrorIs(t, err, configBodyBuffer.ErrNegativeMaxSize)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createBodyBuffer("test_body_buffer", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configBodyBuffer.BodyBuffer")
+	})
+}
+
+func TestCreateSignature(t *testing.T) {
+	t.Run("creates signature middleware successfully", func(t *testing.T) {
+		instance, err := createSignature("test_signature", &configSignature.Signature{Secret: "s3cret"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects a missing secret", func(t *testing.T) {
+		instance, err := createSignature("test_signature", &configSignature.Signature{})
+
+		require.ErrorIs(t, err, configSignature.ErrEmptySecret)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createSignature("test_signature", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configSignature.Signature")
+	})
+}
+
+func TestCreateIPFilter(t *testing.T) {
+	t.Run("creates ip filter middleware successfully", func(t *testing.T) {
+		instance, err := createIPFilter("test_ip_filter", &configIPFilter.IPFilter{Allow: []string{"10.0.0.0/8"}})
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects a malformed CIDR", func(t *testing.T) {
+		instance, err := createIPFilter("test_ip_filter", &configIPFilter.IPFilter{Deny: []string{"10.0.0.0/99"}})
+
+		require.ErrorIs(t, err, configIPFilter.ErrInvalidRule)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createIPFilter("test_ip_filter", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configIPFilter.IPFilter")
+	})
+}
+
+func TestCreateCache(t *testing.T) {
+	t.Run("creates cache middleware successfully", func(t *testing.T) {
+		instance, err := createCache("test_cache", &configCache.Cache{TTL: time.Minute})
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects a missing TTL", func(t *testing.T) {
+		instance, err := createCache("test_cache", &configCache.Cache{})
+
+		require.ErrorIs(t, err, configCache.ErrMissingTTL)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createCache("test_cache", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configCache.Cache")
+	})
+}
+
+func TestCreateTransform(t *testing.T) {
+	t.Run("creates transform middleware successfully", func(t *testing.T) {
+		instance, err := createTransform("test_transform", &configTransform.Transform{
+			Response: []configTransform.Rule{{Action: configTransform.ActionSetStatus, Status: 202}},
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, instance)
+	})
+
+	t.Run("rejects a transform without rules", func(t *testing.T) {
+		instance, err := createTransform("test_transform", &configTransform.Transform{})
+
+		require.ErrorIs(t, err, configTransform.ErrNoRules)
+		assert.Nil(t, instance)
+	})
+
+	t.Run("returns error for invalid config type", func(t *testing.T) {
+		instance, err := createTransform("test_transform", struct{}{})
+
+		require.Error(t, err)
+		assert.Nil(t, instance)
+		assert.Contains(t, err.Error(), "expected *configTransform.Transform")
+	})
+}
+
 // Helper function for testing buildMiddlewareSlice
 func getMockMiddlewareCollection() middleware.MiddlewareCollection {
 	return middleware.MiddlewareCollection{
@@ -250,7 +513,7 @@ func getMockMiddlewareCollection() middleware.MiddlewareCollection {
 func TestBuildMiddlewareSlice(t *testing.T) {
 	t.Run("returns empty slice for no middleware", func(t *testing.T) {
 		registry := make(MiddlewareRegistry)
-		handlers, err := buildMiddlewareSlice(nil, registry)
+		handlers, err := buildMiddlewareSlice(nil, registry, nil)
 		require.NoError(t, err)
 		assert.Nil(t, handlers)
 	})
@@ -259,7 +522,7 @@ func TestBuildMiddlewareSlice(t *testing.T) {
 		registry := make(MiddlewareRegistry)
 		middlewares := getMockMiddlewareCollection()
 
-		handlers, err := buildMiddlewareSlice(middlewares, registry)
+		handlers, err := buildMiddlewareSlice(middlewares, registry, nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "middleware type 'console_logger' not found in registry")
 		assert.Nil(t, handlers)
@@ -270,7 +533,7 @@ func TestBuildMiddlewareSlice(t *testing.T) {
 		registry["console_logger"] = make(map[string]httpMiddleware.Instance)
 		middlewares := getMockMiddlewareCollection()
 
-		handlers, err := buildMiddlewareSlice(middlewares, registry)
+		handlers, err := buildMiddlewareSlice(middlewares, registry, nil)
 		require.Error(t, err)
 		assert.Contains(
 			t,
@@ -288,9 +551,52 @@ func TestBuildMiddlewareSlice(t *testing.T) {
 
 		middlewares := getMockMiddlewareCollection()
 
-		handlers, err := buildMiddlewareSlice(middlewares, registry)
+		handlers, err := buildMiddlewareSlice(middlewares, registry, nil)
 		require.NoError(t, err)
 		assert.Len(t, handlers, 1)
 		assert.NotNil(t, handlers[0])
 	})
+
+	t.Run("chain wrapper wraps later middleware and the app", func(t *testing.T) {
+		calls := map[string]int{}
+		counting := func(id string) httpMiddleware.Instance {
+			return &countingMiddleware{id: id, calls: calls}
+		}
+		registry := make(MiddlewareRegistry)
+		registry.AddMiddleware("console_logger", "before", counting("before"))
+		registry.AddMiddleware("console_logger", "wrapper", &MockChainWrapper{})
+		registry.AddMiddleware("console_logger", "after", counting("after"))
+
+		middlewares := middleware.MiddlewareCollection{
+			{ID: "before", Config: &configLogger.ConsoleLogger{}},
+			{ID: "wrapper", Config: &configLogger.ConsoleLogger{}},
+			{ID: "after", Config: &configLogger.ConsoleLogger{}},
+		}
+		app := func(w http.ResponseWriter, r *http.Request) {
+			calls["app"]++
+		}
+
+		handlers, err := buildMiddlewareSlice(middlewares, registry, app)
+		require.NoError(t, err)
+		require.Len(t, handlers, 2)
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/", app, handlers...)
+		require.NoError(t, err)
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, map[string]int{"before": 1, "after": 2, "app": 2}, calls)
+	})
+}
+
+// countingMiddleware counts the requests it sees
+type countingMiddleware struct {
+	id    string
+	calls map[string]int
+}
+
+func (m *countingMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		m.calls[m.id]++
+		rp.Next()
+	}
 }
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// concurrencyLimits holds a semaphore for each listener with a limit on the
+// requests it handles at once. Like bodyLimits they are read on each request,
+// so changing a limit doesn't restart a server.
+type concurrencyLimits struct {
+	mu         sync.RWMutex
+	semaphores map[string]chan struct{}
+	logger     *slog.Logger
+}
+
+func newConcurrencyLimits(logger *slog.Logger) *concurrencyLimits {
+	return &concurrencyLimits{logger: logger}
+}
+
+// set replaces the limits of all listeners. A listener whose limit is
+// unchanged keeps its semaphore, so the requests it is handling still count;
+// a changed limit starts a new semaphore, and requests in flight release the
+// old one when they finish.
+func (c *concurrencyLimits) set(limits map[string]int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	semaphores := make(map[string]chan struct{}, len(limits))
+	for listenerID, limit := range limits {
+		if sem, ok := c.semaphores[listenerID]; ok && cap(sem) == limit {
+			semaphores[listenerID] = sem
+			continue
+		}
+		semaphores[listenerID] = make(chan struct{}, limit)
+	}
+	c.semaphores = semaphores
+}
+
+// get returns the semaphore of a listener, nil when its requests are unlimited
+func (c *concurrencyLimits) get(listenerID string) chan struct{} {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.semaphores[listenerID]
+}
+
+// Middleware returns a middleware enforcing the listener's limit. A request
+// arriving while the listener is handling its maximum number of requests is
+// answered with a 503 straight away, rather than waiting for a slot, and
+// counted in the concurrency rejections metric.
+func (c *concurrencyLimits) Middleware(listenerID string) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		sem := c.get(listenerID)
+		if sem == nil {
+			rp.Next()
+			return
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			metrics.ConcurrencyRejections.WithLabelValues(listenerID).Inc()
+			c.logger.Warn("Rejected request, listener is at its concurrent request limit",
+				"listener", listenerID,
+				"limit", cap(sem),
+				"method", rp.Request().Method,
+				"path", rp.Request().URL.Path)
+			http.Error(rp.Writer(), "Service Unavailable", http.StatusServiceUnavailable)
+			rp.Abort()
+			return
+		}
+		defer func() { <-sem }()
+		rp.Next()
+	}
+}
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockingRoute returns a route whose requests signal started, then block
+// until release is closed
+func newBlockingRoute(
+	t *testing.T,
+	limits *concurrencyLimits,
+	listenerID string,
+	started chan<- struct{},
+	release <-chan struct{},
+) *httpserver.Route {
+	t.Helper()
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/", func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}, limits.Middleware(listenerID))
+	require.NoError(t, err)
+	return route
+}
+
+func TestConcurrencyLimits_Middleware(t *testing.T) {
+	t.Parallel()
+
+	limits := newConcurrencyLimits(slog.Default())
+	limits.set(map[string]int{"concurrency-limited": 2})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	route := newBlockingRoute(t, limits, "concurrency-limited", started, release)
+	serve := func() int {
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		return w.Code
+	}
+
+	rejections := metrics.ConcurrencyRejections.WithLabelValues("concurrency-limited")
+	before := testutil.ToFloat64(rejections)
+
+	// Fill the listener's two slots
+	var wg sync.WaitGroup
+	codes := make(chan int, 2)
+	for range 2 {
+		wg.Go(func() { codes <- serve() })
+		<-started
+	}
+
+	assert.Equal(t, http.StatusServiceUnavailable, serve())
+	assert.InDelta(t, before+1, testutil.ToFloat64(rejections), 0)
+
+	close(release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+
+	// The slots are released once the requests finish
+	go func() { <-started }()
+	assert.Equal(t, http.StatusOK, serve())
+}
+
+func TestConcurrencyLimits_Set(t *testing.T) {
+	t.Parallel()
+
+	limits := newConcurrencyLimits(slog.Default())
+	assert.Nil(t, limits.get("http"), "listeners are unlimited by default")
+
+	limits.set(map[string]int{"http": 1, "other": 2})
+	sem := limits.get("http")
+	require.NotNil(t, sem)
+	assert.Equal(t, 1, cap(sem))
+
+	t.Run("unchanged limit keeps its semaphore", func(t *testing.T) {
+		limits.set(map[string]int{"http": 1})
+		assert.Equal(t, sem, limits.get("http"))
+		assert.Nil(t, limits.get("other"))
+	})
+
+	t.Run("changed limit gets a new semaphore", func(t *testing.T) {
+		limits.set(map[string]int{"http": 3})
+		assert.NotEqual(t, sem, limits.get("http"))
+		assert.Equal(t, 3, cap(limits.get("http")))
+	})
+
+	t.Run("removed limit is unlimited", func(t *testing.T) {
+		limits.set(nil)
+		assert.Nil(t, limits.get("http"))
+	})
+}
@@ -0,0 +1,248 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// connTracker follows a server's connections through http.Server.ConnState
+type connTracker struct {
+	mu    sync.Mutex
+	conns map[net.Conn]http.ConnState
+}
+
+func newConnTracker() *connTracker {
+	return &connTracker{conns: make(map[net.Conn]http.ConnState)}
+}
+
+// track is the http.Server.ConnState hook
+func (t *connTracker) track(conn net.Conn, state http.ConnState) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch state {
+	case http.StateHijacked, http.StateClosed:
+		delete(t.conns, conn)
+	default:
+		t.conns[conn] = state
+	}
+}
+
+// counts returns the number of open connections, and how many of them are
+// serving a request
+func (t *connTracker) counts() (open, active int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, state := range t.conns {
+		if state == http.StateActive {
+			active++
+		}
+	}
+	return len(t.conns), active
+}
+
+// closeNotifyListener is a net.Listener that reports when it is closed
+type closeNotifyListener struct {
+	net.Listener
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+func (l *closeNotifyListener) Close() error {
+	err := l.Listener.Close()
+	l.closeOnce.Do(func() { close(l.closed) })
+	return err
+}
+
+// drainingServer is an httpserver.HttpServer that lets in-flight requests
+// finish when it is stopped. Shutdown returns as soon as the listener is
+// closed, so a replacement server can bind the same address right away, while
+// the remaining connections drain in the background for up to the drain
+// timeout. Connections still open after that are closed and their request
+// contexts canceled.
+//
+// Request contexts don't derive from the runner's context, so stopping the
+// runner doesn't cancel requests that are still draining.
+type drainingServer struct {
+	server       *http.Server
+	conns        *connTracker
+	drainTimeout time.Duration
+	drains       *sync.WaitGroup
+	logger       *slog.Logger
+	listen       listenFunc
+
+	// cancelRequests cancels the base context of every request, with
+	// apps.ErrServerShutdown as the cause
+	cancelRequests context.CancelFunc
+
+	mu       sync.Mutex
+	listener *closeNotifyListener
+
+	// stopped is closed when the server can't accept connections anymore:
+	// its listener was closed or never opened
+	stopped     chan struct{}
+	stoppedOnce sync.Once
+}
+
+// serverOptions holds the listener settings that httpserver.Config has no
+// field for, applied by the server creator
+type serverOptions struct {
+	readHeaderTimeout time.Duration
+
+	// h2c accepts HTTP/2 without TLS from clients with prior knowledge
+	h2c bool
+}
+
+// protocols returns the protocols the server accepts, or nil for the
+// http.Server default
+func (o serverOptions) protocols() *http.Protocols {
+	if !o.h2c {
+		return nil
+	}
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	return protocols
+}
+
+// newServerCreator returns an httpserver.ServerCreator for the given listener
+// that creates draining servers. The servers bind their address with listen,
+// or listenReusePort when it is nil. Background drains are added to drains.
+func newServerCreator(
+	listenerID string,
+	opts serverOptions,
+	listen listenFunc,
+	drains *sync.WaitGroup,
+	logger *slog.Logger,
+) httpserver.ServerCreator {
+	if listen == nil {
+		listen = listenReusePort
+	}
+	return func(addr string, handler http.Handler, cfg *httpserver.Config) httpserver.HttpServer {
+		baseCtx, cancel := context.WithCancelCause(context.Background())
+		conns := newConnTracker()
+		return &drainingServer{
+			server: &http.Server{
+				Addr:              addr,
+				Handler:           handler,
+				ReadTimeout:       cfg.ReadTimeout,
+				ReadHeaderTimeout: opts.readHeaderTimeout,
+				WriteTimeout:      cfg.WriteTimeout,
+				IdleTimeout:       cfg.IdleTimeout,
+				Protocols:         opts.protocols(),
+				BaseContext:       func(net.Listener) context.Context { return baseCtx },
+				ConnState:         conns.track,
+			},
+			conns:          conns,
+			drainTimeout:   cfg.DrainTimeout,
+			drains:         drains,
+			logger:         logger.With("listener_id", listenerID, "addr", addr),
+			listen:         listen,
+			cancelRequests: func() { cancel(apps.ErrServerShutdown) },
+			stopped:        make(chan struct{}),
+		}
+	}
+}
+
+// ListenAndServe binds the listen address and serves until Shutdown
+func (s *drainingServer) ListenAndServe() error {
+	ln, err := s.listen(s.server.Addr)
+	if err != nil {
+		s.markStopped()
+		return err
+	}
+
+	listener := &closeNotifyListener{Listener: ln, closed: make(chan struct{})}
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+	go func() {
+		<-listener.closed
+		s.markStopped()
+	}()
+
+	return s.server.Serve(listener)
+}
+
+// Addr returns the address the server is listening on, or nil before it
+// listens
+func (s *drainingServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Shutdown stops accepting connections and starts draining the open ones. It
+// returns once the listener is closed or ctx is done; the drain continues
+// until the connections are closed or the drain timeout passes.
+func (s *drainingServer) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.listener == nil {
+		s.markStopped()
+	}
+	s.mu.Unlock()
+
+	s.drains.Add(1)
+	go func() {
+		defer s.drains.Done()
+		s.drain()
+	}()
+
+	select {
+	case <-s.stopped:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// drain waits for open connections to finish, then force-closes any that
+// remain after the drain timeout
+func (s *drainingServer) drain() {
+	defer s.cancelRequests()
+
+	open, active := s.conns.counts()
+	s.logger.Info("Draining HTTP listener",
+		"open_connections", open,
+		"active_connections", active,
+		"timeout", s.drainTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+
+	err := s.server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		open, active := s.conns.counts()
+		s.logger.Warn("Drain timeout reached, closing remaining connections",
+			"open_connections", open,
+			"active_connections", active)
+		// Cancel the requests before closing their connections, so handlers
+		// see the shutdown as the cause rather than a client going away
+		s.cancelRequests()
+		if err := s.server.Close(); err != nil {
+			s.logger.Warn("Error closing HTTP server", "error", err)
+		}
+		return
+	}
+	if err != nil {
+		s.logger.Warn("Error draining HTTP listener", "error", err)
+		return
+	}
+	s.logger.Debug("HTTP listener drained")
+}
+
+// markStopped records that the server no longer accepts connections
+func (s *drainingServer) markStopped() {
+	s.stoppedOnce.Do(func() { close(s.stopped) })
+}
@@ -0,0 +1,286 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startDrainingServer starts a draining server for handler on a free port
+// and returns it once it is listening
+func startDrainingServer(
+	t *testing.T,
+	handler http.Handler,
+	drainTimeout time.Duration,
+	drains *sync.WaitGroup,
+) *drainingServer {
+	t.Helper()
+	create := newServerCreator("test", serverOptions{}, nil, drains, slog.Default())
+	server := create("127.0.0.1:0", handler, &httpserver.Config{DrainTimeout: drainTimeout}).(*drainingServer)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.ListenAndServe() }()
+	t.Cleanup(func() {
+		_ = server.server.Close()
+		assert.ErrorIs(t, <-serveErr, http.ErrServerClosed)
+	})
+
+	require.Eventually(t, func() bool { return server.Addr() != nil }, time.Second, time.Millisecond)
+	return server
+}
+
+// blockingHandler blocks each request until release is closed, reporting
+// when the request arrived and how its context ended
+type blockingHandler struct {
+	started  chan struct{}
+	release  chan struct{}
+	canceled chan struct{}
+	cause    error
+}
+
+func newBlockingHandler() *blockingHandler {
+	return &blockingHandler{
+		started:  make(chan struct{}),
+		release:  make(chan struct{}),
+		canceled: make(chan struct{}),
+	}
+}
+
+func (h *blockingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	close(h.started)
+	select {
+	case <-h.release:
+		_, _ = io.WriteString(w, "done")
+	case <-r.Context().Done():
+		h.cause = context.Cause(r.Context())
+		close(h.canceled)
+	}
+}
+
+func TestDrainingServer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("in-flight request completes after shutdown", func(t *testing.T) {
+		t.Parallel()
+		var drains sync.WaitGroup
+		handler := newBlockingHandler()
+		server := startDrainingServer(t, handler, 5*time.Second, &drains)
+		addr := server.Addr().String()
+
+		type result struct {
+			body string
+			err  error
+		}
+		done := make(chan result, 1)
+		go func() {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				done <- result{err: err}
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			done <- result{body: string(body), err: err}
+		}()
+		<-handler.started
+
+		open, active := server.conns.counts()
+		assert.Equal(t, 1, open)
+		assert.Equal(t, 1, active)
+
+		require.NoError(t, server.Shutdown(t.Context()))
+
+		// The address is free for a replacement server while the request drains
+		ln, err := net.Listen("tcp", addr)
+		require.NoError(t, err)
+		require.NoError(t, ln.Close())
+
+		close(handler.release)
+		res := <-done
+		require.NoError(t, res.err)
+		assert.Equal(t, "done", res.body)
+
+		drains.Wait()
+		open, _ = server.conns.counts()
+		assert.Zero(t, open)
+	})
+
+	t.Run("remaining requests are canceled after the drain timeout", func(t *testing.T) {
+		t.Parallel()
+		var drains sync.WaitGroup
+		handler := newBlockingHandler()
+		server := startDrainingServer(t, handler, 50*time.Millisecond, &drains)
+
+		done := make(chan error, 1)
+		go func() {
+			resp, err := http.Get("http://" + server.Addr().String())
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			done <- err
+		}()
+		<-handler.started
+
+		require.NoError(t, server.Shutdown(t.Context()))
+		drains.Wait()
+
+		select {
+		case <-handler.canceled:
+		case <-time.After(time.Second):
+			t.Fatal("request context was not canceled after the drain timeout")
+		}
+		assert.ErrorIs(t, handler.cause, apps.ErrServerShutdown)
+		assert.Error(t, <-done)
+	})
+
+	t.Run("server that never listened", func(t *testing.T) {
+		t.Parallel()
+		var drains sync.WaitGroup
+		create := newServerCreator("test", serverOptions{}, nil, &drains, slog.Default())
+		server := create("127.0.0.1:0", http.NotFoundHandler(), &httpserver.Config{DrainTimeout: time.Second})
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+		defer cancel()
+		require.NoError(t, server.Shutdown(ctx))
+		assert.NoError(t, ctx.Err(), "shutdown should not wait for a listener")
+		drains.Wait()
+	})
+}
+
+func TestNewServerCreator_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	var drains sync.WaitGroup
+	create := newServerCreator("test", serverOptions{readHeaderTimeout: 100 * time.Millisecond}, nil, &drains, slog.Default())
+	server := create("127.0.0.1:0", http.NotFoundHandler(), &httpserver.Config{
+		ReadTimeout:  time.Second,
+		WriteTimeout: 2 * time.Second,
+		IdleTimeout:  3 * time.Second,
+		DrainTimeout: time.Second,
+	}).(*drainingServer)
+
+	assert.Equal(t, time.Second, server.server.ReadTimeout)
+	assert.Equal(t, 100*time.Millisecond, server.server.ReadHeaderTimeout)
+	assert.Equal(t, 2*time.Second, server.server.WriteTimeout)
+	assert.Equal(t, 3*time.Second, server.server.IdleTimeout)
+
+	serveErr := make(chan error, 1)
+	go func() { serveErr <- server.ListenAndServe() }()
+	t.Cleanup(func() {
+		_ = server.server.Close()
+		assert.ErrorIs(t, <-serveErr, http.ErrServerClosed)
+	})
+	require.Eventually(t, func() bool { return server.Addr() != nil }, time.Second, time.Millisecond)
+
+	// A client that never finishes its headers is disconnected
+	conn, err := net.Dial("tcp", server.Addr().String())
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, conn.Close()) }()
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, err = io.ReadAll(conn)
+	require.NoError(t, err, "server should close the connection before the read deadline")
+}
+
+func TestNewServerCreator_H2C(t *testing.T) {
+	t.Parallel()
+
+	protoHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Proto)
+	})
+	start := func(t *testing.T, opts serverOptions) string {
+		t.Helper()
+		var drains sync.WaitGroup
+		create := newServerCreator("test", opts, nil, &drains, slog.Default())
+		server := create("127.0.0.1:0", protoHandler, &httpserver.Config{DrainTimeout: time.Second}).(*drainingServer)
+
+		serveErr := make(chan error, 1)
+		go func() { serveErr <- server.ListenAndServe() }()
+		t.Cleanup(func() {
+			_ = server.server.Close()
+			assert.ErrorIs(t, <-serveErr, http.ErrServerClosed)
+		})
+		require.Eventually(t, func() bool { return server.Addr() != nil }, time.Second, time.Millisecond)
+		return "http://" + server.Addr().String()
+	}
+	newClient := func(h2c bool) *http.Client {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if h2c {
+			transport.Protocols = new(http.Protocols)
+			transport.Protocols.SetUnencryptedHTTP2(true)
+		}
+		t.Cleanup(transport.CloseIdleConnections)
+		return &http.Client{Transport: transport, Timeout: 2 * time.Second}
+	}
+	get := func(t *testing.T, client *http.Client, url string) (string, error) {
+		t.Helper()
+		resp, err := client.Get(url)
+		if err != nil {
+			return "", err
+		}
+		defer func() { assert.NoError(t, resp.Body.Close()) }()
+		body, err := io.ReadAll(resp.Body)
+		return string(body), err
+	}
+
+	t.Run("h2c listener accepts HTTP/2 and HTTP/1 clients", func(t *testing.T) {
+		url := start(t, serverOptions{h2c: true})
+
+		proto, err := get(t, newClient(true), url)
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/2.0", proto)
+
+		proto, err = get(t, newClient(false), url)
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/1.1", proto)
+	})
+
+	t.Run("plain listener rejects HTTP/2 without TLS", func(t *testing.T) {
+		url := start(t, serverOptions{})
+
+		_, err := get(t, newClient(true), url)
+		require.Error(t, err)
+
+		proto, err := get(t, newClient(false), url)
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/1.1", proto)
+	})
+}
+
+func TestConnTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newConnTracker()
+	c1, c2 := net.Pipe()
+	defer func() { _ = c1.Close() }()
+	defer func() { _ = c2.Close() }()
+
+	tracker.track(c1, http.StateNew)
+	tracker.track(c2, http.StateNew)
+	tracker.track(c1, http.StateActive)
+	open, active := tracker.counts()
+	assert.Equal(t, 2, open)
+	assert.Equal(t, 1, active)
+
+	tracker.track(c1, http.StateIdle)
+	tracker.track(c2, http.StateHijacked)
+	open, active = tracker.counts()
+	assert.Equal(t, 1, open)
+	assert.Zero(t, active)
+
+	tracker.track(c1, http.StateClosed)
+	open, _ = tracker.counts()
+	assert.Zero(t, open)
+}
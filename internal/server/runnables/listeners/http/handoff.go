@@ -0,0 +1,132 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// listenFunc binds the listen address of a server
+type listenFunc func(addr string) (net.Listener, error)
+
+// listenReusePort binds addr with SO_REUSEPORT set where the platform supports
+// it, so a replacement listener can later bind the same address while this
+// one is still open
+func listenReusePort(addr string) (net.Listener, error) {
+	lc := net.ListenConfig{Control: setReusePort}
+	return lc.Listen(context.Background(), "tcp", addr)
+}
+
+// listenerHandoff binds the addresses of the servers a reload is about to
+// start before the cluster stops the servers they replace. The cluster stops
+// servers before starting their replacements; with the new sockets already
+// bound, connections arriving in between queue on the new socket instead of
+// being refused, and an address that can't be bound fails the reload while
+// the old servers are still running.
+type listenerHandoff struct {
+	mu     sync.Mutex
+	bound  map[string]net.Listener
+	logger *slog.Logger
+}
+
+func newListenerHandoff(logger *slog.Logger) *listenerHandoff {
+	return &listenerHandoff{
+		bound:  make(map[string]net.Listener),
+		logger: logger,
+	}
+}
+
+// handoffAddrs returns the addresses to bind ahead of sending configs to the
+// cluster: those of listeners that are new or will be restarted, including
+// the listeners in restarts, restarted for changed server options. Without
+// SO_REUSEPORT an address still served by a running listener can't be bound
+// twice, so it is left for the new server to bind after the old one stops.
+func handoffAddrs(configs, applied map[string]*httpserver.Config, restarts map[string]bool) []string {
+	served := make(map[string]bool, len(applied))
+	for _, c := range applied {
+		served[c.ListenAddr] = true
+	}
+
+	var addrs []string
+	for _, id := range slices.Sorted(maps.Keys(configs)) {
+		c := configs[id]
+		if prev, ok := applied[id]; ok && prev.Equal(c) && !restarts[id] {
+			continue
+		}
+		if served[c.ListenAddr] && !reusePortSupported {
+			continue
+		}
+		addrs = append(addrs, c.ListenAddr)
+	}
+	return addrs
+}
+
+// prebind binds each address. If any address can't be bound, the ones that
+// were are closed again and the error is returned.
+func (h *listenerHandoff) prebind(addrs []string) error {
+	h.release()
+
+	bound := make(map[string]net.Listener, len(addrs))
+	var errs []error
+	for _, addr := range addrs {
+		ln, err := listenReusePort(addr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot bind %s: %w", addr, err))
+			continue
+		}
+		bound[addr] = ln
+	}
+
+	if len(errs) > 0 {
+		for _, ln := range bound {
+			h.closeListener(ln)
+		}
+		return errors.Join(errs...)
+	}
+
+	h.mu.Lock()
+	h.bound = bound
+	h.mu.Unlock()
+	return nil
+}
+
+// listen is the listenFunc of the runner's servers. It hands over the socket
+// bound for addr by prebind, or binds addr when there is none.
+func (h *listenerHandoff) listen(addr string) (net.Listener, error) {
+	h.mu.Lock()
+	ln, ok := h.bound[addr]
+	delete(h.bound, addr)
+	h.mu.Unlock()
+
+	if ok {
+		return ln, nil
+	}
+	return listenReusePort(addr)
+}
+
+// release closes the sockets no server has taken over, returning how many
+// there were
+func (h *listenerHandoff) release() int {
+	h.mu.Lock()
+	bound := h.bound
+	h.bound = make(map[string]net.Listener)
+	h.mu.Unlock()
+
+	for _, ln := range bound {
+		h.closeListener(ln)
+	}
+	return len(bound)
+}
+
+func (h *listenerHandoff) closeListener(ln net.Listener) {
+	if err := ln.Close(); err != nil {
+		h.logger.Warn("Failed to close pre-bound listener", "addr", ln.Addr().String(), "error", err)
+	}
+}
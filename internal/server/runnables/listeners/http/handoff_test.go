@@ -0,0 +1,268 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandoffAddrs(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	route, err := httpserver.NewRouteFromHandlerFunc("route", "/", handler)
+	require.NoError(t, err)
+	newConfig := func(addr string, writeTimeout time.Duration) *httpserver.Config {
+		return &httpserver.Config{
+			ListenAddr:   addr,
+			Routes:       []httpserver.Route{*route},
+			WriteTimeout: writeTimeout,
+		}
+	}
+
+	applied := map[string]*httpserver.Config{
+		"unchanged": newConfig("127.0.0.1:8001", time.Second),
+		"changed":   newConfig("127.0.0.1:8002", time.Second),
+		"moved":     newConfig("127.0.0.1:8003", time.Second),
+		"removed":   newConfig("127.0.0.1:8004", time.Second),
+	}
+	configs := map[string]*httpserver.Config{
+		"unchanged": newConfig("127.0.0.1:8001", time.Second),
+		"changed":   newConfig("127.0.0.1:8002", 2*time.Second),
+		"moved":     newConfig("127.0.0.1:8013", time.Second),
+		"new":       newConfig("127.0.0.1:8015", time.Second),
+	}
+
+	expected := []string{"127.0.0.1:8002", "127.0.0.1:8013", "127.0.0.1:8015"}
+	if !reusePortSupported {
+		// The changed listener's address is still bound by its old server
+		expected = []string{"127.0.0.1:8013", "127.0.0.1:8015"}
+	}
+	assert.Equal(t, expected, handoffAddrs(configs, applied, nil))
+	assert.Empty(t, handoffAddrs(applied, applied, nil))
+
+	// A listener restarted for changed server options is bound like a changed one
+	restarts := map[string]bool{"unchanged": true}
+	if reusePortSupported {
+		assert.Equal(t, []string{"127.0.0.1:8001"}, handoffAddrs(applied, applied, restarts))
+	} else {
+		assert.Empty(t, handoffAddrs(applied, applied, restarts))
+	}
+}
+
+func TestListenerHandoff(t *testing.T) {
+	t.Run("prebound socket is handed over", func(t *testing.T) {
+		h := newListenerHandoff(slog.Default())
+		addr := testutil.GetRandomListeningPort(t)
+		require.NoError(t, h.prebind([]string{addr}))
+
+		// The address accepts connections before any server takes it over
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		require.NoError(t, conn.Close())
+
+		ln, err := h.listen(addr)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, ln.Close()) }()
+		assert.Zero(t, h.release(), "the handed over socket is not released")
+
+		// Serving the prebound socket answers the queued connection
+		accepted, err := ln.Accept()
+		require.NoError(t, err)
+		require.NoError(t, accepted.Close())
+	})
+
+	t.Run("listen binds addresses that weren't prebound", func(t *testing.T) {
+		h := newListenerHandoff(slog.Default())
+		ln, err := h.listen("127.0.0.1:0")
+		require.NoError(t, err)
+		require.NoError(t, ln.Close())
+	})
+
+	t.Run("unclaimed sockets are released", func(t *testing.T) {
+		h := newListenerHandoff(slog.Default())
+		addr := testutil.GetRandomListeningPort(t)
+		require.NoError(t, h.prebind([]string{addr}))
+		assert.Equal(t, 1, h.release())
+
+		_, err := net.Dial("tcp", addr)
+		require.Error(t, err)
+	})
+
+	t.Run("a failed bind releases the other addresses", func(t *testing.T) {
+		// Bound without SO_REUSEPORT, so nothing else can bind it
+		occupied, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, occupied.Close()) }()
+
+		h := newListenerHandoff(slog.Default())
+		free := testutil.GetRandomListeningPort(t)
+		err = h.prebind([]string{free, occupied.Addr().String()})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot bind "+occupied.Addr().String())
+		assert.Zero(t, h.release())
+
+		ln, err := net.Listen("tcp", free)
+		require.NoError(t, err, "the free address should have been released")
+		require.NoError(t, ln.Close())
+	})
+}
+
+func TestListenReusePort_SameAddress(t *testing.T) {
+	if !reusePortSupported {
+		t.Skip("SO_REUSEPORT is not supported on this platform")
+	}
+
+	first, err := listenReusePort("127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, first.Close()) }()
+
+	second, err := listenReusePort(first.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, second.Close())
+}
+
+func TestRunner_RestartWithoutRefusedConnections(t *testing.T) {
+	if !reusePortSupported {
+		t.Skip("SO_REUSEPORT is not supported on this platform")
+	}
+
+	runner, err := NewRunner()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run(ctx) }()
+	assert.Eventually(t, func() bool { return runner.IsReady() },
+		2*time.Second, 10*time.Millisecond, "runner should become ready")
+
+	route, err := httpserver.NewRouteFromHandlerFunc("route", "/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	require.NoError(t, err)
+	addr := testutil.GetRandomListeningPort(t)
+	commit := func(txID string, writeTimeout time.Duration) {
+		t.Helper()
+		runner.configMgr.SetPending(&cfg.Adapter{
+			TxID: txID,
+			Listeners: map[string]cfg.ListenerConfig{
+				"listener1": {ID: "listener1", Address: addr, WriteTimeout: writeTimeout},
+			},
+			Routes: map[string][]httpserver.Route{"listener1": {*route}},
+		})
+		require.NoError(t, runner.CommitConfig(ctx))
+	}
+	commit("tx-1", time.Second)
+
+	// Dial the address continuously while the listener restarts
+	var dials, refused atomic.Int64
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			dials.Add(1)
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				// A handshake still queued on the old socket when it closes
+				// is reset, which SO_REUSEPORT can't prevent
+				if errors.Is(err, syscall.ECONNREFUSED) {
+					refused.Add(1)
+				}
+				continue
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	// Each commit changes the write timeout, which restarts the server
+	for i, timeout := range []time.Duration{2 * time.Second, 3 * time.Second, 4 * time.Second} {
+		commit("tx-restart", timeout)
+		assert.Equal(t, "restarted", string(runner.LastReloadResults()[0].Action), "commit %d", i)
+	}
+	close(stop)
+	<-done
+
+	assert.Positive(t, dials.Load())
+	assert.Zero(t, refused.Load(), "connections were refused during the restarts")
+
+	runner.Stop()
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runner did not stop within timeout")
+	}
+}
+
+func TestRunner_ReloadFailsWhenAddressCannotBeBound(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run(ctx) }()
+	assert.Eventually(t, func() bool { return runner.IsReady() },
+		2*time.Second, 10*time.Millisecond, "runner should become ready")
+
+	route, err := httpserver.NewRouteFromHandlerFunc("route", "/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	require.NoError(t, err)
+	routes := map[string][]httpserver.Route{"listener1": {*route}}
+
+	addr := testutil.GetRandomListeningPort(t)
+	runner.configMgr.SetPending(&cfg.Adapter{
+		TxID:      "tx-1",
+		Listeners: map[string]cfg.ListenerConfig{"listener1": {ID: "listener1", Address: addr}},
+		Routes:    routes,
+	})
+	require.NoError(t, runner.CommitConfig(ctx))
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, occupied.Close()) }()
+
+	// Moving the listener to an address in use fails before the old server is
+	// stopped
+	runner.configMgr.SetPending(&cfg.Adapter{
+		TxID:      "tx-2",
+		Listeners: map[string]cfg.ListenerConfig{"listener1": {ID: "listener1", Address: occupied.Addr().String()}},
+		Routes:    routes,
+	})
+	err = runner.CommitConfig(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to bind HTTP listeners")
+
+	resp, err := http.Get("http://" + addr + "/")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	runner.Stop()
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runner did not stop within timeout")
+	}
+}
@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksRefreshInterval is how long a fetched key set is used before it is
+	// fetched again
+	jwksRefreshInterval = time.Hour
+
+	// jwksMinRefreshInterval limits how often a token with an unknown key ID
+	// can trigger a fetch, so bogus tokens can't hammer the JWKS endpoint
+	jwksMinRefreshInterval = 30 * time.Second
+
+	// jwksFetchTimeout bounds a single request for the key set
+	jwksFetchTimeout = 10 * time.Second
+
+	// maxJWKSSize bounds the size of a key set response
+	maxJWKSSize = 1 << 20
+)
+
+// Sentinel errors for JWKS lookups.
+var (
+	ErrUnknownKey      = errors.New("no key to verify token")
+	ErrJWKSUnavailable = errors.New("JWKS unavailable")
+	ErrUnsupportedJWK  = errors.New("unsupported JWK")
+	ErrMalformedJWK    = errors.New("malformed JWK")
+)
+
+// jwk is a single JSON Web Key, as defined by RFC 7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// verificationKey is a public key from a JWKS and the algorithm it is
+// restricted to, if the key set names one
+type verificationKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// jwksCache fetches and caches the keys of a JSON Web Key Set. The set is
+// fetched again when it is older than jwksRefreshInterval, or when a token
+// names a key ID the set doesn't contain. If a fetch fails, the last good
+// set is used.
+type jwksCache struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]verificationKey
+	fetchedAt   time.Time
+	lastAttempt time.Time
+
+	// fetchMu serializes fetches, so concurrent requests wait for one
+	// fetch instead of each making their own
+	fetchMu sync.Mutex
+}
+
+func newJWKSCache(url string) *jwksCache {
+	return &jwksCache{
+		url:    url,
+		client: &http.Client{Timeout: jwksFetchTimeout},
+		now:    time.Now,
+	}
+}
+
+// key returns the key with the given key ID. A token without a key ID can
+// only be verified when the set holds a single key.
+func (c *jwksCache) key(ctx context.Context, kid string) (verificationKey, error) {
+	if key, found, fresh := c.lookup(kid); found && fresh {
+		return key, nil
+	}
+
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+
+	// Another request may have refreshed the set while this one waited
+	key, found, fresh := c.lookup(kid)
+	if found && fresh {
+		return key, nil
+	}
+	// Fetch attempts are rate limited, so a stale set keeps being used while
+	// the JWKS endpoint is failing
+	if c.mayRefetch() {
+		if err := c.refresh(ctx); err != nil && !found {
+			return verificationKey{}, err
+		}
+		key, found, _ = c.lookup(kid)
+	}
+	if !found {
+		return verificationKey{}, fmt.Errorf("%w: kid %q", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+// lookup returns the cached key with the given key ID, and whether the set
+// is young enough to use without fetching it again
+func (c *jwksCache) lookup(kid string) (verificationKey, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	fresh := c.keys != nil && c.now().Sub(c.fetchedAt) < jwksRefreshInterval
+	key, found := c.keys[kid]
+	if !found && kid == "" && len(c.keys) == 1 {
+		for _, only := range c.keys {
+			key, found = only, true
+		}
+	}
+	return key, found, fresh
+}
+
+// mayRefetch reports whether enough time passed since the last fetch
+// attempt to fetch the set again
+func (c *jwksCache) mayRefetch() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now().Sub(c.lastAttempt) >= jwksMinRefreshInterval
+}
+
+// refresh fetches the key set, keeping the last good set if the fetch fails
+func (c *jwksCache) refresh(ctx context.Context) error {
+	c.mu.Lock()
+	c.lastAttempt = c.now()
+	hadKeys := c.keys != nil
+	c.mu.Unlock()
+
+	keys, err := c.fetch(ctx)
+	if err != nil {
+		if hadKeys {
+			slog.Default().Warn("Failed to refresh JWKS, using last good key set",
+				"url", c.url,
+				"error", err)
+		}
+		return err
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = c.now()
+	c.mu.Unlock()
+	return nil
+}
+
+// fetch requests the key set and parses its signing keys. Keys of
+// unsupported types are skipped.
+func (c *jwksCache) fetch(ctx context.Context) (map[string]verificationKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create request: %w", ErrJWKSUnavailable, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrJWKSUnavailable, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%w: HTTP %s", ErrJWKSUnavailable, resp.Status)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxJWKSSize)).Decode(&set); err != nil {
+		return nil, fmt.Errorf("%w: invalid key set: %w", ErrJWKSUnavailable, err)
+	}
+
+	keys := make(map[string]verificationKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.publicKey()
+		if err != nil {
+			slog.Default().Debug("Skipping JWK", "url", c.url, "kid", k.Kid, "error", err)
+			continue
+		}
+		keys[k.Kid] = verificationKey{key: key, alg: k.Alg}
+	}
+	return keys, nil
+}
+
+// publicKey decodes the RSA or EC public key held by the JWK
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("%w: n: %w", ErrMalformedJWK, err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("%w: e: %w", ErrMalformedJWK, err)
+		}
+		if !e.IsInt64() || e.Int64() < 2 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("%w: invalid exponent", ErrMalformedJWK)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve %q", ErrUnsupportedJWK, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("%w: x: %w", ErrMalformedJWK, err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("%w: y: %w", ErrMalformedJWK, err)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, fmt.Errorf("%w: coordinates must be %d bytes", ErrMalformedJWK, size)
+		}
+		point := append(append([]byte{4}, x...), y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedJWK, err)
+		}
+		return key, nil
+
+	default:
+		return nil, fmt.Errorf("%w: key type %q", ErrUnsupportedJWK, k.Kty)
+	}
+}
+
+// decodeBigInt decodes a base64url-encoded big-endian unsigned integer
+func decodeBigInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testJWKS serves a key set that tests can swap out or break
+type testJWKS struct {
+	mu      sync.Mutex
+	keys    []map[string]any
+	fail    bool
+	fetches atomic.Int32
+}
+
+func (s *testJWKS) setKeys(keys ...map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *testJWKS) setFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+func (s *testJWKS) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.fetches.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+}
+
+// jwksServer starts a server for a key set holding keys
+func jwksServer(t *testing.T, keys ...map[string]any) (*httptest.Server, *testJWKS) {
+	t.Helper()
+	jwks := &testJWKS{keys: keys}
+	server := httptest.NewServer(jwks)
+	t.Cleanup(server.Close)
+	return server, jwks
+}
+
+func TestJWKSCache_Key(t *testing.T) {
+	t.Parallel()
+
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("cached until the refresh interval", func(t *testing.T) {
+		server, jwks := jwksServer(t, ecJWK(t, "k1", &key1.PublicKey))
+		now := time.Unix(1_700_000_000, 0)
+		cache := newJWKSCache(server.URL)
+		cache.now = func() time.Time { return now }
+
+		for range 3 {
+			_, err := cache.key(t.Context(), "k1")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), jwks.fetches.Load())
+
+		now = now.Add(jwksRefreshInterval)
+		_, err := cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), jwks.fetches.Load())
+	})
+
+	t.Run("unknown key ID triggers a refresh", func(t *testing.T) {
+		server, jwks := jwksServer(t, ecJWK(t, "k1", &key1.PublicKey))
+		now := time.Unix(1_700_000_000, 0)
+		cache := newJWKSCache(server.URL)
+		cache.now = func() time.Time { return now }
+
+		_, err := cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+
+		// The issuer rotates in a new key
+		jwks.setKeys(ecJWK(t, "k1", &key1.PublicKey), ecJWK(t, "k2", &key2.PublicKey))
+		now = now.Add(jwksMinRefreshInterval)
+		key, err := cache.key(t.Context(), "k2")
+		require.NoError(t, err)
+		assert.True(t, key2.PublicKey.Equal(key.key))
+		assert.Equal(t, int32(2), jwks.fetches.Load())
+	})
+
+	t.Run("unknown key ID refreshes at most once per interval", func(t *testing.T) {
+		server, jwks := jwksServer(t, ecJWK(t, "k1", &key1.PublicKey))
+		now := time.Unix(1_700_000_000, 0)
+		cache := newJWKSCache(server.URL)
+		cache.now = func() time.Time { return now }
+
+		_, err := cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+		for range 5 {
+			_, err := cache.key(t.Context(), "bogus")
+			require.ErrorIs(t, err, ErrUnknownKey)
+		}
+		assert.Equal(t, int32(1), jwks.fetches.Load())
+	})
+
+	t.Run("last good key set is used when a refresh fails", func(t *testing.T) {
+		server, jwks := jwksServer(t, ecJWK(t, "k1", &key1.PublicKey))
+		now := time.Unix(1_700_000_000, 0)
+		cache := newJWKSCache(server.URL)
+		cache.now = func() time.Time { return now }
+
+		_, err := cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+
+		jwks.setFail(true)
+		now = now.Add(2 * jwksRefreshInterval)
+		_, err = cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+	})
+
+	t.Run("unavailable key set", func(t *testing.T) {
+		server, jwks := jwksServer(t)
+		jwks.setFail(true)
+
+		_, err := newJWKSCache(server.URL).key(t.Context(), "k1")
+		require.ErrorIs(t, err, ErrJWKSUnavailable)
+	})
+
+	t.Run("token without key ID uses the only key", func(t *testing.T) {
+		server, _ := jwksServer(t, ecJWK(t, "k1", &key1.PublicKey))
+		key, err := newJWKSCache(server.URL).key(t.Context(), "")
+		require.NoError(t, err)
+		assert.True(t, key1.PublicKey.Equal(key.key))
+	})
+
+	t.Run("unsupported and encryption keys are skipped", func(t *testing.T) {
+		server, _ := jwksServer(t,
+			map[string]any{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "AAAA"},
+			map[string]any{"kty": "EC", "kid": "enc", "use": "enc", "crv": "P-256"},
+			ecJWK(t, "k1", &key1.PublicKey),
+		)
+		cache := newJWKSCache(server.URL)
+
+		_, err := cache.key(t.Context(), "k1")
+		require.NoError(t, err)
+		_, err = cache.key(t.Context(), "ed")
+		require.ErrorIs(t, err, ErrUnknownKey)
+	})
+}
+
+func TestJWK_PublicKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		key     jwk
+		wantErr error
+	}{
+		{
+			name:    "unsupported key type",
+			key:     jwk{Kty: "oct"},
+			wantErr: ErrUnsupportedJWK,
+		},
+		{
+			name:    "unsupported curve",
+			key:     jwk{Kty: "EC", Crv: "secp256k1"},
+			wantErr: ErrUnsupportedJWK,
+		},
+		{
+			name:    "missing modulus",
+			key:     jwk{Kty: "RSA", E: "AQAB"},
+			wantErr: ErrMalformedJWK,
+		},
+		{
+			name:    "short EC coordinates",
+			key:     jwk{Kty: "EC", Crv: "P-256", X: "AAAA", Y: "AAAA"},
+			wantErr: ErrMalformedJWK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.key.publicKey()
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
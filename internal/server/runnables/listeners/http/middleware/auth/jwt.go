@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256" // registers SHA-256 for crypto.Hash
+	_ "crypto/sha512" // registers SHA-384 and SHA-512 for crypto.Hash
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Sentinel errors for JWT validation.
+var (
+	ErrMalformedToken   = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature = errors.New("invalid token signature")
+	ErrTokenExpired     = errors.New("token expired")
+	ErrMissingExpiry    = errors.New("token has no valid exp claim")
+	ErrTokenNotYetValid = errors.New("token not yet valid")
+	ErrInvalidIssuer    = errors.New("invalid token issuer")
+	ErrInvalidAudience  = errors.New("invalid token audience")
+)
+
+// keyFamily identifies the kind of key an algorithm is verified with
+type keyFamily int
+
+const (
+	familyHMAC keyFamily = iota
+	familyRSA
+	familyRSAPSS
+	familyECDSA
+)
+
+// signingAlg describes a supported JWS algorithm
+type signingAlg struct {
+	family keyFamily
+	hash   crypto.Hash
+}
+
+// signingAlgs holds the supported JWS algorithms, as named in the "alg" header
+var signingAlgs = map[string]signingAlg{
+	"HS256": {familyHMAC, crypto.SHA256},
+	"HS384": {familyHMAC, crypto.SHA384},
+	"HS512": {familyHMAC, crypto.SHA512},
+	"RS256": {familyRSA, crypto.SHA256},
+	"RS384": {familyRSA, crypto.SHA384},
+	"RS512": {familyRSA, crypto.SHA512},
+	"PS256": {familyRSAPSS, crypto.SHA256},
+	"PS384": {familyRSAPSS, crypto.SHA384},
+	"PS512": {familyRSAPSS, crypto.SHA512},
+	"ES256": {familyECDSA, crypto.SHA256},
+	"ES384": {familyECDSA, crypto.SHA384},
+	"ES512": {familyECDSA, crypto.SHA512},
+}
+
+// jwtHeader is the JOSE header of a JWT
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtVerifier validates JWTs signed with a shared secret or with a key from a
+// JSON Web Key Set, and checks their time, issuer, and audience claims.
+type jwtVerifier struct {
+	secret   []byte
+	jwks     *jwksCache
+	issuer   string
+	audience []string
+
+	// allowMissingExp accepts tokens without an exp claim
+	allowMissingExp bool
+}
+
+// verify checks the signature and claims of token and returns its claims.
+func (v *jwtVerifier) verify(ctx context.Context, token string, now time.Time) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 segments, got %d", ErrMalformedToken, len(parts))
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: header: %w", ErrMalformedToken, err)
+	}
+	alg, ok := signingAlgs[header.Alg]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature: %w", ErrMalformedToken, err)
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+
+	if alg.family == familyHMAC {
+		err = v.verifyHMAC(alg, signingInput, signature)
+	} else {
+		err = v.verifyPublicKey(ctx, header, alg, signingInput, signature)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %w", ErrMalformedToken, err)
+	}
+	if err := v.checkClaims(claims, now); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// verifyHMAC checks an HMAC signature against the shared secret
+func (v *jwtVerifier) verifyHMAC(alg signingAlg, signingInput, signature []byte) error {
+	if len(v.secret) == 0 {
+		return fmt.Errorf("%w: no shared secret configured", ErrUnknownKey)
+	}
+	mac := hmac.New(alg.hash.New, v.secret)
+	mac.Write(signingInput)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyPublicKey checks an RSA or ECDSA signature against the key named by
+// the token's key ID
+func (v *jwtVerifier) verifyPublicKey(
+	ctx context.Context,
+	header jwtHeader,
+	alg signingAlg,
+	signingInput, signature []byte,
+) error {
+	if v.jwks == nil {
+		return fmt.Errorf("%w: no JWKS configured", ErrUnknownKey)
+	}
+	key, err := v.jwks.key(ctx, header.Kid)
+	if err != nil {
+		return err
+	}
+	if key.alg != "" && key.alg != header.Alg {
+		return fmt.Errorf("%w: key %q is for %s", ErrUnsupportedAlg, header.Kid, key.alg)
+	}
+
+	h := alg.hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch pub := key.key.(type) {
+	case *rsa.PublicKey:
+		switch alg.family {
+		case familyRSA:
+			err = rsa.VerifyPKCS1v15(pub, alg.hash, digest, signature)
+		case familyRSAPSS:
+			err = rsa.VerifyPSS(pub, alg.hash, digest, signature,
+				&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		default:
+			return fmt.Errorf("%w: %s with an RSA key", ErrUnsupportedAlg, header.Alg)
+		}
+		if err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if alg.family != familyECDSA {
+			return fmt.Errorf("%w: %s with an EC key", ErrUnsupportedAlg, header.Alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return ErrInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return ErrInvalidSignature
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedJWK, pub)
+	}
+}
+
+// checkClaims checks the exp, nbf, iss, and aud claims. A token without a
+// numeric exp claim is rejected unless allowMissingExp is set; a malformed
+// exp is always rejected.
+func (v *jwtVerifier) checkClaims(claims map[string]any, now time.Time) error {
+	exp, ok := numericDate(claims, "exp")
+	switch {
+	case ok && !now.Before(exp):
+		return ErrTokenExpired
+	case !ok && (claims["exp"] != nil || !v.allowMissingExp):
+		return ErrMissingExpiry
+	}
+	if nbf, ok := numericDate(claims, "nbf"); ok && now.Before(nbf) {
+		return ErrTokenNotYetValid
+	}
+	if v.issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.issuer {
+			return ErrInvalidIssuer
+		}
+	}
+	if len(v.audience) > 0 && !slices.ContainsFunc(audiences(claims), func(aud string) bool {
+		return slices.Contains(v.audience, aud)
+	}) {
+		return ErrInvalidAudience
+	}
+	return nil
+}
+
+// audiences returns the aud claim, which may be a single string or an array
+func audiences(claims map[string]any) []string {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []any:
+		values := make([]string, 0, len(aud))
+		for _, a := range aud {
+			if s, ok := a.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// decodeSegment decodes a base64url-encoded JSON segment of a JWT into v
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// numericDate returns the time of a NumericDate claim, if it is present
+func numericDate(claims map[string]any, name string) (time.Time, bool) {
+	seconds, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
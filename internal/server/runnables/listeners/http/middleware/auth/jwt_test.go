@@ -0,0 +1,356 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encodeSegment encodes v as a base64url JSON segment of a JWT
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signJWT builds a JWT with the given header and claims, signed with the HMAC
+// algorithm named in the header (HS256 when it names none)
+func signJWT(t *testing.T, header, claims map[string]any, secret string) string {
+	t.Helper()
+	hash := crypto.SHA256
+	if alg, ok := signingAlgs[header["alg"].(string)]; ok && alg.family == familyHMAC {
+		hash = alg.hash
+	}
+	signingInput := encodeSegment(t, header) + "." + encodeSegment(t, claims)
+	mac := hmac.New(hash.New, []byte(secret))
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// signRSA builds a JWT signed with an RSA key, using RS* or PS* per alg
+func signRSA(t *testing.T, key *rsa.PrivateKey, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	method := signingAlgs[alg]
+	signingInput := encodeSegment(t, map[string]any{"alg": alg, "kid": kid}) + "." + encodeSegment(t, claims)
+	h := method.hash.New()
+	h.Write([]byte(signingInput))
+
+	var signature []byte
+	var err error
+	if method.family == familyRSAPSS {
+		signature, err = rsa.SignPSS(rand.Reader, key, method.hash, h.Sum(nil),
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	} else {
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, method.hash, h.Sum(nil))
+	}
+	require.NoError(t, err)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// signES256 builds a JWT signed with a P-256 key
+func signES256(t *testing.T, key *ecdsa.PrivateKey, kid string, claims map[string]any) string {
+	t.Helper()
+	signingInput := encodeSegment(t, map[string]any{"alg": "ES256", "kid": kid}) + "." + encodeSegment(t, claims)
+	h := crypto.SHA256.New()
+	h.Write([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, h.Sum(nil))
+	require.NoError(t, err)
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// rsaJWK returns the JWK of an RSA public key
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]any {
+	return map[string]any{
+		"kty": "RSA",
+		"kid": kid,
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+	}
+}
+
+// ecJWK returns the JWK of a P-256 public key
+func ecJWK(t *testing.T, kid string, key *ecdsa.PublicKey) map[string]any {
+	t.Helper()
+	point, err := key.Bytes()
+	require.NoError(t, err)
+	return map[string]any{
+		"kty": "EC",
+		"kid": kid,
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		"y":   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}
+}
+
+func TestJWTVerifier_Secret(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := map[string]any{"alg": "HS256", "typ": "JWT"}
+	verifier := &jwtVerifier{secret: []byte("secret")}
+
+	t.Run("valid token", func(t *testing.T) {
+		token := signJWT(t, hs256, map[string]any{
+			"sub": "user-1",
+			"exp": now.Add(time.Minute).Unix(),
+			"nbf": now.Add(-time.Minute).Unix(),
+		}, "secret")
+
+		claims, err := verifier.verify(t.Context(), token, now)
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", claims["sub"])
+	})
+
+	t.Run("HS512", func(t *testing.T) {
+		token := signJWT(t, map[string]any{"alg": "HS512"}, map[string]any{
+			"sub": "user-1",
+			"exp": now.Add(time.Minute).Unix(),
+		}, "secret")
+		_, err := verifier.verify(t.Context(), token, now)
+		require.NoError(t, err)
+	})
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		wantErr error
+	}{
+		{
+			name: "wrong secret",
+			token: func(t *testing.T) string {
+				return signJWT(t, hs256, map[string]any{"sub": "user-1"}, "other")
+			},
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return signJWT(t, hs256, map[string]any{"exp": now.Unix()}, "secret")
+			},
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name: "not yet valid",
+			token: func(t *testing.T) string {
+				return signJWT(t, hs256, map[string]any{
+					"exp": now.Add(2 * time.Minute).Unix(),
+					"nbf": now.Add(time.Minute).Unix(),
+				}, "secret")
+			},
+			wantErr: ErrTokenNotYetValid,
+		},
+		{
+			name: "alg none",
+			token: func(t *testing.T) string {
+				return signJWT(t, map[string]any{"alg": "none"}, map[string]any{"sub": "user-1"}, "secret")
+			},
+			wantErr: ErrUnsupportedAlg,
+		},
+		{
+			name: "RS256 without a JWKS",
+			token: func(t *testing.T) string {
+				return signJWT(t, map[string]any{"alg": "RS256"}, map[string]any{"sub": "user-1"}, "secret")
+			},
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "too few segments",
+			token:   func(*testing.T) string { return "abc.def" },
+			wantErr: ErrMalformedToken,
+		},
+		{
+			name:    "bad encoding",
+			token:   func(*testing.T) string { return "!!!.def.ghi" },
+			wantErr: ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.verify(t.Context(), tt.token(t), now)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJWTVerifier_Expiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := map[string]any{"alg": "HS256"}
+	strict := &jwtVerifier{secret: []byte("secret")}
+	lenient := &jwtVerifier{secret: []byte("secret"), allowMissingExp: true}
+
+	tests := []struct {
+		name          string
+		claims        map[string]any
+		wantErr       error
+		wantLenientOK bool
+	}{
+		{
+			name:          "missing exp",
+			claims:        map[string]any{"sub": "user-1"},
+			wantErr:       ErrMissingExpiry,
+			wantLenientOK: true,
+		},
+		{
+			name:    "string exp",
+			claims:  map[string]any{"sub": "user-1", "exp": "1700000060"},
+			wantErr: ErrMissingExpiry,
+		},
+		{
+			name:    "object exp",
+			claims:  map[string]any{"sub": "user-1", "exp": map[string]any{}},
+			wantErr: ErrMissingExpiry,
+		},
+		{
+			name:    "expired",
+			claims:  map[string]any{"sub": "user-1", "exp": now.Unix()},
+			wantErr: ErrTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signJWT(t, hs256, tt.claims, "secret")
+
+			_, err := strict.verify(t.Context(), token, now)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			_, err = lenient.verify(t.Context(), token, now)
+			if tt.wantLenientOK {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJWTVerifier_IssuerAndAudience(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := map[string]any{"alg": "HS256"}
+	verifier := &jwtVerifier{
+		secret:   []byte("secret"),
+		issuer:   "https://issuer.example.com",
+		audience: []string{"api", "admin"},
+	}
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		wantErr error
+	}{
+		{
+			name:   "matching string audience",
+			claims: map[string]any{"iss": "https://issuer.example.com", "aud": "api"},
+		},
+		{
+			name:   "matching audience in array",
+			claims: map[string]any{"iss": "https://issuer.example.com", "aud": []string{"other", "admin"}},
+		},
+		{
+			name:    "wrong issuer",
+			claims:  map[string]any{"iss": "https://evil.example.com", "aud": "api"},
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "missing issuer",
+			claims:  map[string]any{"aud": "api"},
+			wantErr: ErrInvalidIssuer,
+		},
+		{
+			name:    "wrong audience",
+			claims:  map[string]any{"iss": "https://issuer.example.com", "aud": []string{"other"}},
+			wantErr: ErrInvalidAudience,
+		},
+		{
+			name:    "missing audience",
+			claims:  map[string]any{"iss": "https://issuer.example.com"},
+			wantErr: ErrInvalidAudience,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims["exp"] = now.Add(time.Minute).Unix()
+			_, err := verifier.verify(t.Context(), signJWT(t, hs256, tt.claims, "secret"), now)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestJWTVerifier_JWKS(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server, _ := jwksServer(t, rsaJWK("rsa-1", &rsaKey.PublicKey), ecJWK(t, "ec-1", &ecKey.PublicKey))
+	verifier := &jwtVerifier{secret: []byte("secret"), jwks: newJWKSCache(server.URL)}
+	claims := map[string]any{"sub": "user-1", "exp": now.Add(time.Minute).Unix()}
+
+	for name, token := range map[string]string{
+		"RS256": signRSA(t, rsaKey, "RS256", "rsa-1", claims),
+		"RS512": signRSA(t, rsaKey, "RS512", "rsa-1", claims),
+		"PS256": signRSA(t, rsaKey, "PS256", "rsa-1", claims),
+		"ES256": signES256(t, ecKey, "ec-1", claims),
+	} {
+		t.Run(name, func(t *testing.T) {
+			verified, err := verifier.verify(t.Context(), token, now)
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", verified["sub"])
+		})
+	}
+
+	t.Run("signed with another key", func(t *testing.T) {
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		_, err = verifier.verify(t.Context(), signES256(t, otherKey, "ec-1", claims), now)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("algorithm doesn't match the key type", func(t *testing.T) {
+		token := signRSA(t, rsaKey, "RS256", "ec-1", claims)
+		_, err := verifier.verify(t.Context(), token, now)
+		require.ErrorIs(t, err, ErrUnsupportedAlg)
+	})
+
+	t.Run("HMAC token signed with a public key", func(t *testing.T) {
+		// A token claiming HS256 must be checked against the secret, never a JWKS key
+		publicKey := string(rsaKey.N.Bytes())
+		token := signJWT(t, map[string]any{"alg": "HS256", "kid": "rsa-1"}, claims, publicKey)
+		_, err := verifier.verify(t.Context(), token, now)
+		require.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		_, err := verifier.verify(t.Context(), signRSA(t, rsaKey, "RS256", "rsa-2", claims), now)
+		require.ErrorIs(t, err, ErrUnknownKey)
+	})
+}
@@ -0,0 +1,276 @@
+// Package auth provides HTTP authentication middleware using static API keys,
+// opaque bearer tokens, or JWTs.
+//
+// Requests that fail authentication get a 401 with a WWW-Authenticate header and
+// don't reach the rest of the chain. Authenticated requests carry an
+// apps.Principal in their context, which the logger middleware and script apps
+// read.
+//
+// JWTs are verified with a shared secret, or with keys from a JWKS URL that are
+// cached and fetched again when a token names an unknown key ID. Their claims
+// are part of the principal.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "00-auth"
+//	type = "auth"
+//
+//	[endpoints.middlewares.auth.api_key]
+//	header = "X-API-Key"
+//	keys = ["${file:/run/secrets/api_key}"]
+//
+//	[[endpoints.middlewares]]
+//	id = "00-jwt"
+//	type = "auth"
+//
+//	[endpoints.middlewares.auth.jwt]
+//	jwks_url = "https://issuer.example.com/.well-known/jwks.json"
+//	issuer = "https://issuer.example.com"
+//	audience = ["firelynx"]
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/auth"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Authentication methods reported in apps.Principal.Method
+const (
+	MethodAPIKey = "api_key"
+	MethodBearer = "bearer"
+	MethodJWT    = "jwt"
+)
+
+// Authentication schemes named in the WWW-Authenticate header
+const (
+	schemeAPIKey = "APIKey"
+	schemeBearer = "Bearer"
+)
+
+// Sentinel errors for auth middleware.
+var (
+	ErrNilConfig     = errors.New("auth config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid auth config")
+)
+
+var (
+	// errMissingCredentials means the request carried no key or token
+	errMissingCredentials = errors.New("missing credentials")
+
+	// errUnknownCredentials means the request carried a key or token that isn't configured
+	errUnknownCredentials = errors.New("unknown credentials")
+)
+
+// tokenErrors are the JWT validation failures described to clients in the
+// error_description of the WWW-Authenticate header
+var tokenErrors = []error{
+	ErrMalformedToken,
+	ErrUnsupportedAlg,
+	ErrInvalidSignature,
+	ErrTokenExpired,
+	ErrMissingExpiry,
+	ErrTokenNotYetValid,
+	ErrInvalidIssuer,
+	ErrInvalidAudience,
+	ErrUnknownKey,
+}
+
+// AuthMiddleware is a middleware implementation that rejects unauthenticated requests.
+type AuthMiddleware struct {
+	id         string
+	realm      string
+	header     string
+	queryParam string
+	scheme     string
+	keys       map[[sha256.Size]byte]struct{}
+	jwt        *jwtVerifier
+	now        func() time.Time
+}
+
+// NewAuthMiddleware creates a new AuthMiddleware instance.
+func NewAuthMiddleware(id string, cfg *auth.Auth) (*AuthMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	am := &AuthMiddleware{
+		id:     id,
+		realm:  cfg.GetRealm(),
+		scheme: schemeBearer,
+		keys:   make(map[[sha256.Size]byte]struct{}),
+		now:    time.Now,
+	}
+
+	var keys []string
+	switch {
+	case cfg.APIKey != nil:
+		am.scheme = schemeAPIKey
+		am.header = cfg.APIKey.GetHeader()
+		am.queryParam = cfg.APIKey.QueryParam
+		keys = cfg.APIKey.Keys
+	case cfg.Bearer != nil:
+		keys = cfg.Bearer.Tokens
+	case cfg.JWT != nil:
+		am.jwt = &jwtVerifier{
+			secret:          []byte(cfg.JWT.Secret),
+			issuer:          cfg.JWT.Issuer,
+			audience:        cfg.JWT.Audience,
+			allowMissingExp: cfg.JWT.AllowMissingExp,
+		}
+		if cfg.JWT.JWKSURL != "" {
+			am.jwt.jwks = newJWKSCache(cfg.JWT.JWKSURL)
+		}
+	}
+
+	// Only digests are kept, so lookups don't compare secrets byte by byte
+	for _, key := range keys {
+		am.keys[sha256.Sum256([]byte(key))] = struct{}{}
+	}
+
+	return am, nil
+}
+
+// Middleware returns the middleware function that authenticates requests.
+func (am *AuthMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+
+		var principal *apps.Principal
+		var err error
+		switch {
+		case am.scheme == schemeAPIKey:
+			principal, err = am.authenticateAPIKey(r)
+		case am.jwt != nil:
+			principal, err = am.authenticateJWT(r)
+		default:
+			principal, err = am.authenticateBearer(r)
+		}
+
+		if err != nil {
+			am.reject(rp.Writer(), err)
+			rp.Abort()
+			return
+		}
+
+		rp.SetRequest(r.WithContext(apps.WithPrincipal(r.Context(), principal)))
+		rp.Next()
+	}
+}
+
+// reject writes the response for a request that failed authentication. A
+// JWKS that can't be fetched is the server's fault, so it gets a 503.
+func (am *AuthMiddleware) reject(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrJWKSUnavailable) {
+		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	challenge := fmt.Sprintf("%s realm=%q", am.scheme, am.realm)
+	message := "Unauthorized"
+	if am.scheme == schemeBearer && !errors.Is(err, errMissingCredentials) {
+		challenge += `, error="invalid_token"`
+		if !errors.Is(err, errUnknownCredentials) {
+			description := describeTokenError(err)
+			challenge += fmt.Sprintf(`, error_description=%q`, description)
+			message += ": " + description
+		}
+	}
+
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, message, http.StatusUnauthorized)
+}
+
+// describeTokenError returns the message of the sentinel behind a JWT
+// validation failure, leaving out details such as key IDs
+func describeTokenError(err error) string {
+	for _, sentinel := range tokenErrors {
+		if errors.Is(err, sentinel) {
+			return sentinel.Error()
+		}
+	}
+	return "invalid token"
+}
+
+// authenticateAPIKey checks the key in the configured header, falling back to
+// the query parameter.
+func (am *AuthMiddleware) authenticateAPIKey(r *http.Request) (*apps.Principal, error) {
+	var key string
+	if am.header != "" {
+		key = r.Header.Get(am.header)
+	}
+	if key == "" && am.queryParam != "" {
+		key = r.URL.Query().Get(am.queryParam)
+	}
+	if key == "" {
+		return nil, errMissingCredentials
+	}
+
+	subject, ok := am.lookup(key)
+	if !ok {
+		return nil, errUnknownCredentials
+	}
+	return &apps.Principal{Subject: subject, Method: MethodAPIKey}, nil
+}
+
+// authenticateBearer checks the bearer token against the allowed tokens.
+func (am *AuthMiddleware) authenticateBearer(r *http.Request) (*apps.Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, errMissingCredentials
+	}
+
+	subject, ok := am.lookup(token)
+	if !ok {
+		return nil, errUnknownCredentials
+	}
+	return &apps.Principal{Subject: subject, Method: MethodBearer}, nil
+}
+
+// authenticateJWT verifies the bearer token as a JWT. The token's "sub" claim
+// is the principal's subject.
+func (am *AuthMiddleware) authenticateJWT(r *http.Request) (*apps.Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, errMissingCredentials
+	}
+
+	claims, err := am.jwt.verify(r.Context(), token, am.now())
+	if err != nil {
+		return nil, err
+	}
+	subject, _ := claims["sub"].(string)
+	return &apps.Principal{Subject: subject, Method: MethodJWT, Claims: claims}, nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// lookup reports whether key is one of the configured keys and returns its
+// fingerprint, which identifies the client without revealing the key.
+func (am *AuthMiddleware) lookup(key string) (string, bool) {
+	digest := sha256.Sum256([]byte(key))
+	if _, ok := am.keys[digest]; !ok {
+		return "", false
+	}
+	return "sha256:" + hex.EncodeToString(digest[:4]), true
+}
@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/auth"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve sends req through the middleware to a handler that records the
+// principal it sees
+func serve(
+	t *testing.T,
+	middleware *AuthMiddleware,
+	req *http.Request,
+) (*httptest.ResponseRecorder, *apps.Principal) {
+	t.Helper()
+	var principal *apps.Principal
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			principal = apps.PrincipalFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}, middleware.Middleware())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec, principal
+}
+
+func TestNewAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewAuthMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("empty key list", func(t *testing.T) {
+		_, err := NewAuthMiddleware("test", &auth.Auth{APIKey: &auth.APIKey{}})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, auth.ErrEmptyKeys)
+	})
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	t.Parallel()
+
+	middleware, err := NewAuthMiddleware("test", &auth.Auth{
+		Realm:  "api",
+		APIKey: &auth.APIKey{Header: "X-Key", QueryParam: "key", Keys: []string{"k1", "k2"}},
+	})
+	require.NoError(t, err)
+
+	t.Run("key in header", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-Key", "k2")
+
+		rec, principal := serve(t, middleware, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, MethodAPIKey, principal.Method)
+		assert.Regexp(t, `^sha256:[0-9a-f]{8}$`, principal.Subject)
+		assert.NotContains(t, principal.Subject, "k2")
+	})
+
+	t.Run("key in query parameter", func(t *testing.T) {
+		rec, principal := serve(t, middleware, httptest.NewRequest(http.MethodGet, "/test?key=k1", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+	})
+
+	t.Run("different keys have different subjects", func(t *testing.T) {
+		_, p1 := serve(t, middleware, httptest.NewRequest(http.MethodGet, "/test?key=k1", nil))
+		_, p2 := serve(t, middleware, httptest.NewRequest(http.MethodGet, "/test?key=k2", nil))
+		require.NotNil(t, p1)
+		require.NotNil(t, p2)
+		assert.NotEqual(t, p1.Subject, p2.Subject)
+	})
+
+	for name, req := range map[string]*http.Request{
+		"missing key": httptest.NewRequest(http.MethodGet, "/test", nil),
+		"unknown key": httptest.NewRequest(http.MethodGet, "/test?key=nope", nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec, principal := serve(t, middleware, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, `APIKey realm="api"`, rec.Header().Get("WWW-Authenticate"))
+			assert.Nil(t, principal, "handler should not run")
+		})
+	}
+
+	t.Run("default header", func(t *testing.T) {
+		defaultHeader, err := NewAuthMiddleware("test", &auth.Auth{APIKey: &auth.APIKey{Keys: []string{"k1"}}})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set(auth.DefaultAPIKeyHeader, "k1")
+		rec, _ := serve(t, defaultHeader, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		rec, _ = serve(t, defaultHeader, httptest.NewRequest(http.MethodGet, "/test?key=k1", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `APIKey realm="firelynx"`, rec.Header().Get("WWW-Authenticate"))
+	})
+}
+
+// bearerRequest returns a request carrying the given Authorization header
+func bearerRequest(authorization string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	return req
+}
+
+func TestAuthMiddleware_Bearer(t *testing.T) {
+	t.Parallel()
+
+	middleware, err := NewAuthMiddleware("test", &auth.Auth{
+		Bearer: &auth.Bearer{Tokens: []string{"opaque-token"}},
+	})
+	require.NoError(t, err)
+
+	t.Run("allowed token", func(t *testing.T) {
+		rec, principal := serve(t, middleware, bearerRequest("bearer opaque-token"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, MethodBearer, principal.Method)
+		assert.Regexp(t, `^sha256:[0-9a-f]{8}$`, principal.Subject)
+		assert.Nil(t, principal.Claims)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		rec, principal := serve(t, middleware, bearerRequest(""))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Bearer realm="firelynx"`, rec.Header().Get("WWW-Authenticate"))
+		assert.Nil(t, principal)
+	})
+
+	t.Run("wrong scheme", func(t *testing.T) {
+		rec, _ := serve(t, middleware, bearerRequest("Basic opaque-token"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, `Bearer realm="firelynx"`, rec.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		rec, principal := serve(t, middleware, bearerRequest("Bearer not-a-token"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t,
+			`Bearer realm="firelynx", error="invalid_token"`,
+			rec.Header().Get("WWW-Authenticate"))
+		assert.Nil(t, principal)
+	})
+}
+
+func TestAuthMiddleware_JWT(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	hs256 := map[string]any{"alg": "HS256"}
+
+	t.Run("shared secret", func(t *testing.T) {
+		middleware, err := NewAuthMiddleware("test", &auth.Auth{
+			Realm: "api",
+			JWT:   &auth.JWT{Secret: "shared-secret", Issuer: "issuer", Audience: []string{"firelynx"}},
+		})
+		require.NoError(t, err)
+		middleware.now = func() time.Time { return now }
+
+		token := signJWT(t, hs256, map[string]any{
+			"sub":   "user-1",
+			"iss":   "issuer",
+			"aud":   "firelynx",
+			"scope": "read",
+			"exp":   now.Add(time.Hour).Unix(),
+		}, "shared-secret")
+
+		rec, principal := serve(t, middleware, bearerRequest("Bearer "+token))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, MethodJWT, principal.Method)
+		assert.Equal(t, "user-1", principal.Subject)
+		assert.Equal(t, "read", principal.Claims["scope"])
+	})
+
+	t.Run("invalid tokens are described", func(t *testing.T) {
+		middleware, err := NewAuthMiddleware("test", &auth.Auth{
+			JWT: &auth.JWT{Secret: "shared-secret", Issuer: "issuer"},
+		})
+		require.NoError(t, err)
+		middleware.now = func() time.Time { return now }
+
+		tests := []struct {
+			name        string
+			token       string
+			description string
+		}{
+			{
+				name:        "expired",
+				token:       signJWT(t, hs256, map[string]any{"iss": "issuer", "exp": now.Unix()}, "shared-secret"),
+				description: "token expired",
+			},
+			{
+				name:        "wrong secret",
+				token:       signJWT(t, hs256, map[string]any{"iss": "issuer"}, "other-secret"),
+				description: "invalid token signature",
+			},
+			{
+				name:        "wrong issuer",
+				token:       signJWT(t, hs256, map[string]any{"iss": "other", "exp": now.Add(time.Hour).Unix()}, "shared-secret"),
+				description: "invalid token issuer",
+			},
+			{
+				name:        "missing exp",
+				token:       signJWT(t, hs256, map[string]any{"iss": "issuer"}, "shared-secret"),
+				description: "token has no valid exp claim",
+			},
+			{
+				name:        "not a JWT",
+				token:       "opaque-token",
+				description: "malformed token",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rec, principal := serve(t, middleware, bearerRequest("Bearer "+tt.token))
+				assert.Equal(t, http.StatusUnauthorized, rec.Code)
+				assert.Equal(t,
+					`Bearer realm="firelynx", error="invalid_token", error_description="`+tt.description+`"`,
+					rec.Header().Get("WWW-Authenticate"))
+				assert.Equal(t, "Unauthorized: "+tt.description+"\n", rec.Body.String())
+				assert.Nil(t, principal)
+			})
+		}
+	})
+
+	t.Run("allow missing exp", func(t *testing.T) {
+		middleware, err := NewAuthMiddleware("test", &auth.Auth{
+			JWT: &auth.JWT{Secret: "shared-secret", AllowMissingExp: true},
+		})
+		require.NoError(t, err)
+
+		token := signJWT(t, hs256, map[string]any{"sub": "user-1"}, "shared-secret")
+		rec, principal := serve(t, middleware, bearerRequest("Bearer "+token))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, "user-1", principal.Subject)
+	})
+
+	t.Run("JWKS", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		server, jwks := jwksServer(t, ecJWK(t, "k1", &key.PublicKey))
+
+		middleware, err := NewAuthMiddleware("test", &auth.Auth{JWT: &auth.JWT{JWKSURL: server.URL}})
+		require.NoError(t, err)
+
+		rec, principal := serve(t, middleware, bearerRequest("Bearer "+signES256(t, key, "k1", map[string]any{"sub": "user-2", "exp": time.Now().Add(time.Hour).Unix()})))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, "user-2", principal.Subject)
+
+		rec, _ = serve(t, middleware, bearerRequest("Bearer "+signES256(t, key, "k9", map[string]any{"sub": "user-2", "exp": time.Now().Add(time.Hour).Unix()})))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Header().Get("WWW-Authenticate"), `error_description="no key to verify token"`)
+		assert.Equal(t, int32(1), jwks.fetches.Load(), "unknown kid refetch should be rate limited")
+	})
+
+	t.Run("unreachable JWKS", func(t *testing.T) {
+		server, jwks := jwksServer(t)
+		jwks.setFail(true)
+
+		middleware, err := NewAuthMiddleware("test", &auth.Auth{JWT: &auth.JWT{JWKSURL: server.URL}})
+		require.NoError(t, err)
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		rec, principal := serve(t, middleware, bearerRequest("Bearer "+signES256(t, key, "k1", map[string]any{"sub": "user-2"})))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Nil(t, principal)
+	})
+
+	t.Run("missing verification key", func(t *testing.T) {
+		_, err := NewAuthMiddleware("test", &auth.Auth{JWT: &auth.JWT{Issuer: "issuer"}})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, auth.ErrNoVerificationKey)
+	})
+}
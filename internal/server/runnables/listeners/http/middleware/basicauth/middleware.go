@@ -0,0 +1,134 @@
+// Package basicauth provides HTTP Basic authentication middleware.
+//
+// Passwords are checked against bcrypt hashes from the config. Usernames are
+// compared in constant time, and a request naming an unknown user still pays
+// for a bcrypt comparison, so response times don't reveal which usernames
+// exist. Requests that fail authentication get a 401 with a Basic
+// WWW-Authenticate challenge and don't reach the rest of the chain.
+// Authenticated requests carry an apps.Principal whose subject is the
+// username, which the logger middleware and script apps read.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "00-basic-auth"
+//	type = "basic_auth"
+//
+//	[endpoints.middlewares.basic_auth]
+//	realm = "internal-tools"
+//
+//	[[endpoints.middlewares.basic_auth.users]]
+//	username = "alice"
+//	password_hash = "${file:/run/secrets/alice_password_hash}"
+package basicauth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/basicauth"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MethodBasic is the authentication method reported in apps.Principal.Method
+const MethodBasic = "basic"
+
+// Sentinel errors for basic auth middleware.
+var (
+	ErrNilConfig     = errors.New("basic auth config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid basic auth config")
+)
+
+// user is a configured username and password hash
+type user struct {
+	username     []byte
+	passwordHash []byte
+}
+
+// BasicAuthMiddleware is a middleware implementation that rejects requests
+// without valid HTTP Basic credentials.
+type BasicAuthMiddleware struct {
+	id        string
+	challenge string
+	users     []user
+
+	// unknownUserHash is compared against when the username isn't known, at
+	// the highest cost of any configured hash
+	unknownUserHash []byte
+}
+
+// NewBasicAuthMiddleware creates a new BasicAuthMiddleware instance.
+func NewBasicAuthMiddleware(id string, cfg *basicauth.BasicAuth) (*BasicAuthMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	users := make([]user, len(cfg.Users))
+	cost := bcrypt.MinCost
+	for i, u := range cfg.Users {
+		users[i] = user{username: []byte(u.Username), passwordHash: []byte(u.PasswordHash)}
+		// Validate checked that every hash has a cost
+		if userCost, _ := bcrypt.Cost(users[i].passwordHash); userCost > cost {
+			cost = userCost
+		}
+	}
+
+	unknownUserHash, err := bcrypt.GenerateFromPassword([]byte(rand.Text()), cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate password hash for unknown users: %w", err)
+	}
+
+	return &BasicAuthMiddleware{
+		id:              id,
+		challenge:       fmt.Sprintf(`Basic realm=%q, charset="UTF-8"`, cfg.GetRealm()),
+		users:           users,
+		unknownUserHash: unknownUserHash,
+	}, nil
+}
+
+// Middleware returns the middleware function that authenticates requests.
+func (bm *BasicAuthMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+
+		username, password, ok := r.BasicAuth()
+		if !ok || !bm.authenticate(username, password) {
+			w := rp.Writer()
+			w.Header().Set("WWW-Authenticate", bm.challenge)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			rp.Abort()
+			return
+		}
+
+		principal := &apps.Principal{Subject: username, Method: MethodBasic}
+		rp.SetRequest(r.WithContext(apps.WithPrincipal(r.Context(), principal)))
+		rp.Next()
+	}
+}
+
+// authenticate reports whether password is the password of username. Every
+// configured username is compared, and a bcrypt comparison runs even when no
+// username matches, so the time taken doesn't depend on which users exist.
+func (bm *BasicAuthMiddleware) authenticate(username, password string) bool {
+	hash := bm.unknownUserHash
+	found := 0
+	for _, u := range bm.users {
+		match := subtle.ConstantTimeCompare(u.username, []byte(username))
+		if match == 1 {
+			hash = u.passwordHash
+		}
+		found |= match
+	}
+
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	return found == 1 && err == nil
+}
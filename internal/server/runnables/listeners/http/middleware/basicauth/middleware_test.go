@@ -0,0 +1,127 @@
+package basicauth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/basicauth"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashPassword returns a low-cost bcrypt hash of password
+func hashPassword(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	return string(hash)
+}
+
+// serve sends req through the middleware to a handler that records the
+// principal it sees
+func serve(
+	t *testing.T,
+	middleware *BasicAuthMiddleware,
+	req *http.Request,
+) (*httptest.ResponseRecorder, *apps.Principal) {
+	t.Helper()
+	var principal *apps.Principal
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			principal = apps.PrincipalFromContext(r.Context())
+			w.WriteHeader(http.StatusOK)
+		}, middleware.Middleware())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec, principal
+}
+
+func TestNewBasicAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewBasicAuthMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("plaintext password", func(t *testing.T) {
+		_, err := NewBasicAuthMiddleware("test", &basicauth.BasicAuth{
+			Users: []basicauth.User{{Username: "alice", PasswordHash: "secret"}},
+		})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, basicauth.ErrInvalidPasswordHash)
+	})
+
+	t.Run("unknown user hash matches the highest cost", func(t *testing.T) {
+		costly, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost+1)
+		require.NoError(t, err)
+
+		middleware, err := NewBasicAuthMiddleware("test", &basicauth.BasicAuth{
+			Users: []basicauth.User{
+				{Username: "alice", PasswordHash: hashPassword(t, "secret")},
+				{Username: "bob", PasswordHash: string(costly)},
+			},
+		})
+		require.NoError(t, err)
+
+		cost, err := bcrypt.Cost(middleware.unknownUserHash)
+		require.NoError(t, err)
+		assert.Equal(t, bcrypt.MinCost+1, cost)
+	})
+}
+
+func TestBasicAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	middleware, err := NewBasicAuthMiddleware("test", &basicauth.BasicAuth{
+		Realm: "tools",
+		Users: []basicauth.User{
+			{Username: "alice", PasswordHash: hashPassword(t, "alice-password")},
+			{Username: "bob", PasswordHash: hashPassword(t, "bob-password")},
+		},
+	})
+	require.NoError(t, err)
+
+	t.Run("valid credentials", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.SetBasicAuth("bob", "bob-password")
+
+		rec, principal := serve(t, middleware, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, principal)
+		assert.Equal(t, "bob", principal.Subject)
+		assert.Equal(t, MethodBasic, principal.Method)
+	})
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		noAuth   bool
+	}{
+		{name: "missing credentials", noAuth: true},
+		{name: "wrong password", username: "alice", password: "bob-password"},
+		{name: "unknown user", username: "carol", password: "alice-password"},
+		{name: "empty username", username: "", password: "alice-password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			if !tt.noAuth {
+				req.SetBasicAuth(tt.username, tt.password)
+			}
+
+			rec, principal := serve(t, middleware, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.Equal(t, `Basic realm="tools", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+			assert.Nil(t, principal, "handler should not run")
+		})
+	}
+}
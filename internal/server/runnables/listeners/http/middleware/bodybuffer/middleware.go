@@ -0,0 +1,100 @@
+// Package bodybuffer provides middleware that buffers the request body so
+// every later middleware and the app can read it in full.
+//
+// The body is read once, up to a size limit, and stored in the request
+// context. apps.ReadBody returns the buffer to each caller, so the logger,
+// signature checks, retries and the app all see the same bytes even when one
+// of them drains the request's Body without restoring it. Requests whose body
+// is larger than the limit are rejected with 413 Request Entity Too Large.
+//
+// Middleware runs in ID order, so give the body buffer an ID that sorts before
+// the middleware reading the body.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "00-body-buffer"
+//	type = "body_buffer"
+//
+//	[endpoints.middlewares.body_buffer]
+//	max_size = 1048576
+package bodybuffer
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/bodybuffer"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for body buffer middleware.
+var (
+	ErrNilConfig     = errors.New("body buffer config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid body buffer config")
+)
+
+// BodyBufferMiddleware is a middleware implementation that buffers request
+// bodies for repeated reads.
+type BodyBufferMiddleware struct {
+	id      string
+	maxSize int64
+	logger  *slog.Logger
+}
+
+// NewBodyBufferMiddleware creates a new BodyBufferMiddleware instance.
+func NewBodyBufferMiddleware(id string, cfg *bodybuffer.BodyBuffer) (*BodyBufferMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return &BodyBufferMiddleware{
+		id:      id,
+		maxSize: cfg.GetMaxSize(),
+		logger:  slog.Default().WithGroup("body_buffer").With("id", id),
+	}, nil
+}
+
+// Middleware returns the middleware function that buffers the request body
+func (bm *BodyBufferMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+		if _, ok := apps.BufferedBody(r.Context()); ok {
+			rp.Next()
+			return
+		}
+
+		body, err := apps.BufferBody(r, bm.maxSize)
+		if err != nil {
+			if errors.Is(err, apps.ErrBodyTooLarge) {
+				bm.logger.WarnContext(r.Context(), "Request body too large",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"error", err,
+				)
+				http.Error(rp.Writer(),
+					fmt.Sprintf("Request Entity Too Large: %s", err),
+					http.StatusRequestEntityTooLarge)
+			} else {
+				bm.logger.DebugContext(r.Context(), "Failed to read request body",
+					"method", r.Method,
+					"path", r.URL.Path,
+					"error", err,
+				)
+				http.Error(rp.Writer(), "Bad Request", http.StatusBadRequest)
+			}
+			rp.Abort()
+			return
+		}
+
+		rp.SetRequest(r.WithContext(apps.WithBufferedBody(r.Context(), body)))
+		rp.Next()
+	}
+}
@@ -0,0 +1,142 @@
+package bodybuffer
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/bodybuffer"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMiddleware(t *testing.T, maxSize int64) *BodyBufferMiddleware {
+	t.Helper()
+	bm, err := NewBodyBufferMiddleware("body", &bodybuffer.BodyBuffer{MaxSize: maxSize})
+	require.NoError(t, err)
+	bm.logger = slog.New(slog.DiscardHandler)
+	return bm
+}
+
+// serve routes req through the middleware, then a middleware draining the
+// body without restoring it, to an app reading the body with apps.ReadBody
+func serve(t *testing.T, bm *BodyBufferMiddleware, req *http.Request) (*httptest.ResponseRecorder, *string) {
+	t.Helper()
+	var drained, appBody *string
+	drain := func(rp *httpserver.RequestProcessor) {
+		b, err := io.ReadAll(rp.Request().Body)
+		require.NoError(t, err)
+		s := string(b)
+		drained = &s
+		rp.Next()
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			body, err := apps.ReadBody(r)
+			require.NoError(t, err)
+			s := string(body)
+			appBody = &s
+		}, bm.Middleware(), drain)
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	if drained != nil && appBody != nil {
+		assert.Equal(t, *drained, *appBody)
+	}
+	return rec, appBody
+}
+
+func TestNewBodyBufferMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewBodyBufferMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewBodyBufferMiddleware("test", &bodybuffer.BodyBuffer{MaxSize: -1})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, bodybuffer.ErrNegativeMaxSize)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		bm, err := NewBodyBufferMiddleware("test", &bodybuffer.BodyBuffer{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(bodybuffer.DefaultMaxSize), bm.maxSize)
+	})
+}
+
+func TestBodyBufferMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("body is readable after being drained", func(t *testing.T) {
+		bm := newTestMiddleware(t, 64)
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"ok":true}`))
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, appBody)
+		assert.JSONEq(t, `{"ok":true}`, *appBody)
+	})
+
+	t.Run("request without a body", func(t *testing.T) {
+		bm := newTestMiddleware(t, 64)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, appBody)
+		assert.Empty(t, *appBody)
+	})
+
+	t.Run("declared length above the limit", func(t *testing.T) {
+		bm := newTestMiddleware(t, 4)
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("too long"))
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Contains(t, rec.Body.String(), "exceeds the 4 byte buffer limit")
+		assert.Nil(t, appBody)
+	})
+
+	t.Run("streamed body above the limit", func(t *testing.T) {
+		bm := newTestMiddleware(t, 4)
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("too long"))
+		req.ContentLength = -1
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Nil(t, appBody)
+	})
+
+	t.Run("body that fails to read", func(t *testing.T) {
+		bm := newTestMiddleware(t, 64)
+		req := httptest.NewRequest(http.MethodPost, "/test", iotest.ErrReader(errors.New("reset")))
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, appBody)
+	})
+
+	t.Run("already buffered body is kept", func(t *testing.T) {
+		bm := newTestMiddleware(t, 4)
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("buffered"))
+		body, err := apps.BufferBody(req, 64)
+		require.NoError(t, err)
+		req = req.WithContext(apps.WithBufferedBody(req.Context(), body))
+
+		rec, appBody := serve(t, bm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.NotNil(t, appBody)
+		assert.Equal(t, "buffered", *appBody)
+	})
+}
@@ -0,0 +1,40 @@
+package cache
+
+import "context"
+
+// Result is how the cache handled a request
+type Result string
+
+const (
+	// ResultHit is a response served from the cache
+	ResultHit Result = "hit"
+	// ResultMiss is a response produced by the app, and stored if cacheable
+	ResultMiss Result = "miss"
+	// ResultBypass is a request the cache doesn't handle, such as a POST or
+	// one with Cache-Control: no-store
+	ResultBypass Result = "bypass"
+)
+
+// Status records how the cache handled a request
+type Status struct {
+	// ID is the ID of the cache middleware
+	ID string
+
+	// Result is whether the response came from the cache
+	Result Result
+}
+
+// statusKey is the context key for the cache status
+type statusKey struct{}
+
+// withStatus returns a copy of ctx carrying the cache status
+func withStatus(ctx context.Context, s *Status) context.Context {
+	return context.WithValue(ctx, statusKey{}, s)
+}
+
+// StatusFromContext returns the cache status stored in ctx, or nil if the
+// request didn't pass through a cache middleware
+func StatusFromContext(ctx context.Context) *Status {
+	s, _ := ctx.Value(statusKey{}).(*Status)
+	return s
+}
@@ -0,0 +1,269 @@
+// Package cache provides middleware that serves repeated GET and HEAD
+// requests from an in-memory cache of responses.
+//
+// Responses are stored for the configured TTL, keyed by the request method,
+// host, path and query, and the values of the configured vary headers. A
+// cached response is written without running the middleware after the cache
+// or the app. The cache holds at most max_entries responses, evicting the
+// least recently used first, and skips bodies over max_body_size. Each cache
+// middleware instance has its own cache; set one on a route to give that
+// route its own TTL.
+//
+// Requests with Cache-Control: no-cache, or Pragma: no-cache, skip the
+// lookup and refresh the stored response. Requests with Cache-Control:
+// no-store, a Range header, or credentials (Authorization or Cookie headers
+// that aren't vary headers) bypass the cache. Responses are only stored with
+// a cacheable status, without Set-Cookie, without a Cache-Control no-store,
+// no-cache or private directive, and with a Vary header naming only vary
+// headers.
+//
+// Whether the response was a hit, a miss or bypassed the cache is stored in
+// the request context, where the logger middleware reads it.
+//
+// Example configuration:
+//
+//	[[endpoints.routes.middlewares]]
+//	id = "30-cache"
+//	type = "cache"
+//
+//	[endpoints.routes.middlewares.cache]
+//	ttl = "30s"
+//	max_entries = 1000
+//	max_body_size = 1048576
+//	vary_headers = ["Accept"]
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for cache middleware.
+var (
+	ErrNilConfig     = errors.New("cache config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid cache config")
+)
+
+// cacheableStatus lists the status codes whose responses are stored, the
+// codes RFC 9110 defines as heuristically cacheable apart from 206
+var cacheableStatus = []int{
+	http.StatusOK,
+	http.StatusNonAuthoritativeInfo,
+	http.StatusNoContent,
+	http.StatusMultipleChoices,
+	http.StatusMovedPermanently,
+	http.StatusPermanentRedirect,
+	http.StatusNotFound,
+	http.StatusMethodNotAllowed,
+	http.StatusGone,
+	http.StatusRequestURITooLong,
+	http.StatusNotImplemented,
+}
+
+// CacheMiddleware is a middleware implementation that serves stored responses
+// to repeated requests.
+type CacheMiddleware struct {
+	id          string
+	ttl         time.Duration
+	maxBodySize int64
+	varyHeaders []string
+	entries     *lru
+
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewCacheMiddleware creates a new CacheMiddleware instance.
+func NewCacheMiddleware(id string, cfg *cache.Cache) (*CacheMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return &CacheMiddleware{
+		id:          id,
+		ttl:         cfg.TTL,
+		maxBodySize: cfg.GetMaxBodySize(),
+		varyHeaders: cfg.GetVaryHeaders(),
+		entries:     newLRU(cfg.GetMaxEntries()),
+		now:         time.Now,
+	}, nil
+}
+
+// Middleware returns the middleware function that serves cached responses
+// and stores the cacheable responses of the app.
+func (cm *CacheMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		status := &Status{ID: cm.id, Result: ResultBypass}
+		r := rp.Request()
+		r = r.WithContext(withStatus(r.Context(), status))
+		rp.SetRequest(r)
+
+		if !cm.cacheableRequest(r) {
+			rp.Next()
+			return
+		}
+
+		key := cm.key(r)
+		if !noCache(r.Header) {
+			if e, ok := cm.entries.get(key, cm.now()); ok {
+				status.Result = ResultHit
+				cm.serve(rp.Writer(), r, e)
+				rp.Abort()
+				return
+			}
+		}
+		status.Result = ResultMiss
+
+		// Share the error report of a retry middleware earlier in the chain,
+		// which the app handler fills in
+		report := apps.ErrorReportFromContext(r.Context())
+		if report == nil {
+			report = &apps.ErrorReport{}
+			rp.SetRequest(r.WithContext(apps.WithErrorReport(r.Context(), report)))
+		}
+
+		w := rp.Writer()
+		rw := newRecordingWriter(w, cm.maxBodySize)
+		rp.SetWriter(rw)
+		rp.Next()
+		rp.SetWriter(w)
+
+		cm.store(key, rw, report.Err)
+	}
+}
+
+// store keeps the recorded response under key if it is cacheable, and
+// otherwise drops the response stored under key, which a no-cache request
+// was meant to refresh
+func (cm *CacheMiddleware) store(key string, rw *recordingWriter, appErr error) {
+	status, header, body, ok := rw.recorded()
+	if !ok || appErr != nil || !slices.Contains(cacheableStatus, status) || !cm.storable(header) {
+		cm.entries.remove(key)
+		return
+	}
+	now := cm.now()
+	cm.entries.add(&entry{
+		key:     key,
+		status:  status,
+		header:  header,
+		body:    body,
+		stored:  now,
+		expires: now.Add(cm.ttl),
+	})
+}
+
+// cacheableRequest reports whether the cache handles r. Requests carrying
+// credentials are only cached when the credential header is a vary header,
+// so one client's response is never served to another.
+func (cm *CacheMiddleware) cacheableRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if r.Header.Get("Range") != "" || hasDirective(r.Header, "no-store") {
+		return false
+	}
+	for _, name := range []string{"Authorization", "Cookie"} {
+		if r.Header.Get(name) != "" && !slices.Contains(cm.varyHeaders, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// storable reports whether a response with header can be stored and served
+// to other requests with the same key
+func (cm *CacheMiddleware) storable(header http.Header) bool {
+	if header.Get("Set-Cookie") != "" {
+		return false
+	}
+	for _, directive := range []string{"no-store", "no-cache", "private"} {
+		if hasDirective(header, directive) {
+			return false
+		}
+	}
+	for _, name := range header.Values("Vary") {
+		for field := range strings.SplitSeq(name, ",") {
+			field = http.CanonicalHeaderKey(strings.TrimSpace(field))
+			if field == "" {
+				continue
+			}
+			if field == "*" || !slices.Contains(cm.varyHeaders, field) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// key returns the cache key of r
+func (cm *CacheMiddleware) key(r *http.Request) string {
+	var b strings.Builder
+	b.WriteString(r.Method)
+	b.WriteByte(0)
+	b.WriteString(strings.ToLower(r.Host))
+	b.WriteByte(0)
+	b.WriteString(r.URL.RequestURI())
+	for _, name := range cm.varyHeaders {
+		b.WriteByte(0)
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(strings.Join(r.Header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// serve writes the cached response e, with an Age header giving the seconds
+// since it was stored. A request whose If-None-Match matches the response's
+// ETag gets a 304.
+func (cm *CacheMiddleware) serve(w http.ResponseWriter, r *http.Request, e *entry) {
+	header := w.Header()
+	for key, values := range e.header {
+		header[key] = slices.Clone(values)
+	}
+	header.Set("Age", strconv.FormatInt(int64(cm.now().Sub(e.stored)/time.Second), 10))
+
+	if etag := e.header.Get("ETag"); etag != "" && e.status == http.StatusOK && apps.NotModified(r, etag) {
+		apps.WriteNotModified(w, etag)
+		return
+	}
+	w.WriteHeader(e.status)
+	if len(e.body) > 0 {
+		// The response is committed, a failed write only affects this client
+		_, _ = w.Write(e.body) //nolint:errcheck
+	}
+}
+
+// noCache reports whether the request asks for a fresh response
+func noCache(header http.Header) bool {
+	if header.Get("Cache-Control") == "" {
+		return strings.EqualFold(strings.TrimSpace(header.Get("Pragma")), "no-cache")
+	}
+	return hasDirective(header, "no-cache")
+}
+
+// hasDirective reports whether the Cache-Control header has the directive,
+// with or without a value
+func hasDirective(header http.Header, directive string) bool {
+	for _, value := range header.Values("Cache-Control") {
+		for d := range strings.SplitSeq(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(d), "=")
+			if strings.EqualFold(name, directive) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,386 @@
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp counts its calls and answers with a body naming the call
+type testApp struct {
+	mu      sync.Mutex
+	calls   int
+	respond func(w http.ResponseWriter, r *http.Request, call int)
+}
+
+func (a *testApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.calls++
+	call := a.calls
+	a.mu.Unlock()
+
+	if a.respond != nil {
+		a.respond(w, r, call)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = fmt.Fprintf(w, "response %d", call)
+}
+
+func (a *testApp) callCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls
+}
+
+// testCache is a cache middleware with a clock the test moves
+type testCache struct {
+	*CacheMiddleware
+	app *testApp
+	now time.Time
+}
+
+func newTestCache(t *testing.T, cfg *cache.Cache) *testCache {
+	t.Helper()
+	cm, err := NewCacheMiddleware("cache", cfg)
+	require.NoError(t, err)
+	tc := &testCache{CacheMiddleware: cm, app: &testApp{}, now: time.Unix(1700000000, 0)}
+	cm.now = func() time.Time { return tc.now }
+	return tc
+}
+
+// serve sends req through the cache middleware to the test app, and returns
+// the response and the cache status the logger would see
+func (tc *testCache) serve(t *testing.T, req *http.Request) (*httptest.ResponseRecorder, *Status) {
+	t.Helper()
+	var status *Status
+	observe := func(rp *httpserver.RequestProcessor) {
+		rp.Next()
+		status = StatusFromContext(rp.Request().Context())
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/",
+		tc.app.ServeHTTP, observe, tc.Middleware())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec, status
+}
+
+func (tc *testCache) get(t *testing.T, target string, headers ...string) (*httptest.ResponseRecorder, *Status) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Add(headers[i], headers[i+1])
+	}
+	return tc.serve(t, req)
+}
+
+func TestNewCacheMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewCacheMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewCacheMiddleware("test", &cache.Cache{})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, cache.ErrMissingTTL)
+	})
+}
+
+func TestCacheMiddleware_HitAndMiss(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+
+	rec, status := tc.get(t, "/items?page=1")
+	assert.Equal(t, "response 1", rec.Body.String())
+	require.NotNil(t, status)
+	assert.Equal(t, "cache", status.ID)
+	assert.Equal(t, ResultMiss, status.Result)
+
+	tc.now = tc.now.Add(10 * time.Second)
+	rec, status = tc.get(t, "/items?page=1")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "response 1", rec.Body.String())
+	assert.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "10", rec.Header().Get("Age"))
+	assert.Equal(t, ResultHit, status.Result)
+	assert.Equal(t, 1, tc.app.callCount())
+
+	// Other queries are other responses
+	rec, _ = tc.get(t, "/items?page=2")
+	assert.Equal(t, "response 2", rec.Body.String())
+
+	// Entries expire after the TTL
+	tc.now = tc.now.Add(time.Minute)
+	rec, status = tc.get(t, "/items?page=1")
+	assert.Equal(t, "response 3", rec.Body.String())
+	assert.Equal(t, ResultMiss, status.Result)
+}
+
+func TestCacheMiddleware_RequestDirectives(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no-cache refreshes the entry", func(t *testing.T) {
+		tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+		tc.get(t, "/")
+
+		rec, status := tc.get(t, "/", "Cache-Control", "no-cache")
+		assert.Equal(t, "response 2", rec.Body.String())
+		assert.Equal(t, ResultMiss, status.Result)
+
+		rec, _ = tc.get(t, "/")
+		assert.Equal(t, "response 2", rec.Body.String())
+	})
+
+	t.Run("pragma no-cache", func(t *testing.T) {
+		tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+		tc.get(t, "/")
+		rec, _ := tc.get(t, "/", "Pragma", "no-cache")
+		assert.Equal(t, "response 2", rec.Body.String())
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		headers []string
+	}{
+		{name: "post", method: http.MethodPost},
+		{name: "no-store", method: http.MethodGet, headers: []string{"Cache-Control", "max-age=0, no-store"}},
+		{name: "range", method: http.MethodGet, headers: []string{"Range", "bytes=0-1"}},
+		{name: "authorization", method: http.MethodGet, headers: []string{"Authorization", "Bearer x"}},
+		{name: "cookie", method: http.MethodGet, headers: []string{"Cookie", "session=x"}},
+	}
+	for _, tt := range tests {
+		t.Run("bypass "+tt.name, func(t *testing.T) {
+			tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+			for range 2 {
+				req := httptest.NewRequest(tt.method, "/", nil)
+				for i := 0; i+1 < len(tt.headers); i += 2 {
+					req.Header.Set(tt.headers[i], tt.headers[i+1])
+				}
+				_, status := tc.serve(t, req)
+				assert.Equal(t, ResultBypass, status.Result)
+			}
+			assert.Equal(t, 2, tc.app.callCount())
+		})
+	}
+}
+
+func TestCacheMiddleware_VaryHeaders(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute, VaryHeaders: []string{"accept", "Authorization"}})
+
+	json, _ := tc.get(t, "/", "Accept", "application/json")
+	text, _ := tc.get(t, "/", "Accept", "text/plain")
+	assert.Equal(t, "response 1", json.Body.String())
+	assert.Equal(t, "response 2", text.Body.String())
+
+	again, status := tc.get(t, "/", "Accept", "application/json")
+	assert.Equal(t, "response 1", again.Body.String())
+	assert.Equal(t, ResultHit, status.Result)
+
+	// Credentials that are part of the key are cached per credential
+	alice, status := tc.get(t, "/", "Authorization", "Bearer alice")
+	assert.Equal(t, ResultMiss, status.Result)
+	bob, _ := tc.get(t, "/", "Authorization", "Bearer bob")
+	assert.NotEqual(t, alice.Body.String(), bob.Body.String())
+}
+
+func TestCacheMiddleware_UncacheableResponses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		respond func(w http.ResponseWriter)
+	}{
+		{
+			name: "server error",
+			respond: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		},
+		{
+			name: "created",
+			respond: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusCreated)
+			},
+		},
+		{
+			name: "no-store",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Cache-Control", "no-store")
+			},
+		},
+		{
+			name: "private",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Cache-Control", "private, max-age=60")
+			},
+		},
+		{
+			name: "set-cookie",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Set-Cookie", "session=x")
+			},
+		},
+		{
+			name: "vary on a header outside the key",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Vary", "Accept, Accept-Language")
+			},
+		},
+		{
+			name: "vary star",
+			respond: func(w http.ResponseWriter) {
+				w.Header().Set("Vary", "*")
+			},
+		},
+		{
+			name: "body over the size limit",
+			respond: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(strings.Repeat("x", 10)))
+				_, _ = w.Write([]byte(strings.Repeat("x", 10)))
+			},
+		},
+		{
+			name: "streamed",
+			respond: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte("chunk"))
+				w.(http.Flusher).Flush()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc := newTestCache(t, &cache.Cache{TTL: time.Minute, MaxBodySize: 16, VaryHeaders: []string{"Accept"}})
+			tc.app.respond = func(w http.ResponseWriter, r *http.Request, call int) {
+				tt.respond(w)
+			}
+			tc.get(t, "/")
+			_, status := tc.get(t, "/")
+			assert.Equal(t, ResultMiss, status.Result)
+			assert.Equal(t, 2, tc.app.callCount())
+		})
+	}
+
+	t.Run("app error", func(t *testing.T) {
+		tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+		tc.app.respond = func(w http.ResponseWriter, r *http.Request, call int) {
+			_, _ = w.Write([]byte("partial"))
+			apps.ErrorReportFromContext(r.Context()).Err = errors.New("failed mid-stream")
+		}
+		tc.get(t, "/")
+		tc.get(t, "/")
+		assert.Equal(t, 2, tc.app.callCount())
+	})
+
+	t.Run("uncacheable refresh drops the entry", func(t *testing.T) {
+		tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+		tc.app.respond = func(w http.ResponseWriter, r *http.Request, call int) {
+			if call == 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = fmt.Fprintf(w, "response %d", call)
+		}
+		tc.get(t, "/")
+		tc.get(t, "/", "Cache-Control", "no-cache")
+		rec, status := tc.get(t, "/")
+		assert.Equal(t, ResultMiss, status.Result)
+		assert.Equal(t, "response 3", rec.Body.String())
+	})
+}
+
+func TestCacheMiddleware_CachedStatusAndHeaders(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+	tc.app.respond = func(w http.ResponseWriter, r *http.Request, call int) {
+		w.Header().Set("X-Call", fmt.Sprint(call))
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("missing"))
+		// Headers set after the response started aren't sent, or cached
+		w.Header().Set("X-Late", "yes")
+	}
+
+	tc.get(t, "/missing")
+	rec, status := tc.get(t, "/missing")
+	assert.Equal(t, ResultHit, status.Result)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "missing", rec.Body.String())
+	assert.Equal(t, "1", rec.Header().Get("X-Call"))
+	assert.Empty(t, rec.Header().Get("X-Late"))
+}
+
+func TestCacheMiddleware_ConditionalHit(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute})
+	tc.app.respond = func(w http.ResponseWriter, r *http.Request, call int) {
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("body"))
+	}
+
+	tc.get(t, "/")
+	rec, status := tc.get(t, "/", "If-None-Match", `"v1"`)
+	assert.Equal(t, ResultHit, status.Result)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, `"v1"`, rec.Header().Get("ETag"))
+}
+
+func TestCacheMiddleware_Eviction(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute, MaxEntries: 2})
+
+	tc.get(t, "/a")
+	tc.get(t, "/b")
+	tc.get(t, "/a") // /b is now the least recently used
+	tc.get(t, "/c")
+	assert.Equal(t, 2, tc.entries.len())
+
+	_, status := tc.get(t, "/a")
+	assert.Equal(t, ResultHit, status.Result)
+	_, status = tc.get(t, "/b")
+	assert.Equal(t, ResultMiss, status.Result)
+}
+
+func TestCacheMiddleware_Concurrent(t *testing.T) {
+	t.Parallel()
+	tc := newTestCache(t, &cache.Cache{TTL: time.Minute, MaxEntries: 8})
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Go(func() {
+			for j := range 50 {
+				rec, _ := tc.get(t, fmt.Sprintf("/%d", (i+j)%12))
+				assert.Equal(t, http.StatusOK, rec.Code)
+			}
+		})
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, tc.entries.len(), 8)
+}
+
+func TestStatusFromContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, StatusFromContext(t.Context()))
+
+	s := &Status{ID: "cache", Result: ResultHit}
+	assert.Same(t, s, StatusFromContext(withStatus(t.Context(), s)))
+}
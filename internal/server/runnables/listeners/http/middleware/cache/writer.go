@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// recordingWriter writes a response through while keeping a copy of it to
+// store. A response whose body outgrows the limit, or that is flushed as a
+// stream, is written through without a copy.
+type recordingWriter struct {
+	httpserver.ResponseWriter
+	limit int64
+
+	status   int
+	header   http.Header
+	body     bytes.Buffer
+	uncached bool
+}
+
+func newRecordingWriter(w httpserver.ResponseWriter, limit int64) *recordingWriter {
+	return &recordingWriter{ResponseWriter: w, limit: limit}
+}
+
+// WriteHeader records the status and a copy of the headers as they are sent.
+// Informational responses are passed on without being recorded.
+func (rw *recordingWriter) WriteHeader(status int) {
+	if rw.status == 0 && status >= http.StatusOK {
+		rw.status = status
+		rw.header = rw.Header().Clone()
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the body up to the limit and writes it through
+func (rw *recordingWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if !rw.uncached {
+		if int64(rw.body.Len()+len(b)) > rw.limit {
+			rw.stopRecording()
+		} else {
+			rw.body.Write(b)
+		}
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Flush stops recording, as a flushed response is a stream, and flushes it
+func (rw *recordingWriter) Flush() {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	rw.stopRecording()
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (rw *recordingWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *recordingWriter) stopRecording() {
+	rw.uncached = true
+	rw.body.Reset()
+}
+
+// recorded returns the status, headers and body of the response, and whether
+// the whole response was recorded. A handler that wrote nothing sends an
+// implicit 200 with the headers it set.
+func (rw *recordingWriter) recorded() (int, http.Header, []byte, bool) {
+	if rw.uncached {
+		return 0, nil, nil, false
+	}
+	if rw.status == 0 {
+		return http.StatusOK, rw.Header().Clone(), nil, true
+	}
+	return rw.status, rw.header, bytes.Clone(rw.body.Bytes()), true
+}
@@ -0,0 +1,205 @@
+package circuitbreaker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// State is the state of a circuit breaker
+type State int
+
+// Circuit breaker states
+const (
+	// StateClosed passes requests through and counts failures
+	StateClosed State = iota
+	// StateOpen rejects every request until the open timeout passes
+	StateOpen
+	// StateHalfOpen lets a limited number of trial requests through
+	StateHalfOpen
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// settings holds the resolved configuration of a breaker
+type settings struct {
+	failureThreshold int
+	failureRatio     float64
+	minRequests      int
+	window           time.Duration
+	openTimeout      time.Duration
+	halfOpenRequests int
+}
+
+// ticket identifies the state a request was admitted under. Results are only
+// counted while the breaker is still in that state, so a slow request started
+// before the breaker opened can't close it again.
+type ticket struct {
+	generation uint64
+	state      State
+}
+
+// breaker is the state machine of one route. It is safe for concurrent use.
+type breaker struct {
+	settings settings
+	logger   *slog.Logger
+	now      func() time.Time
+
+	mu    sync.Mutex
+	state State
+	// generation changes on every state transition
+	generation uint64
+
+	// Counts while closed. The window counts reset when the window elapses.
+	windowStart time.Time
+	requests    int
+	failures    int
+	consecutive int
+
+	// openUntil is when an open breaker half-opens
+	openUntil time.Time
+
+	// Counts while half-open
+	trials    int
+	successes int
+}
+
+// newBreaker returns a closed breaker
+func newBreaker(s settings, logger *slog.Logger, now func() time.Time) *breaker {
+	return &breaker{
+		settings:    s,
+		logger:      logger,
+		now:         now,
+		windowStart: now(),
+	}
+}
+
+// allow reports whether a request may proceed. A rejected request gets the
+// time left until the breaker half-opens.
+func (b *breaker) allow() (ticket, time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if b.state == StateOpen {
+		if now.Before(b.openUntil) {
+			return b.ticket(), b.openUntil.Sub(now), false
+		}
+		b.transition(StateHalfOpen, now)
+	}
+
+	switch b.state {
+	case StateClosed:
+		if now.Sub(b.windowStart) >= b.settings.window {
+			b.resetWindow(now)
+		}
+	case StateHalfOpen:
+		if b.trials >= b.settings.halfOpenRequests {
+			// Trials are in flight, so there is no better estimate than the
+			// time a failed trial would keep the breaker open
+			return b.ticket(), b.settings.openTimeout, false
+		}
+		b.trials++
+	}
+	return b.ticket(), 0, true
+}
+
+// record counts the result of a request admitted with t and returns the
+// state of the breaker afterwards
+func (b *breaker) record(t ticket, failed bool) State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if t.generation != b.generation {
+		return b.state
+	}
+
+	now := b.now()
+	switch b.state {
+	case StateClosed:
+		b.requests++
+		if failed {
+			b.failures++
+			b.consecutive++
+		} else {
+			b.consecutive = 0
+		}
+		if b.shouldTrip() {
+			b.transition(StateOpen, now)
+		}
+	case StateHalfOpen:
+		if failed {
+			b.transition(StateOpen, now)
+			break
+		}
+		b.successes++
+		if b.successes >= b.settings.halfOpenRequests {
+			b.transition(StateClosed, now)
+		}
+	}
+	return b.state
+}
+
+// shouldTrip reports whether the closed counts call for opening the breaker
+func (b *breaker) shouldTrip() bool {
+	s := b.settings
+	if s.failureThreshold > 0 && b.consecutive >= s.failureThreshold {
+		return true
+	}
+	return s.failureRatio > 0 &&
+		b.requests >= s.minRequests &&
+		float64(b.failures)/float64(b.requests) >= s.failureRatio
+}
+
+// transition moves the breaker to state and resets the counts of the new state
+func (b *breaker) transition(state State, now time.Time) {
+	from := b.state
+	b.state = state
+	b.generation++
+
+	switch state {
+	case StateClosed:
+		b.resetWindow(now)
+		b.consecutive = 0
+	case StateOpen:
+		b.openUntil = now.Add(b.settings.openTimeout)
+	case StateHalfOpen:
+		b.trials = 0
+		b.successes = 0
+	}
+
+	level := slog.LevelInfo
+	if state == StateOpen {
+		level = slog.LevelWarn
+	}
+	b.logger.Log(context.Background(), level, "Circuit breaker state changed",
+		"from", from.String(),
+		"to", state.String(),
+	)
+}
+
+// resetWindow starts a new window for the failure ratio. Consecutive
+// failures carry over, as they span windows.
+func (b *breaker) resetWindow(now time.Time) {
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+// ticket returns a ticket for the current state
+func (b *breaker) ticket() ticket {
+	return ticket{generation: b.generation, state: b.state}
+}
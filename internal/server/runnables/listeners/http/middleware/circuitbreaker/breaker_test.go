@@ -0,0 +1,292 @@
+package circuitbreaker
+
+import (
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// testSettings trips after 3 consecutive failures and stays open for 10s
+var testSettings = settings{
+	failureThreshold: 3,
+	minRequests:      10,
+	window:           time.Minute,
+	openTimeout:      10 * time.Second,
+	halfOpenRequests: 2,
+}
+
+func newTestBreaker(s settings) (*breaker, *fakeClock) {
+	clock := newFakeClock()
+	return newBreaker(s, slog.New(slog.DiscardHandler), clock.Now), clock
+}
+
+// do sends one request through the breaker and returns whether it was
+// admitted and the state afterwards
+func do(t *testing.T, b *breaker, failed bool) (bool, State) {
+	t.Helper()
+	tk, _, ok := b.allow()
+	if !ok {
+		return false, tk.state
+	}
+	return true, b.record(tk, failed)
+}
+
+// trip opens the breaker with consecutive failures
+func trip(t *testing.T, b *breaker) {
+	t.Helper()
+	for range b.settings.failureThreshold {
+		ok, _ := do(t, b, true)
+		require.True(t, ok)
+	}
+	require.Equal(t, StateOpen, b.state)
+}
+
+func TestState_String(t *testing.T) {
+	assert.Equal(t, "closed", StateClosed.String())
+	assert.Equal(t, "open", StateOpen.String())
+	assert.Equal(t, "half-open", StateHalfOpen.String())
+	assert.Equal(t, "unknown", State(42).String())
+}
+
+func TestBreaker_Closed(t *testing.T) {
+	t.Run("successes keep it closed", func(t *testing.T) {
+		b, _ := newTestBreaker(testSettings)
+		for range 20 {
+			ok, state := do(t, b, false)
+			assert.True(t, ok)
+			assert.Equal(t, StateClosed, state)
+		}
+	})
+
+	t.Run("a success resets consecutive failures", func(t *testing.T) {
+		b, _ := newTestBreaker(testSettings)
+		for range 5 {
+			do(t, b, true)
+			do(t, b, true)
+			_, state := do(t, b, false)
+			assert.Equal(t, StateClosed, state)
+		}
+	})
+
+	t.Run("consecutive failures open it", func(t *testing.T) {
+		b, _ := newTestBreaker(testSettings)
+		_, state := do(t, b, true)
+		assert.Equal(t, StateClosed, state)
+		_, state = do(t, b, true)
+		assert.Equal(t, StateClosed, state)
+		_, state = do(t, b, true)
+		assert.Equal(t, StateOpen, state)
+	})
+
+	t.Run("failure ratio opens it after min requests", func(t *testing.T) {
+		s := testSettings
+		s.failureThreshold = 0
+		s.failureRatio = 0.5
+		s.minRequests = 4
+		b, _ := newTestBreaker(s)
+
+		// 2 of 3 failed, but fewer than min requests
+		do(t, b, true)
+		do(t, b, false)
+		_, state := do(t, b, true)
+		assert.Equal(t, StateClosed, state)
+
+		// 2 of 4 failed
+		_, state = do(t, b, false)
+		assert.Equal(t, StateOpen, state)
+	})
+
+	t.Run("failure ratio below threshold keeps it closed", func(t *testing.T) {
+		s := testSettings
+		s.failureThreshold = 0
+		s.failureRatio = 0.5
+		s.minRequests = 4
+		b, _ := newTestBreaker(s)
+
+		// 1 in 4 fails
+		for range 10 {
+			for _, failed := range []bool{true, false, false, false} {
+				_, state := do(t, b, failed)
+				require.Equal(t, StateClosed, state)
+			}
+		}
+	})
+
+	t.Run("window resets the failure ratio", func(t *testing.T) {
+		s := testSettings
+		s.failureThreshold = 0
+		s.failureRatio = 0.5
+		s.minRequests = 4
+		b, clock := newTestBreaker(s)
+
+		do(t, b, true)
+		do(t, b, true)
+		do(t, b, false)
+		clock.Advance(s.window)
+
+		// Only the failures of the new window count
+		do(t, b, true)
+		do(t, b, false)
+		do(t, b, false)
+		_, state := do(t, b, false)
+		assert.Equal(t, StateClosed, state)
+	})
+}
+
+func TestBreaker_Open(t *testing.T) {
+	b, clock := newTestBreaker(testSettings)
+	trip(t, b)
+
+	tk, retryAfter, ok := b.allow()
+	assert.False(t, ok)
+	assert.Equal(t, StateOpen, tk.state)
+	assert.Equal(t, testSettings.openTimeout, retryAfter)
+
+	clock.Advance(4 * time.Second)
+	_, retryAfter, ok = b.allow()
+	assert.False(t, ok)
+	assert.Equal(t, 6*time.Second, retryAfter)
+
+	// Results of requests admitted before the breaker opened are ignored
+	assert.Equal(t, StateOpen, b.record(ticket{generation: 0, state: StateClosed}, false))
+
+	clock.Advance(6 * time.Second)
+	tk, _, ok = b.allow()
+	assert.True(t, ok)
+	assert.Equal(t, StateHalfOpen, tk.state)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	// halfOpen returns a breaker that just half-opened
+	halfOpen := func(t *testing.T) (*breaker, *fakeClock) {
+		t.Helper()
+		b, clock := newTestBreaker(testSettings)
+		trip(t, b)
+		clock.Advance(testSettings.openTimeout)
+		return b, clock
+	}
+
+	t.Run("limits trial requests", func(t *testing.T) {
+		b, _ := halfOpen(t)
+		first, _, ok := b.allow()
+		require.True(t, ok)
+		_, _, ok = b.allow()
+		require.True(t, ok)
+
+		tk, retryAfter, ok := b.allow()
+		assert.False(t, ok)
+		assert.Equal(t, StateHalfOpen, tk.state)
+		assert.Equal(t, testSettings.openTimeout, retryAfter)
+
+		// A finished trial doesn't free a slot
+		assert.Equal(t, StateHalfOpen, b.record(first, false))
+		_, _, ok = b.allow()
+		assert.False(t, ok)
+	})
+
+	t.Run("closes when every trial succeeds", func(t *testing.T) {
+		b, _ := halfOpen(t)
+		first, _, ok := b.allow()
+		require.True(t, ok)
+		second, _, ok := b.allow()
+		require.True(t, ok)
+
+		assert.Equal(t, StateHalfOpen, b.record(first, false))
+		assert.Equal(t, StateClosed, b.record(second, false))
+
+		ok, state := do(t, b, false)
+		assert.True(t, ok)
+		assert.Equal(t, StateClosed, state)
+	})
+
+	t.Run("reopens when a trial fails", func(t *testing.T) {
+		b, clock := halfOpen(t)
+		first, _, ok := b.allow()
+		require.True(t, ok)
+		second, _, ok := b.allow()
+		require.True(t, ok)
+
+		assert.Equal(t, StateOpen, b.record(first, true))
+		// The other trial finished after the breaker reopened
+		assert.Equal(t, StateOpen, b.record(second, false))
+
+		_, retryAfter, ok := b.allow()
+		assert.False(t, ok)
+		assert.Equal(t, testSettings.openTimeout, retryAfter)
+
+		clock.Advance(testSettings.openTimeout)
+		tk, _, ok := b.allow()
+		assert.True(t, ok)
+		assert.Equal(t, StateHalfOpen, tk.state)
+	})
+
+	t.Run("closed breaker starts with clean counts", func(t *testing.T) {
+		b, _ := halfOpen(t)
+		do(t, b, false)
+		_, state := do(t, b, false)
+		require.Equal(t, StateClosed, state)
+
+		_, state = do(t, b, true)
+		assert.Equal(t, StateClosed, state)
+		_, state = do(t, b, true)
+		assert.Equal(t, StateClosed, state)
+		_, state = do(t, b, true)
+		assert.Equal(t, StateOpen, state)
+	})
+}
+
+func TestBreaker_Concurrent(t *testing.T) {
+	b, clock := newTestBreaker(testSettings)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	admitted := 0
+	for i := range 200 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := do(t, b, i%2 == 0); ok {
+				mu.Lock()
+				admitted++
+				mu.Unlock()
+			}
+			if i%50 == 0 {
+				clock.Advance(testSettings.openTimeout)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Positive(t, admitted)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen {
+		assert.LessOrEqual(t, b.trials, testSettings.halfOpenRequests)
+	}
+}
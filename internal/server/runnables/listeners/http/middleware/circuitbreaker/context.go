@@ -0,0 +1,29 @@
+package circuitbreaker
+
+import "context"
+
+// Status is the circuit breaker state seen by a request. State is the state
+// the request was admitted or rejected under, updated once the response is
+// counted, so a request whose failure opens the breaker reports it as open.
+type Status struct {
+	// ID is the ID of the circuit breaker middleware
+	ID string
+
+	// State is the state of the route's breaker
+	State State
+}
+
+// statusKey is the context key for the circuit breaker status
+type statusKey struct{}
+
+// withStatus returns a copy of ctx carrying the circuit breaker status
+func withStatus(ctx context.Context, s *Status) context.Context {
+	return context.WithValue(ctx, statusKey{}, s)
+}
+
+// StatusFromContext returns the circuit breaker status stored in ctx, or nil
+// if the request didn't pass through a circuit breaker
+func StatusFromContext(ctx context.Context) *Status {
+	s, _ := ctx.Value(statusKey{}).(*Status)
+	return s
+}
@@ -0,0 +1,130 @@
+// Package circuitbreaker provides circuit breaker middleware that stops
+// sending requests to a route whose responses keep failing.
+//
+// A response with a 5xx status, or a handler that panics, counts as a failure.
+// Each route the middleware is attached to gets its own breaker. A closed
+// breaker passes requests through and counts failures within a window; it
+// opens after too many consecutive failures or too high a failure ratio. An
+// open breaker answers every request with 503 Service Unavailable and a
+// Retry-After header until the open timeout passes, then half-opens and lets a
+// few trial requests through. The breaker closes when every trial succeeds and
+// opens again when any of them fails.
+//
+// The state seen by each request is stored in its context, where the logger
+// middleware reads it.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "10-circuit-breaker"
+//	type = "circuit_breaker"
+//
+//	[endpoints.middlewares.circuit_breaker]
+//	failure_threshold = 5
+//	failure_ratio = 0.5
+//	min_requests = 20
+//	window = "1m"
+//	open_timeout = "30s"
+//	half_open_requests = 2
+package circuitbreaker
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for circuit breaker middleware.
+var (
+	ErrNilConfig     = errors.New("circuit breaker config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid circuit breaker config")
+)
+
+// CircuitBreakerMiddleware is a middleware implementation that short-circuits
+// requests to routes that keep failing.
+type CircuitBreakerMiddleware struct {
+	id       string
+	settings settings
+	logger   *slog.Logger
+
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewCircuitBreakerMiddleware creates a new CircuitBreakerMiddleware instance.
+func NewCircuitBreakerMiddleware(
+	id string,
+	cfg *circuitbreaker.CircuitBreaker,
+) (*CircuitBreakerMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return &CircuitBreakerMiddleware{
+		id: id,
+		settings: settings{
+			failureThreshold: cfg.GetFailureThreshold(),
+			failureRatio:     cfg.FailureRatio,
+			minRequests:      cfg.GetMinRequests(),
+			window:           cfg.GetWindow(),
+			openTimeout:      cfg.GetOpenTimeout(),
+			halfOpenRequests: cfg.GetHalfOpenRequests(),
+		},
+		logger: slog.Default().WithGroup("circuit_breaker").With("id", id),
+		now:    time.Now,
+	}, nil
+}
+
+// Middleware returns the middleware function that guards a route. Every call
+// returns a function with a new breaker, so each route trips on its own.
+func (cm *CircuitBreakerMiddleware) Middleware() httpserver.HandlerFunc {
+	b := newBreaker(cm.settings, cm.logger, cm.now)
+
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+		status := &Status{ID: cm.id}
+		rp.SetRequest(r.WithContext(withStatus(r.Context(), status)))
+
+		ticket, retryAfter, ok := b.allow()
+		status.State = ticket.state
+		if !ok {
+			w := rp.Writer()
+			w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			rp.Abort()
+			return
+		}
+
+		// A panic in the rest of the chain counts as a failure before it
+		// continues up the stack
+		completed := false
+		defer func() {
+			if !completed {
+				b.record(ticket, true)
+			}
+		}()
+		rp.Next()
+		completed = true
+
+		status.State = b.record(ticket, rp.Writer().Status() >= http.StatusInternalServerError)
+	}
+}
+
+// retryAfterSeconds formats a Retry-After value in whole seconds, rounding up
+// so clients don't retry before the breaker half-opens
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int64((d + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return fmt.Sprintf("%d", seconds)
+}
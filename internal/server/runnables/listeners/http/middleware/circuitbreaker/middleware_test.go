@@ -0,0 +1,202 @@
+package circuitbreaker
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMiddleware returns a middleware that opens after 2 consecutive
+// failures for 10 seconds, on a fake clock
+func newTestMiddleware(t *testing.T) (*CircuitBreakerMiddleware, *fakeClock) {
+	t.Helper()
+	cm, err := NewCircuitBreakerMiddleware("breaker", &circuitbreaker.CircuitBreaker{
+		FailureThreshold: 2,
+		OpenTimeout:      10 * time.Second,
+	})
+	require.NoError(t, err)
+	clock := newFakeClock()
+	cm.now = clock.Now
+	cm.logger = slog.New(slog.DiscardHandler)
+	return cm, clock
+}
+
+// testRoute routes requests through the middleware to a handler responding
+// with the status it is set to, and records the status each request carried
+type testRoute struct {
+	handlerStatus int
+	lastStatus    *Status
+	route         *httpserver.Route
+}
+
+func newTestRoute(t *testing.T, cm *CircuitBreakerMiddleware) *testRoute {
+	t.Helper()
+	tr := &testRoute{handlerStatus: http.StatusOK}
+	// Observe the status after the breaker, as a logger earlier in the chain would
+	observe := func(rp *httpserver.RequestProcessor) {
+		rp.Next()
+		tr.lastStatus = StatusFromContext(rp.Request().Context())
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tr.handlerStatus)
+		}, observe, cm.Middleware())
+	require.NoError(t, err)
+	tr.route = route
+	return tr
+}
+
+func (tr *testRoute) serve() *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	tr.route.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+	return rec
+}
+
+func TestNewCircuitBreakerMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewCircuitBreakerMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewCircuitBreakerMiddleware("test", &circuitbreaker.CircuitBreaker{FailureRatio: 2})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, circuitbreaker.ErrInvalidFailureRatio)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		cm, err := NewCircuitBreakerMiddleware("test", &circuitbreaker.CircuitBreaker{})
+		require.NoError(t, err)
+		assert.Equal(t, settings{
+			failureThreshold: circuitbreaker.DefaultFailureThreshold,
+			minRequests:      circuitbreaker.DefaultMinRequests,
+			window:           circuitbreaker.DefaultWindow,
+			openTimeout:      circuitbreaker.DefaultOpenTimeout,
+			halfOpenRequests: circuitbreaker.DefaultHalfOpenRequests,
+		}, cm.settings)
+	})
+}
+
+func TestCircuitBreakerMiddleware_Transitions(t *testing.T) {
+	cm, clock := newTestMiddleware(t)
+	tr := newTestRoute(t, cm)
+
+	// Closed: failures pass through until the threshold
+	tr.handlerStatus = http.StatusBadGateway
+	rec := tr.serve()
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	require.NotNil(t, tr.lastStatus)
+	assert.Equal(t, "breaker", tr.lastStatus.ID)
+	assert.Equal(t, StateClosed, tr.lastStatus.State)
+
+	// The failure that trips the breaker reports it open
+	rec = tr.serve()
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Equal(t, StateOpen, tr.lastStatus.State)
+
+	// Open: requests are short-circuited
+	tr.handlerStatus = http.StatusOK
+	clock.Advance(2500 * time.Millisecond)
+	rec = tr.serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "8", rec.Header().Get("Retry-After"))
+	assert.Equal(t, StateOpen, tr.lastStatus.State)
+
+	// Half-open: the trial request succeeds and closes the breaker
+	clock.Advance(7500 * time.Millisecond)
+	rec = tr.serve()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, StateClosed, tr.lastStatus.State)
+
+	rec = tr.serve()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, StateClosed, tr.lastStatus.State)
+}
+
+func TestCircuitBreakerMiddleware_HalfOpenFailure(t *testing.T) {
+	cm, clock := newTestMiddleware(t)
+	tr := newTestRoute(t, cm)
+
+	tr.handlerStatus = http.StatusInternalServerError
+	tr.serve()
+	tr.serve()
+	clock.Advance(10 * time.Second)
+
+	rec := tr.serve()
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, StateOpen, tr.lastStatus.State)
+
+	rec = tr.serve()
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "10", rec.Header().Get("Retry-After"))
+}
+
+func TestCircuitBreakerMiddleware_ClientErrorsAreNotFailures(t *testing.T) {
+	cm, _ := newTestMiddleware(t)
+	tr := newTestRoute(t, cm)
+
+	tr.handlerStatus = http.StatusNotFound
+	for range 5 {
+		rec := tr.serve()
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Equal(t, StateClosed, tr.lastStatus.State)
+	}
+}
+
+func TestCircuitBreakerMiddleware_PanicIsFailure(t *testing.T) {
+	cm, _ := newTestMiddleware(t)
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}, cm.Middleware())
+	require.NoError(t, err)
+
+	for range 2 {
+		assert.Panics(t, func() {
+			route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestCircuitBreakerMiddleware_RoutesTripIndependently(t *testing.T) {
+	cm, _ := newTestMiddleware(t)
+	failing := newTestRoute(t, cm)
+	healthy := newTestRoute(t, cm)
+
+	failing.handlerStatus = http.StatusInternalServerError
+	failing.serve()
+	failing.serve()
+	assert.Equal(t, http.StatusServiceUnavailable, failing.serve().Code)
+
+	assert.Equal(t, http.StatusOK, healthy.serve().Code)
+	assert.Equal(t, StateClosed, healthy.lastStatus.State)
+}
+
+func TestStatusFromContext(t *testing.T) {
+	assert.Nil(t, StatusFromContext(context.Background()))
+
+	status := &Status{ID: "breaker", State: StateHalfOpen}
+	assert.Same(t, status, StatusFromContext(withStatus(context.Background(), status)))
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, "1", retryAfterSeconds(0))
+	assert.Equal(t, "1", retryAfterSeconds(time.Millisecond))
+	assert.Equal(t, "1", retryAfterSeconds(time.Second))
+	assert.Equal(t, "2", retryAfterSeconds(1001*time.Millisecond))
+	assert.Equal(t, "30", retryAfterSeconds(30*time.Second))
+}
@@ -2,6 +2,10 @@
 //
 // This middleware allows setting, adding, and removing HTTP headers on both incoming
 // requests and outgoing responses with operation ordering: remove, set, add.
+// Headers can also be removed by glob pattern, and response headers can be
+// overridden per status class; both are applied when the response headers are
+// written, so they cover headers set by the handler.
+// A security preset sets common security headers on every response.
 // It validates all headers using RFC-compliant rules and integrates with the go-supervisor
 // middleware chain.
 //
@@ -96,38 +100,78 @@ func NewHeadersMiddleware(id string, cfg *headers.Headers) (*HeadersMiddleware,
 	}
 
 	// Add response header operations
-	if cfg.Response != nil {
-		if len(cfg.Response.RemoveHeaders) > 0 {
-			operations = append(
-				operations,
-				supervisorHeaders.WithRemove(cfg.Response.RemoveHeaders...),
-			)
-		}
+	if cfg.Response != nil && len(cfg.Response.RemoveHeaders) > 0 {
+		operations = append(
+			operations,
+			supervisorHeaders.WithRemove(cfg.Response.RemoveHeaders...),
+		)
+	}
 
-		if len(cfg.Response.SetHeaders) > 0 {
-			operations = append(
-				operations,
-				supervisorHeaders.WithSet(convertToHTTPHeader(cfg.Response.SetHeaders)),
-			)
-		}
+	if setHeaders := responseSetHeaders(cfg); len(setHeaders) > 0 {
+		operations = append(operations, supervisorHeaders.WithSet(setHeaders))
+	}
 
-		if len(cfg.Response.AddHeaders) > 0 {
-			operations = append(
-				operations,
-				supervisorHeaders.WithAdd(convertToHTTPHeader(cfg.Response.AddHeaders)),
-			)
-		}
+	if cfg.Response != nil && len(cfg.Response.AddHeaders) > 0 {
+		operations = append(
+			operations,
+			supervisorHeaders.WithAdd(convertToHTTPHeader(cfg.Response.AddHeaders)),
+		)
 	}
 
 	// Create the middleware using go-supervisor's NewWithOperations
 	middleware := supervisorHeaders.NewWithOperations(operations...)
 
+	// Pattern removal and status overrides need more than the fixed operations
+	// above: request patterns are matched against the incoming headers, and
+	// response patterns and overrides are applied once the status is known
+	response := responseOperations{ops: cfg.Response}
+	requestPatterns := cfg.Request != nil && len(cfg.Request.RemoveHeaderPatterns) > 0
+	if requestPatterns || response.needed() {
+		middleware = withPatternsAndOverrides(middleware, cfg.Request, response)
+	}
+
 	return &HeadersMiddleware{
 		id:         id,
 		middleware: middleware,
 	}, nil
 }
 
+// responseSetHeaders returns the headers to set on responses: those of the
+// security preset, replaced by the response set headers of the same name
+func responseSetHeaders(cfg *headers.Headers) http.Header {
+	h := convertToHTTPHeader(cfg.Security.Headers())
+	if cfg.Response != nil {
+		for key, value := range cfg.Response.SetHeaders {
+			h.Set(key, value)
+		}
+	}
+	return h
+}
+
+// withPatternsAndOverrides wraps next to remove the request headers matching
+// the request remove patterns before it runs, and to apply the response
+// operations when the response headers are written
+func withPatternsAndOverrides(
+	next httpserver.HandlerFunc,
+	request *headers.HeaderOperations,
+	response responseOperations,
+) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		if request != nil {
+			removeMatching(rp.Request().Header, request)
+		}
+		if !response.needed() {
+			next(rp)
+			return
+		}
+
+		hw := newHeaderWriter(rp.Writer(), response)
+		rp.SetWriter(hw)
+		next(rp)
+		hw.finish()
+	}
+}
+
 // Middleware returns the middleware function that manipulates request and response headers.
 func (hm *HeadersMiddleware) Middleware() httpserver.HandlerFunc {
 	return hm.middleware
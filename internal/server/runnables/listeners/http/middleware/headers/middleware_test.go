@@ -541,3 +541,200 @@ func TestHeadersMiddleware_BothRequestAndResponse(t *testing.T) {
 		assert.Equal(t, "response", rec.Body.String())
 	})
 }
+
+func TestHeadersMiddleware_RemovePatterns(t *testing.T) {
+	t.Parallel()
+
+	cfg := &headers.Headers{
+		Request: &headers.HeaderOperations{
+			RemoveHeaderPatterns: []string{"x-debug-*"},
+			SetHeaders:           map[string]string{"X-Debug": "kept"},
+		},
+		Response: &headers.HeaderOperations{
+			RemoveHeaderPatterns: []string{"X-Internal-*", "X-Backend-?"},
+		},
+	}
+
+	middleware, err := NewHeadersMiddleware("test", cfg)
+	require.NoError(t, err)
+
+	t.Run("removes matching headers set by the handler", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		req.Header.Set("X-Debug-Trace", "1")
+		req.Header.Set("X-Debug-User", "admin")
+		req.Header.Set("X-Other", "kept")
+		rec := httptest.NewRecorder()
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {
+				assert.Empty(t, r.Header.Get("X-Debug-Trace"))
+				assert.Empty(t, r.Header.Get("X-Debug-User"))
+				assert.Equal(t, "kept", r.Header.Get("X-Other"))
+				assert.Equal(t, "kept", r.Header.Get("X-Debug"))
+
+				w.Header().Set("X-Internal-Node", "node-1")
+				w.Header().Set("x-internal-trace", "abc")
+				w.Header().Set("X-Backend-1", "10.0.0.1")
+				w.Header().Set("X-Backend-10", "10.0.0.10")
+				w.Header().Set("X-Public", "visible")
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte("response"))
+				assert.NoError(t, err)
+			}, middleware.Middleware())
+		require.NoError(t, err)
+
+		route.ServeHTTP(rec, req)
+
+		result := rec.Result()
+		assert.Empty(t, result.Header.Get("X-Internal-Node"))
+		assert.Empty(t, result.Header.Get("X-Internal-Trace"))
+		assert.Empty(t, result.Header.Get("X-Backend-1"))
+		assert.Equal(t, "10.0.0.10", result.Header.Get("X-Backend-10"), "? matches a single character")
+		assert.Equal(t, "visible", result.Header.Get("X-Public"))
+	})
+
+	t.Run("applies when the handler writes nothing", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		rec := httptest.NewRecorder()
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Internal-Node", "node-1")
+			}, middleware.Middleware())
+		require.NoError(t, err)
+
+		route.ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get("X-Internal-Node"))
+	})
+}
+
+func TestHeadersMiddleware_StatusOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg := &headers.Headers{
+		Response: &headers.HeaderOperations{
+			SetHeaders: map[string]string{"Cache-Control": "max-age=3600"},
+			StatusOverrides: []headers.StatusOverride{
+				{
+					StatusClass: headers.StatusClass5xx,
+					SetHeaders:  map[string]string{"Cache-Control": "no-store"},
+				},
+				{
+					StatusClass:   headers.StatusClass4xx,
+					RemoveHeaders: []string{"Cache-Control"},
+					SetHeaders:    map[string]string{"X-Error": "client"},
+				},
+			},
+		},
+	}
+
+	middleware, err := NewHeadersMiddleware("test", cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		status       int
+		cacheControl string
+		xError       string
+	}{
+		{"success keeps the base headers", http.StatusOK, "max-age=3600", ""},
+		{"server error overrides", http.StatusBadGateway, "no-store", ""},
+		{"client error removes and sets", http.StatusNotFound, "", "client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			rec := httptest.NewRecorder()
+
+			route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+				func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tt.status)
+				}, middleware.Middleware())
+			require.NoError(t, err)
+
+			route.ServeHTTP(rec, req)
+
+			result := rec.Result()
+			assert.Equal(t, tt.status, result.StatusCode)
+			assert.Equal(t, tt.cacheControl, result.Header.Get("Cache-Control"))
+			assert.Equal(t, tt.xError, result.Header.Get("X-Error"))
+		})
+	}
+
+	t.Run("implicit status from write", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/test", nil)
+		rec := httptest.NewRecorder()
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte("ok"))
+				assert.NoError(t, err)
+			}, middleware.Middleware())
+		require.NoError(t, err)
+
+		route.ServeHTTP(rec, req)
+		assert.Equal(t, "max-age=3600", rec.Result().Header.Get("Cache-Control"))
+	})
+}
+
+func TestHeadersMiddleware_SecurityPreset(t *testing.T) {
+	t.Parallel()
+
+	serve := func(t *testing.T, cfg *headers.Headers) *httptest.ResponseRecorder {
+		t.Helper()
+		middleware, err := NewHeadersMiddleware("test", cfg)
+		require.NoError(t, err)
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}, middleware.Middleware())
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		route.ServeHTTP(rec, httptest.NewRequest("GET", "/test", nil))
+		return rec
+	}
+
+	t.Run("default preset", func(t *testing.T) {
+		rec := serve(t, &headers.Headers{Security: headers.NewSecurityPreset()})
+
+		assert.Equal(t, "max-age=31536000; includeSubDomains", rec.Header().Get("Strict-Transport-Security"))
+		assert.Equal(t, "default-src 'self'", rec.Header().Get("Content-Security-Policy"))
+		assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "strict-origin-when-cross-origin", rec.Header().Get("Referrer-Policy"))
+	})
+
+	t.Run("preset values and response set headers override the defaults", func(t *testing.T) {
+		security := headers.NewSecurityPreset()
+		security.HSTSMaxAgeSeconds = 600
+		security.HSTSIncludeSubdomains = false
+		security.HSTSPreload = true
+		security.FrameOptions = ""
+		rec := serve(t, &headers.Headers{
+			Response: &headers.HeaderOperations{
+				SetHeaders: map[string]string{"content-security-policy": "default-src 'none'"},
+			},
+			Security: security,
+		})
+
+		assert.Equal(t, "max-age=600; preload", rec.Header().Get("Strict-Transport-Security"))
+		assert.Equal(t, []string{"default-src 'none'"}, rec.Header().Values("Content-Security-Policy"))
+		assert.Empty(t, rec.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+	})
+
+	t.Run("disabled preset sets nothing", func(t *testing.T) {
+		security := headers.NewSecurityPreset()
+		security.Enabled = false
+		rec := serve(t, &headers.Headers{
+			Response: &headers.HeaderOperations{SetHeaders: map[string]string{"X-Test": "1"}},
+			Security: security,
+		})
+
+		assert.Equal(t, "1", rec.Header().Get("X-Test"))
+		assert.Empty(t, rec.Header().Get("Strict-Transport-Security"))
+	})
+}
@@ -0,0 +1,108 @@
+package headers
+
+import (
+	"net/http"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/headers"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// responseOperations are the response header operations that can only be
+// applied once the handler has chosen the status code and set its own headers
+type responseOperations struct {
+	ops *headers.HeaderOperations
+}
+
+// needed reports whether any operation has to wait for the response status
+func (ro responseOperations) needed() bool {
+	return ro.ops != nil && (len(ro.ops.RemoveHeaderPatterns) > 0 || len(ro.ops.StatusOverrides) > 0)
+}
+
+// apply removes the headers matching the remove patterns, then applies the
+// status overrides matching the status code in order
+func (ro responseOperations) apply(h http.Header, status int) {
+	removeMatching(h, ro.ops)
+
+	for _, override := range ro.ops.StatusOverrides {
+		if !override.StatusClass.Matches(status) {
+			continue
+		}
+		for _, key := range override.RemoveHeaders {
+			h.Del(key)
+		}
+		for key, value := range override.SetHeaders {
+			h.Set(key, value)
+		}
+	}
+}
+
+// removeMatching deletes the headers whose names match one of the remove
+// patterns of ops
+func removeMatching(h http.Header, ops *headers.HeaderOperations) {
+	if len(ops.RemoveHeaderPatterns) == 0 {
+		return
+	}
+	for key := range h {
+		if ops.MatchesRemovePattern(key) {
+			delete(h, key)
+		}
+	}
+}
+
+// headerWriter applies the response operations to the headers right before
+// they are written, so they also cover headers set by the handler
+type headerWriter struct {
+	httpserver.ResponseWriter
+	ops         responseOperations
+	wroteHeader bool
+}
+
+func newHeaderWriter(w httpserver.ResponseWriter, ops responseOperations) *headerWriter {
+	return &headerWriter{ResponseWriter: w, ops: ops}
+}
+
+// WriteHeader applies the response operations for the status code before
+// sending the headers. Informational responses leave the final response's
+// headers for the final status.
+func (hw *headerWriter) WriteHeader(status int) {
+	if !hw.wroteHeader {
+		hw.ops.apply(hw.Header(), status)
+		if status >= http.StatusOK || status == http.StatusSwitchingProtocols {
+			hw.wroteHeader = true
+		}
+	}
+	hw.ResponseWriter.WriteHeader(status)
+}
+
+// Write sends the headers with an implicit 200 status if they haven't been
+// written yet
+func (hw *headerWriter) Write(b []byte) (int, error) {
+	if !hw.wroteHeader {
+		hw.WriteHeader(http.StatusOK)
+	}
+	return hw.ResponseWriter.Write(b)
+}
+
+// Flush sends the headers before flushing, so streamed responses get them too
+func (hw *headerWriter) Flush() {
+	if !hw.wroteHeader {
+		hw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := hw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish applies the response operations when the handler returned without
+// writing anything, before the server sends the headers with an implicit 200
+func (hw *headerWriter) finish() {
+	if !hw.wroteHeader {
+		hw.ops.apply(hw.Header(), http.StatusOK)
+		hw.wroteHeader = true
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (hw *headerWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
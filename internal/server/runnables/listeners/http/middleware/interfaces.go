@@ -6,3 +6,13 @@ import "github.com/robbyt/go-supervisor/runnables/httpserver"
 type Instance interface {
 	Middleware() httpserver.HandlerFunc
 }
+
+// ChainWrapper is implemented by middleware that runs the rest of the chain
+// itself, possibly more than once, such as retries. Routes use the handler
+// returned by WrapChain in place of Middleware. It is given the handlers that
+// follow it, ending with the app handler, and aborts the route's chain so they
+// don't run again.
+type ChainWrapper interface {
+	Instance
+	WrapChain(next []httpserver.HandlerFunc) httpserver.HandlerFunc
+}
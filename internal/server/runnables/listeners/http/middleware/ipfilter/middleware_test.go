@@ -0,0 +1,189 @@
+package ipfilter
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/ipfilter"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMiddleware(t *testing.T, cfg *ipfilter.IPFilter) *IPFilterMiddleware {
+	t.Helper()
+	fm, err := NewIPFilterMiddleware("ip-filter", cfg)
+	require.NoError(t, err)
+	fm.logger = slog.New(slog.DiscardHandler)
+	return fm
+}
+
+// serve sends a request from remoteAddr with the headers through the
+// middleware, and reports whether the app ran
+func serve(
+	t *testing.T,
+	fm *IPFilterMiddleware,
+	remoteAddr string,
+	headers map[string]string,
+	proxies []netip.Prefix,
+) (*httptest.ResponseRecorder, bool) {
+	t.Helper()
+	called := false
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}, fm.Middleware())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.RemoteAddr = remoteAddr
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if proxies != nil {
+		req = req.WithContext(apps.WithTrustedProxies(req.Context(), proxies))
+	}
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec, called
+}
+
+func TestNewIPFilterMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewIPFilterMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewIPFilterMiddleware("test", &ipfilter.IPFilter{Allow: []string{"10.0.0.0/99"}})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, ipfilter.ErrInvalidRule)
+	})
+}
+
+func TestIPFilterMiddleware(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cfg        *ipfilter.IPFilter
+		remoteAddr string
+		headers    map[string]string
+		proxies    []netip.Prefix
+		allowed    bool
+	}{
+		{
+			name:       "in allow list",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}},
+			remoteAddr: "10.1.2.3:5000",
+			allowed:    true,
+		},
+		{
+			name:       "outside allow list",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}},
+			remoteAddr: "203.0.113.7:5000",
+		},
+		{
+			name:       "single address rule",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"127.0.0.1"}},
+			remoteAddr: "127.0.0.1:5000",
+			allowed:    true,
+		},
+		{
+			name:       "deny wins over allow",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.66.0.0/16"}},
+			remoteAddr: "10.66.1.1:5000",
+		},
+		{
+			name:       "deny only allows others",
+			cfg:        &ipfilter.IPFilter{Deny: []string{"203.0.113.0/24"}},
+			remoteAddr: "198.51.100.1:5000",
+			allowed:    true,
+		},
+		{
+			name:       "deny only blocks listed",
+			cfg:        &ipfilter.IPFilter{Deny: []string{"203.0.113.0/24"}},
+			remoteAddr: "203.0.113.9:5000",
+		},
+		{
+			name:       "IPv6 client",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"2001:db8::/32"}},
+			remoteAddr: "[2001:db8::42]:5000",
+			allowed:    true,
+		},
+		{
+			name:       "IPv4-mapped client matches IPv4 rule",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}},
+			remoteAddr: "[::ffff:10.0.0.5]:5000",
+			allowed:    true,
+		},
+		{
+			name:       "forwarded header ignored by default",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}},
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.1"},
+		},
+		{
+			name:       "forwarded header from a trusted proxy",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}, TrustForwardedFor: true},
+			remoteAddr: "192.168.0.1:5000",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.1, 192.168.0.2"},
+			proxies:    []netip.Prefix{netip.MustParsePrefix("192.168.0.0/24")},
+			allowed:    true,
+		},
+		{
+			name:       "spoofed forwarded header from an untrusted peer",
+			cfg:        &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}, TrustForwardedFor: true},
+			remoteAddr: "203.0.113.7:5000",
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.1"},
+			proxies:    []netip.Prefix{netip.MustParsePrefix("192.168.0.0/24")},
+		},
+		{
+			name:       "unparseable forwarded client",
+			cfg:        &ipfilter.IPFilter{Deny: []string{"203.0.113.0/24"}, TrustForwardedFor: true},
+			remoteAddr: "192.168.0.1:5000",
+			headers:    map[string]string{"X-Forwarded-For": "unknown"},
+			proxies:    []netip.Prefix{netip.MustParsePrefix("192.168.0.0/24")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := newTestMiddleware(t, tt.cfg)
+
+			rec, called := serve(t, fm, tt.remoteAddr, tt.headers, tt.proxies)
+			assert.Equal(t, tt.allowed, called)
+			if tt.allowed {
+				assert.Equal(t, http.StatusOK, rec.Code)
+			} else {
+				assert.Equal(t, http.StatusForbidden, rec.Code)
+			}
+		})
+	}
+}
+
+func TestIPFilterMiddleware_LogsDecisions(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	fm := newTestMiddleware(t, &ipfilter.IPFilter{Allow: []string{"10.0.0.0/8"}})
+	fm.logger = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	serve(t, fm, "10.0.0.1:5000", nil, nil)
+	assert.Contains(t, buf.String(), "Allowed request")
+	assert.Contains(t, buf.String(), "allowed by 10.0.0.0/8")
+
+	buf.Reset()
+	serve(t, fm, "203.0.113.7:5000", nil, nil)
+	assert.Contains(t, buf.String(), "level=INFO")
+	assert.Contains(t, buf.String(), "Blocked request")
+	assert.Contains(t, buf.String(), "client_ip=203.0.113.7")
+	assert.Contains(t, buf.String(), `reason="not in allow list"`)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 )
 
@@ -23,9 +24,19 @@ const (
 	attrHeaders  = "headers"
 	attrBody     = "body"
 	attrBodySize = "body_size"
-
-	groupRequest  = "request"
-	groupResponse = "response"
+	attrSubject  = "subject"
+	attrID       = "id"
+	attrState    = "state"
+	attrAttempts = "attempts"
+	attrResult   = "result"
+	attrSlow     = "slow"
+
+	groupRequest        = "request"
+	groupResponse       = "response"
+	groupAuth           = "auth"
+	groupCircuitBreaker = "circuit_breaker"
+	groupRetry          = "retry"
+	groupCache          = "cache"
 
 	schemeHTTP  = "http"
 	schemeHTTPS = "https"
@@ -37,6 +48,10 @@ type logFilter struct {
 	methodExclude map[string]bool
 	headerInclude map[string]bool
 	headerExclude map[string]bool
+	headerRedact  map[string]bool
+
+	// bodyRedact holds the JSON field paths masked in logged bodies
+	bodyRedact [][]string
 
 	pathInclude []string
 	pathExclude []string
@@ -66,12 +81,18 @@ func newLogFilter(cfg *logger.ConsoleLogger) *logFilter {
 	for _, header := range cfg.Fields.Request.ExcludeHeaders {
 		headerExclude[strings.ToLower(header)] = true
 	}
+	headerRedact := make(map[string]bool)
+	for _, header := range cfg.Redaction.Headers {
+		headerRedact[strings.ToLower(header)] = true
+	}
 
 	return &logFilter{
 		methodInclude:          methodInclude,
 		methodExclude:          methodExclude,
 		headerInclude:          headerInclude,
 		headerExclude:          headerExclude,
+		headerRedact:           headerRedact,
+		bodyRedact:             cfg.Redaction.BodyFieldPaths(),
 		pathInclude:            cfg.IncludeOnlyPaths,
 		pathExclude:            cfg.ExcludePaths,
 		fields:                 cfg.Fields,
@@ -239,17 +260,10 @@ func (lf *logFilter) buildResponseAttrs(rw httpserver.ResponseWriter, responseBo
 	return respAttrs
 }
 
-// filterHeaders filters headers using pre-computed maps for O(1) lookup
+// filterHeaders filters headers using pre-computed maps for O(1) lookup, and
+// masks the values of redacted headers. The request's headers are not modified.
 func (lf *logFilter) filterHeaders(headers http.Header) map[string][]string {
-	if len(lf.headerInclude) == 0 && len(lf.headerExclude) == 0 {
-		result := make(map[string][]string, len(headers))
-		for k, v := range headers {
-			result[k] = v
-		}
-		return result
-	}
-
-	result := make(map[string][]string)
+	result := make(map[string][]string, len(headers))
 	for key, values := range headers {
 		keyLower := strings.ToLower(key)
 
@@ -259,12 +273,22 @@ func (lf *logFilter) filterHeaders(headers http.Header) map[string][]string {
 		if lf.headerExclude[keyLower] {
 			continue
 		}
+		if lf.headerRedact[keyLower] {
+			values = []string{redactedValue}
+		}
 
 		result[key] = values
 	}
 	return result
 }
 
+// RedactBody returns body with the configured JSON fields masked. The full
+// body should be passed, before it is truncated for logging, since a truncated
+// JSON document can't be parsed.
+func (lf *logFilter) RedactBody(body []byte) []byte {
+	return redactJSON(body, lf.bodyRedact)
+}
+
 // RequestBodyLogEnabled returns true if request body logging is enabled
 func (lf *logFilter) RequestBodyLogEnabled() bool {
 	return lf.logReqBody
@@ -285,21 +309,8 @@ func (lf *logFilter) MaxResponseBodyLogSize() int {
 	return lf.maxResponseBodyLogSize
 }
 
-// getClientIP extracts client IP from request headers
+// getClientIP returns the client IP, honoring forwarding headers only from
+// the listener's trusted proxies
 func getClientIP(r *http.Request) string {
-	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
-		if idx := strings.Index(xff, ","); idx != -1 {
-			return strings.TrimSpace(xff[:idx])
-		}
-		return xff
-	}
-
-	if xri := r.Header.Get("X-Real-IP"); xri != "" {
-		return xri
-	}
-
-	if idx := strings.LastIndex(r.RemoteAddr, ":"); idx != -1 {
-		return r.RemoteAddr[:idx]
-	}
-	return r.RemoteAddr
+	return apps.ClientIP(r, true)
 }
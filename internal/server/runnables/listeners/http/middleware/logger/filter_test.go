@@ -5,10 +5,12 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -279,6 +281,9 @@ func TestLogFilter_BuildLogAttrs(t *testing.T) {
 
 		req := httptest.NewRequest("GET", "/test?param=value", nil)
 		req.Header.Set("X-Forwarded-For", "192.168.1.1")
+		// httptest requests come from 192.0.2.1, trusted here as a proxy
+		req = req.WithContext(apps.WithTrustedProxies(req.Context(),
+			[]netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")}))
 		req.Host = "example.com"
 		req.Proto = "HTTP/1.1"
 		req.TLS = &tls.ConnectionState{} // Makes it HTTPS
@@ -1,20 +1,33 @@
 package logger
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"strings"
 	"testing"
+	"time"
 
+	configCache "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/cache"
+	configCircuitBreaker "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/circuitbreaker"
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
+	configRetry "github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	"github.com/atlanticdynamic/firelynx/internal/logging/otlp"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/cache"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/circuitbreaker"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/retry"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestResponseBuffer(t *testing.T) {
@@ -62,6 +75,41 @@ func TestResponseBuffer(t *testing.T) {
 		assert.Equal(t, 201, rb.Status())
 	})
 
+	t.Run("Flush without a client is a no-op", func(t *testing.T) {
+		rb := NewResponseBuffer()
+		_, err := rb.Write([]byte("response"))
+		require.NoError(t, err)
+
+		rb.Flush()
+		assert.False(t, rb.Streaming())
+		assert.Equal(t, "response", rb.buffer.String())
+	})
+
+	t.Run("Flush streams to the client", func(t *testing.T) {
+		client := httptest.NewRecorder()
+		rb := newStreamingResponseBuffer(client, 4)
+		rb.Header().Set("X-Custom", "value")
+		_, err := rb.Write([]byte("first"))
+		require.NoError(t, err)
+
+		rb.Flush()
+		assert.True(t, rb.Streaming())
+		assert.True(t, client.Flushed)
+		assert.Equal(t, http.StatusOK, client.Code)
+		assert.Equal(t, "value", client.Header().Get("X-Custom"))
+		assert.Equal(t, "first", client.Body.String())
+
+		_, err = rb.Write([]byte(" second"))
+		require.NoError(t, err)
+		assert.Equal(t, "first second", client.Body.String())
+		assert.Equal(t, "firs", rb.buffer.String())
+		assert.Equal(t, 12, rb.Size())
+
+		// Status can't change once streamed
+		rb.WriteHeader(http.StatusInternalServerError)
+		assert.Equal(t, http.StatusOK, rb.Status())
+	})
+
 	t.Run("Status defaults to 200 when written but no status set", func(t *testing.T) {
 		rb := NewResponseBuffer()
 		_, err := rb.Write([]byte("response"))
@@ -99,6 +147,109 @@ func TestNewConsoleLogger(t *testing.T) {
 		assert.NotNil(t, consoleLogger.filter)
 		assert.NotNil(t, consoleLogger.logger)
 	})
+
+	t.Run("OTLP output requires an endpoint", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		cfg.Output = "otlp"
+
+		_, err := NewConsoleLogger("test-logger-otlp", cfg)
+		require.ErrorIs(t, err, otlp.ErrInvalidConfig)
+
+		cfg.OTLP.Endpoint = "localhost:4317"
+		consoleLogger, err := NewConsoleLogger("test-logger-otlp", cfg)
+		require.NoError(t, err)
+		assert.NotNil(t, consoleLogger.logger)
+	})
+}
+
+func TestConsoleLogger_OTLPExport(t *testing.T) {
+	requests := make(chan *collogspb.ExportLogsServiceRequest, 1)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		req := &collogspb.ExportLogsServiceRequest{}
+		if !assert.NoError(t, proto.Unmarshal(body, req)) {
+			return
+		}
+		requests <- req
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(collector.Close)
+
+	cfg := logger.NewConsoleLogger()
+	cfg.Output = "otlp"
+	cfg.OTLP = logger.OTLPExport{
+		Endpoint:       collector.URL + "/v1/logs",
+		Protocol:       logger.OTLPProtocolHTTP,
+		ExportInterval: time.Hour,
+	}
+	cl, err := NewConsoleLogger("otlp-logger", cfg)
+	require.NoError(t, err)
+
+	cl.Log(t.Context(), []slog.Attr{
+		slog.String("method", "GET"),
+		slog.String("path", "/api"),
+		slog.Int("status", 503),
+	})
+
+	// The record is batched until the export pipeline is flushed
+	require.NoError(t, otlp.Shutdown(t.Context()))
+
+	select {
+	case req := <-requests:
+		records := req.GetResourceLogs()[0].GetScopeLogs()[0].GetLogRecords()
+		require.Len(t, records, 1)
+		record := records[0]
+		assert.Equal(t, "otlp-logger", record.GetBody().GetStringValue())
+		assert.Equal(t, "WARN", record.GetSeverityText())
+
+		// Attributes are nested under the logger's ID, as with the other outputs
+		require.Len(t, record.GetAttributes(), 1)
+		group := record.GetAttributes()[0]
+		assert.Equal(t, "otlp-logger", group.GetKey())
+		fields := make(map[string]*commonpb.AnyValue)
+		for _, kv := range group.GetValue().GetKvlistValue().GetValues() {
+			fields[kv.GetKey()] = kv.GetValue()
+		}
+		assert.Equal(t, "GET", fields["method"].GetStringValue())
+		assert.Equal(t, "/api", fields["path"].GetStringValue())
+		assert.Equal(t, int64(503), fields["status"].GetIntValue())
+	case <-time.After(5 * time.Second):
+		t.Fatal("collector received no export request")
+	}
+}
+
+func TestConsoleLogger_SyslogOutput(t *testing.T) {
+	collector, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, collector.Close()) })
+
+	cfg := logger.NewConsoleLogger()
+	cfg.Output = "syslog"
+	cfg.Syslog = logger.SyslogOutput{Address: collector.LocalAddr().String(), Facility: "local1"}
+	cl, err := NewConsoleLogger("syslog-logger", cfg)
+	require.NoError(t, err)
+
+	cl.Log(t.Context(), []slog.Attr{
+		slog.String("method", "GET"),
+		slog.Int("status", 503),
+	})
+
+	buf := make([]byte, 2048)
+	require.NoError(t, collector.SetReadDeadline(time.Now().Add(5*time.Second)))
+	n, _, err := collector.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+
+	// local1.warning, with the middleware ID as the default tag
+	assert.True(t, strings.HasPrefix(msg, fmt.Sprintf("<%d>1 ", 17*8+4)), msg)
+	assert.Contains(t, msg, " syslog-logger ")
+
+	// The message body is the record in the configured JSON format
+	body := msg[strings.Index(msg, " - - ")+len(" - - "):]
+	assert.Contains(t, body, `"syslog-logger":{"method":"GET","status":503}`)
 }
 
 func TestConsoleLogger_Middleware(t *testing.T) {
@@ -170,6 +321,43 @@ func TestConsoleLogger_Middleware(t *testing.T) {
 		assert.NotEmpty(t, mockLogger.loggedAttrs)
 	})
 
+	t.Run("Middleware flags slow requests", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		newRoute := func(cl *ConsoleLogger) *httpserver.Route {
+			route, err := httpserver.NewRouteFromHandlerFunc(
+				"test",
+				"/api/test",
+				func(w http.ResponseWriter, r *http.Request) {
+					time.Sleep(5 * time.Millisecond)
+					w.WriteHeader(http.StatusOK)
+				},
+				cl.Middleware(),
+			)
+			require.NoError(t, err)
+			return route
+		}
+
+		slowLogger := &MockLogger{}
+		newRoute(&ConsoleLogger{
+			id:            "test-middleware",
+			filter:        newLogFilter(cfg),
+			logger:        slowLogger,
+			slowThreshold: time.Millisecond,
+		}).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+		assert.Equal(t, slog.LevelWarn, slowLogger.loggedLevel)
+		assert.Contains(t, slowLogger.loggedAttrs, slog.Bool("slow", true))
+
+		fastLogger := &MockLogger{}
+		newRoute(&ConsoleLogger{
+			id:            "test-middleware",
+			filter:        newLogFilter(cfg),
+			logger:        fastLogger,
+			slowThreshold: time.Minute,
+		}).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+		assert.Equal(t, slog.LevelInfo, fastLogger.loggedLevel)
+		assert.NotContains(t, fastLogger.loggedAttrs, slog.Bool("slow", true))
+	})
+
 	t.Run("Middleware skips filtered requests", func(t *testing.T) {
 		cfg := logger.NewConsoleLogger()
 		cfg.ExcludeMethods = []string{"OPTIONS"}
@@ -408,6 +596,208 @@ func TestConsoleLogger_Middleware(t *testing.T) {
 		// Logger should have been called
 		assert.Equal(t, "test-middleware", mockLogger.loggedMessage)
 	})
+
+	t.Run("Middleware logs principal set later in the chain", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		cfg.Fields.Method = true
+
+		mockLogger := &MockLogger{}
+		cl := &ConsoleLogger{
+			id:     "test-middleware",
+			filter: newLogFilter(cfg),
+			logger: mockLogger,
+		}
+
+		authenticate := func(rp *httpserver.RequestProcessor) {
+			r := rp.Request()
+			principal := &apps.Principal{Subject: "alice", Method: "bearer"}
+			rp.SetRequest(r.WithContext(apps.WithPrincipal(r.Context(), principal)))
+			rp.Next()
+		}
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			"test",
+			"/api/test",
+			handler,
+			cl.Middleware(),
+			authenticate,
+		)
+		require.NoError(t, err)
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+
+		var authAttr *slog.Attr
+		for i := range mockLogger.loggedAttrs {
+			if mockLogger.loggedAttrs[i].Key == groupAuth {
+				authAttr = &mockLogger.loggedAttrs[i]
+			}
+		}
+		require.NotNil(t, authAttr, "auth group should be logged")
+		group := authAttr.Value.Group()
+		require.Len(t, group, 2)
+		assert.Equal(t, "alice", group[0].Value.String())
+		assert.Equal(t, "bearer", group[1].Value.String())
+	})
+
+	t.Run("Middleware logs circuit breaker state", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		mockLogger := &MockLogger{}
+		cl := &ConsoleLogger{
+			id:     "test-middleware",
+			filter: newLogFilter(cfg),
+			logger: mockLogger,
+		}
+		breaker, err := circuitbreaker.NewCircuitBreakerMiddleware(
+			"breaker",
+			&configCircuitBreaker.CircuitBreaker{FailureThreshold: 1},
+		)
+		require.NoError(t, err)
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			"test",
+			"/api/test",
+			handler,
+			cl.Middleware(),
+			breaker.Middleware(),
+		)
+		require.NoError(t, err)
+
+		breakerState := func() string {
+			t.Helper()
+			for _, attr := range mockLogger.loggedAttrs {
+				if attr.Key == groupCircuitBreaker {
+					group := attr.Value.Group()
+					require.Len(t, group, 2)
+					assert.Equal(t, "breaker", group[0].Value.String())
+					return group[1].Value.String()
+				}
+			}
+			require.Fail(t, "circuit_breaker group should be logged")
+			return ""
+		}
+
+		// The failure trips the breaker, which then rejects the next request
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+		assert.Equal(t, "open", breakerState())
+
+		rec := httptest.NewRecorder()
+		route.ServeHTTP(rec, httptest.NewRequest("GET", "/api/test", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "open", breakerState())
+	})
+
+	t.Run("Middleware logs retry attempts", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		mockLogger := &MockLogger{}
+		cl := &ConsoleLogger{
+			id:     "test-middleware",
+			filter: newLogFilter(cfg),
+			logger: mockLogger,
+		}
+		retrier, err := retry.NewRetryMiddleware("retry", &configRetry.Retry{
+			Backoff:         configRetry.BackoffFixed,
+			InitialInterval: time.Millisecond,
+		})
+		require.NoError(t, err)
+
+		tries := 0
+		app := func(rp *httpserver.RequestProcessor) {
+			tries++
+			if tries == 1 {
+				rp.Writer().WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			rp.Writer().WriteHeader(http.StatusOK)
+		}
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			"test",
+			"/api/test",
+			func(w http.ResponseWriter, r *http.Request) {},
+			cl.Middleware(),
+			retrier.WrapChain([]httpserver.HandlerFunc{app}),
+		)
+		require.NoError(t, err)
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+
+		var retryAttr *slog.Attr
+		for i := range mockLogger.loggedAttrs {
+			if mockLogger.loggedAttrs[i].Key == groupRetry {
+				retryAttr = &mockLogger.loggedAttrs[i]
+			}
+		}
+		require.NotNil(t, retryAttr, "retry group should be logged")
+		group := retryAttr.Value.Group()
+		require.Len(t, group, 2)
+		assert.Equal(t, "retry", group[0].Value.String())
+		assert.Equal(t, int64(2), group[1].Value.Int64())
+	})
+
+	t.Run("Middleware logs cache results", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		mockLogger := &MockLogger{}
+		cl := &ConsoleLogger{
+			id:     "test-middleware",
+			filter: newLogFilter(cfg),
+			logger: mockLogger,
+		}
+		cacher, err := cache.NewCacheMiddleware("cache", &configCache.Cache{TTL: time.Minute})
+		require.NoError(t, err)
+
+		route, err := httpserver.NewRouteFromHandlerFunc(
+			"test",
+			"/api/test",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("cached"))
+			},
+			cl.Middleware(),
+			cacher.Middleware(),
+		)
+		require.NoError(t, err)
+
+		cacheResult := func() string {
+			mockLogger.loggedAttrs = nil
+			rec := httptest.NewRecorder()
+			route.ServeHTTP(rec, httptest.NewRequest("GET", "/api/test", nil))
+			assert.Equal(t, "cached", rec.Body.String())
+			for _, attr := range mockLogger.loggedAttrs {
+				if attr.Key == groupCache {
+					group := attr.Value.Group()
+					require.Len(t, group, 2)
+					assert.Equal(t, "cache", group[0].Value.String())
+					return group[1].Value.String()
+				}
+			}
+			return ""
+		}
+		assert.Equal(t, "miss", cacheResult())
+		assert.Equal(t, "hit", cacheResult())
+	})
+
+	t.Run("Middleware omits auth group for anonymous requests", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		mockLogger := &MockLogger{}
+		cl := &ConsoleLogger{
+			id:     "test-middleware",
+			filter: newLogFilter(cfg),
+			logger: mockLogger,
+		}
+
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/api/test", handler, cl.Middleware())
+		require.NoError(t, err)
+		route.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/test", nil))
+
+		for _, attr := range mockLogger.loggedAttrs {
+			assert.NotEqual(t, groupAuth, attr.Key)
+		}
+	})
 }
 
 func TestLogFilter_skipPath(t *testing.T) {
@@ -540,87 +930,59 @@ func (e errorReader) Read(p []byte) (n int, err error) {
 	return 0, fmt.Errorf("simulated read error")
 }
 
-func TestReadBody(t *testing.T) {
-	t.Parallel()
-
-	t.Run("Nil body", func(t *testing.T) {
-		req := httptest.NewRequest("GET", "/test", nil)
-		req.Body = nil // Explicitly set to nil
-		body, err := readBody(req, 1024)
-		require.NoError(t, err)
-		assert.Nil(t, body)
-	})
-
-	t.Run("Read body with limit", func(t *testing.T) {
-		bodyContent := "test body content"
-		req := httptest.NewRequest("POST", "/test", strings.NewReader(bodyContent))
-
-		body, err := readBody(req, 1024)
-		require.NoError(t, err)
-		assert.Equal(t, bodyContent, string(body))
-
-		// Check that body can still be read by handler
-		buf := new(bytes.Buffer)
-		_, err = buf.ReadFrom(req.Body)
-		require.NoError(t, err)
-		assert.Equal(t, bodyContent, buf.String())
-	})
-
-	t.Run("Read body with log size limit preserves full body for handler", func(t *testing.T) {
-		bodyContent := "this is a longer test body content that exceeds the log limit"
-		req := httptest.NewRequest("POST", "/test", strings.NewReader(bodyContent))
-
-		// readBody should return truncated content for logging
-		loggedBody, err := readBody(req, 10)
-		require.NoError(t, err)
-		assert.Equal(t, "this is a ", string(loggedBody))
-		assert.Len(t, loggedBody, 10)
-
-		// But the full body should still be available to the handler
-		buf := new(bytes.Buffer)
-		_, err = buf.ReadFrom(req.Body)
-		require.NoError(t, err)
-		assert.Equal(t, bodyContent, buf.String())
-	})
-
-	t.Run("Read error handling", func(t *testing.T) {
-		req := httptest.NewRequest("POST", "/test", nil)
-		req.Body = io.NopCloser(errorReader{})
-
-		body, err := readBody(req, 1024)
-		require.Error(t, err)
-		assert.Nil(t, body)
-		assert.Contains(t, err.Error(), "simulated read error")
-	})
-}
-
 func TestGetClientIP(t *testing.T) {
 	t.Parallel()
 
+	proxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
 	tests := []struct {
 		name       string
 		headers    map[string]string
 		remoteAddr string
+		proxies    []netip.Prefix
 		expectedIP string
 	}{
 		{
 			name:       "X-Forwarded-For single IP",
 			headers:    map[string]string{"X-Forwarded-For": "192.168.1.1"},
 			remoteAddr: "10.0.0.1:12345",
+			proxies:    proxies,
 			expectedIP: "192.168.1.1",
 		},
 		{
 			name:       "X-Forwarded-For multiple IPs",
 			headers:    map[string]string{"X-Forwarded-For": "192.168.1.1, 10.0.0.1, 172.16.0.1"},
 			remoteAddr: "10.0.0.1:12345",
+			proxies:    []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("172.16.0.0/12")},
 			expectedIP: "192.168.1.1",
 		},
 		{
 			name:       "X-Real-IP",
 			headers:    map[string]string{"X-Real-IP": "192.168.1.1"},
 			remoteAddr: "10.0.0.1:12345",
+			proxies:    proxies,
 			expectedIP: "192.168.1.1",
 		},
+		{
+			name:       "spoofed X-Forwarded-For from untrusted peer",
+			headers:    map[string]string{"X-Forwarded-For": "192.168.1.1"},
+			remoteAddr: "203.0.113.7:12345",
+			proxies:    proxies,
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "spoofed X-Real-IP from untrusted peer",
+			headers:    map[string]string{"X-Real-IP": "192.168.1.1"},
+			remoteAddr: "203.0.113.7:12345",
+			proxies:    proxies,
+			expectedIP: "203.0.113.7",
+		},
+		{
+			name:       "forwarding headers ignored without trusted proxies",
+			headers:    map[string]string{"X-Forwarded-For": "192.168.1.1"},
+			remoteAddr: "10.0.0.1:12345",
+			expectedIP: "10.0.0.1",
+		},
 		{
 			name:       "RemoteAddr fallback",
 			headers:    map[string]string{},
@@ -640,6 +1002,9 @@ func TestGetClientIP(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			req := httptest.NewRequest("GET", "/test", nil)
 			req.RemoteAddr = tt.remoteAddr
+			if tt.proxies != nil {
+				req = req.WithContext(apps.WithTrustedProxies(req.Context(), tt.proxies))
+			}
 
 			for key, value := range tt.headers {
 				req.Header.Set(key, value)
@@ -794,6 +1159,36 @@ func TestConsoleLogger_Log(t *testing.T) {
 		}
 	})
 
+	t.Run("Slow requests log at the higher severity", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			name          string
+			attrs         []slog.Attr
+			expectedLevel slog.Level
+		}{
+			{"fast", []slog.Attr{slog.Int("status", 200)}, slog.LevelInfo},
+			{"slow", []slog.Attr{slog.Int("status", 200), slog.Bool("slow", true)}, slog.LevelWarn},
+			{"slow without status", []slog.Attr{slog.Bool("slow", true)}, slog.LevelWarn},
+			{"slow server error", []slog.Attr{slog.Bool("slow", true), slog.Int("status", 503)}, slog.LevelWarn},
+			{"not slow", []slog.Attr{slog.Int("status", 200), slog.Bool("slow", false)}, slog.LevelInfo},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				mockLogger := &MockLogger{loggedLevel: slog.Level(-999)}
+				cl := &ConsoleLogger{
+					id:     "test-logger",
+					filter: newLogFilter(logger.NewConsoleLogger()),
+					logger: mockLogger,
+				}
+
+				cl.Log(t.Context(), tt.attrs)
+				assert.Equal(t, tt.expectedLevel, mockLogger.loggedLevel)
+			})
+		}
+	})
+
 	t.Run("Empty attributes skipped", func(t *testing.T) {
 		t.Parallel()
 
@@ -869,7 +1264,7 @@ func TestConsoleLogger_captureRequestBody(t *testing.T) {
 		assert.Nil(t, body)
 	})
 
-	t.Run("Returns nil on readBody error", func(t *testing.T) {
+	t.Run("Returns nil on body read error", func(t *testing.T) {
 		cfg := logger.NewConsoleLogger()
 		cfg.Fields.Request.Body = true
 
@@ -886,6 +1281,24 @@ func TestConsoleLogger_captureRequestBody(t *testing.T) {
 		assert.Nil(t, body)
 	})
 
+	t.Run("Stops at the request body limit and leaves the error for the handler", func(t *testing.T) {
+		cfg := logger.NewConsoleLogger()
+		cfg.Fields.Request.Body = true
+
+		cl := &ConsoleLogger{
+			id:     "test-logger",
+			filter: newLogFilter(cfg),
+		}
+
+		req := httptest.NewRequest("POST", "/test", strings.NewReader("more than ten bytes"))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 10)
+		body := cl.captureRequestBody(req)
+		assert.Nil(t, body)
+
+		_, err := apps.ReadBody(req)
+		require.ErrorIs(t, err, apps.ErrBodyTooLarge)
+	})
+
 	t.Run("Truncates logged request body but preserves full body for handler", func(t *testing.T) {
 		cfg := logger.NewConsoleLogger()
 		cfg.Fields.Request.Body = true
@@ -1111,3 +1524,139 @@ func TestConsoleLogger_captureAndRestoreResponse(t *testing.T) {
 		assert.Equal(t, "application/json", originalWriter.Header().Get("Content-Type"))
 	})
 }
+
+// groupAttrs returns the attributes of the named group in attrs
+func groupAttrs(attrs []slog.Attr, group string) map[string]slog.Value {
+	values := make(map[string]slog.Value)
+	for _, attr := range attrs {
+		if attr.Key != group {
+			continue
+		}
+		for _, member := range attr.Value.Group() {
+			values[member.Key] = member.Value
+		}
+	}
+	return values
+}
+
+func TestConsoleLogger_Redaction(t *testing.T) {
+	t.Parallel()
+
+	cfg := logger.NewConsoleLogger()
+	cfg.Fields.Request.Headers = true
+	cfg.Fields.Request.Body = true
+	cfg.Fields.Request.MaxBodySize = 1024
+	cfg.Fields.Response.Body = true
+	cfg.Fields.Response.MaxBodySize = 1024
+	cfg.Redaction = logger.Redaction{
+		Headers:    []string{"authorization"},
+		BodyFields: []string{"password", "session.token"},
+	}
+
+	mockLogger := &MockLogger{}
+	cl := &ConsoleLogger{
+		id:     "test-redaction",
+		filter: newLogFilter(cfg),
+		logger: mockLogger,
+	}
+
+	requestBody := `{"user": "bob", "password": "hunter2"}`
+	responseBody := `{"session": {"token": "abc123", "expires": 60}}`
+
+	var handlerBody string
+	var handlerAuth string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		handlerBody = string(body)
+		handlerAuth = r.Header.Get("Authorization")
+		_, err = w.Write([]byte(responseBody))
+		assert.NoError(t, err)
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/login", handler, cl.Middleware())
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/login", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", "Bearer secret")
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+
+	// The handler and client see the unmodified values
+	assert.Equal(t, requestBody, handlerBody)
+	assert.Equal(t, "Bearer secret", handlerAuth)
+	assert.Equal(t, responseBody, rec.Body.String())
+
+	// The log has them masked
+	request := groupAttrs(mockLogger.loggedAttrs, groupRequest)
+	assert.JSONEq(t, `{"user":"bob","password":"[REDACTED]"}`, request[attrBody].String())
+	headers, ok := request[attrHeaders].Any().(map[string][]string)
+	require.True(t, ok)
+	assert.Equal(t, []string{redactedValue}, headers["Authorization"])
+	assert.Equal(t, []string{"application/json"}, headers["Content-Type"])
+
+	response := groupAttrs(mockLogger.loggedAttrs, groupResponse)
+	assert.JSONEq(t, `{"session":{"token":"[REDACTED]","expires":60}}`, response[attrBody].String())
+}
+
+func TestConsoleLogger_StreamedResponse(t *testing.T) {
+	t.Parallel()
+
+	cfg := logger.NewConsoleLogger()
+	cfg.Fields.Response.Enabled = true
+	cfg.Fields.Response.Body = true
+	cfg.Fields.Response.BodySize = true
+	cfg.Fields.Response.MaxBodySize = 8
+
+	mockLogger := &MockLogger{}
+	cl := &ConsoleLogger{
+		id:     "test-stream",
+		filter: newLogFilter(cfg),
+		logger: mockLogger,
+	}
+
+	rec := httptest.NewRecorder()
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusAccepted)
+		_, err := w.Write([]byte("data: 1\n\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, http.NewResponseController(w).Flush())
+
+		// The flushed chunk reached the client before the handler returned
+		assert.Equal(t, "data: 1\n\n", rec.Body.String())
+		assert.True(t, rec.Flushed)
+
+		_, err = w.Write([]byte("data: 2\n\n"))
+		assert.NoError(t, err)
+	}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/events", handler, cl.Middleware())
+	require.NoError(t, err)
+	route.ServeHTTP(rec, httptest.NewRequest("GET", "/events", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "data: 1\n\ndata: 2\n\n", rec.Body.String())
+
+	// Only the start of the body is kept, but the size counts all of it
+	response := groupAttrs(mockLogger.loggedAttrs, groupResponse)
+	assert.Equal(t, "data: 1\n", response[attrBody].String())
+	assert.Equal(t, int64(18), response[attrBodySize].Int64())
+}
+
+func TestConsoleLogger_logBody(t *testing.T) {
+	t.Parallel()
+
+	cfg := logger.NewConsoleLogger()
+	cfg.Redaction.BodyFields = []string{"password"}
+	cl := &ConsoleLogger{
+		id:     "test-logger",
+		filter: newLogFilter(cfg),
+	}
+
+	// The full body is redacted before it is truncated, since a truncated
+	// document can't be parsed to find the fields
+	body := []byte(`{"password": "hunter2", "padding": "xxxxxxxxxxxxxxxxxxxx"}`)
+	assert.Equal(t, `{"padding":"xxxxxxxxxxxxxxxxxxxx","password":"[REDACTED]"}`, string(cl.logBody(body, 1024)))
+	assert.Equal(t, `{"padding"`, string(cl.logBody(body, 10)))
+}
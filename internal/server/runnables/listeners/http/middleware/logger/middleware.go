@@ -1,7 +1,6 @@
 package logger
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -12,7 +11,12 @@ import (
 	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/logger"
 	"github.com/atlanticdynamic/firelynx/internal/interpolation"
 	centralLogger "github.com/atlanticdynamic/firelynx/internal/logging"
+	"github.com/atlanticdynamic/firelynx/internal/logging/otlp"
 	"github.com/atlanticdynamic/firelynx/internal/logging/writers"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/cache"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/circuitbreaker"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/middleware/retry"
 	"github.com/robbyt/go-supervisor/runnables/httpserver"
 )
 
@@ -30,6 +34,7 @@ type filter interface {
 	ResponseBodyLogEnabled() bool
 	MaxRequestBodyLogSize() int
 	MaxResponseBodyLogSize() int
+	RedactBody(body []byte) []byte
 }
 
 // lgr is implemented by slog.Logger
@@ -48,6 +53,10 @@ type ConsoleLogger struct {
 	id     string
 	filter filter
 	logger lgr
+
+	// slowThreshold is the duration past which a request is logged as slow,
+	// disabled when zero
+	slowThreshold time.Duration
 }
 
 // NewConsoleLogger creates a new ConsoleLogger middleware implementation instance.
@@ -68,26 +77,70 @@ func NewConsoleLogger(id string, cfg *logger.ConsoleLogger) (*ConsoleLogger, err
 		return nil, fmt.Errorf("environment variable expansion failed: %w", err)
 	}
 
+	handler, err := newHandler(id, &configCopy, expandedOutput)
+	if err != nil {
+		return nil, err
+	}
+
+	lgr := slog.New(handler).WithGroup(id)
+	return &ConsoleLogger{
+		id:            id,
+		filter:        filter,
+		logger:        lgr,
+		slowThreshold: configCopy.SlowThreshold,
+	}, nil
+}
+
+// newHandler creates the slog handler writing to output. The "otlp" output
+// exports each record to the configured OpenTelemetry collector instead of
+// writing it, in batches flushed by otlp.Shutdown. The "syslog" output sends
+// each formatted record to the configured syslog collector.
+func newHandler(id string, cfg *logger.ConsoleLogger, output string) (slog.Handler, error) {
+	if writers.ParseWriterType(output) == writers.WriterTypeOTLP {
+		exportCfg := cfg.OTLP.Config()
+		endpoint, err := interpolation.ExpandEnvVars(exportCfg.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("environment variable expansion failed: %w", err)
+		}
+		exportCfg.Endpoint = endpoint
+
+		level := centralLogger.ParseLevel(string(cfg.Options.Level))
+		return otlp.NewHandler(id, exportCfg, level)
+	}
+
+	if writers.ParseWriterType(output) == writers.WriterTypeSyslog {
+		syslogCfg := cfg.Syslog.Config(id)
+		for _, field := range []*string{&syslogCfg.Network, &syslogCfg.Address, &syslogCfg.Facility, &syslogCfg.Tag} {
+			expanded, err := interpolation.ExpandEnvVars(*field)
+			if err != nil {
+				return nil, fmt.Errorf("environment variable expansion failed: %w", err)
+			}
+			*field = expanded
+		}
+
+		writer, err := writers.NewSyslogWriter(syslogCfg)
+		if err != nil {
+			return nil, err
+		}
+		return writer.WithSeverity(formatHandler(cfg, writer)), nil
+	}
+
 	// Create writer based on output configuration
-	writer, err := writers.CreateWriter(expandedOutput)
+	writer, err := writers.CreateWriter(output)
 	if err != nil {
 		return nil, err
 	}
+	return formatHandler(cfg, writer), nil
+}
 
-	var handler slog.Handler
-	switch configCopy.Options.Format {
+// formatHandler creates the txt or json handler writing to writer
+func formatHandler(cfg *logger.ConsoleLogger, writer io.Writer) slog.Handler {
+	switch cfg.Options.Format {
 	case logger.FormatJSON:
-		handler = centralLogger.SetupHandlerJSON(string(configCopy.Options.Level), writer)
+		return centralLogger.SetupHandlerJSON(string(cfg.Options.Level), writer)
 	default:
-		handler = centralLogger.SetupHandlerText(string(configCopy.Options.Level), writer)
+		return centralLogger.SetupHandlerText(string(cfg.Options.Level), writer)
 	}
-
-	lgr := slog.New(handler).WithGroup(id)
-	return &ConsoleLogger{
-		id:     id,
-		filter: filter,
-		logger: lgr,
-	}, nil
 }
 
 // Middleware returns the middleware function
@@ -117,6 +170,35 @@ func (cl *ConsoleLogger) Middleware() httpserver.HandlerFunc {
 		// Build log attributes and write log entry
 		duration := time.Since(start)
 		attrs := cl.filter.BuildLogAttrs(r, rp.Writer(), duration, requestBody, responseBody)
+		// Auth, circuit breaker, retry and cache middlewares later in the
+		// chain replace the request, so read their state from it
+		if principal := apps.PrincipalFromContext(rp.Request().Context()); principal != nil {
+			attrs = append(attrs, slog.Group(groupAuth,
+				slog.String(attrSubject, principal.Subject),
+				slog.String(attrMethod, principal.Method),
+			))
+		}
+		if breaker := circuitbreaker.StatusFromContext(rp.Request().Context()); breaker != nil {
+			attrs = append(attrs, slog.Group(groupCircuitBreaker,
+				slog.String(attrID, breaker.ID),
+				slog.String(attrState, breaker.State.String()),
+			))
+		}
+		if retried := retry.StatusFromContext(rp.Request().Context()); retried != nil {
+			attrs = append(attrs, slog.Group(groupRetry,
+				slog.String(attrID, retried.ID),
+				slog.Int(attrAttempts, retried.Attempts),
+			))
+		}
+		if cached := cache.StatusFromContext(rp.Request().Context()); cached != nil {
+			attrs = append(attrs, slog.Group(groupCache,
+				slog.String(attrID, cached.ID),
+				slog.String(attrResult, string(cached.Result)),
+			))
+		}
+		if cl.slowThreshold > 0 && duration > cl.slowThreshold {
+			attrs = append(attrs, slog.Bool(attrSlow, true))
+		}
 		cl.Log(r.Context(), attrs)
 	}
 }
@@ -127,14 +209,17 @@ func (cl *ConsoleLogger) captureRequestBody(r *http.Request) []byte {
 		return nil
 	}
 
-	body, err := readBody(r, cl.filter.MaxRequestBodyLogSize())
+	// A body over the listener's size limit fails here without being read
+	// further, and the request keeps failing for the handler
+	body, err := apps.ReadBody(r)
 	if err != nil {
 		return nil
 	}
-	return body
+	return cl.logBody(body, cl.filter.MaxRequestBodyLogSize())
 }
 
-// setupResponseBuffering sets up response buffering if enabled
+// setupResponseBuffering sets up response buffering if enabled. A handler
+// that flushes streams its response through the buffer to the original writer.
 func (cl *ConsoleLogger) setupResponseBuffering(
 	rp requestProcessor,
 ) (*ResponseBuffer, httpserver.ResponseWriter) {
@@ -143,7 +228,7 @@ func (cl *ConsoleLogger) setupResponseBuffering(
 	}
 
 	originalWriter := rp.Writer()
-	responseBuffer := NewResponseBuffer()
+	responseBuffer := newStreamingResponseBuffer(originalWriter, cl.filter.MaxResponseBodyLogSize())
 	rp.SetWriter(responseBuffer)
 	return responseBuffer, originalWriter
 }
@@ -157,6 +242,12 @@ func (cl *ConsoleLogger) captureAndRestoreResponse(
 		return nil
 	}
 
+	// A streamed response was written as it was flushed, and only its start
+	// was kept
+	if responseBuffer.Streaming() {
+		return cl.logBody(responseBuffer.buffer.Bytes(), cl.filter.MaxResponseBodyLogSize())
+	}
+
 	// Get the full response body from the buffer
 	fullResponseBody := responseBuffer.buffer.Bytes()
 
@@ -179,54 +270,44 @@ func (cl *ConsoleLogger) captureAndRestoreResponse(
 	// and there's no way to recover from a write error at this stage
 	_, _ = originalWriter.Write(fullResponseBody) //nolint:errcheck
 
-	// Return truncated body for logging purposes only
-	logBody := fullResponseBody
-	if len(logBody) > cl.filter.MaxResponseBodyLogSize() {
-		logBody = logBody[:cl.filter.MaxResponseBodyLogSize()]
+	return cl.logBody(fullResponseBody, cl.filter.MaxResponseBodyLogSize())
+}
+
+// logBody returns the redacted body, truncated to maxLogSize, for logging
+// purposes only
+func (cl *ConsoleLogger) logBody(body []byte, maxLogSize int) []byte {
+	logBody := cl.filter.RedactBody(body)
+	if len(logBody) > maxLogSize {
+		logBody = logBody[:maxLogSize]
 	}
 	return logBody
 }
 
-// Log writes the log entry with appropriate level based on status code
+// Log writes the log entry at the level for its status code and slowness
 func (cl *ConsoleLogger) Log(ctx context.Context, attrs []slog.Attr) {
 	if len(attrs) == 0 {
 		return
 	}
 
-	// Determine log level from status code
+	cl.logger.LogAttrs(ctx, logLevel(attrs), cl.id, attrs...)
+}
+
+// logLevel returns the level of a log entry: warn for a 5xx status or a slow
+// request, info otherwise. The status and slowness are judged independently,
+// and the more severe level wins.
+func logLevel(attrs []slog.Attr) slog.Level {
 	level := slog.LevelInfo
 	for _, attr := range attrs {
-		if attr.Key == "status" {
-			if statusCode, ok := attr.Value.Any().(int64); ok {
-				if statusCode >= 500 {
-					level = slog.LevelWarn
-				}
+		switch attr.Key {
+		case attrStatus:
+			if statusCode, ok := attr.Value.Any().(int64); ok && statusCode >= 500 {
+				level = max(level, slog.LevelWarn)
+			}
+		case attrSlow:
+			if attr.Value.Kind() == slog.KindBool && attr.Value.Bool() {
+				level = max(level, slog.LevelWarn)
 			}
-			break
 		}
 	}
-
-	cl.logger.LogAttrs(ctx, level, cl.id, attrs...)
-}
-
-func readBody(r *http.Request, maxLogSize int) ([]byte, error) {
-	if r.Body == nil {
-		return nil, nil
-	}
-
-	// Read the entire body (not limited by log size)
-	bodyBytes, err := io.ReadAll(r.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	// Restore the body so it can be read by the handler
-	r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-
-	// Return truncated body for logging purposes only
-	logBody := bodyBytes
-	if len(logBody) > maxLogSize {
-		logBody = logBody[:maxLogSize]
-	}
-	return logBody, nil
+	return level
 }
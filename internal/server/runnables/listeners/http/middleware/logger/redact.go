@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// redactedValue replaces sensitive header and body values in the log
+const redactedValue = "[REDACTED]"
+
+// redactJSON returns a copy of body with the value at each path replaced by
+// redactedValue. A path is a sequence of object keys; arrays along the path
+// are searched element by element. body is returned unchanged when it isn't
+// JSON or has none of the paths, and is never modified.
+func redactJSON(body []byte, paths [][]string) []byte {
+	if len(paths) == 0 || len(body) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return body
+	}
+
+	redacted := false
+	for _, path := range paths {
+		if redactPath(doc, path) {
+			redacted = true
+		}
+	}
+	if !redacted {
+		return body
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return []byte(redactedValue)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// redactPath replaces the value at path within node, reporting whether any
+// value was replaced
+func redactPath(node any, path []string) bool {
+	switch v := node.(type) {
+	case map[string]any:
+		child, ok := v[path[0]]
+		if !ok {
+			return false
+		}
+		if len(path) == 1 {
+			v[path[0]] = redactedValue
+			return true
+		}
+		return redactPath(child, path[1:])
+	case []any:
+		redacted := false
+		for _, elem := range v {
+			if redactPath(elem, path) {
+				redacted = true
+			}
+		}
+		return redacted
+	default:
+		return false
+	}
+}
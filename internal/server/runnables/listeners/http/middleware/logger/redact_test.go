@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		paths    [][]string
+		expected string
+	}{
+		{
+			name:     "top-level field",
+			body:     `{"user": "bob", "password": "hunter2"}`,
+			paths:    [][]string{{"password"}},
+			expected: `{"password":"[REDACTED]","user":"bob"}`,
+		},
+		{
+			name:     "nested field",
+			body:     `{"user": {"name": "bob", "token": {"value": "abc"}}}`,
+			paths:    [][]string{{"user", "token"}},
+			expected: `{"user":{"name":"bob","token":"[REDACTED]"}}`,
+		},
+		{
+			name:     "fields in arrays",
+			body:     `{"users": [{"token": "a"}, {"name": "carol"}, {"token": "b"}]}`,
+			paths:    [][]string{{"users", "token"}},
+			expected: `{"users":[{"token":"[REDACTED]"},{"name":"carol"},{"token":"[REDACTED]"}]}`,
+		},
+		{
+			name:     "top-level array",
+			body:     `[{"password": "a"}, {"password": "b"}]`,
+			paths:    [][]string{{"password"}},
+			expected: `[{"password":"[REDACTED]"},{"password":"[REDACTED]"}]`,
+		},
+		{
+			name:     "numbers and markup are kept as written",
+			body:     `{"amount": 12345678901234567890, "html": "<b>&</b>", "secret": 1}`,
+			paths:    [][]string{{"secret"}},
+			expected: `{"amount":12345678901234567890,"html":"<b>&</b>","secret":"[REDACTED]"}`,
+		},
+		{
+			name:     "no matching field leaves the body unchanged",
+			body:     `{"user": "bob",  "nested": {"x": 1}}`,
+			paths:    [][]string{{"password"}, {"nested", "x", "y"}},
+			expected: `{"user": "bob",  "nested": {"x": 1}}`,
+		},
+		{
+			name:     "non-JSON body is unchanged",
+			body:     `password=hunter2`,
+			paths:    [][]string{{"password"}},
+			expected: `password=hunter2`,
+		},
+		{
+			name:     "no paths",
+			body:     `{"password": "hunter2"}`,
+			expected: `{"password": "hunter2"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(tt.body)
+			assert.Equal(t, tt.expected, string(redactJSON(body, tt.paths)))
+			assert.Equal(t, tt.body, string(body), "the original body must not be modified")
+		})
+	}
+}
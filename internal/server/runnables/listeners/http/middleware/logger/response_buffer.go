@@ -5,11 +5,20 @@ import (
 	"net/http"
 )
 
-// ResponseBuffer captures response data for logging
+// ResponseBuffer captures response data for logging. A response that is
+// flushed is streamed instead: the buffered status, headers and body are sent
+// to the client writer, later writes go straight through, and only the start
+// of the body, up to the capture limit, is kept for the log.
 type ResponseBuffer struct {
 	buffer  *bytes.Buffer
 	headers http.Header
 	status  int
+	size    int
+
+	// client receives a streamed response; flushing is a no-op without it
+	client       http.ResponseWriter
+	captureLimit int
+	streaming    bool
 }
 
 func NewResponseBuffer() *ResponseBuffer {
@@ -20,6 +29,15 @@ func NewResponseBuffer() *ResponseBuffer {
 	}
 }
 
+// newStreamingResponseBuffer returns a buffer that streams to client once
+// flushed, keeping at most captureLimit bytes of a streamed body
+func newStreamingResponseBuffer(client http.ResponseWriter, captureLimit int) *ResponseBuffer {
+	rb := NewResponseBuffer()
+	rb.client = client
+	rb.captureLimit = captureLimit
+	return rb
+}
+
 // Header implements http.ResponseWriter
 func (rb *ResponseBuffer) Header() http.Header {
 	return rb.headers
@@ -27,7 +45,18 @@ func (rb *ResponseBuffer) Header() http.Header {
 
 // Write implements http.ResponseWriter
 func (rb *ResponseBuffer) Write(data []byte) (int, error) {
-	return rb.buffer.Write(data)
+	if !rb.streaming {
+		n, err := rb.buffer.Write(data)
+		rb.size += n
+		return n, err
+	}
+
+	if remaining := rb.captureLimit - rb.buffer.Len(); remaining > 0 {
+		rb.buffer.Write(data[:min(remaining, len(data))])
+	}
+	n, err := rb.client.Write(data)
+	rb.size += n
+	return n, err
 }
 
 // WriteHeader implements http.ResponseWriter
@@ -37,9 +66,47 @@ func (rb *ResponseBuffer) WriteHeader(statusCode int) {
 	}
 }
 
+// Flush implements http.Flusher. The first flush sends the buffered response
+// to the client and switches to streaming; each flush is passed on to the
+// client writer.
+func (rb *ResponseBuffer) Flush() {
+	if rb.client == nil {
+		return
+	}
+
+	if !rb.streaming {
+		for key, values := range rb.headers {
+			for _, value := range values {
+				rb.client.Header().Add(key, value)
+			}
+		}
+		if rb.status == 0 {
+			rb.status = http.StatusOK
+		}
+		rb.client.WriteHeader(rb.status)
+		// The response is committed, a failed write shows in the client's
+		// next write
+		_, _ = rb.client.Write(rb.buffer.Bytes()) //nolint:errcheck
+		if rb.buffer.Len() > rb.captureLimit {
+			rb.buffer.Truncate(max(rb.captureLimit, 0))
+		}
+		rb.streaming = true
+	}
+
+	if f, ok := rb.client.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Streaming reports whether the response was flushed to the client, rather
+// than held in the buffer
+func (rb *ResponseBuffer) Streaming() bool {
+	return rb.streaming
+}
+
 // Status implements httpserver.ResponseWriter
 func (rb *ResponseBuffer) Status() int {
-	if rb.status == 0 && rb.buffer.Len() > 0 {
+	if rb.status == 0 && rb.size > 0 {
 		return http.StatusOK
 	}
 	return rb.status
@@ -47,10 +114,11 @@ func (rb *ResponseBuffer) Status() int {
 
 // Written implements httpserver.ResponseWriter
 func (rb *ResponseBuffer) Written() bool {
-	return rb.buffer.Len() > 0 || rb.status != 0
+	return rb.size > 0 || rb.status != 0
 }
 
-// Size implements httpserver.ResponseWriter
+// Size implements httpserver.ResponseWriter. For a streamed response it counts
+// every byte written, not just those kept for the log.
 func (rb *ResponseBuffer) Size() int {
-	return rb.buffer.Len()
+	return rb.size
 }
@@ -0,0 +1,32 @@
+package retry
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+)
+
+// backoff computes the delay between attempts
+type backoff struct {
+	kind    retry.Backoff
+	initial time.Duration
+	max     time.Duration
+	jitter  bool
+}
+
+// delay returns the wait after the given attempt, counted from 1. With
+// jitter, the delay is drawn uniformly between zero and its full length.
+func (b backoff) delay(attempt int) time.Duration {
+	d := b.initial
+	if b.kind == retry.BackoffExponential {
+		for i := 1; i < attempt && d < b.max; i++ {
+			d *= 2
+		}
+	}
+	d = min(d, b.max)
+	if b.jitter && d > 0 {
+		d = rand.N(d + 1)
+	}
+	return d
+}
@@ -0,0 +1,28 @@
+package retry
+
+import "context"
+
+// Status records the attempts made for a request
+type Status struct {
+	// ID is the ID of the retry middleware
+	ID string
+
+	// Attempts is the number of attempts made, the last of which produced
+	// the response
+	Attempts int
+}
+
+// statusKey is the context key for the retry status
+type statusKey struct{}
+
+// withStatus returns a copy of ctx carrying the retry status
+func withStatus(ctx context.Context, s *Status) context.Context {
+	return context.WithValue(ctx, statusKey{}, s)
+}
+
+// StatusFromContext returns the retry status stored in ctx, or nil if the
+// request didn't pass through a retry middleware
+func StatusFromContext(ctx context.Context) *Status {
+	s, _ := ctx.Value(statusKey{}).(*Status)
+	return s
+}
@@ -0,0 +1,256 @@
+// Package retry provides middleware that retries requests whose app responded
+// with a transient failure.
+//
+// Requests using one of the configured methods, the idempotent methods by
+// default, are replayed through the middleware after the retry middleware and
+// the app when an attempt responds with a retryable status code or the app
+// returns a retryable error. The request body is buffered, up to a limit, so
+// every attempt reads it in full; a body already buffered by the body_buffer
+// middleware is replayed from that buffer instead. The response of an attempt that may be
+// retried is held back; all other responses, including that of the last
+// attempt, are written as they are produced. Attempts stop when the next
+// delay would run past the request context's deadline.
+//
+// The number of attempts made is stored in the request context, where the
+// logger middleware reads it.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "20-retry"
+//	type = "retry"
+//
+//	[endpoints.middlewares.retry]
+//	max_attempts = 3
+//	backoff = "exponential"
+//	initial_interval = "100ms"
+//	max_interval = "2s"
+//	jitter = true
+//	retry_on_status = [502, 503, 504]
+//	retry_on_errors = ["timeout"]
+package retry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for retry middleware.
+var (
+	ErrNilConfig     = errors.New("retry config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid retry config")
+)
+
+// RetryMiddleware is a middleware implementation that replays requests
+// failing with transient errors.
+type RetryMiddleware struct {
+	id            string
+	maxAttempts   int
+	methods       []string
+	backoff       backoff
+	retryOnStatus []int
+	retryOnErrors []string
+	maxBodySize   int64
+	logger        *slog.Logger
+}
+
+// NewRetryMiddleware creates a new RetryMiddleware instance.
+func NewRetryMiddleware(id string, cfg *retry.Retry) (*RetryMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return &RetryMiddleware{
+		id:          id,
+		maxAttempts: cfg.GetMaxAttempts(),
+		methods:     cfg.GetMethods(),
+		backoff: backoff{
+			kind:    cfg.GetBackoff(),
+			initial: cfg.GetInitialInterval(),
+			max:     cfg.GetMaxInterval(),
+			jitter:  cfg.Jitter,
+		},
+		retryOnStatus: cfg.GetRetryOnStatus(),
+		retryOnErrors: cfg.RetryOnErrors,
+		maxBodySize:   cfg.GetMaxBodySize(),
+		logger:        slog.Default().WithGroup("retry").With("id", id),
+	}, nil
+}
+
+// Middleware returns a middleware function that passes each request through
+// once, as it can't replay the rest of the chain. Routes built by the HTTP
+// listener use WrapChain instead.
+func (rm *RetryMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+		rp.SetRequest(r.WithContext(withStatus(r.Context(), &Status{ID: rm.id, Attempts: 1})))
+		rp.Next()
+	}
+}
+
+// WrapChain returns the middleware function that runs next, the middleware
+// after this one and the app handler, once per attempt.
+func (rm *RetryMiddleware) WrapChain(next []httpserver.HandlerFunc) httpserver.HandlerFunc {
+	chain := &httpserver.Route{Handlers: next}
+
+	return func(rp *httpserver.RequestProcessor) {
+		// The chain after this middleware runs here, not in the route
+		defer rp.Abort()
+
+		status := &Status{ID: rm.id, Attempts: 1}
+		r := rp.Request()
+		r = r.WithContext(withStatus(r.Context(), status))
+		rp.SetRequest(r)
+
+		if !slices.Contains(rm.methods, r.Method) {
+			chain.ServeHTTP(rp.Writer(), r)
+			return
+		}
+
+		body, ok := rm.bufferBody(r)
+		if !ok {
+			chain.ServeHTTP(rp.Writer(), r)
+			return
+		}
+
+		ctx := r.Context()
+		for attempt := 1; ; attempt++ {
+			status.Attempts = attempt
+			report := &apps.ErrorReport{}
+			req := r.Clone(apps.WithErrorReport(ctx, report))
+			if body != nil {
+				req.Body = io.NopCloser(bytes.NewReader(body))
+				req.GetBody = func() (io.ReadCloser, error) {
+					return io.NopCloser(bytes.NewReader(body)), nil
+				}
+			}
+
+			delay := rm.backoff.delay(attempt)
+			if attempt >= rm.maxAttempts || !hasTimeFor(ctx, delay) {
+				chain.ServeHTTP(rp.Writer(), req)
+				return
+			}
+
+			w := newAttemptWriter(rp.Writer(), rm.holds, rm.maxBodySize)
+			chain.ServeHTTP(w, req)
+			if !w.held() || !rm.retryable(w.status, report.Err) {
+				w.commit()
+				return
+			}
+
+			rm.logger.DebugContext(ctx, "Retrying request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"attempt", attempt,
+				"status", w.status,
+				"error", report.Err,
+				"delay", delay,
+			)
+			if !sleep(ctx, delay) {
+				w.commit()
+				return
+			}
+		}
+	}
+}
+
+// holds reports whether a response with the status is held back, as the
+// attempt might be retried. Responses to app errors are at least 500.
+func (rm *RetryMiddleware) holds(status int) bool {
+	return slices.Contains(rm.retryOnStatus, status) ||
+		(len(rm.retryOnErrors) > 0 && status >= http.StatusInternalServerError)
+}
+
+// retryable reports whether an attempt that responded with the status and
+// whose app returned err is retried
+func (rm *RetryMiddleware) retryable(status int, err error) bool {
+	if slices.Contains(rm.retryOnStatus, status) {
+		return true
+	}
+	if err == nil {
+		return false
+	}
+	for _, name := range rm.retryOnErrors {
+		switch name {
+		case retry.ErrorAny:
+			return true
+		case retry.ErrorTimeout:
+			if errors.Is(err, context.DeadlineExceeded) {
+				return true
+			}
+		case retry.ErrorUnavailable:
+			if errors.Is(err, apps.ErrAppUnavailable) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bufferBody reads the request body so it can be replayed, reusing the
+// buffer of a body buffer middleware that ran earlier. When the body is
+// larger than the size limit, or reading it fails, it reports false and
+// restores the body so the request can still be sent once.
+func (rm *RetryMiddleware) bufferBody(r *http.Request) ([]byte, bool) {
+	if body, ok := apps.BufferedBody(r.Context()); ok {
+		return body, true
+	}
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, true
+	}
+	if r.ContentLength > rm.maxBodySize {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, rm.maxBodySize+1))
+	if err != nil || int64(len(body)) > rm.maxBodySize {
+		// The rest of the body, or its read error, follows what was read
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+		return nil, false
+	}
+	_ = r.Body.Close()
+	return body, true
+}
+
+// hasTimeFor reports whether ctx is still live and its deadline, if any, is
+// further away than d
+func hasTimeFor(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > d
+}
+
+// sleep waits for d, and reports false if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,368 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/retry"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMiddleware returns a middleware with a 1ms fixed backoff
+func newTestMiddleware(t *testing.T, cfg *retry.Retry) *RetryMiddleware {
+	t.Helper()
+	if cfg.InitialInterval == 0 {
+		cfg.Backoff = retry.BackoffFixed
+		cfg.InitialInterval = time.Millisecond
+	}
+	rm, err := NewRetryMiddleware("retry", cfg)
+	require.NoError(t, err)
+	return rm
+}
+
+// flakyApp fails the first failures requests with the status, then succeeds.
+// It records the request body of every attempt.
+type flakyApp struct {
+	failures int
+	status   int
+	bodies   []string
+	attempts []int
+}
+
+func (a *flakyApp) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	a.bodies = append(a.bodies, string(body))
+	if s := StatusFromContext(r.Context()); s != nil {
+		a.attempts = append(a.attempts, s.Attempts)
+	}
+
+	w.Header().Set("X-Attempt", fmt.Sprint(len(a.bodies)))
+	if len(a.bodies) <= a.failures {
+		w.Header().Set("X-Failed", "true")
+		w.WriteHeader(a.status)
+		_, _ = fmt.Fprintf(w, "failure %d", len(a.bodies))
+		return
+	}
+	_, _ = io.WriteString(w, "ok")
+}
+
+// serve sends req through the middleware's wrapped chain to handler
+func serve(t *testing.T, rm *RetryMiddleware, handler http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	next := []httpserver.HandlerFunc{func(rp *httpserver.RequestProcessor) {
+		handler.ServeHTTP(rp.Writer(), rp.Request())
+	}}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			t.Error("the route's own handler must not run after the retry middleware")
+		}, rm.WrapChain(next))
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewRetryMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewRetryMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewRetryMiddleware("test", &retry.Retry{RetryOnErrors: []string{"bogus"}})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, retry.ErrUnknownError)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		rm, err := NewRetryMiddleware("test", &retry.Retry{})
+		require.NoError(t, err)
+		assert.Equal(t, retry.DefaultMaxAttempts, rm.maxAttempts)
+		assert.Equal(t, retry.DefaultMethods(), rm.methods)
+		assert.Equal(t, retry.DefaultRetryOnStatus(), rm.retryOnStatus)
+		assert.Equal(t, int64(retry.DefaultMaxBodySize), rm.maxBodySize)
+	})
+}
+
+func TestRetryMiddleware_WrapChain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("retries until an attempt succeeds", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{})
+		app := &flakyApp{failures: 2, status: http.StatusServiceUnavailable}
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "ok", rec.Body.String())
+		assert.Equal(t, "3", rec.Header().Get("X-Attempt"))
+		assert.Empty(t, rec.Header().Get("X-Failed"), "headers of retried attempts are discarded")
+		assert.Equal(t, []int{1, 2, 3}, app.attempts)
+	})
+
+	t.Run("returns the last failure after max attempts", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{MaxAttempts: 2})
+		app := &flakyApp{failures: 5, status: http.StatusBadGateway}
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.Equal(t, "failure 2", rec.Body.String())
+		assert.Equal(t, []int{1, 2}, app.attempts)
+	})
+
+	t.Run("doesn't retry other status codes", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{})
+		app := &flakyApp{failures: 1, status: http.StatusInternalServerError}
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "failure 1", rec.Body.String())
+		assert.Equal(t, []int{1}, app.attempts)
+	})
+
+	t.Run("doesn't retry non-idempotent methods", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{})
+		app := &flakyApp{failures: 1, status: http.StatusServiceUnavailable}
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("data")))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, []string{"data"}, app.bodies)
+	})
+
+	t.Run("replays the request body", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{Methods: []string{"POST"}})
+		app := &flakyApp{failures: 2, status: http.StatusServiceUnavailable}
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("payload")))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"payload", "payload", "payload"}, app.bodies)
+	})
+
+	t.Run("sends a body over the limit once", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{Methods: []string{"PUT"}, MaxBodySize: 4})
+		app := &flakyApp{failures: 1, status: http.StatusServiceUnavailable}
+
+		req := httptest.NewRequest(http.MethodPut, "/test", strings.NewReader("too long"))
+		req.ContentLength = -1
+		rec := serve(t, rm, app, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, []string{"too long"}, app.bodies)
+	})
+
+	t.Run("replays a body buffered earlier in the chain", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{Methods: []string{"PUT"}, MaxBodySize: 16})
+		app := &flakyApp{failures: 1, status: http.StatusServiceUnavailable}
+
+		req := httptest.NewRequest(http.MethodPut, "/test", strings.NewReader("a buffered request body"))
+		body, err := apps.BufferBody(req, 64)
+		require.NoError(t, err)
+		req = req.WithContext(apps.WithBufferedBody(req.Context(), body))
+		// An earlier consumer drained the body without restoring it
+		_, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		rec := serve(t, rm, app, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"a buffered request body", "a buffered request body"}, app.bodies)
+	})
+
+	t.Run("retries app errors", func(t *testing.T) {
+		tests := []struct {
+			name      string
+			errors    []string
+			err       error
+			wantTries int
+		}{
+			{"timeout", []string{retry.ErrorTimeout}, fmt.Errorf("eval: %w", context.DeadlineExceeded), 2},
+			{"unavailable", []string{retry.ErrorUnavailable}, apps.ErrAppUnavailable, 2},
+			{"any", []string{retry.ErrorAny}, io.ErrUnexpectedEOF, 2},
+			{"not listed", []string{retry.ErrorTimeout}, io.ErrUnexpectedEOF, 1},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rm := newTestMiddleware(t, &retry.Retry{RetryOnErrors: tt.errors})
+				tries := 0
+				app := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					tries++
+					if tries == 1 {
+						// The dispatcher reports the app error and writes a 500
+						apps.ErrorReportFromContext(r.Context()).Err = tt.err
+						http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				})
+
+				serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+				assert.Equal(t, tt.wantTries, tries)
+			})
+		}
+	})
+
+	t.Run("stops before the context deadline", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{InitialInterval: time.Hour, MaxInterval: time.Hour})
+		app := &flakyApp{failures: 5, status: http.StatusServiceUnavailable}
+
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		defer cancel()
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+		rec := serve(t, rm, app, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "failure 1", rec.Body.String())
+		assert.Equal(t, []int{1}, app.attempts)
+	})
+
+	t.Run("stops when the context is canceled while waiting", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{InitialInterval: time.Hour, MaxInterval: time.Hour})
+		ctx, cancel := context.WithCancel(t.Context())
+		tries := 0
+		app := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tries++
+			cancel()
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		})
+
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/test", nil)
+		rec := serve(t, rm, app, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "unavailable\n", rec.Body.String())
+		assert.Equal(t, 1, tries)
+	})
+
+	t.Run("doesn't retry a flushed response", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{})
+		tries := 0
+		app := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tries++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, "streaming")
+			w.(http.Flusher).Flush()
+		})
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "streaming", rec.Body.String())
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, 1, tries)
+	})
+
+	t.Run("doesn't retry a response over the buffer limit", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{MaxBodySize: 8})
+		tries := 0
+		app := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tries++
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, "a long ")
+			_, _ = io.WriteString(w, "error page")
+		})
+
+		rec := serve(t, rm, app, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Equal(t, "a long error page", rec.Body.String())
+		assert.Equal(t, 1, tries)
+	})
+
+	t.Run("later middleware runs on every attempt", func(t *testing.T) {
+		rm := newTestMiddleware(t, &retry.Retry{})
+		app := &flakyApp{failures: 1, status: http.StatusServiceUnavailable}
+		middlewareRuns := 0
+		next := []httpserver.HandlerFunc{
+			func(rp *httpserver.RequestProcessor) {
+				middlewareRuns++
+				rp.Next()
+			},
+			func(rp *httpserver.RequestProcessor) {
+				app.ServeHTTP(rp.Writer(), rp.Request())
+			},
+		}
+		var status *Status
+		observe := func(rp *httpserver.RequestProcessor) {
+			rp.Next()
+			status = StatusFromContext(rp.Request().Context())
+		}
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {}, observe, rm.WrapChain(next))
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		route.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, middlewareRuns)
+		require.NotNil(t, status)
+		assert.Equal(t, "retry", status.ID)
+		assert.Equal(t, 2, status.Attempts)
+	})
+}
+
+func TestRetryMiddleware_Middleware(t *testing.T) {
+	rm := newTestMiddleware(t, &retry.Retry{})
+	app := &flakyApp{failures: 1, status: http.StatusServiceUnavailable}
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test", app.ServeHTTP, rm.Middleware())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, []int{1}, app.attempts)
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fixed", func(t *testing.T) {
+		b := backoff{kind: retry.BackoffFixed, initial: 100 * time.Millisecond, max: time.Second}
+		for attempt := 1; attempt <= 5; attempt++ {
+			assert.Equal(t, 100*time.Millisecond, b.delay(attempt))
+		}
+	})
+
+	t.Run("exponential", func(t *testing.T) {
+		b := backoff{kind: retry.BackoffExponential, initial: 100 * time.Millisecond, max: time.Second}
+		assert.Equal(t, 100*time.Millisecond, b.delay(1))
+		assert.Equal(t, 200*time.Millisecond, b.delay(2))
+		assert.Equal(t, 400*time.Millisecond, b.delay(3))
+		assert.Equal(t, 800*time.Millisecond, b.delay(4))
+		assert.Equal(t, time.Second, b.delay(5))
+		assert.Equal(t, time.Second, b.delay(100))
+	})
+
+	t.Run("jitter", func(t *testing.T) {
+		b := backoff{kind: retry.BackoffExponential, initial: 100 * time.Millisecond, max: time.Second, jitter: true}
+		for range 100 {
+			d := b.delay(3)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+			assert.LessOrEqual(t, d, 400*time.Millisecond)
+		}
+	})
+}
+
+func TestStatusFromContext(t *testing.T) {
+	assert.Nil(t, StatusFromContext(context.Background()))
+
+	status := &Status{ID: "retry", Attempts: 2}
+	assert.Same(t, status, StatusFromContext(withStatus(context.Background(), status)))
+}
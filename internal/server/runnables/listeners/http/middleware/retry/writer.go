@@ -0,0 +1,109 @@
+package retry
+
+import (
+	"bytes"
+	"maps"
+	"net/http"
+)
+
+// attemptWriter is the response writer of an attempt that may be retried. A
+// response whose status hold accepts is buffered until the attempt is judged;
+// any other response is written through. A held response is written through
+// once it outgrows the buffer or is flushed, as it can no longer be retried.
+type attemptWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+	hold   func(status int) bool
+	limit  int64
+
+	status    int
+	buffered  bool
+	committed bool
+	body      bytes.Buffer
+}
+
+// newAttemptWriter returns an attemptWriter starting from the headers already
+// set on w
+func newAttemptWriter(w http.ResponseWriter, hold func(int) bool, limit int64) *attemptWriter {
+	return &attemptWriter{
+		w:      w,
+		header: w.Header().Clone(),
+		hold:   hold,
+		limit:  limit,
+	}
+}
+
+// Header returns the headers of the attempt's response
+func (aw *attemptWriter) Header() http.Header {
+	if aw.committed {
+		return aw.w.Header()
+	}
+	return aw.header
+}
+
+// WriteHeader holds the response back or writes it through, by status
+func (aw *attemptWriter) WriteHeader(status int) {
+	if aw.status != 0 {
+		return
+	}
+	aw.status = status
+	if aw.hold(status) {
+		aw.buffered = true
+		return
+	}
+	aw.commit()
+}
+
+// Write buffers a held response body and writes others through
+func (aw *attemptWriter) Write(b []byte) (int, error) {
+	if aw.status == 0 {
+		aw.WriteHeader(http.StatusOK)
+	}
+	if !aw.committed && int64(aw.body.Len()+len(b)) > aw.limit {
+		aw.commit()
+	}
+	if aw.committed {
+		return aw.w.Write(b)
+	}
+	return aw.body.Write(b)
+}
+
+// Flush writes a held response through and flushes it
+func (aw *attemptWriter) Flush() {
+	if aw.status == 0 {
+		aw.WriteHeader(http.StatusOK)
+	}
+	aw.commit()
+	if f, ok := aw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer, for http.ResponseController
+func (aw *attemptWriter) Unwrap() http.ResponseWriter {
+	return aw.w
+}
+
+// held reports whether the attempt's response is still held back
+func (aw *attemptWriter) held() bool {
+	return aw.buffered && !aw.committed
+}
+
+// commit writes the response through, along with anything buffered
+func (aw *attemptWriter) commit() {
+	if aw.committed {
+		return
+	}
+	aw.committed = true
+
+	header := aw.w.Header()
+	clear(header)
+	maps.Copy(header, aw.header)
+	if aw.status == 0 {
+		return
+	}
+	aw.w.WriteHeader(aw.status)
+	if aw.body.Len() > 0 {
+		_, _ = aw.w.Write(aw.body.Bytes())
+	}
+}
@@ -0,0 +1,149 @@
+// Package signature provides middleware that verifies an HMAC signature of
+// the request body, as sent by webhook providers such as GitHub and Stripe.
+//
+// The HMAC-SHA256 of the raw request body, keyed with the configured secret,
+// is compared in constant time against the signature in the configured
+// header. The header value may start with a fixed prefix, such as "sha256=",
+// and the signature is hex or base64 encoded. Requests with a missing,
+// malformed or wrong signature get a 401 and don't reach the rest of the
+// chain.
+//
+// The body is read through apps.ReadBody, so later middleware and the app can
+// still read it. Add a body_buffer middleware before this one to cap the size
+// of the body read and share a single buffer among every reader.
+//
+// Example configuration:
+//
+//	[[endpoints.middlewares]]
+//	id = "10-signature"
+//	type = "signature"
+//
+//	[endpoints.middlewares.signature]
+//	secret = "${file:/run/secrets/webhook_secret}"
+//	header = "X-Hub-Signature-256"
+//	encoding = "hex"
+//	prefix = "sha256="
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/signature"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for signature middleware.
+var (
+	ErrNilConfig     = errors.New("signature config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid signature config")
+)
+
+// Reasons a signature is rejected, logged at debug level
+var (
+	errMissingSignature   = errors.New("missing signature header")
+	errMalformedSignature = errors.New("malformed signature")
+	errSignatureMismatch  = errors.New("signature mismatch")
+)
+
+// SignatureMiddleware is a middleware implementation that rejects requests
+// without a valid HMAC signature of their body.
+type SignatureMiddleware struct {
+	id     string
+	secret []byte
+	header string
+	prefix string
+	decode func(string) ([]byte, error)
+	logger *slog.Logger
+}
+
+// NewSignatureMiddleware creates a new SignatureMiddleware instance.
+func NewSignatureMiddleware(id string, cfg *signature.Signature) (*SignatureMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	decode := hex.DecodeString
+	if cfg.GetEncoding() == signature.EncodingBase64 {
+		decode = base64.StdEncoding.DecodeString
+	}
+
+	return &SignatureMiddleware{
+		id:     id,
+		secret: []byte(cfg.Secret),
+		header: cfg.GetHeader(),
+		prefix: cfg.Prefix,
+		decode: decode,
+		logger: slog.Default().WithGroup("signature").With("id", id),
+	}, nil
+}
+
+// Middleware returns the middleware function that verifies request signatures.
+func (sm *SignatureMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		r := rp.Request()
+
+		body, err := apps.ReadBody(r)
+		if err != nil {
+			if errors.Is(err, apps.ErrBodyTooLarge) {
+				http.Error(rp.Writer(), "Request Entity Too Large", http.StatusRequestEntityTooLarge)
+			} else {
+				http.Error(rp.Writer(), "Bad Request", http.StatusBadRequest)
+			}
+			sm.logger.DebugContext(r.Context(), "Failed to read request body",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"error", err,
+			)
+			rp.Abort()
+			return
+		}
+
+		if err := sm.verify(r.Header.Get(sm.header), body); err != nil {
+			sm.logger.DebugContext(r.Context(), "Rejected request signature",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"error", err,
+			)
+			http.Error(rp.Writer(), "Unauthorized", http.StatusUnauthorized)
+			rp.Abort()
+			return
+		}
+
+		rp.Next()
+	}
+}
+
+// verify checks that value, a header value, carries the signature of body
+func (sm *SignatureMiddleware) verify(value string, body []byte) error {
+	if value == "" {
+		return errMissingSignature
+	}
+	encoded, ok := strings.CutPrefix(value, sm.prefix)
+	if !ok {
+		return fmt.Errorf("%w: missing prefix %q", errMalformedSignature, sm.prefix)
+	}
+	got, err := sm.decode(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errMalformedSignature, err)
+	}
+
+	mac := hmac.New(sha256.New, sm.secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errSignatureMismatch
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/signature"
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "s3cret"
+
+func sign(body string) []byte {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return mac.Sum(nil)
+}
+
+func newTestMiddleware(t *testing.T, cfg *signature.Signature) *SignatureMiddleware {
+	t.Helper()
+	cfg.Secret = testSecret
+	sm, err := NewSignatureMiddleware("signature", cfg)
+	require.NoError(t, err)
+	sm.logger = slog.New(slog.DiscardHandler)
+	return sm
+}
+
+// serve sends req through the middleware to an app that records the body it
+// read, and reports whether the app ran
+func serve(t *testing.T, sm *SignatureMiddleware, req *http.Request) (*httptest.ResponseRecorder, string, bool) {
+	t.Helper()
+	var appBody string
+	called := false
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+		func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			appBody = string(b)
+		}, sm.Middleware())
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec, appBody, called
+}
+
+func newRequest(body, header, value string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	return req
+}
+
+func TestNewSignatureMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil configuration", func(t *testing.T) {
+		_, err := NewSignatureMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := NewSignatureMiddleware("test", &signature.Signature{})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, signature.ErrEmptySecret)
+	})
+}
+
+func TestSignatureMiddleware(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"action":"opened"}`
+
+	t.Run("valid hex signature", func(t *testing.T) {
+		sm := newTestMiddleware(t, &signature.Signature{})
+		req := newRequest(body, signature.DefaultHeader, hex.EncodeToString(sign(body)))
+
+		rec, appBody, called := serve(t, sm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, called)
+		assert.Equal(t, body, appBody, "the app still reads the full body")
+	})
+
+	t.Run("valid base64 signature with prefix", func(t *testing.T) {
+		sm := newTestMiddleware(t, &signature.Signature{
+			Header:   "X-Hub-Signature-256",
+			Encoding: signature.EncodingBase64,
+			Prefix:   "sha256=",
+		})
+		value := "sha256=" + base64.StdEncoding.EncodeToString(sign(body))
+		req := newRequest(body, "X-Hub-Signature-256", value)
+
+		rec, _, called := serve(t, sm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		sm := newTestMiddleware(t, &signature.Signature{})
+		req := httptest.NewRequest(http.MethodPost, "/test", nil)
+		req.Header.Set(signature.DefaultHeader, hex.EncodeToString(sign("")))
+
+		rec, _, called := serve(t, sm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("body buffered earlier in the chain", func(t *testing.T) {
+		sm := newTestMiddleware(t, &signature.Signature{})
+		req := newRequest(body, signature.DefaultHeader, hex.EncodeToString(sign(body)))
+		buffered, err := apps.BufferBody(req, 1024)
+		require.NoError(t, err)
+		req = req.WithContext(apps.WithBufferedBody(req.Context(), buffered))
+		// An earlier consumer drained the body without restoring it
+		_, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+
+		rec, appBody, called := serve(t, sm, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, called)
+		assert.Equal(t, body, appBody)
+	})
+
+	rejected := []struct {
+		name  string
+		cfg   *signature.Signature
+		value string
+	}{
+		{
+			name: "missing header",
+			cfg:  &signature.Signature{},
+		},
+		{
+			name:  "wrong signature",
+			cfg:   &signature.Signature{},
+			value: hex.EncodeToString(sign("other body")),
+		},
+		{
+			name:  "not hex",
+			cfg:   &signature.Signature{},
+			value: "not-hex",
+		},
+		{
+			name:  "truncated signature",
+			cfg:   &signature.Signature{},
+			value: hex.EncodeToString(sign(body)[:16]),
+		},
+		{
+			name:  "missing prefix",
+			cfg:   &signature.Signature{Prefix: "sha256="},
+			value: hex.EncodeToString(sign(body)),
+		},
+		{
+			name:  "hex sent for base64",
+			cfg:   &signature.Signature{Encoding: signature.EncodingBase64},
+			value: hex.EncodeToString(sign(body)),
+		},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := newTestMiddleware(t, tt.cfg)
+			req := newRequest(body, signature.DefaultHeader, tt.value)
+
+			rec, _, called := serve(t, sm, req)
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+			assert.False(t, called)
+		})
+	}
+
+	t.Run("body over the listener limit", func(t *testing.T) {
+		sm := newTestMiddleware(t, &signature.Signature{})
+		req := newRequest(body, signature.DefaultHeader, hex.EncodeToString(sign(body)))
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 4)
+
+		rec, _, called := serve(t, sm, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.False(t, called)
+	})
+}
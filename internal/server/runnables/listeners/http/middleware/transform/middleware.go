@@ -0,0 +1,180 @@
+// Package transform provides middleware that rewrites requests and responses
+// with declarative rules, for changes too small to need a script.
+//
+// Request rules are applied in order before the rest of the chain runs: they
+// set, add, remove and rename request headers, and rewrite the request path
+// the later middleware and the app see. The path is rewritten after the
+// listener has routed the request, so it doesn't select another route.
+// Response rules are applied in order when the response headers are written,
+// so they cover headers set by the app: they change the response headers and
+// replace the status code.
+//
+// Example configuration:
+//
+//	[[endpoints.routes.middlewares]]
+//	id = "20-legacy-transform"
+//	type = "transform"
+//
+//	[[endpoints.routes.middlewares.transform.request]]
+//	rename_header = { from = "X-Api-Key", to = "Authorization" }
+//
+//	[[endpoints.routes.middlewares.transform.request]]
+//	rewrite_path = { pattern = "^/api/v1/(.*)$", replacement = "/$1" }
+//
+//	[[endpoints.routes.middlewares.transform.response]]
+//	remove_header = "Server"
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/transform"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// Sentinel errors for transform middleware.
+var (
+	ErrNilConfig     = errors.New("transform config cannot be nil")
+	ErrInvalidConfig = errors.New("invalid transform config")
+)
+
+// rule is a configured rule with its path pattern compiled
+type rule struct {
+	transform.Rule
+	pattern *regexp.Regexp
+}
+
+// TransformMiddleware is a middleware implementation that applies transform
+// rules to requests and responses.
+type TransformMiddleware struct {
+	id       string
+	request  []rule
+	response []rule
+}
+
+// NewTransformMiddleware creates a new TransformMiddleware instance.
+func NewTransformMiddleware(id string, cfg *transform.Transform) (*TransformMiddleware, error) {
+	if cfg == nil {
+		return nil, ErrNilConfig
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	request, err := compileRules(cfg.Request)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	return &TransformMiddleware{
+		id:       id,
+		request:  request,
+		response: toRules(cfg.Response),
+	}, nil
+}
+
+// compileRules compiles the path patterns of request rules
+func compileRules(rules []transform.Rule) ([]rule, error) {
+	compiled := toRules(rules)
+	for i := range compiled {
+		if compiled[i].Action != transform.ActionRewritePath {
+			continue
+		}
+		pattern, err := regexp.Compile(compiled[i].Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("request rule %d: %w", i, err)
+		}
+		compiled[i].pattern = pattern
+	}
+	return compiled, nil
+}
+
+// toRules wraps configured rules
+func toRules(rules []transform.Rule) []rule {
+	wrapped := make([]rule, 0, len(rules))
+	for _, r := range rules {
+		wrapped = append(wrapped, rule{Rule: r})
+	}
+	return wrapped
+}
+
+// Middleware returns the middleware function that applies the rules.
+func (m *TransformMiddleware) Middleware() httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		applyRequest(rp.Request(), m.request)
+		if len(m.response) == 0 {
+			rp.Next()
+			return
+		}
+
+		tw := newTransformWriter(rp.Writer(), m.response)
+		rp.SetWriter(tw)
+		rp.Next()
+		tw.finish()
+	}
+}
+
+// applyRequest applies the request rules in order
+func applyRequest(r *http.Request, rules []rule) {
+	for i := range rules {
+		if rules[i].Action == transform.ActionRewritePath {
+			rewritePath(r, rules[i].pattern, rules[i].Replacement)
+			continue
+		}
+		applyHeader(r.Header, &rules[i].Rule)
+	}
+}
+
+// rewritePath replaces the matches of pattern in the request path. The
+// escaped form of the old path no longer applies, so it's dropped.
+func rewritePath(r *http.Request, pattern *regexp.Regexp, replacement string) {
+	path := pattern.ReplaceAllString(r.URL.Path, replacement)
+	if path == r.URL.Path {
+		return
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	r.URL.Path = path
+	r.URL.RawPath = ""
+}
+
+// applyHeader applies a header rule to h, other rules are ignored
+func applyHeader(h http.Header, r *transform.Rule) {
+	switch r.Action {
+	case transform.ActionSetHeader:
+		h.Set(r.Header, r.Value)
+	case transform.ActionAddHeader:
+		h.Add(r.Header, r.Value)
+	case transform.ActionRemoveHeader:
+		h.Del(r.Header)
+	case transform.ActionRenameHeader:
+		values := h.Values(r.Header)
+		if len(values) == 0 {
+			return
+		}
+		h.Del(r.Header)
+		h.Del(r.To)
+		for _, v := range values {
+			h.Add(r.To, v)
+		}
+	}
+}
+
+// applyResponse applies the response rules in order to the headers and
+// returns the status code to send
+func applyResponse(h http.Header, status int, rules []rule) int {
+	for i := range rules {
+		if rules[i].Action == transform.ActionSetStatus {
+			status = rules[i].Status
+			continue
+		}
+		applyHeader(h, &rules[i].Rule)
+	}
+	return status
+}
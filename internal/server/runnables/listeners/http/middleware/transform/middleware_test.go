@@ -0,0 +1,142 @@
+package transform
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/endpoints/middleware/transform"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs a request through the transform middleware in front of handler
+func serve(
+	t *testing.T,
+	cfg *transform.Transform,
+	req *http.Request,
+	handler http.HandlerFunc,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	m, err := NewTransformMiddleware("test", cfg)
+	require.NoError(t, err)
+	route, err := httpserver.NewRouteFromHandlerFunc("test", "/", handler, m.Middleware())
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	route.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNewTransformMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid configuration", func(t *testing.T) {
+		m, err := NewTransformMiddleware("test", &transform.Transform{
+			Request: []transform.Rule{{Action: transform.ActionRemoveHeader, Header: "Cookie"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "test", m.id)
+	})
+
+	t.Run("nil configuration", func(t *testing.T) {
+		m, err := NewTransformMiddleware("test", nil)
+		require.ErrorIs(t, err, ErrNilConfig)
+		assert.Nil(t, m)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		m, err := NewTransformMiddleware("test", &transform.Transform{})
+		require.ErrorIs(t, err, ErrInvalidConfig)
+		require.ErrorIs(t, err, transform.ErrNoRules)
+		assert.Nil(t, m)
+	})
+}
+
+func TestTransformMiddleware_Request(t *testing.T) {
+	t.Parallel()
+
+	cfg := &transform.Transform{Request: []transform.Rule{
+		{Action: transform.ActionRenameHeader, Header: "X-Api-Key", To: "Authorization"},
+		{Action: transform.ActionSetHeader, Header: "X-Source", Value: "edge"},
+		{Action: transform.ActionAddHeader, Header: "X-Tag", Value: "b"},
+		{Action: transform.ActionRemoveHeader, Header: "Cookie"},
+		{Action: transform.ActionRewritePath, Pattern: `^/api/v1/(.*)$`, Replacement: "/$1"},
+	}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/items%2Fall?page=2", nil)
+	req.Header.Set("X-Api-Key", "secret")
+	req.Header.Set("Authorization", "replaced")
+	req.Header.Set("X-Source", "client")
+	req.Header.Set("X-Tag", "a")
+	req.Header.Set("Cookie", "session=1")
+
+	var seen *http.Request
+	rec := serve(t, cfg, req, func(w http.ResponseWriter, r *http.Request) {
+		seen = r
+		w.WriteHeader(http.StatusOK)
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, seen)
+
+	assert.Empty(t, seen.Header.Values("X-Api-Key"))
+	assert.Equal(t, []string{"secret"}, seen.Header.Values("Authorization"))
+	assert.Equal(t, "edge", seen.Header.Get("X-Source"))
+	assert.Equal(t, []string{"a", "b"}, seen.Header.Values("X-Tag"))
+	assert.Empty(t, seen.Header.Get("Cookie"))
+	assert.Equal(t, "/items/all", seen.URL.Path)
+	assert.Empty(t, seen.URL.RawPath)
+	assert.Equal(t, "page=2", seen.URL.RawQuery)
+
+	t.Run("path not matching the pattern is left alone", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/other", nil)
+		var path string
+		serve(t, cfg, req, func(w http.ResponseWriter, r *http.Request) { path = r.URL.Path })
+		assert.Equal(t, "/other", path)
+	})
+}
+
+func TestTransformMiddleware_Response(t *testing.T) {
+	t.Parallel()
+
+	cfg := &transform.Transform{Response: []transform.Rule{
+		{Action: transform.ActionRemoveHeader, Header: "Server"},
+		{Action: transform.ActionRenameHeader, Header: "X-Internal-Id", To: "X-Request-Id"},
+		{Action: transform.ActionSetHeader, Header: "X-Api-Version", Value: "1"},
+		{Action: transform.ActionSetStatus, Status: http.StatusAccepted},
+	}}
+
+	t.Run("rules apply to headers set by the handler", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := serve(t, cfg, req, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Server", "app/1.0")
+			w.Header().Set("X-Internal-Id", "42")
+			w.WriteHeader(http.StatusOK)
+			_, _ = io.WriteString(w, "queued")
+		})
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Header().Get("Server"))
+		assert.Empty(t, rec.Header().Get("X-Internal-Id"))
+		assert.Equal(t, "42", rec.Header().Get("X-Request-Id"))
+		assert.Equal(t, "1", rec.Header().Get("X-Api-Version"))
+		assert.Equal(t, "queued", rec.Body.String())
+	})
+
+	t.Run("implicit status from a write", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := serve(t, cfg, req, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "queued")
+		})
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("X-Api-Version"))
+	})
+
+	t.Run("handler writing nothing", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		rec := serve(t, cfg, req, func(w http.ResponseWriter, r *http.Request) {})
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("X-Api-Version"))
+	})
+}
@@ -0,0 +1,65 @@
+package transform
+
+import (
+	"net/http"
+
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// transformWriter applies the response rules right before the headers are
+// written, so they also cover headers set by the handler
+type transformWriter struct {
+	httpserver.ResponseWriter
+	rules       []rule
+	wroteHeader bool
+}
+
+func newTransformWriter(w httpserver.ResponseWriter, rules []rule) *transformWriter {
+	return &transformWriter{ResponseWriter: w, rules: rules}
+}
+
+// WriteHeader applies the response rules before sending the headers.
+// Informational responses are sent unchanged, the rules apply to the final
+// response.
+func (tw *transformWriter) WriteHeader(status int) {
+	if !tw.wroteHeader && (status >= http.StatusOK || status == http.StatusSwitchingProtocols) {
+		tw.wroteHeader = true
+		if status != http.StatusSwitchingProtocols {
+			status = applyResponse(tw.Header(), status, tw.rules)
+		}
+	}
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+// Write sends the headers with an implicit 200 status if they haven't been
+// written yet
+func (tw *transformWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Flush sends the headers before flushing, so streamed responses get the
+// rules applied too
+func (tw *transformWriter) Flush() {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if f, ok := tw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// finish writes the headers when the handler returned without writing
+// anything, so the rules apply to the implicit 200 response too
+func (tw *transformWriter) finish() {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+}
+
+// Unwrap returns the wrapped writer for http.ResponseController
+func (tw *transformWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package http
+
+import "syscall"
+
+// reusePortSupported reports whether a listener can bind an address that
+// another of its listeners is still bound to
+const reusePortSupported = false
+
+// setReusePort leaves the socket unchanged where SO_REUSEPORT isn't available
+func setReusePort(network, address string, conn syscall.RawConn) error {
+	return nil
+}
@@ -8,9 +8,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/accesslog"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
 	"github.com/robbyt/go-supervisor/runnables/httpcluster"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
 	"github.com/robbyt/go-supervisor/supervisor"
 )
 
@@ -27,6 +30,34 @@ type Runner struct {
 	// Configuration options
 	siphonTimeout       time.Duration
 	clusterReadyTimeout time.Duration
+
+	// Listener configs last sent to the cluster, the server options their
+	// servers were created with, and what the most recent CommitConfig did
+	// with each listener
+	appliedConfigs map[string]*httpserver.Config
+	appliedOptions map[string]serverOptions
+	lastReload     []transaction.ReloadResult
+
+	// accessLog holds the runtime access log settings of each listener
+	accessLog *accesslog.Controller
+
+	// bodyLimits holds the committed request body size limit of each listener
+	bodyLimits bodyLimits
+
+	// trustedProxies holds the committed trusted proxies of each listener
+	trustedProxies trustedProxies
+
+	// concurrencyLimits holds the committed concurrent request limit of each
+	// listener
+	concurrencyLimits *concurrencyLimits
+
+	// handoff holds the sockets bound for listeners a reload is starting,
+	// until their servers take them over
+	handoff *listenerHandoff
+
+	// drains tracks servers that stopped accepting connections but are
+	// still finishing in-flight requests
+	drains sync.WaitGroup
 }
 
 // Interface guards
@@ -35,6 +66,8 @@ var (
 	_ supervisor.Stateable         = (*Runner)(nil)
 	_ supervisor.Readiness         = (*Runner)(nil)
 	_ orchestrator.SagaParticipant = (*Runner)(nil)
+	_ orchestrator.ReloadReporter  = (*Runner)(nil)
+	_ orchestrator.DryRunner       = (*Runner)(nil)
 )
 
 // NewRunner creates a new HTTP cluster runner
@@ -52,6 +85,9 @@ func NewRunner(options ...Option) (*Runner, error) {
 
 	// Create config manager
 	r.configMgr = cfg.NewManager(r.logger)
+	r.accessLog = accesslog.NewController(r.logger.WithGroup("access"))
+	r.handoff = newListenerHandoff(r.logger.WithGroup("handoff"))
+	r.concurrencyLimits = newConcurrencyLimits(r.logger.WithGroup("concurrency"))
 
 	// Create httpcluster with default unbuffered siphon channel
 	cluster, err := httpcluster.NewRunner(
@@ -65,6 +101,12 @@ func NewRunner(options ...Option) (*Runner, error) {
 	return r, nil
 }
 
+// SetAccessLog enables, disables, or changes the level of a listener's access
+// logging. The change applies to the next request, without a reload.
+func (r *Runner) SetAccessLog(listenerID string, enabled bool, level slog.Level) {
+	r.accessLog.Set(listenerID, accesslog.Settings{Enabled: enabled, Level: level})
+}
+
 // String returns a unique identifier for this runner
 func (r *Runner) String() string {
 	return "HTTPRunner"
@@ -80,7 +122,9 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.cancel = ctxCancel
 
 	// The httpcluster will start with no servers and wait for configuration
+	clusterDone := make(chan struct{})
 	go func() {
+		defer close(clusterDone)
 		if err := r.cluster.Run(ctx); err != nil {
 			r.logger.Error("HTTP cluster failed", "error", err)
 		}
@@ -95,6 +139,11 @@ func (r *Runner) Run(ctx context.Context) error {
 	// block here until the run context is canceled
 	<-ctx.Done()
 
+	// The cluster stops its servers when the context is canceled; wait for
+	// that, then for their in-flight requests to drain
+	<-clusterDone
+	r.drains.Wait()
+
 	return r.shutdown()
 }
 
@@ -2,7 +2,13 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
 
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
@@ -33,6 +39,62 @@ func (r *Runner) StageConfig(ctx context.Context, tx *transaction.ConfigTransact
 	return nil
 }
 
+// DryRunConfig implements orchestrator.DryRunner. It builds the listener
+// configs for tx the same way StageConfig and CommitConfig would, and checks
+// that the routes of each listener are accepted and its address can be bound.
+// The pending and running configuration are left untouched.
+func (r *Runner) DryRunConfig(ctx context.Context, tx *transaction.ConfigTransaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	logger := r.logger.WithGroup("DryRunConfig").With("tx_id", tx.GetTransactionID())
+	logger.Debug("Checking HTTP configuration")
+
+	adapter, err := cfg.NewAdapter(tx, r.logger)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP adapter: %w", err)
+	}
+
+	r.mutex.RLock()
+	applied := r.appliedConfigs
+	r.mutex.RUnlock()
+
+	return checkListenerConfigs(r.prepConfigPayload(adapter), applied)
+}
+
+// checkListenerConfigs returns an error for each listener in configs that the
+// cluster would fail to start: its routes or timeouts are rejected by
+// httpserver, or its address can't be bound. Addresses served by a listener in
+// applied are not bound, since that listener releases them when the new
+// configuration is committed.
+func checkListenerConfigs(configs, applied map[string]*httpserver.Config) error {
+	served := make(map[string]bool, len(applied))
+	for _, c := range applied {
+		served[c.ListenAddr] = true
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(configs)) {
+		c := configs[id]
+		if _, err := httpserver.NewConfig(c.ListenAddr, c.Routes, httpserver.WithConfigCopy(c)); err != nil {
+			errs = append(errs, fmt.Errorf("listener %s: %w", id, err))
+		}
+		if served[c.ListenAddr] {
+			continue
+		}
+
+		ln, err := net.Listen("tcp", c.ListenAddr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("listener %s: cannot bind %s: %w", id, c.ListenAddr, err))
+			continue
+		}
+		if err := ln.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("listener %s: failed to release %s: %w", id, c.ListenAddr, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // CompensateConfig implements SagaParticipant.CompensateConfig
 func (r *Runner) CompensateConfig(ctx context.Context, failedTXID string) error {
 	logger := r.logger.WithGroup("CompensateConfig").With("tx_id", failedTXID)
@@ -60,8 +122,10 @@ func (r *Runner) CommitConfig(ctx context.Context) error {
 	hasPending := r.configMgr.HasPendingChanges()
 	if !hasPending {
 		logger.Debug("No pending HTTP configuration to apply")
+		r.lastReload = diffListenerConfigs(r.appliedConfigs, r.appliedConfigs, nil)
 		return nil
 	}
+	r.lastReload = nil
 
 	// Commit pending configuration
 	r.configMgr.CommitPending()
@@ -75,6 +139,25 @@ func (r *Runner) CommitConfig(ctx context.Context) error {
 // and sends them through the siphon channel, then waits for cluster to be ready
 func (r *Runner) sendConfigToCluster(ctx context.Context, cfg *cfg.Adapter) error {
 	configs := r.prepConfigPayload(cfg)
+	options := listenerServerOptions(cfg)
+	previous := r.appliedConfigs
+	restarts := serverOptionChanges(configs, previous, options, r.appliedOptions)
+
+	// Bind the addresses of new and restarted listeners before the cluster
+	// stops the servers they replace, so a reload that can't bind them fails
+	// with the old servers still running
+	if err := r.handoff.prebind(handoffAddrs(configs, previous, restarts)); err != nil {
+		return fmt.Errorf("failed to bind HTTP listeners: %w", err)
+	}
+	defer func() {
+		if n := r.handoff.release(); n > 0 {
+			r.logger.Warn("Closed pre-bound listeners not taken over by a server", "count", n)
+		}
+	}()
+
+	r.bodyLimits.set(listenerBodyLimits(cfg))
+	r.trustedProxies.set(listenerTrustedProxies(cfg))
+	r.concurrencyLimits.set(listenerConcurrencyLimits(cfg))
 
 	keys := make([]string, 0, len(configs))
 	for k := range configs {
@@ -85,31 +168,119 @@ func (r *Runner) sendConfigToCluster(ctx context.Context, cfg *cfg.Adapter) erro
 		r.logger.Warn("No HTTP listeners configured")
 	}
 
+	// The cluster only restarts a listener whose httpserver.Config changed,
+	// so listeners whose server options changed are first removed from the
+	// cluster, then started again with the new options
+	if len(restarts) > 0 {
+		interim := maps.Clone(previous)
+		for id := range restarts {
+			delete(interim, id)
+		}
+		r.logger.Debug("Stopping listeners to apply new server options",
+			"listeners", slices.Sorted(maps.Keys(restarts)))
+		if err := r.applyToCluster(ctx, interim); err != nil {
+			return err
+		}
+		r.appliedConfigs = interim
+	}
+
 	r.logger.Debug("Sending configuration to cluster", "config", keys)
+	if err := r.applyToCluster(ctx, configs); err != nil {
+		return err
+	}
+
+	// Log each HTTP server that's now ready at INFO level (matching gRPC format)
+	for listenerID, cfg := range configs {
+		r.logger.Info("HTTP listener is ready", "id", listenerID, "addr", cfg.ListenAddr)
+	}
+
+	r.lastReload = diffListenerConfigs(previous, configs, restarts)
+	r.appliedConfigs = configs
+	r.appliedOptions = options
+	for _, result := range r.lastReload {
+		r.logger.Info("HTTP listener reload result",
+			"id", result.Component, "action", result.Action)
+	}
+
+	return nil
+}
 
+// applyToCluster sends configs through the cluster's siphon and waits for the
+// cluster to become ready with them
+func (r *Runner) applyToCluster(ctx context.Context, configs map[string]*httpserver.Config) error {
 	// Send configuration through siphon with configurable timeout
 	siphonCtx, siphonCancel := context.WithTimeout(ctx, r.siphonTimeout)
 	defer siphonCancel()
 
 	select {
 	case r.cluster.GetConfigSiphon() <- configs:
-		r.logger.Debug("Sent configuration to cluster", "config", keys)
+		r.logger.Debug("Sent configuration to cluster", "listeners", len(configs))
 	case <-siphonCtx.Done():
 		return fmt.Errorf("timeout sending configuration to cluster after %v", r.siphonTimeout)
 	}
 
 	// Wait for cluster to finish processing and become ready
-	err := r.waitForClusterReady(ctx, r.clusterReadyTimeout)
-	if err != nil {
-		return err
+	return r.waitForClusterReady(ctx, r.clusterReadyTimeout)
+}
+
+// serverOptionChanges returns the listeners the cluster would leave running,
+// since their httpserver.Config is unchanged, but whose server options
+// changed. The options live in the ServerCreator closure, which
+// httpserver.Config.Equal doesn't compare.
+func serverOptionChanges(
+	configs, applied map[string]*httpserver.Config,
+	options, appliedOptions map[string]serverOptions,
+) map[string]bool {
+	restarts := make(map[string]bool)
+	for id, c := range configs {
+		if prev, ok := applied[id]; ok && prev.Equal(c) && options[id] != appliedOptions[id] {
+			restarts[id] = true
+		}
 	}
+	return restarts
+}
 
-	// Log each HTTP server that's now ready at INFO level (matching gRPC format)
-	for listenerID, cfg := range configs {
-		r.logger.Info("HTTP listener is ready", "id", listenerID, "addr", cfg.ListenAddr)
+// LastReloadResults implements orchestrator.ReloadReporter, reporting for each
+// listener whether the most recent CommitConfig left it running (skipped),
+// restarted, started, or stopped it.
+func (r *Runner) LastReloadResults() []transaction.ReloadResult {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return slices.Clone(r.lastReload)
+}
+
+// diffListenerConfigs compares the listener configs previously sent to the
+// cluster with the new ones, using the same equality check the cluster uses to
+// decide whether a server must be restarted. Listeners in restarts were
+// restarted for changed server options. Results are sorted by listener ID.
+func diffListenerConfigs(
+	previous, next map[string]*httpserver.Config,
+	restarts map[string]bool,
+) []transaction.ReloadResult {
+	var results []transaction.ReloadResult
+
+	for id, cfg := range next {
+		prev, exists := previous[id]
+		switch {
+		case !exists:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadStarted})
+		case prev.Equal(cfg) && !restarts[id]:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadSkipped})
+		default:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadRestarted})
+		}
 	}
 
-	return nil
+	for id := range previous {
+		if _, exists := next[id]; !exists {
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadStopped})
+		}
+	}
+
+	slices.SortFunc(results, func(a, b transaction.ReloadResult) int {
+		return strings.Compare(a.Component, b.Component)
+	})
+	return results
 }
 
 // prepConfigPayload converts the adapters into httpserver.Config objects
@@ -128,7 +299,7 @@ func (r *Runner) prepConfigPayload(cfg *cfg.Adapter) map[string]*httpserver.Conf
 
 			// Get routes for this listener
 			adapterRoutes := cfg.GetRoutesForListener(listenerID)
-			routes := r.convertRoutes(adapterRoutes)
+			routes := r.convertRoutes(listenerID, adapterRoutes)
 
 			logger.Debug("Routes for listener",
 				"adapter_routes_count", len(adapterRoutes),
@@ -152,6 +323,13 @@ func (r *Runner) prepConfigPayload(cfg *cfg.Adapter) map[string]*httpserver.Conf
 				WriteTimeout: listenerCfg.WriteTimeout,
 				IdleTimeout:  listenerCfg.IdleTimeout,
 				DrainTimeout: listenerCfg.DrainTimeout,
+				ServerCreator: newServerCreator(
+					listenerID,
+					newServerOptions(listenerCfg),
+					r.handoff.listen,
+					&r.drains,
+					r.logger.WithGroup("drain"),
+				),
 			}
 
 			configs[listenerID] = serverCfg
@@ -161,9 +339,89 @@ func (r *Runner) prepConfigPayload(cfg *cfg.Adapter) map[string]*httpserver.Conf
 	return configs
 }
 
-// convertRoutes converts adapter routes to httpserver.Route format
-func (r *Runner) convertRoutes(adapterRoutes []httpserver.Route) httpserver.Routes {
-	// The adapter already provides httpserver.Route objects
-	// Just return them as Routes (which is []Route)
-	return httpserver.Routes(adapterRoutes)
+// newServerOptions returns the server options of a listener
+func newServerOptions(listenerCfg cfg.ListenerConfig) serverOptions {
+	return serverOptions{
+		readHeaderTimeout: listenerCfg.ReadHeaderTimeout,
+		h2c:               listenerCfg.H2C,
+	}
+}
+
+// listenerServerOptions returns the server options of each listener in cfg
+func listenerServerOptions(adapter *cfg.Adapter) map[string]serverOptions {
+	options := make(map[string]serverOptions)
+	if adapter == nil {
+		return options
+	}
+	for _, listenerID := range adapter.GetListenerIDs() {
+		if listenerCfg, ok := adapter.GetListenerConfig(listenerID); ok {
+			options[listenerID] = newServerOptions(listenerCfg)
+		}
+	}
+	return options
+}
+
+// listenerBodyLimits returns the request body size limit of each listener in
+// cfg that has one
+func listenerBodyLimits(adapter *cfg.Adapter) map[string]int64 {
+	limits := make(map[string]int64)
+	if adapter == nil {
+		return limits
+	}
+	for _, listenerID := range adapter.GetListenerIDs() {
+		if listenerCfg, ok := adapter.GetListenerConfig(listenerID); ok && listenerCfg.MaxRequestBodyBytes > 0 {
+			limits[listenerID] = listenerCfg.MaxRequestBodyBytes
+		}
+	}
+	return limits
+}
+
+// listenerConcurrencyLimits returns the concurrent request limit of each
+// listener in cfg that has one
+func listenerConcurrencyLimits(adapter *cfg.Adapter) map[string]int {
+	limits := make(map[string]int)
+	if adapter == nil {
+		return limits
+	}
+	for _, listenerID := range adapter.GetListenerIDs() {
+		if listenerCfg, ok := adapter.GetListenerConfig(listenerID); ok && listenerCfg.MaxConcurrentRequests > 0 {
+			limits[listenerID] = listenerCfg.MaxConcurrentRequests
+		}
+	}
+	return limits
+}
+
+// listenerTrustedProxies returns the trusted proxies of each listener in cfg
+// that has any
+func listenerTrustedProxies(adapter *cfg.Adapter) map[string][]netip.Prefix {
+	proxies := make(map[string][]netip.Prefix)
+	if adapter == nil {
+		return proxies
+	}
+	for _, listenerID := range adapter.GetListenerIDs() {
+		if listenerCfg, ok := adapter.GetListenerConfig(listenerID); ok && len(listenerCfg.TrustedProxies) > 0 {
+			proxies[listenerID] = listenerCfg.TrustedProxies
+		}
+	}
+	return proxies
+}
+
+// convertRoutes converts adapter routes to httpserver.Route format, prepending
+// the listener's trusted proxy, runtime access log, concurrent request limit
+// and request body limit middleware to each route
+func (r *Runner) convertRoutes(listenerID string, adapterRoutes []httpserver.Route) httpserver.Routes {
+	proxies := r.trustedProxies.Middleware(listenerID)
+	accessLog := r.accessLog.Middleware(listenerID)
+	concurrencyLimit := r.concurrencyLimits.Middleware(listenerID)
+	bodyLimit := r.bodyLimits.Middleware(listenerID)
+
+	routes := make(httpserver.Routes, 0, len(adapterRoutes))
+	for _, route := range adapterRoutes {
+		route.Handlers = append(
+			[]httpserver.HandlerFunc{proxies, accessLog, concurrencyLimit, bodyLimit},
+			route.Handlers...,
+		)
+		routes = append(routes, route)
+	}
+	return routes
 }
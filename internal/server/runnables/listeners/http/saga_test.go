@@ -1,11 +1,19 @@
 package http
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
 	"github.com/atlanticdynamic/firelynx/internal/testutil"
 	"github.com/robbyt/go-supervisor/runnables/httpcluster"
@@ -238,11 +246,52 @@ func TestRunner_InternalHelpers(t *testing.T) {
 
 		adapterRoutes := []httpserver.Route{*route1, *route2}
 
-		convertedRoutes := runner.convertRoutes(adapterRoutes)
+		convertedRoutes := runner.convertRoutes("listener1", adapterRoutes)
 
 		assert.Len(t, convertedRoutes, 2, "Should return the same number of routes")
 		assert.Equal(t, "/test1", convertedRoutes[0].Path)
 		assert.Equal(t, "/test2", convertedRoutes[1].Path)
+		assert.Len(t, convertedRoutes[0].Handlers, len(route1.Handlers)+4,
+			"Should prepend the trusted proxy, access log, concurrency limit and body limit middleware")
+		assert.Len(t, route1.Handlers, 1, "Should not modify the adapter routes")
+	})
+
+	t.Run("SetAccessLog toggles access logging", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+		runner, err := NewRunner(WithLogger(logger))
+		require.NoError(t, err)
+
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/test",
+			func(w http.ResponseWriter, r *http.Request) {})
+		require.NoError(t, err)
+		routes := runner.convertRoutes("listener1", []httpserver.Route{*route})
+		require.Len(t, routes, 1)
+
+		accessLines := func() int {
+			return strings.Count(buf.String(), "msg=access")
+		}
+		serve := func() {
+			routes[0].ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+		}
+
+		serve()
+		assert.Equal(t, 0, accessLines())
+
+		runner.SetAccessLog("listener1", true, slog.LevelInfo)
+		serve()
+		serve()
+		assert.Equal(t, 2, accessLines())
+		assert.Contains(t, buf.String(), "listener_id=listener1")
+
+		runner.SetAccessLog("listener1", false, slog.LevelInfo)
+		serve()
+		assert.Equal(t, 2, accessLines())
+
+		// Entries below the logger's level are dropped
+		runner.SetAccessLog("listener1", true, slog.LevelDebug)
+		serve()
+		assert.Equal(t, 2, accessLines())
 	})
 }
 
@@ -420,3 +469,274 @@ func TestRunner_SendConfigToCluster(t *testing.T) {
 		}
 	})
 }
+
+func TestRunner_LastReloadResults(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run(ctx) }()
+	assert.Eventually(t, func() bool { return runner.IsReady() },
+		2*time.Second, 10*time.Millisecond, "runner should become ready")
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	newRoute := func(name, path string) httpserver.Route {
+		route, err := httpserver.NewRouteFromHandlerFunc(name, path, testHandler)
+		require.NoError(t, err)
+		return *route
+	}
+
+	listeners := map[string]cfg.ListenerConfig{
+		"listener1": {ID: "listener1", Address: testutil.GetRandomListeningPort(t)},
+		"listener2": {ID: "listener2", Address: testutil.GetRandomListeningPort(t)},
+	}
+	commit := func(txID string, routes map[string][]httpserver.Route) {
+		t.Helper()
+		runner.configMgr.SetPending(&cfg.Adapter{TxID: txID, Listeners: listeners, Routes: routes})
+		require.NoError(t, runner.CommitConfig(ctx))
+	}
+
+	commit("tx-1", map[string][]httpserver.Route{
+		"listener1": {newRoute("route1", "/one")},
+		"listener2": {newRoute("route2", "/two")},
+	})
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "listener1", Action: transaction.ReloadStarted},
+		{Component: "listener2", Action: transaction.ReloadStarted},
+	}, runner.LastReloadResults())
+
+	// listener1 is unchanged, listener2 gets a new route
+	commit("tx-2", map[string][]httpserver.Route{
+		"listener1": {newRoute("route1", "/one")},
+		"listener2": {newRoute("route2", "/two"), newRoute("route3", "/three")},
+	})
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "listener1", Action: transaction.ReloadSkipped},
+		{Component: "listener2", Action: transaction.ReloadRestarted},
+	}, runner.LastReloadResults())
+
+	// listener2 loses all routes and is removed from the cluster
+	commit("tx-3", map[string][]httpserver.Route{
+		"listener1": {newRoute("route1", "/one")},
+	})
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "listener1", Action: transaction.ReloadSkipped},
+		{Component: "listener2", Action: transaction.ReloadStopped},
+	}, runner.LastReloadResults())
+
+	// Nothing pending: every listener is reported as skipped
+	require.NoError(t, runner.CommitConfig(ctx))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "listener1", Action: transaction.ReloadSkipped},
+	}, runner.LastReloadResults())
+
+	runner.Stop()
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runner did not stop within timeout")
+	}
+}
+
+// startTestRunner runs a runner until the test ends
+func startTestRunner(t *testing.T) (*Runner, context.Context) {
+	t.Helper()
+	runner, err := NewRunner()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run(ctx) }()
+	t.Cleanup(func() {
+		runner.Stop()
+		select {
+		case err := <-runErr:
+			assert.NoError(t, err)
+		case <-time.After(2 * time.Second):
+			t.Error("runner did not stop within timeout")
+		}
+		cancel()
+	})
+	require.Eventually(t, func() bool { return runner.IsReady() },
+		2*time.Second, 10*time.Millisecond, "runner should become ready")
+	return runner, ctx
+}
+
+func TestRunner_ReloadServerOptions(t *testing.T) {
+	runner, ctx := startTestRunner(t)
+
+	route, err := httpserver.NewRouteFromHandlerFunc("route", "/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	})
+	require.NoError(t, err)
+	addr := testutil.GetRandomListeningPort(t)
+	commit := func(txID string, listener cfg.ListenerConfig) {
+		t.Helper()
+		listener.ID = "listener1"
+		listener.Address = addr
+		runner.configMgr.SetPending(&cfg.Adapter{
+			TxID:      txID,
+			Listeners: map[string]cfg.ListenerConfig{"listener1": listener},
+			Routes:    map[string][]httpserver.Route{"listener1": {*route}},
+		})
+		require.NoError(t, runner.CommitConfig(ctx))
+	}
+	reloadAction := func() transaction.ReloadAction {
+		t.Helper()
+		results := runner.LastReloadResults()
+		require.Len(t, results, 1)
+		return results[0].Action
+	}
+
+	t.Run("read header timeout", func(t *testing.T) {
+		// headersClosed reports whether the server closes a connection whose
+		// request headers are never finished within a second
+		headersClosed := func(t *testing.T) bool {
+			t.Helper()
+			conn, err := net.Dial("tcp", addr)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, conn.Close()) }()
+			_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: test\r\n"))
+			require.NoError(t, err)
+
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+			_, err = conn.Read(make([]byte, 1))
+			var netErr net.Error
+			return !errors.As(err, &netErr) || !netErr.Timeout()
+		}
+
+		commit("tx-1", cfg.ListenerConfig{ReadHeaderTimeout: time.Minute})
+		assert.False(t, headersClosed(t))
+
+		commit("tx-2", cfg.ListenerConfig{ReadHeaderTimeout: 100 * time.Millisecond})
+		assert.Equal(t, transaction.ReloadRestarted, reloadAction())
+		assert.True(t, headersClosed(t), "the new header timeout must be enforced")
+
+		commit("tx-3", cfg.ListenerConfig{ReadHeaderTimeout: 100 * time.Millisecond})
+		assert.Equal(t, transaction.ReloadSkipped, reloadAction())
+	})
+
+	t.Run("h2c", func(t *testing.T) {
+		// getProto makes a request with prior knowledge of HTTP/2 and returns
+		// the protocol the server saw
+		getProto := func(t *testing.T) (string, error) {
+			t.Helper()
+			transport := &http.Transport{Protocols: new(http.Protocols)}
+			transport.Protocols.SetUnencryptedHTTP2(true)
+			defer transport.CloseIdleConnections()
+
+			resp, err := (&http.Client{Transport: transport, Timeout: 2 * time.Second}).Get("http://" + addr + "/")
+			if err != nil {
+				return "", err
+			}
+			defer func() { assert.NoError(t, resp.Body.Close()) }()
+			body, err := io.ReadAll(resp.Body)
+			return string(body), err
+		}
+
+		commit("tx-4", cfg.ListenerConfig{})
+		_, err := getProto(t)
+		require.Error(t, err, "a plain listener rejects HTTP/2 without TLS")
+
+		commit("tx-5", cfg.ListenerConfig{H2C: true})
+		assert.Equal(t, transaction.ReloadRestarted, reloadAction())
+		proto, err := getProto(t)
+		require.NoError(t, err)
+		assert.Equal(t, "HTTP/2.0", proto)
+
+		commit("tx-6", cfg.ListenerConfig{})
+		assert.Equal(t, transaction.ReloadRestarted, reloadAction())
+		_, err = getProto(t)
+		require.Error(t, err, "turning h2c off by reload must be applied")
+	})
+}
+
+func TestRunner_DryRunConfig(t *testing.T) {
+	t.Run("leaves the pending config untouched", func(t *testing.T) {
+		runner, err := NewRunner()
+		require.NoError(t, err)
+
+		tx := createMockTransaction(t)
+		require.NoError(t, runner.DryRunConfig(t.Context(), tx))
+		assert.False(t, runner.configMgr.HasPendingChanges())
+	})
+
+	t.Run("nil transaction", func(t *testing.T) {
+		runner, err := NewRunner()
+		require.NoError(t, err)
+
+		err = runner.DryRunConfig(t.Context(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "transaction is nil")
+	})
+}
+
+func TestCheckListenerConfigs(t *testing.T) {
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	newConfig := func(addr string, paths ...string) *httpserver.Config {
+		t.Helper()
+		routes := make(httpserver.Routes, 0, len(paths))
+		for _, path := range paths {
+			route, err := httpserver.NewRouteFromHandlerFunc(path, path, testHandler)
+			require.NoError(t, err)
+			routes = append(routes, *route)
+		}
+		return &httpserver.Config{ListenAddr: addr, Routes: routes}
+	}
+
+	// Hold a port, as another process would
+	held, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = held.Close() })
+	heldAddr := held.Addr().String()
+
+	t.Run("free address", func(t *testing.T) {
+		configs := map[string]*httpserver.Config{
+			"listener1": newConfig(testutil.GetRandomListeningPort(t), "/one"),
+		}
+		require.NoError(t, checkListenerConfigs(configs, nil))
+
+		// The address is released after the check
+		ln, err := net.Listen("tcp", configs["listener1"].ListenAddr)
+		require.NoError(t, err)
+		require.NoError(t, ln.Close())
+	})
+
+	t.Run("address in use", func(t *testing.T) {
+		configs := map[string]*httpserver.Config{
+			"listener1": newConfig(heldAddr, "/one"),
+		}
+		err := checkListenerConfigs(configs, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "listener listener1: cannot bind "+heldAddr)
+	})
+
+	t.Run("address served by a running listener", func(t *testing.T) {
+		configs := map[string]*httpserver.Config{
+			"listener1": newConfig(heldAddr, "/one", "/two"),
+		}
+		applied := map[string]*httpserver.Config{
+			"listener1": newConfig(heldAddr, "/one"),
+		}
+		require.NoError(t, checkListenerConfigs(configs, applied))
+	})
+
+	t.Run("invalid routes", func(t *testing.T) {
+		configs := map[string]*httpserver.Config{
+			"listener1": newConfig(testutil.GetRandomListeningPort(t), "/one", "/one"),
+			"listener2": newConfig(heldAddr, "/two"),
+		}
+		err := checkListenerConfigs(configs, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `listener listener1: duplicate route path: "/one"`)
+		assert.Contains(t, err.Error(), "listener listener2: cannot bind")
+	})
+}
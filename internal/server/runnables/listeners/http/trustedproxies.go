@@ -0,0 +1,45 @@
+package http
+
+import (
+	"maps"
+	"net/netip"
+	"sync"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+)
+
+// trustedProxies holds the trusted proxies of each listener. Like bodyLimits
+// they are read on each request, so changing them doesn't restart a server.
+type trustedProxies struct {
+	mu      sync.RWMutex
+	proxies map[string][]netip.Prefix
+}
+
+// set replaces the trusted proxies of all listeners
+func (t *trustedProxies) set(proxies map[string][]netip.Prefix) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.proxies = maps.Clone(proxies)
+}
+
+// get returns the trusted proxies of a listener
+func (t *trustedProxies) get(listenerID string) []netip.Prefix {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.proxies[listenerID]
+}
+
+// Middleware returns a middleware storing the listener's trusted proxies in
+// the request context, where apps.ClientIP reads them to decide whether the
+// forwarding headers of the immediate peer are honored.
+func (t *trustedProxies) Middleware(listenerID string) httpserver.HandlerFunc {
+	return func(rp *httpserver.RequestProcessor) {
+		proxies := t.get(listenerID)
+		if len(proxies) > 0 {
+			r := rp.Request()
+			rp.SetRequest(r.WithContext(apps.WithTrustedProxies(r.Context(), proxies)))
+		}
+		rp.Next()
+	}
+}
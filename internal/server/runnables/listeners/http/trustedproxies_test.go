@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/apps"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/listeners/http/cfg"
+	"github.com/robbyt/go-supervisor/runnables/httpserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustedProxies_Middleware(t *testing.T) {
+	t.Parallel()
+
+	proxies := &trustedProxies{}
+	proxies.set(map[string][]netip.Prefix{
+		"proxied": {netip.MustParsePrefix("10.0.0.0/8")},
+	})
+
+	// serve returns the client IP a route on the listener resolves
+	serve := func(listenerID, remoteAddr string) string {
+		route, err := httpserver.NewRouteFromHandlerFunc("test", "/", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(apps.ClientIP(r, true)))
+		}, proxies.Middleware(listenerID))
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		w := httptest.NewRecorder()
+		route.ServeHTTP(w, req)
+		return w.Body.String()
+	}
+
+	assert.Equal(t, "203.0.113.7", serve("proxied", "10.0.0.1:5000"))
+	assert.Equal(t, "192.0.2.1", serve("proxied", "192.0.2.1:5000"), "untrusted peers can't spoof the client IP")
+	assert.Equal(t, "10.0.0.1", serve("direct", "10.0.0.1:5000"), "listeners without trusted proxies ignore the header")
+
+	proxies.set(nil)
+	assert.Equal(t, "10.0.0.1", serve("proxied", "10.0.0.1:5000"))
+}
+
+func TestListenerTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, listenerTrustedProxies(nil))
+
+	prefixes := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	adapter := &cfg.Adapter{
+		Listeners: map[string]cfg.ListenerConfig{
+			"proxied": {ID: "proxied", TrustedProxies: prefixes},
+			"direct":  {ID: "direct"},
+		},
+	}
+	assert.Equal(t, map[string][]netip.Prefix{"proxied": prefixes}, listenerTrustedProxies(adapter))
+}
@@ -0,0 +1,23 @@
+package tcp
+
+import "log/slog"
+
+type Option func(*Runner)
+
+// WithLogHandler sets a custom slog handler for the Runner instance.
+func WithLogHandler(handler slog.Handler) Option {
+	return func(r *Runner) {
+		if handler != nil {
+			r.logger = slog.New(handler).WithGroup("tcp.Runner")
+		}
+	}
+}
+
+// WithLogger sets a logger for the Runner instance.
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Runner) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
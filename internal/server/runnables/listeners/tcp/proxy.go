@@ -0,0 +1,219 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// acceptRetryDelay is how long the accept loop waits after a failed Accept
+// before trying again
+const acceptRetryDelay = 50 * time.Millisecond
+
+// proxy accepts connections on one listener address and copies bytes in both
+// directions between each connection and a new connection to the upstream
+type proxy struct {
+	cfg    ListenerConfig
+	logger *slog.Logger
+
+	listener net.Listener
+
+	// ctx is canceled to abort upstream dials when the drain times out
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	// conns holds both sides of every proxied connection, so a drain that
+	// times out can close them
+	connsMu sync.Mutex
+	conns   map[net.Conn]struct{}
+
+	active   atomic.Int64
+	accepted atomic.Uint64
+
+	// wg tracks the accept loop and every connection handler
+	wg sync.WaitGroup
+}
+
+// startProxy binds the listener address and starts accepting connections
+func startProxy(cfg ListenerConfig, logger *slog.Logger) (*proxy, error) {
+	ln, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", cfg.Address, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &proxy{
+		cfg:      cfg,
+		logger:   logger.With("listener_id", cfg.ID, "upstream", cfg.Upstream),
+		listener: ln,
+		ctx:      ctx,
+		cancel:   cancel,
+		conns:    make(map[net.Conn]struct{}),
+	}
+
+	p.wg.Add(1)
+	go p.serve()
+	return p, nil
+}
+
+// Addr returns the address the proxy is listening on
+func (p *proxy) Addr() net.Addr {
+	return p.listener.Addr()
+}
+
+// ActiveConnections returns the number of connections currently being proxied
+func (p *proxy) ActiveConnections() int64 {
+	return p.active.Load()
+}
+
+// AcceptedConnections returns the number of connections accepted since the
+// proxy started
+func (p *proxy) AcceptedConnections() uint64 {
+	return p.accepted.Load()
+}
+
+// serve accepts connections until the listener is closed
+func (p *proxy) serve() {
+	defer p.wg.Done()
+
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			p.logger.Warn("Failed to accept connection", "error", err)
+			time.Sleep(acceptRetryDelay)
+			continue
+		}
+
+		p.accepted.Add(1)
+		p.wg.Add(1)
+		go p.handle(conn)
+	}
+}
+
+// handle proxies one client connection to the upstream until both directions
+// are closed
+func (p *proxy) handle(client net.Conn) {
+	defer p.wg.Done()
+
+	p.active.Add(1)
+	defer p.active.Add(-1)
+
+	p.track(client)
+	defer p.untrack(client)
+
+	logger := p.logger.With("remote_addr", client.RemoteAddr().String())
+
+	dialer := net.Dialer{Timeout: p.cfg.DialTimeout}
+	upstream, err := dialer.DialContext(p.ctx, "tcp", p.cfg.Upstream)
+	if err != nil {
+		logger.Warn("Failed to connect to upstream", "error", err)
+		return
+	}
+	p.track(upstream)
+	defer p.untrack(upstream)
+
+	logger.Debug("Proxying connection")
+
+	done := make(chan struct{}, 2)
+	go func() {
+		pipe(upstream, client, logger)
+		done <- struct{}{}
+	}()
+	go func() {
+		pipe(client, upstream, logger)
+		done <- struct{}{}
+	}()
+	<-done
+	<-done
+
+	logger.Debug("Connection closed")
+}
+
+// pipe copies src to dst, then closes the write side of dst so the peer sees
+// EOF while the other direction keeps flowing
+func pipe(dst, src net.Conn, logger *slog.Logger) {
+	if _, err := io.Copy(dst, src); err != nil && !errors.Is(err, net.ErrClosed) {
+		logger.Debug("Proxy copy ended with error", "error", err)
+	}
+
+	if cw, ok := dst.(interface{ CloseWrite() error }); ok {
+		if err := cw.CloseWrite(); err == nil {
+			return
+		}
+	}
+	if err := dst.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		logger.Debug("Failed to close connection", "error", err)
+	}
+}
+
+func (p *proxy) track(conn net.Conn) {
+	p.connsMu.Lock()
+	defer p.connsMu.Unlock()
+	p.conns[conn] = struct{}{}
+
+	// A connection tracked after a timed out drain swept conns is closed here
+	if p.ctx.Err() != nil {
+		_ = conn.Close()
+	}
+}
+
+func (p *proxy) untrack(conn net.Conn) {
+	p.connsMu.Lock()
+	delete(p.conns, conn)
+	p.connsMu.Unlock()
+
+	if err := conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		p.logger.Debug("Failed to close connection", "error", err)
+	}
+}
+
+// closeListener stops accepting new connections, releasing the address.
+// Connections already accepted keep running.
+func (p *proxy) closeListener() {
+	if err := p.listener.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+		p.logger.Warn("Failed to close listener", "error", err)
+	}
+}
+
+// drain closes the listener and waits for open connections to finish. After
+// the drain timeout any remaining connections are closed. It returns the
+// number of connections that were still open when the drain timed out.
+func (p *proxy) drain() int64 {
+	p.closeListener()
+
+	finished := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(finished)
+	}()
+
+	timer := time.NewTimer(p.cfg.DrainTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-finished:
+		p.cancel()
+		return 0
+	case <-timer.C:
+	}
+
+	remaining := p.active.Load()
+	p.cancel()
+	p.connsMu.Lock()
+	for conn := range p.conns {
+		_ = conn.Close()
+	}
+	p.connsMu.Unlock()
+
+	<-finished
+	return remaining
+}
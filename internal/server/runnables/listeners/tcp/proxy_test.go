@@ -0,0 +1,172 @@
+package tcp
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startUpstream starts a TCP server that answers each line it reads with the
+// line prefixed by name, and reports the number of bytes it read once the
+// client closes its write side
+func startUpstream(t *testing.T, name string) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, ln.Close()) })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				reader := bufio.NewReader(conn)
+				total := 0
+				for {
+					line, err := reader.ReadString('\n')
+					total += len(line)
+					if err != nil {
+						_, _ = fmt.Fprintf(conn, "%s read %d bytes\n", name, total)
+						return
+					}
+					_, _ = fmt.Fprintf(conn, "%s: %s", name, line)
+				}
+			}()
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func newTestProxy(t *testing.T, upstream string, drainTimeout time.Duration) *proxy {
+	t.Helper()
+	p, err := startProxy(ListenerConfig{
+		ID:           "tcp1",
+		Address:      testutil.GetRandomListeningPort(t),
+		Upstream:     upstream,
+		DialTimeout:  time.Second,
+		DrainTimeout: drainTimeout,
+	}, slog.Default())
+	require.NoError(t, err)
+	return p
+}
+
+// roundTrip writes a line to conn and returns the line read back
+func roundTrip(t *testing.T, conn net.Conn, reader *bufio.Reader, line string) string {
+	t.Helper()
+	_, err := fmt.Fprintln(conn, line)
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	reply, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	return reply
+}
+
+func TestProxy_CopiesBothDirections(t *testing.T) {
+	p := newTestProxy(t, startUpstream(t, "up"), time.Second)
+	defer p.drain()
+
+	conn, err := net.Dial("tcp", p.Addr().String())
+	require.NoError(t, err)
+	reader := bufio.NewReader(conn)
+
+	assert.Equal(t, "up: hello\n", roundTrip(t, conn, reader, "hello"))
+	assert.Equal(t, "up: world\n", roundTrip(t, conn, reader, "world"))
+	assert.Equal(t, int64(1), p.ActiveConnections())
+
+	// Closing the write side is passed on to the upstream, which can still
+	// send its reply
+	require.NoError(t, conn.(*net.TCPConn).CloseWrite())
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "up read 12 bytes\n", string(rest))
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool { return p.ActiveConnections() == 0 },
+		time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(1), p.AcceptedConnections())
+}
+
+func TestProxy_UpstreamUnavailable(t *testing.T) {
+	// Reserve an address with nothing listening on it
+	upstream := testutil.GetRandomListeningPort(t)
+	p := newTestProxy(t, upstream, time.Second)
+	defer p.drain()
+
+	conn, err := net.Dial("tcp", p.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	// The client connection is closed once the dial fails
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestProxy_Drain(t *testing.T) {
+	t.Run("waits for open connections", func(t *testing.T) {
+		p := newTestProxy(t, startUpstream(t, "up"), 5*time.Second)
+
+		conn, err := net.Dial("tcp", p.Addr().String())
+		require.NoError(t, err)
+		reader := bufio.NewReader(conn)
+		assert.Equal(t, "up: before\n", roundTrip(t, conn, reader, "before"))
+
+		drained := make(chan int64, 1)
+		go func() { drained <- p.drain() }()
+
+		// New connections are refused while the open one keeps working
+		assert.Eventually(t, func() bool {
+			c, err := net.Dial("tcp", p.Addr().String())
+			if err != nil {
+				return true
+			}
+			_ = c.Close()
+			return false
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, "up: during\n", roundTrip(t, conn, reader, "during"))
+
+		select {
+		case <-drained:
+			t.Fatal("drain returned while a connection was open")
+		default:
+		}
+
+		require.NoError(t, conn.Close())
+		select {
+		case remaining := <-drained:
+			assert.Zero(t, remaining)
+		case <-time.After(2 * time.Second):
+			t.Fatal("drain did not return after the connection closed")
+		}
+	})
+
+	t.Run("closes connections after the timeout", func(t *testing.T) {
+		p := newTestProxy(t, startUpstream(t, "up"), 50*time.Millisecond)
+
+		conn, err := net.Dial("tcp", p.Addr().String())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		reader := bufio.NewReader(conn)
+		assert.Equal(t, "up: hello\n", roundTrip(t, conn, reader, "hello"))
+
+		assert.Equal(t, int64(1), p.drain())
+		assert.Zero(t, p.ActiveConnections())
+
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		_, err = reader.ReadString('\n')
+		require.Error(t, err)
+	})
+}
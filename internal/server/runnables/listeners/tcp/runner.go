@@ -0,0 +1,173 @@
+// Package tcp provides the raw TCP listener implementation with SagaParticipant
+// support. Each TCP listener proxies the byte stream of every accepted
+// connection to its configured upstream address.
+package tcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/orchestrator"
+	"github.com/robbyt/go-supervisor/supervisor"
+)
+
+// Runner manages the TCP proxy listeners with saga participant support
+type Runner struct {
+	logger *slog.Logger
+	fsm    finitestate.Machine
+
+	cancel context.CancelFunc
+	mutex  sync.RWMutex
+
+	// pending holds the listener configs staged by StageConfig, nil when
+	// nothing is staged
+	pending map[string]ListenerConfig
+
+	// proxies holds the running proxy of each listener, and lastReload what
+	// the most recent CommitConfig did with each listener
+	proxies    map[string]*proxy
+	lastReload []transaction.ReloadResult
+
+	// drains tracks proxies that stopped accepting connections but are
+	// still finishing open connections
+	drains sync.WaitGroup
+}
+
+// Interface guards
+var (
+	_ supervisor.Runnable          = (*Runner)(nil)
+	_ supervisor.Stateable         = (*Runner)(nil)
+	_ supervisor.Readiness         = (*Runner)(nil)
+	_ orchestrator.SagaParticipant = (*Runner)(nil)
+	_ orchestrator.ReloadReporter  = (*Runner)(nil)
+	_ orchestrator.DryRunner       = (*Runner)(nil)
+)
+
+// NewRunner creates a new TCP runner
+func NewRunner(options ...Option) (*Runner, error) {
+	r := &Runner{
+		logger:  slog.Default().WithGroup("tcp.Runner"),
+		proxies: make(map[string]*proxy),
+	}
+
+	// Apply functional options
+	for _, option := range options {
+		option(r)
+	}
+
+	fsm, err := finitestate.New(r.logger.WithGroup("fsm").Handler())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state machine: %w", err)
+	}
+	r.fsm = fsm
+
+	return r, nil
+}
+
+// String returns a unique identifier for this runner
+func (r *Runner) String() string {
+	return "TCPRunner"
+}
+
+// Run starts the TCP runner. Listeners are started and stopped by CommitConfig;
+// Run blocks until the context is canceled, then drains every listener.
+func (r *Runner) Run(ctx context.Context) error {
+	r.logger.Debug("Starting TCP runner")
+
+	if err := r.fsm.Transition(finitestate.StatusBooting); err != nil {
+		return fmt.Errorf("failed to transition to booting state: %w", err)
+	}
+
+	ctx, ctxCancel := context.WithCancel(ctx)
+	defer ctxCancel()
+	r.mutex.Lock()
+	r.cancel = ctxCancel
+	r.mutex.Unlock()
+
+	if err := r.fsm.Transition(finitestate.StatusRunning); err != nil {
+		return fmt.Errorf("failed to transition to running state: %w", err)
+	}
+
+	// block here until the run context is canceled
+	<-ctx.Done()
+
+	return r.shutdown()
+}
+
+// Stop stops the TCP runner
+func (r *Runner) Stop() {
+	r.logger.Debug("Stopping TCP runner")
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// ActiveConnections returns the number of connections a listener is
+// currently proxying, 0 when the listener isn't running
+func (r *Runner) ActiveConnections(listenerID string) int64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	p, ok := r.proxies[listenerID]
+	if !ok {
+		return 0
+	}
+	return p.ActiveConnections()
+}
+
+// shutdown stops every listener and waits for their connections to drain
+func (r *Runner) shutdown() error {
+	logger := r.logger.WithGroup("shutdown")
+	logger.Debug("Shutting down TCP runner")
+
+	if err := r.fsm.Transition(finitestate.StatusStopping); err != nil {
+		logger.Error("Failed to transition to stopping state", "error", err)
+		// Continue with shutdown despite the state transition error
+	}
+
+	r.mutex.Lock()
+	r.pending = nil
+	for id, p := range r.proxies {
+		r.drainProxy(id, p)
+	}
+	r.proxies = make(map[string]*proxy)
+	r.mutex.Unlock()
+
+	r.drains.Wait()
+
+	if err := r.fsm.Transition(finitestate.StatusStopped); err != nil {
+		return fmt.Errorf("failed to transition to stopped state: %w", err)
+	}
+
+	logger.Debug("TCP runner shutdown complete")
+	return nil
+}
+
+// drainProxy releases the proxy's address right away and drains its open
+// connections in the background. Must be called with the mutex held.
+func (r *Runner) drainProxy(listenerID string, p *proxy) {
+	p.closeListener()
+
+	logger := r.logger.With("id", listenerID, "addr", p.cfg.Address)
+	logger.Debug("Draining TCP listener",
+		"active_connections", p.ActiveConnections(),
+		"drain_timeout", p.cfg.DrainTimeout)
+
+	r.drains.Add(1)
+	go func() {
+		defer r.drains.Done()
+		if remaining := p.drain(); remaining > 0 {
+			logger.Warn("Closed TCP connections still open after drain timeout",
+				"connections", remaining)
+		}
+		logger.Debug("TCP listener drained",
+			"accepted_connections", p.AcceptedConnections())
+	}()
+}
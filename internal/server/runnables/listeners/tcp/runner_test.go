@@ -0,0 +1,80 @@
+package tcp
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRunner(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		runner, err := NewRunner()
+		require.NoError(t, err)
+		assert.Equal(t, "TCPRunner", runner.String())
+		assert.Equal(t, finitestate.StatusNew, runner.GetState())
+		assert.False(t, runner.IsReady())
+	})
+
+	t.Run("with custom logger", func(t *testing.T) {
+		runner, err := NewRunner(WithLogger(slog.Default().With("test", "custom")))
+		require.NoError(t, err)
+		assert.NotNil(t, runner)
+	})
+
+	t.Run("with log handler", func(t *testing.T) {
+		runner, err := NewRunner(WithLogHandler(slog.Default().Handler()))
+		require.NoError(t, err)
+		assert.NotNil(t, runner)
+	})
+}
+
+func TestRunner_RunAndStop(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	runErr := make(chan error, 1)
+	go func() { runErr <- runner.Run(ctx) }()
+	assert.Eventually(t, runner.IsReady, time.Second, 10*time.Millisecond)
+
+	address := testutil.GetRandomListeningPort(t)
+	applyTransaction(t, runner, createTransaction(t,
+		tcpListener("tcp1", address, startUpstream(t, "up")),
+	))
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	reader := bufio.NewReader(conn)
+	assert.Equal(t, "up: hello\n", roundTrip(t, conn, reader, "hello"))
+
+	// Stopping waits for the open connection to drain
+	runner.Stop()
+	select {
+	case <-runErr:
+		t.Fatal("runner stopped while a connection was open")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.Equal(t, "up: still open\n", roundTrip(t, conn, reader, "still open"))
+	require.NoError(t, conn.Close())
+
+	select {
+	case err := <-runErr:
+		require.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("runner did not stop within timeout")
+	}
+	assert.Equal(t, finitestate.StatusStopped, runner.GetState())
+
+	_, err = net.Dial("tcp", address)
+	require.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package tcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+)
+
+// ListenerConfig is the runtime configuration of one TCP listener. Two
+// listeners with equal configs are left running across a reload.
+type ListenerConfig struct {
+	ID           string
+	Address      string
+	Upstream     string
+	DialTimeout  time.Duration
+	DrainTimeout time.Duration
+}
+
+// listenerConfigs extracts the TCP listener configs from a domain config
+func listenerConfigs(cfg *config.Config) map[string]ListenerConfig {
+	configs := make(map[string]ListenerConfig)
+	if cfg == nil {
+		return configs
+	}
+
+	for l := range cfg.Listeners.GetTCPListeners() {
+		opts, ok := l.GetTCPOptions()
+		if !ok {
+			continue
+		}
+		configs[l.ID] = ListenerConfig{
+			ID:           l.ID,
+			Address:      l.Address,
+			Upstream:     opts.Upstream,
+			DialTimeout:  opts.GetDialTimeout(),
+			DrainTimeout: opts.GetDrainTimeout(),
+		}
+	}
+	return configs
+}
+
+// StageConfig implements SagaParticipant.StageConfig
+func (r *Runner) StageConfig(ctx context.Context, tx *transaction.ConfigTransaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	logger := r.logger.WithGroup("StageConfig").With("tx_id", tx.GetTransactionID())
+	logger.Debug("Executing TCP configuration")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.pending = listenerConfigs(tx.GetConfig())
+	logger.Debug("TCP configuration prepared successfully", "listeners", len(r.pending))
+
+	return nil
+}
+
+// DryRunConfig implements orchestrator.DryRunner. It checks that the address
+// of each TCP listener in tx can be bound, skipping addresses already served
+// by a running listener, which releases them when the configuration is
+// committed. The pending and running configuration are left untouched.
+func (r *Runner) DryRunConfig(ctx context.Context, tx *transaction.ConfigTransaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction is nil")
+	}
+	logger := r.logger.WithGroup("DryRunConfig").With("tx_id", tx.GetTransactionID())
+	logger.Debug("Checking TCP configuration")
+
+	configs := listenerConfigs(tx.GetConfig())
+
+	r.mutex.RLock()
+	served := make(map[string]bool, len(r.proxies))
+	for _, p := range r.proxies {
+		served[p.cfg.Address] = true
+	}
+	r.mutex.RUnlock()
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(configs)) {
+		c := configs[id]
+		if served[c.Address] {
+			continue
+		}
+
+		ln, err := net.Listen("tcp", c.Address)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("listener %s: cannot bind %s: %w", id, c.Address, err))
+			continue
+		}
+		if err := ln.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("listener %s: failed to release %s: %w", id, c.Address, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CompensateConfig implements SagaParticipant.CompensateConfig
+func (r *Runner) CompensateConfig(ctx context.Context, failedTXID string) error {
+	logger := r.logger.WithGroup("CompensateConfig").With("tx_id", failedTXID)
+	logger.Debug("Compensating TCP configuration")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Discard pending configuration
+	r.pending = nil
+	logger.Debug("TCP configuration compensated successfully")
+	return nil
+}
+
+// CommitConfig applies the pending configuration. Listeners whose config is
+// unchanged keep running with their open connections. Changed and removed
+// listeners stop accepting right away and drain their open connections in
+// the background, so a changed listener can bind its address again. A
+// changed listener whose new config can't be bound is started again with its
+// previous config, which is what the reload results report.
+// This should only be called by the saga orchestrator during TriggerReload
+func (r *Runner) CommitConfig(ctx context.Context) error {
+	logger := r.logger.WithGroup("CommitConfig")
+	logger.Debug("Applying pending TCP configuration")
+	defer logger.Debug("CommitConfig completed")
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	applied := make(map[string]ListenerConfig, len(r.proxies))
+	for id, p := range r.proxies {
+		applied[id] = p.cfg
+	}
+
+	if r.pending == nil {
+		logger.Debug("No pending TCP configuration to apply")
+		r.lastReload = diffListenerConfigs(applied, applied)
+		return nil
+	}
+	next := r.pending
+	r.pending = nil
+
+	// Release the addresses of changed and removed listeners first. Their
+	// open connections are drained once the new listeners are started.
+	replaced := make(map[string]*proxy)
+	for id, p := range r.proxies {
+		if c, ok := next[id]; ok && c == p.cfg {
+			continue
+		}
+		p.closeListener()
+		replaced[id] = p
+		delete(r.proxies, id)
+	}
+
+	var errs []error
+	for _, id := range slices.Sorted(maps.Keys(next)) {
+		if _, running := r.proxies[id]; running {
+			continue
+		}
+		c := next[id]
+		p, err := startProxy(c, r.logger)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("TCP listener %s: %w", id, err))
+			restored := r.restoreProxy(id, replaced[id])
+			if restored == nil {
+				delete(next, id)
+				continue
+			}
+			r.proxies[id] = restored
+			next[id] = restored.cfg
+			continue
+		}
+		r.proxies[id] = p
+		r.logger.Info("TCP listener is ready", "id", id, "addr", p.Addr().String(), "upstream", c.Upstream)
+	}
+
+	for id, p := range replaced {
+		r.drainProxy(id, p)
+	}
+
+	r.lastReload = diffListenerConfigs(applied, next)
+	for _, result := range r.lastReload {
+		r.logger.Info("TCP listener reload result",
+			"id", result.Component, "action", result.Action)
+	}
+
+	return errors.Join(errs...)
+}
+
+// restoreProxy starts a listener again with the config of the proxy it was
+// replacing, after the new config failed to start. It returns nil when the
+// listener is new or its previous config can't be started either.
+func (r *Runner) restoreProxy(listenerID string, old *proxy) *proxy {
+	if old == nil {
+		return nil
+	}
+	p, err := startProxy(old.cfg, r.logger)
+	if err != nil {
+		r.logger.Error("Failed to restore TCP listener with its previous config",
+			"id", listenerID, "addr", old.cfg.Address, "error", err)
+		return nil
+	}
+	r.logger.Warn("Restored TCP listener with its previous config",
+		"id", listenerID, "addr", p.Addr().String(), "upstream", old.cfg.Upstream)
+	return p
+}
+
+// LastReloadResults implements orchestrator.ReloadReporter, reporting for each
+// listener whether the most recent CommitConfig left it running (skipped),
+// restarted, started, or stopped it.
+func (r *Runner) LastReloadResults() []transaction.ReloadResult {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return slices.Clone(r.lastReload)
+}
+
+// diffListenerConfigs compares the previously running listener configs with
+// the new ones. Results are sorted by listener ID.
+func diffListenerConfigs(previous, next map[string]ListenerConfig) []transaction.ReloadResult {
+	var results []transaction.ReloadResult
+
+	for id, c := range next {
+		prev, exists := previous[id]
+		switch {
+		case !exists:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadStarted})
+		case prev == c:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadSkipped})
+		default:
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadRestarted})
+		}
+	}
+
+	for id := range previous {
+		if _, exists := next[id]; !exists {
+			results = append(results, transaction.ReloadResult{Component: id, Action: transaction.ReloadStopped})
+		}
+	}
+
+	slices.SortFunc(results, func(a, b transaction.ReloadResult) int {
+		return strings.Compare(a.Component, b.Component)
+	})
+	return results
+}
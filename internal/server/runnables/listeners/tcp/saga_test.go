@@ -0,0 +1,256 @@
+package tcp
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// tcpListener returns a proto TCP listener proxying address to upstream
+func tcpListener(id, address, upstream string) *pb.Listener {
+	return &pb.Listener{
+		Id:      proto.String(id),
+		Address: proto.String(address),
+		Type:    pb.Listener_TYPE_TCP.Enum(),
+		ProtocolOptions: &pb.Listener_Tcp{
+			Tcp: &pb.TcpListenerOptions{
+				Upstream:     proto.String(upstream),
+				DrainTimeout: durationpb.New(5 * time.Second),
+			},
+		},
+	}
+}
+
+// createTransaction creates a validated transaction for the listeners
+func createTransaction(t *testing.T, listeners ...*pb.Listener) *transaction.ConfigTransaction {
+	t.Helper()
+	cfg, err := config.NewFromProto(&pb.ServerConfig{
+		Version:   proto.String(config.VersionLatest),
+		Listeners: listeners,
+	})
+	require.NoError(t, err)
+
+	tx, err := transaction.FromTest(t.Name(), cfg, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+	return tx
+}
+
+// applyTransaction stages and commits tx, returning the reload results
+func applyTransaction(t *testing.T, runner *Runner, tx *transaction.ConfigTransaction) []transaction.ReloadResult {
+	t.Helper()
+	require.NoError(t, runner.StageConfig(t.Context(), tx))
+	require.NoError(t, runner.CommitConfig(t.Context()))
+	return runner.LastReloadResults()
+}
+
+func TestListenerConfigs(t *testing.T) {
+	tx := createTransaction(t,
+		tcpListener("tcp1", ":5432", "10.0.0.5:5432"),
+		&pb.Listener{
+			Id:      proto.String("http1"),
+			Address: proto.String(":8080"),
+			Type:    pb.Listener_TYPE_HTTP.Enum(),
+		},
+	)
+
+	configs := listenerConfigs(tx.GetConfig())
+	require.Len(t, configs, 1)
+	assert.Equal(t, ListenerConfig{
+		ID:           "tcp1",
+		Address:      ":5432",
+		Upstream:     "10.0.0.5:5432",
+		DialTimeout:  5 * time.Second,
+		DrainTimeout: 5 * time.Second,
+	}, configs["tcp1"])
+
+	assert.Empty(t, listenerConfigs(nil))
+}
+
+func TestRunner_StageConfig_NilTransaction(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	require.Error(t, runner.StageConfig(t.Context(), nil))
+	require.Error(t, runner.DryRunConfig(t.Context(), nil))
+}
+
+func TestRunner_CompensateConfig(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	defer runner.shutdown()
+
+	address := testutil.GetRandomListeningPort(t)
+	tx := createTransaction(t, tcpListener("tcp1", address, startUpstream(t, "up")))
+	require.NoError(t, runner.StageConfig(t.Context(), tx))
+	require.NoError(t, runner.CompensateConfig(t.Context(), tx.GetTransactionID()))
+
+	// Nothing is pending, so the commit starts no listener
+	require.NoError(t, runner.CommitConfig(t.Context()))
+	assert.Empty(t, runner.LastReloadResults())
+	_, err = net.Dial("tcp", address)
+	require.Error(t, err)
+}
+
+func TestRunner_CommitConfig_Reload(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	defer runner.shutdown()
+
+	upstreamA := startUpstream(t, "a")
+	upstreamB := startUpstream(t, "b")
+	address := testutil.GetRandomListeningPort(t)
+	otherAddress := testutil.GetRandomListeningPort(t)
+
+	// Initial configuration starts both listeners
+	results := applyTransaction(t, runner, createTransaction(t,
+		tcpListener("tcp1", address, upstreamA),
+		tcpListener("tcp2", otherAddress, upstreamA),
+	))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadStarted},
+		{Component: "tcp2", Action: transaction.ReloadStarted},
+	}, results)
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+	assert.Equal(t, "a: first\n", roundTrip(t, conn, reader, "first"))
+	assert.Equal(t, int64(1), runner.ActiveConnections("tcp1"))
+
+	// Reapplying the same configuration leaves the listeners running
+	results = applyTransaction(t, runner, createTransaction(t,
+		tcpListener("tcp1", address, upstreamA),
+		tcpListener("tcp2", otherAddress, upstreamA),
+	))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadSkipped},
+		{Component: "tcp2", Action: transaction.ReloadSkipped},
+	}, results)
+	assert.Equal(t, "a: second\n", roundTrip(t, conn, reader, "second"))
+
+	// Changing the upstream restarts the listener on the same address. The
+	// open connection drains on the old upstream while new connections go to
+	// the new one.
+	results = applyTransaction(t, runner, createTransaction(t,
+		tcpListener("tcp1", address, upstreamB),
+	))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadRestarted},
+		{Component: "tcp2", Action: transaction.ReloadStopped},
+	}, results)
+	assert.Equal(t, "a: draining\n", roundTrip(t, conn, reader, "draining"))
+	assert.Zero(t, runner.ActiveConnections("tcp1"))
+
+	newConn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer func() { _ = newConn.Close() }()
+	assert.Equal(t, "b: new\n", roundTrip(t, newConn, bufio.NewReader(newConn), "new"))
+
+	_, err = net.Dial("tcp", otherAddress)
+	require.Error(t, err)
+
+	// A commit with nothing staged reports every listener as skipped
+	require.NoError(t, runner.CommitConfig(t.Context()))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadSkipped},
+	}, runner.LastReloadResults())
+}
+
+func TestRunner_CommitConfig_BindFailure(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	defer runner.shutdown()
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = occupied.Close() }()
+
+	tx := createTransaction(t, tcpListener("tcp1", occupied.Addr().String(), startUpstream(t, "up")))
+	require.NoError(t, runner.StageConfig(t.Context(), tx))
+	err = runner.CommitConfig(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TCP listener tcp1")
+	assert.Empty(t, runner.LastReloadResults())
+}
+
+func TestRunner_CommitConfig_RestoresOnBindFailure(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	defer runner.shutdown()
+
+	upstreamA := startUpstream(t, "a")
+	address := testutil.GetRandomListeningPort(t)
+	applyTransaction(t, runner, createTransaction(t, tcpListener("tcp1", address, upstreamA)))
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+	assert.Equal(t, "a: first\n", roundTrip(t, conn, reader, "first"))
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = occupied.Close() }()
+
+	// Moving the listener to an address in use fails the reload, and the
+	// listener keeps serving its previous config
+	tx := createTransaction(t, tcpListener("tcp1", occupied.Addr().String(), startUpstream(t, "b")))
+	require.NoError(t, runner.StageConfig(t.Context(), tx))
+	err = runner.CommitConfig(t.Context())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TCP listener tcp1")
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadSkipped},
+	}, runner.LastReloadResults())
+
+	assert.Equal(t, "a: open\n", roundTrip(t, conn, reader, "open"))
+
+	newConn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer func() { _ = newConn.Close() }()
+	assert.Equal(t, "a: restored\n", roundTrip(t, newConn, bufio.NewReader(newConn), "restored"))
+	assert.Equal(t, int64(1), runner.ActiveConnections("tcp1"))
+}
+
+func TestRunner_DryRunConfig(t *testing.T) {
+	runner, err := NewRunner()
+	require.NoError(t, err)
+	defer runner.shutdown()
+
+	upstream := startUpstream(t, "up")
+	address := testutil.GetRandomListeningPort(t)
+	applyTransaction(t, runner, createTransaction(t, tcpListener("tcp1", address, upstream)))
+
+	occupied, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = occupied.Close() }()
+
+	t.Run("address served by a running listener", func(t *testing.T) {
+		tx := createTransaction(t, tcpListener("tcp1", address, upstream))
+		require.NoError(t, runner.DryRunConfig(t.Context(), tx))
+	})
+
+	t.Run("address in use", func(t *testing.T) {
+		tx := createTransaction(t, tcpListener("tcp2", occupied.Addr().String(), upstream))
+		err := runner.DryRunConfig(t.Context(), tx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "listener tcp2: cannot bind")
+	})
+
+	// The dry run leaves nothing staged
+	require.NoError(t, runner.CommitConfig(t.Context()))
+	assert.Equal(t, []transaction.ReloadResult{
+		{Component: "tcp1", Action: transaction.ReloadSkipped},
+	}, runner.LastReloadResults())
+}
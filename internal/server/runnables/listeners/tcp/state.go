@@ -0,0 +1,22 @@
+package tcp
+
+import (
+	"context"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+)
+
+// GetState returns the current state of the runner
+func (r *Runner) GetState() string {
+	return r.fsm.GetState()
+}
+
+// IsReady returns whether the runner is running
+func (r *Runner) IsReady() bool {
+	return r.fsm.GetState() == finitestate.StatusRunning
+}
+
+// GetStateChan returns a channel that emits state changes
+func (r *Runner) GetStateChan(ctx context.Context) <-chan string {
+	return r.fsm.GetStateChan(ctx)
+}
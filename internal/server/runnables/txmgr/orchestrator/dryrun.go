@@ -0,0 +1,74 @@
+package orchestrator
+
+import (
+	"context"
+
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+)
+
+// DryRunner is an optional interface for saga participants that can check,
+// before a configuration is staged, whether they would be able to apply it:
+// for example whether its ports can be bound and its routes constructed. A dry
+// run must not change the participant's pending or running configuration.
+type DryRunner interface {
+	// DryRunConfig runs the participant's pre-flight checks against a
+	// validated transaction, returning an error describing every check that
+	// failed.
+	DryRunConfig(ctx context.Context, tx *transaction.ConfigTransaction) error
+}
+
+// DryRunResult is one participant's readiness to apply a configuration
+type DryRunResult struct {
+	// Participant is the participant's name
+	Participant string
+
+	// Checked is false when the participant doesn't implement DryRunner, in
+	// which case it can only be exercised by applying the configuration
+	Checked bool
+
+	// Error is the reason the participant isn't ready, nil when it is
+	Error error
+}
+
+// Ready reports whether the participant could apply the configuration. A
+// participant that wasn't checked isn't considered not ready.
+func (r DryRunResult) Ready() bool {
+	return r.Error == nil
+}
+
+// DryRun asks each registered participant whether it could apply the
+// validated transaction tx, without staging it. The transaction's state, the
+// participants, and the transaction storage are left untouched. Each check is
+// bounded by the participant timeout. Results are sorted by participant name.
+func (o *SagaOrchestrator) DryRun(
+	ctx context.Context,
+	tx *transaction.ConfigTransaction,
+) ([]DryRunResult, error) {
+	if err := o.validateTransactionState(tx); err != nil {
+		return nil, err
+	}
+	logger := o.logger.With("tx_id", tx.GetTransactionID())
+
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	names := o.getSortedParticipantNames()
+	results := make([]DryRunResult, 0, len(names))
+	for _, name := range names {
+		dryRunner, ok := o.runnables[name].(DryRunner)
+		if !ok {
+			logger.Debug("Participant does not support dry runs", "name", name)
+			results = append(results, DryRunResult{Participant: name})
+			continue
+		}
+
+		err := o.callParticipant(ctx, name, "dry run", func(ctx context.Context) error {
+			return dryRunner.DryRunConfig(ctx, tx)
+		})
+		if err != nil {
+			logger.Info("Participant is not ready for config", "name", name, "error", err)
+		}
+		results = append(results, DryRunResult{Participant: name, Checked: true, Error: err})
+	}
+	return results, nil
+}
@@ -0,0 +1,111 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// dryRunParticipant is a MockParticipant that supports dry runs
+type dryRunParticipant struct {
+	*MockParticipant
+}
+
+func (m *dryRunParticipant) DryRunConfig(ctx context.Context, tx *transaction.ConfigTransaction) error {
+	args := m.Called(ctx, tx)
+	return args.Error(0)
+}
+
+func TestDryRun(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+
+	newValidatedTx := func(t *testing.T) *transaction.ConfigTransaction {
+		t.Helper()
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+		cfg.Version = config.VersionLatest
+		tx, err := transaction.New(transaction.SourceTest, "test", "req-dry-run", cfg, handler)
+		require.NoError(t, err)
+		require.NoError(t, tx.RunValidation())
+		return tx
+	}
+
+	t.Run("reports each participant without staging", func(t *testing.T) {
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+		tx := newValidatedTx(t)
+
+		portErr := errors.New("cannot bind :8080")
+		notReady := &dryRunParticipant{MockParticipant: NewMockParticipant("a-listeners")}
+		notReady.On("DryRunConfig", mock.Anything, tx).Return(portErr)
+		ready := &dryRunParticipant{MockParticipant: NewMockParticipant("b-listeners")}
+		ready.On("DryRunConfig", mock.Anything, tx).Return(nil)
+		plain := NewMockParticipant("c-plain")
+
+		require.NoError(t, orchestrator.RegisterParticipant(notReady))
+		require.NoError(t, orchestrator.RegisterParticipant(ready))
+		require.NoError(t, orchestrator.RegisterParticipant(plain))
+
+		results, err := orchestrator.DryRun(t.Context(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, []DryRunResult{
+			{Participant: "a-listeners", Checked: true, Error: portErr},
+			{Participant: "b-listeners", Checked: true},
+			{Participant: "c-plain"},
+		}, results)
+		assert.False(t, results[0].Ready())
+		assert.True(t, results[1].Ready())
+		assert.True(t, results[2].Ready())
+
+		for _, p := range []*MockParticipant{notReady.MockParticipant, ready.MockParticipant, plain} {
+			p.AssertNotCalled(t, "StageConfig", mock.Anything, mock.Anything)
+		}
+		assert.Equal(t, finitestate.StateValidated, tx.GetState())
+		assert.Empty(t, tx.GetParticipantStates())
+		assert.Nil(t, storage.GetCurrent())
+		assert.Empty(t, storage.GetAll())
+	})
+
+	t.Run("hung participant times out", func(t *testing.T) {
+		orchestrator := NewSagaOrchestrator(txstorage.NewMemoryStorage(), handler,
+			WithParticipantTimeout(50*time.Millisecond))
+		tx := newValidatedTx(t)
+
+		hung := &dryRunParticipant{MockParticipant: NewMockParticipant("hung")}
+		hung.On("DryRunConfig", mock.Anything, tx).
+			Run(func(args mock.Arguments) { <-args.Get(0).(context.Context).Done() }).
+			Return(context.DeadlineExceeded)
+		require.NoError(t, orchestrator.RegisterParticipant(hung))
+
+		results, err := orchestrator.DryRun(t.Context(), tx)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.ErrorIs(t, results[0].Error, ErrParticipantTimeout)
+		assert.Contains(t, results[0].Error.Error(), "hung dry run after 50ms")
+	})
+
+	t.Run("transaction must be validated", func(t *testing.T) {
+		orchestrator := NewSagaOrchestrator(txstorage.NewMemoryStorage(), handler)
+		cfg, err := config.NewFromProto(&pb.ServerConfig{})
+		require.NoError(t, err)
+		tx, err := transaction.New(transaction.SourceTest, "test", "req-dry-run", cfg, handler)
+		require.NoError(t, err)
+
+		_, err = orchestrator.DryRun(t.Context(), tx)
+		require.Error(t, err)
+		_, err = orchestrator.DryRun(t.Context(), nil)
+		require.Error(t, err)
+	})
+}
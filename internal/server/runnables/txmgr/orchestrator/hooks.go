@@ -0,0 +1,188 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts"
+	"github.com/atlanticdynamic/firelynx/internal/config/apps/scripts/evaluators"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/robbyt/go-polyscript/platform/constants"
+	"github.com/robbyt/go-polyscript/platform/data"
+)
+
+// PreApplyParticipant is the name the pre-apply hook is tracked under in a
+// transaction's participants
+const PreApplyParticipant = "pre_apply_hook"
+
+// ErrPreApplyHookFailed is returned when the pre-apply hook script aborts a
+// transaction
+var ErrPreApplyHookFailed = errors.New("pre-apply hook failed")
+
+// runPreApplyHook runs the pre-apply hook of the transaction's config, if it
+// has one, as the transaction's first participant. An error from the script
+// fails the transaction before any other participant has staged it, so there
+// is nothing to roll back. previous is the config being replaced, nil when
+// there is none.
+func (o *SagaOrchestrator) runPreApplyHook(
+	ctx context.Context,
+	tx *transaction.ConfigTransaction,
+	previous *config.Config,
+) error {
+	cfg := tx.GetConfig()
+	if cfg == nil || cfg.PreApply == nil {
+		return nil
+	}
+
+	if err := tx.RegisterParticipant(PreApplyParticipant); err != nil {
+		return fmt.Errorf("failed to register participant %s with transaction: %w", PreApplyParticipant, err)
+	}
+	participantState, err := tx.GetParticipants().GetOrCreate(PreApplyParticipant)
+	if err != nil {
+		return fmt.Errorf("failed to get participant state for %s: %w", PreApplyParticipant, err)
+	}
+	if err := participantState.Execute(); err != nil {
+		return fmt.Errorf("failed to start execution for participant %s: %w", PreApplyParticipant, err)
+	}
+
+	hookData := applyHookData(config.HookPreApply, tx, previous)
+	if err := runApplyHook(ctx, cfg.PreApply, cfg.DefaultTimeout, hookData); err != nil {
+		err = fmt.Errorf("%w: %w", ErrPreApplyHookFailed, err)
+		if markErr := participantState.MarkFailed(err); markErr != nil {
+			o.logger.Error("Failed to mark participant as failed",
+				"name", PreApplyParticipant, "error", markErr, "originalError", err)
+		}
+		if markErr := tx.MarkFailed(ctx, err); markErr != nil {
+			o.logger.Error("Failed to mark transaction as failed",
+				"error", markErr, "originalError", err)
+		}
+		o.compensateParticipants(ctx, tx)
+		return err
+	}
+
+	if err := participantState.MarkSucceeded(); err != nil {
+		o.logger.Error("Failed to mark participant as succeeded", "name", PreApplyParticipant, "error", err)
+	}
+	return nil
+}
+
+// runPostApplyHook runs the post-apply hook of the transaction's config, if it
+// has one, after the transaction was applied. It's best-effort: a failure is
+// logged and doesn't affect the transaction.
+func (o *SagaOrchestrator) runPostApplyHook(
+	ctx context.Context,
+	tx *transaction.ConfigTransaction,
+	previous *config.Config,
+) {
+	cfg := tx.GetConfig()
+	if cfg == nil || cfg.PostApply == nil {
+		return
+	}
+
+	hookData := applyHookData(config.HookPostApply, tx, previous)
+	if err := runApplyHook(ctx, cfg.PostApply, cfg.DefaultTimeout, hookData); err != nil {
+		o.logger.Error("Post-apply hook failed", "id", tx.ID, "error", err)
+		return
+	}
+	o.logger.Debug("Post-apply hook completed", "id", tx.ID)
+}
+
+// markPreApplyHookCompensated marks a pre-apply hook that was being
+// compensated as compensated. The hook doesn't change anything, so there's
+// nothing to revert.
+func (o *SagaOrchestrator) markPreApplyHookCompensated(tx *transaction.ConfigTransaction) {
+	if tx.GetParticipantStates()[PreApplyParticipant] != finitestate.ParticipantCompensating {
+		return
+	}
+	participantState, err := tx.GetParticipants().GetOrCreate(PreApplyParticipant)
+	if err != nil {
+		o.logger.Error("Failed to get participant state", "name", PreApplyParticipant, "error", err)
+		return
+	}
+	if err := participantState.MarkCompensated(); err != nil {
+		o.logger.Error("Failed to mark participant as compensated", "name", PreApplyParticipant, "error", err)
+	}
+}
+
+// runApplyHook evaluates a hook script with hookData. The script's timeout
+// falls back to defaultTimeout like a script app's, and an error it returns
+// is returned.
+func runApplyHook(
+	ctx context.Context,
+	hook *scripts.AppScript,
+	defaultTimeout time.Duration,
+	hookData map[string]any,
+) error {
+	if hook.Evaluator == nil {
+		return scripts.ErrMissingEvaluator
+	}
+	evaluator, err := hook.Evaluator.GetCompiledEvaluator()
+	if err != nil {
+		return fmt.Errorf("failed to get compiled evaluator: %w", err)
+	}
+
+	timeout, _ := evaluators.ResolveTimeout(hook.Evaluator, defaultTimeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	staticData := make(map[string]any)
+	if hook.StaticData != nil {
+		maps.Copy(staticData, hook.StaticData.Data)
+	}
+	hookData["data"] = staticData
+
+	contextProvider := data.NewContextProvider(constants.EvalData)
+	enrichedCtx, err := contextProvider.AddDataToContext(ctx, hookData)
+	if err != nil {
+		return fmt.Errorf("failed to add hook data: %w", err)
+	}
+
+	if _, err := evaluator.Eval(enrichedCtx); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("hook timed out after %s: %w", timeout, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// applyHookData is the data an apply hook script receives: the hook's phase,
+// the transaction, and the components the transaction changes compared to
+// the previous config.
+func applyHookData(
+	phase string,
+	tx *transaction.ConfigTransaction,
+	previous *config.Config,
+) map[string]any {
+	delta := config.Diff(previous, tx.GetConfig())
+	return map[string]any{
+		"phase": phase,
+		"transaction": map[string]any{
+			"id":            tx.ID.String(),
+			"source":        string(tx.Source),
+			"source_detail": tx.SourceDetail,
+		},
+		"changes": map[string]any{
+			"listeners":   changesData(delta.Listeners),
+			"endpoints":   changesData(delta.Endpoints),
+			"apps":        changesData(delta.Apps),
+			"middlewares": changesData(delta.Middlewares),
+		},
+	}
+}
+
+func changesData(changes []config.Change) []any {
+	result := make([]any, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, map[string]any{
+			"id":   c.ID,
+			"kind": string(c.Kind),
+		})
+	}
+	return result
+}
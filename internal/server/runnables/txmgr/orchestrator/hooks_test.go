@@ -0,0 +1,166 @@
+package orchestrator
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	pb "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1"
+	pbApps "github.com/atlanticdynamic/firelynx/gen/settings/v1alpha1/apps/v1"
+	"github.com/atlanticdynamic/firelynx/internal/config"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
+	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
+	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func risorHook(code string) *pbApps.ScriptApp {
+	return &pbApps.ScriptApp{
+		Evaluator: &pbApps.ScriptApp_Risor{
+			Risor: &pbApps.RisorEvaluator{
+				Source: &pbApps.RisorEvaluator_Code{Code: code},
+			},
+		},
+	}
+}
+
+func TestProcessTransaction_ApplyHooks(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+
+	newValidatedTx := func(t *testing.T, pbConfig *pb.ServerConfig) *transaction.ConfigTransaction {
+		t.Helper()
+		pbConfig.Version = proto.String(config.VersionLatest)
+		cfg, err := config.NewFromProto(pbConfig)
+		require.NoError(t, err)
+		tx, err := transaction.New(transaction.SourceTest, "test", "req-hooks", cfg, handler)
+		require.NoError(t, err)
+		require.NoError(t, tx.RunValidation())
+		return tx
+	}
+
+	t.Run("pre-apply hook receives the change summary", func(t *testing.T) {
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, mock.Anything).Return(nil)
+		participant.On("CommitConfig", mock.Anything).Return(nil)
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		tx := newValidatedTx(t, &pb.ServerConfig{
+			Listeners: []*pb.Listener{{
+				Id:      proto.String("http"),
+				Address: proto.String(":8080"),
+				Type:    pb.Listener_TYPE_HTTP.Enum(),
+			}},
+			PreApply: risorHook(`
+let listeners = ctx["changes"]["listeners"]
+if (ctx["phase"] != "pre_apply" || ctx["transaction"]["source"] != "test") {
+	throw error("unexpected hook context")
+}
+if (len(listeners) != 1 || listeners[0]["id"] != "http" || listeners[0]["kind"] != "added") {
+	throw error("unexpected listener changes")
+}
+true`),
+		})
+
+		require.NoError(t, orchestrator.ProcessTransaction(t.Context(), tx))
+		assert.Equal(t, finitestate.StateCompleted, tx.GetState())
+		assert.Equal(t, finitestate.ParticipantSucceeded,
+			tx.GetParticipantStates()[PreApplyParticipant])
+		participant.AssertExpectations(t)
+	})
+
+	t.Run("pre-apply hook error aborts the transaction", func(t *testing.T) {
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		participant := NewMockParticipant("participant1")
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		tx := newValidatedTx(t, &pb.ServerConfig{
+			PreApply: risorHook(`error("change freeze in effect")`),
+		})
+
+		err := orchestrator.ProcessTransaction(t.Context(), tx)
+		require.ErrorIs(t, err, ErrPreApplyHookFailed)
+		assert.Contains(t, err.Error(), "change freeze in effect")
+		assert.Equal(t, finitestate.StateCompensated, tx.GetState())
+		assert.Equal(t, finitestate.ParticipantFailed,
+			tx.GetParticipantStates()[PreApplyParticipant])
+		assert.Nil(t, storage.GetCurrent())
+
+		// No participant was asked to stage the config
+		participant.AssertNotCalled(t, "StageConfig", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pre-apply hook is compensated with the participants", func(t *testing.T) {
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, mock.Anything).Return(assert.AnError)
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		tx := newValidatedTx(t, &pb.ServerConfig{PreApply: risorHook(`true`)})
+
+		err := orchestrator.ProcessTransaction(t.Context(), tx)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, finitestate.StateCompensated, tx.GetState())
+		assert.Equal(t, finitestate.ParticipantCompensated,
+			tx.GetParticipantStates()[PreApplyParticipant])
+	})
+
+	t.Run("post-apply hook failure doesn't fail the transaction", func(t *testing.T) {
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, mock.Anything).Return(nil)
+		participant.On("CommitConfig", mock.Anything).Return(nil)
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		tx := newValidatedTx(t, &pb.ServerConfig{
+			PostApply: risorHook(`error("notification service unavailable")`),
+		})
+
+		require.NoError(t, orchestrator.ProcessTransaction(t.Context(), tx))
+		assert.Equal(t, finitestate.StateCompleted, tx.GetState())
+		assert.Equal(t, tx, storage.GetCurrent())
+		assert.NotContains(t, tx.GetParticipantStates(), PreApplyParticipant)
+	})
+}
+
+func TestApplyHookData(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+
+	previous, err := config.NewFromProto(&pb.ServerConfig{
+		Listeners: []*pb.Listener{{
+			Id:      proto.String("old"),
+			Address: proto.String(":8080"),
+			Type:    pb.Listener_TYPE_HTTP.Enum(),
+		}},
+	})
+	require.NoError(t, err)
+	next, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	tx, err := transaction.New(transaction.SourceFile, "/etc/firelynx.toml", "", next, handler)
+	require.NoError(t, err)
+
+	hookData := applyHookData(config.HookPostApply, tx, previous)
+	assert.Equal(t, config.HookPostApply, hookData["phase"])
+	assert.Equal(t, map[string]any{
+		"id":            tx.ID.String(),
+		"source":        "file",
+		"source_detail": "/etc/firelynx.toml",
+	}, hookData["transaction"])
+	assert.Equal(t, map[string]any{
+		"listeners":   []any{map[string]any{"id": "old", "kind": "removed"}},
+		"endpoints":   []any{},
+		"apps":        []any{},
+		"middlewares": []any{},
+	}, hookData["changes"])
+}
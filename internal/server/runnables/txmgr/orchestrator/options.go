@@ -0,0 +1,17 @@
+package orchestrator
+
+import "time"
+
+// Option configures a SagaOrchestrator
+type Option func(*SagaOrchestrator)
+
+// WithParticipantTimeout sets how long a participant may take to stage or
+// compensate a configuration. A participant that takes longer is marked failed
+// and the transaction is compensated. Non-positive values are ignored.
+func WithParticipantTimeout(timeout time.Duration) Option {
+	return func(o *SagaOrchestrator) {
+		if timeout > 0 {
+			o.participantTimeout = timeout
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/atlanticdynamic/firelynx/internal/config"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction"
 	"github.com/atlanticdynamic/firelynx/internal/config/transaction/finitestate"
 	"github.com/atlanticdynamic/firelynx/internal/server/runnables/txmgr/txstorage"
@@ -21,6 +22,23 @@ const (
 	DefaultReloadTimeout = 30 * time.Second
 	// DefaultReloadRetryInterval is the interval to check component readiness
 	DefaultReloadRetryInterval = 10 * time.Millisecond
+	// DefaultParticipantTimeout is the time a participant may take to stage or
+	// compensate a configuration
+	DefaultParticipantTimeout = 30 * time.Second
+)
+
+var (
+	// ErrParticipantTimeout is recorded for a participant that didn't finish
+	// staging or compensating within the participant timeout
+	ErrParticipantTimeout = errors.New("participant timed out")
+
+	// ErrParticipantBusy is returned for a participant whose abandoned call
+	// from an earlier timeout hasn't finished within the participant timeout
+	ErrParticipantBusy = errors.New("participant still running an abandoned call")
+
+	// ErrRollbackFailed is returned when the last good config couldn't be
+	// re-applied after a failed transaction
+	ErrRollbackFailed = errors.New("rollback to last good config failed")
 )
 
 // SagaParticipant defines the interface for components participating
@@ -49,6 +67,16 @@ type SagaParticipant interface {
 	CommitConfig(ctx context.Context) error
 }
 
+// ReloadReporter is an optional interface for saga participants that can
+// describe, per component, what their most recent CommitConfig did (e.g. which
+// listeners were left running and which were restarted). Participants that
+// don't implement it are reported as executed.
+type ReloadReporter interface {
+	// LastReloadResults returns the results of the most recent CommitConfig.
+	// The Participant field is filled in by the orchestrator.
+	LastReloadResults() []transaction.ReloadResult
+}
+
 // SagaOrchestrator coordinates configuration changes across multiple components
 // using the saga pattern. It maintains participant state tracking and handles
 // compensation if any component fails.
@@ -59,8 +87,18 @@ type SagaOrchestrator struct {
 	// Registry of saga participants
 	runnables map[string]SagaParticipant
 
-	// Logger
-	logger *slog.Logger
+	// Logger, and the handler that rollback transactions log to
+	logger  *slog.Logger
+	handler slog.Handler
+
+	// participantTimeout bounds each StageConfig and CompensateConfig call
+	participantTimeout time.Duration
+
+	// abandoned holds, per participant, a channel closed when a call that
+	// timed out finally returns. The next call to that participant waits for
+	// it, so a late StageConfig can't overwrite a newer pending config.
+	abandoned   map[string]chan struct{}
+	abandonedMu sync.Mutex
 
 	// Internal state
 	mutex sync.RWMutex
@@ -70,14 +108,22 @@ type SagaOrchestrator struct {
 func NewSagaOrchestrator(
 	txStorage *txstorage.MemoryStorage,
 	handler slog.Handler,
+	opts ...Option,
 ) *SagaOrchestrator {
 	logger := slog.New(handler).WithGroup("sagaOrchestrator")
 
-	return &SagaOrchestrator{
-		txStorage: txStorage,
-		runnables: make(map[string]SagaParticipant),
-		logger:    logger,
+	o := &SagaOrchestrator{
+		txStorage:          txStorage,
+		runnables:          make(map[string]SagaParticipant),
+		logger:             logger,
+		handler:            handler,
+		participantTimeout: DefaultParticipantTimeout,
+		abandoned:          make(map[string]chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
 	}
+	return o
 }
 
 // RegisterParticipant registers a component as a saga participant.
@@ -139,13 +185,43 @@ func (o *SagaOrchestrator) ProcessTransaction(
 	o.logger.Debug("Processing transaction without waiting for participant states",
 		"participantCount", len(o.runnables))
 
+	// Keep the config being replaced, which the apply hooks are given a
+	// summary of the changes against
+	previous := o.currentConfig()
+
+	// Give the pre-apply hook the chance to abort before anything is staged
+	if err := o.runPreApplyHook(ctx, tx, previous); err != nil {
+		return err
+	}
+
 	// Register and execute all participants
 	if err := o.registerAndExecuteParticipants(ctx, tx); err != nil {
+		if rollbackErr := o.rollback(ctx, tx); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
 		return err
 	}
 
 	// Finalize successful transaction
-	return o.finalizeSuccessfulTransaction(ctx, tx)
+	if err := o.finalizeSuccessfulTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	o.runPostApplyHook(ctx, tx, previous)
+	return nil
+}
+
+// currentConfig returns the config of the current transaction, or nil when no
+// transaction has been applied yet
+func (o *SagaOrchestrator) currentConfig() *config.Config {
+	o.mutex.RLock()
+	current := o.txStorage.GetCurrent()
+	o.mutex.RUnlock()
+
+	if current == nil {
+		return nil
+	}
+	return current.GetConfig()
 }
 
 // registerAndExecuteParticipants registers all participants with the transaction,
@@ -197,7 +273,10 @@ func (o *SagaOrchestrator) registerAndExecuteParticipants(
 		}
 
 		// Execute the configuration on this participant
-		if err := participant.StageConfig(ctx, tx); err != nil {
+		err = o.callParticipant(ctx, name, "stage", func(ctx context.Context) error {
+			return participant.StageConfig(ctx, tx)
+		})
+		if err != nil {
 			// Mark participant as failed
 			if markErr := participantState.MarkFailed(err); markErr != nil {
 				o.logger.Error("Failed to mark participant as failed",
@@ -253,6 +332,56 @@ func (o *SagaOrchestrator) finalizeSuccessfulTransaction(
 	return nil
 }
 
+// rollback re-applies the last good config after tx failed and was
+// compensated. The revert is processed as a transaction of its own, so it
+// shows in the transaction history. A failed rollback isn't rolled back again,
+// so a last good config that no longer applies can't cause a loop.
+func (o *SagaOrchestrator) rollback(ctx context.Context, failed *transaction.ConfigTransaction) error {
+	if failed.GetState() != finitestate.StateCompensated {
+		return nil
+	}
+
+	logger := o.logger.WithGroup("rollback").With("failedID", failed.ID)
+	if failed.Source == transaction.SourceRollback {
+		logger.Error("Rollback transaction failed, not rolling back again")
+		return nil
+	}
+
+	o.mutex.RLock()
+	lastGood := o.txStorage.GetCurrent()
+	o.mutex.RUnlock()
+	if lastGood == nil || lastGood.GetState() != finitestate.StateCompleted {
+		logger.Warn("No last good config to roll back to")
+		return nil
+	}
+
+	logger.Info("Rolling back to last good config", "lastGoodID", lastGood.ID)
+	tx, err := transaction.FromRollback(failed, lastGood, o.handler)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrRollbackFailed, err)
+	}
+	if err := o.AddToStorage(tx); err != nil {
+		return fmt.Errorf("%w: %w", ErrRollbackFailed, err)
+	}
+	if err := tx.RunValidation(); err != nil {
+		return fmt.Errorf("%w: %w", ErrRollbackFailed, err)
+	}
+
+	if err := o.ProcessTransaction(ctx, tx); err != nil {
+		// Compensated rollbacks are already marked; others failed before any
+		// participant staged the config
+		if tx.GetState() == finitestate.StateExecuting {
+			if markErr := tx.MarkFailed(ctx, err); markErr != nil {
+				logger.Error("Failed to mark rollback transaction as failed", "error", markErr)
+			}
+		}
+		return fmt.Errorf("%w: %w", ErrRollbackFailed, err)
+	}
+
+	logger.Info("Rolled back to last good config", "id", tx.ID, "lastGoodID", lastGood.ID)
+	return nil
+}
+
 // compensateParticipants triggers compensation for all successful participants
 func (o *SagaOrchestrator) compensateParticipants(
 	ctx context.Context,
@@ -287,13 +416,17 @@ func (o *SagaOrchestrator) compensateParticipants(
 			continue
 		}
 
-		// Only compensate participants that succeeded
-		if participantState.GetState() != finitestate.ParticipantSucceeded {
+		// Only compensate participants that succeeded, which BeginCompensation
+		// moved to the compensating state
+		if participantState.GetState() != finitestate.ParticipantCompensating {
 			continue
 		}
 
 		// Execute compensation
-		if err := participant.CompensateConfig(ctx, tx.GetTransactionID()); err != nil {
+		err = o.callParticipant(ctx, name, "compensate", func(ctx context.Context) error {
+			return participant.CompensateConfig(ctx, tx.GetTransactionID())
+		})
+		if err != nil {
 			o.logger.Error("Failed to compensate participant", "name", name, "error", err)
 			continue
 		}
@@ -304,12 +437,109 @@ func (o *SagaOrchestrator) compensateParticipants(
 		}
 	}
 
+	o.markPreApplyHookCompensated(tx)
+
 	// Mark transaction as compensated
 	if err := tx.MarkCompensated(); err != nil {
 		o.logger.Error("Failed to mark transaction as compensated", "error", err)
 	}
 }
 
+// callParticipant runs a participant operation with the participant timeout.
+// The operation's context is canceled at the deadline, but an operation that
+// ignores it is abandoned rather than waited for, so a hung participant can't
+// block the transaction. The next operation on that participant first waits,
+// up to the participant timeout, for the abandoned one to return, and fails
+// with ErrParticipantBusy if it doesn't.
+func (o *SagaOrchestrator) callParticipant(
+	ctx context.Context,
+	name string,
+	operation string,
+	fn func(ctx context.Context) error,
+) error {
+	if err := o.waitForAbandoned(ctx, name); err != nil {
+		return fmt.Errorf("%s %s: %w", name, operation, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.participantTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		done <- fn(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("%w: %s %s after %s: %w", ErrParticipantTimeout, name, operation, o.participantTimeout, err)
+		}
+		return err
+	case <-ctx.Done():
+		o.abandonedMu.Lock()
+		o.abandoned[name] = returned
+		o.abandonedMu.Unlock()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			o.logger.Error("Participant timed out",
+				"name", name, "operation", operation, "timeout", o.participantTimeout)
+			return fmt.Errorf("%w: %s %s after %s", ErrParticipantTimeout, name, operation, o.participantTimeout)
+		}
+		return ctx.Err()
+	}
+}
+
+// waitForAbandoned waits up to the participant timeout for an abandoned call
+// to the named participant to return
+func (o *SagaOrchestrator) waitForAbandoned(ctx context.Context, name string) error {
+	o.abandonedMu.Lock()
+	returned, ok := o.abandoned[name]
+	o.abandonedMu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	o.logger.Warn("Waiting for an abandoned participant call to return",
+		"name", name, "timeout", o.participantTimeout)
+	timer := time.NewTimer(o.participantTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-returned:
+		o.abandonedMu.Lock()
+		if o.abandoned[name] == returned {
+			delete(o.abandoned, name)
+		}
+		o.abandonedMu.Unlock()
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w after %s", ErrParticipantBusy, o.participantTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// collectReloadResults returns the reload results for a participant after a
+// successful CommitConfig, falling back to a single "executed" result when the
+// participant doesn't report component-level detail.
+func collectReloadResults(name string, participant SagaParticipant) []transaction.ReloadResult {
+	reporter, ok := participant.(ReloadReporter)
+	if !ok {
+		return []transaction.ReloadResult{{Participant: name, Action: transaction.ReloadExecuted}}
+	}
+
+	results := reporter.LastReloadResults()
+	if len(results) == 0 {
+		return []transaction.ReloadResult{{Participant: name, Action: transaction.ReloadExecuted}}
+	}
+	for i := range results {
+		results[i].Participant = name
+	}
+	return results
+}
+
 // getSortedParticipantNames returns a sorted slice of participant names for deterministic ordering.
 // This makes components always process in the same order for reproducibility and testing.
 func (o *SagaOrchestrator) getSortedParticipantNames() []string {
@@ -345,6 +575,11 @@ func (o *SagaOrchestrator) GetTransactionStatus(txID string) (map[string]any, er
 		status["participants"] = participantStates
 	}
 
+	// Add reload results if available
+	if reloadResults := tx.GetReloadResults(); len(reloadResults) > 0 {
+		status["reloadResults"] = reloadResults
+	}
+
 	return status, nil
 }
 
@@ -491,6 +726,9 @@ func (o *SagaOrchestrator) TriggerReload(ctx context.Context) error {
 			continue
 		}
 
+		// Record what the participant did with the committed configuration
+		currentTx.RecordReloadResults(collectReloadResults(name, participant)...)
+
 		// Log post-reload state if available
 		if stateable, ok := participant.(supervisor.Stateable); ok {
 			logger.Debug("Post-reload state", "state", stateable.GetState())
@@ -19,6 +19,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 )
 
 // MockParticipant is a mock implementation of the SagaParticipant interface for testing
@@ -100,6 +101,13 @@ func TestNewSagaOrchestrator(t *testing.T) {
 	assert.Equal(t, storage, orchestrator.txStorage)
 	assert.NotNil(t, orchestrator.runnables)
 	assert.Empty(t, orchestrator.runnables)
+	assert.Equal(t, DefaultParticipantTimeout, orchestrator.participantTimeout)
+
+	orchestrator = NewSagaOrchestrator(storage, handler, WithParticipantTimeout(time.Second))
+	assert.Equal(t, time.Second, orchestrator.participantTimeout)
+
+	orchestrator = NewSagaOrchestrator(storage, handler, WithParticipantTimeout(0))
+	assert.Equal(t, DefaultParticipantTimeout, orchestrator.participantTimeout)
 }
 
 func TestRegisterParticipant(t *testing.T) {
@@ -269,6 +277,191 @@ func TestProcessTransaction_Failure(t *testing.T) {
 	assert.Nil(t, storage.GetCurrent())
 }
 
+func TestProcessTransaction_ParticipantTimeout(t *testing.T) {
+	tests := []struct {
+		name  string
+		stage func(release <-chan struct{}) func(args mock.Arguments)
+	}{
+		{
+			name: "participant ignores cancellation",
+			stage: func(release <-chan struct{}) func(args mock.Arguments) {
+				return func(mock.Arguments) { <-release }
+			},
+		},
+		{
+			name: "participant returns on cancellation",
+			stage: func(<-chan struct{}) func(args mock.Arguments) {
+				return func(args mock.Arguments) {
+					<-args.Get(0).(context.Context).Done()
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := slog.NewTextHandler(os.Stdout, nil)
+			storage := txstorage.NewMemoryStorage()
+			orchestrator := NewSagaOrchestrator(storage, handler,
+				WithParticipantTimeout(50*time.Millisecond))
+
+			cfg, err := config.NewFromProto(&pb.ServerConfig{})
+			require.NoError(t, err)
+			cfg.Version = config.VersionLatest
+			tx, err := transaction.New(transaction.SourceTest, "test", "req-123", cfg, handler)
+			require.NoError(t, err)
+			require.NoError(t, tx.RunValidation())
+
+			release := make(chan struct{})
+			t.Cleanup(func() { close(release) })
+
+			// Participants stage in name order, so the first one has succeeded
+			// by the time the second one hangs
+			staged := NewMockParticipant("participant1")
+			staged.On("StageConfig", mock.Anything, tx).Return(nil)
+			staged.On("CompensateConfig", mock.Anything, tx.GetTransactionID()).Return(nil)
+
+			hung := NewMockParticipant("participant2")
+			hung.On("StageConfig", mock.Anything, tx).
+				Run(tt.stage(release)).
+				Return(context.DeadlineExceeded)
+
+			require.NoError(t, orchestrator.RegisterParticipant(staged))
+			require.NoError(t, orchestrator.RegisterParticipant(hung))
+
+			err = orchestrator.ProcessTransaction(t.Context(), tx)
+			require.ErrorIs(t, err, ErrParticipantTimeout)
+			assert.Contains(t, err.Error(), "participant2 stage after 50ms")
+
+			staged.AssertCalled(t, "CompensateConfig", mock.Anything, tx.GetTransactionID())
+			assert.Equal(t, finitestate.StateCompensated, tx.GetState())
+
+			participantErrors := tx.GetParticipantErrors()
+			require.Contains(t, participantErrors, "participant2")
+			require.ErrorIs(t, participantErrors["participant2"], ErrParticipantTimeout)
+			assert.NotContains(t, participantErrors, "participant1")
+
+			assert.Nil(t, storage.GetCurrent())
+		})
+	}
+}
+
+// TestProcessTransaction_AbandonedStage checks that a StageConfig abandoned
+// after a timeout can't leave its stale config pending for a later commit
+func TestProcessTransaction_AbandonedStage(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+	newTx := func(t *testing.T, requestID string) *transaction.ConfigTransaction {
+		t.Helper()
+		cfg, err := config.NewFromProto(&pb.ServerConfig{Version: proto.String(config.VersionLatest)})
+		require.NoError(t, err)
+		tx, err := transaction.New(transaction.SourceTest, "test", requestID, cfg, handler)
+		require.NoError(t, err)
+		require.NoError(t, tx.RunValidation())
+		return tx
+	}
+
+	// setup registers a participant that stages by recording the transaction
+	// ID as pending, and commits by applying the pending ID. Staging stale
+	// blocks until release is closed, ignoring cancellation.
+	setup := func(t *testing.T) (*SagaOrchestrator, *transaction.ConfigTransaction, *transaction.ConfigTransaction, chan struct{}, *MockParticipant, func() (string, string)) {
+		t.Helper()
+		orchestrator := NewSagaOrchestrator(txstorage.NewMemoryStorage(), handler,
+			WithParticipantTimeout(200*time.Millisecond))
+		stale := newTx(t, "req-stale")
+		next := newTx(t, "req-next")
+
+		var mu sync.Mutex
+		var pending, committed string
+		release := make(chan struct{})
+
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, stale).Run(func(mock.Arguments) {
+			<-release
+			mu.Lock()
+			pending = stale.GetTransactionID()
+			mu.Unlock()
+		}).Return(nil)
+		participant.On("StageConfig", mock.Anything, next).Run(func(mock.Arguments) {
+			mu.Lock()
+			pending = next.GetTransactionID()
+			mu.Unlock()
+		}).Return(nil)
+		participant.On("CommitConfig", mock.Anything).Run(func(mock.Arguments) {
+			mu.Lock()
+			committed = pending
+			mu.Unlock()
+		}).Return(nil)
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		err := orchestrator.ProcessTransaction(t.Context(), stale)
+		require.ErrorIs(t, err, ErrParticipantTimeout)
+
+		return orchestrator, stale, next, release, participant, func() (string, string) {
+			mu.Lock()
+			defer mu.Unlock()
+			return pending, committed
+		}
+	}
+
+	t.Run("next stage waits for the abandoned one", func(t *testing.T) {
+		orchestrator, _, next, release, _, state := setup(t)
+
+		time.AfterFunc(50*time.Millisecond, func() { close(release) })
+		require.NoError(t, orchestrator.ProcessTransaction(t.Context(), next))
+
+		_, committed := state()
+		assert.Equal(t, next.GetTransactionID(), committed)
+		assert.Never(t, func() bool {
+			pending, _ := state()
+			return pending != next.GetTransactionID()
+		}, 100*time.Millisecond, 5*time.Millisecond, "the stale stage must not overwrite the newer config")
+	})
+
+	t.Run("next transaction fails while the abandoned stage runs", func(t *testing.T) {
+		orchestrator, stale, next, release, participant, state := setup(t)
+
+		err := orchestrator.ProcessTransaction(t.Context(), next)
+		require.ErrorIs(t, err, ErrParticipantBusy)
+		assert.Equal(t, finitestate.StateCompensated, next.GetState())
+		participant.AssertNotCalled(t, "StageConfig", mock.Anything, next)
+		participant.AssertNotCalled(t, "CommitConfig", mock.Anything)
+
+		close(release)
+		assert.Eventually(t, func() bool {
+			pending, _ := state()
+			return pending == stale.GetTransactionID()
+		}, time.Second, 5*time.Millisecond)
+		_, committed := state()
+		assert.Empty(t, committed, "the stale config is never committed")
+	})
+}
+
+func TestCallParticipant(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+	orchestrator := NewSagaOrchestrator(txstorage.NewMemoryStorage(), handler,
+		WithParticipantTimeout(time.Second))
+
+	t.Run("passes through results", func(t *testing.T) {
+		testErr := errors.New("stage config failed")
+		err := orchestrator.callParticipant(t.Context(), "p", "stage", func(context.Context) error {
+			return testErr
+		})
+		require.ErrorIs(t, err, testErr)
+		require.NotErrorIs(t, err, ErrParticipantTimeout)
+	})
+
+	t.Run("canceled parent context is not a timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		err := orchestrator.callParticipant(ctx, "p", "stage", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrParticipantTimeout)
+	})
+}
+
 func TestCompensateParticipants(t *testing.T) {
 	handler := slog.NewTextHandler(os.Stdout, nil)
 	storage := txstorage.NewMemoryStorage()
@@ -317,6 +510,8 @@ func TestCompensateParticipants(t *testing.T) {
 
 	// Participant1 should have been compensated since it succeeded
 	participant1.AssertExpectations(t)
+	participant1.AssertCalled(t, "CompensateConfig", mock.Anything, tx.GetTransactionID())
+	participant2.AssertNotCalled(t, "CompensateConfig", mock.Anything, mock.Anything)
 
 	// Verify transaction state is either still compensating or already compensated
 	finalState := tx.GetState()
@@ -778,3 +973,154 @@ func TestFinalizeSuccessfulTransaction_TriggerReloadWaitForReadyFails(t *testing
 
 	participant.AssertExpectations(t)
 }
+
+// reportingParticipant is a MockParticipant that also implements ReloadReporter
+type reportingParticipant struct {
+	*MockParticipant
+	results []transaction.ReloadResult
+}
+
+func (p *reportingParticipant) LastReloadResults() []transaction.ReloadResult {
+	return p.results
+}
+
+func TestProcessTransaction_RecordsReloadResults(t *testing.T) {
+	handler := slog.NewTextHandler(os.Stdout, nil)
+	storage := txstorage.NewMemoryStorage()
+	orchestrator := NewSagaOrchestrator(storage, handler)
+
+	cfg, err := config.NewFromProto(&pb.ServerConfig{})
+	require.NoError(t, err)
+	cfg.Version = config.VersionLatest
+	tx, err := transaction.New(transaction.SourceTest, "test", "req-reload", cfg, handler)
+	require.NoError(t, err)
+	require.NoError(t, tx.RunValidation())
+
+	plain := NewMockParticipant("a-plain")
+	plain.On("StageConfig", mock.Anything, tx).Return(nil)
+	plain.On("CommitConfig", mock.Anything).Return(nil)
+
+	reporting := &reportingParticipant{
+		MockParticipant: NewMockParticipant("b-listeners"),
+		results: []transaction.ReloadResult{
+			{Component: "unchanged", Action: transaction.ReloadSkipped},
+			{Component: "changed", Action: transaction.ReloadRestarted},
+		},
+	}
+	reporting.On("StageConfig", mock.Anything, tx).Return(nil)
+	reporting.On("CommitConfig", mock.Anything).Return(nil)
+
+	require.NoError(t, orchestrator.RegisterParticipant(plain))
+	require.NoError(t, orchestrator.RegisterParticipant(reporting))
+
+	require.NoError(t, orchestrator.ProcessTransaction(t.Context(), tx))
+	assert.Equal(t, finitestate.StateCompleted, tx.GetState())
+
+	expected := []transaction.ReloadResult{
+		{Participant: "a-plain", Action: transaction.ReloadExecuted},
+		{Participant: "b-listeners", Component: "unchanged", Action: transaction.ReloadSkipped},
+		{Participant: "b-listeners", Component: "changed", Action: transaction.ReloadRestarted},
+	}
+	assert.Equal(t, expected, tx.GetReloadResults())
+
+	status, err := orchestrator.GetTransactionStatus(tx.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, expected, status["reloadResults"])
+}
+
+// isRollback matches transactions that re-apply the last good config
+var isRollback = mock.MatchedBy(func(tx *transaction.ConfigTransaction) bool {
+	return tx.Source == transaction.SourceRollback
+})
+
+func TestProcessTransaction_Rollback(t *testing.T) {
+	// newTx creates a validated transaction whose config has the given version
+	newTx := func(t *testing.T, handler slog.Handler, requestID string) *transaction.ConfigTransaction {
+		t.Helper()
+		cfg, err := config.NewFromProto(&pb.ServerConfig{Version: proto.String(config.VersionLatest)})
+		require.NoError(t, err)
+		tx, err := transaction.New(transaction.SourceTest, "test", requestID, cfg, handler)
+		require.NoError(t, err)
+		require.NoError(t, tx.RunValidation())
+		return tx
+	}
+
+	// setup applies a good transaction, then processes a bad one that
+	// participant fails to stage
+	setup := func(t *testing.T, rollbackErr error) (*txstorage.MemoryStorage, *MockParticipant, *transaction.ConfigTransaction, *transaction.ConfigTransaction, error) {
+		t.Helper()
+		handler := slog.NewTextHandler(os.Stdout, nil)
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		good := newTx(t, handler, "req-good")
+		bad := newTx(t, handler, "req-bad")
+
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, good).Return(nil)
+		participant.On("StageConfig", mock.Anything, bad).Return(errors.New("stage config failed"))
+		participant.On("StageConfig", mock.Anything, isRollback).Return(rollbackErr)
+		participant.On("CommitConfig", mock.Anything).Return(nil)
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+
+		require.NoError(t, storage.Add(good))
+		require.NoError(t, orchestrator.ProcessTransaction(t.Context(), good))
+
+		require.NoError(t, storage.Add(bad))
+		err := orchestrator.ProcessTransaction(t.Context(), bad)
+		return storage, participant, good, bad, err
+	}
+
+	t.Run("last good config is re-applied", func(t *testing.T) {
+		storage, participant, good, bad, err := setup(t, nil)
+		require.ErrorContains(t, err, "stage config failed")
+		require.NotErrorIs(t, err, ErrRollbackFailed)
+		assert.Equal(t, finitestate.StateCompensated, bad.GetState())
+
+		rollback := storage.GetCurrent()
+		require.NotNil(t, rollback)
+		assert.Equal(t, transaction.SourceRollback, rollback.Source)
+		assert.Equal(t, finitestate.StateCompleted, rollback.GetState())
+		assert.Contains(t, rollback.SourceDetail, bad.GetTransactionID())
+		assert.Contains(t, rollback.SourceDetail, good.GetTransactionID())
+		assert.Equal(t, "req-bad", rollback.RequestID)
+		assert.NotSame(t, good.GetConfig(), rollback.GetConfig())
+
+		// History shows the good, failed, and rollback transactions
+		assert.Len(t, storage.GetAll(), 3)
+		participant.AssertNumberOfCalls(t, "CommitConfig", 2)
+	})
+
+	t.Run("failed rollback is not rolled back again", func(t *testing.T) {
+		storage, participant, good, _, err := setup(t, errors.New("last good no longer applies"))
+		require.ErrorIs(t, err, ErrRollbackFailed)
+		require.ErrorContains(t, err, "last good no longer applies")
+
+		// The good transaction is still current, and only one rollback was tried
+		assert.Same(t, good, storage.GetCurrent())
+		all := storage.GetAll()
+		require.Len(t, all, 3)
+		rollback := all[2]
+		assert.Equal(t, transaction.SourceRollback, rollback.Source)
+		assert.Equal(t, finitestate.StateCompensated, rollback.GetState())
+		participant.AssertNumberOfCalls(t, "StageConfig", 3)
+	})
+
+	t.Run("nothing to roll back to", func(t *testing.T) {
+		handler := slog.NewTextHandler(os.Stdout, nil)
+		storage := txstorage.NewMemoryStorage()
+		orchestrator := NewSagaOrchestrator(storage, handler)
+
+		bad := newTx(t, handler, "req-bad")
+		participant := NewMockParticipant("participant1")
+		participant.On("StageConfig", mock.Anything, bad).Return(errors.New("stage config failed"))
+		require.NoError(t, orchestrator.RegisterParticipant(participant))
+		require.NoError(t, storage.Add(bad))
+
+		err := orchestrator.ProcessTransaction(t.Context(), bad)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrRollbackFailed)
+		assert.Nil(t, storage.GetCurrent())
+		assert.Len(t, storage.GetAll(), 1)
+	})
+}
@@ -19,6 +19,19 @@ func WithMaxTransactions(max int) Option {
 	}
 }
 
+// WithMaxAge removes transactions in a terminal state once they are older than
+// maxAge, in addition to the WithMaxTransactions limit. The current transaction
+// is always kept. Expired transactions are removed when a transaction is added.
+// It has no effect with a custom cleanup function. Non-positive values keep
+// transactions regardless of age.
+func WithMaxAge(maxAge time.Duration) Option {
+	return func(s *MemoryStorage) {
+		if maxAge > 0 {
+			s.maxAge = maxAge
+		}
+	}
+}
+
 // WithCleanupFunc sets a custom cleanup function
 func WithCleanupFunc(
 	fn func([]*transaction.ConfigTransaction) []*transaction.ConfigTransaction,
@@ -34,6 +34,10 @@ type MemoryStorage struct {
 	// Maximum number of transactions to store
 	maxTransactions int
 
+	// Age after which finished transactions are removed, zero to keep them
+	// regardless of age
+	maxAge time.Duration
+
 	// Function to clean up transactions (e.g., remove old ones)
 	cleanupFunc func([]*transaction.ConfigTransaction) []*transaction.ConfigTransaction
 
@@ -62,8 +66,10 @@ func NewMemoryStorage(opts ...Option) *MemoryStorage {
 		logger:                  slog.Default().WithGroup("txstorage"),
 	}
 
-	// Default cleanup function: keep only the last maxTransactions
+	// Default cleanup function: drop expired transactions, then keep only the
+	// last maxTransactions
 	s.cleanupFunc = func(txs []*transaction.ConfigTransaction) []*transaction.ConfigTransaction {
+		txs = s.removeExpired(txs)
 		if len(txs) <= s.maxTransactions {
 			return txs
 		}
@@ -182,6 +188,24 @@ func (s *MemoryStorage) cleanup() {
 	logger.Debug("Finished cleanup", "transactions", len(s.transactions))
 }
 
+// removeExpired returns txs without the transactions in a terminal state that
+// were created longer than maxAge ago. The current transaction is kept. It
+// must be called with the lock held.
+func (s *MemoryStorage) removeExpired(
+	txs []*transaction.ConfigTransaction,
+) []*transaction.ConfigTransaction {
+	if s.maxAge <= 0 {
+		return txs
+	}
+
+	cutoff := time.Now().Add(-s.maxAge)
+	return slices.DeleteFunc(txs, func(tx *transaction.ConfigTransaction) bool {
+		return tx != s.current &&
+			tx.CreatedAt.Before(cutoff) &&
+			slices.Contains(finitestate.SagaTerminalStates, tx.GetState())
+	})
+}
+
 // cleanupWorker runs cleanup operations asynchronously
 func (s *MemoryStorage) cleanupWorker() {
 	defer s.cleanupRunning.Store(false)
@@ -422,6 +422,64 @@ func TestDefaultCleanupBehavior(t *testing.T) {
 	})
 }
 
+func TestMaxAgeCleanup(t *testing.T) {
+	t.Parallel()
+
+	newTx := func(t *testing.T, state string, age time.Duration) *transaction.ConfigTransaction {
+		t.Helper()
+		tx := createTestTransactionWithState(t, state)
+		tx.CreatedAt = time.Now().Add(-age)
+		return tx
+	}
+
+	t.Run("removes finished transactions older than max age", func(t *testing.T) {
+		storage := NewMemoryStorage(WithMaxAge(time.Hour))
+
+		expired := newTx(t, "invalid", 2*time.Hour)
+		inProgress := newTx(t, "validating", 2*time.Hour)
+		recent := newTx(t, "invalid", time.Minute)
+		for _, tx := range []*transaction.ConfigTransaction{expired, inProgress, recent} {
+			require.NoError(t, storage.Add(tx))
+		}
+
+		result := storage.GetAll()
+		require.Len(t, result, 2)
+		assert.Equal(t, inProgress.ID, result[0].ID)
+		assert.Equal(t, recent.ID, result[1].ID)
+	})
+
+	t.Run("keeps the current transaction", func(t *testing.T) {
+		storage := NewMemoryStorage(WithMaxAge(time.Hour))
+
+		current := newTx(t, "error", 2*time.Hour)
+		storage.SetCurrent(current)
+		require.NoError(t, storage.Add(current))
+		require.NoError(t, storage.Add(newTx(t, "error", 2*time.Hour)))
+
+		result := storage.GetAll()
+		require.Len(t, result, 1)
+		assert.Equal(t, current.ID, result[0].ID)
+	})
+
+	t.Run("combined with max transactions", func(t *testing.T) {
+		storage := NewMemoryStorage(WithMaxAge(time.Hour), WithMaxTransactions(2))
+
+		require.NoError(t, storage.Add(newTx(t, "invalid", 2*time.Hour)))
+		for range 3 {
+			require.NoError(t, storage.Add(newTx(t, "invalid", time.Minute)))
+		}
+
+		assert.Len(t, storage.GetAll(), 2)
+	})
+
+	t.Run("zero max age keeps old transactions", func(t *testing.T) {
+		storage := NewMemoryStorage(WithMaxAge(0))
+
+		require.NoError(t, storage.Add(newTx(t, "invalid", 1000*time.Hour)))
+		assert.Len(t, storage.GetAll(), 1)
+	})
+}
+
 func TestTransactionRetrieval(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,141 @@
+// Package shutdown stops the server's runnables in stages, so each stage
+// finishes before the next one starts.
+//
+// The supervisor cancels every runnable at once when it shuts down, so
+// listeners could still be draining requests while the components they
+// depend on are already gone. A Sequencer stops the listeners first, waits
+// for them to drain, then stops the app resources and the config sources.
+package shutdown
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/robbyt/go-supervisor/supervisor"
+)
+
+// defaultPollInterval is how often a stage checks whether its runnables have
+// stopped
+const defaultPollInterval = 10 * time.Millisecond
+
+// Stage is a group of runnables stopped together. The next stage starts
+// once every runnable in this one has stopped, or Timeout has passed.
+type Stage struct {
+	Name      string
+	Timeout   time.Duration
+	Runnables []supervisor.Runnable
+}
+
+// Sequencer stops runnables stage by stage
+type Sequencer struct {
+	stages       []Stage
+	logger       *slog.Logger
+	pollInterval time.Duration
+}
+
+// Option configures a Sequencer
+type Option func(*Sequencer)
+
+// WithLogHandler sets the log handler
+func WithLogHandler(handler slog.Handler) Option {
+	return func(s *Sequencer) {
+		if handler != nil {
+			s.logger = slog.New(handler)
+		}
+	}
+}
+
+// WithPollInterval sets how often a stage checks whether its runnables have
+// stopped
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Sequencer) {
+		if interval > 0 {
+			s.pollInterval = interval
+		}
+	}
+}
+
+// New creates a Sequencer that stops stages in the given order. Stages
+// without runnables are skipped.
+func New(stages []Stage, opts ...Option) *Sequencer {
+	s := &Sequencer{
+		logger:       slog.Default().WithGroup("shutdown"),
+		pollInterval: defaultPollInterval,
+	}
+	for _, stage := range stages {
+		if len(stage.Runnables) > 0 {
+			s.stages = append(s.stages, stage)
+		}
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Shutdown stops each stage in turn. Runnables that never started are
+// skipped, and a stage whose runnables haven't stopped by its timeout is
+// abandoned with a warning so the next stage still runs.
+func (s *Sequencer) Shutdown() {
+	for _, stage := range s.stages {
+		s.stopStage(stage)
+	}
+}
+
+func (s *Sequencer) stopStage(stage Stage) {
+	logger := s.logger.With("stage", stage.Name)
+	logger.Debug("Stopping stage", "timeout", stage.Timeout)
+
+	var pending []supervisor.Stateable
+	for _, r := range stage.Runnables {
+		stateable, ok := r.(supervisor.Stateable)
+		if ok && stateable.GetState() == finitestate.StatusNew {
+			continue
+		}
+		r.Stop()
+		if ok {
+			pending = append(pending, stateable)
+		}
+	}
+
+	ctx := context.Background()
+	if stage.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, stage.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		pending = running(pending)
+		if len(pending) == 0 {
+			logger.Debug("Stage stopped")
+			return
+		}
+		select {
+		case <-ctx.Done():
+			for _, r := range pending {
+				logger.Warn("Runnable did not stop before the stage timeout",
+					"runnable", r, "state", r.GetState())
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// running returns the runnables that haven't reached a final state
+func running(runnables []supervisor.Stateable) []supervisor.Stateable {
+	var pending []supervisor.Stateable
+	for _, r := range runnables {
+		switch r.GetState() {
+		case finitestate.StatusStopped, finitestate.StatusError:
+		default:
+			pending = append(pending, r)
+		}
+	}
+	return pending
+}
@@ -0,0 +1,132 @@
+package shutdown
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/atlanticdynamic/firelynx/internal/server/finitestate"
+	"github.com/robbyt/go-supervisor/supervisor"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder records the order runnables are stopped and have stopped in
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) Events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.events...)
+}
+
+// fakeRunnable stops stopDelay after Stop is called, or never if hang is set
+type fakeRunnable struct {
+	name      string
+	recorder  *recorder
+	stopDelay time.Duration
+	hang      bool
+	state     atomic.Value
+}
+
+func newFakeRunnable(name string, rec *recorder) *fakeRunnable {
+	r := &fakeRunnable{name: name, recorder: rec}
+	r.state.Store(finitestate.StatusRunning)
+	return r
+}
+
+func (r *fakeRunnable) String() string                { return r.name }
+func (r *fakeRunnable) Run(ctx context.Context) error { return nil }
+func (r *fakeRunnable) GetState() string              { return r.state.Load().(string) }
+
+func (r *fakeRunnable) GetStateChan(ctx context.Context) <-chan string {
+	ch := make(chan string, 1)
+	ch <- r.GetState()
+	return ch
+}
+
+func (r *fakeRunnable) Stop() {
+	r.recorder.record("stop " + r.name)
+	r.state.Store(finitestate.StatusStopping)
+	if r.hang {
+		return
+	}
+	time.AfterFunc(r.stopDelay, func() {
+		r.recorder.record("stopped " + r.name)
+		r.state.Store(finitestate.StatusStopped)
+	})
+}
+
+func TestSequencer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stages stop in order", func(t *testing.T) {
+		t.Parallel()
+		rec := &recorder{}
+		httpListener := newFakeRunnable("http", rec)
+		httpListener.stopDelay = 30 * time.Millisecond
+		tcpListener := newFakeRunnable("tcp", rec)
+		tcpListener.stopDelay = 10 * time.Millisecond
+		txmgr := newFakeRunnable("txmgr", rec)
+		cfgService := newFakeRunnable("cfgservice", rec)
+
+		New([]Stage{
+			{Name: "listeners", Timeout: time.Second, Runnables: []supervisor.Runnable{httpListener, tcpListener}},
+			{Name: "apps", Timeout: time.Second, Runnables: []supervisor.Runnable{txmgr}},
+			{Name: "config", Timeout: time.Second, Runnables: []supervisor.Runnable{cfgService}},
+		}, WithPollInterval(time.Millisecond)).Shutdown()
+
+		assert.Equal(t, []string{
+			"stop http",
+			"stop tcp",
+			"stopped tcp",
+			"stopped http",
+			"stop txmgr",
+			"stopped txmgr",
+			"stop cfgservice",
+			"stopped cfgservice",
+		}, rec.Events())
+	})
+
+	t.Run("stage timeout moves on to the next stage", func(t *testing.T) {
+		t.Parallel()
+		rec := &recorder{}
+		listener := newFakeRunnable("http", rec)
+		listener.hang = true
+		cfgService := newFakeRunnable("cfgservice", rec)
+
+		start := time.Now()
+		New([]Stage{
+			{Name: "listeners", Timeout: 20 * time.Millisecond, Runnables: []supervisor.Runnable{listener}},
+			{Name: "config", Timeout: time.Second, Runnables: []supervisor.Runnable{cfgService}},
+		}, WithPollInterval(time.Millisecond)).Shutdown()
+
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		assert.Equal(t, []string{"stop http", "stop cfgservice", "stopped cfgservice"}, rec.Events())
+	})
+
+	t.Run("runnables that never started are skipped", func(t *testing.T) {
+		t.Parallel()
+		rec := &recorder{}
+		started := newFakeRunnable("txmgr", rec)
+		notStarted := newFakeRunnable("http", rec)
+		notStarted.state.Store(finitestate.StatusNew)
+
+		New([]Stage{
+			{Name: "listeners", Timeout: time.Second, Runnables: []supervisor.Runnable{notStarted}},
+			{Name: "apps", Timeout: time.Second, Runnables: []supervisor.Runnable{started}},
+		}, WithPollInterval(time.Millisecond)).Shutdown()
+
+		assert.Equal(t, []string{"stop txmgr", "stopped txmgr"}, rec.Events())
+	})
+}
@@ -0,0 +1,128 @@
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestCerts holds the paths of PEM files for TLS tests: a CA, a server
+// certificate for localhost signed by it, a client certificate signed by it,
+// and a self-signed client certificate the CA doesn't trust.
+type TestCerts struct {
+	CAFile            string
+	ServerCertFile    string
+	ServerKeyFile     string
+	ClientCertFile    string
+	ClientKeyFile     string
+	UntrustedCertFile string
+	UntrustedKeyFile  string
+}
+
+// GenerateTestCerts writes a CA and certificates signed by it to a temporary
+// directory.
+func GenerateTestCerts(t *testing.T) TestCerts {
+	t.Helper()
+	dir := t.TempDir()
+
+	caTmpl := certTemplate(t, "firelynx test CA")
+	caTmpl.IsCA = true
+	caTmpl.BasicConstraintsValid = true
+	caTmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	caKey, caCert := createCert(t, caTmpl, nil, nil)
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", caCert.Raw)
+
+	serverTmpl := certTemplate(t, "localhost")
+	serverTmpl.DNSNames = []string{"localhost"}
+	serverTmpl.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	serverTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	serverKey, serverCert := createCert(t, serverTmpl, caCert, caKey)
+
+	clientTmpl := certTemplate(t, "firelynx test client")
+	clientTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientKey, clientCert := createCert(t, clientTmpl, caCert, caKey)
+
+	untrustedTmpl := certTemplate(t, "untrusted client")
+	untrustedTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	untrustedKey, untrustedCert := createCert(t, untrustedTmpl, nil, nil)
+
+	return TestCerts{
+		CAFile:            caFile,
+		ServerCertFile:    writePEM(t, dir, "server.pem", "CERTIFICATE", serverCert.Raw),
+		ServerKeyFile:     writeKey(t, dir, "server-key.pem", serverKey),
+		ClientCertFile:    writePEM(t, dir, "client.pem", "CERTIFICATE", clientCert.Raw),
+		ClientKeyFile:     writeKey(t, dir, "client-key.pem", clientKey),
+		UntrustedCertFile: writePEM(t, dir, "untrusted.pem", "CERTIFICATE", untrustedCert.Raw),
+		UntrustedKeyFile:  writeKey(t, dir, "untrusted-key.pem", untrustedKey),
+	}
+}
+
+func certTemplate(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("Failed to generate serial number: %v", err)
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+// createCert creates a certificate from tmpl, signed by parent, or
+// self-signed when parent is nil
+func createCert(
+	t *testing.T,
+	tmpl, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey,
+) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	if parent == nil {
+		parent, parentKey = tmpl, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+	return key, cert
+}
+
+func writeKey(t *testing.T, dir, name string, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return writePEM(t, dir, name, "PRIVATE KEY", der)
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
@@ -0,0 +1,259 @@
+package testutil
+
+// Functions exported by the test WASM modules
+const (
+	// HostFunctionModuleEntrypoint is exported by HostFunctionModule
+	HostFunctionModuleEntrypoint = "get_static_data"
+	// SpinModuleEntrypoint is exported by SpinModule
+	SpinModuleEntrypoint = "spin"
+	// MemoryGrowModuleEntrypoint is exported by MemoryGrowModule
+	MemoryGrowModuleEntrypoint = "grow"
+)
+
+// HostFunctionModule describes a tiny Extism WASM module that uses firelynx
+// host functions. Its entrypoint calls the static data host function with Key
+// and returns the result as the plugin output. When Log is set, it first calls
+// firelynx_log at info level with Key as the message.
+//
+// The module is assembled by hand so tests don't need a WASM toolchain.
+type HostFunctionModule struct {
+	// Key is the static data key to look up
+	Key string
+	// GetStaticData is the name of the imported static data function,
+	// defaulting to "firelynx_get_static_data"
+	GetStaticData string
+	// Log imports and calls "firelynx_log"
+	Log bool
+}
+
+// WASM encoding constants used by HostFunctionModule
+const (
+	wasmTypeI32  = 0x7f
+	wasmTypeI64  = 0x7e
+	wasmTypeFunc = 0x60
+
+	wasmBlockEmpty = 0x40
+
+	wasmOpUnreachable = 0x00
+	wasmOpLoop        = 0x03
+	wasmOpIf          = 0x04
+	wasmOpEnd         = 0x0b
+	wasmOpBr          = 0x0c
+	wasmOpCall        = 0x10
+	wasmOpLocalGet    = 0x20
+	wasmOpLocalSet    = 0x21
+	wasmOpMemoryGrow  = 0x40
+	wasmOpI32Const    = 0x41
+	wasmOpI64Const    = 0x42
+	wasmOpI32Eq       = 0x46
+	wasmOpI64Add      = 0x7c
+)
+
+// Bytes assembles the WASM binary
+func (m HostFunctionModule) Bytes() []byte {
+	getStaticData := m.GetStaticData
+	if getStaticData == "" {
+		getStaticData = "firelynx_get_static_data"
+	}
+
+	// Types: 0 (i64)->i64, 1 (i64,i32)->(), 2 (i64,i64)->(), 3 ()->i32
+	types := wasmVector(
+		[]byte{wasmTypeFunc, 1, wasmTypeI64, 1, wasmTypeI64},
+		[]byte{wasmTypeFunc, 2, wasmTypeI64, wasmTypeI32, 0},
+		[]byte{wasmTypeFunc, 2, wasmTypeI64, wasmTypeI64, 0},
+		[]byte{wasmTypeFunc, 0, 1, wasmTypeI32},
+	)
+
+	// Imported function indexes, the entrypoint follows the imports
+	const (
+		fnAlloc = iota
+		fnStoreU8
+		fnLength
+		fnOutputSet
+		fnGetStaticData
+		fnLog
+	)
+	imports := [][]byte{
+		wasmImport("extism:host/env", "alloc", 0),
+		wasmImport("extism:host/env", "store_u8", 1),
+		wasmImport("extism:host/env", "length", 0),
+		wasmImport("extism:host/env", "output_set", 2),
+		wasmImport("extism:host/user", getStaticData, 0),
+	}
+	if m.Log {
+		imports = append(imports, wasmImport("extism:host/user", "firelynx_log", 2))
+	}
+	entrypoint := len(imports)
+
+	// Locals: 0 key offset, 1 result offset, 2 log level offset
+	const (
+		localKey = iota
+		localResult
+		localLevel
+	)
+	body := []byte{1, 3, wasmTypeI64}
+	body = append(body, wasmStoreString(m.Key, localKey, fnAlloc, fnStoreU8)...)
+	if m.Log {
+		body = append(body, wasmStoreString("info", localLevel, fnAlloc, fnStoreU8)...)
+		body = append(body,
+			wasmOpLocalGet, localLevel,
+			wasmOpLocalGet, localKey,
+			wasmOpCall, fnLog,
+		)
+	}
+	body = append(body,
+		wasmOpLocalGet, localKey,
+		wasmOpCall, fnGetStaticData,
+		wasmOpLocalSet, localResult,
+		wasmOpLocalGet, localResult,
+		wasmOpLocalGet, localResult,
+		wasmOpCall, fnLength,
+		wasmOpCall, fnOutputSet,
+		wasmOpI32Const, 0,
+		wasmOpEnd,
+	)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmSection(1, types)...)
+	module = append(module, wasmSection(2, wasmVector(imports...))...)
+	module = append(module, wasmSection(3, wasmVector([]byte{3}))...)
+	module = append(module, wasmSection(7, wasmVector(wasmExport(HostFunctionModuleEntrypoint, entrypoint)))...)
+	module = append(module, wasmSection(10, wasmVector(wasmCode(body)))...)
+	return module
+}
+
+// SpinModule returns a WASM module whose entrypoint calls an empty function in
+// an endless loop, for testing fuel limits and timeouts
+func SpinModule() []byte {
+	// Types: 0 ()->(), 1 ()->i32
+	types := wasmVector(
+		[]byte{wasmTypeFunc, 0, 0},
+		[]byte{wasmTypeFunc, 0, 1, wasmTypeI32},
+	)
+	noop := []byte{0, wasmOpEnd}
+	spin := []byte{
+		0,
+		wasmOpLoop, wasmBlockEmpty,
+		wasmOpCall, 0,
+		wasmOpBr, 0,
+		wasmOpEnd,
+		wasmOpI32Const, 0,
+		wasmOpEnd,
+	}
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmSection(1, types)...)
+	module = append(module, wasmSection(3, wasmVector([]byte{0}, []byte{1}))...)
+	module = append(module, wasmSection(7, wasmVector(wasmExport(SpinModuleEntrypoint, 1)))...)
+	module = append(module, wasmSection(10, wasmVector(wasmCode(noop), wasmCode(spin)))...)
+	return module
+}
+
+// MemoryGrowModule returns a WASM module with one page of memory whose
+// entrypoint grows the memory by the given number of pages, and traps if that
+// fails
+func MemoryGrowModule(pages int) []byte {
+	// Types: 0 ()->i32
+	types := wasmVector([]byte{wasmTypeFunc, 0, 1, wasmTypeI32})
+	grow := []byte{0, wasmOpI32Const}
+	grow = append(grow, wasmSigned(int64(pages))...)
+	grow = append(grow,
+		wasmOpMemoryGrow, 0,
+		wasmOpI32Const, 0x7f, // -1
+		wasmOpI32Eq,
+		wasmOpIf, wasmBlockEmpty,
+		wasmOpUnreachable,
+		wasmOpEnd,
+		wasmOpI32Const, 0,
+		wasmOpEnd,
+	)
+
+	module := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmSection(1, types)...)
+	module = append(module, wasmSection(3, wasmVector([]byte{0}))...)
+	module = append(module, wasmSection(5, wasmVector([]byte{0x00, 1}))...)
+	module = append(module, wasmSection(7, wasmVector(wasmExport(MemoryGrowModuleEntrypoint, 0)))...)
+	module = append(module, wasmSection(10, wasmVector(wasmCode(grow)))...)
+	return module
+}
+
+// wasmStoreString allocates Extism memory for s, stores its offset in the
+// local, and writes s byte by byte
+func wasmStoreString(s string, local byte, fnAlloc, fnStoreU8 byte) []byte {
+	code := []byte{wasmOpI64Const}
+	code = append(code, wasmSigned(int64(len(s)))...)
+	code = append(code, wasmOpCall, fnAlloc, wasmOpLocalSet, local)
+	for i := 0; i < len(s); i++ {
+		code = append(code, wasmOpLocalGet, local, wasmOpI64Const)
+		code = append(code, wasmSigned(int64(i))...)
+		code = append(code, wasmOpI64Add, wasmOpI32Const)
+		code = append(code, wasmSigned(int64(s[i]))...)
+		code = append(code, wasmOpCall, fnStoreU8)
+	}
+	return code
+}
+
+// wasmImport encodes a function import with the given type index
+func wasmImport(module, name string, typeIdx byte) []byte {
+	imp := append(wasmName(module), wasmName(name)...)
+	return append(imp, 0x00, typeIdx)
+}
+
+// wasmExport encodes a function export
+func wasmExport(name string, funcIdx int) []byte {
+	export := append(wasmName(name), 0x00)
+	return append(export, wasmUnsigned(uint64(funcIdx))...)
+}
+
+// wasmCode encodes a function body, which starts with its local declarations,
+// prefixed with its size
+func wasmCode(body []byte) []byte {
+	return append(wasmUnsigned(uint64(len(body))), body...)
+}
+
+// wasmSection encodes a section with its id and size
+func wasmSection(id byte, content []byte) []byte {
+	section := append([]byte{id}, wasmUnsigned(uint64(len(content)))...)
+	return append(section, content...)
+}
+
+// wasmVector encodes the item count followed by the items
+func wasmVector(items ...[]byte) []byte {
+	vec := wasmUnsigned(uint64(len(items)))
+	for _, item := range items {
+		vec = append(vec, item...)
+	}
+	return vec
+}
+
+// wasmName encodes a size-prefixed UTF-8 name
+func wasmName(s string) []byte {
+	return append(wasmUnsigned(uint64(len(s))), s...)
+}
+
+// wasmUnsigned encodes an unsigned LEB128 integer
+func wasmUnsigned(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			out = append(out, b|0x80)
+			continue
+		}
+		return append(out, b)
+	}
+}
+
+// wasmSigned encodes a signed LEB128 integer
+func wasmSigned(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}